@@ -15,7 +15,7 @@ import (
 	"github.com/spf13/viper"
 )
 
-const VERSION = "34.1"
+const VERSION = "35.0"
 
 type Config struct {
 	Server              ServerConfig
@@ -29,6 +29,7 @@ type Config struct {
 	Broadcast           BroadcastConfig
 	TaskScheduler       TaskSchedulerConfig
 	AutomationScheduler AutomationSchedulerConfig
+	WebhookDisableAfter time.Duration // Disable webhook subscriptions failing continuously for this long (default: 72h, 0 = never)
 	Telemetry           bool
 	CheckForUpdates     bool
 	RootEmail           string
@@ -486,6 +487,9 @@ func LoadWithOptions(opts LoadOptions) (*Config, error) {
 	v.SetDefault("AUTOMATION_SCHEDULER_INTERVAL", "10s")
 	v.SetDefault("AUTOMATION_SCHEDULER_BATCH_SIZE", 50)
 
+	// Webhook subscriptions defaults
+	v.SetDefault("WEBHOOK_AUTO_DISABLE_AFTER", "72h")
+
 	// Load environment file if specified
 	if opts.EnvFile != "" {
 		v.SetConfigName(opts.EnvFile)
@@ -918,6 +922,7 @@ func LoadWithOptions(opts LoadOptions) (*Config, error) {
 			Interval:  v.GetDuration("AUTOMATION_SCHEDULER_INTERVAL"),
 			BatchSize: v.GetInt("AUTOMATION_SCHEDULER_BATCH_SIZE"),
 		},
+		WebhookDisableAfter: v.GetDuration("WEBHOOK_AUTO_DISABLE_AFTER"),
 
 		RootEmail:       rootEmail,
 		Environment:     v.GetString("ENVIRONMENT"),
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"sync"
 	"sync/atomic"
@@ -23,7 +24,9 @@ import (
 	"github.com/Notifuse/notifuse/internal/service/broadcast"
 	"github.com/Notifuse/notifuse/internal/service/queue"
 	"github.com/Notifuse/notifuse/pkg/cache"
+	"github.com/Notifuse/notifuse/pkg/captcha"
 	pkgDatabase "github.com/Notifuse/notifuse/pkg/database"
+	"github.com/Notifuse/notifuse/pkg/emailverifier"
 	"github.com/Notifuse/notifuse/pkg/logger"
 	"github.com/Notifuse/notifuse/pkg/mailer"
 	"github.com/Notifuse/notifuse/pkg/ratelimiter"
@@ -116,12 +119,17 @@ type App struct {
 	contactSegmentQueueRepo       domain.ContactSegmentQueueRepository
 	blogCategoryRepo              domain.BlogCategoryRepository
 	blogPostRepo                  domain.BlogPostRepository
+	blogCommentRepo               domain.BlogCommentRepository
 	blogThemeRepo                 domain.BlogThemeRepository
 	customEventRepo               domain.CustomEventRepository
 	webhookSubscriptionRepo       domain.WebhookSubscriptionRepository
 	webhookDeliveryRepo           domain.WebhookDeliveryRepository
 	automationRepo                domain.AutomationRepository
 	emailQueueRepo                domain.EmailQueueRepository
+	signupFormRepo                domain.SignupFormRepository
+	contactImportRepo             domain.ContactImportRepository
+	contactBulkOperationRepo      domain.ContactBulkOperationRepository
+	llmToolCallRepo               domain.LLMToolCallRepository
 
 	// Services
 	authService                      *service.AuthService
@@ -130,6 +138,7 @@ type App struct {
 	workspaceService                 *service.WorkspaceService
 	contactService                   *service.ContactService
 	listService                      *service.ListService
+	emailVerificationService         *service.EmailVerificationService
 	contactListService               *service.ContactListService
 	templateService                  *service.TemplateService
 	templateBlockService             *service.TemplateBlockService
@@ -148,6 +157,7 @@ type App struct {
 	contactTimelineService           domain.ContactTimelineService
 	segmentService                   *service.SegmentService
 	blogService                      *service.BlogService
+	blogCommentService               *service.BlogCommentService
 	settingService                   *service.SettingService
 	setupService                     *service.SetupService
 	supabaseService                  *service.SupabaseService
@@ -160,6 +170,9 @@ type App struct {
 	automationScheduler              *service.AutomationScheduler
 	smtpBouncePoller                 *service.SMTPBouncePoller
 	llmService                       *service.LLMService
+	signupFormService                *service.SignupFormService
+	contactImportService             *service.ContactImportService
+	contactBulkOperationService      *service.ContactBulkOperationService
 	emailQueueWorker                 *queue.EmailQueueWorker
 	dataFeedFetcher                  broadcast.DataFeedFetcher
 	// providers
@@ -431,10 +444,15 @@ func (a *App) InitRepositories() error {
 	a.contactSegmentQueueRepo = repository.NewContactSegmentQueueRepository(a.workspaceRepo)
 	a.blogCategoryRepo = repository.NewBlogCategoryRepository(a.workspaceRepo)
 	a.blogPostRepo = repository.NewBlogPostRepository(a.workspaceRepo)
+	a.blogCommentRepo = repository.NewBlogCommentRepository(a.workspaceRepo)
 	a.blogThemeRepo = repository.NewBlogThemeRepository(a.workspaceRepo)
 	a.customEventRepo = repository.NewCustomEventRepository(a.workspaceRepo)
 	a.webhookSubscriptionRepo = repository.NewWebhookSubscriptionRepository(a.workspaceRepo)
 	a.webhookDeliveryRepo = repository.NewWebhookDeliveryRepository(a.workspaceRepo)
+	a.signupFormRepo = repository.NewSignupFormRepository(a.workspaceRepo)
+	a.contactImportRepo = repository.NewContactImportRepository(a.workspaceRepo)
+	a.contactBulkOperationRepo = repository.NewContactBulkOperationRepository(a.workspaceRepo)
+	a.llmToolCallRepo = repository.NewLLMToolCallRepository(a.workspaceRepo)
 
 	// Create trigger generator for automation repository
 	queryBuilder := service.NewQueryBuilder()
@@ -487,6 +505,10 @@ func (a *App) InitServices() error {
 	a.rateLimiter.SetPolicy("preferences:ip", 100, 1*time.Minute)    // Public preferences by IP
 	a.rateLimiter.SetPolicy("inbound:ip", 240, 1*time.Minute)        // Public inbound replies by source IP (generous; providers share IPs)
 	a.rateLimiter.SetPolicy("inbound:workspace", 120, 1*time.Minute) // Public inbound replies by workspace
+	a.rateLimiter.SetPolicy("comment:email", 5, 1*time.Minute)       // Public blog comments by email
+	a.rateLimiter.SetPolicy("comment:ip", 20, 1*time.Minute)         // Public blog comments and reactions by IP
+	a.rateLimiter.SetPolicy("blog_access:email", 3, 5*time.Minute)   // Gated blog magic links by email
+	a.rateLimiter.SetPolicy("blog_access:ip", 20, 1*time.Minute)     // Gated blog magic links by IP
 	// OIDC policies are registered UNCONDITIONALLY (even when OIDC is disabled):
 	// RateLimiter.Allow fails closed on an unknown namespace, so enabling OIDC at
 	// runtime (settings drawer → graceful restart) must not 429 every request.
@@ -589,6 +611,8 @@ func (a *App) InitServices() error {
 	// Initialize template block service
 	a.templateBlockService = service.NewTemplateBlockService(
 		a.workspaceRepo,
+		a.templateRepo,
+		a.automationRepo,
 		a.authService,
 		a.logger,
 	)
@@ -602,6 +626,8 @@ func (a *App) InitServices() error {
 		a.inboundWebhookEventRepo,
 		a.contactListRepo,
 		a.contactTimelineRepo,
+		a.blogCommentRepo,
+		a.contactBulkOperationRepo,
 		a.logger,
 	)
 
@@ -686,6 +712,34 @@ func (a *App) InitServices() error {
 		a.blogCache,
 	)
 
+	// Initialize email verification service, the SMTP probe announces the API host
+	heloName := "localhost"
+	if endpoint, err := url.Parse(a.config.APIEndpoint); err == nil && endpoint.Hostname() != "" {
+		heloName = endpoint.Hostname()
+	}
+	a.emailVerificationService = service.NewEmailVerificationService(
+		emailverifier.New(nil, emailverifier.NewSMTPProber(heloName, "")),
+		a.contactRepo,
+		a.authService,
+		a.logger,
+	)
+	a.contactService.SetEmailVerificationService(a.emailVerificationService)
+	a.listService.SetEmailVerificationService(a.emailVerificationService)
+
+	// Initialize signup form service, solved proof-of-work challenges are remembered
+	// in memory until they expire to prevent replays
+	a.signupFormService = service.NewSignupFormService(
+		a.signupFormRepo,
+		a.listRepo,
+		a.workspaceRepo,
+		a.listService,
+		a.authService,
+		captcha.NewHTTPVerifier(nil),
+		cache.NewInMemoryCache(time.Minute),
+		a.logger,
+		a.config.Security.SecretKey,
+	)
+
 	// Initialize DNS verification service (before workspace service)
 	a.dnsVerificationService = service.NewDNSVerificationService(
 		a.logger,
@@ -695,6 +749,10 @@ func (a *App) InitServices() error {
 	// Initialize task service
 	a.taskService = service.NewTaskService(a.taskRepo, a.settingRepo, a.logger, a.authService, a.config.APIEndpoint)
 
+	// Publisher of lifecycle webhook events (broadcasts, automations, queue failures, tasks)
+	webhookEventPublisher := service.NewWebhookEventPublisher(a.webhookSubscriptionRepo, a.webhookDeliveryRepo, a.logger)
+	a.taskService.SetWebhookEventPublisher(webhookEventPublisher)
+
 	// Configure autoExecuteImmediate based on TaskScheduler.Enabled
 	// If task scheduler is disabled (e.g., in tests), also disable background task execution
 	a.taskService.SetAutoExecuteImmediate(a.config.TaskScheduler.Enabled)
@@ -793,6 +851,7 @@ func (a *App) InitServices() error {
 		a.eventBus,
 		true, // useQueueSender - use queue-based message sender for broadcasts
 	)
+	broadcastFactory.SetWebhookEventPublisher(webhookEventPublisher)
 
 	// Register the broadcast factory with the task service
 	broadcastFactory.RegisterWithTaskService(a.taskService)
@@ -811,6 +870,7 @@ func (a *App) InitServices() error {
 		a.contactRepo,
 		a.workspaceRepo,
 		a.listRepo,
+		a.contactListRepo,
 		a.logger,
 	)
 
@@ -841,11 +901,26 @@ func (a *App) InitServices() error {
 		a.blogThemeRepo,
 		a.workspaceRepo,
 		a.listRepo,
+		a.contactListRepo,
 		a.templateRepo,
 		a.authService,
+		a.taskService,
+		a.broadcastService,
+		a.transactionalNotificationService,
 		a.blogCache,
 	)
 
+	// Initialize blog comment service
+	a.blogCommentService = service.NewBlogCommentService(
+		a.logger,
+		a.blogCommentRepo,
+		a.blogPostRepo,
+		a.blogCategoryRepo,
+		a.workspaceRepo,
+		a.authService,
+		a.transactionalNotificationService,
+	)
+
 	// Initialize workspace service (after all its dependencies)
 	a.workspaceService = service.NewWorkspaceService(
 		a.workspaceRepo,
@@ -866,6 +941,7 @@ func (a *App) InitServices() error {
 		a.dnsVerificationService,
 		a.blogService,
 	)
+	a.workspaceService.SetContactRepo(a.contactRepo)
 
 	// Initialize and register segment build processor
 	segmentBuildProcessor := service.NewSegmentBuildProcessor(
@@ -886,6 +962,65 @@ func (a *App) InitServices() error {
 	)
 	a.taskService.RegisterProcessor(segmentRecomputeProcessor)
 
+	// Initialize and register computed contact properties task processor
+	contactPropertiesProcessor := service.NewContactPropertiesTaskProcessor(
+		a.workspaceRepo,
+		a.contactRepo,
+		a.taskRepo,
+		a.logger,
+	)
+	a.taskService.RegisterProcessor(contactPropertiesProcessor)
+
+	// Initialize contact import service and its task processor, files of the workspace
+	// bucket are fetched again by each run of the task
+	contactImportFileFetcher := service.NewS3FileFetcher()
+	a.contactImportService = service.NewContactImportService(
+		a.contactImportRepo,
+		a.workspaceRepo,
+		a.listRepo,
+		a.taskService,
+		a.authService,
+		contactImportFileFetcher,
+		a.logger,
+	)
+	contactImportProcessor := service.NewContactImportTaskProcessor(
+		a.contactImportRepo,
+		a.workspaceRepo,
+		a.contactRepo,
+		a.contactListRepo,
+		a.taskRepo,
+		contactImportFileFetcher,
+		a.logger,
+	)
+	contactImportProcessor.SetEmailVerificationService(a.emailVerificationService)
+	a.taskService.RegisterProcessor(contactImportProcessor)
+
+	// Initialize contact bulk operation service and its task processor
+	a.contactBulkOperationService = service.NewContactBulkOperationService(
+		a.contactBulkOperationRepo,
+		a.segmentRepo,
+		a.listRepo,
+		a.automationRepo,
+		a.taskService,
+		a.authService,
+		a.logger,
+	)
+	contactBulkOperationProcessor := service.NewContactBulkOperationTaskProcessor(
+		a.contactBulkOperationRepo,
+		a.workspaceRepo,
+		a.contactRepo,
+		a.contactListRepo,
+		a.automationRepo,
+		a.taskRepo,
+		a.contactService,
+		a.logger,
+	)
+	a.taskService.RegisterProcessor(contactBulkOperationProcessor)
+
+	// Initialize scheduled blog post publishing processor
+	blogPostPublishProcessor := service.NewBlogPostPublishTaskProcessor(a.blogService, a.logger)
+	a.taskService.RegisterProcessor(blogPostPublishProcessor)
+
 	// Initialize contact segment queue processor
 	contactSegmentQueueProcessor := service.NewContactSegmentQueueProcessor(
 		a.contactSegmentQueueRepo,
@@ -915,6 +1050,7 @@ func (a *App) InitServices() error {
 		a.webhookDeliveryRepo,
 		a.authService,
 		a.logger,
+		a.config.Security.SecretKey,
 	)
 
 	// Initialize demo service
@@ -981,7 +1117,10 @@ func (a *App) InitServices() error {
 		a.workspaceRepo,
 		a.logger,
 		httpClient,
+		a.config.Security.SecretKey,
 	)
+	a.webhookDeliveryWorker.SetEventBus(a.eventBus)
+	a.webhookDeliveryWorker.SetAutoDisableAfter(a.config.WebhookDisableAfter)
 
 	// Initialize email queue worker for processing marketing emails (broadcasts & automations)
 	// Worker creates message_history entries via UPSERT after each send attempt
@@ -995,6 +1134,9 @@ func (a *App) InitServices() error {
 	)
 	// Enable the stop-on-reply just-in-time guard for automation sends.
 	a.emailQueueWorker.SetAutomationRepo(a.automationRepo)
+	// Publish email.failed and integration.circuit_opened webhook events
+	a.emailQueueWorker.SetCallbacks(nil, webhookEventPublisher.HandleEmailFailed)
+	a.emailQueueWorker.SetCircuitOpenedCallback(webhookEventPublisher.HandleCircuitOpened)
 
 	// Initialize automation service
 	a.automationService = service.NewAutomationService(
@@ -1009,12 +1151,29 @@ func (a *App) InitServices() error {
 	// Initialize server-side tool registry
 	toolRegistry := service.NewServerSideToolRegistry(firecrawlService, a.logger)
 
+	// Initialize the assistant tools acting on workspace data
+	workspaceTools := service.NewWorkspaceToolRegistry(
+		a.analyticsService,
+		a.broadcastService,
+		a.automationService,
+		a.segmentService,
+		a.templateService,
+		a.logger,
+	)
+
 	// Initialize LLM service with tool registry
 	a.llmService = service.NewLLMService(service.LLMServiceConfig{
-		AuthService:   a.authService,
-		WorkspaceRepo: a.workspaceRepo,
-		Logger:        a.logger,
-		ToolRegistry:  toolRegistry,
+		AuthService:        a.authService,
+		WorkspaceRepo:      a.workspaceRepo,
+		Logger:             a.logger,
+		ToolRegistry:       toolRegistry,
+		TemplateService:    a.templateService,
+		BroadcastService:   a.broadcastService,
+		MessageHistoryRepo: a.messageHistoryRepo,
+		ListRepo:           a.listRepo,
+		SegmentRepo:        a.segmentRepo,
+		WorkspaceTools:     workspaceTools,
+		ToolCallRepo:       a.llmToolCallRepo,
 	})
 
 	// Initialize automation executor and scheduler
@@ -1031,6 +1190,7 @@ func (a *App) InitServices() error {
 		a.logger,
 		a.config.APIEndpoint,
 	)
+	automationExecutor.SetWebhookEventPublisher(webhookEventPublisher)
 	a.automationScheduler = service.NewAutomationScheduler(
 		automationExecutor,
 		a.logger,
@@ -1151,6 +1311,8 @@ func (a *App) InitHandlers() error {
 		a.blogCache,
 		a.config.OIDC.Enabled,
 		a.config.OIDC.ButtonLabel,
+		a.blogCommentService,
+		a.rateLimiter,
 	)
 	setupHandler := httpHandler.NewSetupHandler(
 		a.setupService,
@@ -1177,13 +1339,18 @@ func (a *App) InitHandlers() error {
 	)
 	contactHandler := httpHandler.NewContactHandler(a.contactService, getJWTSecret, a.logger)
 	listHandler := httpHandler.NewListHandler(a.listService, getJWTSecret, a.logger)
+	emailVerificationHandler := httpHandler.NewEmailVerificationHandler(a.emailVerificationService, getJWTSecret, a.logger)
+	contactImportHandler := httpHandler.NewContactImportHandler(a.contactImportService, getJWTSecret, a.logger)
+	contactBulkOperationHandler := httpHandler.NewContactBulkOperationHandler(a.contactBulkOperationService, getJWTSecret, a.logger)
 	contactListHandler := httpHandler.NewContactListHandler(a.contactListService, getJWTSecret, a.logger)
+	signupFormHandler := httpHandler.NewSignupFormHandler(a.signupFormService, getJWTSecret, a.logger, a.rateLimiter, a.config.APIEndpoint)
 	templateHandler := httpHandler.NewTemplateHandler(a.templateService, getJWTSecret, a.logger)
 	templateBlockHandler := httpHandler.NewTemplateBlockHandler(a.templateBlockService, getJWTSecret, a.logger)
 	emailHandler := httpHandler.NewEmailHandler(a.emailService, getJWTSecret, a.logger, a.config.Security.SecretKey)
 	broadcastHandler := httpHandler.NewBroadcastHandler(a.broadcastService, a.templateService, getJWTSecret, a.logger, a.config.IsDemo())
 	blogHandler := httpHandler.NewBlogHandler(a.blogService, getJWTSecret, a.logger, a.config.IsDemo())
 	blogThemeHandler := httpHandler.NewBlogThemeHandler(a.blogService, getJWTSecret, a.logger)
+	blogCommentHandler := httpHandler.NewBlogCommentHandler(a.blogCommentService, getJWTSecret, a.logger, a.config.IsDemo())
 	taskHandler := httpHandler.NewTaskHandler(
 		a.taskService,
 		getJWTSecret,
@@ -1257,13 +1424,18 @@ func (a *App) InitHandlers() error {
 	rootHandler.RegisterRoutes(a.mux)
 	contactHandler.RegisterRoutes(a.mux)
 	listHandler.RegisterRoutes(a.mux)
+	emailVerificationHandler.RegisterRoutes(a.mux)
+	contactImportHandler.RegisterRoutes(a.mux)
+	contactBulkOperationHandler.RegisterRoutes(a.mux)
 	contactListHandler.RegisterRoutes(a.mux)
+	signupFormHandler.RegisterRoutes(a.mux)
 	templateHandler.RegisterRoutes(a.mux)
 	templateBlockHandler.RegisterRoutes(a.mux)
 	emailHandler.RegisterRoutes(a.mux)
 	broadcastHandler.RegisterRoutes(a.mux)
 	blogHandler.RegisterRoutes(a.mux)
 	blogThemeHandler.RegisterRoutes(a.mux)
+	blogCommentHandler.RegisterRoutes(a.mux)
 	taskHandler.RegisterRoutes(a.mux)
 	transactionalHandler.RegisterRoutes(a.mux)
 	inboundWebhookEventHandler.RegisterRoutes(a.mux)
@@ -118,9 +118,13 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			db_created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
-			db_updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			db_updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			computed_properties JSONB,
+			attributes JSONB,
+			email_verification JSONB
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_contacts_external_id ON contacts(external_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_contacts_email_verification_status ON contacts ((email_verification->>'status')) WHERE email_verification IS NOT NULL`,
 		`CREATE TABLE IF NOT EXISTS lists (
 			id VARCHAR(32) PRIMARY KEY,
 			name VARCHAR(255) NOT NULL,
@@ -139,9 +143,120 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			deleted_at TIMESTAMP WITH TIME ZONE,
+			frequency VARCHAR(20) NOT NULL DEFAULT 'all',
+			paused_until TIMESTAMP WITH TIME ZONE,
 			PRIMARY KEY (email, list_id)
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_contact_lists_list_id ON contact_lists(list_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_contact_lists_paused_until ON contact_lists(email) WHERE paused_until IS NOT NULL`,
+		// Consent ledger: append-only proof of list opt-ins, confirmations and opt-outs
+		`CREATE TABLE IF NOT EXISTS consent_records (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			email VARCHAR(255) NOT NULL,
+			list_id VARCHAR(32) NOT NULL,
+			action VARCHAR(20) NOT NULL,
+			source VARCHAR(32) NOT NULL,
+			source_id VARCHAR(255),
+			ip_address VARCHAR(64),
+			user_agent TEXT,
+			page_url TEXT,
+			consent_text_version VARCHAR(255),
+			message_id VARCHAR(255),
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_consent_records_email ON consent_records(email, list_id, created_at DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_consent_records_list_id ON consent_records(list_id, created_at DESC)`,
+		// Signup forms and their daily submission counters
+		`CREATE TABLE IF NOT EXISTS signup_forms (
+			id VARCHAR(32) PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			list_ids TEXT[] NOT NULL DEFAULT '{}',
+			fields JSONB NOT NULL DEFAULT '[]',
+			consent_text TEXT NOT NULL DEFAULT '',
+			consent_text_version VARCHAR(32) NOT NULL DEFAULT '',
+			success_message TEXT NOT NULL DEFAULT '',
+			success_redirect_url TEXT NOT NULL DEFAULT '',
+			style JSONB NOT NULL DEFAULT '{}',
+			captcha JSONB NOT NULL DEFAULT '{}',
+			enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			deleted_at TIMESTAMP WITH TIME ZONE
+		)`,
+		`CREATE TABLE IF NOT EXISTS signup_form_stats (
+			form_id VARCHAR(32) NOT NULL,
+			day DATE NOT NULL,
+			views INTEGER NOT NULL DEFAULT 0,
+			submitted INTEGER NOT NULL DEFAULT 0,
+			subscribed INTEGER NOT NULL DEFAULT 0,
+			spam_blocked INTEGER NOT NULL DEFAULT 0,
+			disposable_blocked INTEGER NOT NULL DEFAULT 0,
+			invalid INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (form_id, day)
+		)`,
+		`CREATE TABLE IF NOT EXISTS contact_imports (
+			id VARCHAR(36) PRIMARY KEY,
+			file_name VARCHAR(255) NOT NULL,
+			format VARCHAR(10) NOT NULL,
+			source VARCHAR(20) NOT NULL,
+			s3_key TEXT,
+			file_size BIGINT NOT NULL DEFAULT 0,
+			file_data BYTEA,
+			columns TEXT[] NOT NULL DEFAULT '{}',
+			preview JSONB NOT NULL DEFAULT '[]',
+			mapping JSONB NOT NULL DEFAULT '{}',
+			list_ids TEXT[] NOT NULL DEFAULT '{}',
+			list_status VARCHAR(20),
+			dedupe_strategy VARCHAR(20),
+			status VARCHAR(20) NOT NULL,
+			dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+			task_id VARCHAR(36),
+			total_rows INTEGER NOT NULL DEFAULT 0,
+			processed_rows INTEGER NOT NULL DEFAULT 0,
+			created_count INTEGER NOT NULL DEFAULT 0,
+			updated_count INTEGER NOT NULL DEFAULT 0,
+			skipped_count INTEGER NOT NULL DEFAULT 0,
+			failed_count INTEGER NOT NULL DEFAULT 0,
+			errors JSONB NOT NULL DEFAULT '[]',
+			error_message TEXT,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			completed_at TIMESTAMP WITH TIME ZONE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_contact_imports_created_at ON contact_imports(created_at DESC)`,
+		`CREATE TABLE IF NOT EXISTS contact_aliases (
+			email VARCHAR(255) PRIMARY KEY,
+			contact_email VARCHAR(255) NOT NULL,
+			reason VARCHAR(20) NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_contact_aliases_contact_email ON contact_aliases(contact_email)`,
+		`CREATE TABLE IF NOT EXISTS contact_bulk_operations (
+			id VARCHAR(36) PRIMARY KEY,
+			target JSONB NOT NULL,
+			action JSONB NOT NULL,
+			status VARCHAR(20) NOT NULL,
+			task_id VARCHAR(36),
+			total_count INTEGER NOT NULL DEFAULT 0,
+			processed_count INTEGER NOT NULL DEFAULT 0,
+			succeeded_count INTEGER NOT NULL DEFAULT 0,
+			skipped_count INTEGER NOT NULL DEFAULT 0,
+			failed_count INTEGER NOT NULL DEFAULT 0,
+			error_message TEXT,
+			created_by VARCHAR(255),
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			completed_at TIMESTAMP WITH TIME ZONE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_contact_bulk_operations_created_at ON contact_bulk_operations(created_at DESC)`,
+		`CREATE TABLE IF NOT EXISTS contact_bulk_operation_results (
+			operation_id VARCHAR(36) NOT NULL REFERENCES contact_bulk_operations(id) ON DELETE CASCADE,
+			email VARCHAR(255) NOT NULL,
+			status VARCHAR(20) NOT NULL,
+			error TEXT,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (operation_id, email)
+		)`,
 		`CREATE TABLE IF NOT EXISTS templates (
 			id VARCHAR(32) NOT NULL,
 			name VARCHAR(255) NOT NULL,
@@ -184,6 +299,7 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			paused_at TIMESTAMP WITH TIME ZONE,
 			pause_reason TEXT,
 			data_feed JSONB,
+			blog_post JSONB,
 			PRIMARY KEY (id)
 		)`,
 		`CREATE TABLE IF NOT EXISTS message_history (
@@ -297,6 +413,16 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			queued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_contact_segment_queue_queued_at ON contact_segment_queue(queued_at ASC)`,
+		`CREATE TABLE IF NOT EXISTS segment_history (
+			segment_id VARCHAR(32) NOT NULL,
+			day DATE NOT NULL,
+			users_count INTEGER NOT NULL DEFAULT 0,
+			joined_count INTEGER NOT NULL DEFAULT 0,
+			left_count INTEGER NOT NULL DEFAULT 0,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (segment_id, day)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_segment_history_day ON segment_history(day)`,
 		`CREATE TABLE IF NOT EXISTS message_attachments (
 			checksum VARCHAR(64) PRIMARY KEY,
 			content BYTEA NOT NULL,
@@ -319,6 +445,10 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			slug VARCHAR(100) NOT NULL UNIQUE,
 			settings JSONB NOT NULL DEFAULT '{}',
 			published_at TIMESTAMP,
+			scheduled_publish_at TIMESTAMP,
+			search_config VARCHAR(32) NOT NULL DEFAULT 'simple',
+			search_text TEXT,
+			search_vector TSVECTOR,
 			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
 			deleted_at TIMESTAMP
@@ -326,6 +456,57 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 		`CREATE INDEX IF NOT EXISTS idx_blog_posts_published ON blog_posts(published_at DESC) WHERE deleted_at IS NULL AND published_at IS NOT NULL`,
 		`CREATE INDEX IF NOT EXISTS idx_blog_posts_category ON blog_posts(category_id) WHERE deleted_at IS NULL`,
 		`CREATE UNIQUE INDEX IF NOT EXISTS idx_blog_posts_slug ON blog_posts(slug) WHERE deleted_at IS NULL`,
+		`CREATE TABLE IF NOT EXISTS blog_post_revisions (
+			post_id UUID NOT NULL REFERENCES blog_posts(id) ON DELETE CASCADE,
+			version INTEGER NOT NULL,
+			category_id UUID,
+			slug VARCHAR(100) NOT NULL,
+			settings JSONB NOT NULL DEFAULT '{}',
+			status VARCHAR(20) NOT NULL,
+			preview_token VARCHAR(64),
+			restored_from INTEGER,
+			created_by VARCHAR(255),
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			applied_at TIMESTAMP,
+			PRIMARY KEY (post_id, version)
+		)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_blog_post_revisions_preview_token ON blog_post_revisions(preview_token) WHERE preview_token IS NOT NULL`,
+		`CREATE TABLE IF NOT EXISTS blog_comments (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			post_id UUID NOT NULL REFERENCES blog_posts(id) ON DELETE CASCADE,
+			parent_id UUID REFERENCES blog_comments(id) ON DELETE CASCADE,
+			email VARCHAR(255) NOT NULL,
+			name VARCHAR(100) NOT NULL,
+			content TEXT NOT NULL,
+			status VARCHAR(20) NOT NULL,
+			spam_reasons TEXT[],
+			ip_address VARCHAR(45),
+			user_agent TEXT,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			approved_at TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_blog_comments_post ON blog_comments(post_id, created_at) WHERE status = 'approved'`,
+		`CREATE INDEX IF NOT EXISTS idx_blog_comments_status ON blog_comments(status, created_at DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_blog_comments_email ON blog_comments(email, created_at DESC)`,
+		`CREATE TABLE IF NOT EXISTS blog_post_reactions (
+			post_id UUID NOT NULL REFERENCES blog_posts(id) ON DELETE CASCADE,
+			email VARCHAR(255) NOT NULL,
+			reaction VARCHAR(20) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (post_id, email, reaction)
+		)`,
+		`CREATE TABLE IF NOT EXISTS llm_tool_calls (
+			id VARCHAR(36) PRIMARY KEY,
+			user_id VARCHAR(36) NOT NULL,
+			tool_name VARCHAR(100) NOT NULL,
+			input JSONB NOT NULL DEFAULT '{}'::jsonb,
+			status VARCHAR(20) NOT NULL,
+			error TEXT,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_llm_tool_calls_created_at ON llm_tool_calls(created_at DESC)`,
 		`CREATE TABLE IF NOT EXISTS blog_themes (
 			version INTEGER NOT NULL PRIMARY KEY,
 			published_at TIMESTAMP,
@@ -373,7 +554,9 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			enabled BOOLEAN DEFAULT true,
 			created_at TIMESTAMPTZ DEFAULT NOW(),
 			updated_at TIMESTAMPTZ DEFAULT NOW(),
-			last_delivery_at TIMESTAMPTZ
+			last_delivery_at TIMESTAMPTZ,
+			failing_since TIMESTAMPTZ,
+			disabled_reason TEXT
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_enabled ON webhook_subscriptions(enabled) WHERE enabled = true`,
 		// Webhook deliveries table
@@ -391,11 +574,13 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			last_response_status INT,
 			last_response_body TEXT,
 			last_error TEXT,
-			created_at TIMESTAMPTZ DEFAULT NOW()
+			created_at TIMESTAMPTZ DEFAULT NOW(),
+			seq BIGSERIAL
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at) WHERE status IN ('pending', 'failed') AND attempts < max_attempts`,
 		`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, created_at DESC)`,
 		`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries(status)`,
+		`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_seq ON webhook_deliveries(subscription_id, seq) WHERE status IN ('pending', 'failed')`,
 		// Automation tables (nodes embedded as JSONB in automations)
 		`CREATE TABLE IF NOT EXISTS automations (
 			id VARCHAR(36) PRIMARY KEY,
@@ -540,6 +725,12 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 				IF OLD.custom_json_3 IS DISTINCT FROM NEW.custom_json_3 THEN changes_json := changes_json || jsonb_build_object('custom_json_3', jsonb_build_object('old', OLD.custom_json_3, 'new', NEW.custom_json_3)); END IF;
 				IF OLD.custom_json_4 IS DISTINCT FROM NEW.custom_json_4 THEN changes_json := changes_json || jsonb_build_object('custom_json_4', jsonb_build_object('old', OLD.custom_json_4, 'new', NEW.custom_json_4)); END IF;
 				IF OLD.custom_json_5 IS DISTINCT FROM NEW.custom_json_5 THEN changes_json := changes_json || jsonb_build_object('custom_json_5', jsonb_build_object('old', OLD.custom_json_5, 'new', NEW.custom_json_5)); END IF;
+				IF OLD.computed_properties IS DISTINCT FROM NEW.computed_properties THEN changes_json := changes_json || jsonb_build_object('computed_properties', jsonb_build_object('old', OLD.computed_properties, 'new', NEW.computed_properties)); END IF;
+				IF OLD.attributes IS DISTINCT FROM NEW.attributes THEN
+					SELECT changes_json || COALESCE(jsonb_object_agg('attributes.' || k, jsonb_build_object('old', OLD.attributes->k, 'new', NEW.attributes->k)), '{}'::jsonb) INTO changes_json
+					FROM (SELECT jsonb_object_keys(COALESCE(OLD.attributes, '{}'::jsonb)) AS k UNION SELECT jsonb_object_keys(COALESCE(NEW.attributes, '{}'::jsonb))) attribute_keys
+					WHERE (OLD.attributes->k) IS DISTINCT FROM (NEW.attributes->k);
+				END IF;
 				IF changes_json = '{}'::jsonb THEN RETURN NEW; END IF;
 			END IF;
 		IF TG_OP = 'INSERT' THEN
@@ -604,6 +795,20 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 					changes_json := jsonb_build_object(
 						'status', jsonb_build_object('old', OLD.status, 'new', NEW.status)
 					);
+
+				-- Handle marketing pauses set from the notification center
+				ELSIF OLD.paused_until IS DISTINCT FROM NEW.paused_until THEN
+					kind_value := CASE WHEN NEW.paused_until IS NULL THEN 'list.resumed' ELSE 'list.paused' END;
+					changes_json := jsonb_build_object(
+						'paused_until', jsonb_build_object('old', OLD.paused_until, 'new', NEW.paused_until)
+					);
+
+				-- Handle delivery frequency changes
+				ELSIF OLD.frequency IS DISTINCT FROM NEW.frequency THEN
+					kind_value := 'list.frequency_changed';
+					changes_json := jsonb_build_object(
+						'frequency', jsonb_build_object('old', OLD.frequency, 'new', NEW.frequency)
+					);
 				ELSE
 					RETURN NEW;
 				END IF;
@@ -695,8 +900,9 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			IF NEW.bounce_category IS NOT NULL AND NEW.bounce_category != '' THEN changes_json := changes_json || jsonb_build_object('bounce_category', jsonb_build_object('new', NEW.bounce_category)); END IF;
 			IF NEW.bounce_diagnostic IS NOT NULL AND NEW.bounce_diagnostic != '' THEN changes_json := changes_json || jsonb_build_object('bounce_diagnostic', jsonb_build_object('new', NEW.bounce_diagnostic)); END IF;
 			IF NEW.complaint_feedback_type IS NOT NULL AND NEW.complaint_feedback_type != '' THEN changes_json := changes_json || jsonb_build_object('complaint_feedback_type', jsonb_build_object('new', NEW.complaint_feedback_type)); END IF;
+			-- Events for a merged or changed address are recorded on the contact now owning it
 			INSERT INTO contact_timeline (email, operation, entity_type, kind, entity_id, changes, created_at)
-			VALUES (NEW.recipient_email, 'insert', 'inbound_webhook_event', kind_value, entity_id_value, changes_json, CURRENT_TIMESTAMP);
+			VALUES (COALESCE((SELECT contact_email FROM contact_aliases WHERE email = NEW.recipient_email), NEW.recipient_email), 'insert', 'inbound_webhook_event', kind_value, entity_id_value, changes_json, CURRENT_TIMESTAMP);
 			RETURN NEW;
 		END;
 		$$ LANGUAGE plpgsql;`,
@@ -808,6 +1014,10 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 					changes_json := changes_json || jsonb_build_object('goal_name', jsonb_build_object('new', NEW.goal_name));
 				END IF;
 			ELSIF TG_OP = 'UPDATE' THEN
+				-- Re-pointing the event to another contact (merge or email change) is not an event change
+				IF OLD.email IS DISTINCT FROM NEW.email THEN
+					RETURN NEW;
+				END IF;
 				timeline_operation := 'update';
 				kind_value := 'custom_event.' || NEW.event_name;
 				property_diff := '{}'::jsonb;
@@ -856,7 +1066,31 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			RETURN NEW;
 		END;
 		$$ LANGUAGE plpgsql;`,
+		// Blog comment timeline trigger function
+		`CREATE OR REPLACE FUNCTION track_blog_comment_changes()
+		RETURNS TRIGGER AS $$
+		BEGIN
+			-- A comment is recorded on the timeline of its author the first time it is approved
+			IF NEW.approved_at IS NULL OR (TG_OP = 'UPDATE' AND OLD.approved_at IS NOT NULL) THEN
+				RETURN NEW;
+			END IF;
+			INSERT INTO contact_timeline (email, operation, entity_type, kind, entity_id, changes, created_at)
+			VALUES (NEW.email, 'insert', 'blog_post', 'blog.commented', NEW.post_id::text,
+				jsonb_build_object('comment_id', jsonb_build_object('new', NEW.id), 'parent_id', jsonb_build_object('new', NEW.parent_id)),
+				CURRENT_TIMESTAMP);
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql;`,
+		// Consent ledger entries cannot be modified once written
+		`CREATE OR REPLACE FUNCTION prevent_consent_record_update()
+		RETURNS TRIGGER AS $$
+		BEGIN
+			RAISE EXCEPTION 'consent_records is append-only';
+		END;
+		$$ LANGUAGE plpgsql;`,
 		// Create triggers
+		`DROP TRIGGER IF EXISTS consent_records_immutable_trigger ON consent_records`,
+		`CREATE TRIGGER consent_records_immutable_trigger BEFORE UPDATE ON consent_records FOR EACH ROW EXECUTE FUNCTION prevent_consent_record_update()`,
 		`DROP TRIGGER IF EXISTS contact_changes_trigger ON contacts`,
 		`CREATE TRIGGER contact_changes_trigger AFTER INSERT OR UPDATE ON contacts FOR EACH ROW EXECUTE FUNCTION track_contact_changes()`,
 		`DROP TRIGGER IF EXISTS contact_list_changes_trigger ON contact_lists`,
@@ -873,6 +1107,8 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 		`CREATE TRIGGER message_history_status_trigger AFTER UPDATE ON message_history FOR EACH ROW EXECUTE FUNCTION update_contact_lists_on_status_change()`,
 		`DROP TRIGGER IF EXISTS custom_event_timeline_trigger ON custom_events`,
 		`CREATE TRIGGER custom_event_timeline_trigger AFTER INSERT OR UPDATE ON custom_events FOR EACH ROW EXECUTE FUNCTION track_custom_event_timeline()`,
+		`DROP TRIGGER IF EXISTS blog_comment_changes_trigger ON blog_comments`,
+		`CREATE TRIGGER blog_comment_changes_trigger AFTER INSERT OR UPDATE ON blog_comments FOR EACH ROW EXECUTE FUNCTION track_blog_comment_changes()`,
 		// Webhook trigger functions for outgoing webhooks
 		// Trigger 1: contacts table - contact.created, contact.updated, contact.deleted
 		`CREATE OR REPLACE FUNCTION webhook_contacts_trigger()
@@ -923,7 +1159,8 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 				   NEW.custom_json_2 IS NOT DISTINCT FROM OLD.custom_json_2 AND
 				   NEW.custom_json_3 IS NOT DISTINCT FROM OLD.custom_json_3 AND
 				   NEW.custom_json_4 IS NOT DISTINCT FROM OLD.custom_json_4 AND
-				   NEW.custom_json_5 IS NOT DISTINCT FROM OLD.custom_json_5 THEN
+				   NEW.custom_json_5 IS NOT DISTINCT FROM OLD.custom_json_5 AND
+				   NEW.attributes IS NOT DISTINCT FROM OLD.attributes THEN
 					RETURN NEW;
 				END IF;
 			ELSIF TG_OP = 'DELETE' THEN
@@ -991,6 +1228,14 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 					END IF;
 				ELSIF NEW.deleted_at IS NOT NULL AND OLD.deleted_at IS NULL THEN
 					event_kind := 'list.removed';
+				ELSIF NEW.paused_until IS DISTINCT FROM OLD.paused_until THEN
+					IF NEW.paused_until IS NULL THEN
+						event_kind := 'list.resumed';
+					ELSE
+						event_kind := 'list.paused';
+					END IF;
+				ELSIF NEW.frequency IS DISTINCT FROM OLD.frequency THEN
+					event_kind := 'list.frequency_changed';
 				ELSE
 					RETURN NEW;
 				END IF;
@@ -1004,7 +1249,9 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 				'list_id', NEW.list_id,
 				'list_name', list_name,
 				'status', NEW.status,
-				'previous_status', CASE WHEN TG_OP = 'UPDATE' THEN OLD.status ELSE NULL END
+				'previous_status', CASE WHEN TG_OP = 'UPDATE' THEN OLD.status ELSE NULL END,
+				'frequency', NEW.frequency,
+				'paused_until', NEW.paused_until
 			);
 
 			-- Insert webhook deliveries for matching subscriptions
@@ -1153,6 +1400,10 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 					subscribed_event_type := 'custom_event.created';
 				END IF;
 			ELSIF TG_OP = 'UPDATE' THEN
+				-- Re-pointing the event to another contact (merge or email change) is not an event change
+				IF OLD.email IS DISTINCT FROM NEW.email THEN
+					RETURN NEW;
+				END IF;
 				-- Check for soft-delete: was not deleted, now is deleted
 				IF (OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL) THEN
 					event_kind := 'custom_event.deleted';
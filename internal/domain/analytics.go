@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Notifuse/notifuse/pkg/analytics"
 )
@@ -751,6 +752,99 @@ var PredefinedSchemas = map[string]analytics.SchemaDefinition{
 			},
 		},
 	},
+	"segment_history": {
+		Name: "segment_history",
+		Measures: map[string]analytics.MeasureDefinition{
+			"count": {
+				Type:        "count",
+				Title:       "Snapshots",
+				SQL:         "*",
+				Description: "Number of daily segment snapshots",
+			},
+			"max_users_count": {
+				Type:        "max",
+				Title:       "Segment Size (max)",
+				SQL:         "users_count",
+				Description: "Largest daily segment size in the period",
+			},
+			"min_users_count": {
+				Type:        "min",
+				Title:       "Segment Size (min)",
+				SQL:         "users_count",
+				Description: "Smallest daily segment size in the period",
+			},
+			"avg_users_count": {
+				Type:        "avg",
+				Title:       "Segment Size (avg)",
+				SQL:         "users_count",
+				Description: "Average daily segment size in the period",
+			},
+			"sum_joined": {
+				Type:        "sum",
+				Title:       "Joined",
+				SQL:         "joined_count",
+				Description: "Contacts that joined the segment",
+			},
+			"sum_left": {
+				Type:        "sum",
+				Title:       "Left",
+				SQL:         "left_count",
+				Description: "Contacts that left the segment",
+			},
+		},
+		Dimensions: map[string]analytics.DimensionDefinition{
+			"segment_id": {
+				Type:        "string",
+				Title:       "Segment ID",
+				SQL:         "segment_id",
+				Description: "Segment identifier",
+			},
+			"day": {
+				Type:        "time",
+				Title:       "Day",
+				SQL:         "day",
+				Description: "UTC day of the snapshot, refreshed hourly while the day is current and finalized after midnight",
+			},
+		},
+	},
+}
+
+// ContactAttributeDimensionPrefix prefixes the contacts schema dimensions of contact attributes
+const ContactAttributeDimensionPrefix = "attr_"
+
+// WithContactAttributeDimensions returns a copy of the contacts schema exposing the
+// workspace contact attributes as "attr_<key>" dimensions. PII and JSON attributes
+// are not exposed.
+func WithContactAttributeDimensions(schema analytics.SchemaDefinition, attrs []ContactAttribute) analytics.SchemaDefinition {
+	dimensions := make(map[string]analytics.DimensionDefinition, len(schema.Dimensions)+len(attrs))
+	for name, dimension := range schema.Dimensions {
+		dimensions[name] = dimension
+	}
+
+	for i := range attrs {
+		attr := &attrs[i]
+		fieldType := attr.FilterType()
+		if attr.PII || fieldType == "" {
+			continue
+		}
+		title := attr.Label
+		if title == "" {
+			title = attr.Key
+		}
+		description := attr.Description
+		if description == "" {
+			description = fmt.Sprintf("Contact attribute %s", attr.Key)
+		}
+		dimensions[ContactAttributeDimensionPrefix+attr.Key] = analytics.DimensionDefinition{
+			Type:        fieldType,
+			Title:       title,
+			SQL:         ContactJSONFieldSQL("attributes", attr.Key, fieldType),
+			Description: description,
+		}
+	}
+
+	schema.Dimensions = dimensions
+	return schema
 }
 
 // AnalyticsService defines the analytics business logic interface
@@ -10,7 +10,7 @@ import (
 
 func TestPredefinedSchemas(t *testing.T) {
 	// Test that all expected schemas exist
-	expectedSchemas := []string{"message_history", "contacts", "broadcasts", "webhook_deliveries", "email_queue", "automation_node_executions"}
+	expectedSchemas := []string{"message_history", "contacts", "broadcasts", "webhook_deliveries", "email_queue", "automation_node_executions", "segment_history"}
 
 	for _, schemaName := range expectedSchemas {
 		t.Run("schema_"+schemaName, func(t *testing.T) {
@@ -239,6 +239,22 @@ func TestAutomationNodeExecutionsSchema(t *testing.T) {
 	}
 }
 
+func TestSegmentHistorySchema(t *testing.T) {
+	schema := PredefinedSchemas["segment_history"]
+
+	// Test measures
+	requiredMeasures := []string{"count", "max_users_count", "min_users_count", "avg_users_count", "sum_joined", "sum_left"}
+	for _, measure := range requiredMeasures {
+		assert.Contains(t, schema.Measures, measure, "segment_history should have measure %s", measure)
+	}
+
+	// Test dimensions
+	requiredDimensions := []string{"segment_id", "day"}
+	for _, dimension := range requiredDimensions {
+		assert.Contains(t, schema.Dimensions, dimension, "segment_history should have dimension %s", dimension)
+	}
+}
+
 func TestPredefinedSchemasWithFilters(t *testing.T) {
 	// Test that our new filter-based measures generate valid SQL
 	builder := analytics.NewSQLBuilder()
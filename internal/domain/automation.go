@@ -55,11 +55,14 @@ var ValidEventKinds = []string{
 	// List events (require list_id)
 	"list.subscribed", "list.unsubscribed", "list.confirmed", "list.resubscribed",
 	"list.bounced", "list.complained", "list.pending", "list.removed",
+	"list.paused", "list.resumed", "list.frequency_changed",
 	// Segment events (require segment_id)
 	"segment.joined", "segment.left",
 	// Email events
 	"email.sent", "email.delivered", "email.opened", "email.clicked",
 	"email.bounced", "email.complained", "email.unsubscribed",
+	// Blog events
+	"blog.commented",
 	// Custom events (require custom_event_name)
 	"custom_event",
 }
@@ -714,6 +717,11 @@ type AutomationRepository interface {
 	// number of journeys exited.
 	ExitContactJourneysOnReply(ctx context.Context, workspaceID, contactEmail string, automationID *string, reason string, before time.Time) (int, error)
 
+	// ExitContactJourneys marks the active journeys of the given contacts in an
+	// automation as exited with the given reason. Returns the emails whose journey
+	// was exited.
+	ExitContactJourneys(ctx context.Context, workspaceID, automationID string, emails []string, reason string) ([]string, error)
+
 	// Global scheduling (across all workspaces with round-robin)
 	GetScheduledContactAutomationsGlobal(ctx context.Context, beforeTime time.Time, limit int) ([]*ContactAutomationWithWorkspace, error)
 
@@ -118,9 +118,92 @@ type BlogAuthor struct {
 
 // BlogCategorySettings contains the settings for a blog category
 type BlogCategorySettings struct {
+	Name        string                          `json:"name"`
+	Description string                          `json:"description,omitempty"`
+	SEO         *SEOSettings                    `json:"seo,omitempty"`        // SEO metadata
+	Newsletter  *BlogCategoryNewsletterSettings `json:"newsletter,omitempty"` // Newsletter sent for the posts of the category
+	// Translations holds the category in the other languages of the workspace, by language code
+	Translations map[string]BlogCategoryTranslation `json:"translations,omitempty"`
+	// Gate restricts the body of the posts of the category to the subscribers of a list
+	Gate *BlogGate `json:"gate,omitempty"`
+}
+
+// BlogCategoryTranslation is a category in another language than the workspace default
+// language. An empty slug keeps the slug of the category.
+type BlogCategoryTranslation struct {
+	Slug        string       `json:"slug,omitempty"`
 	Name        string       `json:"name"`
 	Description string       `json:"description,omitempty"`
-	SEO         *SEOSettings `json:"seo,omitempty"` // SEO metadata
+	SEO         *SEOSettings `json:"seo,omitempty"`
+}
+
+// validateBlogCategoryTranslations validates the translations of a category
+func validateBlogCategoryTranslations(translations map[string]BlogCategoryTranslation) error {
+	for lang, translation := range translations {
+		if !IsValidLanguage(lang) {
+			return fmt.Errorf("invalid translation language code: %s", lang)
+		}
+
+		if translation.Name == "" {
+			return fmt.Errorf("translation '%s': name is required", lang)
+		}
+
+		if len(translation.Name) > 255 {
+			return fmt.Errorf("translation '%s': name must be less than 255 characters", lang)
+		}
+
+		if translation.Slug != "" {
+			if !slugRegex.MatchString(translation.Slug) {
+				return fmt.Errorf("translation '%s': slug must contain only lowercase letters, numbers, and hyphens", lang)
+			}
+
+			if len(translation.Slug) > 100 {
+				return fmt.Errorf("translation '%s': slug must be less than 100 characters", lang)
+			}
+		}
+	}
+
+	return nil
+}
+
+// BlogNewsletterSettings configures the broadcast sending a published post to subscribers
+type BlogNewsletterSettings struct {
+	TemplateID    string           `json:"template_id"` // Email template rendering the post
+	Audience      AudienceSettings `json:"audience"`
+	UTMParameters *UTMParameters   `json:"utm_parameters,omitempty"`
+	DelayMinutes  int              `json:"delay_minutes,omitempty"` // Schedules the broadcast after the publication instead of sending it right away
+}
+
+// Validate validates the newsletter settings
+func (s *BlogNewsletterSettings) Validate() error {
+	if s.TemplateID == "" {
+		return fmt.Errorf("newsletter template_id is required")
+	}
+
+	if s.Audience.List == "" {
+		return fmt.Errorf("newsletter audience list is required")
+	}
+
+	if s.DelayMinutes < 0 || s.DelayMinutes > 7*24*60 {
+		return fmt.Errorf("newsletter delay_minutes must be between 0 and 10080")
+	}
+
+	return nil
+}
+
+// BlogCategoryNewsletterSettings configures the newsletter of a category. With AutoSend,
+// every post published in the category is sent to subscribers.
+type BlogCategoryNewsletterSettings struct {
+	AutoSend bool `json:"auto_send"`
+	BlogNewsletterSettings
+}
+
+// Validate validates the category newsletter settings
+func (s *BlogCategoryNewsletterSettings) Validate() error {
+	if !s.AutoSend {
+		return nil
+	}
+	return s.BlogNewsletterSettings.Validate()
 }
 
 // Value implements the driver.Valuer interface for database serialization
@@ -179,7 +262,33 @@ func (c *BlogCategory) Validate() error {
 		return fmt.Errorf("name must be less than 255 characters")
 	}
 
-	return nil
+	if err := c.Settings.Gate.Validate(); err != nil {
+		return err
+	}
+
+	return validateBlogCategoryTranslations(c.Settings.Translations)
+}
+
+// Localize returns the category in a language, with the translated fields of its
+// translation when it has one. The default language ("") returns the category itself.
+func (c *BlogCategory) Localize(language string) *BlogCategory {
+	translation, ok := c.Settings.Translations[language]
+	if language == "" || !ok {
+		return c
+	}
+
+	localized := *c
+	if translation.Slug != "" {
+		localized.Slug = translation.Slug
+	}
+	localized.Settings.Name = translation.Name
+	if translation.Description != "" {
+		localized.Settings.Description = translation.Description
+	}
+	if translation.SEO != nil {
+		localized.Settings.SEO = translation.SEO
+	}
+	return &localized
 }
 
 // BlogPostTemplateReference contains template information for a blog post
@@ -197,6 +306,55 @@ type BlogPostSettings struct {
 	Authors            []BlogAuthor              `json:"authors"`
 	ReadingTimeMinutes int                       `json:"reading_time_minutes"`
 	SEO                *SEOSettings              `json:"seo,omitempty"` // SEO metadata
+	// NewsletterBroadcastID is the broadcast that sent the post to subscribers
+	NewsletterBroadcastID string `json:"newsletter_broadcast_id,omitempty"`
+	// Translations holds the post in the other languages of the workspace, by language code
+	Translations map[string]BlogPostTranslation `json:"translations,omitempty"`
+	// CommentsDisabled turns off comments and reactions on the post when the blog has them
+	CommentsDisabled bool `json:"comments_disabled,omitempty"`
+	// Gate restricts the body of the post to the subscribers of a list, instead of the gate
+	// of its category
+	Gate *BlogGate `json:"gate,omitempty"`
+}
+
+// BlogPostTranslation is a post in another language than the workspace default language.
+// Its content is the translation of the post template in the same language.
+type BlogPostTranslation struct {
+	Slug    string       `json:"slug"`
+	Title   string       `json:"title"`
+	Excerpt string       `json:"excerpt,omitempty"`
+	SEO     *SEOSettings `json:"seo,omitempty"`
+}
+
+// validateBlogPostTranslations validates the translations of a post
+func validateBlogPostTranslations(translations map[string]BlogPostTranslation) error {
+	for lang, translation := range translations {
+		if !IsValidLanguage(lang) {
+			return fmt.Errorf("invalid translation language code: %s", lang)
+		}
+
+		if translation.Slug == "" {
+			return fmt.Errorf("translation '%s': slug is required", lang)
+		}
+
+		if !slugRegex.MatchString(translation.Slug) {
+			return fmt.Errorf("translation '%s': slug must contain only lowercase letters, numbers, and hyphens", lang)
+		}
+
+		if len(translation.Slug) > 100 {
+			return fmt.Errorf("translation '%s': slug must be less than 100 characters", lang)
+		}
+
+		if translation.Title == "" {
+			return fmt.Errorf("translation '%s': title is required", lang)
+		}
+
+		if len(translation.Title) > 500 {
+			return fmt.Errorf("translation '%s': title must be less than 500 characters", lang)
+		}
+	}
+
+	return nil
 }
 
 // Value implements the driver.Valuer interface for database serialization
@@ -226,9 +384,12 @@ type BlogPost struct {
 	Slug        string           `json:"slug"` // URL identifier
 	Settings    BlogPostSettings `json:"settings"`
 	PublishedAt *time.Time       `json:"published_at,omitempty"` // null = draft
-	CreatedAt   time.Time        `json:"created_at"`
-	UpdatedAt   time.Time        `json:"updated_at"`
-	DeletedAt   *time.Time       `json:"deleted_at,omitempty"`
+	// ScheduledPublishAt is when the publish_blog_post task publishes the post, or promotes
+	// its latest draft revision when the post is already published
+	ScheduledPublishAt *time.Time `json:"scheduled_publish_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	DeletedAt          *time.Time `json:"deleted_at,omitempty"`
 }
 
 // Validate validates the blog post
@@ -265,7 +426,32 @@ func (p *BlogPost) Validate() error {
 		return fmt.Errorf("template_id is required")
 	}
 
-	return nil
+	if err := p.Settings.Gate.Validate(); err != nil {
+		return err
+	}
+
+	return validateBlogPostTranslations(p.Settings.Translations)
+}
+
+// Localize returns the post in a language, with the fields of its translation, or nil
+// when the post is not translated in that language. The default language ("") returns
+// the post itself.
+func (p *BlogPost) Localize(language string) *BlogPost {
+	if language == "" {
+		return p
+	}
+
+	translation, ok := p.Settings.Translations[language]
+	if !ok {
+		return nil
+	}
+
+	localized := *p
+	localized.Slug = translation.Slug
+	localized.Settings.Title = translation.Title
+	localized.Settings.Excerpt = translation.Excerpt
+	localized.Settings.SEO = translation.SEO
+	return &localized
 }
 
 // IsDraft returns true if the post is a draft
@@ -278,6 +464,11 @@ func (p *BlogPost) IsPublished() bool {
 	return p.PublishedAt != nil
 }
 
+// IsScheduled returns true if the post has a pending scheduled publication
+func (p *BlogPost) IsScheduled() bool {
+	return p.ScheduledPublishAt != nil
+}
+
 // GetEffectiveSEOSettings merges the post's SEO settings with the category's defaults
 func (p *BlogPost) GetEffectiveSEOSettings(category *BlogCategory) *SEOSettings {
 	if category == nil {
@@ -300,10 +491,13 @@ type TOCItem struct {
 
 // CreateBlogCategoryRequest defines the request to create a blog category
 type CreateBlogCategoryRequest struct {
-	Name        string       `json:"name"`
-	Slug        string       `json:"slug"`
-	Description string       `json:"description,omitempty"`
-	SEO         *SEOSettings `json:"seo,omitempty"`
+	Name         string                             `json:"name"`
+	Slug         string                             `json:"slug"`
+	Description  string                             `json:"description,omitempty"`
+	SEO          *SEOSettings                       `json:"seo,omitempty"`
+	Newsletter   *BlogCategoryNewsletterSettings    `json:"newsletter,omitempty"`
+	Translations map[string]BlogCategoryTranslation `json:"translations,omitempty"`
+	Gate         *BlogGate                          `json:"gate,omitempty"`
 }
 
 // Validate validates the create blog category request
@@ -329,16 +523,25 @@ func (r *CreateBlogCategoryRequest) Validate() error {
 		return fmt.Errorf("slug must be less than 100 characters")
 	}
 
-	return nil
+	if r.Newsletter != nil {
+		if err := r.Newsletter.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return validateBlogCategoryTranslations(r.Translations)
 }
 
 // UpdateBlogCategoryRequest defines the request to update a blog category
 type UpdateBlogCategoryRequest struct {
-	ID          string       `json:"id"`
-	Name        string       `json:"name"`
-	Slug        string       `json:"slug"`
-	Description string       `json:"description,omitempty"`
-	SEO         *SEOSettings `json:"seo,omitempty"`
+	ID           string                             `json:"id"`
+	Name         string                             `json:"name"`
+	Slug         string                             `json:"slug"`
+	Description  string                             `json:"description,omitempty"`
+	SEO          *SEOSettings                       `json:"seo,omitempty"`
+	Newsletter   *BlogCategoryNewsletterSettings    `json:"newsletter,omitempty"`
+	Translations map[string]BlogCategoryTranslation `json:"translations,omitempty"`
+	Gate         *BlogGate                          `json:"gate,omitempty"`
 }
 
 // Validate validates the update blog category request
@@ -368,7 +571,13 @@ func (r *UpdateBlogCategoryRequest) Validate() error {
 		return fmt.Errorf("slug must be less than 100 characters")
 	}
 
-	return nil
+	if r.Newsletter != nil {
+		if err := r.Newsletter.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return validateBlogCategoryTranslations(r.Translations)
 }
 
 // DeleteBlogCategoryRequest defines the request to delete a blog category
@@ -409,16 +618,19 @@ type BlogCategoryListResponse struct {
 
 // CreateBlogPostRequest defines the request to create a blog post
 type CreateBlogPostRequest struct {
-	CategoryID         string       `json:"category_id"`
-	Slug               string       `json:"slug"`
-	Title              string       `json:"title"`
-	TemplateID         string       `json:"template_id"`
-	TemplateVersion    int          `json:"template_version"`
-	Excerpt            string       `json:"excerpt,omitempty"`
-	FeaturedImageURL   string       `json:"featured_image_url,omitempty"`
-	Authors            []BlogAuthor `json:"authors"`
-	ReadingTimeMinutes int          `json:"reading_time_minutes"`
-	SEO                *SEOSettings `json:"seo,omitempty"`
+	CategoryID         string                         `json:"category_id"`
+	Slug               string                         `json:"slug"`
+	Title              string                         `json:"title"`
+	TemplateID         string                         `json:"template_id"`
+	TemplateVersion    int                            `json:"template_version"`
+	Excerpt            string                         `json:"excerpt,omitempty"`
+	FeaturedImageURL   string                         `json:"featured_image_url,omitempty"`
+	Authors            []BlogAuthor                   `json:"authors"`
+	ReadingTimeMinutes int                            `json:"reading_time_minutes"`
+	SEO                *SEOSettings                   `json:"seo,omitempty"`
+	Translations       map[string]BlogPostTranslation `json:"translations,omitempty"`
+	CommentsDisabled   bool                           `json:"comments_disabled,omitempty"`
+	Gate               *BlogGate                      `json:"gate,omitempty"`
 }
 
 // Validate validates the create blog post request
@@ -451,22 +663,25 @@ func (r *CreateBlogPostRequest) Validate() error {
 		return fmt.Errorf("template_id is required")
 	}
 
-	return nil
+	return validateBlogPostTranslations(r.Translations)
 }
 
 // UpdateBlogPostRequest defines the request to update a blog post
 type UpdateBlogPostRequest struct {
-	ID                 string       `json:"id"`
-	CategoryID         string       `json:"category_id"`
-	Slug               string       `json:"slug"`
-	Title              string       `json:"title"`
-	TemplateID         string       `json:"template_id"`
-	TemplateVersion    int          `json:"template_version"`
-	Excerpt            string       `json:"excerpt,omitempty"`
-	FeaturedImageURL   string       `json:"featured_image_url,omitempty"`
-	Authors            []BlogAuthor `json:"authors"`
-	ReadingTimeMinutes int          `json:"reading_time_minutes"`
-	SEO                *SEOSettings `json:"seo,omitempty"`
+	ID                 string                         `json:"id"`
+	CategoryID         string                         `json:"category_id"`
+	Slug               string                         `json:"slug"`
+	Title              string                         `json:"title"`
+	TemplateID         string                         `json:"template_id"`
+	TemplateVersion    int                            `json:"template_version"`
+	Excerpt            string                         `json:"excerpt,omitempty"`
+	FeaturedImageURL   string                         `json:"featured_image_url,omitempty"`
+	Authors            []BlogAuthor                   `json:"authors"`
+	ReadingTimeMinutes int                            `json:"reading_time_minutes"`
+	SEO                *SEOSettings                   `json:"seo,omitempty"`
+	Translations       map[string]BlogPostTranslation `json:"translations,omitempty"`
+	CommentsDisabled   bool                           `json:"comments_disabled,omitempty"`
+	Gate               *BlogGate                      `json:"gate,omitempty"`
 }
 
 // Validate validates the update blog post request
@@ -503,7 +718,7 @@ func (r *UpdateBlogPostRequest) Validate() error {
 		return fmt.Errorf("template_id is required")
 	}
 
-	return nil
+	return validateBlogPostTranslations(r.Translations)
 }
 
 // DeleteBlogPostRequest defines the request to delete a blog post
@@ -526,6 +741,10 @@ type PublishBlogPostRequest struct {
 	ID          string     `json:"id"`
 	PublishedAt *time.Time `json:"published_at,omitempty"` // Optional custom timestamp
 	Timezone    string     `json:"timezone,omitempty"`     // Optional IANA timezone
+	// Newsletter sends the post to subscribers, overriding the newsletter of the category
+	Newsletter *BlogNewsletterSettings `json:"newsletter,omitempty"`
+	// SkipNewsletter publishes the post without the newsletter of the category
+	SkipNewsletter bool `json:"skip_newsletter,omitempty"`
 }
 
 // Validate validates the publish blog post request
@@ -535,6 +754,15 @@ func (r *PublishBlogPostRequest) Validate() error {
 		return fmt.Errorf("id is required")
 	}
 
+	if r.Newsletter != nil {
+		if r.SkipNewsletter {
+			return fmt.Errorf("newsletter and skip_newsletter cannot be used together")
+		}
+		if err := r.Newsletter.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -553,6 +781,26 @@ func (r *UnpublishBlogPostRequest) Validate() error {
 	return nil
 }
 
+// ScheduleBlogPostRequest defines the request to schedule the publication of a blog post.
+// A nil ScheduledPublishAt cancels the current schedule.
+type ScheduleBlogPostRequest struct {
+	ID                 string     `json:"id"`
+	ScheduledPublishAt *time.Time `json:"scheduled_publish_at"`
+}
+
+// Validate validates the schedule blog post request
+func (r *ScheduleBlogPostRequest) Validate() error {
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+
+	if r.ScheduledPublishAt != nil && !r.ScheduledPublishAt.After(time.Now()) {
+		return fmt.Errorf("scheduled_publish_at must be in the future")
+	}
+
+	return nil
+}
+
 // GetBlogPostRequest defines the request to get a blog post
 type GetBlogPostRequest struct {
 	ID           string `json:"id,omitempty"`
@@ -576,15 +824,18 @@ const (
 	BlogPostStatusAll       BlogPostStatus = "all"
 	BlogPostStatusDraft     BlogPostStatus = "draft"
 	BlogPostStatusPublished BlogPostStatus = "published"
+	BlogPostStatusScheduled BlogPostStatus = "scheduled" // Drafts with a scheduled publication
 )
 
 // ListBlogPostsRequest defines the request to list blog posts
 type ListBlogPostsRequest struct {
 	CategoryID string         `json:"category_id,omitempty"`
 	Status     BlogPostStatus `json:"status,omitempty"`
-	Page       int            `json:"page,omitempty"`   // Page number (1-indexed)
-	Limit      int            `json:"limit,omitempty"`  // Posts per page
-	Offset     int            `json:"offset,omitempty"` // Calculated from Page
+	Query      string         `json:"query,omitempty"`    // Full-text search, posts are then ranked by relevance
+	Language   string         `json:"language,omitempty"` // Only the posts translated in this language
+	Page       int            `json:"page,omitempty"`     // Page number (1-indexed)
+	Limit      int            `json:"limit,omitempty"`    // Posts per page
+	Offset     int            `json:"offset,omitempty"`   // Calculated from Page
 }
 
 // Validate validates the list blog posts request
@@ -597,12 +848,21 @@ func (r *ListBlogPostsRequest) Validate() error {
 
 	// Validate status
 	switch r.Status {
-	case BlogPostStatusAll, BlogPostStatusDraft, BlogPostStatusPublished:
+	case BlogPostStatusAll, BlogPostStatusDraft, BlogPostStatusPublished, BlogPostStatusScheduled:
 		// Valid
 	default:
 		return fmt.Errorf("invalid status: %s", r.Status)
 	}
 
+	r.Query = strings.TrimSpace(r.Query)
+	if len(r.Query) > MaxBlogSearchQueryLength {
+		return fmt.Errorf("query must be at most %d characters", MaxBlogSearchQueryLength)
+	}
+
+	if r.Language != "" && !IsValidLanguage(r.Language) {
+		return fmt.Errorf("invalid language: %s", r.Language)
+	}
+
 	// Default page to 1 if not specified
 	if r.Page <= 0 {
 		r.Page = 1
@@ -634,6 +894,137 @@ type BlogPostListResponse struct {
 	HasPreviousPage bool        `json:"has_previous_page"`
 }
 
+// MaxBlogSearchQueryLength is the maximum length of a blog search query
+const MaxBlogSearchQueryLength = 200
+
+// BlogSearchRequest defines a full-text search over the published posts of the public blog
+type BlogSearchRequest struct {
+	Query  string `json:"q"`
+	Page   int    `json:"page,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+	Offset int    `json:"offset,omitempty"` // Calculated from Page
+}
+
+// Validate validates the blog search request
+func (r *BlogSearchRequest) Validate() error {
+	r.Query = strings.TrimSpace(r.Query)
+	if r.Query == "" {
+		return fmt.Errorf("query is required")
+	}
+	if len(r.Query) > MaxBlogSearchQueryLength {
+		return fmt.Errorf("query must be at most %d characters", MaxBlogSearchQueryLength)
+	}
+
+	if r.Page <= 0 {
+		r.Page = 1
+	}
+	if r.Limit <= 0 {
+		r.Limit = 10
+	}
+	if r.Limit > 50 {
+		r.Limit = 50
+	}
+	r.Offset = (r.Page - 1) * r.Limit
+
+	return nil
+}
+
+// BlogSearchResult is a post matching a blog search. Highlights are HTML escaped, with
+// the matched words wrapped in <mark> tags.
+type BlogSearchResult struct {
+	Post           *BlogPost `json:"post"`
+	CategorySlug   string    `json:"category_slug"`
+	Rank           float64   `json:"rank"`
+	TitleHighlight string    `json:"title_highlight"`
+	Snippet        string    `json:"snippet"`
+}
+
+// BlogSearchResponse defines the response of a blog search, most relevant posts first
+type BlogSearchResponse struct {
+	Query           string              `json:"query"`
+	Results         []*BlogSearchResult `json:"results"`
+	TotalCount      int                 `json:"total_count"`
+	CurrentPage     int                 `json:"current_page"`
+	TotalPages      int                 `json:"total_pages"`
+	HasNextPage     bool                `json:"has_next_page"`
+	HasPreviousPage bool                `json:"has_previous_page"`
+}
+
+// BlogPostRevisionStatus represents the status of a blog post revision
+type BlogPostRevisionStatus string
+
+const (
+	// BlogPostRevisionStatusDraft is a pending edit of a published post, not yet live
+	BlogPostRevisionStatusDraft BlogPostRevisionStatus = "draft"
+	// BlogPostRevisionStatusApplied is content that has been written to the post
+	BlogPostRevisionStatusApplied BlogPostRevisionStatus = "applied"
+)
+
+// BlogPostRevision is a numbered snapshot of the content of a blog post. Every save of a
+// post records an applied revision; draft revisions hold edits of a published post that
+// can be previewed with their preview token before being promoted.
+type BlogPostRevision struct {
+	PostID       string                 `json:"post_id"`
+	Version      int                    `json:"version"`
+	CategoryID   string                 `json:"category_id"`
+	Slug         string                 `json:"slug"`
+	Settings     BlogPostSettings       `json:"settings"`
+	Status       BlogPostRevisionStatus `json:"status"`
+	PreviewToken string                 `json:"preview_token,omitempty"` // Secret of the /_preview/{token} URL, drafts only
+	RestoredFrom *int                   `json:"restored_from,omitempty"` // Version this revision was restored from
+	CreatedBy    string                 `json:"created_by,omitempty"`
+	CreatedAt    time.Time              `json:"created_at"`
+	AppliedAt    *time.Time             `json:"applied_at,omitempty"`
+}
+
+// NewBlogPostRevision snapshots the current content of a post
+func NewBlogPostRevision(post *BlogPost, status BlogPostRevisionStatus, createdBy string) *BlogPostRevision {
+	revision := &BlogPostRevision{
+		PostID:     post.ID,
+		CategoryID: post.CategoryID,
+		Slug:       post.Slug,
+		Settings:   post.Settings,
+		Status:     status,
+		CreatedBy:  createdBy,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if status == BlogPostRevisionStatusApplied {
+		appliedAt := revision.CreatedAt
+		revision.AppliedAt = &appliedAt
+	}
+	return revision
+}
+
+// ApplyTo copies the content of the revision to the post
+func (r *BlogPostRevision) ApplyTo(post *BlogPost) {
+	post.CategoryID = r.CategoryID
+	post.Slug = r.Slug
+
+	// The newsletter is not part of the content, keep the one already sent
+	newsletterBroadcastID := post.Settings.NewsletterBroadcastID
+	post.Settings = r.Settings
+	post.Settings.NewsletterBroadcastID = newsletterBroadcastID
+}
+
+// BlogPostRevisionRequest identifies a revision of a blog post to promote or restore
+type BlogPostRevisionRequest struct {
+	ID      string `json:"id"`      // Post ID
+	Version int    `json:"version"` // Revision version
+}
+
+// Validate validates the blog post revision request
+func (r *BlogPostRevisionRequest) Validate() error {
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+
+	if r.Version <= 0 {
+		return fmt.Errorf("version must be positive")
+	}
+
+	return nil
+}
+
 // BlogCategoryRepository defines the data access layer for blog categories
 type BlogCategoryRepository interface {
 	CreateCategory(ctx context.Context, category *BlogCategory) error
@@ -659,23 +1050,41 @@ type BlogPostRepository interface {
 	GetPost(ctx context.Context, id string) (*BlogPost, error)
 	GetPostBySlug(ctx context.Context, slug string) (*BlogPost, error)
 	GetPostByCategoryAndSlug(ctx context.Context, categorySlug, postSlug string) (*BlogPost, error)
+	// GetPostByTranslationSlug returns the post of a category translated in a language
+	// with the given slug
+	GetPostByTranslationSlug(ctx context.Context, categoryID, language, slug string) (*BlogPost, error)
 	UpdatePost(ctx context.Context, post *BlogPost) error
 	DeletePost(ctx context.Context, id string) error
 	ListPosts(ctx context.Context, params ListBlogPostsRequest) (*BlogPostListResponse, error)
 	// ListFeedPosts returns the top `limit` published posts (newest first) for
 	// RSS/JSON syndication. Enforces deleted_at IS NULL AND published_at IS NOT
 	// NULL AND published_at <= NOW() — the filter mirrors GetFeedFingerprint so
-	// the cheap 304 path always agrees with the rendered feed body.
-	ListFeedPosts(ctx context.Context, categorySlug *string, limit int) ([]*BlogPost, error)
+	// the cheap 304 path always agrees with the rendered feed body. A
+	// non-empty language keeps only the posts translated in that language.
+	ListFeedPosts(ctx context.Context, language string, categorySlug *string, limit int) ([]*BlogPost, error)
 	// GetFeedFingerprint returns the inputs needed to compute a feed ETag
 	// without materializing items. maxUpdatedAt is GREATEST(post.updated_at,
 	// category.updated_at) so category renames invalidate the cache even
 	// though they don't touch post rows. idsHash detects deletes/replacements
 	// that preserve the timestamp.
-	GetFeedFingerprint(ctx context.Context, categorySlug *string, limit int) (maxUpdatedAt time.Time, idsHash string, err error)
+	GetFeedFingerprint(ctx context.Context, language string, categorySlug *string, limit int) (maxUpdatedAt time.Time, idsHash string, err error)
 	PublishPost(ctx context.Context, id string, publishedAt *time.Time) error
 	UnpublishPost(ctx context.Context, id string) error
 
+	// Full-text search
+	// SearchPosts returns the published posts matching a search, most relevant first
+	SearchPosts(ctx context.Context, params BlogSearchRequest) (*BlogSearchResponse, error)
+	// UpdatePostSearchIndex indexes the title, excerpt and body text of a post with the
+	// given Postgres text search configuration
+	UpdatePostSearchIndex(ctx context.Context, id, searchConfig, bodyText string) error
+
+	// Revisions
+	CreateRevision(ctx context.Context, revision *BlogPostRevision) error
+	GetRevision(ctx context.Context, postID string, version int) (*BlogPostRevision, error)
+	GetRevisionByPreviewToken(ctx context.Context, token string) (*BlogPostRevision, error)
+	// ListRevisions returns the revisions of a post, newest first
+	ListRevisions(ctx context.Context, postID string) ([]*BlogPostRevision, error)
+
 	// Transaction management
 	WithTransaction(ctx context.Context, workspaceID string, fn func(*sql.Tx) error) error
 	CreatePostTx(ctx context.Context, tx *sql.Tx, post *BlogPost) error
@@ -686,6 +1095,8 @@ type BlogPostRepository interface {
 	DeletePostsByCategoryIDTx(ctx context.Context, tx *sql.Tx, categoryID string) (int64, error)
 	PublishPostTx(ctx context.Context, tx *sql.Tx, id string, publishedAt *time.Time) error
 	UnpublishPostTx(ctx context.Context, tx *sql.Tx, id string) error
+	CreateRevisionTx(ctx context.Context, tx *sql.Tx, revision *BlogPostRevision) error
+	MarkRevisionAppliedTx(ctx context.Context, tx *sql.Tx, postID string, version int) error
 }
 
 // BlogService defines the business logic layer for blog operations
@@ -708,11 +1119,20 @@ type BlogService interface {
 	ListPosts(ctx context.Context, params *ListBlogPostsRequest) (*BlogPostListResponse, error)
 	PublishPost(ctx context.Context, request *PublishBlogPostRequest) error
 	UnpublishPost(ctx context.Context, request *UnpublishBlogPostRequest) error
-
-	// Public operations (no auth required)
-	GetPublicCategoryBySlug(ctx context.Context, slug string) (*BlogCategory, error)
-	GetPublicPostByCategoryAndSlug(ctx context.Context, categorySlug, postSlug string) (*BlogPost, error)
+	SchedulePost(ctx context.Context, request *ScheduleBlogPostRequest) (*BlogPost, error)
+
+	// Post revision operations
+	SaveDraftRevision(ctx context.Context, request *UpdateBlogPostRequest) (*BlogPostRevision, error)
+	ListPostRevisions(ctx context.Context, postID string) ([]*BlogPostRevision, error)
+	PromoteRevision(ctx context.Context, request *BlogPostRevisionRequest) (*BlogPost, error)
+	RestoreRevision(ctx context.Context, request *BlogPostRevisionRequest) (*BlogPost, error)
+
+	// Public operations (no auth required). The language is a translation language of
+	// the workspace, with localized slugs, or empty for the default language.
+	GetPublicCategoryBySlug(ctx context.Context, language, slug string) (*BlogCategory, error)
+	GetPublicPostByCategoryAndSlug(ctx context.Context, language, categorySlug, postSlug string) (*BlogPost, error)
 	ListPublicPosts(ctx context.Context, params *ListBlogPostsRequest) (*BlogPostListResponse, error)
+	SearchPublicPosts(ctx context.Context, params *BlogSearchRequest) (*BlogSearchResponse, error)
 
 	// Theme operations
 	CreateTheme(ctx context.Context, request *CreateBlogThemeRequest) (*BlogTheme, error)
@@ -723,9 +1143,16 @@ type BlogService interface {
 	ListThemes(ctx context.Context, params *ListBlogThemesRequest) (*BlogThemeListResponse, error)
 
 	// Blog page rendering (public, no auth	// Rendering
-	RenderHomePage(ctx context.Context, workspaceID string, page int, themeVersion *int) (string, error)
-	RenderPostPage(ctx context.Context, workspaceID, categorySlug, postSlug string, themeVersion *int) (string, error)
-	RenderCategoryPage(ctx context.Context, workspaceID, categorySlug string, page int, themeVersion *int) (string, error)
+	RenderHomePage(ctx context.Context, workspaceID, language string, page int, themeVersion *int) (string, error)
+	RenderPostPage(ctx context.Context, workspaceID, language, categorySlug, postSlug string, themeVersion *int) (string, error)
+	RenderCategoryPage(ctx context.Context, workspaceID, language, categorySlug string, page int, themeVersion *int) (string, error)
+	// RenderPostPreview renders the post page with the content of the draft revision
+	// matching the preview token
+	RenderPostPreview(ctx context.Context, workspaceID, previewToken string, themeVersion *int) (string, error)
+	// RenderSearchPage renders the search page with the posts matching the query, if any
+	RenderSearchPage(ctx context.Context, workspaceID, query string, page int, themeVersion *int) (string, error)
+	// RequestBlogAccess emails a magic link to a gated post to the subscribers of its gate list
+	RequestBlogAccess(ctx context.Context, workspaceID string, request *BlogAccessRequest) error
 
 	// Feed rendering — returns post body HTML prepared for RSS/JSON Feed
 	// emission (no theme chrome, absolute URLs, XSS-sanitized).
@@ -735,12 +1162,12 @@ type BlogService interface {
 	// (optionally filtered to a category). Callers should call
 	// GetFeedFingerprint first and short-circuit 304 responses before
 	// invoking BuildFeed, which renders post bodies.
-	BuildFeed(ctx context.Context, workspaceID string, categorySlug *string) (*BlogFeed, error)
+	BuildFeed(ctx context.Context, workspaceID, language string, categorySlug *string) (*BlogFeed, error)
 
 	// GetFeedFingerprint computes the ETag inputs for the cheap conditional-GET
 	// path. It does not render post bodies. The second return is a short hex
 	// ETag suitable for emitting in the HTTP response.
-	GetFeedFingerprint(ctx context.Context, workspaceID string, categorySlug *string) (maxUpdatedAt time.Time, etag string, err error)
+	GetFeedFingerprint(ctx context.Context, workspaceID, language string, categorySlug *string) (maxUpdatedAt time.Time, etag string, err error)
 }
 
 // NormalizeSlug normalizes a string to be a valid slug
@@ -777,6 +1204,7 @@ const (
 	BlogThemeFileTypeHome     BlogThemeFileType = "home"
 	BlogThemeFileTypeCategory BlogThemeFileType = "category"
 	BlogThemeFileTypePost     BlogThemeFileType = "post"
+	BlogThemeFileTypeSearch   BlogThemeFileType = "search"
 	BlogThemeFileTypeHeader   BlogThemeFileType = "header"
 	BlogThemeFileTypeFooter   BlogThemeFileType = "footer"
 	BlogThemeFileTypeShared   BlogThemeFileType = "shared"
@@ -787,6 +1215,7 @@ type BlogThemeFiles struct {
 	HomeLiquid     string `json:"home.liquid"`
 	CategoryLiquid string `json:"category.liquid"`
 	PostLiquid     string `json:"post.liquid"`
+	SearchLiquid   string `json:"search.liquid"` // Optional, the search page falls back to home.liquid
 	HeaderLiquid   string `json:"header.liquid"`
 	FooterLiquid   string `json:"footer.liquid"`
 	SharedLiquid   string `json:"shared.liquid"`
@@ -979,6 +1408,24 @@ type BlogTemplateDataRequest struct {
 	ThemeVersion   int                   // Theme version number for cache-busting
 	CustomData     MapOfAny              // Optional additional data
 	PaginationData *BlogPostListResponse // Pagination metadata (optional, for paginated pages)
+	Language       string                // Translation language of the page, empty for the default language
+	Languages      []BlogLanguageLink    // The page in the languages of the workspace, for language switchers
+}
+
+// BlogLanguageLink is the URL of a blog page in one of the workspace languages
+type BlogLanguageLink struct {
+	Code    string
+	URL     string
+	Current bool
+}
+
+// BlogLanguagePrefix returns the path prefix of the blog pages in a language: none for
+// the default language, "/{language}" for translations
+func BlogLanguagePrefix(language string) string {
+	if language == "" {
+		return ""
+	}
+	return "/" + language
 }
 
 // BuildBlogTemplateData creates a template data map for blog Liquid templates
@@ -1058,7 +1505,32 @@ func BuildBlogTemplateData(req BlogTemplateDataRequest) (MapOfAny, error) {
 			baseURL = req.Workspace.Settings.WebsiteURL
 		}
 	}
-	templateData["base_url"] = baseURL
+	// Links of translated pages stay in their language
+	templateData["base_url"] = baseURL + BlogLanguagePrefix(req.Language)
+
+	// Add the language of the page and its versions in the other languages
+	languageCode := req.Language
+	if languageCode == "" {
+		languageCode = DefaultLanguageCode
+		if req.Workspace != nil && req.Workspace.Settings.DefaultLanguage != "" {
+			languageCode = req.Workspace.Settings.DefaultLanguage
+		}
+	}
+	templateData["language"] = MapOfAny{
+		"code":       languageCode,
+		"name":       SupportedLanguages[languageCode],
+		"is_default": req.Language == "",
+	}
+	languagesData := make([]map[string]interface{}, 0, len(req.Languages))
+	for _, link := range req.Languages {
+		languagesData = append(languagesData, map[string]interface{}{
+			"code":    link.Code,
+			"name":    SupportedLanguages[link.Code],
+			"url":     link.URL,
+			"current": link.Current,
+		})
+	}
+	templateData["languages"] = languagesData
 
 	// Add post data (for post pages)
 	if req.Post != nil {
@@ -1087,6 +1559,9 @@ func BuildBlogTemplateData(req BlogTemplateDataRequest) (MapOfAny, error) {
 			"authors":              authorsData,
 			"reading_time_minutes": req.Post.Settings.ReadingTimeMinutes,
 		}
+		if req.Workspace != nil {
+			postData["comments_enabled"] = req.Post.CommentsEnabled(req.Workspace.Settings.BlogSettings)
+		}
 
 		// Add SEO data if available
 		if req.Post.Settings.SEO != nil {
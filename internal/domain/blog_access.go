@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Notifuse/notifuse/pkg/crypto"
+	"github.com/asaskevich/govalidator"
+)
+
+// BlogAccessCookieName is the cookie carrying the signed access token of the subscribers
+// of gated posts
+const BlogAccessCookieName = "notifuse_blog_access"
+
+// BlogAccessLinkTTL is how long the magic links granting access to gated posts are valid
+const BlogAccessLinkTTL = 1 * time.Hour
+
+// BlogGate restricts the body of posts to the contacts subscribed to a list. Set on a
+// category, it gates every post of the category; set on a post, it takes precedence over
+// the gate of its category.
+type BlogGate struct {
+	ListID string `json:"list_id"`
+}
+
+// Validate validates the gate
+func (g *BlogGate) Validate() error {
+	if g == nil {
+		return nil
+	}
+	if g.ListID == "" {
+		return fmt.Errorf("gate list_id is required")
+	}
+	return nil
+}
+
+// EffectiveGate returns the gate restricting the post: its own, or the one of its category
+func (p *BlogPost) EffectiveGate(category *BlogCategory) *BlogGate {
+	if p.Settings.Gate != nil {
+		return p.Settings.Gate
+	}
+	if category != nil {
+		return category.Settings.Gate
+	}
+	return nil
+}
+
+// BlogAccessSettings configures the access of returning subscribers to gated posts
+type BlogAccessSettings struct {
+	// MagicLinkNotificationID is the transactional notification sending the magic link
+	// that grants access to gated posts. The link is available to its template as
+	// {{ blog_access_url }}.
+	MagicLinkNotificationID string `json:"magic_link_notification_id,omitempty"`
+	CookieDays              int    `json:"cookie_days,omitempty"` // Lifetime of the access cookie (default: 30)
+}
+
+// GetCookieDays returns the lifetime of the access cookie in days
+func (s *BlogAccessSettings) GetCookieDays() int {
+	if s == nil || s.CookieDays < 1 {
+		return 30
+	}
+	return s.CookieDays
+}
+
+// Validate validates the access settings
+func (s *BlogAccessSettings) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.CookieDays < 0 || s.CookieDays > 365 {
+		return fmt.Errorf("access cookie_days must be between 0 and 365")
+	}
+	return nil
+}
+
+// BlogViewerKey is the context key carrying the BlogViewer of a blog page request
+const BlogViewerKey ContextKey = "blog_viewer"
+
+// BlogViewer is a visitor of the blog identified by a valid access cookie
+type BlogViewer struct {
+	Email string
+}
+
+// WithBlogViewer returns a copy of ctx carrying the given blog viewer
+func WithBlogViewer(ctx context.Context, viewer *BlogViewer) context.Context {
+	return context.WithValue(ctx, BlogViewerKey, viewer)
+}
+
+// BlogViewerFromContext returns the blog viewer stored in ctx, nil for anonymous visitors
+func BlogViewerFromContext(ctx context.Context) *BlogViewer {
+	viewer, _ := ctx.Value(BlogViewerKey).(*BlogViewer)
+	return viewer
+}
+
+// blogAccessSignature signs an email and an expiry with the workspace secret key
+func blogAccessSignature(email string, expiresAt int64, secretKey string) string {
+	return crypto.ComputeHMAC256([]byte(fmt.Sprintf("blog_access:%s:%d", email, expiresAt)), secretKey)
+}
+
+// SignBlogAccessToken returns a token proving the ownership of an email until expiresAt,
+// used by the magic links and the access cookie of gated posts
+func SignBlogAccessToken(email, secretKey string, expiresAt time.Time) string {
+	expiry := expiresAt.Unix()
+	return strings.Join([]string{
+		base64.RawURLEncoding.EncodeToString([]byte(email)),
+		strconv.FormatInt(expiry, 10),
+		blogAccessSignature(email, expiry, secretKey),
+	}, ".")
+}
+
+// VerifyBlogAccessToken returns the email of a valid and unexpired access token
+func VerifyBlogAccessToken(token, secretKey string, now time.Time) (string, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", false
+	}
+
+	emailBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", false
+	}
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || now.Unix() >= expiry {
+		return "", false
+	}
+
+	email := string(emailBytes)
+	if !hmac.Equal([]byte(blogAccessSignature(email, expiry, secretKey)), []byte(parts[2])) {
+		return "", false
+	}
+	return email, true
+}
+
+// BlogAccessRequest asks for a magic link to the gated post of a returning subscriber
+type BlogAccessRequest struct {
+	Email  string `json:"email"`
+	PostID string `json:"post_id"`
+}
+
+// Validate validates and normalizes the access request
+func (r *BlogAccessRequest) Validate() error {
+	r.Email = strings.ToLower(strings.TrimSpace(r.Email))
+	if r.PostID == "" {
+		return NewValidationError("post_id is required")
+	}
+	if !govalidator.IsEmail(r.Email) {
+		return NewValidationError("a valid email is required")
+	}
+	return nil
+}
+
+// IsSafeBlogRedirectPath returns true for the paths of the blog magic links may redirect
+// to, rejecting absolute and protocol-relative URLs. Control characters are rejected too,
+// as browsers strip tabs and newlines from URLs: "/\t/evil.com" would lead to //evil.com.
+func IsSafeBlogRedirectPath(path string) bool {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.Contains(path, "\\") {
+		return false
+	}
+	if strings.IndexFunc(path, unicode.IsControl) >= 0 {
+		return false
+	}
+	parsed, err := url.Parse(path)
+	return err == nil && parsed.Scheme == "" && parsed.Host == ""
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlogAccessToken(t *testing.T) {
+	now := time.Now()
+	token := SignBlogAccessToken("reader@example.com", "secret", now.Add(time.Hour))
+
+	email, ok := VerifyBlogAccessToken(token, "secret", now)
+	require.True(t, ok)
+	assert.Equal(t, "reader@example.com", email)
+
+	_, ok = VerifyBlogAccessToken(token, "secret", now.Add(2*time.Hour))
+	assert.False(t, ok, "expired token")
+
+	_, ok = VerifyBlogAccessToken(token, "other-secret", now)
+	assert.False(t, ok, "token of another workspace")
+
+	forged := SignBlogAccessToken("attacker@example.com", "secret", now.Add(time.Hour))
+	_, ok = VerifyBlogAccessToken(forged[:len(forged)-1]+"0", "secret", now)
+	assert.False(t, ok, "tampered signature")
+
+	for _, invalid := range []string{"", "abc", "a.b.c", "!!.123.sig"} {
+		_, ok = VerifyBlogAccessToken(invalid, "secret", now)
+		assert.False(t, ok, invalid)
+	}
+}
+
+func TestBlogPost_EffectiveGate(t *testing.T) {
+	categoryGate := &BlogGate{ListID: "members"}
+	postGate := &BlogGate{ListID: "vip"}
+	category := &BlogCategory{Settings: BlogCategorySettings{Gate: categoryGate}}
+
+	post := &BlogPost{}
+	assert.Nil(t, post.EffectiveGate(nil))
+	assert.Nil(t, post.EffectiveGate(&BlogCategory{}))
+	assert.Equal(t, categoryGate, post.EffectiveGate(category))
+
+	post.Settings.Gate = postGate
+	assert.Equal(t, postGate, post.EffectiveGate(category))
+}
+
+func TestBlogAccessSettings_Validate(t *testing.T) {
+	var nilSettings *BlogAccessSettings
+	assert.NoError(t, nilSettings.Validate())
+	assert.Equal(t, 30, nilSettings.GetCookieDays())
+
+	assert.NoError(t, (&BlogAccessSettings{CookieDays: 90}).Validate())
+	assert.Equal(t, 90, (&BlogAccessSettings{CookieDays: 90}).GetCookieDays())
+	assert.Error(t, (&BlogAccessSettings{CookieDays: 366}).Validate())
+
+	blogSettings := &BlogSettings{Access: &BlogAccessSettings{CookieDays: -1}}
+	assert.Error(t, blogSettings.Validate())
+
+	assert.NoError(t, (*BlogGate)(nil).Validate())
+	assert.Error(t, (&BlogGate{}).Validate())
+}
+
+func TestBlogAccessRequest_Validate(t *testing.T) {
+	req := &BlogAccessRequest{Email: " Reader@Example.com ", PostID: "post-1"}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "reader@example.com", req.Email)
+
+	assert.IsType(t, ValidationError{}, (&BlogAccessRequest{Email: "reader@example.com"}).Validate())
+	assert.IsType(t, ValidationError{}, (&BlogAccessRequest{Email: "reader", PostID: "post-1"}).Validate())
+}
+
+func TestBlogViewerFromContext(t *testing.T) {
+	assert.Nil(t, BlogViewerFromContext(context.Background()))
+
+	ctx := WithBlogViewer(context.Background(), &BlogViewer{Email: "reader@example.com"})
+	require.NotNil(t, BlogViewerFromContext(ctx))
+	assert.Equal(t, "reader@example.com", BlogViewerFromContext(ctx).Email)
+}
+
+func TestIsSafeBlogRedirectPath(t *testing.T) {
+	assert.True(t, IsSafeBlogRedirectPath("/premium/secret-plan"))
+	assert.True(t, IsSafeBlogRedirectPath("/"))
+	assert.False(t, IsSafeBlogRedirectPath(""))
+	assert.False(t, IsSafeBlogRedirectPath("https://evil.example.com"))
+	assert.False(t, IsSafeBlogRedirectPath("//evil.example.com"))
+	assert.False(t, IsSafeBlogRedirectPath("/\\evil.example.com"))
+	// browsers strip tabs and newlines, these would become //evil.example.com
+	assert.False(t, IsSafeBlogRedirectPath("/\t/evil.example.com"))
+	assert.False(t, IsSafeBlogRedirectPath("/\n/evil.example.com"))
+	assert.False(t, IsSafeBlogRedirectPath("/\r\n/evil.example.com"))
+	assert.False(t, IsSafeBlogRedirectPath("/\x00/evil.example.com"))
+	assert.True(t, IsSafeBlogRedirectPath("/premium/secret-plan?ref=email#intro"))
+}
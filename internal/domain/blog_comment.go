@@ -0,0 +1,367 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+)
+
+//go:generate mockgen -destination mocks/mock_blog_comment_service.go -package mocks github.com/Notifuse/notifuse/internal/domain BlogCommentService
+//go:generate mockgen -destination mocks/mock_blog_comment_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain BlogCommentRepository
+
+// BlogCommentStatus is the moderation status of a blog comment
+type BlogCommentStatus string
+
+const (
+	// BlogCommentStatusUnverified comments wait for their author to confirm their email
+	// with the magic link sent to them
+	BlogCommentStatusUnverified BlogCommentStatus = "unverified"
+	BlogCommentStatusPending    BlogCommentStatus = "pending"
+	BlogCommentStatusApproved   BlogCommentStatus = "approved"
+	BlogCommentStatusRejected   BlogCommentStatus = "rejected"
+	BlogCommentStatusSpam       BlogCommentStatus = "spam"
+)
+
+// IsValid returns true for the known comment statuses
+func (s BlogCommentStatus) IsValid() bool {
+	switch s {
+	case BlogCommentStatusUnverified, BlogCommentStatusPending, BlogCommentStatusApproved,
+		BlogCommentStatusRejected, BlogCommentStatusSpam:
+		return true
+	}
+	return false
+}
+
+// BlogCommentModeration is how the comments of verified contacts are published
+type BlogCommentModeration string
+
+const (
+	// BlogCommentModerationManual keeps every comment in the moderation queue until approved
+	BlogCommentModerationManual BlogCommentModeration = "manual"
+	// BlogCommentModerationAuto publishes the comments of verified contacts not flagged as spam
+	BlogCommentModerationAuto BlogCommentModeration = "auto"
+)
+
+// BlogCommentHoneypotField is the hidden input of comment forms. Humans never see it, a
+// comment filling it is flagged as spam.
+const BlogCommentHoneypotField = "website"
+
+// Spam reasons recorded on the comments flagged by the spam heuristics
+const (
+	BlogCommentSpamHoneypot        = "honeypot"
+	BlogCommentSpamTooManyLinks    = "too_many_links"
+	BlogCommentSpamBlockedWord     = "blocked_word"
+	BlogCommentSpamDisposableEmail = "disposable_email"
+	BlogCommentSpamDuplicate       = "duplicate"
+)
+
+const (
+	maxBlogCommentLength     = 5000
+	maxBlogCommentNameLength = 100
+	maxBlogCommentLinks      = 2
+	maxBlogCommentPageSize   = 100
+)
+
+// BlogReactionTypes are the reactions readers can leave on a post
+var BlogReactionTypes = []string{"like", "love", "insightful", "celebrate"}
+
+// IsValidBlogReaction returns true for the supported reaction types
+func IsValidBlogReaction(reaction string) bool {
+	for _, r := range BlogReactionTypes {
+		if r == reaction {
+			return true
+		}
+	}
+	return false
+}
+
+// BlogCommentSettings configures the comments and reactions of the blog posts
+type BlogCommentSettings struct {
+	Enabled    bool                  `json:"enabled"`
+	Moderation BlogCommentModeration `json:"moderation,omitempty"` // "manual" (default) or "auto"
+	// VerificationNotificationID is the transactional notification sending the magic link
+	// that confirms the email of commenters not identified with an email_hmac. The link
+	// is available to its template as {{ comment_verification_url }}.
+	VerificationNotificationID string   `json:"verification_notification_id,omitempty"`
+	BlockedWords               []string `json:"blocked_words,omitempty"`
+	MaxLinks                   int      `json:"max_links,omitempty"` // Links allowed in a comment (default: 2)
+}
+
+// GetMaxLinks returns the number of links allowed in a comment
+func (s *BlogCommentSettings) GetMaxLinks() int {
+	if s == nil || s.MaxLinks < 1 {
+		return maxBlogCommentLinks
+	}
+	return s.MaxLinks
+}
+
+// Validate validates the comment settings
+func (s *BlogCommentSettings) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.Moderation != "" && s.Moderation != BlogCommentModerationManual && s.Moderation != BlogCommentModerationAuto {
+		return fmt.Errorf("comments moderation must be 'manual' or 'auto'")
+	}
+	if s.MaxLinks < 0 || s.MaxLinks > 20 {
+		return fmt.Errorf("comments max_links must be between 0 and 20")
+	}
+	if len(s.BlockedWords) > 500 {
+		return fmt.Errorf("comments blocked_words cannot have more than 500 entries")
+	}
+	return nil
+}
+
+// CommentsEnabled returns true when readers can comment and react on a post
+func (p *BlogPost) CommentsEnabled(settings *BlogSettings) bool {
+	return settings != nil && settings.Comments != nil && settings.Comments.Enabled && !p.Settings.CommentsDisabled
+}
+
+// BlogComment is a comment left on a blog post by a contact
+type BlogComment struct {
+	ID          string            `json:"id"`
+	PostID      string            `json:"post_id"`
+	ParentID    *string           `json:"parent_id,omitempty"` // Comment replied to
+	Email       string            `json:"email"`
+	Name        string            `json:"name"`
+	Content     string            `json:"content"`
+	Status      BlogCommentStatus `json:"status"`
+	SpamReasons []string          `json:"spam_reasons,omitempty"`
+	IPAddress   string            `json:"ip_address,omitempty"`
+	UserAgent   string            `json:"user_agent,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	// ApprovedAt is set the first time the comment is approved, which records the
+	// blog.commented event on the timeline of the contact
+	ApprovedAt *time.Time `json:"approved_at,omitempty"`
+}
+
+// PublicBlogComment is a comment as shown to the readers of the blog, without the
+// email of its author
+type PublicBlogComment struct {
+	ID        string            `json:"id"`
+	ParentID  *string           `json:"parent_id,omitempty"`
+	Name      string            `json:"name"`
+	Content   string            `json:"content"`
+	Status    BlogCommentStatus `json:"status"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// Public returns the comment as shown to the readers of the blog
+func (c *BlogComment) Public() *PublicBlogComment {
+	return &PublicBlogComment{
+		ID:        c.ID,
+		ParentID:  c.ParentID,
+		Name:      c.Name,
+		Content:   c.Content,
+		Status:    c.Status,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+// BlogPostReaction is the reaction of a contact to a blog post
+type BlogPostReaction struct {
+	PostID    string    `json:"post_id"`
+	Email     string    `json:"email"`
+	Reaction  string    `json:"reaction"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateBlogCommentRequest is a comment submitted on the public blog. Commenters are
+// identified by the email_hmac of the links sent to contacts; without one, the comment
+// waits for the confirmation of the email through a magic link.
+type CreateBlogCommentRequest struct {
+	PostID    string  `json:"post_id"`
+	ParentID  *string `json:"parent_id,omitempty"`
+	Email     string  `json:"email"`
+	EmailHMAC string  `json:"email_hmac,omitempty"`
+	Name      string  `json:"name"`
+	Content   string  `json:"content"`
+	Honeypot  string  `json:"website,omitempty"`
+	IPAddress string  `json:"-"`
+	UserAgent string  `json:"-"`
+}
+
+// Validate validates and normalizes the comment request
+func (r *CreateBlogCommentRequest) Validate() error {
+	r.Email = strings.ToLower(strings.TrimSpace(r.Email))
+	r.Name = strings.TrimSpace(r.Name)
+	r.Content = strings.TrimSpace(r.Content)
+
+	if r.PostID == "" {
+		return NewValidationError("post_id is required")
+	}
+	if r.ParentID != nil && *r.ParentID == "" {
+		r.ParentID = nil
+	}
+	if !govalidator.IsEmail(r.Email) {
+		return NewValidationError("a valid email is required")
+	}
+	if r.Name == "" {
+		return NewValidationError("name is required")
+	}
+	if len(r.Name) > maxBlogCommentNameLength {
+		return NewValidationError(fmt.Sprintf("name must be less than %d characters", maxBlogCommentNameLength))
+	}
+	if r.Content == "" {
+		return NewValidationError("content is required")
+	}
+	if len(r.Content) > maxBlogCommentLength {
+		return NewValidationError(fmt.Sprintf("content must be less than %d characters", maxBlogCommentLength))
+	}
+	return nil
+}
+
+// VerifyBlogCommentRequest confirms the email of the author of a comment, from the
+// magic link sent to them
+type VerifyBlogCommentRequest struct {
+	CommentID string `json:"comment_id"`
+	Email     string `json:"email"`
+	EmailHMAC string `json:"email_hmac"`
+}
+
+// Validate validates the verification request
+func (r *VerifyBlogCommentRequest) Validate() error {
+	if r.CommentID == "" {
+		return NewValidationError("comment_id is required")
+	}
+	if r.Email == "" || r.EmailHMAC == "" {
+		return NewValidationError("email and email_hmac are required")
+	}
+	return nil
+}
+
+// BlogReactionRequest adds or removes the reaction of a contact to a post
+type BlogReactionRequest struct {
+	PostID    string `json:"post_id"`
+	Reaction  string `json:"reaction"`
+	Email     string `json:"email"`
+	EmailHMAC string `json:"email_hmac"`
+	Remove    bool   `json:"remove,omitempty"`
+}
+
+// Validate validates the reaction request
+func (r *BlogReactionRequest) Validate() error {
+	if r.PostID == "" {
+		return NewValidationError("post_id is required")
+	}
+	if !IsValidBlogReaction(r.Reaction) {
+		return NewValidationError(fmt.Sprintf("reaction must be one of: %s", strings.Join(BlogReactionTypes, ", ")))
+	}
+	if r.Email == "" || r.EmailHMAC == "" {
+		return NewValidationError("email and email_hmac are required")
+	}
+	return nil
+}
+
+// PublicBlogCommentsResponse holds the approved comments of a post and its reaction counts
+type PublicBlogCommentsResponse struct {
+	Comments  []*PublicBlogComment `json:"comments"`
+	Reactions map[string]int       `json:"reactions"`
+}
+
+// ListBlogCommentsRequest lists the comments of the moderation queue
+type ListBlogCommentsRequest struct {
+	Status BlogCommentStatus `json:"status,omitempty"` // Defaults to pending
+	PostID string            `json:"post_id,omitempty"`
+	Limit  int               `json:"limit,omitempty"`
+	Offset int               `json:"offset,omitempty"`
+}
+
+// Validate validates the list request and applies its defaults
+func (r *ListBlogCommentsRequest) Validate() error {
+	if r.Status == "" {
+		r.Status = BlogCommentStatusPending
+	}
+	if !r.Status.IsValid() {
+		return fmt.Errorf("invalid status: %s", r.Status)
+	}
+	if r.Limit <= 0 {
+		r.Limit = 50
+	}
+	if r.Limit > maxBlogCommentPageSize {
+		r.Limit = maxBlogCommentPageSize
+	}
+	if r.Offset < 0 {
+		r.Offset = 0
+	}
+	return nil
+}
+
+// BlogCommentListResponse is a page of the moderation queue
+type BlogCommentListResponse struct {
+	Comments   []*BlogComment `json:"comments"`
+	TotalCount int            `json:"total_count"`
+}
+
+// ModerateBlogCommentRequest approves a comment, or rejects it or marks it as spam
+type ModerateBlogCommentRequest struct {
+	ID     string            `json:"id"`
+	Status BlogCommentStatus `json:"status"`
+}
+
+// Validate validates the moderation request
+func (r *ModerateBlogCommentRequest) Validate() error {
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	switch r.Status {
+	case BlogCommentStatusApproved, BlogCommentStatusRejected, BlogCommentStatusSpam:
+		return nil
+	}
+	return fmt.Errorf("status must be 'approved', 'rejected' or 'spam'")
+}
+
+// DeleteBlogCommentRequest deletes a comment and its replies
+type DeleteBlogCommentRequest struct {
+	ID string `json:"id"`
+}
+
+// Validate validates the delete request
+func (r *DeleteBlogCommentRequest) Validate() error {
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	return nil
+}
+
+// BlogCommentRepository defines the data access layer for blog comments and reactions
+type BlogCommentRepository interface {
+	CreateComment(ctx context.Context, comment *BlogComment) error
+	GetComment(ctx context.Context, id string) (*BlogComment, error)
+	UpdateComment(ctx context.Context, comment *BlogComment) error
+	// DeleteComment deletes a comment and its replies
+	DeleteComment(ctx context.Context, id string) error
+	ListComments(ctx context.Context, params ListBlogCommentsRequest) (*BlogCommentListResponse, error)
+	// ListApprovedComments returns the approved comments of a post, oldest first
+	ListApprovedComments(ctx context.Context, postID string) ([]*BlogComment, error)
+	// CountDuplicateComments counts the comments of an author with the same content since a time
+	CountDuplicateComments(ctx context.Context, email, content string, since time.Time) (int, error)
+
+	AddReaction(ctx context.Context, reaction *BlogPostReaction) error
+	RemoveReaction(ctx context.Context, postID, email, reaction string) error
+	// CountReactions returns the number of reactions of a post by reaction type
+	CountReactions(ctx context.Context, postID string) (map[string]int, error)
+
+	// DeleteForEmail deletes the comments, with their replies, and the reactions of an author
+	DeleteForEmail(ctx context.Context, workspaceID, email string) error
+}
+
+// BlogCommentService defines the business logic layer for blog comments and reactions
+type BlogCommentService interface {
+	// Moderation (console)
+	ListComments(ctx context.Context, params *ListBlogCommentsRequest) (*BlogCommentListResponse, error)
+	ModerateComment(ctx context.Context, request *ModerateBlogCommentRequest) (*BlogComment, error)
+	DeleteComment(ctx context.Context, request *DeleteBlogCommentRequest) error
+
+	// Public operations (no auth required)
+	ListPublicComments(ctx context.Context, workspaceID, postID string) (*PublicBlogCommentsResponse, error)
+	CreatePublicComment(ctx context.Context, workspaceID string, request *CreateBlogCommentRequest) (*BlogComment, error)
+	// VerifyPublicComment confirms the email of the author of a comment and returns the
+	// URL of the post it was left on
+	VerifyPublicComment(ctx context.Context, workspaceID string, request *VerifyBlogCommentRequest) (string, error)
+	ReactToPost(ctx context.Context, workspaceID string, request *BlogReactionRequest) (map[string]int, error)
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlogCommentSettings_Validate(t *testing.T) {
+	var nilSettings *BlogCommentSettings
+	assert.NoError(t, nilSettings.Validate())
+	assert.Equal(t, 2, nilSettings.GetMaxLinks())
+
+	assert.NoError(t, (&BlogCommentSettings{Enabled: true, Moderation: BlogCommentModerationAuto, MaxLinks: 5}).Validate())
+	assert.Error(t, (&BlogCommentSettings{Moderation: "everything"}).Validate())
+	assert.Error(t, (&BlogCommentSettings{MaxLinks: 21}).Validate())
+	assert.Equal(t, 5, (&BlogCommentSettings{MaxLinks: 5}).GetMaxLinks())
+
+	blogSettings := &BlogSettings{Comments: &BlogCommentSettings{Moderation: "everything"}}
+	assert.Error(t, blogSettings.Validate())
+}
+
+func TestBlogPost_CommentsEnabled(t *testing.T) {
+	post := &BlogPost{}
+	enabled := &BlogSettings{Comments: &BlogCommentSettings{Enabled: true}}
+
+	assert.False(t, post.CommentsEnabled(nil))
+	assert.False(t, post.CommentsEnabled(&BlogSettings{}))
+	assert.False(t, post.CommentsEnabled(&BlogSettings{Comments: &BlogCommentSettings{}}))
+	assert.True(t, post.CommentsEnabled(enabled))
+
+	post.Settings.CommentsDisabled = true
+	assert.False(t, post.CommentsEnabled(enabled))
+}
+
+func TestCreateBlogCommentRequest_Validate(t *testing.T) {
+	valid := func() *CreateBlogCommentRequest {
+		return &CreateBlogCommentRequest{
+			PostID:  "post-1",
+			Email:   "  Reader@Example.com ",
+			Name:    " Reader ",
+			Content: " Great post ",
+		}
+	}
+
+	t.Run("normalizes a valid request", func(t *testing.T) {
+		req := valid()
+		empty := ""
+		req.ParentID = &empty
+
+		require.NoError(t, req.Validate())
+		assert.Equal(t, "reader@example.com", req.Email)
+		assert.Equal(t, "Reader", req.Name)
+		assert.Equal(t, "Great post", req.Content)
+		assert.Nil(t, req.ParentID)
+	})
+
+	tests := []struct {
+		name   string
+		mutate func(*CreateBlogCommentRequest)
+		errMsg string
+	}{
+		{"missing post", func(r *CreateBlogCommentRequest) { r.PostID = "" }, "post_id is required"},
+		{"invalid email", func(r *CreateBlogCommentRequest) { r.Email = "reader" }, "a valid email is required"},
+		{"missing name", func(r *CreateBlogCommentRequest) { r.Name = " " }, "name is required"},
+		{"long name", func(r *CreateBlogCommentRequest) { r.Name = strings.Repeat("a", 101) }, "name must be less than"},
+		{"missing content", func(r *CreateBlogCommentRequest) { r.Content = "" }, "content is required"},
+		{"long content", func(r *CreateBlogCommentRequest) { r.Content = strings.Repeat("a", 5001) }, "content must be less than"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.mutate(req)
+
+			err := req.Validate()
+			require.Error(t, err)
+			assert.IsType(t, ValidationError{}, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestBlogReactionRequest_Validate(t *testing.T) {
+	req := &BlogReactionRequest{PostID: "post-1", Reaction: "love", Email: "reader@example.com", EmailHMAC: "hmac"}
+	assert.NoError(t, req.Validate())
+
+	req.Reaction = "angry"
+	assert.ErrorContains(t, req.Validate(), "reaction must be one of")
+
+	req.Reaction = "like"
+	req.EmailHMAC = ""
+	assert.ErrorContains(t, req.Validate(), "email_hmac")
+}
+
+func TestListBlogCommentsRequest_Validate(t *testing.T) {
+	req := &ListBlogCommentsRequest{Limit: 500, Offset: -1}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, BlogCommentStatusPending, req.Status)
+	assert.Equal(t, 100, req.Limit)
+	assert.Equal(t, 0, req.Offset)
+
+	req = &ListBlogCommentsRequest{}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, 50, req.Limit)
+
+	assert.Error(t, (&ListBlogCommentsRequest{Status: "hidden"}).Validate())
+}
+
+func TestModerateBlogCommentRequest_Validate(t *testing.T) {
+	assert.NoError(t, (&ModerateBlogCommentRequest{ID: "c1", Status: BlogCommentStatusApproved}).Validate())
+	assert.NoError(t, (&ModerateBlogCommentRequest{ID: "c1", Status: BlogCommentStatusSpam}).Validate())
+	assert.Error(t, (&ModerateBlogCommentRequest{ID: "c1", Status: BlogCommentStatusUnverified}).Validate())
+	assert.Error(t, (&ModerateBlogCommentRequest{Status: BlogCommentStatusApproved}).Validate())
+}
+
+func TestBlogComment_Public(t *testing.T) {
+	comment := &BlogComment{
+		ID:          "c1",
+		Email:       "reader@example.com",
+		Name:        "Reader",
+		Content:     "Great post",
+		Status:      BlogCommentStatusApproved,
+		SpamReasons: []string{BlogCommentSpamDuplicate},
+		IPAddress:   "1.2.3.4",
+	}
+
+	public := comment.Public()
+	assert.Equal(t, "c1", public.ID)
+	assert.Equal(t, "Reader", public.Name)
+	assert.Equal(t, "Great post", public.Content)
+}
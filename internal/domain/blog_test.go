@@ -2,6 +2,7 @@ package domain
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -293,6 +294,26 @@ func TestListBlogPostsRequest_Validate(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid status")
 	})
+
+	t.Run("trims the search query", func(t *testing.T) {
+		req := ListBlogPostsRequest{
+			Query: "  launch  ",
+		}
+		err := req.Validate()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "launch", req.Query)
+	})
+
+	t.Run("rejects a search query that is too long", func(t *testing.T) {
+		req := ListBlogPostsRequest{
+			Query: strings.Repeat("a", MaxBlogSearchQueryLength+1),
+		}
+		err := req.Validate()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "query must be at most")
+	})
 }
 
 func TestBuildBlogTemplateData_WithPagination(t *testing.T) {
@@ -1652,6 +1673,61 @@ func TestPublishBlogPostRequest_Validate(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "id is required")
 	})
+
+	t.Run("valid with newsletter", func(t *testing.T) {
+		req := &PublishBlogPostRequest{
+			ID:         "post-123",
+			Newsletter: &BlogNewsletterSettings{TemplateID: "tpl-1", Audience: AudienceSettings{List: "list-1"}},
+		}
+
+		assert.NoError(t, req.Validate())
+	})
+
+	t.Run("newsletter with skip_newsletter", func(t *testing.T) {
+		req := &PublishBlogPostRequest{
+			ID:             "post-123",
+			Newsletter:     &BlogNewsletterSettings{TemplateID: "tpl-1", Audience: AudienceSettings{List: "list-1"}},
+			SkipNewsletter: true,
+		}
+
+		err := req.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be used together")
+	})
+
+	t.Run("invalid newsletter", func(t *testing.T) {
+		req := &PublishBlogPostRequest{
+			ID:         "post-123",
+			Newsletter: &BlogNewsletterSettings{Audience: AudienceSettings{List: "list-1"}},
+		}
+
+		err := req.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "template_id is required")
+	})
+}
+
+func TestBlogNewsletterSettings_Validate(t *testing.T) {
+	valid := BlogNewsletterSettings{TemplateID: "tpl-1", Audience: AudienceSettings{List: "list-1"}, DelayMinutes: 60}
+	assert.NoError(t, valid.Validate())
+
+	missingList := valid
+	missingList.Audience = AudienceSettings{}
+	assert.ErrorContains(t, missingList.Validate(), "audience list is required")
+
+	negativeDelay := valid
+	negativeDelay.DelayMinutes = -1
+	assert.ErrorContains(t, negativeDelay.Validate(), "delay_minutes")
+
+	longDelay := valid
+	longDelay.DelayMinutes = 10081
+	assert.ErrorContains(t, longDelay.Validate(), "delay_minutes")
+
+	// Category settings are only validated when auto-send is enabled
+	disabled := &BlogCategoryNewsletterSettings{AutoSend: false}
+	assert.NoError(t, disabled.Validate())
+	enabled := &BlogCategoryNewsletterSettings{AutoSend: true}
+	assert.Error(t, enabled.Validate())
 }
 
 func TestUnpublishBlogPostRequest_Validate(t *testing.T) {
@@ -2049,3 +2125,245 @@ func TestListBlogThemesRequest_Validate(t *testing.T) {
 		assert.Equal(t, 20, req.Offset)
 	})
 }
+
+func TestScheduleBlogPostRequest_Validate(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	assert.NoError(t, (&ScheduleBlogPostRequest{ID: "post-1", ScheduledPublishAt: &future}).Validate())
+	assert.NoError(t, (&ScheduleBlogPostRequest{ID: "post-1"}).Validate(), "nil time cancels the schedule")
+	assert.Error(t, (&ScheduleBlogPostRequest{ScheduledPublishAt: &future}).Validate())
+	assert.Error(t, (&ScheduleBlogPostRequest{ID: "post-1", ScheduledPublishAt: &past}).Validate())
+}
+
+func TestBlogPost_IsScheduled(t *testing.T) {
+	scheduledAt := time.Now().Add(time.Hour)
+	post := &BlogPost{ScheduledPublishAt: &scheduledAt}
+	assert.True(t, post.IsScheduled())
+
+	post.ScheduledPublishAt = nil
+	assert.False(t, post.IsScheduled())
+}
+
+func TestNewBlogPostRevision(t *testing.T) {
+	post := &BlogPost{
+		ID:         "post-1",
+		CategoryID: "cat-1",
+		Slug:       "launch",
+		Settings:   BlogPostSettings{Title: "Launch"},
+	}
+
+	t.Run("applied revision", func(t *testing.T) {
+		revision := NewBlogPostRevision(post, BlogPostRevisionStatusApplied, "user-1")
+		assert.Equal(t, "post-1", revision.PostID)
+		assert.Equal(t, "launch", revision.Slug)
+		assert.Equal(t, "Launch", revision.Settings.Title)
+		assert.Equal(t, "user-1", revision.CreatedBy)
+		require.NotNil(t, revision.AppliedAt)
+		assert.Equal(t, revision.CreatedAt, *revision.AppliedAt)
+	})
+
+	t.Run("draft revision", func(t *testing.T) {
+		revision := NewBlogPostRevision(post, BlogPostRevisionStatusDraft, "user-1")
+		assert.Nil(t, revision.AppliedAt)
+	})
+
+	t.Run("apply to post", func(t *testing.T) {
+		revision := &BlogPostRevision{CategoryID: "cat-2", Slug: "launch-v2", Settings: BlogPostSettings{Title: "Launch v2"}}
+		target := *post
+		revision.ApplyTo(&target)
+		assert.Equal(t, "post-1", target.ID)
+		assert.Equal(t, "cat-2", target.CategoryID)
+		assert.Equal(t, "launch-v2", target.Slug)
+		assert.Equal(t, "Launch v2", target.Settings.Title)
+	})
+
+	t.Run("apply to post keeps the newsletter broadcast", func(t *testing.T) {
+		target := *post
+		target.Settings.NewsletterBroadcastID = "broadcast-1"
+		revision := &BlogPostRevision{CategoryID: "cat-1", Slug: "launch", Settings: BlogPostSettings{Title: "Launch v2"}}
+		revision.ApplyTo(&target)
+		assert.Equal(t, "broadcast-1", target.Settings.NewsletterBroadcastID)
+	})
+}
+
+func TestBlogPostRevisionRequest_Validate(t *testing.T) {
+	assert.NoError(t, (&BlogPostRevisionRequest{ID: "post-1", Version: 1}).Validate())
+	assert.Error(t, (&BlogPostRevisionRequest{Version: 1}).Validate())
+	assert.Error(t, (&BlogPostRevisionRequest{ID: "post-1"}).Validate())
+}
+
+func TestBlogSearchRequest_Validate(t *testing.T) {
+	t.Run("sets defaults", func(t *testing.T) {
+		req := BlogSearchRequest{Query: " launch "}
+		assert.NoError(t, req.Validate())
+		assert.Equal(t, "launch", req.Query)
+		assert.Equal(t, 1, req.Page)
+		assert.Equal(t, 10, req.Limit)
+		assert.Equal(t, 0, req.Offset)
+	})
+
+	t.Run("calculates offset and enforces max limit", func(t *testing.T) {
+		req := BlogSearchRequest{Query: "launch", Page: 3, Limit: 500}
+		assert.NoError(t, req.Validate())
+		assert.Equal(t, 50, req.Limit)
+		assert.Equal(t, 100, req.Offset)
+	})
+
+	t.Run("requires a query", func(t *testing.T) {
+		req := BlogSearchRequest{Query: "   "}
+		err := req.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "query is required")
+	})
+
+	t.Run("rejects a query that is too long", func(t *testing.T) {
+		req := BlogSearchRequest{Query: strings.Repeat("a", MaxBlogSearchQueryLength+1)}
+		assert.Error(t, req.Validate())
+	})
+}
+
+func TestBlogPost_Localize(t *testing.T) {
+	post := &BlogPost{
+		ID:   "post-1",
+		Slug: "launch",
+		Settings: BlogPostSettings{
+			Title:   "Launch day",
+			Excerpt: "We launched",
+			Translations: map[string]BlogPostTranslation{
+				"fr": {Slug: "lancement", Title: "Jour de lancement"},
+			},
+		},
+	}
+
+	t.Run("default language returns the post", func(t *testing.T) {
+		assert.Same(t, post, post.Localize(""))
+	})
+
+	t.Run("translated post", func(t *testing.T) {
+		localized := post.Localize("fr")
+		require.NotNil(t, localized)
+		assert.Equal(t, "post-1", localized.ID)
+		assert.Equal(t, "lancement", localized.Slug)
+		assert.Equal(t, "Jour de lancement", localized.Settings.Title)
+		assert.Equal(t, "", localized.Settings.Excerpt)
+		// The original post is unchanged
+		assert.Equal(t, "launch", post.Slug)
+	})
+
+	t.Run("untranslated post", func(t *testing.T) {
+		assert.Nil(t, post.Localize("de"))
+	})
+}
+
+func TestBlogCategory_Localize(t *testing.T) {
+	category := &BlogCategory{
+		ID:   "cat-1",
+		Slug: "news",
+		Settings: BlogCategorySettings{
+			Name:        "News",
+			Description: "Latest news",
+			Translations: map[string]BlogCategoryTranslation{
+				"fr": {Slug: "actualites", Name: "Actualités"},
+				"de": {Name: "Neuigkeiten"},
+			},
+		},
+	}
+
+	assert.Same(t, category, category.Localize(""))
+	assert.Same(t, category, category.Localize("es"))
+
+	fr := category.Localize("fr")
+	assert.Equal(t, "actualites", fr.Slug)
+	assert.Equal(t, "Actualités", fr.Settings.Name)
+	assert.Equal(t, "Latest news", fr.Settings.Description)
+
+	de := category.Localize("de")
+	assert.Equal(t, "news", de.Slug)
+	assert.Equal(t, "Neuigkeiten", de.Settings.Name)
+}
+
+func TestBlogPost_Validate_Translations(t *testing.T) {
+	newPost := func(translations map[string]BlogPostTranslation) *BlogPost {
+		return &BlogPost{
+			ID:         "post-1",
+			CategoryID: "cat-1",
+			Slug:       "launch",
+			Settings: BlogPostSettings{
+				Title:        "Launch day",
+				Template:     BlogPostTemplateReference{TemplateID: "tpl-1", TemplateVersion: 1},
+				Translations: translations,
+			},
+		}
+	}
+
+	assert.NoError(t, newPost(map[string]BlogPostTranslation{"fr": {Slug: "lancement", Title: "Lancement"}}).Validate())
+	assert.Error(t, newPost(map[string]BlogPostTranslation{"xx": {Slug: "lancement", Title: "Lancement"}}).Validate())
+	assert.Error(t, newPost(map[string]BlogPostTranslation{"fr": {Title: "Lancement"}}).Validate())
+	assert.Error(t, newPost(map[string]BlogPostTranslation{"fr": {Slug: "Not A Slug", Title: "Lancement"}}).Validate())
+	assert.Error(t, newPost(map[string]BlogPostTranslation{"fr": {Slug: "lancement"}}).Validate())
+}
+
+func TestBlogCategory_Validate_Translations(t *testing.T) {
+	newCategory := func(translations map[string]BlogCategoryTranslation) *BlogCategory {
+		return &BlogCategory{
+			ID:   "cat-1",
+			Slug: "news",
+			Settings: BlogCategorySettings{
+				Name:         "News",
+				Translations: translations,
+			},
+		}
+	}
+
+	assert.NoError(t, newCategory(map[string]BlogCategoryTranslation{"fr": {Name: "Actualités"}}).Validate())
+	assert.NoError(t, newCategory(map[string]BlogCategoryTranslation{"fr": {Slug: "actualites", Name: "Actualités"}}).Validate())
+	assert.Error(t, newCategory(map[string]BlogCategoryTranslation{"xx": {Name: "Actualités"}}).Validate())
+	assert.Error(t, newCategory(map[string]BlogCategoryTranslation{"fr": {Slug: "actualites"}}).Validate())
+	assert.Error(t, newCategory(map[string]BlogCategoryTranslation{"fr": {Slug: "Actualités", Name: "Actualités"}}).Validate())
+}
+
+func TestBuildBlogTemplateData_Language(t *testing.T) {
+	workspace := &Workspace{
+		ID:   "ws-1",
+		Name: "Test",
+		Settings: WorkspaceSettings{
+			WebsiteURL:      "https://example.com",
+			DefaultLanguage: "en",
+			Languages:       []string{"en", "fr"},
+		},
+	}
+
+	t.Run("default language", func(t *testing.T) {
+		data, err := BuildBlogTemplateData(BlogTemplateDataRequest{Workspace: workspace})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com", data["base_url"])
+		assert.Equal(t, MapOfAny{"code": "en", "name": "English", "is_default": true}, data["language"])
+		assert.Empty(t, data["languages"])
+	})
+
+	t.Run("translation language", func(t *testing.T) {
+		data, err := BuildBlogTemplateData(BlogTemplateDataRequest{
+			Workspace: workspace,
+			Language:  "fr",
+			Languages: []BlogLanguageLink{
+				{Code: "en", URL: "https://example.com/"},
+				{Code: "fr", URL: "https://example.com/fr/", Current: true},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/fr", data["base_url"])
+		assert.Equal(t, MapOfAny{"code": "fr", "name": "French", "is_default": false}, data["language"])
+
+		languages := data["languages"].([]map[string]interface{})
+		require.Len(t, languages, 2)
+		assert.Equal(t, "https://example.com/fr/", languages[1]["url"])
+		assert.Equal(t, true, languages[1]["current"])
+		assert.Equal(t, "English", languages[0]["name"])
+	})
+}
+
+func TestBlogLanguagePrefix(t *testing.T) {
+	assert.Equal(t, "", BlogLanguagePrefix(""))
+	assert.Equal(t, "/fr", BlogLanguagePrefix("fr"))
+}
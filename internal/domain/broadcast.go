@@ -167,6 +167,8 @@ type AudienceSettings struct {
 	List                string   `json:"list,omitempty"`
 	Segments            []string `json:"segments,omitempty"`
 	ExcludeUnsubscribed bool     `json:"exclude_unsubscribed"`
+	// Digest marks the broadcast as a digest, also sent to the contacts who chose the weekly digest frequency
+	Digest bool `json:"digest,omitempty"`
 }
 
 // Value implements the driver.Valuer interface for database serialization
@@ -300,6 +302,103 @@ type Broadcast struct {
 
 	// Data feed settings (global and recipient feeds)
 	DataFeed *DataFeedSettings `json:"data_feed,omitempty"`
+
+	// Blog post sent by the broadcast, when it was created by publishing a post
+	BlogPost *BroadcastBlogPost `json:"blog_post,omitempty"`
+}
+
+// BroadcastBlogPost links a broadcast to the blog post it sends. The post content is
+// exposed to the email template as the `post` variable.
+type BroadcastBlogPost struct {
+	PostID           string     `json:"post_id"`
+	Title            string     `json:"title"`
+	Excerpt          string     `json:"excerpt,omitempty"`
+	FeaturedImageURL string     `json:"featured_image_url,omitempty"`
+	URL              string     `json:"url"`
+	PublishedAt      *time.Time `json:"published_at,omitempty"`
+	TableOfContents  []TOCItem  `json:"table_of_contents,omitempty"`
+}
+
+// Value implements the driver.Valuer interface for database serialization
+func (p BroadcastBlogPost) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan implements the sql.Scanner interface for database deserialization
+func (p *BroadcastBlogPost) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("type assertion to []byte failed")
+	}
+
+	cloned := bytes.Clone(b)
+	return json.Unmarshal(cloned, p)
+}
+
+// TemplateData returns the `post` template variable. The UTM parameters of the broadcast
+// are added to the post links, which are not rewritten by link tracking as they come
+// from a Liquid placeholder.
+func (p *BroadcastBlogPost) TemplateData(utm *UTMParameters) MapOfAny {
+	postURL := appendUTMParameters(p.URL, utm)
+
+	toc := make([]MapOfAny, len(p.TableOfContents))
+	for i, item := range p.TableOfContents {
+		toc[i] = MapOfAny{
+			"id":    item.ID,
+			"level": item.Level,
+			"text":  item.Text,
+			"url":   postURL + "#" + item.ID,
+		}
+	}
+
+	return MapOfAny{
+		"id":                 p.PostID,
+		"title":              p.Title,
+		"excerpt":            p.Excerpt,
+		"featured_image_url": p.FeaturedImageURL,
+		"url":                postURL,
+		"published_at":       p.PublishedAt,
+		"table_of_contents":  toc,
+	}
+}
+
+// appendUTMParameters adds UTM parameters to a URL, unless it already carries some
+func appendUTMParameters(rawURL string, utm *UTMParameters) string {
+	if rawURL == "" || utm == nil {
+		return rawURL
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	query := parsedURL.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") {
+			return rawURL
+		}
+	}
+
+	params := []struct{ key, value string }{
+		{"utm_source", utm.Source},
+		{"utm_medium", utm.Medium},
+		{"utm_campaign", utm.Campaign},
+		{"utm_content", utm.Content},
+		{"utm_term", utm.Term},
+	}
+	for _, param := range params {
+		if param.value != "" {
+			query.Set(param.key, param.value)
+		}
+	}
+	parsedURL.RawQuery = query.Encode()
+
+	return parsedURL.String()
 }
 
 // UTMParameters contains UTM tracking parameters for the broadcast
@@ -446,6 +545,7 @@ type CreateBroadcastRequest struct {
 	UTMParameters   *UTMParameters        `json:"utm_parameters,omitempty"`
 	Metadata        MapOfAny              `json:"metadata,omitempty"`
 	DataFeed        *DataFeedSettings     `json:"data_feed,omitempty"`
+	BlogPost        *BroadcastBlogPost    `json:"blog_post,omitempty"`
 }
 
 // Validate validates the create broadcast request
@@ -460,6 +560,7 @@ func (r *CreateBroadcastRequest) Validate() (*Broadcast, error) {
 		UTMParameters: r.UTMParameters,
 		Metadata:      r.Metadata,
 		DataFeed:      r.DataFeed,
+		BlogPost:      r.BlogPost,
 		CreatedAt:     time.Now().UTC(),
 		UpdatedAt:     time.Now().UTC(),
 	}
@@ -1056,6 +1056,72 @@ func TestUTMParameters_ValueScan(t *testing.T) {
 	assert.Contains(t, err.Error(), "type assertion to []byte failed")
 }
 
+func TestBroadcastBlogPost_ValueScan(t *testing.T) {
+	publishedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	original := domain.BroadcastBlogPost{
+		PostID:          "post1",
+		Title:           "Launch",
+		URL:             "https://blog.example.com/news/launch",
+		PublishedAt:     &publishedAt,
+		TableOfContents: []domain.TOCItem{{ID: "intro", Level: 2, Text: "Intro"}},
+	}
+
+	value, err := original.Value()
+	require.NoError(t, err)
+
+	var scanned domain.BroadcastBlogPost
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, original.PostID, scanned.PostID)
+	assert.Equal(t, original.URL, scanned.URL)
+	assert.True(t, publishedAt.Equal(*scanned.PublishedAt))
+	assert.Equal(t, original.TableOfContents, scanned.TableOfContents)
+
+	var nilTarget domain.BroadcastBlogPost
+	require.NoError(t, nilTarget.Scan(nil))
+
+	err = nilTarget.Scan("not-a-byte-array")
+	require.Error(t, err)
+}
+
+func TestBroadcastBlogPost_TemplateData(t *testing.T) {
+	post := &domain.BroadcastBlogPost{
+		PostID:           "post1",
+		Title:            "Launch",
+		Excerpt:          "We launched",
+		FeaturedImageURL: "https://cdn.example.com/launch.png",
+		URL:              "https://blog.example.com/news/launch",
+		TableOfContents:  []domain.TOCItem{{ID: "intro", Level: 2, Text: "Intro"}},
+	}
+
+	t.Run("adds UTM parameters to the post links", func(t *testing.T) {
+		data := post.TemplateData(&domain.UTMParameters{Source: "newsletter", Medium: "email", Campaign: "launch"})
+
+		assert.Equal(t, "post1", data["id"])
+		assert.Equal(t, "Launch", data["title"])
+		assert.Equal(t, "We launched", data["excerpt"])
+		assert.Equal(t, "https://cdn.example.com/launch.png", data["featured_image_url"])
+		assert.Equal(t, "https://blog.example.com/news/launch?utm_campaign=launch&utm_medium=email&utm_source=newsletter", data["url"])
+
+		toc, ok := data["table_of_contents"].([]domain.MapOfAny)
+		require.True(t, ok)
+		require.Len(t, toc, 1)
+		assert.Equal(t, "Intro", toc[0]["text"])
+		assert.Equal(t, "https://blog.example.com/news/launch?utm_campaign=launch&utm_medium=email&utm_source=newsletter#intro", toc[0]["url"])
+	})
+
+	t.Run("keeps the URL without UTM parameters", func(t *testing.T) {
+		data := post.TemplateData(nil)
+		assert.Equal(t, "https://blog.example.com/news/launch", data["url"])
+	})
+
+	t.Run("keeps UTM parameters already in the URL", func(t *testing.T) {
+		tagged := *post
+		tagged.URL = "https://blog.example.com/news/launch?utm_source=blog"
+		data := tagged.TemplateData(&domain.UTMParameters{Source: "newsletter"})
+		assert.Equal(t, "https://blog.example.com/news/launch?utm_source=blog", data["url"])
+	})
+}
+
 // TestBroadcastTestSettings_ValueScan tests the Value and Scan methods for BroadcastTestSettings
 func TestBroadcastTestSettings_ValueScan(t *testing.T) {
 	// Test serialization
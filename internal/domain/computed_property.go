@@ -0,0 +1,252 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Computed property sources
+const (
+	ComputedPropertySourceCustomEvents    = "custom_events"
+	ComputedPropertySourceMessageHistory  = "message_history"
+	ComputedPropertySourceContactTimeline = "contact_timeline"
+)
+
+// Computed property aggregates
+const (
+	ComputedPropertyAggregateCount           = "count"
+	ComputedPropertyAggregateSum             = "sum"
+	ComputedPropertyAggregateAvg             = "avg"
+	ComputedPropertyAggregateMin             = "min"
+	ComputedPropertyAggregateMax             = "max"
+	ComputedPropertyAggregateFirstAt         = "first_at"
+	ComputedPropertyAggregateLastAt          = "last_at"
+	ComputedPropertyAggregateDaysSinceLast   = "days_since_last"
+	ComputedPropertyAggregateMostFrequent    = "most_frequent"
+	ComputedPropertyAggregateEngagementScore = "engagement_score"
+	ComputedPropertyAggregatePredictedLTV    = "predicted_ltv"
+)
+
+const (
+	// ComputedPropertyFieldPrefix prefixes the key of a computed property in segment
+	// dimension filters, e.g. "computed.rfm_score"
+	ComputedPropertyFieldPrefix = "computed."
+	// MaxComputedProperties is the maximum number of computed properties per workspace
+	MaxComputedProperties      = 20
+	maxComputedPropertyBuckets = 10
+	maxComputedPropertyDays    = 3650
+)
+
+// computedPropertyAggregateSources lists, for each aggregate, the sources it can be computed from
+var computedPropertyAggregateSources = map[string][]string{
+	ComputedPropertyAggregateCount:           {ComputedPropertySourceCustomEvents, ComputedPropertySourceMessageHistory, ComputedPropertySourceContactTimeline},
+	ComputedPropertyAggregateFirstAt:         {ComputedPropertySourceCustomEvents, ComputedPropertySourceMessageHistory, ComputedPropertySourceContactTimeline},
+	ComputedPropertyAggregateLastAt:          {ComputedPropertySourceCustomEvents, ComputedPropertySourceMessageHistory, ComputedPropertySourceContactTimeline},
+	ComputedPropertyAggregateDaysSinceLast:   {ComputedPropertySourceCustomEvents, ComputedPropertySourceMessageHistory, ComputedPropertySourceContactTimeline},
+	ComputedPropertyAggregateSum:             {ComputedPropertySourceCustomEvents},
+	ComputedPropertyAggregateAvg:             {ComputedPropertySourceCustomEvents},
+	ComputedPropertyAggregateMin:             {ComputedPropertySourceCustomEvents},
+	ComputedPropertyAggregateMax:             {ComputedPropertySourceCustomEvents},
+	ComputedPropertyAggregateMostFrequent:    {ComputedPropertySourceCustomEvents},
+	ComputedPropertyAggregatePredictedLTV:    {ComputedPropertySourceCustomEvents},
+	ComputedPropertyAggregateEngagementScore: {ComputedPropertySourceMessageHistory},
+}
+
+var (
+	computedPropertyKeyRegex  = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+	computedPropertyPathRegex = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+)
+
+// ComputedProperty defines a read-only contact attribute that the system computes
+// from custom_events, message_history or the contact timeline. Values are stored in
+// contacts.computed_properties under Key and refreshed by the compute_contact_properties task.
+type ComputedProperty struct {
+	Key       string `json:"key"`
+	Label     string `json:"label,omitempty"`
+	Source    string `json:"source"`    // custom_events, message_history, contact_timeline
+	Aggregate string `json:"aggregate"` // count, sum, avg, min, max, first_at, last_at, days_since_last, most_frequent, engagement_score, predicted_ltv
+
+	// EventName filters the source rows: the custom event name, the message event
+	// (sent, opened, clicked...) or the timeline kind (e.g. "list.subscribed")
+	EventName string `json:"event_name,omitempty"`
+	// GoalType filters custom events by goal type (custom_events only)
+	GoalType string `json:"goal_type,omitempty"`
+	// PropertyPath is the path of the value in the custom event properties.
+	// Numeric aggregates fall back to goal_value when empty.
+	PropertyPath []string `json:"property_path,omitempty"`
+	// Weights maps message events to their weight (engagement_score only, defaults to opened=1, clicked=3)
+	Weights map[string]float64 `json:"weights,omitempty"`
+	// WindowDays only considers rows from the last N days (0 = all time)
+	WindowDays int `json:"window_days,omitempty"`
+	// HorizonDays is the projection horizon of predicted_ltv (defaults to 365)
+	HorizonDays int `json:"horizon_days,omitempty"`
+	// Buckets turns a numeric value into a 1..len(buckets)+1 score using ascending thresholds,
+	// e.g. RFM scores. With InvertBuckets lower values get higher scores (recency).
+	Buckets       []float64 `json:"buckets,omitempty"`
+	InvertBuckets bool      `json:"invert_buckets,omitempty"`
+}
+
+// DefaultEngagementWeights are the engagement_score weights used when none are configured
+var DefaultEngagementWeights = map[string]float64{"opened": 1, "clicked": 3}
+
+// IsNumeric returns true if the computed value is a number
+func (p *ComputedProperty) IsNumeric() bool {
+	switch p.Aggregate {
+	case ComputedPropertyAggregateFirstAt, ComputedPropertyAggregateLastAt, ComputedPropertyAggregateMostFrequent:
+		return false
+	}
+	return true
+}
+
+// Validate validates the computed property definition
+func (p *ComputedProperty) Validate() error {
+	if !computedPropertyKeyRegex.MatchString(p.Key) {
+		return fmt.Errorf("key must start with a lowercase letter and contain only lowercase letters, digits and underscores (max 50 characters)")
+	}
+	if len(p.Label) > 100 {
+		return fmt.Errorf("label exceeds maximum length of 100 characters")
+	}
+
+	sources, ok := computedPropertyAggregateSources[p.Aggregate]
+	if !ok {
+		return fmt.Errorf("invalid aggregate: %s", p.Aggregate)
+	}
+	validSource := false
+	for _, source := range sources {
+		if p.Source == source {
+			validSource = true
+			break
+		}
+	}
+	if !validSource {
+		return fmt.Errorf("aggregate '%s' cannot be computed from source '%s'", p.Aggregate, p.Source)
+	}
+
+	if len(p.EventName) > 100 {
+		return fmt.Errorf("event_name exceeds maximum length of 100 characters")
+	}
+	if p.Source == ComputedPropertySourceMessageHistory && p.EventName != "" {
+		if p.Aggregate == ComputedPropertyAggregateEngagementScore {
+			return fmt.Errorf("event_name cannot be used with the engagement_score aggregate, use weights instead")
+		}
+		if !isMessageHistoryEvent(p.EventName) {
+			return fmt.Errorf("invalid message event: %s", p.EventName)
+		}
+	}
+
+	if p.GoalType != "" {
+		if p.Source != ComputedPropertySourceCustomEvents {
+			return fmt.Errorf("goal_type can only be used with the custom_events source")
+		}
+		validGoalType := false
+		for _, t := range ValidGoalTypes {
+			if p.GoalType == t {
+				validGoalType = true
+				break
+			}
+		}
+		if !validGoalType {
+			return fmt.Errorf("invalid goal_type: %s", p.GoalType)
+		}
+	}
+
+	if len(p.PropertyPath) > 0 && p.Source != ComputedPropertySourceCustomEvents {
+		return fmt.Errorf("property_path can only be used with the custom_events source")
+	}
+	for i, segment := range p.PropertyPath {
+		if !computedPropertyPathRegex.MatchString(segment) {
+			return fmt.Errorf("property_path segment %d is invalid", i)
+		}
+	}
+	if p.Aggregate == ComputedPropertyAggregateMostFrequent && len(p.PropertyPath) == 0 {
+		return fmt.Errorf("most_frequent requires a property_path")
+	}
+
+	if len(p.Weights) > 0 {
+		if p.Aggregate != ComputedPropertyAggregateEngagementScore {
+			return fmt.Errorf("weights can only be used with the engagement_score aggregate")
+		}
+		for event := range p.Weights {
+			if !isMessageHistoryEvent(event) {
+				return fmt.Errorf("invalid weight event: %s", event)
+			}
+		}
+	}
+
+	if p.WindowDays < 0 || p.WindowDays > maxComputedPropertyDays {
+		return fmt.Errorf("window_days must be between 0 and %d", maxComputedPropertyDays)
+	}
+
+	if p.HorizonDays != 0 {
+		if p.Aggregate != ComputedPropertyAggregatePredictedLTV {
+			return fmt.Errorf("horizon_days can only be used with the predicted_ltv aggregate")
+		}
+		if p.HorizonDays < 0 || p.HorizonDays > maxComputedPropertyDays {
+			return fmt.Errorf("horizon_days must be between 0 and %d", maxComputedPropertyDays)
+		}
+	}
+
+	if len(p.Buckets) > 0 {
+		if !p.IsNumeric() {
+			return fmt.Errorf("buckets can only be used with numeric aggregates")
+		}
+		if len(p.Buckets) > maxComputedPropertyBuckets {
+			return fmt.Errorf("at most %d buckets are allowed", maxComputedPropertyBuckets)
+		}
+		if !sort.Float64sAreSorted(p.Buckets) {
+			return fmt.Errorf("buckets must be in ascending order")
+		}
+		for i := 1; i < len(p.Buckets); i++ {
+			if p.Buckets[i] == p.Buckets[i-1] {
+				return fmt.Errorf("buckets must not contain duplicates")
+			}
+		}
+	} else if p.InvertBuckets {
+		return fmt.Errorf("invert_buckets requires buckets")
+	}
+
+	return nil
+}
+
+// ValidateComputedProperties validates a list of computed properties and checks keys are unique
+func ValidateComputedProperties(props []ComputedProperty) error {
+	if len(props) > MaxComputedProperties {
+		return fmt.Errorf("at most %d computed properties are allowed", MaxComputedProperties)
+	}
+
+	seen := make(map[string]bool, len(props))
+	for i := range props {
+		if err := props[i].Validate(); err != nil {
+			return fmt.Errorf("computed property at index %d: %w", i, err)
+		}
+		if seen[props[i].Key] {
+			return fmt.Errorf("duplicate computed property key: %s", props[i].Key)
+		}
+		seen[props[i].Key] = true
+	}
+
+	return nil
+}
+
+// ComputedPropertyKeyFromField extracts the property key from a "computed.<key>" filter field name
+func ComputedPropertyKeyFromField(fieldName string) (string, bool) {
+	if !strings.HasPrefix(fieldName, ComputedPropertyFieldPrefix) {
+		return "", false
+	}
+	key := strings.TrimPrefix(fieldName, ComputedPropertyFieldPrefix)
+	if !computedPropertyKeyRegex.MatchString(key) {
+		return "", false
+	}
+	return key, true
+}
+
+func isMessageHistoryEvent(event string) bool {
+	for _, e := range MessageHistoryConditionEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,319 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputedProperty_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		prop    ComputedProperty
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "valid custom event count",
+			prop: ComputedProperty{Key: "orders_count", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateCount, EventName: "order_completed"},
+		},
+		{
+			name: "valid sum over property path with window",
+			prop: ComputedProperty{
+				Key:          "revenue_90d",
+				Source:       ComputedPropertySourceCustomEvents,
+				Aggregate:    ComputedPropertyAggregateSum,
+				EventName:    "order_completed",
+				PropertyPath: []string{"order", "total_amount"},
+				WindowDays:   90,
+			},
+		},
+		{
+			name: "valid predicted ltv with goal type",
+			prop: ComputedProperty{Key: "ltv", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregatePredictedLTV, GoalType: GoalTypePurchase, HorizonDays: 180},
+		},
+		{
+			name: "valid most frequent",
+			prop: ComputedProperty{Key: "favorite_category", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateMostFrequent, PropertyPath: []string{"category"}},
+		},
+		{
+			name: "valid engagement score with weights",
+			prop: ComputedProperty{Key: "engagement", Source: ComputedPropertySourceMessageHistory, Aggregate: ComputedPropertyAggregateEngagementScore, Weights: map[string]float64{"opened": 1, "clicked": 5}, WindowDays: 30},
+		},
+		{
+			name: "valid recency score with inverted buckets",
+			prop: ComputedProperty{Key: "rfm_recency", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateDaysSinceLast, Buckets: []float64{7, 30, 90, 180}, InvertBuckets: true},
+		},
+		{
+			name: "valid message history last opened",
+			prop: ComputedProperty{Key: "last_opened_at", Source: ComputedPropertySourceMessageHistory, Aggregate: ComputedPropertyAggregateLastAt, EventName: "opened"},
+		},
+		{
+			name: "valid timeline count",
+			prop: ComputedProperty{Key: "list_subscriptions", Source: ComputedPropertySourceContactTimeline, Aggregate: ComputedPropertyAggregateCount, EventName: "list.subscribed"},
+		},
+		{
+			name:    "key with uppercase",
+			prop:    ComputedProperty{Key: "Orders", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateCount},
+			wantErr: true,
+			errMsg:  "key must start with a lowercase letter",
+		},
+		{
+			name:    "key too long",
+			prop:    ComputedProperty{Key: strings.Repeat("a", 51), Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateCount},
+			wantErr: true,
+			errMsg:  "key must start with a lowercase letter",
+		},
+		{
+			name:    "label too long",
+			prop:    ComputedProperty{Key: "orders", Label: strings.Repeat("a", 101), Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateCount},
+			wantErr: true,
+			errMsg:  "label exceeds maximum length",
+		},
+		{
+			name:    "unknown aggregate",
+			prop:    ComputedProperty{Key: "orders", Source: ComputedPropertySourceCustomEvents, Aggregate: "median"},
+			wantErr: true,
+			errMsg:  "invalid aggregate",
+		},
+		{
+			name:    "sum on message history",
+			prop:    ComputedProperty{Key: "orders", Source: ComputedPropertySourceMessageHistory, Aggregate: ComputedPropertyAggregateSum},
+			wantErr: true,
+			errMsg:  "cannot be computed from source",
+		},
+		{
+			name:    "unknown source",
+			prop:    ComputedProperty{Key: "orders", Source: "page_views", Aggregate: ComputedPropertyAggregateCount},
+			wantErr: true,
+			errMsg:  "cannot be computed from source",
+		},
+		{
+			name:    "invalid message event",
+			prop:    ComputedProperty{Key: "opens", Source: ComputedPropertySourceMessageHistory, Aggregate: ComputedPropertyAggregateCount, EventName: "viewed"},
+			wantErr: true,
+			errMsg:  "invalid message event",
+		},
+		{
+			name:    "event name with engagement score",
+			prop:    ComputedProperty{Key: "engagement", Source: ComputedPropertySourceMessageHistory, Aggregate: ComputedPropertyAggregateEngagementScore, EventName: "opened"},
+			wantErr: true,
+			errMsg:  "use weights instead",
+		},
+		{
+			name:    "goal type on timeline",
+			prop:    ComputedProperty{Key: "orders", Source: ComputedPropertySourceContactTimeline, Aggregate: ComputedPropertyAggregateCount, GoalType: GoalTypePurchase},
+			wantErr: true,
+			errMsg:  "goal_type can only be used",
+		},
+		{
+			name:    "invalid goal type",
+			prop:    ComputedProperty{Key: "orders", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateCount, GoalType: "donation"},
+			wantErr: true,
+			errMsg:  "invalid goal_type",
+		},
+		{
+			name:    "property path on message history",
+			prop:    ComputedProperty{Key: "opens", Source: ComputedPropertySourceMessageHistory, Aggregate: ComputedPropertyAggregateCount, PropertyPath: []string{"amount"}},
+			wantErr: true,
+			errMsg:  "property_path can only be used",
+		},
+		{
+			name:    "property path with invalid segment",
+			prop:    ComputedProperty{Key: "revenue", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateSum, PropertyPath: []string{"order", "total'--"}},
+			wantErr: true,
+			errMsg:  "property_path segment 1 is invalid",
+		},
+		{
+			name:    "most frequent without path",
+			prop:    ComputedProperty{Key: "favorite", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateMostFrequent},
+			wantErr: true,
+			errMsg:  "most_frequent requires a property_path",
+		},
+		{
+			name:    "weights without engagement score",
+			prop:    ComputedProperty{Key: "opens", Source: ComputedPropertySourceMessageHistory, Aggregate: ComputedPropertyAggregateCount, Weights: map[string]float64{"opened": 1}},
+			wantErr: true,
+			errMsg:  "weights can only be used",
+		},
+		{
+			name:    "invalid weight event",
+			prop:    ComputedProperty{Key: "engagement", Source: ComputedPropertySourceMessageHistory, Aggregate: ComputedPropertyAggregateEngagementScore, Weights: map[string]float64{"opened; DROP": 1}},
+			wantErr: true,
+			errMsg:  "invalid weight event",
+		},
+		{
+			name:    "negative window",
+			prop:    ComputedProperty{Key: "orders", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateCount, WindowDays: -1},
+			wantErr: true,
+			errMsg:  "window_days must be between",
+		},
+		{
+			name:    "horizon without predicted ltv",
+			prop:    ComputedProperty{Key: "orders", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateSum, HorizonDays: 30},
+			wantErr: true,
+			errMsg:  "horizon_days can only be used",
+		},
+		{
+			name:    "buckets on date aggregate",
+			prop:    ComputedProperty{Key: "first_order", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateFirstAt, Buckets: []float64{1}},
+			wantErr: true,
+			errMsg:  "buckets can only be used with numeric aggregates",
+		},
+		{
+			name:    "too many buckets",
+			prop:    ComputedProperty{Key: "orders", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateCount, Buckets: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}},
+			wantErr: true,
+			errMsg:  "at most 10 buckets",
+		},
+		{
+			name:    "unsorted buckets",
+			prop:    ComputedProperty{Key: "orders", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateCount, Buckets: []float64{5, 1}},
+			wantErr: true,
+			errMsg:  "ascending order",
+		},
+		{
+			name:    "duplicate buckets",
+			prop:    ComputedProperty{Key: "orders", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateCount, Buckets: []float64{1, 1}},
+			wantErr: true,
+			errMsg:  "must not contain duplicates",
+		},
+		{
+			name:    "invert without buckets",
+			prop:    ComputedProperty{Key: "orders", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateCount, InvertBuckets: true},
+			wantErr: true,
+			errMsg:  "invert_buckets requires buckets",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.prop.Validate()
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestComputedProperty_IsNumeric(t *testing.T) {
+	assert.True(t, (&ComputedProperty{Aggregate: ComputedPropertyAggregateCount}).IsNumeric())
+	assert.True(t, (&ComputedProperty{Aggregate: ComputedPropertyAggregateDaysSinceLast}).IsNumeric())
+	assert.True(t, (&ComputedProperty{Aggregate: ComputedPropertyAggregateEngagementScore}).IsNumeric())
+	assert.False(t, (&ComputedProperty{Aggregate: ComputedPropertyAggregateLastAt}).IsNumeric())
+	assert.False(t, (&ComputedProperty{Aggregate: ComputedPropertyAggregateMostFrequent}).IsNumeric())
+}
+
+func TestValidateComputedProperties(t *testing.T) {
+	t.Run("empty list is valid", func(t *testing.T) {
+		assert.NoError(t, ValidateComputedProperties(nil))
+	})
+
+	t.Run("duplicate keys", func(t *testing.T) {
+		props := []ComputedProperty{
+			{Key: "orders", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateCount},
+			{Key: "orders", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateSum},
+		}
+		err := ValidateComputedProperties(props)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "duplicate computed property key: orders")
+	})
+
+	t.Run("invalid property reports its index", func(t *testing.T) {
+		props := []ComputedProperty{
+			{Key: "orders", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateCount},
+			{Key: "Bad", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateCount},
+		}
+		err := ValidateComputedProperties(props)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "computed property at index 1")
+	})
+
+	t.Run("too many properties", func(t *testing.T) {
+		props := make([]ComputedProperty, MaxComputedProperties+1)
+		for i := range props {
+			props[i] = ComputedProperty{Key: fmt.Sprintf("prop_%d", i), Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateCount}
+		}
+		err := ValidateComputedProperties(props)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "at most 20 computed properties")
+	})
+}
+
+func TestComputedPropertyKeyFromField(t *testing.T) {
+	key, ok := ComputedPropertyKeyFromField("computed.rfm_score")
+	assert.True(t, ok)
+	assert.Equal(t, "rfm_score", key)
+
+	_, ok = ComputedPropertyKeyFromField("custom_number_1")
+	assert.False(t, ok)
+
+	_, ok = ComputedPropertyKeyFromField("computed.")
+	assert.False(t, ok)
+
+	_, ok = ComputedPropertyKeyFromField("computed.score'); DROP TABLE contacts; --")
+	assert.False(t, ok)
+}
+
+func TestSetComputedPropertiesRequest_Validate(t *testing.T) {
+	valid := []ComputedProperty{
+		{Key: "orders", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateCount},
+	}
+
+	t.Run("valid request", func(t *testing.T) {
+		req := SetComputedPropertiesRequest{WorkspaceID: "workspace123", ComputedProperties: valid}
+		workspaceID, props, err := req.Validate()
+		require.NoError(t, err)
+		assert.Equal(t, "workspace123", workspaceID)
+		assert.Equal(t, valid, props)
+	})
+
+	t.Run("empty list clears properties", func(t *testing.T) {
+		req := SetComputedPropertiesRequest{WorkspaceID: "workspace123"}
+		workspaceID, props, err := req.Validate()
+		require.NoError(t, err)
+		assert.Equal(t, "workspace123", workspaceID)
+		assert.Empty(t, props)
+	})
+
+	t.Run("missing workspace ID", func(t *testing.T) {
+		req := SetComputedPropertiesRequest{ComputedProperties: valid}
+		_, _, err := req.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "workspace_id is required")
+	})
+
+	t.Run("invalid property", func(t *testing.T) {
+		req := SetComputedPropertiesRequest{
+			WorkspaceID:        "workspace123",
+			ComputedProperties: []ComputedProperty{{Key: "orders", Source: ComputedPropertySourceMessageHistory, Aggregate: ComputedPropertyAggregateSum}},
+		}
+		_, _, err := req.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be computed from source")
+	})
+}
+
+func TestWorkspaceSettings_ComputedProperties_JSONSerialization(t *testing.T) {
+	settings := WorkspaceSettings{
+		Timezone: "UTC",
+		ComputedProperties: []ComputedProperty{
+			{Key: "rfm_recency", Source: ComputedPropertySourceCustomEvents, Aggregate: ComputedPropertyAggregateDaysSinceLast, Buckets: []float64{7, 30}, InvertBuckets: true},
+		},
+	}
+
+	data, err := json.Marshal(settings)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"computed_properties":[{"key":"rfm_recency"`)
+
+	var decoded WorkspaceSettings
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, settings.ComputedProperties, decoded.ComputedProperties)
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+)
+
+// ConsentAction is the consent event recorded in the consent ledger
+type ConsentAction string
+
+const (
+	// ConsentActionSubscribed records a subscription that became active without confirmation
+	ConsentActionSubscribed ConsentAction = "subscribed"
+	// ConsentActionPending records a subscription waiting for double opt-in confirmation
+	ConsentActionPending ConsentAction = "pending"
+	// ConsentActionConfirmed records a double opt-in confirmation (pending -> active)
+	ConsentActionConfirmed ConsentAction = "confirmed"
+	// ConsentActionUnsubscribed records an unsubscribe
+	ConsentActionUnsubscribed ConsentAction = "unsubscribed"
+)
+
+// ConsentSource identifies the channel through which a consent event was collected
+type ConsentSource string
+
+const (
+	// ConsentSourceAPI is an authenticated API or console call (lists.subscribe, lists.unsubscribe, contact_lists.updateStatus)
+	ConsentSourceAPI ConsentSource = "api"
+	// ConsentSourceForm is an anonymous submission of the public /subscribe endpoint
+	ConsentSourceForm ConsentSource = "form"
+	// ConsentSourceNotificationCenter is a request authenticated with email_hmac (preference center, double opt-in confirmation link)
+	ConsentSourceNotificationCenter ConsentSource = "notification_center"
+	// ConsentSourceOneClick is an RFC 8058 one-click unsubscribe issued by the mailbox provider
+	ConsentSourceOneClick ConsentSource = "one_click"
+	// ConsentSourceImport is a contacts.import batch
+	ConsentSourceImport ConsentSource = "import"
+	// ConsentSourceAutomation is an automation add_to_list node
+	ConsentSourceAutomation ConsentSource = "automation"
+	// ConsentSourceSupabase is the Supabase user sync
+	ConsentSourceSupabase ConsentSource = "supabase"
+	// ConsentSourceBulkOperation is a contacts bulk operation
+	ConsentSourceBulkOperation ConsentSource = "bulk_operation"
+)
+
+// ConsentContextKey is the context key carrying the ConsentContext of the current request
+const ConsentContextKey ContextKey = "consent_context"
+
+// ConsentContext describes where a subscription change comes from. It travels in the
+// request context down to the contact list repository, which stores it on the consent
+// ledger entry written alongside the status change.
+type ConsentContext struct {
+	Source             ConsentSource
+	SourceID           string // automation ID, import batch, ...
+	IPAddress          string
+	UserAgent          string
+	PageURL            string
+	ConsentTextVersion string
+	MessageID          string // double opt-in confirmation email, or the email an unsubscribe came from
+}
+
+// WithConsentContext returns a copy of ctx carrying the given consent context
+func WithConsentContext(ctx context.Context, consent ConsentContext) context.Context {
+	return context.WithValue(ctx, ConsentContextKey, consent)
+}
+
+// ConsentContextFromContext returns the consent context stored in ctx.
+// Requests without one are attributed to the API.
+func ConsentContextFromContext(ctx context.Context) ConsentContext {
+	consent, _ := ctx.Value(ConsentContextKey).(ConsentContext)
+	if consent.Source == "" {
+		consent.Source = ConsentSourceAPI
+	}
+	return consent
+}
+
+// ConsentRecord is an immutable consent ledger entry: proof of how and when a contact
+// opted in to, confirmed or opted out of a list
+type ConsentRecord struct {
+	ID                 string        `json:"id"`
+	Email              string        `json:"email"`
+	ListID             string        `json:"list_id"`
+	Action             ConsentAction `json:"action"`
+	Source             ConsentSource `json:"source"`
+	SourceID           *string       `json:"source_id,omitempty"`
+	IPAddress          *string       `json:"ip_address,omitempty"`
+	UserAgent          *string       `json:"user_agent,omitempty"`
+	PageURL            *string       `json:"page_url,omitempty"`
+	ConsentTextVersion *string       `json:"consent_text_version,omitempty"`
+	MessageID          *string       `json:"message_id,omitempty"`
+	CreatedAt          time.Time     `json:"created_at"`
+}
+
+// ListConsentRecordsRequest lists the consent ledger of a contact, of a list, or of a
+// contact on a list
+type ListConsentRecordsRequest struct {
+	WorkspaceID string
+	Email       string
+	ListID      string
+	Limit       int
+	Cursor      *string
+}
+
+// ListConsentRecordsResponse is the response of contact_lists.consent
+type ListConsentRecordsResponse struct {
+	Records    []*ConsentRecord `json:"records"`
+	NextCursor *string          `json:"next_cursor,omitempty"`
+}
+
+// Validate validates the consent ledger list request
+func (r *ListConsentRecordsRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.Email == "" && r.ListID == "" {
+		return fmt.Errorf("email or list_id is required")
+	}
+	if r.Email != "" && !govalidator.IsEmail(r.Email) {
+		return fmt.Errorf("invalid email format: %s", r.Email)
+	}
+	if r.Limit < 0 {
+		return fmt.Errorf("limit must be non-negative")
+	}
+	if r.Limit > 100 {
+		return fmt.Errorf("limit cannot exceed 100")
+	}
+	return nil
+}
+
+// FromQuery parses query parameters into a ListConsentRecordsRequest
+func (r *ListConsentRecordsRequest) FromQuery(query url.Values) error {
+	r.WorkspaceID = query.Get("workspace_id")
+	r.Email = query.Get("email")
+	r.ListID = query.Get("list_id")
+
+	r.Limit = 50 // Default
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return fmt.Errorf("invalid limit parameter: must be an integer")
+		}
+		r.Limit = parsedLimit
+	}
+
+	if cursorStr := query.Get("cursor"); cursorStr != "" {
+		r.Cursor = &cursorStr
+	}
+
+	return r.Validate()
+}
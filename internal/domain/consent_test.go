@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsentContextFromContext(t *testing.T) {
+	t.Run("defaults to api", func(t *testing.T) {
+		consent := ConsentContextFromContext(context.Background())
+		assert.Equal(t, ConsentSourceAPI, consent.Source)
+		assert.Empty(t, consent.IPAddress)
+	})
+
+	t.Run("returns the stored context", func(t *testing.T) {
+		ctx := WithConsentContext(context.Background(), ConsentContext{
+			Source:    ConsentSourceForm,
+			IPAddress: "203.0.113.7",
+			PageURL:   "https://example.com/newsletter",
+		})
+
+		consent := ConsentContextFromContext(ctx)
+		assert.Equal(t, ConsentSourceForm, consent.Source)
+		assert.Equal(t, "203.0.113.7", consent.IPAddress)
+		assert.Equal(t, "https://example.com/newsletter", consent.PageURL)
+	})
+
+	t.Run("fills a missing source", func(t *testing.T) {
+		ctx := WithConsentContext(context.Background(), ConsentContext{MessageID: "msg-1"})
+
+		consent := ConsentContextFromContext(ctx)
+		assert.Equal(t, ConsentSourceAPI, consent.Source)
+		assert.Equal(t, "msg-1", consent.MessageID)
+	})
+}
+
+func TestListConsentRecordsRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     ListConsentRecordsRequest
+		wantErr string
+	}{
+		{name: "by email", req: ListConsentRecordsRequest{WorkspaceID: "ws", Email: "a@example.com", Limit: 10}},
+		{name: "by list", req: ListConsentRecordsRequest{WorkspaceID: "ws", ListID: "news", Limit: 10}},
+		{name: "missing workspace", req: ListConsentRecordsRequest{Email: "a@example.com"}, wantErr: "workspace_id is required"},
+		{name: "missing email and list", req: ListConsentRecordsRequest{WorkspaceID: "ws"}, wantErr: "email or list_id is required"},
+		{name: "invalid email", req: ListConsentRecordsRequest{WorkspaceID: "ws", Email: "nope"}, wantErr: "invalid email format"},
+		{name: "negative limit", req: ListConsentRecordsRequest{WorkspaceID: "ws", ListID: "news", Limit: -1}, wantErr: "limit must be non-negative"},
+		{name: "limit too high", req: ListConsentRecordsRequest{WorkspaceID: "ws", ListID: "news", Limit: 101}, wantErr: "limit cannot exceed 100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestListConsentRecordsRequest_FromQuery(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		var req ListConsentRecordsRequest
+		err := req.FromQuery(url.Values{"workspace_id": {"ws"}, "email": {"a@example.com"}})
+		require.NoError(t, err)
+		assert.Equal(t, 50, req.Limit)
+		assert.Nil(t, req.Cursor)
+	})
+
+	t.Run("limit and cursor", func(t *testing.T) {
+		var req ListConsentRecordsRequest
+		err := req.FromQuery(url.Values{"workspace_id": {"ws"}, "list_id": {"news"}, "limit": {"20"}, "cursor": {"abc"}})
+		require.NoError(t, err)
+		assert.Equal(t, 20, req.Limit)
+		require.NotNil(t, req.Cursor)
+		assert.Equal(t, "abc", *req.Cursor)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		var req ListConsentRecordsRequest
+		err := req.FromQuery(url.Values{"workspace_id": {"ws"}, "list_id": {"news"}, "limit": {"x"}})
+		assert.Error(t, err)
+	})
+}
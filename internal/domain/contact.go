@@ -69,6 +69,17 @@ type Contact struct {
 	CustomJSON4 *NullableJSON `json:"custom_json_4,omitempty" valid:"optional"`
 	CustomJSON5 *NullableJSON `json:"custom_json_5,omitempty" valid:"optional"`
 
+	// Read-only values of the workspace computed properties, keyed by property key.
+	// Maintained by the compute_contact_properties task and ignored on upsert.
+	ComputedProperties MapOfAny `json:"computed_properties,omitempty"`
+
+	// Typed custom attributes defined in the workspace attribute registry, keyed by attribute key.
+	// On upsert, a nil value removes the attribute.
+	Attributes MapOfAny `json:"attributes,omitempty"`
+
+	// Read-only result of the last email verification, ignored on upsert
+	EmailVerification *EmailVerification `json:"email_verification,omitempty"`
+
 	// Timestamps
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -159,6 +170,10 @@ type dbContact struct {
 	UpdatedAt   time.Time
 	DBCreatedAt time.Time
 	DBUpdatedAt time.Time
+
+	ComputedProperties []byte
+	Attributes         []byte
+	EmailVerification  []byte
 }
 
 // ScanContact scans a contact from the database
@@ -207,6 +222,9 @@ func ScanContact(scanner interface {
 		&dbc.UpdatedAt,
 		&dbc.DBCreatedAt,
 		&dbc.DBUpdatedAt,
+		&dbc.ComputedProperties,
+		&dbc.Attributes,
+		&dbc.EmailVerification,
 	)
 
 	if err != nil {
@@ -363,10 +381,26 @@ func ScanContact(scanner interface {
 			c.CustomJSON5 = &NullableJSON{Data: data, IsNull: false}
 		}
 	}
+	c.ComputedProperties = parseJSONObject(dbc.ComputedProperties)
+	c.Attributes = parseJSONObject(dbc.Attributes)
+	c.EmailVerification = parseEmailVerification(dbc.EmailVerification)
 
 	return c, nil
 }
 
+// parseJSONObject decodes the contacts.computed_properties and contacts.attributes
+// JSONB columns, ignoring empty objects
+func parseJSONObject(data []byte) MapOfAny {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	var props MapOfAny
+	if err := json.Unmarshal(data, &props); err != nil || len(props) == 0 {
+		return nil
+	}
+	return props
+}
+
 // GetContactsRequest represents a request to get contacts with filters and pagination
 type GetContactsRequest struct {
 	// Required fields
@@ -387,6 +421,8 @@ type GetContactsRequest struct {
 
 	// Join contact_lists
 	WithContactLists bool `json:"with_contact_lists,omitempty" valid:"optional"`
+	// Attach the latest consent ledger entry to each contact list (requires with_contact_lists)
+	WithConsent bool `json:"with_consent,omitempty" valid:"optional"`
 
 	// Pagination
 	Limit  int    `json:"limit,omitempty" valid:"optional,range(1|100)"`
@@ -439,6 +475,15 @@ func (r *GetContactsRequest) FromQueryParams(params url.Values) error {
 		r.WithContactLists = withContactLists
 	}
 
+	// Parse with_consent
+	if withConsentStr := params.Get("with_consent"); withConsentStr != "" {
+		withConsent, err := strconv.ParseBool(withConsentStr)
+		if err != nil {
+			return fmt.Errorf("invalid with_consent: %w", err)
+		}
+		r.WithConsent = withConsent
+	}
+
 	return nil
 }
 
@@ -601,6 +646,15 @@ type ContactService interface {
 
 	// CountContacts returns the total number of contacts in a workspace
 	CountContacts(ctx context.Context, workspaceID string) (int, error)
+
+	// MergeContacts merges the secondary contact into the primary one and returns the merged contact
+	MergeContacts(ctx context.Context, req *MergeContactsRequest) (*Contact, error)
+
+	// ChangeContactEmail moves a contact to a new address and returns the updated contact
+	ChangeContactEmail(ctx context.Context, req *ChangeContactEmailRequest) (*Contact, error)
+
+	// GetContactAliases returns the former addresses of a contact
+	GetContactAliases(ctx context.Context, workspaceID string, email string) ([]*ContactAlias, error)
 }
 
 // ContactRepository is the interface for contact operations
@@ -647,6 +701,53 @@ type ContactRepository interface {
 	// 'complained', or has been soft-deleted. The track_contact_list_changes
 	// trigger emits the corresponding list.bounced timeline rows.
 	MarkEmailsAsBounced(ctx context.Context, workspaceID string, emails []string, at time.Time) error
+
+	// UpdateComputedProperties recomputes contacts.computed_properties for the given
+	// emails. propertiesSQL is a JSONB expression over the contact aliased "c" whose
+	// placeholders start at $2. Only changed contacts are written, so the contact
+	// trigger emits contact.updated events for actual changes. Returns the number of
+	// contacts updated.
+	UpdateComputedProperties(ctx context.Context, workspaceID string, emails []string, propertiesSQL string, args []interface{}) (int, error)
+
+	// ClearComputedProperties removes all computed property values in a workspace
+	ClearComputedProperties(ctx context.Context, workspaceID string) (int, error)
+
+	// BackfillContactAttribute copies the values of a legacy custom_* column into
+	// contacts.attributes under key for contacts that don't have the attribute yet.
+	// Returns the number of contacts updated.
+	BackfillContactAttribute(ctx context.Context, workspaceID string, attr ContactAttribute) (int, error)
+
+	// SyncContactAttributeIndexes creates the expression indexes of filterable
+	// attributes and drops the indexes of attributes that are no longer filterable
+	SyncContactAttributeIndexes(ctx context.Context, workspaceID string, attrs []ContactAttribute) error
+
+	// UpdateEmailVerifications stores the email verification results of existing
+	// contacts, keyed by email. Unknown emails are ignored.
+	UpdateEmailVerifications(ctx context.Context, workspaceID string, verifications map[string]*EmailVerification) error
+
+	// GetContactsByEmails returns the contacts matching the given emails, without
+	// their lists and segments. Unknown emails are ignored.
+	GetContactsByEmails(ctx context.Context, workspaceID string, emails []string) ([]*Contact, error)
+
+	// MergeContacts re-points the lists, message history, timeline, custom events and
+	// automation journeys of secondaryEmail to primaryEmail, records secondaryEmail as
+	// an alias of the primary contact and deletes the secondary contact
+	MergeContacts(ctx context.Context, workspaceID string, primaryEmail string, secondaryEmail string) error
+
+	// ChangeContactEmail moves a contact and its related data to newEmail and records
+	// email as an alias of the contact
+	ChangeContactEmail(ctx context.Context, workspaceID string, email string, newEmail string) error
+
+	// GetContactAliases returns the aliases of a contact, newest first
+	GetContactAliases(ctx context.Context, workspaceID string, contactEmail string) ([]*ContactAlias, error)
+
+	// ResolveContactAlias returns the address of the contact email is an alias of,
+	// or an empty string when email is not an alias
+	ResolveContactAlias(ctx context.Context, workspaceID string, email string) (string, error)
+
+	// DeleteContactAliases deletes the aliases of a contact, and an alias at its address,
+	// together with the consent records of the alias addresses
+	DeleteContactAliases(ctx context.Context, workspaceID string, contactEmail string) error
 }
 
 // FromJSON parses JSON data into a Contact struct
@@ -816,6 +917,18 @@ func FromJSON(data interface{}) (*Contact, error) {
 		}
 	}
 
+	// Parse typed attributes, values are checked against the workspace registry on upsert
+	if value := jsonResult.Get("attributes"); value.Exists() && value.Type != gjson.Null {
+		if !value.IsObject() {
+			return nil, fmt.Errorf("invalid type for attributes: expected object, got %s", value.Type)
+		}
+		contact.Attributes = MapOfAny{}
+		value.ForEach(func(key, attr gjson.Result) bool {
+			contact.Attributes[key.String()] = attr.Value()
+			return true
+		})
+	}
+
 	return contact, nil
 }
 
@@ -999,6 +1112,20 @@ func (c *Contact) Merge(other *Contact) {
 		c.CustomJSON5 = other.CustomJSON5
 	}
 
+	// Merge attributes key by key, nil values remove the attribute
+	if len(other.Attributes) > 0 {
+		if c.Attributes == nil {
+			c.Attributes = MapOfAny{}
+		}
+		for key, value := range other.Attributes {
+			if value == nil {
+				delete(c.Attributes, key)
+			} else {
+				c.Attributes[key] = value
+			}
+		}
+	}
+
 	// Update timestamps
 	if !other.CreatedAt.IsZero() {
 		c.CreatedAt = other.CreatedAt
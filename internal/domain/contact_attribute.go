@@ -0,0 +1,534 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Contact attribute types
+const (
+	ContactAttributeTypeString   = "string"
+	ContactAttributeTypeNumber   = "number"
+	ContactAttributeTypeBoolean  = "boolean"
+	ContactAttributeTypeDatetime = "datetime"
+	ContactAttributeTypeJSON     = "json"
+)
+
+const (
+	// ContactAttributeFieldPrefix prefixes the key of a contact attribute in segment
+	// dimension filters, e.g. "attributes.plan"
+	ContactAttributeFieldPrefix = "attributes."
+	// MaxContactAttributes is the maximum number of attributes a workspace can define
+	MaxContactAttributes = 500
+	// maxLegacyStringLength is the size of the custom_string_* columns
+	maxLegacyStringLength   = 255
+	maxContactAttributeEnum = 100
+)
+
+var (
+	contactAttributeKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+	legacyCustomFieldRegex   = regexp.MustCompile(`^custom_(string|number|datetime|json)_[1-5]$`)
+)
+
+// legacyCustomFieldTypes maps the prefix of the legacy custom_* columns to the attribute type they store
+var legacyCustomFieldTypes = map[string]string{
+	"custom_string":   ContactAttributeTypeString,
+	"custom_number":   ContactAttributeTypeNumber,
+	"custom_datetime": ContactAttributeTypeDatetime,
+	"custom_json":     ContactAttributeTypeJSON,
+}
+
+// ContactAttribute defines a typed custom contact attribute. Values are stored in
+// contacts.attributes under Key and validated against the definition on upsert.
+type ContactAttribute struct {
+	Key         string `json:"key"`
+	Type        string `json:"type"` // string, number, boolean, datetime, json
+	Label       string `json:"label,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Validation rules
+	Enum      []string `json:"enum,omitempty"`       // allowed values (string only)
+	MaxLength int      `json:"max_length,omitempty"` // maximum number of characters (string only)
+	Pattern   string   `json:"pattern,omitempty"`    // regular expression values must match (string only)
+	Min       *float64 `json:"min,omitempty"`        // number only
+	Max       *float64 `json:"max,omitempty"`        // number only
+
+	// PII marks attributes holding personal data, they are not exposed as analytics dimensions
+	PII bool `json:"pii,omitempty"`
+	// Filterable creates an expression index on the attribute to speed up segment filters
+	Filterable bool `json:"filterable,omitempty"`
+	// LegacyField maps the attribute onto one of the custom_* contact fields (e.g. "custom_string_1").
+	// Both are kept in sync so existing integrations, templates and segments keep working.
+	LegacyField string `json:"legacy_field,omitempty"`
+
+	// pattern is Pattern compiled when the definition is loaded or validated
+	pattern *regexp.Regexp
+}
+
+// UnmarshalJSON loads the definition and compiles its pattern once for all the values
+// normalized against it. An invalid pattern is reported when normalizing values.
+func (a *ContactAttribute) UnmarshalJSON(data []byte) error {
+	type Alias ContactAttribute
+	var alias Alias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*a = ContactAttribute(alias)
+	if a.Pattern != "" {
+		a.pattern, _ = regexp.Compile(a.Pattern)
+	}
+	return nil
+}
+
+// compiledPattern returns the compiled pattern, compiling it when the definition was
+// neither loaded from JSON nor validated
+func (a *ContactAttribute) compiledPattern() (*regexp.Regexp, error) {
+	if a.pattern != nil && a.pattern.String() == a.Pattern {
+		return a.pattern, nil
+	}
+	return regexp.Compile(a.Pattern)
+}
+
+// Validate validates the contact attribute definition
+func (a *ContactAttribute) Validate() error {
+	if !contactAttributeKeyRegex.MatchString(a.Key) {
+		return fmt.Errorf("key must start with a lowercase letter and contain only lowercase letters, digits and underscores (max 50 characters)")
+	}
+	if len(a.Label) > 100 {
+		return fmt.Errorf("label exceeds maximum length of 100 characters")
+	}
+	if len(a.Description) > 500 {
+		return fmt.Errorf("description exceeds maximum length of 500 characters")
+	}
+
+	switch a.Type {
+	case ContactAttributeTypeString, ContactAttributeTypeNumber, ContactAttributeTypeBoolean,
+		ContactAttributeTypeDatetime, ContactAttributeTypeJSON:
+	default:
+		return fmt.Errorf("invalid type: %s", a.Type)
+	}
+
+	if a.Type != ContactAttributeTypeString && (len(a.Enum) > 0 || a.MaxLength != 0 || a.Pattern != "") {
+		return fmt.Errorf("enum, max_length and pattern can only be used with string attributes")
+	}
+	if len(a.Enum) > maxContactAttributeEnum {
+		return fmt.Errorf("enum cannot have more than %d values", maxContactAttributeEnum)
+	}
+	if a.MaxLength < 0 {
+		return fmt.Errorf("max_length must be positive")
+	}
+	if a.Pattern != "" {
+		pattern, err := regexp.Compile(a.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		a.pattern = pattern
+	}
+
+	if a.Type != ContactAttributeTypeNumber && (a.Min != nil || a.Max != nil) {
+		return fmt.Errorf("min and max can only be used with number attributes")
+	}
+	if a.Min != nil && a.Max != nil && *a.Min > *a.Max {
+		return fmt.Errorf("min must be lower than or equal to max")
+	}
+
+	// Datetime values are cast to timestamptz, a cast PostgreSQL refuses in index expressions
+	if a.Filterable && !a.Indexable() {
+		return fmt.Errorf("filterable is only supported for string, number and boolean attributes")
+	}
+
+	if a.LegacyField != "" {
+		if !legacyCustomFieldRegex.MatchString(a.LegacyField) {
+			return fmt.Errorf("invalid legacy_field: %s", a.LegacyField)
+		}
+		prefix := a.LegacyField[:strings.LastIndex(a.LegacyField, "_")]
+		if legacyCustomFieldTypes[prefix] != a.Type {
+			return fmt.Errorf("legacy_field %s cannot store %s values", a.LegacyField, a.Type)
+		}
+	}
+
+	return nil
+}
+
+// NormalizeValue checks a value against the definition and returns it in its
+// canonical form: trimmed strings, float64 numbers and RFC3339 UTC datetimes.
+func (a *ContactAttribute) NormalizeValue(value interface{}) (interface{}, error) {
+	switch a.Type {
+	case ContactAttributeTypeString:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", value)
+		}
+		s = trimUnicodeSpace(s)
+		maxLength := a.MaxLength
+		if strings.HasPrefix(a.LegacyField, "custom_string") && (maxLength == 0 || maxLength > maxLegacyStringLength) {
+			maxLength = maxLegacyStringLength
+		}
+		if maxLength > 0 && utf8.RuneCountInString(s) > maxLength {
+			return nil, fmt.Errorf("value exceeds maximum length of %d characters", maxLength)
+		}
+		if a.Pattern != "" {
+			pattern, err := a.compiledPattern()
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern: %w", err)
+			}
+			if !pattern.MatchString(s) {
+				return nil, fmt.Errorf("value does not match pattern %s", a.Pattern)
+			}
+		}
+		if len(a.Enum) > 0 {
+			for _, allowed := range a.Enum {
+				if s == allowed {
+					return s, nil
+				}
+			}
+			return nil, fmt.Errorf("value must be one of: %s", strings.Join(a.Enum, ", "))
+		}
+		return s, nil
+
+	case ContactAttributeTypeNumber:
+		var f float64
+		switch v := value.(type) {
+		case float64:
+			f = v
+		case float32:
+			f = float64(v)
+		case int:
+			f = float64(v)
+		case int64:
+			f = float64(v)
+		default:
+			return nil, fmt.Errorf("expected number, got %T", value)
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("value must be a finite number")
+		}
+		if a.Min != nil && f < *a.Min {
+			return nil, fmt.Errorf("value must be greater than or equal to %v", *a.Min)
+		}
+		if a.Max != nil && f > *a.Max {
+			return nil, fmt.Errorf("value must be lower than or equal to %v", *a.Max)
+		}
+		return f, nil
+
+	case ContactAttributeTypeBoolean:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected boolean, got %T", value)
+		}
+		return b, nil
+
+	case ContactAttributeTypeDatetime:
+		var t time.Time
+		switch v := value.(type) {
+		case string:
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid time format: %v", err)
+			}
+			t = parsed
+		case time.Time:
+			t = v
+		default:
+			return nil, fmt.Errorf("expected RFC3339 datetime string, got %T", value)
+		}
+		return t.UTC().Format(time.RFC3339), nil
+
+	case ContactAttributeTypeJSON:
+		switch value.(type) {
+		case map[string]interface{}, []interface{}, MapOfAny:
+			return value, nil
+		}
+		return nil, fmt.Errorf("expected JSON object or array, got %T", value)
+	}
+
+	return nil, fmt.Errorf("invalid type: %s", a.Type)
+}
+
+// FilterType returns the segment filter field type of the attribute values
+// (booleans are filtered as "true"/"false" strings), or "" for JSON attributes
+func (a *ContactAttribute) FilterType() string {
+	switch a.Type {
+	case ContactAttributeTypeString, ContactAttributeTypeBoolean:
+		return "string"
+	case ContactAttributeTypeNumber:
+		return "number"
+	case ContactAttributeTypeDatetime:
+		return "time"
+	}
+	return ""
+}
+
+// ValidateContactAttributes validates a list of contact attributes and checks keys and legacy fields are unique
+func ValidateContactAttributes(attrs []ContactAttribute) error {
+	if len(attrs) > MaxContactAttributes {
+		return fmt.Errorf("at most %d contact attributes are allowed", MaxContactAttributes)
+	}
+
+	seenKeys := make(map[string]bool, len(attrs))
+	seenLegacyFields := make(map[string]bool)
+	for i := range attrs {
+		if err := attrs[i].Validate(); err != nil {
+			return fmt.Errorf("contact attribute at index %d: %w", i, err)
+		}
+		if seenKeys[attrs[i].Key] {
+			return fmt.Errorf("duplicate contact attribute key: %s", attrs[i].Key)
+		}
+		seenKeys[attrs[i].Key] = true
+		if attrs[i].LegacyField != "" {
+			if seenLegacyFields[attrs[i].LegacyField] {
+				return fmt.Errorf("legacy_field %s is mapped to several attributes", attrs[i].LegacyField)
+			}
+			seenLegacyFields[attrs[i].LegacyField] = true
+		}
+	}
+
+	return nil
+}
+
+// ContactAttributeKeyFromField extracts the attribute key from an "attributes.<key>" filter field name
+func ContactAttributeKeyFromField(fieldName string) (string, bool) {
+	if !strings.HasPrefix(fieldName, ContactAttributeFieldPrefix) {
+		return "", false
+	}
+	key := strings.TrimPrefix(fieldName, ContactAttributeFieldPrefix)
+	if !contactAttributeKeyRegex.MatchString(key) {
+		return "", false
+	}
+	return key, true
+}
+
+// ContactJSONFieldSQL returns the SQL expression reading key from a JSONB column of the
+// contacts table as the given filter type (string, number or time). Values of another
+// JSON type yield NULL instead of failing the cast. Keys must be validated beforehand
+// as they are embedded in the expression. Expression indexes on string and number
+// attributes use the same expressions so the planner can match them.
+func ContactJSONFieldSQL(column, key, fieldType string) string {
+	switch fieldType {
+	case "string":
+		return fmt.Sprintf("(%s->>'%s')", column, key)
+	case "number":
+		return fmt.Sprintf("(CASE WHEN jsonb_typeof(%s->'%s') = 'number' THEN (%s->>'%s')::numeric END)", column, key, column, key)
+	case "time":
+		return fmt.Sprintf("(CASE WHEN (%s->>'%s') ~ '^\\d{4}-\\d{2}-\\d{2}' THEN (%s->>'%s')::timestamptz END)", column, key, column, key)
+	}
+	return ""
+}
+
+// ContactAttributeIndexPrefix prefixes the names of the attribute expression indexes
+const ContactAttributeIndexPrefix = "idx_attr_"
+
+// IndexName returns the name of the expression index of a filterable attribute.
+// The filter type is part of the name so that changing the type of an attribute
+// replaces its index. Names stay within the 63 characters limit of PostgreSQL.
+func (a *ContactAttribute) IndexName() string {
+	return fmt.Sprintf("%s%c_%s", ContactAttributeIndexPrefix, a.FilterType()[0], a.Key)
+}
+
+// Indexable reports whether the filter expression of the attribute is immutable and can
+// back an expression index: the timestamptz cast of datetime attributes is only stable
+func (a *ContactAttribute) Indexable() bool {
+	switch a.Type {
+	case ContactAttributeTypeString, ContactAttributeTypeNumber, ContactAttributeTypeBoolean:
+		return true
+	}
+	return false
+}
+
+// IndexSQL returns the indexed expression of a filterable attribute, it matches
+// the expression QueryBuilder generates for "attributes.<key>" filters. Only
+// meaningful for indexable attributes.
+func (a *ContactAttribute) IndexSQL() string {
+	return ContactJSONFieldSQL("attributes", a.Key, a.FilterType())
+}
+
+// NormalizeAttributes validates the contact attributes against the workspace
+// definitions, converts values to their canonical form and keeps attributes
+// mapped onto legacy custom_* fields in sync with them. A nil attribute value
+// removes the attribute. When both an attribute and its legacy field are set,
+// the attribute wins.
+func (c *Contact) NormalizeAttributes(defs []ContactAttribute) error {
+	byKey := make(map[string]*ContactAttribute, len(defs))
+	for i := range defs {
+		byKey[defs[i].Key] = &defs[i]
+	}
+
+	for key, value := range c.Attributes {
+		def, ok := byKey[key]
+		if !ok {
+			return fmt.Errorf("unknown contact attribute: %s", key)
+		}
+		if value == nil {
+			continue
+		}
+		normalized, err := def.NormalizeValue(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for attribute %s: %w", key, err)
+		}
+		c.Attributes[key] = normalized
+	}
+
+	for i := range defs {
+		def := &defs[i]
+		if def.LegacyField == "" {
+			continue
+		}
+
+		if value, ok := c.Attributes[def.Key]; ok {
+			if err := c.setLegacyFieldValue(def.LegacyField, value); err != nil {
+				return fmt.Errorf("invalid value for attribute %s: %w", def.Key, err)
+			}
+			continue
+		}
+
+		value, isSet := c.legacyFieldValue(def.LegacyField)
+		if !isSet {
+			continue
+		}
+		if value != nil {
+			normalized, err := def.NormalizeValue(value)
+			if err != nil {
+				return fmt.Errorf("invalid value for %s: %w", def.LegacyField, err)
+			}
+			value = normalized
+		}
+		if c.Attributes == nil {
+			c.Attributes = MapOfAny{}
+		}
+		c.Attributes[def.Key] = value
+	}
+
+	return nil
+}
+
+// HasAttributeData returns true if the contact carries attributes or legacy
+// custom_* fields, i.e. values that depend on the workspace attribute registry
+func (c *Contact) HasAttributeData() bool {
+	if len(c.Attributes) > 0 {
+		return true
+	}
+	for field := range legacyFieldAccessors {
+		if _, isSet := c.legacyFieldValue(field); isSet {
+			return true
+		}
+	}
+	return false
+}
+
+// legacyFieldAccessors returns a pointer to each legacy custom_* contact field
+var legacyFieldAccessors = map[string]func(c *Contact) interface{}{
+	"custom_string_1":   func(c *Contact) interface{} { return &c.CustomString1 },
+	"custom_string_2":   func(c *Contact) interface{} { return &c.CustomString2 },
+	"custom_string_3":   func(c *Contact) interface{} { return &c.CustomString3 },
+	"custom_string_4":   func(c *Contact) interface{} { return &c.CustomString4 },
+	"custom_string_5":   func(c *Contact) interface{} { return &c.CustomString5 },
+	"custom_number_1":   func(c *Contact) interface{} { return &c.CustomNumber1 },
+	"custom_number_2":   func(c *Contact) interface{} { return &c.CustomNumber2 },
+	"custom_number_3":   func(c *Contact) interface{} { return &c.CustomNumber3 },
+	"custom_number_4":   func(c *Contact) interface{} { return &c.CustomNumber4 },
+	"custom_number_5":   func(c *Contact) interface{} { return &c.CustomNumber5 },
+	"custom_datetime_1": func(c *Contact) interface{} { return &c.CustomDatetime1 },
+	"custom_datetime_2": func(c *Contact) interface{} { return &c.CustomDatetime2 },
+	"custom_datetime_3": func(c *Contact) interface{} { return &c.CustomDatetime3 },
+	"custom_datetime_4": func(c *Contact) interface{} { return &c.CustomDatetime4 },
+	"custom_datetime_5": func(c *Contact) interface{} { return &c.CustomDatetime5 },
+	"custom_json_1":     func(c *Contact) interface{} { return &c.CustomJSON1 },
+	"custom_json_2":     func(c *Contact) interface{} { return &c.CustomJSON2 },
+	"custom_json_3":     func(c *Contact) interface{} { return &c.CustomJSON3 },
+	"custom_json_4":     func(c *Contact) interface{} { return &c.CustomJSON4 },
+	"custom_json_5":     func(c *Contact) interface{} { return &c.CustomJSON5 },
+}
+
+// legacyFieldValue returns the value of a legacy custom_* field and whether it is
+// set. Explicit nulls are returned as a nil value.
+func (c *Contact) legacyFieldValue(field string) (interface{}, bool) {
+	accessor, ok := legacyFieldAccessors[field]
+	if !ok {
+		return nil, false
+	}
+	return nullableFieldValue(accessor(c))
+}
+
+// nullableFieldValue returns the value of a nullable contact field given a pointer to it,
+// and whether it is set. Explicit nulls are returned as a nil value.
+func nullableFieldValue(field interface{}) (interface{}, bool) {
+	switch ptr := field.(type) {
+	case **NullableString:
+		if *ptr == nil {
+			return nil, false
+		}
+		if (*ptr).IsNull {
+			return nil, true
+		}
+		return (*ptr).String, true
+	case **NullableFloat64:
+		if *ptr == nil {
+			return nil, false
+		}
+		if (*ptr).IsNull {
+			return nil, true
+		}
+		return (*ptr).Float64, true
+	case **NullableTime:
+		if *ptr == nil {
+			return nil, false
+		}
+		if (*ptr).IsNull {
+			return nil, true
+		}
+		return (*ptr).Time, true
+	case **NullableJSON:
+		if *ptr == nil {
+			return nil, false
+		}
+		if (*ptr).IsNull || (*ptr).Data == nil {
+			return nil, true
+		}
+		return (*ptr).Data, true
+	}
+	return nil, false
+}
+
+// setLegacyFieldValue sets a legacy custom_* field from a normalized attribute value, nil clears it
+func (c *Contact) setLegacyFieldValue(field string, value interface{}) error {
+	accessor, ok := legacyFieldAccessors[field]
+	if !ok {
+		return fmt.Errorf("invalid legacy field: %s", field)
+	}
+	switch ptr := accessor(c).(type) {
+	case **NullableString:
+		if value == nil {
+			*ptr = &NullableString{IsNull: true}
+			return nil
+		}
+		*ptr = &NullableString{String: value.(string)}
+	case **NullableFloat64:
+		if value == nil {
+			*ptr = &NullableFloat64{IsNull: true}
+			return nil
+		}
+		*ptr = &NullableFloat64{Float64: value.(float64)}
+	case **NullableTime:
+		if value == nil {
+			*ptr = &NullableTime{IsNull: true}
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, value.(string))
+		if err != nil {
+			return err
+		}
+		*ptr = &NullableTime{Time: t}
+	case **NullableJSON:
+		if value == nil {
+			*ptr = &NullableJSON{IsNull: true}
+			return nil
+		}
+		*ptr = &NullableJSON{Data: value}
+	}
+	return nil
+}
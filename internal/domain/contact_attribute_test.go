@@ -0,0 +1,439 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/pkg/analytics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func TestContactAttribute_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		attr    ContactAttribute
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "valid string with enum",
+			attr: ContactAttribute{Key: "plan", Type: ContactAttributeTypeString, Label: "Plan", Enum: []string{"free", "pro"}, Filterable: true},
+		},
+		{
+			name: "valid number with bounds",
+			attr: ContactAttribute{Key: "seats", Type: ContactAttributeTypeNumber, Min: float64Ptr(1), Max: float64Ptr(100)},
+		},
+		{
+			name: "valid datetime mapped to legacy field",
+			attr: ContactAttribute{Key: "trial_ends_at", Type: ContactAttributeTypeDatetime, LegacyField: "custom_datetime_2"},
+		},
+		{
+			name: "valid pii json",
+			attr: ContactAttribute{Key: "preferences", Type: ContactAttributeTypeJSON, PII: true},
+		},
+		{
+			name:    "invalid key",
+			attr:    ContactAttribute{Key: "Plan", Type: ContactAttributeTypeString},
+			wantErr: true,
+			errMsg:  "key must start with a lowercase letter",
+		},
+		{
+			name:    "invalid type",
+			attr:    ContactAttribute{Key: "plan", Type: "text"},
+			wantErr: true,
+			errMsg:  "invalid type: text",
+		},
+		{
+			name:    "enum on number",
+			attr:    ContactAttribute{Key: "seats", Type: ContactAttributeTypeNumber, Enum: []string{"1"}},
+			wantErr: true,
+			errMsg:  "can only be used with string attributes",
+		},
+		{
+			name:    "invalid pattern",
+			attr:    ContactAttribute{Key: "code", Type: ContactAttributeTypeString, Pattern: "[a-"},
+			wantErr: true,
+			errMsg:  "invalid pattern",
+		},
+		{
+			name:    "min on string",
+			attr:    ContactAttribute{Key: "plan", Type: ContactAttributeTypeString, Min: float64Ptr(1)},
+			wantErr: true,
+			errMsg:  "min and max can only be used with number attributes",
+		},
+		{
+			name:    "min greater than max",
+			attr:    ContactAttribute{Key: "seats", Type: ContactAttributeTypeNumber, Min: float64Ptr(10), Max: float64Ptr(1)},
+			wantErr: true,
+			errMsg:  "min must be lower than or equal to max",
+		},
+		{
+			name:    "filterable json",
+			attr:    ContactAttribute{Key: "preferences", Type: ContactAttributeTypeJSON, Filterable: true},
+			wantErr: true,
+			errMsg:  "filterable is only supported",
+		},
+		{
+			name:    "filterable datetime",
+			attr:    ContactAttribute{Key: "renewal_at", Type: ContactAttributeTypeDatetime, Filterable: true},
+			wantErr: true,
+			errMsg:  "filterable is only supported",
+		},
+		{
+			name:    "unknown legacy field",
+			attr:    ContactAttribute{Key: "plan", Type: ContactAttributeTypeString, LegacyField: "custom_string_6"},
+			wantErr: true,
+			errMsg:  "invalid legacy_field",
+		},
+		{
+			name:    "legacy field of another type",
+			attr:    ContactAttribute{Key: "plan", Type: ContactAttributeTypeString, LegacyField: "custom_number_1"},
+			wantErr: true,
+			errMsg:  "legacy_field custom_number_1 cannot store string values",
+		},
+		{
+			name:    "boolean cannot be mapped",
+			attr:    ContactAttribute{Key: "vip", Type: ContactAttributeTypeBoolean, LegacyField: "custom_string_1"},
+			wantErr: true,
+			errMsg:  "cannot store boolean values",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.attr.Validate()
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateContactAttributes(t *testing.T) {
+	t.Run("empty list is valid", func(t *testing.T) {
+		assert.NoError(t, ValidateContactAttributes(nil))
+	})
+
+	t.Run("duplicate keys", func(t *testing.T) {
+		err := ValidateContactAttributes([]ContactAttribute{
+			{Key: "plan", Type: ContactAttributeTypeString},
+			{Key: "plan", Type: ContactAttributeTypeNumber},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "duplicate contact attribute key: plan")
+	})
+
+	t.Run("legacy field mapped twice", func(t *testing.T) {
+		err := ValidateContactAttributes([]ContactAttribute{
+			{Key: "plan", Type: ContactAttributeTypeString, LegacyField: "custom_string_1"},
+			{Key: "tier", Type: ContactAttributeTypeString, LegacyField: "custom_string_1"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "custom_string_1 is mapped to several attributes")
+	})
+
+	t.Run("invalid attribute reports its index", func(t *testing.T) {
+		err := ValidateContactAttributes([]ContactAttribute{
+			{Key: "plan", Type: ContactAttributeTypeString},
+			{Key: "seats", Type: "integer"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "contact attribute at index 1")
+	})
+
+	t.Run("too many attributes", func(t *testing.T) {
+		attrs := make([]ContactAttribute, MaxContactAttributes+1)
+		for i := range attrs {
+			attrs[i] = ContactAttribute{Key: fmt.Sprintf("attr_%d", i), Type: ContactAttributeTypeString}
+		}
+		err := ValidateContactAttributes(attrs)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "at most 500 contact attributes")
+	})
+}
+
+func TestContactAttribute_NormalizeValue(t *testing.T) {
+	t.Run("string is trimmed and checked against enum", func(t *testing.T) {
+		attr := ContactAttribute{Key: "plan", Type: ContactAttributeTypeString, Enum: []string{"free", "pro"}}
+		value, err := attr.NormalizeValue(" pro ")
+		require.NoError(t, err)
+		assert.Equal(t, "pro", value)
+
+		_, err = attr.NormalizeValue("enterprise")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "value must be one of: free, pro")
+
+		_, err = attr.NormalizeValue(42.0)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected string")
+	})
+
+	t.Run("string pattern and max length", func(t *testing.T) {
+		attr := ContactAttribute{Key: "code", Type: ContactAttributeTypeString, Pattern: `^[A-Z]+$`, MaxLength: 3}
+		_, err := attr.NormalizeValue("abc")
+		assert.ErrorContains(t, err, "does not match pattern")
+		_, err = attr.NormalizeValue("ABCD")
+		assert.ErrorContains(t, err, "maximum length of 3")
+	})
+
+	t.Run("pattern is compiled once when the registry is loaded", func(t *testing.T) {
+		var attrs []ContactAttribute
+		require.NoError(t, json.Unmarshal([]byte(`[{"key":"code","type":"string","pattern":"^[A-Z]+$"}]`), &attrs))
+		require.NotNil(t, attrs[0].pattern)
+		compiled := attrs[0].pattern
+
+		_, err := attrs[0].NormalizeValue("ABC")
+		require.NoError(t, err)
+		_, err = attrs[0].NormalizeValue("abc")
+		assert.ErrorContains(t, err, "does not match pattern")
+		assert.Same(t, compiled, attrs[0].pattern)
+	})
+
+	t.Run("pattern is compiled by validate", func(t *testing.T) {
+		attr := ContactAttribute{Key: "code", Type: ContactAttributeTypeString, Pattern: `^[0-9]+$`}
+		require.NoError(t, attr.Validate())
+		require.NotNil(t, attr.pattern)
+		assert.Equal(t, `^[0-9]+$`, attr.pattern.String())
+	})
+
+	t.Run("invalid stored pattern is an error", func(t *testing.T) {
+		var attr ContactAttribute
+		require.NoError(t, json.Unmarshal([]byte(`{"key":"code","type":"string","pattern":"[a-"}`), &attr))
+		assert.Nil(t, attr.pattern)
+
+		_, err := attr.NormalizeValue("abc")
+		assert.ErrorContains(t, err, "invalid pattern")
+	})
+
+	t.Run("legacy string is limited to the column size", func(t *testing.T) {
+		attr := ContactAttribute{Key: "plan", Type: ContactAttributeTypeString, LegacyField: "custom_string_1"}
+		_, err := attr.NormalizeValue(strings.Repeat("a", 256))
+		assert.ErrorContains(t, err, "maximum length of 255")
+	})
+
+	t.Run("number bounds", func(t *testing.T) {
+		attr := ContactAttribute{Key: "seats", Type: ContactAttributeTypeNumber, Min: float64Ptr(1), Max: float64Ptr(10)}
+		value, err := attr.NormalizeValue(5)
+		require.NoError(t, err)
+		assert.Equal(t, 5.0, value)
+
+		_, err = attr.NormalizeValue(0.5)
+		assert.ErrorContains(t, err, "greater than or equal to 1")
+		_, err = attr.NormalizeValue(11.0)
+		assert.ErrorContains(t, err, "lower than or equal to 10")
+		_, err = attr.NormalizeValue("5")
+		assert.ErrorContains(t, err, "expected number")
+	})
+
+	t.Run("boolean", func(t *testing.T) {
+		attr := ContactAttribute{Key: "vip", Type: ContactAttributeTypeBoolean}
+		value, err := attr.NormalizeValue(true)
+		require.NoError(t, err)
+		assert.Equal(t, true, value)
+
+		_, err = attr.NormalizeValue("true")
+		assert.ErrorContains(t, err, "expected boolean")
+	})
+
+	t.Run("datetime is stored as RFC3339 UTC", func(t *testing.T) {
+		attr := ContactAttribute{Key: "trial_ends_at", Type: ContactAttributeTypeDatetime}
+		value, err := attr.NormalizeValue("2026-03-01T10:30:00+02:00")
+		require.NoError(t, err)
+		assert.Equal(t, "2026-03-01T08:30:00Z", value)
+
+		_, err = attr.NormalizeValue("March 1st")
+		assert.ErrorContains(t, err, "invalid time format")
+	})
+
+	t.Run("json accepts objects and arrays", func(t *testing.T) {
+		attr := ContactAttribute{Key: "preferences", Type: ContactAttributeTypeJSON}
+		_, err := attr.NormalizeValue(map[string]interface{}{"theme": "dark"})
+		assert.NoError(t, err)
+		_, err = attr.NormalizeValue([]interface{}{"a"})
+		assert.NoError(t, err)
+		_, err = attr.NormalizeValue("dark")
+		assert.ErrorContains(t, err, "expected JSON object or array")
+	})
+}
+
+func TestContact_NormalizeAttributes(t *testing.T) {
+	defs := []ContactAttribute{
+		{Key: "plan", Type: ContactAttributeTypeString, LegacyField: "custom_string_1"},
+		{Key: "seats", Type: ContactAttributeTypeNumber, LegacyField: "custom_number_2"},
+		{Key: "trial_ends_at", Type: ContactAttributeTypeDatetime, LegacyField: "custom_datetime_1"},
+		{Key: "vip", Type: ContactAttributeTypeBoolean},
+	}
+
+	t.Run("attributes are normalized and mirrored to legacy fields", func(t *testing.T) {
+		contact := &Contact{
+			Email:      "test@example.com",
+			Attributes: MapOfAny{"plan": "pro", "seats": 3, "trial_ends_at": "2026-03-01T10:30:00Z", "vip": true},
+		}
+		require.NoError(t, contact.NormalizeAttributes(defs))
+
+		assert.Equal(t, MapOfAny{"plan": "pro", "seats": 3.0, "trial_ends_at": "2026-03-01T10:30:00Z", "vip": true}, contact.Attributes)
+		require.NotNil(t, contact.CustomString1)
+		assert.Equal(t, "pro", contact.CustomString1.String)
+		require.NotNil(t, contact.CustomNumber2)
+		assert.Equal(t, 3.0, contact.CustomNumber2.Float64)
+		require.NotNil(t, contact.CustomDatetime1)
+		assert.True(t, contact.CustomDatetime1.Time.Equal(time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)))
+	})
+
+	t.Run("legacy fields are mirrored to attributes", func(t *testing.T) {
+		contact := &Contact{
+			Email:         "test@example.com",
+			CustomString1: &NullableString{String: "free"},
+			CustomNumber2: &NullableFloat64{IsNull: true},
+		}
+		require.NoError(t, contact.NormalizeAttributes(defs))
+
+		assert.Equal(t, MapOfAny{"plan": "free", "seats": nil}, contact.Attributes)
+	})
+
+	t.Run("attribute wins over its legacy field", func(t *testing.T) {
+		contact := &Contact{
+			Email:         "test@example.com",
+			CustomString1: &NullableString{String: "free"},
+			Attributes:    MapOfAny{"plan": "pro"},
+		}
+		require.NoError(t, contact.NormalizeAttributes(defs))
+
+		assert.Equal(t, "pro", contact.CustomString1.String)
+	})
+
+	t.Run("removed attribute clears its legacy field", func(t *testing.T) {
+		contact := &Contact{
+			Email:      "test@example.com",
+			Attributes: MapOfAny{"plan": nil},
+		}
+		require.NoError(t, contact.NormalizeAttributes(defs))
+
+		require.NotNil(t, contact.CustomString1)
+		assert.True(t, contact.CustomString1.IsNull)
+	})
+
+	t.Run("unknown attribute", func(t *testing.T) {
+		contact := &Contact{Email: "test@example.com", Attributes: MapOfAny{"color": "blue"}}
+		err := contact.NormalizeAttributes(defs)
+		assert.ErrorContains(t, err, "unknown contact attribute: color")
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		contact := &Contact{Email: "test@example.com", Attributes: MapOfAny{"vip": "yes"}}
+		err := contact.NormalizeAttributes(defs)
+		assert.ErrorContains(t, err, "invalid value for attribute vip")
+	})
+}
+
+func TestContact_HasAttributeData(t *testing.T) {
+	assert.False(t, (&Contact{Email: "test@example.com", FirstName: &NullableString{String: "John"}}).HasAttributeData())
+	assert.True(t, (&Contact{Email: "test@example.com", Attributes: MapOfAny{"plan": "pro"}}).HasAttributeData())
+	assert.True(t, (&Contact{Email: "test@example.com", CustomJSON3: &NullableJSON{IsNull: true}}).HasAttributeData())
+}
+
+func TestContactAttributeKeyFromField(t *testing.T) {
+	key, ok := ContactAttributeKeyFromField("attributes.plan")
+	assert.True(t, ok)
+	assert.Equal(t, "plan", key)
+
+	_, ok = ContactAttributeKeyFromField("computed.plan")
+	assert.False(t, ok)
+
+	_, ok = ContactAttributeKeyFromField("attributes.plan'); DROP TABLE contacts; --")
+	assert.False(t, ok)
+}
+
+func TestContactAttribute_Index(t *testing.T) {
+	plan := ContactAttribute{Key: "plan", Type: ContactAttributeTypeString, Filterable: true}
+	assert.Equal(t, "idx_attr_s_plan", plan.IndexName())
+	assert.Equal(t, "(attributes->>'plan')", plan.IndexSQL())
+
+	seats := ContactAttribute{Key: "seats", Type: ContactAttributeTypeNumber, Filterable: true}
+	assert.Equal(t, "idx_attr_n_seats", seats.IndexName())
+	assert.Equal(t, "(CASE WHEN jsonb_typeof(attributes->'seats') = 'number' THEN (attributes->>'seats')::numeric END)", seats.IndexSQL())
+
+	// The longest keys still fit in a PostgreSQL identifier
+	long := ContactAttribute{Key: strings.Repeat("a", 50), Type: ContactAttributeTypeBoolean}
+	assert.LessOrEqual(t, len(long.IndexName()), 63)
+}
+
+func TestFromJSON_Attributes(t *testing.T) {
+	t.Run("parses attributes object", func(t *testing.T) {
+		contact, err := FromJSON(`{"email": "test@example.com", "attributes": {"plan": "pro", "seats": 3, "vip": true, "old": null}}`)
+		require.NoError(t, err)
+		assert.Equal(t, MapOfAny{"plan": "pro", "seats": 3.0, "vip": true, "old": nil}, contact.Attributes)
+	})
+
+	t.Run("rejects non object attributes", func(t *testing.T) {
+		_, err := FromJSON(`{"email": "test@example.com", "attributes": ["plan"]}`)
+		assert.ErrorContains(t, err, "invalid type for attributes")
+	})
+}
+
+func TestContact_MergeAttributes(t *testing.T) {
+	contact := &Contact{Email: "test@example.com", Attributes: MapOfAny{"plan": "free", "seats": 1.0}}
+	contact.Merge(&Contact{Attributes: MapOfAny{"plan": "pro", "seats": nil, "vip": true}})
+
+	assert.Equal(t, MapOfAny{"plan": "pro", "vip": true}, contact.Attributes)
+}
+
+func TestWithContactAttributeDimensions(t *testing.T) {
+	schema := analytics.SchemaDefinition{
+		Name: "contacts",
+		Dimensions: map[string]analytics.DimensionDefinition{
+			"email": {Type: "string", SQL: "email"},
+		},
+	}
+	attrs := []ContactAttribute{
+		{Key: "plan", Type: ContactAttributeTypeString, Label: "Plan"},
+		{Key: "seats", Type: ContactAttributeTypeNumber},
+		{Key: "phone_backup", Type: ContactAttributeTypeString, PII: true},
+		{Key: "preferences", Type: ContactAttributeTypeJSON},
+	}
+
+	result := WithContactAttributeDimensions(schema, attrs)
+
+	assert.Len(t, result.Dimensions, 3)
+	assert.Equal(t, analytics.DimensionDefinition{Type: "string", Title: "Plan", SQL: "(attributes->>'plan')", Description: "Contact attribute plan"}, result.Dimensions["attr_plan"])
+	assert.Equal(t, "number", result.Dimensions["attr_seats"].Type)
+	assert.NotContains(t, result.Dimensions, "attr_phone_backup")
+	assert.NotContains(t, result.Dimensions, "attr_preferences")
+	// The predefined schema is left untouched
+	assert.Len(t, schema.Dimensions, 1)
+}
+
+func TestSetContactAttributesRequest_Validate(t *testing.T) {
+	valid := []ContactAttribute{{Key: "plan", Type: ContactAttributeTypeString}}
+
+	t.Run("valid request", func(t *testing.T) {
+		req := SetContactAttributesRequest{WorkspaceID: "workspace123", ContactAttributes: valid}
+		workspaceID, attrs, err := req.Validate()
+		require.NoError(t, err)
+		assert.Equal(t, "workspace123", workspaceID)
+		assert.Equal(t, valid, attrs)
+	})
+
+	t.Run("missing workspace ID", func(t *testing.T) {
+		req := SetContactAttributesRequest{ContactAttributes: valid}
+		_, _, err := req.Validate()
+		assert.ErrorContains(t, err, "workspace_id is required")
+	})
+
+	t.Run("invalid attribute", func(t *testing.T) {
+		req := SetContactAttributesRequest{WorkspaceID: "workspace123", ContactAttributes: []ContactAttribute{{Key: "plan", Type: "enum"}}}
+		_, _, err := req.Validate()
+		assert.ErrorContains(t, err, "invalid type: enum")
+	})
+}
@@ -0,0 +1,398 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+)
+
+//go:generate mockgen -destination mocks/mock_contact_bulk_operation_service.go -package mocks github.com/Notifuse/notifuse/internal/domain ContactBulkOperationService
+//go:generate mockgen -destination mocks/mock_contact_bulk_operation_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain ContactBulkOperationRepository
+
+// ContactBulkOperationStatus is the lifecycle state of a bulk operation
+type ContactBulkOperationStatus string
+
+const (
+	// ContactBulkOperationStatusPending is an operation waiting for its task to start
+	ContactBulkOperationStatusPending ContactBulkOperationStatus = "pending"
+	// ContactBulkOperationStatusRunning is an operation applying its action
+	ContactBulkOperationStatusRunning ContactBulkOperationStatus = "running"
+	// ContactBulkOperationStatusCompleted is an operation that went through all its contacts
+	ContactBulkOperationStatusCompleted ContactBulkOperationStatus = "completed"
+	// ContactBulkOperationStatusFailed is an operation stopped by an error affecting every contact
+	ContactBulkOperationStatusFailed ContactBulkOperationStatus = "failed"
+	// ContactBulkOperationStatusCancelled is an operation stopped by the user
+	ContactBulkOperationStatusCancelled ContactBulkOperationStatus = "cancelled"
+)
+
+// IsActive returns true until the operation is completed, failed or cancelled
+func (s ContactBulkOperationStatus) IsActive() bool {
+	return s == ContactBulkOperationStatusPending || s == ContactBulkOperationStatusRunning
+}
+
+// ContactBulkTargetType selects how the contacts of an operation are found
+type ContactBulkTargetType string
+
+const (
+	// ContactBulkTargetEmails is an explicit list of emails, unknown emails are ignored
+	ContactBulkTargetEmails ContactBulkTargetType = "emails"
+	// ContactBulkTargetSegment is the current members of a segment
+	ContactBulkTargetSegment ContactBulkTargetType = "segment"
+	// ContactBulkTargetList is the contacts of a list, optionally with a given status
+	ContactBulkTargetList ContactBulkTargetType = "list"
+	// ContactBulkTargetFilter is the contacts matching a segment-style condition tree
+	ContactBulkTargetFilter ContactBulkTargetType = "filter"
+)
+
+// ContactBulkActionType is the change applied to each targeted contact
+type ContactBulkActionType string
+
+const (
+	// ContactBulkActionDelete erases the contacts with their history, like contacts.delete
+	ContactBulkActionDelete ContactBulkActionType = "delete"
+	// ContactBulkActionAddToList subscribes the contacts to a list
+	ContactBulkActionAddToList ContactBulkActionType = "add_to_list"
+	// ContactBulkActionUnsubscribeFromList sets the unsubscribed status on a list
+	ContactBulkActionUnsubscribeFromList ContactBulkActionType = "unsubscribe_from_list"
+	// ContactBulkActionRemoveFromList removes the contacts from a list without recording an unsubscribe
+	ContactBulkActionRemoveFromList ContactBulkActionType = "remove_from_list"
+	// ContactBulkActionUpdateFields sets contact fields, null clears a field
+	ContactBulkActionUpdateFields ContactBulkActionType = "update_fields"
+	// ContactBulkActionExitAutomation exits the active journeys of an automation
+	ContactBulkActionExitAutomation ContactBulkActionType = "exit_automation"
+)
+
+// ContactBulkResultStatus is the outcome of an operation for one contact
+type ContactBulkResultStatus string
+
+const (
+	ContactBulkResultSucceeded ContactBulkResultStatus = "succeeded"
+	// ContactBulkResultSkipped is a contact the action did not apply to, e.g. not on the list
+	ContactBulkResultSkipped ContactBulkResultStatus = "skipped"
+	ContactBulkResultFailed  ContactBulkResultStatus = "failed"
+)
+
+const (
+	// MaxContactBulkEmails caps the emails of an "emails" target
+	MaxContactBulkEmails = 100000
+	// ContactBulkBatchSize is the number of contacts processed per batch
+	ContactBulkBatchSize = 500
+	// ContactBulkExitReason is the exit reason of journeys exited by a bulk operation
+	ContactBulkExitReason = "bulk_operation"
+)
+
+// ContactBulkTarget selects the contacts of an operation. The target is evaluated
+// while the operation runs, contacts entering it afterwards are not affected.
+type ContactBulkTarget struct {
+	Type       ContactBulkTargetType `json:"type"`
+	Emails     []string              `json:"emails,omitempty"`
+	SegmentID  string                `json:"segment_id,omitempty"`
+	ListID     string                `json:"list_id,omitempty"`
+	ListStatus ContactListStatus     `json:"list_status,omitempty"` // list targets only, all statuses when empty
+	Filter     *TreeNode             `json:"filter,omitempty"`
+}
+
+// Validate validates the target and normalizes its emails
+func (t *ContactBulkTarget) Validate() error {
+	switch t.Type {
+	case ContactBulkTargetEmails:
+		if len(t.Emails) == 0 {
+			return fmt.Errorf("target emails are required")
+		}
+		if len(t.Emails) > MaxContactBulkEmails {
+			return fmt.Errorf("target emails cannot exceed %d entries", MaxContactBulkEmails)
+		}
+		seen := make(map[string]bool, len(t.Emails))
+		emails := make([]string, 0, len(t.Emails))
+		for _, email := range t.Emails {
+			email = NormalizeEmail(email)
+			if !govalidator.IsEmail(email) {
+				return fmt.Errorf("invalid target email: %q", email)
+			}
+			if !seen[email] {
+				seen[email] = true
+				emails = append(emails, email)
+			}
+		}
+		t.Emails = emails
+	case ContactBulkTargetSegment:
+		if t.SegmentID == "" {
+			return fmt.Errorf("target segment_id is required")
+		}
+	case ContactBulkTargetList:
+		if t.ListID == "" {
+			return fmt.Errorf("target list_id is required")
+		}
+		switch t.ListStatus {
+		case "", ContactListStatusActive, ContactListStatusPending, ContactListStatusUnsubscribed,
+			ContactListStatusBounced, ContactListStatusComplained:
+		default:
+			return fmt.Errorf("invalid target list_status: %s", t.ListStatus)
+		}
+	case ContactBulkTargetFilter:
+		if t.Filter == nil {
+			return fmt.Errorf("target filter is required")
+		}
+		if err := t.Filter.Validate(); err != nil {
+			return fmt.Errorf("invalid target filter: %w", err)
+		}
+	default:
+		return fmt.Errorf("invalid target type: %s, must be emails, segment, list or filter", t.Type)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface
+func (t ContactBulkTarget) Value() (driver.Value, error) {
+	return json.Marshal(t)
+}
+
+// Scan implements the sql.Scanner interface
+func (t *ContactBulkTarget) Scan(val interface{}) error {
+	return scanContactImportJSON(val, t)
+}
+
+// ContactBulkAction is the change applied to each contact of the target
+type ContactBulkAction struct {
+	Type         ContactBulkActionType `json:"type"`
+	ListID       string                `json:"list_id,omitempty"`
+	ListStatus   ContactListStatus     `json:"list_status,omitempty"` // add_to_list, defaults to active
+	Fields       json.RawMessage       `json:"fields,omitempty"`      // update_fields, contact fields as in contacts.upsert
+	AutomationID string                `json:"automation_id,omitempty"`
+}
+
+// Validate validates the action and applies its defaults
+func (a *ContactBulkAction) Validate() error {
+	switch a.Type {
+	case ContactBulkActionDelete:
+	case ContactBulkActionAddToList:
+		if a.ListID == "" {
+			return fmt.Errorf("action list_id is required")
+		}
+		if a.ListStatus == "" {
+			a.ListStatus = ContactListStatusActive
+		}
+		switch a.ListStatus {
+		case ContactListStatusActive, ContactListStatusPending:
+		default:
+			return fmt.Errorf("invalid action list_status: %s, must be active or pending", a.ListStatus)
+		}
+	case ContactBulkActionUnsubscribeFromList, ContactBulkActionRemoveFromList:
+		if a.ListID == "" {
+			return fmt.Errorf("action list_id is required")
+		}
+	case ContactBulkActionUpdateFields:
+		if _, err := a.BuildContact("placeholder@example.com"); err != nil {
+			return err
+		}
+	case ContactBulkActionExitAutomation:
+		if a.AutomationID == "" {
+			return fmt.Errorf("action automation_id is required")
+		}
+	default:
+		return fmt.Errorf("invalid action type: %s", a.Type)
+	}
+	return nil
+}
+
+// BuildContact returns the contact upserted for email by an update_fields action
+func (a *ContactBulkAction) BuildContact(email string) (*Contact, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(a.Fields, &fields); err != nil || len(fields) == 0 {
+		return nil, fmt.Errorf("action fields must be a non-empty object")
+	}
+	if _, ok := fields["email"]; ok {
+		return nil, fmt.Errorf("action fields cannot change the email, use contacts.changeEmail")
+	}
+
+	encodedEmail, _ := json.Marshal(email)
+	fields["email"] = encodedEmail
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid action fields: %w", err)
+	}
+
+	contact, err := FromJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid action fields: %w", err)
+	}
+	return contact, nil
+}
+
+// Value implements the driver.Valuer interface
+func (a ContactBulkAction) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+// Scan implements the sql.Scanner interface
+func (a *ContactBulkAction) Scan(val interface{}) error {
+	return scanContactImportJSON(val, a)
+}
+
+// ContactBulkOperation applies an action to every contact of a target in the
+// background, through the bulk_contact_operation task. The outcome for each contact
+// is kept as the result log of the operation.
+type ContactBulkOperation struct {
+	ID     string                     `json:"id"`
+	Target ContactBulkTarget          `json:"target"`
+	Action ContactBulkAction          `json:"action"`
+	Status ContactBulkOperationStatus `json:"status"`
+	TaskID *string                    `json:"task_id,omitempty"`
+
+	// TotalCount is the size of the target when the operation was created
+	TotalCount     int `json:"total_count"`
+	ProcessedCount int `json:"processed_count"`
+	SucceededCount int `json:"succeeded_count"`
+	SkippedCount   int `json:"skipped_count"`
+	FailedCount    int `json:"failed_count"`
+
+	ErrorMessage *string `json:"error_message,omitempty"`
+	CreatedBy    string  `json:"created_by,omitempty"` // user or API key ID
+
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// ContactBulkOperationResult is the outcome of an operation for one contact
+type ContactBulkOperationResult struct {
+	Email     string                  `json:"email"`
+	Status    ContactBulkResultStatus `json:"status"`
+	Error     string                  `json:"error,omitempty"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+// ErasedContactResultEmail returns the value recorded in place of the address of a
+// contact erased by a bulk delete, so that the result log does not keep it
+func ErasedContactResultEmail(email string) string {
+	sum := sha256.Sum256([]byte(email))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// ErrContactBulkOperationNotFound is returned when a bulk operation does not exist
+type ErrContactBulkOperationNotFound struct {
+	Message string
+}
+
+func (e *ErrContactBulkOperationNotFound) Error() string {
+	return e.Message
+}
+
+// Request types
+
+// CreateContactBulkOperationRequest starts a bulk operation, or only counts its
+// target contacts when DryRun is set
+type CreateContactBulkOperationRequest struct {
+	WorkspaceID string            `json:"workspace_id"`
+	Target      ContactBulkTarget `json:"target"`
+	Action      ContactBulkAction `json:"action"`
+	DryRun      bool              `json:"dry_run"`
+}
+
+func (r *CreateContactBulkOperationRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if err := r.Target.Validate(); err != nil {
+		return err
+	}
+	return r.Action.Validate()
+}
+
+// CreateContactBulkOperationResponse holds the started operation, or the number of
+// contacts it would affect for a dry run
+type CreateContactBulkOperationResponse struct {
+	DryRun      bool                  `json:"dry_run"`
+	TargetCount int                   `json:"target_count"`
+	Operation   *ContactBulkOperation `json:"operation,omitempty"`
+}
+
+type GetContactBulkOperationRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ID          string `json:"id"`
+}
+
+func (r *GetContactBulkOperationRequest) FromURLParams(values url.Values) error {
+	r.WorkspaceID = values.Get("workspace_id")
+	r.ID = values.Get("id")
+	return r.Validate()
+}
+
+func (r *GetContactBulkOperationRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	return nil
+}
+
+type ListContactBulkOperationsRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+}
+
+func (r *ListContactBulkOperationsRequest) FromURLParams(values url.Values) error {
+	r.WorkspaceID = values.Get("workspace_id")
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	return nil
+}
+
+type CancelContactBulkOperationRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ID          string `json:"id"`
+}
+
+func (r *CancelContactBulkOperationRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	return nil
+}
+
+// ContactBulkOperationService runs bulk operations on contacts
+type ContactBulkOperationService interface {
+	// CreateContactBulkOperation counts the target contacts and, unless it is a dry
+	// run, starts the bulk_contact_operation task
+	CreateContactBulkOperation(ctx context.Context, req *CreateContactBulkOperationRequest) (*CreateContactBulkOperationResponse, error)
+	GetContactBulkOperation(ctx context.Context, workspaceID string, id string) (*ContactBulkOperation, error)
+	ListContactBulkOperations(ctx context.Context, workspaceID string) ([]*ContactBulkOperation, error)
+	// CancelContactBulkOperation stops an operation after its current batch
+	CancelContactBulkOperation(ctx context.Context, workspaceID string, id string) (*ContactBulkOperation, error)
+	// GetContactBulkOperationResultFile returns the outcome for each contact as a CSV file
+	GetContactBulkOperationResultFile(ctx context.Context, workspaceID string, id string) ([]byte, error)
+}
+
+// ContactBulkOperationRepository persists bulk operations and their result log in the
+// workspace database. Targets are given as a query selecting an email column.
+type ContactBulkOperationRepository interface {
+	Create(ctx context.Context, workspaceID string, operation *ContactBulkOperation) error
+	GetByID(ctx context.Context, workspaceID string, id string) (*ContactBulkOperation, error)
+	List(ctx context.Context, workspaceID string) ([]*ContactBulkOperation, error)
+	// Update saves the status and progress of an operation
+	Update(ctx context.Context, workspaceID string, operation *ContactBulkOperation) error
+	// CountTargets counts the existing contacts returned by the target query
+	CountTargets(ctx context.Context, workspaceID string, targetSQL string, args []interface{}) (int, error)
+	// GetTargetEmails returns the next emails of the target query after afterEmail, in email order
+	GetTargetEmails(ctx context.Context, workspaceID string, targetSQL string, args []interface{}, afterEmail string, limit int) ([]string, error)
+	// SaveBatch records the outcome of a batch and adds it to the counters of the operation,
+	// in one statement. The results of a retried batch are kept as first recorded and
+	// counted once. The operation gets the stored status and counters.
+	SaveBatch(ctx context.Context, workspaceID string, operation *ContactBulkOperation, results []*ContactBulkOperationResult) error
+	// ListResults returns the results after afterEmail, in email order
+	ListResults(ctx context.Context, workspaceID string, operationID string, afterEmail string, limit int) ([]*ContactBulkOperationResult, error)
+	// DeleteResultsForEmail deletes the results of every operation for an address
+	DeleteResultsForEmail(ctx context.Context, workspaceID string, email string) error
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactBulkTarget_Validate(t *testing.T) {
+	t.Run("emails are normalized and deduplicated", func(t *testing.T) {
+		target := ContactBulkTarget{Type: ContactBulkTargetEmails, Emails: []string{" John@Example.com", "john@example.com", "jane@example.com"}}
+		require.NoError(t, target.Validate())
+		assert.Equal(t, []string{"john@example.com", "jane@example.com"}, target.Emails)
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		target := ContactBulkTarget{Type: ContactBulkTargetEmails, Emails: []string{"not-an-email"}}
+		assert.Error(t, target.Validate())
+	})
+
+	t.Run("list status", func(t *testing.T) {
+		target := ContactBulkTarget{Type: ContactBulkTargetList, ListID: "news", ListStatus: ContactListStatusUnsubscribed}
+		assert.NoError(t, target.Validate())
+
+		target.ListStatus = "archived"
+		assert.Error(t, target.Validate())
+	})
+
+	t.Run("missing references", func(t *testing.T) {
+		assert.Error(t, (&ContactBulkTarget{Type: ContactBulkTargetEmails}).Validate())
+		assert.Error(t, (&ContactBulkTarget{Type: ContactBulkTargetSegment}).Validate())
+		assert.Error(t, (&ContactBulkTarget{Type: ContactBulkTargetList}).Validate())
+		assert.Error(t, (&ContactBulkTarget{Type: ContactBulkTargetFilter}).Validate())
+		assert.Error(t, (&ContactBulkTarget{Type: "everyone"}).Validate())
+	})
+}
+
+func TestContactBulkAction_Validate(t *testing.T) {
+	t.Run("add_to_list defaults to active", func(t *testing.T) {
+		action := ContactBulkAction{Type: ContactBulkActionAddToList, ListID: "news"}
+		require.NoError(t, action.Validate())
+		assert.Equal(t, ContactListStatusActive, action.ListStatus)
+
+		action.ListStatus = ContactListStatusUnsubscribed
+		assert.Error(t, action.Validate())
+	})
+
+	t.Run("list and automation references", func(t *testing.T) {
+		assert.Error(t, (&ContactBulkAction{Type: ContactBulkActionUnsubscribeFromList}).Validate())
+		assert.Error(t, (&ContactBulkAction{Type: ContactBulkActionRemoveFromList}).Validate())
+		assert.Error(t, (&ContactBulkAction{Type: ContactBulkActionExitAutomation}).Validate())
+		assert.NoError(t, (&ContactBulkAction{Type: ContactBulkActionDelete}).Validate())
+		assert.Error(t, (&ContactBulkAction{Type: "archive"}).Validate())
+	})
+
+	t.Run("update_fields", func(t *testing.T) {
+		assert.NoError(t, (&ContactBulkAction{Type: ContactBulkActionUpdateFields, Fields: json.RawMessage(`{"country":"FR"}`)}).Validate())
+		assert.Error(t, (&ContactBulkAction{Type: ContactBulkActionUpdateFields}).Validate())
+		assert.Error(t, (&ContactBulkAction{Type: ContactBulkActionUpdateFields, Fields: json.RawMessage(`{}`)}).Validate())
+		assert.Error(t, (&ContactBulkAction{Type: ContactBulkActionUpdateFields, Fields: json.RawMessage(`{"email":"other@example.com"}`)}).Validate())
+	})
+}
+
+func TestContactBulkAction_BuildContact(t *testing.T) {
+	action := ContactBulkAction{Type: ContactBulkActionUpdateFields, Fields: json.RawMessage(`{"country":"FR","job_title":null}`)}
+
+	contact, err := action.BuildContact("john@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "john@example.com", contact.Email)
+	require.NotNil(t, contact.Country)
+	assert.Equal(t, "FR", contact.Country.String)
+	require.NotNil(t, contact.JobTitle)
+	assert.True(t, contact.JobTitle.IsNull, "null clears the field")
+	assert.Nil(t, contact.FirstName, "fields not given are left untouched")
+}
+
+func TestContactBulkOperation_JSONColumns(t *testing.T) {
+	target := ContactBulkTarget{Type: ContactBulkTargetSegment, SegmentID: "seg1"}
+	value, err := target.Value()
+	require.NoError(t, err)
+
+	var scanned ContactBulkTarget
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, target, scanned)
+
+	action := ContactBulkAction{Type: ContactBulkActionExitAutomation, AutomationID: "auto1"}
+	value, err = action.Value()
+	require.NoError(t, err)
+
+	var scannedAction ContactBulkAction
+	require.NoError(t, scannedAction.Scan(value))
+	assert.Equal(t, action, scannedAction)
+}
+
+func TestErasedContactResultEmail(t *testing.T) {
+	erased := ErasedContactResultEmail("a@example.com")
+	assert.Equal(t, "sha256:08168cd80dfd534ab0f10af10f1303fe00af2d43ab5c1432360d137f8197e17a", erased)
+	assert.NotEqual(t, erased, ErasedContactResultEmail("b@example.com"))
+}
@@ -0,0 +1,709 @@
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+)
+
+//go:generate mockgen -destination mocks/mock_contact_import_service.go -package mocks github.com/Notifuse/notifuse/internal/domain ContactImportService
+//go:generate mockgen -destination mocks/mock_contact_import_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain ContactImportRepository
+
+// ContactImportStatus is the lifecycle state of a file import
+type ContactImportStatus string
+
+const (
+	// ContactImportStatusPending is a file uploaded and waiting for its mapping
+	ContactImportStatusPending ContactImportStatus = "pending"
+	// ContactImportStatusValidating is a dry run in progress
+	ContactImportStatusValidating ContactImportStatus = "validating"
+	// ContactImportStatusValidated is a file whose dry run completed, it can be imported
+	ContactImportStatusValidated ContactImportStatus = "validated"
+	// ContactImportStatusImporting is an import writing contacts
+	ContactImportStatusImporting ContactImportStatus = "importing"
+	// ContactImportStatusCompleted is a finished import
+	ContactImportStatusCompleted ContactImportStatus = "completed"
+	// ContactImportStatusFailed is an import stopped by an error affecting the whole file
+	ContactImportStatusFailed ContactImportStatus = "failed"
+)
+
+// IsRunning returns true while the import task is processing the file
+func (s ContactImportStatus) IsRunning() bool {
+	return s == ContactImportStatusValidating || s == ContactImportStatusImporting
+}
+
+// ContactImportSource is where the file of an import is read from
+type ContactImportSource string
+
+const (
+	// ContactImportSourceUpload is a file uploaded with the request, stored with the import
+	ContactImportSourceUpload ContactImportSource = "upload"
+	// ContactImportSourceS3 is an object of the workspace file manager bucket, read on each run
+	ContactImportSourceS3 ContactImportSource = "s3"
+)
+
+// ContactImportDedupeStrategy controls how rows matching an existing contact are applied
+type ContactImportDedupeStrategy string
+
+const (
+	// ContactImportDedupeOverwrite replaces the existing values with the non-empty cells of the row
+	ContactImportDedupeOverwrite ContactImportDedupeStrategy = "overwrite"
+	// ContactImportDedupeFillEmpty only sets the fields the existing contact has no value for
+	ContactImportDedupeFillEmpty ContactImportDedupeStrategy = "fill_empty"
+	// ContactImportDedupeSkip leaves existing contacts and their subscriptions untouched
+	ContactImportDedupeSkip ContactImportDedupeStrategy = "skip"
+)
+
+// IsValid returns true if the strategy is known
+func (s ContactImportDedupeStrategy) IsValid() bool {
+	switch s {
+	case ContactImportDedupeOverwrite, ContactImportDedupeFillEmpty, ContactImportDedupeSkip:
+		return true
+	}
+	return false
+}
+
+const (
+	// MaxContactImportFileSize is the maximum size of an imported file
+	MaxContactImportFileSize = 50 << 20
+	// MaxContactImportErrors is the number of row errors kept for the error file,
+	// the failed counter keeps counting past it
+	MaxContactImportErrors = 10000
+	// ContactImportPreviewRows is the number of rows returned to help mapping the columns
+	ContactImportPreviewRows = 5
+)
+
+// contactImportFieldTypes lists the contact fields a column can be mapped to, with the
+// type their cells are parsed as. Typed attributes are mapped as "attributes.<key>".
+var contactImportFieldTypes = map[string]string{
+	"email":             ContactAttributeTypeString,
+	"external_id":       ContactAttributeTypeString,
+	"timezone":          ContactAttributeTypeString,
+	"language":          ContactAttributeTypeString,
+	"first_name":        ContactAttributeTypeString,
+	"last_name":         ContactAttributeTypeString,
+	"full_name":         ContactAttributeTypeString,
+	"phone":             ContactAttributeTypeString,
+	"address_line_1":    ContactAttributeTypeString,
+	"address_line_2":    ContactAttributeTypeString,
+	"country":           ContactAttributeTypeString,
+	"postcode":          ContactAttributeTypeString,
+	"state":             ContactAttributeTypeString,
+	"job_title":         ContactAttributeTypeString,
+	"custom_string_1":   ContactAttributeTypeString,
+	"custom_string_2":   ContactAttributeTypeString,
+	"custom_string_3":   ContactAttributeTypeString,
+	"custom_string_4":   ContactAttributeTypeString,
+	"custom_string_5":   ContactAttributeTypeString,
+	"custom_number_1":   ContactAttributeTypeNumber,
+	"custom_number_2":   ContactAttributeTypeNumber,
+	"custom_number_3":   ContactAttributeTypeNumber,
+	"custom_number_4":   ContactAttributeTypeNumber,
+	"custom_number_5":   ContactAttributeTypeNumber,
+	"custom_datetime_1": ContactAttributeTypeDatetime,
+	"custom_datetime_2": ContactAttributeTypeDatetime,
+	"custom_datetime_3": ContactAttributeTypeDatetime,
+	"custom_datetime_4": ContactAttributeTypeDatetime,
+	"custom_datetime_5": ContactAttributeTypeDatetime,
+	"custom_json_1":     ContactAttributeTypeJSON,
+	"custom_json_2":     ContactAttributeTypeJSON,
+	"custom_json_3":     ContactAttributeTypeJSON,
+	"custom_json_4":     ContactAttributeTypeJSON,
+	"custom_json_5":     ContactAttributeTypeJSON,
+}
+
+// contactStringFieldAccessors returns a pointer to each standard contact field
+var contactStringFieldAccessors = map[string]func(c *Contact) **NullableString{
+	"external_id":    func(c *Contact) **NullableString { return &c.ExternalID },
+	"timezone":       func(c *Contact) **NullableString { return &c.Timezone },
+	"language":       func(c *Contact) **NullableString { return &c.Language },
+	"first_name":     func(c *Contact) **NullableString { return &c.FirstName },
+	"last_name":      func(c *Contact) **NullableString { return &c.LastName },
+	"full_name":      func(c *Contact) **NullableString { return &c.FullName },
+	"phone":          func(c *Contact) **NullableString { return &c.Phone },
+	"address_line_1": func(c *Contact) **NullableString { return &c.AddressLine1 },
+	"address_line_2": func(c *Contact) **NullableString { return &c.AddressLine2 },
+	"country":        func(c *Contact) **NullableString { return &c.Country },
+	"postcode":       func(c *Contact) **NullableString { return &c.Postcode },
+	"state":          func(c *Contact) **NullableString { return &c.State },
+	"job_title":      func(c *Contact) **NullableString { return &c.JobTitle },
+}
+
+// contactImportColumnAliases maps normalized column headers commonly found in exports to contact fields
+var contactImportColumnAliases = map[string]string{
+	"e_mail":         "email",
+	"email_address":  "email",
+	"mail":           "email",
+	"firstname":      "first_name",
+	"given_name":     "first_name",
+	"lastname":       "last_name",
+	"surname":        "last_name",
+	"family_name":    "last_name",
+	"name":           "full_name",
+	"fullname":       "full_name",
+	"phone_number":   "phone",
+	"mobile":         "phone",
+	"address":        "address_line_1",
+	"address_1":      "address_line_1",
+	"address_2":      "address_line_2",
+	"zip":            "postcode",
+	"zip_code":       "postcode",
+	"postal_code":    "postcode",
+	"region":         "state",
+	"province":       "state",
+	"title":          "job_title",
+	"locale":         "language",
+	"time_zone":      "timezone",
+	"id":             "external_id",
+	"user_id":        "external_id",
+	"customer_id":    "external_id",
+	"country_code":   "country",
+	"job":            "job_title",
+	"position":       "job_title",
+	"telephone":      "phone",
+	"cell":           "phone",
+	"mobile_phone":   "phone",
+	"address_line1":  "address_line_1",
+	"address_line2":  "address_line_2",
+	"street_address": "address_line_1",
+}
+
+var contactImportHeaderRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ContactImportMapping maps the column headers of the file to contact fields
+// ("first_name", "custom_number_1", ...) or typed attributes ("attributes.plan").
+// Unmapped columns are ignored.
+type ContactImportMapping map[string]string
+
+// Value implements the driver.Valuer interface
+func (m ContactImportMapping) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m)
+}
+
+// Scan implements the sql.Scanner interface
+func (m *ContactImportMapping) Scan(val interface{}) error {
+	return scanContactImportJSON(val, m)
+}
+
+// ContactImportRowError is a row of the file that could not be imported
+type ContactImportRowError struct {
+	Row   int    `json:"row"` // line number in the file, the header being line 1
+	Email string `json:"email,omitempty"`
+	Error string `json:"error"`
+}
+
+// ContactImportRowErrors is the list of row errors of an import
+type ContactImportRowErrors []ContactImportRowError
+
+// Value implements the driver.Valuer interface
+func (e ContactImportRowErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(e)
+}
+
+// Scan implements the sql.Scanner interface
+func (e *ContactImportRowErrors) Scan(val interface{}) error {
+	return scanContactImportJSON(val, e)
+}
+
+// ContactImportPreview holds the first rows of the file
+type ContactImportPreview [][]string
+
+// Value implements the driver.Valuer interface
+func (p ContactImportPreview) Value() (driver.Value, error) {
+	if p == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(p)
+}
+
+// Scan implements the sql.Scanner interface
+func (p *ContactImportPreview) Scan(val interface{}) error {
+	return scanContactImportJSON(val, p)
+}
+
+func scanContactImportJSON(val interface{}, target interface{}) error {
+	if val == nil {
+		return nil
+	}
+	var data []byte
+	switch v := val.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("type assertion to []byte failed")
+	}
+	return json.Unmarshal(data, target)
+}
+
+// ContactImport is a CSV or XLSX file of contacts imported in the background by
+// the import_contacts task. A file is validated with dry runs, which report the
+// row errors without writing anything, then imported once.
+type ContactImport struct {
+	ID       string              `json:"id"`
+	FileName string              `json:"file_name"`
+	Format   string              `json:"format"` // csv or xlsx
+	Source   ContactImportSource `json:"source"`
+	S3Key    string              `json:"s3_key,omitempty"`
+	FileSize int64               `json:"file_size"`
+
+	// Columns are the headers of the file, Preview its first rows
+	Columns []string             `json:"columns"`
+	Preview ContactImportPreview `json:"preview"`
+
+	// Import options, set when the import is started
+	Mapping        ContactImportMapping        `json:"mapping"`
+	ListIDs        []string                    `json:"list_ids"`
+	ListStatus     ContactListStatus           `json:"list_status,omitempty"`
+	DedupeStrategy ContactImportDedupeStrategy `json:"dedupe_strategy,omitempty"`
+
+	// Progress of the last run, DryRun tells whether it was a validation
+	Status        ContactImportStatus `json:"status"`
+	DryRun        bool                `json:"dry_run"`
+	TaskID        *string             `json:"task_id,omitempty"`
+	TotalRows     int                 `json:"total_rows"`
+	ProcessedRows int                 `json:"processed_rows"`
+	CreatedCount  int                 `json:"created_count"`
+	UpdatedCount  int                 `json:"updated_count"`
+	SkippedCount  int                 `json:"skipped_count"`
+	FailedCount   int                 `json:"failed_count"`
+	// Errors holds the first MaxContactImportErrors row errors
+	Errors       ContactImportRowErrors `json:"errors"`
+	ErrorMessage *string                `json:"error_message,omitempty"`
+
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	// FileData is the uploaded file, it is only loaded by the import task
+	FileData []byte `json:"-"`
+}
+
+// ResetProgress clears the counters before a new run
+func (i *ContactImport) ResetProgress() {
+	i.ProcessedRows = 0
+	i.CreatedCount = 0
+	i.UpdatedCount = 0
+	i.SkippedCount = 0
+	i.FailedCount = 0
+	i.Errors = ContactImportRowErrors{}
+	i.ErrorMessage = nil
+	i.CompletedAt = nil
+}
+
+// AddRowError records a row that could not be imported
+func (i *ContactImport) AddRowError(row int, email string, err string) {
+	i.FailedCount++
+	if len(i.Errors) < MaxContactImportErrors {
+		i.Errors = append(i.Errors, ContactImportRowError{Row: row, Email: email, Error: err})
+	}
+}
+
+// SetPreview keeps the first rows of the file
+func (i *ContactImport) SetPreview(rows [][]string) {
+	if len(rows) > ContactImportPreviewRows {
+		rows = rows[:ContactImportPreviewRows]
+	}
+	i.Preview = ContactImportPreview(rows)
+}
+
+// ErrContactImportNotFound is returned when an import does not exist
+type ErrContactImportNotFound struct {
+	Message string
+}
+
+func (e *ErrContactImportNotFound) Error() string {
+	return e.Message
+}
+
+// NormalizeImportHeader turns a column header into a field-like key ("First Name" -> "first_name")
+func NormalizeImportHeader(header string) string {
+	return strings.Trim(contactImportHeaderRegex.ReplaceAllString(strings.ToLower(header), "_"), "_")
+}
+
+// SuggestContactImportMapping maps the columns whose header matches a contact field,
+// a common alias of one, or a workspace attribute key. Each field is suggested once.
+func SuggestContactImportMapping(columns []string, attrs []ContactAttribute) ContactImportMapping {
+	attrKeys := make(map[string]bool, len(attrs))
+	for _, attr := range attrs {
+		attrKeys[attr.Key] = true
+	}
+
+	mapping := ContactImportMapping{}
+	used := map[string]bool{}
+	for _, column := range columns {
+		if _, ok := mapping[column]; ok || column == "" {
+			continue
+		}
+
+		key := NormalizeImportHeader(column)
+		field := ""
+		switch {
+		case contactImportFieldTypes[key] != "":
+			field = key
+		case contactImportColumnAliases[key] != "":
+			field = contactImportColumnAliases[key]
+		case attrKeys[key]:
+			field = ContactAttributeFieldPrefix + key
+		case strings.HasPrefix(key, "attributes_") && attrKeys[strings.TrimPrefix(key, "attributes_")]:
+			field = ContactAttributeFieldPrefix + strings.TrimPrefix(key, "attributes_")
+		}
+
+		if field != "" && !used[field] {
+			mapping[column] = field
+			used[field] = true
+		}
+	}
+	return mapping
+}
+
+// ValidateContactImportMapping checks the mapping refers to existing columns and fields,
+// maps each field once and maps the email
+func ValidateContactImportMapping(mapping ContactImportMapping, columns []string, attrs []ContactAttribute) error {
+	columnSet := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		columnSet[column] = true
+	}
+
+	mappedFields := make(map[string]string, len(mapping))
+	for column, field := range mapping {
+		if !columnSet[column] {
+			return fmt.Errorf("column %q does not exist in the file", column)
+		}
+		if field == "" {
+			continue
+		}
+		if _, err := contactImportFieldType(field, attrs); err != nil {
+			return fmt.Errorf("invalid field for column %q: %w", column, err)
+		}
+		if other, ok := mappedFields[field]; ok {
+			return fmt.Errorf("field %s is mapped to both columns %q and %q", field, other, column)
+		}
+		mappedFields[field] = column
+	}
+
+	if _, ok := mappedFields["email"]; !ok {
+		return fmt.Errorf("a column must be mapped to email")
+	}
+	return nil
+}
+
+// contactImportFieldType returns the type cells mapped to a field are parsed as
+func contactImportFieldType(field string, attrs []ContactAttribute) (string, error) {
+	if fieldType, ok := contactImportFieldTypes[field]; ok {
+		return fieldType, nil
+	}
+	if key, ok := ContactAttributeKeyFromField(field); ok {
+		for _, attr := range attrs {
+			if attr.Key == key {
+				return attr.Type, nil
+			}
+		}
+		return "", fmt.Errorf("unknown contact attribute: %s", key)
+	}
+	return "", fmt.Errorf("unknown contact field: %s", field)
+}
+
+// BuildContactFromImportRow creates a contact from a row of the file. Cells are parsed
+// according to the type of the field they are mapped to, empty cells are left unset
+// so they never erase existing values.
+func BuildContactFromImportRow(columns []string, row []string, mapping ContactImportMapping, attrs []ContactAttribute) (*Contact, error) {
+	contact := &Contact{}
+
+	for i, column := range columns {
+		field := mapping[column]
+		if field == "" || i >= len(row) {
+			continue
+		}
+		cell := strings.TrimSpace(row[i])
+		if cell == "" {
+			continue
+		}
+
+		fieldType, err := contactImportFieldType(field, attrs)
+		if err != nil {
+			return nil, err
+		}
+		value, err := parseContactImportCell(cell, fieldType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s (column %q): %w", field, column, err)
+		}
+
+		if key, ok := ContactAttributeKeyFromField(field); ok {
+			if contact.Attributes == nil {
+				contact.Attributes = MapOfAny{}
+			}
+			contact.Attributes[key] = value
+			continue
+		}
+
+		switch {
+		case field == "email":
+			contact.Email = NormalizeEmail(value.(string))
+		case contactStringFieldAccessors[field] != nil:
+			*contactStringFieldAccessors[field](contact) = &NullableString{String: trimUnicodeSpace(value.(string))}
+		default:
+			if err := contact.setLegacyFieldValue(field, value); err != nil {
+				return nil, fmt.Errorf("invalid value for %s (column %q): %w", field, column, err)
+			}
+		}
+	}
+
+	if contact.Email == "" {
+		return nil, fmt.Errorf("email is required")
+	}
+	if !govalidator.IsEmail(contact.Email) {
+		return nil, fmt.Errorf("invalid email format")
+	}
+
+	return contact, nil
+}
+
+// contactImportDatetimeLayouts are the datetime formats accepted in imported files,
+// dates without a timezone are read as UTC
+var contactImportDatetimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseContactImportCell converts a cell to the canonical value of a field type:
+// strings, float64 numbers, booleans, RFC3339 datetimes and decoded JSON
+func parseContactImportCell(cell string, fieldType string) (interface{}, error) {
+	switch fieldType {
+	case ContactAttributeTypeNumber:
+		f, err := strconv.ParseFloat(strings.ReplaceAll(cell, " ", ""), 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a number, got %q", cell)
+		}
+		return f, nil
+
+	case ContactAttributeTypeBoolean:
+		switch strings.ToLower(cell) {
+		case "true", "yes", "y", "1":
+			return true, nil
+		case "false", "no", "n", "0":
+			return false, nil
+		}
+		return nil, fmt.Errorf("expected true or false, got %q", cell)
+
+	case ContactAttributeTypeDatetime:
+		for _, layout := range contactImportDatetimeLayouts {
+			if t, err := time.Parse(layout, cell); err == nil {
+				return t.UTC().Format(time.RFC3339), nil
+			}
+		}
+		return nil, fmt.Errorf("expected a date formatted as YYYY-MM-DD or RFC3339, got %q", cell)
+
+	case ContactAttributeTypeJSON:
+		var value interface{}
+		if err := json.Unmarshal([]byte(cell), &value); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %v", err)
+		}
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return value, nil
+		}
+		return nil, fmt.Errorf("expected a JSON object or array")
+	}
+
+	return cell, nil
+}
+
+// KeepEmptyFields drops the values of the contact that are already set on existing,
+// so an upsert only fills the fields existing has no value for
+func (c *Contact) KeepEmptyFields(existing *Contact) {
+	for _, accessor := range contactStringFieldAccessors {
+		if value, isSet := nullableFieldValue(accessor(existing)); isSet && value != nil {
+			*accessor(c) = nil
+		}
+	}
+	for field, accessor := range legacyFieldAccessors {
+		if value, isSet := existing.legacyFieldValue(field); isSet && value != nil {
+			switch ptr := accessor(c).(type) {
+			case **NullableString:
+				*ptr = nil
+			case **NullableFloat64:
+				*ptr = nil
+			case **NullableTime:
+				*ptr = nil
+			case **NullableJSON:
+				*ptr = nil
+			}
+		}
+	}
+	for key := range c.Attributes {
+		if existing.Attributes[key] != nil {
+			delete(c.Attributes, key)
+		}
+	}
+}
+
+// Request types
+
+// CreateContactImportRequest registers the file of an import, either uploaded with
+// the request (multipart field "file") or read from the workspace file manager bucket
+type CreateContactImportRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	FileName    string `json:"file_name,omitempty"`
+	S3Key       string `json:"s3_key,omitempty"`
+
+	// FileData is the uploaded file
+	FileData []byte `json:"-"`
+}
+
+func (r *CreateContactImportRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if len(r.FileData) == 0 && r.S3Key == "" {
+		return fmt.Errorf("a file or s3_key is required")
+	}
+	if len(r.FileData) > 0 && r.S3Key != "" {
+		return fmt.Errorf("file and s3_key cannot be used together")
+	}
+	if len(r.FileData) > MaxContactImportFileSize {
+		return fmt.Errorf("file exceeds the maximum size of %d MB", MaxContactImportFileSize>>20)
+	}
+	if r.FileName == "" && r.S3Key != "" {
+		r.FileName = r.S3Key[strings.LastIndex(r.S3Key, "/")+1:]
+	}
+	if len(r.FileName) > 255 {
+		return fmt.Errorf("file_name exceeds maximum length of 255 characters")
+	}
+	return nil
+}
+
+// StartContactImportRequest runs a dry run or the import of a file
+type StartContactImportRequest struct {
+	WorkspaceID    string                      `json:"workspace_id"`
+	ID             string                      `json:"id"`
+	Mapping        ContactImportMapping        `json:"mapping"`
+	ListIDs        []string                    `json:"list_ids,omitempty"`
+	ListStatus     ContactListStatus           `json:"list_status,omitempty"`     // defaults to active
+	DedupeStrategy ContactImportDedupeStrategy `json:"dedupe_strategy,omitempty"` // defaults to overwrite
+	DryRun         bool                        `json:"dry_run"`
+}
+
+func (r *StartContactImportRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	if len(r.Mapping) == 0 {
+		return fmt.Errorf("mapping is required")
+	}
+	if r.DedupeStrategy == "" {
+		r.DedupeStrategy = ContactImportDedupeOverwrite
+	}
+	if !r.DedupeStrategy.IsValid() {
+		return fmt.Errorf("invalid dedupe_strategy: %s, must be overwrite, fill_empty or skip", r.DedupeStrategy)
+	}
+	if r.ListStatus == "" {
+		r.ListStatus = ContactListStatusActive
+	}
+	switch r.ListStatus {
+	case ContactListStatusActive, ContactListStatusPending, ContactListStatusUnsubscribed:
+	default:
+		return fmt.Errorf("invalid list_status: %s, must be active, pending or unsubscribed", r.ListStatus)
+	}
+	for _, listID := range r.ListIDs {
+		if listID == "" {
+			return fmt.Errorf("list_ids cannot contain empty values")
+		}
+	}
+	return nil
+}
+
+type GetContactImportRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ID          string `json:"id"`
+}
+
+func (r *GetContactImportRequest) FromURLParams(values url.Values) error {
+	r.WorkspaceID = values.Get("workspace_id")
+	r.ID = values.Get("id")
+	return r.Validate()
+}
+
+func (r *GetContactImportRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	return nil
+}
+
+type ListContactImportsRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+}
+
+func (r *ListContactImportsRequest) FromURLParams(values url.Values) error {
+	r.WorkspaceID = values.Get("workspace_id")
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	return nil
+}
+
+type DeleteContactImportRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ID          string `json:"id"`
+}
+
+func (r *DeleteContactImportRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	return nil
+}
+
+// ContactImportService manages file imports of contacts
+type ContactImportService interface {
+	// CreateContactImport stores the file, reads its columns and suggests a mapping
+	CreateContactImport(ctx context.Context, req *CreateContactImportRequest) (*ContactImport, error)
+	// StartContactImport saves the import options and starts the import_contacts task
+	StartContactImport(ctx context.Context, req *StartContactImportRequest) (*ContactImport, error)
+	GetContactImport(ctx context.Context, workspaceID string, id string) (*ContactImport, error)
+	ListContactImports(ctx context.Context, workspaceID string) ([]*ContactImport, error)
+	DeleteContactImport(ctx context.Context, workspaceID string, id string) error
+	// GetContactImportErrorFile returns the row errors of the last run as a CSV file
+	GetContactImportErrorFile(ctx context.Context, workspaceID string, id string) ([]byte, error)
+}
+
+// ContactImportRepository persists file imports in the workspace database
+type ContactImportRepository interface {
+	Create(ctx context.Context, workspaceID string, contactImport *ContactImport) error
+	// GetByID returns an import without its file
+	GetByID(ctx context.Context, workspaceID string, id string) (*ContactImport, error)
+	// GetFileData returns the uploaded file of an import
+	GetFileData(ctx context.Context, workspaceID string, id string) ([]byte, error)
+	List(ctx context.Context, workspaceID string) ([]*ContactImport, error)
+	// Update saves the options and progress of an import
+	Update(ctx context.Context, workspaceID string, contactImport *ContactImport) error
+	Delete(ctx context.Context, workspaceID string, id string) error
+}
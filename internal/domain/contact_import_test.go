@@ -0,0 +1,225 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testImportAttributes() []ContactAttribute {
+	return []ContactAttribute{
+		{Key: "plan", Type: ContactAttributeTypeString},
+		{Key: "seats", Type: ContactAttributeTypeNumber},
+		{Key: "vip", Type: ContactAttributeTypeBoolean},
+		{Key: "renewal", Type: ContactAttributeTypeDatetime},
+	}
+}
+
+func TestNormalizeImportHeader(t *testing.T) {
+	assert.Equal(t, "first_name", NormalizeImportHeader("First Name"))
+	assert.Equal(t, "e_mail", NormalizeImportHeader(" E-Mail "))
+	assert.Equal(t, "zip_code", NormalizeImportHeader("ZIP / Code"))
+}
+
+func TestSuggestContactImportMapping(t *testing.T) {
+	columns := []string{"Email Address", "First Name", "Surname", "Plan", "attributes.seats", "Mail", "Notes"}
+
+	mapping := SuggestContactImportMapping(columns, testImportAttributes())
+
+	assert.Equal(t, ContactImportMapping{
+		"Email Address":    "email",
+		"First Name":       "first_name",
+		"Surname":          "last_name",
+		"Plan":             "attributes.plan",
+		"attributes.seats": "attributes.seats",
+	}, mapping, "the second email column and unknown columns are left unmapped")
+}
+
+func TestValidateContactImportMapping(t *testing.T) {
+	columns := []string{"email", "name", "plan"}
+	attrs := testImportAttributes()
+
+	tests := []struct {
+		name    string
+		mapping ContactImportMapping
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			mapping: ContactImportMapping{"email": "email", "name": "first_name", "plan": "attributes.plan"},
+		},
+		{
+			name:    "unknown column",
+			mapping: ContactImportMapping{"email": "email", "phone": "phone"},
+			wantErr: "does not exist",
+		},
+		{
+			name:    "unknown field",
+			mapping: ContactImportMapping{"email": "email", "name": "nickname"},
+			wantErr: "unknown contact field",
+		},
+		{
+			name:    "unknown attribute",
+			mapping: ContactImportMapping{"email": "email", "plan": "attributes.tier"},
+			wantErr: "unknown contact attribute",
+		},
+		{
+			name:    "field mapped twice",
+			mapping: ContactImportMapping{"email": "email", "name": "first_name", "plan": "first_name"},
+			wantErr: "mapped to both",
+		},
+		{
+			name:    "email not mapped",
+			mapping: ContactImportMapping{"name": "first_name"},
+			wantErr: "must be mapped to email",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateContactImportMapping(tt.mapping, columns, attrs)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestBuildContactFromImportRow(t *testing.T) {
+	columns := []string{"email", "first", "score", "plan", "seats", "vip", "renewal", "last"}
+	mapping := ContactImportMapping{
+		"email":   "email",
+		"first":   "first_name",
+		"score":   "custom_number_1",
+		"plan":    "attributes.plan",
+		"seats":   "attributes.seats",
+		"vip":     "attributes.vip",
+		"renewal": "attributes.renewal",
+		"last":    "last_name",
+	}
+	attrs := testImportAttributes()
+
+	t.Run("parses cells by field type", func(t *testing.T) {
+		row := []string{" John@Example.com ", "John", "4.5", "pro", "10", "yes", "2024-03-01", ""}
+
+		contact, err := BuildContactFromImportRow(columns, row, mapping, attrs)
+		require.NoError(t, err)
+		assert.Equal(t, "john@example.com", contact.Email)
+		require.NotNil(t, contact.FirstName)
+		assert.Equal(t, "John", contact.FirstName.String)
+		require.NotNil(t, contact.CustomNumber1)
+		assert.Equal(t, 4.5, contact.CustomNumber1.Float64)
+		assert.Nil(t, contact.LastName, "empty cells are left unset")
+		assert.Equal(t, "pro", contact.Attributes["plan"])
+		assert.Equal(t, 10.0, contact.Attributes["seats"])
+		assert.Equal(t, true, contact.Attributes["vip"])
+		assert.Equal(t, "2024-03-01T00:00:00Z", contact.Attributes["renewal"])
+	})
+
+	t.Run("short rows", func(t *testing.T) {
+		contact, err := BuildContactFromImportRow(columns, []string{"jane@example.com"}, mapping, attrs)
+		require.NoError(t, err)
+		assert.Equal(t, "jane@example.com", contact.Email)
+	})
+
+	t.Run("invalid cells", func(t *testing.T) {
+		_, err := BuildContactFromImportRow(columns, []string{"john@example.com", "", "high"}, mapping, attrs)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected a number")
+
+		_, err = BuildContactFromImportRow(columns, []string{"john@example.com", "", "", "", "", "maybe"}, mapping, attrs)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected true or false")
+	})
+
+	t.Run("invalid email", func(t *testing.T) {
+		_, err := BuildContactFromImportRow(columns, []string{"not-an-email"}, mapping, attrs)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid email format")
+
+		_, err = BuildContactFromImportRow(columns, []string{""}, mapping, attrs)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "email is required")
+	})
+}
+
+func TestParseContactImportCell(t *testing.T) {
+	value, err := parseContactImportCell("2024-03-01 12:30:00", ContactAttributeTypeDatetime)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-01T12:30:00Z", value)
+
+	value, err = parseContactImportCell(`{"a":1}`, ContactAttributeTypeJSON)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": 1.0}, value)
+
+	_, err = parseContactImportCell(`"text"`, ContactAttributeTypeJSON)
+	assert.Error(t, err)
+
+	_, err = parseContactImportCell("01/03/2024", ContactAttributeTypeDatetime)
+	assert.Error(t, err)
+}
+
+func TestContact_KeepEmptyFields(t *testing.T) {
+	existing := &Contact{
+		Email:      "john@example.com",
+		FirstName:  &NullableString{String: "John"},
+		Attributes: MapOfAny{"plan": "free"},
+	}
+	imported := &Contact{
+		Email:      "john@example.com",
+		FirstName:  &NullableString{String: "Johnny"},
+		LastName:   &NullableString{String: "Doe"},
+		Attributes: MapOfAny{"plan": "pro", "seats": 3.0},
+	}
+
+	imported.KeepEmptyFields(existing)
+
+	assert.Nil(t, imported.FirstName)
+	require.NotNil(t, imported.LastName)
+	assert.Equal(t, "Doe", imported.LastName.String)
+	assert.Equal(t, MapOfAny{"seats": 3.0}, imported.Attributes)
+}
+
+func TestContactImport_AddRowError(t *testing.T) {
+	contactImport := &ContactImport{}
+	for i := 0; i < MaxContactImportErrors+5; i++ {
+		contactImport.AddRowError(i+2, "", "invalid email format")
+	}
+
+	assert.Equal(t, MaxContactImportErrors+5, contactImport.FailedCount)
+	assert.Len(t, contactImport.Errors, MaxContactImportErrors)
+
+	contactImport.ResetProgress()
+	assert.Zero(t, contactImport.FailedCount)
+	assert.Empty(t, contactImport.Errors)
+}
+
+func TestCreateContactImportRequest_Validate(t *testing.T) {
+	req := &CreateContactImportRequest{WorkspaceID: "ws1", S3Key: "imports/2024/contacts.csv"}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "contacts.csv", req.FileName)
+
+	req = &CreateContactImportRequest{WorkspaceID: "ws1"}
+	assert.Error(t, req.Validate())
+
+	req = &CreateContactImportRequest{WorkspaceID: "ws1", S3Key: "contacts.csv", FileData: []byte("email")}
+	assert.Error(t, req.Validate())
+}
+
+func TestStartContactImportRequest_Validate(t *testing.T) {
+	req := &StartContactImportRequest{WorkspaceID: "ws1", ID: "import1", Mapping: ContactImportMapping{"email": "email"}}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, ContactImportDedupeOverwrite, req.DedupeStrategy)
+	assert.Equal(t, ContactListStatusActive, req.ListStatus)
+
+	req.DedupeStrategy = "merge"
+	assert.Error(t, req.Validate())
+
+	req.DedupeStrategy = ContactImportDedupeSkip
+	req.ListStatus = ContactListStatusBounced
+	assert.Error(t, req.Validate())
+}
@@ -34,6 +34,25 @@ func IsTerminalContactListStatus(status ContactListStatus) bool {
 	return status == ContactListStatusBounced || status == ContactListStatusComplained
 }
 
+// ContactListFrequency is how often a contact wants to receive the broadcasts of a list
+type ContactListFrequency string
+
+const (
+	// ContactListFrequencyAll receives every broadcast sent to the list
+	ContactListFrequencyAll ContactListFrequency = "all"
+	// ContactListFrequencyWeeklyDigest only receives the broadcasts sent as a digest
+	ContactListFrequencyWeeklyDigest ContactListFrequency = "weekly_digest"
+)
+
+// IsValid returns true if the frequency is a known value
+func (f ContactListFrequency) IsValid() bool {
+	return f == ContactListFrequencyAll || f == ContactListFrequencyWeeklyDigest
+}
+
+// ContactListPauseDays are the marketing pause lengths, in days, a contact can choose
+// in the notification center
+var ContactListPauseDays = []int{30, 60, 90}
+
 // ContactList represents the relationship between a contact and a list
 type ContactList struct {
 	Email     string            `json:"email"`
@@ -43,6 +62,17 @@ type ContactList struct {
 	CreatedAt time.Time         `json:"created_at"`
 	UpdatedAt time.Time         `json:"updated_at"`
 	DeletedAt *time.Time        `json:"deleted_at"`
+	// Frequency is the delivery preference of the contact for the list
+	Frequency ContactListFrequency `json:"frequency,omitempty"`
+	// PausedUntil suspends marketing emails to the contact until that time
+	PausedUntil *time.Time `json:"paused_until,omitempty"`
+	// Consent is the latest consent ledger entry of the subscription, when requested
+	Consent *ConsentRecord `json:"consent,omitempty"`
+}
+
+// IsPaused returns true if marketing emails to the contact are paused at the given time
+func (cl *ContactList) IsPaused(now time.Time) bool {
+	return cl.PausedUntil != nil && cl.PausedUntil.After(now)
 }
 
 // Validate performs validation on the contact list fields
@@ -90,12 +120,14 @@ func (cl *ContactList) Validate() error {
 
 // For database scanning
 type dbContactList struct {
-	Email     string
-	ListID    string
-	Status    string
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt *time.Time
+	Email       string
+	ListID      string
+	Status      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   *time.Time
+	Frequency   string
+	PausedUntil *time.Time
 }
 
 // ScanContactList scans a contact list from the database
@@ -110,17 +142,21 @@ func ScanContactList(scanner interface {
 		&dbcl.CreatedAt,
 		&dbcl.UpdatedAt,
 		&dbcl.DeletedAt,
+		&dbcl.Frequency,
+		&dbcl.PausedUntil,
 	); err != nil {
 		return nil, err
 	}
 
 	cl := &ContactList{
-		Email:     dbcl.Email,
-		ListID:    dbcl.ListID,
-		Status:    ContactListStatus(dbcl.Status),
-		CreatedAt: dbcl.CreatedAt,
-		UpdatedAt: dbcl.UpdatedAt,
-		DeletedAt: dbcl.DeletedAt,
+		Email:       dbcl.Email,
+		ListID:      dbcl.ListID,
+		Status:      ContactListStatus(dbcl.Status),
+		CreatedAt:   dbcl.CreatedAt,
+		UpdatedAt:   dbcl.UpdatedAt,
+		DeletedAt:   dbcl.DeletedAt,
+		Frequency:   ContactListFrequency(dbcl.Frequency),
+		PausedUntil: dbcl.PausedUntil,
 	}
 
 	return cl, nil
@@ -345,8 +381,15 @@ type ContactListService interface {
 
 	// RemoveContactFromList removes a contact from a list
 	RemoveContactFromList(ctx context.Context, workspaceID string, email, listID string) error
+
+	// ListConsentRecords retrieves the consent ledger of a contact and/or a list
+	ListConsentRecords(ctx context.Context, req *ListConsentRecordsRequest) (*ListConsentRecordsResponse, error)
 }
 
+// ContactListRepository persists contact list subscriptions. Every status change made
+// through AddContactToList, BulkAddContactsToLists and UpdateContactListStatus that is a
+// consent action also appends an entry to the consent ledger, using the ConsentContext
+// found in ctx.
 type ContactListRepository interface {
 	// AddContactToList adds a contact to a list
 	AddContactToList(ctx context.Context, workspaceID string, contactList *ContactList) error
@@ -371,6 +414,16 @@ type ContactListRepository interface {
 
 	// DeleteForEmail deletes all contact list relationships for a specific email
 	DeleteForEmail(ctx context.Context, workspaceID, email string) error
+
+	// PauseContactLists pauses marketing emails on every list of a contact until pausedUntil,
+	// a nil pausedUntil resumes them
+	PauseContactLists(ctx context.Context, workspaceID string, email string, pausedUntil *time.Time) error
+
+	// UpdateContactListFrequency sets the delivery frequency of a contact on a list
+	UpdateContactListFrequency(ctx context.Context, workspaceID string, email, listID string, frequency ContactListFrequency) error
+
+	// ListConsentRecords retrieves consent ledger entries, most recent first
+	ListConsentRecords(ctx context.Context, workspaceID string, email, listID string, limit int, cursor *string) ([]*ConsentRecord, *string, error)
 }
 
 // ErrContactListNotFound is returned when a contact list is not found
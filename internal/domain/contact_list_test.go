@@ -121,6 +121,8 @@ func TestScanContactList(t *testing.T) {
 					status,             // Status
 					now,                // CreatedAt
 					now,                // UpdatedAt
+					nil,                // DeletedAt
+					"weekly_digest",    // Frequency
 				},
 			}
 
@@ -132,6 +134,8 @@ func TestScanContactList(t *testing.T) {
 			assert.Equal(t, domain.ContactListStatus(status), contactList.Status)
 			assert.Equal(t, now, contactList.CreatedAt)
 			assert.Equal(t, now, contactList.UpdatedAt)
+			assert.Equal(t, domain.ContactListFrequencyWeeklyDigest, contactList.Frequency)
+			assert.Nil(t, contactList.PausedUntil)
 		})
 	}
 
@@ -162,6 +166,23 @@ func TestIsTerminalContactListStatus(t *testing.T) {
 	assert.False(t, domain.IsTerminalContactListStatus(domain.ContactListStatusUnsubscribed))
 }
 
+func TestContactList_IsPaused(t *testing.T) {
+	now := time.Now()
+	future := now.Add(24 * time.Hour)
+	past := now.Add(-time.Hour)
+
+	assert.False(t, (&domain.ContactList{}).IsPaused(now))
+	assert.True(t, (&domain.ContactList{PausedUntil: &future}).IsPaused(now))
+	assert.False(t, (&domain.ContactList{PausedUntil: &past}).IsPaused(now))
+}
+
+func TestContactListFrequency_IsValid(t *testing.T) {
+	assert.True(t, domain.ContactListFrequencyAll.IsValid())
+	assert.True(t, domain.ContactListFrequencyWeeklyDigest.IsValid())
+	assert.False(t, domain.ContactListFrequency("daily").IsValid())
+	assert.False(t, domain.ContactListFrequency("").IsValid())
+}
+
 // Mock scanner for testing
 type contactListMockScanner struct {
 	data []interface{}
@@ -174,6 +195,9 @@ func (m *contactListMockScanner) Scan(dest ...interface{}) error {
 	}
 
 	for i, d := range dest {
+		if i >= len(m.data) {
+			break
+		}
 		switch v := d.(type) {
 		case *string:
 			if s, ok := m.data[i].(string); ok {
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+)
+
+// ErrContactEmailInUse is returned when an email change targets the address of
+// another contact, which must be merged instead
+var ErrContactEmailInUse = errors.New("another contact already uses this email")
+
+// ContactAliasReason tells why an address became an alias of a contact
+type ContactAliasReason string
+
+const (
+	ContactAliasReasonMerge       ContactAliasReason = "merge"
+	ContactAliasReasonEmailChange ContactAliasReason = "email_change"
+)
+
+// ContactAlias is a former address of a contact, kept after a merge or an email
+// change so that replies, inbound webhook events and links of messages sent to
+// it still resolve to the contact
+type ContactAlias struct {
+	Email        string             `json:"email"`
+	ContactEmail string             `json:"contact_email"`
+	Reason       ContactAliasReason `json:"reason"`
+	CreatedAt    time.Time          `json:"created_at"`
+}
+
+// ContactMergeStrategy decides which values win when two contacts are merged
+type ContactMergeStrategy string
+
+const (
+	// ContactMergeFillEmpty keeps the values of the surviving contact and only
+	// fills the fields it has no value for
+	ContactMergeFillEmpty ContactMergeStrategy = "fill_empty"
+	// ContactMergeOverwrite applies every value set on the merged contact
+	ContactMergeOverwrite ContactMergeStrategy = "overwrite"
+)
+
+func (s ContactMergeStrategy) IsValid() bool {
+	return s == ContactMergeFillEmpty || s == ContactMergeOverwrite
+}
+
+// MergeFieldsFrom returns the fields to upsert on c when other is merged into it.
+// Read-only values (computed properties, email verification) are not carried over
+// and the earliest creation date is kept.
+func (c *Contact) MergeFieldsFrom(other *Contact, strategy ContactMergeStrategy) *Contact {
+	fields := &Contact{}
+	fields.Merge(other)
+	fields.Email = c.Email
+	fields.CreatedAt = time.Time{}
+	fields.UpdatedAt = time.Time{}
+	fields.DBCreatedAt = time.Time{}
+	fields.DBUpdatedAt = time.Time{}
+
+	if strategy != ContactMergeOverwrite {
+		fields.KeepEmptyFields(c)
+	}
+	if !other.CreatedAt.IsZero() && other.CreatedAt.Before(c.CreatedAt) {
+		fields.CreatedAt = other.CreatedAt
+	}
+	return fields
+}
+
+// MergeContactsRequest merges the secondary contact into the primary one. The
+// secondary contact is deleted and its address becomes an alias of the primary.
+type MergeContactsRequest struct {
+	WorkspaceID    string               `json:"workspace_id"`
+	PrimaryEmail   string               `json:"primary_email"`
+	SecondaryEmail string               `json:"secondary_email"`
+	Strategy       ContactMergeStrategy `json:"strategy,omitempty"` // defaults to fill_empty
+}
+
+func (r *MergeContactsRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	r.PrimaryEmail = NormalizeEmail(r.PrimaryEmail)
+	r.SecondaryEmail = NormalizeEmail(r.SecondaryEmail)
+	if r.PrimaryEmail == "" {
+		return fmt.Errorf("primary_email is required")
+	}
+	if !govalidator.IsEmail(r.PrimaryEmail) {
+		return fmt.Errorf("invalid primary_email format")
+	}
+	if r.SecondaryEmail == "" {
+		return fmt.Errorf("secondary_email is required")
+	}
+	if !govalidator.IsEmail(r.SecondaryEmail) {
+		return fmt.Errorf("invalid secondary_email format")
+	}
+	if r.PrimaryEmail == r.SecondaryEmail {
+		return fmt.Errorf("primary_email and secondary_email must be different")
+	}
+	if r.Strategy == "" {
+		r.Strategy = ContactMergeFillEmpty
+	}
+	if !r.Strategy.IsValid() {
+		return fmt.Errorf("invalid strategy: %s, must be fill_empty or overwrite", r.Strategy)
+	}
+	return nil
+}
+
+// ChangeContactEmailRequest moves a contact to a new address. The previous
+// address becomes an alias of the contact.
+type ChangeContactEmailRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	Email       string `json:"email"`
+	NewEmail    string `json:"new_email"`
+}
+
+func (r *ChangeContactEmailRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	r.Email = NormalizeEmail(r.Email)
+	r.NewEmail = NormalizeEmail(r.NewEmail)
+	if r.Email == "" {
+		return fmt.Errorf("email is required")
+	}
+	if !govalidator.IsEmail(r.Email) {
+		return fmt.Errorf("invalid email format")
+	}
+	if r.NewEmail == "" {
+		return fmt.Errorf("new_email is required")
+	}
+	if !govalidator.IsEmail(r.NewEmail) {
+		return fmt.Errorf("invalid new_email format")
+	}
+	if r.Email == r.NewEmail {
+		return fmt.Errorf("new_email must be different from email")
+	}
+	return nil
+}
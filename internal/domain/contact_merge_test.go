@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeContactsRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     MergeContactsRequest
+		wantErr string
+	}{
+		{
+			name: "valid with default strategy",
+			req:  MergeContactsRequest{WorkspaceID: "ws1", PrimaryEmail: "John@Example.com ", SecondaryEmail: "john.doe@example.com"},
+		},
+		{
+			name:    "missing workspace",
+			req:     MergeContactsRequest{PrimaryEmail: "john@example.com", SecondaryEmail: "john.doe@example.com"},
+			wantErr: "workspace_id is required",
+		},
+		{
+			name:    "invalid secondary email",
+			req:     MergeContactsRequest{WorkspaceID: "ws1", PrimaryEmail: "john@example.com", SecondaryEmail: "john"},
+			wantErr: "invalid secondary_email format",
+		},
+		{
+			name:    "same contact",
+			req:     MergeContactsRequest{WorkspaceID: "ws1", PrimaryEmail: "john@example.com", SecondaryEmail: "JOHN@example.com"},
+			wantErr: "must be different",
+		},
+		{
+			name:    "invalid strategy",
+			req:     MergeContactsRequest{WorkspaceID: "ws1", PrimaryEmail: "john@example.com", SecondaryEmail: "john.doe@example.com", Strategy: "skip"},
+			wantErr: "invalid strategy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "john@example.com", tt.req.PrimaryEmail)
+			assert.Equal(t, ContactMergeFillEmpty, tt.req.Strategy)
+		})
+	}
+}
+
+func TestChangeContactEmailRequest_Validate(t *testing.T) {
+	req := ChangeContactEmailRequest{WorkspaceID: "ws1", Email: "old@example.com", NewEmail: " New@Example.com"}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "new@example.com", req.NewEmail)
+
+	req = ChangeContactEmailRequest{WorkspaceID: "ws1", Email: "old@example.com"}
+	assert.EqualError(t, req.Validate(), "new_email is required")
+
+	req = ChangeContactEmailRequest{WorkspaceID: "ws1", Email: "old@example.com", NewEmail: "OLD@example.com"}
+	assert.EqualError(t, req.Validate(), "new_email must be different from email")
+}
+
+func TestContact_MergeFieldsFrom(t *testing.T) {
+	earlier := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	primary := &Contact{
+		Email:      "john@example.com",
+		FirstName:  &NullableString{String: "John"},
+		Attributes: MapOfAny{"plan": "pro"},
+		CreatedAt:  earlier.AddDate(1, 0, 0),
+	}
+	secondary := &Contact{
+		Email:              "john.doe@example.com",
+		FirstName:          &NullableString{String: "Johnny"},
+		LastName:           &NullableString{String: "Doe"},
+		Attributes:         MapOfAny{"plan": "free", "company": "Acme"},
+		ComputedProperties: MapOfAny{"orders": 3},
+		EmailVerification:  &EmailVerification{Status: EmailVerificationStatusValid},
+		CreatedAt:          earlier,
+	}
+
+	t.Run("fill empty", func(t *testing.T) {
+		fields := primary.MergeFieldsFrom(secondary, ContactMergeFillEmpty)
+		assert.Equal(t, "john@example.com", fields.Email)
+		assert.Nil(t, fields.FirstName)
+		assert.Equal(t, "Doe", fields.LastName.String)
+		assert.Equal(t, MapOfAny{"company": "Acme"}, fields.Attributes)
+		assert.Nil(t, fields.ComputedProperties)
+		assert.Nil(t, fields.EmailVerification)
+		assert.Equal(t, earlier, fields.CreatedAt)
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		fields := primary.MergeFieldsFrom(secondary, ContactMergeOverwrite)
+		assert.Equal(t, "Johnny", fields.FirstName.String)
+		assert.Equal(t, MapOfAny{"plan": "free", "company": "Acme"}, fields.Attributes)
+		assert.Equal(t, MapOfAny{"plan": "pro"}, primary.Attributes)
+	})
+}
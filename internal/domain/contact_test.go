@@ -293,12 +293,14 @@ func TestScanContact(t *testing.T) {
 			now,                                                 // UpdatedAt
 			now,                                                 // DBCreatedAt
 			now,                                                 // DBUpdatedAt
+			[]byte(`{"orders_count": 3}`),                       // ComputedProperties
 		},
 	}
 
 	// Test successful scan
 	contact, err := ScanContact(scanner)
 	assert.NoError(t, err)
+	assert.Equal(t, float64(3), contact.ComputedProperties["orders_count"])
 	assert.Equal(t, "test@example.com", contact.Email)
 	assert.Equal(t, "ext123", contact.ExternalID.String)
 	assert.Equal(t, "Europe/Paris", contact.Timezone.String)
@@ -400,12 +402,14 @@ func TestScanContact(t *testing.T) {
 				time.Now(),     // UpdatedAt
 				time.Now(),     // DBCreatedAt
 				time.Now(),     // DBUpdatedAt
+				[]byte("null"), // ComputedProperties
 			},
 		}
 
 		contact, err := ScanContact(scanner)
 		assert.NoError(t, err)
 		assert.Equal(t, "test@example.com", contact.Email)
+		assert.Nil(t, contact.ComputedProperties)
 		assert.Nil(t, contact.ExternalID)
 		assert.Nil(t, contact.Timezone)
 		assert.Nil(t, contact.Language)
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+//go:generate mockgen -destination mocks/mock_email_verification_service.go -package mocks github.com/Notifuse/notifuse/internal/domain EmailVerificationService
+
+// EmailVerificationStatus is the deliverability verdict of an email address
+type EmailVerificationStatus string
+
+const (
+	// EmailVerificationStatusValid is an address that passed every check
+	EmailVerificationStatusValid EmailVerificationStatus = "valid"
+	// EmailVerificationStatusRisky is a deliverable address that may hurt engagement
+	// (role account, disposable domain, possible typo, catch-all domain)
+	EmailVerificationStatusRisky EmailVerificationStatus = "risky"
+	// EmailVerificationStatusUnknown is an address that could not be checked (DNS or SMTP failure)
+	EmailVerificationStatusUnknown EmailVerificationStatus = "unknown"
+	// EmailVerificationStatusInvalid is an address that cannot receive email
+	EmailVerificationStatusInvalid EmailVerificationStatus = "invalid"
+)
+
+// IsValid returns true if the status is a known verification status
+func (s EmailVerificationStatus) IsValid() bool {
+	switch s {
+	case EmailVerificationStatusValid, EmailVerificationStatusRisky,
+		EmailVerificationStatusUnknown, EmailVerificationStatusInvalid:
+		return true
+	}
+	return false
+}
+
+// EmailVerificationMode controls what happens to addresses failing verification
+type EmailVerificationMode string
+
+const (
+	// EmailVerificationModeFlag stores the verification on the contact and accepts the address
+	EmailVerificationModeFlag EmailVerificationMode = "flag"
+	// EmailVerificationModeBlock rejects addresses whose status is in BlockedStatuses
+	EmailVerificationModeBlock EmailVerificationMode = "block"
+)
+
+// EmailVerification is the outcome of the last verification of a contact email,
+// stored in contacts.email_verification
+type EmailVerification struct {
+	Status     EmailVerificationStatus `json:"status"`
+	Reasons    []string                `json:"reasons,omitempty"`
+	Suggestion string                  `json:"suggestion,omitempty"`
+	CatchAll   bool                    `json:"catch_all,omitempty"`
+	VerifiedAt time.Time               `json:"verified_at"`
+}
+
+// parseEmailVerification decodes the contacts.email_verification JSONB column
+func parseEmailVerification(data []byte) *EmailVerification {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	var verification EmailVerification
+	if err := json.Unmarshal(data, &verification); err != nil || verification.Status == "" {
+		return nil
+	}
+	return &verification
+}
+
+// EmailVerificationSettings configures the verification of addresses entering the
+// workspace through contact upserts, imports and list subscriptions
+type EmailVerificationSettings struct {
+	Enabled bool                  `json:"enabled"`
+	Mode    EmailVerificationMode `json:"mode"`
+	// Statuses rejected in block mode, defaults to invalid
+	BlockedStatuses []EmailVerificationStatus `json:"blocked_statuses,omitempty"`
+	// SMTPProbe checks the mailbox with the recipient mail server on single-contact
+	// ingestion. Imports never probe to keep them fast.
+	SMTPProbe bool `json:"smtp_probe"`
+}
+
+// Validate validates the email verification settings
+func (s *EmailVerificationSettings) Validate() error {
+	if s.Mode != EmailVerificationModeFlag && s.Mode != EmailVerificationModeBlock {
+		return fmt.Errorf("invalid mode: %s, must be %s or %s", s.Mode, EmailVerificationModeFlag, EmailVerificationModeBlock)
+	}
+	for _, status := range s.BlockedStatuses {
+		if !status.IsValid() {
+			return fmt.Errorf("invalid blocked status: %s", status)
+		}
+		if status == EmailVerificationStatusValid {
+			return fmt.Errorf("valid addresses cannot be blocked")
+		}
+	}
+	return nil
+}
+
+// Blocks returns true if an address with the given status must be rejected
+func (s *EmailVerificationSettings) Blocks(status EmailVerificationStatus) bool {
+	if !s.Enabled || s.Mode != EmailVerificationModeBlock {
+		return false
+	}
+	if len(s.BlockedStatuses) == 0 {
+		return status == EmailVerificationStatusInvalid
+	}
+	for _, blocked := range s.BlockedStatuses {
+		if blocked == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrEmailVerificationBlocked is returned when an address is rejected by the
+// workspace email verification settings
+type ErrEmailVerificationBlocked struct {
+	Email        string
+	Verification *EmailVerification
+}
+
+func (e *ErrEmailVerificationBlocked) Error() string {
+	msg := fmt.Sprintf("email address %s failed verification (%s)", e.Email, e.Verification.Status)
+	if e.Verification.Suggestion != "" {
+		msg += fmt.Sprintf(", did you mean %s?", e.Verification.Suggestion)
+	}
+	return msg
+}
+
+// VerifyContactEmailRequest is the request to verify an email address on demand
+type VerifyContactEmailRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	Email       string `json:"email"`
+}
+
+// Validate validates the verify contact email request
+func (r *VerifyContactEmailRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.Email == "" {
+		return fmt.Errorf("email is required")
+	}
+	return nil
+}
+
+// EmailVerificationService verifies email addresses
+type EmailVerificationService interface {
+	// VerifyEmail runs every check including the SMTP probe, and stores the result
+	// on the contact when the address belongs to a contact of the workspace
+	VerifyEmail(ctx context.Context, workspaceID string, email string) (*EmailVerification, error)
+
+	// VerifyForIngestion verifies an address entering the workspace according to its
+	// settings. It returns nil when verification is disabled, and an
+	// ErrEmailVerificationBlocked along with the verification when the address is rejected.
+	// allowSMTPProbe lets bulk callers skip the probe even if the settings enable it.
+	VerifyForIngestion(ctx context.Context, workspace *Workspace, email string, allowSMTPProbe bool) (*EmailVerification, error)
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailVerificationSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings EmailVerificationSettings
+		wantErr  string
+	}{
+		{"flag mode", EmailVerificationSettings{Enabled: true, Mode: EmailVerificationModeFlag}, ""},
+		{"block mode with statuses", EmailVerificationSettings{Enabled: true, Mode: EmailVerificationModeBlock, BlockedStatuses: []EmailVerificationStatus{EmailVerificationStatusInvalid, EmailVerificationStatusRisky}}, ""},
+		{"missing mode", EmailVerificationSettings{Enabled: true}, "invalid mode"},
+		{"unknown status", EmailVerificationSettings{Mode: EmailVerificationModeBlock, BlockedStatuses: []EmailVerificationStatus{"bad"}}, "invalid blocked status"},
+		{"valid status blocked", EmailVerificationSettings{Mode: EmailVerificationModeBlock, BlockedStatuses: []EmailVerificationStatus{EmailVerificationStatusValid}}, "cannot be blocked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEmailVerificationSettings_Blocks(t *testing.T) {
+	t.Run("defaults to invalid", func(t *testing.T) {
+		settings := &EmailVerificationSettings{Enabled: true, Mode: EmailVerificationModeBlock}
+		assert.True(t, settings.Blocks(EmailVerificationStatusInvalid))
+		assert.False(t, settings.Blocks(EmailVerificationStatusRisky))
+	})
+
+	t.Run("configured statuses", func(t *testing.T) {
+		settings := &EmailVerificationSettings{
+			Enabled:         true,
+			Mode:            EmailVerificationModeBlock,
+			BlockedStatuses: []EmailVerificationStatus{EmailVerificationStatusRisky},
+		}
+		assert.True(t, settings.Blocks(EmailVerificationStatusRisky))
+		assert.False(t, settings.Blocks(EmailVerificationStatusInvalid))
+	})
+
+	t.Run("flag mode never blocks", func(t *testing.T) {
+		settings := &EmailVerificationSettings{Enabled: true, Mode: EmailVerificationModeFlag}
+		assert.False(t, settings.Blocks(EmailVerificationStatusInvalid))
+	})
+
+	t.Run("disabled never blocks", func(t *testing.T) {
+		settings := &EmailVerificationSettings{Mode: EmailVerificationModeBlock}
+		assert.False(t, settings.Blocks(EmailVerificationStatusInvalid))
+	})
+}
+
+func TestErrEmailVerificationBlocked(t *testing.T) {
+	err := &ErrEmailVerificationBlocked{
+		Email:        "john@gmial.com",
+		Verification: &EmailVerification{Status: EmailVerificationStatusRisky, Suggestion: "john@gmail.com"},
+	}
+	assert.Equal(t, "email address john@gmial.com failed verification (risky), did you mean john@gmail.com?", err.Error())
+
+	err.Verification.Suggestion = ""
+	assert.Equal(t, "email address john@gmial.com failed verification (risky)", err.Error())
+}
+
+func TestParseEmailVerification(t *testing.T) {
+	assert.Nil(t, parseEmailVerification(nil))
+	assert.Nil(t, parseEmailVerification([]byte("null")))
+	assert.Nil(t, parseEmailVerification([]byte("{}")))
+
+	verification := parseEmailVerification([]byte(`{"status":"invalid","reasons":["no_mx"],"verified_at":"2026-03-01T12:00:00Z"}`))
+	require.NotNil(t, verification)
+	assert.Equal(t, EmailVerificationStatusInvalid, verification.Status)
+	assert.Equal(t, []string{"no_mx"}, verification.Reasons)
+}
+
+func TestWorkspaceSettings_ValidateEmailVerification(t *testing.T) {
+	settings := &WorkspaceSettings{
+		Timezone:          "UTC",
+		DefaultLanguage:   "en",
+		Languages:         []string{"en"},
+		EmailVerification: &EmailVerificationSettings{Enabled: true, Mode: "reject"},
+	}
+	assert.ErrorContains(t, settings.Validate("passphrase"), "invalid email verification settings")
+
+	settings.EmailVerification.Mode = EmailVerificationModeBlock
+	assert.NoError(t, settings.Validate("passphrase"))
+}
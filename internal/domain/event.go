@@ -21,6 +21,8 @@ const (
 	EventBroadcastFailed         EventType = "broadcast.failed"
 	EventBroadcastCancelled      EventType = "broadcast.cancelled"
 	EventBroadcastCircuitBreaker EventType = "broadcast.circuit_breaker"
+
+	EventWebhookSubscriptionDisabled EventType = "webhook_subscription.disabled"
 )
 
 // EventPayload represents the data associated with an event
@@ -0,0 +1,351 @@
+package domain
+
+//go:generate mockgen -destination mocks/mock_event_sink.go -package mocks github.com/Notifuse/notifuse/internal/domain EventSink,EventSinkFactory
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+
+	"github.com/Notifuse/notifuse/pkg/crypto"
+)
+
+// EventSinkType identifies the streaming platform a webhook subscription publishes to
+type EventSinkType string
+
+const (
+	EventSinkTypeKafka    EventSinkType = "kafka"
+	EventSinkTypeNATS     EventSinkType = "nats"
+	EventSinkTypePostgres EventSinkType = "postgres"
+)
+
+// KafkaSASLMechanism is the SASL mechanism authenticating a Kafka sink
+type KafkaSASLMechanism string
+
+const (
+	KafkaSASLMechanismPlain       KafkaSASLMechanism = "PLAIN"
+	KafkaSASLMechanismScramSHA256 KafkaSASLMechanism = "SCRAM-SHA-256"
+	KafkaSASLMechanismScramSHA512 KafkaSASLMechanism = "SCRAM-SHA-512"
+)
+
+const (
+	// DefaultEventSinkBatchSize is the number of events published per batch when not configured
+	DefaultEventSinkBatchSize = 100
+	// MaxEventSinkBatchSize caps the configurable batch size
+	MaxEventSinkBatchSize = 500
+)
+
+var (
+	natsSubjectRegexp     = regexp.MustCompile(`^[A-Za-z0-9_\-]+(\.[A-Za-z0-9_\-]+)*$`)
+	postgresChannelRegexp = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+	kafkaTopicRegexp      = regexp.MustCompile(`^[A-Za-z0-9._\-]{1,249}$`)
+)
+
+// EventSinkSettings routes the events of a webhook subscription to a streaming sink
+// instead of an HTTP endpoint. Events keep the webhook envelope and are signed with
+// the subscription secret; the Standard Webhooks headers travel as message metadata.
+type EventSinkSettings struct {
+	Type      EventSinkType         `json:"type"`
+	BatchSize int                   `json:"batch_size,omitempty"`
+	Kafka     *KafkaSinkSettings    `json:"kafka,omitempty"`
+	NATS      *NATSSinkSettings     `json:"nats,omitempty"`
+	Postgres  *PostgresSinkSettings `json:"postgres,omitempty"`
+}
+
+// KafkaSinkSettings produces to a Kafka topic through the brokers. Records are keyed by
+// contact email, so the events of a contact land in the same partition and keep their order.
+type KafkaSinkSettings struct {
+	Brokers       []string           `json:"brokers"` // bootstrap brokers, host:port
+	Topic         string             `json:"topic"`
+	TLS           bool               `json:"tls,omitempty"`
+	SASLMechanism KafkaSASLMechanism `json:"sasl_mechanism,omitempty"` // no authentication when empty
+	Username      string             `json:"username,omitempty"`       // API key when using Confluent Cloud
+	// EncryptedPassword is the API secret when using Confluent Cloud
+	EncryptedPassword string `json:"encrypted_password,omitempty"`
+
+	// Decoded password, not stored in the database
+	Password string `json:"password,omitempty"`
+}
+
+// NATSSinkSettings publishes to NATS subjects `{subject}.{event_type}`. With JetStream,
+// every message waits for the stream acknowledgement.
+type NATSSinkSettings struct {
+	URL               string `json:"url"` // nats://host:4222 or tls://host:4222, without credentials
+	Subject           string `json:"subject"`
+	Username          string `json:"username,omitempty"`
+	EncryptedPassword string `json:"encrypted_password,omitempty"`
+	EncryptedToken    string `json:"encrypted_token,omitempty"`
+	JetStream         bool   `json:"jetstream,omitempty"`
+
+	// Decoded credentials, not stored in the database
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
+}
+
+// PostgresSinkSettings sends events with NOTIFY on a channel of the workspace database
+type PostgresSinkSettings struct {
+	Channel string `json:"channel"`
+}
+
+// Validate validates the sink settings
+func (s *EventSinkSettings) Validate() error {
+	if s.BatchSize < 0 || s.BatchSize > MaxEventSinkBatchSize {
+		return fmt.Errorf("batch_size must be between 1 and %d", MaxEventSinkBatchSize)
+	}
+
+	switch s.Type {
+	case EventSinkTypeKafka:
+		if s.Kafka == nil {
+			return fmt.Errorf("kafka settings are required")
+		}
+		return s.Kafka.Validate()
+	case EventSinkTypeNATS:
+		if s.NATS == nil {
+			return fmt.Errorf("nats settings are required")
+		}
+		return s.NATS.Validate()
+	case EventSinkTypePostgres:
+		if s.Postgres == nil {
+			return fmt.Errorf("postgres settings are required")
+		}
+		return s.Postgres.Validate()
+	default:
+		return fmt.Errorf("invalid sink type: %s", s.Type)
+	}
+}
+
+// GetBatchSize returns the configured batch size or the default
+func (s *EventSinkSettings) GetBatchSize() int {
+	if s.BatchSize <= 0 {
+		return DefaultEventSinkBatchSize
+	}
+	return s.BatchSize
+}
+
+// Destination returns a URL-like description of where the events are published,
+// stored as the subscription URL
+func (s *EventSinkSettings) Destination() string {
+	switch s.Type {
+	case EventSinkTypeKafka:
+		if s.Kafka != nil {
+			return "kafka://" + s.Kafka.Topic
+		}
+	case EventSinkTypeNATS:
+		if s.NATS != nil {
+			return "nats://" + s.NATS.Subject
+		}
+	case EventSinkTypePostgres:
+		if s.Postgres != nil {
+			return "postgres://" + s.Postgres.Channel
+		}
+	}
+	return ""
+}
+
+// EncryptSecretKeys encrypts the credentials of the sink and clears their decoded value
+func (s *EventSinkSettings) EncryptSecretKeys(passphrase string) error {
+	if s.Type == EventSinkTypeKafka && s.Kafka != nil && s.Kafka.Password != "" {
+		encrypted, err := crypto.EncryptString(s.Kafka.Password, passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt kafka password: %w", err)
+		}
+		s.Kafka.EncryptedPassword = encrypted
+		s.Kafka.Password = ""
+	}
+
+	if s.Type == EventSinkTypeNATS && s.NATS != nil {
+		if s.NATS.Password != "" {
+			encrypted, err := crypto.EncryptString(s.NATS.Password, passphrase)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt nats password: %w", err)
+			}
+			s.NATS.EncryptedPassword = encrypted
+			s.NATS.Password = ""
+		}
+		if s.NATS.Token != "" {
+			encrypted, err := crypto.EncryptString(s.NATS.Token, passphrase)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt nats token: %w", err)
+			}
+			s.NATS.EncryptedToken = encrypted
+			s.NATS.Token = ""
+		}
+	}
+
+	return nil
+}
+
+// DecryptSecretKeys decrypts the encrypted credentials of the sink
+func (s *EventSinkSettings) DecryptSecretKeys(passphrase string) error {
+	if s.Type == EventSinkTypeKafka && s.Kafka != nil && s.Kafka.EncryptedPassword != "" {
+		password, err := crypto.DecryptFromHexString(s.Kafka.EncryptedPassword, passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt kafka password: %w", err)
+		}
+		s.Kafka.Password = password
+	}
+
+	if s.Type == EventSinkTypeNATS && s.NATS != nil {
+		if s.NATS.EncryptedPassword != "" {
+			password, err := crypto.DecryptFromHexString(s.NATS.EncryptedPassword, passphrase)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt nats password: %w", err)
+			}
+			s.NATS.Password = password
+		}
+		if s.NATS.EncryptedToken != "" {
+			token, err := crypto.DecryptFromHexString(s.NATS.EncryptedToken, passphrase)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt nats token: %w", err)
+			}
+			s.NATS.Token = token
+		}
+	}
+
+	return nil
+}
+
+// PreserveSecretKeys keeps the encrypted credentials of the existing settings that an
+// update does not provide again, as long as the sink type is unchanged
+func (s *EventSinkSettings) PreserveSecretKeys(existing *EventSinkSettings) {
+	if existing == nil || existing.Type != s.Type {
+		return
+	}
+
+	if s.Kafka != nil && existing.Kafka != nil &&
+		s.Kafka.Password == "" && s.Kafka.EncryptedPassword == "" {
+		s.Kafka.EncryptedPassword = existing.Kafka.EncryptedPassword
+	}
+
+	if s.NATS != nil && existing.NATS != nil {
+		if s.NATS.Password == "" && s.NATS.EncryptedPassword == "" {
+			s.NATS.EncryptedPassword = existing.NATS.EncryptedPassword
+		}
+		if s.NATS.Token == "" && s.NATS.EncryptedToken == "" {
+			s.NATS.EncryptedToken = existing.NATS.EncryptedToken
+		}
+	}
+}
+
+// Clone returns a copy of the settings that shares nothing with them
+func (s *EventSinkSettings) Clone() *EventSinkSettings {
+	cloned := *s
+	if s.Kafka != nil {
+		kafka := *s.Kafka
+		kafka.Brokers = append([]string(nil), s.Kafka.Brokers...)
+		cloned.Kafka = &kafka
+	}
+	if s.NATS != nil {
+		nats := *s.NATS
+		cloned.NATS = &nats
+	}
+	if s.Postgres != nil {
+		postgres := *s.Postgres
+		cloned.Postgres = &postgres
+	}
+	return &cloned
+}
+
+// Masked returns a copy of the settings without the decoded credentials, for API responses
+func (s *EventSinkSettings) Masked() *EventSinkSettings {
+	masked := s.Clone()
+	if masked.Kafka != nil {
+		masked.Kafka.Password = ""
+	}
+	if masked.NATS != nil {
+		masked.NATS.Password = ""
+		masked.NATS.Token = ""
+	}
+	return masked
+}
+
+// Validate validates the Kafka sink settings
+func (k *KafkaSinkSettings) Validate() error {
+	if len(k.Brokers) == 0 {
+		return fmt.Errorf("kafka brokers are required")
+	}
+	for _, broker := range k.Brokers {
+		host, port, err := net.SplitHostPort(broker)
+		if err != nil || host == "" || port == "" {
+			return fmt.Errorf("invalid kafka broker %q, expected host:port", broker)
+		}
+	}
+	if !kafkaTopicRegexp.MatchString(k.Topic) {
+		return fmt.Errorf("invalid kafka topic: %q", k.Topic)
+	}
+
+	switch k.SASLMechanism {
+	case "":
+	case KafkaSASLMechanismPlain, KafkaSASLMechanismScramSHA256, KafkaSASLMechanismScramSHA512:
+		if k.Username == "" {
+			return fmt.Errorf("kafka username is required with sasl_mechanism")
+		}
+	default:
+		return fmt.Errorf("invalid kafka sasl_mechanism: %s", k.SASLMechanism)
+	}
+	return nil
+}
+
+// Validate validates the NATS sink settings
+func (n *NATSSinkSettings) Validate() error {
+	parsed, err := url.Parse(n.URL)
+	if err != nil || (parsed.Scheme != "nats" && parsed.Scheme != "tls") || parsed.Host == "" {
+		return fmt.Errorf("nats url must use the nats:// or tls:// scheme")
+	}
+	if parsed.User != nil {
+		return fmt.Errorf("nats url must not contain credentials, use username, password or token")
+	}
+	if !natsSubjectRegexp.MatchString(n.Subject) {
+		return fmt.Errorf("invalid nats subject: %q", n.Subject)
+	}
+	return nil
+}
+
+// Validate validates the Postgres sink settings
+func (p *PostgresSinkSettings) Validate() error {
+	if !postgresChannelRegexp.MatchString(p.Channel) {
+		return fmt.Errorf("postgres channel must be a lowercase identifier of at most 63 characters")
+	}
+	return nil
+}
+
+// EventSinkMessage is a signed webhook event published to a sink
+type EventSinkMessage struct {
+	ID        string // webhook-id, the delivery ID
+	EventType string
+	// Key groups the events of a contact (its email), empty for events without a contact
+	Key       string
+	Timestamp int64  // webhook-timestamp
+	Signature string // webhook-signature
+	Body      []byte // the JSON envelope, identical to a webhook request body
+}
+
+// Headers returns the Standard Webhooks metadata of the message
+func (m *EventSinkMessage) Headers() map[string]string {
+	return map[string]string{
+		"content-type":      "application/json",
+		"webhook-id":        m.ID,
+		"webhook-timestamp": fmt.Sprintf("%d", m.Timestamp),
+		"webhook-signature": m.Signature,
+	}
+}
+
+// Subject returns the subject of the message under a dotted prefix, e.g. `notifuse.contact.created`
+func (m *EventSinkMessage) Subject(prefix string) string {
+	return prefix + "." + m.EventType
+}
+
+// EventSink publishes batches of events to a streaming platform. Publish succeeds only
+// when every message of the batch was accepted, the batch is published again otherwise
+// (at-least-once: consumers deduplicate on the webhook-id).
+type EventSink interface {
+	Publish(ctx context.Context, messages []*EventSinkMessage) error
+	Close() error
+}
+
+// EventSinkFactory creates the sink of a webhook subscription
+type EventSinkFactory interface {
+	NewEventSink(ctx context.Context, workspaceID string, settings *EventSinkSettings) (EventSink, error)
+}
@@ -45,6 +45,39 @@ func IsValidLanguage(code string) bool {
 	return ok
 }
 
+// textSearchConfigs maps supported languages to the Postgres text search configuration
+// stemming them, for the configurations shipped with every supported Postgres version.
+var textSearchConfigs = map[string]string{
+	"ar":    "arabic",
+	"da":    "danish",
+	"de":    "german",
+	"el":    "greek",
+	"en":    "english",
+	"es":    "spanish",
+	"fi":    "finnish",
+	"fr":    "french",
+	"hu":    "hungarian",
+	"id":    "indonesian",
+	"it":    "italian",
+	"nb":    "norwegian",
+	"nl":    "dutch",
+	"pt":    "portuguese",
+	"pt-BR": "portuguese",
+	"ro":    "romanian",
+	"ru":    "russian",
+	"sv":    "swedish",
+	"tr":    "turkish",
+}
+
+// TextSearchConfig returns the Postgres text search configuration for a language code,
+// or "simple" (no stemming nor stop words) for languages without a dedicated one.
+func TextSearchConfig(code string) string {
+	if config, ok := textSearchConfigs[code]; ok {
+		return config
+	}
+	return "simple"
+}
+
 // SupportedUILanguages are the locales the console UI and the system emails
 // (magic code, workspace invitation, circuit-breaker alert) are translated into.
 // A user's language preference must be one of these. It is deliberately narrower
@@ -66,3 +66,11 @@ func TestIsSupportedUILanguage(t *testing.T) {
 		assert.True(t, IsValidLanguage(code), "UI language %s must be in SupportedLanguages", code)
 	}
 }
+
+func TestTextSearchConfig(t *testing.T) {
+	assert.Equal(t, "english", TextSearchConfig("en"))
+	assert.Equal(t, "french", TextSearchConfig("fr"))
+	assert.Equal(t, "portuguese", TextSearchConfig("pt-BR"))
+	assert.Equal(t, "simple", TextSearchConfig("ja"))
+	assert.Equal(t, "simple", TextSearchConfig(""))
+}
@@ -286,6 +286,11 @@ type SubscribeToListsRequest struct {
 	WorkspaceID string   `json:"workspace_id"`
 	Contact     Contact  `json:"contact"`
 	ListIDs     []string `json:"list_ids"`
+
+	// Optional proof of consent recorded on the consent ledger
+	PageURL            string `json:"page_url,omitempty"`
+	ConsentTextVersion string `json:"consent_text_version,omitempty"`
+	MessageID          string `json:"message_id,omitempty"` // double opt-in email being confirmed
 }
 
 func (r *SubscribeToListsRequest) Validate() (err error) {
@@ -21,6 +21,8 @@ type LLMChatRequest struct {
 	MaxTokens     int          `json:"max_tokens,omitempty"`
 	SystemPrompt  string       `json:"system_prompt,omitempty"`
 	Tools         []LLMTool    `json:"tools,omitempty"`
+	// WorkspaceTools offers the tools reading and changing the workspace data the user has access to
+	WorkspaceTools bool `json:"workspace_tools,omitempty"`
 }
 
 // LLMMessage represents a chat message
@@ -31,11 +33,12 @@ type LLMMessage struct {
 
 // LLMChatEvent represents an SSE event sent during streaming
 type LLMChatEvent struct {
-	Type         string                 `json:"type"`                    // "text", "tool_use", "server_tool_start", "server_tool_result", "done", "error"
+	Type         string                 `json:"type"`                    // "text", "tool_use", "tool_confirmation", "server_tool_start", "server_tool_result", "done", "error"
 	Content      string                 `json:"content,omitempty"`       // Text content for "text" events
 	Error        string                 `json:"error,omitempty"`         // Error message for "error" events
 	ToolName     string                 `json:"tool_name,omitempty"`     // Tool name for "tool_use" events
 	ToolInput    map[string]interface{} `json:"tool_input,omitempty"`    // Tool input for "tool_use" events
+	ToolCallID   string                 `json:"tool_call_id,omitempty"`  // Tool call to confirm for "tool_confirmation" events
 	InputTokens  *int64                 `json:"input_tokens,omitempty"`  // Token count (done event only)
 	OutputTokens *int64                 `json:"output_tokens,omitempty"` // Token count (done event only)
 	InputCost    *float64               `json:"input_cost,omitempty"`    // Cost in USD (done event only)
@@ -75,4 +78,21 @@ func (r *LLMChatRequest) Validate() error {
 type LLMService interface {
 	// StreamChat sends a chat request and streams the response
 	StreamChat(ctx context.Context, req *LLMChatRequest, onEvent func(LLMChatEvent) error) error
+	// GenerateSubjectLines generates subject line and preheader candidates for a template,
+	// informed by the open rates of the past broadcasts of the workspace
+	GenerateSubjectLines(ctx context.Context, req *GenerateSubjectLinesRequest) (*GenerateSubjectLinesResponse, error)
+	// CreateSubjectVariations copies the template of a broadcast for each candidate and
+	// adds the copies to its A/B test
+	CreateSubjectVariations(ctx context.Context, req *CreateSubjectVariationsRequest) (*Broadcast, error)
+	// RewriteBlocks rewrites the text of the blocks of a visual editor tree
+	RewriteBlocks(ctx context.Context, req *RewriteBlocksRequest) (*RewriteBlocksResponse, error)
+	// TranslateTemplates translates email templates into the languages of the workspace and
+	// stores the results as draft translations
+	TranslateTemplates(ctx context.Context, req *TranslateTemplatesRequest) (*TranslateTemplatesResponse, error)
+	// ConfirmToolCall executes or declines a workspace tool call proposed by the assistant
+	ConfirmToolCall(ctx context.Context, req *ConfirmLLMToolCallRequest) (*ConfirmLLMToolCallResponse, error)
+	// ListToolCalls returns the audit trail of the workspace tools called by the assistant
+	ListToolCalls(ctx context.Context, workspaceID string, limit int) ([]*LLMToolCall, error)
+	// ListOllamaModels lists the models available on the server of an Ollama integration
+	ListOllamaModels(ctx context.Context, req *ListOllamaModelsRequest) (*ListOllamaModelsResponse, error)
 }
@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+)
+
+// SubjectPerformance is the open rate of the broadcast emails sent with a subject line
+type SubjectPerformance struct {
+	Subject  string  `json:"subject"`
+	Sent     int     `json:"sent"`
+	Opened   int     `json:"opened"`
+	OpenRate float64 `json:"open_rate"`
+}
+
+// SubjectPerformanceParams filters the subject lines of SubjectPerformance
+type SubjectPerformanceParams struct {
+	Since   time.Time // Only emails sent since then
+	MinSent int       // Ignore subjects sent to fewer recipients
+	Limit   int
+}
+
+// LLMUsage is the token consumption of a structured LLM call
+type LLMUsage struct {
+	Model        string  `json:"model"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	TotalCost    float64 `json:"total_cost"`
+}
+
+// SubjectLineCandidate is a subject line and preheader generated for an A/B test
+type SubjectLineCandidate struct {
+	Subject   string `json:"subject"`
+	Preheader string `json:"preheader,omitempty"`
+	Rationale string `json:"rationale,omitempty"`
+}
+
+// GenerateSubjectLinesRequest asks the LLM for subject line candidates for a template
+// sent to an audience
+type GenerateSubjectLinesRequest struct {
+	WorkspaceID   string           `json:"workspace_id"`
+	IntegrationID string           `json:"integration_id"`
+	TemplateID    string           `json:"template_id"`
+	Audience      AudienceSettings `json:"audience"`
+	Count         int              `json:"count,omitempty"`        // Number of candidates (default: 5)
+	Instructions  string           `json:"instructions,omitempty"` // Extra guidance, e.g. the tone
+}
+
+// Validate validates the request and applies the defaults
+func (r *GenerateSubjectLinesRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.IntegrationID == "" {
+		return fmt.Errorf("integration_id is required")
+	}
+	if r.TemplateID == "" {
+		return fmt.Errorf("template_id is required")
+	}
+	if r.Count == 0 {
+		r.Count = 5
+	}
+	// A broadcast A/B test has at most 8 variations
+	if r.Count < 1 || r.Count > 8 {
+		return fmt.Errorf("count must be between 1 and 8")
+	}
+	if len(r.Instructions) > 1000 {
+		return fmt.Errorf("instructions must be less than 1000 characters")
+	}
+	return nil
+}
+
+// GenerateSubjectLinesResponse contains the generated candidates, and the past subject
+// lines the LLM was given as examples
+type GenerateSubjectLinesResponse struct {
+	Candidates []SubjectLineCandidate `json:"candidates"`
+	History    []*SubjectPerformance  `json:"history"`
+	Usage      LLMUsage               `json:"usage"`
+}
+
+// CreateSubjectVariationsRequest turns subject line candidates into the A/B test
+// variations of a broadcast
+type CreateSubjectVariationsRequest struct {
+	WorkspaceID string                 `json:"workspace_id"`
+	BroadcastID string                 `json:"broadcast_id"`
+	Candidates  []SubjectLineCandidate `json:"candidates"`
+}
+
+// Validate validates the request
+func (r *CreateSubjectVariationsRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.BroadcastID == "" {
+		return fmt.Errorf("broadcast_id is required")
+	}
+	if len(r.Candidates) == 0 {
+		return fmt.Errorf("at least one candidate is required")
+	}
+	for i, candidate := range r.Candidates {
+		if strings.TrimSpace(candidate.Subject) == "" {
+			return fmt.Errorf("candidate %d: subject is required", i+1)
+		}
+	}
+	return nil
+}
+
+// RewriteBlocksRequest asks the LLM to rewrite the text and button blocks of a visual
+// editor tree in a tone or language
+type RewriteBlocksRequest struct {
+	WorkspaceID   string          `json:"workspace_id"`
+	IntegrationID string          `json:"integration_id"`
+	Tree          json.RawMessage `json:"visual_editor_tree"`
+	BlockIDs      []string        `json:"block_ids,omitempty"` // Blocks to rewrite (default: every text and button block)
+	Tone          string          `json:"tone,omitempty"`
+	Language      string          `json:"language,omitempty"`
+}
+
+// Validate validates the request
+func (r *RewriteBlocksRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.IntegrationID == "" {
+		return fmt.Errorf("integration_id is required")
+	}
+	if len(r.Tree) == 0 {
+		return fmt.Errorf("visual_editor_tree is required")
+	}
+	if r.Tone == "" && r.Language == "" {
+		return fmt.Errorf("tone or language is required")
+	}
+	if len(r.Tone) > 200 {
+		return fmt.Errorf("tone must be less than 200 characters")
+	}
+	return nil
+}
+
+// RewriteBlocksResponse contains the tree with the rewritten blocks
+type RewriteBlocksResponse struct {
+	Tree         notifuse_mjml.EmailBlock `json:"visual_editor_tree"`
+	RewrittenIDs []string                 `json:"rewritten_block_ids"`
+	Usage        LLMUsage                 `json:"usage"`
+}
+
+// TranslateTemplatesRequest translates email templates into the languages of the workspace
+type TranslateTemplatesRequest struct {
+	WorkspaceID   string   `json:"workspace_id"`
+	IntegrationID string   `json:"integration_id"`
+	TemplateID    string   `json:"template_id,omitempty"` // Template to translate (default: every email template missing a language)
+	Languages     []string `json:"languages,omitempty"`   // Target languages (default: every workspace language but the default one)
+	Overwrite     bool     `json:"overwrite,omitempty"`   // Translate again the languages that already have a translation
+}
+
+// Validate validates the request
+func (r *TranslateTemplatesRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.IntegrationID == "" {
+		return fmt.Errorf("integration_id is required")
+	}
+	for _, language := range r.Languages {
+		if !IsValidLanguage(language) {
+			return fmt.Errorf("invalid language code: %s", language)
+		}
+	}
+	if r.Overwrite && r.TemplateID == "" {
+		return fmt.Errorf("overwrite requires a template_id")
+	}
+	return nil
+}
+
+// TemplateTranslationResult is the outcome of the translation of a template into a language
+type TemplateTranslationResult struct {
+	TemplateID string `json:"template_id"`
+	Language   string `json:"language"`
+	Error      string `json:"error,omitempty"`
+}
+
+// TranslateTemplatesResponse lists the translations made, and the ones that failed
+type TranslateTemplatesResponse struct {
+	Results []TemplateTranslationResult `json:"results"`
+	Usage   LLMUsage                    `json:"usage"`
+}
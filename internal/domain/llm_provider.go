@@ -9,6 +9,7 @@ const (
 	LLMProviderKindAnthropic LLMProviderKind = "anthropic"
 	LLMProviderKindOpenAI    LLMProviderKind = "openai"
 	LLMProviderKindGemini    LLMProviderKind = "gemini"
+	LLMProviderKindOllama    LLMProviderKind = "ollama"
 )
 
 // LLMProvider contains configuration for an LLM service provider
@@ -17,6 +18,7 @@ type LLMProvider struct {
 	Anthropic *AnthropicSettings `json:"anthropic,omitempty"`
 	OpenAI    *OpenAISettings    `json:"openai,omitempty"`
 	Gemini    *GeminiSettings    `json:"gemini,omitempty"`
+	Ollama    *OllamaSettings    `json:"ollama,omitempty"`
 }
 
 // Validate validates the LLM provider settings
@@ -41,6 +43,11 @@ func (l *LLMProvider) Validate(passphrase string) error {
 			return fmt.Errorf("Gemini settings required when LLM provider kind is gemini")
 		}
 		return l.Gemini.Validate(passphrase)
+	case LLMProviderKindOllama:
+		if l.Ollama == nil {
+			return fmt.Errorf("Ollama settings required when LLM provider kind is ollama")
+		}
+		return l.Ollama.Validate(passphrase)
 	default:
 		return fmt.Errorf("invalid LLM provider kind: %s", l.Kind)
 	}
@@ -69,6 +76,13 @@ func (l *LLMProvider) EncryptSecretKeys(passphrase string) error {
 		l.Gemini.APIKey = ""
 	}
 
+	if l.Kind == LLMProviderKindOllama && l.Ollama != nil && l.Ollama.APIKey != "" {
+		if err := l.Ollama.EncryptAPIKey(passphrase); err != nil {
+			return err
+		}
+		l.Ollama.APIKey = ""
+	}
+
 	return nil
 }
 
@@ -92,5 +106,11 @@ func (l *LLMProvider) DecryptSecretKeys(passphrase string) error {
 		}
 	}
 
+	if l.Kind == LLMProviderKindOllama && l.Ollama != nil && l.Ollama.EncryptedAPIKey != "" {
+		if err := l.Ollama.DecryptAPIKey(passphrase); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
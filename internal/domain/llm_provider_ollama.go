@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Notifuse/notifuse/pkg/crypto"
+)
+
+// OllamaServer is the software serving the models of an Ollama integration
+type OllamaServer string
+
+const (
+	// OllamaServerOllama is an Ollama server, reached through its native chat API
+	OllamaServerOllama OllamaServer = "ollama"
+	// OllamaServerLlamaCpp is a llama.cpp server, reached through its chat completion API
+	OllamaServerLlamaCpp OllamaServer = "llama.cpp"
+)
+
+const (
+	// DefaultOllamaTimeout bounds a chat with a self-hosted model when no timeout is configured
+	DefaultOllamaTimeout = 5 * time.Minute
+	// MaxOllamaTimeoutSeconds bounds the timeout of a chat with a self-hosted model
+	MaxOllamaTimeoutSeconds = 3600
+)
+
+// OllamaSettings contains configuration for a self-hosted model served by Ollama or by a
+// llama.cpp server, so that prompts never leave the infrastructure of the workspace
+type OllamaSettings struct {
+	Server  OllamaServer `json:"server,omitempty"` // ollama when empty
+	BaseURL string       `json:"base_url"`         // e.g. http://localhost:11434
+	Model   string       `json:"model"`            // free text - e.g. llama3.1:8b, listed from the server
+	// ContextWindow is the context size in tokens (num_ctx), the server default when 0.
+	// A llama.cpp server has the context size it was started with.
+	ContextWindow int `json:"context_window,omitempty"`
+	// TimeoutSeconds bounds a whole chat request, DefaultOllamaTimeout when 0
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+	// EncryptedAPIKey is sent as a bearer token, for servers behind an authenticating proxy
+	EncryptedAPIKey string `json:"encrypted_api_key,omitempty"`
+
+	// Decoded API key, not stored in the database
+	APIKey string `json:"api_key,omitempty"`
+}
+
+// Timeout returns the duration a chat request may take
+func (o *OllamaSettings) Timeout() time.Duration {
+	if o.TimeoutSeconds <= 0 {
+		return DefaultOllamaTimeout
+	}
+	return time.Duration(o.TimeoutSeconds) * time.Second
+}
+
+// DecryptAPIKey decrypts the encrypted API key
+func (o *OllamaSettings) DecryptAPIKey(passphrase string) error {
+	apiKey, err := crypto.DecryptFromHexString(o.EncryptedAPIKey, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt Ollama API key: %w", err)
+	}
+	o.APIKey = apiKey
+	return nil
+}
+
+// EncryptAPIKey encrypts the API key
+func (o *OllamaSettings) EncryptAPIKey(passphrase string) error {
+	encryptedAPIKey, err := crypto.EncryptString(o.APIKey, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt Ollama API key: %w", err)
+	}
+	o.EncryptedAPIKey = encryptedAPIKey
+	return nil
+}
+
+// IsLlamaCpp returns true when the models are served by a llama.cpp server
+func (o *OllamaSettings) IsLlamaCpp() bool {
+	return o.Server == OllamaServerLlamaCpp
+}
+
+// ValidateServer validates the settings needed to reach the server, the model aside
+func (o *OllamaSettings) ValidateServer() error {
+	if o.Server != "" && o.Server != OllamaServerOllama && o.Server != OllamaServerLlamaCpp {
+		return fmt.Errorf("invalid server: %s", o.Server)
+	}
+	if o.BaseURL == "" {
+		return fmt.Errorf("base_url is required for Ollama configuration")
+	}
+	parsed, err := url.Parse(o.BaseURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("base_url must be an http or https URL")
+	}
+	return nil
+}
+
+// Validate validates the Ollama settings
+func (o *OllamaSettings) Validate(passphrase string) error {
+	if err := o.ValidateServer(); err != nil {
+		return err
+	}
+	if o.Model == "" {
+		return fmt.Errorf("model is required for Ollama configuration")
+	}
+	if o.ContextWindow < 0 {
+		return fmt.Errorf("context_window cannot be negative")
+	}
+	if o.TimeoutSeconds < 0 || o.TimeoutSeconds > MaxOllamaTimeoutSeconds {
+		return fmt.Errorf("timeout_seconds must be between 0 and %d", MaxOllamaTimeoutSeconds)
+	}
+
+	// Encrypt API key if it's not empty
+	if o.APIKey != "" {
+		if err := o.EncryptAPIKey(passphrase); err != nil {
+			return fmt.Errorf("failed to encrypt Ollama API key: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// OllamaModel is a model available on an Ollama server
+type OllamaModel struct {
+	Name              string    `json:"name"`
+	Size              int64     `json:"size,omitempty"`
+	ModifiedAt        time.Time `json:"modified_at,omitempty"`
+	Family            string    `json:"family,omitempty"`
+	ParameterSize     string    `json:"parameter_size,omitempty"`
+	QuantizationLevel string    `json:"quantization_level,omitempty"`
+}
+
+// ListOllamaModelsRequest lists the models of the server of an integration, or of the server
+// being configured in the integration form. When both are given for the same base URL, the
+// API key of the integration is used if the settings have none.
+type ListOllamaModelsRequest struct {
+	WorkspaceID   string          `json:"workspace_id"`
+	IntegrationID string          `json:"integration_id,omitempty"`
+	Ollama        *OllamaSettings `json:"ollama,omitempty"`
+}
+
+// Validate validates the request
+func (r *ListOllamaModelsRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.IntegrationID == "" && r.Ollama == nil {
+		return fmt.Errorf("integration_id or ollama settings are required")
+	}
+	if r.Ollama != nil {
+		return r.Ollama.ValidateServer()
+	}
+	return nil
+}
+
+// ListOllamaModelsResponse contains the models available on the server
+type ListOllamaModelsResponse struct {
+	Models []OllamaModel `json:"models"`
+}
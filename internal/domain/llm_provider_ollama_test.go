@@ -0,0 +1,100 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOllamaSettings_EncryptDecryptAPIKey(t *testing.T) {
+	passphrase := "test-passphrase"
+
+	settings := domain.OllamaSettings{
+		BaseURL: "https://llm.internal.example.com",
+		Model:   "llama3.1:8b",
+		APIKey:  "proxy-token",
+	}
+
+	err := settings.EncryptAPIKey(passphrase)
+	require.NoError(t, err)
+	assert.NotEmpty(t, settings.EncryptedAPIKey)
+
+	decrypted, err := crypto.DecryptFromHexString(settings.EncryptedAPIKey, passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, "proxy-token", decrypted)
+
+	settings.APIKey = ""
+	require.NoError(t, settings.DecryptAPIKey(passphrase))
+	assert.Equal(t, "proxy-token", settings.APIKey)
+
+	settings.APIKey = ""
+	assert.Error(t, settings.DecryptAPIKey("wrong-passphrase"))
+}
+
+func TestOllamaSettings_Validate(t *testing.T) {
+	passphrase := "test-passphrase"
+
+	t.Run("valid settings", func(t *testing.T) {
+		settings := domain.OllamaSettings{
+			BaseURL:        "http://localhost:11434",
+			Model:          "qwen2.5:14b",
+			ContextWindow:  32768,
+			TimeoutSeconds: 600,
+		}
+
+		require.NoError(t, settings.Validate(passphrase))
+		// The API key is optional
+		assert.Empty(t, settings.EncryptedAPIKey)
+	})
+
+	t.Run("llama.cpp server", func(t *testing.T) {
+		settings := domain.OllamaSettings{
+			Server:  domain.OllamaServerLlamaCpp,
+			BaseURL: "http://localhost:8080",
+			Model:   "qwen2.5-14b-instruct-q4_k_m.gguf",
+		}
+
+		require.NoError(t, settings.Validate(passphrase))
+		assert.True(t, settings.IsLlamaCpp())
+	})
+
+	t.Run("API key is encrypted", func(t *testing.T) {
+		settings := domain.OllamaSettings{
+			BaseURL: "https://llm.internal.example.com",
+			Model:   "qwen2.5:14b",
+			APIKey:  "proxy-token",
+		}
+
+		require.NoError(t, settings.Validate(passphrase))
+		assert.NotEmpty(t, settings.EncryptedAPIKey)
+	})
+
+	testCases := []struct {
+		name     string
+		settings domain.OllamaSettings
+		errMsg   string
+	}{
+		{"missing base URL", domain.OllamaSettings{Model: "llama3.1"}, "base_url is required"},
+		{"base URL without scheme", domain.OllamaSettings{BaseURL: "localhost:11434", Model: "llama3.1"}, "http or https URL"},
+		{"unknown server", domain.OllamaSettings{Server: "vllm", BaseURL: "http://localhost:8000", Model: "llama3.1"}, "invalid server"},
+		{"missing model", domain.OllamaSettings{BaseURL: "http://localhost:11434"}, "model is required"},
+		{"negative context window", domain.OllamaSettings{BaseURL: "http://localhost:11434", Model: "llama3.1", ContextWindow: -1}, "context_window"},
+		{"timeout too long", domain.OllamaSettings{BaseURL: "http://localhost:11434", Model: "llama3.1", TimeoutSeconds: 7200}, "timeout_seconds"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.settings.Validate(passphrase)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestOllamaSettings_Timeout(t *testing.T) {
+	assert.Equal(t, domain.DefaultOllamaTimeout, (&domain.OllamaSettings{}).Timeout())
+	assert.Equal(t, 90*time.Second, (&domain.OllamaSettings{TimeoutSeconds: 90}).Timeout())
+}
@@ -120,6 +120,30 @@ func TestLLMProvider_Validate(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "Gemini settings required")
 	})
+
+	t.Run("valid Ollama provider", func(t *testing.T) {
+		provider := domain.LLMProvider{
+			Kind: domain.LLMProviderKindOllama,
+			Ollama: &domain.OllamaSettings{
+				BaseURL: "http://localhost:11434",
+				Model:   "llama3.1:8b",
+			},
+		}
+
+		err := provider.Validate(passphrase)
+		require.NoError(t, err)
+		assert.Empty(t, provider.Ollama.EncryptedAPIKey)
+	})
+
+	t.Run("Ollama kind without settings", func(t *testing.T) {
+		provider := domain.LLMProvider{
+			Kind: domain.LLMProviderKindOllama,
+		}
+
+		err := provider.Validate(passphrase)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Ollama settings required")
+	})
 }
 
 func TestLLMProvider_EncryptDecryptSecretKeys(t *testing.T) {
@@ -245,4 +269,28 @@ func TestLLMProvider_EncryptDecryptSecretKeys(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, originalAPIKey, provider.Gemini.APIKey)
 	})
+
+	t.Run("encrypt and decrypt Ollama API key", func(t *testing.T) {
+		originalAPIKey := "proxy-token-12345"
+
+		provider := domain.LLMProvider{
+			Kind: domain.LLMProviderKindOllama,
+			Ollama: &domain.OllamaSettings{
+				BaseURL: "https://llm.internal.example.com",
+				Model:   "llama3.1:8b",
+				APIKey:  originalAPIKey,
+			},
+		}
+
+		// Encrypt
+		err := provider.EncryptSecretKeys(passphrase)
+		require.NoError(t, err)
+		assert.NotEmpty(t, provider.Ollama.EncryptedAPIKey)
+		assert.Empty(t, provider.Ollama.APIKey) // Should be cleared after encryption
+
+		// Decrypt
+		err = provider.DecryptSecretKeys(passphrase)
+		require.NoError(t, err)
+		assert.Equal(t, originalAPIKey, provider.Ollama.APIKey)
+	})
 }
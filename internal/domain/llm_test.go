@@ -406,3 +406,33 @@ func TestLLMChatRequest_WithTools(t *testing.T) {
 		assert.Equal(t, 2, len(req.Tools))
 	})
 }
+
+func TestGenerateSubjectLinesRequest_Validate(t *testing.T) {
+	req := GenerateSubjectLinesRequest{WorkspaceID: "ws1", IntegrationID: "llm1", TemplateID: "newsletter"}
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, 5, req.Count)
+
+	assert.Error(t, (&GenerateSubjectLinesRequest{WorkspaceID: "ws1", IntegrationID: "llm1"}).Validate())
+	assert.Error(t, (&GenerateSubjectLinesRequest{WorkspaceID: "ws1", IntegrationID: "llm1", TemplateID: "t", Count: 9}).Validate())
+}
+
+func TestCreateSubjectVariationsRequest_Validate(t *testing.T) {
+	assert.NoError(t, (&CreateSubjectVariationsRequest{WorkspaceID: "ws1", BroadcastID: "b1", Candidates: []SubjectLineCandidate{{Subject: "Hello"}}}).Validate())
+	assert.Error(t, (&CreateSubjectVariationsRequest{WorkspaceID: "ws1", BroadcastID: "b1"}).Validate())
+	assert.Error(t, (&CreateSubjectVariationsRequest{WorkspaceID: "ws1", BroadcastID: "b1", Candidates: []SubjectLineCandidate{{Subject: " "}}}).Validate())
+}
+
+func TestRewriteBlocksRequest_Validate(t *testing.T) {
+	tree := json.RawMessage(`{"id":"root","type":"mjml"}`)
+	assert.NoError(t, (&RewriteBlocksRequest{WorkspaceID: "ws1", IntegrationID: "llm1", Tree: tree, Tone: "friendly"}).Validate())
+	assert.NoError(t, (&RewriteBlocksRequest{WorkspaceID: "ws1", IntegrationID: "llm1", Tree: tree, Language: "fr"}).Validate())
+	assert.Error(t, (&RewriteBlocksRequest{WorkspaceID: "ws1", IntegrationID: "llm1", Tree: tree}).Validate())
+	assert.Error(t, (&RewriteBlocksRequest{WorkspaceID: "ws1", IntegrationID: "llm1", Tone: "friendly"}).Validate())
+}
+
+func TestTranslateTemplatesRequest_Validate(t *testing.T) {
+	assert.NoError(t, (&TranslateTemplatesRequest{WorkspaceID: "ws1", IntegrationID: "llm1"}).Validate())
+	assert.NoError(t, (&TranslateTemplatesRequest{WorkspaceID: "ws1", IntegrationID: "llm1", TemplateID: "welcome", Languages: []string{"fr"}, Overwrite: true}).Validate())
+	assert.Error(t, (&TranslateTemplatesRequest{WorkspaceID: "ws1", IntegrationID: "llm1", Languages: []string{"xx"}}).Validate())
+	assert.Error(t, (&TranslateTemplatesRequest{WorkspaceID: "ws1", IntegrationID: "llm1", Overwrite: true}).Validate())
+}
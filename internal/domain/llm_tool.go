@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+//go:generate mockgen -destination mocks/mock_llm_tool_call_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain LLMToolCallRepository
+
+// LLMToolCallStatus is the state of a workspace tool call made by the assistant
+type LLMToolCallStatus string
+
+const (
+	// LLMToolCallStatusProposed is a write tool call waiting for the user to confirm it
+	LLMToolCallStatusProposed LLMToolCallStatus = "proposed"
+	// LLMToolCallStatusRunning is a confirmed tool call being executed
+	LLMToolCallStatusRunning LLMToolCallStatus = "running"
+	// LLMToolCallStatusDeclined is a proposed tool call the user declined
+	LLMToolCallStatusDeclined LLMToolCallStatus = "declined"
+	// LLMToolCallStatusSucceeded is an executed tool call
+	LLMToolCallStatusSucceeded LLMToolCallStatus = "succeeded"
+	// LLMToolCallStatusFailed is a tool call that returned an error
+	LLMToolCallStatusFailed LLMToolCallStatus = "failed"
+)
+
+// ErrLLMToolCallResolved is returned when confirming a tool call that is no longer proposed
+var ErrLLMToolCallResolved = errors.New("tool call was already confirmed or declined")
+
+// LLMToolCall is the audit record of a workspace tool called by the assistant on behalf
+// of a user. The result of the tool is not stored, it may contain contact data.
+type LLMToolCall struct {
+	ID        string            `json:"id"`
+	UserID    string            `json:"user_id"`
+	ToolName  string            `json:"tool_name"`
+	Input     MapOfAny          `json:"input"`
+	Status    LLMToolCallStatus `json:"status"`
+	Error     string            `json:"error,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// ConfirmLLMToolCallRequest executes or declines a proposed tool call
+type ConfirmLLMToolCallRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ToolCallID  string `json:"tool_call_id"`
+	Approve     bool   `json:"approve"`
+}
+
+// Validate validates the request
+func (r *ConfirmLLMToolCallRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.ToolCallID == "" {
+		return fmt.Errorf("tool_call_id is required")
+	}
+	return nil
+}
+
+// ConfirmLLMToolCallResponse contains the resolved tool call, and the result of the tool
+// to hand back to the assistant when it was executed
+type ConfirmLLMToolCallResponse struct {
+	ToolCall *LLMToolCall `json:"tool_call"`
+	Result   string       `json:"result,omitempty"`
+}
+
+// LLMToolCallRepository stores the audit trail of the workspace tools called by the assistant
+type LLMToolCallRepository interface {
+	Create(ctx context.Context, workspaceID string, call *LLMToolCall) error
+	GetByID(ctx context.Context, workspaceID string, id string) (*LLMToolCall, error)
+	// UpdateStatus saves the status and error of a call still in the from status, and
+	// returns ErrLLMToolCallResolved otherwise
+	UpdateStatus(ctx context.Context, workspaceID string, call *LLMToolCall, from LLMToolCallStatus) error
+	// List returns the most recent calls first
+	List(ctx context.Context, workspaceID string, limit int) ([]*LLMToolCall, error)
+}
@@ -193,6 +193,10 @@ type MessageHistoryRepository interface {
 	// GetBroadcastVariationStats retrieves statistics for a specific variation of a broadcast
 	GetBroadcastVariationStats(ctx context.Context, workspaceID, broadcastID, templateID string) (*MessageHistoryStatusSum, error)
 
+	// GetSubjectPerformance retrieves the open rates of the subject lines of the broadcast emails,
+	// best performing first
+	GetSubjectPerformance(ctx context.Context, workspaceID string, params SubjectPerformanceParams) ([]*SubjectPerformance, error)
+
 	// DeleteForEmail deletes all message history records for a specific email
 	DeleteForEmail(ctx context.Context, workspaceID, email string) error
 }
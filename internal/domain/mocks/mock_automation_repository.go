@@ -149,34 +149,34 @@ func (mr *MockAutomationRepositoryMockRecorder) DropAutomationTrigger(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropAutomationTrigger", reflect.TypeOf((*MockAutomationRepository)(nil).DropAutomationTrigger), arg0, arg1, arg2)
 }
 
-// ExitContactJourneysOnReply mocks base method.
-func (m *MockAutomationRepository) ExitContactJourneysOnReply(arg0 context.Context, arg1, arg2 string, arg3 *string, arg4 string, arg5 time.Time) (int, error) {
+// ExitContactJourneys mocks base method.
+func (m *MockAutomationRepository) ExitContactJourneys(arg0 context.Context, arg1, arg2 string, arg3 []string, arg4 string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExitContactJourneysOnReply", arg0, arg1, arg2, arg3, arg4, arg5)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "ExitContactJourneys", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ExitContactJourneysOnReply indicates an expected call of ExitContactJourneysOnReply.
-func (mr *MockAutomationRepositoryMockRecorder) ExitContactJourneysOnReply(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+// ExitContactJourneys indicates an expected call of ExitContactJourneys.
+func (mr *MockAutomationRepositoryMockRecorder) ExitContactJourneys(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExitContactJourneysOnReply", reflect.TypeOf((*MockAutomationRepository)(nil).ExitContactJourneysOnReply), arg0, arg1, arg2, arg3, arg4, arg5)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExitContactJourneys", reflect.TypeOf((*MockAutomationRepository)(nil).ExitContactJourneys), arg0, arg1, arg2, arg3, arg4)
 }
 
-// UpdateContactAutomationIfActive mocks base method.
-func (m *MockAutomationRepository) UpdateContactAutomationIfActive(arg0 context.Context, arg1 string, arg2 *domain.ContactAutomation) (bool, error) {
+// ExitContactJourneysOnReply mocks base method.
+func (m *MockAutomationRepository) ExitContactJourneysOnReply(arg0 context.Context, arg1, arg2 string, arg3 *string, arg4 string, arg5 time.Time) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateContactAutomationIfActive", arg0, arg1, arg2)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "ExitContactJourneysOnReply", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateContactAutomationIfActive indicates an expected call of UpdateContactAutomationIfActive.
-func (mr *MockAutomationRepositoryMockRecorder) UpdateContactAutomationIfActive(arg0, arg1, arg2 interface{}) *gomock.Call {
+// ExitContactJourneysOnReply indicates an expected call of ExitContactJourneysOnReply.
+func (mr *MockAutomationRepositoryMockRecorder) ExitContactJourneysOnReply(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContactAutomationIfActive", reflect.TypeOf((*MockAutomationRepository)(nil).UpdateContactAutomationIfActive), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExitContactJourneysOnReply", reflect.TypeOf((*MockAutomationRepository)(nil).ExitContactJourneysOnReply), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // GetByID mocks base method.
@@ -401,6 +401,21 @@ func (mr *MockAutomationRepositoryMockRecorder) UpdateContactAutomation(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContactAutomation", reflect.TypeOf((*MockAutomationRepository)(nil).UpdateContactAutomation), arg0, arg1, arg2)
 }
 
+// UpdateContactAutomationIfActive mocks base method.
+func (m *MockAutomationRepository) UpdateContactAutomationIfActive(arg0 context.Context, arg1 string, arg2 *domain.ContactAutomation) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContactAutomationIfActive", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateContactAutomationIfActive indicates an expected call of UpdateContactAutomationIfActive.
+func (mr *MockAutomationRepositoryMockRecorder) UpdateContactAutomationIfActive(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContactAutomationIfActive", reflect.TypeOf((*MockAutomationRepository)(nil).UpdateContactAutomationIfActive), arg0, arg1, arg2)
+}
+
 // UpdateContactAutomationTx mocks base method.
 func (m *MockAutomationRepository) UpdateContactAutomationTx(arg0 context.Context, arg1 *sql.Tx, arg2 string, arg3 *domain.ContactAutomation) error {
 	m.ctrl.T.Helper()
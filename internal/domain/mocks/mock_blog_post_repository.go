@@ -65,6 +65,34 @@ func (mr *MockBlogPostRepositoryMockRecorder) CreatePostTx(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePostTx", reflect.TypeOf((*MockBlogPostRepository)(nil).CreatePostTx), arg0, arg1, arg2)
 }
 
+// CreateRevision mocks base method.
+func (m *MockBlogPostRepository) CreateRevision(arg0 context.Context, arg1 *domain.BlogPostRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRevision", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRevision indicates an expected call of CreateRevision.
+func (mr *MockBlogPostRepositoryMockRecorder) CreateRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRevision", reflect.TypeOf((*MockBlogPostRepository)(nil).CreateRevision), arg0, arg1)
+}
+
+// CreateRevisionTx mocks base method.
+func (m *MockBlogPostRepository) CreateRevisionTx(arg0 context.Context, arg1 *sql.Tx, arg2 *domain.BlogPostRevision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRevisionTx", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRevisionTx indicates an expected call of CreateRevisionTx.
+func (mr *MockBlogPostRepositoryMockRecorder) CreateRevisionTx(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRevisionTx", reflect.TypeOf((*MockBlogPostRepository)(nil).CreateRevisionTx), arg0, arg1, arg2)
+}
+
 // DeletePost mocks base method.
 func (m *MockBlogPostRepository) DeletePost(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -109,9 +137,9 @@ func (mr *MockBlogPostRepositoryMockRecorder) DeletePostsByCategoryIDTx(arg0, ar
 }
 
 // GetFeedFingerprint mocks base method.
-func (m *MockBlogPostRepository) GetFeedFingerprint(arg0 context.Context, arg1 *string, arg2 int) (time.Time, string, error) {
+func (m *MockBlogPostRepository) GetFeedFingerprint(arg0 context.Context, arg1 string, arg2 *string, arg3 int) (time.Time, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFeedFingerprint", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetFeedFingerprint", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(time.Time)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(error)
@@ -119,9 +147,9 @@ func (m *MockBlogPostRepository) GetFeedFingerprint(arg0 context.Context, arg1 *
 }
 
 // GetFeedFingerprint indicates an expected call of GetFeedFingerprint.
-func (mr *MockBlogPostRepositoryMockRecorder) GetFeedFingerprint(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockBlogPostRepositoryMockRecorder) GetFeedFingerprint(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedFingerprint", reflect.TypeOf((*MockBlogPostRepository)(nil).GetFeedFingerprint), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedFingerprint", reflect.TypeOf((*MockBlogPostRepository)(nil).GetFeedFingerprint), arg0, arg1, arg2, arg3)
 }
 
 // GetPost mocks base method.
@@ -184,6 +212,21 @@ func (mr *MockBlogPostRepositoryMockRecorder) GetPostBySlugTx(arg0, arg1, arg2 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostBySlugTx", reflect.TypeOf((*MockBlogPostRepository)(nil).GetPostBySlugTx), arg0, arg1, arg2)
 }
 
+// GetPostByTranslationSlug mocks base method.
+func (m *MockBlogPostRepository) GetPostByTranslationSlug(arg0 context.Context, arg1, arg2, arg3 string) (*domain.BlogPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostByTranslationSlug", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.BlogPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostByTranslationSlug indicates an expected call of GetPostByTranslationSlug.
+func (mr *MockBlogPostRepositoryMockRecorder) GetPostByTranslationSlug(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostByTranslationSlug", reflect.TypeOf((*MockBlogPostRepository)(nil).GetPostByTranslationSlug), arg0, arg1, arg2, arg3)
+}
+
 // GetPostTx mocks base method.
 func (m *MockBlogPostRepository) GetPostTx(arg0 context.Context, arg1 *sql.Tx, arg2 string) (*domain.BlogPost, error) {
 	m.ctrl.T.Helper()
@@ -199,19 +242,49 @@ func (mr *MockBlogPostRepositoryMockRecorder) GetPostTx(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostTx", reflect.TypeOf((*MockBlogPostRepository)(nil).GetPostTx), arg0, arg1, arg2)
 }
 
+// GetRevision mocks base method.
+func (m *MockBlogPostRepository) GetRevision(arg0 context.Context, arg1 string, arg2 int) (*domain.BlogPostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.BlogPostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockBlogPostRepositoryMockRecorder) GetRevision(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockBlogPostRepository)(nil).GetRevision), arg0, arg1, arg2)
+}
+
+// GetRevisionByPreviewToken mocks base method.
+func (m *MockBlogPostRepository) GetRevisionByPreviewToken(arg0 context.Context, arg1 string) (*domain.BlogPostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisionByPreviewToken", arg0, arg1)
+	ret0, _ := ret[0].(*domain.BlogPostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisionByPreviewToken indicates an expected call of GetRevisionByPreviewToken.
+func (mr *MockBlogPostRepositoryMockRecorder) GetRevisionByPreviewToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisionByPreviewToken", reflect.TypeOf((*MockBlogPostRepository)(nil).GetRevisionByPreviewToken), arg0, arg1)
+}
+
 // ListFeedPosts mocks base method.
-func (m *MockBlogPostRepository) ListFeedPosts(arg0 context.Context, arg1 *string, arg2 int) ([]*domain.BlogPost, error) {
+func (m *MockBlogPostRepository) ListFeedPosts(arg0 context.Context, arg1 string, arg2 *string, arg3 int) ([]*domain.BlogPost, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListFeedPosts", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "ListFeedPosts", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]*domain.BlogPost)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListFeedPosts indicates an expected call of ListFeedPosts.
-func (mr *MockBlogPostRepositoryMockRecorder) ListFeedPosts(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockBlogPostRepositoryMockRecorder) ListFeedPosts(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeedPosts", reflect.TypeOf((*MockBlogPostRepository)(nil).ListFeedPosts), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeedPosts", reflect.TypeOf((*MockBlogPostRepository)(nil).ListFeedPosts), arg0, arg1, arg2, arg3)
 }
 
 // ListPosts mocks base method.
@@ -229,6 +302,35 @@ func (mr *MockBlogPostRepositoryMockRecorder) ListPosts(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPosts", reflect.TypeOf((*MockBlogPostRepository)(nil).ListPosts), arg0, arg1)
 }
 
+// ListRevisions mocks base method.
+func (m *MockBlogPostRepository) ListRevisions(arg0 context.Context, arg1 string) ([]*domain.BlogPostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.BlogPostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockBlogPostRepositoryMockRecorder) ListRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockBlogPostRepository)(nil).ListRevisions), arg0, arg1)
+}
+
+// MarkRevisionAppliedTx mocks base method.
+func (m *MockBlogPostRepository) MarkRevisionAppliedTx(arg0 context.Context, arg1 *sql.Tx, arg2 string, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRevisionAppliedTx", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRevisionAppliedTx indicates an expected call of MarkRevisionAppliedTx.
+func (mr *MockBlogPostRepositoryMockRecorder) MarkRevisionAppliedTx(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRevisionAppliedTx", reflect.TypeOf((*MockBlogPostRepository)(nil).MarkRevisionAppliedTx), arg0, arg1, arg2, arg3)
+}
+
 // PublishPost mocks base method.
 func (m *MockBlogPostRepository) PublishPost(arg0 context.Context, arg1 string, arg2 *time.Time) error {
 	m.ctrl.T.Helper()
@@ -257,6 +359,21 @@ func (mr *MockBlogPostRepositoryMockRecorder) PublishPostTx(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishPostTx", reflect.TypeOf((*MockBlogPostRepository)(nil).PublishPostTx), arg0, arg1, arg2, arg3)
 }
 
+// SearchPosts mocks base method.
+func (m *MockBlogPostRepository) SearchPosts(arg0 context.Context, arg1 domain.BlogSearchRequest) (*domain.BlogSearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPosts", arg0, arg1)
+	ret0, _ := ret[0].(*domain.BlogSearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPosts indicates an expected call of SearchPosts.
+func (mr *MockBlogPostRepositoryMockRecorder) SearchPosts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPosts", reflect.TypeOf((*MockBlogPostRepository)(nil).SearchPosts), arg0, arg1)
+}
+
 // UnpublishPost mocks base method.
 func (m *MockBlogPostRepository) UnpublishPost(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -299,6 +416,20 @@ func (mr *MockBlogPostRepositoryMockRecorder) UpdatePost(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePost", reflect.TypeOf((*MockBlogPostRepository)(nil).UpdatePost), arg0, arg1)
 }
 
+// UpdatePostSearchIndex mocks base method.
+func (m *MockBlogPostRepository) UpdatePostSearchIndex(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePostSearchIndex", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePostSearchIndex indicates an expected call of UpdatePostSearchIndex.
+func (mr *MockBlogPostRepositoryMockRecorder) UpdatePostSearchIndex(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePostSearchIndex", reflect.TypeOf((*MockBlogPostRepository)(nil).UpdatePostSearchIndex), arg0, arg1, arg2, arg3)
+}
+
 // UpdatePostTx mocks base method.
 func (m *MockBlogPostRepository) UpdatePostTx(arg0 context.Context, arg1 *sql.Tx, arg2 *domain.BlogPost) error {
 	m.ctrl.T.Helper()
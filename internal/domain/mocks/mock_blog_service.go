@@ -37,18 +37,18 @@ func (m *MockBlogService) EXPECT() *MockBlogServiceMockRecorder {
 }
 
 // BuildFeed mocks base method.
-func (m *MockBlogService) BuildFeed(arg0 context.Context, arg1 string, arg2 *string) (*domain.BlogFeed, error) {
+func (m *MockBlogService) BuildFeed(arg0 context.Context, arg1, arg2 string, arg3 *string) (*domain.BlogFeed, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BuildFeed", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "BuildFeed", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(*domain.BlogFeed)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BuildFeed indicates an expected call of BuildFeed.
-func (mr *MockBlogServiceMockRecorder) BuildFeed(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockBlogServiceMockRecorder) BuildFeed(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildFeed", reflect.TypeOf((*MockBlogService)(nil).BuildFeed), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildFeed", reflect.TypeOf((*MockBlogService)(nil).BuildFeed), arg0, arg1, arg2, arg3)
 }
 
 // CreateCategory mocks base method.
@@ -155,9 +155,9 @@ func (mr *MockBlogServiceMockRecorder) GetCategoryBySlug(arg0, arg1 interface{})
 }
 
 // GetFeedFingerprint mocks base method.
-func (m *MockBlogService) GetFeedFingerprint(arg0 context.Context, arg1 string, arg2 *string) (time.Time, string, error) {
+func (m *MockBlogService) GetFeedFingerprint(arg0 context.Context, arg1, arg2 string, arg3 *string) (time.Time, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFeedFingerprint", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetFeedFingerprint", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(time.Time)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(error)
@@ -165,9 +165,9 @@ func (m *MockBlogService) GetFeedFingerprint(arg0 context.Context, arg1 string,
 }
 
 // GetFeedFingerprint indicates an expected call of GetFeedFingerprint.
-func (mr *MockBlogServiceMockRecorder) GetFeedFingerprint(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockBlogServiceMockRecorder) GetFeedFingerprint(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedFingerprint", reflect.TypeOf((*MockBlogService)(nil).GetFeedFingerprint), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedFingerprint", reflect.TypeOf((*MockBlogService)(nil).GetFeedFingerprint), arg0, arg1, arg2, arg3)
 }
 
 // GetPost mocks base method.
@@ -216,33 +216,33 @@ func (mr *MockBlogServiceMockRecorder) GetPostBySlug(arg0, arg1 interface{}) *go
 }
 
 // GetPublicCategoryBySlug mocks base method.
-func (m *MockBlogService) GetPublicCategoryBySlug(arg0 context.Context, arg1 string) (*domain.BlogCategory, error) {
+func (m *MockBlogService) GetPublicCategoryBySlug(arg0 context.Context, arg1, arg2 string) (*domain.BlogCategory, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPublicCategoryBySlug", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetPublicCategoryBySlug", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*domain.BlogCategory)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPublicCategoryBySlug indicates an expected call of GetPublicCategoryBySlug.
-func (mr *MockBlogServiceMockRecorder) GetPublicCategoryBySlug(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockBlogServiceMockRecorder) GetPublicCategoryBySlug(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicCategoryBySlug", reflect.TypeOf((*MockBlogService)(nil).GetPublicCategoryBySlug), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicCategoryBySlug", reflect.TypeOf((*MockBlogService)(nil).GetPublicCategoryBySlug), arg0, arg1, arg2)
 }
 
 // GetPublicPostByCategoryAndSlug mocks base method.
-func (m *MockBlogService) GetPublicPostByCategoryAndSlug(arg0 context.Context, arg1, arg2 string) (*domain.BlogPost, error) {
+func (m *MockBlogService) GetPublicPostByCategoryAndSlug(arg0 context.Context, arg1, arg2, arg3 string) (*domain.BlogPost, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPublicPostByCategoryAndSlug", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetPublicPostByCategoryAndSlug", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(*domain.BlogPost)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPublicPostByCategoryAndSlug indicates an expected call of GetPublicPostByCategoryAndSlug.
-func (mr *MockBlogServiceMockRecorder) GetPublicPostByCategoryAndSlug(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockBlogServiceMockRecorder) GetPublicPostByCategoryAndSlug(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicPostByCategoryAndSlug", reflect.TypeOf((*MockBlogService)(nil).GetPublicPostByCategoryAndSlug), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicPostByCategoryAndSlug", reflect.TypeOf((*MockBlogService)(nil).GetPublicPostByCategoryAndSlug), arg0, arg1, arg2, arg3)
 }
 
 // GetPublishedTheme mocks base method.
@@ -290,6 +290,21 @@ func (mr *MockBlogServiceMockRecorder) ListCategories(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockBlogService)(nil).ListCategories), arg0)
 }
 
+// ListPostRevisions mocks base method.
+func (m *MockBlogService) ListPostRevisions(arg0 context.Context, arg1 string) ([]*domain.BlogPostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.BlogPostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPostRevisions indicates an expected call of ListPostRevisions.
+func (mr *MockBlogServiceMockRecorder) ListPostRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostRevisions", reflect.TypeOf((*MockBlogService)(nil).ListPostRevisions), arg0, arg1)
+}
+
 // ListPosts mocks base method.
 func (m *MockBlogService) ListPosts(arg0 context.Context, arg1 *domain.ListBlogPostsRequest) (*domain.BlogPostListResponse, error) {
 	m.ctrl.T.Helper()
@@ -335,6 +350,21 @@ func (mr *MockBlogServiceMockRecorder) ListThemes(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListThemes", reflect.TypeOf((*MockBlogService)(nil).ListThemes), arg0, arg1)
 }
 
+// PromoteRevision mocks base method.
+func (m *MockBlogService) PromoteRevision(arg0 context.Context, arg1 *domain.BlogPostRevisionRequest) (*domain.BlogPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteRevision", arg0, arg1)
+	ret0, _ := ret[0].(*domain.BlogPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromoteRevision indicates an expected call of PromoteRevision.
+func (mr *MockBlogServiceMockRecorder) PromoteRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteRevision", reflect.TypeOf((*MockBlogService)(nil).PromoteRevision), arg0, arg1)
+}
+
 // PublishPost mocks base method.
 func (m *MockBlogService) PublishPost(arg0 context.Context, arg1 *domain.PublishBlogPostRequest) error {
 	m.ctrl.T.Helper()
@@ -364,33 +394,33 @@ func (mr *MockBlogServiceMockRecorder) PublishTheme(arg0, arg1 interface{}) *gom
 }
 
 // RenderCategoryPage mocks base method.
-func (m *MockBlogService) RenderCategoryPage(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 *int) (string, error) {
+func (m *MockBlogService) RenderCategoryPage(arg0 context.Context, arg1, arg2, arg3 string, arg4 int, arg5 *int) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RenderCategoryPage", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "RenderCategoryPage", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RenderCategoryPage indicates an expected call of RenderCategoryPage.
-func (mr *MockBlogServiceMockRecorder) RenderCategoryPage(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockBlogServiceMockRecorder) RenderCategoryPage(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderCategoryPage", reflect.TypeOf((*MockBlogService)(nil).RenderCategoryPage), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderCategoryPage", reflect.TypeOf((*MockBlogService)(nil).RenderCategoryPage), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // RenderHomePage mocks base method.
-func (m *MockBlogService) RenderHomePage(arg0 context.Context, arg1 string, arg2 int, arg3 *int) (string, error) {
+func (m *MockBlogService) RenderHomePage(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 *int) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RenderHomePage", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "RenderHomePage", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RenderHomePage indicates an expected call of RenderHomePage.
-func (mr *MockBlogServiceMockRecorder) RenderHomePage(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockBlogServiceMockRecorder) RenderHomePage(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderHomePage", reflect.TypeOf((*MockBlogService)(nil).RenderHomePage), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderHomePage", reflect.TypeOf((*MockBlogService)(nil).RenderHomePage), arg0, arg1, arg2, arg3, arg4)
 }
 
 // RenderPostContent mocks base method.
@@ -409,18 +439,122 @@ func (mr *MockBlogServiceMockRecorder) RenderPostContent(arg0, arg1, arg2, arg3
 }
 
 // RenderPostPage mocks base method.
-func (m *MockBlogService) RenderPostPage(arg0 context.Context, arg1, arg2, arg3 string, arg4 *int) (string, error) {
+func (m *MockBlogService) RenderPostPage(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 *int) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RenderPostPage", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "RenderPostPage", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RenderPostPage indicates an expected call of RenderPostPage.
-func (mr *MockBlogServiceMockRecorder) RenderPostPage(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockBlogServiceMockRecorder) RenderPostPage(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderPostPage", reflect.TypeOf((*MockBlogService)(nil).RenderPostPage), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// RenderPostPreview mocks base method.
+func (m *MockBlogService) RenderPostPreview(arg0 context.Context, arg1, arg2 string, arg3 *int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderPostPreview", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenderPostPreview indicates an expected call of RenderPostPreview.
+func (mr *MockBlogServiceMockRecorder) RenderPostPreview(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderPostPreview", reflect.TypeOf((*MockBlogService)(nil).RenderPostPreview), arg0, arg1, arg2, arg3)
+}
+
+// RenderSearchPage mocks base method.
+func (m *MockBlogService) RenderSearchPage(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 *int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderSearchPage", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenderSearchPage indicates an expected call of RenderSearchPage.
+func (mr *MockBlogServiceMockRecorder) RenderSearchPage(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderSearchPage", reflect.TypeOf((*MockBlogService)(nil).RenderSearchPage), arg0, arg1, arg2, arg3, arg4)
+}
+
+// RequestBlogAccess mocks base method.
+func (m *MockBlogService) RequestBlogAccess(arg0 context.Context, arg1 string, arg2 *domain.BlogAccessRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestBlogAccess", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestBlogAccess indicates an expected call of RequestBlogAccess.
+func (mr *MockBlogServiceMockRecorder) RequestBlogAccess(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestBlogAccess", reflect.TypeOf((*MockBlogService)(nil).RequestBlogAccess), arg0, arg1, arg2)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockBlogService) RestoreRevision(arg0 context.Context, arg1 *domain.BlogPostRevisionRequest) (*domain.BlogPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", arg0, arg1)
+	ret0, _ := ret[0].(*domain.BlogPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockBlogServiceMockRecorder) RestoreRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockBlogService)(nil).RestoreRevision), arg0, arg1)
+}
+
+// SaveDraftRevision mocks base method.
+func (m *MockBlogService) SaveDraftRevision(arg0 context.Context, arg1 *domain.UpdateBlogPostRequest) (*domain.BlogPostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDraftRevision", arg0, arg1)
+	ret0, _ := ret[0].(*domain.BlogPostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveDraftRevision indicates an expected call of SaveDraftRevision.
+func (mr *MockBlogServiceMockRecorder) SaveDraftRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDraftRevision", reflect.TypeOf((*MockBlogService)(nil).SaveDraftRevision), arg0, arg1)
+}
+
+// SchedulePost mocks base method.
+func (m *MockBlogService) SchedulePost(arg0 context.Context, arg1 *domain.ScheduleBlogPostRequest) (*domain.BlogPost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePost", arg0, arg1)
+	ret0, _ := ret[0].(*domain.BlogPost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulePost indicates an expected call of SchedulePost.
+func (mr *MockBlogServiceMockRecorder) SchedulePost(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePost", reflect.TypeOf((*MockBlogService)(nil).SchedulePost), arg0, arg1)
+}
+
+// SearchPublicPosts mocks base method.
+func (m *MockBlogService) SearchPublicPosts(arg0 context.Context, arg1 *domain.BlogSearchRequest) (*domain.BlogSearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPublicPosts", arg0, arg1)
+	ret0, _ := ret[0].(*domain.BlogSearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPublicPosts indicates an expected call of SearchPublicPosts.
+func (mr *MockBlogServiceMockRecorder) SearchPublicPosts(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderPostPage", reflect.TypeOf((*MockBlogService)(nil).RenderPostPage), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPublicPosts", reflect.TypeOf((*MockBlogService)(nil).SearchPublicPosts), arg0, arg1)
 }
 
 // UnpublishPost mocks base method.
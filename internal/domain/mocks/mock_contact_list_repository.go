@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/Notifuse/notifuse/internal/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -122,6 +123,36 @@ func (mr *MockContactListRepositoryMockRecorder) GetListsByEmail(arg0, arg1, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListsByEmail", reflect.TypeOf((*MockContactListRepository)(nil).GetListsByEmail), arg0, arg1, arg2)
 }
 
+// ListConsentRecords mocks base method.
+func (m *MockContactListRepository) ListConsentRecords(arg0 context.Context, arg1, arg2, arg3 string, arg4 int, arg5 *string) ([]*domain.ConsentRecord, *string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConsentRecords", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]*domain.ConsentRecord)
+	ret1, _ := ret[1].(*string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListConsentRecords indicates an expected call of ListConsentRecords.
+func (mr *MockContactListRepositoryMockRecorder) ListConsentRecords(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConsentRecords", reflect.TypeOf((*MockContactListRepository)(nil).ListConsentRecords), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// PauseContactLists mocks base method.
+func (m *MockContactListRepository) PauseContactLists(arg0 context.Context, arg1, arg2 string, arg3 *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseContactLists", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseContactLists indicates an expected call of PauseContactLists.
+func (mr *MockContactListRepositoryMockRecorder) PauseContactLists(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseContactLists", reflect.TypeOf((*MockContactListRepository)(nil).PauseContactLists), arg0, arg1, arg2, arg3)
+}
+
 // RemoveContactFromList mocks base method.
 func (m *MockContactListRepository) RemoveContactFromList(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -136,6 +167,20 @@ func (mr *MockContactListRepositoryMockRecorder) RemoveContactFromList(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveContactFromList", reflect.TypeOf((*MockContactListRepository)(nil).RemoveContactFromList), arg0, arg1, arg2, arg3)
 }
 
+// UpdateContactListFrequency mocks base method.
+func (m *MockContactListRepository) UpdateContactListFrequency(arg0 context.Context, arg1, arg2, arg3 string, arg4 domain.ContactListFrequency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContactListFrequency", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateContactListFrequency indicates an expected call of UpdateContactListFrequency.
+func (mr *MockContactListRepositoryMockRecorder) UpdateContactListFrequency(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContactListFrequency", reflect.TypeOf((*MockContactListRepository)(nil).UpdateContactListFrequency), arg0, arg1, arg2, arg3, arg4)
+}
+
 // UpdateContactListStatus mocks base method.
 func (m *MockContactListRepository) UpdateContactListStatus(arg0 context.Context, arg1, arg2, arg3 string, arg4 domain.ContactListStatus) error {
 	m.ctrl.T.Helper()
@@ -80,6 +80,21 @@ func (mr *MockContactListServiceMockRecorder) GetListsByEmail(arg0, arg1, arg2 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListsByEmail", reflect.TypeOf((*MockContactListService)(nil).GetListsByEmail), arg0, arg1, arg2)
 }
 
+// ListConsentRecords mocks base method.
+func (m *MockContactListService) ListConsentRecords(arg0 context.Context, arg1 *domain.ListConsentRecordsRequest) (*domain.ListConsentRecordsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConsentRecords", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ListConsentRecordsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConsentRecords indicates an expected call of ListConsentRecords.
+func (mr *MockContactListServiceMockRecorder) ListConsentRecords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConsentRecords", reflect.TypeOf((*MockContactListService)(nil).ListConsentRecords), arg0, arg1)
+}
+
 // RemoveContactFromList mocks base method.
 func (m *MockContactListService) RemoveContactFromList(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
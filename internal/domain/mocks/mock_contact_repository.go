@@ -36,6 +36,21 @@ func (m *MockContactRepository) EXPECT() *MockContactRepositoryMockRecorder {
 	return m.recorder
 }
 
+// BackfillContactAttribute mocks base method.
+func (m *MockContactRepository) BackfillContactAttribute(arg0 context.Context, arg1 string, arg2 domain.ContactAttribute) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillContactAttribute", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillContactAttribute indicates an expected call of BackfillContactAttribute.
+func (mr *MockContactRepositoryMockRecorder) BackfillContactAttribute(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillContactAttribute", reflect.TypeOf((*MockContactRepository)(nil).BackfillContactAttribute), arg0, arg1, arg2)
+}
+
 // BulkUpsertContacts mocks base method.
 func (m *MockContactRepository) BulkUpsertContacts(arg0 context.Context, arg1 string, arg2 []*domain.Contact) ([]domain.BulkUpsertResult, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +66,35 @@ func (mr *MockContactRepositoryMockRecorder) BulkUpsertContacts(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpsertContacts", reflect.TypeOf((*MockContactRepository)(nil).BulkUpsertContacts), arg0, arg1, arg2)
 }
 
+// ChangeContactEmail mocks base method.
+func (m *MockContactRepository) ChangeContactEmail(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeContactEmail", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeContactEmail indicates an expected call of ChangeContactEmail.
+func (mr *MockContactRepositoryMockRecorder) ChangeContactEmail(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeContactEmail", reflect.TypeOf((*MockContactRepository)(nil).ChangeContactEmail), arg0, arg1, arg2, arg3)
+}
+
+// ClearComputedProperties mocks base method.
+func (m *MockContactRepository) ClearComputedProperties(arg0 context.Context, arg1 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearComputedProperties", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearComputedProperties indicates an expected call of ClearComputedProperties.
+func (mr *MockContactRepositoryMockRecorder) ClearComputedProperties(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearComputedProperties", reflect.TypeOf((*MockContactRepository)(nil).ClearComputedProperties), arg0, arg1)
+}
+
 // Count mocks base method.
 func (m *MockContactRepository) Count(arg0 context.Context, arg1 string) (int, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +139,20 @@ func (mr *MockContactRepositoryMockRecorder) DeleteContact(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContact", reflect.TypeOf((*MockContactRepository)(nil).DeleteContact), arg0, arg1, arg2)
 }
 
+// DeleteContactAliases mocks base method.
+func (m *MockContactRepository) DeleteContactAliases(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteContactAliases", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteContactAliases indicates an expected call of DeleteContactAliases.
+func (mr *MockContactRepositoryMockRecorder) DeleteContactAliases(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContactAliases", reflect.TypeOf((*MockContactRepository)(nil).DeleteContactAliases), arg0, arg1, arg2)
+}
+
 // GetBatchForSegment mocks base method.
 func (m *MockContactRepository) GetBatchForSegment(arg0 context.Context, arg1 string, arg2 int64, arg3 int) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +168,21 @@ func (mr *MockContactRepositoryMockRecorder) GetBatchForSegment(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchForSegment", reflect.TypeOf((*MockContactRepository)(nil).GetBatchForSegment), arg0, arg1, arg2, arg3)
 }
 
+// GetContactAliases mocks base method.
+func (m *MockContactRepository) GetContactAliases(arg0 context.Context, arg1, arg2 string) ([]*domain.ContactAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactAliases", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.ContactAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactAliases indicates an expected call of GetContactAliases.
+func (mr *MockContactRepositoryMockRecorder) GetContactAliases(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactAliases", reflect.TypeOf((*MockContactRepository)(nil).GetContactAliases), arg0, arg1, arg2)
+}
+
 // GetContactByEmail mocks base method.
 func (m *MockContactRepository) GetContactByEmail(arg0 context.Context, arg1, arg2 string) (*domain.Contact, error) {
 	m.ctrl.T.Helper()
@@ -155,6 +228,21 @@ func (mr *MockContactRepositoryMockRecorder) GetContacts(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockContactRepository)(nil).GetContacts), arg0, arg1)
 }
 
+// GetContactsByEmails mocks base method.
+func (m *MockContactRepository) GetContactsByEmails(arg0 context.Context, arg1 string, arg2 []string) ([]*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactsByEmails", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactsByEmails indicates an expected call of GetContactsByEmails.
+func (mr *MockContactRepositoryMockRecorder) GetContactsByEmails(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactsByEmails", reflect.TypeOf((*MockContactRepository)(nil).GetContactsByEmails), arg0, arg1, arg2)
+}
+
 // GetContactsForBroadcast mocks base method.
 func (m *MockContactRepository) GetContactsForBroadcast(arg0 context.Context, arg1 string, arg2 domain.AudienceSettings, arg3 int, arg4 string) ([]*domain.ContactWithList, error) {
 	m.ctrl.T.Helper()
@@ -184,6 +272,78 @@ func (mr *MockContactRepositoryMockRecorder) MarkEmailsAsBounced(arg0, arg1, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailsAsBounced", reflect.TypeOf((*MockContactRepository)(nil).MarkEmailsAsBounced), arg0, arg1, arg2, arg3)
 }
 
+// MergeContacts mocks base method.
+func (m *MockContactRepository) MergeContacts(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeContacts", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeContacts indicates an expected call of MergeContacts.
+func (mr *MockContactRepositoryMockRecorder) MergeContacts(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeContacts", reflect.TypeOf((*MockContactRepository)(nil).MergeContacts), arg0, arg1, arg2, arg3)
+}
+
+// ResolveContactAlias mocks base method.
+func (m *MockContactRepository) ResolveContactAlias(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveContactAlias", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveContactAlias indicates an expected call of ResolveContactAlias.
+func (mr *MockContactRepositoryMockRecorder) ResolveContactAlias(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveContactAlias", reflect.TypeOf((*MockContactRepository)(nil).ResolveContactAlias), arg0, arg1, arg2)
+}
+
+// SyncContactAttributeIndexes mocks base method.
+func (m *MockContactRepository) SyncContactAttributeIndexes(arg0 context.Context, arg1 string, arg2 []domain.ContactAttribute) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncContactAttributeIndexes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncContactAttributeIndexes indicates an expected call of SyncContactAttributeIndexes.
+func (mr *MockContactRepositoryMockRecorder) SyncContactAttributeIndexes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncContactAttributeIndexes", reflect.TypeOf((*MockContactRepository)(nil).SyncContactAttributeIndexes), arg0, arg1, arg2)
+}
+
+// UpdateComputedProperties mocks base method.
+func (m *MockContactRepository) UpdateComputedProperties(arg0 context.Context, arg1 string, arg2 []string, arg3 string, arg4 []interface{}) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComputedProperties", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComputedProperties indicates an expected call of UpdateComputedProperties.
+func (mr *MockContactRepositoryMockRecorder) UpdateComputedProperties(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComputedProperties", reflect.TypeOf((*MockContactRepository)(nil).UpdateComputedProperties), arg0, arg1, arg2, arg3, arg4)
+}
+
+// UpdateEmailVerifications mocks base method.
+func (m *MockContactRepository) UpdateEmailVerifications(arg0 context.Context, arg1 string, arg2 map[string]*domain.EmailVerification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmailVerifications", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmailVerifications indicates an expected call of UpdateEmailVerifications.
+func (mr *MockContactRepositoryMockRecorder) UpdateEmailVerifications(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmailVerifications", reflect.TypeOf((*MockContactRepository)(nil).UpdateEmailVerifications), arg0, arg1, arg2)
+}
+
 // UpsertContact mocks base method.
 func (m *MockContactRepository) UpsertContact(arg0 context.Context, arg1 string, arg2 *domain.Contact) (bool, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +49,21 @@ func (mr *MockContactServiceMockRecorder) BatchImportContacts(arg0, arg1, arg2,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchImportContacts", reflect.TypeOf((*MockContactService)(nil).BatchImportContacts), arg0, arg1, arg2, arg3)
 }
 
+// ChangeContactEmail mocks base method.
+func (m *MockContactService) ChangeContactEmail(arg0 context.Context, arg1 *domain.ChangeContactEmailRequest) (*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeContactEmail", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeContactEmail indicates an expected call of ChangeContactEmail.
+func (mr *MockContactServiceMockRecorder) ChangeContactEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeContactEmail", reflect.TypeOf((*MockContactService)(nil).ChangeContactEmail), arg0, arg1)
+}
+
 // CountContacts mocks base method.
 func (m *MockContactService) CountContacts(arg0 context.Context, arg1 string) (int, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +93,21 @@ func (mr *MockContactServiceMockRecorder) DeleteContact(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContact", reflect.TypeOf((*MockContactService)(nil).DeleteContact), arg0, arg1, arg2)
 }
 
+// GetContactAliases mocks base method.
+func (m *MockContactService) GetContactAliases(arg0 context.Context, arg1, arg2 string) ([]*domain.ContactAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactAliases", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.ContactAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactAliases indicates an expected call of GetContactAliases.
+func (mr *MockContactServiceMockRecorder) GetContactAliases(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactAliases", reflect.TypeOf((*MockContactService)(nil).GetContactAliases), arg0, arg1, arg2)
+}
+
 // GetContactByEmail mocks base method.
 func (m *MockContactService) GetContactByEmail(arg0 context.Context, arg1, arg2 string) (*domain.Contact, error) {
 	m.ctrl.T.Helper()
@@ -123,6 +153,21 @@ func (mr *MockContactServiceMockRecorder) GetContacts(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockContactService)(nil).GetContacts), arg0, arg1)
 }
 
+// MergeContacts mocks base method.
+func (m *MockContactService) MergeContacts(arg0 context.Context, arg1 *domain.MergeContactsRequest) (*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeContacts", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeContacts indicates an expected call of MergeContacts.
+func (mr *MockContactServiceMockRecorder) MergeContacts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeContacts", reflect.TypeOf((*MockContactService)(nil).MergeContacts), arg0, arg1)
+}
+
 // UpsertContact mocks base method.
 func (m *MockContactService) UpsertContact(arg0 context.Context, arg1 string, arg2 *domain.Contact) domain.UpsertContactOperation {
 	m.ctrl.T.Helper()
@@ -35,6 +35,96 @@ func (m *MockLLMService) EXPECT() *MockLLMServiceMockRecorder {
 	return m.recorder
 }
 
+// ConfirmToolCall mocks base method.
+func (m *MockLLMService) ConfirmToolCall(arg0 context.Context, arg1 *domain.ConfirmLLMToolCallRequest) (*domain.ConfirmLLMToolCallResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmToolCall", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ConfirmLLMToolCallResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmToolCall indicates an expected call of ConfirmToolCall.
+func (mr *MockLLMServiceMockRecorder) ConfirmToolCall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmToolCall", reflect.TypeOf((*MockLLMService)(nil).ConfirmToolCall), arg0, arg1)
+}
+
+// CreateSubjectVariations mocks base method.
+func (m *MockLLMService) CreateSubjectVariations(arg0 context.Context, arg1 *domain.CreateSubjectVariationsRequest) (*domain.Broadcast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubjectVariations", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Broadcast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubjectVariations indicates an expected call of CreateSubjectVariations.
+func (mr *MockLLMServiceMockRecorder) CreateSubjectVariations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubjectVariations", reflect.TypeOf((*MockLLMService)(nil).CreateSubjectVariations), arg0, arg1)
+}
+
+// GenerateSubjectLines mocks base method.
+func (m *MockLLMService) GenerateSubjectLines(arg0 context.Context, arg1 *domain.GenerateSubjectLinesRequest) (*domain.GenerateSubjectLinesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSubjectLines", arg0, arg1)
+	ret0, _ := ret[0].(*domain.GenerateSubjectLinesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateSubjectLines indicates an expected call of GenerateSubjectLines.
+func (mr *MockLLMServiceMockRecorder) GenerateSubjectLines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSubjectLines", reflect.TypeOf((*MockLLMService)(nil).GenerateSubjectLines), arg0, arg1)
+}
+
+// ListOllamaModels mocks base method.
+func (m *MockLLMService) ListOllamaModels(arg0 context.Context, arg1 *domain.ListOllamaModelsRequest) (*domain.ListOllamaModelsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOllamaModels", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ListOllamaModelsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOllamaModels indicates an expected call of ListOllamaModels.
+func (mr *MockLLMServiceMockRecorder) ListOllamaModels(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOllamaModels", reflect.TypeOf((*MockLLMService)(nil).ListOllamaModels), arg0, arg1)
+}
+
+// ListToolCalls mocks base method.
+func (m *MockLLMService) ListToolCalls(arg0 context.Context, arg1 string, arg2 int) ([]*domain.LLMToolCall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListToolCalls", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.LLMToolCall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListToolCalls indicates an expected call of ListToolCalls.
+func (mr *MockLLMServiceMockRecorder) ListToolCalls(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListToolCalls", reflect.TypeOf((*MockLLMService)(nil).ListToolCalls), arg0, arg1, arg2)
+}
+
+// RewriteBlocks mocks base method.
+func (m *MockLLMService) RewriteBlocks(arg0 context.Context, arg1 *domain.RewriteBlocksRequest) (*domain.RewriteBlocksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RewriteBlocks", arg0, arg1)
+	ret0, _ := ret[0].(*domain.RewriteBlocksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RewriteBlocks indicates an expected call of RewriteBlocks.
+func (mr *MockLLMServiceMockRecorder) RewriteBlocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RewriteBlocks", reflect.TypeOf((*MockLLMService)(nil).RewriteBlocks), arg0, arg1)
+}
+
 // StreamChat mocks base method.
 func (m *MockLLMService) StreamChat(arg0 context.Context, arg1 *domain.LLMChatRequest, arg2 func(domain.LLMChatEvent) error) error {
 	m.ctrl.T.Helper()
@@ -48,3 +138,18 @@ func (mr *MockLLMServiceMockRecorder) StreamChat(arg0, arg1, arg2 interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamChat", reflect.TypeOf((*MockLLMService)(nil).StreamChat), arg0, arg1, arg2)
 }
+
+// TranslateTemplates mocks base method.
+func (m *MockLLMService) TranslateTemplates(arg0 context.Context, arg1 *domain.TranslateTemplatesRequest) (*domain.TranslateTemplatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TranslateTemplates", arg0, arg1)
+	ret0, _ := ret[0].(*domain.TranslateTemplatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TranslateTemplates indicates an expected call of TranslateTemplates.
+func (mr *MockLLMServiceMockRecorder) TranslateTemplates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslateTemplates", reflect.TypeOf((*MockLLMService)(nil).TranslateTemplates), arg0, arg1)
+}
@@ -171,6 +171,21 @@ func (mr *MockMessageHistoryRepositoryMockRecorder) GetBySMTPMessageID(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySMTPMessageID", reflect.TypeOf((*MockMessageHistoryRepository)(nil).GetBySMTPMessageID), arg0, arg1, arg2)
 }
 
+// GetSubjectPerformance mocks base method.
+func (m *MockMessageHistoryRepository) GetSubjectPerformance(arg0 context.Context, arg1 string, arg2 domain.SubjectPerformanceParams) ([]*domain.SubjectPerformance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubjectPerformance", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.SubjectPerformance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubjectPerformance indicates an expected call of GetSubjectPerformance.
+func (mr *MockMessageHistoryRepositoryMockRecorder) GetSubjectPerformance(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubjectPerformance", reflect.TypeOf((*MockMessageHistoryRepository)(nil).GetSubjectPerformance), arg0, arg1, arg2)
+}
+
 // ListMessages mocks base method.
 func (m *MockMessageHistoryRepository) ListMessages(arg0 context.Context, arg1, arg2 string, arg3 domain.MessageListParams) ([]*domain.MessageHistory, string, error) {
 	m.ctrl.T.Helper()
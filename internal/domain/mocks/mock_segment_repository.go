@@ -123,6 +123,21 @@ func (mr *MockSegmentRepositoryMockRecorder) GetSegmentContactCount(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSegmentContactCount", reflect.TypeOf((*MockSegmentRepository)(nil).GetSegmentContactCount), arg0, arg1, arg2)
 }
 
+// GetSegmentHistory mocks base method.
+func (m *MockSegmentRepository) GetSegmentHistory(arg0 context.Context, arg1, arg2 string, arg3, arg4 time.Time) ([]*domain.SegmentHistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSegmentHistory", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*domain.SegmentHistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSegmentHistory indicates an expected call of GetSegmentHistory.
+func (mr *MockSegmentRepositoryMockRecorder) GetSegmentHistory(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSegmentHistory", reflect.TypeOf((*MockSegmentRepository)(nil).GetSegmentHistory), arg0, arg1, arg2, arg3, arg4)
+}
+
 // GetSegments mocks base method.
 func (m *MockSegmentRepository) GetSegments(arg0 context.Context, arg1 string, arg2 bool) ([]*domain.Segment, error) {
 	m.ctrl.T.Helper()
@@ -196,6 +211,21 @@ func (mr *MockSegmentRepositoryMockRecorder) RemoveOldMemberships(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveOldMemberships", reflect.TypeOf((*MockSegmentRepository)(nil).RemoveOldMemberships), arg0, arg1, arg2, arg3)
 }
 
+// SnapshotSegmentHistory mocks base method.
+func (m *MockSegmentRepository) SnapshotSegmentHistory(arg0 context.Context, arg1 string, arg2 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotSegmentHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotSegmentHistory indicates an expected call of SnapshotSegmentHistory.
+func (mr *MockSegmentRepositoryMockRecorder) SnapshotSegmentHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotSegmentHistory", reflect.TypeOf((*MockSegmentRepository)(nil).SnapshotSegmentHistory), arg0, arg1, arg2)
+}
+
 // UpdateRecomputeAfter mocks base method.
 func (m *MockSegmentRepository) UpdateRecomputeAfter(arg0 context.Context, arg1, arg2 string, arg3 *time.Time) error {
 	m.ctrl.T.Helper()
@@ -94,6 +94,21 @@ func (mr *MockSegmentServiceMockRecorder) GetSegmentContacts(arg0, arg1, arg2, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSegmentContacts", reflect.TypeOf((*MockSegmentService)(nil).GetSegmentContacts), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GetSegmentHistory mocks base method.
+func (m *MockSegmentService) GetSegmentHistory(arg0 context.Context, arg1 *domain.GetSegmentHistoryRequest) ([]*domain.SegmentHistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSegmentHistory", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.SegmentHistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSegmentHistory indicates an expected call of GetSegmentHistory.
+func (mr *MockSegmentServiceMockRecorder) GetSegmentHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSegmentHistory", reflect.TypeOf((*MockSegmentService)(nil).GetSegmentHistory), arg0, arg1)
+}
+
 // ListSegments mocks base method.
 func (m *MockSegmentService) ListSegments(arg0 context.Context, arg1 *domain.GetSegmentsRequest) ([]*domain.Segment, error) {
 	m.ctrl.T.Helper()
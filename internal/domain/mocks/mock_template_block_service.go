@@ -78,6 +78,21 @@ func (mr *MockTemplateBlockServiceMockRecorder) GetTemplateBlock(arg0, arg1, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateBlock", reflect.TypeOf((*MockTemplateBlockService)(nil).GetTemplateBlock), arg0, arg1, arg2)
 }
 
+// GetTemplateBlockUsage mocks base method.
+func (m *MockTemplateBlockService) GetTemplateBlockUsage(arg0 context.Context, arg1, arg2 string) (*domain.TemplateBlockUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateBlockUsage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.TemplateBlockUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateBlockUsage indicates an expected call of GetTemplateBlockUsage.
+func (mr *MockTemplateBlockServiceMockRecorder) GetTemplateBlockUsage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateBlockUsage", reflect.TypeOf((*MockTemplateBlockService)(nil).GetTemplateBlockUsage), arg0, arg1, arg2)
+}
+
 // ListTemplateBlocks mocks base method.
 func (m *MockTemplateBlockService) ListTemplateBlocks(arg0 context.Context, arg1 string) ([]*domain.TemplateBlock, error) {
 	m.ctrl.T.Helper()
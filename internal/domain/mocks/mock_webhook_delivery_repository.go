@@ -65,6 +65,36 @@ func (mr *MockWebhookDeliveryRepositoryMockRecorder) Create(arg0, arg1, arg2 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).Create), arg0, arg1, arg2)
 }
 
+// GetByID mocks base method.
+func (m *MockWebhookDeliveryRepository) GetByID(arg0 context.Context, arg1, arg2 string) (*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) GetByID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).GetByID), arg0, arg1, arg2)
+}
+
+// GetPendingForSubscription mocks base method.
+func (m *MockWebhookDeliveryRepository) GetPendingForSubscription(arg0 context.Context, arg1, arg2 string, arg3 int) ([]*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingForSubscription", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingForSubscription indicates an expected call of GetPendingForSubscription.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) GetPendingForSubscription(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingForSubscription", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).GetPendingForSubscription), arg0, arg1, arg2, arg3)
+}
+
 // GetPendingForWorkspace mocks base method.
 func (m *MockWebhookDeliveryRepository) GetPendingForWorkspace(arg0 context.Context, arg1 string, arg2 int) ([]*domain.WebhookDelivery, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +126,20 @@ func (mr *MockWebhookDeliveryRepositoryMockRecorder) ListAll(arg0, arg1, arg2, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAll", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).ListAll), arg0, arg1, arg2, arg3, arg4)
 }
 
+// MarkBatchDelivered mocks base method.
+func (m *MockWebhookDeliveryRepository) MarkBatchDelivered(arg0 context.Context, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkBatchDelivered", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkBatchDelivered indicates an expected call of MarkBatchDelivered.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) MarkBatchDelivered(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBatchDelivered", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).MarkBatchDelivered), arg0, arg1, arg2)
+}
+
 // MarkDelivered mocks base method.
 func (m *MockWebhookDeliveryRepository) MarkDelivered(arg0 context.Context, arg1, arg2 string, arg3 int, arg4 string) error {
 	m.ctrl.T.Helper()
@@ -124,6 +168,35 @@ func (mr *MockWebhookDeliveryRepositoryMockRecorder) MarkFailed(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).MarkFailed), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// Requeue mocks base method.
+func (m *MockWebhookDeliveryRepository) Requeue(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Requeue", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Requeue indicates an expected call of Requeue.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) Requeue(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Requeue", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).Requeue), arg0, arg1, arg2)
+}
+
+// RequeueFailed mocks base method.
+func (m *MockWebhookDeliveryRepository) RequeueFailed(arg0 context.Context, arg1 string, arg2 *string, arg3, arg4 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueFailed", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueFailed indicates an expected call of RequeueFailed.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) RequeueFailed(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueFailed", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).RequeueFailed), arg0, arg1, arg2, arg3, arg4)
+}
+
 // ScheduleRetry mocks base method.
 func (m *MockWebhookDeliveryRepository) ScheduleRetry(arg0 context.Context, arg1, arg2 string, arg3 time.Time, arg4 int, arg5 *int, arg6, arg7 *string) error {
 	m.ctrl.T.Helper()
@@ -64,6 +64,20 @@ func (mr *MockWebhookSubscriptionRepositoryMockRecorder) Delete(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookSubscriptionRepository)(nil).Delete), arg0, arg1, arg2)
 }
 
+// Disable mocks base method.
+func (m *MockWebhookSubscriptionRepository) Disable(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockWebhookSubscriptionRepositoryMockRecorder) Disable(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockWebhookSubscriptionRepository)(nil).Disable), arg0, arg1, arg2, arg3)
+}
+
 // GetByID mocks base method.
 func (m *MockWebhookSubscriptionRepository) GetByID(arg0 context.Context, arg1, arg2 string) (*domain.WebhookSubscription, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +108,21 @@ func (mr *MockWebhookSubscriptionRepositoryMockRecorder) List(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhookSubscriptionRepository)(nil).List), arg0, arg1)
 }
 
+// RecordFailure mocks base method.
+func (m *MockWebhookSubscriptionRepository) RecordFailure(arg0 context.Context, arg1, arg2 string, arg3 time.Time) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockWebhookSubscriptionRepositoryMockRecorder) RecordFailure(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockWebhookSubscriptionRepository)(nil).RecordFailure), arg0, arg1, arg2, arg3)
+}
+
 // Update mocks base method.
 func (m *MockWebhookSubscriptionRepository) Update(arg0 context.Context, arg1 string, arg2 *domain.WebhookSubscription) error {
 	m.ctrl.T.Helper()
@@ -270,6 +270,34 @@ func (mr *MockWorkspaceServiceInterfaceMockRecorder) SetBlogSettings(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBlogSettings", reflect.TypeOf((*MockWorkspaceServiceInterface)(nil).SetBlogSettings), arg0, arg1, arg2, arg3)
 }
 
+// SetComputedProperties mocks base method.
+func (m *MockWorkspaceServiceInterface) SetComputedProperties(arg0 context.Context, arg1 string, arg2 []domain.ComputedProperty) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetComputedProperties", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetComputedProperties indicates an expected call of SetComputedProperties.
+func (mr *MockWorkspaceServiceInterfaceMockRecorder) SetComputedProperties(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetComputedProperties", reflect.TypeOf((*MockWorkspaceServiceInterface)(nil).SetComputedProperties), arg0, arg1, arg2)
+}
+
+// SetContactAttributes mocks base method.
+func (m *MockWorkspaceServiceInterface) SetContactAttributes(arg0 context.Context, arg1 string, arg2 []domain.ContactAttribute) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetContactAttributes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetContactAttributes indicates an expected call of SetContactAttributes.
+func (mr *MockWorkspaceServiceInterfaceMockRecorder) SetContactAttributes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetContactAttributes", reflect.TypeOf((*MockWorkspaceServiceInterface)(nil).SetContactAttributes), arg0, arg1, arg2)
+}
+
 // SetCustomFieldLabels mocks base method.
 func (m *MockWorkspaceServiceInterface) SetCustomFieldLabels(arg0 context.Context, arg1 string, arg2 map[string]string) error {
 	m.ctrl.T.Helper()
@@ -3,8 +3,10 @@ package domain
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/url"
 	"regexp"
+	"slices"
 )
 
 //go:generate mockgen -destination mocks/mock_notification_center_service.go -package mocks github.com/Notifuse/notifuse/internal/domain NotificationCenterService
@@ -12,7 +14,7 @@ import (
 type NotificationCenterService interface {
 	// GetContactPreferences returns public lists and notifications for a contact
 	GetContactPreferences(ctx context.Context, workspaceID string, email string, emailHMAC string) (*ContactPreferencesResponse, error)
-	// UpdateContactPreferences updates a contact's language and/or timezone
+	// UpdateContactPreferences updates a contact's language, timezone, marketing pause and list frequencies
 	UpdateContactPreferences(ctx context.Context, req *UpdateContactPreferencesRequest) error
 }
 
@@ -57,13 +59,17 @@ type ContactPreferencesResponse struct {
 	WebsiteURL   string         `json:"website_url"`
 }
 
-// UpdateContactPreferencesRequest represents a request to update a contact's language/timezone
+// UpdateContactPreferencesRequest represents a request to update a contact's preferences
 type UpdateContactPreferencesRequest struct {
 	WorkspaceID string `json:"workspace_id"`
 	Email       string `json:"email"`
 	EmailHMAC   string `json:"email_hmac"`
 	Language    string `json:"language,omitempty"`
 	Timezone    string `json:"timezone,omitempty"`
+	// PauseDays pauses marketing emails for one of ContactListPauseDays, 0 resumes them
+	PauseDays *int `json:"pause_days,omitempty"`
+	// ListFrequencies sets the delivery frequency of the contact per public list ID
+	ListFrequencies map[string]ContactListFrequency `json:"list_frequencies,omitempty"`
 }
 
 var languageCodeRegex = regexp.MustCompile(`^[a-z]{2}$`)
@@ -78,8 +84,8 @@ func (r *UpdateContactPreferencesRequest) Validate() error {
 	if r.EmailHMAC == "" {
 		return errors.New("email_hmac is required")
 	}
-	if r.Language == "" && r.Timezone == "" {
-		return errors.New("at least one of language or timezone must be provided")
+	if r.Language == "" && r.Timezone == "" && r.PauseDays == nil && len(r.ListFrequencies) == 0 {
+		return errors.New("at least one of language, timezone, pause_days or list_frequencies must be provided")
 	}
 	if r.Language != "" && !languageCodeRegex.MatchString(r.Language) {
 		return errors.New("language must be a 2-letter lowercase code")
@@ -87,5 +93,16 @@ func (r *UpdateContactPreferencesRequest) Validate() error {
 	if r.Timezone != "" && (len(r.Timezone) > 50 || len(r.Timezone) < 2) {
 		return errors.New("timezone must be between 2 and 50 characters")
 	}
+	if r.PauseDays != nil && *r.PauseDays != 0 && !slices.Contains(ContactListPauseDays, *r.PauseDays) {
+		return fmt.Errorf("pause_days must be 0 or one of %v", ContactListPauseDays)
+	}
+	for listID, frequency := range r.ListFrequencies {
+		if listID == "" {
+			return errors.New("list_frequencies contains an empty list id")
+		}
+		if !frequency.IsValid() {
+			return fmt.Errorf("invalid frequency %q for list %s", frequency, listID)
+		}
+	}
 	return nil
 }
@@ -383,6 +383,53 @@ func TestUpdateContactPreferencesRequest_Validate(t *testing.T) {
 				Timezone:    "America/New_York",
 			},
 		},
+		{
+			name: "valid pause",
+			request: UpdateContactPreferencesRequest{
+				WorkspaceID: "ws1",
+				Email:       "test@example.com",
+				EmailHMAC:   "hmac",
+				PauseDays:   intPtr(60),
+			},
+		},
+		{
+			name: "valid resume",
+			request: UpdateContactPreferencesRequest{
+				WorkspaceID: "ws1",
+				Email:       "test@example.com",
+				EmailHMAC:   "hmac",
+				PauseDays:   intPtr(0),
+			},
+		},
+		{
+			name: "invalid pause length",
+			request: UpdateContactPreferencesRequest{
+				WorkspaceID: "ws1",
+				Email:       "test@example.com",
+				EmailHMAC:   "hmac",
+				PauseDays:   intPtr(45),
+			},
+			wantErr: "pause_days must be 0 or one of [30 60 90]",
+		},
+		{
+			name: "valid list frequencies",
+			request: UpdateContactPreferencesRequest{
+				WorkspaceID:     "ws1",
+				Email:           "test@example.com",
+				EmailHMAC:       "hmac",
+				ListFrequencies: map[string]ContactListFrequency{"news": ContactListFrequencyWeeklyDigest},
+			},
+		},
+		{
+			name: "invalid list frequency",
+			request: UpdateContactPreferencesRequest{
+				WorkspaceID:     "ws1",
+				Email:           "test@example.com",
+				EmailHMAC:       "hmac",
+				ListFrequencies: map[string]ContactListFrequency{"news": "daily"},
+			},
+			wantErr: `invalid frequency "daily" for list news`,
+		},
 		{
 			name: "missing workspace_id",
 			request: UpdateContactPreferencesRequest{
@@ -417,7 +464,7 @@ func TestUpdateContactPreferencesRequest_Validate(t *testing.T) {
 				Email:       "test@example.com",
 				EmailHMAC:   "hmac",
 			},
-			wantErr: "at least one of language or timezone must be provided",
+			wantErr: "at least one of language, timezone, pause_days or list_frequencies must be provided",
 		},
 		{
 			name: "invalid language - too long",
@@ -377,6 +377,83 @@ func (r *DeleteSegmentRequest) Validate() (workspaceID string, id string, err er
 	return r.WorkspaceID, r.ID, nil
 }
 
+// SegmentHistoryEntry is a daily snapshot of a segment size and its membership churn.
+// The snapshot of the current day is refreshed hourly and holds the end-of-day values
+// once finalized after midnight; CreatedAt is when it was last written.
+type SegmentHistoryEntry struct {
+	SegmentID   string    `json:"segment_id"`
+	Day         string    `json:"day"` // YYYY-MM-DD (UTC)
+	UsersCount  int       `json:"users_count"`
+	JoinedCount int       `json:"joined_count"`
+	LeftCount   int       `json:"left_count"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// SegmentHistoryDateFormat is the date layout used by segment history days
+const SegmentHistoryDateFormat = "2006-01-02"
+
+// maxSegmentHistoryDays bounds the range a single history request may cover
+const maxSegmentHistoryDays = 366
+
+type GetSegmentHistoryRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	SegmentID   string `json:"segment_id"`
+	From        string `json:"from,omitempty"` // YYYY-MM-DD, defaults to 30 days ago
+	To          string `json:"to,omitempty"`   // YYYY-MM-DD, defaults to today
+}
+
+func (r *GetSegmentHistoryRequest) FromURLParams(values url.Values) error {
+	r.WorkspaceID = values.Get("workspace_id")
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+
+	r.SegmentID = values.Get("segment_id")
+	if r.SegmentID == "" {
+		return fmt.Errorf("segment_id is required")
+	}
+
+	r.From = values.Get("from")
+	r.To = values.Get("to")
+	return nil
+}
+
+// Validate checks the request and returns the inclusive day range to fetch
+func (r *GetSegmentHistoryRequest) Validate() (from time.Time, to time.Time, err error) {
+	if r.WorkspaceID == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid get segment history request: workspace_id is required")
+	}
+	if r.SegmentID == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid get segment history request: segment_id is required")
+	}
+
+	now := time.Now().UTC()
+	to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if r.To != "" {
+		to, err = time.Parse(SegmentHistoryDateFormat, r.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid get segment history request: to must be formatted as YYYY-MM-DD")
+		}
+	}
+
+	from = to.AddDate(0, 0, -30)
+	if r.From != "" {
+		from, err = time.Parse(SegmentHistoryDateFormat, r.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid get segment history request: from must be formatted as YYYY-MM-DD")
+		}
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid get segment history request: from must be before to")
+	}
+	if to.Sub(from) > maxSegmentHistoryDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid get segment history request: range cannot exceed %d days", maxSegmentHistoryDays)
+	}
+
+	return from, to, nil
+}
+
 type PreviewSegmentResponse struct {
 	Emails       []string      `json:"emails"`
 	TotalCount   int           `json:"total_count"`
@@ -410,6 +487,9 @@ type SegmentService interface {
 
 	// GetSegmentContacts retrieves the contacts belonging to a segment
 	GetSegmentContacts(ctx context.Context, workspaceID, segmentID string, limit, offset int) ([]string, error)
+
+	// GetSegmentHistory retrieves the daily size and churn snapshots of a segment
+	GetSegmentHistory(ctx context.Context, req *GetSegmentHistoryRequest) ([]*SegmentHistoryEntry, error)
 }
 
 type SegmentRepository interface {
@@ -451,6 +531,14 @@ type SegmentRepository interface {
 
 	// UpdateRecomputeAfter updates only the recompute_after field for a segment
 	UpdateRecomputeAfter(ctx context.Context, workspaceID string, segmentID string, recomputeAfter *time.Time) error
+
+	// SnapshotSegmentHistory records the size and join/leave counts of every segment under the UTC day of now,
+	// and finalizes the snapshots of the previous day taken before midnight. Snapshots of the current day taken
+	// less than an hour ago are left untouched. Returns the number of snapshots written.
+	SnapshotSegmentHistory(ctx context.Context, workspaceID string, now time.Time) (int, error)
+
+	// GetSegmentHistory retrieves the daily snapshots of a segment between two UTC days (inclusive)
+	GetSegmentHistory(ctx context.Context, workspaceID string, segmentID string, from, to time.Time) ([]*SegmentHistoryEntry, error)
 }
 
 // ErrSegmentNotFound is returned when a segment is not found
@@ -1026,3 +1026,70 @@ func TestErrSegmentNotFound_Error(t *testing.T) {
 		assert.Equal(t, "", err.Error())
 	})
 }
+
+func TestGetSegmentHistoryRequest_FromURLParams(t *testing.T) {
+	t.Run("all params", func(t *testing.T) {
+		var req GetSegmentHistoryRequest
+		err := req.FromURLParams(url.Values{
+			"workspace_id": []string{"ws1"},
+			"segment_id":   []string{"vip"},
+			"from":         []string{"2026-01-01"},
+			"to":           []string{"2026-01-31"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "ws1", req.WorkspaceID)
+		assert.Equal(t, "vip", req.SegmentID)
+		assert.Equal(t, "2026-01-01", req.From)
+		assert.Equal(t, "2026-01-31", req.To)
+	})
+
+	t.Run("missing workspace_id", func(t *testing.T) {
+		var req GetSegmentHistoryRequest
+		err := req.FromURLParams(url.Values{"segment_id": []string{"vip"}})
+		assert.EqualError(t, err, "workspace_id is required")
+	})
+
+	t.Run("missing segment_id", func(t *testing.T) {
+		var req GetSegmentHistoryRequest
+		err := req.FromURLParams(url.Values{"workspace_id": []string{"ws1"}})
+		assert.EqualError(t, err, "segment_id is required")
+	})
+}
+
+func TestGetSegmentHistoryRequest_Validate(t *testing.T) {
+	t.Run("explicit range", func(t *testing.T) {
+		req := &GetSegmentHistoryRequest{WorkspaceID: "ws1", SegmentID: "vip", From: "2026-01-01", To: "2026-01-31"}
+		from, to, err := req.Validate()
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), from)
+		assert.Equal(t, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), to)
+	})
+
+	t.Run("defaults to the last 30 days", func(t *testing.T) {
+		req := &GetSegmentHistoryRequest{WorkspaceID: "ws1", SegmentID: "vip"}
+		from, to, err := req.Validate()
+		require.NoError(t, err)
+		assert.Equal(t, time.Now().UTC().Format(SegmentHistoryDateFormat), to.Format(SegmentHistoryDateFormat))
+		assert.Equal(t, to.AddDate(0, 0, -30), from)
+	})
+
+	tests := []struct {
+		name        string
+		req         GetSegmentHistoryRequest
+		errContains string
+	}{
+		{"missing workspace", GetSegmentHistoryRequest{SegmentID: "vip"}, "workspace_id is required"},
+		{"missing segment", GetSegmentHistoryRequest{WorkspaceID: "ws1"}, "segment_id is required"},
+		{"invalid from", GetSegmentHistoryRequest{WorkspaceID: "ws1", SegmentID: "vip", From: "2026/01/01"}, "from must be formatted"},
+		{"invalid to", GetSegmentHistoryRequest{WorkspaceID: "ws1", SegmentID: "vip", To: "tomorrow"}, "to must be formatted"},
+		{"from after to", GetSegmentHistoryRequest{WorkspaceID: "ws1", SegmentID: "vip", From: "2026-02-01", To: "2026-01-01"}, "from must be before to"},
+		{"range too long", GetSegmentHistoryRequest{WorkspaceID: "ws1", SegmentID: "vip", From: "2024-01-01", To: "2026-01-01"}, "range cannot exceed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.req.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
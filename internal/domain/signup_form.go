@@ -0,0 +1,850 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Notifuse/notifuse/pkg/captcha"
+	"github.com/Notifuse/notifuse/pkg/crypto"
+	"github.com/asaskevich/govalidator"
+)
+
+//go:generate mockgen -destination mocks/mock_signup_form_service.go -package mocks github.com/Notifuse/notifuse/internal/domain SignupFormService
+//go:generate mockgen -destination mocks/mock_signup_form_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain SignupFormRepository
+
+// Signup form spam protection modes
+const (
+	SignupFormCaptchaNone        = "none"
+	SignupFormCaptchaProofOfWork = "proof_of_work"
+	SignupFormCaptchaTurnstile   = captcha.ProviderTurnstile
+	SignupFormCaptchaHCaptcha    = captcha.ProviderHCaptcha
+	SignupFormCaptchaRecaptcha   = captcha.ProviderRecaptcha
+)
+
+// SignupFormHoneypotField is the name of the hidden input rendered on every form.
+// Humans never see it, a submission filling it is treated as spam.
+const SignupFormHoneypotField = "website"
+
+const (
+	maxSignupFormFields = 20
+	maxSignupFormLists  = 20
+)
+
+// signupFormContactFields are the contact fields a form can collect in addition to the email
+var signupFormContactFields = map[string]string{
+	"first_name":     "text",
+	"last_name":      "text",
+	"full_name":      "text",
+	"phone":          "tel",
+	"job_title":      "text",
+	"address_line_1": "text",
+	"address_line_2": "text",
+	"postcode":       "text",
+	"state":          "text",
+	"country":        "text",
+	"language":       "text",
+	"timezone":       "text",
+}
+
+var signupFormColorRegex = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// SignupFormField maps an input of the form onto a contact field
+type SignupFormField struct {
+	Key         string `json:"key"` // contact field (e.g. "first_name") or "attributes.<key>"
+	Label       string `json:"label"`
+	Placeholder string `json:"placeholder,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// SignupFormFields is stored as JSONB
+type SignupFormFields []SignupFormField
+
+// Value implements the driver.Valuer interface
+func (f SignupFormFields) Value() (driver.Value, error) {
+	if f == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan implements the sql.Scanner interface
+func (f *SignupFormFields) Scan(val interface{}) error {
+	return scanSignupFormJSON(val, f)
+}
+
+// SignupFormStyle customizes the rendering of the hosted page and the embedded form
+type SignupFormStyle struct {
+	Title           string `json:"title,omitempty"`
+	Description     string `json:"description,omitempty"`
+	ButtonText      string `json:"button_text,omitempty"`
+	PrimaryColor    string `json:"primary_color,omitempty"`
+	BackgroundColor string `json:"background_color,omitempty"`
+	TextColor       string `json:"text_color,omitempty"`
+}
+
+// Value implements the driver.Valuer interface
+func (s SignupFormStyle) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Scan implements the sql.Scanner interface
+func (s *SignupFormStyle) Scan(val interface{}) error {
+	return scanSignupFormJSON(val, s)
+}
+
+// Validate validates the form style
+func (s *SignupFormStyle) Validate() error {
+	if len(s.Title) > 255 {
+		return fmt.Errorf("title exceeds maximum length of 255 characters")
+	}
+	if len(s.Description) > 1000 {
+		return fmt.Errorf("description exceeds maximum length of 1000 characters")
+	}
+	if len(s.ButtonText) > 50 {
+		return fmt.Errorf("button_text exceeds maximum length of 50 characters")
+	}
+	for name, color := range map[string]string{
+		"primary_color":    s.PrimaryColor,
+		"background_color": s.BackgroundColor,
+		"text_color":       s.TextColor,
+	} {
+		if color != "" && !signupFormColorRegex.MatchString(color) {
+			return fmt.Errorf("%s must be a hex color (e.g. #1677ff)", name)
+		}
+	}
+	return nil
+}
+
+// SignupFormCaptcha configures the bot protection of a form. Every form has a honeypot
+// field; on top of it submissions can require a proof of work solved by the browser or
+// a token from a third-party captcha widget (Turnstile, hCaptcha, reCAPTCHA).
+type SignupFormCaptcha struct {
+	Provider           string `json:"provider"`             // none, proof_of_work, turnstile, hcaptcha, recaptcha
+	SiteKey            string `json:"site_key,omitempty"`   // third-party providers
+	Difficulty         int    `json:"difficulty,omitempty"` // proof_of_work leading zero bits, defaults to 16
+	EncryptedSecretKey string `json:"encrypted_secret_key,omitempty"`
+
+	// Decoded secret key, not stored in the database
+	SecretKey string `json:"secret_key,omitempty"`
+}
+
+// Value implements the driver.Valuer interface, the plaintext secret key is never stored
+func (c SignupFormCaptcha) Value() (driver.Value, error) {
+	c.SecretKey = ""
+	return json.Marshal(c)
+}
+
+// Scan implements the sql.Scanner interface
+func (c *SignupFormCaptcha) Scan(val interface{}) error {
+	return scanSignupFormJSON(val, c)
+}
+
+// IsThirdParty returns true if submissions are verified by an external captcha provider
+func (c *SignupFormCaptcha) IsThirdParty() bool {
+	return captcha.IsSupportedProvider(c.Provider)
+}
+
+// GetDifficulty returns the proof of work difficulty, or the default one
+func (c *SignupFormCaptcha) GetDifficulty() int {
+	if c.Difficulty == 0 {
+		return captcha.DefaultDifficulty
+	}
+	return c.Difficulty
+}
+
+// Validate validates the captcha settings
+func (c *SignupFormCaptcha) Validate() error {
+	switch c.Provider {
+	case "", SignupFormCaptchaNone:
+		c.Provider = SignupFormCaptchaNone
+	case SignupFormCaptchaProofOfWork:
+		if c.Difficulty != 0 && (c.Difficulty < captcha.MinDifficulty || c.Difficulty > captcha.MaxDifficulty) {
+			return fmt.Errorf("difficulty must be between %d and %d", captcha.MinDifficulty, captcha.MaxDifficulty)
+		}
+	case SignupFormCaptchaTurnstile, SignupFormCaptchaHCaptcha, SignupFormCaptchaRecaptcha:
+		if c.SiteKey == "" {
+			return fmt.Errorf("site_key is required for %s", c.Provider)
+		}
+		if c.SecretKey == "" && c.EncryptedSecretKey == "" {
+			return fmt.Errorf("secret_key is required for %s", c.Provider)
+		}
+	default:
+		return fmt.Errorf("invalid captcha provider: %s", c.Provider)
+	}
+	return nil
+}
+
+// EncryptSecretKey encrypts the secret key and clears its plaintext value
+func (c *SignupFormCaptcha) EncryptSecretKey(passphrase string) error {
+	if c.SecretKey == "" {
+		return nil
+	}
+	encrypted, err := crypto.EncryptString(c.SecretKey, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt captcha secret key: %w", err)
+	}
+	c.EncryptedSecretKey = encrypted
+	c.SecretKey = ""
+	return nil
+}
+
+// DecryptSecretKey decrypts the encrypted secret key
+func (c *SignupFormCaptcha) DecryptSecretKey(passphrase string) error {
+	if c.EncryptedSecretKey == "" {
+		return nil
+	}
+	secretKey, err := crypto.DecryptFromHexString(c.EncryptedSecretKey, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt captcha secret key: %w", err)
+	}
+	c.SecretKey = secretKey
+	return nil
+}
+
+// SignupForm is a hosted and embeddable form subscribing visitors to lists
+type SignupForm struct {
+	ID                 string            `json:"id"`
+	Name               string            `json:"name"`
+	ListIDs            []string          `json:"list_ids"`
+	Fields             SignupFormFields  `json:"fields"`
+	ConsentText        string            `json:"consent_text,omitempty"`         // shown next to a required checkbox when set
+	ConsentTextVersion string            `json:"consent_text_version,omitempty"` // fingerprint of consent_text, stored on consent records
+	SuccessMessage     string            `json:"success_message,omitempty"`
+	SuccessRedirectURL string            `json:"success_redirect_url,omitempty"`
+	Style              SignupFormStyle   `json:"style"`
+	Captcha            SignupFormCaptcha `json:"captcha"`
+	Enabled            bool              `json:"enabled"`
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+	DeletedAt          *time.Time        `json:"-"`
+}
+
+// Validate validates the form. Field keys are checked against the workspace
+// attribute registry by ValidateFields.
+func (f *SignupForm) Validate() error {
+	if f.ID == "" {
+		return fmt.Errorf("invalid signup form: id is required")
+	}
+	if !govalidator.IsAlphanumeric(f.ID) {
+		return fmt.Errorf("invalid signup form: id must be alphanumeric")
+	}
+	if len(f.ID) > 32 {
+		return fmt.Errorf("invalid signup form: id length must be between 1 and 32")
+	}
+	if f.Name == "" {
+		return fmt.Errorf("invalid signup form: name is required")
+	}
+	if len(f.Name) > 255 {
+		return fmt.Errorf("invalid signup form: name length must be between 1 and 255")
+	}
+
+	if len(f.ListIDs) == 0 {
+		return fmt.Errorf("invalid signup form: at least one list is required")
+	}
+	if len(f.ListIDs) > maxSignupFormLists {
+		return fmt.Errorf("invalid signup form: cannot target more than %d lists", maxSignupFormLists)
+	}
+	seenLists := make(map[string]bool, len(f.ListIDs))
+	for _, listID := range f.ListIDs {
+		if listID == "" {
+			return fmt.Errorf("invalid signup form: list id cannot be empty")
+		}
+		if seenLists[listID] {
+			return fmt.Errorf("invalid signup form: duplicate list %s", listID)
+		}
+		seenLists[listID] = true
+	}
+
+	if len(f.Fields) > maxSignupFormFields {
+		return fmt.Errorf("invalid signup form: cannot have more than %d fields", maxSignupFormFields)
+	}
+	seenFields := make(map[string]bool, len(f.Fields))
+	for _, field := range f.Fields {
+		if field.Key == "" {
+			return fmt.Errorf("invalid signup form: field key is required")
+		}
+		if seenFields[field.Key] {
+			return fmt.Errorf("invalid signup form: duplicate field %s", field.Key)
+		}
+		seenFields[field.Key] = true
+		if len(field.Label) > 255 || len(field.Placeholder) > 255 {
+			return fmt.Errorf("invalid signup form: field %s label and placeholder cannot exceed 255 characters", field.Key)
+		}
+	}
+
+	if len(f.ConsentText) > 2000 {
+		return fmt.Errorf("invalid signup form: consent_text exceeds maximum length of 2000 characters")
+	}
+	if len(f.SuccessMessage) > 1000 {
+		return fmt.Errorf("invalid signup form: success_message exceeds maximum length of 1000 characters")
+	}
+	if f.SuccessRedirectURL != "" {
+		u, err := url.Parse(f.SuccessRedirectURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid signup form: success_redirect_url must be an absolute http(s) URL")
+		}
+	}
+
+	if err := f.Style.Validate(); err != nil {
+		return fmt.Errorf("invalid signup form: style: %w", err)
+	}
+	if err := f.Captcha.Validate(); err != nil {
+		return fmt.Errorf("invalid signup form: captcha: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateFields checks that every field maps onto a contact field or onto an
+// attribute of the workspace registry that can be typed into a form input
+func (f *SignupForm) ValidateFields(attrs []ContactAttribute) error {
+	for _, field := range f.Fields {
+		if _, err := signupFormInputType(field.Key, attrs); err != nil {
+			return fmt.Errorf("invalid signup form: %w", err)
+		}
+	}
+	return nil
+}
+
+// ComputeConsentTextVersion sets the consent text version to a fingerprint of the
+// consent text, so consent records can prove which wording a contact agreed to
+func (f *SignupForm) ComputeConsentTextVersion() {
+	if f.ConsentText == "" {
+		f.ConsentTextVersion = ""
+		return
+	}
+	sum := sha256.Sum256([]byte(f.ConsentText))
+	f.ConsentTextVersion = hex.EncodeToString(sum[:])[:12]
+}
+
+// signupFormInputType returns the HTML input type of a field key
+func signupFormInputType(key string, attrs []ContactAttribute) (string, error) {
+	if inputType, ok := signupFormContactFields[key]; ok {
+		return inputType, nil
+	}
+
+	attrKey, ok := ContactAttributeKeyFromField(key)
+	if !ok {
+		return "", fmt.Errorf("unsupported field: %s", key)
+	}
+	for _, attr := range attrs {
+		if attr.Key != attrKey {
+			continue
+		}
+		switch attr.Type {
+		case ContactAttributeTypeString:
+			if len(attr.Enum) > 0 {
+				return "select", nil
+			}
+			return "text", nil
+		case ContactAttributeTypeNumber:
+			return "number", nil
+		case ContactAttributeTypeBoolean:
+			return "checkbox", nil
+		case ContactAttributeTypeDatetime:
+			return "date", nil
+		default:
+			return "", fmt.Errorf("%s attributes cannot be collected by a form", attr.Type)
+		}
+	}
+	return "", fmt.Errorf("unknown contact attribute: %s", attrKey)
+}
+
+func scanSignupFormJSON(val interface{}, target interface{}) error {
+	var data []byte
+	switch v := val.(type) {
+	case nil:
+		return nil
+	case []byte:
+		// clone the bytes, the driver reuses the buffer for the next rows
+		data = bytes.Clone(v)
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for signup form JSON: %T", val)
+	}
+	return json.Unmarshal(data, target)
+}
+
+// SignupFormPublicField is a form input as rendered to visitors
+type SignupFormPublicField struct {
+	Key         string   `json:"key"`
+	Label       string   `json:"label"`
+	Placeholder string   `json:"placeholder,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+	Type        string   `json:"type"`              // text, tel, number, checkbox, date, select
+	Options     []string `json:"options,omitempty"` // select only
+}
+
+// SignupFormPublicConfig is the part of a form exposed to visitors by the hosted page
+// and the embed script. It never contains secrets.
+type SignupFormPublicConfig struct {
+	ID                 string                  `json:"id"`
+	WorkspaceID        string                  `json:"workspace_id"`
+	Fields             []SignupFormPublicField `json:"fields"`
+	ConsentText        string                  `json:"consent_text,omitempty"`
+	SuccessMessage     string                  `json:"success_message"`
+	SuccessRedirectURL string                  `json:"success_redirect_url,omitempty"`
+	Style              SignupFormStyle         `json:"style"`
+	CaptchaProvider    string                  `json:"captcha_provider"`
+	CaptchaSiteKey     string                  `json:"captcha_site_key,omitempty"`
+	HoneypotField      string                  `json:"honeypot_field"`
+}
+
+// PublicConfig returns the visitor-facing configuration of the form
+func (f *SignupForm) PublicConfig(workspaceID string, attrs []ContactAttribute) *SignupFormPublicConfig {
+	config := &SignupFormPublicConfig{
+		ID:                 f.ID,
+		WorkspaceID:        workspaceID,
+		Fields:             make([]SignupFormPublicField, 0, len(f.Fields)),
+		ConsentText:        f.ConsentText,
+		SuccessMessage:     f.SuccessMessage,
+		SuccessRedirectURL: f.SuccessRedirectURL,
+		Style:              f.Style,
+		CaptchaProvider:    f.Captcha.Provider,
+		HoneypotField:      SignupFormHoneypotField,
+	}
+	if config.SuccessMessage == "" {
+		config.SuccessMessage = "Thanks for subscribing!"
+	}
+	if config.Style.ButtonText == "" {
+		config.Style.ButtonText = "Subscribe"
+	}
+	if config.CaptchaProvider == "" {
+		config.CaptchaProvider = SignupFormCaptchaNone
+	}
+	if f.Captcha.IsThirdParty() {
+		config.CaptchaSiteKey = f.Captcha.SiteKey
+	}
+
+	for _, field := range f.Fields {
+		inputType, err := signupFormInputType(field.Key, attrs)
+		if err != nil {
+			// the attribute was removed from the registry after the form was saved
+			continue
+		}
+		publicField := SignupFormPublicField{
+			Key:         field.Key,
+			Label:       field.Label,
+			Placeholder: field.Placeholder,
+			Required:    field.Required,
+			Type:        inputType,
+		}
+		if inputType == "select" {
+			attrKey, _ := ContactAttributeKeyFromField(field.Key)
+			for _, attr := range attrs {
+				if attr.Key == attrKey {
+					publicField.Options = attr.Enum
+				}
+			}
+		}
+		config.Fields = append(config.Fields, publicField)
+	}
+
+	return config
+}
+
+// SignupFormSubmission is a visitor submission of a form
+type SignupFormSubmission struct {
+	Email        string            `json:"email"`
+	Fields       map[string]string `json:"fields,omitempty"` // keyed by field key
+	Consent      bool              `json:"consent,omitempty"`
+	Honeypot     string            `json:"website,omitempty"`
+	CaptchaToken string            `json:"captcha_token,omitempty"`
+	Challenge    string            `json:"pow_challenge,omitempty"`
+	Nonce        string            `json:"pow_nonce,omitempty"`
+	PageURL      string            `json:"page_url,omitempty"`
+}
+
+// signupFormReservedInputs are the inputs of the hosted form that are not form fields
+var signupFormReservedInputs = map[string]bool{
+	"email":                 true,
+	"consent":               true,
+	SignupFormHoneypotField: true,
+	"captcha_token":         true,
+	"cf-turnstile-response": true,
+	"h-captcha-response":    true,
+	"g-recaptcha-response":  true,
+	"pow_challenge":         true,
+	"pow_nonce":             true,
+	"page_url":              true,
+}
+
+// FromForm parses an application/x-www-form-urlencoded submission of the hosted page.
+// The captcha widgets post their token under their own input name.
+func (s *SignupFormSubmission) FromForm(values url.Values) {
+	s.Email = strings.TrimSpace(values.Get("email"))
+	s.Honeypot = values.Get(SignupFormHoneypotField)
+	s.Challenge = values.Get("pow_challenge")
+	s.Nonce = values.Get("pow_nonce")
+	s.PageURL = values.Get("page_url")
+	s.Consent = isSignupFormChecked(values.Get("consent"))
+
+	for _, name := range []string{"captcha_token", "cf-turnstile-response", "h-captcha-response", "g-recaptcha-response"} {
+		if token := values.Get(name); token != "" {
+			s.CaptchaToken = token
+			break
+		}
+	}
+
+	s.Fields = make(map[string]string)
+	for name, value := range values {
+		if signupFormReservedInputs[name] || len(value) == 0 {
+			continue
+		}
+		s.Fields[name] = value[0]
+	}
+}
+
+// BuildContact validates the submitted values against the form and returns the contact
+// to subscribe. Attribute values are converted to their type and checked against the
+// registry on upsert.
+func (s *SignupFormSubmission) BuildContact(form *SignupForm, attrs []ContactAttribute) (*Contact, error) {
+	email := NormalizeEmail(s.Email)
+	if email == "" {
+		return nil, fmt.Errorf("email is required")
+	}
+	if !govalidator.IsEmail(email) {
+		return nil, fmt.Errorf("invalid email format")
+	}
+	if form.ConsentText != "" && !s.Consent {
+		return nil, fmt.Errorf("consent is required")
+	}
+
+	data := map[string]interface{}{"email": email}
+	attributes := map[string]interface{}{}
+
+	for _, field := range form.Fields {
+		inputType, err := signupFormInputType(field.Key, attrs)
+		if err != nil {
+			// the attribute was removed from the registry after the form was saved
+			continue
+		}
+
+		label := field.Label
+		if label == "" {
+			label = field.Key
+		}
+
+		raw := strings.TrimSpace(s.Fields[field.Key])
+		if inputType == "checkbox" {
+			checked := isSignupFormChecked(raw)
+			if field.Required && !checked {
+				return nil, fmt.Errorf("%s is required", label)
+			}
+			attrKey, _ := ContactAttributeKeyFromField(field.Key)
+			attributes[attrKey] = checked
+			continue
+		}
+		if raw == "" {
+			if field.Required {
+				return nil, fmt.Errorf("%s is required", label)
+			}
+			continue
+		}
+		if len(raw) > 1000 {
+			return nil, fmt.Errorf("%s is too long", label)
+		}
+
+		attrKey, isAttribute := ContactAttributeKeyFromField(field.Key)
+		if !isAttribute {
+			data[field.Key] = raw
+			continue
+		}
+		if inputType == "number" {
+			number, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a number", label)
+			}
+			attributes[attrKey] = number
+			continue
+		}
+		attributes[attrKey] = raw
+	}
+	if len(attributes) > 0 {
+		data["attributes"] = attributes
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode contact: %w", err)
+	}
+	return FromJSON(payload)
+}
+
+func isSignupFormChecked(value string) bool {
+	switch strings.ToLower(value) {
+	case "on", "true", "1", "yes":
+		return true
+	}
+	return false
+}
+
+// SignupFormChallenge is a proof-of-work challenge the browser must solve before submitting
+type SignupFormChallenge struct {
+	Challenge  string    `json:"challenge"`
+	Difficulty int       `json:"difficulty"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// SignupFormSubmitResult tells the visitor what happens after a successful submission
+type SignupFormSubmitResult struct {
+	Message     string `json:"message"`
+	RedirectURL string `json:"redirect_url,omitempty"`
+}
+
+// SignupFormMetric is a per-form daily counter, named after its signup_form_stats column
+type SignupFormMetric string
+
+const (
+	SignupFormMetricViews             SignupFormMetric = "views"
+	SignupFormMetricSubmitted         SignupFormMetric = "submitted"
+	SignupFormMetricSubscribed        SignupFormMetric = "subscribed"
+	SignupFormMetricSpamBlocked       SignupFormMetric = "spam_blocked"
+	SignupFormMetricDisposableBlocked SignupFormMetric = "disposable_blocked"
+	SignupFormMetricInvalid           SignupFormMetric = "invalid"
+)
+
+// IsValid returns true if the metric is a known counter
+func (m SignupFormMetric) IsValid() bool {
+	switch m {
+	case SignupFormMetricViews, SignupFormMetricSubmitted, SignupFormMetricSubscribed,
+		SignupFormMetricSpamBlocked, SignupFormMetricDisposableBlocked, SignupFormMetricInvalid:
+		return true
+	}
+	return false
+}
+
+// SignupFormRejectedError is returned when a submission is refused. Reason is the
+// metric the rejection is counted under.
+type SignupFormRejectedError struct {
+	Reason  SignupFormMetric
+	Message string
+}
+
+func (e *SignupFormRejectedError) Error() string {
+	return e.Message
+}
+
+// ErrSignupFormNotFound is returned when a form does not exist or is disabled
+type ErrSignupFormNotFound struct {
+	Message string
+}
+
+func (e *ErrSignupFormNotFound) Error() string {
+	return e.Message
+}
+
+// SignupFormStatsEntry holds the submission counters of a form for a UTC day
+type SignupFormStatsEntry struct {
+	Day               string `json:"day"` // YYYY-MM-DD (UTC)
+	Views             int    `json:"views"`
+	Submitted         int    `json:"submitted"`
+	Subscribed        int    `json:"subscribed"`
+	SpamBlocked       int    `json:"spam_blocked"`
+	DisposableBlocked int    `json:"disposable_blocked"`
+	Invalid           int    `json:"invalid"`
+}
+
+// Add adds the counters of other to the entry
+func (e *SignupFormStatsEntry) Add(other *SignupFormStatsEntry) {
+	e.Views += other.Views
+	e.Submitted += other.Submitted
+	e.Subscribed += other.Subscribed
+	e.SpamBlocked += other.SpamBlocked
+	e.DisposableBlocked += other.DisposableBlocked
+	e.Invalid += other.Invalid
+}
+
+// SignupFormStatsResponse is the response of signupForms.stats
+type SignupFormStatsResponse struct {
+	Days           []*SignupFormStatsEntry `json:"days"`
+	Totals         *SignupFormStatsEntry   `json:"totals"`
+	ConversionRate float64                 `json:"conversion_rate"` // subscribed / views
+}
+
+// maxSignupFormStatsDays bounds the range a single stats request may cover
+const maxSignupFormStatsDays = 366
+
+// Request/Response types
+
+type CreateSignupFormRequest struct {
+	WorkspaceID string      `json:"workspace_id"`
+	Form        *SignupForm `json:"form"`
+}
+
+func (r *CreateSignupFormRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.Form == nil {
+		return fmt.Errorf("form is required")
+	}
+	return r.Form.Validate()
+}
+
+type UpdateSignupFormRequest struct {
+	WorkspaceID string      `json:"workspace_id"`
+	Form        *SignupForm `json:"form"`
+}
+
+func (r *UpdateSignupFormRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.Form == nil {
+		return fmt.Errorf("form is required")
+	}
+	return r.Form.Validate()
+}
+
+type GetSignupFormRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ID          string `json:"id"`
+}
+
+func (r *GetSignupFormRequest) FromURLParams(values url.Values) error {
+	r.WorkspaceID = values.Get("workspace_id")
+	r.ID = values.Get("id")
+	return r.Validate()
+}
+
+func (r *GetSignupFormRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	return nil
+}
+
+type ListSignupFormsRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+}
+
+func (r *ListSignupFormsRequest) FromURLParams(values url.Values) error {
+	r.WorkspaceID = values.Get("workspace_id")
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	return nil
+}
+
+type DeleteSignupFormRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ID          string `json:"id"`
+}
+
+func (r *DeleteSignupFormRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	return nil
+}
+
+type GetSignupFormStatsRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ID          string `json:"id"`
+	From        string `json:"from,omitempty"` // YYYY-MM-DD, defaults to 30 days ago
+	To          string `json:"to,omitempty"`   // YYYY-MM-DD, defaults to today
+}
+
+func (r *GetSignupFormStatsRequest) FromURLParams(values url.Values) error {
+	r.WorkspaceID = values.Get("workspace_id")
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	r.ID = values.Get("id")
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	r.From = values.Get("from")
+	r.To = values.Get("to")
+	return nil
+}
+
+// Validate checks the request and returns the inclusive day range to fetch
+func (r *GetSignupFormStatsRequest) Validate() (from time.Time, to time.Time, err error) {
+	if r.WorkspaceID == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid get signup form stats request: workspace_id is required")
+	}
+	if r.ID == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid get signup form stats request: id is required")
+	}
+
+	now := time.Now().UTC()
+	to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if r.To != "" {
+		to, err = time.Parse(SegmentHistoryDateFormat, r.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid get signup form stats request: to must be formatted as YYYY-MM-DD")
+		}
+	}
+
+	from = to.AddDate(0, 0, -30)
+	if r.From != "" {
+		from, err = time.Parse(SegmentHistoryDateFormat, r.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid get signup form stats request: from must be formatted as YYYY-MM-DD")
+		}
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid get signup form stats request: from must be before to")
+	}
+	if to.Sub(from) > maxSignupFormStatsDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid get signup form stats request: range cannot exceed %d days", maxSignupFormStatsDays)
+	}
+
+	return from, to, nil
+}
+
+// SignupFormService manages signup forms and handles their public submissions
+type SignupFormService interface {
+	CreateSignupForm(ctx context.Context, workspaceID string, form *SignupForm) error
+	GetSignupForm(ctx context.Context, workspaceID string, id string) (*SignupForm, error)
+	ListSignupForms(ctx context.Context, workspaceID string) ([]*SignupForm, error)
+	UpdateSignupForm(ctx context.Context, workspaceID string, form *SignupForm) error
+	DeleteSignupForm(ctx context.Context, workspaceID string, id string) error
+	GetSignupFormStats(ctx context.Context, req *GetSignupFormStatsRequest) (*SignupFormStatsResponse, error)
+
+	// GetPublicSignupForm returns the visitor-facing configuration of an enabled form.
+	// countView records a view in the form stats.
+	GetPublicSignupForm(ctx context.Context, workspaceID string, id string, countView bool) (*SignupFormPublicConfig, error)
+
+	// CreateSignupFormChallenge issues a proof-of-work challenge for a form
+	CreateSignupFormChallenge(ctx context.Context, workspaceID string, id string) (*SignupFormChallenge, error)
+
+	// SubmitSignupForm checks a visitor submission for spam and subscribes the contact to the form lists.
+	// Rejected submissions return a *SignupFormRejectedError.
+	SubmitSignupForm(ctx context.Context, workspaceID string, id string, submission *SignupFormSubmission) (*SignupFormSubmitResult, error)
+}
+
+// SignupFormRepository persists signup forms and their daily stats
+type SignupFormRepository interface {
+	CreateSignupForm(ctx context.Context, workspaceID string, form *SignupForm) error
+	GetSignupFormByID(ctx context.Context, workspaceID string, id string) (*SignupForm, error)
+	GetSignupForms(ctx context.Context, workspaceID string) ([]*SignupForm, error)
+	UpdateSignupForm(ctx context.Context, workspaceID string, form *SignupForm) error
+	DeleteSignupForm(ctx context.Context, workspaceID string, id string) error
+
+	// IncrementSignupFormStats adds one to each metric of the form for the UTC day of at
+	IncrementSignupFormStats(ctx context.Context, workspaceID string, formID string, at time.Time, metrics ...SignupFormMetric) error
+
+	// GetSignupFormStats retrieves the daily stats of a form between two UTC days (inclusive)
+	GetSignupFormStats(ctx context.Context, workspaceID string, formID string, from, to time.Time) ([]*SignupFormStatsEntry, error)
+}
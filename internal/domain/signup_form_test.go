@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSignupForm() *SignupForm {
+	return &SignupForm{
+		ID:      "newsletter",
+		Name:    "Newsletter",
+		ListIDs: []string{"news"},
+		Fields: SignupFormFields{
+			{Key: "first_name", Label: "First name", Required: true},
+			{Key: "attributes.plan", Label: "Plan"},
+			{Key: "attributes.seats", Label: "Seats"},
+			{Key: "attributes.beta", Label: "Join the beta"},
+		},
+		ConsentText: "I agree to receive emails",
+		Enabled:     true,
+	}
+}
+
+func testSignupFormAttributes() []ContactAttribute {
+	return []ContactAttribute{
+		{Key: "plan", Type: "string", Enum: []string{"free", "pro"}},
+		{Key: "seats", Type: "number"},
+		{Key: "beta", Type: "boolean"},
+		{Key: "metadata", Type: "json"},
+	}
+}
+
+func TestSignupForm_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(f *SignupForm)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(f *SignupForm) {}},
+		{name: "missing id", mutate: func(f *SignupForm) { f.ID = "" }, wantErr: "id is required"},
+		{name: "non alphanumeric id", mutate: func(f *SignupForm) { f.ID = "news-letter" }, wantErr: "alphanumeric"},
+		{name: "missing name", mutate: func(f *SignupForm) { f.Name = "" }, wantErr: "name is required"},
+		{name: "no list", mutate: func(f *SignupForm) { f.ListIDs = nil }, wantErr: "at least one list"},
+		{name: "duplicate list", mutate: func(f *SignupForm) { f.ListIDs = []string{"news", "news"} }, wantErr: "duplicate list"},
+		{name: "duplicate field", mutate: func(f *SignupForm) {
+			f.Fields = append(f.Fields, SignupFormField{Key: "first_name"})
+		}, wantErr: "duplicate field"},
+		{name: "relative redirect", mutate: func(f *SignupForm) { f.SuccessRedirectURL = "/thanks" }, wantErr: "success_redirect_url"},
+		{name: "javascript redirect", mutate: func(f *SignupForm) { f.SuccessRedirectURL = "javascript:alert(1)" }, wantErr: "success_redirect_url"},
+		{name: "invalid color", mutate: func(f *SignupForm) { f.Style.PrimaryColor = "red;}body{" }, wantErr: "style"},
+		{name: "valid color", mutate: func(f *SignupForm) { f.Style.PrimaryColor = "#ff0000" }},
+		{name: "proof of work difficulty too high", mutate: func(f *SignupForm) {
+			f.Captcha = SignupFormCaptcha{Provider: SignupFormCaptchaProofOfWork, Difficulty: 40}
+		}, wantErr: "captcha"},
+		{name: "third party captcha without site key", mutate: func(f *SignupForm) {
+			f.Captcha = SignupFormCaptcha{Provider: SignupFormCaptchaTurnstile, SecretKey: "secret"}
+		}, wantErr: "site_key"},
+		{name: "unknown captcha", mutate: func(f *SignupForm) { f.Captcha = SignupFormCaptcha{Provider: "magic"} }, wantErr: "captcha"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := testSignupForm()
+			tt.mutate(form)
+			err := form.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSignupForm_ValidateFields(t *testing.T) {
+	form := testSignupForm()
+	require.NoError(t, form.ValidateFields(testSignupFormAttributes()))
+
+	form.Fields = append(form.Fields, SignupFormField{Key: "attributes.metadata"})
+	assert.Error(t, form.ValidateFields(testSignupFormAttributes()), "json attributes cannot be typed into a form")
+
+	form.Fields = SignupFormFields{{Key: "attributes.unknown"}}
+	assert.Error(t, form.ValidateFields(testSignupFormAttributes()))
+
+	form.Fields = SignupFormFields{{Key: "email_verified"}}
+	assert.Error(t, form.ValidateFields(testSignupFormAttributes()))
+}
+
+func TestSignupForm_ComputeConsentTextVersion(t *testing.T) {
+	form := testSignupForm()
+	form.ComputeConsentTextVersion()
+	first := form.ConsentTextVersion
+	assert.Len(t, first, 12)
+
+	form.ComputeConsentTextVersion()
+	assert.Equal(t, first, form.ConsentTextVersion)
+
+	form.ConsentText = "I agree to receive weekly emails"
+	form.ComputeConsentTextVersion()
+	assert.NotEqual(t, first, form.ConsentTextVersion)
+
+	form.ConsentText = ""
+	form.ComputeConsentTextVersion()
+	assert.Empty(t, form.ConsentTextVersion)
+}
+
+func TestSignupFormCaptcha_SecretKey(t *testing.T) {
+	captcha := SignupFormCaptcha{Provider: SignupFormCaptchaTurnstile, SiteKey: "site", SecretKey: "secret"}
+	require.NoError(t, captcha.EncryptSecretKey("passphrase"))
+	assert.NotEmpty(t, captcha.EncryptedSecretKey)
+	assert.Empty(t, captcha.SecretKey)
+
+	value, err := captcha.Value()
+	require.NoError(t, err)
+	assert.NotContains(t, string(value.([]byte)), `"secret"`)
+
+	require.NoError(t, captcha.DecryptSecretKey("passphrase"))
+	assert.Equal(t, "secret", captcha.SecretKey)
+}
+
+func TestSignupForm_PublicConfig(t *testing.T) {
+	form := testSignupForm()
+	form.Fields = append(form.Fields, SignupFormField{Key: "attributes.removed"})
+	form.Captcha = SignupFormCaptcha{Provider: SignupFormCaptchaHCaptcha, SiteKey: "site", EncryptedSecretKey: "encrypted"}
+
+	config := form.PublicConfig("ws1", testSignupFormAttributes())
+	assert.Equal(t, "ws1", config.WorkspaceID)
+	assert.Equal(t, "Thanks for subscribing!", config.SuccessMessage)
+	assert.Equal(t, "Subscribe", config.Style.ButtonText)
+	assert.Equal(t, "site", config.CaptchaSiteKey)
+	assert.Equal(t, SignupFormHoneypotField, config.HoneypotField)
+
+	// the removed attribute is skipped
+	require.Len(t, config.Fields, 4)
+	assert.Equal(t, "text", config.Fields[0].Type)
+	assert.Equal(t, "select", config.Fields[1].Type)
+	assert.Equal(t, []string{"free", "pro"}, config.Fields[1].Options)
+	assert.Equal(t, "number", config.Fields[2].Type)
+	assert.Equal(t, "checkbox", config.Fields[3].Type)
+}
+
+func TestSignupFormSubmission_FromForm(t *testing.T) {
+	var submission SignupFormSubmission
+	submission.FromForm(url.Values{
+		"email":                 {" jane@example.com "},
+		"first_name":            {"Jane"},
+		"consent":               {"on"},
+		"cf-turnstile-response": {"token"},
+		"pow_nonce":             {"42"},
+		"page_url":              {"https://example.com/blog"},
+		SignupFormHoneypotField: {""},
+	})
+
+	assert.Equal(t, "jane@example.com", submission.Email)
+	assert.True(t, submission.Consent)
+	assert.Equal(t, "token", submission.CaptchaToken)
+	assert.Equal(t, "42", submission.Nonce)
+	assert.Equal(t, "https://example.com/blog", submission.PageURL)
+	assert.Equal(t, map[string]string{"first_name": "Jane"}, submission.Fields)
+}
+
+func TestSignupFormSubmission_BuildContact(t *testing.T) {
+	attrs := testSignupFormAttributes()
+
+	t.Run("maps fields to the contact and its attributes", func(t *testing.T) {
+		submission := &SignupFormSubmission{
+			Email:   "Jane@Example.com",
+			Consent: true,
+			Fields: map[string]string{
+				"first_name":       "Jane",
+				"attributes.plan":  "pro",
+				"attributes.seats": "12",
+				"attributes.beta":  "true",
+			},
+		}
+		contact, err := submission.BuildContact(testSignupForm(), attrs)
+		require.NoError(t, err)
+		assert.Equal(t, "jane@example.com", contact.Email)
+		require.NotNil(t, contact.FirstName)
+		assert.Equal(t, "Jane", contact.FirstName.String)
+		assert.Equal(t, "pro", contact.Attributes["plan"])
+		assert.Equal(t, float64(12), contact.Attributes["seats"])
+		assert.Equal(t, true, contact.Attributes["beta"])
+	})
+
+	tests := []struct {
+		name       string
+		submission SignupFormSubmission
+		wantErr    string
+	}{
+		{name: "missing email", submission: SignupFormSubmission{Consent: true}, wantErr: "email is required"},
+		{name: "invalid email", submission: SignupFormSubmission{Email: "nope", Consent: true}, wantErr: "invalid email"},
+		{name: "missing consent", submission: SignupFormSubmission{Email: "jane@example.com"}, wantErr: "consent is required"},
+		{name: "missing required field", submission: SignupFormSubmission{Email: "jane@example.com", Consent: true}, wantErr: "First name is required"},
+		{name: "invalid number", submission: SignupFormSubmission{
+			Email: "jane@example.com", Consent: true,
+			Fields: map[string]string{"first_name": "Jane", "attributes.seats": "many"},
+		}, wantErr: "Seats must be a number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.submission.BuildContact(testSignupForm(), attrs)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestGetSignupFormStatsRequest_Validate(t *testing.T) {
+	req := &GetSignupFormStatsRequest{WorkspaceID: "ws1", ID: "form1", From: "2026-01-01", To: "2026-01-31"}
+	from, to, err := req.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, "2026-01-01", from.Format(SegmentHistoryDateFormat))
+	assert.Equal(t, "2026-01-31", to.Format(SegmentHistoryDateFormat))
+
+	req = &GetSignupFormStatsRequest{WorkspaceID: "ws1", ID: "form1"}
+	from, to, err = req.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, 30*24*60*60.0, to.Sub(from).Seconds())
+
+	req = &GetSignupFormStatsRequest{WorkspaceID: "ws1", ID: "form1", From: "2026-02-01", To: "2026-01-01"}
+	_, _, err = req.Validate()
+	assert.Error(t, err)
+
+	req = &GetSignupFormStatsRequest{WorkspaceID: "ws1", ID: "form1", From: "2024-01-01", To: "2026-01-01"}
+	_, _, err = req.Validate()
+	assert.Error(t, err)
+}
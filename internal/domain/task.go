@@ -62,6 +62,11 @@ type TaskState struct {
 	SendBroadcast   *SendBroadcastState   `json:"send_broadcast,omitempty"`
 	BuildSegment    *BuildSegmentState    `json:"build_segment,omitempty"`
 	IntegrationSync *IntegrationSyncState `json:"integration_sync,omitempty"`
+
+	ComputeContactProperties *ComputeContactPropertiesState `json:"compute_contact_properties,omitempty"`
+	ImportContacts           *ImportContactsState           `json:"import_contacts,omitempty"`
+	BulkContactOperation     *BulkContactOperationState     `json:"bulk_contact_operation,omitempty"`
+	PublishBlogPost          *PublishBlogPostState          `json:"publish_blog_post,omitempty"`
 }
 
 // Value implements the driver.Valuer interface for TaskState
@@ -115,6 +120,35 @@ type BuildSegmentState struct {
 	StartedAt      string `json:"started_at"`
 }
 
+// ComputeContactPropertiesState contains state for the recurring computed contact properties task
+type ComputeContactPropertiesState struct {
+	TotalContacts   int        `json:"total_contacts"`
+	ProcessedCount  int        `json:"processed_count"`
+	UpdatedCount    int        `json:"updated_count"`
+	ContactOffset   int64      `json:"contact_offset"` // For resumable processing
+	StartedAt       string     `json:"started_at,omitempty"`
+	LastCompletedAt *time.Time `json:"last_completed_at,omitempty"`
+}
+
+// ImportContactsState contains state for contact file import tasks
+type ImportContactsState struct {
+	ImportID  string `json:"import_id"`
+	DryRun    bool   `json:"dry_run"`
+	RowOffset int    `json:"row_offset"` // Data rows already processed, for resumable processing
+}
+
+// BulkContactOperationState contains state for bulk contact operation tasks
+type BulkContactOperationState struct {
+	OperationID string `json:"operation_id"`
+	AfterEmail  string `json:"after_email"` // Last email processed, the target is walked in email order
+}
+
+// PublishBlogPostState contains state for scheduled blog post publishing tasks
+type PublishBlogPostState struct {
+	PostID       string    `json:"post_id"`
+	ScheduledFor time.Time `json:"scheduled_for"` // The task is stale when the post has been rescheduled since
+}
+
 // IntegrationSyncState contains state for integration sync tasks (recurring polling tasks)
 type IntegrationSyncState struct {
 	IntegrationID   string     `json:"integration_id"`
@@ -76,6 +76,11 @@ func (t TemplateCategory) Validate() error {
 type TemplateTranslation struct {
 	Email *EmailTemplate `json:"email,omitempty"`
 	Web   *WebTemplate   `json:"web,omitempty"`
+	// Draft translations are kept for review and not sent: contacts of the language receive
+	// the default content until the draft flag is cleared
+	Draft bool `json:"draft,omitempty"`
+	// MachineTranslated marks the translations generated by templates.translate
+	MachineTranslated bool `json:"machine_translated,omitempty"`
 }
 
 // validateTranslations validates translation language keys, channel match, and content.
@@ -129,8 +134,22 @@ type Template struct {
 	DeletedAt       *time.Time                     `json:"deleted_at,omitempty"`
 }
 
+// SetMachineTranslation stores the email content translated into a language as a draft
+// translation to review, after validating it like the translations of a template update
+func (t *Template) SetMachineTranslation(language string, email *EmailTemplate) error {
+	translation := TemplateTranslation{Email: email, Draft: true, MachineTranslated: true}
+	if err := validateTranslations(map[string]TemplateTranslation{language: translation}, t.Channel, t.TestData); err != nil {
+		return err
+	}
+	if t.Translations == nil {
+		t.Translations = make(map[string]TemplateTranslation)
+	}
+	t.Translations[language] = translation
+	return nil
+}
+
 // ResolveEmailContent returns the EmailTemplate for the given contact language.
-// Falls back to the default template content if no translation exists.
+// Falls back to the default template content if no translation exists, or if it is a draft.
 func (t *Template) ResolveEmailContent(contactLanguage string, workspaceDefaultLanguage string) *EmailTemplate {
 	if t.Email == nil || t.Translations == nil || contactLanguage == "" {
 		return t.Email
@@ -138,12 +157,62 @@ func (t *Template) ResolveEmailContent(contactLanguage string, workspaceDefaultL
 	if contactLanguage == workspaceDefaultLanguage {
 		return t.Email
 	}
-	if translation, ok := t.Translations[contactLanguage]; ok && translation.Email != nil {
+	if translation, ok := t.Translations[contactLanguage]; ok && translation.Email != nil && !translation.Draft {
 		return translation.Email
 	}
 	return t.Email
 }
 
+// SyncedBlockIDs returns the distinct workspace template block IDs referenced by
+// the email visual editor trees of the template and its translations
+func (t *Template) SyncedBlockIDs() []string {
+	seen := make(map[string]bool)
+	var ids []string
+	collect := func(e *EmailTemplate) {
+		if e == nil || e.VisualEditorTree == nil {
+			return
+		}
+		for _, id := range notifuse_mjml.CollectSyncedBlockIDs(e.VisualEditorTree) {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	collect(t.Email)
+	for _, translation := range t.Translations {
+		collect(translation.Email)
+	}
+	return ids
+}
+
+// ResolveSyncedBlocks inlines the current content of the referenced workspace
+// template blocks into the email visual editor trees of the template and its
+// translations. Used by batch senders that load a template once and compile it
+// for many recipients.
+func (t *Template) ResolveSyncedBlocks(blocks map[string]notifuse_mjml.EmailBlock) error {
+	resolve := func(e *EmailTemplate) error {
+		if e == nil || e.VisualEditorTree == nil {
+			return nil
+		}
+		tree, err := notifuse_mjml.ResolveSyncedBlocks(e.VisualEditorTree, blocks)
+		if err != nil {
+			return err
+		}
+		e.VisualEditorTree = tree
+		return nil
+	}
+	if err := resolve(t.Email); err != nil {
+		return err
+	}
+	for lang, translation := range t.Translations {
+		if err := resolve(translation.Email); err != nil {
+			return fmt.Errorf("translation %s: %w", lang, err)
+		}
+	}
+	return nil
+}
+
 // ResolveSubjectPreviewOverride picks the preview text to inject into the mj-preview block at
 // compile time. An explicit caller-supplied override (e.g. transactional EmailOptions) wins;
 // otherwise it falls back to the resolved email content's own SubjectPreview. This guarantees a
@@ -179,7 +248,7 @@ func (e *EmailTemplate) ApplyToCompileRequest(req *CompileTemplateRequest, expli
 }
 
 // ResolveWebContent returns the WebTemplate for the given contact language.
-// Falls back to the default template content if no translation exists.
+// Falls back to the default template content if no translation exists, or if it is a draft.
 func (t *Template) ResolveWebContent(contactLanguage string, workspaceDefaultLanguage string) *WebTemplate {
 	if t.Web == nil || t.Translations == nil || contactLanguage == "" {
 		return t.Web
@@ -187,7 +256,7 @@ func (t *Template) ResolveWebContent(contactLanguage string, workspaceDefaultLan
 	if contactLanguage == workspaceDefaultLanguage {
 		return t.Web
 	}
-	if translation, ok := t.Translations[contactLanguage]; ok && translation.Web != nil {
+	if translation, ok := t.Translations[contactLanguage]; ok && translation.Web != nil && !translation.Draft {
 		return translation.Web
 	}
 	return t.Web
@@ -999,6 +1068,11 @@ func BuildTemplateData(req TemplateDataRequest) (MapOfAny, error) {
 		templateData["global_feed"] = req.Broadcast.DataFeed.GlobalFeedData
 	}
 
+	// Add the blog post sent by the broadcast
+	if req.Broadcast != nil && req.Broadcast.BlogPost != nil {
+		templateData["post"] = req.Broadcast.BlogPost.TemplateData(req.Broadcast.UTMParameters)
+	}
+
 	// Expose workspace URLs for composing links from relative paths.
 	//   - base_url: the tracking endpoint (resolved CustomEndpointURL, or API endpoint fallback),
 	//     used for unsubscribe/tracking/notification-center links on the Notifuse domain.
@@ -14,11 +14,15 @@ import (
 
 //go:generate mockgen -destination mocks/mock_template_block_service.go -package mocks github.com/Notifuse/notifuse/internal/domain TemplateBlockService
 
-// TemplateBlock represents a reusable email block template
+// TemplateBlock represents a reusable email block template.
+// Templates can either copy a block into their tree or reference it through an
+// mj-synced-block node, in which case the block is resolved at compile time and
+// every change to it is reflected in the templates that use it.
 type TemplateBlock struct {
 	ID      string                   `json:"id"`
 	Name    string                   `json:"name"`
 	Block   notifuse_mjml.EmailBlock `json:"block"`
+	Version int                      `json:"version"` // Incremented on every update
 	Created time.Time                `json:"created"`
 	Updated time.Time                `json:"updated"`
 }
@@ -30,12 +34,14 @@ func (tb TemplateBlock) MarshalJSON() ([]byte, error) {
 		ID      string      `json:"id"`
 		Name    string      `json:"name"`
 		Block   interface{} `json:"block"`
+		Version int         `json:"version"`
 		Created time.Time   `json:"created"`
 		Updated time.Time   `json:"updated"`
 	}{
 		ID:      tb.ID,
 		Name:    tb.Name,
 		Block:   tb.Block,
+		Version: tb.Version,
 		Created: tb.Created,
 		Updated: tb.Updated,
 	}
@@ -49,6 +55,7 @@ func (tb *TemplateBlock) UnmarshalJSON(data []byte) error {
 		ID      string          `json:"id"`
 		Name    string          `json:"name"`
 		Block   json.RawMessage `json:"block"`
+		Version int             `json:"version"`
 		Created time.Time       `json:"created"`
 		Updated time.Time       `json:"updated"`
 	}{}
@@ -60,6 +67,7 @@ func (tb *TemplateBlock) UnmarshalJSON(data []byte) error {
 	// Set the simple fields
 	tb.ID = temp.ID
 	tb.Name = temp.Name
+	tb.Version = temp.Version
 	tb.Created = temp.Created
 	tb.Updated = temp.Updated
 
@@ -81,6 +89,18 @@ func (tb *TemplateBlock) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// SyncedBlocks returns the workspace template blocks keyed by ID, in the form
+// expected by CompileTemplateRequest.SyncedBlocks
+func (ws *WorkspaceSettings) SyncedBlocks() map[string]notifuse_mjml.EmailBlock {
+	blocks := make(map[string]notifuse_mjml.EmailBlock, len(ws.TemplateBlocks))
+	for _, block := range ws.TemplateBlocks {
+		if block.Block != nil {
+			blocks[block.ID] = block.Block
+		}
+	}
+	return blocks
+}
+
 // Request Types
 
 // CreateTemplateBlockRequest defines the request structure for creating a template block
@@ -154,6 +174,7 @@ func (r *CreateTemplateBlockRequest) Validate() (block *TemplateBlock, workspace
 		ID:      uuid.New().String(),
 		Name:    r.Name,
 		Block:   r.Block,
+		Version: 1,
 		Created: now,
 		Updated: now,
 	}, r.WorkspaceID, nil
@@ -309,6 +330,65 @@ func (r *ListTemplateBlocksRequest) FromURLParams(queryParams url.Values) error
 	return nil
 }
 
+// GetTemplateBlockUsageRequest defines the request structure for getting where a template block is used
+type GetTemplateBlockUsageRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ID          string `json:"id"`
+}
+
+// FromURLParams parses the request from URL query parameters
+func (r *GetTemplateBlockUsageRequest) FromURLParams(queryParams url.Values) error {
+	r.WorkspaceID = queryParams.Get("workspace_id")
+	r.ID = queryParams.Get("id")
+
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("invalid get template block usage request: workspace_id is required")
+	}
+	if len(r.WorkspaceID) > 32 {
+		return fmt.Errorf("invalid get template block usage request: workspace_id length must be between 1 and 32")
+	}
+
+	if r.ID == "" {
+		return fmt.Errorf("invalid get template block usage request: id is required")
+	}
+
+	return nil
+}
+
+// TemplateBlockUsageTemplate is a template that references a synced block
+type TemplateBlockUsageTemplate struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Version  int64  `json:"version"`
+}
+
+// TemplateBlockUsageAutomation is a live automation sending one of the templates using a synced block
+type TemplateBlockUsageAutomation struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	TemplateIDs []string `json:"template_ids"`
+}
+
+// TemplateBlockUsage describes the impact of changing a synced block
+type TemplateBlockUsage struct {
+	BlockID          string                         `json:"block_id"`
+	BlockVersion     int                            `json:"block_version"`
+	TemplatesCount   int                            `json:"templates_count"`
+	Templates        []TemplateBlockUsageTemplate   `json:"templates"`
+	AutomationsCount int                            `json:"live_automations_count"`
+	Automations      []TemplateBlockUsageAutomation `json:"live_automations"`
+}
+
+// ErrTemplateBlockInUse is returned when deleting a template block still referenced by templates
+type ErrTemplateBlockInUse struct {
+	Message string
+}
+
+func (e *ErrTemplateBlockInUse) Error() string {
+	return e.Message
+}
+
 // ErrTemplateBlockNotFound is returned when a template block is not found
 type ErrTemplateBlockNotFound struct {
 	Message string
@@ -334,4 +414,7 @@ type TemplateBlockService interface {
 
 	// DeleteTemplateBlock deletes a template block by ID
 	DeleteTemplateBlock(ctx context.Context, workspaceID string, id string) error
+
+	// GetTemplateBlockUsage returns the templates and live automations using a synced block
+	GetTemplateBlockUsage(ctx context.Context, workspaceID string, id string) (*TemplateBlockUsage, error)
 }
@@ -661,3 +661,74 @@ func TestErrTemplateBlockNotFound(t *testing.T) {
 	assert.Equal(t, "template block not found", err.Error())
 	assert.Error(t, err)
 }
+
+func TestTemplateBlock_VersionRoundTrip(t *testing.T) {
+	tb := TemplateBlock{ID: "footer", Name: "Footer", Block: createTestEmailBlock(), Version: 3}
+
+	data, err := json.Marshal(tb)
+	require.NoError(t, err)
+
+	var decoded TemplateBlock
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, 3, decoded.Version)
+}
+
+func TestGetTemplateBlockUsageRequest_FromURLParams(t *testing.T) {
+	var req GetTemplateBlockUsageRequest
+	require.NoError(t, req.FromURLParams(url.Values{"workspace_id": {"ws1"}, "id": {"footer"}}))
+	assert.Equal(t, "ws1", req.WorkspaceID)
+	assert.Equal(t, "footer", req.ID)
+
+	assert.Error(t, req.FromURLParams(url.Values{"id": {"footer"}}))
+	assert.Error(t, req.FromURLParams(url.Values{"workspace_id": {"ws1"}}))
+	assert.Error(t, req.FromURLParams(url.Values{"workspace_id": {strings.Repeat("a", 33)}, "id": {"footer"}}))
+}
+
+func TestWorkspaceSettings_SyncedBlocks(t *testing.T) {
+	settings := WorkspaceSettings{
+		TemplateBlocks: []TemplateBlock{
+			{ID: "footer", Name: "Footer", Block: createTestEmailBlock()},
+			{ID: "empty", Name: "Empty"},
+		},
+	}
+
+	blocks := settings.SyncedBlocks()
+	assert.Len(t, blocks, 1)
+	assert.Equal(t, notifuse_mjml.MJMLComponentMjText, blocks["footer"].GetType())
+}
+
+func TestTemplate_ResolveSyncedBlocks(t *testing.T) {
+	newTree := func(blockID string) notifuse_mjml.EmailBlock {
+		tree, err := notifuse_mjml.UnmarshalEmailBlock([]byte(`{"id":"root","type":"mjml","children":[{"id":"body","type":"mj-body","children":[` +
+			`{"id":"sec","type":"mj-section","children":[{"id":"col","type":"mj-column","children":[` +
+			`{"id":"ref","type":"mj-synced-block","attributes":{"blockId":"` + blockID + `"}}]}]}]}]}`))
+		require.NoError(t, err)
+		return tree
+	}
+
+	template := &Template{
+		Email: &EmailTemplate{VisualEditorTree: newTree("footer")},
+		Translations: map[string]TemplateTranslation{
+			"fr": {Email: &EmailTemplate{VisualEditorTree: newTree("footer-fr")}},
+		},
+	}
+
+	ids := template.SyncedBlockIDs()
+	assert.ElementsMatch(t, []string{"footer", "footer-fr"}, ids)
+
+	t.Run("missing block in translation", func(t *testing.T) {
+		clone := *template
+		err := clone.ResolveSyncedBlocks(map[string]notifuse_mjml.EmailBlock{"footer": createTestEmailBlock()})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "translation fr")
+	})
+
+	t.Run("resolves default and translations", func(t *testing.T) {
+		err := template.ResolveSyncedBlocks(map[string]notifuse_mjml.EmailBlock{
+			"footer":    createTestEmailBlock(),
+			"footer-fr": createTestEmailBlock(),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, template.SyncedBlockIDs())
+	})
+}
@@ -1647,6 +1647,32 @@ func TestErrTemplateNotFound_Error(t *testing.T) {
 }
 
 func TestBuildTemplateData(t *testing.T) {
+	t.Run("with blog post", func(t *testing.T) {
+		req := TemplateDataRequest{
+			WorkspaceID:        "ws-123",
+			WorkspaceSecretKey: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			ContactWithList:    ContactWithList{Contact: &Contact{Email: "test@example.com"}},
+			MessageID:          "msg-456",
+			Broadcast: &Broadcast{
+				ID:            "broadcast-001",
+				Name:          "Launch",
+				UTMParameters: &UTMParameters{Source: "newsletter", Medium: "email"},
+				BlogPost: &BroadcastBlogPost{
+					PostID: "post-1",
+					Title:  "Launch",
+					URL:    "https://blog.example.com/news/launch",
+				},
+			},
+		}
+		data, err := BuildTemplateData(req)
+		assert.NoError(t, err)
+
+		postData, ok := data["post"].(MapOfAny)
+		assert.True(t, ok)
+		assert.Equal(t, "Launch", postData["title"])
+		assert.Equal(t, "https://blog.example.com/news/launch?utm_medium=email&utm_source=newsletter", postData["url"])
+	})
+
 	t.Run("with complete data", func(t *testing.T) {
 		// Setup test data
 		workspaceID := "ws-123"
@@ -2446,6 +2472,35 @@ func TestTemplate_ResolveEmailContent(t *testing.T) {
 		result := tmpl.ResolveEmailContent("fr", "en")
 		assert.Equal(t, "Default Subject", result.Subject)
 	})
+
+	t.Run("draft translation falls back", func(t *testing.T) {
+		tmpl := &Template{
+			Email: defaultEmail,
+			Translations: map[string]TemplateTranslation{
+				"fr": {Email: frEmail, Draft: true, MachineTranslated: true},
+			},
+		}
+		result := tmpl.ResolveEmailContent("fr", "en")
+		assert.Equal(t, "Default Subject", result.Subject)
+	})
+}
+
+func TestTemplate_SetMachineTranslation(t *testing.T) {
+	tmpl := &Template{ID: "welcome", Channel: ChannelEmail, Email: &EmailTemplate{Subject: "Welcome", VisualEditorTree: createValidMJMLBlock()}}
+
+	err := tmpl.SetMachineTranslation("fr", &EmailTemplate{Subject: "Bienvenue", VisualEditorTree: createValidMJMLBlock()})
+	assert.NoError(t, err)
+	translation := tmpl.Translations["fr"]
+	assert.True(t, translation.Draft)
+	assert.True(t, translation.MachineTranslated)
+	assert.NotEmpty(t, translation.Email.CompiledPreview)
+
+	err = tmpl.SetMachineTranslation("de", &EmailTemplate{VisualEditorTree: createValidMJMLBlock()})
+	assert.Error(t, err)
+	assert.NotContains(t, tmpl.Translations, "de")
+
+	err = tmpl.SetMachineTranslation("xx", &EmailTemplate{Subject: "Hello", VisualEditorTree: createValidMJMLBlock()})
+	assert.Error(t, err)
 }
 
 func TestTemplate_ResolveWebContent(t *testing.T) {
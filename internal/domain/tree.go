@@ -21,11 +21,13 @@ type TreeNodeBranch struct {
 
 // TreeNodeLeaf represents an actual condition on a data source
 type TreeNodeLeaf struct {
-	Source           string                     `json:"source"` // "contacts", "contact_lists", "contact_timeline", "custom_events_goals"
-	Contact          *ContactCondition          `json:"contact,omitempty"`
-	ContactList      *ContactListCondition      `json:"contact_list,omitempty"`
-	ContactTimeline  *ContactTimelineCondition  `json:"contact_timeline,omitempty"`
-	CustomEventsGoal *CustomEventsGoalCondition `json:"custom_events_goal,omitempty"`
+	Source            string                      `json:"source"` // "contacts", "contact_lists", "contact_timeline", "custom_events_goals", "message_history", "contact_automations"
+	Contact           *ContactCondition           `json:"contact,omitempty"`
+	ContactList       *ContactListCondition       `json:"contact_list,omitempty"`
+	ContactTimeline   *ContactTimelineCondition   `json:"contact_timeline,omitempty"`
+	CustomEventsGoal  *CustomEventsGoalCondition  `json:"custom_events_goal,omitempty"`
+	MessageHistory    *MessageHistoryCondition    `json:"message_history,omitempty"`
+	ContactAutomation *ContactAutomationCondition `json:"contact_automation,omitempty"`
 }
 
 // ContactCondition represents filters on the contacts table
@@ -64,6 +66,32 @@ type CustomEventsGoalCondition struct {
 	TimeframeValues   []string `json:"timeframe_values,omitempty"`
 }
 
+// MessageHistoryCondition represents conditions on the messages sent to a contact
+// Used for per-campaign engagement segmentation (e.g. "opened broadcast X", "received 5 emails without opening")
+type MessageHistoryCondition struct {
+	Event             string   `json:"event"`          // sent, delivered, opened, clicked, bounced, complained, unsubscribed, failed
+	CountOperator     string   `json:"count_operator"` // "at_least", "at_most", "exactly"
+	CountValue        int      `json:"count_value"`
+	Channel           *string  `json:"channel,omitempty"`
+	BroadcastID       *string  `json:"broadcast_id,omitempty"`
+	AutomationID      *string  `json:"automation_id,omitempty"`
+	TemplateID        *string  `json:"template_id,omitempty"`
+	TimeframeOperator *string  `json:"timeframe_operator,omitempty"` // "anytime", "in_date_range", "before_date", "after_date", "in_the_last_days"
+	TimeframeValues   []string `json:"timeframe_values,omitempty"`   // applied to the timestamp of the event
+}
+
+// MessageHistoryConditionEvents lists the message events a MessageHistoryCondition can match
+var MessageHistoryConditionEvents = []string{"sent", "delivered", "opened", "clicked", "bounced", "complained", "unsubscribed", "failed"}
+
+// ContactAutomationCondition represents conditions on a contact's enrollment in an automation
+type ContactAutomationCondition struct {
+	Operator          string   `json:"operator"` // "in" or "not_in"
+	AutomationID      string   `json:"automation_id"`
+	Status            *string  `json:"status,omitempty"`             // active, completed, exited, failed (any status when empty)
+	TimeframeOperator *string  `json:"timeframe_operator,omitempty"` // "anytime", "in_date_range", "before_date", "after_date", "in_the_last_days"
+	TimeframeValues   []string `json:"timeframe_values,omitempty"`   // applied to entered_at
+}
+
 // DimensionFilter represents a single filter condition on a field
 type DimensionFilter struct {
 	FieldName    string    `json:"field_name"`
@@ -149,8 +177,18 @@ func (l *TreeNodeLeaf) Validate() error {
 			return fmt.Errorf("leaf with source 'custom_events_goals' must have 'custom_events_goal' field")
 		}
 		return l.CustomEventsGoal.Validate()
+	case "message_history":
+		if l.MessageHistory == nil {
+			return fmt.Errorf("leaf with source 'message_history' must have 'message_history' field")
+		}
+		return l.MessageHistory.Validate()
+	case "contact_automations":
+		if l.ContactAutomation == nil {
+			return fmt.Errorf("leaf with source 'contact_automations' must have 'contact_automation' field")
+		}
+		return l.ContactAutomation.Validate()
 	default:
-		return fmt.Errorf("invalid source: %s (must be 'contacts', 'contact_lists', 'contact_timeline', or 'custom_events_goals')", l.Source)
+		return fmt.Errorf("invalid source: %s (must be 'contacts', 'contact_lists', 'contact_timeline', 'custom_events_goals', 'message_history', or 'contact_automations')", l.Source)
 	}
 }
 
@@ -303,6 +341,78 @@ func (g *CustomEventsGoalCondition) Validate() error {
 	return nil
 }
 
+// Validate validates message history conditions
+func (c *MessageHistoryCondition) Validate() error {
+	validEvent := false
+	for _, event := range MessageHistoryConditionEvents {
+		if c.Event == event {
+			validEvent = true
+			break
+		}
+	}
+	if !validEvent {
+		return fmt.Errorf("invalid message_history event: %s (must be one of: %v)", c.Event, MessageHistoryConditionEvents)
+	}
+
+	if c.CountOperator != "at_least" && c.CountOperator != "at_most" && c.CountOperator != "exactly" {
+		return fmt.Errorf("invalid count_operator: %s (must be 'at_least', 'at_most', or 'exactly')", c.CountOperator)
+	}
+
+	if c.CountValue < 0 {
+		return fmt.Errorf("count_value must be non-negative")
+	}
+
+	if c.Channel != nil && *c.Channel != "" && *c.Channel != "email" && *c.Channel != "sms" && *c.Channel != "push" {
+		return fmt.Errorf("invalid channel: %s (must be 'email', 'sms', or 'push')", *c.Channel)
+	}
+
+	return validateTimeframe(c.TimeframeOperator, c.TimeframeValues)
+}
+
+// Validate validates contact automation conditions
+func (c *ContactAutomationCondition) Validate() error {
+	if c.Operator != "in" && c.Operator != "not_in" {
+		return fmt.Errorf("invalid contact_automation operator: %s (must be 'in' or 'not_in')", c.Operator)
+	}
+
+	if c.AutomationID == "" {
+		return fmt.Errorf("contact_automation condition must have 'automation_id'")
+	}
+
+	if c.Status != nil && *c.Status != "" {
+		switch ContactAutomationStatus(*c.Status) {
+		case ContactAutomationStatusActive, ContactAutomationStatusCompleted, ContactAutomationStatusExited, ContactAutomationStatusFailed:
+			// Valid
+		default:
+			return fmt.Errorf("invalid contact_automation status: %s (must be 'active', 'completed', 'exited', or 'failed')", *c.Status)
+		}
+	}
+
+	return validateTimeframe(c.TimeframeOperator, c.TimeframeValues)
+}
+
+// validateTimeframe validates an optional timeframe operator and its values
+func validateTimeframe(operator *string, values []string) error {
+	if operator == nil || *operator == "" || *operator == "anytime" {
+		return nil
+	}
+
+	switch *operator {
+	case "in_date_range":
+		if len(values) != 2 {
+			return fmt.Errorf("timeframe_operator 'in_date_range' requires 2 timeframe_values")
+		}
+	case "before_date", "after_date", "in_the_last_days":
+		if len(values) != 1 {
+			return fmt.Errorf("timeframe_operator '%s' requires 1 timeframe_value", *operator)
+		}
+	default:
+		return fmt.Errorf("invalid timeframe_operator: %s", *operator)
+	}
+
+	return nil
+}
+
 // Validate validates a dimension filter
 func (f *DimensionFilter) Validate() error {
 	if f.FieldName == "" {
@@ -486,6 +596,19 @@ func (t *TreeNode) HasRelativeDates() bool {
 				return true
 			}
 		}
+		// Check message history and automation conditions for relative date operators
+		if t.Leaf.MessageHistory != nil {
+			if t.Leaf.MessageHistory.TimeframeOperator != nil &&
+				*t.Leaf.MessageHistory.TimeframeOperator == "in_the_last_days" {
+				return true
+			}
+		}
+		if t.Leaf.ContactAutomation != nil {
+			if t.Leaf.ContactAutomation.TimeframeOperator != nil &&
+				*t.Leaf.ContactAutomation.TimeframeOperator == "in_the_last_days" {
+				return true
+			}
+		}
 		return false
 
 	default:
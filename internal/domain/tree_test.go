@@ -1081,3 +1081,157 @@ func TestContactTimelineCondition_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageHistoryCondition_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cond    MessageHistoryCondition
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "valid opened for a broadcast",
+			cond:    MessageHistoryCondition{Event: "opened", CountOperator: "at_least", CountValue: 1, BroadcastID: stringPtr("bc_1")},
+			wantErr: false,
+		},
+		{
+			name: "valid with timeframe",
+			cond: MessageHistoryCondition{
+				Event: "sent", CountOperator: "at_least", CountValue: 5,
+				TimeframeOperator: stringPtr("in_the_last_days"), TimeframeValues: []string{"30"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid event",
+			cond:    MessageHistoryCondition{Event: "read", CountOperator: "at_least", CountValue: 1},
+			wantErr: true,
+			errMsg:  "invalid message_history event",
+		},
+		{
+			name:    "invalid count_operator",
+			cond:    MessageHistoryCondition{Event: "opened", CountOperator: "more_than", CountValue: 1},
+			wantErr: true,
+			errMsg:  "invalid count_operator",
+		},
+		{
+			name:    "negative count_value",
+			cond:    MessageHistoryCondition{Event: "opened", CountOperator: "at_least", CountValue: -1},
+			wantErr: true,
+			errMsg:  "count_value must be non-negative",
+		},
+		{
+			name:    "invalid channel",
+			cond:    MessageHistoryCondition{Event: "opened", CountOperator: "at_least", CountValue: 1, Channel: stringPtr("fax")},
+			wantErr: true,
+			errMsg:  "invalid channel",
+		},
+		{
+			name:    "invalid timeframe_operator",
+			cond:    MessageHistoryCondition{Event: "opened", CountOperator: "at_least", CountValue: 1, TimeframeOperator: stringPtr("someday")},
+			wantErr: true,
+			errMsg:  "invalid timeframe_operator",
+		},
+		{
+			name:    "date range without two values",
+			cond:    MessageHistoryCondition{Event: "opened", CountOperator: "at_least", CountValue: 1, TimeframeOperator: stringPtr("in_date_range"), TimeframeValues: []string{"2026-01-01T00:00:00Z"}},
+			wantErr: true,
+			errMsg:  "requires 2 timeframe_values",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cond.Validate()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestContactAutomationCondition_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cond    ContactAutomationCondition
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "valid active",
+			cond:    ContactAutomationCondition{Operator: "in", AutomationID: "auto_1", Status: stringPtr("active")},
+			wantErr: false,
+		},
+		{
+			name:    "valid not_in any status",
+			cond:    ContactAutomationCondition{Operator: "not_in", AutomationID: "auto_1"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid operator",
+			cond:    ContactAutomationCondition{Operator: "maybe", AutomationID: "auto_1"},
+			wantErr: true,
+			errMsg:  "invalid contact_automation operator",
+		},
+		{
+			name:    "missing automation_id",
+			cond:    ContactAutomationCondition{Operator: "in"},
+			wantErr: true,
+			errMsg:  "must have 'automation_id'",
+		},
+		{
+			name:    "invalid status",
+			cond:    ContactAutomationCondition{Operator: "in", AutomationID: "auto_1", Status: stringPtr("paused")},
+			wantErr: true,
+			errMsg:  "invalid contact_automation status",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cond.Validate()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTreeNode_HasRelativeDates_MessageHistoryAndAutomations(t *testing.T) {
+	inTheLastDays := "in_the_last_days"
+
+	messageNode := &TreeNode{
+		Kind: "leaf",
+		Leaf: &TreeNodeLeaf{
+			Source: "message_history",
+			MessageHistory: &MessageHistoryCondition{
+				Event: "opened", CountOperator: "exactly", CountValue: 0,
+				TimeframeOperator: &inTheLastDays, TimeframeValues: []string{"30"},
+			},
+		},
+	}
+	assert.True(t, messageNode.HasRelativeDates())
+
+	automationNode := &TreeNode{
+		Kind: "leaf",
+		Leaf: &TreeNodeLeaf{
+			Source: "contact_automations",
+			ContactAutomation: &ContactAutomationCondition{
+				Operator: "in", AutomationID: "auto_1",
+				TimeframeOperator: &inTheLastDays, TimeframeValues: []string{"7"},
+			},
+		},
+	}
+	assert.True(t, automationNode.HasRelativeDates())
+
+	automationNode.Leaf.ContactAutomation.TimeframeOperator = nil
+	assert.False(t, automationNode.HasRelativeDates())
+}
@@ -2,17 +2,21 @@ package domain
 
 //go:generate mockgen -destination mocks/mock_webhook_subscription_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain WebhookSubscriptionRepository
 //go:generate mockgen -destination mocks/mock_webhook_delivery_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain WebhookDeliveryRepository
+//go:generate mockgen -destination mocks/mock_webhook_event_publisher.go -package mocks github.com/Notifuse/notifuse/internal/domain WebhookEventPublisher
 
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
 // WebhookSubscriptionSettings contains event subscription configuration
 type WebhookSubscriptionSettings struct {
-	EventTypes         []string            `json:"event_types"`
-	CustomEventFilters *CustomEventFilters `json:"custom_event_filters,omitempty"`
+	EventTypes         []string               `json:"event_types"`
+	CustomEventFilters *CustomEventFilters    `json:"custom_event_filters,omitempty"`
+	PayloadFilters     *WebhookPayloadFilters `json:"payload_filters,omitempty"`
+	Sink               *EventSinkSettings     `json:"sink,omitempty"` // publish to a streaming sink instead of the URL
 }
 
 // WebhookSubscription represents an outgoing webhook subscription configuration
@@ -24,22 +28,34 @@ type WebhookSubscription struct {
 	Settings       WebhookSubscriptionSettings `json:"settings"`
 	Enabled        bool                        `json:"enabled"`
 	LastDeliveryAt *time.Time                  `json:"last_delivery_at,omitempty"`
+	FailingSince   *time.Time                  `json:"failing_since,omitempty"`   // first failed attempt since the last successful delivery
+	DisabledReason *string                     `json:"disabled_reason,omitempty"` // set when the subscription was disabled automatically
 	CreatedAt      time.Time                   `json:"created_at"`
 	UpdatedAt      time.Time                   `json:"updated_at"`
 }
 
 // MarshalJSON implements custom JSON marshaling to flatten settings into top-level fields
-// This maintains backward-compatible API responses while using nested internal structure
+// This maintains backward-compatible API responses while using nested internal structure.
+// Sink credentials are write-only: only their encrypted form is returned.
 func (w WebhookSubscription) MarshalJSON() ([]byte, error) {
 	type Alias WebhookSubscription
+	var sink *EventSinkSettings
+	if w.Settings.Sink != nil {
+		sink = w.Settings.Sink.Masked()
+	}
+	w.Settings.Sink = sink
 	return json.Marshal(&struct {
 		Alias
-		EventTypes         []string            `json:"event_types"`
-		CustomEventFilters *CustomEventFilters `json:"custom_event_filters,omitempty"`
+		EventTypes         []string               `json:"event_types"`
+		CustomEventFilters *CustomEventFilters    `json:"custom_event_filters,omitempty"`
+		PayloadFilters     *WebhookPayloadFilters `json:"payload_filters,omitempty"`
+		Sink               *EventSinkSettings     `json:"sink,omitempty"`
 	}{
 		Alias:              Alias(w),
 		EventTypes:         w.Settings.EventTypes,
 		CustomEventFilters: w.Settings.CustomEventFilters,
+		PayloadFilters:     w.Settings.PayloadFilters,
+		Sink:               sink,
 	})
 }
 
@@ -49,13 +65,83 @@ type CustomEventFilters struct {
 	EventNames []string `json:"event_names,omitempty"` // Filter by event_name
 }
 
+// WebhookPayloadFilters restricts the deliveries of a subscription to the events
+// concerning given lists, segments, broadcasts or contacts. Each filter only applies
+// to the events carrying the matching payload field: a list_ids filter drops the
+// list and email events of other lists, but lets contact or broadcast events through.
+// Filters are evaluated by the delivery worker, deliveries that don't match are
+// marked as filtered instead of being sent.
+type WebhookPayloadFilters struct {
+	ListIDs          []string  `json:"list_ids,omitempty"`
+	SegmentIDs       []string  `json:"segment_ids,omitempty"`
+	BroadcastIDs     []string  `json:"broadcast_ids,omitempty"`
+	ContactCondition *TreeNode `json:"contact_condition,omitempty"` // evaluated against the contact of the event
+}
+
+// Validate validates the payload filters
+func (f *WebhookPayloadFilters) Validate() error {
+	if f.ContactCondition != nil {
+		if err := f.ContactCondition.Validate(); err != nil {
+			return fmt.Errorf("invalid contact_condition: %w", err)
+		}
+	}
+	return nil
+}
+
+// IsEmpty returns true when no filter is set
+func (f *WebhookPayloadFilters) IsEmpty() bool {
+	return len(f.ListIDs) == 0 && len(f.SegmentIDs) == 0 && len(f.BroadcastIDs) == 0 && f.ContactCondition == nil
+}
+
+// MatchesIDs checks the list, segment and broadcast filters against a delivery payload
+func (f *WebhookPayloadFilters) MatchesIDs(payload map[string]interface{}) bool {
+	return matchesPayloadID(payload, "list_id", f.ListIDs) &&
+		matchesPayloadID(payload, "segment_id", f.SegmentIDs) &&
+		matchesPayloadID(payload, "broadcast_id", f.BroadcastIDs)
+}
+
+// matchesPayloadID returns true when the filter is empty, the payload doesn't carry the
+// field, or its value is one of the allowed IDs
+func matchesPayloadID(payload map[string]interface{}, field string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	value, ok := payload[field].(string)
+	if !ok || value == "" {
+		return true
+	}
+	for _, id := range allowed {
+		if id == value {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookPayloadContactEmail returns the email of the contact an event is about:
+// the top-level email of list, segment and email events, or the email of the
+// contact and custom_event objects. Returns "" for events without a contact.
+func WebhookPayloadContactEmail(payload map[string]interface{}) string {
+	if email, ok := payload["email"].(string); ok && email != "" {
+		return email
+	}
+	for _, key := range []string{"contact", "custom_event"} {
+		if obj, ok := payload[key].(map[string]interface{}); ok {
+			if email, ok := obj["email"].(string); ok && email != "" {
+				return email
+			}
+		}
+	}
+	return ""
+}
+
 // WebhookDelivery represents a pending or completed webhook delivery
 type WebhookDelivery struct {
 	ID                 string                 `json:"id"`
 	SubscriptionID     string                 `json:"subscription_id"`
 	EventType          string                 `json:"event_type"`
 	Payload            map[string]interface{} `json:"payload"`
-	Status             string                 `json:"status"` // pending, delivering, delivered, failed
+	Status             string                 `json:"status"` // pending, delivering, delivered, failed, filtered
 	Attempts           int                    `json:"attempts"`
 	MaxAttempts        int                    `json:"max_attempts"`
 	NextAttemptAt      time.Time              `json:"next_attempt_at"`
@@ -73,6 +159,24 @@ const (
 	WebhookDeliveryStatusDelivering = "delivering"
 	WebhookDeliveryStatusDelivered  = "delivered"
 	WebhookDeliveryStatusFailed     = "failed"
+	WebhookDeliveryStatusFiltered   = "filtered" // skipped by the subscription payload filters
+)
+
+// Lifecycle webhook event types. Unlike the data change events, which are queued by
+// database triggers, these are published by the services running the lifecycle.
+const (
+	WebhookEventBroadcastStarted         = "broadcast.started"
+	WebhookEventBroadcastCompleted       = "broadcast.completed"
+	WebhookEventBroadcastPaused          = "broadcast.paused"
+	WebhookEventBroadcastFailed          = "broadcast.failed"
+	WebhookEventBroadcastTestCompleted   = "broadcast.test_completed"
+	WebhookEventAutomationContactEntered = "automation.contact_entered"
+	WebhookEventAutomationCompleted      = "automation.completed"
+	WebhookEventAutomationExited         = "automation.exited"
+	WebhookEventAutomationFailed         = "automation.failed"
+	WebhookEventEmailFailed              = "email.failed"
+	WebhookEventIntegrationCircuitOpened = "integration.circuit_opened"
+	WebhookEventTaskFailed               = "task.failed"
 )
 
 // Available webhook event types
@@ -90,6 +194,9 @@ var WebhookEventTypes = []string{
 	"list.complained",
 	"list.pending",
 	"list.removed",
+	"list.paused",
+	"list.resumed",
+	"list.frequency_changed",
 	// Segment events
 	"segment.joined",
 	"segment.left",
@@ -101,10 +208,26 @@ var WebhookEventTypes = []string{
 	"email.bounced",
 	"email.complained",
 	"email.unsubscribed",
+	WebhookEventEmailFailed,
 	// Custom events (with optional filtering)
 	"custom_event.created",
 	"custom_event.updated",
 	"custom_event.deleted",
+	// Broadcast events
+	WebhookEventBroadcastStarted,
+	WebhookEventBroadcastCompleted,
+	WebhookEventBroadcastPaused,
+	WebhookEventBroadcastFailed,
+	WebhookEventBroadcastTestCompleted,
+	// Automation events
+	WebhookEventAutomationContactEntered,
+	WebhookEventAutomationCompleted,
+	WebhookEventAutomationExited,
+	WebhookEventAutomationFailed,
+	// Integration events
+	WebhookEventIntegrationCircuitOpened,
+	// Task events
+	WebhookEventTaskFailed,
 }
 
 // WebhookSubscriptionRepository defines the interface for webhook subscription data access
@@ -114,19 +237,43 @@ type WebhookSubscriptionRepository interface {
 	List(ctx context.Context, workspaceID string) ([]*WebhookSubscription, error)
 	Update(ctx context.Context, workspaceID string, sub *WebhookSubscription) error
 	Delete(ctx context.Context, workspaceID, id string) error
+	// UpdateLastDeliveryAt records a successful delivery and clears failing_since
 	UpdateLastDeliveryAt(ctx context.Context, workspaceID, id string, deliveredAt time.Time) error
+	// RecordFailure sets failing_since if not already set and returns its value
+	RecordFailure(ctx context.Context, workspaceID, id string, failedAt time.Time) (time.Time, error)
+	// Disable disables a subscription and records why it was disabled automatically
+	Disable(ctx context.Context, workspaceID, id string, reason string) error
 }
 
 // WebhookDeliveryRepository defines the interface for webhook delivery data access
 type WebhookDeliveryRepository interface {
+	// GetPendingForWorkspace returns the due deliveries of the HTTP subscriptions
 	GetPendingForWorkspace(ctx context.Context, workspaceID string, limit int) ([]*WebhookDelivery, error)
+	// GetPendingForSubscription returns the undelivered deliveries of a subscription in
+	// insertion order, including the ones waiting for a retry
+	GetPendingForSubscription(ctx context.Context, workspaceID, subscriptionID string, limit int) ([]*WebhookDelivery, error)
+	GetByID(ctx context.Context, workspaceID, id string) (*WebhookDelivery, error)
 	ListAll(ctx context.Context, workspaceID string, subscriptionID *string, limit, offset int) ([]*WebhookDelivery, int, error)
 	UpdateStatus(ctx context.Context, workspaceID, id string, status string, attempts int, responseStatus *int, responseBody, lastError *string) error
 	MarkDelivered(ctx context.Context, workspaceID, id string, responseStatus int, responseBody string) error
+	// MarkBatchDelivered marks the deliveries published together to a sink as delivered
+	MarkBatchDelivered(ctx context.Context, workspaceID string, ids []string) error
 	ScheduleRetry(ctx context.Context, workspaceID, id string, nextAttempt time.Time, attempts int, responseStatus *int, responseBody, lastError *string) error
 	MarkFailed(ctx context.Context, workspaceID, id string, attempts int, lastError string, responseStatus *int, responseBody *string) error
 	Create(ctx context.Context, workspaceID string, delivery *WebhookDelivery) error
 	CleanupOldDeliveries(ctx context.Context, workspaceID string, retentionDays int) (int64, error)
+	// Requeue resets a delivery to pending so the worker sends it again
+	Requeue(ctx context.Context, workspaceID, id string) error
+	// RequeueFailed resets the permanently failed deliveries created in [from, to),
+	// optionally for one subscription, and returns how many were requeued
+	RequeueFailed(ctx context.Context, workspaceID string, subscriptionID *string, from, to time.Time) (int64, error)
+}
+
+// WebhookEventPublisher queues lifecycle webhook events for the enabled subscriptions
+// of a workspace. Publishing is best effort: failures are logged and never interrupt
+// the lifecycle that emitted the event.
+type WebhookEventPublisher interface {
+	Publish(ctx context.Context, workspaceID string, eventType string, payload map[string]interface{})
 }
 
 // WebhookDeliveryWithSubscription contains a delivery with its associated subscription
@@ -1,10 +1,12 @@
 package domain
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWebhookEventTypes_ContainsExpectedEvents(t *testing.T) {
@@ -23,6 +25,9 @@ func TestWebhookEventTypes_ContainsExpectedEvents(t *testing.T) {
 		"list.complained",
 		"list.pending",
 		"list.removed",
+		"list.paused",
+		"list.resumed",
+		"list.frequency_changed",
 		// Segment events
 		"segment.joined",
 		"segment.left",
@@ -34,10 +39,26 @@ func TestWebhookEventTypes_ContainsExpectedEvents(t *testing.T) {
 		"email.bounced",
 		"email.complained",
 		"email.unsubscribed",
+		"email.failed",
 		// Custom events
 		"custom_event.created",
 		"custom_event.updated",
 		"custom_event.deleted",
+		// Broadcast events
+		"broadcast.started",
+		"broadcast.completed",
+		"broadcast.paused",
+		"broadcast.failed",
+		"broadcast.test_completed",
+		// Automation events
+		"automation.contact_entered",
+		"automation.completed",
+		"automation.exited",
+		"automation.failed",
+		// Integration events
+		"integration.circuit_opened",
+		// Task events
+		"task.failed",
 	}
 
 	for _, expected := range expectedEvents {
@@ -113,6 +134,28 @@ func TestWebhookSubscription_NilCustomEventFilters(t *testing.T) {
 	assert.Nil(t, sub.Settings.CustomEventFilters)
 }
 
+func TestWebhookSubscription_MarshalJSON_MasksSinkCredentials(t *testing.T) {
+	sub := WebhookSubscription{
+		ID:  "sub_123",
+		URL: "nats://notifuse",
+		Settings: WebhookSubscriptionSettings{
+			EventTypes: []string{"contact.created"},
+			Sink: &EventSinkSettings{
+				Type: EventSinkTypeNATS,
+				NATS: &NATSSinkSettings{Subject: "notifuse", Token: "plain-token", EncryptedToken: "enc-token"},
+			},
+		},
+	}
+
+	data, err := json.Marshal(sub)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "plain-token")
+	assert.Contains(t, string(data), "enc-token")
+
+	// The subscription itself keeps its settings
+	assert.Equal(t, "plain-token", sub.Settings.Sink.NATS.Token)
+}
+
 func TestCustomEventFilters_Fields(t *testing.T) {
 	t.Run("with both filters", func(t *testing.T) {
 		filters := CustomEventFilters{
@@ -323,8 +366,63 @@ func TestWebhookEventTypes_GroupedByCategory(t *testing.T) {
 
 	// Verify expected categories
 	assert.Equal(t, 3, categories["contact"], "Should have 3 contact events")
-	assert.Equal(t, 8, categories["list"], "Should have 8 list events")
+	assert.Equal(t, 11, categories["list"], "Should have 11 list events")
 	assert.Equal(t, 2, categories["segment"], "Should have 2 segment events")
-	assert.Equal(t, 7, categories["email"], "Should have 7 email events")
+	assert.Equal(t, 8, categories["email"], "Should have 8 email events")
 	assert.Equal(t, 3, categories["custom_event"], "Should have 3 custom_event events")
+	assert.Equal(t, 5, categories["broadcast"], "Should have 5 broadcast events")
+	assert.Equal(t, 4, categories["automation"], "Should have 4 automation events")
+	assert.Equal(t, 1, categories["integration"], "Should have 1 integration event")
+	assert.Equal(t, 1, categories["task"], "Should have 1 task event")
+}
+
+func TestWebhookPayloadFilters_MatchesIDs(t *testing.T) {
+	filters := &WebhookPayloadFilters{
+		ListIDs:      []string{"newsletter"},
+		BroadcastIDs: []string{"bc-1", "bc-2"},
+	}
+
+	tests := []struct {
+		name     string
+		payload  map[string]interface{}
+		expected bool
+	}{
+		{"matching list", map[string]interface{}{"list_id": "newsletter"}, true},
+		{"other list", map[string]interface{}{"list_id": "promotions"}, false},
+		{"matching broadcast", map[string]interface{}{"broadcast_id": "bc-2", "list_id": "newsletter"}, true},
+		{"other broadcast", map[string]interface{}{"broadcast_id": "bc-3"}, false},
+		{"event without filtered fields", map[string]interface{}{"contact": map[string]interface{}{"email": "a@example.com"}}, true},
+		{"empty field value", map[string]interface{}{"list_id": ""}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, filters.MatchesIDs(tt.payload))
+		})
+	}
+}
+
+func TestWebhookPayloadFilters_IsEmpty(t *testing.T) {
+	assert.True(t, (&WebhookPayloadFilters{}).IsEmpty())
+	assert.False(t, (&WebhookPayloadFilters{SegmentIDs: []string{"vip"}}).IsEmpty())
+	assert.False(t, (&WebhookPayloadFilters{ContactCondition: &TreeNode{Kind: "leaf"}}).IsEmpty())
+}
+
+func TestWebhookPayloadFilters_Validate(t *testing.T) {
+	assert.NoError(t, (&WebhookPayloadFilters{ListIDs: []string{"newsletter"}}).Validate())
+
+	err := (&WebhookPayloadFilters{ContactCondition: &TreeNode{Kind: "invalid"}}).Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid contact_condition")
+}
+
+func TestWebhookPayloadContactEmail(t *testing.T) {
+	assert.Equal(t, "a@example.com", WebhookPayloadContactEmail(map[string]interface{}{"email": "a@example.com"}))
+	assert.Equal(t, "b@example.com", WebhookPayloadContactEmail(map[string]interface{}{
+		"contact": map[string]interface{}{"email": "b@example.com"},
+	}))
+	assert.Equal(t, "c@example.com", WebhookPayloadContactEmail(map[string]interface{}{
+		"custom_event": map[string]interface{}{"email": "c@example.com"},
+	}))
+	assert.Equal(t, "", WebhookPayloadContactEmail(map[string]interface{}{"broadcast_id": "bc-1"}))
 }
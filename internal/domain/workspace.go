@@ -282,6 +282,10 @@ type BlogSettings struct {
 	CategoryPageSize int          `json:"category_page_size,omitempty"` // Posts per page on category (default: 20)
 	FeedSummaryOnly  bool         `json:"feed_summary_only,omitempty"`  // When true, RSS/JSON feeds emit excerpt instead of full HTML
 	FeedMaxItems     int          `json:"feed_max_items,omitempty"`     // Items per RSS/JSON feed (default and cap: 20)
+	// Comments configures the comments and reactions of the posts, off by default
+	Comments *BlogCommentSettings `json:"comments,omitempty"`
+	// Access configures the magic links of the subscribers of gated posts
+	Access *BlogAccessSettings `json:"access,omitempty"`
 }
 
 // GetHomePageSize returns the home page size with validation and default
@@ -328,7 +332,10 @@ func (bs *BlogSettings) Validate() error {
 	if bs.FeedMaxItems != 0 && (bs.FeedMaxItems < 1 || bs.FeedMaxItems > 20) {
 		return fmt.Errorf("feed_max_items must be between 1 and 20")
 	}
-	return nil
+	if err := bs.Comments.Validate(); err != nil {
+		return err
+	}
+	return bs.Access.Validate()
 }
 
 // Value implements the driver.Valuer interface for database serialization
@@ -353,22 +360,25 @@ func (b *BlogSettings) Scan(value interface{}) error {
 
 // WorkspaceSettings contains configurable workspace settings
 type WorkspaceSettings struct {
-	WebsiteURL                   string              `json:"website_url,omitempty"`
-	LogoURL                      string              `json:"logo_url,omitempty"`
-	CoverURL                     string              `json:"cover_url,omitempty"`
-	Timezone                     string              `json:"timezone"`
-	FileManager                  FileManagerSettings `json:"file_manager,omitempty"`
-	TransactionalEmailProviderID string              `json:"transactional_email_provider_id,omitempty"`
-	MarketingEmailProviderID     string              `json:"marketing_email_provider_id,omitempty"`
-	EncryptedSecretKey           string              `json:"encrypted_secret_key,omitempty"`
-	EmailTrackingEnabled         bool                `json:"email_tracking_enabled"`
-	TemplateBlocks               []TemplateBlock     `json:"template_blocks,omitempty"`
-	CustomEndpointURL            *string             `json:"custom_endpoint_url,omitempty"`
-	CustomFieldLabels            map[string]string   `json:"custom_field_labels,omitempty"`
-	BlogEnabled                  bool                `json:"blog_enabled"`            // Enable blog feature at workspace level
-	BlogSettings                 *BlogSettings       `json:"blog_settings,omitempty"` // Blog styling and SEO settings
-	DefaultLanguage              string              `json:"default_language"`
-	Languages                    []string            `json:"languages"`
+	WebsiteURL                   string                     `json:"website_url,omitempty"`
+	LogoURL                      string                     `json:"logo_url,omitempty"`
+	CoverURL                     string                     `json:"cover_url,omitempty"`
+	Timezone                     string                     `json:"timezone"`
+	FileManager                  FileManagerSettings        `json:"file_manager,omitempty"`
+	TransactionalEmailProviderID string                     `json:"transactional_email_provider_id,omitempty"`
+	MarketingEmailProviderID     string                     `json:"marketing_email_provider_id,omitempty"`
+	EncryptedSecretKey           string                     `json:"encrypted_secret_key,omitempty"`
+	EmailTrackingEnabled         bool                       `json:"email_tracking_enabled"`
+	TemplateBlocks               []TemplateBlock            `json:"template_blocks,omitempty"`
+	CustomEndpointURL            *string                    `json:"custom_endpoint_url,omitempty"`
+	CustomFieldLabels            map[string]string          `json:"custom_field_labels,omitempty"`
+	ComputedProperties           []ComputedProperty         `json:"computed_properties,omitempty"`
+	ContactAttributes            []ContactAttribute         `json:"contact_attributes,omitempty"`
+	EmailVerification            *EmailVerificationSettings `json:"email_verification,omitempty"`
+	BlogEnabled                  bool                       `json:"blog_enabled"`            // Enable blog feature at workspace level
+	BlogSettings                 *BlogSettings              `json:"blog_settings,omitempty"` // Blog styling and SEO settings
+	DefaultLanguage              string                     `json:"default_language"`
+	Languages                    []string                   `json:"languages"`
 
 	// decoded secret key, not stored in the database
 	SecretKey string `json:"-"`
@@ -431,6 +441,20 @@ func (ws *WorkspaceSettings) Validate(passphrase string) error {
 		return fmt.Errorf("invalid custom field labels: %w", err)
 	}
 
+	if err := ValidateComputedProperties(ws.ComputedProperties); err != nil {
+		return fmt.Errorf("invalid computed properties: %w", err)
+	}
+
+	if err := ValidateContactAttributes(ws.ContactAttributes); err != nil {
+		return fmt.Errorf("invalid contact attributes: %w", err)
+	}
+
+	if ws.EmailVerification != nil {
+		if err := ws.EmailVerification.Validate(); err != nil {
+			return fmt.Errorf("invalid email verification settings: %w", err)
+		}
+	}
+
 	// Validate default language is set
 	if ws.DefaultLanguage == "" {
 		return fmt.Errorf("default language is required")
@@ -470,6 +494,20 @@ func (ws *WorkspaceSettings) Validate(passphrase string) error {
 	return nil
 }
 
+// IsTranslationLanguage reports whether a language is one of the workspace languages
+// other than its default language
+func (ws *WorkspaceSettings) IsTranslationLanguage(code string) bool {
+	if code == "" || code == ws.DefaultLanguage {
+		return false
+	}
+	for _, lang := range ws.Languages {
+		if lang == code {
+			return true
+		}
+	}
+	return false
+}
+
 // ResolveEndpoint returns the base endpoint used for tracking links, the
 // notification center, and template URL composition: the configured Custom
 // Endpoint URL when set, otherwise the provided default API endpoint.
@@ -1064,6 +1102,8 @@ type WorkspaceServiceInterface interface {
 
 	// Custom field management
 	SetCustomFieldLabels(ctx context.Context, workspaceID string, labels map[string]string) error
+	SetComputedProperties(ctx context.Context, workspaceID string, props []ComputedProperty) error
+	SetContactAttributes(ctx context.Context, workspaceID string, attrs []ContactAttribute) error
 
 	// Blog management
 	SetBlogSettings(ctx context.Context, workspaceID string, enabled bool, settings *BlogSettings) error
@@ -1330,6 +1370,60 @@ func (r *SetCustomFieldLabelsRequest) Validate() (workspaceID string, labels map
 	return r.WorkspaceID, r.CustomFieldLabels, nil
 }
 
+// SetComputedPropertiesRequest defines the request structure for setting computed contact properties
+type SetComputedPropertiesRequest struct {
+	WorkspaceID        string             `json:"workspace_id"`
+	ComputedProperties []ComputedProperty `json:"computed_properties"`
+}
+
+// Validate validates the set computed properties request and returns the
+// sanitized workspace ID and definitions. An empty list is valid and removes
+// all computed properties.
+func (r *SetComputedPropertiesRequest) Validate() (workspaceID string, props []ComputedProperty, err error) {
+	if r.WorkspaceID == "" {
+		return "", nil, fmt.Errorf("invalid set computed properties request: workspace_id is required")
+	}
+	if !govalidator.IsAlphanumeric(r.WorkspaceID) {
+		return "", nil, fmt.Errorf("invalid set computed properties request: workspace_id must be alphanumeric")
+	}
+	if len(r.WorkspaceID) > 32 {
+		return "", nil, fmt.Errorf("invalid set computed properties request: workspace_id length must be between 1 and 32")
+	}
+
+	if err := ValidateComputedProperties(r.ComputedProperties); err != nil {
+		return "", nil, err
+	}
+
+	return r.WorkspaceID, r.ComputedProperties, nil
+}
+
+// SetContactAttributesRequest defines the request structure for setting the contact attribute registry
+type SetContactAttributesRequest struct {
+	WorkspaceID       string             `json:"workspace_id"`
+	ContactAttributes []ContactAttribute `json:"contact_attributes"`
+}
+
+// Validate validates the set contact attributes request and returns the
+// sanitized workspace ID and definitions. An empty list is valid and removes
+// all attribute definitions (stored values are kept).
+func (r *SetContactAttributesRequest) Validate() (workspaceID string, attrs []ContactAttribute, err error) {
+	if r.WorkspaceID == "" {
+		return "", nil, fmt.Errorf("invalid set contact attributes request: workspace_id is required")
+	}
+	if !govalidator.IsAlphanumeric(r.WorkspaceID) {
+		return "", nil, fmt.Errorf("invalid set contact attributes request: workspace_id must be alphanumeric")
+	}
+	if len(r.WorkspaceID) > 32 {
+		return "", nil, fmt.Errorf("invalid set contact attributes request: workspace_id length must be between 1 and 32")
+	}
+
+	if err := ValidateContactAttributes(r.ContactAttributes); err != nil {
+		return "", nil, err
+	}
+
+	return r.WorkspaceID, r.ContactAttributes, nil
+}
+
 // SetBlogSettingsRequest defines the request structure for setting blog settings
 // (the enable flag plus title/SEO/pagination/feed config) via the dedicated,
 // blog:write gated endpoint.
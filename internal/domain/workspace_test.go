@@ -5342,3 +5342,13 @@ func TestWorkspaceSettings_ResolveEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkspaceSettings_IsTranslationLanguage(t *testing.T) {
+	settings := WorkspaceSettings{DefaultLanguage: "en", Languages: []string{"en", "fr", "de"}}
+
+	assert.True(t, settings.IsTranslationLanguage("fr"))
+	assert.True(t, settings.IsTranslationLanguage("de"))
+	assert.False(t, settings.IsTranslationLanguage("en"))
+	assert.False(t, settings.IsTranslationLanguage("es"))
+	assert.False(t, settings.IsTranslationLanguage(""))
+}
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/http/middleware"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+type BlogCommentHandler struct {
+	service      domain.BlogCommentService
+	logger       logger.Logger
+	getJWTSecret func() ([]byte, error)
+	isDemo       bool
+}
+
+func NewBlogCommentHandler(service domain.BlogCommentService, getJWTSecret func() ([]byte, error), logger logger.Logger, isDemo bool) *BlogCommentHandler {
+	return &BlogCommentHandler{
+		service:      service,
+		logger:       logger,
+		getJWTSecret: getJWTSecret,
+		isDemo:       isDemo,
+	}
+}
+
+func (h *BlogCommentHandler) RegisterRoutes(mux *http.ServeMux) {
+	// Create auth middleware
+	authMiddleware := middleware.NewAuthMiddleware(h.getJWTSecret)
+	requireAuth := authMiddleware.RequireAuth()
+
+	restrictedInDemo := middleware.RestrictedInDemo(h.isDemo)
+
+	// Register RPC-style endpoints with camelCase notation for comment moderation
+	mux.Handle("/api/blogComments.list", requireAuth(http.HandlerFunc(h.HandleListComments)))
+	mux.Handle("/api/blogComments.moderate", restrictedInDemo(requireAuth(http.HandlerFunc(h.HandleModerateComment))))
+	mux.Handle("/api/blogComments.delete", restrictedInDemo(requireAuth(http.HandlerFunc(h.HandleDeleteComment))))
+}
+
+// HandleListComments handles the list comments request (GET)
+func (h *BlogCommentHandler) HandleListComments(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	workspace_id := r.URL.Query().Get("workspace_id")
+	if workspace_id == "" {
+		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
+		return
+	}
+
+	params, err := parseListBlogCommentsParams(r.URL.Query())
+	if err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Add workspace_id to context
+	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace_id)
+
+	response, err := h.service.ListComments(ctx, &params)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to list comments")
+		WriteJSONError(w, "Failed to list comments", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"comments":    response.Comments,
+		"total_count": response.TotalCount,
+	})
+}
+
+// HandleModerateComment handles the moderate comment request (POST)
+func (h *BlogCommentHandler) HandleModerateComment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	workspace_id := r.URL.Query().Get("workspace_id")
+	if workspace_id == "" {
+		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.ModerateBlogCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Add workspace_id to context
+	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace_id)
+
+	comment, err := h.service.ModerateComment(ctx, &req)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to moderate comment")
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"comment": comment,
+	})
+}
+
+// HandleDeleteComment handles the delete comment request (POST)
+func (h *BlogCommentHandler) HandleDeleteComment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	workspace_id := r.URL.Query().Get("workspace_id")
+	if workspace_id == "" {
+		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.DeleteBlogCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Add workspace_id to context
+	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace_id)
+
+	if err := h.service.DeleteComment(ctx, &req); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to delete comment")
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+	})
+}
+
+// parseListBlogCommentsParams parses URL query parameters into ListBlogCommentsRequest
+func parseListBlogCommentsParams(values url.Values) (domain.ListBlogCommentsRequest, error) {
+	params := domain.ListBlogCommentsRequest{
+		Status: domain.BlogCommentStatus(values.Get("status")),
+		PostID: values.Get("post_id"),
+	}
+
+	// Parse limit
+	if limitStr := values.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return params, err
+		}
+		params.Limit = limit
+	}
+
+	// Parse offset
+	if offsetStr := values.Get("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil {
+			return params, err
+		}
+		params.Offset = offset
+	}
+
+	return params, nil
+}
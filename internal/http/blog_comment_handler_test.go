@@ -0,0 +1,149 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	http_handler "github.com/Notifuse/notifuse/internal/http"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupBlogCommentHandler sets up a blog comment handler with mocks for testing
+func setupBlogCommentHandler(t *testing.T) (
+	*http_handler.BlogCommentHandler,
+	*mocks.MockBlogCommentService,
+	*pkgmocks.MockLogger,
+) {
+	ctrl := gomock.NewController(t)
+
+	mockService := mocks.NewMockBlogCommentService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	jwtSecret := []byte("test-jwt-secret-key-for-testing-32bytes")
+
+	handler := http_handler.NewBlogCommentHandler(
+		mockService,
+		func() ([]byte, error) { return jwtSecret, nil },
+		mockLogger,
+		false,
+	)
+
+	return handler, mockService, mockLogger
+}
+
+func TestBlogCommentHandler_HandleListComments(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		handler, mockService, _ := setupBlogCommentHandler(t)
+
+		mockService.EXPECT().
+			ListComments(gomock.Any(), &domain.ListBlogCommentsRequest{
+				Status: domain.BlogCommentStatusSpam,
+				PostID: "post-1",
+				Limit:  10,
+				Offset: 20,
+			}).
+			Return(&domain.BlogCommentListResponse{
+				Comments:   []*domain.BlogComment{{ID: "c1", Status: domain.BlogCommentStatusSpam}},
+				TotalCount: 21,
+			}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/blogComments.list?workspace_id=ws-123&status=spam&post_id=post-1&limit=10&offset=20", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleListComments(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, float64(21), response["total_count"])
+		assert.Len(t, response["comments"], 1)
+	})
+
+	t.Run("Missing workspace_id", func(t *testing.T) {
+		handler, _, _ := setupBlogCommentHandler(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/blogComments.list", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleListComments(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Invalid limit", func(t *testing.T) {
+		handler, _, _ := setupBlogCommentHandler(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/blogComments.list?workspace_id=ws-123&limit=abc", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleListComments(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestBlogCommentHandler_HandleModerateComment(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		handler, mockService, _ := setupBlogCommentHandler(t)
+
+		mockService.EXPECT().
+			ModerateComment(gomock.Any(), &domain.ModerateBlogCommentRequest{ID: "c1", Status: domain.BlogCommentStatusApproved}).
+			Return(&domain.BlogComment{ID: "c1", Status: domain.BlogCommentStatusApproved}, nil)
+
+		body, _ := json.Marshal(map[string]string{"id": "c1", "status": "approved"})
+		req := httptest.NewRequest(http.MethodPost, "/api/blogComments.moderate?workspace_id=ws-123", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.HandleModerateComment(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Service error", func(t *testing.T) {
+		handler, mockService, _ := setupBlogCommentHandler(t)
+
+		mockService.EXPECT().ModerateComment(gomock.Any(), gomock.Any()).Return(nil, errors.New("id is required"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/blogComments.moderate?workspace_id=ws-123", bytes.NewReader([]byte(`{}`)))
+		w := httptest.NewRecorder()
+
+		handler.HandleModerateComment(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		handler, _, _ := setupBlogCommentHandler(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/blogComments.moderate?workspace_id=ws-123", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleModerateComment(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestBlogCommentHandler_HandleDeleteComment(t *testing.T) {
+	handler, mockService, _ := setupBlogCommentHandler(t)
+
+	mockService.EXPECT().DeleteComment(gomock.Any(), &domain.DeleteBlogCommentRequest{ID: "c1"}).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/blogComments.delete?workspace_id=ws-123", bytes.NewReader([]byte(`{"id":"c1"}`)))
+	w := httptest.NewRecorder()
+
+	handler.HandleDeleteComment(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -50,6 +50,13 @@ func (h *BlogHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/api/blogPosts.delete", restrictedInDemo(requireAuth(http.HandlerFunc(h.HandleDeletePost))))
 	mux.Handle("/api/blogPosts.publish", restrictedInDemo(requireAuth(http.HandlerFunc(h.HandlePublishPost))))
 	mux.Handle("/api/blogPosts.unpublish", restrictedInDemo(requireAuth(http.HandlerFunc(h.HandleUnpublishPost))))
+	mux.Handle("/api/blogPosts.schedule", restrictedInDemo(requireAuth(http.HandlerFunc(h.HandleSchedulePost))))
+
+	// Register RPC-style endpoints with camelCase notation for post revisions
+	mux.Handle("/api/blogPosts.revisions", requireAuth(http.HandlerFunc(h.HandleListPostRevisions)))
+	mux.Handle("/api/blogPosts.saveDraft", restrictedInDemo(requireAuth(http.HandlerFunc(h.HandleSaveDraftRevision))))
+	mux.Handle("/api/blogPosts.promoteRevision", restrictedInDemo(requireAuth(http.HandlerFunc(h.HandlePromoteRevision))))
+	mux.Handle("/api/blogPosts.restoreRevision", restrictedInDemo(requireAuth(http.HandlerFunc(h.HandleRestoreRevision))))
 }
 
 // ====================
@@ -483,6 +490,161 @@ func (h *BlogHandler) HandleUnpublishPost(w http.ResponseWriter, r *http.Request
 	})
 }
 
+// HandleSchedulePost handles the schedule post request (POST)
+func (h *BlogHandler) HandleSchedulePost(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	workspace_id := r.URL.Query().Get("workspace_id")
+	if workspace_id == "" {
+		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.ScheduleBlogPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Add workspace_id to context
+	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace_id)
+
+	post, err := h.service.SchedulePost(ctx, &req)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to schedule post")
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"post": post,
+	})
+}
+
+// ====================
+// Revision Handlers
+// ====================
+
+// HandleListPostRevisions handles the list post revisions request (GET)
+func (h *BlogHandler) HandleListPostRevisions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	workspace_id := r.URL.Query().Get("workspace_id")
+	if workspace_id == "" {
+		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		WriteJSONError(w, "id is required", http.StatusBadRequest)
+		return
+	}
+
+	// Add workspace_id to context
+	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace_id)
+
+	revisions, err := h.service.ListPostRevisions(ctx, id)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to list post revisions")
+		WriteJSONError(w, "Failed to list post revisions", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"revisions": revisions,
+	})
+}
+
+// HandleSaveDraftRevision handles the save draft revision request (POST)
+func (h *BlogHandler) HandleSaveDraftRevision(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	workspace_id := r.URL.Query().Get("workspace_id")
+	if workspace_id == "" {
+		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.UpdateBlogPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Add workspace_id to context
+	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace_id)
+
+	revision, err := h.service.SaveDraftRevision(ctx, &req)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to save draft revision")
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"revision": revision,
+	})
+}
+
+// HandlePromoteRevision handles the promote revision request (POST)
+func (h *BlogHandler) HandlePromoteRevision(w http.ResponseWriter, r *http.Request) {
+	h.handleApplyRevision(w, r, h.service.PromoteRevision, "Failed to promote revision")
+}
+
+// HandleRestoreRevision handles the restore revision request (POST)
+func (h *BlogHandler) HandleRestoreRevision(w http.ResponseWriter, r *http.Request) {
+	h.handleApplyRevision(w, r, h.service.RestoreRevision, "Failed to restore revision")
+}
+
+// handleApplyRevision decodes a revision request and applies it with the given service method
+func (h *BlogHandler) handleApplyRevision(
+	w http.ResponseWriter,
+	r *http.Request,
+	apply func(context.Context, *domain.BlogPostRevisionRequest) (*domain.BlogPost, error),
+	failureMessage string,
+) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	workspace_id := r.URL.Query().Get("workspace_id")
+	if workspace_id == "" {
+		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.BlogPostRevisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Add workspace_id to context
+	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace_id)
+
+	post, err := apply(ctx, &req)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error(failureMessage)
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"post": post,
+	})
+}
+
 // ====================
 // Helper Functions
 // ====================
@@ -492,6 +654,7 @@ func parseListBlogPostsParams(values url.Values) (domain.ListBlogPostsRequest, e
 	params := domain.ListBlogPostsRequest{
 		CategoryID: values.Get("category_id"),
 		Status:     domain.BlogPostStatus(values.Get("status")),
+		Query:      values.Get("query"),
 	}
 
 	// Parse limit
@@ -1167,6 +1167,156 @@ func TestBlogHandler_HandleUnpublishPost(t *testing.T) {
 	})
 }
 
+func TestBlogHandler_HandleSchedulePost(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		handler, mockService, _, ctrl := setupBlogHandler(t)
+		defer ctrl.Finish()
+
+		scheduledAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		mockService.EXPECT().
+			SchedulePost(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, req *domain.ScheduleBlogPostRequest) (*domain.BlogPost, error) {
+				assert.Equal(t, "ws-123", ctx.Value(domain.WorkspaceIDKey))
+				assert.Equal(t, "post-1", req.ID)
+				require.NotNil(t, req.ScheduledPublishAt)
+				return &domain.BlogPost{ID: "post-1", ScheduledPublishAt: req.ScheduledPublishAt}, nil
+			})
+
+		body, _ := json.Marshal(domain.ScheduleBlogPostRequest{ID: "post-1", ScheduledPublishAt: &scheduledAt})
+		req := httptest.NewRequest(http.MethodPost, "/api/blogPosts.schedule?workspace_id=ws-123", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedulePost(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.NotNil(t, response["post"]["scheduled_publish_at"])
+	})
+
+	t.Run("Service error", func(t *testing.T) {
+		handler, mockService, mockLogger, ctrl := setupBlogHandler(t)
+		defer ctrl.Finish()
+
+		mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger)
+		mockLogger.EXPECT().Error(gomock.Any())
+		mockService.EXPECT().
+			SchedulePost(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("scheduled_publish_at must be in the future"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/blogPosts.schedule?workspace_id=ws-123", bytes.NewReader([]byte(`{"id":"post-1"}`)))
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedulePost(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "must be in the future")
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		handler, _, _, ctrl := setupBlogHandler(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/blogPosts.schedule?workspace_id=ws-123", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedulePost(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestBlogHandler_HandleRevisions(t *testing.T) {
+	t.Run("List revisions", func(t *testing.T) {
+		handler, mockService, _, ctrl := setupBlogHandler(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().
+			ListPostRevisions(gomock.Any(), "post-1").
+			Return([]*domain.BlogPostRevision{
+				{PostID: "post-1", Version: 2, Status: domain.BlogPostRevisionStatusDraft},
+				{PostID: "post-1", Version: 1, Status: domain.BlogPostRevisionStatusApplied},
+			}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/blogPosts.revisions?workspace_id=ws-123&id=post-1", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleListPostRevisions(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string][]map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response["revisions"], 2)
+		assert.Equal(t, float64(2), response["revisions"][0]["version"])
+	})
+
+	t.Run("List revisions requires id", func(t *testing.T) {
+		handler, _, _, ctrl := setupBlogHandler(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/blogPosts.revisions?workspace_id=ws-123", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleListPostRevisions(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Save draft", func(t *testing.T) {
+		handler, mockService, _, ctrl := setupBlogHandler(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().
+			SaveDraftRevision(gomock.Any(), gomock.Any()).
+			Return(&domain.BlogPostRevision{PostID: "post-1", Version: 3, Status: domain.BlogPostRevisionStatusDraft, PreviewToken: "token"}, nil)
+
+		body, _ := json.Marshal(domain.UpdateBlogPostRequest{ID: "post-1", Title: "Launch v2"})
+		req := httptest.NewRequest(http.MethodPost, "/api/blogPosts.saveDraft?workspace_id=ws-123", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.HandleSaveDraftRevision(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"preview_token":"token"`)
+	})
+
+	t.Run("Promote revision", func(t *testing.T) {
+		handler, mockService, _, ctrl := setupBlogHandler(t)
+		defer ctrl.Finish()
+
+		mockService.EXPECT().
+			PromoteRevision(gomock.Any(), &domain.BlogPostRevisionRequest{ID: "post-1", Version: 3}).
+			Return(&domain.BlogPost{ID: "post-1"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/blogPosts.promoteRevision?workspace_id=ws-123", bytes.NewReader([]byte(`{"id":"post-1","version":3}`)))
+		w := httptest.NewRecorder()
+
+		handler.HandlePromoteRevision(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Restore revision error", func(t *testing.T) {
+		handler, mockService, mockLogger, ctrl := setupBlogHandler(t)
+		defer ctrl.Finish()
+
+		mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger)
+		mockLogger.EXPECT().Error("Failed to restore revision")
+		mockService.EXPECT().
+			RestoreRevision(gomock.Any(), &domain.BlogPostRevisionRequest{ID: "post-1", Version: 3}).
+			Return(nil, errors.New("revision 3 is a draft, promote it instead"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/blogPosts.restoreRevision?workspace_id=ws-123", bytes.NewReader([]byte(`{"id":"post-1","version":3}`)))
+		w := httptest.NewRecorder()
+
+		handler.HandleRestoreRevision(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
 func TestBlogHandler_RegisterRoutes(t *testing.T) {
 	// Test BlogHandler.RegisterRoutes - this was at 0% coverage
 	handler, _, _, ctrl := setupBlogHandler(t)
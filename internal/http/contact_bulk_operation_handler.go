@@ -0,0 +1,196 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/http/middleware"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// maxContactBulkOperationBodyBytes leaves room for an "emails" target at its maximum size
+const maxContactBulkOperationBodyBytes = 16 << 20
+
+type ContactBulkOperationHandler struct {
+	service      domain.ContactBulkOperationService
+	logger       logger.Logger
+	getJWTSecret func() ([]byte, error)
+}
+
+func NewContactBulkOperationHandler(service domain.ContactBulkOperationService, getJWTSecret func() ([]byte, error), logger logger.Logger) *ContactBulkOperationHandler {
+	return &ContactBulkOperationHandler{
+		service:      service,
+		logger:       logger,
+		getJWTSecret: getJWTSecret,
+	}
+}
+
+func (h *ContactBulkOperationHandler) RegisterRoutes(mux *http.ServeMux) {
+	// Create auth middleware
+	authMiddleware := middleware.NewAuthMiddleware(h.getJWTSecret)
+	requireAuth := authMiddleware.RequireAuth()
+
+	// Register RPC-style endpoints with dot notation
+	mux.Handle("/api/contactBulkOperations.list", requireAuth(http.HandlerFunc(h.handleList)))
+	mux.Handle("/api/contactBulkOperations.get", requireAuth(http.HandlerFunc(h.handleGet)))
+	mux.Handle("/api/contactBulkOperations.create", requireAuth(http.HandlerFunc(h.handleCreate)))
+	mux.Handle("/api/contactBulkOperations.cancel", requireAuth(http.HandlerFunc(h.handleCancel)))
+	mux.Handle("/api/contactBulkOperations.results", requireAuth(http.HandlerFunc(h.handleResults)))
+}
+
+// writeContactBulkOperationError maps service errors to HTTP responses
+func (h *ContactBulkOperationHandler) writeContactBulkOperationError(w http.ResponseWriter, err error, message string) {
+	var permErr *domain.PermissionError
+	if errors.As(err, &permErr) {
+		WriteJSONError(w, permErr.Message, http.StatusForbidden)
+		return
+	}
+	var validationErr domain.ValidationError
+	if errors.As(err, &validationErr) {
+		WriteJSONError(w, validationErr.Message, http.StatusBadRequest)
+		return
+	}
+	var notFoundErr *domain.ErrContactBulkOperationNotFound
+	if errors.As(err, &notFoundErr) {
+		WriteJSONError(w, "Contact bulk operation not found", http.StatusNotFound)
+		return
+	}
+	h.logger.WithField("error", err.Error()).Error(message)
+	WriteJSONError(w, message, http.StatusInternalServerError)
+}
+
+func (h *ContactBulkOperationHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.ListContactBulkOperationsRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	operations, err := h.service.ListContactBulkOperations(r.Context(), req.WorkspaceID)
+	if err != nil {
+		h.writeContactBulkOperationError(w, err, "Failed to list contact bulk operations")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"operations": operations,
+	})
+}
+
+func (h *ContactBulkOperationHandler) handleGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.GetContactBulkOperationRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	operation, err := h.service.GetContactBulkOperation(r.Context(), req.WorkspaceID, req.ID)
+	if err != nil {
+		h.writeContactBulkOperationError(w, err, "Failed to get contact bulk operation")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"operation": operation,
+	})
+}
+
+// handleCreate starts an operation, or returns the number of contacts it would affect
+// when dry_run is set
+func (h *ContactBulkOperationHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxContactBulkOperationBodyBytes)
+
+	var req domain.CreateContactBulkOperationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.CreateContactBulkOperation(r.Context(), &req)
+	if err != nil {
+		h.writeContactBulkOperationError(w, err, "Failed to create contact bulk operation")
+		return
+	}
+
+	status := http.StatusCreated
+	if response.DryRun {
+		status = http.StatusOK
+	}
+	writeJSON(w, status, response)
+}
+
+func (h *ContactBulkOperationHandler) handleCancel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.CancelContactBulkOperationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	operation, err := h.service.CancelContactBulkOperation(r.Context(), req.WorkspaceID, req.ID)
+	if err != nil {
+		h.writeContactBulkOperationError(w, err, "Failed to cancel contact bulk operation")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"operation": operation,
+	})
+}
+
+// handleResults downloads the outcome for each contact as a CSV file
+func (h *ContactBulkOperationHandler) handleResults(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.GetContactBulkOperationRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := h.service.GetContactBulkOperationResultFile(r.Context(), req.WorkspaceID, req.ID)
+	if err != nil {
+		h.writeContactBulkOperationError(w, err, "Failed to get contact bulk operation results")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"bulk-operation-%s-results.csv\"", req.ID))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,237 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupContactBulkOperationHandlerTest(t *testing.T) (*mocks.MockContactBulkOperationService, *ContactBulkOperationHandler) {
+	ctrl := gomock.NewController(t)
+
+	mockService := mocks.NewMockContactBulkOperationService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	jwtSecret := []byte("test-jwt-secret-key-for-testing-32bytes")
+	handler := NewContactBulkOperationHandler(mockService, func() ([]byte, error) { return jwtSecret, nil }, mockLogger)
+	return mockService, handler
+}
+
+func TestContactBulkOperationHandler_RegisterRoutes(t *testing.T) {
+	_, handler := setupContactBulkOperationHandlerTest(t)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	for _, route := range []string{
+		"/api/contactBulkOperations.list",
+		"/api/contactBulkOperations.get",
+		"/api/contactBulkOperations.create",
+		"/api/contactBulkOperations.cancel",
+		"/api/contactBulkOperations.results",
+	} {
+		_, pattern := mux.Handler(&http.Request{URL: &url.URL{Path: route}})
+		assert.NotEmpty(t, pattern, "Route %s should be registered", route)
+	}
+}
+
+func TestContactBulkOperationHandler_HandleCreate(t *testing.T) {
+	t.Run("starts the operation", func(t *testing.T) {
+		mockService, handler := setupContactBulkOperationHandlerTest(t)
+
+		mockService.EXPECT().
+			CreateContactBulkOperation(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, req *domain.CreateContactBulkOperationRequest) (*domain.CreateContactBulkOperationResponse, error) {
+				assert.Equal(t, []string{"john@example.com"}, req.Target.Emails)
+				assert.Equal(t, domain.ContactListStatusActive, req.Action.ListStatus)
+				return &domain.CreateContactBulkOperationResponse{
+					TargetCount: 1,
+					Operation:   &domain.ContactBulkOperation{ID: "op1", Status: domain.ContactBulkOperationStatusPending},
+				}, nil
+			})
+
+		body := `{"workspace_id":"ws1","target":{"type":"emails","emails":["John@Example.com"]},"action":{"type":"add_to_list","list_id":"news"}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/contactBulkOperations.create", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		handler.handleCreate(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response domain.CreateContactBulkOperationResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "op1", response.Operation.ID)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		mockService, handler := setupContactBulkOperationHandlerTest(t)
+
+		mockService.EXPECT().
+			CreateContactBulkOperation(gomock.Any(), gomock.Any()).
+			Return(&domain.CreateContactBulkOperationResponse{DryRun: true, TargetCount: 1200}, nil)
+
+		body := `{"workspace_id":"ws1","target":{"type":"segment","segment_id":"seg1"},"action":{"type":"delete"},"dry_run":true}`
+		req := httptest.NewRequest(http.MethodPost, "/api/contactBulkOperations.create", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		handler.handleCreate(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"target_count":1200`)
+	})
+
+	t.Run("invalid action", func(t *testing.T) {
+		_, handler := setupContactBulkOperationHandlerTest(t)
+
+		body := `{"workspace_id":"ws1","target":{"type":"segment","segment_id":"seg1"},"action":{"type":"archive"}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/contactBulkOperations.create", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		handler.handleCreate(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		mockService, handler := setupContactBulkOperationHandlerTest(t)
+
+		mockService.EXPECT().
+			CreateContactBulkOperation(gomock.Any(), gomock.Any()).
+			Return(nil, domain.NewPermissionError(domain.PermissionResourceAutomations, domain.PermissionTypeWrite, "no access"))
+
+		body := `{"workspace_id":"ws1","target":{"type":"segment","segment_id":"seg1"},"action":{"type":"exit_automation","automation_id":"auto1"}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/contactBulkOperations.create", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		handler.handleCreate(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		_, handler := setupContactBulkOperationHandlerTest(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/contactBulkOperations.create", nil)
+		w := httptest.NewRecorder()
+		handler.handleCreate(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestContactBulkOperationHandler_HandleGet(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		mockService, handler := setupContactBulkOperationHandlerTest(t)
+
+		mockService.EXPECT().
+			GetContactBulkOperation(gomock.Any(), "ws1", "op1").
+			Return(&domain.ContactBulkOperation{ID: "op1", ProcessedCount: 500, TotalCount: 1000}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/contactBulkOperations.get?workspace_id=ws1&id=op1", nil)
+		w := httptest.NewRecorder()
+		handler.handleGet(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"processed_count":500`)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockService, handler := setupContactBulkOperationHandlerTest(t)
+
+		mockService.EXPECT().
+			GetContactBulkOperation(gomock.Any(), "ws1", "missing").
+			Return(nil, &domain.ErrContactBulkOperationNotFound{Message: "contact bulk operation not found"})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/contactBulkOperations.get?workspace_id=ws1&id=missing", nil)
+		w := httptest.NewRecorder()
+		handler.handleGet(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestContactBulkOperationHandler_HandleList(t *testing.T) {
+	mockService, handler := setupContactBulkOperationHandlerTest(t)
+
+	mockService.EXPECT().
+		ListContactBulkOperations(gomock.Any(), "ws1").
+		Return([]*domain.ContactBulkOperation{{ID: "op1"}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/contactBulkOperations.list?workspace_id=ws1", nil)
+	w := httptest.NewRecorder()
+	handler.handleList(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"operations"`)
+}
+
+func TestContactBulkOperationHandler_HandleCancel(t *testing.T) {
+	t.Run("cancelled", func(t *testing.T) {
+		mockService, handler := setupContactBulkOperationHandlerTest(t)
+
+		mockService.EXPECT().
+			CancelContactBulkOperation(gomock.Any(), "ws1", "op1").
+			Return(&domain.ContactBulkOperation{ID: "op1", Status: domain.ContactBulkOperationStatusCancelled}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/contactBulkOperations.cancel", bytes.NewBufferString(`{"workspace_id":"ws1","id":"op1"}`))
+		w := httptest.NewRecorder()
+		handler.handleCancel(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"cancelled"`)
+	})
+
+	t.Run("already completed", func(t *testing.T) {
+		mockService, handler := setupContactBulkOperationHandlerTest(t)
+
+		mockService.EXPECT().
+			CancelContactBulkOperation(gomock.Any(), "ws1", "op1").
+			Return(nil, domain.NewValidationError("the operation is already completed"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/contactBulkOperations.cancel", bytes.NewBufferString(`{"workspace_id":"ws1","id":"op1"}`))
+		w := httptest.NewRecorder()
+		handler.handleCancel(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestContactBulkOperationHandler_HandleResults(t *testing.T) {
+	t.Run("downloads the CSV", func(t *testing.T) {
+		mockService, handler := setupContactBulkOperationHandlerTest(t)
+
+		mockService.EXPECT().
+			GetContactBulkOperationResultFile(gomock.Any(), "ws1", "op1").
+			Return([]byte("email,status,error\njohn@example.com,succeeded,\n"), nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/contactBulkOperations.results?workspace_id=ws1&id=op1", nil)
+		w := httptest.NewRecorder()
+		handler.handleResults(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "bulk-operation-op1-results.csv")
+		assert.Contains(t, w.Body.String(), "john@example.com,succeeded")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mockService, handler := setupContactBulkOperationHandlerTest(t)
+
+		mockService.EXPECT().
+			GetContactBulkOperationResultFile(gomock.Any(), "ws1", "op1").
+			Return(nil, errors.New("db down"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/contactBulkOperations.results?workspace_id=ws1&id=op1", nil)
+		w := httptest.NewRecorder()
+		handler.handleResults(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
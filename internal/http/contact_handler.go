@@ -2,6 +2,7 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -39,6 +40,9 @@ func (h *ContactHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/api/contacts.delete", requireAuth(http.HandlerFunc(h.handleDelete)))
 	mux.Handle("/api/contacts.import", requireAuth(http.HandlerFunc(h.handleImport)))
 	mux.Handle("/api/contacts.upsert", requireAuth(http.HandlerFunc(h.handleUpsert)))
+	mux.Handle("/api/contacts.merge", requireAuth(http.HandlerFunc(h.handleMerge)))
+	mux.Handle("/api/contacts.changeEmail", requireAuth(http.HandlerFunc(h.handleChangeEmail)))
+	mux.Handle("/api/contacts.aliases", requireAuth(http.HandlerFunc(h.handleAliases)))
 }
 
 func (h *ContactHandler) handleList(w http.ResponseWriter, r *http.Request) {
@@ -230,7 +234,8 @@ func (h *ContactHandler) handleImport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result := h.service.BatchImportContacts(r.Context(), workspaceID, contacts, req.SubscribeToLists)
+	ctx := withConsentContext(r, domain.ConsentContext{Source: domain.ConsentSourceImport})
+	result := h.service.BatchImportContacts(ctx, workspaceID, contacts, req.SubscribeToLists)
 	if result.Error != "" {
 		h.logger.WithField("error", result.Error).Error("Failed to import contacts")
 		WriteJSONError(w, result.Error, http.StatusInternalServerError)
@@ -290,3 +295,105 @@ func (h *ContactHandler) handleUpsert(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, result)
 }
+
+func (h *ContactHandler) handleMerge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.MergeContactsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	contact, err := h.service.MergeContacts(r.Context(), &req)
+	if err != nil {
+		h.writeContactError(w, err, "Failed to merge contacts")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"contact": contact,
+	})
+}
+
+func (h *ContactHandler) handleChangeEmail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.ChangeContactEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	contact, err := h.service.ChangeContactEmail(r.Context(), &req)
+	if err != nil {
+		h.writeContactError(w, err, "Failed to change contact email")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"contact": contact,
+	})
+}
+
+func (h *ContactHandler) handleAliases(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	workspaceID := r.URL.Query().Get("workspace_id")
+	if workspaceID == "" {
+		WriteJSONError(w, "Missing workspace ID", http.StatusBadRequest)
+		return
+	}
+	email := r.URL.Query().Get("email")
+	if email == "" {
+		WriteJSONError(w, "Missing email", http.StatusBadRequest)
+		return
+	}
+
+	aliases, err := h.service.GetContactAliases(r.Context(), workspaceID, email)
+	if err != nil {
+		h.writeContactError(w, err, "Failed to get contact aliases")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"aliases": aliases,
+	})
+}
+
+// writeContactError maps the errors of the merge and email change endpoints to a status code
+func (h *ContactHandler) writeContactError(w http.ResponseWriter, err error, message string) {
+	var validationErr domain.ValidationError
+	switch {
+	case errors.Is(err, domain.ErrContactNotFound):
+		WriteJSONError(w, "Contact not found", http.StatusNotFound)
+	case errors.As(err, &validationErr):
+		WriteJSONError(w, validationErr.Error(), http.StatusBadRequest)
+	default:
+		if _, ok := err.(*domain.PermissionError); ok {
+			WriteJSONError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error(message)
+		WriteJSONError(w, message, http.StatusInternalServerError)
+	}
+}
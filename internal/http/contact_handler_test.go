@@ -58,6 +58,9 @@ func TestContactHandler_RegisterRoutes(t *testing.T) {
 		"/api/contacts.delete",
 		"/api/contacts.import",
 		"/api/contacts.upsert",
+		"/api/contacts.merge",
+		"/api/contacts.changeEmail",
+		"/api/contacts.aliases",
 	}
 
 	for _, endpoint := range endpoints {
@@ -902,3 +905,151 @@ func TestContactHandler_HandleUpsert(t *testing.T) {
 		})
 	}
 }
+
+func TestContactHandler_HandleMerge(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		setupMock      func(*mocks.MockContactService)
+		expectedStatus int
+	}{
+		{
+			name:   "success",
+			method: http.MethodPost,
+			body:   `{"workspace_id":"ws1","primary_email":"john@example.com","secondary_email":"john.doe@example.com"}`,
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().MergeContacts(gomock.Any(), &domain.MergeContactsRequest{
+					WorkspaceID:    "ws1",
+					PrimaryEmail:   "john@example.com",
+					SecondaryEmail: "john.doe@example.com",
+					Strategy:       domain.ContactMergeFillEmpty,
+				}).Return(&domain.Contact{Email: "john@example.com"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodGet,
+			setupMock:      func(m *mocks.MockContactService) {},
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "same contact",
+			method:         http.MethodPost,
+			body:           `{"workspace_id":"ws1","primary_email":"john@example.com","secondary_email":"john@example.com"}`,
+			setupMock:      func(m *mocks.MockContactService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "contact not found",
+			method: http.MethodPost,
+			body:   `{"workspace_id":"ws1","primary_email":"john@example.com","secondary_email":"john.doe@example.com"}`,
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().MergeContacts(gomock.Any(), gomock.Any()).Return(nil, domain.ErrContactNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "permission denied",
+			method: http.MethodPost,
+			body:   `{"workspace_id":"ws1","primary_email":"john@example.com","secondary_email":"john.doe@example.com"}`,
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().MergeContacts(gomock.Any(), gomock.Any()).
+					Return(nil, domain.NewPermissionError(domain.PermissionResourceContacts, domain.PermissionTypeWrite, "Insufficient permissions"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "service error",
+			method: http.MethodPost,
+			body:   `{"workspace_id":"ws1","primary_email":"john@example.com","secondary_email":"john.doe@example.com"}`,
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().MergeContacts(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService, _, handler := setupContactHandlerTest(t)
+			tt.setupMock(mockService)
+
+			req := httptest.NewRequest(tt.method, "/api/contacts.merge", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+			handler.handleMerge(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestContactHandler_HandleChangeEmail(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockService, _, handler := setupContactHandlerTest(t)
+
+		mockService.EXPECT().ChangeContactEmail(gomock.Any(), &domain.ChangeContactEmailRequest{
+			WorkspaceID: "ws1",
+			Email:       "old@example.com",
+			NewEmail:    "new@example.com",
+		}).Return(&domain.Contact{Email: "new@example.com"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/contacts.changeEmail",
+			bytes.NewBufferString(`{"workspace_id":"ws1","email":"old@example.com","new_email":"New@Example.com"}`))
+		rr := httptest.NewRecorder()
+		handler.handleChangeEmail(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "new@example.com", response["contact"].(map[string]interface{})["email"])
+	})
+
+	t.Run("new email already used", func(t *testing.T) {
+		mockService, _, handler := setupContactHandlerTest(t)
+
+		mockService.EXPECT().ChangeContactEmail(gomock.Any(), gomock.Any()).
+			Return(nil, domain.NewValidationError("another contact already uses this email, merge the contacts instead"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/contacts.changeEmail",
+			bytes.NewBufferString(`{"workspace_id":"ws1","email":"old@example.com","new_email":"new@example.com"}`))
+		rr := httptest.NewRecorder()
+		handler.handleChangeEmail(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "merge the contacts instead")
+	})
+
+	t.Run("missing new email", func(t *testing.T) {
+		_, _, handler := setupContactHandlerTest(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/contacts.changeEmail",
+			bytes.NewBufferString(`{"workspace_id":"ws1","email":"old@example.com"}`))
+		rr := httptest.NewRecorder()
+		handler.handleChangeEmail(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestContactHandler_HandleAliases(t *testing.T) {
+	mockService, _, handler := setupContactHandlerTest(t)
+
+	mockService.EXPECT().GetContactAliases(gomock.Any(), "ws1", "new@example.com").
+		Return([]*domain.ContactAlias{{Email: "old@example.com", ContactEmail: "new@example.com", Reason: domain.ContactAliasReasonEmailChange}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/contacts.aliases?workspace_id=ws1&email=new@example.com", nil)
+	rr := httptest.NewRecorder()
+	handler.handleAliases(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Len(t, response["aliases"], 1)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/contacts.aliases?workspace_id=ws1", nil)
+	rr = httptest.NewRecorder()
+	handler.handleAliases(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
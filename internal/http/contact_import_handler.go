@@ -0,0 +1,245 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/http/middleware"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// maxContactImportBodyBytes caps uploads, leaving room for the multipart envelope
+const maxContactImportBodyBytes = domain.MaxContactImportFileSize + 1<<20
+
+type ContactImportHandler struct {
+	service      domain.ContactImportService
+	logger       logger.Logger
+	getJWTSecret func() ([]byte, error)
+}
+
+func NewContactImportHandler(service domain.ContactImportService, getJWTSecret func() ([]byte, error), logger logger.Logger) *ContactImportHandler {
+	return &ContactImportHandler{
+		service:      service,
+		logger:       logger,
+		getJWTSecret: getJWTSecret,
+	}
+}
+
+func (h *ContactImportHandler) RegisterRoutes(mux *http.ServeMux) {
+	// Create auth middleware
+	authMiddleware := middleware.NewAuthMiddleware(h.getJWTSecret)
+	requireAuth := authMiddleware.RequireAuth()
+
+	// Register RPC-style endpoints with dot notation
+	mux.Handle("/api/contactImports.list", requireAuth(http.HandlerFunc(h.handleList)))
+	mux.Handle("/api/contactImports.get", requireAuth(http.HandlerFunc(h.handleGet)))
+	mux.Handle("/api/contactImports.create", requireAuth(http.HandlerFunc(h.handleCreate)))
+	mux.Handle("/api/contactImports.start", requireAuth(http.HandlerFunc(h.handleStart)))
+	mux.Handle("/api/contactImports.delete", requireAuth(http.HandlerFunc(h.handleDelete)))
+	mux.Handle("/api/contactImports.errors", requireAuth(http.HandlerFunc(h.handleErrors)))
+}
+
+// writeContactImportError maps service errors to HTTP responses
+func (h *ContactImportHandler) writeContactImportError(w http.ResponseWriter, err error, message string) {
+	var permErr *domain.PermissionError
+	if errors.As(err, &permErr) {
+		WriteJSONError(w, permErr.Message, http.StatusForbidden)
+		return
+	}
+	var validationErr domain.ValidationError
+	if errors.As(err, &validationErr) {
+		WriteJSONError(w, validationErr.Message, http.StatusBadRequest)
+		return
+	}
+	var notFoundErr *domain.ErrContactImportNotFound
+	if errors.As(err, &notFoundErr) {
+		WriteJSONError(w, "Contact import not found", http.StatusNotFound)
+		return
+	}
+	h.logger.WithField("error", err.Error()).Error(message)
+	WriteJSONError(w, message, http.StatusInternalServerError)
+}
+
+func (h *ContactImportHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.ListContactImportsRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	imports, err := h.service.ListContactImports(r.Context(), req.WorkspaceID)
+	if err != nil {
+		h.writeContactImportError(w, err, "Failed to list contact imports")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"contact_imports": imports,
+	})
+}
+
+func (h *ContactImportHandler) handleGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.GetContactImportRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	contactImport, err := h.service.GetContactImport(r.Context(), req.WorkspaceID, req.ID)
+	if err != nil {
+		h.writeContactImportError(w, err, "Failed to get contact import")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"contact_import": contactImport,
+	})
+}
+
+// handleCreate accepts either a multipart upload (field "file" with a workspace_id form
+// value) or a JSON body referencing a file of the workspace bucket with s3_key
+func (h *ContactImportHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxContactImportBodyBytes)
+
+	var req domain.CreateContactImportRequest
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				WriteJSONError(w, fmt.Sprintf("File exceeds the maximum size of %d MB", domain.MaxContactImportFileSize>>20), http.StatusRequestEntityTooLarge)
+				return
+			}
+			WriteJSONError(w, "A file is required in the \"file\" field", http.StatusBadRequest)
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		data, err := io.ReadAll(file)
+		if err != nil {
+			WriteJSONError(w, "Failed to read the uploaded file", http.StatusBadRequest)
+			return
+		}
+		req.WorkspaceID = r.FormValue("workspace_id")
+		req.FileName = header.Filename
+		req.FileData = data
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	contactImport, err := h.service.CreateContactImport(r.Context(), &req)
+	if err != nil {
+		h.writeContactImportError(w, err, "Failed to create contact import")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"contact_import": contactImport,
+	})
+}
+
+func (h *ContactImportHandler) handleStart(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.StartContactImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	contactImport, err := h.service.StartContactImport(r.Context(), &req)
+	if err != nil {
+		h.writeContactImportError(w, err, "Failed to start contact import")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"contact_import": contactImport,
+	})
+}
+
+func (h *ContactImportHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.DeleteContactImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteContactImport(r.Context(), req.WorkspaceID, req.ID); err != nil {
+		h.writeContactImportError(w, err, "Failed to delete contact import")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+	})
+}
+
+// handleErrors downloads the row errors of the last run as a CSV file
+func (h *ContactImportHandler) handleErrors(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.GetContactImportRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := h.service.GetContactImportErrorFile(r.Context(), req.WorkspaceID, req.ID)
+	if err != nil {
+		h.writeContactImportError(w, err, "Failed to get contact import errors")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"import-%s-errors.csv\"", req.ID))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,234 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupContactImportHandlerTest(t *testing.T) (*mocks.MockContactImportService, *ContactImportHandler) {
+	ctrl := gomock.NewController(t)
+
+	mockService := mocks.NewMockContactImportService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	jwtSecret := []byte("test-jwt-secret-key-for-testing-32bytes")
+	handler := NewContactImportHandler(mockService, func() ([]byte, error) { return jwtSecret, nil }, mockLogger)
+	return mockService, handler
+}
+
+func TestContactImportHandler_RegisterRoutes(t *testing.T) {
+	_, handler := setupContactImportHandlerTest(t)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	for _, route := range []string{
+		"/api/contactImports.list",
+		"/api/contactImports.get",
+		"/api/contactImports.create",
+		"/api/contactImports.start",
+		"/api/contactImports.delete",
+		"/api/contactImports.errors",
+	} {
+		_, pattern := mux.Handler(&http.Request{URL: &url.URL{Path: route}})
+		assert.NotEmpty(t, pattern, "Route %s should be registered", route)
+	}
+}
+
+func TestContactImportHandler_HandleCreate(t *testing.T) {
+	t.Run("multipart upload", func(t *testing.T) {
+		mockService, handler := setupContactImportHandlerTest(t)
+
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		require.NoError(t, writer.WriteField("workspace_id", "ws1"))
+		part, err := writer.CreateFormFile("file", "contacts.csv")
+		require.NoError(t, err)
+		_, _ = part.Write([]byte("email\njohn@example.com\n"))
+		require.NoError(t, writer.Close())
+
+		mockService.EXPECT().CreateContactImport(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, req *domain.CreateContactImportRequest) (*domain.ContactImport, error) {
+				assert.Equal(t, "ws1", req.WorkspaceID)
+				assert.Equal(t, "contacts.csv", req.FileName)
+				assert.Equal(t, "email\njohn@example.com\n", string(req.FileData))
+				return &domain.ContactImport{ID: "import1", FileName: "contacts.csv"}, nil
+			})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/contactImports.create", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		rr := httptest.NewRecorder()
+		handler.handleCreate(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "import1", response["contact_import"].(map[string]interface{})["id"])
+	})
+
+	t.Run("s3 key", func(t *testing.T) {
+		mockService, handler := setupContactImportHandlerTest(t)
+
+		mockService.EXPECT().CreateContactImport(gomock.Any(), gomock.Any()).
+			Return(&domain.ContactImport{ID: "import1"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/contactImports.create",
+			bytes.NewBufferString(`{"workspace_id":"ws1","s3_key":"imports/contacts.csv"}`))
+		rr := httptest.NewRecorder()
+		handler.handleCreate(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, handler := setupContactImportHandlerTest(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/contactImports.create", bytes.NewBufferString(`{"workspace_id":"ws1"}`))
+		rr := httptest.NewRecorder()
+		handler.handleCreate(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		mockService, handler := setupContactImportHandlerTest(t)
+
+		mockService.EXPECT().CreateContactImport(gomock.Any(), gomock.Any()).
+			Return(nil, domain.NewValidationError("file has no rows besides the header"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/contactImports.create",
+			bytes.NewBufferString(`{"workspace_id":"ws1","s3_key":"imports/contacts.csv"}`))
+		rr := httptest.NewRecorder()
+		handler.handleCreate(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "no rows")
+	})
+}
+
+func TestContactImportHandler_HandleStart(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*mocks.MockContactImportService)
+		expectedStatus int
+	}{
+		{
+			name: "success",
+			body: `{"workspace_id":"ws1","id":"import1","mapping":{"Email":"email"},"dry_run":true}`,
+			setupMock: func(m *mocks.MockContactImportService) {
+				m.EXPECT().StartContactImport(gomock.Any(), gomock.Any()).
+					Return(&domain.ContactImport{ID: "import1", Status: domain.ContactImportStatusValidating}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid request",
+			body:           `{"workspace_id":"ws1","id":"import1"}`,
+			setupMock:      func(m *mocks.MockContactImportService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			body: `{"workspace_id":"ws1","id":"import1","mapping":{"Email":"email"}}`,
+			setupMock: func(m *mocks.MockContactImportService) {
+				m.EXPECT().StartContactImport(gomock.Any(), gomock.Any()).
+					Return(nil, &domain.ErrContactImportNotFound{Message: "contact import not found"})
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "permission denied",
+			body: `{"workspace_id":"ws1","id":"import1","mapping":{"Email":"email"},"list_ids":["news"]}`,
+			setupMock: func(m *mocks.MockContactImportService) {
+				m.EXPECT().StartContactImport(gomock.Any(), gomock.Any()).
+					Return(nil, domain.NewPermissionError(domain.PermissionResourceLists, domain.PermissionTypeWrite, "Insufficient permissions"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "service error",
+			body: `{"workspace_id":"ws1","id":"import1","mapping":{"Email":"email"}}`,
+			setupMock: func(m *mocks.MockContactImportService) {
+				m.EXPECT().StartContactImport(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService, handler := setupContactImportHandlerTest(t)
+			tt.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/contactImports.start", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+			handler.handleStart(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestContactImportHandler_HandleList(t *testing.T) {
+	mockService, handler := setupContactImportHandlerTest(t)
+
+	mockService.EXPECT().ListContactImports(gomock.Any(), "ws1").
+		Return([]*domain.ContactImport{{ID: "import1"}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/contactImports.list?workspace_id=ws1", nil)
+	rr := httptest.NewRecorder()
+	handler.handleList(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	assert.Len(t, response["contact_imports"], 1)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/contactImports.list?workspace_id=ws1", nil)
+	rr = httptest.NewRecorder()
+	handler.handleList(rr, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
+
+func TestContactImportHandler_HandleErrors(t *testing.T) {
+	mockService, handler := setupContactImportHandlerTest(t)
+
+	mockService.EXPECT().GetContactImportErrorFile(gomock.Any(), "ws1", "import1").
+		Return([]byte("row,email,error\n3,john@,invalid email format\n"), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/contactImports.errors?workspace_id=ws1&id=import1", nil)
+	rr := httptest.NewRecorder()
+	handler.handleErrors(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "import-import1-errors.csv")
+	assert.Equal(t, "row,email,error\n3,john@,invalid email format\n", rr.Body.String())
+}
+
+func TestContactImportHandler_HandleDelete(t *testing.T) {
+	mockService, handler := setupContactImportHandlerTest(t)
+
+	mockService.EXPECT().DeleteContactImport(gomock.Any(), "ws1", "import1").Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/contactImports.delete", bytes.NewBufferString(`{"workspace_id":"ws1","id":"import1"}`))
+	rr := httptest.NewRecorder()
+	handler.handleDelete(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
@@ -35,6 +35,7 @@ func (h *ContactListHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/api/contactLists.getListsByContact", requireAuth(http.HandlerFunc(h.handleGetListsByContact)))
 	mux.Handle("/api/contactLists.updateStatus", requireAuth(http.HandlerFunc(h.handleUpdateStatus)))
 	mux.Handle("/api/contactLists.removeContact", requireAuth(http.HandlerFunc(h.handleRemoveContact)))
+	mux.Handle("/api/contactLists.consent", requireAuth(http.HandlerFunc(h.handleConsent)))
 }
 
 func (h *ContactListHandler) handleGetByIDs(w http.ResponseWriter, r *http.Request) {
@@ -136,7 +137,8 @@ func (h *ContactListHandler) handleUpdateStatus(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	result, err := h.service.UpdateContactListStatus(r.Context(), workspaceID, contactList.Email, contactList.ListID, contactList.Status)
+	ctx := withConsentContext(r, domain.ConsentContext{Source: domain.ConsentSourceAPI})
+	result, err := h.service.UpdateContactListStatus(ctx, workspaceID, contactList.Email, contactList.ListID, contactList.Status)
 	if err != nil {
 		if _, ok := err.(*domain.ErrContactListNotFound); ok {
 			WriteJSONError(w, err.Error(), http.StatusNotFound)
@@ -188,3 +190,30 @@ func (h *ContactListHandler) handleRemoveContact(w http.ResponseWriter, r *http.
 		"success": true,
 	})
 }
+
+func (h *ContactListHandler) handleConsent(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.ListConsentRecordsRequest
+	if err := req.FromQuery(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.ListConsentRecords(r.Context(), &req)
+	if err != nil {
+		var permErr *domain.PermissionError
+		if errors.As(err, &permErr) {
+			WriteJSONError(w, permErr.Message, http.StatusForbidden)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to list consent records")
+		WriteJSONError(w, "Failed to list consent records", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
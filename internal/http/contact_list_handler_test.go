@@ -71,6 +71,7 @@ func TestContactListHandler_RegisterRoutes(t *testing.T) {
 		"/api/contactLists.getListsByContact",
 		"/api/contactLists.updateStatus",
 		"/api/contactLists.removeContact",
+		"/api/contactLists.consent",
 	}
 
 	for _, endpoint := range endpoints {
@@ -491,3 +492,91 @@ func TestContactListHandler_HandleRemoveContact(t *testing.T) {
 		})
 	}
 }
+
+func TestContactListHandler_HandleConsent(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		queryParams    string
+		setupMock      func(*mocks.MockContactListService)
+		expectedStatus int
+	}{
+		{
+			name:        "Success",
+			method:      http.MethodGet,
+			queryParams: "workspace_id=workspace123&email=test@example.com&limit=10",
+			setupMock: func(m *mocks.MockContactListService) {
+				m.EXPECT().ListConsentRecords(gomock.Any(), &domain.ListConsentRecordsRequest{
+					WorkspaceID: "workspace123",
+					Email:       "test@example.com",
+					Limit:       10,
+				}).Return(&domain.ListConsentRecordsResponse{
+					Records: []*domain.ConsentRecord{
+						{ID: "rec1", Email: "test@example.com", ListID: "list123", Action: domain.ConsentActionSubscribed, Source: domain.ConsentSourceAPI},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Missing Email And List",
+			method:      http.MethodGet,
+			queryParams: "workspace_id=workspace123",
+			setupMock: func(m *mocks.MockContactListService) {
+				m.EXPECT().ListConsentRecords(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Method Not Allowed",
+			method:      http.MethodPost,
+			queryParams: "workspace_id=workspace123&email=test@example.com",
+			setupMock: func(m *mocks.MockContactListService) {
+				m.EXPECT().ListConsentRecords(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:        "Permission Denied",
+			method:      http.MethodGet,
+			queryParams: "workspace_id=workspace123&list_id=list123",
+			setupMock: func(m *mocks.MockContactListService) {
+				m.EXPECT().ListConsentRecords(gomock.Any(), gomock.Any()).Return(nil, domain.NewPermissionError(
+					domain.PermissionResourceContacts,
+					domain.PermissionTypeRead,
+					"Insufficient permissions: read access to contacts required",
+				))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:        "Service Error",
+			method:      http.MethodGet,
+			queryParams: "workspace_id=workspace123&list_id=list123",
+			setupMock: func(m *mocks.MockContactListService) {
+				m.EXPECT().ListConsentRecords(gomock.Any(), gomock.Any()).Return(nil, errors.New("service error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService, _, handler := setupContactListHandlerTest(t)
+			tt.setupMock(mockService)
+
+			req := httptest.NewRequest(tt.method, "/api/contactLists.consent?"+tt.queryParams, nil)
+			rr := httptest.NewRecorder()
+			handler.handleConsent(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var response map[string]interface{}
+				err := json.NewDecoder(rr.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Len(t, response["records"], 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/http/middleware"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+type EmailVerificationHandler struct {
+	service      domain.EmailVerificationService
+	logger       logger.Logger
+	getJWTSecret func() ([]byte, error)
+}
+
+func NewEmailVerificationHandler(service domain.EmailVerificationService, getJWTSecret func() ([]byte, error), logger logger.Logger) *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		service:      service,
+		logger:       logger,
+		getJWTSecret: getJWTSecret,
+	}
+}
+
+func (h *EmailVerificationHandler) RegisterRoutes(mux *http.ServeMux) {
+	// Create auth middleware
+	authMiddleware := middleware.NewAuthMiddleware(h.getJWTSecret)
+	requireAuth := authMiddleware.RequireAuth()
+
+	// Register RPC-style endpoints with dot notation
+	mux.Handle("/api/contacts.verifyEmail", requireAuth(http.HandlerFunc(h.handleVerifyEmail)))
+}
+
+func (h *EmailVerificationHandler) handleVerifyEmail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.VerifyContactEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	verification, err := h.service.VerifyEmail(r.Context(), req.WorkspaceID, req.Email)
+	if err != nil {
+		var permErr *domain.PermissionError
+		if errors.As(err, &permErr) {
+			WriteJSONError(w, permErr.Message, http.StatusForbidden)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to verify email")
+		WriteJSONError(w, "Failed to verify email", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"email_verification": verification,
+	})
+}
@@ -0,0 +1,118 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupEmailVerificationHandlerTest(t *testing.T) (*mocks.MockEmailVerificationService, *EmailVerificationHandler) {
+	ctrl := gomock.NewController(t)
+
+	mockService := mocks.NewMockEmailVerificationService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	jwtSecret := []byte("test-jwt-secret-key-for-testing-32bytes")
+	handler := NewEmailVerificationHandler(mockService, func() ([]byte, error) { return jwtSecret, nil }, mockLogger)
+	return mockService, handler
+}
+
+func TestEmailVerificationHandler_RegisterRoutes(t *testing.T) {
+	_, handler := setupEmailVerificationHandlerTest(t)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	_, pattern := mux.Handler(&http.Request{URL: &url.URL{Path: "/api/contacts.verifyEmail"}})
+	assert.NotEmpty(t, pattern)
+}
+
+func TestEmailVerificationHandler_HandleVerifyEmail(t *testing.T) {
+	verification := &domain.EmailVerification{
+		Status:     domain.EmailVerificationStatusRisky,
+		Reasons:    []string{"possible_typo"},
+		Suggestion: "john@gmail.com",
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		body           interface{}
+		setupMock      func(*mocks.MockEmailVerificationService)
+		expectedStatus int
+	}{
+		{
+			name:   "success",
+			method: http.MethodPost,
+			body:   domain.VerifyContactEmailRequest{WorkspaceID: "ws1", Email: "john@gmial.com"},
+			setupMock: func(m *mocks.MockEmailVerificationService) {
+				m.EXPECT().VerifyEmail(gomock.Any(), "ws1", "john@gmial.com").Return(verification, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "method not allowed",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "missing email",
+			method:         http.MethodPost,
+			body:           domain.VerifyContactEmailRequest{WorkspaceID: "ws1"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "insufficient permissions",
+			method: http.MethodPost,
+			body:   domain.VerifyContactEmailRequest{WorkspaceID: "ws1", Email: "john@gmial.com"},
+			setupMock: func(m *mocks.MockEmailVerificationService) {
+				m.EXPECT().VerifyEmail(gomock.Any(), "ws1", "john@gmial.com").
+					Return(nil, domain.NewPermissionError(domain.PermissionResourceContacts, domain.PermissionTypeWrite, "write access to contacts required"))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "service error",
+			method: http.MethodPost,
+			body:   domain.VerifyContactEmailRequest{WorkspaceID: "ws1", Email: "john@gmial.com"},
+			setupMock: func(m *mocks.MockEmailVerificationService) {
+				m.EXPECT().VerifyEmail(gomock.Any(), "ws1", "john@gmial.com").Return(nil, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService, handler := setupEmailVerificationHandlerTest(t)
+			if tt.setupMock != nil {
+				tt.setupMock(mockService)
+			}
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(tt.method, "/api/contacts.verifyEmail", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			handler.handleVerifyEmail(w, req)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var response map[string]domain.EmailVerification
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+				assert.Equal(t, "john@gmail.com", response["email_verification"].Suggestion)
+			}
+		})
+	}
+}
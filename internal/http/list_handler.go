@@ -2,6 +2,7 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -240,8 +241,14 @@ func (h *ListHandler) handleSubscribe(w http.ResponseWriter, r *http.Request) {
 	}
 
 	hasBearerToken := true
-	if err := h.service.SubscribeToLists(r.Context(), &req, hasBearerToken); err != nil {
+	ctx := withConsentContext(r, domain.ConsentContext{Source: domain.ConsentSourceAPI})
+	if err := h.service.SubscribeToLists(ctx, &req, hasBearerToken); err != nil {
 		h.logger.WithField("error", err.Error()).Error("Failed to subscribe to lists")
+		var blocked *domain.ErrEmailVerificationBlocked
+		if errors.As(err, &blocked) {
+			WriteJSONError(w, blocked.Error(), http.StatusBadRequest)
+			return
+		}
 		WriteJSONError(w, "Failed to subscribe to lists", http.StatusInternalServerError)
 		return
 	}
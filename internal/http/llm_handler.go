@@ -2,8 +2,10 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/http/middleware"
@@ -36,6 +38,14 @@ func (h *LLMHandler) RegisterRoutes(mux *http.ServeMux) {
 	requireAuth := authMiddleware.RequireAuth()
 
 	mux.Handle("/api/llm.chat", requireAuth(http.HandlerFunc(h.handleChat)))
+	mux.Handle("/api/llm.generateSubjectLines", requireAuth(http.HandlerFunc(h.handleGenerateSubjectLines)))
+	mux.Handle("/api/llm.createSubjectVariations", requireAuth(http.HandlerFunc(h.handleCreateSubjectVariations)))
+	mux.Handle("/api/llm.rewriteBlocks", requireAuth(http.HandlerFunc(h.handleRewriteBlocks)))
+	mux.Handle("/api/llm.confirmToolCall", requireAuth(http.HandlerFunc(h.handleConfirmToolCall)))
+	mux.Handle("/api/llm.toolCalls", requireAuth(http.HandlerFunc(h.handleListToolCalls)))
+	mux.Handle("/api/llm.ollamaModels", requireAuth(http.HandlerFunc(h.handleListOllamaModels)))
+	// Served here rather than by the template handler, as translating requires an LLM integration
+	mux.Handle("/api/templates.translate", requireAuth(http.HandlerFunc(h.handleTranslateTemplates)))
 }
 
 // handleChat handles the streaming chat endpoint
@@ -133,3 +143,193 @@ func (h *LLMHandler) handleChat(w http.ResponseWriter, r *http.Request) {
 		writeEvent(ev)
 	}
 }
+
+// handleGenerateSubjectLines generates subject line candidates for a template
+func (h *LLMHandler) handleGenerateSubjectLines(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.GenerateSubjectLinesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.GenerateSubjectLines(r.Context(), &req)
+	if err != nil {
+		h.writeLLMError(w, err, "Failed to generate subject lines")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleCreateSubjectVariations adds subject line candidates to the A/B test of a broadcast
+func (h *LLMHandler) handleCreateSubjectVariations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.CreateSubjectVariationsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	broadcast, err := h.service.CreateSubjectVariations(r.Context(), &req)
+	if err != nil {
+		h.writeLLMError(w, err, "Failed to create subject variations")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"broadcast": broadcast,
+	})
+}
+
+// handleRewriteBlocks rewrites the text blocks of a visual editor tree
+func (h *LLMHandler) handleRewriteBlocks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.RewriteBlocksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.RewriteBlocks(r.Context(), &req)
+	if err != nil {
+		h.writeLLMError(w, err, "Failed to rewrite blocks")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleTranslateTemplates translates templates into the languages of the workspace
+func (h *LLMHandler) handleTranslateTemplates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.TranslateTemplatesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.TranslateTemplates(r.Context(), &req)
+	if err != nil {
+		h.writeLLMError(w, err, "Failed to translate templates")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleConfirmToolCall executes or declines a workspace tool call proposed by the assistant
+func (h *LLMHandler) handleConfirmToolCall(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.ConfirmLLMToolCallRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.ConfirmToolCall(r.Context(), &req)
+	if err != nil {
+		h.writeLLMError(w, err, "Failed to confirm tool call")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleListToolCalls returns the audit trail of the workspace tools called by the assistant
+func (h *LLMHandler) handleListToolCalls(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	workspaceID := r.URL.Query().Get("workspace_id")
+	if workspaceID == "" {
+		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
+		return
+	}
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			WriteJSONError(w, "limit must be a number", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	calls, err := h.service.ListToolCalls(r.Context(), workspaceID, limit)
+	if err != nil {
+		h.writeLLMError(w, err, "Failed to list tool calls")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"tool_calls": calls,
+	})
+}
+
+// handleListOllamaModels lists the models of an Ollama server for the integration form
+func (h *LLMHandler) handleListOllamaModels(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.ListOllamaModelsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.ListOllamaModels(r.Context(), &req)
+	if err != nil {
+		h.writeLLMError(w, err, "Failed to list models")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// writeLLMError maps the errors of the copy endpoints to a status code. Like the chat
+// stream, unexpected errors are returned to the client, as they usually come from the
+// LLM provider (invalid API key, unparsable reply, rejected rewrite).
+func (h *LLMHandler) writeLLMError(w http.ResponseWriter, err error, message string) {
+	var validationErr domain.ValidationError
+	var templateNotFound *domain.ErrTemplateNotFound
+	var broadcastNotFound *domain.ErrBroadcastNotFound
+	var notFound *domain.ErrNotFound
+	var permissionErr *domain.PermissionError
+	var unauthorized *domain.ErrUnauthorized
+	switch {
+	case errors.As(err, &validationErr):
+		WriteJSONError(w, validationErr.Error(), http.StatusBadRequest)
+	case errors.As(err, &permissionErr), errors.As(err, &unauthorized):
+		WriteJSONError(w, err.Error(), http.StatusForbidden)
+	case errors.As(err, &templateNotFound), errors.As(err, &broadcastNotFound), errors.As(err, &notFound):
+		WriteJSONError(w, err.Error(), http.StatusNotFound)
+	default:
+		h.logger.WithField("error", err.Error()).Error(message)
+		WriteJSONError(w, fmt.Sprintf("%s: %s", message, err.Error()), http.StatusInternalServerError)
+	}
+}
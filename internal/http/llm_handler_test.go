@@ -266,3 +266,274 @@ func TestNewLLMHandler(t *testing.T) {
 	assert.Equal(t, mockLogger, handler.logger)
 	assert.NotNil(t, handler.getJWTSecret)
 }
+
+func TestLLMHandler_HandleGenerateSubjectLines(t *testing.T) {
+	t.Run("returns the candidates", func(t *testing.T) {
+		handler, mockService := setupLLMHandlerTest(t)
+		mockService.EXPECT().
+			GenerateSubjectLines(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, req *domain.GenerateSubjectLinesRequest) (*domain.GenerateSubjectLinesResponse, error) {
+				assert.Equal(t, "newsletter", req.TemplateID)
+				return &domain.GenerateSubjectLinesResponse{
+					Candidates: []domain.SubjectLineCandidate{{Subject: "Your May update"}},
+				}, nil
+			})
+
+		body := `{"workspace_id":"ws1","integration_id":"llm1","template_id":"newsletter"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/llm.generateSubjectLines", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.handleGenerateSubjectLines(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response domain.GenerateSubjectLinesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "Your May update", response.Candidates[0].Subject)
+	})
+
+	t.Run("maps validation errors to 400", func(t *testing.T) {
+		handler, mockService := setupLLMHandlerTest(t)
+		mockService.EXPECT().
+			GenerateSubjectLines(gomock.Any(), gomock.Any()).
+			Return(nil, domain.NewValidationError("count must be between 1 and 8"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/llm.generateSubjectLines", strings.NewReader(`{"count":9}`))
+		w := httptest.NewRecorder()
+
+		handler.handleGenerateSubjectLines(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("rejects GET", func(t *testing.T) {
+		handler, _ := setupLLMHandlerTest(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/llm.generateSubjectLines", nil)
+		w := httptest.NewRecorder()
+
+		handler.handleGenerateSubjectLines(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestLLMHandler_HandleCreateSubjectVariations(t *testing.T) {
+	t.Run("returns the updated broadcast", func(t *testing.T) {
+		handler, mockService := setupLLMHandlerTest(t)
+		mockService.EXPECT().
+			CreateSubjectVariations(gomock.Any(), gomock.Any()).
+			Return(&domain.Broadcast{ID: "b1"}, nil)
+
+		body := `{"workspace_id":"ws1","broadcast_id":"b1","candidates":[{"subject":"Hello"}]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/llm.createSubjectVariations", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.handleCreateSubjectVariations(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"id":"b1"`)
+	})
+
+	t.Run("maps permission errors to 403", func(t *testing.T) {
+		handler, mockService := setupLLMHandlerTest(t)
+		mockService.EXPECT().
+			CreateSubjectVariations(gomock.Any(), gomock.Any()).
+			Return(nil, domain.NewPermissionError(domain.PermissionResourceBroadcasts, domain.PermissionTypeWrite, "denied"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/llm.createSubjectVariations", strings.NewReader(`{}`))
+		w := httptest.NewRecorder()
+
+		handler.handleCreateSubjectVariations(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("maps a missing broadcast to 404", func(t *testing.T) {
+		handler, mockService := setupLLMHandlerTest(t)
+		mockService.EXPECT().
+			CreateSubjectVariations(gomock.Any(), gomock.Any()).
+			Return(nil, &domain.ErrBroadcastNotFound{ID: "b1"})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/llm.createSubjectVariations", strings.NewReader(`{}`))
+		w := httptest.NewRecorder()
+
+		handler.handleCreateSubjectVariations(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestLLMHandler_HandleRewriteBlocks(t *testing.T) {
+	t.Run("returns the error of the LLM", func(t *testing.T) {
+		handler, mockService := setupLLMHandlerTest(t)
+		mockService.EXPECT().
+			RewriteBlocks(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("rejected the rewrite of block intro: liquid tags were not preserved"))
+
+		body := `{"workspace_id":"ws1","integration_id":"llm1","visual_editor_tree":{"id":"root","type":"mjml"},"tone":"casual"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/llm.rewriteBlocks", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.handleRewriteBlocks(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "liquid tags were not preserved")
+	})
+
+	t.Run("rejects an invalid body", func(t *testing.T) {
+		handler, _ := setupLLMHandlerTest(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/llm.rewriteBlocks", strings.NewReader("invalid json"))
+		w := httptest.NewRecorder()
+
+		handler.handleRewriteBlocks(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestLLMHandler_HandleTranslateTemplates(t *testing.T) {
+	t.Run("returns the results", func(t *testing.T) {
+		handler, mockService := setupLLMHandlerTest(t)
+		mockService.EXPECT().
+			TranslateTemplates(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, req *domain.TranslateTemplatesRequest) (*domain.TranslateTemplatesResponse, error) {
+				assert.Equal(t, []string{"fr"}, req.Languages)
+				return &domain.TranslateTemplatesResponse{
+					Results: []domain.TemplateTranslationResult{{TemplateID: "welcome", Language: "fr"}},
+				}, nil
+			})
+
+		body := `{"workspace_id":"ws1","integration_id":"llm1","template_id":"welcome","languages":["fr"]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/templates.translate", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.handleTranslateTemplates(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"template_id":"welcome"`)
+	})
+
+	t.Run("maps a missing template to 404", func(t *testing.T) {
+		handler, mockService := setupLLMHandlerTest(t)
+		mockService.EXPECT().
+			TranslateTemplates(gomock.Any(), gomock.Any()).
+			Return(nil, &domain.ErrTemplateNotFound{Message: "template not found"})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/templates.translate", strings.NewReader(`{}`))
+		w := httptest.NewRecorder()
+
+		handler.handleTranslateTemplates(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestLLMHandler_HandleConfirmToolCall(t *testing.T) {
+	t.Run("returns the result of the tool", func(t *testing.T) {
+		handler, mockService := setupLLMHandlerTest(t)
+		mockService.EXPECT().
+			ConfirmToolCall(gomock.Any(), &domain.ConfirmLLMToolCallRequest{WorkspaceID: "ws1", ToolCallID: "call1", Approve: true}).
+			Return(&domain.ConfirmLLMToolCallResponse{
+				ToolCall: &domain.LLMToolCall{ID: "call1", Status: domain.LLMToolCallStatusSucceeded},
+				Result:   `{"segment_id":"seg1"}`,
+			}, nil)
+
+		body := `{"workspace_id":"ws1","tool_call_id":"call1","approve":true}`
+		req := httptest.NewRequest(http.MethodPost, "/api/llm.confirmToolCall", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.handleConfirmToolCall(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"succeeded"`)
+	})
+
+	t.Run("maps a missing tool call to 404", func(t *testing.T) {
+		handler, mockService := setupLLMHandlerTest(t)
+		mockService.EXPECT().
+			ConfirmToolCall(gomock.Any(), gomock.Any()).
+			Return(nil, &domain.ErrNotFound{Entity: "tool call", ID: "call1"})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/llm.confirmToolCall", strings.NewReader(`{}`))
+		w := httptest.NewRecorder()
+
+		handler.handleConfirmToolCall(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestLLMHandler_HandleListToolCalls(t *testing.T) {
+	t.Run("returns the tool calls", func(t *testing.T) {
+		handler, mockService := setupLLMHandlerTest(t)
+		mockService.EXPECT().
+			ListToolCalls(gomock.Any(), "ws1", 20).
+			Return([]*domain.LLMToolCall{{ID: "call1", ToolName: "query_analytics"}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/llm.toolCalls?workspace_id=ws1&limit=20", nil)
+		w := httptest.NewRecorder()
+
+		handler.handleListToolCalls(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"tool_name":"query_analytics"`)
+	})
+
+	t.Run("requires the workspace", func(t *testing.T) {
+		handler, _ := setupLLMHandlerTest(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/llm.toolCalls", nil)
+		w := httptest.NewRecorder()
+
+		handler.handleListToolCalls(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestLLMHandler_HandleListOllamaModels(t *testing.T) {
+	t.Run("returns the models of the server", func(t *testing.T) {
+		handler, mockService := setupLLMHandlerTest(t)
+		mockService.EXPECT().
+			ListOllamaModels(gomock.Any(), &domain.ListOllamaModelsRequest{
+				WorkspaceID: "ws1",
+				Ollama:      &domain.OllamaSettings{BaseURL: "http://localhost:11434"},
+			}).
+			Return(&domain.ListOllamaModelsResponse{Models: []domain.OllamaModel{{Name: "llama3.1:8b"}}}, nil)
+
+		body := `{"workspace_id":"ws1","ollama":{"base_url":"http://localhost:11434"}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/llm.ollamaModels", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.handleListOllamaModels(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"name":"llama3.1:8b"`)
+	})
+
+	t.Run("maps a non owner to 403", func(t *testing.T) {
+		handler, mockService := setupLLMHandlerTest(t)
+		mockService.EXPECT().
+			ListOllamaModels(gomock.Any(), gomock.Any()).
+			Return(nil, &domain.ErrUnauthorized{Message: "user is not an owner of the workspace"})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/llm.ollamaModels", strings.NewReader(`{"workspace_id":"ws1","integration_id":"llm1"}`))
+		w := httptest.NewRecorder()
+
+		handler.handleListOllamaModels(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("rejects other methods", func(t *testing.T) {
+		handler, _ := setupLLMHandlerTest(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/llm.ollamaModels", nil)
+		w := httptest.NewRecorder()
+
+		handler.handleListOllamaModels(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -139,6 +140,11 @@ func (h *NotificationCenterHandler) handleUpdatePreferences(w http.ResponseWrite
 			WriteJSONError(w, "Unauthorized: invalid verification", http.StatusUnauthorized)
 			return
 		}
+		var validationErr domain.ValidationError
+		if errors.As(err, &validationErr) {
+			WriteJSONError(w, validationErr.Message, http.StatusBadRequest)
+			return
+		}
 		h.logger.WithField("error", err.Error()).Error("Failed to update contact preferences")
 		WriteJSONError(w, "Failed to update contact preferences", http.StatusInternalServerError)
 		return
@@ -189,7 +195,8 @@ func (h *NotificationCenterHandler) handleSubscribe(w http.ResponseWriter, r *ht
 
 	fromAPI := false
 
-	if err := h.listService.SubscribeToLists(r.Context(), &req, fromAPI); err != nil {
+	ctx := withConsentContext(r, domain.ConsentContext{Source: domain.ConsentSourceForm})
+	if err := h.listService.SubscribeToLists(ctx, &req, fromAPI); err != nil {
 		h.logger.WithField("error", err.Error()).Error("Failed to subscribe to lists")
 
 		// Return specific error for non-public lists (matches OpenAPI spec)
@@ -198,6 +205,12 @@ func (h *NotificationCenterHandler) handleSubscribe(w http.ResponseWriter, r *ht
 			return
 		}
 
+		var blocked *domain.ErrEmailVerificationBlocked
+		if errors.As(err, &blocked) {
+			WriteJSONError(w, blocked.Error(), http.StatusBadRequest)
+			return
+		}
+
 		WriteJSONError(w, "Failed to subscribe to lists", http.StatusInternalServerError)
 		return
 	}
@@ -231,7 +244,8 @@ func (h *NotificationCenterHandler) handleUnsubscribe(w http.ResponseWriter, r *
 		return
 	}
 
-	if err := h.listService.UnsubscribeFromLists(r.Context(), &req, false); err != nil {
+	ctx := withConsentContext(r, domain.ConsentContext{Source: domain.ConsentSourceNotificationCenter})
+	if err := h.listService.UnsubscribeFromLists(ctx, &req, false); err != nil {
 		h.logger.WithField("error", err.Error()).Error("Failed to unsubscribe from lists")
 		WriteJSONError(w, "Failed to unsubscribe from lists", http.StatusInternalServerError)
 		return
@@ -263,7 +277,9 @@ func (h *NotificationCenterHandler) handleUnsubscribeOneClick(w http.ResponseWri
 	//      (handleUnsubscribe), but already-cached widget bundles may still hit this
 	//      path. New code should use /unsubscribe.
 	var req domain.UnsubscribeFromListsRequest
+	consentSource := domain.ConsentSourceOneClick
 	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		consentSource = domain.ConsentSourceNotificationCenter
 		// Backward-compat shim for the SPA (see above). A link-prefetcher/scanner cannot
 		// reach here: it does not run the widget's JS and so never builds this JSON body,
 		// so the RFC 8058 token gate is unnecessary for this shape - and email_hmac still
@@ -301,7 +317,8 @@ func (h *NotificationCenterHandler) handleUnsubscribeOneClick(w http.ResponseWri
 
 	fromBearerToken := false
 
-	if err := h.listService.UnsubscribeFromLists(r.Context(), &req, fromBearerToken); err != nil {
+	ctx := withConsentContext(r, domain.ConsentContext{Source: consentSource})
+	if err := h.listService.UnsubscribeFromLists(ctx, &req, fromBearerToken); err != nil {
 		h.logger.WithField("error", err.Error()).Error("Failed to unsubscribe from lists")
 		WriteJSONError(w, "Failed to unsubscribe from lists", http.StatusInternalServerError)
 		return
@@ -663,3 +680,14 @@ func getClientIP(r *http.Request) string {
 	}
 	return ip
 }
+
+// withConsentContext returns the request context carrying the client details recorded on
+// consent ledger entries. The Referer is used as the page URL unless one is given.
+func withConsentContext(r *http.Request, consent domain.ConsentContext) context.Context {
+	consent.IPAddress = getClientIP(r)
+	consent.UserAgent = r.UserAgent()
+	if consent.PageURL == "" {
+		consent.PageURL = r.Referer()
+	}
+	return domain.WithConsentContext(r.Context(), consent)
+}
@@ -250,6 +250,21 @@ func TestNotificationCenterHandler_handleSubscribe(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse:   `{"error":"list is not public"}`,
 		},
+		{
+			name:        "service rejects the email address",
+			method:      http.MethodPost,
+			requestBody: validRequest,
+			setupMock: func() {
+				mockListService.EXPECT().
+					SubscribeToLists(gomock.Any(), gomock.Any(), false).
+					Return(&domain.ErrEmailVerificationBlocked{
+						Email:        "test@example.com",
+						Verification: &domain.EmailVerification{Status: domain.EmailVerificationStatusInvalid},
+					})
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"email address test@example.com failed verification (invalid)"}`,
+		},
 		{
 			name:        "successful request",
 			method:      http.MethodPost,
@@ -637,7 +652,7 @@ func TestNotificationCenterHandler_handleUpdatePreferences(t *testing.T) {
 			},
 			setupMock:          func() {},
 			expectedStatusCode: http.StatusBadRequest,
-			expectedResponse:   `{"error":"at least one of language or timezone must be provided"}`,
+			expectedResponse:   `{"error":"at least one of language, timezone, pause_days or list_frequencies must be provided"}`,
 		},
 		{
 			name: "service returns HMAC error",
@@ -655,6 +670,22 @@ func TestNotificationCenterHandler_handleUpdatePreferences(t *testing.T) {
 			expectedStatusCode: http.StatusUnauthorized,
 			expectedResponse:   `{"error":"Unauthorized: invalid verification"}`,
 		},
+		{
+			name: "service returns validation error",
+			requestBody: domain.UpdateContactPreferencesRequest{
+				WorkspaceID:     "ws123",
+				Email:           "test@example.com",
+				EmailHMAC:       "valid",
+				ListFrequencies: map[string]domain.ContactListFrequency{"internal": domain.ContactListFrequencyWeeklyDigest},
+			},
+			setupMock: func() {
+				mockService.EXPECT().
+					UpdateContactPreferences(gomock.Any(), gomock.Any()).
+					Return(domain.NewValidationError("list internal is not available in the notification center"))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"error":"list internal is not available in the notification center"}`,
+		},
 		{
 			name: "service returns other error",
 			requestBody: domain.UpdateContactPreferencesRequest{
@@ -4,17 +4,21 @@ import (
 	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/blogfeed"
 	"github.com/Notifuse/notifuse/pkg/cache"
 	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/Notifuse/notifuse/pkg/ratelimiter"
 )
 
 type RootHandler struct {
@@ -34,6 +38,8 @@ type RootHandler struct {
 	cache                 cache.Cache
 	oidcEnabled           bool
 	oidcButtonLabel       string
+	blogCommentService    domain.BlogCommentService
+	rateLimiter           *ratelimiter.RateLimiter
 }
 
 // NewRootHandler creates a root handler that serves both console and notification center static files
@@ -54,6 +60,8 @@ func NewRootHandler(
 	cache cache.Cache,
 	oidcEnabled bool,
 	oidcButtonLabel string,
+	blogCommentService domain.BlogCommentService,
+	rateLimiter *ratelimiter.RateLimiter,
 ) *RootHandler {
 	return &RootHandler{
 		consoleDir:            consoleDir,
@@ -72,6 +80,8 @@ func NewRootHandler(
 		cache:                 cache,
 		oidcEnabled:           oidcEnabled,
 		oidcButtonLabel:       oidcButtonLabel,
+		blogCommentService:    blogCommentService,
+		rateLimiter:           rateLimiter,
 	}
 }
 
@@ -246,19 +256,57 @@ func (h *RootHandler) serveBlog(w http.ResponseWriter, r *http.Request, workspac
 	case "/sitemap.xml":
 		h.serveBlogSitemap(w, r, workspace)
 		return
+	case "/search":
+		h.serveBlogSearch(w, r, workspace)
+		return
+	case "/search.json":
+		h.serveBlogSearchJSON(w, r, workspace)
+		return
+	case "/comments.json":
+		h.serveBlogComments(w, r, workspace)
+		return
+	case "/comments/verify":
+		h.serveBlogCommentVerification(w, r, workspace)
+		return
+	case "/reactions.json":
+		h.serveBlogReactions(w, r, workspace)
+		return
+	case "/access.json":
+		h.serveBlogAccessRequest(w, r, workspace)
+		return
+	case "/access":
+		h.serveBlogAccess(w, r, workspace)
+		return
+	}
+
+	// Subscribers holding a valid access cookie may read the gated posts
+	if viewer := blogViewerFromRequest(r, workspace); viewer != nil {
+		r = r.WithContext(domain.WithBlogViewer(r.Context(), viewer))
+	}
+
+	// Pages translated in the other languages of the workspace are served under /{language}
+	language, path := splitBlogLanguage(workspace, r.URL.Path)
+
+	switch path {
 	case "/feed.xml":
-		h.serveBlogFeed(w, r, workspace, nil, feedFormatRSS)
+		h.serveBlogFeed(w, r, workspace, language, nil, feedFormatRSS)
 		return
 	case "/feed.json":
-		h.serveBlogFeed(w, r, workspace, nil, feedFormatJSON)
+		h.serveBlogFeed(w, r, workspace, language, nil, feedFormatJSON)
 		return
 	case "/":
-		h.serveBlogHome(w, r, workspace)
+		h.serveBlogHome(w, r, workspace, language)
 		return
 	}
 
 	// Try to parse URL parts
-	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	// Handle /_preview/{token} - draft revision preview (slugs cannot start with "_")
+	if language == "" && len(parts) == 2 && parts[0] == "_preview" && parts[1] != "" {
+		h.serveBlogPostPreview(w, r, workspace, parts[1])
+		return
+	}
 
 	// Handle /{category-slug}/feed.xml or /{category-slug}/feed.json
 	if len(parts) == 2 && (parts[1] == "feed.xml" || parts[1] == "feed.json") {
@@ -267,7 +315,7 @@ func (h *RootHandler) serveBlog(w http.ResponseWriter, r *http.Request, workspac
 		if parts[1] == "feed.json" {
 			format = feedFormatJSON
 		}
-		h.serveBlogFeed(w, r, workspace, &slug, format)
+		h.serveBlogFeed(w, r, workspace, language, &slug, format)
 		return
 	}
 
@@ -275,9 +323,9 @@ func (h *RootHandler) serveBlog(w http.ResponseWriter, r *http.Request, workspac
 	if len(parts) == 1 && parts[0] != "" {
 		categorySlug := parts[0]
 		// Try to get the category (public access, no authentication required)
-		category, err := h.blogService.GetPublicCategoryBySlug(ctx, categorySlug)
+		category, err := h.blogService.GetPublicCategoryBySlug(ctx, language, categorySlug)
 		if err == nil && category != nil {
-			h.serveBlogCategory(w, r, workspace, categorySlug)
+			h.serveBlogCategory(w, r, workspace, language, categorySlug)
 			return
 		}
 	}
@@ -288,7 +336,7 @@ func (h *RootHandler) serveBlog(w http.ResponseWriter, r *http.Request, workspac
 		postSlug := parts[1]
 
 		// Try to get the post (public access, no authentication required)
-		post, err := h.blogService.GetPublicPostByCategoryAndSlug(ctx, categorySlug, postSlug)
+		post, err := h.blogService.GetPublicPostByCategoryAndSlug(ctx, language, categorySlug, postSlug)
 		if err == nil && post != nil {
 			h.serveBlogPost(w, r, workspace, post)
 			return
@@ -299,9 +347,20 @@ func (h *RootHandler) serveBlog(w http.ResponseWriter, r *http.Request, workspac
 	h.serveBlog404(w, r)
 }
 
+// splitBlogLanguage splits the language prefix of the translated pages of a blog off a
+// URL path. Paths without one are in the workspace default language, returned as "".
+func splitBlogLanguage(workspace *domain.Workspace, path string) (string, string) {
+	segment, rest, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if !workspace.Settings.IsTranslationLanguage(segment) {
+		return "", path
+	}
+	return segment, "/" + rest
+}
+
 // serveBlogHome serves the blog home page with a list of posts
-func (h *RootHandler) serveBlogHome(w http.ResponseWriter, r *http.Request, workspace *domain.Workspace) {
+func (h *RootHandler) serveBlogHome(w http.ResponseWriter, r *http.Request, workspace *domain.Workspace, language string) {
 	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace.ID)
+	homePath := domain.BlogLanguagePrefix(language) + "/"
 
 	// Extract page parameter from query string
 	pageStr := r.URL.Query().Get("page")
@@ -311,14 +370,14 @@ func (h *RootHandler) serveBlogHome(w http.ResponseWriter, r *http.Request, work
 		page, err = strconv.Atoi(pageStr)
 		if err != nil || page < 1 {
 			// Invalid page parameter, redirect to page 1
-			http.Redirect(w, r, "/", http.StatusFound)
+			http.Redirect(w, r, homePath, http.StatusFound)
 			return
 		}
 	}
 
 	// Redirect ?page=1 to base URL to avoid duplicate content
 	if page == 1 && pageStr != "" {
-		http.Redirect(w, r, "/", http.StatusMovedPermanently)
+		http.Redirect(w, r, homePath, http.StatusMovedPermanently)
 		return
 	}
 
@@ -332,7 +391,7 @@ func (h *RootHandler) serveBlogHome(w http.ResponseWriter, r *http.Request, work
 
 	// Try cache first (include page in cache key)
 	// Skip cache if previewing
-	cacheKey := fmt.Sprintf("%s:/?page=%d", r.Host, page)
+	cacheKey := fmt.Sprintf("%s:%s?page=%d", r.Host, homePath, page)
 	if h.cache != nil && themeVersion == nil {
 		if cached, found := h.cache.Get(cacheKey); found {
 			if html, ok := cached.(string); ok {
@@ -345,7 +404,7 @@ func (h *RootHandler) serveBlogHome(w http.ResponseWriter, r *http.Request, work
 	}
 
 	// Cache miss - render the page
-	html, err := h.blogService.RenderHomePage(ctx, workspace.ID, page, themeVersion)
+	html, err := h.blogService.RenderHomePage(ctx, workspace.ID, language, page, themeVersion)
 	if err != nil {
 		// Map error codes to HTTP status codes (includes 404 for invalid pages)
 		if blogErr, ok := err.(*domain.BlogRenderError); ok {
@@ -375,8 +434,9 @@ func (h *RootHandler) serveBlogHome(w http.ResponseWriter, r *http.Request, work
 }
 
 // serveBlogCategory serves a blog category page with posts in that category
-func (h *RootHandler) serveBlogCategory(w http.ResponseWriter, r *http.Request, workspace *domain.Workspace, categorySlug string) {
+func (h *RootHandler) serveBlogCategory(w http.ResponseWriter, r *http.Request, workspace *domain.Workspace, language, categorySlug string) {
 	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace.ID)
+	categoryPath := domain.BlogLanguagePrefix(language) + "/" + categorySlug
 
 	// Extract page parameter from query string
 	pageStr := r.URL.Query().Get("page")
@@ -386,14 +446,14 @@ func (h *RootHandler) serveBlogCategory(w http.ResponseWriter, r *http.Request,
 		page, err = strconv.Atoi(pageStr)
 		if err != nil || page < 1 {
 			// Invalid page parameter, redirect to page 1
-			http.Redirect(w, r, "/"+categorySlug, http.StatusFound)
+			http.Redirect(w, r, categoryPath, http.StatusFound)
 			return
 		}
 	}
 
 	// Redirect ?page=1 to base URL to avoid duplicate content
 	if page == 1 && pageStr != "" {
-		http.Redirect(w, r, "/"+categorySlug, http.StatusMovedPermanently)
+		http.Redirect(w, r, categoryPath, http.StatusMovedPermanently)
 		return
 	}
 
@@ -407,7 +467,7 @@ func (h *RootHandler) serveBlogCategory(w http.ResponseWriter, r *http.Request,
 
 	// Try cache first (include page in cache key)
 	// Skip cache if previewing
-	cacheKey := fmt.Sprintf("%s:/%s?page=%d", r.Host, categorySlug, page)
+	cacheKey := fmt.Sprintf("%s:%s?page=%d", r.Host, categoryPath, page)
 	if h.cache != nil && themeVersion == nil {
 		if cached, found := h.cache.Get(cacheKey); found {
 			if html, ok := cached.(string); ok {
@@ -420,7 +480,7 @@ func (h *RootHandler) serveBlogCategory(w http.ResponseWriter, r *http.Request,
 	}
 
 	// Cache miss - render the page
-	html, err := h.blogService.RenderCategoryPage(ctx, workspace.ID, categorySlug, page, themeVersion)
+	html, err := h.blogService.RenderCategoryPage(ctx, workspace.ID, language, categorySlug, page, themeVersion)
 	if err != nil {
 		// Map error codes to HTTP status codes
 		if blogErr, ok := err.(*domain.BlogRenderError); ok {
@@ -454,8 +514,9 @@ func (h *RootHandler) serveBlogPost(w http.ResponseWriter, r *http.Request, work
 	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace.ID)
 
 	// Get category from post to build proper URL
-	// Extract category slug and post slug from URL
-	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	// Extract language, category slug and post slug from URL
+	language, path := splitBlogLanguage(workspace, r.URL.Path)
+	parts := strings.Split(strings.Trim(path, "/"), "/")
 	if len(parts) != 2 {
 		h.serveBlog404(w, r)
 		return
@@ -472,9 +533,11 @@ func (h *RootHandler) serveBlogPost(w http.ResponseWriter, r *http.Request, work
 	}
 
 	// Try cache first
-	// Skip cache if previewing
-	cacheKey := fmt.Sprintf("%s:/%s/%s", r.Host, categorySlug, postSlug)
-	if h.cache != nil && themeVersion == nil {
+	// Skip cache if previewing, or for subscribers whose page may show gated content
+	viewer := domain.BlogViewerFromContext(r.Context())
+	useCache := h.cache != nil && themeVersion == nil && viewer == nil
+	cacheKey := fmt.Sprintf("%s:%s/%s/%s", r.Host, domain.BlogLanguagePrefix(language), categorySlug, postSlug)
+	if useCache {
 		if cached, found := h.cache.Get(cacheKey); found {
 			if html, ok := cached.(string); ok {
 				h.logger.WithFields(map[string]interface{}{
@@ -493,7 +556,7 @@ func (h *RootHandler) serveBlogPost(w http.ResponseWriter, r *http.Request, work
 	}
 
 	// Cache miss - render the page
-	html, err := h.blogService.RenderPostPage(ctx, workspace.ID, categorySlug, postSlug, themeVersion)
+	html, err := h.blogService.RenderPostPage(ctx, workspace.ID, language, categorySlug, postSlug, themeVersion)
 	if err != nil {
 		// Map error codes to HTTP status codes
 		if blogErr, ok := err.(*domain.BlogRenderError); ok {
@@ -507,7 +570,7 @@ func (h *RootHandler) serveBlogPost(w http.ResponseWriter, r *http.Request, work
 	}
 
 	// Store in cache (skip if previewing)
-	if h.cache != nil && themeVersion == nil {
+	if useCache {
 		h.cache.Set(cacheKey, html, domain.BlogCacheTTL)
 	}
 
@@ -516,12 +579,401 @@ func (h *RootHandler) serveBlogPost(w http.ResponseWriter, r *http.Request, work
 	if themeVersion != nil {
 		w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
 		w.Header().Set("X-Cache", "BYPASS")
+	} else if viewer != nil {
+		w.Header().Set("Cache-Control", "private, no-store")
+		w.Header().Set("X-Cache", "BYPASS")
 	} else {
 		w.Header().Set("X-Cache", "MISS")
 	}
 	_, _ = w.Write([]byte(html))
 }
 
+// serveBlogPostPreview serves the preview of a draft post revision. Previews are never
+// cached and are kept out of search engines.
+func (h *RootHandler) serveBlogPostPreview(w http.ResponseWriter, r *http.Request, workspace *domain.Workspace, previewToken string) {
+	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace.ID)
+
+	// Extract preview_theme_version
+	var themeVersion *int
+	if versionStr := r.URL.Query().Get("preview_theme_version"); versionStr != "" {
+		if v, err := strconv.Atoi(versionStr); err == nil {
+			themeVersion = &v
+		}
+	}
+
+	html, err := h.blogService.RenderPostPreview(ctx, workspace.ID, previewToken, themeVersion)
+	if err != nil {
+		if blogErr, ok := err.(*domain.BlogRenderError); ok {
+			h.handleBlogRenderError(w, blogErr)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to render blog post preview")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
+	w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+	w.Header().Set("X-Cache", "BYPASS")
+	_, _ = w.Write([]byte(html))
+}
+
+// serveBlogSearch serves the search page of the blog. Search pages are not cached, as
+// queries are arbitrary, and are kept out of search engines.
+func (h *RootHandler) serveBlogSearch(w http.ResponseWriter, r *http.Request, workspace *domain.Workspace) {
+	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace.ID)
+	query := r.URL.Query().Get("q")
+
+	page := 1
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		var err error
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			http.Redirect(w, r, "/search?q="+url.QueryEscape(query), http.StatusFound)
+			return
+		}
+	}
+
+	// Extract preview_theme_version
+	var themeVersion *int
+	if versionStr := r.URL.Query().Get("preview_theme_version"); versionStr != "" {
+		if v, err := strconv.Atoi(versionStr); err == nil {
+			themeVersion = &v
+		}
+	}
+
+	html, err := h.blogService.RenderSearchPage(ctx, workspace.ID, query, page, themeVersion)
+	if err != nil {
+		if blogErr, ok := err.(*domain.BlogRenderError); ok {
+			h.handleBlogRenderError(w, blogErr)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to render blog search page")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate")
+	w.Header().Set("X-Robots-Tag", "noindex, follow")
+	w.Header().Set("X-Cache", "BYPASS")
+	_, _ = w.Write([]byte(html))
+}
+
+// serveBlogSearchJSON serves the search results used by instant search in themes
+func (h *RootHandler) serveBlogSearchJSON(w http.ResponseWriter, r *http.Request, workspace *domain.Workspace) {
+	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace.ID)
+
+	params := &domain.BlogSearchRequest{Query: r.URL.Query().Get("q")}
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil {
+			WriteJSONError(w, "page must be a number", http.StatusBadRequest)
+			return
+		}
+		params.Page = page
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			WriteJSONError(w, "limit must be a number", http.StatusBadRequest)
+			return
+		}
+		params.Limit = limit
+	}
+	if err := params.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.blogService.SearchPublicPosts(ctx, params)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to search blog posts")
+		WriteJSONError(w, "Failed to search posts", http.StatusInternalServerError)
+		return
+	}
+
+	results := make([]map[string]interface{}, 0, len(response.Results))
+	for _, result := range response.Results {
+		results = append(results, map[string]interface{}{
+			"id":                 result.Post.ID,
+			"title":              result.Post.Settings.Title,
+			"title_highlight":    result.TitleHighlight,
+			"excerpt":            result.Post.Settings.Excerpt,
+			"snippet":            result.Snippet,
+			"featured_image_url": result.Post.Settings.FeaturedImageURL,
+			"category_slug":      result.CategorySlug,
+			"url":                "/" + result.CategorySlug + "/" + result.Post.Slug,
+			"published_at":       result.Post.PublishedAt,
+		})
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Robots-Tag", "noindex")
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"query":         response.Query,
+		"results":       results,
+		"total_count":   response.TotalCount,
+		"current_page":  response.CurrentPage,
+		"total_pages":   response.TotalPages,
+		"has_next_page": response.HasNextPage,
+	})
+}
+
+// writeBlogAPIError maps the errors of the public comment endpoints to HTTP responses
+func (h *RootHandler) writeBlogAPIError(w http.ResponseWriter, err error, message string) {
+	var validationErr domain.ValidationError
+	if errors.As(err, &validationErr) {
+		WriteJSONError(w, validationErr.Message, http.StatusBadRequest)
+		return
+	}
+	var notFoundErr *domain.ErrNotFound
+	if errors.As(err, &notFoundErr) {
+		WriteJSONError(w, "Not found", http.StatusNotFound)
+		return
+	}
+	h.logger.WithField("error", err.Error()).Error(message)
+	WriteJSONError(w, message, http.StatusInternalServerError)
+}
+
+// allowBlogRequest applies the rate limit of a namespace, and writes the response when exceeded
+func (h *RootHandler) allowBlogRequest(w http.ResponseWriter, namespace, key string) bool {
+	if h.rateLimiter == nil || h.rateLimiter.Allow(namespace, key) {
+		return true
+	}
+	retryAfter := h.rateLimiter.GetRemainingWindow(namespace, key)
+	w.Header().Set("Retry-After", fmt.Sprintf("%d", retryAfter))
+	WriteJSONError(w, "Too many requests. Please try again later.", http.StatusTooManyRequests)
+	return false
+}
+
+// serveBlogComments lists the approved comments of a post (GET) or creates a comment (POST)
+func (h *RootHandler) serveBlogComments(w http.ResponseWriter, r *http.Request, workspace *domain.Workspace) {
+	if h.blogCommentService == nil {
+		h.serveBlog404(w, r)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace.ID)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Robots-Tag", "noindex")
+
+	switch r.Method {
+	case http.MethodGet:
+		postID := r.URL.Query().Get("post_id")
+		if postID == "" {
+			WriteJSONError(w, "post_id is required", http.StatusBadRequest)
+			return
+		}
+
+		response, err := h.blogCommentService.ListPublicComments(ctx, workspace.ID, postID)
+		if err != nil {
+			h.writeBlogAPIError(w, err, "Failed to list comments")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, response)
+	case http.MethodPost:
+		if !h.allowBlogRequest(w, "comment:ip", getClientIP(r)) {
+			return
+		}
+
+		var req domain.CreateBlogCommentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if err := req.Validate(); err != nil {
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !h.allowBlogRequest(w, "comment:email", req.Email) {
+			return
+		}
+		req.IPAddress = getClientIP(r)
+		req.UserAgent = r.UserAgent()
+
+		comment, err := h.blogCommentService.CreatePublicComment(ctx, workspace.ID, &req)
+		if err != nil {
+			h.writeBlogAPIError(w, err, "Failed to create comment")
+			return
+		}
+
+		// Spam is not disclosed to its author
+		status := comment.Status
+		if status == domain.BlogCommentStatusSpam {
+			status = domain.BlogCommentStatusPending
+		}
+
+		writeJSON(w, http.StatusCreated, map[string]interface{}{
+			"id":     comment.ID,
+			"status": status,
+		})
+	default:
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// serveBlogCommentVerification confirms the email of the author of a comment from the
+// magic link sent to them, and redirects to the comment
+func (h *RootHandler) serveBlogCommentVerification(w http.ResponseWriter, r *http.Request, workspace *domain.Workspace) {
+	if h.blogCommentService == nil {
+		h.serveBlog404(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.allowBlogRequest(w, "comment:ip", getClientIP(r)) {
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace.ID)
+	query := r.URL.Query()
+
+	postURL, err := h.blogCommentService.VerifyPublicComment(ctx, workspace.ID, &domain.VerifyBlogCommentRequest{
+		CommentID: query.Get("comment_id"),
+		Email:     query.Get("email"),
+		EmailHMAC: query.Get("email_hmac"),
+	})
+	if err != nil {
+		h.writeBlogAPIError(w, err, "Failed to verify comment")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, postURL, http.StatusSeeOther)
+}
+
+// serveBlogReactions adds or removes the reaction of a contact to a post (POST)
+func (h *RootHandler) serveBlogReactions(w http.ResponseWriter, r *http.Request, workspace *domain.Workspace) {
+	if h.blogCommentService == nil {
+		h.serveBlog404(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.allowBlogRequest(w, "comment:ip", getClientIP(r)) {
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace.ID)
+
+	var req domain.BlogReactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	reactions, err := h.blogCommentService.ReactToPost(ctx, workspace.ID, &req)
+	if err != nil {
+		h.writeBlogAPIError(w, err, "Failed to react to post")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"reactions": reactions,
+	})
+}
+
+// blogViewerFromRequest returns the viewer identified by the access cookie of the request,
+// nil for anonymous visitors and invalid or expired cookies
+func blogViewerFromRequest(r *http.Request, workspace *domain.Workspace) *domain.BlogViewer {
+	cookie, err := r.Cookie(domain.BlogAccessCookieName)
+	if err != nil || cookie.Value == "" {
+		return nil
+	}
+	email, ok := domain.VerifyBlogAccessToken(cookie.Value, workspace.Settings.SecretKey, time.Now())
+	if !ok {
+		return nil
+	}
+	return &domain.BlogViewer{Email: email}
+}
+
+// serveBlogAccessRequest emails a magic link to a gated post to a returning subscriber
+// (POST). The response does not disclose whether the email is a subscriber.
+func (h *RootHandler) serveBlogAccessRequest(w http.ResponseWriter, r *http.Request, workspace *domain.Workspace) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.allowBlogRequest(w, "blog_access:ip", getClientIP(r)) {
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), domain.WorkspaceIDKey, workspace.ID)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Robots-Tag", "noindex")
+
+	var req domain.BlogAccessRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !h.allowBlogRequest(w, "blog_access:email", req.Email) {
+		return
+	}
+
+	if err := h.blogService.RequestBlogAccess(ctx, workspace.ID, &req); err != nil {
+		h.writeBlogAPIError(w, err, "Failed to request access")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+	})
+}
+
+// serveBlogAccess exchanges the token of a magic link for the access cookie of gated
+// posts, and redirects to the post the link was sent for
+func (h *RootHandler) serveBlogAccess(w http.ResponseWriter, r *http.Request, workspace *domain.Workspace) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	email, ok := domain.VerifyBlogAccessToken(query.Get("token"), workspace.Settings.SecretKey, time.Now())
+	if !ok {
+		http.Error(w, "This link is invalid or has expired", http.StatusBadRequest)
+		return
+	}
+
+	var accessSettings *domain.BlogAccessSettings
+	if workspace.Settings.BlogSettings != nil {
+		accessSettings = workspace.Settings.BlogSettings.Access
+	}
+	cookieDays := accessSettings.GetCookieDays()
+	expiresAt := time.Now().Add(time.Duration(cookieDays) * 24 * time.Hour)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     domain.BlogAccessCookieName,
+		Value:    domain.SignBlogAccessToken(email, workspace.Settings.SecretKey, expiresAt),
+		Path:     "/",
+		MaxAge:   cookieDays * 24 * 60 * 60,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	redirect := query.Get("redirect")
+	if !domain.IsSafeBlogRedirectPath(redirect) {
+		redirect = "/"
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
 // serveBlogRobots serves robots.txt for the blog
 func (h *RootHandler) serveBlogRobots(w http.ResponseWriter, r *http.Request) {
 	robotsTxt := `User-agent: *
@@ -555,40 +1007,44 @@ func (h *RootHandler) serveBlogSitemap(w http.ResponseWriter, r *http.Request, w
 	var sitemap strings.Builder
 	sitemap.WriteString(`<?xml version="1.0" encoding="UTF-8"?>`)
 	sitemap.WriteString("\n")
-	sitemap.WriteString(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	if len(workspace.Settings.Languages) > 1 {
+		// Translated pages list their versions in the other languages as xhtml:link
+		sitemap.WriteString(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:xhtml="http://www.w3.org/1999/xhtml">`)
+	} else {
+		sitemap.WriteString(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	}
 	sitemap.WriteString("\n")
 
-	// Add homepage
-	sitemap.WriteString("  <url>\n")
-	fmt.Fprintf(&sitemap, "    <loc>https://%s/</loc>\n", r.Host)
-	sitemap.WriteString("    <changefreq>daily</changefreq>\n")
-	sitemap.WriteString("    <priority>1.0</priority>\n")
-	sitemap.WriteString("  </url>\n")
+	// Add homepage, in each language of the blog
+	writeSitemapURLs(&sitemap, r.Host, blogSitemapVersions(workspace, func(string) (string, bool) {
+		return "/", true
+	}), nil, "daily", "1.0")
 
-	// Add posts
+	// Add posts, in each language they are translated in
 	for _, post := range response.Posts {
 		if post.CategoryID != "" {
 			// Get category to build the URL
 			category, err := h.blogService.GetCategory(ctx, post.CategoryID)
 			if err == nil && category != nil {
-				sitemap.WriteString("  <url>\n")
-				fmt.Fprintf(&sitemap, "    <loc>https://%s/%s/%s</loc>\n", r.Host, category.Slug, post.Slug)
-				if post.PublishedAt != nil {
-					fmt.Fprintf(&sitemap, "    <lastmod>%s</lastmod>\n", post.PublishedAt.Format("2006-01-02"))
-				}
-				sitemap.WriteString("    <changefreq>monthly</changefreq>\n")
-				sitemap.WriteString("    <priority>0.8</priority>\n")
-				sitemap.WriteString("  </url>\n")
+				versions := blogSitemapVersions(workspace, func(language string) (string, bool) {
+					localizedPost := post.Localize(language)
+					if localizedPost == nil {
+						return "", false
+					}
+					return "/" + category.Localize(language).Slug + "/" + localizedPost.Slug, true
+				})
+				writeSitemapURLs(&sitemap, r.Host, versions, post.PublishedAt, "monthly", "0.8")
 			}
 		}
 	}
 
-	// Add main feed URL
-	sitemap.WriteString("  <url>\n")
-	fmt.Fprintf(&sitemap, "    <loc>https://%s/feed.xml</loc>\n", r.Host)
-	sitemap.WriteString("    <changefreq>daily</changefreq>\n")
-	sitemap.WriteString("    <priority>0.3</priority>\n")
-	sitemap.WriteString("  </url>\n")
+	// Add main feed URL, and the feeds of the other languages of the blog
+	writeSitemapURLs(&sitemap, r.Host, []blogSitemapVersion{{path: "/feed.xml"}}, nil, "daily", "0.3")
+	for _, language := range workspace.Settings.Languages {
+		if workspace.Settings.IsTranslationLanguage(language) {
+			writeSitemapURLs(&sitemap, r.Host, []blogSitemapVersion{{path: "/" + language + "/feed.xml"}}, nil, "daily", "0.3")
+		}
+	}
 
 	// Add per-category feed URLs (deduplicated from the category set above)
 	seenCategories := map[string]struct{}{}
@@ -602,11 +1058,7 @@ func (h *RootHandler) serveBlogSitemap(w http.ResponseWriter, r *http.Request, w
 		seenCategories[post.CategoryID] = struct{}{}
 		category, err := h.blogService.GetCategory(ctx, post.CategoryID)
 		if err == nil && category != nil {
-			sitemap.WriteString("  <url>\n")
-			fmt.Fprintf(&sitemap, "    <loc>https://%s/%s/feed.xml</loc>\n", r.Host, category.Slug)
-			sitemap.WriteString("    <changefreq>daily</changefreq>\n")
-			sitemap.WriteString("    <priority>0.3</priority>\n")
-			sitemap.WriteString("  </url>\n")
+			writeSitemapURLs(&sitemap, r.Host, []blogSitemapVersion{{path: "/" + category.Slug + "/feed.xml"}}, nil, "daily", "0.3")
 		}
 	}
 
@@ -616,6 +1068,54 @@ func (h *RootHandler) serveBlogSitemap(w http.ResponseWriter, r *http.Request, w
 	_, _ = w.Write([]byte(sitemap.String()))
 }
 
+// blogSitemapVersion is the path of a blog page in one of the languages of the workspace
+type blogSitemapVersion struct {
+	language string
+	path     string
+}
+
+// blogSitemapVersions returns the paths of a page in the languages of the workspace.
+// pathFor returns the path of the page in a language ("" for the default language)
+// without its language prefix, or false when the page does not exist in that language.
+func blogSitemapVersions(workspace *domain.Workspace, pathFor func(language string) (string, bool)) []blogSitemapVersion {
+	if len(workspace.Settings.Languages) < 2 {
+		path, _ := pathFor("")
+		return []blogSitemapVersion{{path: path}}
+	}
+
+	versions := []blogSitemapVersion{}
+	for _, code := range workspace.Settings.Languages {
+		language := code
+		if code == workspace.Settings.DefaultLanguage {
+			language = ""
+		}
+		if path, ok := pathFor(language); ok {
+			versions = append(versions, blogSitemapVersion{language: code, path: domain.BlogLanguagePrefix(language) + path})
+		}
+	}
+	return versions
+}
+
+// writeSitemapURLs writes a sitemap entry for each version of a page, listing all the
+// versions as alternates when there are several
+func writeSitemapURLs(sitemap *strings.Builder, host string, versions []blogSitemapVersion, lastmod *time.Time, changefreq, priority string) {
+	for _, version := range versions {
+		sitemap.WriteString("  <url>\n")
+		fmt.Fprintf(sitemap, "    <loc>https://%s%s</loc>\n", host, version.path)
+		if len(versions) > 1 {
+			for _, alternate := range versions {
+				fmt.Fprintf(sitemap, "    <xhtml:link rel=\"alternate\" hreflang=\"%s\" href=\"https://%s%s\"/>\n", alternate.language, host, alternate.path)
+			}
+		}
+		if lastmod != nil {
+			fmt.Fprintf(sitemap, "    <lastmod>%s</lastmod>\n", lastmod.Format("2006-01-02"))
+		}
+		fmt.Fprintf(sitemap, "    <changefreq>%s</changefreq>\n", changefreq)
+		fmt.Fprintf(sitemap, "    <priority>%s</priority>\n", priority)
+		sitemap.WriteString("  </url>\n")
+	}
+}
+
 type feedFormat int
 
 const (
@@ -623,13 +1123,13 @@ const (
 	feedFormatJSON
 )
 
-func (h *RootHandler) serveBlogFeed(w http.ResponseWriter, r *http.Request, workspace *domain.Workspace, categorySlug *string, format feedFormat) {
+func (h *RootHandler) serveBlogFeed(w http.ResponseWriter, r *http.Request, workspace *domain.Workspace, language string, categorySlug *string, format feedFormat) {
 	if r.Method != http.MethodGet && r.Method != http.MethodHead {
 		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	maxUpdatedAt, etag, err := h.blogService.GetFeedFingerprint(r.Context(), workspace.ID, categorySlug)
+	maxUpdatedAt, etag, err := h.blogService.GetFeedFingerprint(r.Context(), workspace.ID, language, categorySlug)
 	if err != nil {
 		h.logger.WithField("error", err.Error()).Error("Feed: fingerprint failed")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -656,7 +1156,7 @@ func (h *RootHandler) serveBlogFeed(w http.ResponseWriter, r *http.Request, work
 		}
 	}
 
-	feed, err := h.blogService.BuildFeed(r.Context(), workspace.ID, categorySlug)
+	feed, err := h.blogService.BuildFeed(r.Context(), workspace.ID, language, categorySlug)
 	if err != nil {
 		h.logger.WithField("error", err.Error()).Error("Feed: build failed")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
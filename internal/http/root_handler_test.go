@@ -7,8 +7,10 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,6 +19,7 @@ import (
 	"github.com/Notifuse/notifuse/pkg/cache"
 	"github.com/Notifuse/notifuse/pkg/logger"
 	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/Notifuse/notifuse/pkg/ratelimiter"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -40,11 +43,13 @@ func TestNewRootHandler(t *testing.T) {
 		"",
 		0,
 		"off",
-		nil, // workspaceRepo
-		nil, // blogService
-		nil, // cache
+		nil,   // workspaceRepo
+		nil,   // blogService
+		nil,   // cache
 		false, // oidcEnabled
 		"",    // oidcButtonLabel
+		nil,   // blogCommentService
+		nil,   // rateLimiter
 	)
 
 	// Assert fields are set correctly
@@ -61,6 +66,7 @@ func TestServeConfigJS_OIDC(t *testing.T) {
 			false, "", 0, "off",
 			nil, nil, nil,
 			enabled, label,
+			nil, nil,
 		)
 	}
 
@@ -104,11 +110,13 @@ func TestRootHandler_Handle(t *testing.T) {
 		"",
 		0,
 		"off",
-		nil, // workspaceRepo
-		nil, // blogService
-		nil, // cache
+		nil,   // workspaceRepo
+		nil,   // blogService
+		nil,   // cache
 		false, // oidcEnabled
 		"",    // oidcButtonLabel
+		nil,   // blogCommentService
+		nil,   // rateLimiter
 	)
 
 	// Create a test request
@@ -148,11 +156,13 @@ func TestRootHandler_RegisterRoutes(t *testing.T) {
 		"",
 		0,
 		"off",
-		nil, // workspaceRepo
-		nil, // blogService
-		nil, // cache
+		nil,   // workspaceRepo
+		nil,   // blogService
+		nil,   // cache
 		false, // oidcEnabled
 		"",    // oidcButtonLabel
+		nil,   // blogCommentService
+		nil,   // rateLimiter
 	)
 	mux := http.NewServeMux()
 
@@ -198,11 +208,13 @@ func TestRootHandler_RegisterRoutesWithNotificationCenter(t *testing.T) {
 		"",
 		0,
 		"off",
-		nil, // workspaceRepo
-		nil, // blogService
-		nil, // cache
+		nil,   // workspaceRepo
+		nil,   // blogService
+		nil,   // cache
 		false, // oidcEnabled
 		"",    // oidcButtonLabel
+		nil,   // blogCommentService
+		nil,   // rateLimiter
 	)
 
 	mux := http.NewServeMux()
@@ -241,11 +253,13 @@ func TestRootHandler_ServeConfigJS(t *testing.T) {
 		"",
 		0,
 		"off",
-		nil, // workspaceRepo
-		nil, // blogService
-		nil, // cache
+		nil,   // workspaceRepo
+		nil,   // blogService
+		nil,   // cache
 		false, // oidcEnabled
 		"",    // oidcButtonLabel
+		nil,   // blogCommentService
+		nil,   // rateLimiter
 	)
 
 	// Create a request to /config.js
@@ -299,11 +313,13 @@ func TestRootHandler_Handle_ConfigJS(t *testing.T) {
 		"",
 		0,
 		"off",
-		nil, // workspaceRepo
-		nil, // blogService
-		nil, // cache
+		nil,   // workspaceRepo
+		nil,   // blogService
+		nil,   // cache
 		false, // oidcEnabled
 		"",    // oidcButtonLabel
+		nil,   // blogCommentService
+		nil,   // rateLimiter
 	)
 
 	// Create a request to /config.js
@@ -360,11 +376,13 @@ func TestRootHandler_ServeNotificationCenter(t *testing.T) {
 		"",
 		0,
 		"off",
-		nil, // workspaceRepo
-		nil, // blogService
-		nil, // cache
+		nil,   // workspaceRepo
+		nil,   // blogService
+		nil,   // cache
 		false, // oidcEnabled
 		"",    // oidcButtonLabel
+		nil,   // blogCommentService
+		nil,   // rateLimiter
 	)
 
 	t.Run("ServeExactPath", func(t *testing.T) {
@@ -439,11 +457,13 @@ func TestRootHandler_ServeConsole(t *testing.T) {
 		"",
 		0,
 		"off",
-		nil, // workspaceRepo
-		nil, // blogService
-		nil, // cache
+		nil,   // workspaceRepo
+		nil,   // blogService
+		nil,   // cache
 		false, // oidcEnabled
 		"",    // oidcButtonLabel
+		nil,   // blogCommentService
+		nil,   // rateLimiter
 	)
 
 	t.Run("ServeExactPath", func(t *testing.T) {
@@ -534,11 +554,13 @@ func TestRootHandler_Handle_Comprehensive(t *testing.T) {
 		"",
 		0,
 		"off",
-		nil, // workspaceRepo
-		nil, // blogService
-		nil, // cache
+		nil,   // workspaceRepo
+		nil,   // blogService
+		nil,   // cache
 		false, // oidcEnabled
 		"",    // oidcButtonLabel
+		nil,   // blogCommentService
+		nil,   // rateLimiter
 	)
 
 	t.Run("NotFoundAPIPath", func(t *testing.T) {
@@ -654,11 +676,13 @@ func TestRootHandler_CacheIntegration(t *testing.T) {
 			"",
 			0,
 			"off",
-			nil, // workspaceRepo
-			nil, // blogService
-			nil, // cache - nil is allowed
+			nil,   // workspaceRepo
+			nil,   // blogService
+			nil,   // cache - nil is allowed
 			false, // oidcEnabled
 			"",    // oidcButtonLabel
+			nil,   // blogCommentService
+			nil,   // rateLimiter
 		)
 
 		// Verify handler is created successfully
@@ -728,6 +752,8 @@ func setupBlogHandlerTest(t *testing.T) (*mocks.MockBlogService, *pkgmocks.MockL
 		testCache,
 		false, // oidcEnabled
 		"",    // oidcButtonLabel
+		nil,   // blogCommentService
+		nil,   // rateLimiter
 	)
 
 	return mockBlogService, mockLogger, testCache, workspace, handler
@@ -918,14 +944,14 @@ func TestRootHandler_serveBlogHome(t *testing.T) {
 
 		expectedHTML := "<html><body>Home Page</body></html>"
 		mockBlogService.EXPECT().
-			RenderHomePage(gomock.Any(), workspace.ID, 1, nil).
+			RenderHomePage(gomock.Any(), workspace.ID, "", 1, nil).
 			Return(expectedHTML, nil)
 
 		req := httptest.NewRequest("GET", "/", nil)
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogHome(w, req, workspace)
+		handler.serveBlogHome(w, req, workspace, "")
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
@@ -939,14 +965,14 @@ func TestRootHandler_serveBlogHome(t *testing.T) {
 
 		expectedHTML := "<html><body>Home Page 2</body></html>"
 		mockBlogService.EXPECT().
-			RenderHomePage(gomock.Any(), workspace.ID, 2, nil).
+			RenderHomePage(gomock.Any(), workspace.ID, "", 2, nil).
 			Return(expectedHTML, nil)
 
 		req := httptest.NewRequest("GET", "/?page=2", nil)
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogHome(w, req, workspace)
+		handler.serveBlogHome(w, req, workspace, "")
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Equal(t, expectedHTML, w.Body.String())
@@ -959,7 +985,7 @@ func TestRootHandler_serveBlogHome(t *testing.T) {
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogHome(w, req, workspace)
+		handler.serveBlogHome(w, req, workspace, "")
 
 		assert.Equal(t, http.StatusFound, w.Code)
 		assert.Equal(t, "/", w.Header().Get("Location"))
@@ -972,7 +998,7 @@ func TestRootHandler_serveBlogHome(t *testing.T) {
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogHome(w, req, workspace)
+		handler.serveBlogHome(w, req, workspace, "")
 
 		assert.Equal(t, http.StatusMovedPermanently, w.Code)
 		assert.Equal(t, "/", w.Header().Get("Location"))
@@ -989,7 +1015,7 @@ func TestRootHandler_serveBlogHome(t *testing.T) {
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogHome(w, req, workspace)
+		handler.serveBlogHome(w, req, workspace, "")
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
@@ -1002,14 +1028,14 @@ func TestRootHandler_serveBlogHome(t *testing.T) {
 		expectedHTML := "<html><body>Preview</body></html>"
 		themeVersion := 5
 		mockBlogService.EXPECT().
-			RenderHomePage(gomock.Any(), workspace.ID, 1, &themeVersion).
+			RenderHomePage(gomock.Any(), workspace.ID, "", 1, &themeVersion).
 			Return(expectedHTML, nil)
 
 		req := httptest.NewRequest("GET", "/?preview_theme_version=5", nil)
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogHome(w, req, workspace)
+		handler.serveBlogHome(w, req, workspace, "")
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Equal(t, "BYPASS", w.Header().Get("X-Cache"))
@@ -1064,14 +1090,14 @@ func TestRootHandler_serveBlogHome(t *testing.T) {
 					Message: "Test error",
 				}
 				mockBlogService.EXPECT().
-					RenderHomePage(gomock.Any(), workspace.ID, 1, nil).
+					RenderHomePage(gomock.Any(), workspace.ID, "", 1, nil).
 					Return("", blogErr)
 
 				req := httptest.NewRequest("GET", "/", nil)
 				req.Host = "example.com"
 				w := httptest.NewRecorder()
 
-				handler.serveBlogHome(w, req, workspace)
+				handler.serveBlogHome(w, req, workspace, "")
 
 				assert.Equal(t, tc.expectedStatus, w.Code)
 			})
@@ -1082,14 +1108,14 @@ func TestRootHandler_serveBlogHome(t *testing.T) {
 		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
 
 		mockBlogService.EXPECT().
-			RenderHomePage(gomock.Any(), workspace.ID, 1, nil).
+			RenderHomePage(gomock.Any(), workspace.ID, "", 1, nil).
 			Return("", assert.AnError)
 
 		req := httptest.NewRequest("GET", "/", nil)
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogHome(w, req, workspace)
+		handler.serveBlogHome(w, req, workspace, "")
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
@@ -1101,7 +1127,7 @@ func TestRootHandler_serveBlogHome(t *testing.T) {
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogHome(w, req, workspace)
+		handler.serveBlogHome(w, req, workspace, "")
 
 		assert.Equal(t, http.StatusFound, w.Code)
 		assert.Equal(t, "/", w.Header().Get("Location"))
@@ -1114,14 +1140,14 @@ func TestRootHandler_serveBlogCategory(t *testing.T) {
 
 		expectedHTML := "<html><body>Category Page</body></html>"
 		mockBlogService.EXPECT().
-			RenderCategoryPage(gomock.Any(), workspace.ID, "tech", 1, nil).
+			RenderCategoryPage(gomock.Any(), workspace.ID, "", "tech", 1, nil).
 			Return(expectedHTML, nil)
 
 		req := httptest.NewRequest("GET", "/tech", nil)
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogCategory(w, req, workspace, "tech")
+		handler.serveBlogCategory(w, req, workspace, "", "tech")
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
@@ -1133,14 +1159,14 @@ func TestRootHandler_serveBlogCategory(t *testing.T) {
 
 		expectedHTML := "<html><body>Category Page 2</body></html>"
 		mockBlogService.EXPECT().
-			RenderCategoryPage(gomock.Any(), workspace.ID, "tech", 2, nil).
+			RenderCategoryPage(gomock.Any(), workspace.ID, "", "tech", 2, nil).
 			Return(expectedHTML, nil)
 
 		req := httptest.NewRequest("GET", "/tech?page=2", nil)
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogCategory(w, req, workspace, "tech")
+		handler.serveBlogCategory(w, req, workspace, "", "tech")
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Equal(t, expectedHTML, w.Body.String())
@@ -1153,7 +1179,7 @@ func TestRootHandler_serveBlogCategory(t *testing.T) {
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogCategory(w, req, workspace, "tech")
+		handler.serveBlogCategory(w, req, workspace, "", "tech")
 
 		assert.Equal(t, http.StatusFound, w.Code)
 		assert.Equal(t, "/tech", w.Header().Get("Location"))
@@ -1166,7 +1192,7 @@ func TestRootHandler_serveBlogCategory(t *testing.T) {
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogCategory(w, req, workspace, "tech")
+		handler.serveBlogCategory(w, req, workspace, "", "tech")
 
 		assert.Equal(t, http.StatusMovedPermanently, w.Code)
 		assert.Equal(t, "/tech", w.Header().Get("Location"))
@@ -1183,7 +1209,7 @@ func TestRootHandler_serveBlogCategory(t *testing.T) {
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogCategory(w, req, workspace, "tech")
+		handler.serveBlogCategory(w, req, workspace, "", "tech")
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
@@ -1196,14 +1222,14 @@ func TestRootHandler_serveBlogCategory(t *testing.T) {
 		expectedHTML := "<html><body>Preview Category</body></html>"
 		themeVersion := 3
 		mockBlogService.EXPECT().
-			RenderCategoryPage(gomock.Any(), workspace.ID, "tech", 1, &themeVersion).
+			RenderCategoryPage(gomock.Any(), workspace.ID, "", "tech", 1, &themeVersion).
 			Return(expectedHTML, nil)
 
 		req := httptest.NewRequest("GET", "/tech?preview_theme_version=3", nil)
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogCategory(w, req, workspace, "tech")
+		handler.serveBlogCategory(w, req, workspace, "", "tech")
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Equal(t, "BYPASS", w.Header().Get("X-Cache"))
@@ -1219,14 +1245,14 @@ func TestRootHandler_serveBlogCategory(t *testing.T) {
 			Message: "Category not found",
 		}
 		mockBlogService.EXPECT().
-			RenderCategoryPage(gomock.Any(), workspace.ID, "tech", 1, nil).
+			RenderCategoryPage(gomock.Any(), workspace.ID, "", "tech", 1, nil).
 			Return("", blogErr)
 
 		req := httptest.NewRequest("GET", "/tech", nil)
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogCategory(w, req, workspace, "tech")
+		handler.serveBlogCategory(w, req, workspace, "", "tech")
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
@@ -1235,14 +1261,14 @@ func TestRootHandler_serveBlogCategory(t *testing.T) {
 		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
 
 		mockBlogService.EXPECT().
-			RenderCategoryPage(gomock.Any(), workspace.ID, "tech", 1, nil).
+			RenderCategoryPage(gomock.Any(), workspace.ID, "", "tech", 1, nil).
 			Return("", assert.AnError)
 
 		req := httptest.NewRequest("GET", "/tech", nil)
 		req.Host = "example.com"
 		w := httptest.NewRecorder()
 
-		handler.serveBlogCategory(w, req, workspace, "tech")
+		handler.serveBlogCategory(w, req, workspace, "", "tech")
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
@@ -1264,7 +1290,7 @@ func TestRootHandler_serveBlogPost(t *testing.T) {
 
 		expectedHTML := "<html><body>Post Page</body></html>"
 		mockBlogService.EXPECT().
-			RenderPostPage(gomock.Any(), workspace.ID, "tech", "my-post", nil).
+			RenderPostPage(gomock.Any(), workspace.ID, "", "tech", "my-post", nil).
 			Return(expectedHTML, nil)
 
 		req := httptest.NewRequest("GET", "/tech/my-post", nil)
@@ -1322,7 +1348,7 @@ func TestRootHandler_serveBlogPost(t *testing.T) {
 		expectedHTML := "<html><body>Preview Post</body></html>"
 		themeVersion := 2
 		mockBlogService.EXPECT().
-			RenderPostPage(gomock.Any(), workspace.ID, "tech", "my-post", &themeVersion).
+			RenderPostPage(gomock.Any(), workspace.ID, "", "tech", "my-post", &themeVersion).
 			Return(expectedHTML, nil)
 
 		req := httptest.NewRequest("GET", "/tech/my-post?preview_theme_version=2", nil)
@@ -1376,7 +1402,7 @@ func TestRootHandler_serveBlogPost(t *testing.T) {
 			Message: "Post not found",
 		}
 		mockBlogService.EXPECT().
-			RenderPostPage(gomock.Any(), workspace.ID, "tech", "my-post", nil).
+			RenderPostPage(gomock.Any(), workspace.ID, "", "tech", "my-post", nil).
 			Return("", blogErr)
 
 		req := httptest.NewRequest("GET", "/tech/my-post", nil)
@@ -1402,7 +1428,7 @@ func TestRootHandler_serveBlogPost(t *testing.T) {
 		}
 
 		mockBlogService.EXPECT().
-			RenderPostPage(gomock.Any(), workspace.ID, "tech", "my-post", nil).
+			RenderPostPage(gomock.Any(), workspace.ID, "", "tech", "my-post", nil).
 			Return("", assert.AnError)
 
 		req := httptest.NewRequest("GET", "/tech/my-post", nil)
@@ -1451,7 +1477,7 @@ func TestRootHandler_serveBlog(t *testing.T) {
 		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
 
 		mockBlogService.EXPECT().
-			RenderHomePage(gomock.Any(), workspace.ID, 1, nil).
+			RenderHomePage(gomock.Any(), workspace.ID, "", 1, nil).
 			Return("<html><body>Home</body></html>", nil)
 
 		req := httptest.NewRequest("GET", "/", nil)
@@ -1479,11 +1505,11 @@ func TestRootHandler_serveBlog(t *testing.T) {
 		}
 
 		mockBlogService.EXPECT().
-			GetPublicCategoryBySlug(gomock.Any(), "tech").
+			GetPublicCategoryBySlug(gomock.Any(), "", "tech").
 			Return(category, nil)
 
 		mockBlogService.EXPECT().
-			RenderCategoryPage(gomock.Any(), workspace.ID, "tech", 1, nil).
+			RenderCategoryPage(gomock.Any(), workspace.ID, "", "tech", 1, nil).
 			Return("<html><body>Category</body></html>", nil)
 
 		req := httptest.NewRequest("GET", "/tech", nil)
@@ -1510,11 +1536,11 @@ func TestRootHandler_serveBlog(t *testing.T) {
 		}
 
 		mockBlogService.EXPECT().
-			GetPublicPostByCategoryAndSlug(gomock.Any(), "tech", "my-post").
+			GetPublicPostByCategoryAndSlug(gomock.Any(), "", "tech", "my-post").
 			Return(post, nil)
 
 		mockBlogService.EXPECT().
-			RenderPostPage(gomock.Any(), workspace.ID, "tech", "my-post", nil).
+			RenderPostPage(gomock.Any(), workspace.ID, "", "tech", "my-post", nil).
 			Return("<html><body>Post</body></html>", nil)
 
 		req := httptest.NewRequest("GET", "/tech/my-post", nil)
@@ -1527,11 +1553,46 @@ func TestRootHandler_serveBlog(t *testing.T) {
 		assert.Contains(t, w.Body.String(), "Post")
 	})
 
+	t.Run("routing to draft preview", func(t *testing.T) {
+		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
+
+		mockBlogService.EXPECT().
+			RenderPostPreview(gomock.Any(), workspace.ID, "preview-token", nil).
+			Return("<html><body>Preview</body></html>", nil)
+
+		req := httptest.NewRequest("GET", "/_preview/preview-token", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Preview")
+		assert.Equal(t, "noindex, nofollow", w.Header().Get("X-Robots-Tag"))
+		assert.Contains(t, w.Header().Get("Cache-Control"), "no-store")
+	})
+
+	t.Run("unknown preview token returns 404", func(t *testing.T) {
+		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
+
+		mockBlogService.EXPECT().
+			RenderPostPreview(gomock.Any(), workspace.ID, "revoked", nil).
+			Return("", &domain.BlogRenderError{Code: domain.ErrCodePostNotFound, Message: "Preview not found"})
+
+		req := httptest.NewRequest("GET", "/_preview/revoked", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
 	t.Run("category not found returns 404", func(t *testing.T) {
 		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
 
 		mockBlogService.EXPECT().
-			GetPublicCategoryBySlug(gomock.Any(), "nonexistent").
+			GetPublicCategoryBySlug(gomock.Any(), "", "nonexistent").
 			Return(nil, assert.AnError)
 
 		req := httptest.NewRequest("GET", "/nonexistent", nil)
@@ -1547,7 +1608,7 @@ func TestRootHandler_serveBlog(t *testing.T) {
 		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
 
 		mockBlogService.EXPECT().
-			GetPublicPostByCategoryAndSlug(gomock.Any(), "tech", "nonexistent").
+			GetPublicPostByCategoryAndSlug(gomock.Any(), "", "tech", "nonexistent").
 			Return(nil, assert.AnError)
 
 		req := httptest.NewRequest("GET", "/tech/nonexistent", nil)
@@ -1575,8 +1636,8 @@ func TestRootHandler_serveBlog(t *testing.T) {
 		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
 
 		mockBlogService.EXPECT().
-			RenderHomePage(gomock.Any(), workspace.ID, 1, nil).
-			DoAndReturn(func(ctx context.Context, workspaceID string, page int, themeVersion *int) (string, error) {
+			RenderHomePage(gomock.Any(), workspace.ID, "", 1, nil).
+			DoAndReturn(func(ctx context.Context, workspaceID, language string, page int, themeVersion *int) (string, error) {
 				// Verify context has WorkspaceIDKey
 				ctxWorkspaceID := ctx.Value(domain.WorkspaceIDKey)
 				assert.Equal(t, workspace.ID, ctxWorkspaceID)
@@ -1593,6 +1654,157 @@ func TestRootHandler_serveBlog(t *testing.T) {
 	})
 }
 
+func TestRootHandler_serveBlog_Translated(t *testing.T) {
+	setupTranslated := func(t *testing.T) (*mocks.MockBlogService, *domain.Workspace, *RootHandler) {
+		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
+		workspace.Settings.DefaultLanguage = "en"
+		workspace.Settings.Languages = []string{"en", "fr"}
+		return mockBlogService, workspace, handler
+	}
+
+	t.Run("routing to the translated home page", func(t *testing.T) {
+		mockBlogService, workspace, handler := setupTranslated(t)
+
+		mockBlogService.EXPECT().
+			RenderHomePage(gomock.Any(), workspace.ID, "fr", 1, nil).
+			Return("<html><body>Accueil</body></html>", nil)
+
+		req := httptest.NewRequest("GET", "/fr/", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Accueil")
+	})
+
+	t.Run("routing to a translated category page", func(t *testing.T) {
+		mockBlogService, workspace, handler := setupTranslated(t)
+
+		mockBlogService.EXPECT().
+			GetPublicCategoryBySlug(gomock.Any(), "fr", "actualites").
+			Return(&domain.BlogCategory{ID: "cat-1", Slug: "news"}, nil)
+		mockBlogService.EXPECT().
+			RenderCategoryPage(gomock.Any(), workspace.ID, "fr", "actualites", 1, nil).
+			Return("<html><body>Actualités</body></html>", nil)
+
+		req := httptest.NewRequest("GET", "/fr/actualites", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("routing to a translated post page", func(t *testing.T) {
+		mockBlogService, workspace, handler := setupTranslated(t)
+
+		post := &domain.BlogPost{ID: "post-1", Slug: "lancement"}
+		mockBlogService.EXPECT().
+			GetPublicPostByCategoryAndSlug(gomock.Any(), "fr", "actualites", "lancement").
+			Return(post, nil)
+		mockBlogService.EXPECT().
+			RenderPostPage(gomock.Any(), workspace.ID, "fr", "actualites", "lancement", nil).
+			Return("<html><body>Lancement</body></html>", nil)
+
+		req := httptest.NewRequest("GET", "/fr/actualites/lancement", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Lancement")
+	})
+
+	t.Run("routing to a translated feed", func(t *testing.T) {
+		mockBlogService, workspace, handler := setupTranslated(t)
+
+		now := time.Now().UTC()
+		mockBlogService.EXPECT().
+			GetFeedFingerprint(gomock.Any(), workspace.ID, "fr", nil).
+			Return(now, `W/"fr"`, nil)
+		mockBlogService.EXPECT().
+			BuildFeed(gomock.Any(), workspace.ID, "fr", nil).
+			Return(&domain.BlogFeed{Meta: domain.BlogFeedMeta{Title: "Blog", SiteURL: "https://example.com", SelfURL: "https://example.com/fr/feed.xml", UpdatedAt: now}}, nil)
+
+		req := httptest.NewRequest("GET", "/fr/feed.xml", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `W/"fr"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("languages that are not translation languages are category slugs", func(t *testing.T) {
+		mockBlogService, workspace, handler := setupTranslated(t)
+
+		mockBlogService.EXPECT().
+			GetPublicPostByCategoryAndSlug(gomock.Any(), "", "en", "launch").
+			Return(nil, assert.AnError)
+
+		req := httptest.NewRequest("GET", "/en/launch", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("sitemap lists the translations of pages", func(t *testing.T) {
+		mockBlogService, workspace, handler := setupTranslated(t)
+
+		now := time.Now().UTC()
+		category := &domain.BlogCategory{
+			ID:   "cat-1",
+			Slug: "news",
+			Settings: domain.BlogCategorySettings{
+				Name:         "News",
+				Translations: map[string]domain.BlogCategoryTranslation{"fr": {Slug: "actualites", Name: "Actualités"}},
+			},
+		}
+		posts := []*domain.BlogPost{
+			{
+				ID:          "post-1",
+				CategoryID:  "cat-1",
+				Slug:        "launch",
+				PublishedAt: &now,
+				Settings: domain.BlogPostSettings{
+					Translations: map[string]domain.BlogPostTranslation{"fr": {Slug: "lancement", Title: "Lancement"}},
+				},
+			},
+			{ID: "post-2", CategoryID: "cat-1", Slug: "english-only", PublishedAt: &now},
+		}
+
+		mockBlogService.EXPECT().
+			ListPublicPosts(gomock.Any(), gomock.Any()).
+			Return(&domain.BlogPostListResponse{Posts: posts}, nil)
+		mockBlogService.EXPECT().GetCategory(gomock.Any(), "cat-1").Return(category, nil).Times(3)
+
+		req := httptest.NewRequest("GET", "/sitemap.xml", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlogSitemap(w, req, workspace)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		assert.Contains(t, body, `xmlns:xhtml="http://www.w3.org/1999/xhtml"`)
+		assert.Contains(t, body, `<loc>https://example.com/fr/</loc>`)
+		assert.Contains(t, body, `<loc>https://example.com/fr/actualites/lancement</loc>`)
+		assert.Contains(t, body, `<xhtml:link rel="alternate" hreflang="fr" href="https://example.com/fr/actualites/lancement"/>`)
+		assert.Contains(t, body, `<xhtml:link rel="alternate" hreflang="en" href="https://example.com/news/launch"/>`)
+		assert.Contains(t, body, `<loc>https://example.com/news/english-only</loc>`)
+		assert.NotContains(t, body, `href="https://example.com/news/english-only"`)
+		assert.Contains(t, body, `<loc>https://example.com/fr/feed.xml</loc>`)
+	})
+}
+
 func TestRootHandler_handleBlogRenderError(t *testing.T) {
 	testCases := []struct {
 		name                string
@@ -1706,12 +1918,12 @@ func TestRootHandler_serveBlogFeed(t *testing.T) {
 	t.Run("200 RSS with valid XML", func(t *testing.T) {
 		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
 
-		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, nil).Return(now, etag, nil)
-		mockBlogService.EXPECT().BuildFeed(gomock.Any(), workspace.ID, nil).Return(feedPayload, nil)
+		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, "", nil).Return(now, etag, nil)
+		mockBlogService.EXPECT().BuildFeed(gomock.Any(), workspace.ID, "", nil).Return(feedPayload, nil)
 
 		req := httptest.NewRequest("GET", "/feed.xml", nil)
 		w := httptest.NewRecorder()
-		handler.serveBlogFeed(w, req, workspace, nil, feedFormatRSS)
+		handler.serveBlogFeed(w, req, workspace, "", nil, feedFormatRSS)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Equal(t, "application/rss+xml; charset=utf-8", w.Header().Get("Content-Type"))
@@ -1723,12 +1935,12 @@ func TestRootHandler_serveBlogFeed(t *testing.T) {
 	t.Run("200 JSON Feed", func(t *testing.T) {
 		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
 
-		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, nil).Return(now, etag, nil)
-		mockBlogService.EXPECT().BuildFeed(gomock.Any(), workspace.ID, nil).Return(feedPayload, nil)
+		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, "", nil).Return(now, etag, nil)
+		mockBlogService.EXPECT().BuildFeed(gomock.Any(), workspace.ID, "", nil).Return(feedPayload, nil)
 
 		req := httptest.NewRequest("GET", "/feed.json", nil)
 		w := httptest.NewRecorder()
-		handler.serveBlogFeed(w, req, workspace, nil, feedFormatJSON)
+		handler.serveBlogFeed(w, req, workspace, "", nil, feedFormatJSON)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Equal(t, "application/feed+json; charset=utf-8", w.Header().Get("Content-Type"))
@@ -1738,13 +1950,13 @@ func TestRootHandler_serveBlogFeed(t *testing.T) {
 	t.Run("304 on matching ETag", func(t *testing.T) {
 		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
 
-		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, nil).Return(now, etag, nil)
+		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, "", nil).Return(now, etag, nil)
 		// BuildFeed must NOT be called on the 304 path.
 
 		req := httptest.NewRequest("GET", "/feed.xml", nil)
 		req.Header.Set("If-None-Match", etag)
 		w := httptest.NewRecorder()
-		handler.serveBlogFeed(w, req, workspace, nil, feedFormatRSS)
+		handler.serveBlogFeed(w, req, workspace, "", nil, feedFormatRSS)
 
 		assert.Equal(t, http.StatusNotModified, w.Code)
 		assert.Equal(t, etag, w.Header().Get("ETag"))
@@ -1755,12 +1967,12 @@ func TestRootHandler_serveBlogFeed(t *testing.T) {
 	t.Run("304 on If-Modified-Since", func(t *testing.T) {
 		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
 
-		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, nil).Return(now, etag, nil)
+		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, "", nil).Return(now, etag, nil)
 
 		req := httptest.NewRequest("GET", "/feed.xml", nil)
 		req.Header.Set("If-Modified-Since", now.Add(time.Hour).UTC().Format(http.TimeFormat))
 		w := httptest.NewRecorder()
-		handler.serveBlogFeed(w, req, workspace, nil, feedFormatRSS)
+		handler.serveBlogFeed(w, req, workspace, "", nil, feedFormatRSS)
 
 		assert.Equal(t, http.StatusNotModified, w.Code)
 	})
@@ -1770,7 +1982,7 @@ func TestRootHandler_serveBlogFeed(t *testing.T) {
 
 		req := httptest.NewRequest("POST", "/feed.xml", nil)
 		w := httptest.NewRecorder()
-		handler.serveBlogFeed(w, req, workspace, nil, feedFormatRSS)
+		handler.serveBlogFeed(w, req, workspace, "", nil, feedFormatRSS)
 
 		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
 	})
@@ -1778,12 +1990,12 @@ func TestRootHandler_serveBlogFeed(t *testing.T) {
 	t.Run("HEAD returns headers but empty body", func(t *testing.T) {
 		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
 
-		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, nil).Return(now, etag, nil)
-		mockBlogService.EXPECT().BuildFeed(gomock.Any(), workspace.ID, nil).Return(feedPayload, nil)
+		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, "", nil).Return(now, etag, nil)
+		mockBlogService.EXPECT().BuildFeed(gomock.Any(), workspace.ID, "", nil).Return(feedPayload, nil)
 
 		req := httptest.NewRequest("HEAD", "/feed.xml", nil)
 		w := httptest.NewRecorder()
-		handler.serveBlogFeed(w, req, workspace, nil, feedFormatRSS)
+		handler.serveBlogFeed(w, req, workspace, "", nil, feedFormatRSS)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Equal(t, etag, w.Header().Get("ETag"))
@@ -1794,12 +2006,12 @@ func TestRootHandler_serveBlogFeed(t *testing.T) {
 		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
 		slug := "tech"
 
-		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, &slug).Return(now, etag, nil)
-		mockBlogService.EXPECT().BuildFeed(gomock.Any(), workspace.ID, &slug).Return(feedPayload, nil)
+		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, "", &slug).Return(now, etag, nil)
+		mockBlogService.EXPECT().BuildFeed(gomock.Any(), workspace.ID, "", &slug).Return(feedPayload, nil)
 
 		req := httptest.NewRequest("GET", "/tech/feed.xml", nil)
 		w := httptest.NewRecorder()
-		handler.serveBlogFeed(w, req, workspace, &slug, feedFormatRSS)
+		handler.serveBlogFeed(w, req, workspace, "", &slug, feedFormatRSS)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
@@ -1807,12 +2019,12 @@ func TestRootHandler_serveBlogFeed(t *testing.T) {
 	t.Run("Cache-Control header set correctly", func(t *testing.T) {
 		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
 
-		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, nil).Return(now, etag, nil)
-		mockBlogService.EXPECT().BuildFeed(gomock.Any(), workspace.ID, nil).Return(feedPayload, nil)
+		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, "", nil).Return(now, etag, nil)
+		mockBlogService.EXPECT().BuildFeed(gomock.Any(), workspace.ID, "", nil).Return(feedPayload, nil)
 
 		req := httptest.NewRequest("GET", "/feed.xml", nil)
 		w := httptest.NewRecorder()
-		handler.serveBlogFeed(w, req, workspace, nil, feedFormatRSS)
+		handler.serveBlogFeed(w, req, workspace, "", nil, feedFormatRSS)
 
 		assert.Equal(t, "public, max-age=0, s-maxage=300, must-revalidate", w.Header().Get("Cache-Control"))
 	})
@@ -1820,13 +2032,13 @@ func TestRootHandler_serveBlogFeed(t *testing.T) {
 	t.Run("gzip response when Accept-Encoding set", func(t *testing.T) {
 		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
 
-		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, nil).Return(now, etag, nil)
-		mockBlogService.EXPECT().BuildFeed(gomock.Any(), workspace.ID, nil).Return(feedPayload, nil)
+		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, "", nil).Return(now, etag, nil)
+		mockBlogService.EXPECT().BuildFeed(gomock.Any(), workspace.ID, "", nil).Return(feedPayload, nil)
 
 		req := httptest.NewRequest("GET", "/feed.xml", nil)
 		req.Header.Set("Accept-Encoding", "gzip")
 		w := httptest.NewRecorder()
-		handler.serveBlogFeed(w, req, workspace, nil, feedFormatRSS)
+		handler.serveBlogFeed(w, req, workspace, "", nil, feedFormatRSS)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
@@ -1843,13 +2055,401 @@ func TestRootHandler_serveBlogFeed(t *testing.T) {
 	t.Run("GetFeedFingerprint error returns 500", func(t *testing.T) {
 		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
 
-		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, nil).
+		mockBlogService.EXPECT().GetFeedFingerprint(gomock.Any(), workspace.ID, "", nil).
 			Return(time.Time{}, "", assert.AnError)
 
 		req := httptest.NewRequest("GET", "/feed.xml", nil)
 		w := httptest.NewRecorder()
-		handler.serveBlogFeed(w, req, workspace, nil, feedFormatRSS)
+		handler.serveBlogFeed(w, req, workspace, "", nil, feedFormatRSS)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 }
+
+func TestRootHandler_serveBlogSearch(t *testing.T) {
+	t.Run("renders the search page", func(t *testing.T) {
+		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
+
+		mockBlogService.EXPECT().
+			RenderSearchPage(gomock.Any(), workspace.ID, "product launch", 2, nil).
+			Return("<html><body>Results</body></html>", nil)
+
+		req := httptest.NewRequest("GET", "/search?q=product+launch&page=2", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, "noindex, follow", w.Header().Get("X-Robots-Tag"))
+		assert.Equal(t, "BYPASS", w.Header().Get("X-Cache"))
+		assert.Equal(t, "<html><body>Results</body></html>", w.Body.String())
+	})
+
+	t.Run("invalid page parameter redirects", func(t *testing.T) {
+		_, _, _, workspace, handler := setupBlogHandlerTest(t)
+
+		req := httptest.NewRequest("GET", "/search?q=launch&page=0", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "/search?q=launch", w.Header().Get("Location"))
+	})
+
+	t.Run("page out of range", func(t *testing.T) {
+		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
+
+		mockBlogService.EXPECT().
+			RenderSearchPage(gomock.Any(), workspace.ID, "launch", 9, nil).
+			Return("", &domain.BlogRenderError{Code: domain.ErrCodePostNotFound, Message: "Page 9 does not exist"})
+
+		req := httptest.NewRequest("GET", "/search?q=launch&page=9", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestRootHandler_serveBlogSearchJSON(t *testing.T) {
+	t.Run("returns the ranked results", func(t *testing.T) {
+		mockBlogService, _, _, workspace, handler := setupBlogHandlerTest(t)
+
+		publishedAt := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+		mockBlogService.EXPECT().
+			SearchPublicPosts(gomock.Any(), &domain.BlogSearchRequest{Query: "launch", Page: 1, Limit: 5, Offset: 0}).
+			Return(&domain.BlogSearchResponse{
+				Query: "launch",
+				Results: []*domain.BlogSearchResult{{
+					Post: &domain.BlogPost{
+						ID:          "post-1",
+						Slug:        "launch-day",
+						Settings:    domain.BlogPostSettings{Title: "Launch day"},
+						PublishedAt: &publishedAt,
+					},
+					CategorySlug:   "news",
+					TitleHighlight: "<mark>Launch</mark> day",
+					Snippet:        "We <mark>launched</mark>",
+				}},
+				TotalCount:  1,
+				CurrentPage: 1,
+				TotalPages:  1,
+			}, nil)
+
+		req := httptest.NewRequest("GET", "/search.json?q=launch&limit=5", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "launch", body["query"])
+		assert.Equal(t, float64(1), body["total_count"])
+		results := body["results"].([]interface{})
+		require.Len(t, results, 1)
+		result := results[0].(map[string]interface{})
+		assert.Equal(t, "/news/launch-day", result["url"])
+		assert.Equal(t, "<mark>Launch</mark> day", result["title_highlight"])
+		assert.Equal(t, "We <mark>launched</mark>", result["snippet"])
+	})
+
+	t.Run("requires a query", func(t *testing.T) {
+		_, _, _, workspace, handler := setupBlogHandlerTest(t)
+
+		req := httptest.NewRequest("GET", "/search.json", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "query is required")
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		_, _, _, workspace, handler := setupBlogHandlerTest(t)
+
+		req := httptest.NewRequest("GET", "/search.json?q=launch&limit=many", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestRootHandler_serveBlogComments(t *testing.T) {
+	setup := func(t *testing.T) (*mocks.MockBlogCommentService, *domain.Workspace, *RootHandler) {
+		_, _, _, workspace, handler := setupBlogHandlerTest(t)
+		mockCommentService := mocks.NewMockBlogCommentService(gomock.NewController(t))
+		handler.blogCommentService = mockCommentService
+		return mockCommentService, workspace, handler
+	}
+
+	t.Run("lists the approved comments of a post", func(t *testing.T) {
+		mockCommentService, workspace, handler := setup(t)
+
+		mockCommentService.EXPECT().
+			ListPublicComments(gomock.Any(), workspace.ID, "post-1").
+			Return(&domain.PublicBlogCommentsResponse{
+				Comments:  []*domain.PublicBlogComment{{ID: "c1", Name: "Reader", Content: "Great post"}},
+				Reactions: map[string]int{"like": 2},
+			}, nil)
+
+		req := httptest.NewRequest("GET", "/comments.json?post_id=post-1", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		assert.NotContains(t, w.Body.String(), "email")
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Len(t, body["comments"], 1)
+		assert.Equal(t, float64(2), body["reactions"].(map[string]interface{})["like"])
+	})
+
+	t.Run("creates a comment and hides spam from its author", func(t *testing.T) {
+		mockCommentService, workspace, handler := setup(t)
+
+		mockCommentService.EXPECT().
+			CreatePublicComment(gomock.Any(), workspace.ID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, req *domain.CreateBlogCommentRequest) (*domain.BlogComment, error) {
+				assert.Equal(t, "reader@example.com", req.Email)
+				assert.Equal(t, "1.2.3.4", req.IPAddress)
+				return &domain.BlogComment{ID: "c1", Status: domain.BlogCommentStatusSpam}, nil
+			})
+
+		body := `{"post_id":"post-1","email":"Reader@example.com","name":"Reader","content":"Great post"}`
+		req := httptest.NewRequest("POST", "/comments.json", strings.NewReader(body))
+		req.Host = "example.com"
+		req.Header.Set("X-Forwarded-For", "1.2.3.4")
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"pending"`)
+	})
+
+	t.Run("maps validation errors to bad request", func(t *testing.T) {
+		mockCommentService, workspace, handler := setup(t)
+
+		mockCommentService.EXPECT().
+			CreatePublicComment(gomock.Any(), workspace.ID, gomock.Any()).
+			Return(nil, domain.NewValidationError("invalid email verification"))
+
+		body := `{"post_id":"post-1","email":"reader@example.com","email_hmac":"forged","name":"Reader","content":"Great post"}`
+		req := httptest.NewRequest("POST", "/comments.json", strings.NewReader(body))
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid email verification")
+	})
+
+	t.Run("rate limits comments by email", func(t *testing.T) {
+		_, workspace, handler := setup(t)
+
+		rateLimiter := ratelimiter.NewRateLimiter()
+		t.Cleanup(rateLimiter.Stop)
+		rateLimiter.SetPolicy("comment:ip", 10, time.Minute)
+		rateLimiter.SetPolicy("comment:email", 1, time.Minute)
+		rateLimiter.Allow("comment:email", "reader@example.com")
+		handler.rateLimiter = rateLimiter
+
+		body := `{"post_id":"post-1","email":"reader@example.com","name":"Reader","content":"Great post"}`
+		req := httptest.NewRequest("POST", "/comments.json", strings.NewReader(body))
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	})
+
+	t.Run("verification redirects to the comment", func(t *testing.T) {
+		mockCommentService, workspace, handler := setup(t)
+
+		mockCommentService.EXPECT().
+			VerifyPublicComment(gomock.Any(), workspace.ID, &domain.VerifyBlogCommentRequest{
+				CommentID: "c1",
+				Email:     "reader@example.com",
+				EmailHMAC: "hmac",
+			}).
+			Return("https://example.com/news/launch#comment-c1", nil)
+
+		req := httptest.NewRequest("GET", "/comments/verify?comment_id=c1&email=reader%40example.com&email_hmac=hmac", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusSeeOther, w.Code)
+		assert.Equal(t, "https://example.com/news/launch#comment-c1", w.Header().Get("Location"))
+	})
+
+	t.Run("reacts to a post", func(t *testing.T) {
+		mockCommentService, workspace, handler := setup(t)
+
+		mockCommentService.EXPECT().
+			ReactToPost(gomock.Any(), workspace.ID, gomock.Any()).
+			Return(map[string]int{"love": 1}, nil)
+
+		body := `{"post_id":"post-1","reaction":"love","email":"reader@example.com","email_hmac":"hmac"}`
+		req := httptest.NewRequest("POST", "/reactions.json", strings.NewReader(body))
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"love":1`)
+	})
+
+	t.Run("unknown posts are not found", func(t *testing.T) {
+		mockCommentService, workspace, handler := setup(t)
+
+		mockCommentService.EXPECT().
+			ListPublicComments(gomock.Any(), workspace.ID, "missing").
+			Return(nil, &domain.ErrNotFound{Entity: "blog post", ID: "missing"})
+
+		req := httptest.NewRequest("GET", "/comments.json?post_id=missing", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestRootHandler_serveBlogAccess(t *testing.T) {
+	setup := func(t *testing.T) (*mocks.MockBlogService, cache.Cache, *domain.Workspace, *RootHandler) {
+		mockBlogService, _, testCache, workspace, handler := setupBlogHandlerTest(t)
+		workspace.Settings.SecretKey = "secret"
+		workspace.Settings.BlogSettings.Access = &domain.BlogAccessSettings{CookieDays: 7}
+		return mockBlogService, testCache, workspace, handler
+	}
+
+	t.Run("requests a magic link without disclosing subscribers", func(t *testing.T) {
+		mockBlogService, _, workspace, handler := setup(t)
+
+		mockBlogService.EXPECT().
+			RequestBlogAccess(gomock.Any(), workspace.ID, &domain.BlogAccessRequest{Email: "reader@example.com", PostID: "post-1"}).
+			Return(nil)
+
+		req := httptest.NewRequest("POST", "/access.json", strings.NewReader(`{"email":"Reader@example.com","post_id":"post-1"}`))
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"success":true`)
+	})
+
+	t.Run("magic link sets the access cookie and redirects to the post", func(t *testing.T) {
+		_, _, workspace, handler := setup(t)
+
+		token := domain.SignBlogAccessToken("reader@example.com", "secret", time.Now().Add(time.Hour))
+		req := httptest.NewRequest("GET", "/access?token="+url.QueryEscape(token)+"&redirect=%2Fpremium%2Fsecret-plan", nil)
+		req.Host = "example.com"
+		req.Header.Set("X-Forwarded-Proto", "https")
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusSeeOther, w.Code)
+		assert.Equal(t, "/premium/secret-plan", w.Header().Get("Location"))
+
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, domain.BlogAccessCookieName, cookies[0].Name)
+		assert.True(t, cookies[0].HttpOnly)
+		assert.True(t, cookies[0].Secure)
+		assert.Equal(t, 7*24*60*60, cookies[0].MaxAge)
+		email, ok := domain.VerifyBlogAccessToken(cookies[0].Value, "secret", time.Now().Add(6*24*time.Hour))
+		assert.True(t, ok)
+		assert.Equal(t, "reader@example.com", email)
+	})
+
+	t.Run("magic link never redirects off the blog", func(t *testing.T) {
+		_, _, workspace, handler := setup(t)
+
+		token := domain.SignBlogAccessToken("reader@example.com", "secret", time.Now().Add(time.Hour))
+		req := httptest.NewRequest("GET", "/access?token="+url.QueryEscape(token)+"&redirect=%2F%2Fevil.example.com", nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusSeeOther, w.Code)
+		assert.Equal(t, "/", w.Header().Get("Location"))
+	})
+
+	t.Run("expired magic links are rejected", func(t *testing.T) {
+		_, _, workspace, handler := setup(t)
+
+		token := domain.SignBlogAccessToken("reader@example.com", "secret", time.Now().Add(-time.Minute))
+		req := httptest.NewRequest("GET", "/access?token="+url.QueryEscape(token), nil)
+		req.Host = "example.com"
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, w.Result().Cookies())
+	})
+
+	t.Run("subscribers bypass the post cache", func(t *testing.T) {
+		mockBlogService, testCache, workspace, handler := setup(t)
+
+		testCache.Set("example.com:/premium/secret-plan", "<html>teaser</html>", time.Minute)
+		mockBlogService.EXPECT().
+			GetPublicPostByCategoryAndSlug(gomock.Any(), "", "premium", "secret-plan").
+			Return(&domain.BlogPost{ID: "post-1"}, nil)
+		mockBlogService.EXPECT().
+			RenderPostPage(gomock.Any(), workspace.ID, "", "premium", "secret-plan", nil).
+			DoAndReturn(func(ctx context.Context, _, _, _, _ string, _ *int) (string, error) {
+				viewer := domain.BlogViewerFromContext(ctx)
+				require.NotNil(t, viewer)
+				assert.Equal(t, "reader@example.com", viewer.Email)
+				return "<html>full post</html>", nil
+			})
+
+		req := httptest.NewRequest("GET", "/premium/secret-plan", nil)
+		req.Host = "example.com"
+		req.AddCookie(&http.Cookie{
+			Name:  domain.BlogAccessCookieName,
+			Value: domain.SignBlogAccessToken("reader@example.com", "secret", time.Now().Add(time.Hour)),
+		})
+		w := httptest.NewRecorder()
+
+		handler.serveBlog(w, req, workspace)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "<html>full post</html>", w.Body.String())
+		assert.Equal(t, "private, no-store", w.Header().Get("Cache-Control"))
+
+		cached, _ := testCache.Get("example.com:/premium/secret-plan")
+		assert.Equal(t, "<html>teaser</html>", cached)
+	})
+}
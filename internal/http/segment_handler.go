@@ -38,6 +38,7 @@ func (h *SegmentHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/api/segments.rebuild", requireAuth(http.HandlerFunc(h.handleRebuild)))
 	mux.Handle("/api/segments.preview", requireAuth(http.HandlerFunc(h.handlePreview)))
 	mux.Handle("/api/segments.contacts", requireAuth(http.HandlerFunc(h.handleGetContacts)))
+	mux.Handle("/api/segments.history", requireAuth(http.HandlerFunc(h.handleHistory)))
 }
 
 func (h *SegmentHandler) handleList(w http.ResponseWriter, r *http.Request) {
@@ -309,3 +310,36 @@ func (h *SegmentHandler) handleGetContacts(w http.ResponseWriter, r *http.Reques
 		"offset": offset,
 	})
 }
+
+func (h *SegmentHandler) handleHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.GetSegmentHistoryRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, _, err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	history, err := h.service.GetSegmentHistory(r.Context(), &req)
+	if err != nil {
+		if _, ok := err.(*domain.ErrSegmentNotFound); ok {
+			WriteJSONError(w, "Segment not found", http.StatusNotFound)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to get segment history")
+		WriteJSONError(w, "Failed to get segment history", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"history": history,
+	})
+}
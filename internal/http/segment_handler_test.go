@@ -85,6 +85,7 @@ func TestSegmentHandler_RegisterRoutes(t *testing.T) {
 		"/api/segments.rebuild",
 		"/api/segments.preview",
 		"/api/segments.contacts",
+		"/api/segments.history",
 	}
 
 	for _, endpoint := range endpoints {
@@ -1110,3 +1111,124 @@ func TestSegmentHandler_HandleGetContacts(t *testing.T) {
 		})
 	}
 }
+
+func TestSegmentHandler_HandleHistory(t *testing.T) {
+	testCases := []struct {
+		name             string
+		method           string
+		queryParams      url.Values
+		setupMock        func(*mocks.MockSegmentService)
+		expectedStatus   int
+		validateResponse func(*testing.T, map[string]interface{})
+	}{
+		{
+			name:   "Get History Success",
+			method: http.MethodGet,
+			queryParams: url.Values{
+				"workspace_id": []string{"workspace123"},
+				"segment_id":   []string{"segment1"},
+				"from":         []string{"2026-01-01"},
+				"to":           []string{"2026-01-31"},
+			},
+			setupMock: func(m *mocks.MockSegmentService) {
+				m.EXPECT().GetSegmentHistory(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ interface{}, req *domain.GetSegmentHistoryRequest) ([]*domain.SegmentHistoryEntry, error) {
+						assert.Equal(t, "2026-01-01", req.From)
+						assert.Equal(t, "2026-01-31", req.To)
+						return []*domain.SegmentHistoryEntry{
+							{SegmentID: "segment1", Day: "2026-01-01", UsersCount: 10, JoinedCount: 3, LeftCount: 1},
+							{SegmentID: "segment1", Day: "2026-01-02", UsersCount: 12, JoinedCount: 2, LeftCount: 0},
+						}, nil
+					},
+				)
+			},
+			expectedStatus: http.StatusOK,
+			validateResponse: func(t *testing.T, response map[string]interface{}) {
+				history, ok := response["history"].([]interface{})
+				assert.True(t, ok)
+				assert.Len(t, history, 2)
+				first := history[0].(map[string]interface{})
+				assert.Equal(t, "2026-01-01", first["day"])
+				assert.Equal(t, float64(10), first["users_count"])
+				assert.Equal(t, float64(3), first["joined_count"])
+				assert.Equal(t, float64(1), first["left_count"])
+			},
+		},
+		{
+			name:   "Get History Not Found",
+			method: http.MethodGet,
+			queryParams: url.Values{
+				"workspace_id": []string{"workspace123"},
+				"segment_id":   []string{"nonexistent"},
+			},
+			setupMock: func(m *mocks.MockSegmentService) {
+				m.EXPECT().GetSegmentHistory(gomock.Any(), gomock.Any()).Return(
+					nil,
+					&domain.ErrSegmentNotFound{Message: "segment not found"},
+				)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Get History Service Error",
+			method: http.MethodGet,
+			queryParams: url.Values{
+				"workspace_id": []string{"workspace123"},
+				"segment_id":   []string{"segment1"},
+			},
+			setupMock: func(m *mocks.MockSegmentService) {
+				m.EXPECT().GetSegmentHistory(gomock.Any(), gomock.Any()).Return(nil, errors.New("service error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:   "Invalid Date",
+			method: http.MethodGet,
+			queryParams: url.Values{
+				"workspace_id": []string{"workspace123"},
+				"segment_id":   []string{"segment1"},
+				"from":         []string{"01/01/2026"},
+			},
+			setupMock:      func(m *mocks.MockSegmentService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing Segment ID",
+			method:         http.MethodGet,
+			queryParams:    url.Values{"workspace_id": []string{"workspace123"}},
+			setupMock:      func(m *mocks.MockSegmentService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Method Not Allowed",
+			method: http.MethodPost,
+			queryParams: url.Values{
+				"workspace_id": []string{"workspace123"},
+				"segment_id":   []string{"segment1"},
+			},
+			setupMock:      func(m *mocks.MockSegmentService) {},
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService, _, handler := setupSegmentHandlerTest(t)
+			tc.setupMock(mockService)
+
+			req := httptest.NewRequest(tc.method, "/api/segments.history?"+tc.queryParams.Encode(), nil)
+			rr := httptest.NewRecorder()
+
+			handler.handleHistory(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusOK && tc.validateResponse != nil {
+				var response map[string]interface{}
+				err := json.NewDecoder(rr.Body).Decode(&response)
+				assert.NoError(t, err)
+				tc.validateResponse(t, response)
+			}
+		})
+	}
+}
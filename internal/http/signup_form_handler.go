@@ -0,0 +1,536 @@
+package http
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/http/middleware"
+	"github.com/Notifuse/notifuse/pkg/botdetection"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/Notifuse/notifuse/pkg/ratelimiter"
+)
+
+//go:embed templates/signup_form.html
+var signupFormPageHTML string
+
+//go:embed templates/signup_form_pow.js
+var signupFormPowJS string
+
+//go:embed templates/signup_form_embed.js
+var signupFormEmbedJS string
+
+var signupFormPageTemplate = template.Must(template.New("signup_form").Parse(signupFormPageHTML))
+
+// maxSignupFormBodyBytes caps public submissions, a form has at most 20 short fields
+const maxSignupFormBodyBytes = 64 * 1024
+
+// signupFormCaptchaWidgets maps third-party captcha providers to their browser widget
+var signupFormCaptchaWidgets = map[string]struct {
+	ScriptURL string
+	Class     string
+}{
+	domain.SignupFormCaptchaTurnstile: {ScriptURL: "https://challenges.cloudflare.com/turnstile/v0/api.js", Class: "cf-turnstile"},
+	domain.SignupFormCaptchaHCaptcha:  {ScriptURL: "https://js.hcaptcha.com/1/api.js", Class: "h-captcha"},
+	domain.SignupFormCaptchaRecaptcha: {ScriptURL: "https://www.google.com/recaptcha/api.js", Class: "g-recaptcha"},
+}
+
+type SignupFormHandler struct {
+	service      domain.SignupFormService
+	logger       logger.Logger
+	getJWTSecret func() ([]byte, error)
+	rateLimiter  *ratelimiter.RateLimiter
+	apiEndpoint  string
+}
+
+func NewSignupFormHandler(service domain.SignupFormService, getJWTSecret func() ([]byte, error), logger logger.Logger, rateLimiter *ratelimiter.RateLimiter, apiEndpoint string) *SignupFormHandler {
+	return &SignupFormHandler{
+		service:      service,
+		logger:       logger,
+		getJWTSecret: getJWTSecret,
+		rateLimiter:  rateLimiter,
+		apiEndpoint:  strings.TrimSuffix(apiEndpoint, "/"),
+	}
+}
+
+func (h *SignupFormHandler) RegisterRoutes(mux *http.ServeMux) {
+	// Create auth middleware
+	authMiddleware := middleware.NewAuthMiddleware(h.getJWTSecret)
+	requireAuth := authMiddleware.RequireAuth()
+
+	// Register RPC-style endpoints with dot notation
+	mux.Handle("/api/signupForms.list", requireAuth(http.HandlerFunc(h.handleList)))
+	mux.Handle("/api/signupForms.get", requireAuth(http.HandlerFunc(h.handleGet)))
+	mux.Handle("/api/signupForms.create", requireAuth(http.HandlerFunc(h.handleCreate)))
+	mux.Handle("/api/signupForms.update", requireAuth(http.HandlerFunc(h.handleUpdate)))
+	mux.Handle("/api/signupForms.delete", requireAuth(http.HandlerFunc(h.handleDelete)))
+	mux.Handle("/api/signupForms.stats", requireAuth(http.HandlerFunc(h.handleStats)))
+
+	// Public hosted page, embed script, proof-of-work challenge and submission:
+	// /forms/{workspace_id}/{form_id}[/embed.js|/challenge|/submit]
+	mux.HandleFunc("/forms/", h.handlePublic)
+}
+
+// writeSignupFormError maps service errors of the authenticated endpoints to HTTP responses
+func (h *SignupFormHandler) writeSignupFormError(w http.ResponseWriter, err error, message string) {
+	var permErr *domain.PermissionError
+	if errors.As(err, &permErr) {
+		WriteJSONError(w, permErr.Message, http.StatusForbidden)
+		return
+	}
+	var validationErr domain.ValidationError
+	if errors.As(err, &validationErr) {
+		WriteJSONError(w, validationErr.Message, http.StatusBadRequest)
+		return
+	}
+	var notFoundErr *domain.ErrSignupFormNotFound
+	if errors.As(err, &notFoundErr) {
+		WriteJSONError(w, "Signup form not found", http.StatusNotFound)
+		return
+	}
+	h.logger.WithField("error", err.Error()).Error(message)
+	WriteJSONError(w, message, http.StatusInternalServerError)
+}
+
+func (h *SignupFormHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.ListSignupFormsRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	forms, err := h.service.ListSignupForms(r.Context(), req.WorkspaceID)
+	if err != nil {
+		h.writeSignupFormError(w, err, "Failed to list signup forms")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"signup_forms": forms,
+	})
+}
+
+func (h *SignupFormHandler) handleGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.GetSignupFormRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	form, err := h.service.GetSignupForm(r.Context(), req.WorkspaceID, req.ID)
+	if err != nil {
+		h.writeSignupFormError(w, err, "Failed to get signup form")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"signup_form": form,
+	})
+}
+
+func (h *SignupFormHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.CreateSignupFormRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.CreateSignupForm(r.Context(), req.WorkspaceID, req.Form); err != nil {
+		h.writeSignupFormError(w, err, "Failed to create signup form")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"signup_form": req.Form,
+	})
+}
+
+func (h *SignupFormHandler) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.UpdateSignupFormRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.UpdateSignupForm(r.Context(), req.WorkspaceID, req.Form); err != nil {
+		h.writeSignupFormError(w, err, "Failed to update signup form")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"signup_form": req.Form,
+	})
+}
+
+func (h *SignupFormHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.DeleteSignupFormRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteSignupForm(r.Context(), req.WorkspaceID, req.ID); err != nil {
+		h.writeSignupFormError(w, err, "Failed to delete signup form")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+	})
+}
+
+func (h *SignupFormHandler) handleStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.GetSignupFormStatsRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := h.service.GetSignupFormStats(r.Context(), &req)
+	if err != nil {
+		h.writeSignupFormError(w, err, "Failed to get signup form stats")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, stats)
+}
+
+// formURL returns the absolute public URL of a form, with an optional action suffix
+func (h *SignupFormHandler) formURL(workspaceID string, formID string, action string) string {
+	url := h.apiEndpoint + "/forms/" + workspaceID + "/" + formID
+	if action != "" {
+		url += "/" + action
+	}
+	return url
+}
+
+func (h *SignupFormHandler) handlePublic(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/forms/"), "/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		http.NotFound(w, r)
+		return
+	}
+	workspaceID, formID := parts[0], parts[1]
+	action := ""
+	if len(parts) == 3 {
+		action = parts[2]
+	}
+
+	switch action {
+	case "":
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.handlePage(w, r, workspaceID, formID)
+	case "embed.js":
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.handleEmbed(w, r, workspaceID, formID)
+	case "challenge":
+		if r.Method != http.MethodGet {
+			WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.handleChallenge(w, r, workspaceID, formID)
+	case "submit":
+		if r.Method != http.MethodPost {
+			WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.handleSubmit(w, r, workspaceID, formID)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// signupFormPageData is the data of the hosted page template
+type signupFormPageData struct {
+	Form               *domain.SignupFormPublicConfig
+	TextColor          template.CSS
+	BackgroundColor    template.CSS
+	PrimaryColor       template.CSS
+	Success            bool
+	Error              string
+	SubmitURL          string
+	Values             *domain.SignupFormSubmission
+	CaptchaWidgetClass string
+	CaptchaScriptURL   string
+	ProofOfWorkJS      template.JS
+	ChallengeURL       string
+	UsesProofOfWork    bool
+}
+
+// signupFormColor returns a validated style color, or the fallback when unset
+func signupFormColor(color string, fallback string) template.CSS {
+	if color == "" {
+		color = fallback
+	}
+	// colors are validated as hex codes when the form is saved
+	return template.CSS(color)
+}
+
+func (h *SignupFormHandler) renderPage(w http.ResponseWriter, status int, config *domain.SignupFormPublicConfig, success bool, errorMessage string, values *domain.SignupFormSubmission) {
+	if values == nil {
+		values = &domain.SignupFormSubmission{}
+	}
+	if values.Fields == nil {
+		values.Fields = map[string]string{}
+	}
+
+	data := signupFormPageData{
+		Form:            config,
+		TextColor:       signupFormColor(config.Style.TextColor, "#262626"),
+		BackgroundColor: signupFormColor(config.Style.BackgroundColor, "#f5f5f5"),
+		PrimaryColor:    signupFormColor(config.Style.PrimaryColor, "#1677ff"),
+		Success:         success,
+		Error:           errorMessage,
+		SubmitURL:       h.formURL(config.WorkspaceID, config.ID, "submit"),
+		Values:          values,
+		ChallengeURL:    h.formURL(config.WorkspaceID, config.ID, "challenge"),
+		UsesProofOfWork: config.CaptchaProvider == domain.SignupFormCaptchaProofOfWork,
+	}
+	if widget, ok := signupFormCaptchaWidgets[config.CaptchaProvider]; ok {
+		data.CaptchaWidgetClass = widget.Class
+		data.CaptchaScriptURL = widget.ScriptURL
+	}
+	if data.UsesProofOfWork {
+		data.ProofOfWorkJS = template.JS(signupFormPowJS)
+	}
+
+	var body strings.Builder
+	if err := signupFormPageTemplate.Execute(&body, data); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to render signup form page")
+		http.Error(w, "Failed to render signup form", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(body.String()))
+}
+
+func (h *SignupFormHandler) handlePage(w http.ResponseWriter, r *http.Request, workspaceID string, formID string) {
+	success := r.URL.Query().Get("status") == "success"
+	countView := !success && !botdetection.IsBotUserAgent(r.UserAgent())
+
+	config, err := h.service.GetPublicSignupForm(r.Context(), workspaceID, formID, countView)
+	if err != nil {
+		h.writePublicPageError(w, err)
+		return
+	}
+
+	h.renderPage(w, http.StatusOK, config, success, "", nil)
+}
+
+func (h *SignupFormHandler) writePublicPageError(w http.ResponseWriter, err error) {
+	var notFoundErr *domain.ErrSignupFormNotFound
+	if errors.As(err, &notFoundErr) {
+		http.Error(w, "Signup form not found", http.StatusNotFound)
+		return
+	}
+	h.logger.WithField("error", err.Error()).Error("Failed to get public signup form")
+	http.Error(w, "Failed to load signup form", http.StatusInternalServerError)
+}
+
+func (h *SignupFormHandler) handleEmbed(w http.ResponseWriter, r *http.Request, workspaceID string, formID string) {
+	countView := !botdetection.IsBotUserAgent(r.UserAgent())
+
+	config, err := h.service.GetPublicSignupForm(r.Context(), workspaceID, formID, countView)
+	if err != nil {
+		h.writePublicPageError(w, err)
+		return
+	}
+
+	embedConfig, err := json.Marshal(struct {
+		*domain.SignupFormPublicConfig
+		SubmitURL    string `json:"submit_url"`
+		ChallengeURL string `json:"challenge_url"`
+	}{
+		SignupFormPublicConfig: config,
+		SubmitURL:              h.formURL(workspaceID, formID, "submit"),
+		ChallengeURL:           h.formURL(workspaceID, formID, "challenge"),
+	})
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to encode signup form config")
+		http.Error(w, "Failed to load signup form", http.StatusInternalServerError)
+		return
+	}
+
+	// json.Marshal escapes <, > and & so the config cannot close a surrounding script tag
+	script := strings.Replace(signupFormEmbedJS, "/*__CONFIG__*/null", string(embedConfig), 1)
+	if config.CaptchaProvider == domain.SignupFormCaptchaProofOfWork {
+		script = signupFormPowJS + "\n" + script
+	}
+
+	w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write([]byte(script))
+}
+
+func (h *SignupFormHandler) handleChallenge(w http.ResponseWriter, r *http.Request, workspaceID string, formID string) {
+	challenge, err := h.service.CreateSignupFormChallenge(r.Context(), workspaceID, formID)
+	if err != nil {
+		h.writeSignupFormError(w, err, "Failed to create challenge")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, challenge)
+}
+
+// checkSubmitRateLimit applies the subscribe rate limits to a form submission
+func (h *SignupFormHandler) checkSubmitRateLimit(w http.ResponseWriter, r *http.Request, email string) bool {
+	if h.rateLimiter == nil {
+		return true
+	}
+
+	if email != "" && !h.rateLimiter.Allow("subscribe:email", email) {
+		retryAfter := h.rateLimiter.GetRemainingWindow("subscribe:email", email)
+		w.Header().Set("Retry-After", fmt.Sprintf("%d", retryAfter))
+		h.logger.WithField("email", email).Warn("Signup form: Rate limit exceeded")
+		return false
+	}
+
+	clientIP := getClientIP(r)
+	if !h.rateLimiter.Allow("subscribe:ip", clientIP) {
+		retryAfter := h.rateLimiter.GetRemainingWindow("subscribe:ip", clientIP)
+		w.Header().Set("Retry-After", fmt.Sprintf("%d", retryAfter))
+		h.logger.WithField("ip", clientIP).Warn("Signup form: IP rate limit exceeded")
+		return false
+	}
+
+	return true
+}
+
+const signupFormRateLimitMessage = "Too many subscription attempts. Please try again in a few minutes."
+
+func (h *SignupFormHandler) handleSubmit(w http.ResponseWriter, r *http.Request, workspaceID string, formID string) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSignupFormBodyBytes)
+
+	// the embed script posts JSON, the hosted page posts a regular HTML form
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		h.handleSubmitJSON(w, r, workspaceID, formID)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form submission", http.StatusBadRequest)
+		return
+	}
+	submission := &domain.SignupFormSubmission{}
+	submission.FromForm(r.PostForm)
+
+	if !h.checkSubmitRateLimit(w, r, submission.Email) {
+		h.renderSubmitError(w, r, workspaceID, formID, http.StatusTooManyRequests, signupFormRateLimitMessage, submission)
+		return
+	}
+
+	ctx := withConsentContext(r, domain.ConsentContext{Source: domain.ConsentSourceForm, PageURL: submission.PageURL})
+	result, err := h.service.SubmitSignupForm(ctx, workspaceID, formID, submission)
+	if err != nil {
+		var rejectedErr *domain.SignupFormRejectedError
+		if errors.As(err, &rejectedErr) {
+			h.renderSubmitError(w, r, workspaceID, formID, http.StatusBadRequest, rejectedErr.Message, submission)
+			return
+		}
+		h.writePublicPageError(w, err)
+		return
+	}
+
+	redirectURL := result.RedirectURL
+	if redirectURL == "" {
+		redirectURL = h.formURL(workspaceID, formID, "") + "?status=success"
+	}
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
+
+// renderSubmitError re-renders the hosted page with the visitor's values and an error message
+func (h *SignupFormHandler) renderSubmitError(w http.ResponseWriter, r *http.Request, workspaceID string, formID string, status int, message string, submission *domain.SignupFormSubmission) {
+	config, err := h.service.GetPublicSignupForm(r.Context(), workspaceID, formID, false)
+	if err != nil {
+		h.writePublicPageError(w, err)
+		return
+	}
+	h.renderPage(w, status, config, false, message, submission)
+}
+
+func (h *SignupFormHandler) handleSubmitJSON(w http.ResponseWriter, r *http.Request, workspaceID string, formID string) {
+	var submission domain.SignupFormSubmission
+	if err := json.NewDecoder(r.Body).Decode(&submission); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if !h.checkSubmitRateLimit(w, r, submission.Email) {
+		WriteJSONError(w, signupFormRateLimitMessage, http.StatusTooManyRequests)
+		return
+	}
+
+	ctx := withConsentContext(r, domain.ConsentContext{Source: domain.ConsentSourceForm, PageURL: submission.PageURL})
+	result, err := h.service.SubmitSignupForm(ctx, workspaceID, formID, &submission)
+	if err != nil {
+		var rejectedErr *domain.SignupFormRejectedError
+		if errors.As(err, &rejectedErr) {
+			WriteJSONError(w, rejectedErr.Message, http.StatusBadRequest)
+			return
+		}
+		h.writeSignupFormError(w, err, "Failed to submit signup form")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,328 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSignupFormHandlerTest(t *testing.T) (*mocks.MockSignupFormService, *SignupFormHandler) {
+	ctrl := gomock.NewController(t)
+
+	mockService := mocks.NewMockSignupFormService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	jwtSecret := []byte("test-jwt-secret-key-for-testing-32bytes")
+	handler := NewSignupFormHandler(mockService, func() ([]byte, error) { return jwtSecret, nil }, mockLogger, nil, "https://api.example.com/")
+	return mockService, handler
+}
+
+func testSignupFormPublicConfig() *domain.SignupFormPublicConfig {
+	return &domain.SignupFormPublicConfig{
+		ID:          "form1",
+		WorkspaceID: "ws1",
+		Fields: []domain.SignupFormPublicField{
+			{Key: "first_name", Label: "First name", Type: "text", Required: true},
+			{Key: "attributes.plan", Label: "Plan", Type: "select", Options: []string{"free", "pro"}},
+		},
+		ConsentText:     "I agree to <b>emails</b>",
+		SuccessMessage:  "Thanks!",
+		Style:           domain.SignupFormStyle{Title: "Join us", ButtonText: "Subscribe", PrimaryColor: "#ff0000"},
+		CaptchaProvider: domain.SignupFormCaptchaProofOfWork,
+		HoneypotField:   domain.SignupFormHoneypotField,
+	}
+}
+
+func TestSignupFormHandler_RegisterRoutes(t *testing.T) {
+	_, handler := setupSignupFormHandlerTest(t)
+	mux := http.NewServeMux()
+	handler.RegisterRoutes(mux)
+
+	for _, route := range []string{
+		"/api/signupForms.list",
+		"/api/signupForms.get",
+		"/api/signupForms.create",
+		"/api/signupForms.update",
+		"/api/signupForms.delete",
+		"/api/signupForms.stats",
+		"/forms/ws1/form1",
+		"/forms/ws1/form1/submit",
+	} {
+		_, pattern := mux.Handler(&http.Request{URL: &url.URL{Path: route}})
+		assert.NotEmpty(t, pattern, "Route %s should be registered", route)
+	}
+}
+
+func TestSignupFormHandler_HandleCreate(t *testing.T) {
+	form := &domain.SignupForm{ID: "form1", Name: "Newsletter", ListIDs: []string{"news"}}
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(*mocks.MockSignupFormService)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			body: domain.CreateSignupFormRequest{WorkspaceID: "ws1", Form: form},
+			setupMock: func(m *mocks.MockSignupFormService) {
+				m.EXPECT().CreateSignupForm(gomock.Any(), "ws1", gomock.Any()).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Missing Form",
+			body:           domain.CreateSignupFormRequest{WorkspaceID: "ws1"},
+			setupMock:      func(m *mocks.MockSignupFormService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Validation Error",
+			body: domain.CreateSignupFormRequest{WorkspaceID: "ws1", Form: form},
+			setupMock: func(m *mocks.MockSignupFormService) {
+				m.EXPECT().CreateSignupForm(gomock.Any(), "ws1", gomock.Any()).Return(domain.NewValidationError("list news is not public"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Permission Denied",
+			body: domain.CreateSignupFormRequest{WorkspaceID: "ws1", Form: form},
+			setupMock: func(m *mocks.MockSignupFormService) {
+				m.EXPECT().CreateSignupForm(gomock.Any(), "ws1", gomock.Any()).Return(domain.NewPermissionError(
+					domain.PermissionResourceLists, domain.PermissionTypeWrite, "Insufficient permissions: write access to lists required",
+				))
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "Service Error",
+			body: domain.CreateSignupFormRequest{WorkspaceID: "ws1", Form: form},
+			setupMock: func(m *mocks.MockSignupFormService) {
+				m.EXPECT().CreateSignupForm(gomock.Any(), "ws1", gomock.Any()).Return(errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService, handler := setupSignupFormHandlerTest(t)
+			tt.setupMock(mockService)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/api/signupForms.create", bytes.NewBuffer(body))
+			rr := httptest.NewRecorder()
+			handler.handleCreate(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestSignupFormHandler_HandleGet(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		mockService, handler := setupSignupFormHandlerTest(t)
+		mockService.EXPECT().GetSignupForm(gomock.Any(), "ws1", "missing").Return(nil, &domain.ErrSignupFormNotFound{Message: "signup form not found"})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/signupForms.get?workspace_id=ws1&id=missing", nil)
+		rr := httptest.NewRecorder()
+		handler.handleGet(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		mockService, handler := setupSignupFormHandlerTest(t)
+		mockService.EXPECT().GetSignupFormStats(gomock.Any(), &domain.GetSignupFormStatsRequest{WorkspaceID: "ws1", ID: "form1", From: "2026-01-01"}).
+			Return(&domain.SignupFormStatsResponse{Totals: &domain.SignupFormStatsEntry{Views: 3}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/signupForms.stats?workspace_id=ws1&id=form1&from=2026-01-01", nil)
+		rr := httptest.NewRecorder()
+		handler.handleStats(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"views":3`)
+	})
+}
+
+func TestSignupFormHandler_HostedPage(t *testing.T) {
+	t.Run("renders the form and counts the view", func(t *testing.T) {
+		mockService, handler := setupSignupFormHandlerTest(t)
+		mockService.EXPECT().GetPublicSignupForm(gomock.Any(), "ws1", "form1", true).Return(testSignupFormPublicConfig(), nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/forms/ws1/form1", nil)
+		req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7)")
+		rr := httptest.NewRecorder()
+		handler.handlePublic(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		assert.Contains(t, body, `action="https://api.example.com/forms/ws1/form1/submit"`)
+		assert.Contains(t, body, `name="first_name"`)
+		assert.Contains(t, body, `<option value="pro"`)
+		assert.Contains(t, body, "background: #ff0000")
+		assert.Contains(t, body, "notifuseSolveChallenge")
+		assert.Contains(t, body, "I agree to &lt;b&gt;emails&lt;/b&gt;")
+		assert.NotContains(t, body, "ZgotmplZ")
+	})
+
+	t.Run("bots do not count as views", func(t *testing.T) {
+		mockService, handler := setupSignupFormHandlerTest(t)
+		mockService.EXPECT().GetPublicSignupForm(gomock.Any(), "ws1", "form1", false).Return(testSignupFormPublicConfig(), nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/forms/ws1/form1", nil)
+		req.Header.Set("User-Agent", "Googlebot/2.1 (+http://www.google.com/bot.html)")
+		rr := httptest.NewRecorder()
+		handler.handlePublic(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("unknown form", func(t *testing.T) {
+		mockService, handler := setupSignupFormHandlerTest(t)
+		mockService.EXPECT().GetPublicSignupForm(gomock.Any(), "ws1", "nope", gomock.Any()).Return(nil, &domain.ErrSignupFormNotFound{Message: "signup form not found"})
+
+		req := httptest.NewRequest(http.MethodGet, "/forms/ws1/nope", nil)
+		rr := httptest.NewRecorder()
+		handler.handlePublic(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		_, handler := setupSignupFormHandlerTest(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/forms/ws1", nil)
+		rr := httptest.NewRecorder()
+		handler.handlePublic(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestSignupFormHandler_Embed(t *testing.T) {
+	mockService, handler := setupSignupFormHandlerTest(t)
+	config := testSignupFormPublicConfig()
+	config.Style.Title = "</script><script>alert(1)</script>"
+	mockService.EXPECT().GetPublicSignupForm(gomock.Any(), "ws1", "form1", true).Return(config, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/forms/ws1/form1/embed.js", nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+	rr := httptest.NewRecorder()
+	handler.handlePublic(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/javascript; charset=utf-8", rr.Header().Get("Content-Type"))
+	body := rr.Body.String()
+	assert.NotContains(t, body, "/*__CONFIG__*/")
+	assert.Contains(t, body, `"submit_url":"https://api.example.com/forms/ws1/form1/submit"`)
+	assert.Contains(t, body, "notifuseSolveChallenge")
+	assert.NotContains(t, body, "</script>")
+}
+
+func TestSignupFormHandler_Submit(t *testing.T) {
+	t.Run("json submission", func(t *testing.T) {
+		mockService, handler := setupSignupFormHandlerTest(t)
+		mockService.EXPECT().SubmitSignupForm(gomock.Any(), "ws1", "form1", gomock.Any()).
+			DoAndReturn(func(ctx interface{}, workspaceID, id string, submission *domain.SignupFormSubmission) (*domain.SignupFormSubmitResult, error) {
+				assert.Equal(t, "jane@example.com", submission.Email)
+				assert.Equal(t, "Jane", submission.Fields["first_name"])
+				return &domain.SignupFormSubmitResult{Message: "Thanks!"}, nil
+			})
+
+		body := `{"email":"jane@example.com","fields":{"first_name":"Jane"},"consent":true}`
+		req := httptest.NewRequest(http.MethodPost, "/forms/ws1/form1/submit", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		handler.handlePublic(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Thanks!")
+	})
+
+	t.Run("json rejection", func(t *testing.T) {
+		mockService, handler := setupSignupFormHandlerTest(t)
+		mockService.EXPECT().SubmitSignupForm(gomock.Any(), "ws1", "form1", gomock.Any()).
+			Return(nil, &domain.SignupFormRejectedError{Reason: domain.SignupFormMetricDisposableBlocked, Message: "Disposable email addresses are not allowed"})
+
+		req := httptest.NewRequest(http.MethodPost, "/forms/ws1/form1/submit", strings.NewReader(`{"email":"bot@mailinator.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		handler.handlePublic(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Disposable email addresses are not allowed")
+	})
+
+	t.Run("hosted form redirects on success", func(t *testing.T) {
+		mockService, handler := setupSignupFormHandlerTest(t)
+		mockService.EXPECT().SubmitSignupForm(gomock.Any(), "ws1", "form1", gomock.Any()).
+			Return(&domain.SignupFormSubmitResult{Message: "Thanks!"}, nil)
+
+		form := url.Values{"email": {"jane@example.com"}, "first_name": {"Jane"}, "consent": {"true"}}
+		req := httptest.NewRequest(http.MethodPost, "/forms/ws1/form1/submit", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+		handler.handlePublic(rr, req)
+
+		assert.Equal(t, http.StatusSeeOther, rr.Code)
+		assert.Equal(t, "https://api.example.com/forms/ws1/form1?status=success", rr.Header().Get("Location"))
+	})
+
+	t.Run("hosted form re-renders rejections", func(t *testing.T) {
+		mockService, handler := setupSignupFormHandlerTest(t)
+		mockService.EXPECT().SubmitSignupForm(gomock.Any(), "ws1", "form1", gomock.Any()).
+			Return(nil, &domain.SignupFormRejectedError{Reason: domain.SignupFormMetricInvalid, Message: "First name is required"})
+		mockService.EXPECT().GetPublicSignupForm(gomock.Any(), "ws1", "form1", false).Return(testSignupFormPublicConfig(), nil)
+
+		form := url.Values{"email": {"jane@example.com"}}
+		req := httptest.NewRequest(http.MethodPost, "/forms/ws1/form1/submit", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+		handler.handlePublic(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "First name is required")
+		assert.Contains(t, rr.Body.String(), `value="jane@example.com"`)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		_, handler := setupSignupFormHandlerTest(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/forms/ws1/form1/submit", nil)
+		rr := httptest.NewRecorder()
+		handler.handlePublic(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
+
+func TestSignupFormHandler_Challenge(t *testing.T) {
+	mockService, handler := setupSignupFormHandlerTest(t)
+	mockService.EXPECT().CreateSignupFormChallenge(gomock.Any(), "ws1", "form1").
+		Return(&domain.SignupFormChallenge{Challenge: "abc", Difficulty: 16}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/forms/ws1/form1/challenge", nil)
+	rr := httptest.NewRecorder()
+	handler.handlePublic(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"challenge":"abc"`)
+}
@@ -35,6 +35,7 @@ func (h *TemplateBlockHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/api/templateBlocks.create", requireAuth(http.HandlerFunc(h.handleCreate)))
 	mux.Handle("/api/templateBlocks.update", requireAuth(http.HandlerFunc(h.handleUpdate)))
 	mux.Handle("/api/templateBlocks.delete", requireAuth(http.HandlerFunc(h.handleDelete)))
+	mux.Handle("/api/templateBlocks.usage", requireAuth(http.HandlerFunc(h.handleUsage)))
 }
 
 func (h *TemplateBlockHandler) handleList(w http.ResponseWriter, r *http.Request) {
@@ -183,6 +184,10 @@ func (h *TemplateBlockHandler) handleDelete(w http.ResponseWriter, r *http.Reque
 			WriteJSONError(w, "Template block not found", http.StatusNotFound)
 			return
 		}
+		if inUseErr, ok := err.(*domain.ErrTemplateBlockInUse); ok {
+			WriteJSONError(w, inUseErr.Error(), http.StatusConflict)
+			return
+		}
 		h.logger.WithField("error", err.Error()).Error("Failed to delete template block")
 		WriteJSONError(w, "Failed to delete template block", http.StatusInternalServerError)
 		return
@@ -192,3 +197,31 @@ func (h *TemplateBlockHandler) handleDelete(w http.ResponseWriter, r *http.Reque
 		"success": true,
 	})
 }
+
+func (h *TemplateBlockHandler) handleUsage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.GetTemplateBlockUsageRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	usage, err := h.service.GetTemplateBlockUsage(r.Context(), req.WorkspaceID, req.ID)
+	if err != nil {
+		if _, ok := err.(*domain.ErrTemplateBlockNotFound); ok {
+			WriteJSONError(w, "Template block not found", http.StatusNotFound)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to get template block usage")
+		WriteJSONError(w, "Failed to get template block usage", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"usage": usage,
+	})
+}
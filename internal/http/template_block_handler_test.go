@@ -593,6 +593,16 @@ func TestTemplateBlockHandler_HandleDelete(t *testing.T) {
 			expectBody:     false,
 			authenticate:   true,
 		},
+		{
+			name:        "Block In Use",
+			requestBody: validRequest,
+			setupMock: func(m *mocks.MockTemplateBlockService) {
+				m.EXPECT().DeleteTemplateBlock(gomock.Any(), workspaceID, blockID).Return(&domain.ErrTemplateBlockInUse{Message: "in use"})
+			},
+			expectedStatus: http.StatusConflict,
+			expectBody:     false,
+			authenticate:   true,
+		},
 		{
 			name:        "Service Error",
 			requestBody: validRequest,
@@ -653,3 +663,98 @@ func TestTemplateBlockHandler_HandleDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateBlockHandler_HandleUsage(t *testing.T) {
+	workspaceID := "workspace123"
+	blockID := uuid.New().String()
+
+	testCases := []struct {
+		name           string
+		queryParams    url.Values
+		setupMock      func(*mocks.MockTemplateBlockService)
+		expectedStatus int
+		expectBody     bool
+		authenticate   bool
+	}{
+		{
+			name:        "Success",
+			queryParams: url.Values{"workspace_id": {workspaceID}, "id": {blockID}},
+			setupMock: func(m *mocks.MockTemplateBlockService) {
+				m.EXPECT().GetTemplateBlockUsage(gomock.Any(), workspaceID, blockID).Return(&domain.TemplateBlockUsage{
+					BlockID:        blockID,
+					BlockVersion:   2,
+					TemplatesCount: 1,
+					Templates:      []domain.TemplateBlockUsageTemplate{{ID: "welcome", Name: "Welcome"}},
+					Automations:    []domain.TemplateBlockUsageAutomation{},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectBody:     true,
+			authenticate:   true,
+		},
+		{
+			name:        "Not Found",
+			queryParams: url.Values{"workspace_id": {workspaceID}, "id": {blockID}},
+			setupMock: func(m *mocks.MockTemplateBlockService) {
+				m.EXPECT().GetTemplateBlockUsage(gomock.Any(), workspaceID, blockID).Return(nil, &domain.ErrTemplateBlockNotFound{Message: "not found"})
+			},
+			expectedStatus: http.StatusNotFound,
+			expectBody:     false,
+			authenticate:   true,
+		},
+		{
+			name:        "Service Error",
+			queryParams: url.Values{"workspace_id": {workspaceID}, "id": {blockID}},
+			setupMock: func(m *mocks.MockTemplateBlockService) {
+				m.EXPECT().GetTemplateBlockUsage(gomock.Any(), workspaceID, blockID).Return(nil, errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectBody:     false,
+			authenticate:   true,
+		},
+		{
+			name:           "Missing Block ID",
+			queryParams:    url.Values{"workspace_id": {workspaceID}},
+			setupMock:      func(m *mocks.MockTemplateBlockService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectBody:     false,
+			authenticate:   true,
+		},
+		{
+			name:           "Unauthorized",
+			queryParams:    url.Values{"workspace_id": {workspaceID}, "id": {blockID}},
+			setupMock:      func(m *mocks.MockTemplateBlockService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectBody:     false,
+			authenticate:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService, _, serverURL, secretKey, cleanup := setupTemplateBlockHandlerTest(t)
+			defer cleanup()
+
+			tc.setupMock(mockService)
+
+			usageURL := fmt.Sprintf("%s/api/templateBlocks.usage?%s", serverURL, tc.queryParams.Encode())
+			token := ""
+			if tc.authenticate {
+				token = createTestTokenForBlock(secretKey)
+			}
+
+			resp := sendBlockRequest(t, http.MethodGet, usageURL, token, nil)
+			defer func() { _ = resp.Body.Close() }()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+
+			if tc.expectBody && resp.StatusCode == http.StatusOK {
+				var responseMap map[string]interface{}
+				err := json.NewDecoder(resp.Body).Decode(&responseMap)
+				require.NoError(t, err, "Failed to decode response body")
+				usage := responseMap["usage"].(map[string]interface{})
+				assert.Equal(t, float64(1), usage["templates_count"])
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+(function () {
+    var config = /*__CONFIG__*/null;
+    if (!config) return;
+
+    var container = document.getElementById('notifuse-form-' + config.id);
+    if (!container) {
+        container = document.createElement('div');
+        container.id = 'notifuse-form-' + config.id;
+        var script = document.currentScript;
+        if (script && script.parentNode) {
+            script.parentNode.insertBefore(container, script.nextSibling);
+        } else {
+            document.body.appendChild(container);
+        }
+    }
+
+    var prefix = 'nf-' + config.id;
+    var style = document.createElement('style');
+    style.textContent =
+        '.' + prefix + ' { font-family: inherit; color: ' + (config.style.text_color || 'inherit') + '; }' +
+        '.' + prefix + ' .nf-field { margin-bottom: 12px; }' +
+        '.' + prefix + ' label { display: block; font-weight: 600; margin-bottom: 4px; }' +
+        '.' + prefix + ' .nf-checkbox label { display: flex; gap: 8px; font-weight: 400; }' +
+        '.' + prefix + ' input:not([type=checkbox]), .' + prefix + ' select { width: 100%; box-sizing: border-box; padding: 8px 10px; border: 1px solid #d9d9d9; border-radius: 6px; font-size: 15px; }' +
+        '.' + prefix + ' .nf-hp { position: absolute; left: -10000px; width: 1px; height: 1px; overflow: hidden; }' +
+        '.' + prefix + ' button { padding: 10px 16px; border: none; border-radius: 6px; color: #fff; font-weight: 600; cursor: pointer; background: ' + (config.style.primary_color || '#1677ff') + '; }' +
+        '.' + prefix + ' button[disabled] { opacity: 0.6; cursor: wait; }' +
+        '.' + prefix + ' .nf-error { color: #a8071a; margin-bottom: 12px; }' +
+        '.' + prefix + ' .nf-captcha { margin-bottom: 12px; }';
+    document.head.appendChild(style);
+
+    function el(tag, attrs, text) {
+        var node = document.createElement(tag);
+        for (var name in attrs || {}) {
+            if (attrs[name] !== undefined && attrs[name] !== false) node.setAttribute(name, attrs[name] === true ? '' : attrs[name]);
+        }
+        if (text) node.textContent = text;
+        return node;
+    }
+
+    var form = el('form', { 'class': prefix, novalidate: false });
+    if (config.style.background_color) form.style.background = config.style.background_color;
+    if (config.style.title) form.appendChild(el('h3', {}, config.style.title));
+    if (config.style.description) form.appendChild(el('p', {}, config.style.description));
+
+    var error = el('div', { 'class': 'nf-error', role: 'alert' });
+    error.style.display = 'none';
+    form.appendChild(error);
+
+    function addInput(field) {
+        var wrapper = el('div', { 'class': field.type === 'checkbox' ? 'nf-field nf-checkbox' : 'nf-field' });
+        var id = prefix + '-' + field.key.replace(/[^a-z0-9_]/gi, '-');
+        var input;
+        if (field.type === 'checkbox') {
+            var label = el('label');
+            input = el('input', { type: 'checkbox', name: field.key, value: 'true', required: !!field.required });
+            label.appendChild(input);
+            label.appendChild(el('span', {}, field.label));
+            wrapper.appendChild(label);
+        } else {
+            wrapper.appendChild(el('label', { 'for': id }, field.label));
+            if (field.type === 'select') {
+                input = el('select', { id: id, name: field.key, required: !!field.required });
+                input.appendChild(el('option', { value: '' }));
+                (field.options || []).forEach(function (option) {
+                    input.appendChild(el('option', { value: option }, option));
+                });
+            } else {
+                input = el('input', { id: id, type: field.type, name: field.key, placeholder: field.placeholder, required: !!field.required, step: field.type === 'number' ? 'any' : undefined });
+            }
+            wrapper.appendChild(input);
+        }
+        form.appendChild(wrapper);
+    }
+
+    addInput({ key: 'email', label: 'Email', type: 'email', required: true });
+    config.fields.forEach(addInput);
+    if (config.consent_text) addInput({ key: 'consent', label: config.consent_text, type: 'checkbox', required: true });
+
+    var honeypot = el('div', { 'class': 'nf-hp', 'aria-hidden': 'true' });
+    honeypot.appendChild(el('input', { type: 'text', name: config.honeypot_field, tabindex: '-1', autocomplete: 'off' }));
+    form.appendChild(honeypot);
+
+    var widgets = {
+        turnstile: { script: 'https://challenges.cloudflare.com/turnstile/v0/api.js', className: 'cf-turnstile' },
+        hcaptcha: { script: 'https://js.hcaptcha.com/1/api.js', className: 'h-captcha' },
+        recaptcha: { script: 'https://www.google.com/recaptcha/api.js', className: 'g-recaptcha' }
+    };
+    var widget = widgets[config.captcha_provider];
+    if (widget) {
+        form.appendChild(el('div', { 'class': 'nf-captcha ' + widget.className, 'data-sitekey': config.captcha_site_key }));
+    }
+
+    var button = el('button', { type: 'submit' }, config.style.button_text);
+    form.appendChild(button);
+    container.appendChild(form);
+
+    if (widget) {
+        var loader = el('script', { src: widget.script, async: true, defer: true });
+        document.head.appendChild(loader);
+    }
+
+    function showError(message) {
+        error.textContent = message;
+        error.style.display = message ? 'block' : 'none';
+    }
+
+    function proofOfWork() {
+        if (config.captcha_provider !== 'proof_of_work') return Promise.resolve({});
+        return fetch(config.challenge_url)
+            .then(function (response) { return response.json(); })
+            .then(function (data) {
+                return notifuseSolveChallenge(data.challenge, data.difficulty).then(function (nonce) {
+                    return { pow_challenge: data.challenge, pow_nonce: nonce };
+                });
+            });
+    }
+
+    form.addEventListener('submit', function (event) {
+        event.preventDefault();
+        showError('');
+        button.disabled = true;
+
+        var data = new FormData(form);
+        var payload = { email: data.get('email') || '', fields: {}, page_url: window.location.href };
+        payload[config.honeypot_field] = data.get(config.honeypot_field) || '';
+        payload.consent = data.get('consent') === 'true';
+        config.fields.forEach(function (field) {
+            var value = data.get(field.key);
+            if (value !== null) payload.fields[field.key] = String(value);
+        });
+        ['cf-turnstile-response', 'h-captcha-response', 'g-recaptcha-response'].forEach(function (name) {
+            if (data.get(name)) payload.captcha_token = data.get(name);
+        });
+
+        proofOfWork()
+            .then(function (solution) {
+                for (var key in solution) payload[key] = solution[key];
+                return fetch(config.submit_url, {
+                    method: 'POST',
+                    headers: { 'Content-Type': 'application/json' },
+                    body: JSON.stringify(payload)
+                });
+            })
+            .then(function (response) {
+                return response.json().then(function (body) { return { ok: response.ok, body: body }; });
+            })
+            .then(function (result) {
+                if (!result.ok) {
+                    showError(result.body.error || 'Something went wrong, please try again.');
+                    button.disabled = false;
+                    return;
+                }
+                if (result.body.redirect_url) {
+                    window.location.href = result.body.redirect_url;
+                    return;
+                }
+                form.innerHTML = '';
+                form.appendChild(el('p', {}, result.body.message));
+            })
+            .catch(function () {
+                showError('Something went wrong, please try again.');
+                button.disabled = false;
+            });
+    });
+})();
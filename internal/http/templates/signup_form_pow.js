@@ -0,0 +1,16 @@
+// notifuseSolveChallenge finds a nonce such that sha256(challenge + ":" + nonce)
+// starts with difficulty zero bits, see pkg/captcha
+async function notifuseSolveChallenge(challenge, difficulty) {
+    var encoder = new TextEncoder();
+    for (var nonce = 0; ; nonce++) {
+        var digest = new Uint8Array(await crypto.subtle.digest('SHA-256', encoder.encode(challenge + ':' + nonce)));
+        var bits = 0;
+        for (var i = 0; i < digest.length; i++) {
+            var b = digest[i];
+            if (b === 0) { bits += 8; continue; }
+            while ((b & 0x80) === 0) { bits++; b <<= 1; }
+            break;
+        }
+        if (bits >= difficulty) return String(nonce);
+    }
+}
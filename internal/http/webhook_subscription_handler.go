@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/http/middleware"
@@ -47,6 +48,7 @@ func (h *WebhookSubscriptionHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/api/webhookSubscriptions.toggle", requireAuth(http.HandlerFunc(h.handleToggle)))
 	mux.Handle("/api/webhookSubscriptions.regenerateSecret", requireAuth(http.HandlerFunc(h.handleRegenerateSecret)))
 	mux.Handle("/api/webhookSubscriptions.deliveries", requireAuth(http.HandlerFunc(h.handleGetDeliveries)))
+	mux.Handle("/api/webhookSubscriptions.redeliver", requireAuth(http.HandlerFunc(h.handleRedeliver)))
 	mux.Handle("/api/webhookSubscriptions.test", requireAuth(http.HandlerFunc(h.handleTest)))
 	mux.Handle("/api/webhookSubscriptions.eventTypes", requireAuth(http.HandlerFunc(h.handleGetEventTypes)))
 }
@@ -59,11 +61,13 @@ func (h *WebhookSubscriptionHandler) handleCreate(w http.ResponseWriter, r *http
 	}
 
 	var req struct {
-		WorkspaceID        string                     `json:"workspace_id"`
-		Name               string                     `json:"name"`
-		URL                string                     `json:"url"`
-		EventTypes         []string                   `json:"event_types"`
-		CustomEventFilters *domain.CustomEventFilters `json:"custom_event_filters,omitempty"`
+		WorkspaceID        string                        `json:"workspace_id"`
+		Name               string                        `json:"name"`
+		URL                string                        `json:"url"`
+		EventTypes         []string                      `json:"event_types"`
+		CustomEventFilters *domain.CustomEventFilters    `json:"custom_event_filters,omitempty"`
+		PayloadFilters     *domain.WebhookPayloadFilters `json:"payload_filters,omitempty"`
+		Sink               *domain.EventSinkSettings     `json:"sink,omitempty"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -76,7 +80,7 @@ func (h *WebhookSubscriptionHandler) handleCreate(w http.ResponseWriter, r *http
 		return
 	}
 
-	sub, err := h.service.Create(r.Context(), req.WorkspaceID, req.Name, req.URL, req.EventTypes, req.CustomEventFilters)
+	sub, err := h.service.Create(r.Context(), req.WorkspaceID, req.Name, req.URL, req.EventTypes, req.CustomEventFilters, req.PayloadFilters, req.Sink)
 	if err != nil {
 		h.logger.WithField("error", err.Error()).Error("Failed to create webhook subscription")
 		WriteJSONError(w, err.Error(), http.StatusBadRequest)
@@ -152,13 +156,15 @@ func (h *WebhookSubscriptionHandler) handleUpdate(w http.ResponseWriter, r *http
 	}
 
 	var req struct {
-		WorkspaceID        string                     `json:"workspace_id"`
-		ID                 string                     `json:"id"`
-		Name               string                     `json:"name"`
-		URL                string                     `json:"url"`
-		EventTypes         []string                   `json:"event_types"`
-		CustomEventFilters *domain.CustomEventFilters `json:"custom_event_filters,omitempty"`
-		Enabled            bool                       `json:"enabled"`
+		WorkspaceID        string                        `json:"workspace_id"`
+		ID                 string                        `json:"id"`
+		Name               string                        `json:"name"`
+		URL                string                        `json:"url"`
+		EventTypes         []string                      `json:"event_types"`
+		CustomEventFilters *domain.CustomEventFilters    `json:"custom_event_filters,omitempty"`
+		PayloadFilters     *domain.WebhookPayloadFilters `json:"payload_filters,omitempty"`
+		Sink               *domain.EventSinkSettings     `json:"sink,omitempty"`
+		Enabled            bool                          `json:"enabled"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -175,7 +181,7 @@ func (h *WebhookSubscriptionHandler) handleUpdate(w http.ResponseWriter, r *http
 		return
 	}
 
-	sub, err := h.service.Update(r.Context(), req.WorkspaceID, req.ID, req.Name, req.URL, req.EventTypes, req.CustomEventFilters, req.Enabled)
+	sub, err := h.service.Update(r.Context(), req.WorkspaceID, req.ID, req.Name, req.URL, req.EventTypes, req.CustomEventFilters, req.PayloadFilters, req.Sink, req.Enabled)
 	if err != nil {
 		h.logger.WithField("error", err.Error()).Error("Failed to update webhook subscription")
 		WriteJSONError(w, err.Error(), http.StatusBadRequest)
@@ -352,6 +358,70 @@ func (h *WebhookSubscriptionHandler) handleGetDeliveries(w http.ResponseWriter,
 	})
 }
 
+// handleRedeliver handles POST /api/webhookSubscriptions.redeliver
+// Either redelivers a single delivery (delivery_id), or every delivery that failed
+// permanently between from and to, optionally for one subscription.
+func (h *WebhookSubscriptionHandler) handleRedeliver(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		WorkspaceID    string     `json:"workspace_id"`
+		DeliveryID     string     `json:"delivery_id,omitempty"`
+		SubscriptionID string     `json:"subscription_id,omitempty"`
+		From           *time.Time `json:"from,omitempty"`
+		To             *time.Time `json:"to,omitempty"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.WorkspaceID == "" {
+		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
+		return
+	}
+
+	if req.DeliveryID != "" {
+		delivery, err := h.service.Redeliver(r.Context(), req.WorkspaceID, req.DeliveryID)
+		if err != nil {
+			h.logger.WithField("error", err.Error()).Error("Failed to redeliver webhook delivery")
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"delivery":    delivery,
+			"redelivered": 1,
+		})
+		return
+	}
+
+	if req.From == nil || req.To == nil {
+		WriteJSONError(w, "delivery_id or from and to are required", http.StatusBadRequest)
+		return
+	}
+
+	var subscriptionIDPtr *string
+	if req.SubscriptionID != "" {
+		subscriptionIDPtr = &req.SubscriptionID
+	}
+
+	count, err := h.service.RedeliverFailed(r.Context(), req.WorkspaceID, subscriptionIDPtr, *req.From, *req.To)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to redeliver webhook deliveries")
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"redelivered": count,
+	})
+}
+
 // handleTest handles POST /api/webhookSubscriptions.test
 func (h *WebhookSubscriptionHandler) handleTest(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -561,6 +561,74 @@ func TestWebhookSubscriptionHandler_HandleTest_ValidationErrors(t *testing.T) {
 	}
 }
 
+func TestWebhookSubscriptionHandler_HandleRedeliver_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		reqBody        interface{}
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "Method Not Allowed",
+			method:         http.MethodGet,
+			reqBody:        map[string]interface{}{"workspace_id": "ws123", "delivery_id": "del123"},
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedError:  "Method not allowed",
+		},
+		{
+			name:           "Invalid JSON",
+			method:         http.MethodPost,
+			reqBody:        "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid request body",
+		},
+		{
+			name:           "Missing Workspace ID",
+			method:         http.MethodPost,
+			reqBody:        map[string]interface{}{"delivery_id": "del123"},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "workspace_id is required",
+		},
+		{
+			name:           "Missing Delivery ID And Range",
+			method:         http.MethodPost,
+			reqBody:        map[string]interface{}{"workspace_id": "ws123", "from": "2026-01-01T00:00:00Z"},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "delivery_id or from and to are required",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := &WebhookSubscriptionHandler{
+				service:      nil,
+				worker:       nil,
+				logger:       &mockLogger{},
+				getJWTSecret: func() ([]byte, error) { return []byte("test"), nil },
+			}
+
+			var reqBody bytes.Buffer
+			if str, ok := tc.reqBody.(string); ok {
+				reqBody = *bytes.NewBufferString(str)
+			} else {
+				_ = json.NewEncoder(&reqBody).Encode(tc.reqBody)
+			}
+
+			req := httptest.NewRequest(tc.method, "/api/webhookSubscriptions.redeliver", &reqBody)
+			rr := httptest.NewRecorder()
+
+			handler.handleRedeliver(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+
+			var response map[string]string
+			_ = json.NewDecoder(rr.Body).Decode(&response)
+			assert.Equal(t, tc.expectedError, response["error"])
+		})
+	}
+}
+
 func TestWebhookSubscriptionHandler_HandleGetEventTypes_Success(t *testing.T) {
 	handler := &WebhookSubscriptionHandler{
 		service:      &service.WebhookSubscriptionService{},
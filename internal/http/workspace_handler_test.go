@@ -2953,6 +2953,179 @@ func TestWorkspaceHandler_HandleSetCustomFieldLabels(t *testing.T) {
 	})
 }
 
+func TestWorkspaceHandler_HandleSetComputedProperties(t *testing.T) {
+	_, workspaceSvc, mux, secretKey, _ := setupTest(t)
+
+	validBody := domain.SetComputedPropertiesRequest{
+		WorkspaceID: "workspace123",
+		ComputedProperties: []domain.ComputedProperty{
+			{Key: "orders_count", Source: domain.ComputedPropertySourceCustomEvents, Aggregate: domain.ComputedPropertyAggregateCount, EventName: "order_completed"},
+		},
+	}
+
+	t.Run("successful update", func(t *testing.T) {
+		workspaceSvc.EXPECT().
+			SetComputedProperties(gomock.Any(), "workspace123", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, props []domain.ComputedProperty) error {
+				require.Len(t, props, 1)
+				assert.Equal(t, "orders_count", props[0].Key)
+				return nil
+			})
+
+		body, err := json.Marshal(validBody)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/workspaces.setComputedProperties", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]string
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "success", response["status"])
+		assert.Equal(t, "Computed properties updated successfully", response["message"])
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/workspaces.setComputedProperties", nil)
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("validation error - invalid aggregate for source", func(t *testing.T) {
+		body, err := json.Marshal(domain.SetComputedPropertiesRequest{
+			WorkspaceID: "workspace123",
+			ComputedProperties: []domain.ComputedProperty{
+				{Key: "revenue", Source: domain.ComputedPropertySourceMessageHistory, Aggregate: domain.ComputedPropertyAggregateSum},
+			},
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/workspaces.setComputedProperties", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response map[string]string
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Contains(t, response["error"], "cannot be computed from source")
+	})
+
+	t.Run("permission denied returns 403", func(t *testing.T) {
+		permErr := domain.NewPermissionError(domain.PermissionResourceWorkspace, domain.PermissionTypeWrite, "Insufficient permissions: write access to workspace required")
+		workspaceSvc.EXPECT().
+			SetComputedProperties(gomock.Any(), "workspace123", gomock.Any()).
+			Return(permErr)
+
+		body, err := json.Marshal(validBody)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/workspaces.setComputedProperties", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("internal error returns 500", func(t *testing.T) {
+		workspaceSvc.EXPECT().
+			SetComputedProperties(gomock.Any(), "workspace123", gomock.Any()).
+			Return(assert.AnError)
+
+		body, err := json.Marshal(validBody)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/workspaces.setComputedProperties", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestWorkspaceHandler_HandleSetContactAttributes(t *testing.T) {
+	_, workspaceSvc, mux, secretKey, _ := setupTest(t)
+
+	validBody := domain.SetContactAttributesRequest{
+		WorkspaceID: "workspace123",
+		ContactAttributes: []domain.ContactAttribute{
+			{Key: "plan", Type: domain.ContactAttributeTypeString, Enum: []string{"free", "pro"}, Filterable: true},
+		},
+	}
+
+	t.Run("successful update", func(t *testing.T) {
+		workspaceSvc.EXPECT().
+			SetContactAttributes(gomock.Any(), "workspace123", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, attrs []domain.ContactAttribute) error {
+				require.Len(t, attrs, 1)
+				assert.Equal(t, "plan", attrs[0].Key)
+				return nil
+			})
+
+		body, err := json.Marshal(validBody)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/workspaces.setContactAttributes", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response map[string]string
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "Contact attributes updated successfully", response["message"])
+	})
+
+	t.Run("validation error - enum on number attribute", func(t *testing.T) {
+		body, err := json.Marshal(domain.SetContactAttributesRequest{
+			WorkspaceID: "workspace123",
+			ContactAttributes: []domain.ContactAttribute{
+				{Key: "seats", Type: domain.ContactAttributeTypeNumber, Enum: []string{"1"}},
+			},
+		})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/workspaces.setContactAttributes", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("permission denied returns 403", func(t *testing.T) {
+		permErr := domain.NewPermissionError(domain.PermissionResourceWorkspace, domain.PermissionTypeWrite, "Insufficient permissions: write access to workspace required")
+		workspaceSvc.EXPECT().
+			SetContactAttributes(gomock.Any(), "workspace123", gomock.Any()).
+			Return(permErr)
+
+		body, err := json.Marshal(validBody)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/workspaces.setContactAttributes", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
 func TestWorkspaceHandler_HandleSetBlogSettings(t *testing.T) {
 	_, workspaceSvc, mux, secretKey, _ := setupTest(t)
 
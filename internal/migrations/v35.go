@@ -0,0 +1,824 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// V35Migration adds segment membership history, computed contact properties, typed
+// contact attributes, the consent ledger, signup forms, contact delivery preferences,
+// email verification results, contact file imports, contact aliases, webhook
+// subscription failure tracking, ordered webhook deliveries for event sinks, bulk
+// contact operations, scheduled publishing and revisions of blog posts, blog post
+// newsletters, blog search, blog comments and the audit trail of the LLM assistant tools.
+//
+// Workspace changes (all additive / idempotent):
+//   - segment_history: one row per segment and UTC day with the segment size and
+//     the number of contacts that joined / left it that day, written by the
+//     check_segment_recompute task and exposed as the "segment_history" analytics schema.
+//   - contacts.computed_properties: nullable JSONB holding the read-only values of the
+//     workspace computed properties (instant column add, no table rewrite).
+//   - contacts.attributes: nullable JSONB holding the values of the typed attributes
+//     defined in the workspace contact attribute registry.
+//   - track_contact_changes(): redefined so computed_properties changes, and attribute
+//     changes keyed "attributes.<key>", are recorded as contact.updated timeline events.
+//   - webhook_contacts_trigger(): redefined so attribute-only changes fire contact.updated.
+//   - consent_records: append-only consent ledger (source, IP, user agent, page URL,
+//     consent text version and message ID of every list opt-in, confirmation and
+//     opt-out), protected from updates by consent_records_immutable_trigger.
+//   - signup_forms: hosted / embeddable signup forms, and signup_form_stats: their
+//     daily view and submission counters.
+//   - contact_lists.frequency / contact_lists.paused_until: per-list delivery frequency
+//     and marketing pause chosen in the notification center.
+//   - track_contact_list_changes() / webhook_contact_lists_trigger(): redefined to emit
+//     list.paused, list.resumed and list.frequency_changed events.
+//   - contacts.email_verification: nullable JSONB holding the last email verification
+//     result (status, reasons, typo suggestion), with an index on its status for segments.
+//   - contact_imports: CSV / XLSX contact imports with their column mapping, options,
+//     progress counters and row errors, processed by the import_contacts task.
+//   - contact_aliases: former addresses of merged / renamed contacts, so inbound webhook
+//     events received for an old address are recorded on the surviving contact.
+//   - track_inbound_webhook_event_changes(): redefined to resolve recipients through contact_aliases.
+//   - track_custom_event_timeline() / webhook_custom_events_trigger(): redefined to ignore
+//     events being re-pointed to another contact by a merge or email change.
+//   - webhook_subscriptions.failing_since / disabled_reason: failure tracking used to
+//     auto-disable subscriptions whose endpoint keeps failing.
+//   - webhook_deliveries.seq: insertion order of the deliveries, used by the Kafka, NATS
+//     and Postgres NOTIFY sinks to publish the events of a subscription in order.
+//   - contact_bulk_operations / contact_bulk_operation_results: bulk actions applied to
+//     a target of contacts by the bulk_contact_operation task, and their per-contact log.
+//   - blog_posts.scheduled_publish_at: publication time handled by the publish_blog_post task.
+//   - blog_post_revisions: numbered snapshots of blog post content, including draft edits
+//     of published posts previewed through their preview token before being promoted.
+//   - broadcasts.blog_post: the blog post sent by a broadcast created when publishing it.
+//   - blog_posts.search_config / search_text / search_vector: full-text search index of
+//     the posts, built with the text search configuration of their language. Existing
+//     posts are indexed with the workspace default language.
+//   - blog_comments / blog_post_reactions: comments left on blog posts by contacts, with
+//     their moderation status, and the reactions of contacts to posts.
+//   - track_blog_comment_changes(): records blog.commented on the timeline of the author
+//     the first time a comment is approved.
+//   - llm_tool_calls: audit trail of the workspace tools called by the LLM assistant on
+//     behalf of a user, with the input and the status of each call, listed by llm.toolCalls.
+//
+// The SQL here is kept identical to the fresh-install definitions in
+// internal/database/init.go to avoid drift between new and migrated installs.
+type V35Migration struct{}
+
+func (m *V35Migration) GetMajorVersion() float64 {
+	return 35.0
+}
+
+func (m *V35Migration) HasSystemUpdate() bool {
+	return false
+}
+
+func (m *V35Migration) HasWorkspaceUpdate() bool {
+	return true
+}
+
+func (m *V35Migration) ShouldRestartServer() bool {
+	return false
+}
+
+func (m *V35Migration) UpdateSystem(ctx context.Context, cfg *config.Config, db DBExecutor) error {
+	return nil
+}
+
+func (m *V35Migration) UpdateWorkspace(ctx context.Context, cfg *config.Config, workspace *domain.Workspace, db DBExecutor) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS segment_history (
+			segment_id VARCHAR(32) NOT NULL,
+			day DATE NOT NULL,
+			users_count INTEGER NOT NULL DEFAULT 0,
+			joined_count INTEGER NOT NULL DEFAULT 0,
+			left_count INTEGER NOT NULL DEFAULT 0,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (segment_id, day)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_segment_history_day ON segment_history(day)`,
+		`ALTER TABLE contacts ADD COLUMN IF NOT EXISTS computed_properties JSONB`,
+		`ALTER TABLE contacts ADD COLUMN IF NOT EXISTS attributes JSONB`,
+		`CREATE OR REPLACE FUNCTION track_contact_changes()
+		RETURNS TRIGGER AS $$
+		DECLARE
+			changes_json JSONB := '{}'::jsonb;
+			op VARCHAR(20);
+		BEGIN
+			IF TG_OP = 'INSERT' THEN
+				op := 'insert';
+				changes_json := NULL;
+			ELSIF TG_OP = 'UPDATE' THEN
+				op := 'update';
+				IF OLD.external_id IS DISTINCT FROM NEW.external_id THEN changes_json := changes_json || jsonb_build_object('external_id', jsonb_build_object('old', OLD.external_id, 'new', NEW.external_id)); END IF;
+				IF OLD.timezone IS DISTINCT FROM NEW.timezone THEN changes_json := changes_json || jsonb_build_object('timezone', jsonb_build_object('old', OLD.timezone, 'new', NEW.timezone)); END IF;
+				IF OLD.language IS DISTINCT FROM NEW.language THEN changes_json := changes_json || jsonb_build_object('language', jsonb_build_object('old', OLD.language, 'new', NEW.language)); END IF;
+				IF OLD.first_name IS DISTINCT FROM NEW.first_name THEN changes_json := changes_json || jsonb_build_object('first_name', jsonb_build_object('old', OLD.first_name, 'new', NEW.first_name)); END IF;
+				IF OLD.last_name IS DISTINCT FROM NEW.last_name THEN changes_json := changes_json || jsonb_build_object('last_name', jsonb_build_object('old', OLD.last_name, 'new', NEW.last_name)); END IF;
+				IF OLD.full_name IS DISTINCT FROM NEW.full_name THEN changes_json := changes_json || jsonb_build_object('full_name', jsonb_build_object('old', OLD.full_name, 'new', NEW.full_name)); END IF;
+				IF OLD.phone IS DISTINCT FROM NEW.phone THEN changes_json := changes_json || jsonb_build_object('phone', jsonb_build_object('old', OLD.phone, 'new', NEW.phone)); END IF;
+				IF OLD.address_line_1 IS DISTINCT FROM NEW.address_line_1 THEN changes_json := changes_json || jsonb_build_object('address_line_1', jsonb_build_object('old', OLD.address_line_1, 'new', NEW.address_line_1)); END IF;
+				IF OLD.address_line_2 IS DISTINCT FROM NEW.address_line_2 THEN changes_json := changes_json || jsonb_build_object('address_line_2', jsonb_build_object('old', OLD.address_line_2, 'new', NEW.address_line_2)); END IF;
+				IF OLD.country IS DISTINCT FROM NEW.country THEN changes_json := changes_json || jsonb_build_object('country', jsonb_build_object('old', OLD.country, 'new', NEW.country)); END IF;
+				IF OLD.postcode IS DISTINCT FROM NEW.postcode THEN changes_json := changes_json || jsonb_build_object('postcode', jsonb_build_object('old', OLD.postcode, 'new', NEW.postcode)); END IF;
+				IF OLD.state IS DISTINCT FROM NEW.state THEN changes_json := changes_json || jsonb_build_object('state', jsonb_build_object('old', OLD.state, 'new', NEW.state)); END IF;
+				IF OLD.job_title IS DISTINCT FROM NEW.job_title THEN changes_json := changes_json || jsonb_build_object('job_title', jsonb_build_object('old', OLD.job_title, 'new', NEW.job_title)); END IF;
+				IF OLD.custom_string_1 IS DISTINCT FROM NEW.custom_string_1 THEN changes_json := changes_json || jsonb_build_object('custom_string_1', jsonb_build_object('old', OLD.custom_string_1, 'new', NEW.custom_string_1)); END IF;
+				IF OLD.custom_string_2 IS DISTINCT FROM NEW.custom_string_2 THEN changes_json := changes_json || jsonb_build_object('custom_string_2', jsonb_build_object('old', OLD.custom_string_2, 'new', NEW.custom_string_2)); END IF;
+				IF OLD.custom_string_3 IS DISTINCT FROM NEW.custom_string_3 THEN changes_json := changes_json || jsonb_build_object('custom_string_3', jsonb_build_object('old', OLD.custom_string_3, 'new', NEW.custom_string_3)); END IF;
+				IF OLD.custom_string_4 IS DISTINCT FROM NEW.custom_string_4 THEN changes_json := changes_json || jsonb_build_object('custom_string_4', jsonb_build_object('old', OLD.custom_string_4, 'new', NEW.custom_string_4)); END IF;
+				IF OLD.custom_string_5 IS DISTINCT FROM NEW.custom_string_5 THEN changes_json := changes_json || jsonb_build_object('custom_string_5', jsonb_build_object('old', OLD.custom_string_5, 'new', NEW.custom_string_5)); END IF;
+				IF OLD.custom_number_1 IS DISTINCT FROM NEW.custom_number_1 THEN changes_json := changes_json || jsonb_build_object('custom_number_1', jsonb_build_object('old', OLD.custom_number_1, 'new', NEW.custom_number_1)); END IF;
+				IF OLD.custom_number_2 IS DISTINCT FROM NEW.custom_number_2 THEN changes_json := changes_json || jsonb_build_object('custom_number_2', jsonb_build_object('old', OLD.custom_number_2, 'new', NEW.custom_number_2)); END IF;
+				IF OLD.custom_number_3 IS DISTINCT FROM NEW.custom_number_3 THEN changes_json := changes_json || jsonb_build_object('custom_number_3', jsonb_build_object('old', OLD.custom_number_3, 'new', NEW.custom_number_3)); END IF;
+				IF OLD.custom_number_4 IS DISTINCT FROM NEW.custom_number_4 THEN changes_json := changes_json || jsonb_build_object('custom_number_4', jsonb_build_object('old', OLD.custom_number_4, 'new', NEW.custom_number_4)); END IF;
+				IF OLD.custom_number_5 IS DISTINCT FROM NEW.custom_number_5 THEN changes_json := changes_json || jsonb_build_object('custom_number_5', jsonb_build_object('old', OLD.custom_number_5, 'new', NEW.custom_number_5)); END IF;
+				IF OLD.custom_datetime_1 IS DISTINCT FROM NEW.custom_datetime_1 THEN changes_json := changes_json || jsonb_build_object('custom_datetime_1', jsonb_build_object('old', OLD.custom_datetime_1, 'new', NEW.custom_datetime_1)); END IF;
+				IF OLD.custom_datetime_2 IS DISTINCT FROM NEW.custom_datetime_2 THEN changes_json := changes_json || jsonb_build_object('custom_datetime_2', jsonb_build_object('old', OLD.custom_datetime_2, 'new', NEW.custom_datetime_2)); END IF;
+				IF OLD.custom_datetime_3 IS DISTINCT FROM NEW.custom_datetime_3 THEN changes_json := changes_json || jsonb_build_object('custom_datetime_3', jsonb_build_object('old', OLD.custom_datetime_3, 'new', NEW.custom_datetime_3)); END IF;
+				IF OLD.custom_datetime_4 IS DISTINCT FROM NEW.custom_datetime_4 THEN changes_json := changes_json || jsonb_build_object('custom_datetime_4', jsonb_build_object('old', OLD.custom_datetime_4, 'new', NEW.custom_datetime_4)); END IF;
+				IF OLD.custom_datetime_5 IS DISTINCT FROM NEW.custom_datetime_5 THEN changes_json := changes_json || jsonb_build_object('custom_datetime_5', jsonb_build_object('old', OLD.custom_datetime_5, 'new', NEW.custom_datetime_5)); END IF;
+				IF OLD.custom_json_1 IS DISTINCT FROM NEW.custom_json_1 THEN changes_json := changes_json || jsonb_build_object('custom_json_1', jsonb_build_object('old', OLD.custom_json_1, 'new', NEW.custom_json_1)); END IF;
+				IF OLD.custom_json_2 IS DISTINCT FROM NEW.custom_json_2 THEN changes_json := changes_json || jsonb_build_object('custom_json_2', jsonb_build_object('old', OLD.custom_json_2, 'new', NEW.custom_json_2)); END IF;
+				IF OLD.custom_json_3 IS DISTINCT FROM NEW.custom_json_3 THEN changes_json := changes_json || jsonb_build_object('custom_json_3', jsonb_build_object('old', OLD.custom_json_3, 'new', NEW.custom_json_3)); END IF;
+				IF OLD.custom_json_4 IS DISTINCT FROM NEW.custom_json_4 THEN changes_json := changes_json || jsonb_build_object('custom_json_4', jsonb_build_object('old', OLD.custom_json_4, 'new', NEW.custom_json_4)); END IF;
+				IF OLD.custom_json_5 IS DISTINCT FROM NEW.custom_json_5 THEN changes_json := changes_json || jsonb_build_object('custom_json_5', jsonb_build_object('old', OLD.custom_json_5, 'new', NEW.custom_json_5)); END IF;
+				IF OLD.computed_properties IS DISTINCT FROM NEW.computed_properties THEN changes_json := changes_json || jsonb_build_object('computed_properties', jsonb_build_object('old', OLD.computed_properties, 'new', NEW.computed_properties)); END IF;
+				IF OLD.attributes IS DISTINCT FROM NEW.attributes THEN
+					SELECT changes_json || COALESCE(jsonb_object_agg('attributes.' || k, jsonb_build_object('old', OLD.attributes->k, 'new', NEW.attributes->k)), '{}'::jsonb) INTO changes_json
+					FROM (SELECT jsonb_object_keys(COALESCE(OLD.attributes, '{}'::jsonb)) AS k UNION SELECT jsonb_object_keys(COALESCE(NEW.attributes, '{}'::jsonb))) attribute_keys
+					WHERE (OLD.attributes->k) IS DISTINCT FROM (NEW.attributes->k);
+				END IF;
+				IF changes_json = '{}'::jsonb THEN RETURN NEW; END IF;
+			END IF;
+		IF TG_OP = 'INSERT' THEN
+			INSERT INTO contact_timeline (email, operation, entity_type, kind, changes, created_at)
+			VALUES (NEW.email, op, 'contact', 'contact.created', changes_json, NEW.created_at);
+		ELSE
+			INSERT INTO contact_timeline (email, operation, entity_type, kind, changes, created_at)
+			VALUES (NEW.email, op, 'contact', 'contact.updated', changes_json, NEW.updated_at);
+		END IF;
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql;`,
+		`CREATE OR REPLACE FUNCTION webhook_contacts_trigger()
+		RETURNS TRIGGER AS $$
+		DECLARE
+			sub RECORD;
+			event_kind VARCHAR(50);
+			payload JSONB;
+			contact_record RECORD;
+		BEGIN
+			-- Determine event kind and which record to use
+			IF TG_OP = 'INSERT' THEN
+				event_kind := 'contact.created';
+				contact_record := NEW;
+			ELSIF TG_OP = 'UPDATE' THEN
+				event_kind := 'contact.updated';
+				contact_record := NEW;
+				-- Skip if nothing changed (compare all relevant fields)
+				IF NEW.external_id IS NOT DISTINCT FROM OLD.external_id AND
+				   NEW.timezone IS NOT DISTINCT FROM OLD.timezone AND
+				   NEW.language IS NOT DISTINCT FROM OLD.language AND
+				   NEW.first_name IS NOT DISTINCT FROM OLD.first_name AND
+				   NEW.last_name IS NOT DISTINCT FROM OLD.last_name AND
+				   NEW.full_name IS NOT DISTINCT FROM OLD.full_name AND
+				   NEW.phone IS NOT DISTINCT FROM OLD.phone AND
+				   NEW.address_line_1 IS NOT DISTINCT FROM OLD.address_line_1 AND
+				   NEW.address_line_2 IS NOT DISTINCT FROM OLD.address_line_2 AND
+				   NEW.country IS NOT DISTINCT FROM OLD.country AND
+				   NEW.postcode IS NOT DISTINCT FROM OLD.postcode AND
+				   NEW.state IS NOT DISTINCT FROM OLD.state AND
+				   NEW.job_title IS NOT DISTINCT FROM OLD.job_title AND
+				   NEW.custom_string_1 IS NOT DISTINCT FROM OLD.custom_string_1 AND
+				   NEW.custom_string_2 IS NOT DISTINCT FROM OLD.custom_string_2 AND
+				   NEW.custom_string_3 IS NOT DISTINCT FROM OLD.custom_string_3 AND
+				   NEW.custom_string_4 IS NOT DISTINCT FROM OLD.custom_string_4 AND
+				   NEW.custom_string_5 IS NOT DISTINCT FROM OLD.custom_string_5 AND
+				   NEW.custom_number_1 IS NOT DISTINCT FROM OLD.custom_number_1 AND
+				   NEW.custom_number_2 IS NOT DISTINCT FROM OLD.custom_number_2 AND
+				   NEW.custom_number_3 IS NOT DISTINCT FROM OLD.custom_number_3 AND
+				   NEW.custom_number_4 IS NOT DISTINCT FROM OLD.custom_number_4 AND
+				   NEW.custom_number_5 IS NOT DISTINCT FROM OLD.custom_number_5 AND
+				   NEW.custom_datetime_1 IS NOT DISTINCT FROM OLD.custom_datetime_1 AND
+				   NEW.custom_datetime_2 IS NOT DISTINCT FROM OLD.custom_datetime_2 AND
+				   NEW.custom_datetime_3 IS NOT DISTINCT FROM OLD.custom_datetime_3 AND
+				   NEW.custom_datetime_4 IS NOT DISTINCT FROM OLD.custom_datetime_4 AND
+				   NEW.custom_datetime_5 IS NOT DISTINCT FROM OLD.custom_datetime_5 AND
+				   NEW.custom_json_1 IS NOT DISTINCT FROM OLD.custom_json_1 AND
+				   NEW.custom_json_2 IS NOT DISTINCT FROM OLD.custom_json_2 AND
+				   NEW.custom_json_3 IS NOT DISTINCT FROM OLD.custom_json_3 AND
+				   NEW.custom_json_4 IS NOT DISTINCT FROM OLD.custom_json_4 AND
+				   NEW.custom_json_5 IS NOT DISTINCT FROM OLD.custom_json_5 AND
+				   NEW.attributes IS NOT DISTINCT FROM OLD.attributes THEN
+					RETURN NEW;
+				END IF;
+			ELSIF TG_OP = 'DELETE' THEN
+				event_kind := 'contact.deleted';
+				contact_record := OLD;
+			ELSE
+				RETURN COALESCE(NEW, OLD);
+			END IF;
+
+			-- Build payload with full contact object
+			payload := jsonb_build_object(
+				'contact', to_jsonb(contact_record)
+			);
+
+			-- Insert webhook deliveries for matching subscriptions
+			FOR sub IN
+				SELECT id FROM webhook_subscriptions
+				WHERE enabled = true AND event_kind = ANY(ARRAY(SELECT jsonb_array_elements_text(settings->'event_types')))
+			LOOP
+				INSERT INTO webhook_deliveries (id, subscription_id, event_type, payload, status, attempts, max_attempts, next_attempt_at)
+				VALUES (gen_random_uuid()::text, sub.id, event_kind, payload, 'pending', 0, 10, NOW());
+			END LOOP;
+			RETURN COALESCE(NEW, OLD);
+		END;
+		$$ LANGUAGE plpgsql`,
+		`CREATE TABLE IF NOT EXISTS consent_records (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			email VARCHAR(255) NOT NULL,
+			list_id VARCHAR(32) NOT NULL,
+			action VARCHAR(20) NOT NULL,
+			source VARCHAR(32) NOT NULL,
+			source_id VARCHAR(255),
+			ip_address VARCHAR(64),
+			user_agent TEXT,
+			page_url TEXT,
+			consent_text_version VARCHAR(255),
+			message_id VARCHAR(255),
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_consent_records_email ON consent_records(email, list_id, created_at DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_consent_records_list_id ON consent_records(list_id, created_at DESC)`,
+		`CREATE OR REPLACE FUNCTION prevent_consent_record_update()
+		RETURNS TRIGGER AS $$
+		BEGIN
+			RAISE EXCEPTION 'consent_records is append-only';
+		END;
+		$$ LANGUAGE plpgsql`,
+		`DROP TRIGGER IF EXISTS consent_records_immutable_trigger ON consent_records`,
+		`CREATE TRIGGER consent_records_immutable_trigger BEFORE UPDATE ON consent_records FOR EACH ROW EXECUTE FUNCTION prevent_consent_record_update()`,
+		`CREATE TABLE IF NOT EXISTS signup_forms (
+			id VARCHAR(32) PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			list_ids TEXT[] NOT NULL DEFAULT '{}',
+			fields JSONB NOT NULL DEFAULT '[]',
+			consent_text TEXT NOT NULL DEFAULT '',
+			consent_text_version VARCHAR(32) NOT NULL DEFAULT '',
+			success_message TEXT NOT NULL DEFAULT '',
+			success_redirect_url TEXT NOT NULL DEFAULT '',
+			style JSONB NOT NULL DEFAULT '{}',
+			captcha JSONB NOT NULL DEFAULT '{}',
+			enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			deleted_at TIMESTAMP WITH TIME ZONE
+		)`,
+		`CREATE TABLE IF NOT EXISTS signup_form_stats (
+			form_id VARCHAR(32) NOT NULL,
+			day DATE NOT NULL,
+			views INTEGER NOT NULL DEFAULT 0,
+			submitted INTEGER NOT NULL DEFAULT 0,
+			subscribed INTEGER NOT NULL DEFAULT 0,
+			spam_blocked INTEGER NOT NULL DEFAULT 0,
+			disposable_blocked INTEGER NOT NULL DEFAULT 0,
+			invalid INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (form_id, day)
+		)`,
+		`ALTER TABLE contact_lists ADD COLUMN IF NOT EXISTS frequency VARCHAR(20) NOT NULL DEFAULT 'all'`,
+		`ALTER TABLE contact_lists ADD COLUMN IF NOT EXISTS paused_until TIMESTAMP WITH TIME ZONE`,
+		`CREATE INDEX IF NOT EXISTS idx_contact_lists_paused_until ON contact_lists(email) WHERE paused_until IS NOT NULL`,
+		`CREATE OR REPLACE FUNCTION track_contact_list_changes()
+		RETURNS TRIGGER AS $$
+		DECLARE
+			changes_json JSONB := '{}'::jsonb;
+			op VARCHAR(20);
+			kind_value VARCHAR(50);
+		BEGIN
+			IF TG_OP = 'INSERT' THEN
+				op := 'insert';
+
+				-- Map initial status to semantic event kind (dotted format)
+				kind_value := CASE NEW.status
+					WHEN 'active' THEN 'list.subscribed'
+					WHEN 'pending' THEN 'list.pending'
+					WHEN 'unsubscribed' THEN 'list.unsubscribed'
+					WHEN 'bounced' THEN 'list.bounced'
+					WHEN 'complained' THEN 'list.complained'
+					ELSE 'list.subscribed'
+				END;
+
+				changes_json := jsonb_build_object(
+					'list_id', jsonb_build_object('new', NEW.list_id),
+					'status', jsonb_build_object('new', NEW.status)
+				);
+
+			ELSIF TG_OP = 'UPDATE' THEN
+				op := 'update';
+
+				-- Handle soft delete
+				IF OLD.deleted_at IS DISTINCT FROM NEW.deleted_at AND NEW.deleted_at IS NOT NULL THEN
+					kind_value := 'list.removed';
+					changes_json := jsonb_build_object(
+						'deleted_at', jsonb_build_object('old', OLD.deleted_at, 'new', NEW.deleted_at)
+					);
+
+				-- Handle status transitions
+				ELSIF OLD.status IS DISTINCT FROM NEW.status THEN
+					kind_value := CASE
+						WHEN OLD.status = 'pending' AND NEW.status = 'active' THEN 'list.confirmed'
+						WHEN OLD.status IN ('unsubscribed', 'bounced', 'complained') AND NEW.status = 'active' THEN 'list.resubscribed'
+						WHEN NEW.status = 'unsubscribed' THEN 'list.unsubscribed'
+						WHEN NEW.status = 'bounced' THEN 'list.bounced'
+						WHEN NEW.status = 'complained' THEN 'list.complained'
+						WHEN NEW.status = 'pending' THEN 'list.pending'
+						WHEN NEW.status = 'active' THEN 'list.subscribed'
+						ELSE 'list.status_changed'
+					END;
+
+					changes_json := jsonb_build_object(
+						'status', jsonb_build_object('old', OLD.status, 'new', NEW.status)
+					);
+
+				-- Handle marketing pauses set from the notification center
+				ELSIF OLD.paused_until IS DISTINCT FROM NEW.paused_until THEN
+					kind_value := CASE WHEN NEW.paused_until IS NULL THEN 'list.resumed' ELSE 'list.paused' END;
+					changes_json := jsonb_build_object(
+						'paused_until', jsonb_build_object('old', OLD.paused_until, 'new', NEW.paused_until)
+					);
+
+				-- Handle delivery frequency changes
+				ELSIF OLD.frequency IS DISTINCT FROM NEW.frequency THEN
+					kind_value := 'list.frequency_changed';
+					changes_json := jsonb_build_object(
+						'frequency', jsonb_build_object('old', OLD.frequency, 'new', NEW.frequency)
+					);
+				ELSE
+					RETURN NEW;
+				END IF;
+			END IF;
+
+			INSERT INTO contact_timeline (email, operation, entity_type, kind, entity_id, changes, created_at)
+			VALUES (NEW.email, op, 'contact_list', kind_value, NEW.list_id, changes_json, CURRENT_TIMESTAMP);
+
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql;`,
+		`CREATE OR REPLACE FUNCTION webhook_contact_lists_trigger()
+		RETURNS TRIGGER AS $$
+		DECLARE
+			sub RECORD;
+			event_kind VARCHAR(50);
+			payload JSONB;
+			list_name VARCHAR(255);
+		BEGIN
+			-- Get list name for payload enrichment
+			SELECT name INTO list_name FROM lists WHERE id = NEW.list_id;
+
+			-- Determine event kind based on status transitions
+			IF TG_OP = 'INSERT' THEN
+				CASE NEW.status
+					WHEN 'active' THEN event_kind := 'list.subscribed';
+					WHEN 'pending' THEN event_kind := 'list.pending';
+					WHEN 'unsubscribed' THEN event_kind := 'list.unsubscribed';
+					WHEN 'bounced' THEN event_kind := 'list.bounced';
+					WHEN 'complained' THEN event_kind := 'list.complained';
+					ELSE RETURN NEW;
+				END CASE;
+			ELSIF TG_OP = 'UPDATE' THEN
+				-- Detect status transitions
+				IF NEW.status IS DISTINCT FROM OLD.status THEN
+					IF OLD.status = 'pending' AND NEW.status = 'active' THEN
+						event_kind := 'list.confirmed';
+					ELSIF OLD.status IN ('unsubscribed', 'bounced', 'complained') AND NEW.status = 'active' THEN
+						event_kind := 'list.resubscribed';
+					ELSIF NEW.status = 'unsubscribed' THEN
+						event_kind := 'list.unsubscribed';
+					ELSIF NEW.status = 'bounced' THEN
+						event_kind := 'list.bounced';
+					ELSIF NEW.status = 'complained' THEN
+						event_kind := 'list.complained';
+					ELSE
+						RETURN NEW;
+					END IF;
+				ELSIF NEW.deleted_at IS NOT NULL AND OLD.deleted_at IS NULL THEN
+					event_kind := 'list.removed';
+				ELSIF NEW.paused_until IS DISTINCT FROM OLD.paused_until THEN
+					IF NEW.paused_until IS NULL THEN
+						event_kind := 'list.resumed';
+					ELSE
+						event_kind := 'list.paused';
+					END IF;
+				ELSIF NEW.frequency IS DISTINCT FROM OLD.frequency THEN
+					event_kind := 'list.frequency_changed';
+				ELSE
+					RETURN NEW;
+				END IF;
+			ELSE
+				RETURN NEW;
+			END IF;
+
+			-- Build payload
+			payload := jsonb_build_object(
+				'email', NEW.email,
+				'list_id', NEW.list_id,
+				'list_name', list_name,
+				'status', NEW.status,
+				'previous_status', CASE WHEN TG_OP = 'UPDATE' THEN OLD.status ELSE NULL END,
+				'frequency', NEW.frequency,
+				'paused_until', NEW.paused_until
+			);
+
+			-- Insert webhook deliveries for matching subscriptions
+			FOR sub IN
+				SELECT id FROM webhook_subscriptions
+				WHERE enabled = true AND event_kind = ANY(ARRAY(SELECT jsonb_array_elements_text(settings->'event_types')))
+			LOOP
+				INSERT INTO webhook_deliveries (id, subscription_id, event_type, payload, status, attempts, max_attempts, next_attempt_at)
+				VALUES (gen_random_uuid()::text, sub.id, event_kind, payload, 'pending', 0, 10, NOW());
+			END LOOP;
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql`,
+		`ALTER TABLE contacts ADD COLUMN IF NOT EXISTS email_verification JSONB`,
+		`CREATE INDEX IF NOT EXISTS idx_contacts_email_verification_status ON contacts ((email_verification->>'status')) WHERE email_verification IS NOT NULL`,
+		`CREATE TABLE IF NOT EXISTS contact_imports (
+			id VARCHAR(36) PRIMARY KEY,
+			file_name VARCHAR(255) NOT NULL,
+			format VARCHAR(10) NOT NULL,
+			source VARCHAR(20) NOT NULL,
+			s3_key TEXT,
+			file_size BIGINT NOT NULL DEFAULT 0,
+			file_data BYTEA,
+			columns TEXT[] NOT NULL DEFAULT '{}',
+			preview JSONB NOT NULL DEFAULT '[]',
+			mapping JSONB NOT NULL DEFAULT '{}',
+			list_ids TEXT[] NOT NULL DEFAULT '{}',
+			list_status VARCHAR(20),
+			dedupe_strategy VARCHAR(20),
+			status VARCHAR(20) NOT NULL,
+			dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+			task_id VARCHAR(36),
+			total_rows INTEGER NOT NULL DEFAULT 0,
+			processed_rows INTEGER NOT NULL DEFAULT 0,
+			created_count INTEGER NOT NULL DEFAULT 0,
+			updated_count INTEGER NOT NULL DEFAULT 0,
+			skipped_count INTEGER NOT NULL DEFAULT 0,
+			failed_count INTEGER NOT NULL DEFAULT 0,
+			errors JSONB NOT NULL DEFAULT '[]',
+			error_message TEXT,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			completed_at TIMESTAMP WITH TIME ZONE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_contact_imports_created_at ON contact_imports(created_at DESC)`,
+		`CREATE TABLE IF NOT EXISTS contact_aliases (
+			email VARCHAR(255) PRIMARY KEY,
+			contact_email VARCHAR(255) NOT NULL,
+			reason VARCHAR(20) NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_contact_aliases_contact_email ON contact_aliases(contact_email)`,
+		`CREATE OR REPLACE FUNCTION track_inbound_webhook_event_changes()
+		RETURNS TRIGGER AS $$
+		DECLARE
+			changes_json JSONB := '{}'::jsonb;
+			entity_id_value VARCHAR(255);
+			kind_value VARCHAR(50);
+		BEGIN
+			-- Use message_id if available, otherwise use inbound webhook event id
+			entity_id_value := COALESCE(NEW.message_id, NEW.id::text);
+
+			-- Reply / auto-reply events get dedicated timeline kinds so automations
+			-- can react to them (stop-on-reply); other inbound events keep the generic kind.
+			IF NEW.type = 'reply' THEN
+				kind_value := 'email.replied';
+			ELSIF NEW.type = 'auto_reply' THEN
+				kind_value := 'email.auto_reply';
+			ELSE
+				kind_value := 'insert_inbound_webhook_event';
+			END IF;
+
+			changes_json := jsonb_build_object('type', jsonb_build_object('new', NEW.type), 'source', jsonb_build_object('new', NEW.source));
+			IF NEW.bounce_type IS NOT NULL AND NEW.bounce_type != '' THEN changes_json := changes_json || jsonb_build_object('bounce_type', jsonb_build_object('new', NEW.bounce_type)); END IF;
+			IF NEW.bounce_category IS NOT NULL AND NEW.bounce_category != '' THEN changes_json := changes_json || jsonb_build_object('bounce_category', jsonb_build_object('new', NEW.bounce_category)); END IF;
+			IF NEW.bounce_diagnostic IS NOT NULL AND NEW.bounce_diagnostic != '' THEN changes_json := changes_json || jsonb_build_object('bounce_diagnostic', jsonb_build_object('new', NEW.bounce_diagnostic)); END IF;
+			IF NEW.complaint_feedback_type IS NOT NULL AND NEW.complaint_feedback_type != '' THEN changes_json := changes_json || jsonb_build_object('complaint_feedback_type', jsonb_build_object('new', NEW.complaint_feedback_type)); END IF;
+			-- Events for a merged or changed address are recorded on the contact now owning it
+			INSERT INTO contact_timeline (email, operation, entity_type, kind, entity_id, changes, created_at)
+			VALUES (COALESCE((SELECT contact_email FROM contact_aliases WHERE email = NEW.recipient_email), NEW.recipient_email), 'insert', 'inbound_webhook_event', kind_value, entity_id_value, changes_json, CURRENT_TIMESTAMP);
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql;`,
+		`CREATE OR REPLACE FUNCTION track_custom_event_timeline()
+		RETURNS TRIGGER AS $$
+		DECLARE
+			timeline_operation TEXT;
+			changes_json JSONB;
+			property_key TEXT;
+			property_diff JSONB;
+			kind_value TEXT;
+		BEGIN
+			IF TG_OP = 'INSERT' THEN
+				timeline_operation := 'insert';
+				kind_value := 'custom_event.' || NEW.event_name;
+				changes_json := jsonb_build_object(
+					'event_name', jsonb_build_object('new', NEW.event_name),
+					'external_id', jsonb_build_object('new', NEW.external_id)
+				);
+				-- Add goal fields if present
+				IF NEW.goal_type IS NOT NULL THEN
+					changes_json := changes_json || jsonb_build_object('goal_type', jsonb_build_object('new', NEW.goal_type));
+				END IF;
+				IF NEW.goal_value IS NOT NULL THEN
+					changes_json := changes_json || jsonb_build_object('goal_value', jsonb_build_object('new', NEW.goal_value));
+				END IF;
+				IF NEW.goal_name IS NOT NULL THEN
+					changes_json := changes_json || jsonb_build_object('goal_name', jsonb_build_object('new', NEW.goal_name));
+				END IF;
+			ELSIF TG_OP = 'UPDATE' THEN
+				-- Re-pointing the event to another contact (merge or email change) is not an event change
+				IF OLD.email IS DISTINCT FROM NEW.email THEN
+					RETURN NEW;
+				END IF;
+				timeline_operation := 'update';
+				kind_value := 'custom_event.' || NEW.event_name;
+				property_diff := '{}'::jsonb;
+				FOR property_key IN
+					SELECT DISTINCT key
+					FROM (
+						SELECT key FROM jsonb_object_keys(OLD.properties) AS key
+						UNION
+						SELECT key FROM jsonb_object_keys(NEW.properties) AS key
+					) AS all_keys
+				LOOP
+					IF (OLD.properties->property_key) IS DISTINCT FROM (NEW.properties->property_key) THEN
+						property_diff := property_diff || jsonb_build_object(
+							property_key,
+							jsonb_build_object(
+								'old', OLD.properties->property_key,
+								'new', NEW.properties->property_key
+							)
+						);
+					END IF;
+				END LOOP;
+				changes_json := jsonb_build_object(
+					'properties', property_diff,
+					'occurred_at', jsonb_build_object(
+						'old', OLD.occurred_at,
+						'new', NEW.occurred_at
+					)
+				);
+				-- Add goal fields if changed
+				IF OLD.goal_type IS DISTINCT FROM NEW.goal_type THEN
+					changes_json := changes_json || jsonb_build_object('goal_type', jsonb_build_object('old', OLD.goal_type, 'new', NEW.goal_type));
+				END IF;
+				IF OLD.goal_value IS DISTINCT FROM NEW.goal_value THEN
+					changes_json := changes_json || jsonb_build_object('goal_value', jsonb_build_object('old', OLD.goal_value, 'new', NEW.goal_value));
+				END IF;
+				IF OLD.goal_name IS DISTINCT FROM NEW.goal_name THEN
+					changes_json := changes_json || jsonb_build_object('goal_name', jsonb_build_object('old', OLD.goal_name, 'new', NEW.goal_name));
+				END IF;
+			END IF;
+			INSERT INTO contact_timeline (
+				email, operation, entity_type, kind, entity_id, changes, created_at
+			) VALUES (
+				NEW.email, timeline_operation, 'custom_event', kind_value,
+				NEW.external_id, changes_json, NEW.occurred_at
+			);
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql;`,
+		`CREATE OR REPLACE FUNCTION webhook_custom_events_trigger()
+		RETURNS TRIGGER AS $$
+		DECLARE
+			sub RECORD;
+			custom_filters JSONB;
+			should_deliver BOOLEAN;
+			payload JSONB;
+			event_kind VARCHAR(50);
+			subscribed_event_type VARCHAR(50);
+		BEGIN
+			-- Determine event kind based on operation and soft-delete status
+			IF TG_OP = 'INSERT' THEN
+				-- New record - check if it's being created as deleted
+				IF NEW.deleted_at IS NOT NULL THEN
+					event_kind := 'custom_event.deleted';
+					subscribed_event_type := 'custom_event.deleted';
+				ELSE
+					event_kind := 'custom_event.created';
+					subscribed_event_type := 'custom_event.created';
+				END IF;
+			ELSIF TG_OP = 'UPDATE' THEN
+				-- Re-pointing the event to another contact (merge or email change) is not an event change
+				IF OLD.email IS DISTINCT FROM NEW.email THEN
+					RETURN NEW;
+				END IF;
+				-- Check for soft-delete: was not deleted, now is deleted
+				IF (OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL) THEN
+					event_kind := 'custom_event.deleted';
+					subscribed_event_type := 'custom_event.deleted';
+				-- Check for restore: was deleted, now is not deleted
+				ELSIF (OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL) THEN
+					event_kind := 'custom_event.created';
+					subscribed_event_type := 'custom_event.created';
+				-- Regular update (skip if record is deleted)
+				ELSIF NEW.deleted_at IS NULL THEN
+					event_kind := 'custom_event.updated';
+					subscribed_event_type := 'custom_event.updated';
+				ELSE
+					-- Record is deleted and staying deleted, skip
+					RETURN NEW;
+				END IF;
+			ELSE
+				RETURN NEW;
+			END IF;
+
+			-- Build payload with full custom_event object
+			payload := jsonb_build_object('custom_event', to_jsonb(NEW));
+
+			-- Find matching subscriptions with the correct event type
+			FOR sub IN
+				SELECT id, settings FROM webhook_subscriptions
+				WHERE enabled = true AND subscribed_event_type = ANY(ARRAY(SELECT jsonb_array_elements_text(settings->'event_types')))
+			LOOP
+				should_deliver := true;
+				custom_filters := sub.settings->'custom_event_filters';
+
+				-- Apply goal_types filter if specified
+				IF custom_filters IS NOT NULL AND custom_filters ? 'goal_types'
+				   AND jsonb_array_length(custom_filters->'goal_types') > 0 THEN
+					IF NEW.goal_type IS NULL OR NOT (NEW.goal_type = ANY(
+						SELECT jsonb_array_elements_text(custom_filters->'goal_types')
+					)) THEN
+						should_deliver := false;
+					END IF;
+				END IF;
+
+				-- Apply event_names filter if specified
+				IF should_deliver AND custom_filters IS NOT NULL AND custom_filters ? 'event_names'
+				   AND jsonb_array_length(custom_filters->'event_names') > 0 THEN
+					IF NOT (NEW.event_name = ANY(
+						SELECT jsonb_array_elements_text(custom_filters->'event_names')
+					)) THEN
+						should_deliver := false;
+					END IF;
+				END IF;
+
+				IF should_deliver THEN
+					INSERT INTO webhook_deliveries (id, subscription_id, event_type, payload, status, attempts, max_attempts, next_attempt_at)
+					VALUES (gen_random_uuid()::text, sub.id, event_kind, payload, 'pending', 0, 10, NOW());
+				END IF;
+			END LOOP;
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql`,
+		`ALTER TABLE webhook_subscriptions ADD COLUMN IF NOT EXISTS failing_since TIMESTAMPTZ`,
+		`ALTER TABLE webhook_subscriptions ADD COLUMN IF NOT EXISTS disabled_reason TEXT`,
+		`ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS seq BIGSERIAL`,
+		`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_seq ON webhook_deliveries(subscription_id, seq) WHERE status IN ('pending', 'failed')`,
+		`CREATE TABLE IF NOT EXISTS contact_bulk_operations (
+			id VARCHAR(36) PRIMARY KEY,
+			target JSONB NOT NULL,
+			action JSONB NOT NULL,
+			status VARCHAR(20) NOT NULL,
+			task_id VARCHAR(36),
+			total_count INTEGER NOT NULL DEFAULT 0,
+			processed_count INTEGER NOT NULL DEFAULT 0,
+			succeeded_count INTEGER NOT NULL DEFAULT 0,
+			skipped_count INTEGER NOT NULL DEFAULT 0,
+			failed_count INTEGER NOT NULL DEFAULT 0,
+			error_message TEXT,
+			created_by VARCHAR(255),
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			completed_at TIMESTAMP WITH TIME ZONE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_contact_bulk_operations_created_at ON contact_bulk_operations(created_at DESC)`,
+		`CREATE TABLE IF NOT EXISTS contact_bulk_operation_results (
+			operation_id VARCHAR(36) NOT NULL REFERENCES contact_bulk_operations(id) ON DELETE CASCADE,
+			email VARCHAR(255) NOT NULL,
+			status VARCHAR(20) NOT NULL,
+			error TEXT,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (operation_id, email)
+		)`,
+		`ALTER TABLE blog_posts ADD COLUMN IF NOT EXISTS scheduled_publish_at TIMESTAMP`,
+		`CREATE TABLE IF NOT EXISTS blog_post_revisions (
+			post_id UUID NOT NULL REFERENCES blog_posts(id) ON DELETE CASCADE,
+			version INTEGER NOT NULL,
+			category_id UUID,
+			slug VARCHAR(100) NOT NULL,
+			settings JSONB NOT NULL DEFAULT '{}',
+			status VARCHAR(20) NOT NULL,
+			preview_token VARCHAR(64),
+			restored_from INTEGER,
+			created_by VARCHAR(255),
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			applied_at TIMESTAMP,
+			PRIMARY KEY (post_id, version)
+		)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_blog_post_revisions_preview_token ON blog_post_revisions(preview_token) WHERE preview_token IS NOT NULL`,
+		`ALTER TABLE broadcasts ADD COLUMN IF NOT EXISTS blog_post JSONB`,
+		`ALTER TABLE blog_posts ADD COLUMN IF NOT EXISTS search_config VARCHAR(32) NOT NULL DEFAULT 'simple'`,
+		`ALTER TABLE blog_posts ADD COLUMN IF NOT EXISTS search_text TEXT`,
+		`ALTER TABLE blog_posts ADD COLUMN IF NOT EXISTS search_vector TSVECTOR`,
+		`CREATE TABLE IF NOT EXISTS blog_comments (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			post_id UUID NOT NULL REFERENCES blog_posts(id) ON DELETE CASCADE,
+			parent_id UUID REFERENCES blog_comments(id) ON DELETE CASCADE,
+			email VARCHAR(255) NOT NULL,
+			name VARCHAR(100) NOT NULL,
+			content TEXT NOT NULL,
+			status VARCHAR(20) NOT NULL,
+			spam_reasons TEXT[],
+			ip_address VARCHAR(45),
+			user_agent TEXT,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			approved_at TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_blog_comments_post ON blog_comments(post_id, created_at) WHERE status = 'approved'`,
+		`CREATE INDEX IF NOT EXISTS idx_blog_comments_status ON blog_comments(status, created_at DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_blog_comments_email ON blog_comments(email, created_at DESC)`,
+		`CREATE TABLE IF NOT EXISTS blog_post_reactions (
+			post_id UUID NOT NULL REFERENCES blog_posts(id) ON DELETE CASCADE,
+			email VARCHAR(255) NOT NULL,
+			reaction VARCHAR(20) NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (post_id, email, reaction)
+		)`,
+		`CREATE OR REPLACE FUNCTION track_blog_comment_changes()
+		RETURNS TRIGGER AS $$
+		BEGIN
+			-- A comment is recorded on the timeline of its author the first time it is approved
+			IF NEW.approved_at IS NULL OR (TG_OP = 'UPDATE' AND OLD.approved_at IS NOT NULL) THEN
+				RETURN NEW;
+			END IF;
+			INSERT INTO contact_timeline (email, operation, entity_type, kind, entity_id, changes, created_at)
+			VALUES (NEW.email, 'insert', 'blog_post', 'blog.commented', NEW.post_id::text,
+				jsonb_build_object('comment_id', jsonb_build_object('new', NEW.id), 'parent_id', jsonb_build_object('new', NEW.parent_id)),
+				CURRENT_TIMESTAMP);
+			RETURN NEW;
+		END;
+		$$ LANGUAGE plpgsql;`,
+		`DROP TRIGGER IF EXISTS blog_comment_changes_trigger ON blog_comments`,
+		`CREATE TRIGGER blog_comment_changes_trigger AFTER INSERT OR UPDATE ON blog_comments FOR EACH ROW EXECUTE FUNCTION track_blog_comment_changes()`,
+		`CREATE TABLE IF NOT EXISTS llm_tool_calls (
+			id VARCHAR(36) PRIMARY KEY,
+			user_id VARCHAR(36) NOT NULL,
+			tool_name VARCHAR(100) NOT NULL,
+			input JSONB NOT NULL DEFAULT '{}'::jsonb,
+			status VARCHAR(20) NOT NULL,
+			error TEXT,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_llm_tool_calls_created_at ON llm_tool_calls(created_at DESC)`,
+	}
+
+	for _, stmt := range statements {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("v35 workspace migration failed: %w", err)
+		}
+	}
+
+	// Index the existing posts with the body text of their template. New and updated
+	// posts are indexed by the blog service.
+	searchConfig := domain.TextSearchConfig(domain.DefaultLanguageCode)
+	if workspace != nil && workspace.Settings.DefaultLanguage != "" {
+		searchConfig = domain.TextSearchConfig(workspace.Settings.DefaultLanguage)
+	}
+	if _, err := db.ExecContext(ctx, blogPostSearchBackfill, searchConfig); err != nil {
+		return fmt.Errorf("v35 workspace migration failed to index blog posts: %w", err)
+	}
+
+	return nil
+}
+
+// blogPostSearchBackfill builds the search index of the posts not indexed yet, with the
+// same weights as the blog post repository
+const blogPostSearchBackfill = `UPDATE blog_posts p
+	SET search_config = s.config,
+		search_text = s.body,
+		search_vector = setweight(to_tsvector(s.config::regconfig, COALESCE(p.settings->>'title', '')), 'A') ||
+			setweight(to_tsvector(s.config::regconfig, COALESCE(p.settings->>'excerpt', '')), 'B') ||
+			setweight(to_tsvector(s.config::regconfig, s.body), 'C')
+	FROM (
+		SELECT bp.id, $1::text AS config,
+			COALESCE(NULLIF(t.web->>'plain_text', ''), regexp_replace(t.web->>'html', '<[^>]*>', ' ', 'g'), '') AS body
+		FROM blog_posts bp
+		LEFT JOIN templates t ON t.id = bp.settings->'template'->>'template_id'
+			AND t.version = (bp.settings->'template'->>'template_version')::int
+		WHERE bp.search_vector IS NULL
+	) s
+	WHERE p.id = s.id`
+
+func init() {
+	Register(&V35Migration{})
+}
@@ -0,0 +1,108 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestV35Migration_Metadata(t *testing.T) {
+	m := &V35Migration{}
+	assert.Equal(t, 35.0, m.GetMajorVersion())
+	assert.False(t, m.HasSystemUpdate())
+	assert.True(t, m.HasWorkspaceUpdate())
+	assert.False(t, m.ShouldRestartServer())
+}
+
+func TestV35Migration_UpdateSystem_NoOp(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	err = (&V35Migration{}).UpdateSystem(context.Background(), &config.Config{}, db)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestV35Migration_UpdateWorkspace_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS segment_history").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("idx_segment_history_day").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE contacts ADD COLUMN IF NOT EXISTS computed_properties JSONB").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE contacts ADD COLUMN IF NOT EXISTS attributes JSONB").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE OR REPLACE FUNCTION track_contact_changes").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE OR REPLACE FUNCTION webhook_contacts_trigger").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS consent_records").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("idx_consent_records_email").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("idx_consent_records_list_id").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE OR REPLACE FUNCTION prevent_consent_record_update").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DROP TRIGGER IF EXISTS consent_records_immutable_trigger").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TRIGGER consent_records_immutable_trigger").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS signup_forms").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS signup_form_stats").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE contact_lists ADD COLUMN IF NOT EXISTS frequency").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE contact_lists ADD COLUMN IF NOT EXISTS paused_until").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("idx_contact_lists_paused_until").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE OR REPLACE FUNCTION track_contact_list_changes").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE OR REPLACE FUNCTION webhook_contact_lists_trigger").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE contacts ADD COLUMN IF NOT EXISTS email_verification JSONB").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("idx_contacts_email_verification_status").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_imports").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("idx_contact_imports_created_at").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_aliases").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("idx_contact_aliases_contact_email").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE OR REPLACE FUNCTION track_inbound_webhook_event_changes").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE OR REPLACE FUNCTION track_custom_event_timeline").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE OR REPLACE FUNCTION webhook_custom_events_trigger").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE webhook_subscriptions ADD COLUMN IF NOT EXISTS failing_since").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE webhook_subscriptions ADD COLUMN IF NOT EXISTS disabled_reason").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS seq").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_seq").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_bulk_operations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("idx_contact_bulk_operations_created_at").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_bulk_operation_results").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE blog_posts ADD COLUMN IF NOT EXISTS scheduled_publish_at").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS blog_post_revisions").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("idx_blog_post_revisions_preview_token").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE broadcasts ADD COLUMN IF NOT EXISTS blog_post").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE blog_posts ADD COLUMN IF NOT EXISTS search_config").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE blog_posts ADD COLUMN IF NOT EXISTS search_text").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE blog_posts ADD COLUMN IF NOT EXISTS search_vector").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS blog_comments").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("idx_blog_comments_post").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("idx_blog_comments_status").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("idx_blog_comments_email").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS blog_post_reactions").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE OR REPLACE FUNCTION track_blog_comment_changes").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DROP TRIGGER IF EXISTS blog_comment_changes_trigger").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TRIGGER blog_comment_changes_trigger").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS llm_tool_calls").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("idx_llm_tool_calls_created_at").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE blog_posts p").WithArgs("french").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	workspace := &domain.Workspace{ID: "ws", Settings: domain.WorkspaceSettings{DefaultLanguage: "fr"}}
+	err = (&V35Migration{}).UpdateWorkspace(context.Background(), &config.Config{}, workspace, db)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestV35Migration_UpdateWorkspace_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS segment_history").WillReturnError(errors.New("boom"))
+
+	err = (&V35Migration{}).UpdateWorkspace(context.Background(), &config.Config{}, &domain.Workspace{ID: "ws"}, db)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "v35 workspace migration failed")
+}
@@ -31,6 +31,13 @@ func (r *analyticsRepository) Query(ctx context.Context, workspaceID string, que
 		return nil, fmt.Errorf("unknown schema: %s", query.Schema)
 	}
 
+	if query.Schema == "contacts" {
+		var err error
+		if schema, err = r.withContactAttributes(ctx, workspaceID, schema); err != nil {
+			return nil, err
+		}
+	}
+
 	// Get workspace database connection
 	db, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
 	if err != nil {
@@ -50,12 +57,30 @@ func (r *analyticsRepository) Query(ctx context.Context, workspaceID string, que
 
 // GetSchemas returns the available predefined schemas
 func (r *analyticsRepository) GetSchemas(ctx context.Context, workspaceID string) (map[string]analytics.SchemaDefinition, error) {
-	// For now, return all predefined schemas
-	// In the future, this could be filtered based on workspace permissions or available tables
+	// Return all predefined schemas, the contacts schema also exposes the workspace
+	// contact attributes. In the future, this could be filtered based on workspace permissions
 	schemas := make(map[string]analytics.SchemaDefinition)
 	for name, schema := range domain.PredefinedSchemas {
 		schemas[name] = schema
 	}
 
+	if contacts, ok := schemas["contacts"]; ok {
+		contacts, err := r.withContactAttributes(ctx, workspaceID, contacts)
+		if err != nil {
+			return nil, err
+		}
+		schemas["contacts"] = contacts
+	}
+
 	return schemas, nil
 }
+
+// withContactAttributes adds the workspace contact attributes to the contacts schema dimensions
+func (r *analyticsRepository) withContactAttributes(ctx context.Context, workspaceID string, schema analytics.SchemaDefinition) (analytics.SchemaDefinition, error) {
+	workspace, err := r.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		r.logger.WithField("workspace_id", workspaceID).WithField("error", err.Error()).Error("Failed to get workspace for analytics schema")
+		return schema, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	return domain.WithContactAttributeDimensions(schema, workspace.Settings.ContactAttributes), nil
+}
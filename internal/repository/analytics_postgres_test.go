@@ -157,3 +157,63 @@ func TestAnalyticsRepository_GetSchemas(t *testing.T) {
 	assert.Contains(t, schemas, "schema1")
 	assert.Contains(t, schemas, "schema2")
 }
+
+func TestAnalyticsRepository_GetSchemas_ContactAttributes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "workspace-123").Return(&domain.Workspace{
+		ID: "workspace-123",
+		Settings: domain.WorkspaceSettings{
+			ContactAttributes: []domain.ContactAttribute{
+				{Key: "plan", Type: domain.ContactAttributeTypeString, Label: "Plan"},
+				{Key: "birth_date", Type: domain.ContactAttributeTypeDatetime, PII: true},
+			},
+		},
+	}, nil)
+
+	repo := NewAnalyticsRepository(mockWorkspaceRepo, logger.NewLogger())
+
+	schemas, err := repo.GetSchemas(context.Background(), "workspace-123")
+
+	require.NoError(t, err)
+	contacts := schemas["contacts"]
+	assert.Equal(t, "(attributes->>'plan')", contacts.Dimensions["attr_plan"].SQL)
+	assert.NotContains(t, contacts.Dimensions, "attr_birth_date")
+	// Predefined schemas are not modified
+	assert.NotContains(t, domain.PredefinedSchemas["contacts"].Dimensions, "attr_plan")
+}
+
+func TestAnalyticsRepository_Query_ContactAttributes(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "workspace-123").Return(&domain.Workspace{
+		ID: "workspace-123",
+		Settings: domain.WorkspaceSettings{
+			ContactAttributes: []domain.ContactAttribute{{Key: "plan", Type: domain.ContactAttributeTypeString}},
+		},
+	}, nil)
+	mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace-123").Return(db, nil)
+
+	sqlMock.ExpectQuery(`SELECT \(COUNT\(\*\)\) AS count, \(attributes->>'plan'\) AS attr_plan FROM contacts GROUP BY \(attributes->>'plan'\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "attr_plan"}).AddRow(4, "pro"))
+
+	repo := NewAnalyticsRepository(mockWorkspaceRepo, logger.NewLogger())
+	response, err := repo.Query(context.Background(), "workspace-123", analytics.Query{
+		Schema:     "contacts",
+		Measures:   []string{"count"},
+		Dimensions: []string{"attr_plan"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, "pro", response.Data[0]["attr_plan"])
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
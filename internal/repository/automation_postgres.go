@@ -11,6 +11,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/service"
+	"github.com/lib/pq"
 )
 
 // AutomationRepository implements domain.AutomationRepository
@@ -453,6 +454,45 @@ func (r *AutomationRepository) ExitContactJourneysOnReply(ctx context.Context, w
 	return int(n), nil
 }
 
+// ExitContactJourneys marks the active journeys of the given contacts in an automation as exited
+func (r *AutomationRepository) ExitContactJourneys(ctx context.Context, workspaceID, automationID string, emails []string, reason string) ([]string, error) {
+	if len(emails) == 0 {
+		return []string{}, nil
+	}
+
+	db, err := r.getDB(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database connection: %w", err)
+	}
+
+	query := `
+		UPDATE contact_automations
+		SET status = 'exited', scheduled_at = NULL, exit_reason = $1
+		WHERE automation_id = $2
+		  AND contact_email = ANY($3)
+		  AND status = 'active'
+		RETURNING contact_email`
+
+	rows, err := db.QueryContext(ctx, query, reason, automationID, pq.Array(emails))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exit contact journeys: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	exited := []string{}
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("failed to scan exited journey: %w", err)
+		}
+		exited = append(exited, email)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating exited journeys: %w", err)
+	}
+	return exited, nil
+}
+
 // Delete soft-deletes an automation by setting deleted_at timestamp
 // It also drops the trigger if automation is live and exits all active contacts
 func (r *AutomationRepository) Delete(ctx context.Context, workspaceID, id string) error {
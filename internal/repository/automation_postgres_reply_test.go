@@ -45,3 +45,29 @@ func TestAutomationRepository_ExitContactJourneysOnReply(t *testing.T) {
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestAutomationRepository_ExitContactJourneys(t *testing.T) {
+	t.Run("exits the active journeys of the automation and returns their contacts", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(`(?s)UPDATE contact_automations\s+SET status = 'exited', scheduled_at = NULL, exit_reason = \$1\s+WHERE automation_id = \$2\s+AND contact_email = ANY\(\$3\)\s+AND status = 'active'\s+RETURNING contact_email`).
+			WithArgs("bulk_operation", "auto-1", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"contact_email"}).AddRow("jane@x.com"))
+
+		exited, err := repo.ExitContactJourneys(context.Background(), "ws", "auto-1", []string{"jane@x.com", "john@x.com"}, "bulk_operation")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"jane@x.com"}, exited)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no emails", func(t *testing.T) {
+		db, mock, repo := setupAutomationMock(t)
+		defer func() { _ = db.Close() }()
+
+		exited, err := repo.ExitContactJourneys(context.Background(), "ws", "auto-1", nil, "bulk_operation")
+		require.NoError(t, err)
+		assert.Empty(t, exited)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
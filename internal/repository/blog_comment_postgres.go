@@ -0,0 +1,392 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/lib/pq"
+)
+
+// blogCommentRepository implements domain.BlogCommentRepository for PostgreSQL
+type blogCommentRepository struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+// NewBlogCommentRepository creates a new PostgreSQL blog comment repository
+func NewBlogCommentRepository(workspaceRepo domain.WorkspaceRepository) domain.BlogCommentRepository {
+	return &blogCommentRepository{
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// getConnection returns the database of the workspace in the context
+func (r *blogCommentRepository) getConnection(ctx context.Context) (*sql.DB, error) {
+	workspaceID, ok := ctx.Value(domain.WorkspaceIDKey).(string)
+	if !ok {
+		return nil, fmt.Errorf("workspace_id not found in context")
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	return workspaceDB, nil
+}
+
+// blogCommentColumns lists the columns scanned by scanBlogComment
+const blogCommentColumns = `id, post_id, parent_id, email, name, content, status, spam_reasons,
+		ip_address, user_agent, created_at, updated_at, approved_at`
+
+// scanBlogComment scans a blog comment row
+func scanBlogComment(scanner interface{ Scan(...interface{}) error }) (*domain.BlogComment, error) {
+	var comment domain.BlogComment
+	var parentID, ipAddress, userAgent sql.NullString
+	var spamReasons pq.StringArray
+
+	err := scanner.Scan(
+		&comment.ID,
+		&comment.PostID,
+		&parentID,
+		&comment.Email,
+		&comment.Name,
+		&comment.Content,
+		&comment.Status,
+		&spamReasons,
+		&ipAddress,
+		&userAgent,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+		&comment.ApprovedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID.Valid {
+		comment.ParentID = &parentID.String
+	}
+	comment.SpamReasons = spamReasons
+	comment.IPAddress = ipAddress.String
+	comment.UserAgent = userAgent.String
+
+	return &comment, nil
+}
+
+// CreateComment persists a new blog comment
+func (r *blogCommentRepository) CreateComment(ctx context.Context, comment *domain.BlogComment) error {
+	workspaceDB, err := r.getConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO blog_comments (
+			id, post_id, parent_id, email, name, content, status, spam_reasons,
+			ip_address, user_agent, created_at, updated_at, approved_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err = workspaceDB.ExecContext(ctx, query,
+		comment.ID,
+		comment.PostID,
+		comment.ParentID,
+		comment.Email,
+		comment.Name,
+		comment.Content,
+		comment.Status,
+		pq.Array(comment.SpamReasons),
+		sql.NullString{String: comment.IPAddress, Valid: comment.IPAddress != ""},
+		sql.NullString{String: comment.UserAgent, Valid: comment.UserAgent != ""},
+		comment.CreatedAt,
+		comment.UpdatedAt,
+		comment.ApprovedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create blog comment: %w", err)
+	}
+
+	return nil
+}
+
+// GetComment retrieves a blog comment by ID
+func (r *blogCommentRepository) GetComment(ctx context.Context, id string) (*domain.BlogComment, error) {
+	workspaceDB, err := r.getConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + blogCommentColumns + `
+		FROM blog_comments
+		WHERE id = $1`
+
+	comment, err := scanBlogComment(workspaceDB.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, &domain.ErrNotFound{Entity: "blog comment", ID: id}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+// UpdateComment updates the moderation status of a blog comment
+func (r *blogCommentRepository) UpdateComment(ctx context.Context, comment *domain.BlogComment) error {
+	workspaceDB, err := r.getConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE blog_comments
+		SET status = $1, spam_reasons = $2, updated_at = $3, approved_at = $4
+		WHERE id = $5
+	`
+
+	result, err := workspaceDB.ExecContext(ctx, query,
+		comment.Status,
+		pq.Array(comment.SpamReasons),
+		comment.UpdatedAt,
+		comment.ApprovedAt,
+		comment.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update blog comment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return &domain.ErrNotFound{Entity: "blog comment", ID: comment.ID}
+	}
+
+	return nil
+}
+
+// DeleteComment deletes a blog comment, its replies are deleted with it
+func (r *blogCommentRepository) DeleteComment(ctx context.Context, id string) error {
+	workspaceDB, err := r.getConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := workspaceDB.ExecContext(ctx, `DELETE FROM blog_comments WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete blog comment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return &domain.ErrNotFound{Entity: "blog comment", ID: id}
+	}
+
+	return nil
+}
+
+// ListComments retrieves the comments with a status, newest first
+func (r *blogCommentRepository) ListComments(ctx context.Context, params domain.ListBlogCommentsRequest) (*domain.BlogCommentListResponse, error) {
+	workspaceDB, err := r.getConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	whereConditions := []string{"status = $1"}
+	args := []interface{}{params.Status}
+	argIndex := 2
+
+	if params.PostID != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("post_id = $%d", argIndex))
+		args = append(args, params.PostID)
+		argIndex++
+	}
+
+	whereClause := "WHERE " + strings.Join(whereConditions, " AND ")
+
+	var totalCount int
+	countQuery := `SELECT COUNT(*) FROM blog_comments ` + whereClause
+	if err := workspaceDB.QueryRowContext(ctx, countQuery, args...).Scan(&totalCount); err != nil {
+		return nil, fmt.Errorf("failed to count blog comments: %w", err)
+	}
+
+	query := fmt.Sprintf(`SELECT %s
+		FROM blog_comments
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`, blogCommentColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, params.Limit, params.Offset)
+
+	comments, err := r.queryComments(ctx, workspaceDB, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.BlogCommentListResponse{
+		Comments:   comments,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// ListApprovedComments retrieves the approved comments of a post, oldest first
+func (r *blogCommentRepository) ListApprovedComments(ctx context.Context, postID string) ([]*domain.BlogComment, error) {
+	workspaceDB, err := r.getConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + blogCommentColumns + `
+		FROM blog_comments
+		WHERE post_id = $1 AND status = 'approved'
+		ORDER BY created_at ASC`
+
+	return r.queryComments(ctx, workspaceDB, query, postID)
+}
+
+// queryComments scans the comments returned by a query
+func (r *blogCommentRepository) queryComments(ctx context.Context, workspaceDB *sql.DB, query string, args ...interface{}) ([]*domain.BlogComment, error) {
+	rows, err := workspaceDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blog comments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	comments := []*domain.BlogComment{}
+	for rows.Next() {
+		comment, err := scanBlogComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan blog comment: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating blog comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// CountDuplicateComments counts the comments of an author with the same content since a time
+func (r *blogCommentRepository) CountDuplicateComments(ctx context.Context, email, content string, since time.Time) (int, error) {
+	workspaceDB, err := r.getConnection(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		SELECT COUNT(*)
+		FROM blog_comments
+		WHERE email = $1 AND content = $2 AND created_at >= $3
+	`
+
+	var count int
+	if err := workspaceDB.QueryRowContext(ctx, query, email, content, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count duplicate blog comments: %w", err)
+	}
+
+	return count, nil
+}
+
+// AddReaction records the reaction of a contact to a post, once
+func (r *blogCommentRepository) AddReaction(ctx context.Context, reaction *domain.BlogPostReaction) error {
+	workspaceDB, err := r.getConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO blog_post_reactions (post_id, email, reaction, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (post_id, email, reaction) DO NOTHING
+	`
+
+	_, err = workspaceDB.ExecContext(ctx, query, reaction.PostID, reaction.Email, reaction.Reaction, reaction.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add blog post reaction: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveReaction removes the reaction of a contact to a post
+func (r *blogCommentRepository) RemoveReaction(ctx context.Context, postID, email, reaction string) error {
+	workspaceDB, err := r.getConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM blog_post_reactions WHERE post_id = $1 AND email = $2 AND reaction = $3`
+
+	if _, err := workspaceDB.ExecContext(ctx, query, postID, email, reaction); err != nil {
+		return fmt.Errorf("failed to remove blog post reaction: %w", err)
+	}
+
+	return nil
+}
+
+// CountReactions returns the number of reactions of a post by reaction type
+func (r *blogCommentRepository) CountReactions(ctx context.Context, postID string) (map[string]int, error) {
+	workspaceDB, err := r.getConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT reaction, COUNT(*)
+		FROM blog_post_reactions
+		WHERE post_id = $1
+		GROUP BY reaction
+	`
+
+	rows, err := workspaceDB.QueryContext(ctx, query, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count blog post reactions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var reaction string
+		var count int
+		if err := rows.Scan(&reaction, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan blog post reaction count: %w", err)
+		}
+		counts[reaction] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating blog post reactions: %w", err)
+	}
+
+	return counts, nil
+}
+
+// DeleteForEmail deletes the comments, with their replies, and the reactions of an
+// author when the contact is erased
+func (r *blogCommentRepository) DeleteForEmail(ctx context.Context, workspaceID, email string) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	if _, err := workspaceDB.ExecContext(ctx, `DELETE FROM blog_post_reactions WHERE email = $1`, email); err != nil {
+		return fmt.Errorf("failed to delete blog post reactions: %w", err)
+	}
+
+	if _, err := workspaceDB.ExecContext(ctx, `DELETE FROM blog_comments WHERE email = $1`, email); err != nil {
+		return fmt.Errorf("failed to delete blog comments: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+)
+
+func TestBlogCommentRepository(t *testing.T) {
+	commentColumns := []string{
+		"id", "post_id", "parent_id", "email", "name", "content", "status", "spam_reasons",
+		"ip_address", "user_agent", "created_at", "updated_at", "approved_at",
+	}
+
+	setup := func(t *testing.T) (domain.BlogCommentRepository, sqlmock.Sqlmock, context.Context) {
+		ctrl := gomock.NewController(t)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+
+		db, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws1").Return(db, nil).AnyTimes()
+		ctx := context.WithValue(context.Background(), domain.WorkspaceIDKey, "ws1")
+		return NewBlogCommentRepository(mockWorkspaceRepo), sqlMock, ctx
+	}
+
+	now := time.Now().UTC()
+
+	t.Run("CreateComment stores the spam reasons", func(t *testing.T) {
+		repo, sqlMock, ctx := setup(t)
+
+		comment := &domain.BlogComment{
+			ID:          "c1",
+			PostID:      "p1",
+			Email:       "reader@example.com",
+			Name:        "Reader",
+			Content:     "Great post",
+			Status:      domain.BlogCommentStatusSpam,
+			SpamReasons: []string{domain.BlogCommentSpamHoneypot},
+			IPAddress:   "1.2.3.4",
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		sqlMock.ExpectExec(`INSERT INTO blog_comments`).
+			WithArgs("c1", "p1", comment.ParentID, "reader@example.com", "Reader", "Great post",
+				domain.BlogCommentStatusSpam, pq.Array([]string{domain.BlogCommentSpamHoneypot}),
+				sql.NullString{String: "1.2.3.4", Valid: true}, sql.NullString{},
+				now, now, comment.ApprovedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.CreateComment(ctx, comment))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("GetComment scans a reply", func(t *testing.T) {
+		repo, sqlMock, ctx := setup(t)
+
+		sqlMock.ExpectQuery(`FROM blog_comments\s+WHERE id = \$1`).
+			WithArgs("c2").
+			WillReturnRows(sqlmock.NewRows(commentColumns).AddRow(
+				"c2", "p1", "c1", "reader@example.com", "Reader", "Thanks", "approved", "{duplicate}",
+				nil, nil, now, now, now,
+			))
+
+		comment, err := repo.GetComment(ctx, "c2")
+		require.NoError(t, err)
+		require.NotNil(t, comment.ParentID)
+		assert.Equal(t, "c1", *comment.ParentID)
+		assert.Equal(t, []string{"duplicate"}, comment.SpamReasons)
+		assert.NotNil(t, comment.ApprovedAt)
+		assert.Empty(t, comment.IPAddress)
+	})
+
+	t.Run("GetComment returns not found", func(t *testing.T) {
+		repo, sqlMock, ctx := setup(t)
+
+		sqlMock.ExpectQuery(`FROM blog_comments`).WithArgs("missing").WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.GetComment(ctx, "missing")
+		assert.IsType(t, &domain.ErrNotFound{}, err)
+	})
+
+	t.Run("UpdateComment returns not found without rows", func(t *testing.T) {
+		repo, sqlMock, ctx := setup(t)
+
+		sqlMock.ExpectExec(`UPDATE blog_comments`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.UpdateComment(ctx, &domain.BlogComment{ID: "c1", Status: domain.BlogCommentStatusApproved})
+		assert.IsType(t, &domain.ErrNotFound{}, err)
+	})
+
+	t.Run("ListComments filters by status and post", func(t *testing.T) {
+		repo, sqlMock, ctx := setup(t)
+
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM blog_comments WHERE status = $1 AND post_id = $2`)).
+			WithArgs(domain.BlogCommentStatusPending, "p1").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		sqlMock.ExpectQuery(`ORDER BY created_at DESC\s+LIMIT \$3 OFFSET \$4`).
+			WithArgs(domain.BlogCommentStatusPending, "p1", 50, 0).
+			WillReturnRows(sqlmock.NewRows(commentColumns).AddRow(
+				"c1", "p1", nil, "reader@example.com", "Reader", "Great post", "pending", "{}",
+				"1.2.3.4", "Mozilla", now, now, nil,
+			))
+
+		response, err := repo.ListComments(ctx, domain.ListBlogCommentsRequest{
+			Status: domain.BlogCommentStatusPending,
+			PostID: "p1",
+			Limit:  50,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, response.TotalCount)
+		require.Len(t, response.Comments, 1)
+		assert.Equal(t, "Mozilla", response.Comments[0].UserAgent)
+		assert.Nil(t, response.Comments[0].ApprovedAt)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("AddReaction ignores repeated reactions", func(t *testing.T) {
+		repo, sqlMock, ctx := setup(t)
+
+		sqlMock.ExpectExec(`ON CONFLICT \(post_id, email, reaction\) DO NOTHING`).
+			WithArgs("p1", "reader@example.com", "like", now).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.AddReaction(ctx, &domain.BlogPostReaction{PostID: "p1", Email: "reader@example.com", Reaction: "like", CreatedAt: now})
+		require.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("CountReactions groups by reaction", func(t *testing.T) {
+		repo, sqlMock, ctx := setup(t)
+
+		sqlMock.ExpectQuery(`GROUP BY reaction`).
+			WithArgs("p1").
+			WillReturnRows(sqlmock.NewRows([]string{"reaction", "count"}).AddRow("like", 3).AddRow("love", 1))
+
+		counts, err := repo.CountReactions(ctx, "p1")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"like": 3, "love": 1}, counts)
+	})
+
+	t.Run("CountDuplicateComments", func(t *testing.T) {
+		repo, sqlMock, ctx := setup(t)
+
+		sqlMock.ExpectQuery(`WHERE email = \$1 AND content = \$2 AND created_at >= \$3`).
+			WithArgs("reader@example.com", "Great post", now).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+		count, err := repo.CountDuplicateComments(ctx, "reader@example.com", "Great post", now)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+	t.Run("DeleteForEmail deletes the reactions and comments of an author", func(t *testing.T) {
+		repo, sqlMock, _ := setup(t)
+
+		sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM blog_post_reactions WHERE email = $1`)).
+			WithArgs("reader@example.com").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM blog_comments WHERE email = $1`)).
+			WithArgs("reader@example.com").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.DeleteForEmail(context.Background(), "ws1", "reader@example.com")
+		require.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
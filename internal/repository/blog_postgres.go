@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"html"
 	"strings"
 	"time"
 
@@ -431,8 +432,8 @@ func (r *blogPostRepository) CreatePostTx(ctx context.Context, tx *sql.Tx, post
 
 	query := `
 		INSERT INTO blog_posts (
-			id, category_id, slug, settings, published_at, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			id, category_id, slug, settings, published_at, scheduled_publish_at, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
 	_, err := tx.ExecContext(ctx, query,
@@ -441,6 +442,7 @@ func (r *blogPostRepository) CreatePostTx(ctx context.Context, tx *sql.Tx, post
 		post.Slug,
 		post.Settings,
 		post.PublishedAt,
+		post.ScheduledPublishAt,
 		post.CreatedAt,
 		post.UpdatedAt,
 	)
@@ -464,7 +466,7 @@ func (r *blogPostRepository) GetPost(ctx context.Context, id string) (*domain.Bl
 	}
 
 	query := `
-		SELECT id, category_id, slug, settings, published_at, created_at, updated_at, deleted_at
+		SELECT id, category_id, slug, settings, published_at, scheduled_publish_at, created_at, updated_at, deleted_at
 		FROM blog_posts
 		WHERE id = $1 AND deleted_at IS NULL
 	`
@@ -476,6 +478,7 @@ func (r *blogPostRepository) GetPost(ctx context.Context, id string) (*domain.Bl
 		&post.Slug,
 		&post.Settings,
 		&post.PublishedAt,
+		&post.ScheduledPublishAt,
 		&post.CreatedAt,
 		&post.UpdatedAt,
 		&post.DeletedAt,
@@ -493,7 +496,7 @@ func (r *blogPostRepository) GetPost(ctx context.Context, id string) (*domain.Bl
 // GetPostTx retrieves a blog post by ID within a transaction
 func (r *blogPostRepository) GetPostTx(ctx context.Context, tx *sql.Tx, id string) (*domain.BlogPost, error) {
 	query := `
-		SELECT id, category_id, slug, settings, published_at, created_at, updated_at, deleted_at
+		SELECT id, category_id, slug, settings, published_at, scheduled_publish_at, created_at, updated_at, deleted_at
 		FROM blog_posts
 		WHERE id = $1 AND deleted_at IS NULL
 	`
@@ -505,6 +508,7 @@ func (r *blogPostRepository) GetPostTx(ctx context.Context, tx *sql.Tx, id strin
 		&post.Slug,
 		&post.Settings,
 		&post.PublishedAt,
+		&post.ScheduledPublishAt,
 		&post.CreatedAt,
 		&post.UpdatedAt,
 		&post.DeletedAt,
@@ -532,7 +536,7 @@ func (r *blogPostRepository) GetPostBySlug(ctx context.Context, slug string) (*d
 	}
 
 	query := `
-		SELECT id, category_id, slug, settings, published_at, created_at, updated_at, deleted_at
+		SELECT id, category_id, slug, settings, published_at, scheduled_publish_at, created_at, updated_at, deleted_at
 		FROM blog_posts
 		WHERE slug = $1 AND deleted_at IS NULL
 	`
@@ -544,6 +548,7 @@ func (r *blogPostRepository) GetPostBySlug(ctx context.Context, slug string) (*d
 		&post.Slug,
 		&post.Settings,
 		&post.PublishedAt,
+		&post.ScheduledPublishAt,
 		&post.CreatedAt,
 		&post.UpdatedAt,
 		&post.DeletedAt,
@@ -561,7 +566,7 @@ func (r *blogPostRepository) GetPostBySlug(ctx context.Context, slug string) (*d
 // GetPostBySlugTx retrieves a blog post by slug within a transaction
 func (r *blogPostRepository) GetPostBySlugTx(ctx context.Context, tx *sql.Tx, slug string) (*domain.BlogPost, error) {
 	query := `
-		SELECT id, category_id, slug, settings, published_at, created_at, updated_at, deleted_at
+		SELECT id, category_id, slug, settings, published_at, scheduled_publish_at, created_at, updated_at, deleted_at
 		FROM blog_posts
 		WHERE slug = $1 AND deleted_at IS NULL
 	`
@@ -573,6 +578,7 @@ func (r *blogPostRepository) GetPostBySlugTx(ctx context.Context, tx *sql.Tx, sl
 		&post.Slug,
 		&post.Settings,
 		&post.PublishedAt,
+		&post.ScheduledPublishAt,
 		&post.CreatedAt,
 		&post.UpdatedAt,
 		&post.DeletedAt,
@@ -600,7 +606,7 @@ func (r *blogPostRepository) GetPostByCategoryAndSlug(ctx context.Context, categ
 	}
 
 	query := `
-		SELECT p.id, p.category_id, p.slug, p.settings, p.published_at, p.created_at, p.updated_at, p.deleted_at
+		SELECT p.id, p.category_id, p.slug, p.settings, p.published_at, p.scheduled_publish_at, p.created_at, p.updated_at, p.deleted_at
 		FROM blog_posts p
 		INNER JOIN blog_categories c ON p.category_id = c.id
 		WHERE c.slug = $1 AND p.slug = $2 AND p.deleted_at IS NULL AND c.deleted_at IS NULL
@@ -613,6 +619,49 @@ func (r *blogPostRepository) GetPostByCategoryAndSlug(ctx context.Context, categ
 		&post.Slug,
 		&post.Settings,
 		&post.PublishedAt,
+		&post.ScheduledPublishAt,
+		&post.CreatedAt,
+		&post.UpdatedAt,
+		&post.DeletedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("blog post not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog post: %w", err)
+	}
+
+	return &post, nil
+}
+
+// GetPostByTranslationSlug retrieves a post of a category by the slug of its translation
+// in a language
+func (r *blogPostRepository) GetPostByTranslationSlug(ctx context.Context, categoryID, language, slug string) (*domain.BlogPost, error) {
+	workspaceID, ok := ctx.Value(domain.WorkspaceIDKey).(string)
+	if !ok {
+		return nil, fmt.Errorf("workspace_id not found in context")
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		SELECT id, category_id, slug, settings, published_at, scheduled_publish_at, created_at, updated_at, deleted_at
+		FROM blog_posts
+		WHERE category_id = $1 AND settings->'translations'->$2->>'slug' = $3 AND deleted_at IS NULL
+		LIMIT 1
+	`
+
+	var post domain.BlogPost
+	err = workspaceDB.QueryRowContext(ctx, query, categoryID, language, slug).Scan(
+		&post.ID,
+		&post.CategoryID,
+		&post.Slug,
+		&post.Settings,
+		&post.PublishedAt,
+		&post.ScheduledPublishAt,
 		&post.CreatedAt,
 		&post.UpdatedAt,
 		&post.DeletedAt,
@@ -645,8 +694,8 @@ func (r *blogPostRepository) UpdatePostTx(ctx context.Context, tx *sql.Tx, post
 
 	query := `
 		UPDATE blog_posts
-		SET category_id = $1, slug = $2, settings = $3, published_at = $4, updated_at = $5
-		WHERE id = $6 AND deleted_at IS NULL
+		SET category_id = $1, slug = $2, settings = $3, published_at = $4, scheduled_publish_at = $5, updated_at = $6
+		WHERE id = $7 AND deleted_at IS NULL
 	`
 
 	result, err := tx.ExecContext(ctx, query,
@@ -654,6 +703,7 @@ func (r *blogPostRepository) UpdatePostTx(ctx context.Context, tx *sql.Tx, post
 		post.Slug,
 		post.Settings,
 		post.PublishedAt,
+		post.ScheduledPublishAt,
 		post.UpdatedAt,
 		post.ID,
 	)
@@ -738,9 +788,26 @@ func (r *blogPostRepository) ListPosts(ctx context.Context, params domain.ListBl
 		whereConditions = append(whereConditions, "published_at IS NULL")
 	case domain.BlogPostStatusPublished:
 		whereConditions = append(whereConditions, "published_at IS NOT NULL")
+	case domain.BlogPostStatusScheduled:
+		whereConditions = append(whereConditions, "published_at IS NULL AND scheduled_publish_at IS NOT NULL")
 		// BlogPostStatusAll means no filter on published_at
 	}
 
+	if params.Language != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("settings->'translations' ? $%d", argIndex))
+		args = append(args, params.Language)
+		argIndex++
+	}
+
+	// Each post is matched with the text search configuration it was indexed with
+	searchQuery := ""
+	if params.Query != "" {
+		searchQuery = fmt.Sprintf("websearch_to_tsquery(search_config::regconfig, $%d)", argIndex)
+		whereConditions = append(whereConditions, "search_vector @@ "+searchQuery)
+		args = append(args, params.Query)
+		argIndex++
+	}
+
 	whereClause := "WHERE " + whereConditions[0]
 	for i := 1; i < len(whereConditions); i++ {
 		whereClause += " AND " + whereConditions[i]
@@ -750,6 +817,11 @@ func (r *blogPostRepository) ListPosts(ctx context.Context, params domain.ListBl
 	orderByClause := "ORDER BY created_at DESC"
 	if params.Status == domain.BlogPostStatusPublished {
 		orderByClause = "ORDER BY published_at DESC"
+	} else if params.Status == domain.BlogPostStatusScheduled {
+		orderByClause = "ORDER BY scheduled_publish_at ASC"
+	}
+	if searchQuery != "" {
+		orderByClause = fmt.Sprintf("ORDER BY ts_rank_cd(search_vector, %s) DESC, created_at DESC", searchQuery)
 	}
 
 	// Count total
@@ -767,7 +839,7 @@ func (r *blogPostRepository) ListPosts(ctx context.Context, params domain.ListBl
 
 	// Get posts with pagination
 	query := fmt.Sprintf(`
-		SELECT id, category_id, slug, settings, published_at, created_at, updated_at, deleted_at
+		SELECT id, category_id, slug, settings, published_at, scheduled_publish_at, created_at, updated_at, deleted_at
 		FROM blog_posts
 		%s
 		%s
@@ -791,6 +863,7 @@ func (r *blogPostRepository) ListPosts(ctx context.Context, params domain.ListBl
 			&post.Slug,
 			&post.Settings,
 			&post.PublishedAt,
+			&post.ScheduledPublishAt,
 			&post.CreatedAt,
 			&post.UpdatedAt,
 			&post.DeletedAt,
@@ -827,7 +900,7 @@ func (r *blogPostRepository) ListPosts(ctx context.Context, params domain.ListBl
 
 // ListFeedPosts returns the top `limit` published posts (newest first) for
 // feed syndication.
-func (r *blogPostRepository) ListFeedPosts(ctx context.Context, categorySlug *string, limit int) ([]*domain.BlogPost, error) {
+func (r *blogPostRepository) ListFeedPosts(ctx context.Context, language string, categorySlug *string, limit int) ([]*domain.BlogPost, error) {
 	workspaceID, ok := ctx.Value(domain.WorkspaceIDKey).(string)
 	if !ok {
 		return nil, fmt.Errorf("workspace_id not found in context")
@@ -838,17 +911,12 @@ func (r *blogPostRepository) ListFeedPosts(ctx context.Context, categorySlug *st
 		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
 	}
 
-	args := []interface{}{}
-	categoryFilter := ""
-	if categorySlug != nil && *categorySlug != "" {
-		categoryFilter = " AND c.slug = $1"
-		args = append(args, *categorySlug)
-	}
+	categoryFilter, args := feedPostFilters(language, categorySlug)
 	args = append(args, limit)
 	limitPlaceholder := fmt.Sprintf("$%d", len(args))
 
 	query := fmt.Sprintf(`
-		SELECT p.id, p.category_id, p.slug, p.settings, p.published_at, p.created_at, p.updated_at, p.deleted_at
+		SELECT p.id, p.category_id, p.slug, p.settings, p.published_at, p.scheduled_publish_at, p.created_at, p.updated_at, p.deleted_at
 		FROM blog_posts p
 		JOIN blog_categories c ON c.id = p.category_id AND c.deleted_at IS NULL
 		WHERE p.deleted_at IS NULL
@@ -874,6 +942,7 @@ func (r *blogPostRepository) ListFeedPosts(ctx context.Context, categorySlug *st
 			&post.Slug,
 			&post.Settings,
 			&post.PublishedAt,
+			&post.ScheduledPublishAt,
 			&post.CreatedAt,
 			&post.UpdatedAt,
 			&post.DeletedAt,
@@ -888,6 +957,22 @@ func (r *blogPostRepository) ListFeedPosts(ctx context.Context, categorySlug *st
 	return posts, nil
 }
 
+// feedPostFilters returns the conditions and arguments selecting the posts of a feed
+// beyond the published ones: those of a category, and those translated in a language
+func feedPostFilters(language string, categorySlug *string) (string, []interface{}) {
+	filters := ""
+	args := []interface{}{}
+	if categorySlug != nil && *categorySlug != "" {
+		args = append(args, *categorySlug)
+		filters += fmt.Sprintf(" AND c.slug = $%d", len(args))
+	}
+	if language != "" {
+		args = append(args, language)
+		filters += fmt.Sprintf(" AND p.settings->'translations' ? $%d", len(args))
+	}
+	return filters, args
+}
+
 // GetFeedFingerprint returns (maxUpdatedAt, idsHash) over the same slice of
 // posts ListFeedPosts would return.
 //
@@ -895,7 +980,7 @@ func (r *blogPostRepository) ListFeedPosts(ctx context.Context, categorySlug *st
 // rename invalidates the ETag even if no post was touched. idsHash = MD5 of
 // the comma-joined, sorted post IDs so a same-second delete-and-publish that
 // leaves the timestamp unchanged still invalidates.
-func (r *blogPostRepository) GetFeedFingerprint(ctx context.Context, categorySlug *string, limit int) (time.Time, string, error) {
+func (r *blogPostRepository) GetFeedFingerprint(ctx context.Context, language string, categorySlug *string, limit int) (time.Time, string, error) {
 	workspaceID, ok := ctx.Value(domain.WorkspaceIDKey).(string)
 	if !ok {
 		return time.Time{}, "", fmt.Errorf("workspace_id not found in context")
@@ -906,12 +991,7 @@ func (r *blogPostRepository) GetFeedFingerprint(ctx context.Context, categorySlu
 		return time.Time{}, "", fmt.Errorf("failed to get workspace connection: %w", err)
 	}
 
-	args := []interface{}{}
-	categoryFilter := ""
-	if categorySlug != nil && *categorySlug != "" {
-		categoryFilter = " AND c.slug = $1"
-		args = append(args, *categorySlug)
-	}
+	categoryFilter, args := feedPostFilters(language, categorySlug)
 	args = append(args, limit)
 	limitPlaceholder := fmt.Sprintf("$%d", len(args))
 
@@ -950,6 +1030,168 @@ func (r *blogPostRepository) GetFeedFingerprint(ctx context.Context, categorySlu
 	return maxUpdatedAt.Time.UTC(), idsHash, nil
 }
 
+// Search highlights are delimited with control characters, which cannot come from the
+// indexed text, so that the text can be HTML escaped before the delimiters become tags
+const (
+	searchHighlightStart = "\x02"
+	searchHighlightStop  = "\x03"
+)
+
+var (
+	searchTitleHeadlineOptions   = fmt.Sprintf("StartSel=%s, StopSel=%s, HighlightAll=true", searchHighlightStart, searchHighlightStop)
+	searchSnippetHeadlineOptions = fmt.Sprintf(`StartSel=%s, StopSel=%s, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "`, searchHighlightStart, searchHighlightStop)
+	searchHighlightReplacer      = strings.NewReplacer(searchHighlightStart, "<mark>", searchHighlightStop, "</mark>")
+)
+
+// searchHighlightHTML escapes a ts_headline result and wraps its matches in <mark> tags
+func searchHighlightHTML(headline string) string {
+	return searchHighlightReplacer.Replace(html.EscapeString(headline))
+}
+
+// searchPostsFrom selects the searchable posts with their text search query. The body of
+// gated posts, restricted by their own gate or by the one of their category, is not
+// searchable: they are matched on their title and excerpt only, so that a search does not
+// reveal what they contain.
+const searchPostsFrom = `
+		FROM blog_posts p
+		LEFT JOIN blog_categories c ON c.id = p.category_id
+		CROSS JOIN LATERAL (
+			SELECT p.search_config::regconfig AS config, websearch_to_tsquery(p.search_config::regconfig, $1) AS query,
+				(p.settings->'gate' IS NOT NULL OR c.settings->'gate' IS NOT NULL) AS gated
+		) q
+		CROSS JOIN LATERAL (
+			SELECT CASE WHEN q.gated
+				THEN setweight(to_tsvector(q.config, COALESCE(p.settings->>'title', '')), 'A') ||
+					setweight(to_tsvector(q.config, COALESCE(p.settings->>'excerpt', '')), 'B')
+				ELSE p.search_vector
+			END AS vector
+		) v
+		WHERE p.deleted_at IS NULL
+		  AND p.published_at IS NOT NULL
+		  AND v.vector @@ q.query`
+
+// SearchPosts returns the published posts matching a search, ranked by relevance. Each
+// post is matched with the text search configuration of its language, so the search
+// works across the languages of the workspace.
+func (r *blogPostRepository) SearchPosts(ctx context.Context, params domain.BlogSearchRequest) (*domain.BlogSearchResponse, error) {
+	workspaceID, ok := ctx.Value(domain.WorkspaceIDKey).(string)
+	if !ok {
+		return nil, fmt.Errorf("workspace_id not found in context")
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	countQuery := `SELECT COUNT(*)` + searchPostsFrom
+
+	var totalCount int
+	if err := workspaceDB.QueryRowContext(ctx, countQuery, params.Query).Scan(&totalCount); err != nil {
+		return nil, fmt.Errorf("failed to count blog search results: %w", err)
+	}
+
+	// The snippet is taken from the body, or from the excerpt of gated posts and of posts
+	// without body text
+	query := `
+		SELECT p.id, p.category_id, p.slug, p.settings, p.published_at, p.scheduled_publish_at, p.created_at, p.updated_at, p.deleted_at,
+			COALESCE(c.slug, ''),
+			ts_rank_cd(v.vector, q.query) AS rank,
+			ts_headline(q.config, COALESCE(p.settings->>'title', ''), q.query, $2),
+			ts_headline(q.config, COALESCE(NULLIF(CASE WHEN q.gated THEN '' ELSE p.search_text END, ''), p.settings->>'excerpt', ''), q.query, $3)` +
+		searchPostsFrom + `
+		ORDER BY rank DESC, p.published_at DESC
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := workspaceDB.QueryContext(ctx, query, params.Query, searchTitleHeadlineOptions, searchSnippetHeadlineOptions, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search blog posts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	results := []*domain.BlogSearchResult{}
+	for rows.Next() {
+		var post domain.BlogPost
+		var result domain.BlogSearchResult
+		if err := rows.Scan(
+			&post.ID,
+			&post.CategoryID,
+			&post.Slug,
+			&post.Settings,
+			&post.PublishedAt,
+			&post.ScheduledPublishAt,
+			&post.CreatedAt,
+			&post.UpdatedAt,
+			&post.DeletedAt,
+			&result.CategorySlug,
+			&result.Rank,
+			&result.TitleHighlight,
+			&result.Snippet,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan blog search result: %w", err)
+		}
+		result.Post = &post
+		result.TitleHighlight = searchHighlightHTML(result.TitleHighlight)
+		result.Snippet = searchHighlightHTML(result.Snippet)
+		results = append(results, &result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating blog search results: %w", err)
+	}
+
+	totalPages := 0
+	if params.Limit > 0 {
+		totalPages = (totalCount + params.Limit - 1) / params.Limit
+	}
+	currentPage := params.Page
+	if currentPage <= 0 {
+		currentPage = 1
+	}
+
+	return &domain.BlogSearchResponse{
+		Query:           params.Query,
+		Results:         results,
+		TotalCount:      totalCount,
+		CurrentPage:     currentPage,
+		TotalPages:      totalPages,
+		HasNextPage:     currentPage < totalPages,
+		HasPreviousPage: currentPage > 1,
+	}, nil
+}
+
+// UpdatePostSearchIndex stores the body text of a post and rebuilds its search vector,
+// weighting the title above the excerpt and the excerpt above the body
+func (r *blogPostRepository) UpdatePostSearchIndex(ctx context.Context, id, searchConfig, bodyText string) error {
+	workspaceID, ok := ctx.Value(domain.WorkspaceIDKey).(string)
+	if !ok {
+		return fmt.Errorf("workspace_id not found in context")
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		UPDATE blog_posts p
+		SET search_config = s.config,
+			search_text = s.body,
+			search_vector = setweight(to_tsvector(s.config::regconfig, COALESCE(p.settings->>'title', '')), 'A') ||
+				setweight(to_tsvector(s.config::regconfig, COALESCE(p.settings->>'excerpt', '')), 'B') ||
+				setweight(to_tsvector(s.config::regconfig, s.body), 'C')
+		FROM (SELECT $1::text AS config, $2::text AS body) s
+		WHERE p.id = $3 AND p.deleted_at IS NULL
+	`
+
+	if _, err := workspaceDB.ExecContext(ctx, query, searchConfig, bodyText, id); err != nil {
+		return fmt.Errorf("failed to update blog post search index: %w", err)
+	}
+
+	return nil
+}
+
 // PublishPost sets the published_at timestamp to provided time or now
 func (r *blogPostRepository) PublishPost(ctx context.Context, id string, publishedAt *time.Time) error {
 	workspaceID, ok := ctx.Value(domain.WorkspaceIDKey).(string)
@@ -966,7 +1208,7 @@ func (r *blogPostRepository) PublishPost(ctx context.Context, id string, publish
 func (r *blogPostRepository) PublishPostTx(ctx context.Context, tx *sql.Tx, id string, publishedAt *time.Time) error {
 	query := `
 		UPDATE blog_posts
-		SET published_at = $1, updated_at = $2
+		SET published_at = $1, scheduled_publish_at = NULL, updated_at = $2
 		WHERE id = $3 AND deleted_at IS NULL AND published_at IS NULL
 	`
 
@@ -1053,3 +1295,209 @@ func (r *blogPostRepository) DeletePostsByCategoryIDTx(ctx context.Context, tx *
 
 	return rowsAffected, nil
 }
+
+// blogPostRevisionColumns lists the columns scanned by scanBlogPostRevision
+const blogPostRevisionColumns = `post_id, version, category_id, slug, settings, status, preview_token,
+		restored_from, created_by, created_at, applied_at`
+
+// scanBlogPostRevision scans a blog post revision row
+func scanBlogPostRevision(scanner interface{ Scan(...interface{}) error }) (*domain.BlogPostRevision, error) {
+	var revision domain.BlogPostRevision
+	var previewToken, createdBy sql.NullString
+	var restoredFrom sql.NullInt64
+
+	err := scanner.Scan(
+		&revision.PostID,
+		&revision.Version,
+		&revision.CategoryID,
+		&revision.Slug,
+		&revision.Settings,
+		&revision.Status,
+		&previewToken,
+		&restoredFrom,
+		&createdBy,
+		&revision.CreatedAt,
+		&revision.AppliedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	revision.PreviewToken = previewToken.String
+	revision.CreatedBy = createdBy.String
+	if restoredFrom.Valid {
+		version := int(restoredFrom.Int64)
+		revision.RestoredFrom = &version
+	}
+
+	return &revision, nil
+}
+
+// CreateRevision persists a new revision of a blog post
+func (r *blogPostRepository) CreateRevision(ctx context.Context, revision *domain.BlogPostRevision) error {
+	workspaceID, ok := ctx.Value(domain.WorkspaceIDKey).(string)
+	if !ok {
+		return fmt.Errorf("workspace_id not found in context")
+	}
+
+	return r.WithTransaction(ctx, workspaceID, func(tx *sql.Tx) error {
+		return r.CreateRevisionTx(ctx, tx, revision)
+	})
+}
+
+// CreateRevisionTx persists a new revision of a blog post within a transaction,
+// numbering it after the latest revision of the post
+func (r *blogPostRepository) CreateRevisionTx(ctx context.Context, tx *sql.Tx, revision *domain.BlogPostRevision) error {
+	// Serialize the numbering of concurrent saves of the same post
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM blog_posts WHERE id = $1 FOR UPDATE`, revision.PostID); err != nil {
+		return fmt.Errorf("failed to lock blog post: %w", err)
+	}
+
+	query := `
+		INSERT INTO blog_post_revisions (
+			post_id, version, category_id, slug, settings, status, preview_token,
+			restored_from, created_by, created_at, applied_at
+		)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		FROM blog_post_revisions
+		WHERE post_id = $1
+		RETURNING version
+	`
+
+	err := tx.QueryRowContext(ctx, query,
+		revision.PostID,
+		revision.CategoryID,
+		revision.Slug,
+		revision.Settings,
+		revision.Status,
+		sql.NullString{String: revision.PreviewToken, Valid: revision.PreviewToken != ""},
+		revision.RestoredFrom,
+		sql.NullString{String: revision.CreatedBy, Valid: revision.CreatedBy != ""},
+		revision.CreatedAt,
+		revision.AppliedAt,
+	).Scan(&revision.Version)
+	if err != nil {
+		return fmt.Errorf("failed to create blog post revision: %w", err)
+	}
+
+	return nil
+}
+
+// GetRevision retrieves a revision of a blog post by version
+func (r *blogPostRepository) GetRevision(ctx context.Context, postID string, version int) (*domain.BlogPostRevision, error) {
+	workspaceID, ok := ctx.Value(domain.WorkspaceIDKey).(string)
+	if !ok {
+		return nil, fmt.Errorf("workspace_id not found in context")
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `SELECT ` + blogPostRevisionColumns + `
+		FROM blog_post_revisions
+		WHERE post_id = $1 AND version = $2`
+
+	revision, err := scanBlogPostRevision(workspaceDB.QueryRowContext(ctx, query, postID, version))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("blog post revision not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog post revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+// GetRevisionByPreviewToken retrieves the draft revision matching a preview token
+func (r *blogPostRepository) GetRevisionByPreviewToken(ctx context.Context, token string) (*domain.BlogPostRevision, error) {
+	workspaceID, ok := ctx.Value(domain.WorkspaceIDKey).(string)
+	if !ok {
+		return nil, fmt.Errorf("workspace_id not found in context")
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `SELECT ` + blogPostRevisionColumns + `
+		FROM blog_post_revisions
+		WHERE preview_token = $1 AND status = 'draft'`
+
+	revision, err := scanBlogPostRevision(workspaceDB.QueryRowContext(ctx, query, token))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("blog post revision not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blog post revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+// ListRevisions retrieves the revisions of a blog post, newest first
+func (r *blogPostRepository) ListRevisions(ctx context.Context, postID string) ([]*domain.BlogPostRevision, error) {
+	workspaceID, ok := ctx.Value(domain.WorkspaceIDKey).(string)
+	if !ok {
+		return nil, fmt.Errorf("workspace_id not found in context")
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `SELECT ` + blogPostRevisionColumns + `
+		FROM blog_post_revisions
+		WHERE post_id = $1
+		ORDER BY version DESC`
+
+	rows, err := workspaceDB.QueryContext(ctx, query, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blog post revisions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	revisions := []*domain.BlogPostRevision{}
+	for rows.Next() {
+		revision, err := scanBlogPostRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan blog post revision: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating blog post revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// MarkRevisionAppliedTx marks a draft revision as applied within a transaction
+// and revokes its preview token
+func (r *blogPostRepository) MarkRevisionAppliedTx(ctx context.Context, tx *sql.Tx, postID string, version int) error {
+	query := `
+		UPDATE blog_post_revisions
+		SET status = 'applied', applied_at = $1, preview_token = NULL
+		WHERE post_id = $2 AND version = $3 AND status = 'draft'
+	`
+
+	result, err := tx.ExecContext(ctx, query, time.Now().UTC(), postID, version)
+	if err != nil {
+		return fmt.Errorf("failed to mark blog post revision as applied: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("blog post revision not found or already applied")
+	}
+
+	return nil
+}
@@ -434,14 +434,15 @@ func TestBlogPostRepository(t *testing.T) {
 			sqlMock.ExpectBegin()
 			sqlMock.ExpectExec(regexp.QuoteMeta(`
 		INSERT INTO blog_posts (
-			id, category_id, slug, settings, published_at, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			id, category_id, slug, settings, published_at, scheduled_publish_at, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`)).WithArgs(
 				testPost.ID,
 				testPost.CategoryID,
 				testPost.Slug,
 				sqlmock.AnyArg(),
 				testPost.PublishedAt,
+				testPost.ScheduledPublishAt,
 				sqlmock.AnyArg(),
 				sqlmock.AnyArg(),
 			).WillReturnResult(sqlmock.NewResult(1, 1))
@@ -475,20 +476,21 @@ func TestBlogPostRepository(t *testing.T) {
 				Return(db, nil)
 
 			rows := sqlmock.NewRows([]string{
-				"id", "category_id", "slug", "settings", "published_at", "created_at", "updated_at", "deleted_at",
+				"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at",
 			}).AddRow(
 				testPost.ID,
 				testPost.CategoryID,
 				testPost.Slug,
 				[]byte(`{"title":"My First Post","template":{"template_id":"tpl123","template_version":1,"template_data":{}},"authors":[],"reading_time_minutes":0}`),
 				testPost.PublishedAt,
+				nil,
 				testPost.CreatedAt,
 				testPost.UpdatedAt,
 				nil,
 			)
 
 			sqlMock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT id, category_id, slug, settings, published_at, created_at, updated_at, deleted_at
+		SELECT id, category_id, slug, settings, published_at, scheduled_publish_at, created_at, updated_at, deleted_at
 		FROM blog_posts
 		WHERE id = $1 AND deleted_at IS NULL
 	`)).WithArgs(testPost.ID).WillReturnRows(rows)
@@ -523,13 +525,14 @@ func TestBlogPostRepository(t *testing.T) {
 				Return(db, nil)
 
 			rows := sqlmock.NewRows([]string{
-				"id", "category_id", "slug", "settings", "published_at", "created_at", "updated_at", "deleted_at",
+				"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at",
 			}).AddRow(
 				testPost.ID,
 				testPost.CategoryID,
 				testPost.Slug,
 				[]byte(`{"title":"My First Post","template":{"template_id":"tpl123","template_version":1,"template_data":{}},"authors":[],"reading_time_minutes":0}`),
 				testPost.PublishedAt,
+				nil,
 				testPost.CreatedAt,
 				testPost.UpdatedAt,
 				nil,
@@ -552,13 +555,14 @@ func TestBlogPostRepository(t *testing.T) {
 				Return(db, nil)
 
 			rows := sqlmock.NewRows([]string{
-				"id", "category_id", "slug", "settings", "published_at", "created_at", "updated_at", "deleted_at",
+				"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at",
 			}).AddRow(
 				testPost.ID,
 				testPost.CategoryID,
 				testPost.Slug,
 				[]byte(`{"title":"My First Post","template":{"template_id":"tpl123","template_version":1,"template_data":{}},"authors":[],"reading_time_minutes":0}`),
 				testPost.PublishedAt,
+				nil,
 				testPost.CreatedAt,
 				testPost.UpdatedAt,
 				nil,
@@ -574,6 +578,50 @@ func TestBlogPostRepository(t *testing.T) {
 		})
 	})
 
+	t.Run("GetPostByTranslationSlug", func(t *testing.T) {
+		t.Run("post found", func(t *testing.T) {
+			mockWorkspaceRepo.EXPECT().
+				GetConnection(gomock.Any(), "workspace123").
+				Return(db, nil)
+
+			rows := sqlmock.NewRows([]string{
+				"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at",
+			}).AddRow(
+				testPost.ID,
+				testPost.CategoryID,
+				testPost.Slug,
+				[]byte(`{"title":"My First Post","template":{"template_id":"tpl123","template_version":1,"template_data":{}},"translations":{"fr":{"slug":"mon-premier-article","title":"Mon premier article"}}}`),
+				testPost.PublishedAt,
+				nil,
+				testPost.CreatedAt,
+				testPost.UpdatedAt,
+				nil,
+			)
+
+			sqlMock.ExpectQuery(regexp.QuoteMeta(`settings->'translations'->$2->>'slug' = $3`)).
+				WithArgs(testPost.CategoryID, "fr", "mon-premier-article").
+				WillReturnRows(rows)
+
+			post, err := repo.GetPostByTranslationSlug(ctx, testPost.CategoryID, "fr", "mon-premier-article")
+			require.NoError(t, err)
+			assert.Equal(t, "mon-premier-article", post.Settings.Translations["fr"].Slug)
+		})
+
+		t.Run("post not found", func(t *testing.T) {
+			mockWorkspaceRepo.EXPECT().
+				GetConnection(gomock.Any(), "workspace123").
+				Return(db, nil)
+
+			sqlMock.ExpectQuery(regexp.QuoteMeta(`settings->'translations'->$2->>'slug' = $3`)).
+				WithArgs(testPost.CategoryID, "fr", "missing").
+				WillReturnError(sql.ErrNoRows)
+
+			_, err := repo.GetPostByTranslationSlug(ctx, testPost.CategoryID, "fr", "missing")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "blog post not found")
+		})
+	})
+
 	t.Run("UpdatePost", func(t *testing.T) {
 		t.Run("successful update", func(t *testing.T) {
 			mockWorkspaceRepo.EXPECT().
@@ -583,13 +631,14 @@ func TestBlogPostRepository(t *testing.T) {
 			sqlMock.ExpectBegin()
 			sqlMock.ExpectExec(regexp.QuoteMeta(`
 		UPDATE blog_posts
-		SET category_id = $1, slug = $2, settings = $3, published_at = $4, updated_at = $5
-		WHERE id = $6 AND deleted_at IS NULL
+		SET category_id = $1, slug = $2, settings = $3, published_at = $4, scheduled_publish_at = $5, updated_at = $6
+		WHERE id = $7 AND deleted_at IS NULL
 	`)).WithArgs(
 				testPost.CategoryID,
 				testPost.Slug,
 				sqlmock.AnyArg(),
 				testPost.PublishedAt,
+				testPost.ScheduledPublishAt,
 				sqlmock.AnyArg(),
 				testPost.ID,
 			).WillReturnResult(sqlmock.NewResult(0, 1))
@@ -660,13 +709,14 @@ func TestBlogPostRepository(t *testing.T) {
 
 			// List query
 			rows := sqlmock.NewRows([]string{
-				"id", "category_id", "slug", "settings", "published_at", "created_at", "updated_at", "deleted_at",
+				"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at",
 			}).AddRow(
 				testPost.ID,
 				testPost.CategoryID,
 				testPost.Slug,
 				[]byte(`{"title":"My First Post","template":{"template_id":"tpl123","template_version":1,"template_data":{}},"authors":[],"reading_time_minutes":0}`),
 				testPost.PublishedAt,
+				nil,
 				testPost.CreatedAt,
 				testPost.UpdatedAt,
 				nil,
@@ -676,6 +726,7 @@ func TestBlogPostRepository(t *testing.T) {
 				"second-post",
 				[]byte(`{"title":"Second Post","template":{"template_id":"tpl123","template_version":1,"template_data":{}},"authors":[],"reading_time_minutes":0}`),
 				nil,
+				nil,
 				time.Now().UTC(),
 				time.Now().UTC(),
 				nil,
@@ -717,13 +768,14 @@ func TestBlogPostRepository(t *testing.T) {
 				WillReturnRows(countRows)
 
 			rows := sqlmock.NewRows([]string{
-				"id", "category_id", "slug", "settings", "published_at", "created_at", "updated_at", "deleted_at",
+				"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at",
 			}).AddRow(
 				testPost.ID,
 				testPost.CategoryID,
 				testPost.Slug,
 				[]byte(`{"title":"My First Post","template":{"template_id":"tpl123","template_version":1,"template_data":{}},"authors":[],"reading_time_minutes":0}`),
 				testPost.PublishedAt,
+				nil,
 				testPost.CreatedAt,
 				testPost.UpdatedAt,
 				nil,
@@ -767,13 +819,14 @@ func TestBlogPostRepository(t *testing.T) {
 
 			// List query - page 2 with 10 per page
 			rows := sqlmock.NewRows([]string{
-				"id", "category_id", "slug", "settings", "published_at", "created_at", "updated_at", "deleted_at",
+				"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at",
 			}).AddRow(
 				"post1",
 				"cat123",
 				"post1",
 				[]byte(`{"title":"Post 1","template":{"template_id":"tpl123","template_version":1,"template_data":{}},"authors":[],"reading_time_minutes":0}`),
 				nil,
+				nil,
 				time.Now().UTC(),
 				time.Now().UTC(),
 				nil,
@@ -820,13 +873,14 @@ func TestBlogPostRepository(t *testing.T) {
 
 			now := time.Now().UTC()
 			rows := sqlmock.NewRows([]string{
-				"id", "category_id", "slug", "settings", "published_at", "created_at", "updated_at", "deleted_at",
+				"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at",
 			}).AddRow(
 				testPost.ID,
 				testPost.CategoryID,
 				testPost.Slug,
 				[]byte(`{"title":"My First Post","template":{"template_id":"tpl123","template_version":1,"template_data":{}},"authors":[],"reading_time_minutes":0}`),
 				&now,
+				nil,
 				testPost.CreatedAt,
 				testPost.UpdatedAt,
 				nil,
@@ -856,7 +910,7 @@ func TestBlogPostRepository(t *testing.T) {
 			sqlMock.ExpectBegin()
 			sqlMock.ExpectExec(regexp.QuoteMeta(`
 		UPDATE blog_posts
-		SET published_at = $1, updated_at = $2
+		SET published_at = $1, scheduled_publish_at = NULL, updated_at = $2
 		WHERE id = $3 AND deleted_at IS NULL AND published_at IS NULL
 	`)).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), testPost.ID).
 				WillReturnResult(sqlmock.NewResult(0, 1))
@@ -1131,12 +1185,12 @@ func TestBlogPostRepository_TxMethods(t *testing.T) {
 	t.Run("GetPostTx", func(t *testing.T) {
 		sqlMock.ExpectBegin()
 		sqlMock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT id, category_id, slug, settings, published_at, created_at, updated_at, deleted_at
+		SELECT id, category_id, slug, settings, published_at, scheduled_publish_at, created_at, updated_at, deleted_at
 		FROM blog_posts
 		WHERE id = $1 AND deleted_at IS NULL
 	`)).WithArgs("post123").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "slug", "settings", "published_at", "created_at", "updated_at", "deleted_at"}).
-				AddRow(testPost.ID, testPost.CategoryID, testPost.Slug, []byte(`{"title":"Test Post"}`), nil, testPost.CreatedAt, testPost.UpdatedAt, nil))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at"}).
+				AddRow(testPost.ID, testPost.CategoryID, testPost.Slug, []byte(`{"title":"Test Post"}`), nil, nil, testPost.CreatedAt, testPost.UpdatedAt, nil))
 		sqlMock.ExpectCommit()
 
 		tx, err := db.Begin()
@@ -1157,12 +1211,12 @@ func TestBlogPostRepository_TxMethods(t *testing.T) {
 
 		sqlMock2.ExpectBegin()
 		sqlMock2.ExpectQuery(regexp.QuoteMeta(`
-		SELECT id, category_id, slug, settings, published_at, created_at, updated_at, deleted_at
+		SELECT id, category_id, slug, settings, published_at, scheduled_publish_at, created_at, updated_at, deleted_at
 		FROM blog_posts
 		WHERE slug = $1 AND deleted_at IS NULL
 	`)).WithArgs("test-post").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "slug", "settings", "published_at", "created_at", "updated_at", "deleted_at"}).
-				AddRow(testPost.ID, testPost.CategoryID, testPost.Slug, []byte(`{"title":"Test Post"}`), nil, testPost.CreatedAt, testPost.UpdatedAt, nil))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at"}).
+				AddRow(testPost.ID, testPost.CategoryID, testPost.Slug, []byte(`{"title":"Test Post"}`), nil, nil, testPost.CreatedAt, testPost.UpdatedAt, nil))
 		sqlMock2.ExpectCommit()
 
 		tx, err := db2.Begin()
@@ -1199,8 +1253,8 @@ func TestBlogPostRepository_ListFeedPosts(t *testing.T) {
 	mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws1").Return(db, nil)
 
 	rows := sqlmock.NewRows([]string{
-		"id", "category_id", "slug", "settings", "published_at", "created_at", "updated_at", "deleted_at",
-	}).AddRow("p1", "c1", "hello", []byte(`{"title":"Hi","template":{"template_id":"tpl","template_version":1}}`), time.Now(), time.Now(), time.Now(), nil)
+		"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at",
+	}).AddRow("p1", "c1", "hello", []byte(`{"title":"Hi","template":{"template_id":"tpl","template_version":1}}`), time.Now(), nil, time.Now(), time.Now(), nil)
 
 	// The query MUST filter on `published_at <= NOW()` so scheduled posts
 	// don't leak into feeds. Match a loose regex — SQL whitespace is normalized
@@ -1211,7 +1265,7 @@ func TestBlogPostRepository_ListFeedPosts(t *testing.T) {
 		WithArgs(10).
 		WillReturnRows(rows)
 
-	posts, err := repo.ListFeedPosts(ctx, nil, 10)
+	posts, err := repo.ListFeedPosts(ctx, "", nil, 10)
 	require.NoError(t, err)
 	require.Len(t, posts, 1)
 	assert.Equal(t, "p1", posts[0].ID)
@@ -1235,10 +1289,36 @@ func TestBlogPostRepository_ListFeedPosts_CategoryFilter(t *testing.T) {
 	sqlMock.ExpectQuery(`(?s)JOIN blog_categories c.*c\.slug = \$1.*LIMIT \$2`).
 		WithArgs("tech", 5).
 		WillReturnRows(sqlmock.NewRows([]string{
-			"id", "category_id", "slug", "settings", "published_at", "created_at", "updated_at", "deleted_at",
+			"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at",
+		}))
+
+	posts, err := repo.ListFeedPosts(ctx, "", &slug, 5)
+	require.NoError(t, err)
+	assert.Empty(t, posts)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestBlogPostRepository_ListFeedPosts_LanguageFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewBlogPostRepository(mockWorkspaceRepo)
+
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.WithValue(context.Background(), domain.WorkspaceIDKey, "ws1")
+	mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws1").Return(db, nil)
+
+	slug := "tech"
+	sqlMock.ExpectQuery(`(?s)c\.slug = \$1.*p\.settings->'translations' \? \$2.*LIMIT \$3`).
+		WithArgs("tech", "fr", 5).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at",
 		}))
 
-	posts, err := repo.ListFeedPosts(ctx, &slug, 5)
+	posts, err := repo.ListFeedPosts(ctx, "fr", &slug, 5)
 	require.NoError(t, err)
 	assert.Empty(t, posts)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
@@ -1266,7 +1346,7 @@ func TestBlogPostRepository_GetFeedFingerprint(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"max_updated_at", "ids_hash"}).
 			AddRow(expectedTime, "hash123"))
 
-	maxUpdated, idsHash, err := repo.GetFeedFingerprint(ctx, nil, 10)
+	maxUpdated, idsHash, err := repo.GetFeedFingerprint(ctx, "", nil, 10)
 	require.NoError(t, err)
 	assert.Equal(t, "hash123", idsHash)
 	assert.True(t, maxUpdated.Equal(expectedTime), "expected %s got %s", expectedTime, maxUpdated)
@@ -1294,9 +1374,275 @@ func TestBlogPostRepository_GetFeedFingerprint_EmptyFeed(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"max_updated_at", "ids_hash"}).
 			AddRow(nil, ""))
 
-	maxUpdated, idsHash, err := repo.GetFeedFingerprint(ctx, nil, 10)
+	maxUpdated, idsHash, err := repo.GetFeedFingerprint(ctx, "", nil, 10)
 	require.NoError(t, err)
 	assert.True(t, maxUpdated.IsZero(), "expected zero-time for empty feed, got %s", maxUpdated)
 	assert.Empty(t, idsHash)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
+
+func TestBlogPostRepository_ListPosts_Scheduled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewBlogPostRepository(mockWorkspaceRepo)
+
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.WithValue(context.Background(), domain.WorkspaceIDKey, "ws1")
+	mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws1").Return(db, nil)
+
+	scheduledAt := time.Now().Add(24 * time.Hour).UTC()
+	sqlMock.ExpectQuery(`(?s)SELECT COUNT\(\*\).*published_at IS NULL AND scheduled_publish_at IS NOT NULL`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	sqlMock.ExpectQuery(`(?s)published_at IS NULL AND scheduled_publish_at IS NOT NULL\s+ORDER BY scheduled_publish_at ASC`).
+		WithArgs(50, 0).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at",
+		}).AddRow("p1", "c1", "launch", []byte(`{"title":"Launch"}`), nil, scheduledAt, time.Now(), time.Now(), nil))
+
+	params := domain.ListBlogPostsRequest{Status: domain.BlogPostStatusScheduled}
+	require.NoError(t, params.Validate())
+
+	response, err := repo.ListPosts(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, response.Posts, 1)
+	require.NotNil(t, response.Posts[0].ScheduledPublishAt)
+	assert.True(t, response.Posts[0].IsScheduled())
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestBlogPostRepository_Revisions(t *testing.T) {
+	revisionColumns := []string{
+		"post_id", "version", "category_id", "slug", "settings", "status", "preview_token",
+		"restored_from", "created_by", "created_at", "applied_at",
+	}
+
+	setup := func(t *testing.T) (domain.BlogPostRepository, *sql.DB, sqlmock.Sqlmock, context.Context) {
+		ctrl := gomock.NewController(t)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+
+		db, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws1").Return(db, nil).AnyTimes()
+		ctx := context.WithValue(context.Background(), domain.WorkspaceIDKey, "ws1")
+		return NewBlogPostRepository(mockWorkspaceRepo), db, sqlMock, ctx
+	}
+
+	t.Run("CreateRevision numbers after the latest revision", func(t *testing.T) {
+		repo, _, sqlMock, ctx := setup(t)
+
+		revision := &domain.BlogPostRevision{
+			PostID:       "p1",
+			CategoryID:   "c1",
+			Slug:         "launch",
+			Settings:     domain.BlogPostSettings{Title: "Launch"},
+			Status:       domain.BlogPostRevisionStatusDraft,
+			PreviewToken: "secret",
+			CreatedBy:    "user1",
+			CreatedAt:    time.Now().UTC(),
+		}
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(regexp.QuoteMeta(`SELECT id FROM blog_posts WHERE id = $1 FOR UPDATE`)).
+			WithArgs("p1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectQuery(`(?s)INSERT INTO blog_post_revisions.*SELECT \$1, COALESCE\(MAX\(version\), 0\) \+ 1.*RETURNING version`).
+			WithArgs("p1", "c1", "launch", sqlmock.AnyArg(), domain.BlogPostRevisionStatusDraft,
+				sql.NullString{String: "secret", Valid: true}, revision.RestoredFrom,
+				sql.NullString{String: "user1", Valid: true}, sqlmock.AnyArg(), revision.AppliedAt).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+		sqlMock.ExpectCommit()
+
+		err := repo.CreateRevision(ctx, revision)
+		require.NoError(t, err)
+		assert.Equal(t, 4, revision.Version)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("GetRevisionByPreviewToken only matches drafts", func(t *testing.T) {
+		repo, _, sqlMock, ctx := setup(t)
+
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`WHERE preview_token = $1 AND status = 'draft'`)).
+			WithArgs("secret").
+			WillReturnRows(sqlmock.NewRows(revisionColumns).AddRow(
+				"p1", 4, "c1", "launch", []byte(`{"title":"Launch v2"}`), "draft", "secret", nil, "user1", time.Now(), nil,
+			))
+
+		revision, err := repo.GetRevisionByPreviewToken(ctx, "secret")
+		require.NoError(t, err)
+		assert.Equal(t, 4, revision.Version)
+		assert.Equal(t, "Launch v2", revision.Settings.Title)
+		assert.Equal(t, "secret", revision.PreviewToken)
+		assert.Nil(t, revision.RestoredFrom)
+	})
+
+	t.Run("GetRevisionByPreviewToken not found", func(t *testing.T) {
+		repo, _, sqlMock, ctx := setup(t)
+
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`FROM blog_post_revisions`)).
+			WithArgs("revoked").
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.GetRevisionByPreviewToken(ctx, "revoked")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "blog post revision not found")
+	})
+
+	t.Run("ListRevisions newest first", func(t *testing.T) {
+		repo, _, sqlMock, ctx := setup(t)
+		appliedAt := time.Now()
+
+		sqlMock.ExpectQuery(`(?s)FROM blog_post_revisions\s+WHERE post_id = \$1\s+ORDER BY version DESC`).
+			WithArgs("p1").
+			WillReturnRows(sqlmock.NewRows(revisionColumns).
+				AddRow("p1", 3, "c1", "launch", []byte(`{"title":"Launch"}`), "applied", nil, 1, "user1", appliedAt, appliedAt).
+				AddRow("p1", 2, "c1", "launch", []byte(`{"title":"Launch v2"}`), "applied", nil, nil, nil, appliedAt, appliedAt))
+
+		revisions, err := repo.ListRevisions(ctx, "p1")
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		require.NotNil(t, revisions[0].RestoredFrom)
+		assert.Equal(t, 1, *revisions[0].RestoredFrom)
+		assert.Equal(t, "", revisions[1].CreatedBy)
+	})
+
+	t.Run("MarkRevisionAppliedTx revokes the preview token", func(t *testing.T) {
+		repo, db, sqlMock, ctx := setup(t)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(regexp.QuoteMeta(`SET status = 'applied', applied_at = $1, preview_token = NULL`)).
+			WithArgs(sqlmock.AnyArg(), "p1", 4).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectRollback()
+
+		tx, err := db.Begin()
+		require.NoError(t, err)
+		defer func() { _ = tx.Rollback() }()
+
+		err = repo.MarkRevisionAppliedTx(ctx, tx, "p1", 4)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already applied")
+	})
+}
+
+func TestBlogPostRepository_Search(t *testing.T) {
+	setup := func(t *testing.T) (domain.BlogPostRepository, sqlmock.Sqlmock, context.Context) {
+		ctrl := gomock.NewController(t)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+
+		db, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws1").Return(db, nil).AnyTimes()
+		ctx := context.WithValue(context.Background(), domain.WorkspaceIDKey, "ws1")
+		return NewBlogPostRepository(mockWorkspaceRepo), sqlMock, ctx
+	}
+
+	t.Run("SearchPosts ranks and highlights the matches", func(t *testing.T) {
+		repo, sqlMock, ctx := setup(t)
+
+		publishedAt := time.Now().UTC()
+		sqlMock.ExpectQuery(`(?s)SELECT COUNT\(\*\).*websearch_to_tsquery\(p.search_config::regconfig, \$1\).*v.vector @@ q.query`).
+			WithArgs("launch").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+		sqlMock.ExpectQuery(`(?s)ts_rank_cd\(v.vector, q.query\).*ORDER BY rank DESC, p.published_at DESC\s+LIMIT \$4 OFFSET \$5`).
+			WithArgs("launch", searchTitleHeadlineOptions, searchSnippetHeadlineOptions, 10, 10).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at",
+				"category_slug", "rank", "title_highlight", "snippet",
+			}).AddRow(
+				"p1", "c1", "launch", []byte(`{"title":"Launch <day>"}`), publishedAt, nil, publishedAt, publishedAt, nil,
+				"news", 0.5, "\x02Launch\x03 <day>", "We \x02launched\x03 & shipped",
+			))
+
+		params := domain.BlogSearchRequest{Query: "launch", Page: 2}
+		require.NoError(t, params.Validate())
+
+		response, err := repo.SearchPosts(ctx, params)
+		require.NoError(t, err)
+		require.Len(t, response.Results, 1)
+		assert.Equal(t, "p1", response.Results[0].Post.ID)
+		assert.Equal(t, "news", response.Results[0].CategorySlug)
+		assert.Equal(t, 0.5, response.Results[0].Rank)
+		assert.Equal(t, "<mark>Launch</mark> &lt;day&gt;", response.Results[0].TitleHighlight)
+		assert.Equal(t, "We <mark>launched</mark> &amp; shipped", response.Results[0].Snippet)
+		assert.Equal(t, 11, response.TotalCount)
+		assert.Equal(t, 2, response.TotalPages)
+		assert.Equal(t, 2, response.CurrentPage)
+		assert.False(t, response.HasNextPage)
+		assert.True(t, response.HasPreviousPage)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("SearchPosts does not match the body of gated posts", func(t *testing.T) {
+		repo, sqlMock, ctx := setup(t)
+
+		// gated posts are matched on their title and excerpt, and their snippet is their excerpt
+		gatedVector := `CASE WHEN q.gated\s+THEN setweight\(to_tsvector\(q.config, COALESCE\(p.settings->>'title', ''\)\), 'A'\) \|\|\s+` +
+			`setweight\(to_tsvector\(q.config, COALESCE\(p.settings->>'excerpt', ''\)\), 'B'\)\s+ELSE p.search_vector`
+		gatedCondition := `\(p.settings->'gate' IS NOT NULL OR c.settings->'gate' IS NOT NULL\) AS gated`
+		sqlMock.ExpectQuery(`(?s)SELECT COUNT\(\*\).*` + gatedCondition + `.*` + gatedVector).
+			WithArgs("secret").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		sqlMock.ExpectQuery(`(?s)CASE WHEN q.gated THEN '' ELSE p.search_text END.*`+gatedCondition+`.*`+gatedVector).
+			WithArgs("secret", searchTitleHeadlineOptions, searchSnippetHeadlineOptions, 10, 0).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at",
+				"category_slug", "rank", "title_highlight", "snippet",
+			}))
+
+		response, err := repo.SearchPosts(ctx, domain.BlogSearchRequest{Query: "secret", Page: 1, Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, response.Results)
+		assert.Equal(t, 0, response.TotalCount)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("SearchPosts count error", func(t *testing.T) {
+		repo, sqlMock, ctx := setup(t)
+
+		sqlMock.ExpectQuery(`SELECT COUNT`).WillReturnError(errors.New("text search configuration does not exist"))
+
+		_, err := repo.SearchPosts(ctx, domain.BlogSearchRequest{Query: "launch", Page: 1, Limit: 10})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to count blog search results")
+	})
+
+	t.Run("UpdatePostSearchIndex", func(t *testing.T) {
+		repo, sqlMock, ctx := setup(t)
+
+		sqlMock.ExpectExec(`(?s)UPDATE blog_posts p\s+SET search_config = s.config.*WHERE p.id = \$3`).
+			WithArgs("french", "Bonjour", "p1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.UpdatePostSearchIndex(ctx, "p1", "french", "Bonjour"))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("ListPosts filters and ranks by query", func(t *testing.T) {
+		repo, sqlMock, ctx := setup(t)
+
+		sqlMock.ExpectQuery(`(?s)SELECT COUNT\(\*\).*search_vector @@ websearch_to_tsquery\(search_config::regconfig, \$1\)`).
+			WithArgs("launch").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		sqlMock.ExpectQuery(`(?s)ORDER BY ts_rank_cd\(search_vector, websearch_to_tsquery\(search_config::regconfig, \$1\)\) DESC, created_at DESC`).
+			WithArgs("launch", 50, 0).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "category_id", "slug", "settings", "published_at", "scheduled_publish_at", "created_at", "updated_at", "deleted_at",
+			}))
+
+		params := domain.ListBlogPostsRequest{Query: " launch "}
+		require.NoError(t, params.Validate())
+
+		response, err := repo.ListPosts(ctx, params)
+		require.NoError(t, err)
+		assert.Empty(t, response.Posts)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
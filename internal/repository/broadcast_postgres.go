@@ -32,7 +32,8 @@ const broadcastListColumns = `id,
 			cancelled_at,
 			paused_at,
 			pause_reason,
-			data_feed`
+			data_feed,
+			blog_post`
 
 // escapeLikePattern escapes the characters that carry special meaning in a SQL
 // LIKE/ILIKE pattern so a user-provided search term is matched literally.
@@ -119,9 +120,10 @@ func (r *broadcastRepository) CreateBroadcastTx(ctx context.Context, tx *sql.Tx,
 			cancelled_at,
 			paused_at,
 			pause_reason,
-			data_feed
+			data_feed,
+			blog_post
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22
 		)
 	`
 
@@ -147,6 +149,7 @@ func (r *broadcastRepository) CreateBroadcastTx(ctx context.Context, tx *sql.Tx,
 		broadcast.PausedAt,
 		broadcast.PauseReason,
 		broadcast.DataFeed,
+		broadcast.BlogPost,
 	)
 
 	if err != nil {
@@ -186,7 +189,8 @@ func (r *broadcastRepository) GetBroadcast(ctx context.Context, workspaceID, id
 			cancelled_at,
 			paused_at,
 			pause_reason,
-			data_feed
+			data_feed,
+			blog_post
 		FROM broadcasts
 		WHERE id = $1 AND workspace_id = $2
 	`
@@ -228,7 +232,8 @@ func (r *broadcastRepository) GetBroadcastTx(ctx context.Context, tx *sql.Tx, wo
 			cancelled_at,
 			paused_at,
 			pause_reason,
-			data_feed
+			data_feed,
+			blog_post
 		FROM broadcasts
 		WHERE id = $1 AND workspace_id = $2
 	`
@@ -277,7 +282,8 @@ func (r *broadcastRepository) UpdateBroadcastTx(ctx context.Context, tx *sql.Tx,
 			paused_at = $17,
 			pause_reason = $18,
 			enqueued_count = $19,
-			data_feed = $20
+			data_feed = $20,
+			blog_post = $21
 		WHERE id = $1 AND workspace_id = $2
 			AND status != 'cancelled'
 			AND status != 'processed'
@@ -304,6 +310,7 @@ func (r *broadcastRepository) UpdateBroadcastTx(ctx context.Context, tx *sql.Tx,
 		broadcast.PauseReason,
 		broadcast.EnqueuedCount,
 		broadcast.DataFeed,
+		broadcast.BlogPost,
 	)
 
 	if err != nil {
@@ -509,6 +516,7 @@ func scanBroadcast(scanner interface {
 	var winningTemplate sql.NullString
 	var pauseReason sql.NullString
 	var dataFeed domain.DataFeedSettings
+	var blogPost domain.BroadcastBlogPost
 
 	err := scanner.Scan(
 		&broadcast.ID,
@@ -532,6 +540,7 @@ func scanBroadcast(scanner interface {
 		&broadcast.PausedAt,
 		&pauseReason,
 		&dataFeed,
+		&blogPost,
 	)
 
 	if err != nil {
@@ -551,5 +560,9 @@ func scanBroadcast(scanner interface {
 		broadcast.DataFeed = &dataFeed
 	}
 
+	if blogPost.PostID != "" {
+		broadcast.BlogPost = &blogPost
+	}
+
 	return broadcast, nil
 }
@@ -433,6 +433,7 @@ func TestBroadcastRepository_CreateBroadcast_Success(t *testing.T) {
 			sqlmock.AnyArg(), // paused_at
 			sqlmock.AnyArg(), // pause_reason
 			sqlmock.AnyArg(), // data_feed (consolidated)
+			sqlmock.AnyArg(), // blog_post
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -518,7 +519,7 @@ func TestBroadcastRepository_GetBroadcast_Success(t *testing.T) {
 		"test_sent_at", "winner_sent_at", "enqueued_count",
 		"created_at", "updated_at",
 		"started_at", "completed_at", "cancelled_at", "paused_at", "pause_reason",
-		"data_feed",
+		"data_feed", "blog_post",
 	}).
 		AddRow(
 			broadcastID, workspaceID, "Test Broadcast", domain.BroadcastStatusDraft,
@@ -528,6 +529,7 @@ func TestBroadcastRepository_GetBroadcast_Success(t *testing.T) {
 			time.Now(), time.Now(),
 			nil, nil, nil, nil, nil,
 			nil, // data_feed
+			nil, // blog_post
 		)
 
 	mock.ExpectQuery("SELECT").
@@ -575,7 +577,7 @@ func TestBroadcastRepository_GetBroadcast_NullPauseReason(t *testing.T) {
 		"test_sent_at", "winner_sent_at", "enqueued_count",
 		"created_at", "updated_at",
 		"started_at", "completed_at", "cancelled_at", "paused_at", "pause_reason",
-		"data_feed",
+		"data_feed", "blog_post",
 	}).
 		AddRow(
 			broadcastID, workspaceID, "Test Broadcast", domain.BroadcastStatusDraft,
@@ -585,6 +587,7 @@ func TestBroadcastRepository_GetBroadcast_NullPauseReason(t *testing.T) {
 			time.Now(), time.Now(),
 			nil, nil, nil, nil, nil, // NULL pause_reason
 			nil, // data_feed
+			nil, // blog_post
 		)
 
 	mock.ExpectQuery("SELECT").
@@ -631,7 +634,7 @@ func TestBroadcastRepository_GetBroadcast_WithPauseReason(t *testing.T) {
 		"test_sent_at", "winner_sent_at", "enqueued_count",
 		"created_at", "updated_at",
 		"started_at", "completed_at", "cancelled_at", "paused_at", "pause_reason",
-		"data_feed",
+		"data_feed", "blog_post",
 	}).
 		AddRow(
 			broadcastID, workspaceID, "Test Broadcast", domain.BroadcastStatusPaused,
@@ -641,6 +644,7 @@ func TestBroadcastRepository_GetBroadcast_WithPauseReason(t *testing.T) {
 			time.Now(), time.Now(),
 			nil, nil, nil, time.Now(), expectedReason, // Non-NULL pause_reason
 			nil, // data_feed
+			nil, // blog_post
 		)
 
 	mock.ExpectQuery("SELECT").
@@ -792,6 +796,7 @@ func TestBroadcastRepository_UpdateBroadcast_Success(t *testing.T) {
 			sqlmock.AnyArg(), // pause_reason
 			sqlmock.AnyArg(), // enqueued_count
 			sqlmock.AnyArg(), // data_feed (consolidated)
+			sqlmock.AnyArg(), // blog_post
 		).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -1067,12 +1072,13 @@ func TestBroadcastRepository_ListBroadcasts_RowsIterationError(t *testing.T) {
 		"test_sent_at", "winner_sent_at", "enqueued_count",
 		"created_at", "updated_at",
 		"started_at", "completed_at", "cancelled_at", "paused_at", "pause_reason",
-		"data_feed",
+		"data_feed", "blog_post",
 	}).
 		AddRow(
 			"bc123", workspaceID, "Broadcast 1", "draft", []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
 			"", nil, nil, 0, time.Now(), time.Now(), nil, nil, nil, nil, nil,
 			nil, // data_feed
+			nil, // blog_post
 		).
 		RowError(0, iterationErr) // Set error on the first row
 
@@ -1185,17 +1191,19 @@ func TestBroadcastRepository_ListBroadcasts_WithStatus(t *testing.T) {
 		"test_sent_at", "winner_sent_at", "enqueued_count",
 		"created_at", "updated_at",
 		"started_at", "completed_at", "cancelled_at", "paused_at", "pause_reason",
-		"data_feed",
+		"data_feed", "blog_post",
 	}).
 		AddRow(
 			"bc123", workspaceID, "Broadcast 1", status, []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
 			"", nil, nil, 0, time.Now(), time.Now(), nil, nil, nil, nil, nil,
 			nil, // data_feed
+			nil, // blog_post
 		).
 		AddRow(
 			"bc456", workspaceID, "Broadcast 2", status, []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
 			"", nil, nil, 0, time.Now(), time.Now(), nil, nil, nil, nil, nil,
 			nil, // data_feed
+			nil, // blog_post
 		)
 
 	// Expect query with limit/offset
@@ -1232,7 +1240,7 @@ var broadcastListColumnNames = []string{
 	"test_sent_at", "winner_sent_at", "enqueued_count",
 	"created_at", "updated_at",
 	"started_at", "completed_at", "cancelled_at", "paused_at", "pause_reason",
-	"data_feed",
+	"data_feed", "blog_post",
 }
 
 // TestBroadcastRepository_ListBroadcasts_WithStatusesAndSearch tests listing
@@ -1273,6 +1281,7 @@ func TestBroadcastRepository_ListBroadcasts_WithStatusesAndSearch(t *testing.T)
 			[]byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
 			"", nil, nil, 0, time.Now(), time.Now(), nil, nil, nil, nil, nil,
 			nil, // data_feed
+			nil, // blog_post
 		)
 
 	// Data query pins the same WHERE clause plus pagination placeholders.
@@ -1331,7 +1340,7 @@ func TestBroadcastRepository_ListBroadcasts_SearchOnly(t *testing.T) {
 			"bc1", workspaceID, "50% off sale", domain.BroadcastStatusDraft,
 			[]byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
 			"", nil, nil, 0, time.Now(), time.Now(), nil, nil, nil, nil, nil,
-			nil,
+			nil, nil,
 		)
 
 	mock.ExpectQuery(`FROM broadcasts WHERE workspace_id = \$1 AND name ILIKE \$2 ORDER BY created_at DESC LIMIT \$3 OFFSET \$4`).
@@ -1392,13 +1401,14 @@ func TestBroadcastRepository_GetBroadcastTx(t *testing.T) {
 				"test_sent_at", "winner_sent_at", "enqueued_count",
 				"created_at", "updated_at",
 				"started_at", "completed_at", "cancelled_at", "paused_at", "pause_reason",
-				"data_feed",
+				"data_feed", "blog_post",
 			}).
 				AddRow(
 					broadcastID, workspaceID, "Test Broadcast", "draft",
 					[]byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
 					"", nil, nil, 0, time.Now(), time.Now(), nil, nil, nil, nil, nil,
 					nil, // data_feed
+					nil, // blog_post
 				))
 		sqlMock.ExpectCommit()
 
@@ -1463,13 +1473,14 @@ func TestBroadcastRepository_GetBroadcast_WithDataFeed(t *testing.T) {
 		"test_sent_at", "winner_sent_at", "enqueued_count",
 		"created_at", "updated_at",
 		"started_at", "completed_at", "cancelled_at", "paused_at", "pause_reason",
-		"data_feed",
+		"data_feed", "blog_post",
 	}).
 		AddRow(
 			broadcastID, workspaceID, "Test Broadcast", domain.BroadcastStatusDraft,
 			[]byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
 			"", nil, nil, 0, time.Now(), time.Now(), nil, nil, nil, nil, nil,
 			dataFeedJSON,
+			nil,
 		)
 
 	mock.ExpectQuery("SELECT").
@@ -1565,6 +1576,7 @@ func TestBroadcastRepository_CreateBroadcast_WithDataFeed(t *testing.T) {
 			sqlmock.AnyArg(), // paused_at
 			sqlmock.AnyArg(), // pause_reason
 			sqlmock.AnyArg(), // data_feed (consolidated)
+			sqlmock.AnyArg(), // blog_post
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
@@ -1641,6 +1653,7 @@ func TestBroadcastRepository_UpdateBroadcast_WithDataFeed(t *testing.T) {
 			sqlmock.AnyArg(), // pause_reason
 			sqlmock.AnyArg(), // enqueued_count
 			sqlmock.AnyArg(), // data_feed (consolidated)
+			sqlmock.AnyArg(), // blog_post
 		).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -1651,3 +1664,47 @@ func TestBroadcastRepository_UpdateBroadcast_WithDataFeed(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestBroadcastRepository_GetBroadcast_WithBlogPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewBroadcastRepository(mockWorkspaceRepo)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "ws123"
+	broadcastID := "bc123"
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), workspaceID).
+		Return(db, nil)
+
+	blogPostJSON := []byte(`{"post_id":"post-1","title":"Launch","url":"https://blog.example.com/news/launch","table_of_contents":[{"id":"intro","level":2,"text":"Intro"}]}`)
+
+	rows := sqlmock.NewRows(broadcastListColumnNames).
+		AddRow(
+			broadcastID, workspaceID, "Launch", domain.BroadcastStatusScheduled,
+			[]byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
+			"", nil, nil, 0, time.Now(), time.Now(), nil, nil, nil, nil, nil,
+			nil, blogPostJSON,
+		)
+
+	mock.ExpectQuery("SELECT").
+		WithArgs(broadcastID, workspaceID).
+		WillReturnRows(rows)
+
+	broadcast, err := repo.GetBroadcast(ctx, workspaceID, broadcastID)
+	require.NoError(t, err)
+	assert.Nil(t, broadcast.DataFeed)
+	require.NotNil(t, broadcast.BlogPost)
+	assert.Equal(t, "post-1", broadcast.BlogPost.PostID)
+	assert.Equal(t, "https://blog.example.com/news/launch", broadcast.BlogPost.URL)
+	require.Len(t, broadcast.BlogPost.TableOfContents, 1)
+	assert.Equal(t, "intro", broadcast.BlogPost.TableOfContents[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,340 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+type contactBulkOperationRepository struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+// NewContactBulkOperationRepository creates a new PostgreSQL contact bulk operation repository
+func NewContactBulkOperationRepository(workspaceRepo domain.WorkspaceRepository) domain.ContactBulkOperationRepository {
+	return &contactBulkOperationRepository{
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+const contactBulkOperationColumns = `id, target, action, status, task_id, total_count, processed_count,
+		succeeded_count, skipped_count, failed_count, error_message, created_by,
+		created_at, updated_at, completed_at`
+
+func (r *contactBulkOperationRepository) Create(ctx context.Context, workspaceID string, operation *domain.ContactBulkOperation) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	now := time.Now().UTC()
+	operation.CreatedAt = now
+	operation.UpdatedAt = now
+
+	query := `
+		INSERT INTO contact_bulk_operations (id, target, action, status, task_id, total_count, created_by,
+		                                     created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err = workspaceDB.ExecContext(ctx, query,
+		operation.ID,
+		operation.Target,
+		operation.Action,
+		operation.Status,
+		operation.TaskID,
+		operation.TotalCount,
+		sql.NullString{String: operation.CreatedBy, Valid: operation.CreatedBy != ""},
+		operation.CreatedAt,
+		operation.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create contact bulk operation: %w", err)
+	}
+	return nil
+}
+
+func (r *contactBulkOperationRepository) GetByID(ctx context.Context, workspaceID string, id string) (*domain.ContactBulkOperation, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `SELECT ` + contactBulkOperationColumns + ` FROM contact_bulk_operations WHERE id = $1`
+
+	operation, err := scanContactBulkOperation(workspaceDB.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, &domain.ErrContactBulkOperationNotFound{Message: "contact bulk operation not found"}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact bulk operation: %w", err)
+	}
+	return operation, nil
+}
+
+func (r *contactBulkOperationRepository) List(ctx context.Context, workspaceID string) ([]*domain.ContactBulkOperation, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `SELECT ` + contactBulkOperationColumns + ` FROM contact_bulk_operations ORDER BY created_at DESC LIMIT 100`
+
+	rows, err := workspaceDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact bulk operations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	operations := []*domain.ContactBulkOperation{}
+	for rows.Next() {
+		operation, err := scanContactBulkOperation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan contact bulk operation: %w", err)
+		}
+		operations = append(operations, operation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating contact bulk operation rows: %w", err)
+	}
+
+	return operations, nil
+}
+
+// Update saves the progress of an operation. A cancelled operation keeps its status,
+// the task running it only learns about the cancellation on its next read.
+func (r *contactBulkOperationRepository) Update(ctx context.Context, workspaceID string, operation *domain.ContactBulkOperation) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	operation.UpdatedAt = time.Now().UTC()
+
+	query := `
+		UPDATE contact_bulk_operations
+		SET status = CASE WHEN status = 'cancelled' THEN status ELSE $1 END,
+		    task_id = $2, total_count = $3, processed_count = $4, succeeded_count = $5,
+		    skipped_count = $6, failed_count = $7, error_message = $8, updated_at = $9,
+		    completed_at = COALESCE(completed_at, $10)
+		WHERE id = $11
+		RETURNING status
+	`
+	err = workspaceDB.QueryRowContext(ctx, query,
+		operation.Status,
+		operation.TaskID,
+		operation.TotalCount,
+		operation.ProcessedCount,
+		operation.SucceededCount,
+		operation.SkippedCount,
+		operation.FailedCount,
+		operation.ErrorMessage,
+		operation.UpdatedAt,
+		operation.CompletedAt,
+		operation.ID,
+	).Scan(&operation.Status)
+	if err == sql.ErrNoRows {
+		return &domain.ErrContactBulkOperationNotFound{Message: "contact bulk operation not found"}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update contact bulk operation: %w", err)
+	}
+	return nil
+}
+
+func (r *contactBulkOperationRepository) CountTargets(ctx context.Context, workspaceID string, targetSQL string, args []interface{}) (int, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `SELECT COUNT(*) FROM contacts WHERE email IN (` + targetSQL + `)`
+
+	var count int
+	if err := workspaceDB.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count target contacts: %w", err)
+	}
+	return count, nil
+}
+
+func (r *contactBulkOperationRepository) GetTargetEmails(ctx context.Context, workspaceID string, targetSQL string, args []interface{}, afterEmail string, limit int) ([]string, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT email FROM contacts
+		WHERE email IN (%s) AND email > $%d
+		ORDER BY email
+		LIMIT $%d
+	`, targetSQL, len(args)+1, len(args)+2)
+
+	queryArgs := append(append([]interface{}{}, args...), afterEmail, limit)
+	rows, err := workspaceDB.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target contacts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	emails := []string{}
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("failed to scan target contact: %w", err)
+		}
+		emails = append(emails, email)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating target contacts: %w", err)
+	}
+
+	return emails, nil
+}
+
+func (r *contactBulkOperationRepository) SaveBatch(ctx context.Context, workspaceID string, operation *domain.ContactBulkOperation, results []*domain.ContactBulkOperationResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	now := time.Now().UTC()
+	operation.UpdatedAt = now
+	values := make([]string, 0, len(results))
+	args := make([]interface{}, 0, 3+len(results)*5)
+	args = append(args, operation.ID, operation.Status, operation.UpdatedAt)
+	for i, result := range results {
+		result.CreatedAt = now
+		base := 3 + i*5
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", base+1, base+2, base+3, base+4, base+5))
+		args = append(args,
+			operation.ID,
+			result.Email,
+			result.Status,
+			sql.NullString{String: result.Error, Valid: result.Error != ""},
+			result.CreatedAt,
+		)
+	}
+
+	// A single statement, so that the results and the counters are saved together. Only
+	// the results inserted are counted: a retried batch keeps the results recorded first.
+	query := `
+		WITH inserted AS (
+			INSERT INTO contact_bulk_operation_results (operation_id, email, status, error, created_at)
+			VALUES ` + strings.Join(values, ", ") + `
+			ON CONFLICT (operation_id, email) DO NOTHING
+			RETURNING status
+		)
+		UPDATE contact_bulk_operations
+		SET status = CASE WHEN status = 'cancelled' THEN status ELSE $2 END,
+		    processed_count = processed_count + (SELECT COUNT(*) FROM inserted),
+		    succeeded_count = succeeded_count + (SELECT COUNT(*) FROM inserted WHERE inserted.status = 'succeeded'),
+		    skipped_count = skipped_count + (SELECT COUNT(*) FROM inserted WHERE inserted.status = 'skipped'),
+		    failed_count = failed_count + (SELECT COUNT(*) FROM inserted WHERE inserted.status = 'failed'),
+		    updated_at = $3
+		WHERE id = $1
+		RETURNING status, processed_count, succeeded_count, skipped_count, failed_count
+	`
+	err = workspaceDB.QueryRowContext(ctx, query, args...).Scan(
+		&operation.Status,
+		&operation.ProcessedCount,
+		&operation.SucceededCount,
+		&operation.SkippedCount,
+		&operation.FailedCount,
+	)
+	if err == sql.ErrNoRows {
+		return &domain.ErrContactBulkOperationNotFound{Message: "contact bulk operation not found"}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save contact bulk operation results: %w", err)
+	}
+	return nil
+}
+
+func (r *contactBulkOperationRepository) ListResults(ctx context.Context, workspaceID string, operationID string, afterEmail string, limit int) ([]*domain.ContactBulkOperationResult, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		SELECT email, status, error, created_at
+		FROM contact_bulk_operation_results
+		WHERE operation_id = $1 AND email > $2
+		ORDER BY email
+		LIMIT $3
+	`
+	rows, err := workspaceDB.QueryContext(ctx, query, operationID, afterEmail, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact bulk operation results: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	results := []*domain.ContactBulkOperationResult{}
+	for rows.Next() {
+		result := &domain.ContactBulkOperationResult{}
+		var resultErr sql.NullString
+		if err := rows.Scan(&result.Email, &result.Status, &resultErr, &result.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan contact bulk operation result: %w", err)
+		}
+		result.Error = resultErr.String
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating contact bulk operation results: %w", err)
+	}
+
+	return results, nil
+}
+
+// DeleteResultsForEmail deletes the results of every operation for an address when the
+// contact is erased
+func (r *contactBulkOperationRepository) DeleteResultsForEmail(ctx context.Context, workspaceID string, email string) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	if _, err := workspaceDB.ExecContext(ctx, `DELETE FROM contact_bulk_operation_results WHERE email = $1`, email); err != nil {
+		return fmt.Errorf("failed to delete contact bulk operation results: %w", err)
+	}
+	return nil
+}
+
+func scanContactBulkOperation(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*domain.ContactBulkOperation, error) {
+	operation := &domain.ContactBulkOperation{}
+	var createdBy sql.NullString
+	if err := scanner.Scan(
+		&operation.ID,
+		&operation.Target,
+		&operation.Action,
+		&operation.Status,
+		&operation.TaskID,
+		&operation.TotalCount,
+		&operation.ProcessedCount,
+		&operation.SucceededCount,
+		&operation.SkippedCount,
+		&operation.FailedCount,
+		&operation.ErrorMessage,
+		&createdBy,
+		&operation.CreatedAt,
+		&operation.UpdatedAt,
+		&operation.CompletedAt,
+	); err != nil {
+		return nil, err
+	}
+	operation.CreatedBy = createdBy.String
+	return operation, nil
+}
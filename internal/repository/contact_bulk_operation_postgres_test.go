@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+)
+
+func setupContactBulkOperationRepositoryTest(t *testing.T) (domain.ContactBulkOperationRepository, sqlmock.Sqlmock) {
+	ctrl := gomock.NewController(t)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), "workspace123").
+		Return(db, nil).
+		AnyTimes()
+
+	return NewContactBulkOperationRepository(mockWorkspaceRepo), sqlMock
+}
+
+func testContactBulkOperationRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{
+		"id", "target", "action", "status", "task_id", "total_count", "processed_count",
+		"succeeded_count", "skipped_count", "failed_count", "error_message", "created_by",
+		"created_at", "updated_at", "completed_at",
+	})
+}
+
+func TestContactBulkOperationRepository_Create(t *testing.T) {
+	repo, sqlMock := setupContactBulkOperationRepositoryTest(t)
+
+	taskID := "task1"
+	operation := &domain.ContactBulkOperation{
+		ID:         "op1",
+		Target:     domain.ContactBulkTarget{Type: domain.ContactBulkTargetSegment, SegmentID: "seg1"},
+		Action:     domain.ContactBulkAction{Type: domain.ContactBulkActionDelete},
+		Status:     domain.ContactBulkOperationStatusPending,
+		TaskID:     &taskID,
+		TotalCount: 12,
+		CreatedBy:  "user1",
+	}
+
+	sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO contact_bulk_operations")).
+		WithArgs("op1", sqlmock.AnyArg(), sqlmock.AnyArg(), domain.ContactBulkOperationStatusPending, &taskID, 12,
+			sql.NullString{String: "user1", Valid: true}, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.Create(context.Background(), "workspace123", operation)
+	require.NoError(t, err)
+	assert.False(t, operation.CreatedAt.IsZero())
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestContactBulkOperationRepository_GetByID(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		repo, sqlMock := setupContactBulkOperationRepositoryTest(t)
+		now := time.Now()
+
+		sqlMock.ExpectQuery(regexp.QuoteMeta("FROM contact_bulk_operations WHERE id = $1")).
+			WithArgs("op1").
+			WillReturnRows(testContactBulkOperationRows().AddRow(
+				"op1", []byte(`{"type":"list","list_id":"newsletter","list_status":"active"}`),
+				[]byte(`{"type":"exit_automation","automation_id":"auto1"}`), "running", "task1",
+				10, 4, 3, 1, 0, nil, "user1", now, now, nil,
+			))
+
+		operation, err := repo.GetByID(context.Background(), "workspace123", "op1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContactBulkTargetList, operation.Target.Type)
+		assert.Equal(t, domain.ContactListStatusActive, operation.Target.ListStatus)
+		assert.Equal(t, "auto1", operation.Action.AutomationID)
+		assert.Equal(t, domain.ContactBulkOperationStatusRunning, operation.Status)
+		assert.Equal(t, 4, operation.ProcessedCount)
+		assert.Equal(t, "user1", operation.CreatedBy)
+		assert.Nil(t, operation.CompletedAt)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo, sqlMock := setupContactBulkOperationRepositoryTest(t)
+
+		sqlMock.ExpectQuery(regexp.QuoteMeta("FROM contact_bulk_operations WHERE id = $1")).
+			WithArgs("missing").
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.GetByID(context.Background(), "workspace123", "missing")
+		var notFound *domain.ErrContactBulkOperationNotFound
+		assert.True(t, errors.As(err, &notFound))
+	})
+}
+
+func TestContactBulkOperationRepository_Update(t *testing.T) {
+	t.Run("keeps a cancellation", func(t *testing.T) {
+		repo, sqlMock := setupContactBulkOperationRepositoryTest(t)
+		operation := &domain.ContactBulkOperation{ID: "op1", Status: domain.ContactBulkOperationStatusRunning, ProcessedCount: 500}
+
+		sqlMock.ExpectQuery(regexp.QuoteMeta("UPDATE contact_bulk_operations")).
+			WithArgs(domain.ContactBulkOperationStatusRunning, sqlmock.AnyArg(), 0, 500, 0, 0, 0,
+				sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "op1").
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("cancelled"))
+
+		err := repo.Update(context.Background(), "workspace123", operation)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContactBulkOperationStatusCancelled, operation.Status)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo, sqlMock := setupContactBulkOperationRepositoryTest(t)
+
+		sqlMock.ExpectQuery(regexp.QuoteMeta("UPDATE contact_bulk_operations")).
+			WillReturnError(sql.ErrNoRows)
+
+		err := repo.Update(context.Background(), "workspace123", &domain.ContactBulkOperation{ID: "missing"})
+		var notFound *domain.ErrContactBulkOperationNotFound
+		assert.True(t, errors.As(err, &notFound))
+	})
+}
+
+func TestContactBulkOperationRepository_CountTargets(t *testing.T) {
+	repo, sqlMock := setupContactBulkOperationRepositoryTest(t)
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM contacts WHERE email IN (SELECT email FROM contact_segments WHERE segment_id = $1)")).
+		WithArgs("seg1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+	count, err := repo.CountTargets(context.Background(), "workspace123", "SELECT email FROM contact_segments WHERE segment_id = $1", []interface{}{"seg1"})
+	require.NoError(t, err)
+	assert.Equal(t, 42, count)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestContactBulkOperationRepository_GetTargetEmails(t *testing.T) {
+	repo, sqlMock := setupContactBulkOperationRepositoryTest(t)
+
+	// The keyset arguments are numbered after the target arguments
+	sqlMock.ExpectQuery(`email IN \(SELECT email FROM contact_lists WHERE list_id = \$1 AND deleted_at IS NULL\) AND email > \$2\s+ORDER BY email\s+LIMIT \$3`).
+		WithArgs("newsletter", "b@example.com", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow("c@example.com").AddRow("d@example.com"))
+
+	emails, err := repo.GetTargetEmails(context.Background(), "workspace123",
+		"SELECT email FROM contact_lists WHERE list_id = $1 AND deleted_at IS NULL", []interface{}{"newsletter"}, "b@example.com", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c@example.com", "d@example.com"}, emails)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestContactBulkOperationRepository_SaveBatch(t *testing.T) {
+	t.Run("saves the results and the counters together", func(t *testing.T) {
+		repo, sqlMock := setupContactBulkOperationRepositoryTest(t)
+		operation := &domain.ContactBulkOperation{ID: "op1", Status: domain.ContactBulkOperationStatusRunning, ProcessedCount: 2, SucceededCount: 2}
+
+		sqlMock.ExpectQuery(`(?s)WITH inserted AS \(\s+INSERT INTO contact_bulk_operation_results .* VALUES \(\$4, \$5, \$6, \$7, \$8\), \(\$9, \$10, \$11, \$12, \$13\)\s+`+
+			`ON CONFLICT \(operation_id, email\) DO NOTHING\s+RETURNING status\s+\)\s+UPDATE contact_bulk_operations.*`+
+			`processed_count = processed_count \+ \(SELECT COUNT\(\*\) FROM inserted\).*WHERE id = \$1\s+RETURNING status, processed_count`).
+			WithArgs("op1", domain.ContactBulkOperationStatusRunning, sqlmock.AnyArg(),
+				"op1", "a@example.com", domain.ContactBulkResultSucceeded, sql.NullString{}, sqlmock.AnyArg(),
+				"op1", "b@example.com", domain.ContactBulkResultFailed, sql.NullString{String: "boom", Valid: true}, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"status", "processed_count", "succeeded_count", "skipped_count", "failed_count"}).
+				AddRow("running", 4, 3, 0, 1))
+
+		err := repo.SaveBatch(context.Background(), "workspace123", operation, []*domain.ContactBulkOperationResult{
+			{Email: "a@example.com", Status: domain.ContactBulkResultSucceeded},
+			{Email: "b@example.com", Status: domain.ContactBulkResultFailed, Error: "boom"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 4, operation.ProcessedCount)
+		assert.Equal(t, 3, operation.SucceededCount)
+		assert.Equal(t, 1, operation.FailedCount)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("a retried batch is counted once", func(t *testing.T) {
+		repo, sqlMock := setupContactBulkOperationRepositoryTest(t)
+		operation := &domain.ContactBulkOperation{ID: "op1", Status: domain.ContactBulkOperationStatusRunning}
+
+		// the results were saved before the task state failed to be saved: nothing is inserted
+		// and the stored counters are returned unchanged
+		sqlMock.ExpectQuery(`ON CONFLICT \(operation_id, email\) DO NOTHING`).
+			WillReturnRows(sqlmock.NewRows([]string{"status", "processed_count", "succeeded_count", "skipped_count", "failed_count"}).
+				AddRow("running", 1, 1, 0, 0))
+
+		err := repo.SaveBatch(context.Background(), "workspace123", operation, []*domain.ContactBulkOperationResult{
+			{Email: "a@example.com", Status: domain.ContactBulkResultSkipped},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, operation.ProcessedCount)
+		assert.Equal(t, 1, operation.SucceededCount)
+		assert.Equal(t, 0, operation.SkippedCount)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("returns the stored status", func(t *testing.T) {
+		repo, sqlMock := setupContactBulkOperationRepositoryTest(t)
+		operation := &domain.ContactBulkOperation{ID: "op1", Status: domain.ContactBulkOperationStatusRunning}
+
+		sqlMock.ExpectQuery(`UPDATE contact_bulk_operations`).
+			WillReturnRows(sqlmock.NewRows([]string{"status", "processed_count", "succeeded_count", "skipped_count", "failed_count"}).
+				AddRow("cancelled", 1, 1, 0, 0))
+
+		err := repo.SaveBatch(context.Background(), "workspace123", operation, []*domain.ContactBulkOperationResult{
+			{Email: "a@example.com", Status: domain.ContactBulkResultSucceeded},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContactBulkOperationStatusCancelled, operation.Status)
+	})
+
+	t.Run("operation not found", func(t *testing.T) {
+		repo, sqlMock := setupContactBulkOperationRepositoryTest(t)
+
+		sqlMock.ExpectQuery(`UPDATE contact_bulk_operations`).WillReturnError(sql.ErrNoRows)
+
+		err := repo.SaveBatch(context.Background(), "workspace123", &domain.ContactBulkOperation{ID: "op1"}, []*domain.ContactBulkOperationResult{
+			{Email: "a@example.com", Status: domain.ContactBulkResultSucceeded},
+		})
+		var notFound *domain.ErrContactBulkOperationNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		repo, sqlMock := setupContactBulkOperationRepositoryTest(t)
+
+		err := repo.SaveBatch(context.Background(), "workspace123", &domain.ContactBulkOperation{ID: "op1"}, nil)
+		require.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestContactBulkOperationRepository_ListResults(t *testing.T) {
+	repo, sqlMock := setupContactBulkOperationRepositoryTest(t)
+	now := time.Now()
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta("FROM contact_bulk_operation_results")).
+		WithArgs("op1", "", 500).
+		WillReturnRows(sqlmock.NewRows([]string{"email", "status", "error", "created_at"}).
+			AddRow("a@example.com", "succeeded", nil, now).
+			AddRow("b@example.com", "skipped", "not on the list", now))
+
+	results, err := repo.ListResults(context.Background(), "workspace123", "op1", "", 500)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, domain.ContactBulkResultSucceeded, results[0].Status)
+	assert.Equal(t, "", results[0].Error)
+	assert.Equal(t, "not on the list", results[1].Error)
+}
+
+func TestContactBulkOperationRepository_DeleteResultsForEmail(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo, sqlMock := setupContactBulkOperationRepositoryTest(t)
+
+		sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM contact_bulk_operation_results WHERE email = $1`)).
+			WithArgs("a@example.com").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := repo.DeleteResultsForEmail(context.Background(), "workspace123", "a@example.com")
+		require.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		repo, sqlMock := setupContactBulkOperationRepositoryTest(t)
+
+		sqlMock.ExpectExec(`DELETE FROM contact_bulk_operation_results`).
+			WillReturnError(errors.New("db error"))
+
+		err := repo.DeleteResultsForEmail(context.Background(), "workspace123", "a@example.com")
+		assert.ErrorContains(t, err, "failed to delete contact bulk operation results")
+	})
+}
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/lib/pq"
+)
+
+type contactImportRepository struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+// NewContactImportRepository creates a new PostgreSQL contact import repository
+func NewContactImportRepository(workspaceRepo domain.WorkspaceRepository) domain.ContactImportRepository {
+	return &contactImportRepository{
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// contactImportColumns excludes file_data, only read by the import task
+const contactImportColumns = `id, file_name, format, source, s3_key, file_size, columns, preview, mapping,
+		list_ids, list_status, dedupe_strategy, status, dry_run, task_id, total_rows, processed_rows,
+		created_count, updated_count, skipped_count, failed_count, errors, error_message,
+		created_at, updated_at, completed_at`
+
+func (r *contactImportRepository) Create(ctx context.Context, workspaceID string, contactImport *domain.ContactImport) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	now := time.Now().UTC()
+	contactImport.CreatedAt = now
+	contactImport.UpdatedAt = now
+
+	query := `
+		INSERT INTO contact_imports (id, file_name, format, source, s3_key, file_size, file_data, columns,
+		                             preview, mapping, list_ids, status, total_rows, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+	_, err = workspaceDB.ExecContext(ctx, query,
+		contactImport.ID,
+		contactImport.FileName,
+		contactImport.Format,
+		contactImport.Source,
+		sql.NullString{String: contactImport.S3Key, Valid: contactImport.S3Key != ""},
+		contactImport.FileSize,
+		contactImport.FileData,
+		pq.Array(contactImport.Columns),
+		contactImport.Preview,
+		contactImport.Mapping,
+		pq.Array(contactImport.ListIDs),
+		contactImport.Status,
+		contactImport.TotalRows,
+		contactImport.CreatedAt,
+		contactImport.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create contact import: %w", err)
+	}
+	return nil
+}
+
+func (r *contactImportRepository) GetByID(ctx context.Context, workspaceID string, id string) (*domain.ContactImport, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `SELECT ` + contactImportColumns + ` FROM contact_imports WHERE id = $1`
+
+	contactImport, err := scanContactImport(workspaceDB.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, &domain.ErrContactImportNotFound{Message: "contact import not found"}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact import: %w", err)
+	}
+	return contactImport, nil
+}
+
+func (r *contactImportRepository) GetFileData(ctx context.Context, workspaceID string, id string) ([]byte, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	var data []byte
+	err = workspaceDB.QueryRowContext(ctx, `SELECT file_data FROM contact_imports WHERE id = $1`, id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, &domain.ErrContactImportNotFound{Message: "contact import not found"}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact import file: %w", err)
+	}
+	return data, nil
+}
+
+func (r *contactImportRepository) List(ctx context.Context, workspaceID string) ([]*domain.ContactImport, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `SELECT ` + contactImportColumns + ` FROM contact_imports ORDER BY created_at DESC LIMIT 100`
+
+	rows, err := workspaceDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact imports: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	imports := []*domain.ContactImport{}
+	for rows.Next() {
+		contactImport, err := scanContactImport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan contact import: %w", err)
+		}
+		imports = append(imports, contactImport)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating contact import rows: %w", err)
+	}
+
+	return imports, nil
+}
+
+func (r *contactImportRepository) Update(ctx context.Context, workspaceID string, contactImport *domain.ContactImport) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	contactImport.UpdatedAt = time.Now().UTC()
+
+	query := `
+		UPDATE contact_imports
+		SET mapping = $1, list_ids = $2, list_status = $3, dedupe_strategy = $4, status = $5, dry_run = $6,
+		    task_id = $7, total_rows = $8, processed_rows = $9, created_count = $10, updated_count = $11,
+		    skipped_count = $12, failed_count = $13, errors = $14, error_message = $15, updated_at = $16,
+		    completed_at = $17
+		WHERE id = $18
+	`
+	result, err := workspaceDB.ExecContext(ctx, query,
+		contactImport.Mapping,
+		pq.Array(contactImport.ListIDs),
+		sql.NullString{String: string(contactImport.ListStatus), Valid: string(contactImport.ListStatus) != ""},
+		sql.NullString{String: string(contactImport.DedupeStrategy), Valid: string(contactImport.DedupeStrategy) != ""},
+		contactImport.Status,
+		contactImport.DryRun,
+		contactImport.TaskID,
+		contactImport.TotalRows,
+		contactImport.ProcessedRows,
+		contactImport.CreatedCount,
+		contactImport.UpdatedCount,
+		contactImport.SkippedCount,
+		contactImport.FailedCount,
+		contactImport.Errors,
+		contactImport.ErrorMessage,
+		contactImport.UpdatedAt,
+		contactImport.CompletedAt,
+		contactImport.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update contact import: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return &domain.ErrContactImportNotFound{Message: "contact import not found"}
+	}
+	return nil
+}
+
+func (r *contactImportRepository) Delete(ctx context.Context, workspaceID string, id string) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	result, err := workspaceDB.ExecContext(ctx, `DELETE FROM contact_imports WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete contact import: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return &domain.ErrContactImportNotFound{Message: "contact import not found"}
+	}
+	return nil
+}
+
+func scanContactImport(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*domain.ContactImport, error) {
+	contactImport := &domain.ContactImport{}
+	var s3Key, listStatus, dedupeStrategy sql.NullString
+	var columns, listIDs pq.StringArray
+	if err := scanner.Scan(
+		&contactImport.ID,
+		&contactImport.FileName,
+		&contactImport.Format,
+		&contactImport.Source,
+		&s3Key,
+		&contactImport.FileSize,
+		&columns,
+		&contactImport.Preview,
+		&contactImport.Mapping,
+		&listIDs,
+		&listStatus,
+		&dedupeStrategy,
+		&contactImport.Status,
+		&contactImport.DryRun,
+		&contactImport.TaskID,
+		&contactImport.TotalRows,
+		&contactImport.ProcessedRows,
+		&contactImport.CreatedCount,
+		&contactImport.UpdatedCount,
+		&contactImport.SkippedCount,
+		&contactImport.FailedCount,
+		&contactImport.Errors,
+		&contactImport.ErrorMessage,
+		&contactImport.CreatedAt,
+		&contactImport.UpdatedAt,
+		&contactImport.CompletedAt,
+	); err != nil {
+		return nil, err
+	}
+	contactImport.S3Key = s3Key.String
+	contactImport.ListStatus = domain.ContactListStatus(listStatus.String)
+	contactImport.DedupeStrategy = domain.ContactImportDedupeStrategy(dedupeStrategy.String)
+	contactImport.Columns = []string(columns)
+	contactImport.ListIDs = []string(listIDs)
+	if contactImport.Mapping == nil {
+		contactImport.Mapping = domain.ContactImportMapping{}
+	}
+	if contactImport.Errors == nil {
+		contactImport.Errors = domain.ContactImportRowErrors{}
+	}
+	return contactImport, nil
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+)
+
+func setupContactImportRepositoryTest(t *testing.T) (domain.ContactImportRepository, sqlmock.Sqlmock) {
+	ctrl := gomock.NewController(t)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), "workspace123").
+		Return(db, nil).
+		AnyTimes()
+
+	return NewContactImportRepository(mockWorkspaceRepo), sqlMock
+}
+
+func testContactImportRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{
+		"id", "file_name", "format", "source", "s3_key", "file_size", "columns", "preview", "mapping",
+		"list_ids", "list_status", "dedupe_strategy", "status", "dry_run", "task_id", "total_rows", "processed_rows",
+		"created_count", "updated_count", "skipped_count", "failed_count", "errors", "error_message",
+		"created_at", "updated_at", "completed_at",
+	})
+}
+
+func TestContactImportRepository_Create(t *testing.T) {
+	repo, sqlMock := setupContactImportRepositoryTest(t)
+
+	contactImport := &domain.ContactImport{
+		ID:        "import1",
+		FileName:  "contacts.csv",
+		Format:    "csv",
+		Source:    domain.ContactImportSourceUpload,
+		FileSize:  42,
+		FileData:  []byte("email\njohn@example.com\n"),
+		Columns:   []string{"email"},
+		Mapping:   domain.ContactImportMapping{"email": "email"},
+		ListIDs:   []string{},
+		Status:    domain.ContactImportStatusPending,
+		TotalRows: 1,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		sqlMock.ExpectExec(regexp.QuoteMeta(`INSERT INTO contact_imports`)).
+			WithArgs("import1", "contacts.csv", "csv", domain.ContactImportSourceUpload, sqlmock.AnyArg(), int64(42),
+				contactImport.FileData, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				domain.ContactImportStatusPending, 1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repo.Create(context.Background(), "workspace123", contactImport)
+		require.NoError(t, err)
+		assert.False(t, contactImport.CreatedAt.IsZero())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		sqlMock.ExpectExec(regexp.QuoteMeta(`INSERT INTO contact_imports`)).
+			WillReturnError(errors.New("database error"))
+
+		err := repo.Create(context.Background(), "workspace123", contactImport)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create contact import")
+	})
+
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestContactImportRepository_GetByID(t *testing.T) {
+	repo, sqlMock := setupContactImportRepositoryTest(t)
+	now := time.Now().UTC()
+
+	t.Run("found", func(t *testing.T) {
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`FROM contact_imports WHERE id = $1`)).
+			WithArgs("import1").
+			WillReturnRows(testContactImportRows().AddRow(
+				"import1", "contacts.csv", "csv", "upload", nil, int64(42), "{email,name}",
+				[]byte(`[["john@example.com","John"]]`), []byte(`{"email":"email","name":"first_name"}`),
+				"{news}", "active", "fill_empty", "completed", false, "task1", 1, 1,
+				1, 0, 0, 0, []byte(`[]`), nil,
+				now, now, now,
+			))
+
+		contactImport, err := repo.GetByID(context.Background(), "workspace123", "import1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"email", "name"}, contactImport.Columns)
+		assert.Equal(t, "first_name", contactImport.Mapping["name"])
+		assert.Equal(t, []string{"news"}, contactImport.ListIDs)
+		assert.Equal(t, domain.ContactImportDedupeFillEmpty, contactImport.DedupeStrategy)
+		assert.Equal(t, domain.ContactImportStatusCompleted, contactImport.Status)
+		require.NotNil(t, contactImport.TaskID)
+		assert.Equal(t, "task1", *contactImport.TaskID)
+		assert.Len(t, contactImport.Preview, 1)
+		assert.Empty(t, contactImport.S3Key)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`FROM contact_imports WHERE id = $1`)).
+			WithArgs("missing").
+			WillReturnRows(testContactImportRows())
+
+		_, err := repo.GetByID(context.Background(), "workspace123", "missing")
+		var notFound *domain.ErrContactImportNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestContactImportRepository_GetFileData(t *testing.T) {
+	repo, sqlMock := setupContactImportRepositoryTest(t)
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta(`SELECT file_data FROM contact_imports WHERE id = $1`)).
+		WithArgs("import1").
+		WillReturnRows(sqlmock.NewRows([]string{"file_data"}).AddRow([]byte("email\n")))
+
+	data, err := repo.GetFileData(context.Background(), "workspace123", "import1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("email\n"), data)
+
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestContactImportRepository_List(t *testing.T) {
+	repo, sqlMock := setupContactImportRepositoryTest(t)
+	now := time.Now().UTC()
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta(`FROM contact_imports ORDER BY created_at DESC`)).
+		WillReturnRows(testContactImportRows().AddRow(
+			"import1", "contacts.xlsx", "xlsx", "s3", "imports/contacts.xlsx", int64(1024), "{email}",
+			nil, nil, "{}", nil, nil, "pending", false, nil, 10, 0,
+			0, 0, 0, 0, nil, nil,
+			now, now, nil,
+		))
+
+	imports, err := repo.List(context.Background(), "workspace123")
+	require.NoError(t, err)
+	require.Len(t, imports, 1)
+	assert.Equal(t, "imports/contacts.xlsx", imports[0].S3Key)
+	assert.NotNil(t, imports[0].Mapping)
+	assert.NotNil(t, imports[0].Errors)
+	assert.Nil(t, imports[0].TaskID)
+
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestContactImportRepository_Update(t *testing.T) {
+	repo, sqlMock := setupContactImportRepositoryTest(t)
+
+	contactImport := &domain.ContactImport{
+		ID:             "import1",
+		Mapping:        domain.ContactImportMapping{"email": "email"},
+		ListIDs:        []string{"news"},
+		ListStatus:     domain.ContactListStatusActive,
+		DedupeStrategy: domain.ContactImportDedupeOverwrite,
+		Status:         domain.ContactImportStatusImporting,
+		Errors:         domain.ContactImportRowErrors{},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE contact_imports`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Update(context.Background(), "workspace123", contactImport)
+		require.NoError(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE contact_imports`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.Update(context.Background(), "workspace123", contactImport)
+		var notFound *domain.ErrContactImportNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestContactImportRepository_Delete(t *testing.T) {
+	repo, sqlMock := setupContactImportRepositoryTest(t)
+
+	sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM contact_imports WHERE id = $1`)).
+		WithArgs("import1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.Delete(context.Background(), "workspace123", "import1")
+	require.NoError(t, err)
+
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/lib/pq"
 )
 
 type contactListRepository struct {
@@ -35,19 +36,28 @@ func (r *contactListRepository) AddContactToList(ctx context.Context, workspaceI
 	contactList.UpdatedAt = now
 
 	query := `
-		INSERT INTO contact_lists (email, list_id, status, created_at, updated_at, deleted_at)
-		VALUES ($1, $2, $3, $4, $5, NULL)
-		ON CONFLICT (email, list_id) DO UPDATE
-		SET status = $3, updated_at = $5, deleted_at = NULL
-		WHERE contact_lists.status NOT IN ('bounced', 'complained')
-	`
-	_, err = workspaceDB.ExecContext(ctx, query,
+		WITH previous AS (
+			SELECT email, list_id, status, deleted_at FROM contact_lists WHERE email = $1 AND list_id = $2
+		), changed AS (
+			INSERT INTO contact_lists (email, list_id, status, created_at, updated_at, deleted_at)
+			VALUES ($1, $2, $3, $4, $5, NULL)
+			ON CONFLICT (email, list_id) DO UPDATE
+			SET status = $3, updated_at = $5, deleted_at = NULL
+			WHERE contact_lists.status NOT IN ('bounced', 'complained')
+			RETURNING email, list_id, status
+		)
+	` + consentLedgerInsertSQL(6)
+
+	args := []interface{}{
 		contactList.Email,
 		contactList.ListID,
 		contactList.Status,
 		contactList.CreatedAt,
 		contactList.UpdatedAt,
-	)
+	}
+	args = append(args, consentLedgerArgs(ctx, now)...)
+
+	_, err = workspaceDB.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to add contact to list: %w", err)
 	}
@@ -71,7 +81,8 @@ func (r *contactListRepository) BulkAddContactsToLists(ctx context.Context, work
 	now := time.Now().UTC()
 
 	// Calculate emails per batch based on cross-product size
-	// Each row uses 5 params; PostgreSQL limit is 65,535 params
+	// Each row uses 5 params, plus 10 for the previous-status lookup and consent ledger
+	// details; PostgreSQL limit is 65,535 params
 	emailsPerBatch := domain.BulkListAssignMaxRows / len(listIDs)
 	if emailsPerBatch < 1 {
 		emailsPerBatch = 1
@@ -85,10 +96,14 @@ func (r *contactListRepository) BulkAddContactsToLists(ctx context.Context, work
 		batchEmails := emails[i:end]
 
 		var qb strings.Builder
-		args := make([]interface{}, 0, len(batchEmails)*len(listIDs)*5)
-		argIndex := 1
+		args := make([]interface{}, 0, len(batchEmails)*len(listIDs)*5+2)
+		args = append(args, pq.Array(batchEmails), pq.Array(listIDs))
+		argIndex := 3
 
-		qb.WriteString(`INSERT INTO contact_lists (email, list_id, status, created_at, updated_at, deleted_at) VALUES `)
+		qb.WriteString(`WITH previous AS (
+			SELECT email, list_id, status, deleted_at FROM contact_lists WHERE email = ANY($1) AND list_id = ANY($2)
+		), changed AS (
+		INSERT INTO contact_lists (email, list_id, status, created_at, updated_at, deleted_at) VALUES `)
 
 		first := true
 		for _, email := range batchEmails {
@@ -118,7 +133,12 @@ func (r *contactListRepository) BulkAddContactsToLists(ctx context.Context, work
 		qb.WriteString(`
 		ON CONFLICT (email, list_id) DO UPDATE
 		SET status = EXCLUDED.status, updated_at = EXCLUDED.updated_at, deleted_at = NULL
-		WHERE contact_lists.status NOT IN ('unsubscribed', 'bounced', 'complained')`)
+		WHERE contact_lists.status NOT IN ('unsubscribed', 'bounced', 'complained')
+		RETURNING email, list_id, status
+		)
+		`)
+		qb.WriteString(consentLedgerInsertSQL(argIndex))
+		args = append(args, consentLedgerArgs(ctx, now)...)
 
 		_, err = workspaceDB.ExecContext(ctx, qb.String(), args...)
 		if err != nil {
@@ -138,7 +158,7 @@ func (r *contactListRepository) GetContactListByIDs(ctx context.Context, workspa
 	}
 
 	query := `
-		SELECT email, list_id, status, created_at, updated_at, deleted_at
+		SELECT email, list_id, status, created_at, updated_at, deleted_at, frequency, paused_until
 		FROM contact_lists
 		WHERE email = $1 AND list_id = $2 AND deleted_at IS NULL
 	`
@@ -165,7 +185,7 @@ func (r *contactListRepository) GetContactsByListID(ctx context.Context, workspa
 	}
 
 	query := `
-		SELECT email, list_id, status, created_at, updated_at, deleted_at
+		SELECT email, list_id, status, created_at, updated_at, deleted_at, frequency, paused_until
 		FROM contact_lists
 		WHERE list_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC
@@ -202,7 +222,7 @@ func (r *contactListRepository) GetListsByEmail(ctx context.Context, workspaceID
 	}
 
 	query := `
-		SELECT email, list_id, status, created_at, updated_at, deleted_at
+		SELECT email, list_id, status, created_at, updated_at, deleted_at, frequency, paused_until
 		FROM contact_lists
 		WHERE email = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC
@@ -241,27 +261,27 @@ func (r *contactListRepository) UpdateContactListStatus(ctx context.Context, wor
 	now := time.Now().UTC()
 
 	query := `
-		UPDATE contact_lists
-		SET status = $1, updated_at = $2, deleted_at = NULL	
-		WHERE email = $3 AND list_id = $4
+		WITH previous AS (
+			SELECT email, list_id, status, deleted_at FROM contact_lists WHERE email = $3 AND list_id = $4
+		), changed AS (
+			UPDATE contact_lists
+			SET status = $1, updated_at = $2, deleted_at = NULL
+			WHERE email = $3 AND list_id = $4
+			RETURNING email, list_id, status
+		), ledger AS (
+	` + consentLedgerInsertSQL(5) + `
+		)
+		SELECT COUNT(*) FROM changed
 	`
 
-	result, err := workspaceDB.ExecContext(ctx, query,
-		status,
-		now,
-		email,
-		listID,
-	)
+	args := []interface{}{status, now, email, listID}
+	args = append(args, consentLedgerArgs(ctx, now)...)
 
-	if err != nil {
+	var rows int
+	if err := workspaceDB.QueryRowContext(ctx, query, args...).Scan(&rows); err != nil {
 		return fmt.Errorf("failed to update contact list status: %w", err)
 	}
 
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get affected rows: %w", err)
-	}
-
 	if rows == 0 {
 		return &domain.ErrContactListNotFound{Message: "contact list not found"}
 	}
@@ -296,6 +316,48 @@ func (r *contactListRepository) RemoveContactFromList(ctx context.Context, works
 	return nil
 }
 
+// PauseContactLists pauses (or resumes, when pausedUntil is nil) every list subscription of a contact
+func (r *contactListRepository) PauseContactLists(ctx context.Context, workspaceID string, email string, pausedUntil *time.Time) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `UPDATE contact_lists SET paused_until = $1, updated_at = $2 WHERE email = $3 AND deleted_at IS NULL`
+
+	if _, err := workspaceDB.ExecContext(ctx, query, pausedUntil, time.Now().UTC(), email); err != nil {
+		return fmt.Errorf("failed to pause contact lists: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateContactListFrequency updates the delivery frequency of a contact's list subscription
+func (r *contactListRepository) UpdateContactListFrequency(ctx context.Context, workspaceID string, email, listID string, frequency domain.ContactListFrequency) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `UPDATE contact_lists SET frequency = $1, updated_at = $2 WHERE email = $3 AND list_id = $4 AND deleted_at IS NULL`
+
+	result, err := workspaceDB.ExecContext(ctx, query, string(frequency), time.Now().UTC(), email, listID)
+	if err != nil {
+		return fmt.Errorf("failed to update contact list frequency: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return &domain.ErrContactListNotFound{Message: "contact list not found"}
+	}
+
+	return nil
+}
+
 // DeleteForEmail deletes all contact list relationships for a specific email
 func (r *contactListRepository) DeleteForEmail(ctx context.Context, workspaceID, email string) error {
 	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
@@ -303,7 +365,10 @@ func (r *contactListRepository) DeleteForEmail(ctx context.Context, workspaceID,
 		return fmt.Errorf("failed to get workspace connection: %w", err)
 	}
 
-	query := `DELETE FROM contact_lists WHERE email = $1`
+	// The consent ledger of the contact is erased with its subscriptions
+	query := `
+		WITH ledger AS (DELETE FROM consent_records WHERE email = $1)
+		DELETE FROM contact_lists WHERE email = $1`
 
 	result, err := workspaceDB.ExecContext(ctx, query, email)
 	if err != nil {
@@ -320,3 +385,180 @@ func (r *contactListRepository) DeleteForEmail(ctx context.Context, workspaceID,
 
 	return nil
 }
+
+// consentActionSQL maps a status transition of the "changed" CTE (c), compared with the
+// row it replaced in the "previous" CTE (p), to a consent ledger action. Transitions that
+// are not consent events (bounces, complaints, unchanged statuses) map to NULL.
+const consentActionSQL = `CASE
+				WHEN p.status IS NOT DISTINCT FROM c.status AND p.deleted_at IS NULL THEN NULL
+				WHEN c.status = 'active' AND p.status = 'pending' AND p.deleted_at IS NULL THEN 'confirmed'
+				WHEN c.status = 'active' THEN 'subscribed'
+				WHEN c.status = 'pending' THEN 'pending'
+				WHEN c.status = 'unsubscribed' THEN 'unsubscribed'
+			END`
+
+// consentLedgerInsertSQL returns the statement appending one consent_records row per
+// consent action of the "changed" CTE. The ledger details are bound from argument index
+// first onwards, in the order of consentLedgerArgs. A confirmation without an explicit
+// message ID inherits the one of the double opt-in email it confirms.
+func consentLedgerInsertSQL(first int) string {
+	return fmt.Sprintf(`
+		INSERT INTO consent_records (email, list_id, action, source, source_id, ip_address, user_agent, page_url, consent_text_version, message_id, created_at)
+		SELECT c.email, c.list_id, a.action, $%[1]d::text, $%[2]d::text, $%[3]d::text, $%[4]d::text, $%[5]d::text, $%[6]d::text,
+			COALESCE($%[7]d::text, CASE WHEN a.action = 'confirmed' THEN (
+				SELECT cr.message_id FROM consent_records cr
+				WHERE cr.email = c.email AND cr.list_id = c.list_id AND cr.action = 'pending'
+				ORDER BY cr.created_at DESC LIMIT 1
+			) END),
+			$%[8]d::timestamptz
+		FROM changed c
+		LEFT JOIN previous p ON p.email = c.email AND p.list_id = c.list_id
+		CROSS JOIN LATERAL (SELECT %[9]s AS action) a
+		WHERE a.action IS NOT NULL`,
+		first, first+1, first+2, first+3, first+4, first+5, first+6, first+7, consentActionSQL)
+}
+
+// consentLedgerArgs returns the consent ledger details of the ConsentContext in ctx
+func consentLedgerArgs(ctx context.Context, now time.Time) []interface{} {
+	consent := domain.ConsentContextFromContext(ctx)
+	nullable := func(value string) sql.NullString {
+		return sql.NullString{String: value, Valid: value != ""}
+	}
+	return []interface{}{
+		string(consent.Source),
+		nullable(consent.SourceID),
+		nullable(consent.IPAddress),
+		nullable(consent.UserAgent),
+		nullable(consent.PageURL),
+		nullable(consent.ConsentTextVersion),
+		nullable(consent.MessageID),
+		now,
+	}
+}
+
+// ListConsentRecords retrieves consent ledger entries of a contact and/or a list, most recent first
+func (r *contactListRepository) ListConsentRecords(ctx context.Context, workspaceID string, email, listID string, limit int, cursor *string) ([]*domain.ConsentRecord, *string, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	query := `
+		SELECT id, email, list_id, action, source, source_id, ip_address, user_agent, page_url, consent_text_version, message_id, created_at
+		FROM consent_records
+		WHERE TRUE
+	`
+	var args []interface{}
+	argIndex := 1
+
+	if email != "" {
+		query += fmt.Sprintf(" AND email = $%d", argIndex)
+		args = append(args, email)
+		argIndex++
+	}
+	if listID != "" {
+		query += fmt.Sprintf(" AND list_id = $%d", argIndex)
+		args = append(args, listID)
+		argIndex++
+	}
+
+	if cursor != nil && *cursor != "" {
+		decodedCursor, err := decodeCursor(*cursor)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+
+		// Cursor format: "timestamp|id"
+		parts := strings.Split(decodedCursor, "|")
+		if len(parts) != 2 {
+			return nil, nil, fmt.Errorf("invalid cursor format")
+		}
+
+		cursorTime, err := time.Parse(time.RFC3339Nano, parts[0])
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid cursor timestamp: %w", err)
+		}
+
+		query += fmt.Sprintf(" AND (created_at < $%d OR (created_at = $%d AND id < $%d))", argIndex, argIndex+1, argIndex+2)
+		args = append(args, cursorTime, cursorTime, parts[1])
+		argIndex += 3
+	}
+
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", argIndex)
+	args = append(args, limit+1) // Fetch one extra to determine if there's a next page
+
+	rows, err := workspaceDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query consent records: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	records := make([]*domain.ConsentRecord, 0)
+	for rows.Next() {
+		record, err := scanConsentRecord(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan consent record: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating consent record rows: %w", err)
+	}
+
+	var nextCursor *string
+	if len(records) > limit {
+		lastRecord := records[limit-1]
+		cursorStr := encodeCursor(lastRecord.CreatedAt, lastRecord.ID)
+		nextCursor = &cursorStr
+		records = records[:limit]
+	}
+
+	return records, nextCursor, nil
+}
+
+// scanConsentRecord scans a consent_records row selected in ListConsentRecords column order
+func scanConsentRecord(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*domain.ConsentRecord, error) {
+	var record domain.ConsentRecord
+	var sourceID, ipAddress, userAgent, pageURL, consentTextVersion, messageID sql.NullString
+	if err := scanner.Scan(
+		&record.ID,
+		&record.Email,
+		&record.ListID,
+		&record.Action,
+		&record.Source,
+		&sourceID,
+		&ipAddress,
+		&userAgent,
+		&pageURL,
+		&consentTextVersion,
+		&messageID,
+		&record.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	optional := func(value sql.NullString) *string {
+		if !value.Valid {
+			return nil
+		}
+		return &value.String
+	}
+	record.SourceID = optional(sourceID)
+	record.IPAddress = optional(ipAddress)
+	record.UserAgent = optional(userAgent)
+	record.PageURL = optional(pageURL)
+	record.ConsentTextVersion = optional(consentTextVersion)
+	record.MessageID = optional(messageID)
+
+	return &record, nil
+}
@@ -52,13 +52,38 @@ func TestContactListRepository_AddContactToList(t *testing.T) {
 			Return(db, nil)
 
 		mock.ExpectExec(`INSERT INTO contact_lists`).
-			WithArgs(contactList.Email, contactList.ListID, contactList.Status, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WithArgs(contactList.Email, contactList.ListID, contactList.Status, sqlmock.AnyArg(), sqlmock.AnyArg(),
+				"api", nil, nil, nil, nil, nil, nil, sqlmock.AnyArg()).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		err := repo.AddContactToList(ctx, workspaceID, contactList)
 		require.NoError(t, err)
 	})
 
+	t.Run("records the consent context in the ledger", func(t *testing.T) {
+		consentCtx := domain.WithConsentContext(ctx, domain.ConsentContext{
+			Source:             domain.ConsentSourceForm,
+			IPAddress:          "203.0.113.7",
+			UserAgent:          "Mozilla/5.0",
+			PageURL:            "https://example.com/newsletter",
+			ConsentTextVersion: "v2",
+			MessageID:          "msg-1",
+		})
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(consentCtx, workspaceID).
+			Return(db, nil)
+
+		mock.ExpectExec(`WITH previous AS \(.+\), changed AS \(\s*INSERT INTO contact_lists .+ RETURNING email, list_id, status\s*\)\s*INSERT INTO consent_records`).
+			WithArgs(contactList.Email, contactList.ListID, contactList.Status, sqlmock.AnyArg(), sqlmock.AnyArg(),
+				"form", nil, "203.0.113.7", "Mozilla/5.0", "https://example.com/newsletter", "v2", "msg-1", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repo.AddContactToList(consentCtx, workspaceID, contactList)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("workspace connection error", func(t *testing.T) {
 		mockWorkspaceRepo.EXPECT().
 			GetConnection(ctx, workspaceID).
@@ -98,8 +123,8 @@ func TestContactListRepository_GetContactListByIDs(t *testing.T) {
 			GetConnection(ctx, workspaceID).
 			Return(db, nil)
 
-		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at"}).
-			AddRow(email, listID, domain.ContactListStatusActive, time.Now(), time.Now(), nil)
+		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at", "frequency", "paused_until"}).
+			AddRow(email, listID, domain.ContactListStatusActive, time.Now(), time.Now(), nil, "all", nil)
 
 		mock.ExpectQuery(`SELECT email, list_id, status, created_at, updated_at, deleted_at`).
 			WithArgs(email, listID).
@@ -143,8 +168,8 @@ func TestContactListRepository_GetContactListByIDs(t *testing.T) {
 			GetConnection(ctx, workspaceID).
 			Return(db, nil)
 
-		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at"}).
-			AddRow(nil, nil, nil, nil, nil, nil) // Invalid data to cause scan error
+		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at", "frequency", "paused_until"}).
+			AddRow(nil, nil, nil, nil, nil, nil, nil, nil) // Invalid data to cause scan error
 
 		mock.ExpectQuery(`SELECT email, list_id, status, created_at, updated_at, deleted_at`).
 			WithArgs(email, listID).
@@ -170,8 +195,8 @@ func TestContactListRepository_GetContactsByListID(t *testing.T) {
 			GetConnection(ctx, workspaceID).
 			Return(db, nil)
 
-		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at"}).
-			AddRow("test@example.com", listID, domain.ContactListStatusActive, time.Now(), time.Now(), nil)
+		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at", "frequency", "paused_until"}).
+			AddRow("test@example.com", listID, domain.ContactListStatusActive, time.Now(), time.Now(), nil, "all", nil)
 
 		mock.ExpectQuery(`SELECT email, list_id, status, created_at, updated_at, deleted_at`).
 			WithArgs(listID).
@@ -214,7 +239,7 @@ func TestContactListRepository_GetContactsByListID(t *testing.T) {
 			GetConnection(ctx, workspaceID).
 			Return(db, nil)
 
-		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at"})
+		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at", "frequency", "paused_until"})
 
 		mock.ExpectQuery(`SELECT email, list_id, status, created_at, updated_at, deleted_at`).
 			WithArgs(listID).
@@ -230,8 +255,8 @@ func TestContactListRepository_GetContactsByListID(t *testing.T) {
 			GetConnection(ctx, workspaceID).
 			Return(db, nil)
 
-		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at"}).
-			AddRow(nil, nil, nil, nil, nil, nil) // Invalid data to cause scan error
+		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at", "frequency", "paused_until"}).
+			AddRow(nil, nil, nil, nil, nil, nil, nil, nil) // Invalid data to cause scan error
 
 		mock.ExpectQuery(`SELECT email, list_id, status, created_at, updated_at, deleted_at`).
 			WithArgs(listID).
@@ -257,8 +282,8 @@ func TestContactListRepository_GetListsByEmail(t *testing.T) {
 			GetConnection(ctx, workspaceID).
 			Return(db, nil)
 
-		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at"}).
-			AddRow(email, "list123", domain.ContactListStatusActive, time.Now(), time.Now(), nil)
+		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at", "frequency", "paused_until"}).
+			AddRow(email, "list123", domain.ContactListStatusActive, time.Now(), time.Now(), nil, "all", nil)
 
 		mock.ExpectQuery(`SELECT email, list_id, status, created_at, updated_at, deleted_at`).
 			WithArgs(email).
@@ -301,7 +326,7 @@ func TestContactListRepository_GetListsByEmail(t *testing.T) {
 			GetConnection(ctx, workspaceID).
 			Return(db, nil)
 
-		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at"})
+		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at", "frequency", "paused_until"})
 
 		mock.ExpectQuery(`SELECT email, list_id, status, created_at, updated_at, deleted_at`).
 			WithArgs(email).
@@ -317,8 +342,8 @@ func TestContactListRepository_GetListsByEmail(t *testing.T) {
 			GetConnection(ctx, workspaceID).
 			Return(db, nil)
 
-		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at"}).
-			AddRow(nil, nil, nil, nil, nil, nil) // Invalid data to cause scan error
+		rows := sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "deleted_at", "frequency", "paused_until"}).
+			AddRow(nil, nil, nil, nil, nil, nil, nil, nil) // Invalid data to cause scan error
 
 		mock.ExpectQuery(`SELECT email, list_id, status, created_at, updated_at, deleted_at`).
 			WithArgs(email).
@@ -346,9 +371,9 @@ func TestContactListRepository_UpdateContactListStatus(t *testing.T) {
 			GetConnection(ctx, workspaceID).
 			Return(db, nil)
 
-		mock.ExpectExec(`UPDATE contact_lists`).
-			WithArgs(status, sqlmock.AnyArg(), email, listID).
-			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`UPDATE contact_lists .+ RETURNING email, list_id, status .+ INSERT INTO consent_records .+ SELECT COUNT\(\*\) FROM changed`).
+			WithArgs(status, sqlmock.AnyArg(), email, listID, "api", nil, nil, nil, nil, nil, nil, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
 		err := repo.UpdateContactListStatus(ctx, workspaceID, email, listID, status)
 		require.NoError(t, err)
@@ -359,9 +384,9 @@ func TestContactListRepository_UpdateContactListStatus(t *testing.T) {
 			GetConnection(ctx, workspaceID).
 			Return(db, nil)
 
-		mock.ExpectExec(`UPDATE contact_lists`).
-			WithArgs(status, sqlmock.AnyArg(), email, listID).
-			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`UPDATE contact_lists`).
+			WithArgs(status, sqlmock.AnyArg(), email, listID, "api", nil, nil, nil, nil, nil, nil, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
 		err := repo.UpdateContactListStatus(ctx, workspaceID, email, listID, status)
 		require.Error(t, err)
@@ -383,28 +408,13 @@ func TestContactListRepository_UpdateContactListStatus(t *testing.T) {
 			GetConnection(ctx, workspaceID).
 			Return(db, nil)
 
-		mock.ExpectExec(`UPDATE contact_lists`).
-			WithArgs(status, sqlmock.AnyArg(), email, listID).
+		mock.ExpectQuery(`UPDATE contact_lists`).
 			WillReturnError(errors.New("execution error"))
 
 		err := repo.UpdateContactListStatus(ctx, workspaceID, email, listID, status)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "failed to update contact list status")
 	})
-
-	t.Run("rows affected error", func(t *testing.T) {
-		mockWorkspaceRepo.EXPECT().
-			GetConnection(ctx, workspaceID).
-			Return(db, nil)
-
-		mock.ExpectExec(`UPDATE contact_lists`).
-			WithArgs(status, sqlmock.AnyArg(), email, listID).
-			WillReturnResult(sqlmock.NewErrorResult(errors.New("rows affected error")))
-
-		err := repo.UpdateContactListStatus(ctx, workspaceID, email, listID, status)
-		require.Error(t, err)
-		require.Contains(t, err.Error(), "failed to get affected rows")
-	})
 }
 
 func TestContactListRepository_RemoveContactFromList(t *testing.T) {
@@ -483,6 +493,90 @@ func TestContactListRepository_RemoveContactFromList(t *testing.T) {
 	})
 }
 
+func TestContactListRepository_PauseContactLists(t *testing.T) {
+	mockWorkspaceRepo, repo, mock, db, cleanup := setupContactListTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+	email := "test@example.com"
+	pausedUntil := time.Now().UTC().AddDate(0, 0, 30)
+
+	t.Run("pause", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(db, nil)
+
+		mock.ExpectExec(`UPDATE contact_lists SET paused_until = (.+), updated_at = (.+) WHERE email = (.+) AND deleted_at IS NULL`).
+			WithArgs(&pausedUntil, sqlmock.AnyArg(), email).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		require.NoError(t, repo.PauseContactLists(ctx, workspaceID, email, &pausedUntil))
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(db, nil)
+
+		mock.ExpectExec(`UPDATE contact_lists SET paused_until`).
+			WithArgs(nil, sqlmock.AnyArg(), email).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		require.NoError(t, repo.PauseContactLists(ctx, workspaceID, email, nil))
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(db, nil)
+
+		mock.ExpectExec(`UPDATE contact_lists SET paused_until`).
+			WillReturnError(errors.New("database error"))
+
+		err := repo.PauseContactLists(ctx, workspaceID, email, &pausedUntil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to pause contact lists")
+	})
+}
+
+func TestContactListRepository_UpdateContactListFrequency(t *testing.T) {
+	mockWorkspaceRepo, repo, mock, db, cleanup := setupContactListTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+	email := "test@example.com"
+	listID := "list123"
+
+	t.Run("success", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(db, nil)
+
+		mock.ExpectExec(`UPDATE contact_lists SET frequency = (.+), updated_at = (.+) WHERE email = (.+) AND list_id = (.+) AND deleted_at IS NULL`).
+			WithArgs("weekly_digest", sqlmock.AnyArg(), email, listID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.UpdateContactListFrequency(ctx, workspaceID, email, listID, domain.ContactListFrequencyWeeklyDigest)
+		require.NoError(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(db, nil)
+
+		mock.ExpectExec(`UPDATE contact_lists SET frequency`).
+			WithArgs("all", sqlmock.AnyArg(), email, listID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.UpdateContactListFrequency(ctx, workspaceID, email, listID, domain.ContactListFrequencyAll)
+		require.Error(t, err)
+		require.IsType(t, &domain.ErrContactListNotFound{}, err)
+	})
+}
+
 func TestContactListRepository_DeleteForEmail(t *testing.T) {
 	mockWorkspaceRepo, repo, mock, db, cleanup := setupContactListTest(t)
 	defer cleanup()
@@ -568,22 +662,25 @@ func TestContactListRepository_BulkAddContactsToLists(t *testing.T) {
 	status := domain.ContactListStatusActive
 
 	t.Run("Success - Bulk add", func(t *testing.T) {
+		importCtx := domain.WithConsentContext(ctx, domain.ConsentContext{Source: domain.ConsentSourceImport})
 		mockWorkspaceRepo.EXPECT().
-			GetConnection(ctx, workspaceID).
+			GetConnection(importCtx, workspaceID).
 			Return(db, nil)
 
 		// Expect INSERT with cross-product (2 emails * 2 lists = 4 rows)
 		// Note: deleted_at is NULL in SQL, not a parameter, so only 5 args per row
 		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO contact_lists (email, list_id, status, created_at, updated_at, deleted_at) VALUES`)).
 			WithArgs(
+				sqlmock.AnyArg(), sqlmock.AnyArg(), // previous-status lookup arrays
 				emails[0], listIDs[0], status, sqlmock.AnyArg(), sqlmock.AnyArg(),
 				emails[0], listIDs[1], status, sqlmock.AnyArg(), sqlmock.AnyArg(),
 				emails[1], listIDs[0], status, sqlmock.AnyArg(), sqlmock.AnyArg(),
 				emails[1], listIDs[1], status, sqlmock.AnyArg(), sqlmock.AnyArg(),
+				"import", nil, nil, nil, nil, nil, nil, sqlmock.AnyArg(),
 			).
 			WillReturnResult(sqlmock.NewResult(4, 4))
 
-		err := repo.BulkAddContactsToLists(ctx, workspaceID, emails, listIDs, status)
+		err := repo.BulkAddContactsToLists(importCtx, workspaceID, emails, listIDs, status)
 		require.NoError(t, err)
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
@@ -621,3 +718,63 @@ func TestContactListRepository_BulkAddContactsToLists(t *testing.T) {
 		require.Contains(t, err.Error(), "failed to bulk add contacts to lists")
 	})
 }
+
+func TestContactListRepository_ListConsentRecords(t *testing.T) {
+	mockWorkspaceRepo, repo, mock, db, cleanup := setupContactListTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+	columns := []string{
+		"id", "email", "list_id", "action", "source", "source_id", "ip_address", "user_agent", "page_url", "consent_text_version", "message_id", "created_at",
+	}
+	now := time.Now().UTC()
+
+	t.Run("filters by contact and list and paginates", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(ctx, workspaceID).Return(db, nil)
+
+		mock.ExpectQuery(`FROM consent_records WHERE TRUE AND email = \$1 AND list_id = \$2 ORDER BY created_at DESC, id DESC LIMIT \$3`).
+			WithArgs("test@example.com", "list1", 2).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("rec-2", "test@example.com", "list1", "confirmed", "notification_center", nil, "203.0.113.7", "Mozilla/5.0", nil, nil, "msg-1", now).
+				AddRow("rec-1", "test@example.com", "list1", "pending", "form", nil, "203.0.113.7", "Mozilla/5.0", "https://example.com", "v2", "msg-1", now.Add(-time.Hour)))
+
+		records, nextCursor, err := repo.ListConsentRecords(ctx, workspaceID, "test@example.com", "list1", 1, nil)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, domain.ConsentActionConfirmed, records[0].Action)
+		require.Equal(t, "msg-1", *records[0].MessageID)
+		require.Nil(t, records[0].PageURL)
+		require.NotNil(t, nextCursor)
+
+		// The next page starts after the last returned record
+		mockWorkspaceRepo.EXPECT().GetConnection(ctx, workspaceID).Return(db, nil)
+		mock.ExpectQuery(`FROM consent_records WHERE TRUE AND list_id = \$1 AND \(created_at < \$2 OR \(created_at = \$3 AND id < \$4\)\)`).
+			WithArgs("list1", sqlmock.AnyArg(), sqlmock.AnyArg(), "rec-2", 2).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		records, nextCursor, err = repo.ListConsentRecords(ctx, workspaceID, "", "list1", 1, nextCursor)
+		require.NoError(t, err)
+		require.Empty(t, records)
+		require.Nil(t, nextCursor)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(ctx, workspaceID).Return(db, nil)
+
+		cursor := "not-a-cursor"
+		_, _, err := repo.ListConsentRecords(ctx, workspaceID, "test@example.com", "", 10, &cursor)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid cursor")
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(ctx, workspaceID).Return(db, nil)
+		mock.ExpectQuery(`FROM consent_records`).WillReturnError(errors.New("boom"))
+
+		_, _, err := repo.ListConsentRecords(ctx, workspaceID, "test@example.com", "", 10, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to query consent records")
+	})
+}
@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// contactStatement is a statement run while moving the data of a contact
+type contactStatement struct {
+	query string
+	args  []interface{}
+}
+
+// MergeContacts moves everything keyed by secondaryEmail to primaryEmail and deletes
+// the secondary contact in a single transaction. The contact fields themselves are
+// merged by the caller beforehand.
+func (r *contactRepository) MergeContacts(ctx context.Context, workspaceID string, primaryEmail string, secondaryEmail string) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	tx, err := workspaceDB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var locked int
+	err = tx.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM (SELECT email FROM contacts WHERE email IN ($1, $2) FOR UPDATE) c`,
+		primaryEmail, secondaryEmail,
+	).Scan(&locked)
+	if err != nil {
+		return fmt.Errorf("failed to lock contacts: %w", err)
+	}
+	if locked != 2 {
+		return domain.ErrContactNotFound
+	}
+
+	now := time.Now().UTC()
+	if err := moveContactData(ctx, tx, secondaryEmail, primaryEmail, domain.ContactAliasReasonMerge, now); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM contacts WHERE email = $1`, secondaryEmail); err != nil {
+		return fmt.Errorf("failed to delete merged contact: %w", err)
+	}
+
+	const timelineQuery = `
+INSERT INTO contact_timeline (email, operation, entity_type, kind, entity_id, changes, created_at)
+VALUES ($1, 'update', 'contact', 'contact.merged', $1, jsonb_build_object('merged_email', jsonb_build_object('new', $2::text)), $3)`
+	if _, err := tx.ExecContext(ctx, timelineQuery, primaryEmail, secondaryEmail, now); err != nil {
+		return fmt.Errorf("failed to record merge on timeline: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ChangeContactEmail renames a contact and moves its related data to newEmail
+// in a single transaction
+func (r *contactRepository) ChangeContactEmail(ctx context.Context, workspaceID string, email string, newEmail string) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	tx, err := workspaceDB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var locked string
+	err = tx.QueryRowContext(ctx, `SELECT email FROM contacts WHERE email = $1 FOR UPDATE`, email).Scan(&locked)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrContactNotFound
+		}
+		return fmt.Errorf("failed to lock contact: %w", err)
+	}
+
+	var inUse bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM contacts WHERE email = $1)`, newEmail).Scan(&inUse); err != nil {
+		return fmt.Errorf("failed to check new email: %w", err)
+	}
+	if inUse {
+		return domain.ErrContactEmailInUse
+	}
+
+	now := time.Now().UTC()
+	if _, err := tx.ExecContext(ctx, `UPDATE contacts SET email = $2, db_updated_at = $3 WHERE email = $1`, email, newEmail, now); err != nil {
+		return fmt.Errorf("failed to change contact email: %w", err)
+	}
+
+	if err := moveContactData(ctx, tx, email, newEmail, domain.ContactAliasReasonEmailChange, now); err != nil {
+		return err
+	}
+
+	const timelineQuery = `
+INSERT INTO contact_timeline (email, operation, entity_type, kind, entity_id, changes, created_at)
+VALUES ($1, 'update', 'contact', 'contact.email_changed', $1, jsonb_build_object('email', jsonb_build_object('old', $2::text, 'new', $1::text)), $3)`
+	if _, err := tx.ExecContext(ctx, timelineQuery, newEmail, email, now); err != nil {
+		return fmt.Errorf("failed to record email change on timeline: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// moveContactData re-points the lists, message history, timeline, custom events,
+// automation journeys, blog comments and reactions and aliases of from to the
+// contact to, and records from as an alias of to. Consent records are append-only proof and stay under the address
+// the consent was given with.
+func moveContactData(ctx context.Context, tx *sql.Tx, from string, to string, reason domain.ContactAliasReason, at time.Time) error {
+	statements := []contactStatement{
+		// a more restrictive status of the moved address wins over an active or pending subscription
+		{`
+UPDATE contact_lists t
+   SET status = f.status, updated_at = $3
+  FROM contact_lists f
+ WHERE f.email = $1 AND t.email = $2 AND t.list_id = f.list_id
+   AND f.deleted_at IS NULL AND t.deleted_at IS NULL
+   AND f.status IN ('unsubscribed', 'bounced', 'complained')
+   AND t.status NOT IN ('unsubscribed', 'bounced', 'complained')`, []interface{}{from, to, at}},
+		{`DELETE FROM contact_lists f WHERE f.email = $1 AND EXISTS (SELECT 1 FROM contact_lists t WHERE t.email = $2 AND t.list_id = f.list_id)`, []interface{}{from, to}},
+		{`UPDATE contact_lists SET email = $2 WHERE email = $1`, []interface{}{from, to}},
+		// segment membership is recomputed for the surviving contact
+		{`DELETE FROM contact_segments WHERE email = $1`, []interface{}{from}},
+		{`DELETE FROM contact_segment_queue WHERE email = $1`, []interface{}{from}},
+		{`UPDATE message_history SET contact_email = $2 WHERE contact_email = $1`, []interface{}{from, to}},
+		{`UPDATE contact_timeline SET email = $2 WHERE email = $1`, []interface{}{from, to}},
+		{`UPDATE custom_events SET email = $2 WHERE email = $1`, []interface{}{from, to}},
+		{`UPDATE blog_comments SET email = $2 WHERE email = $1`, []interface{}{from, to}},
+		// a reaction can only be left once per post
+		{`DELETE FROM blog_post_reactions f WHERE f.email = $1 AND EXISTS (SELECT 1 FROM blog_post_reactions t WHERE t.email = $2 AND t.post_id = f.post_id AND t.reaction = f.reaction)`, []interface{}{from, to}},
+		{`UPDATE blog_post_reactions SET email = $2 WHERE email = $1`, []interface{}{from, to}},
+		// a journey already running for the surviving contact wins over the moved one
+		{`
+WITH exited AS (
+	UPDATE contact_automations f
+	   SET status = 'exited', exit_reason = 'contact_merged', scheduled_at = NULL
+	 WHERE f.contact_email = $1 AND f.status = 'active'
+	   AND EXISTS (SELECT 1 FROM contact_automations t WHERE t.contact_email = $2 AND t.automation_id = f.automation_id AND t.status = 'active')
+	RETURNING f.automation_id
+)
+INSERT INTO contact_timeline (email, operation, entity_type, kind, entity_id, changes, created_at)
+SELECT $2, 'update', 'automation', 'automation.end', automation_id,
+       jsonb_build_object('automation_id', jsonb_build_object('new', automation_id), 'exit_reason', jsonb_build_object('new', 'contact_merged'), 'status', jsonb_build_object('new', 'exited')),
+       $3
+  FROM exited`, []interface{}{from, to, at}},
+		{`UPDATE contact_automations SET contact_email = $2 WHERE contact_email = $1`, []interface{}{from, to}},
+		{`DELETE FROM automation_trigger_log f WHERE f.contact_email = $1 AND EXISTS (SELECT 1 FROM automation_trigger_log t WHERE t.contact_email = $2 AND t.automation_id = f.automation_id)`, []interface{}{from, to}},
+		{`UPDATE automation_trigger_log SET contact_email = $2 WHERE contact_email = $1`, []interface{}{from, to}},
+		{`UPDATE contact_aliases SET contact_email = $2 WHERE contact_email = $1`, []interface{}{from, to}},
+		// an address can't be an alias of itself, e.g. when changing back to a former address
+		{`DELETE FROM contact_aliases WHERE email = $1`, []interface{}{to}},
+		{`
+INSERT INTO contact_aliases (email, contact_email, reason, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (email) DO UPDATE SET contact_email = EXCLUDED.contact_email, reason = EXCLUDED.reason, created_at = EXCLUDED.created_at`, []interface{}{from, to, string(reason), at}},
+		{`INSERT INTO contact_segment_queue (email, queued_at) VALUES ($1, $2) ON CONFLICT (email) DO NOTHING`, []interface{}{to, at}},
+	}
+
+	for _, stmt := range statements {
+		if _, err := tx.ExecContext(ctx, stmt.query, stmt.args...); err != nil {
+			return fmt.Errorf("failed to move contact data: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetContactAliases returns the aliases of a contact, newest first
+func (r *contactRepository) GetContactAliases(ctx context.Context, workspaceID string, contactEmail string) ([]*domain.ContactAlias, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	rows, err := workspaceDB.QueryContext(ctx,
+		`SELECT email, contact_email, reason, created_at FROM contact_aliases WHERE contact_email = $1 ORDER BY created_at DESC`,
+		contactEmail,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contact aliases: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	aliases := []*domain.ContactAlias{}
+	for rows.Next() {
+		var alias domain.ContactAlias
+		if err := rows.Scan(&alias.Email, &alias.ContactEmail, &alias.Reason, &alias.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan contact alias: %w", err)
+		}
+		aliases = append(aliases, &alias)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate contact aliases: %w", err)
+	}
+	return aliases, nil
+}
+
+// ResolveContactAlias returns the contact email is an alias of, or an empty string
+func (r *contactRepository) ResolveContactAlias(ctx context.Context, workspaceID string, email string) (string, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	var contactEmail string
+	err = workspaceDB.QueryRowContext(ctx, `SELECT contact_email FROM contact_aliases WHERE email = $1`, email).Scan(&contactEmail)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to resolve contact alias: %w", err)
+	}
+	return contactEmail, nil
+}
+
+// DeleteContactAliases deletes the aliases of a contact, and an alias at its address. The
+// former addresses are personal data too: their consent records are deleted in the same
+// statement, as they are not moved to the new address on a merge or email change.
+func (r *contactRepository) DeleteContactAliases(ctx context.Context, workspaceID string, contactEmail string) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+WITH aliases AS (DELETE FROM contact_aliases WHERE contact_email = $1 OR email = $1 RETURNING email)
+DELETE FROM consent_records WHERE email IN (SELECT email FROM aliases)`
+	if _, err := workspaceDB.ExecContext(ctx, query, contactEmail); err != nil {
+		return fmt.Errorf("failed to delete contact aliases: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+)
+
+func setupContactMergeRepositoryTest(t *testing.T) (domain.ContactRepository, sqlmock.Sqlmock) {
+	ctrl := gomock.NewController(t)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), "workspace123").
+		Return(db, nil).
+		AnyTimes()
+
+	return NewContactRepository(mockWorkspaceRepo), sqlMock
+}
+
+// expectMoveContactData expects the statements of moveContactData, in order
+func expectMoveContactData(sqlMock sqlmock.Sqlmock, from, to string, reason domain.ContactAliasReason) {
+	sqlMock.ExpectExec(`UPDATE contact_lists t\s+SET status = f.status`).
+		WithArgs(from, to, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM contact_lists f WHERE f.email = $1`)).
+		WithArgs(from, to).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE contact_lists SET email = $2 WHERE email = $1`)).
+		WithArgs(from, to).WillReturnResult(sqlmock.NewResult(0, 2))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM contact_segments WHERE email = $1`)).
+		WithArgs(from).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM contact_segment_queue WHERE email = $1`)).
+		WithArgs(from).WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE message_history SET contact_email = $2`)).
+		WithArgs(from, to).WillReturnResult(sqlmock.NewResult(0, 5))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE contact_timeline SET email = $2`)).
+		WithArgs(from, to).WillReturnResult(sqlmock.NewResult(0, 10))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE custom_events SET email = $2`)).
+		WithArgs(from, to).WillReturnResult(sqlmock.NewResult(0, 3))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE blog_comments SET email = $2`)).
+		WithArgs(from, to).WillReturnResult(sqlmock.NewResult(0, 2))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM blog_post_reactions f WHERE f.email = $1`)).
+		WithArgs(from, to).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE blog_post_reactions SET email = $2`)).
+		WithArgs(from, to).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(`WITH exited AS \(\s+UPDATE contact_automations f`).
+		WithArgs(from, to, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE contact_automations SET contact_email = $2`)).
+		WithArgs(from, to).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM automation_trigger_log f`)).
+		WithArgs(from, to).WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE automation_trigger_log SET contact_email = $2`)).
+		WithArgs(from, to).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE contact_aliases SET contact_email = $2`)).
+		WithArgs(from, to).WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM contact_aliases WHERE email = $1`)).
+		WithArgs(to).WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`INSERT INTO contact_aliases`)).
+		WithArgs(from, to, string(reason), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`INSERT INTO contact_segment_queue`)).
+		WithArgs(to, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestContactRepository_MergeContacts(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo, sqlMock := setupContactMergeRepositoryTest(t)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(`SELECT COUNT\(\*\) FROM \(SELECT email FROM contacts WHERE email IN`).
+			WithArgs("new@example.com", "old@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		expectMoveContactData(sqlMock, "old@example.com", "new@example.com", domain.ContactAliasReasonMerge)
+		sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM contacts WHERE email = $1`)).
+			WithArgs("old@example.com").WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(`INSERT INTO contact_timeline .*'contact.merged'`).
+			WithArgs("new@example.com", "old@example.com", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+
+		err := repo.MergeContacts(context.Background(), "workspace123", "new@example.com", "old@example.com")
+		require.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("contact not found", func(t *testing.T) {
+		repo, sqlMock := setupContactMergeRepositoryTest(t)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(`SELECT COUNT\(\*\) FROM`).
+			WithArgs("new@example.com", "old@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		sqlMock.ExpectRollback()
+
+		err := repo.MergeContacts(context.Background(), "workspace123", "new@example.com", "old@example.com")
+		assert.ErrorIs(t, err, domain.ErrContactNotFound)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("statement error rolls back", func(t *testing.T) {
+		repo, sqlMock := setupContactMergeRepositoryTest(t)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(`SELECT COUNT\(\*\) FROM`).
+			WithArgs("new@example.com", "old@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		sqlMock.ExpectExec(`UPDATE contact_lists t`).WillReturnError(errors.New("db error"))
+		sqlMock.ExpectRollback()
+
+		err := repo.MergeContacts(context.Background(), "workspace123", "new@example.com", "old@example.com")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to move contact data")
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestContactRepository_ChangeContactEmail(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo, sqlMock := setupContactMergeRepositoryTest(t)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`SELECT email FROM contacts WHERE email = $1 FOR UPDATE`)).
+			WithArgs("old@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow("old@example.com"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS (SELECT 1 FROM contacts WHERE email = $1)`)).
+			WithArgs("new@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE contacts SET email = $2`)).
+			WithArgs("old@example.com", "new@example.com", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectMoveContactData(sqlMock, "old@example.com", "new@example.com", domain.ContactAliasReasonEmailChange)
+		sqlMock.ExpectExec(`INSERT INTO contact_timeline .*'contact.email_changed'`).
+			WithArgs("new@example.com", "old@example.com", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+
+		err := repo.ChangeContactEmail(context.Background(), "workspace123", "old@example.com", "new@example.com")
+		require.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("contact not found", func(t *testing.T) {
+		repo, sqlMock := setupContactMergeRepositoryTest(t)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`SELECT email FROM contacts WHERE email = $1 FOR UPDATE`)).
+			WithArgs("old@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"email"}))
+		sqlMock.ExpectRollback()
+
+		err := repo.ChangeContactEmail(context.Background(), "workspace123", "old@example.com", "new@example.com")
+		assert.ErrorIs(t, err, domain.ErrContactNotFound)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("new email already used", func(t *testing.T) {
+		repo, sqlMock := setupContactMergeRepositoryTest(t)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`SELECT email FROM contacts WHERE email = $1 FOR UPDATE`)).
+			WithArgs("old@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow("old@example.com"))
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS`)).
+			WithArgs("new@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		sqlMock.ExpectRollback()
+
+		err := repo.ChangeContactEmail(context.Background(), "workspace123", "old@example.com", "new@example.com")
+		assert.ErrorIs(t, err, domain.ErrContactEmailInUse)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestContactRepository_GetContactAliases(t *testing.T) {
+	repo, sqlMock := setupContactMergeRepositoryTest(t)
+	now := time.Now().UTC()
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta(`SELECT email, contact_email, reason, created_at FROM contact_aliases WHERE contact_email = $1`)).
+		WithArgs("new@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"email", "contact_email", "reason", "created_at"}).
+			AddRow("old@example.com", "new@example.com", "email_change", now).
+			AddRow("dup@example.com", "new@example.com", "merge", now.Add(-time.Hour)))
+
+	aliases, err := repo.GetContactAliases(context.Background(), "workspace123", "new@example.com")
+	require.NoError(t, err)
+	require.Len(t, aliases, 2)
+	assert.Equal(t, "old@example.com", aliases[0].Email)
+	assert.Equal(t, domain.ContactAliasReasonEmailChange, aliases[0].Reason)
+	assert.Equal(t, domain.ContactAliasReasonMerge, aliases[1].Reason)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestContactRepository_ResolveContactAlias(t *testing.T) {
+	t.Run("alias", func(t *testing.T) {
+		repo, sqlMock := setupContactMergeRepositoryTest(t)
+
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`SELECT contact_email FROM contact_aliases WHERE email = $1`)).
+			WithArgs("old@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"contact_email"}).AddRow("new@example.com"))
+
+		contactEmail, err := repo.ResolveContactAlias(context.Background(), "workspace123", "old@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "new@example.com", contactEmail)
+	})
+
+	t.Run("not an alias", func(t *testing.T) {
+		repo, sqlMock := setupContactMergeRepositoryTest(t)
+
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`SELECT contact_email FROM contact_aliases WHERE email = $1`)).
+			WithArgs("john@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"contact_email"}))
+
+		contactEmail, err := repo.ResolveContactAlias(context.Background(), "workspace123", "john@example.com")
+		require.NoError(t, err)
+		assert.Empty(t, contactEmail)
+	})
+}
+
+func TestContactRepository_DeleteContactAliases(t *testing.T) {
+	t.Run("deletes the aliases with their consent records", func(t *testing.T) {
+		repo, sqlMock := setupContactMergeRepositoryTest(t)
+
+		sqlMock.ExpectExec(`WITH aliases AS \(DELETE FROM contact_aliases WHERE contact_email = \$1 OR email = \$1 RETURNING email\)\s+DELETE FROM consent_records WHERE email IN \(SELECT email FROM aliases\)`).
+			WithArgs("new@example.com").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		err := repo.DeleteContactAliases(context.Background(), "workspace123", "new@example.com")
+		require.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		repo, sqlMock := setupContactMergeRepositoryTest(t)
+
+		sqlMock.ExpectExec(`WITH aliases AS`).
+			WithArgs("new@example.com").
+			WillReturnError(errors.New("db error"))
+
+		err := repo.DeleteContactAliases(context.Background(), "workspace123", "new@example.com")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete contact aliases")
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -34,6 +35,7 @@ var contactColumns = []string{
 	"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 	"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
 	"created_at", "updated_at", "db_created_at", "db_updated_at",
+	"computed_properties", "attributes", "email_verification",
 }
 
 // contactColumnsWithPrefix returns contact columns prefixed with a table alias
@@ -89,7 +91,7 @@ func (r *contactRepository) fetchContact(ctx context.Context, workspaceID string
 	}
 
 	// Fetch contact lists for this contact
-	listsQuery, listsArgs, err := psql.Select("cl.list_id", "cl.status", "cl.created_at", "cl.updated_at", "cl.deleted_at", "l.name as list_name").
+	listsQuery, listsArgs, err := psql.Select("cl.list_id", "cl.status", "cl.created_at", "cl.updated_at", "cl.deleted_at", "cl.frequency", "cl.paused_until", "l.name as list_name").
 		From("contact_lists cl").
 		Join("lists l ON cl.list_id = l.id").
 		Where(sq.Eq{"cl.email": contact.Email}).
@@ -109,6 +111,7 @@ func (r *contactRepository) fetchContact(ctx context.Context, workspaceID string
 	for rows.Next() {
 		var contactList domain.ContactList
 		var deletedAt *time.Time
+		var frequency string
 		var listName string
 		err := rows.Scan(
 			&contactList.ListID,
@@ -116,6 +119,8 @@ func (r *contactRepository) fetchContact(ctx context.Context, workspaceID string
 			&contactList.CreatedAt,
 			&contactList.UpdatedAt,
 			&deletedAt,
+			&frequency,
+			&contactList.PausedUntil,
 			&listName,
 		)
 		if err != nil {
@@ -123,6 +128,7 @@ func (r *contactRepository) fetchContact(ctx context.Context, workspaceID string
 		}
 		contactList.Email = contact.Email
 		contactList.DeletedAt = deletedAt
+		contactList.Frequency = domain.ContactListFrequency(frequency)
 		contactList.ListName = listName
 		contact.ContactLists = append(contact.ContactLists, &contactList)
 	}
@@ -395,6 +401,12 @@ func (r *contactRepository) GetContacts(ctx context.Context, req *domain.GetCont
 		if err = listRows.Err(); err != nil {
 			return nil, fmt.Errorf("error iterating over contact list rows: %w", err)
 		}
+
+		if req.WithConsent {
+			if err := r.attachLatestConsent(ctx, db, emails, contactMap); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Fetch contact segments for all contacts (always included)
@@ -815,6 +827,11 @@ func (r *contactRepository) UpsertContact(ctx context.Context, workspaceID strin
 			updatedAtValue = contact.DBUpdatedAt // Use db timestamp if not provided
 		}
 
+		attributesSQL, err := contactAttributesToNullJSON(contact.Attributes)
+		if err != nil {
+			return false, err
+		}
+
 		insertBuilder := psql.Insert("contacts").
 			Columns(
 				"email", "external_id", "timezone", "language",
@@ -825,6 +842,7 @@ func (r *contactRepository) UpsertContact(ctx context.Context, workspaceID strin
 				"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 				"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
 				"created_at", "updated_at", "db_created_at", "db_updated_at",
+				"attributes",
 			).
 			Values(
 				contact.Email, externalIDSQL, timezoneSQL, languageSQL,
@@ -835,6 +853,7 @@ func (r *contactRepository) UpsertContact(ctx context.Context, workspaceID strin
 				customDatetime1SQL, customDatetime2SQL, customDatetime3SQL, customDatetime4SQL, customDatetime5SQL,
 				customJSON1SQL, customJSON2SQL, customJSON3SQL, customJSON4SQL, customJSON5SQL,
 				createdAtValue.UTC(), updatedAtValue.UTC(), contact.DBCreatedAt, contact.DBUpdatedAt,
+				attributesSQL,
 			)
 
 		insertQuery, insertArgs, err := insertBuilder.ToSql()
@@ -1065,6 +1084,11 @@ func (r *contactRepository) UpsertContact(ctx context.Context, workspaceID strin
 			}
 		}
 
+		attributesSQL, err := contactAttributesToNullJSON(existingContact.Attributes)
+		if err != nil {
+			return false, err
+		}
+
 		// Build update query using squirrel
 		updateMap := sq.Eq{
 			"external_id":       externalIDSQL,
@@ -1100,6 +1124,7 @@ func (r *contactRepository) UpsertContact(ctx context.Context, workspaceID strin
 			"custom_json_3":     customJSON3SQL,
 			"custom_json_4":     customJSON4SQL,
 			"custom_json_5":     customJSON5SQL,
+			"attributes":        attributesSQL,
 			"db_updated_at":     existingContact.DBUpdatedAt,
 		}
 
@@ -1167,6 +1192,25 @@ func contactToNullJSON(n *domain.NullableJSON) sql.NullString {
 	return sql.NullString{Valid: false}
 }
 
+// contactAttributesToNullJSON encodes contact attributes for the attributes column.
+// Removed (nil) attributes are dropped and an empty map is stored as NULL.
+func contactAttributesToNullJSON(attributes domain.MapOfAny) (sql.NullString, error) {
+	values := make(map[string]interface{}, len(attributes))
+	for key, value := range attributes {
+		if value != nil {
+			values[key] = value
+		}
+	}
+	if len(values) == 0 {
+		return sql.NullString{Valid: false}, nil
+	}
+	jsonBytes, err := json.Marshal(values)
+	if err != nil {
+		return sql.NullString{Valid: false}, fmt.Errorf("failed to marshal attributes: %w", err)
+	}
+	return sql.NullString{String: string(jsonBytes), Valid: true}, nil
+}
+
 // BulkUpsertContacts creates or updates multiple contacts in a single database operation
 // It uses PostgreSQL's INSERT ... ON CONFLICT to efficiently handle both inserts and updates
 // Returns per-contact results indicating whether each was inserted (IsNew=true) or updated (IsNew=false)
@@ -1193,7 +1237,7 @@ func (r *contactRepository) BulkUpsertContacts(ctx context.Context, workspaceID
 	// Build the multi-row INSERT statement
 	// We'll use a raw SQL query because squirrel doesn't handle complex ON CONFLICT well
 	var queryBuilder strings.Builder
-	args := make([]interface{}, 0, len(contacts)*36) // 36 fields per contact (db_created_at and db_updated_at are managed by DB)
+	args := make([]interface{}, 0, len(contacts)*37) // 37 fields per contact (db_created_at and db_updated_at are managed by DB)
 	argIndex := 1
 
 	queryBuilder.WriteString(`INSERT INTO contacts (
@@ -1204,7 +1248,7 @@ func (r *contactRepository) BulkUpsertContacts(ctx context.Context, workspaceID
 		custom_number_1, custom_number_2, custom_number_3, custom_number_4, custom_number_5,
 		custom_datetime_1, custom_datetime_2, custom_datetime_3, custom_datetime_4, custom_datetime_5,
 		custom_json_1, custom_json_2, custom_json_3, custom_json_4, custom_json_5,
-		created_at, updated_at
+		created_at, updated_at, attributes
 	) VALUES `)
 
 	// Add value placeholders for each contact
@@ -1214,8 +1258,8 @@ func (r *contactRepository) BulkUpsertContacts(ctx context.Context, workspaceID
 		}
 		queryBuilder.WriteString("(")
 
-		// Add 36 placeholders for contact fields (excluding db_created_at and db_updated_at)
-		for j := 0; j < 36; j++ {
+		// Add 37 placeholders for contact fields (excluding db_created_at and db_updated_at)
+		for j := 0; j < 37; j++ {
 			if j > 0 {
 				queryBuilder.WriteString(", ")
 			}
@@ -1235,10 +1279,15 @@ func (r *contactRepository) BulkUpsertContacts(ctx context.Context, workspaceID
 			updatedAt = contact.UpdatedAt.UTC()
 		}
 
+		attributes, err := contactAttributesToNullJSON(contact.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
 		// Add all field values in the correct order
 		// Note: db_created_at and db_updated_at are NOT included - they have DEFAULT CURRENT_TIMESTAMP in the schema
 		args = append(args,
-			contact.Email,                        // 1
+			contact.Email,                               // 1
 			contactToNullString(contact.ExternalID),     // 2
 			contactToNullString(contact.Timezone),       // 3
 			contactToNullString(contact.Language),       // 4
@@ -1272,8 +1321,9 @@ func (r *contactRepository) BulkUpsertContacts(ctx context.Context, workspaceID
 			contactToNullJSON(contact.CustomJSON3),      // 32
 			contactToNullJSON(contact.CustomJSON4),      // 33
 			contactToNullJSON(contact.CustomJSON5),      // 34
-			createdAt,                            // 35 - application-level timestamp
-			updatedAt,                            // 36 - application-level timestamp
+			createdAt,                                   // 35 - application-level timestamp
+			updatedAt,                                   // 36 - application-level timestamp
+			attributes,                                  // 37
 		)
 	}
 
@@ -1315,6 +1365,7 @@ func (r *contactRepository) BulkUpsertContacts(ctx context.Context, workspaceID
 		custom_json_3 = CASE WHEN EXCLUDED.custom_json_3 IS NOT NULL THEN EXCLUDED.custom_json_3 ELSE contacts.custom_json_3 END,
 		custom_json_4 = CASE WHEN EXCLUDED.custom_json_4 IS NOT NULL THEN EXCLUDED.custom_json_4 ELSE contacts.custom_json_4 END,
 		custom_json_5 = CASE WHEN EXCLUDED.custom_json_5 IS NOT NULL THEN EXCLUDED.custom_json_5 ELSE contacts.custom_json_5 END,
+		attributes = CASE WHEN EXCLUDED.attributes IS NOT NULL THEN COALESCE(contacts.attributes, '{}'::jsonb) || EXCLUDED.attributes ELSE contacts.attributes END,
 		created_at = EXCLUDED.created_at,
 		updated_at = EXCLUDED.updated_at,
 		db_updated_at = NOW()
@@ -1351,6 +1402,9 @@ func (r *contactRepository) BulkUpsertContacts(ctx context.Context, workspaceID
 	return results, nil
 }
 
+// pausedContactFilter excludes the contacts who paused marketing emails from the notification center
+var pausedContactFilter = sq.Expr("NOT EXISTS (SELECT 1 FROM contact_lists cp WHERE cp.email = c.email AND cp.deleted_at IS NULL AND cp.paused_until > NOW())")
+
 // GetContactsForBroadcast retrieves contacts based on broadcast audience settings
 // It supports filtering by lists, handling unsubscribed contacts, and deduplication
 // Uses cursor-based pagination with afterEmail for deterministic ordering (fixes Issue #157)
@@ -1396,6 +1450,11 @@ func (r *contactRepository) GetContactsForBroadcast(
 			query = query.Where(sq.NotEq{"cl.status": domain.ContactListStatusUnsubscribed})
 			query = query.Where(sq.NotEq{"cl.status": domain.ContactListStatusBounced})
 			query = query.Where(sq.NotEq{"cl.status": domain.ContactListStatusComplained})
+			query = query.Where(pausedContactFilter)
+			// Contacts who chose the weekly digest only receive the broadcasts sent as a digest
+			if !audience.Digest {
+				query = query.Where(sq.NotEq{"cl.frequency": domain.ContactListFrequencyWeeklyDigest})
+			}
 		}
 
 		// Exclude contacts that have not completed double opt-in when the list requires it.
@@ -1443,6 +1502,10 @@ func (r *contactRepository) GetContactsForBroadcast(
 			if afterEmail != "" {
 				query = query.Where(sq.Gt{"c.email": afterEmail})
 			}
+
+			if audience.ExcludeUnsubscribed {
+				query = query.Where(pausedContactFilter)
+			}
 		}
 	}
 
@@ -1479,6 +1542,7 @@ func (r *contactRepository) GetContactsForBroadcast(
 			var customDatetime1, customDatetime2, customDatetime3, customDatetime4, customDatetime5 sql.NullTime
 			var customJSON1, customJSON2, customJSON3, customJSON4, customJSON5 sql.NullString
 			var createdAt, updatedAt, dbCreatedAt, dbUpdatedAt time.Time
+			var computedProperties, attributes, emailVerification []byte
 
 			// Scan all columns including contact fields + list_id + list_name
 			scanErr = rows.Scan(
@@ -1490,6 +1554,7 @@ func (r *contactRepository) GetContactsForBroadcast(
 				&customDatetime1, &customDatetime2, &customDatetime3, &customDatetime4, &customDatetime5,
 				&customJSON1, &customJSON2, &customJSON3, &customJSON4, &customJSON5,
 				&createdAt, &updatedAt, &dbCreatedAt, &dbUpdatedAt,
+				&computedProperties, &attributes, &emailVerification,
 				&listID, &listName, // Additional columns
 			)
 			if scanErr != nil {
@@ -1621,6 +1686,24 @@ func (r *contactRepository) GetContactsForBroadcast(
 					contact.CustomJSON5 = &domain.NullableJSON{Data: jsonData, IsNull: false}
 				}
 			}
+			if len(computedProperties) > 0 {
+				var props domain.MapOfAny
+				if err := json.Unmarshal(computedProperties, &props); err == nil && len(props) > 0 {
+					contact.ComputedProperties = props
+				}
+			}
+			if len(attributes) > 0 {
+				var attrs domain.MapOfAny
+				if err := json.Unmarshal(attributes, &attrs); err == nil && len(attrs) > 0 {
+					contact.Attributes = attrs
+				}
+			}
+			if len(emailVerification) > 0 {
+				var verification domain.EmailVerification
+				if err := json.Unmarshal(emailVerification, &verification); err == nil && verification.Status != "" {
+					contact.EmailVerification = &verification
+				}
+			}
 		} else {
 			// No list ID to scan, just get the contact using the existing ScanContact function
 			contact, scanErr = domain.ScanContact(rows)
@@ -1680,6 +1763,11 @@ func (r *contactRepository) CountContactsForBroadcast(
 			query = query.Where(sq.NotEq{"cl.status": domain.ContactListStatusUnsubscribed})
 			query = query.Where(sq.NotEq{"cl.status": domain.ContactListStatusBounced})
 			query = query.Where(sq.NotEq{"cl.status": domain.ContactListStatusComplained})
+			query = query.Where(pausedContactFilter)
+			// Contacts who chose the weekly digest only receive the broadcasts sent as a digest
+			if !audience.Digest {
+				query = query.Where(sq.NotEq{"cl.frequency": domain.ContactListFrequencyWeeklyDigest})
+			}
 		}
 
 		// Exclude contacts that have not completed double opt-in when the list requires it.
@@ -1706,6 +1794,10 @@ func (r *contactRepository) CountContactsForBroadcast(
 				From("contacts c").
 				Join("contact_segments cs ON c.email = cs.email").
 				Where(sq.Eq{"cs.segment_id": audience.Segments})
+
+			if audience.ExcludeUnsubscribed {
+				query = query.Where(pausedContactFilter)
+			}
 		}
 	}
 
@@ -1814,3 +1906,315 @@ UPDATE contact_lists
 
 	return nil
 }
+
+// UpdateComputedProperties recomputes the computed properties of the given contacts.
+// Empty results are stored as NULL and unchanged contacts are skipped, so the
+// track_contact_changes trigger only records real changes on the timeline.
+func (r *contactRepository) UpdateComputedProperties(ctx context.Context, workspaceID string, emails []string, propertiesSQL string, args []interface{}) (int, error) {
+	if len(emails) == 0 {
+		return 0, nil
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+UPDATE contacts
+   SET computed_properties = v.props,
+       updated_at = NOW()
+  FROM (
+       SELECT c.email, NULLIF(%s, '{}'::jsonb) AS props
+         FROM contacts c
+        WHERE c.email = ANY($1)
+       ) v
+ WHERE contacts.email = v.email
+   AND contacts.computed_properties IS DISTINCT FROM v.props`, propertiesSQL)
+
+	queryArgs := append([]interface{}{pq.Array(emails)}, args...)
+	result, err := workspaceDB.ExecContext(ctx, query, queryArgs...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update computed properties: %w", err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return int(updated), nil
+}
+
+// ClearComputedProperties removes all computed property values in a workspace
+func (r *contactRepository) ClearComputedProperties(ctx context.Context, workspaceID string) (int, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	result, err := workspaceDB.ExecContext(ctx, `
+UPDATE contacts
+   SET computed_properties = NULL,
+       updated_at = NOW()
+ WHERE computed_properties IS NOT NULL`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear computed properties: %w", err)
+	}
+
+	cleared, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return int(cleared), nil
+}
+
+// legacyAttributeValueSQL converts each kind of legacy custom_* column to the
+// JSONB representation of the matching attribute type
+var legacyAttributeValueSQL = map[string]string{
+	domain.ContactAttributeTypeString:   "to_jsonb(%s)",
+	domain.ContactAttributeTypeNumber:   "to_jsonb(%s::float8)",
+	domain.ContactAttributeTypeDatetime: `to_jsonb(to_char(%s AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'))`,
+	domain.ContactAttributeTypeJSON:     "%s",
+}
+
+// BackfillContactAttribute copies the legacy custom_* column of an attribute into
+// contacts.attributes, keeping attribute values already set
+func (r *contactRepository) BackfillContactAttribute(ctx context.Context, workspaceID string, attr domain.ContactAttribute) (int, error) {
+	// The legacy field is embedded in the query, make sure it is a valid custom_* column
+	if err := attr.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid contact attribute: %w", err)
+	}
+	if attr.LegacyField == "" {
+		return 0, fmt.Errorf("contact attribute %s is not mapped to a legacy field", attr.Key)
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	valueSQL := fmt.Sprintf(legacyAttributeValueSQL[attr.Type], attr.LegacyField)
+	query := fmt.Sprintf(`
+UPDATE contacts
+   SET attributes = COALESCE(attributes, '{}'::jsonb) || jsonb_build_object($1::text, %s)
+ WHERE %s IS NOT NULL
+   AND NOT COALESCE(attributes, '{}'::jsonb) ? $1`, valueSQL, attr.LegacyField)
+
+	result, err := workspaceDB.ExecContext(ctx, query, attr.Key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to backfill contact attribute %s: %w", attr.Key, err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return int(updated), nil
+}
+
+// SyncContactAttributeIndexes reconciles the attribute expression indexes with the
+// filterable attributes. Indexes are built concurrently so contacts stay writable;
+// indexes left invalid by a failed build are dropped and built again.
+func (r *contactRepository) SyncContactAttributeIndexes(ctx context.Context, workspaceID string, attrs []domain.ContactAttribute) error {
+	if err := domain.ValidateContactAttributes(attrs); err != nil {
+		return fmt.Errorf("invalid contact attributes: %w", err)
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	// pg_indexes also lists the INVALID leftovers of failed concurrent builds,
+	// pg_index tells them apart so they are dropped and rebuilt
+	rows, err := workspaceDB.QueryContext(ctx, `
+SELECT ic.relname, i.indisvalid
+  FROM pg_index i
+  JOIN pg_class ic ON ic.oid = i.indexrelid
+  JOIN pg_class tc ON tc.oid = i.indrelid
+  JOIN pg_namespace n ON n.oid = tc.relnamespace
+ WHERE n.nspname = current_schema()
+   AND tc.relname = 'contacts'
+   AND starts_with(ic.relname, $1)`, domain.ContactAttributeIndexPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to list contact attribute indexes: %w", err)
+	}
+	existing := make(map[string]bool)
+	invalid := make([]string, 0)
+	for rows.Next() {
+		var name string
+		var valid bool
+		if err := rows.Scan(&name, &valid); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan index name: %w", err)
+		}
+		if valid {
+			existing[name] = true
+		} else {
+			invalid = append(invalid, name)
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to list contact attribute indexes: %w", err)
+	}
+
+	sort.Strings(invalid)
+	for _, name := range invalid {
+		if _, err := workspaceDB.ExecContext(ctx, fmt.Sprintf("DROP INDEX CONCURRENTLY IF EXISTS %s", pq.QuoteIdentifier(name))); err != nil {
+			return fmt.Errorf("failed to drop invalid contact attribute index %s: %w", name, err)
+		}
+	}
+
+	wanted := make(map[string]bool)
+	for i := range attrs {
+		if !attrs[i].Filterable {
+			continue
+		}
+		name := attrs[i].IndexName()
+		wanted[name] = true
+		if existing[name] {
+			continue
+		}
+		query := fmt.Sprintf("CREATE INDEX CONCURRENTLY IF NOT EXISTS %s ON contacts (%s)", pq.QuoteIdentifier(name), attrs[i].IndexSQL())
+		if _, err := workspaceDB.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to create index for contact attribute %s: %w", attrs[i].Key, err)
+		}
+	}
+
+	stale := make([]string, 0, len(existing))
+	for name := range existing {
+		if !wanted[name] {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	for _, name := range stale {
+		if _, err := workspaceDB.ExecContext(ctx, fmt.Sprintf("DROP INDEX CONCURRENTLY IF EXISTS %s", pq.QuoteIdentifier(name))); err != nil {
+			return fmt.Errorf("failed to drop contact attribute index %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// attachLatestConsent sets the latest consent ledger entry on each contact list of the given contacts
+func (r *contactRepository) attachLatestConsent(ctx context.Context, db *sql.DB, emails []string, contactMap map[string]*domain.Contact) error {
+	query := `
+		SELECT DISTINCT ON (email, list_id)
+			id, email, list_id, action, source, source_id, ip_address, user_agent, page_url, consent_text_version, message_id, created_at
+		FROM consent_records
+		WHERE email = ANY($1)
+		ORDER BY email, list_id, created_at DESC, id DESC
+	`
+
+	rows, err := db.QueryContext(ctx, query, pq.Array(emails))
+	if err != nil {
+		return fmt.Errorf("failed to query consent records: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		record, err := scanConsentRecord(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan consent record: %w", err)
+		}
+		contact, ok := contactMap[record.Email]
+		if !ok {
+			continue
+		}
+		for _, list := range contact.ContactLists {
+			if list.ListID == record.ListID {
+				list.Consent = record
+			}
+		}
+	}
+
+	return rows.Err()
+}
+
+// UpdateEmailVerifications stores email verification results in a single statement.
+// The column is not tracked by track_contact_changes, so no timeline entry is written.
+func (r *contactRepository) UpdateEmailVerifications(ctx context.Context, workspaceID string, verifications map[string]*domain.EmailVerification) error {
+	if len(verifications) == 0 {
+		return nil
+	}
+
+	emails := make([]string, 0, len(verifications))
+	values := make([]string, 0, len(verifications))
+	for email, verification := range verifications {
+		if verification == nil {
+			continue
+		}
+		data, err := json.Marshal(verification)
+		if err != nil {
+			return fmt.Errorf("failed to marshal email verification: %w", err)
+		}
+		emails = append(emails, email)
+		values = append(values, string(data))
+	}
+	if len(emails) == 0 {
+		return nil
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	_, err = workspaceDB.ExecContext(ctx, `
+UPDATE contacts
+   SET email_verification = v.verification::jsonb
+  FROM unnest($1::text[], $2::text[]) AS v(email, verification)
+ WHERE contacts.email = v.email`, pq.Array(emails), pq.Array(values))
+	if err != nil {
+		return fmt.Errorf("failed to update email verifications: %w", err)
+	}
+
+	return nil
+}
+
+// GetContactsByEmails fetches the contacts matching a batch of emails
+func (r *contactRepository) GetContactsByEmails(ctx context.Context, workspaceID string, emails []string) ([]*domain.Contact, error) {
+	if len(emails) == 0 {
+		return []*domain.Contact{}, nil
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query, args, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select(contactColumns...).
+		From("contacts").
+		Where("email = ANY(?)", pq.Array(emails)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := workspaceDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contacts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	contacts := []*domain.Contact{}
+	for rows.Next() {
+		contact, err := domain.ScanContact(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan contact: %w", err)
+		}
+		contacts = append(contacts, contact)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating contact rows: %w", err)
+	}
+
+	return contacts, nil
+}
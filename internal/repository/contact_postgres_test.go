@@ -14,6 +14,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -23,7 +24,7 @@ import (
 
 // contactColumnsPattern is the regex pattern for matching explicit contact columns in queries.
 // This matches the contactColumnsWithPrefix("c") output in contact_postgres.go.
-const contactColumnsPattern = `c\.email, c\.external_id, c\.timezone, c\.language, c\.first_name, c\.last_name, c\.full_name, c\.phone, c\.address_line_1, c\.address_line_2, c\.country, c\.postcode, c\.state, c\.job_title, c\.custom_string_1, c\.custom_string_2, c\.custom_string_3, c\.custom_string_4, c\.custom_string_5, c\.custom_number_1, c\.custom_number_2, c\.custom_number_3, c\.custom_number_4, c\.custom_number_5, c\.custom_datetime_1, c\.custom_datetime_2, c\.custom_datetime_3, c\.custom_datetime_4, c\.custom_datetime_5, c\.custom_json_1, c\.custom_json_2, c\.custom_json_3, c\.custom_json_4, c\.custom_json_5, c\.created_at, c\.updated_at, c\.db_created_at, c\.db_updated_at`
+const contactColumnsPattern = `c\.email, c\.external_id, c\.timezone, c\.language, c\.first_name, c\.last_name, c\.full_name, c\.phone, c\.address_line_1, c\.address_line_2, c\.country, c\.postcode, c\.state, c\.job_title, c\.custom_string_1, c\.custom_string_2, c\.custom_string_3, c\.custom_string_4, c\.custom_string_5, c\.custom_number_1, c\.custom_number_2, c\.custom_number_3, c\.custom_number_4, c\.custom_number_5, c\.custom_datetime_1, c\.custom_datetime_2, c\.custom_datetime_3, c\.custom_datetime_4, c\.custom_datetime_5, c\.custom_json_1, c\.custom_json_2, c\.custom_json_3, c\.custom_json_4, c\.custom_json_5, c\.created_at, c\.updated_at, c\.db_created_at, c\.db_updated_at, c\.computed_properties, c\.attributes, c\.email_verification`
 
 // setupMockDB creates a mock database and sqlmock for testing
 func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, func()) {
@@ -60,7 +61,7 @@ func TestGetContactByEmail(t *testing.T) {
 		"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 		"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 		"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-		"created_at", "updated_at", "db_created_at", "db_updated_at",
+		"created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 	}).
 		AddRow(
 			email, "ext123", "Europe/Paris", "en-US",
@@ -70,7 +71,7 @@ func TestGetContactByEmail(t *testing.T) {
 			42.0, 43.0, 44.0, 45.0, 46.0,
 			now, now, now, now, now,
 			[]byte(`{"key": "value1"}`), []byte(`{"key": "value2"}`), []byte(`{"key": "value3"}`), []byte(`{"key": "value4"}`), []byte(`{"key": "value5"}`),
-			now, now, now, now,
+			now, now, now, now, nil, nil, nil,
 		)
 
 	mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE c.email = \$1`).
@@ -79,12 +80,12 @@ func TestGetContactByEmail(t *testing.T) {
 
 	// Set up expectations for contact lists query
 	listRows := sqlmock.NewRows([]string{
-		"list_id", "status", "created_at", "updated_at", "deleted_at", "list_name",
+		"list_id", "status", "created_at", "updated_at", "deleted_at", "frequency", "paused_until", "list_name",
 	}).AddRow(
-		"list1", "active", now, now, nil, "Marketing List",
+		"list1", "active", now, now, nil, "all", nil, "Marketing List",
 	)
 
-	mock.ExpectQuery(`SELECT cl\.list_id, cl\.status, cl\.created_at, cl\.updated_at, cl\.deleted_at, l\.name as list_name FROM contact_lists cl JOIN lists l ON cl\.list_id = l\.id WHERE cl\.email = \$1 AND l\.deleted_at IS NULL`).
+	mock.ExpectQuery(`SELECT cl\.list_id, cl\.status, cl\.created_at, cl\.updated_at, cl\.deleted_at, cl\.frequency, cl\.paused_until, l\.name as list_name FROM contact_lists cl JOIN lists l ON cl\.list_id = l\.id WHERE cl\.email = \$1 AND l\.deleted_at IS NULL`).
 		WithArgs(email).
 		WillReturnRows(listRows)
 
@@ -143,7 +144,7 @@ func TestGetContactByExternalID(t *testing.T) {
 		"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 		"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 		"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-		"created_at", "updated_at", "db_created_at", "db_updated_at",
+		"created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 	}).
 		AddRow(
 			email, externalID, "Europe/Paris", "en-US",
@@ -153,7 +154,7 @@ func TestGetContactByExternalID(t *testing.T) {
 			42.0, 43.0, 44.0, 45.0, 46.0,
 			now, now, now, now, now,
 			[]byte(`{"key": "value1"}`), []byte(`{"key": "value2"}`), []byte(`{"key": "value3"}`), []byte(`{"key": "value4"}`), []byte(`{"key": "value5"}`),
-			now, now, now, now,
+			now, now, now, now, nil, nil, nil,
 		)
 
 	mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE c.external_id = \$1`).
@@ -162,12 +163,12 @@ func TestGetContactByExternalID(t *testing.T) {
 
 	// Set up expectations for contact lists query
 	listRows := sqlmock.NewRows([]string{
-		"list_id", "status", "created_at", "updated_at", "deleted_at", "list_name",
+		"list_id", "status", "created_at", "updated_at", "deleted_at", "frequency", "paused_until", "list_name",
 	}).AddRow(
-		"list1", "active", now, now, nil, "Marketing List",
+		"list1", "active", now, now, nil, "all", nil, "Marketing List",
 	)
 
-	mock.ExpectQuery(`SELECT cl\.list_id, cl\.status, cl\.created_at, cl\.updated_at, cl\.deleted_at, l\.name as list_name FROM contact_lists cl JOIN lists l ON cl\.list_id = l\.id WHERE cl\.email = \$1 AND l\.deleted_at IS NULL`).
+	mock.ExpectQuery(`SELECT cl\.list_id, cl\.status, cl\.created_at, cl\.updated_at, cl\.deleted_at, cl\.frequency, cl\.paused_until, l\.name as list_name FROM contact_lists cl JOIN lists l ON cl\.list_id = l\.id WHERE cl\.email = \$1 AND l\.deleted_at IS NULL`).
 		WithArgs(email).
 		WillReturnRows(listRows)
 
@@ -215,12 +216,12 @@ func TestGetContactByExternalID(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 		}).AddRow(
 			email, "e-123", "Europe/Paris", "en-US", "John", "Doe", "John Doe", "", "", "", "", "", "", "",
 			"", "", "", "", "", 0, 0, 0, 0, 0, time.Time{}, time.Time{}, time.Time{}, time.Time{}, time.Time{},
 			[]byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil, nil, nil,
 		)
 
 		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE c.external_id = \$1`).
@@ -229,10 +230,10 @@ func TestGetContactByExternalID(t *testing.T) {
 
 		// Set up expectations for contact lists query (empty result)
 		listRows := sqlmock.NewRows([]string{
-			"list_id", "status", "created_at", "updated_at", "deleted_at", "list_name",
+			"list_id", "status", "created_at", "updated_at", "deleted_at", "frequency", "paused_until", "list_name",
 		})
 
-		mock.ExpectQuery(`SELECT cl\.list_id, cl\.status, cl\.created_at, cl\.updated_at, cl\.deleted_at, l\.name as list_name FROM contact_lists cl JOIN lists l ON cl\.list_id = l\.id WHERE cl\.email = \$1`).
+		mock.ExpectQuery(`SELECT cl\.list_id, cl\.status, cl\.created_at, cl\.updated_at, cl\.deleted_at, cl\.frequency, cl\.paused_until, l\.name as list_name FROM contact_lists cl JOIN lists l ON cl\.list_id = l\.id WHERE cl\.email = \$1`).
 			WithArgs(email).
 			WillReturnRows(listRows)
 
@@ -284,7 +285,7 @@ func TestFetchContact(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 		}).
 			AddRow(
 				email, "ext123", "Europe/Paris", "en-US",
@@ -294,7 +295,7 @@ func TestFetchContact(t *testing.T) {
 				42.0, 43.0, 44.0, 45.0, 46.0,
 				now, now, now, now, now,
 				[]byte(`{"key": "value1"}`), []byte(`{"key": "value2"}`), []byte(`{"key": "value3"}`), []byte(`{"key": "value4"}`), []byte(`{"key": "value5"}`),
-				now, now, now, now,
+				now, now, now, now, nil, nil, nil,
 			)
 
 		phone := "+1234567890"
@@ -304,14 +305,14 @@ func TestFetchContact(t *testing.T) {
 
 		// Set up expectations for contact lists query
 		listRows := sqlmock.NewRows([]string{
-			"list_id", "status", "created_at", "updated_at", "deleted_at", "list_name",
+			"list_id", "status", "created_at", "updated_at", "deleted_at", "frequency", "paused_until", "list_name",
 		}).AddRow(
-			"list1", "active", now, now, nil, "Marketing List",
+			"list1", "active", now, now, nil, "all", nil, "Marketing List",
 		).AddRow(
-			"list2", "active", now, now, nil, "Newsletter",
+			"list2", "active", now, now, nil, "all", nil, "Newsletter",
 		)
 
-		mock.ExpectQuery(`SELECT cl\.list_id, cl\.status, cl\.created_at, cl\.updated_at, cl\.deleted_at, l\.name as list_name FROM contact_lists cl JOIN lists l ON cl\.list_id = l\.id WHERE cl\.email = \$1`).
+		mock.ExpectQuery(`SELECT cl\.list_id, cl\.status, cl\.created_at, cl\.updated_at, cl\.deleted_at, cl\.frequency, cl\.paused_until, l\.name as list_name FROM contact_lists cl JOIN lists l ON cl\.list_id = l\.id WHERE cl\.email = \$1`).
 			WithArgs(email).
 			WillReturnRows(listRows)
 
@@ -352,7 +353,7 @@ func TestFetchContact(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 		}).
 			AddRow(
 				email, "ext123", "Europe/Paris", "en-US",
@@ -362,7 +363,7 @@ func TestFetchContact(t *testing.T) {
 				42.0, 43.0, 44.0, 45.0, 46.0,
 				now, now, now, now, now,
 				[]byte(`{"key": "value1"}`), []byte(`{"key": "value2"}`), []byte(`{"key": "value3"}`), []byte(`{"key": "value4"}`), []byte(`{"key": "value5"}`),
-				now, now, now, now,
+				now, now, now, now, nil, nil, nil,
 			)
 
 		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE c.email = \$1`).
@@ -370,7 +371,7 @@ func TestFetchContact(t *testing.T) {
 			WillReturnRows(rows)
 
 		// Set up expectations for contact lists query with error
-		mock.ExpectQuery(`SELECT cl\.list_id, cl\.status, cl\.created_at, cl\.updated_at, cl\.deleted_at, l\.name as list_name FROM contact_lists cl JOIN lists l ON cl\.list_id = l\.id WHERE cl\.email = \$1`).
+		mock.ExpectQuery(`SELECT cl\.list_id, cl\.status, cl\.created_at, cl\.updated_at, cl\.deleted_at, cl\.frequency, cl\.paused_until, l\.name as list_name FROM contact_lists cl JOIN lists l ON cl\.list_id = l\.id WHERE cl\.email = \$1`).
 			WithArgs(email).
 			WillReturnError(errors.New("database error"))
 
@@ -405,7 +406,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -415,7 +416,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil, nil, nil,
 		)
 
 		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c ORDER BY c\.created_at DESC, c\.email ASC LIMIT 11`).
@@ -486,7 +487,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -496,7 +497,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil, nil, nil,
 		)
 
 		mock.ExpectQuery(`SELECT `+contactColumnsPattern+` FROM contacts c WHERE c\.email ILIKE \$1 AND c\.first_name ILIKE \$2 AND c\.country ILIKE \$3 ORDER BY c\.created_at DESC, c\.email ASC LIMIT 11`).
@@ -568,7 +569,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 		})
 
 		// Add multiple contacts to ensure pagination works
@@ -583,7 +584,7 @@ func TestGetContacts(t *testing.T) {
 				now, now, now, now, now,
 				[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 				[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-				now.Add(time.Duration(-i)*time.Hour), now, now.Add(time.Duration(-i)*time.Hour), now, // Use decreasing created_at times
+				now.Add(time.Duration(-i)*time.Hour), now, now.Add(time.Duration(-i)*time.Hour), now, nil, nil, nil, // Use decreasing created_at times
 			)
 		}
 
@@ -814,7 +815,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -824,7 +825,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil, nil, nil,
 		)
 
 		mock.ExpectQuery(`SELECT `+contactColumnsPattern+` FROM contacts c WHERE c\.email ILIKE \$1 AND c\.external_id ILIKE \$2 AND c\.first_name ILIKE \$3 AND c\.last_name ILIKE \$4 AND c\.phone ILIKE \$5 AND c\.country ILIKE \$6 ORDER BY c\.created_at DESC, c\.email ASC LIMIT 11`).
@@ -928,7 +929,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -938,7 +939,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil, nil, nil,
 		)
 
 		// Match the query using a regex pattern that includes the EXISTS subquery
@@ -1008,7 +1009,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -1018,7 +1019,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil, nil, nil,
 		)
 
 		// Match the query using a regex pattern that includes the EXISTS subquery
@@ -1088,7 +1089,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -1098,7 +1099,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil, nil, nil,
 		)
 
 		// Match the query using a regex pattern that includes the EXISTS subquery with both list_id and status filters
@@ -1167,7 +1168,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -1177,7 +1178,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil, nil, nil,
 		)
 
 		// Match the query using a regex pattern that includes the EXISTS subquery for segments
@@ -1257,7 +1258,7 @@ func TestGetContacts(t *testing.T) {
 			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
 			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 		}).AddRow(
 			"test@example.com", "ext123", "UTC", "en", "John", "Doe", "John Doe",
 			"+1234567890", "123 Main St", "Apt 4B", "US", "12345", "CA",
@@ -1267,7 +1268,7 @@ func TestGetContacts(t *testing.T) {
 			time.Now(), time.Now(), time.Now(), time.Now(), time.Now(),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
 			[]byte(`{"key": "value"}`), []byte(`{"key": "value"}`),
-			time.Now(), time.Now(), time.Now(), time.Now(),
+			time.Now(), time.Now(), time.Now(), time.Now(), nil, nil, nil,
 		)
 
 		// Match the query using a regex pattern that includes the EXISTS subquery for a single segment
@@ -1343,7 +1344,7 @@ func TestGetContactsForBroadcast(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 			"list_id", "list_name", // Additional columns for list filtering (makes it 42 total)
 		}).
 			AddRow(
@@ -1354,7 +1355,7 @@ func TestGetContactsForBroadcast(t *testing.T) {
 				42.0, 43.0, 44.0, 45.0, 46.0,
 				now, now, now, now, now,
 				[]byte(`{"key": "value1"}`), []byte(`{"key": "value2"}`), []byte(`{"key": "value3"}`), []byte(`{"key": "value4"}`), []byte(`{"key": "value5"}`),
-				now, now, now, now,
+				now, now, now, now, nil, nil, nil,
 				"list1", "Marketing List", // Additional values for list filtering
 			).
 			AddRow(
@@ -1365,16 +1366,17 @@ func TestGetContactsForBroadcast(t *testing.T) {
 				52.0, 53.0, 54.0, 55.0, 56.0,
 				now, now, now, now, now,
 				[]byte(`{"key": "value1-2"}`), []byte(`{"key": "value2-2"}`), []byte(`{"key": "value3-2"}`), []byte(`{"key": "value4-2"}`), []byte(`{"key": "value5-2"}`),
-				now, now, now, now,
+				now, now, now, now, nil, nil, nil,
 				"list1", "Marketing List", // Additional values for list filtering - same list
 			)
 
 			// Expect query with JOINS for list filtering, excludeUnsubscribed, and double opt-in guard (cursor-based pagination)
-		mock.ExpectQuery(`SELECT `+contactColumnsPattern+`, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND \(l\.is_double_optin = \$5 OR cl\.status <> \$6\) ORDER BY c\.email ASC LIMIT 10`).
+		mock.ExpectQuery(`SELECT `+contactColumnsPattern+`, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND NOT EXISTS \(SELECT 1 FROM contact_lists cp WHERE cp\.email = c\.email AND cp\.deleted_at IS NULL AND cp\.paused_until > NOW\(\)\) AND cl\.frequency <> \$5 AND \(l\.is_double_optin = \$6 OR cl\.status <> \$7\) ORDER BY c\.email ASC LIMIT 10`).
 			WithArgs("list1",
 				domain.ContactListStatusUnsubscribed,
 				domain.ContactListStatusBounced,
 				domain.ContactListStatusComplained,
+				domain.ContactListFrequencyWeeklyDigest,
 				false,
 				domain.ContactListStatusPending).
 			WillReturnRows(rows)
@@ -1427,7 +1429,7 @@ func TestGetContactsForBroadcast(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 		}).
 			AddRow(
 				"test1@example.com", "ext123", "Europe/Paris", "en-US",
@@ -1437,7 +1439,7 @@ func TestGetContactsForBroadcast(t *testing.T) {
 				42.0, 43.0, 44.0, 45.0, 46.0,
 				now, now, now, now, now,
 				[]byte(`{"key": "value1"}`), []byte(`{"key": "value2"}`), []byte(`{"key": "value3"}`), []byte(`{"key": "value4"}`), []byte(`{"key": "value5"}`),
-				now, now, now, now,
+				now, now, now, now, nil, nil, nil,
 			).
 			AddRow(
 				"test2@example.com", "ext456", "America/New_York", "en-US",
@@ -1447,7 +1449,7 @@ func TestGetContactsForBroadcast(t *testing.T) {
 				52.0, 53.0, 54.0, 55.0, 56.0,
 				now, now, now, now, now,
 				[]byte(`{"key": "value1-2"}`), []byte(`{"key": "value2-2"}`), []byte(`{"key": "value3-2"}`), []byte(`{"key": "value4-2"}`), []byte(`{"key": "value5-2"}`),
-				now, now, now, now,
+				now, now, now, now, nil, nil, nil,
 			)
 
 		// Expect query without JOINS for all contacts (cursor-based pagination)
@@ -1519,11 +1521,12 @@ func TestGetContactsForBroadcast(t *testing.T) {
 		}
 
 		// Expect query with error (cursor-based pagination, includes double opt-in guard)
-		mock.ExpectQuery(`SELECT `+contactColumnsPattern+`, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND \(l\.is_double_optin = \$5 OR cl\.status <> \$6\) ORDER BY c\.email ASC LIMIT 10`).
+		mock.ExpectQuery(`SELECT `+contactColumnsPattern+`, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND NOT EXISTS \(SELECT 1 FROM contact_lists cp WHERE cp\.email = c\.email AND cp\.deleted_at IS NULL AND cp\.paused_until > NOW\(\)\) AND cl\.frequency <> \$5 AND \(l\.is_double_optin = \$6 OR cl\.status <> \$7\) ORDER BY c\.email ASC LIMIT 10`).
 			WithArgs("list1",
 				domain.ContactListStatusUnsubscribed,
 				domain.ContactListStatusBounced,
 				domain.ContactListStatusComplained,
+				domain.ContactListFrequencyWeeklyDigest,
 				false,
 				domain.ContactListStatusPending).
 			WillReturnError(fmt.Errorf("database error"))
@@ -1568,14 +1571,14 @@ func TestGetContactsForBroadcast(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
-			"created_at", "updated_at", "db_created_at", "db_updated_at",
+			"created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 		}).
 			AddRow("test1@example.com", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
-				nil, nil, nil, nil, nil, createdAt1, createdAt1, createdAt1, createdAt1).
+				nil, nil, nil, nil, nil, createdAt1, createdAt1, createdAt1, createdAt1, nil, nil, nil).
 			AddRow("test2@example.com", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
-				nil, nil, nil, nil, nil, createdAt2, createdAt2, createdAt2, createdAt2)
+				nil, nil, nil, nil, nil, createdAt2, createdAt2, createdAt2, createdAt2, nil, nil, nil)
 
 		// Expect the query to join contacts with contact_segments (cursor-based pagination)
 		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c JOIN contact_segments cs ON c\.email = cs\.email WHERE cs\.segment_id IN \(\$1\) ORDER BY c\.email ASC LIMIT 10`).
@@ -1624,7 +1627,7 @@ func TestGetContactsForBroadcast(t *testing.T) {
 			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
 			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
 			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
-			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
 			"list_id", "list_name",
 		}).AddRow(
 			"confirmed@example.com", nil, nil, nil,
@@ -1634,7 +1637,7 @@ func TestGetContactsForBroadcast(t *testing.T) {
 			nil, nil, nil, nil, nil,
 			nil, nil, nil, nil, nil,
 			nil, nil, nil, nil,
-			nil, now, now, now, now,
+			nil, now, now, now, now, nil, nil, nil,
 			"list-doi", "Double Opt-In List",
 		)
 
@@ -1681,11 +1684,12 @@ func TestCountContactsForBroadcast(t *testing.T) {
 
 		// Expect query with JOINS for list filtering, soft-deleted lists filtering, excludeUnsubscribed,
 		// and the double opt-in guard (l.is_double_optin = $5 OR cl.status <> $6).
-		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND \(l\.is_double_optin = \$5 OR cl\.status <> \$6\)`).
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND NOT EXISTS \(SELECT 1 FROM contact_lists cp WHERE cp\.email = c\.email AND cp\.deleted_at IS NULL AND cp\.paused_until > NOW\(\)\) AND cl\.frequency <> \$5 AND \(l\.is_double_optin = \$6 OR cl\.status <> \$7\)`).
 			WithArgs("list1",
 				domain.ContactListStatusUnsubscribed,
 				domain.ContactListStatusBounced,
 				domain.ContactListStatusComplained,
+				domain.ContactListFrequencyWeeklyDigest,
 				false,
 				domain.ContactListStatusPending).
 			WillReturnRows(rows)
@@ -1832,6 +1836,66 @@ func TestCountContactsForBroadcast(t *testing.T) {
 		assert.Equal(t, 42, count)
 	})
 
+	t.Run("should exclude paused contacts from segment audiences", func(t *testing.T) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		audience := domain.AudienceSettings{
+			Segments:            []string{"segment1"},
+			ExcludeUnsubscribed: true,
+		}
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_segments cs ON c\.email = cs\.email WHERE cs\.segment_id IN \(\$1\) AND NOT EXISTS \(SELECT 1 FROM contact_lists cp WHERE cp\.email = c\.email AND cp\.deleted_at IS NULL AND cp\.paused_until > NOW\(\)\)`).
+			WithArgs("segment1").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+		count, err := repo.CountContactsForBroadcast(context.Background(), "workspace123", audience)
+
+		require.NoError(t, err)
+		assert.Equal(t, 7, count)
+	})
+
+	t.Run("should include weekly digest contacts in digest broadcasts", func(t *testing.T) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		audience := domain.AudienceSettings{
+			List:                "list1",
+			ExcludeUnsubscribed: true,
+			Digest:              true,
+		}
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND NOT EXISTS \(SELECT 1 FROM contact_lists cp WHERE cp\.email = c\.email AND cp\.deleted_at IS NULL AND cp\.paused_until > NOW\(\)\) AND \(l\.is_double_optin = \$5 OR cl\.status <> \$6\)`).
+			WithArgs("list1",
+				domain.ContactListStatusUnsubscribed,
+				domain.ContactListStatusBounced,
+				domain.ContactListStatusComplained,
+				false,
+				domain.ContactListStatusPending).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+
+		count, err := repo.CountContactsForBroadcast(context.Background(), "workspace123", audience)
+
+		require.NoError(t, err)
+		assert.Equal(t, 12, count)
+	})
+
 	t.Run("should count contacts for broadcast with both lists and segments", func(t *testing.T) {
 		// Create a mock workspace database
 		mockDB, mock, cleanup := setupMockDB(t)
@@ -1858,11 +1922,12 @@ func TestCountContactsForBroadcast(t *testing.T) {
 
 		// Expect query with JOINs for both list, lists table (for soft-delete filter), segment filtering,
 		// and the double opt-in guard (l.is_double_optin = $5 OR cl.status <> $6).
-		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id JOIN contact_segments cs ON c\.email = cs\.email WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND \(l\.is_double_optin = \$5 OR cl\.status <> \$6\) AND cs\.segment_id IN \(\$7\)`).
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id JOIN contact_segments cs ON c\.email = cs\.email WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND NOT EXISTS \(SELECT 1 FROM contact_lists cp WHERE cp\.email = c\.email AND cp\.deleted_at IS NULL AND cp\.paused_until > NOW\(\)\) AND cl\.frequency <> \$5 AND \(l\.is_double_optin = \$6 OR cl\.status <> \$7\) AND cs\.segment_id IN \(\$8\)`).
 			WithArgs("list1",
 				domain.ContactListStatusUnsubscribed,
 				domain.ContactListStatusBounced,
 				domain.ContactListStatusComplained,
+				domain.ContactListFrequencyWeeklyDigest,
 				false,
 				domain.ContactListStatusPending,
 				"segment1").
@@ -2537,3 +2602,387 @@ func TestMarkEmailsAsBounced_ExecError(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to mark emails as bounced")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestUpdateComputedProperties(t *testing.T) {
+	t.Run("updates changed contacts", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws-123").Return(db, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		mock.ExpectExec(`UPDATE contacts\s+SET computed_properties = v\.props.*NULLIF\(jsonb_build_object\('orders', \$2\), '\{\}'::jsonb\).*IS DISTINCT FROM v\.props`).
+			WithArgs(sqlmock.AnyArg(), "order").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		updated, err := repo.UpdateComputedProperties(context.Background(), "ws-123", []string{"a@example.com", "b@example.com"}, "jsonb_build_object('orders', $2)", []interface{}{"order"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, updated)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("empty emails short circuits", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := NewContactRepository(mocks.NewMockWorkspaceRepository(ctrl))
+		updated, err := repo.UpdateComputedProperties(context.Background(), "ws-123", nil, "jsonb_build_object()", nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, updated)
+	})
+
+	t.Run("exec error", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws-123").Return(db, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		mock.ExpectExec(`UPDATE contacts`).WillReturnError(errors.New("db down"))
+
+		_, err := repo.UpdateComputedProperties(context.Background(), "ws-123", []string{"a@example.com"}, "jsonb_build_object()", nil)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update computed properties")
+	})
+}
+
+func TestClearComputedProperties(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	workspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws-123").Return(db, nil)
+
+	repo := NewContactRepository(workspaceRepo)
+
+	mock.ExpectExec(`SET computed_properties = NULL.*WHERE computed_properties IS NOT NULL`).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+
+	cleared, err := repo.ClearComputedProperties(context.Background(), "ws-123")
+	require.NoError(t, err)
+	assert.Equal(t, 5, cleared)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBackfillContactAttribute(t *testing.T) {
+	t.Run("copies legacy datetime values", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws-123").Return(db, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		mock.ExpectExec(`UPDATE contacts\s+SET attributes = COALESCE\(attributes, '\{\}'::jsonb\) \|\| jsonb_build_object\(\$1::text, to_jsonb\(to_char\(custom_datetime_2 AT TIME ZONE 'UTC'.*WHERE custom_datetime_2 IS NOT NULL\s+AND NOT COALESCE\(attributes, '\{\}'::jsonb\) \? \$1`).
+			WithArgs("trial_ends_at").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		attr := domain.ContactAttribute{Key: "trial_ends_at", Type: domain.ContactAttributeTypeDatetime, LegacyField: "custom_datetime_2"}
+		updated, err := repo.BackfillContactAttribute(context.Background(), "ws-123", attr)
+		require.NoError(t, err)
+		assert.Equal(t, 3, updated)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rejects unmapped attribute", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := NewContactRepository(mocks.NewMockWorkspaceRepository(ctrl))
+		_, err := repo.BackfillContactAttribute(context.Background(), "ws-123", domain.ContactAttribute{Key: "plan", Type: domain.ContactAttributeTypeString})
+		assert.ErrorContains(t, err, "is not mapped to a legacy field")
+	})
+
+	t.Run("rejects invalid legacy field", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := NewContactRepository(mocks.NewMockWorkspaceRepository(ctrl))
+		attr := domain.ContactAttribute{Key: "plan", Type: domain.ContactAttributeTypeString, LegacyField: "email; DROP TABLE contacts"}
+		_, err := repo.BackfillContactAttribute(context.Background(), "ws-123", attr)
+		assert.ErrorContains(t, err, "invalid contact attribute")
+	})
+}
+
+func TestSyncContactAttributeIndexes(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	workspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws-123").Return(db, nil)
+
+	repo := NewContactRepository(workspaceRepo)
+
+	mock.ExpectQuery(`SELECT ic\.relname, i\.indisvalid\s+FROM pg_index i`).
+		WithArgs(domain.ContactAttributeIndexPrefix).
+		WillReturnRows(sqlmock.NewRows([]string{"relname", "indisvalid"}).
+			AddRow("idx_attr_s_plan", true).
+			AddRow("idx_attr_s_seats", true).
+			AddRow("idx_attr_s_old", true))
+	mock.ExpectExec(`CREATE INDEX CONCURRENTLY IF NOT EXISTS "idx_attr_n_seats" ON contacts \(\(CASE WHEN jsonb_typeof\(attributes->'seats'\) = 'number' THEN \(attributes->>'seats'\)::numeric END\)\)`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DROP INDEX CONCURRENTLY IF EXISTS "idx_attr_s_old"`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DROP INDEX CONCURRENTLY IF EXISTS "idx_attr_s_seats"`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.SyncContactAttributeIndexes(context.Background(), "ws-123", []domain.ContactAttribute{
+		{Key: "plan", Type: domain.ContactAttributeTypeString, Filterable: true},
+		{Key: "seats", Type: domain.ContactAttributeTypeNumber, Filterable: true},
+		{Key: "notes", Type: domain.ContactAttributeTypeString},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSyncContactAttributeIndexes_RebuildsInvalidIndex(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	workspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws-123").Return(db, nil)
+
+	repo := NewContactRepository(workspaceRepo)
+
+	// A failed concurrent build leaves an INVALID index under the wanted name
+	mock.ExpectQuery(`SELECT ic\.relname, i\.indisvalid\s+FROM pg_index i`).
+		WithArgs(domain.ContactAttributeIndexPrefix).
+		WillReturnRows(sqlmock.NewRows([]string{"relname", "indisvalid"}).
+			AddRow("idx_attr_s_plan", true).
+			AddRow("idx_attr_n_seats", false).
+			AddRow("idx_attr_s_old", false))
+	mock.ExpectExec(`DROP INDEX CONCURRENTLY IF EXISTS "idx_attr_n_seats"`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DROP INDEX CONCURRENTLY IF EXISTS "idx_attr_s_old"`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE INDEX CONCURRENTLY IF NOT EXISTS "idx_attr_n_seats" ON contacts`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.SyncContactAttributeIndexes(context.Background(), "ws-123", []domain.ContactAttribute{
+		{Key: "plan", Type: domain.ContactAttributeTypeString, Filterable: true},
+		{Key: "seats", Type: domain.ContactAttributeTypeNumber, Filterable: true},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSyncContactAttributeIndexes_DatetimeNotIndexed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The timestamptz cast is not immutable, no index may be attempted
+	repo := NewContactRepository(mocks.NewMockWorkspaceRepository(ctrl))
+	err := repo.SyncContactAttributeIndexes(context.Background(), "ws-123", []domain.ContactAttribute{
+		{Key: "renewal_at", Type: domain.ContactAttributeTypeDatetime, Filterable: true},
+	})
+	assert.ErrorContains(t, err, "filterable is only supported")
+}
+
+func TestContactRepository_GetContacts_WithConsent(t *testing.T) {
+	mockDB, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil).AnyTimes()
+
+	repo := NewContactRepository(workspaceRepo)
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{
+		"email", "external_id", "timezone", "language", "first_name", "last_name", "full_name",
+		"phone", "address_line_1", "address_line_2", "country", "postcode", "state",
+		"job_title", "custom_string_1", "custom_string_2", "custom_string_3", "custom_string_4",
+		"custom_string_5", "custom_number_1", "custom_number_2", "custom_number_3",
+		"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
+		"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
+		"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
+		"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at", "computed_properties", "attributes", "email_verification",
+	}).AddRow(
+		"test@example.com", nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil,
+		nil,
+		nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil,
+		now, now, now, now, nil, nil, nil,
+	)
+	mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c ORDER BY c\.created_at DESC, c\.email ASC LIMIT 11`).
+		WillReturnRows(rows)
+
+	mock.ExpectQuery(`FROM contact_lists cl JOIN lists l`).
+		WithArgs("test@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "list_name"}).
+			AddRow("test@example.com", "list1", "active", now, now, "Newsletter").
+			AddRow("test@example.com", "list2", "active", now, now, "Product"))
+
+	mock.ExpectQuery(`SELECT DISTINCT ON \(email, list_id\) .+ FROM consent_records WHERE email = ANY\(\$1\)`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "email", "list_id", "action", "source", "source_id", "ip_address", "user_agent", "page_url", "consent_text_version", "message_id", "created_at",
+		}).AddRow("rec-1", "test@example.com", "list1", "confirmed", "notification_center", nil, "203.0.113.7", "Mozilla/5.0", nil, "v2", "msg-1", now))
+
+	mock.ExpectQuery(`FROM contact_segments cs`).
+		WithArgs("test@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"email", "segment_id", "version", "matched_at", "computed_at", "segment_name", "segment_color"}))
+
+	resp, err := repo.GetContacts(context.Background(), &domain.GetContactsRequest{
+		WorkspaceID:      "workspace123",
+		Limit:            10,
+		WithContactLists: true,
+		WithConsent:      true,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Contacts, 1)
+	require.Len(t, resp.Contacts[0].ContactLists, 2)
+
+	consent := resp.Contacts[0].ContactLists[0].Consent
+	require.NotNil(t, consent)
+	assert.Equal(t, domain.ConsentActionConfirmed, consent.Action)
+	assert.Equal(t, domain.ConsentSourceNotificationCenter, consent.Source)
+	assert.Equal(t, "203.0.113.7", *consent.IPAddress)
+	assert.Equal(t, "msg-1", *consent.MessageID)
+	assert.Nil(t, consent.PageURL)
+	assert.Nil(t, resp.Contacts[0].ContactLists[1].Consent)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateEmailVerifications(t *testing.T) {
+	verification := &domain.EmailVerification{
+		Status:     domain.EmailVerificationStatusRisky,
+		Reasons:    []string{"role_account"},
+		VerifiedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("stores verifications", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws-123").Return(db, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		mock.ExpectExec(`UPDATE contacts\s+SET email_verification = v\.verification::jsonb\s+FROM unnest\(\$1::text\[\], \$2::text\[\]\) AS v\(email, verification\)\s+WHERE contacts\.email = v\.email`).
+			WithArgs(
+				pq.Array([]string{"info@example.com"}),
+				pq.Array([]string{`{"status":"risky","reasons":["role_account"],"verified_at":"2026-03-01T12:00:00Z"}`}),
+			).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.UpdateEmailVerifications(context.Background(), "ws-123", map[string]*domain.EmailVerification{
+			"info@example.com":    verification,
+			"skipped@example.com": nil,
+		})
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("empty map short circuits", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := NewContactRepository(mocks.NewMockWorkspaceRepository(ctrl))
+		assert.NoError(t, repo.UpdateEmailVerifications(context.Background(), "ws-123", nil))
+	})
+
+	t.Run("exec error", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "ws-123").Return(db, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		mock.ExpectExec(`UPDATE contacts`).WillReturnError(errors.New("db down"))
+
+		err := repo.UpdateEmailVerifications(context.Background(), "ws-123", map[string]*domain.EmailVerification{"info@example.com": verification})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update email verifications")
+	})
+}
+
+func TestContactRepository_GetContactsByEmails(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(db, nil).AnyTimes()
+
+	repo := NewContactRepository(workspaceRepo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	t.Run("empty emails", func(t *testing.T) {
+		contacts, err := repo.GetContactsByEmails(context.Background(), "workspace123", nil)
+		require.NoError(t, err)
+		assert.Empty(t, contacts)
+	})
+
+	t.Run("returns matching contacts", func(t *testing.T) {
+		columns := append([]string{}, contactColumns...)
+		values := make([]driver.Value, len(columns))
+		values[0] = "john@example.com"
+		for i, column := range columns {
+			if column == "created_at" || column == "updated_at" || column == "db_created_at" || column == "db_updated_at" {
+				values[i] = now
+			}
+		}
+
+		mock.ExpectQuery(`SELECT .* FROM contacts WHERE email = ANY\(\$1\)`).
+			WithArgs(pq.Array([]string{"john@example.com", "jane@example.com"})).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(values...))
+
+		contacts, err := repo.GetContactsByEmails(context.Background(), "workspace123", []string{"john@example.com", "jane@example.com"})
+		require.NoError(t, err)
+		require.Len(t, contacts, 1)
+		assert.Equal(t, "john@example.com", contacts[0].Email)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM contacts WHERE email = ANY\(\$1\)`).
+			WillReturnError(errors.New("database error"))
+
+		_, err := repo.GetContactsByEmails(context.Background(), "workspace123", []string{"john@example.com"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get contacts")
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -121,6 +121,10 @@ func (r *ContactTimelineRepository) List(ctx context.Context, workspaceID string
 			WHEN ct.entity_type = 'automation' THEN (
 				SELECT json_build_object('id', a.id, 'name', a.name, 'status', a.status)
 				FROM automations a WHERE a.id = ct.entity_id
+			)
+			WHEN ct.entity_type = 'blog_post' THEN (
+				SELECT json_build_object('id', bp.id, 'slug', bp.slug, 'title', bp.settings->>'title')
+				FROM blog_posts bp WHERE bp.id::text = ct.entity_id
 			)
 				ELSE NULL
 			END as entity_data
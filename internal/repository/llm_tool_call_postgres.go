@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+type llmToolCallRepository struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+// NewLLMToolCallRepository creates a new PostgreSQL LLM tool call repository
+func NewLLMToolCallRepository(workspaceRepo domain.WorkspaceRepository) domain.LLMToolCallRepository {
+	return &llmToolCallRepository{
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+const llmToolCallColumns = `id, user_id, tool_name, input, status, error, created_at, updated_at`
+
+func (r *llmToolCallRepository) Create(ctx context.Context, workspaceID string, call *domain.LLMToolCall) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	now := time.Now().UTC()
+	call.CreatedAt = now
+	call.UpdatedAt = now
+	if call.Input == nil {
+		call.Input = domain.MapOfAny{}
+	}
+
+	query := `
+		INSERT INTO llm_tool_calls (` + llmToolCallColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err = workspaceDB.ExecContext(ctx, query,
+		call.ID,
+		call.UserID,
+		call.ToolName,
+		call.Input,
+		call.Status,
+		sql.NullString{String: call.Error, Valid: call.Error != ""},
+		call.CreatedAt,
+		call.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create LLM tool call: %w", err)
+	}
+	return nil
+}
+
+func (r *llmToolCallRepository) GetByID(ctx context.Context, workspaceID string, id string) (*domain.LLMToolCall, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `SELECT ` + llmToolCallColumns + ` FROM llm_tool_calls WHERE id = $1`
+
+	call, err := scanLLMToolCall(workspaceDB.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, &domain.ErrNotFound{Entity: "tool call", ID: id}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LLM tool call: %w", err)
+	}
+	return call, nil
+}
+
+// UpdateStatus only updates a call still in the from status, so that a proposed call is
+// executed once when it is confirmed twice concurrently
+func (r *llmToolCallRepository) UpdateStatus(ctx context.Context, workspaceID string, call *domain.LLMToolCall, from domain.LLMToolCallStatus) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	updatedAt := time.Now().UTC()
+
+	query := `
+		UPDATE llm_tool_calls
+		SET status = $1, error = $2, updated_at = $3
+		WHERE id = $4 AND status = $5
+	`
+	result, err := workspaceDB.ExecContext(ctx, query,
+		call.Status,
+		sql.NullString{String: call.Error, Valid: call.Error != ""},
+		updatedAt,
+		call.ID,
+		from,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update LLM tool call: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update LLM tool call: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrLLMToolCallResolved
+	}
+	call.UpdatedAt = updatedAt
+	return nil
+}
+
+func (r *llmToolCallRepository) List(ctx context.Context, workspaceID string, limit int) ([]*domain.LLMToolCall, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `SELECT ` + llmToolCallColumns + ` FROM llm_tool_calls ORDER BY created_at DESC LIMIT $1`
+
+	rows, err := workspaceDB.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LLM tool calls: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	calls := []*domain.LLMToolCall{}
+	for rows.Next() {
+		call, err := scanLLMToolCall(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan LLM tool call: %w", err)
+		}
+		calls = append(calls, call)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating LLM tool call rows: %w", err)
+	}
+
+	return calls, nil
+}
+
+func scanLLMToolCall(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*domain.LLMToolCall, error) {
+	call := &domain.LLMToolCall{}
+	var callErr sql.NullString
+	if err := scanner.Scan(
+		&call.ID,
+		&call.UserID,
+		&call.ToolName,
+		&call.Input,
+		&call.Status,
+		&callErr,
+		&call.CreatedAt,
+		&call.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	call.Error = callErr.String
+	return call, nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+)
+
+func setupLLMToolCallRepositoryTest(t *testing.T) (domain.LLMToolCallRepository, sqlmock.Sqlmock) {
+	ctrl := gomock.NewController(t)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), "workspace123").
+		Return(db, nil).
+		AnyTimes()
+
+	return NewLLMToolCallRepository(mockWorkspaceRepo), sqlMock
+}
+
+func TestLLMToolCallRepository_Create(t *testing.T) {
+	repo, sqlMock := setupLLMToolCallRepositoryTest(t)
+
+	call := &domain.LLMToolCall{
+		ID:       "call1",
+		UserID:   "user1",
+		ToolName: "create_segment",
+		Input:    domain.MapOfAny{"name": "Repeat buyers"},
+		Status:   domain.LLMToolCallStatusProposed,
+	}
+
+	sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO llm_tool_calls")).
+		WithArgs("call1", "user1", "create_segment", sqlmock.AnyArg(), domain.LLMToolCallStatusProposed,
+			sql.NullString{}, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.Create(context.Background(), "workspace123", call))
+	assert.False(t, call.CreatedAt.IsZero())
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestLLMToolCallRepository_GetByID(t *testing.T) {
+	t.Run("returns the call", func(t *testing.T) {
+		repo, sqlMock := setupLLMToolCallRepositoryTest(t)
+		now := time.Now().UTC()
+
+		sqlMock.ExpectQuery(regexp.QuoteMeta("FROM llm_tool_calls WHERE id = $1")).
+			WithArgs("call1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "tool_name", "input", "status", "error", "created_at", "updated_at"}).
+				AddRow("call1", "user1", "create_segment", []byte(`{"name":"Repeat buyers"}`), "failed", "boom", now, now))
+
+		call, err := repo.GetByID(context.Background(), "workspace123", "call1")
+		require.NoError(t, err)
+		assert.Equal(t, "Repeat buyers", call.Input["name"])
+		assert.Equal(t, domain.LLMToolCallStatusFailed, call.Status)
+		assert.Equal(t, "boom", call.Error)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo, sqlMock := setupLLMToolCallRepositoryTest(t)
+
+		sqlMock.ExpectQuery(regexp.QuoteMeta("FROM llm_tool_calls WHERE id = $1")).
+			WithArgs("missing").
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.GetByID(context.Background(), "workspace123", "missing")
+		var notFound *domain.ErrNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+}
+
+func TestLLMToolCallRepository_UpdateStatus(t *testing.T) {
+	t.Run("updates a call in the expected status", func(t *testing.T) {
+		repo, sqlMock := setupLLMToolCallRepositoryTest(t)
+		call := &domain.LLMToolCall{ID: "call1", Status: domain.LLMToolCallStatusRunning}
+
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE llm_tool_calls")).
+			WithArgs(domain.LLMToolCallStatusRunning, sql.NullString{}, sqlmock.AnyArg(), "call1", domain.LLMToolCallStatusProposed).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.UpdateStatus(context.Background(), "workspace123", call, domain.LLMToolCallStatusProposed))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("rejects a call already resolved", func(t *testing.T) {
+		repo, sqlMock := setupLLMToolCallRepositoryTest(t)
+		call := &domain.LLMToolCall{ID: "call1", Status: domain.LLMToolCallStatusDeclined}
+
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE llm_tool_calls")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.UpdateStatus(context.Background(), "workspace123", call, domain.LLMToolCallStatusProposed)
+		assert.ErrorIs(t, err, domain.ErrLLMToolCallResolved)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		repo, sqlMock := setupLLMToolCallRepositoryTest(t)
+		call := &domain.LLMToolCall{ID: "call1", Status: domain.LLMToolCallStatusFailed, Error: "boom"}
+
+		sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE llm_tool_calls")).
+			WillReturnError(errors.New("connection lost"))
+
+		err := repo.UpdateStatus(context.Background(), "workspace123", call, domain.LLMToolCallStatusRunning)
+		assert.ErrorContains(t, err, "failed to update LLM tool call")
+	})
+}
+
+func TestLLMToolCallRepository_List(t *testing.T) {
+	repo, sqlMock := setupLLMToolCallRepositoryTest(t)
+	now := time.Now().UTC()
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta("FROM llm_tool_calls ORDER BY created_at DESC LIMIT $1")).
+		WithArgs(50).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "tool_name", "input", "status", "error", "created_at", "updated_at"}).
+			AddRow("call2", "user1", "query_analytics", []byte(`{}`), "succeeded", nil, now, now).
+			AddRow("call1", "user1", "create_segment", []byte(`{}`), "proposed", nil, now, now))
+
+	calls, err := repo.List(context.Background(), "workspace123", 50)
+	require.NoError(t, err)
+	require.Len(t, calls, 2)
+	assert.Equal(t, "call2", calls[0].ID)
+	assert.Empty(t, calls[0].Error)
+}
@@ -1254,6 +1254,72 @@ func (r *MessageHistoryRepository) GetBroadcastVariationStats(ctx context.Contex
 	return stats, nil
 }
 
+// GetSubjectPerformance retrieves the open rates of the subject lines of the broadcast emails,
+// best performing first
+func (r *MessageHistoryRepository) GetSubjectPerformance(ctx context.Context, workspaceID string, params domain.SubjectPerformanceParams) ([]*domain.SubjectPerformance, error) {
+	// codecov:ignore:start
+	ctx, span := tracing.StartServiceSpan(ctx, "MessageHistoryRepository", "GetSubjectPerformance")
+	defer tracing.EndSpan(span, nil)
+	tracing.AddAttribute(ctx, "workspaceID", workspaceID)
+	// codecov:ignore:end
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	// The subject is read from the template version that was sent
+	query := `
+		SELECT
+			t.email->>'subject' as subject,
+			COUNT(*) as total_sent,
+			SUM(CASE WHEN mh.opened_at IS NOT NULL THEN 1 ELSE 0 END) as total_opened
+		FROM message_history mh
+		JOIN templates t ON t.id = mh.template_id AND t.version = mh.template_version
+		WHERE mh.broadcast_id IS NOT NULL
+			AND mh.channel = 'email'
+			AND mh.sent_at >= $1
+			AND COALESCE(t.email->>'subject', '') != ''
+		GROUP BY t.email->>'subject'
+		HAVING COUNT(*) >= $2
+		ORDER BY SUM(CASE WHEN mh.opened_at IS NOT NULL THEN 1 ELSE 0 END)::float / COUNT(*) DESC
+		LIMIT $3
+	`
+
+	rows, err := workspaceDB.QueryContext(ctx, query, params.Since, params.MinSent, params.Limit)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, fmt.Errorf("failed to get subject performance: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	performances := []*domain.SubjectPerformance{}
+	for rows.Next() {
+		performance := &domain.SubjectPerformance{}
+		var totalOpened sql.NullInt64
+		if err := rows.Scan(&performance.Subject, &performance.Sent, &totalOpened); err != nil {
+			return nil, fmt.Errorf("failed to scan subject performance: %w", err)
+		}
+		if totalOpened.Valid {
+			performance.Opened = int(totalOpened.Int64)
+		}
+		if performance.Sent > 0 {
+			performance.OpenRate = float64(performance.Opened) / float64(performance.Sent)
+		}
+		performances = append(performances, performance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate subject performance: %w", err)
+	}
+
+	return performances, nil
+}
+
 // DeleteForEmail redacts the email address in all message history records for a specific email
 func (r *MessageHistoryRepository) DeleteForEmail(ctx context.Context, workspaceID, email string) error {
 	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
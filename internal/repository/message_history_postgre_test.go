@@ -2613,3 +2613,66 @@ func TestMessageHistoryRepository_DeleteForEmail(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestMessageHistoryRepository_GetSubjectPerformance(t *testing.T) {
+	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	params := domain.SubjectPerformanceParams{
+		Since:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		MinSent: 100,
+		Limit:   20,
+	}
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		rows := sqlmock.NewRows([]string{"subject", "total_sent", "total_opened"}).
+			AddRow("Our spring sale starts now", 400, 200).
+			AddRow("Monthly update", 1000, nil)
+
+		mock.ExpectQuery(`SELECT .* FROM message_history mh JOIN templates t ON t.id = mh.template_id AND t.version = mh.template_version`).
+			WithArgs(params.Since, params.MinSent, params.Limit).
+			WillReturnRows(rows)
+
+		performances, err := repo.GetSubjectPerformance(ctx, workspaceID, params)
+
+		require.NoError(t, err)
+		require.Len(t, performances, 2)
+		assert.Equal(t, "Our spring sale starts now", performances[0].Subject)
+		assert.Equal(t, 400, performances[0].Sent)
+		assert.Equal(t, 200, performances[0].Opened)
+		assert.Equal(t, 0.5, performances[0].OpenRate)
+		assert.Equal(t, 0, performances[1].Opened)
+		assert.Equal(t, float64(0), performances[1].OpenRate)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery(`SELECT .* FROM message_history mh`).
+			WillReturnError(errors.New("query error"))
+
+		performances, err := repo.GetSubjectPerformance(ctx, workspaceID, params)
+		require.Error(t, err)
+		require.Nil(t, performances)
+		require.Contains(t, err.Error(), "failed to get subject performance")
+	})
+
+	t.Run("workspace connection error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(nil, errors.New("connection error"))
+
+		performances, err := repo.GetSubjectPerformance(ctx, workspaceID, params)
+		require.Error(t, err)
+		require.Nil(t, performances)
+		require.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+}
@@ -505,3 +505,138 @@ func (r *segmentRepository) UpdateRecomputeAfter(ctx context.Context, workspaceI
 
 	return nil
 }
+
+// segmentHistoryRefreshInterval is how long a snapshot of the current day is kept before
+// it is refreshed
+const segmentHistoryRefreshInterval = time.Hour
+
+// SnapshotSegmentHistory records the size and join/leave counts of every segment under the UTC day of now.
+// Join/leave counts come from the segment.joined / segment.left timeline events of that day up to now.
+// The snapshot of the day is refreshed once it is older than segmentHistoryRefreshInterval. The first
+// call after midnight also finalizes the snapshots of the previous day: join/leave counts are recounted
+// over the whole day and the size is rolled back to midnight from the events since. Deleted segments
+// are skipped.
+func (r *segmentRepository) SnapshotSegmentHistory(ctx context.Context, workspaceID string, now time.Time) (int, error) {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	now = now.UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	previousDayStart := dayStart.AddDate(0, 0, -1)
+
+	// Snapshots of the previous day taken before midnight miss its last events
+	finalizeQuery := `
+		UPDATE segment_history h SET
+			joined_count = (SELECT COUNT(*) FROM contact_timeline ct
+				WHERE ct.entity_id = h.segment_id AND ct.kind = 'segment.joined'
+				AND ct.created_at >= $2 AND ct.created_at < $3),
+			left_count = (SELECT COUNT(*) FROM contact_timeline ct
+				WHERE ct.entity_id = h.segment_id AND ct.kind = 'segment.left'
+				AND ct.created_at >= $2 AND ct.created_at < $3),
+			users_count = GREATEST(0,
+				(SELECT COUNT(*) FROM contact_segments cs WHERE cs.segment_id = h.segment_id)
+				- (SELECT COUNT(*) FROM contact_timeline ct
+					WHERE ct.entity_id = h.segment_id AND ct.kind = 'segment.joined'
+					AND ct.created_at >= $3 AND ct.created_at <= $4)
+				+ (SELECT COUNT(*) FROM contact_timeline ct
+					WHERE ct.entity_id = h.segment_id AND ct.kind = 'segment.left'
+					AND ct.created_at >= $3 AND ct.created_at <= $4)),
+			created_at = $4
+		WHERE h.day = $1::date AND h.created_at < $3
+	`
+
+	result, err := workspaceDB.ExecContext(ctx, finalizeQuery, previousDayStart.Format(domain.SegmentHistoryDateFormat), previousDayStart, dayStart, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to finalize segment history: %w", err)
+	}
+
+	finalized, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	query := `
+		INSERT INTO segment_history (segment_id, day, users_count, joined_count, left_count, created_at)
+		SELECT
+			s.id,
+			$1::date,
+			(SELECT COUNT(*) FROM contact_segments cs WHERE cs.segment_id = s.id),
+			(SELECT COUNT(*) FROM contact_timeline ct
+				WHERE ct.entity_id = s.id AND ct.kind = 'segment.joined'
+				AND ct.created_at >= $2 AND ct.created_at <= $3),
+			(SELECT COUNT(*) FROM contact_timeline ct
+				WHERE ct.entity_id = s.id AND ct.kind = 'segment.left'
+				AND ct.created_at >= $2 AND ct.created_at <= $3),
+			$3
+		FROM segments s
+		WHERE s.status != 'deleted'
+			AND NOT EXISTS (
+				SELECT 1 FROM segment_history h
+				WHERE h.segment_id = s.id AND h.day = $1::date AND h.created_at > $4
+			)
+		ON CONFLICT (segment_id, day) DO UPDATE SET
+			users_count = EXCLUDED.users_count,
+			joined_count = EXCLUDED.joined_count,
+			left_count = EXCLUDED.left_count,
+			created_at = EXCLUDED.created_at
+	`
+
+	result, err = workspaceDB.ExecContext(ctx, query, dayStart.Format(domain.SegmentHistoryDateFormat), dayStart, now, now.Add(-segmentHistoryRefreshInterval))
+	if err != nil {
+		return 0, fmt.Errorf("failed to snapshot segment history: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(finalized + rows), nil
+}
+
+// GetSegmentHistory retrieves the daily snapshots of a segment between two UTC days (inclusive)
+func (r *segmentRepository) GetSegmentHistory(ctx context.Context, workspaceID string, segmentID string, from, to time.Time) ([]*domain.SegmentHistoryEntry, error) {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		SELECT segment_id, TO_CHAR(day, 'YYYY-MM-DD'), users_count, joined_count, left_count, created_at
+		FROM segment_history
+		WHERE segment_id = $1 AND day >= $2::date AND day <= $3::date
+		ORDER BY day ASC
+	`
+
+	rows, err := workspaceDB.QueryContext(ctx, query, segmentID, from.Format(domain.SegmentHistoryDateFormat), to.Format(domain.SegmentHistoryDateFormat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query segment history: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	entries := make([]*domain.SegmentHistoryEntry, 0)
+	for rows.Next() {
+		entry := &domain.SegmentHistoryEntry{}
+		if err := rows.Scan(
+			&entry.SegmentID,
+			&entry.Day,
+			&entry.UsersCount,
+			&entry.JoinedCount,
+			&entry.LeftCount,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan segment history: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating segment history: %w", err)
+	}
+
+	return entries, nil
+}
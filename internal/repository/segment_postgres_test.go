@@ -1059,3 +1059,165 @@ func TestSegmentRepository_UpdateRecomputeAfter(t *testing.T) {
 		assert.NoError(t, sqlMock.ExpectationsWereMet())
 	})
 }
+
+func TestSegmentRepository_SnapshotSegmentHistory(t *testing.T) {
+	repo, _, mockWorkspaceRepo := setupSegmentRepositoryTest(t)
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+	day := time.Date(2026, 3, 14, 17, 30, 0, 0, time.UTC)
+	dayStart := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	previousDayStart := time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success - Snapshots the day it is taken", func(t *testing.T) {
+		dbMock, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = dbMock.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(dbMock, nil)
+
+		sqlMock.ExpectExec(`UPDATE segment_history h SET`).
+			WithArgs("2026-03-13", previousDayStart, dayStart, day).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectExec(`INSERT INTO segment_history .* ON CONFLICT \(segment_id, day\) DO UPDATE`).
+			WithArgs("2026-03-14", dayStart, day, day.Add(-time.Hour)).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		created, err := repo.SnapshotSegmentHistory(ctx, workspaceID, day)
+		require.NoError(t, err)
+		assert.Equal(t, 3, created)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Success - Finalizes the previous day after midnight", func(t *testing.T) {
+		dbMock, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = dbMock.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(dbMock, nil)
+
+		// The last snapshot of March 14 was taken at 23:10, contacts joined and left
+		// until midnight: the day is recounted over [March 14, March 15) and its size
+		// rolled back from the events of the 5 minutes since midnight
+		now := time.Date(2026, 3, 15, 0, 5, 0, 0, time.UTC)
+		midnight := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+
+		sqlMock.ExpectExec(`UPDATE segment_history h SET\s+joined_count = .*ct.created_at >= \$2 AND ct.created_at < \$3.*users_count = GREATEST\(0,.*ct.created_at >= \$3 AND ct.created_at <= \$4.*WHERE h.day = \$1::date AND h.created_at < \$3`).
+			WithArgs("2026-03-14", dayStart, midnight, now).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		sqlMock.ExpectExec(`INSERT INTO segment_history`).
+			WithArgs("2026-03-15", midnight, now, now.Add(-time.Hour)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		written, err := repo.SnapshotSegmentHistory(ctx, workspaceID, now)
+		require.NoError(t, err)
+		assert.Equal(t, 4, written)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Connection error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(nil, errors.New("connection error"))
+
+		_, err := repo.SnapshotSegmentHistory(ctx, workspaceID, day)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+
+	t.Run("Error - Finalize fails", func(t *testing.T) {
+		dbMock, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = dbMock.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(dbMock, nil)
+
+		sqlMock.ExpectExec(`UPDATE segment_history h SET`).
+			WillReturnError(errors.New("update error"))
+
+		_, err = repo.SnapshotSegmentHistory(ctx, workspaceID, day)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to finalize segment history")
+	})
+
+	t.Run("Error - Insert fails", func(t *testing.T) {
+		dbMock, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = dbMock.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(dbMock, nil)
+
+		sqlMock.ExpectExec(`UPDATE segment_history h SET`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectExec(`INSERT INTO segment_history`).
+			WillReturnError(errors.New("insert error"))
+
+		_, err = repo.SnapshotSegmentHistory(ctx, workspaceID, day)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to snapshot segment history")
+	})
+}
+
+func TestSegmentRepository_GetSegmentHistory(t *testing.T) {
+	repo, _, mockWorkspaceRepo := setupSegmentRepositoryTest(t)
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success - Returns entries", func(t *testing.T) {
+		dbMock, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = dbMock.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(dbMock, nil)
+
+		now := time.Now().UTC()
+		rows := sqlmock.NewRows([]string{"segment_id", "day", "users_count", "joined_count", "left_count", "created_at"}).
+			AddRow("seg1", "2026-03-01", 100, 5, 2, now).
+			AddRow("seg1", "2026-03-02", 103, 4, 1, now)
+
+		sqlMock.ExpectQuery(`SELECT segment_id, TO_CHAR\(day, 'YYYY-MM-DD'\)`).
+			WithArgs("seg1", "2026-03-01", "2026-03-31").
+			WillReturnRows(rows)
+
+		history, err := repo.GetSegmentHistory(ctx, workspaceID, "seg1", from, to)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, "2026-03-01", history[0].Day)
+		assert.Equal(t, 100, history[0].UsersCount)
+		assert.Equal(t, 5, history[0].JoinedCount)
+		assert.Equal(t, 2, history[0].LeftCount)
+		assert.Equal(t, 103, history[1].UsersCount)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Query fails", func(t *testing.T) {
+		dbMock, sqlMock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = dbMock.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(dbMock, nil)
+
+		sqlMock.ExpectQuery(`SELECT segment_id`).
+			WillReturnError(errors.New("query error"))
+
+		history, err := repo.GetSegmentHistory(ctx, workspaceID, "seg1", from, to)
+		assert.Error(t, err)
+		assert.Nil(t, history)
+		assert.Contains(t, err.Error(), "failed to query segment history")
+	})
+}
@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/lib/pq"
+)
+
+type signupFormRepository struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+// NewSignupFormRepository creates a new PostgreSQL signup form repository
+func NewSignupFormRepository(workspaceRepo domain.WorkspaceRepository) domain.SignupFormRepository {
+	return &signupFormRepository{
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+const signupFormColumns = `id, name, list_ids, fields, consent_text, consent_text_version, success_message,
+		success_redirect_url, style, captcha, enabled, created_at, updated_at, deleted_at`
+
+func (r *signupFormRepository) CreateSignupForm(ctx context.Context, workspaceID string, form *domain.SignupForm) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	now := time.Now().UTC()
+	form.CreatedAt = now
+	form.UpdatedAt = now
+
+	query := `
+		INSERT INTO signup_forms (id, name, list_ids, fields, consent_text, consent_text_version, success_message,
+		                          success_redirect_url, style, captcha, enabled, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+	_, err = workspaceDB.ExecContext(ctx, query,
+		form.ID,
+		form.Name,
+		pq.Array(form.ListIDs),
+		form.Fields,
+		form.ConsentText,
+		form.ConsentTextVersion,
+		form.SuccessMessage,
+		form.SuccessRedirectURL,
+		form.Style,
+		form.Captcha,
+		form.Enabled,
+		form.CreatedAt,
+		form.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create signup form: %w", err)
+	}
+	return nil
+}
+
+func (r *signupFormRepository) GetSignupFormByID(ctx context.Context, workspaceID string, id string) (*domain.SignupForm, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `SELECT ` + signupFormColumns + ` FROM signup_forms WHERE id = $1 AND deleted_at IS NULL`
+
+	form, err := scanSignupForm(workspaceDB.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, &domain.ErrSignupFormNotFound{Message: "signup form not found"}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signup form: %w", err)
+	}
+	return form, nil
+}
+
+func (r *signupFormRepository) GetSignupForms(ctx context.Context, workspaceID string) ([]*domain.SignupForm, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `SELECT ` + signupFormColumns + ` FROM signup_forms WHERE deleted_at IS NULL ORDER BY created_at DESC`
+
+	rows, err := workspaceDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signup forms: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	forms := []*domain.SignupForm{}
+	for rows.Next() {
+		form, err := scanSignupForm(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan signup form: %w", err)
+		}
+		forms = append(forms, form)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating signup form rows: %w", err)
+	}
+
+	return forms, nil
+}
+
+func (r *signupFormRepository) UpdateSignupForm(ctx context.Context, workspaceID string, form *domain.SignupForm) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	form.UpdatedAt = time.Now().UTC()
+
+	query := `
+		UPDATE signup_forms
+		SET name = $1, list_ids = $2, fields = $3, consent_text = $4, consent_text_version = $5,
+		    success_message = $6, success_redirect_url = $7, style = $8, captcha = $9, enabled = $10,
+		    updated_at = $11
+		WHERE id = $12 AND deleted_at IS NULL
+	`
+	result, err := workspaceDB.ExecContext(ctx, query,
+		form.Name,
+		pq.Array(form.ListIDs),
+		form.Fields,
+		form.ConsentText,
+		form.ConsentTextVersion,
+		form.SuccessMessage,
+		form.SuccessRedirectURL,
+		form.Style,
+		form.Captcha,
+		form.Enabled,
+		form.UpdatedAt,
+		form.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update signup form: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return &domain.ErrSignupFormNotFound{Message: "signup form not found or already deleted"}
+	}
+	return nil
+}
+
+func (r *signupFormRepository) DeleteSignupForm(ctx context.Context, workspaceID string, id string) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	result, err := workspaceDB.ExecContext(ctx,
+		`UPDATE signup_forms SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`,
+		time.Now().UTC(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete signup form: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return &domain.ErrSignupFormNotFound{Message: "signup form not found or already deleted"}
+	}
+	return nil
+}
+
+func (r *signupFormRepository) IncrementSignupFormStats(ctx context.Context, workspaceID string, formID string, at time.Time, metrics ...domain.SignupFormMetric) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	// metric names are the column names, they are checked against the known counters
+	// before being embedded in the statement
+	columns := make([]string, 0, len(metrics))
+	values := make([]string, 0, len(metrics))
+	updates := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		if !metric.IsValid() {
+			return fmt.Errorf("invalid signup form metric: %s", metric)
+		}
+		columns = append(columns, string(metric))
+		values = append(values, "1")
+		updates = append(updates, fmt.Sprintf("%s = signup_form_stats.%s + 1", metric, metric))
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO signup_form_stats (form_id, day, %s)
+		VALUES ($1, $2, %s)
+		ON CONFLICT (form_id, day) DO UPDATE SET %s
+	`, strings.Join(columns, ", "), strings.Join(values, ", "), strings.Join(updates, ", "))
+
+	if _, err := workspaceDB.ExecContext(ctx, query, formID, at.UTC().Format(domain.SegmentHistoryDateFormat)); err != nil {
+		return fmt.Errorf("failed to increment signup form stats: %w", err)
+	}
+	return nil
+}
+
+func (r *signupFormRepository) GetSignupFormStats(ctx context.Context, workspaceID string, formID string, from, to time.Time) ([]*domain.SignupFormStatsEntry, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		SELECT day, views, submitted, subscribed, spam_blocked, disposable_blocked, invalid
+		FROM signup_form_stats
+		WHERE form_id = $1 AND day >= $2 AND day <= $3
+		ORDER BY day ASC
+	`
+	rows, err := workspaceDB.QueryContext(ctx, query, formID,
+		from.UTC().Format(domain.SegmentHistoryDateFormat),
+		to.UTC().Format(domain.SegmentHistoryDateFormat),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signup form stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	entries := []*domain.SignupFormStatsEntry{}
+	for rows.Next() {
+		var day time.Time
+		entry := &domain.SignupFormStatsEntry{}
+		if err := rows.Scan(&day, &entry.Views, &entry.Submitted, &entry.Subscribed,
+			&entry.SpamBlocked, &entry.DisposableBlocked, &entry.Invalid); err != nil {
+			return nil, fmt.Errorf("failed to scan signup form stats: %w", err)
+		}
+		entry.Day = day.Format(domain.SegmentHistoryDateFormat)
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating signup form stats rows: %w", err)
+	}
+
+	return entries, nil
+}
+
+func scanSignupForm(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*domain.SignupForm, error) {
+	form := &domain.SignupForm{}
+	var listIDs pq.StringArray
+	if err := scanner.Scan(
+		&form.ID,
+		&form.Name,
+		&listIDs,
+		&form.Fields,
+		&form.ConsentText,
+		&form.ConsentTextVersion,
+		&form.SuccessMessage,
+		&form.SuccessRedirectURL,
+		&form.Style,
+		&form.Captcha,
+		&form.Enabled,
+		&form.CreatedAt,
+		&form.UpdatedAt,
+		&form.DeletedAt,
+	); err != nil {
+		return nil, err
+	}
+	form.ListIDs = []string(listIDs)
+	if form.Fields == nil {
+		form.Fields = domain.SignupFormFields{}
+	}
+	return form, nil
+}
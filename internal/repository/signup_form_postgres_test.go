@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+)
+
+func setupSignupFormRepositoryTest(t *testing.T) (domain.SignupFormRepository, sqlmock.Sqlmock) {
+	ctrl := gomock.NewController(t)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), "workspace123").
+		Return(db, nil).
+		AnyTimes()
+
+	return NewSignupFormRepository(mockWorkspaceRepo), sqlMock
+}
+
+func testSignupFormRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{
+		"id", "name", "list_ids", "fields", "consent_text", "consent_text_version", "success_message",
+		"success_redirect_url", "style", "captcha", "enabled", "created_at", "updated_at", "deleted_at",
+	})
+}
+
+func TestSignupFormRepository_CreateSignupForm(t *testing.T) {
+	repo, sqlMock := setupSignupFormRepositoryTest(t)
+
+	form := &domain.SignupForm{
+		ID:      "form1",
+		Name:    "Newsletter",
+		ListIDs: []string{"news"},
+		Fields:  domain.SignupFormFields{{Key: "first_name", Label: "First name"}},
+		Captcha: domain.SignupFormCaptcha{Provider: domain.SignupFormCaptchaProofOfWork},
+		Enabled: true,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		sqlMock.ExpectExec(regexp.QuoteMeta(`INSERT INTO signup_forms`)).
+			WithArgs("form1", "Newsletter", pq.Array([]string{"news"}), sqlmock.AnyArg(), "", "", "", "",
+				sqlmock.AnyArg(), sqlmock.AnyArg(), true, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repo.CreateSignupForm(context.Background(), "workspace123", form)
+		require.NoError(t, err)
+		assert.False(t, form.CreatedAt.IsZero())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		sqlMock.ExpectExec(regexp.QuoteMeta(`INSERT INTO signup_forms`)).
+			WillReturnError(errors.New("database error"))
+
+		err := repo.CreateSignupForm(context.Background(), "workspace123", form)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create signup form")
+	})
+
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSignupFormRepository_GetSignupFormByID(t *testing.T) {
+	repo, sqlMock := setupSignupFormRepositoryTest(t)
+	now := time.Now().UTC()
+
+	t.Run("found", func(t *testing.T) {
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`FROM signup_forms WHERE id = $1 AND deleted_at IS NULL`)).
+			WithArgs("form1").
+			WillReturnRows(testSignupFormRows().AddRow(
+				"form1", "Newsletter", "{news,product}", []byte(`[{"key":"first_name","label":"First name"}]`),
+				"I agree", "abc123", "Thanks", "", []byte(`{"title":"Join"}`), []byte(`{"provider":"proof_of_work"}`),
+				true, now, now, nil,
+			))
+
+		form, err := repo.GetSignupFormByID(context.Background(), "workspace123", "form1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"news", "product"}, form.ListIDs)
+		require.Len(t, form.Fields, 1)
+		assert.Equal(t, "first_name", form.Fields[0].Key)
+		assert.Equal(t, "Join", form.Style.Title)
+		assert.Equal(t, domain.SignupFormCaptchaProofOfWork, form.Captcha.Provider)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`FROM signup_forms WHERE id = $1`)).
+			WithArgs("missing").
+			WillReturnRows(testSignupFormRows())
+
+		_, err := repo.GetSignupFormByID(context.Background(), "workspace123", "missing")
+		var notFound *domain.ErrSignupFormNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSignupFormRepository_GetSignupForms(t *testing.T) {
+	repo, sqlMock := setupSignupFormRepositoryTest(t)
+	now := time.Now().UTC()
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta(`FROM signup_forms WHERE deleted_at IS NULL ORDER BY created_at DESC`)).
+		WillReturnRows(testSignupFormRows().
+			AddRow("form1", "A", "{news}", nil, "", "", "", "", []byte(`{}`), []byte(`{}`), true, now, now, nil).
+			AddRow("form2", "B", "{news}", []byte(`[]`), "", "", "", "", []byte(`{}`), []byte(`{}`), false, now, now, nil))
+
+	forms, err := repo.GetSignupForms(context.Background(), "workspace123")
+	require.NoError(t, err)
+	require.Len(t, forms, 2)
+	assert.NotNil(t, forms[0].Fields)
+	assert.False(t, forms[1].Enabled)
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSignupFormRepository_UpdateAndDelete(t *testing.T) {
+	repo, sqlMock := setupSignupFormRepositoryTest(t)
+	form := &domain.SignupForm{ID: "form1", Name: "Newsletter", ListIDs: []string{"news"}}
+
+	t.Run("update", func(t *testing.T) {
+		sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE signup_forms`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		require.NoError(t, repo.UpdateSignupForm(context.Background(), "workspace123", form))
+	})
+
+	t.Run("update missing form", func(t *testing.T) {
+		sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE signup_forms`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		err := repo.UpdateSignupForm(context.Background(), "workspace123", form)
+		var notFound *domain.ErrSignupFormNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE signup_forms SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`)).
+			WithArgs(sqlmock.AnyArg(), "form1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		require.NoError(t, repo.DeleteSignupForm(context.Background(), "workspace123", "form1"))
+	})
+
+	t.Run("delete missing form", func(t *testing.T) {
+		sqlMock.ExpectExec(regexp.QuoteMeta(`UPDATE signup_forms SET deleted_at`)).
+			WithArgs(sqlmock.AnyArg(), "form1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		err := repo.DeleteSignupForm(context.Background(), "workspace123", "form1")
+		var notFound *domain.ErrSignupFormNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSignupFormRepository_Stats(t *testing.T) {
+	repo, sqlMock := setupSignupFormRepositoryTest(t)
+	at := time.Date(2026, 3, 4, 22, 0, 0, 0, time.UTC)
+
+	t.Run("increment upserts the daily counters", func(t *testing.T) {
+		sqlMock.ExpectExec(regexp.QuoteMeta(`INSERT INTO signup_form_stats (form_id, day, submitted, subscribed)
+		VALUES ($1, $2, 1, 1)
+		ON CONFLICT (form_id, day) DO UPDATE SET submitted = signup_form_stats.submitted + 1, subscribed = signup_form_stats.subscribed + 1`)).
+			WithArgs("form1", "2026-03-04").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.IncrementSignupFormStats(context.Background(), "workspace123", "form1", at,
+			domain.SignupFormMetricSubmitted, domain.SignupFormMetricSubscribed)
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown metric is rejected", func(t *testing.T) {
+		err := repo.IncrementSignupFormStats(context.Background(), "workspace123", "form1", at, domain.SignupFormMetric("views; DROP TABLE x"))
+		require.Error(t, err)
+	})
+
+	t.Run("no metric is a no-op", func(t *testing.T) {
+		require.NoError(t, repo.IncrementSignupFormStats(context.Background(), "workspace123", "form1", at))
+	})
+
+	t.Run("get stats", func(t *testing.T) {
+		sqlMock.ExpectQuery(regexp.QuoteMeta(`FROM signup_form_stats`)).
+			WithArgs("form1", "2026-03-01", "2026-03-04").
+			WillReturnRows(sqlmock.NewRows([]string{"day", "views", "submitted", "subscribed", "spam_blocked", "disposable_blocked", "invalid"}).
+				AddRow(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), 10, 4, 2, 1, 1, 0))
+
+		entries, err := repo.GetSignupFormStats(context.Background(), "workspace123", "form1",
+			time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), at)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "2026-03-02", entries[0].Day)
+		assert.Equal(t, 10, entries[0].Views)
+		assert.Equal(t, 2, entries[0].Subscribed)
+	})
+
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+}
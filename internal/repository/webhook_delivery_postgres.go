@@ -4,10 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/lib/pq"
 )
 
 // webhookDeliveryRepository implements domain.WebhookDeliveryRepository for PostgreSQL
@@ -41,6 +43,11 @@ func (r *webhookDeliveryRepository) GetPendingForWorkspace(ctx context.Context,
 		WHERE status IN ('pending', 'failed')
 			AND attempts < max_attempts
 			AND next_attempt_at <= NOW()
+			AND NOT EXISTS (
+				SELECT 1 FROM webhook_subscriptions
+				WHERE webhook_subscriptions.id = webhook_deliveries.subscription_id
+					AND webhook_subscriptions.settings->'sink' IS NOT NULL
+			)
 		ORDER BY next_attempt_at ASC
 		LIMIT $1
 	`
@@ -67,6 +74,77 @@ func (r *webhookDeliveryRepository) GetPendingForWorkspace(ctx context.Context,
 	return deliveries, nil
 }
 
+// GetByID retrieves a delivery by ID
+func (r *webhookDeliveryRepository) GetByID(ctx context.Context, workspaceID, id string) (*WebhookDelivery, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		SELECT
+			id, subscription_id, event_type, payload, status,
+			attempts, max_attempts, next_attempt_at, last_attempt_at,
+			delivered_at, last_response_status, last_response_body, last_error, created_at
+		FROM webhook_deliveries
+		WHERE id = $1
+	`
+
+	delivery, err := scanWebhookDelivery(workspaceDB.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("webhook delivery not found")
+		}
+		return nil, fmt.Errorf("failed to get delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// GetPendingForSubscription retrieves the undelivered deliveries of a subscription in
+// insertion order. Sinks publish them strictly in this order, so deliveries waiting for
+// a retry are returned too and block the ones behind them.
+func (r *webhookDeliveryRepository) GetPendingForSubscription(ctx context.Context, workspaceID, subscriptionID string, limit int) ([]*WebhookDelivery, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		SELECT
+			id, subscription_id, event_type, payload, status,
+			attempts, max_attempts, next_attempt_at, last_attempt_at,
+			delivered_at, last_response_status, last_response_body, last_error, created_at
+		FROM webhook_deliveries
+		WHERE subscription_id = $1
+			AND status IN ('pending', 'failed')
+			AND attempts < max_attempts
+		ORDER BY seq ASC
+		LIMIT $2
+	`
+
+	rows, err := workspaceDB.QueryContext(ctx, query, subscriptionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDeliveryFromRows(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
 // ListAll retrieves all deliveries for a workspace with optional subscription filter and pagination
 func (r *webhookDeliveryRepository) ListAll(ctx context.Context, workspaceID string, subscriptionID *string, limit, offset int) ([]*WebhookDelivery, int, error) {
 	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
@@ -190,6 +268,31 @@ func (r *webhookDeliveryRepository) MarkDelivered(ctx context.Context, workspace
 	return nil
 }
 
+// MarkBatchDelivered marks the deliveries published together to a sink as delivered
+func (r *webhookDeliveryRepository) MarkBatchDelivered(ctx context.Context, workspaceID string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'delivered', delivered_at = $2, last_attempt_at = $2, attempts = attempts + 1
+		WHERE id = ANY($1)
+	`
+
+	_, err = workspaceDB.ExecContext(ctx, query, pq.Array(ids), time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to mark deliveries as delivered: %w", err)
+	}
+
+	return nil
+}
+
 // ScheduleRetry schedules a retry for a failed delivery
 func (r *webhookDeliveryRepository) ScheduleRetry(ctx context.Context, workspaceID, id string, nextAttempt time.Time, attempts int, responseStatus *int, responseBody, lastError *string) error {
 	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
@@ -316,8 +419,78 @@ func (r *webhookDeliveryRepository) CleanupOldDeliveries(ctx context.Context, wo
 	return rowsAffected, nil
 }
 
+// Requeue resets a delivery to pending with a fresh attempt budget so it is sent again
+func (r *webhookDeliveryRepository) Requeue(ctx context.Context, workspaceID, id string) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW(), delivered_at = NULL
+		WHERE id = $1
+	`
+
+	result, err := workspaceDB.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to requeue delivery: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("webhook delivery not found: %s", id)
+	}
+
+	return nil
+}
+
+// RequeueFailed resets the permanently failed deliveries created in [from, to) to pending
+func (r *webhookDeliveryRepository) RequeueFailed(ctx context.Context, workspaceID string, subscriptionID *string, from, to time.Time) (int64, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW()
+		WHERE status = 'failed' AND attempts >= max_attempts
+			AND created_at >= $1 AND created_at < $2
+	`
+	args := []interface{}{from, to}
+
+	if subscriptionID != nil && *subscriptionID != "" {
+		query += ` AND subscription_id = $3`
+		args = append(args, *subscriptionID)
+	}
+
+	result, err := workspaceDB.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue failed deliveries: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
 // scanWebhookDeliveryFromRows scans a row from sql.Rows into a WebhookDelivery
 func scanWebhookDeliveryFromRows(rows *sql.Rows) (*WebhookDelivery, error) {
+	return scanWebhookDelivery(rows)
+}
+
+// scanWebhookDelivery scans a row into a WebhookDelivery
+func scanWebhookDelivery(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*WebhookDelivery, error) {
 	var delivery WebhookDelivery
 	var payloadJSON []byte
 	var lastAttemptAt sql.NullTime
@@ -326,7 +499,7 @@ func scanWebhookDeliveryFromRows(rows *sql.Rows) (*WebhookDelivery, error) {
 	var lastResponseBody sql.NullString
 	var lastError sql.NullString
 
-	err := rows.Scan(
+	err := scanner.Scan(
 		&delivery.ID,
 		&delivery.SubscriptionID,
 		&delivery.EventType,
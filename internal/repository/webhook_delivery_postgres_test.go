@@ -10,6 +10,7 @@ import (
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/domain/mocks"
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -892,3 +893,299 @@ func TestWebhookDeliveryRepository_MarkFailed(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestWebhookDeliveryRepository_GetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewWebhookDeliveryRepository(mockWorkspaceRepo)
+
+	ctx := context.Background()
+	workspaceID := "ws-123"
+	deliveryID := "delivery-456"
+	now := time.Now().UTC()
+
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		rows := sqlmock.NewRows([]string{
+			"id", "subscription_id", "event_type", "payload", "status",
+			"attempts", "max_attempts", "next_attempt_at", "last_attempt_at",
+			"delivered_at", "last_response_status", "last_response_body", "last_error", "created_at",
+		}).AddRow(
+			deliveryID, "sub-1", "contact.created", `{"email": "test@example.com"}`, domain.WebhookDeliveryStatusFailed,
+			10, 10, now, now, nil, 500, "Error", "HTTP 500", now,
+		)
+
+		mock.ExpectQuery(`SELECT .+ FROM webhook_deliveries\s+WHERE id = \$1`).
+			WithArgs(deliveryID).
+			WillReturnRows(rows)
+
+		delivery, err := repo.GetByID(ctx, workspaceID, deliveryID)
+		require.NoError(t, err)
+		assert.Equal(t, deliveryID, delivery.ID)
+		assert.Equal(t, domain.WebhookDeliveryStatusFailed, delivery.Status)
+		assert.Equal(t, "test@example.com", delivery.Payload["email"])
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery(`SELECT .+ FROM webhook_deliveries\s+WHERE id = \$1`).
+			WithArgs(deliveryID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, err = repo.GetByID(ctx, workspaceID, deliveryID)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "webhook delivery not found")
+	})
+}
+
+func TestWebhookDeliveryRepository_Requeue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewWebhookDeliveryRepository(mockWorkspaceRepo)
+
+	ctx := context.Background()
+	workspaceID := "ws-123"
+	deliveryID := "delivery-456"
+
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectExec(`UPDATE webhook_deliveries\s+SET status = 'pending', attempts = 0`).
+			WithArgs(deliveryID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = repo.Requeue(ctx, workspaceID, deliveryID)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectExec(`UPDATE webhook_deliveries\s+SET status = 'pending'`).
+			WithArgs(deliveryID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = repo.Requeue(ctx, workspaceID, deliveryID)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "webhook delivery not found")
+	})
+}
+
+func TestWebhookDeliveryRepository_RequeueFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewWebhookDeliveryRepository(mockWorkspaceRepo)
+
+	ctx := context.Background()
+	workspaceID := "ws-123"
+	subscriptionID := "sub-456"
+	to := time.Now().UTC()
+	from := to.Add(-24 * time.Hour)
+
+	t.Run("Success - all subscriptions", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectExec(`UPDATE webhook_deliveries\s+SET status = 'pending'.+WHERE status = 'failed' AND attempts >= max_attempts\s+AND created_at >= \$1 AND created_at < \$2\s*$`).
+			WithArgs(from, to).
+			WillReturnResult(sqlmock.NewResult(0, 7))
+
+		count, err := repo.RequeueFailed(ctx, workspaceID, nil, from, to)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success - single subscription", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectExec(`UPDATE webhook_deliveries.+AND subscription_id = \$3`).
+			WithArgs(from, to, subscriptionID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		count, err := repo.RequeueFailed(ctx, workspaceID, &subscriptionID, from, to)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - database error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectExec(`UPDATE webhook_deliveries`).
+			WithArgs(from, to).
+			WillReturnError(errors.New("database error"))
+
+		_, err = repo.RequeueFailed(ctx, workspaceID, nil, from, to)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to requeue failed deliveries")
+	})
+}
+
+func TestWebhookDeliveryRepository_GetPendingForSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewWebhookDeliveryRepository(mockWorkspaceRepo)
+
+	ctx := context.Background()
+	workspaceID := "ws-123"
+	now := time.Now().UTC()
+
+	t.Run("Success - Ordered By Sequence", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		rows := sqlmock.NewRows([]string{
+			"id", "subscription_id", "event_type", "payload", "status",
+			"attempts", "max_attempts", "next_attempt_at", "last_attempt_at",
+			"delivered_at", "last_response_status", "last_response_body", "last_error", "created_at",
+		}).
+			AddRow(
+				"delivery-1", "sub-1", "contact.created", `{"test": "data1"}`, domain.WebhookDeliveryStatusFailed,
+				1, 10, now.Add(time.Minute), now, nil, nil, nil, "broker unavailable", now,
+			).
+			AddRow(
+				"delivery-2", "sub-1", "contact.updated", `{"test": "data2"}`, domain.WebhookDeliveryStatusPending,
+				0, 10, now, nil, nil, nil, nil, nil, now,
+			)
+
+		mock.ExpectQuery(`SELECT .+ FROM webhook_deliveries WHERE subscription_id = \$1 .+ ORDER BY seq ASC LIMIT \$2`).
+			WithArgs("sub-1", 100).
+			WillReturnRows(rows)
+
+		deliveries, err := repo.GetPendingForSubscription(ctx, workspaceID, "sub-1", 100)
+		assert.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		assert.Equal(t, "delivery-1", deliveries[0].ID)
+		assert.Equal(t, domain.WebhookDeliveryStatusFailed, deliveries[0].Status)
+		assert.Equal(t, "delivery-2", deliveries[1].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Query Failure", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery(`SELECT .+ FROM webhook_deliveries`).
+			WillReturnError(errors.New("query error"))
+
+		deliveries, err := repo.GetPendingForSubscription(ctx, workspaceID, "sub-1", 100)
+		assert.Error(t, err)
+		assert.Nil(t, deliveries)
+		assert.Contains(t, err.Error(), "failed to query pending deliveries")
+	})
+}
+
+func TestWebhookDeliveryRepository_MarkBatchDelivered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewWebhookDeliveryRepository(mockWorkspaceRepo)
+
+	ctx := context.Background()
+	workspaceID := "ws-123"
+
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectExec(`UPDATE webhook_deliveries SET status = 'delivered'.+WHERE id = ANY\(\$1\)`).
+			WithArgs(pq.Array([]string{"delivery-1", "delivery-2"}), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err = repo.MarkBatchDelivered(ctx, workspaceID, []string{"delivery-1", "delivery-2"})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Empty Batch", func(t *testing.T) {
+		err := repo.MarkBatchDelivered(ctx, workspaceID, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Error - Exec Failure", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectExec(`UPDATE webhook_deliveries`).
+			WillReturnError(errors.New("exec error"))
+
+		err = repo.MarkBatchDelivered(ctx, workspaceID, []string{"delivery-1"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to mark deliveries as delivered")
+	})
+}
@@ -77,7 +77,7 @@ func (r *webhookSubscriptionRepository) GetByID(ctx context.Context, workspaceID
 		SELECT
 			id, name, url, secret, settings,
 			enabled, created_at, updated_at,
-			last_delivery_at
+			last_delivery_at, failing_since, disabled_reason
 		FROM webhook_subscriptions
 		WHERE id = $1
 	`
@@ -97,7 +97,7 @@ func (r *webhookSubscriptionRepository) List(ctx context.Context, workspaceID st
 		SELECT
 			id, name, url, secret, settings,
 			enabled, created_at, updated_at,
-			last_delivery_at
+			last_delivery_at, failing_since, disabled_reason
 		FROM webhook_subscriptions
 		ORDER BY created_at DESC
 	`
@@ -139,10 +139,13 @@ func (r *webhookSubscriptionRepository) Update(ctx context.Context, workspaceID
 		return fmt.Errorf("failed to marshal settings: %w", err)
 	}
 
+	// Re-enabling a subscription resets its failure tracking
 	query := `
 		UPDATE webhook_subscriptions
 		SET name = $2, url = $3, secret = $4, settings = $5,
-			enabled = $6, updated_at = $7
+			enabled = $6, updated_at = $7,
+			failing_since = CASE WHEN $6 AND NOT enabled THEN NULL ELSE failing_since END,
+			disabled_reason = CASE WHEN $6 THEN NULL ELSE disabled_reason END
 		WHERE id = $1
 	`
 
@@ -198,14 +201,14 @@ func (r *webhookSubscriptionRepository) Delete(ctx context.Context, workspaceID,
 	return nil
 }
 
-// UpdateLastDeliveryAt updates the last delivery timestamp
+// UpdateLastDeliveryAt updates the last delivery timestamp and clears the failure tracking
 func (r *webhookSubscriptionRepository) UpdateLastDeliveryAt(ctx context.Context, workspaceID, id string, deliveredAt time.Time) error {
 	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
 	if err != nil {
 		return fmt.Errorf("failed to get workspace connection: %w", err)
 	}
 
-	query := `UPDATE webhook_subscriptions SET last_delivery_at = $2 WHERE id = $1`
+	query := `UPDATE webhook_subscriptions SET last_delivery_at = $2, failing_since = NULL WHERE id = $1`
 
 	_, err = workspaceDB.ExecContext(ctx, query, id, deliveredAt)
 	if err != nil {
@@ -215,6 +218,54 @@ func (r *webhookSubscriptionRepository) UpdateLastDeliveryAt(ctx context.Context
 	return nil
 }
 
+// RecordFailure sets failing_since if the subscription wasn't already failing and
+// returns the time since which it has been failing
+func (r *webhookSubscriptionRepository) RecordFailure(ctx context.Context, workspaceID, id string, failedAt time.Time) (time.Time, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `UPDATE webhook_subscriptions SET failing_since = COALESCE(failing_since, $2) WHERE id = $1 RETURNING failing_since`
+
+	var failingSince time.Time
+	err = workspaceDB.QueryRowContext(ctx, query, id, failedAt).Scan(&failingSince)
+	if err == sql.ErrNoRows {
+		return time.Time{}, fmt.Errorf("webhook subscription not found: %s", id)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to record webhook subscription failure: %w", err)
+	}
+
+	return failingSince, nil
+}
+
+// Disable disables a webhook subscription and records the reason
+func (r *webhookSubscriptionRepository) Disable(ctx context.Context, workspaceID, id string, reason string) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `UPDATE webhook_subscriptions SET enabled = false, disabled_reason = $2, updated_at = $3 WHERE id = $1`
+
+	result, err := workspaceDB.ExecContext(ctx, query, id, reason, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to disable webhook subscription: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("webhook subscription not found: %s", id)
+	}
+
+	return nil
+}
+
 // WebhookSubscription alias for domain type
 type WebhookSubscription = domain.WebhookSubscription
 
@@ -223,6 +274,8 @@ func scanWebhookSubscription(row *sql.Row) (*WebhookSubscription, error) {
 	var sub WebhookSubscription
 	var settingsJSON []byte
 	var lastDeliveryAt sql.NullTime
+	var failingSince sql.NullTime
+	var disabledReason sql.NullString
 
 	err := row.Scan(
 		&sub.ID,
@@ -234,6 +287,8 @@ func scanWebhookSubscription(row *sql.Row) (*WebhookSubscription, error) {
 		&sub.CreatedAt,
 		&sub.UpdatedAt,
 		&lastDeliveryAt,
+		&failingSince,
+		&disabledReason,
 	)
 
 	if err == sql.ErrNoRows {
@@ -246,6 +301,12 @@ func scanWebhookSubscription(row *sql.Row) (*WebhookSubscription, error) {
 	if lastDeliveryAt.Valid {
 		sub.LastDeliveryAt = &lastDeliveryAt.Time
 	}
+	if failingSince.Valid {
+		sub.FailingSince = &failingSince.Time
+	}
+	if disabledReason.Valid {
+		sub.DisabledReason = &disabledReason.String
+	}
 
 	if len(settingsJSON) > 0 {
 		if err := json.Unmarshal(settingsJSON, &sub.Settings); err != nil {
@@ -261,6 +322,8 @@ func scanWebhookSubscriptionFromRows(rows *sql.Rows) (*WebhookSubscription, erro
 	var sub WebhookSubscription
 	var settingsJSON []byte
 	var lastDeliveryAt sql.NullTime
+	var failingSince sql.NullTime
+	var disabledReason sql.NullString
 
 	err := rows.Scan(
 		&sub.ID,
@@ -272,6 +335,8 @@ func scanWebhookSubscriptionFromRows(rows *sql.Rows) (*WebhookSubscription, erro
 		&sub.CreatedAt,
 		&sub.UpdatedAt,
 		&lastDeliveryAt,
+		&failingSince,
+		&disabledReason,
 	)
 
 	if err != nil {
@@ -281,6 +346,12 @@ func scanWebhookSubscriptionFromRows(rows *sql.Rows) (*WebhookSubscription, erro
 	if lastDeliveryAt.Valid {
 		sub.LastDeliveryAt = &lastDeliveryAt.Time
 	}
+	if failingSince.Valid {
+		sub.FailingSince = &failingSince.Time
+	}
+	if disabledReason.Valid {
+		sub.DisabledReason = &disabledReason.String
+	}
 
 	if len(settingsJSON) > 0 {
 		if err := json.Unmarshal(settingsJSON, &sub.Settings); err != nil {
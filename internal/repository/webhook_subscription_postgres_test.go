@@ -210,7 +210,7 @@ func TestWebhookSubscriptionRepository_GetByID(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "name", "url", "secret", "settings",
 			"enabled", "created_at", "updated_at",
-			"last_delivery_at",
+			"last_delivery_at", "failing_since", "disabled_reason",
 		}).AddRow(
 			subscriptionID,
 			"Test Subscription",
@@ -221,6 +221,8 @@ func TestWebhookSubscriptionRepository_GetByID(t *testing.T) {
 			now,
 			now,
 			lastDelivery,
+			nil,
+			nil,
 		)
 
 		mock.ExpectQuery(`SELECT .+ FROM webhook_subscriptions WHERE id = \$1`).
@@ -262,7 +264,7 @@ func TestWebhookSubscriptionRepository_GetByID(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "name", "url", "secret", "settings",
 			"enabled", "created_at", "updated_at",
-			"last_delivery_at",
+			"last_delivery_at", "failing_since", "disabled_reason",
 		}).AddRow(
 			subscriptionID,
 			"Simple Subscription",
@@ -273,6 +275,8 @@ func TestWebhookSubscriptionRepository_GetByID(t *testing.T) {
 			now,
 			now,
 			nil, // no last delivery
+			nil,
+			nil,
 		)
 
 		mock.ExpectQuery(`SELECT .+ FROM webhook_subscriptions WHERE id = \$1`).
@@ -330,7 +334,7 @@ func TestWebhookSubscriptionRepository_GetByID(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "name", "url", "secret", "settings",
 			"enabled", "created_at", "updated_at",
-			"last_delivery_at",
+			"last_delivery_at", "failing_since", "disabled_reason",
 		}).AddRow(
 			subscriptionID,
 			"Test",
@@ -341,6 +345,8 @@ func TestWebhookSubscriptionRepository_GetByID(t *testing.T) {
 			now,
 			now,
 			nil,
+			nil,
+			nil,
 		)
 
 		mock.ExpectQuery(`SELECT .+ FROM webhook_subscriptions WHERE id = \$1`).
@@ -390,7 +396,7 @@ func TestWebhookSubscriptionRepository_List(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "name", "url", "secret", "settings",
 			"enabled", "created_at", "updated_at",
-			"last_delivery_at",
+			"last_delivery_at", "failing_since", "disabled_reason",
 		}).
 			AddRow(
 				"sub-1",
@@ -402,6 +408,8 @@ func TestWebhookSubscriptionRepository_List(t *testing.T) {
 				now,
 				now,
 				now,
+				nil,
+				nil,
 			).
 			AddRow(
 				"sub-2",
@@ -413,6 +421,8 @@ func TestWebhookSubscriptionRepository_List(t *testing.T) {
 				now,
 				now,
 				nil,
+				nil,
+				nil,
 			)
 
 		mock.ExpectQuery(`SELECT .+ FROM webhook_subscriptions ORDER BY created_at DESC`).
@@ -450,7 +460,7 @@ func TestWebhookSubscriptionRepository_List(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "name", "url", "secret", "settings",
 			"enabled", "created_at", "updated_at",
-			"last_delivery_at",
+			"last_delivery_at", "failing_since", "disabled_reason",
 		})
 
 		mock.ExpectQuery(`SELECT .+ FROM webhook_subscriptions ORDER BY created_at DESC`).
@@ -526,12 +536,12 @@ func TestWebhookSubscriptionRepository_List(t *testing.T) {
 		rows := sqlmock.NewRows([]string{
 			"id", "name", "url", "secret", "settings",
 			"enabled", "created_at", "updated_at",
-			"last_delivery_at",
+			"last_delivery_at", "failing_since", "disabled_reason",
 		}).
 			AddRow(
 				"sub-1", "Test", "https://example.com", "secret",
 				settings1JSON, true,
-				now, now, nil,
+				now, now, nil, nil, nil,
 			).
 			RowError(0, errors.New("rows iteration error"))
 
@@ -584,7 +594,7 @@ func TestWebhookSubscriptionRepository_Update(t *testing.T) {
 			GetConnection(gomock.Any(), workspaceID).
 			Return(db, nil)
 
-		mock.ExpectExec(`UPDATE webhook_subscriptions SET name = \$2, url = \$3, secret = \$4, settings = \$5, enabled = \$6, updated_at = \$7 WHERE id = \$1`).
+		mock.ExpectExec(`UPDATE webhook_subscriptions SET name = \$2, url = \$3, secret = \$4, settings = \$5, enabled = \$6, updated_at = \$7, failing_since = .+, disabled_reason = .+ WHERE id = \$1`).
 			WithArgs(
 				"sub-1",
 				"Updated Subscription",
@@ -625,7 +635,7 @@ func TestWebhookSubscriptionRepository_Update(t *testing.T) {
 			Enabled: true,
 		}
 
-		mock.ExpectExec(`UPDATE webhook_subscriptions SET name = \$2, url = \$3, secret = \$4, settings = \$5, enabled = \$6, updated_at = \$7 WHERE id = \$1`).
+		mock.ExpectExec(`UPDATE webhook_subscriptions SET name = \$2, url = \$3, secret = \$4, settings = \$5, enabled = \$6, updated_at = \$7, failing_since = .+, disabled_reason = .+ WHERE id = \$1`).
 			WithArgs(
 				"sub-2",
 				"Simple Update",
@@ -821,7 +831,7 @@ func TestWebhookSubscriptionRepository_UpdateLastDeliveryAt(t *testing.T) {
 			GetConnection(gomock.Any(), workspaceID).
 			Return(db, nil)
 
-		mock.ExpectExec(`UPDATE webhook_subscriptions SET last_delivery_at = \$2 WHERE id = \$1`).
+		mock.ExpectExec(`UPDATE webhook_subscriptions SET last_delivery_at = \$2, failing_since = NULL WHERE id = \$1`).
 			WithArgs(subscriptionID, deliveryTime).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -850,7 +860,7 @@ func TestWebhookSubscriptionRepository_UpdateLastDeliveryAt(t *testing.T) {
 			GetConnection(gomock.Any(), workspaceID).
 			Return(db, nil)
 
-		mock.ExpectExec(`UPDATE webhook_subscriptions SET last_delivery_at = \$2 WHERE id = \$1`).
+		mock.ExpectExec(`UPDATE webhook_subscriptions SET last_delivery_at = \$2, failing_since = NULL WHERE id = \$1`).
 			WithArgs(subscriptionID, deliveryTime).
 			WillReturnError(errors.New("database error"))
 
@@ -869,7 +879,7 @@ func TestWebhookSubscriptionRepository_UpdateLastDeliveryAt(t *testing.T) {
 			Return(db, nil)
 
 		// Note: The implementation doesn't check rows affected for this method
-		mock.ExpectExec(`UPDATE webhook_subscriptions SET last_delivery_at = \$2 WHERE id = \$1`).
+		mock.ExpectExec(`UPDATE webhook_subscriptions SET last_delivery_at = \$2, failing_since = NULL WHERE id = \$1`).
 			WithArgs(subscriptionID, deliveryTime).
 			WillReturnResult(sqlmock.NewResult(0, 0))
 
@@ -879,3 +889,103 @@ func TestWebhookSubscriptionRepository_UpdateLastDeliveryAt(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestWebhookSubscriptionRepository_RecordFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewWebhookSubscriptionRepository(mockWorkspaceRepo)
+
+	ctx := context.Background()
+	workspaceID := "ws-123"
+	subscriptionID := "sub-456"
+	failedAt := time.Now().UTC()
+	failingSince := failedAt.Add(-2 * time.Hour)
+
+	t.Run("Success - keeps first failure time", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery(`UPDATE webhook_subscriptions SET failing_since = COALESCE\(failing_since, \$2\) WHERE id = \$1 RETURNING failing_since`).
+			WithArgs(subscriptionID, failedAt).
+			WillReturnRows(sqlmock.NewRows([]string{"failing_since"}).AddRow(failingSince))
+
+		result, err := repo.RecordFailure(ctx, workspaceID, subscriptionID, failedAt)
+		assert.NoError(t, err)
+		assert.Equal(t, failingSince, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - subscription not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery(`UPDATE webhook_subscriptions SET failing_since`).
+			WithArgs(subscriptionID, failedAt).
+			WillReturnRows(sqlmock.NewRows([]string{"failing_since"}))
+
+		_, err = repo.RecordFailure(ctx, workspaceID, subscriptionID, failedAt)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "webhook subscription not found")
+	})
+}
+
+func TestWebhookSubscriptionRepository_Disable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewWebhookSubscriptionRepository(mockWorkspaceRepo)
+
+	ctx := context.Background()
+	workspaceID := "ws-123"
+	subscriptionID := "sub-456"
+	reason := "Deliveries failing continuously"
+
+	t.Run("Success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectExec(`UPDATE webhook_subscriptions SET enabled = false, disabled_reason = \$2, updated_at = \$3 WHERE id = \$1`).
+			WithArgs(subscriptionID, reason, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = repo.Disable(ctx, workspaceID, subscriptionID, reason)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - subscription not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectExec(`UPDATE webhook_subscriptions SET enabled = false`).
+			WithArgs(subscriptionID, reason, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = repo.Disable(ctx, workspaceID, subscriptionID, reason)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "webhook subscription not found")
+	})
+}
@@ -23,6 +23,9 @@ type AutomationExecutor struct {
 	nodeExecutors   map[domain.NodeType]NodeExecutor
 	logger          logger.Logger
 	apiEndpoint     string
+	// webhookEvents is optional; when set, journey lifecycle changes are published as
+	// outgoing webhook events. Injected via SetWebhookEventPublisher.
+	webhookEvents domain.WebhookEventPublisher
 }
 
 // NewAutomationExecutor creates a new AutomationExecutor
@@ -69,6 +72,11 @@ func NewAutomationExecutor(
 	}
 }
 
+// SetWebhookEventPublisher injects the publisher of automation lifecycle webhook events
+func (e *AutomationExecutor) SetWebhookEventPublisher(publisher domain.WebhookEventPublisher) {
+	e.webhookEvents = publisher
+}
+
 // Execute processes a contact through their automation nodes until a delay or completion.
 // It loops through multiple nodes in a single tick for efficiency, persisting state after each node.
 func (e *AutomationExecutor) Execute(ctx context.Context, workspaceID string, contactAutomation *domain.ContactAutomation) error {
@@ -184,6 +192,11 @@ func (e *AutomationExecutor) Execute(ctx context.Context, workspaceID string, co
 		nodeExecution.Output = result.Output
 		_ = e.automationRepo.UpdateNodeExecution(ctx, workspaceID, nodeExecution)
 
+		// The root node is processed once per journey, when the contact enters it
+		if node.ID == automation.RootNodeID {
+			e.publishWebhookEvent(ctx, workspaceID, domain.WebhookEventAutomationContactEntered, contactAutomation, nil)
+		}
+
 		// EXIT: Completed (terminal node reached)
 		if contactAutomation.Status == domain.ContactAutomationStatusCompleted {
 			_ = e.automationRepo.IncrementAutomationStat(ctx, workspaceID, automation.ID, "completed")
@@ -388,6 +401,7 @@ func (e *AutomationExecutor) buildContextFromNodeExecutions(ctx context.Context,
 }
 
 // createAutomationEndEvent creates an automation.end timeline event when a contact exits an automation
+// and publishes the matching automation webhook event
 func (e *AutomationExecutor) createAutomationEndEvent(ctx context.Context, workspaceID string, ca *domain.ContactAutomation, exitReason string) {
 	entry := &domain.ContactTimelineEntry{
 		Email:      ca.ContactEmail,
@@ -409,4 +423,41 @@ func (e *AutomationExecutor) createAutomationEndEvent(ctx context.Context, works
 			"error":         err.Error(),
 		}).Warn("Failed to create automation.end timeline event")
 	}
+
+	var eventType string
+	switch ca.Status {
+	case domain.ContactAutomationStatusCompleted:
+		eventType = domain.WebhookEventAutomationCompleted
+	case domain.ContactAutomationStatusExited:
+		eventType = domain.WebhookEventAutomationExited
+	case domain.ContactAutomationStatusFailed:
+		eventType = domain.WebhookEventAutomationFailed
+	default:
+		return
+	}
+	data := map[string]interface{}{
+		"exit_reason": exitReason,
+	}
+	if ca.LastError != nil {
+		data["error"] = *ca.LastError
+	}
+	e.publishWebhookEvent(ctx, workspaceID, eventType, ca, data)
+}
+
+// publishWebhookEvent publishes an automation lifecycle webhook event, best effort
+func (e *AutomationExecutor) publishWebhookEvent(ctx context.Context, workspaceID, eventType string, ca *domain.ContactAutomation, data map[string]interface{}) {
+	if e.webhookEvents == nil {
+		return
+	}
+	payload := map[string]interface{}{
+		"automation_id":         ca.AutomationID,
+		"contact_automation_id": ca.ID,
+		"email":                 ca.ContactEmail,
+		"status":                string(ca.Status),
+		"entered_at":            ca.EnteredAt,
+	}
+	for key, value := range data {
+		payload[key] = value
+	}
+	e.webhookEvents.Publish(ctx, workspaceID, eventType, payload)
 }
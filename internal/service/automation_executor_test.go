@@ -732,6 +732,129 @@ func TestAutomationExecutor_handleError_MaxRetriesExceeded(t *testing.T) {
 	assert.Equal(t, domain.ContactAutomationStatusFailed, ca.Status)
 }
 
+func TestAutomationExecutor_Execute_PublishesWebhookEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+	mockPublisher := mocks.NewMockWebhookEventPublisher(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	executor := &AutomationExecutor{
+		automationRepo:  mockAutomationRepo,
+		contactRepo:     mockContactRepo,
+		contactListRepo: mockContactListRepo,
+		timelineRepo:    mockTimelineRepo,
+		nodeExecutors: map[domain.NodeType]NodeExecutor{
+			domain.NodeTypeAddToList: NewAddToListNodeExecutor(mockContactListRepo),
+		},
+		logger: mockLogger,
+	}
+	executor.SetWebhookEventPublisher(mockPublisher)
+
+	workspaceID := "ws1"
+	nodeID := "root_node"
+
+	contactAutomation := &domain.ContactAutomation{
+		ID:            "ca1",
+		AutomationID:  "auto1",
+		ContactEmail:  "test@example.com",
+		CurrentNodeID: &nodeID,
+		Status:        domain.ContactAutomationStatusActive,
+	}
+
+	// A root node with no NextNodeID: the contact enters and completes the journey in one tick
+	rootNode := &domain.AutomationNode{
+		ID:   nodeID,
+		Type: domain.NodeTypeAddToList,
+		Config: map[string]interface{}{
+			"list_id": "list1",
+			"status":  "active",
+		},
+	}
+
+	automation := &domain.Automation{
+		ID:         "auto1",
+		Name:       "Test Automation",
+		Status:     domain.AutomationStatusLive,
+		RootNodeID: nodeID,
+		Nodes:      []*domain.AutomationNode{rootNode},
+	}
+
+	mockAutomationRepo.EXPECT().GetByID(gomock.Any(), workspaceID, "auto1").Return(automation, nil)
+	mockContactRepo.EXPECT().GetContactByEmail(gomock.Any(), workspaceID, "test@example.com").Return(&domain.Contact{Email: "test@example.com"}, nil)
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().GetNodeExecutions(gomock.Any(), workspaceID, "ca1").Return([]*domain.NodeExecution{}, nil)
+	mockContactListRepo.EXPECT().AddContactToList(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().UpdateContactAutomationIfActive(gomock.Any(), workspaceID, gomock.Any()).Return(true, nil)
+	mockAutomationRepo.EXPECT().UpdateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "completed").Return(nil)
+	mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+	gomock.InOrder(
+		mockPublisher.EXPECT().Publish(gomock.Any(), workspaceID, domain.WebhookEventAutomationContactEntered, gomock.Any()).
+			Do(func(_ context.Context, _ string, _ string, payload map[string]interface{}) {
+				assert.Equal(t, "auto1", payload["automation_id"])
+				assert.Equal(t, "ca1", payload["contact_automation_id"])
+				assert.Equal(t, "test@example.com", payload["email"])
+			}),
+		mockPublisher.EXPECT().Publish(gomock.Any(), workspaceID, domain.WebhookEventAutomationCompleted, gomock.Any()).
+			Do(func(_ context.Context, _ string, _ string, payload map[string]interface{}) {
+				assert.Equal(t, "completed", payload["status"])
+				assert.Equal(t, "completed", payload["exit_reason"])
+			}),
+	)
+
+	err := executor.Execute(context.Background(), workspaceID, contactAutomation)
+	require.NoError(t, err)
+}
+
+func TestAutomationExecutor_handleError_PublishesFailedWebhookEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAutomationRepo := mocks.NewMockAutomationRepository(ctrl)
+	mockTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+	mockPublisher := mocks.NewMockWebhookEventPublisher(ctrl)
+	mockLogger := setupMockLogger(ctrl)
+
+	executor := &AutomationExecutor{
+		automationRepo: mockAutomationRepo,
+		timelineRepo:   mockTimelineRepo,
+		logger:         mockLogger,
+	}
+	executor.SetWebhookEventPublisher(mockPublisher)
+
+	workspaceID := "ws1"
+	nodeID := "node1"
+
+	ca := &domain.ContactAutomation{
+		ID:            "ca1",
+		AutomationID:  "auto1",
+		ContactEmail:  "test@example.com",
+		CurrentNodeID: &nodeID,
+		Status:        domain.ContactAutomationStatusActive,
+		RetryCount:    2,
+		MaxRetries:    3,
+	}
+
+	mockAutomationRepo.EXPECT().IncrementAutomationStat(gomock.Any(), workspaceID, "auto1", "failed").Return(nil)
+	mockAutomationRepo.EXPECT().CreateNodeExecution(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockAutomationRepo.EXPECT().UpdateContactAutomationIfActive(gomock.Any(), workspaceID, gomock.Any()).Return(true, nil)
+	mockTimelineRepo.EXPECT().Create(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	mockPublisher.EXPECT().Publish(gomock.Any(), workspaceID, domain.WebhookEventAutomationFailed, gomock.Any()).
+		Do(func(_ context.Context, _ string, _ string, payload map[string]interface{}) {
+			assert.Equal(t, "failed", payload["status"])
+			assert.Equal(t, "test context: test error", payload["error"])
+		})
+
+	err := executor.handleError(context.Background(), workspaceID, ca, errors.New("test error"), "test context")
+	require.NoError(t, err)
+}
+
 func TestAutomationExecutor_createNodeExecution(t *testing.T) {
 	executor := &AutomationExecutor{}
 
@@ -287,6 +287,36 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 					}),
 				}, nil
 			}
+
+			// A paused contact or one who only wants the weekly digest stays in the
+			// automation, the email is skipped and the flow moves on
+			skipReason := ""
+			if contactList.IsPaused(time.Now().UTC()) {
+				skipReason = "paused"
+			} else if contactList.Frequency == domain.ContactListFrequencyWeeklyDigest {
+				skipReason = string(domain.ContactListFrequencyWeeklyDigest)
+			}
+			if skipReason != "" {
+				e.logger.WithFields(map[string]interface{}{
+					"workspace_id":  params.WorkspaceID,
+					"automation_id": params.Automation.ID,
+					"contact_email": params.ContactData.Email,
+					"template_id":   config.TemplateID,
+					"list_id":       params.Automation.ListID,
+					"skip_reason":   skipReason,
+				}).Info("Email node skipped - contact delivery preferences")
+
+				return &NodeExecutionResult{
+					NextNodeID: params.Node.NextNodeID,
+					Status:     domain.ContactAutomationStatusActive,
+					Output: buildNodeOutput(domain.NodeTypeEmail, map[string]interface{}{
+						"template_id": config.TemplateID,
+						"skipped":     true,
+						"skip_reason": skipReason,
+						"to":          params.ContactData.Email,
+					}),
+				}, nil
+			}
 		}
 	}
 
@@ -347,6 +377,7 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 		MessageID:        messageID,
 		TemplateData:     notifuse_mjml.MapOfAny(templateData),
 		TrackingSettings: trackingSettings,
+		SyncedBlocks:     workspace.Settings.SyncedBlocks(),
 	}
 	// Wires the resolved variant's tree/source + its inbox-preview override.
 	emailContent.ApplyToCompileRequest(&compileReq, nil)
@@ -735,7 +766,11 @@ func (e *AddToListNodeExecutor) Execute(ctx context.Context, params NodeExecutio
 		UpdatedAt: now,
 	}
 
-	err = e.contactListRepo.AddContactToList(ctx, params.WorkspaceID, contactList)
+	consentCtx := domain.WithConsentContext(ctx, domain.ConsentContext{
+		Source:   domain.ConsentSourceAutomation,
+		SourceID: params.Contact.AutomationID,
+	})
+	err = e.contactListRepo.AddContactToList(consentCtx, params.WorkspaceID, contactList)
 	if err != nil {
 		// Log but don't fail - contact might already be in list
 		return &NodeExecutionResult{
@@ -1539,6 +1539,81 @@ func TestEmailNodeExecutor_Execute_MarketingEmail_ComplainedContact(t *testing.T
 	assert.Equal(t, "complained", *result.ExitReason)
 }
 
+func TestEmailNodeExecutor_Execute_MarketingEmail_DeliveryPreferences(t *testing.T) {
+	pausedUntil := time.Now().UTC().AddDate(0, 0, 30)
+
+	testCases := []struct {
+		name        string
+		contactList *domain.ContactList
+		skipReason  string
+	}{
+		{
+			name: "paused contact",
+			contactList: &domain.ContactList{
+				Status:      domain.ContactListStatusActive,
+				Frequency:   domain.ContactListFrequencyAll,
+				PausedUntil: &pausedUntil,
+			},
+			skipReason: "paused",
+		},
+		{
+			name: "weekly digest contact",
+			contactList: &domain.ContactList{
+				Status:    domain.ContactListStatusActive,
+				Frequency: domain.ContactListFrequencyWeeklyDigest,
+			},
+			skipReason: "weekly_digest",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockEmailQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+			mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+			mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+			mockListRepo := mocks.NewMockListRepository(ctrl)
+			mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+			mockLogger := setupMockLoggerForNodeExecutor(ctrl)
+
+			executor := NewEmailNodeExecutor(mockEmailQueueRepo, mockTemplateRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, "https://api.example.com", mockLogger)
+
+			mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(createTestWorkspaceWithEmailProvider(), nil)
+			mockTemplateRepo.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "tpl123", int64(0)).Return(createTestTemplateWithCategory("marketing"), nil)
+			mockContactListRepo.EXPECT().
+				GetContactListByIDs(gomock.Any(), "ws1", "recipient@example.com", "list1").
+				Return(tc.contactList, nil)
+			// No email is enqueued for a skipped node
+			mockEmailQueueRepo.EXPECT().Enqueue(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			params := NodeExecutionParams{
+				WorkspaceID: "ws1",
+				Node: &domain.AutomationNode{
+					ID:         "email_node1",
+					Type:       domain.NodeTypeEmail,
+					NextNodeID: strPtr("next_node"),
+					Config:     map[string]interface{}{"template_id": "tpl123"},
+				},
+				Contact:     &domain.ContactAutomation{ID: "ca1", ContactEmail: "recipient@example.com"},
+				ContactData: &domain.Contact{Email: "recipient@example.com"},
+				Automation:  &domain.Automation{ID: "auto1", Name: "Test Automation", ListID: "list1"},
+			}
+
+			result, err := executor.Execute(context.Background(), params)
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.Equal(t, domain.ContactAutomationStatusActive, result.Status)
+			require.NotNil(t, result.NextNodeID)
+			assert.Equal(t, "next_node", *result.NextNodeID)
+			assert.Nil(t, result.ExitReason)
+			assert.Equal(t, true, result.Output["skipped"])
+			assert.Equal(t, tc.skipReason, result.Output["skip_reason"])
+		})
+	}
+}
+
 func TestEmailNodeExecutor_Execute_MarketingEmail_ActiveContact(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
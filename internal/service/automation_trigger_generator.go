@@ -126,7 +126,8 @@ func (g *AutomationTriggerGenerator) buildWHENClause(automation *domain.Automati
 	if trigger.EventKind == "contact.updated" && len(trigger.UpdatedFields) > 0 {
 		fieldChecks := make([]string, 0, len(trigger.UpdatedFields))
 		for _, field := range trigger.UpdatedFields {
-			if !AllowedContactFields[field] {
+			// Attribute changes are recorded per key as "attributes.<key>"
+			if _, isAttribute := domain.ContactAttributeKeyFromField(field); !AllowedContactFields[field] && !isAttribute {
 				return "", fmt.Errorf("invalid updated_field: %s", field)
 			}
 			// Use JSONB ? operator to check if field exists in changes
@@ -566,6 +566,25 @@ func TestAutomationTriggerGenerator_Generate(t *testing.T) {
 		assert.Contains(t, result.WHENClause, "NEW.changes ? 'phone'")
 	})
 
+	t.Run("contact.updated with attribute updated_field", func(t *testing.T) {
+		automation := &domain.Automation{
+			ID:         "testattr",
+			ListID:     "list1",
+			RootNodeID: "node1",
+			Trigger: &domain.TimelineTriggerConfig{
+				EventKind:     "contact.updated",
+				UpdatedFields: []string{"attributes.plan"},
+				Frequency:     domain.TriggerFrequencyEveryTime,
+			},
+		}
+
+		result, err := gen.Generate(automation)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		assert.Contains(t, result.WHENClause, "NEW.changes ? 'attributes.plan'")
+	})
+
 	t.Run("contact.updated without updated_fields (any field change)", func(t *testing.T) {
 		automation := &domain.Automation{
 			ID:         "testany",
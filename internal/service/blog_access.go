@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// checkBlogGate ensures the list of a gate exists in the workspace
+func (s *BlogService) checkBlogGate(ctx context.Context, workspaceID string, gate *domain.BlogGate) error {
+	if gate == nil {
+		return nil
+	}
+	if _, err := s.listRepo.GetListByID(ctx, workspaceID, gate.ListID); err != nil {
+		return fmt.Errorf("gate list not found: %s", gate.ListID)
+	}
+	return nil
+}
+
+// isSubscribed returns true when the email is an active subscriber of the list
+func (s *BlogService) isSubscribed(ctx context.Context, workspaceID, email, listID string) bool {
+	contactList, err := s.contactListRepo.GetContactListByIDs(ctx, workspaceID, email, listID)
+	if err != nil {
+		return false
+	}
+	return contactList.Status == domain.ContactListStatusActive
+}
+
+// hasBlogAccess returns true when the viewer of the request may read the body of a post
+// restricted by the gate
+func (s *BlogService) hasBlogAccess(ctx context.Context, workspaceID string, gate *domain.BlogGate) bool {
+	if gate == nil {
+		return true
+	}
+	viewer := domain.BlogViewerFromContext(ctx)
+	if viewer == nil {
+		return false
+	}
+	return s.isSubscribed(ctx, workspaceID, viewer.Email, gate.ListID)
+}
+
+// buildBlogGateData returns the gate data exposed to the post template
+func (s *BlogService) buildBlogGateData(ctx context.Context, workspaceID string, gate *domain.BlogGate) domain.MapOfAny {
+	gateData := domain.MapOfAny{
+		"list_id":    gate.ListID,
+		"access_url": "/access.json",
+	}
+	if list, err := s.listRepo.GetListByID(ctx, workspaceID, gate.ListID); err == nil {
+		gateData["list_name"] = list.Name
+	}
+	return gateData
+}
+
+// maskGatedSnippets replaces the snippet of the gated posts of search results by their
+// excerpt, so that searching never reveals a gated body
+func maskGatedSnippets(results []*domain.BlogSearchResult, categories []*domain.BlogCategory) {
+	categoriesByID := make(map[string]*domain.BlogCategory, len(categories))
+	for _, category := range categories {
+		categoriesByID[category.ID] = category
+	}
+	for _, result := range results {
+		if result.Post != nil && result.Post.EffectiveGate(categoriesByID[result.Post.CategoryID]) != nil {
+			result.Snippet = html.EscapeString(result.Post.Settings.Excerpt)
+		}
+	}
+}
+
+// RequestBlogAccess emails a magic link granting access to the gated posts to a returning
+// subscriber. Nothing is sent, and no error returned, when the email is not an active
+// subscriber of the gate list, so that the endpoint does not disclose the subscribers.
+func (s *BlogService) RequestBlogAccess(ctx context.Context, workspaceID string, request *domain.BlogAccessRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	var accessSettings *domain.BlogAccessSettings
+	if workspace.Settings.BlogSettings != nil {
+		accessSettings = workspace.Settings.BlogSettings.Access
+	}
+	if accessSettings == nil || accessSettings.MagicLinkNotificationID == "" {
+		return domain.NewValidationError("magic links are not enabled on this blog")
+	}
+
+	post, err := s.postRepo.GetPost(ctx, request.PostID)
+	if err != nil || !post.IsPublished() {
+		return &domain.ErrNotFound{Entity: "blog post", ID: request.PostID}
+	}
+
+	category, err := s.categoryRepo.GetCategory(ctx, post.CategoryID)
+	if err != nil {
+		return &domain.ErrNotFound{Entity: "blog post", ID: request.PostID}
+	}
+
+	gate := post.EffectiveGate(category)
+	if gate == nil {
+		return domain.NewValidationError("this post is not gated")
+	}
+
+	if !s.isSubscribed(ctx, workspaceID, request.Email, gate.ListID) {
+		return nil
+	}
+
+	token := domain.SignBlogAccessToken(request.Email, workspace.Settings.SecretKey, time.Now().Add(domain.BlogAccessLinkTTL))
+	query := url.Values{}
+	query.Set("token", token)
+	query.Set("redirect", "/"+category.Slug+"/"+post.Slug)
+	accessURL := joinURL(workspaceBlogOrigin(workspace), "/access?"+query.Encode())
+
+	systemCtx := context.WithValue(ctx, domain.SystemCallKey, true)
+	_, err = s.transactionalService.SendNotification(systemCtx, workspaceID, domain.TransactionalNotificationSendParams{
+		ID:      accessSettings.MagicLinkNotificationID,
+		Contact: &domain.Contact{Email: request.Email},
+		Data: domain.MapOfAny{
+			"blog_access_url": accessURL,
+			"post": domain.MapOfAny{
+				"title": post.Settings.Title,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send magic link: %w", err)
+	}
+	return nil
+}
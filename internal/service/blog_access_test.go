@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gatedPost returns a published post gated to the members list
+func gatedPost() *domain.BlogPost {
+	publishedAt := time.Now().Add(-time.Hour)
+	return &domain.BlogPost{
+		ID:          "post-1",
+		Slug:        "secret-plan",
+		CategoryID:  "cat-1",
+		PublishedAt: &publishedAt,
+		Settings: domain.BlogPostSettings{
+			Title:   "Secret plan",
+			Excerpt: "A teaser",
+			Template: domain.BlogPostTemplateReference{
+				TemplateID:      "tpl-1",
+				TemplateVersion: 1,
+			},
+			Gate: &domain.BlogGate{ListID: "members"},
+		},
+	}
+}
+
+func TestBlogService_RenderPostPage_Gated(t *testing.T) {
+	ctx := context.WithValue(context.Background(), domain.WorkspaceIDKey, "workspace123")
+	workspace := &domain.Workspace{ID: "workspace123", Name: "Test"}
+	category := &domain.BlogCategory{ID: "cat-1", Slug: "premium", Settings: domain.BlogCategorySettings{Name: "Premium"}}
+	theme := &domain.BlogTheme{
+		Version: 1,
+		Files: domain.BlogThemeFiles{
+			PostLiquid: `{% if is_gated and access_granted == false %}<p>{{ post.excerpt }}</p><form data-list="{{ gate.list_id }}">{{ gate.list_name }}</form>{% else %}<div>{{ post.content }}</div>{% endif %}{% if viewer %}<span>{{ viewer.email }}</span>{% endif %}`,
+		},
+	}
+
+	expectPage := func(mockWorkspaceRepo *mocks.MockWorkspaceRepository, mockThemeRepo *mocks.MockBlogThemeRepository, mockPostRepo *mocks.MockBlogPostRepository, mockCategoryRepo *mocks.MockBlogCategoryRepository, mockListRepo *mocks.MockListRepository, ctx context.Context) {
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(workspace, nil)
+		mockThemeRepo.EXPECT().GetPublishedTheme(ctx).Return(theme, nil)
+		mockPostRepo.EXPECT().GetPostByCategoryAndSlug(ctx, "premium", "secret-plan").Return(gatedPost(), nil)
+		mockCategoryRepo.EXPECT().GetCategory(ctx, "cat-1").Return(category, nil)
+		mockListRepo.EXPECT().GetLists(ctx, "workspace123").Return([]*domain.List{}, nil)
+		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{category}, nil)
+		mockListRepo.EXPECT().GetListByID(ctx, "workspace123", "members").Return(&domain.List{ID: "members", Name: "Members"}, nil)
+	}
+
+	t.Run("hides the body from anonymous visitors", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, mockThemeRepo, mockWorkspaceRepo, mockListRepo, _, _ := setupBlogServiceTest(t)
+		expectPage(mockWorkspaceRepo, mockThemeRepo, mockPostRepo, mockCategoryRepo, mockListRepo, ctx)
+
+		html, err := service.RenderPostPage(ctx, "workspace123", "", "premium", "secret-plan", nil)
+		require.NoError(t, err)
+		assert.Contains(t, html, "A teaser")
+		assert.Contains(t, html, `data-list="members">Members`)
+		assert.NotContains(t, html, "<div>")
+	})
+
+	t.Run("shows the body to active subscribers", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, mockThemeRepo, mockWorkspaceRepo, mockListRepo, mockTemplateRepo, _ := setupBlogServiceTest(t)
+		mockContactListRepo := mocks.NewMockContactListRepository(gomock.NewController(t))
+		service.contactListRepo = mockContactListRepo
+		viewerCtx := domain.WithBlogViewer(ctx, &domain.BlogViewer{Email: "reader@example.com"})
+
+		expectPage(mockWorkspaceRepo, mockThemeRepo, mockPostRepo, mockCategoryRepo, mockListRepo, viewerCtx)
+		mockContactListRepo.EXPECT().
+			GetContactListByIDs(viewerCtx, "workspace123", "reader@example.com", "members").
+			Return(&domain.ContactList{Status: domain.ContactListStatusActive}, nil)
+		mockTemplateRepo.EXPECT().
+			GetTemplateByID(viewerCtx, "workspace123", "tpl-1", int64(1)).
+			Return(&domain.Template{Web: &domain.WebTemplate{HTML: "<p>The whole plan</p>"}}, nil)
+
+		html, err := service.RenderPostPage(viewerCtx, "workspace123", "", "premium", "secret-plan", nil)
+		require.NoError(t, err)
+		assert.Contains(t, html, "The whole plan")
+		assert.Contains(t, html, "<span>reader@example.com</span>")
+	})
+
+	t.Run("hides the body from unsubscribed viewers", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, mockThemeRepo, mockWorkspaceRepo, mockListRepo, _, _ := setupBlogServiceTest(t)
+		mockContactListRepo := mocks.NewMockContactListRepository(gomock.NewController(t))
+		service.contactListRepo = mockContactListRepo
+		viewerCtx := domain.WithBlogViewer(ctx, &domain.BlogViewer{Email: "former@example.com"})
+
+		expectPage(mockWorkspaceRepo, mockThemeRepo, mockPostRepo, mockCategoryRepo, mockListRepo, viewerCtx)
+		mockContactListRepo.EXPECT().
+			GetContactListByIDs(viewerCtx, "workspace123", "former@example.com", "members").
+			Return(&domain.ContactList{Status: domain.ContactListStatusUnsubscribed}, nil)
+
+		html, err := service.RenderPostPage(viewerCtx, "workspace123", "", "premium", "secret-plan", nil)
+		require.NoError(t, err)
+		assert.Contains(t, html, "A teaser")
+		assert.NotContains(t, html, "<div>")
+	})
+}
+
+func TestBlogService_BuildFeed_Gated(t *testing.T) {
+	service, mockCategoryRepo, mockPostRepo, _, mockWorkspaceRepo, _, _, _ := setupBlogServiceTest(t)
+	ctx := context.Background()
+
+	mockWorkspaceRepo.EXPECT().GetByID(ctx, "ws-feed").Return(&domain.Workspace{
+		ID:       "ws-feed",
+		Settings: domain.WorkspaceSettings{WebsiteURL: "https://blog.example.com"},
+	}, nil)
+	mockPostRepo.EXPECT().ListFeedPosts(gomock.Any(), "", nil, gomock.Any()).Return([]*domain.BlogPost{gatedPost()}, nil)
+	mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), "", nil, gomock.Any()).Return(time.Now(), "abcd", nil)
+	mockCategoryRepo.EXPECT().
+		GetCategoriesByIDs(gomock.Any(), []string{"cat-1"}).
+		Return([]*domain.BlogCategory{{ID: "cat-1", Slug: "premium"}}, nil)
+
+	// No template is fetched for gated posts
+	feed, err := service.BuildFeed(ctx, "ws-feed", "", nil)
+	require.NoError(t, err)
+	require.Len(t, feed.Items, 1)
+	assert.Equal(t, "A teaser", feed.Items[0].ContentHTML)
+}
+
+func TestBlogService_RequestBlogAccess(t *testing.T) {
+	ctx := context.WithValue(context.Background(), domain.WorkspaceIDKey, "workspace123")
+	workspace := &domain.Workspace{
+		ID: "workspace123",
+		Settings: domain.WorkspaceSettings{
+			WebsiteURL: "https://blog.example.com",
+			SecretKey:  "secret",
+			BlogSettings: &domain.BlogSettings{
+				Access: &domain.BlogAccessSettings{MagicLinkNotificationID: "blog_access"},
+			},
+		},
+	}
+	category := &domain.BlogCategory{ID: "cat-1", Slug: "premium"}
+
+	setup := func(t *testing.T) (*BlogService, *mocks.MockBlogCategoryRepository, *mocks.MockBlogPostRepository, *mocks.MockWorkspaceRepository, *mocks.MockContactListRepository, *mocks.MockTransactionalNotificationService) {
+		service, mockCategoryRepo, mockPostRepo, _, mockWorkspaceRepo, _, _, _ := setupBlogServiceTest(t)
+		ctrl := gomock.NewController(t)
+		mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+		mockTransactionalService := mocks.NewMockTransactionalNotificationService(ctrl)
+		service.contactListRepo = mockContactListRepo
+		service.transactionalService = mockTransactionalService
+		return service, mockCategoryRepo, mockPostRepo, mockWorkspaceRepo, mockContactListRepo, mockTransactionalService
+	}
+
+	t.Run("emails a magic link to active subscribers", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, mockWorkspaceRepo, mockContactListRepo, mockTransactionalService := setup(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(workspace, nil)
+		mockPostRepo.EXPECT().GetPost(ctx, "post-1").Return(gatedPost(), nil)
+		mockCategoryRepo.EXPECT().GetCategory(ctx, "cat-1").Return(category, nil)
+		mockContactListRepo.EXPECT().
+			GetContactListByIDs(ctx, "workspace123", "reader@example.com", "members").
+			Return(&domain.ContactList{Status: domain.ContactListStatusActive}, nil)
+		mockTransactionalService.EXPECT().
+			SendNotification(gomock.Any(), "workspace123", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, params domain.TransactionalNotificationSendParams) (string, error) {
+				assert.Equal(t, true, ctx.Value(domain.SystemCallKey))
+				assert.Equal(t, "blog_access", params.ID)
+				assert.Equal(t, "reader@example.com", params.Contact.Email)
+
+				accessURL, ok := params.Data["blog_access_url"].(string)
+				require.True(t, ok)
+				assert.True(t, strings.HasPrefix(accessURL, "https://blog.example.com/access?"))
+				parsed, err := url.Parse(accessURL)
+				require.NoError(t, err)
+				assert.Equal(t, "/premium/secret-plan", parsed.Query().Get("redirect"))
+				email, valid := domain.VerifyBlogAccessToken(parsed.Query().Get("token"), "secret", time.Now())
+				assert.True(t, valid)
+				assert.Equal(t, "reader@example.com", email)
+				return "msg-1", nil
+			})
+
+		err := service.RequestBlogAccess(ctx, "workspace123", &domain.BlogAccessRequest{Email: " Reader@Example.com ", PostID: "post-1"})
+		require.NoError(t, err)
+	})
+
+	t.Run("sends nothing to non subscribers", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, mockWorkspaceRepo, mockContactListRepo, _ := setup(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(workspace, nil)
+		mockPostRepo.EXPECT().GetPost(ctx, "post-1").Return(gatedPost(), nil)
+		mockCategoryRepo.EXPECT().GetCategory(ctx, "cat-1").Return(category, nil)
+		mockContactListRepo.EXPECT().
+			GetContactListByIDs(ctx, "workspace123", "stranger@example.com", "members").
+			Return(nil, errors.New("contact list not found"))
+
+		err := service.RequestBlogAccess(ctx, "workspace123", &domain.BlogAccessRequest{Email: "stranger@example.com", PostID: "post-1"})
+		require.NoError(t, err)
+	})
+
+	t.Run("requires magic links to be enabled", func(t *testing.T) {
+		service, _, _, mockWorkspaceRepo, _, _ := setup(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(&domain.Workspace{ID: "workspace123"}, nil)
+
+		err := service.RequestBlogAccess(ctx, "workspace123", &domain.BlogAccessRequest{Email: "reader@example.com", PostID: "post-1"})
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+
+	t.Run("rejects posts that are not gated", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, mockWorkspaceRepo, _, _ := setup(t)
+
+		post := gatedPost()
+		post.Settings.Gate = nil
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(workspace, nil)
+		mockPostRepo.EXPECT().GetPost(ctx, "post-1").Return(post, nil)
+		mockCategoryRepo.EXPECT().GetCategory(ctx, "cat-1").Return(category, nil)
+
+		err := service.RequestBlogAccess(ctx, "workspace123", &domain.BlogAccessRequest{Email: "reader@example.com", PostID: "post-1"})
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+}
+
+func TestBlogService_CheckBlogGate(t *testing.T) {
+	service, _, _, _, _, mockListRepo, _, _ := setupBlogServiceTest(t)
+	ctx := context.Background()
+
+	assert.NoError(t, service.checkBlogGate(ctx, "workspace123", nil))
+
+	mockListRepo.EXPECT().GetListByID(ctx, "workspace123", "members").Return(&domain.List{ID: "members"}, nil)
+	assert.NoError(t, service.checkBlogGate(ctx, "workspace123", &domain.BlogGate{ListID: "members"}))
+
+	mockListRepo.EXPECT().GetListByID(ctx, "workspace123", "missing").Return(nil, errors.New("not found"))
+	assert.ErrorContains(t, service.checkBlogGate(ctx, "workspace123", &domain.BlogGate{ListID: "missing"}), "gate list not found")
+}
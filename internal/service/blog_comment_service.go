@@ -0,0 +1,427 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/disposable_emails"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// blogCommentLinkRegex matches the links of a comment
+var blogCommentLinkRegex = regexp.MustCompile(`(?i)https?://|www\.`)
+
+// blogCommentDuplicateWindow is how long the same comment of an author is flagged as a duplicate
+const blogCommentDuplicateWindow = 24 * time.Hour
+
+// BlogCommentService handles the comments and reactions of blog posts
+type BlogCommentService struct {
+	logger               logger.Logger
+	commentRepo          domain.BlogCommentRepository
+	postRepo             domain.BlogPostRepository
+	categoryRepo         domain.BlogCategoryRepository
+	workspaceRepo        domain.WorkspaceRepository
+	authService          domain.AuthService
+	transactionalService domain.TransactionalNotificationService
+}
+
+// NewBlogCommentService creates a new blog comment service
+func NewBlogCommentService(
+	logger logger.Logger,
+	commentRepository domain.BlogCommentRepository,
+	postRepository domain.BlogPostRepository,
+	categoryRepository domain.BlogCategoryRepository,
+	workspaceRepository domain.WorkspaceRepository,
+	authService domain.AuthService,
+	transactionalService domain.TransactionalNotificationService,
+) *BlogCommentService {
+	return &BlogCommentService{
+		logger:               logger,
+		commentRepo:          commentRepository,
+		postRepo:             postRepository,
+		categoryRepo:         categoryRepository,
+		workspaceRepo:        workspaceRepository,
+		authService:          authService,
+		transactionalService: transactionalService,
+	}
+}
+
+// ====================
+// Moderation Operations
+// ====================
+
+// authenticateBlogUser authenticates the user of the workspace in the context and checks
+// their access to the blog
+func (s *BlogCommentService) authenticateBlogUser(ctx context.Context, permissionType domain.PermissionType) (context.Context, error) {
+	workspaceID, ok := ctx.Value(domain.WorkspaceIDKey).(string)
+	if !ok {
+		return nil, fmt.Errorf("workspace_id not found in context")
+	}
+
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceBlog, permissionType) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceBlog,
+			permissionType,
+			fmt.Sprintf("Insufficient permissions: %s access to blog required", permissionType),
+		)
+	}
+
+	return ctx, nil
+}
+
+// ListComments lists the comments of the moderation queue
+func (s *BlogCommentService) ListComments(ctx context.Context, params *domain.ListBlogCommentsRequest) (*domain.BlogCommentListResponse, error) {
+	ctx, err := s.authenticateBlogUser(ctx, domain.PermissionTypeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.commentRepo.ListComments(ctx, *params)
+}
+
+// ModerateComment approves, rejects or marks a comment as spam. Approving a comment for
+// the first time records blog.commented on the timeline of its author.
+func (s *BlogCommentService) ModerateComment(ctx context.Context, request *domain.ModerateBlogCommentRequest) (*domain.BlogComment, error) {
+	ctx, err := s.authenticateBlogUser(ctx, domain.PermissionTypeWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentRepo.GetComment(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	comment.Status = request.Status
+	comment.UpdatedAt = now
+	if request.Status == domain.BlogCommentStatusApproved {
+		// Approved by a moderator, the heuristics were wrong
+		comment.SpamReasons = nil
+		if comment.ApprovedAt == nil {
+			comment.ApprovedAt = &now
+		}
+	}
+
+	if err := s.commentRepo.UpdateComment(ctx, comment); err != nil {
+		s.logger.WithField("comment_id", comment.ID).Error(fmt.Sprintf("Failed to moderate blog comment: %v", err))
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// DeleteComment deletes a comment and its replies
+func (s *BlogCommentService) DeleteComment(ctx context.Context, request *domain.DeleteBlogCommentRequest) error {
+	ctx, err := s.authenticateBlogUser(ctx, domain.PermissionTypeWrite)
+	if err != nil {
+		return err
+	}
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	return s.commentRepo.DeleteComment(ctx, request.ID)
+}
+
+// ====================
+// Public Operations
+// ====================
+
+// getCommentablePost returns the workspace and a published post accepting comments
+func (s *BlogCommentService) getCommentablePost(ctx context.Context, workspaceID, postID string) (*domain.Workspace, *domain.BlogPost, error) {
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	post, err := s.postRepo.GetPost(ctx, postID)
+	if err != nil || !post.IsPublished() || !post.CommentsEnabled(workspace.Settings.BlogSettings) {
+		return nil, nil, &domain.ErrNotFound{Entity: "blog post", ID: postID}
+	}
+
+	return workspace, post, nil
+}
+
+// ListPublicComments returns the approved comments of a post and its reaction counts
+func (s *BlogCommentService) ListPublicComments(ctx context.Context, workspaceID, postID string) (*domain.PublicBlogCommentsResponse, error) {
+	if _, _, err := s.getCommentablePost(ctx, workspaceID, postID); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.ListApprovedComments(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	reactions, err := s.commentRepo.CountReactions(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	publicComments := make([]*domain.PublicBlogComment, len(comments))
+	for i, comment := range comments {
+		publicComments[i] = comment.Public()
+	}
+
+	return &domain.PublicBlogCommentsResponse{
+		Comments:  publicComments,
+		Reactions: reactions,
+	}, nil
+}
+
+// CreatePublicComment records a comment left on the blog. Comments flagged by the spam
+// heuristics go to spam; the others are published right away when their author is a
+// verified contact and moderation is automatic, and wait for approval otherwise. Authors
+// without an email_hmac are sent a magic link confirming their email first.
+func (s *BlogCommentService) CreatePublicComment(ctx context.Context, workspaceID string, request *domain.CreateBlogCommentRequest) (*domain.BlogComment, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	workspace, post, err := s.getCommentablePost(ctx, workspaceID, request.PostID)
+	if err != nil {
+		return nil, err
+	}
+	settings := workspace.Settings.BlogSettings.Comments
+
+	verified := false
+	if request.EmailHMAC != "" {
+		if !domain.VerifyEmailHMAC(request.Email, request.EmailHMAC, workspace.Settings.SecretKey) {
+			return nil, domain.NewValidationError("invalid email verification")
+		}
+		verified = true
+	} else if settings.VerificationNotificationID == "" {
+		return nil, domain.NewValidationError("email_hmac is required")
+	}
+
+	if request.ParentID != nil {
+		parent, err := s.commentRepo.GetComment(ctx, *request.ParentID)
+		if err != nil || parent.PostID != post.ID || parent.Status != domain.BlogCommentStatusApproved {
+			return nil, domain.NewValidationError("parent comment not found")
+		}
+	}
+
+	spamReasons, err := s.blogCommentSpamReasons(ctx, settings, request)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	comment := &domain.BlogComment{
+		ID:          uuid.New().String(),
+		PostID:      post.ID,
+		ParentID:    request.ParentID,
+		Email:       request.Email,
+		Name:        request.Name,
+		Content:     request.Content,
+		SpamReasons: spamReasons,
+		IPAddress:   request.IPAddress,
+		UserAgent:   request.UserAgent,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	switch {
+	case len(spamReasons) > 0:
+		comment.Status = domain.BlogCommentStatusSpam
+	case !verified:
+		comment.Status = domain.BlogCommentStatusUnverified
+	default:
+		comment.Status = verifiedCommentStatus(settings)
+		if comment.Status == domain.BlogCommentStatusApproved {
+			comment.ApprovedAt = &now
+		}
+	}
+
+	if err := s.commentRepo.CreateComment(ctx, comment); err != nil {
+		s.logger.WithField("post_id", post.ID).Error(fmt.Sprintf("Failed to create blog comment: %v", err))
+		return nil, err
+	}
+
+	if comment.Status == domain.BlogCommentStatusUnverified {
+		if err := s.sendCommentVerification(ctx, workspace, post, comment); err != nil {
+			s.logger.WithField("comment_id", comment.ID).Error(fmt.Sprintf("Failed to send blog comment verification: %v", err))
+			return nil, fmt.Errorf("failed to send the verification email")
+		}
+	}
+
+	return comment, nil
+}
+
+// verifiedCommentStatus returns the status of the comments of verified contacts
+func verifiedCommentStatus(settings *domain.BlogCommentSettings) domain.BlogCommentStatus {
+	if settings.Moderation == domain.BlogCommentModerationAuto {
+		return domain.BlogCommentStatusApproved
+	}
+	return domain.BlogCommentStatusPending
+}
+
+// blogCommentSpamReasons returns the spam heuristics a comment matches: a filled
+// honeypot, too many links, a blocked word, a disposable email address or the same
+// comment already posted by its author recently
+func (s *BlogCommentService) blogCommentSpamReasons(ctx context.Context, settings *domain.BlogCommentSettings, request *domain.CreateBlogCommentRequest) ([]string, error) {
+	reasons := []string{}
+
+	if request.Honeypot != "" {
+		reasons = append(reasons, domain.BlogCommentSpamHoneypot)
+	}
+
+	if len(blogCommentLinkRegex.FindAllStringIndex(request.Content, -1)) > settings.GetMaxLinks() {
+		reasons = append(reasons, domain.BlogCommentSpamTooManyLinks)
+	}
+
+	text := strings.ToLower(request.Name + " " + request.Content)
+	for _, word := range settings.BlockedWords {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" && strings.Contains(text, word) {
+			reasons = append(reasons, domain.BlogCommentSpamBlockedWord)
+			break
+		}
+	}
+
+	// the disposable list is keyed by domain, the email is already normalized and validated
+	if disposable_emails.IsDisposableEmail(request.Email[strings.LastIndex(request.Email, "@")+1:]) {
+		reasons = append(reasons, domain.BlogCommentSpamDisposableEmail)
+	}
+
+	duplicates, err := s.commentRepo.CountDuplicateComments(ctx, request.Email, request.Content, time.Now().UTC().Add(-blogCommentDuplicateWindow))
+	if err != nil {
+		return nil, err
+	}
+	if duplicates > 0 {
+		reasons = append(reasons, domain.BlogCommentSpamDuplicate)
+	}
+
+	if len(reasons) == 0 {
+		return nil, nil
+	}
+	return reasons, nil
+}
+
+// sendCommentVerification sends the magic link confirming the email of the author of a
+// comment with the verification notification of the blog
+func (s *BlogCommentService) sendCommentVerification(ctx context.Context, workspace *domain.Workspace, post *domain.BlogPost, comment *domain.BlogComment) error {
+	query := url.Values{}
+	query.Set("comment_id", comment.ID)
+	query.Set("email", comment.Email)
+	query.Set("email_hmac", domain.ComputeEmailHMAC(comment.Email, workspace.Settings.SecretKey))
+	verificationURL := joinURL(workspaceBlogOrigin(workspace), "/comments/verify?"+query.Encode())
+
+	systemCtx := context.WithValue(ctx, domain.SystemCallKey, true)
+	_, err := s.transactionalService.SendNotification(systemCtx, workspace.ID, domain.TransactionalNotificationSendParams{
+		ID:      workspace.Settings.BlogSettings.Comments.VerificationNotificationID,
+		Contact: &domain.Contact{Email: comment.Email},
+		Data: domain.MapOfAny{
+			"comment_verification_url": verificationURL,
+			"comment": domain.MapOfAny{
+				"name":    comment.Name,
+				"content": comment.Content,
+			},
+			"post": domain.MapOfAny{
+				"title": post.Settings.Title,
+			},
+		},
+	})
+	return err
+}
+
+// VerifyPublicComment confirms the email of the author of a comment from the magic link
+// sent to them, and returns the URL of the comment on its post
+func (s *BlogCommentService) VerifyPublicComment(ctx context.Context, workspaceID string, request *domain.VerifyBlogCommentRequest) (string, error) {
+	if err := request.Validate(); err != nil {
+		return "", err
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	if !domain.VerifyEmailHMAC(request.Email, request.EmailHMAC, workspace.Settings.SecretKey) {
+		return "", domain.NewValidationError("invalid email verification")
+	}
+
+	comment, err := s.commentRepo.GetComment(ctx, request.CommentID)
+	if err != nil || comment.Email != request.Email {
+		return "", &domain.ErrNotFound{Entity: "blog comment", ID: request.CommentID}
+	}
+
+	_, post, err := s.getCommentablePost(ctx, workspaceID, comment.PostID)
+	if err != nil {
+		return "", err
+	}
+
+	// Following the link again leaves the comment as it is
+	if comment.Status == domain.BlogCommentStatusUnverified {
+		now := time.Now().UTC()
+		comment.Status = verifiedCommentStatus(workspace.Settings.BlogSettings.Comments)
+		comment.UpdatedAt = now
+		if comment.Status == domain.BlogCommentStatusApproved {
+			comment.ApprovedAt = &now
+		}
+		if err := s.commentRepo.UpdateComment(ctx, comment); err != nil {
+			return "", err
+		}
+	}
+
+	category, err := s.categoryRepo.GetCategory(ctx, post.CategoryID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get post category: %w", err)
+	}
+
+	return joinURL(workspaceBlogOrigin(workspace), "/"+category.Slug+"/"+post.Slug) + "#comment-" + comment.ID, nil
+}
+
+// ReactToPost adds or removes the reaction of a verified contact to a post, and returns
+// the reaction counts of the post
+func (s *BlogCommentService) ReactToPost(ctx context.Context, workspaceID string, request *domain.BlogReactionRequest) (map[string]int, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	request.Email = strings.ToLower(strings.TrimSpace(request.Email))
+
+	workspace, post, err := s.getCommentablePost(ctx, workspaceID, request.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.VerifyEmailHMAC(request.Email, request.EmailHMAC, workspace.Settings.SecretKey) {
+		return nil, domain.NewValidationError("invalid email verification")
+	}
+
+	if request.Remove {
+		err = s.commentRepo.RemoveReaction(ctx, post.ID, request.Email, request.Reaction)
+	} else {
+		err = s.commentRepo.AddReaction(ctx, &domain.BlogPostReaction{
+			PostID:    post.ID,
+			Email:     request.Email,
+			Reaction:  request.Reaction,
+			CreatedAt: time.Now().UTC(),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.commentRepo.CountReactions(ctx, post.ID)
+}
@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+type blogCommentServiceMocks struct {
+	commentRepo   *mocks.MockBlogCommentRepository
+	postRepo      *mocks.MockBlogPostRepository
+	categoryRepo  *mocks.MockBlogCategoryRepository
+	workspaceRepo *mocks.MockWorkspaceRepository
+	authService   *mocks.MockAuthService
+	transactional *mocks.MockTransactionalNotificationService
+}
+
+func setupBlogCommentServiceTest(t *testing.T) (*BlogCommentService, *blogCommentServiceMocks) {
+	ctrl := gomock.NewController(t)
+
+	m := &blogCommentServiceMocks{
+		commentRepo:   mocks.NewMockBlogCommentRepository(ctrl),
+		postRepo:      mocks.NewMockBlogPostRepository(ctrl),
+		categoryRepo:  mocks.NewMockBlogCategoryRepository(ctrl),
+		workspaceRepo: mocks.NewMockWorkspaceRepository(ctrl),
+		authService:   mocks.NewMockAuthService(ctrl),
+		transactional: mocks.NewMockTransactionalNotificationService(ctrl),
+	}
+
+	service := NewBlogCommentService(
+		logger.NewLoggerWithLevel("disabled"),
+		m.commentRepo,
+		m.postRepo,
+		m.categoryRepo,
+		m.workspaceRepo,
+		m.authService,
+		m.transactional,
+	)
+
+	return service, m
+}
+
+// commentsWorkspace returns a workspace with its blog comments enabled
+func commentsWorkspace(settings domain.BlogCommentSettings) *domain.Workspace {
+	settings.Enabled = true
+	return &domain.Workspace{
+		ID: "ws1",
+		Settings: domain.WorkspaceSettings{
+			SecretKey:    "secret",
+			WebsiteURL:   "https://blog.example.com",
+			BlogEnabled:  true,
+			BlogSettings: &domain.BlogSettings{Comments: &settings},
+		},
+	}
+}
+
+func publishedCommentPost() *domain.BlogPost {
+	publishedAt := time.Now().Add(-time.Hour)
+	return &domain.BlogPost{
+		ID:          "post1",
+		CategoryID:  "cat1",
+		Slug:        "launch",
+		Settings:    domain.BlogPostSettings{Title: "Launch"},
+		PublishedAt: &publishedAt,
+	}
+}
+
+func TestBlogCommentService_CreatePublicComment(t *testing.T) {
+	ctx := context.WithValue(context.Background(), domain.WorkspaceIDKey, "ws1")
+	email := "reader@example.com"
+
+	newRequest := func() *domain.CreateBlogCommentRequest {
+		return &domain.CreateBlogCommentRequest{
+			PostID:    "post1",
+			Email:     email,
+			EmailHMAC: domain.ComputeEmailHMAC(email, "secret"),
+			Name:      "Reader",
+			Content:   "Great post",
+		}
+	}
+
+	t.Run("publishes the comment of a verified contact with auto moderation", func(t *testing.T) {
+		service, m := setupBlogCommentServiceTest(t)
+
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").
+			Return(commentsWorkspace(domain.BlogCommentSettings{Moderation: domain.BlogCommentModerationAuto}), nil)
+		m.postRepo.EXPECT().GetPost(gomock.Any(), "post1").Return(publishedCommentPost(), nil)
+		m.commentRepo.EXPECT().CountDuplicateComments(gomock.Any(), email, "Great post", gomock.Any()).Return(0, nil)
+		m.commentRepo.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(nil)
+
+		comment, err := service.CreatePublicComment(ctx, "ws1", newRequest())
+		require.NoError(t, err)
+		assert.Equal(t, domain.BlogCommentStatusApproved, comment.Status)
+		assert.NotNil(t, comment.ApprovedAt)
+		assert.Empty(t, comment.SpamReasons)
+	})
+
+	t.Run("keeps the comment of a verified contact pending with manual moderation", func(t *testing.T) {
+		service, m := setupBlogCommentServiceTest(t)
+
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(commentsWorkspace(domain.BlogCommentSettings{}), nil)
+		m.postRepo.EXPECT().GetPost(gomock.Any(), "post1").Return(publishedCommentPost(), nil)
+		m.commentRepo.EXPECT().CountDuplicateComments(gomock.Any(), email, "Great post", gomock.Any()).Return(0, nil)
+		m.commentRepo.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(nil)
+
+		comment, err := service.CreatePublicComment(ctx, "ws1", newRequest())
+		require.NoError(t, err)
+		assert.Equal(t, domain.BlogCommentStatusPending, comment.Status)
+		assert.Nil(t, comment.ApprovedAt)
+	})
+
+	t.Run("flags spam heuristics", func(t *testing.T) {
+		service, m := setupBlogCommentServiceTest(t)
+
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").
+			Return(commentsWorkspace(domain.BlogCommentSettings{
+				Moderation:   domain.BlogCommentModerationAuto,
+				BlockedWords: []string{"Casino"},
+				MaxLinks:     1,
+			}), nil)
+		m.postRepo.EXPECT().GetPost(gomock.Any(), "post1").Return(publishedCommentPost(), nil)
+		m.commentRepo.EXPECT().CountDuplicateComments(gomock.Any(), email, gomock.Any(), gomock.Any()).Return(1, nil)
+		m.commentRepo.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(nil)
+
+		req := newRequest()
+		req.Content = "Best casino at https://a.example and www.b.example"
+		req.Honeypot = "https://spam.example"
+
+		comment, err := service.CreatePublicComment(ctx, "ws1", req)
+		require.NoError(t, err)
+		assert.Equal(t, domain.BlogCommentStatusSpam, comment.Status)
+		assert.Equal(t, []string{
+			domain.BlogCommentSpamHoneypot,
+			domain.BlogCommentSpamTooManyLinks,
+			domain.BlogCommentSpamBlockedWord,
+			domain.BlogCommentSpamDuplicate,
+		}, comment.SpamReasons)
+		assert.Nil(t, comment.ApprovedAt)
+	})
+
+	t.Run("flags a disposable email", func(t *testing.T) {
+		service, m := setupBlogCommentServiceTest(t)
+		disposable := "reader@mailinator.com"
+
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").
+			Return(commentsWorkspace(domain.BlogCommentSettings{Moderation: domain.BlogCommentModerationAuto}), nil)
+		m.postRepo.EXPECT().GetPost(gomock.Any(), "post1").Return(publishedCommentPost(), nil)
+		m.commentRepo.EXPECT().CountDuplicateComments(gomock.Any(), disposable, "Great post", gomock.Any()).Return(0, nil)
+		m.commentRepo.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(nil)
+
+		req := newRequest()
+		req.Email = "Reader@Mailinator.com"
+		req.EmailHMAC = domain.ComputeEmailHMAC(disposable, "secret")
+
+		comment, err := service.CreatePublicComment(ctx, "ws1", req)
+		require.NoError(t, err)
+		assert.Equal(t, domain.BlogCommentStatusSpam, comment.Status)
+		assert.Equal(t, []string{domain.BlogCommentSpamDisposableEmail}, comment.SpamReasons)
+	})
+
+	t.Run("sends a magic link to unverified commenters", func(t *testing.T) {
+		service, m := setupBlogCommentServiceTest(t)
+
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").
+			Return(commentsWorkspace(domain.BlogCommentSettings{VerificationNotificationID: "comment_verification"}), nil)
+		m.postRepo.EXPECT().GetPost(gomock.Any(), "post1").Return(publishedCommentPost(), nil)
+		m.commentRepo.EXPECT().CountDuplicateComments(gomock.Any(), email, "Great post", gomock.Any()).Return(0, nil)
+		m.commentRepo.EXPECT().CreateComment(gomock.Any(), gomock.Any()).Return(nil)
+		m.transactional.EXPECT().SendNotification(gomock.Any(), "ws1", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, params domain.TransactionalNotificationSendParams) (string, error) {
+				assert.Equal(t, true, ctx.Value(domain.SystemCallKey))
+				assert.Equal(t, "comment_verification", params.ID)
+				assert.Equal(t, email, params.Contact.Email)
+
+				verificationURL, err := url.Parse(params.Data["comment_verification_url"].(string))
+				require.NoError(t, err)
+				assert.Equal(t, "blog.example.com", verificationURL.Host)
+				assert.Equal(t, "/comments/verify", verificationURL.Path)
+				assert.Equal(t, domain.ComputeEmailHMAC(email, "secret"), verificationURL.Query().Get("email_hmac"))
+				return "message1", nil
+			})
+
+		req := newRequest()
+		req.EmailHMAC = ""
+
+		comment, err := service.CreatePublicComment(ctx, "ws1", req)
+		require.NoError(t, err)
+		assert.Equal(t, domain.BlogCommentStatusUnverified, comment.Status)
+	})
+
+	t.Run("requires an email_hmac without verification notification", func(t *testing.T) {
+		service, m := setupBlogCommentServiceTest(t)
+
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(commentsWorkspace(domain.BlogCommentSettings{}), nil)
+		m.postRepo.EXPECT().GetPost(gomock.Any(), "post1").Return(publishedCommentPost(), nil)
+
+		req := newRequest()
+		req.EmailHMAC = ""
+
+		_, err := service.CreatePublicComment(ctx, "ws1", req)
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+
+	t.Run("rejects an invalid email_hmac", func(t *testing.T) {
+		service, m := setupBlogCommentServiceTest(t)
+
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(commentsWorkspace(domain.BlogCommentSettings{}), nil)
+		m.postRepo.EXPECT().GetPost(gomock.Any(), "post1").Return(publishedCommentPost(), nil)
+
+		req := newRequest()
+		req.EmailHMAC = "forged"
+
+		_, err := service.CreatePublicComment(ctx, "ws1", req)
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+
+	t.Run("rejects replies to comments of other posts", func(t *testing.T) {
+		service, m := setupBlogCommentServiceTest(t)
+
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(commentsWorkspace(domain.BlogCommentSettings{}), nil)
+		m.postRepo.EXPECT().GetPost(gomock.Any(), "post1").Return(publishedCommentPost(), nil)
+		m.commentRepo.EXPECT().GetComment(gomock.Any(), "parent1").
+			Return(&domain.BlogComment{ID: "parent1", PostID: "post2", Status: domain.BlogCommentStatusApproved}, nil)
+
+		req := newRequest()
+		parentID := "parent1"
+		req.ParentID = &parentID
+
+		_, err := service.CreatePublicComment(ctx, "ws1", req)
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+
+	t.Run("returns not found when the post disables comments", func(t *testing.T) {
+		service, m := setupBlogCommentServiceTest(t)
+
+		post := publishedCommentPost()
+		post.Settings.CommentsDisabled = true
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(commentsWorkspace(domain.BlogCommentSettings{}), nil)
+		m.postRepo.EXPECT().GetPost(gomock.Any(), "post1").Return(post, nil)
+
+		_, err := service.CreatePublicComment(ctx, "ws1", newRequest())
+		assert.IsType(t, &domain.ErrNotFound{}, err)
+	})
+}
+
+func TestBlogCommentService_VerifyPublicComment(t *testing.T) {
+	ctx := context.WithValue(context.Background(), domain.WorkspaceIDKey, "ws1")
+	email := "reader@example.com"
+
+	t.Run("approves the comment with auto moderation and returns its URL", func(t *testing.T) {
+		service, m := setupBlogCommentServiceTest(t)
+
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").
+			Return(commentsWorkspace(domain.BlogCommentSettings{Moderation: domain.BlogCommentModerationAuto}), nil).Times(2)
+		m.commentRepo.EXPECT().GetComment(gomock.Any(), "c1").
+			Return(&domain.BlogComment{ID: "c1", PostID: "post1", Email: email, Status: domain.BlogCommentStatusUnverified}, nil)
+		m.postRepo.EXPECT().GetPost(gomock.Any(), "post1").Return(publishedCommentPost(), nil)
+		m.commentRepo.EXPECT().UpdateComment(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, comment *domain.BlogComment) error {
+				assert.Equal(t, domain.BlogCommentStatusApproved, comment.Status)
+				assert.NotNil(t, comment.ApprovedAt)
+				return nil
+			})
+		m.categoryRepo.EXPECT().GetCategory(gomock.Any(), "cat1").Return(&domain.BlogCategory{ID: "cat1", Slug: "news"}, nil)
+
+		postURL, err := service.VerifyPublicComment(ctx, "ws1", &domain.VerifyBlogCommentRequest{
+			CommentID: "c1",
+			Email:     email,
+			EmailHMAC: domain.ComputeEmailHMAC(email, "secret"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://blog.example.com/news/launch#comment-c1", postURL)
+	})
+
+	t.Run("rejects the email of another commenter", func(t *testing.T) {
+		service, m := setupBlogCommentServiceTest(t)
+
+		other := "other@example.com"
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(commentsWorkspace(domain.BlogCommentSettings{}), nil)
+		m.commentRepo.EXPECT().GetComment(gomock.Any(), "c1").
+			Return(&domain.BlogComment{ID: "c1", PostID: "post1", Email: email, Status: domain.BlogCommentStatusUnverified}, nil)
+
+		_, err := service.VerifyPublicComment(ctx, "ws1", &domain.VerifyBlogCommentRequest{
+			CommentID: "c1",
+			Email:     other,
+			EmailHMAC: domain.ComputeEmailHMAC(other, "secret"),
+		})
+		assert.IsType(t, &domain.ErrNotFound{}, err)
+	})
+}
+
+func TestBlogCommentService_ReactToPost(t *testing.T) {
+	ctx := context.WithValue(context.Background(), domain.WorkspaceIDKey, "ws1")
+	email := "reader@example.com"
+
+	t.Run("adds a reaction and returns the counts", func(t *testing.T) {
+		service, m := setupBlogCommentServiceTest(t)
+
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(commentsWorkspace(domain.BlogCommentSettings{}), nil)
+		m.postRepo.EXPECT().GetPost(gomock.Any(), "post1").Return(publishedCommentPost(), nil)
+		m.commentRepo.EXPECT().AddReaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, reaction *domain.BlogPostReaction) error {
+				assert.Equal(t, email, reaction.Email)
+				assert.Equal(t, "love", reaction.Reaction)
+				return nil
+			})
+		m.commentRepo.EXPECT().CountReactions(gomock.Any(), "post1").Return(map[string]int{"love": 1}, nil)
+
+		counts, err := service.ReactToPost(ctx, "ws1", &domain.BlogReactionRequest{
+			PostID:    "post1",
+			Reaction:  "love",
+			Email:     " Reader@Example.com",
+			EmailHMAC: domain.ComputeEmailHMAC(email, "secret"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"love": 1}, counts)
+	})
+
+	t.Run("removes a reaction", func(t *testing.T) {
+		service, m := setupBlogCommentServiceTest(t)
+
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(commentsWorkspace(domain.BlogCommentSettings{}), nil)
+		m.postRepo.EXPECT().GetPost(gomock.Any(), "post1").Return(publishedCommentPost(), nil)
+		m.commentRepo.EXPECT().RemoveReaction(gomock.Any(), "post1", email, "like").Return(nil)
+		m.commentRepo.EXPECT().CountReactions(gomock.Any(), "post1").Return(map[string]int{}, nil)
+
+		_, err := service.ReactToPost(ctx, "ws1", &domain.BlogReactionRequest{
+			PostID:    "post1",
+			Reaction:  "like",
+			Email:     email,
+			EmailHMAC: domain.ComputeEmailHMAC(email, "secret"),
+			Remove:    true,
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestBlogCommentService_ModerateComment(t *testing.T) {
+	t.Run("approving clears the spam reasons", func(t *testing.T) {
+		service, m := setupBlogCommentServiceTest(t)
+		ctx := setupBlogContextWithAuth(m.authService, "ws1", true, true)
+
+		m.commentRepo.EXPECT().GetComment(gomock.Any(), "c1").
+			Return(&domain.BlogComment{ID: "c1", Status: domain.BlogCommentStatusSpam, SpamReasons: []string{"duplicate"}}, nil)
+		m.commentRepo.EXPECT().UpdateComment(gomock.Any(), gomock.Any()).Return(nil)
+
+		comment, err := service.ModerateComment(ctx, &domain.ModerateBlogCommentRequest{ID: "c1", Status: domain.BlogCommentStatusApproved})
+		require.NoError(t, err)
+		assert.Equal(t, domain.BlogCommentStatusApproved, comment.Status)
+		assert.Nil(t, comment.SpamReasons)
+		assert.NotNil(t, comment.ApprovedAt)
+	})
+
+	t.Run("requires write permission", func(t *testing.T) {
+		service, m := setupBlogCommentServiceTest(t)
+		ctx := setupBlogContextWithAuth(m.authService, "ws1", true, false)
+
+		_, err := service.ModerateComment(ctx, &domain.ModerateBlogCommentRequest{ID: "c1", Status: domain.BlogCommentStatusApproved})
+		var permErr *domain.PermissionError
+		assert.True(t, errors.As(err, &permErr))
+	})
+}
+
+func TestBlogCommentService_ListComments(t *testing.T) {
+	service, m := setupBlogCommentServiceTest(t)
+	ctx := setupBlogContextWithAuth(m.authService, "ws1", true, false)
+
+	m.commentRepo.EXPECT().ListComments(gomock.Any(), domain.ListBlogCommentsRequest{Status: domain.BlogCommentStatusPending, Limit: 50}).
+		Return(&domain.BlogCommentListResponse{TotalCount: 0}, nil)
+
+	_, err := service.ListComments(ctx, &domain.ListBlogCommentsRequest{})
+	require.NoError(t, err)
+}
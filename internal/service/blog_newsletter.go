@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// ==============================
+// Newsletter Operations
+// ==============================
+
+// categoryNewsletter returns the newsletter automatically sent for the posts of a
+// category, or nil when the category does not auto-send
+func (s *BlogService) categoryNewsletter(ctx context.Context, categoryID string) *domain.BlogNewsletterSettings {
+	category, err := s.categoryRepo.GetCategory(ctx, categoryID)
+	if err != nil {
+		s.logger.WithField("category_id", categoryID).Warn(fmt.Sprintf("Failed to get category for newsletter: %v", err))
+		return nil
+	}
+
+	if category.Settings.Newsletter == nil || !category.Settings.Newsletter.AutoSend {
+		return nil
+	}
+
+	settings := category.Settings.Newsletter.BlogNewsletterSettings
+	return &settings
+}
+
+// checkNewsletterPermission checks that the user in context can create the broadcasts
+// sent by blog newsletters
+func checkNewsletterPermission(ctx context.Context, userWorkspace *domain.UserWorkspace) error {
+	if ctx.Value(domain.SystemCallKey) != nil {
+		return nil
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceBroadcasts, domain.PermissionTypeWrite) {
+		return domain.NewPermissionError(
+			domain.PermissionResourceBroadcasts,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to broadcasts required to send newsletters",
+		)
+	}
+
+	return nil
+}
+
+// newsletterUnchanged reports whether a category update keeps the newsletter settings
+// as they are, which does not need broadcast permissions
+func newsletterUnchanged(current, updated *domain.BlogCategoryNewsletterSettings) bool {
+	return reflect.DeepEqual(current, updated)
+}
+
+// sendPostNewsletter creates the broadcast sending a published post to subscribers,
+// schedules it and records it on the post. Broadcast permissions are checked by the
+// broadcast service unless the context is a system call.
+func (s *BlogService) sendPostNewsletter(ctx context.Context, workspaceID string, post *domain.BlogPost, settings *domain.BlogNewsletterSettings) (*domain.Broadcast, error) {
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	category, err := s.categoryRepo.GetCategory(ctx, post.CategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	// The email template links to the headings of the post page
+	tocItems := []domain.TOCItem{}
+	template, err := s.templateRepo.GetTemplateByID(ctx, workspaceID, post.Settings.Template.TemplateID, int64(post.Settings.Template.TemplateVersion))
+	if err != nil {
+		s.logger.WithField("post_id", post.ID).Warn(fmt.Sprintf("Failed to get post template for newsletter: %v", err))
+	} else if template.Web != nil && template.Web.HTML != "" {
+		if items, _, err := ExtractTableOfContents(template.Web.HTML); err == nil {
+			tocItems = items
+		}
+	}
+
+	utm := &domain.UTMParameters{}
+	if settings.UTMParameters != nil {
+		*utm = *settings.UTMParameters
+	}
+	if utm.Source == "" {
+		utm.Source = "newsletter"
+	}
+	if utm.Medium == "" {
+		utm.Medium = "email"
+	}
+	if utm.Campaign == "" {
+		utm.Campaign = post.Slug
+	}
+
+	// Post titles can be longer than broadcast names
+	name := post.Settings.Title
+	if len(name) > 255 {
+		name = strings.ToValidUTF8(name[:255], "")
+	}
+
+	broadcast, err := s.broadcastService.CreateBroadcast(ctx, &domain.CreateBroadcastRequest{
+		WorkspaceID: workspaceID,
+		Name:        name,
+		Audience:    settings.Audience,
+		TestSettings: domain.BroadcastTestSettings{
+			Variations: []domain.BroadcastVariation{
+				{VariationName: "Default", TemplateID: settings.TemplateID},
+			},
+		},
+		UTMParameters: utm,
+		BlogPost: &domain.BroadcastBlogPost{
+			PostID:           post.ID,
+			Title:            post.Settings.Title,
+			Excerpt:          post.Settings.Excerpt,
+			FeaturedImageURL: post.Settings.FeaturedImageURL,
+			URL:              buildPostURL(workspaceBlogOrigin(workspace), category.Slug, post.Slug),
+			PublishedAt:      post.PublishedAt,
+			TableOfContents:  tocItems,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create newsletter broadcast: %w", err)
+	}
+
+	scheduleRequest := &domain.ScheduleBroadcastRequest{
+		WorkspaceID: workspaceID,
+		ID:          broadcast.ID,
+		SendNow:     settings.DelayMinutes == 0,
+	}
+	if !scheduleRequest.SendNow {
+		sendAt := time.Now().UTC().Add(time.Duration(settings.DelayMinutes) * time.Minute)
+		scheduleRequest.ScheduledDate = sendAt.Format("2006-01-02")
+		scheduleRequest.ScheduledTime = sendAt.Format("15:04")
+		scheduleRequest.Timezone = "UTC"
+	}
+
+	if err := s.broadcastService.ScheduleBroadcast(ctx, scheduleRequest); err != nil {
+		return nil, fmt.Errorf("failed to schedule newsletter broadcast %s: %w", broadcast.ID, err)
+	}
+
+	post.Settings.NewsletterBroadcastID = broadcast.ID
+	post.UpdatedAt = time.Now().UTC()
+	if err := s.postRepo.UpdatePost(ctx, post); err != nil {
+		return nil, fmt.Errorf("failed to link newsletter broadcast to post: %w", err)
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"post_id":      post.ID,
+		"broadcast_id": broadcast.ID,
+	}).Info("Blog post newsletter scheduled")
+
+	return broadcast, nil
+}
+
+// sendCategoryNewsletter sends a newly published post with the newsletter of its
+// category, if the category auto-sends and the post was not sent already. It runs as a
+// system call: the newsletter was set up by a user allowed to create broadcasts.
+func (s *BlogService) sendCategoryNewsletter(ctx context.Context, workspaceID string, post *domain.BlogPost) error {
+	if post.Settings.NewsletterBroadcastID != "" {
+		return nil
+	}
+
+	settings := s.categoryNewsletter(ctx, post.CategoryID)
+	if settings == nil {
+		return nil
+	}
+
+	systemCtx := context.WithValue(ctx, domain.SystemCallKey, true)
+	_, err := s.sendPostNewsletter(systemCtx, workspaceID, post, settings)
+	return err
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newNewsletterSettings(delayMinutes int) *domain.BlogNewsletterSettings {
+	return &domain.BlogNewsletterSettings{
+		TemplateID:   "newsletter-tpl",
+		Audience:     domain.AudienceSettings{List: "subscribers"},
+		DelayMinutes: delayMinutes,
+	}
+}
+
+// setupNewsletterContextWithAuth authenticates a user with write access to the blog and,
+// optionally, to broadcasts
+func setupNewsletterContextWithAuth(mockAuthService *mocks.MockAuthService, broadcastsWrite bool) context.Context {
+	ctx := context.WithValue(context.Background(), domain.WorkspaceIDKey, "workspace123")
+
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user123",
+		WorkspaceID: "workspace123",
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceBlog:       domain.ResourcePermissions{Read: true, Write: true},
+			domain.PermissionResourceBroadcasts: domain.ResourcePermissions{Read: true, Write: broadcastsWrite},
+		},
+	}
+
+	mockAuthService.EXPECT().
+		AuthenticateUserForWorkspace(gomock.Any(), "workspace123").
+		Return(ctx, &domain.User{ID: "user123"}, userWorkspace, nil).
+		Times(1)
+
+	return ctx
+}
+
+func expectNewsletterPostData(mockWorkspaceRepo *mocks.MockWorkspaceRepository, mockCategoryRepo *mocks.MockBlogCategoryRepository, mockTemplateRepo *mocks.MockTemplateRepository) {
+	mockWorkspaceRepo.EXPECT().
+		GetByID(gomock.Any(), "workspace123").
+		Return(&domain.Workspace{ID: "workspace123", Settings: domain.WorkspaceSettings{WebsiteURL: "https://blog.example.com"}}, nil)
+	mockCategoryRepo.EXPECT().
+		GetCategory(gomock.Any(), "cat123").
+		Return(&domain.BlogCategory{ID: "cat123", Slug: "news"}, nil)
+	mockTemplateRepo.EXPECT().
+		GetTemplateByID(gomock.Any(), "workspace123", "tpl123", int64(1)).
+		Return(&domain.Template{ID: "tpl123", Web: &domain.WebTemplate{HTML: `<h2 id="intro">Intro</h2><p>Hello</p>`}}, nil)
+}
+
+func TestBlogService_PublishPost_Newsletter(t *testing.T) {
+	t.Run("creates, schedules and links the newsletter broadcast", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, mockAuthService := setupBlogServiceTest(t)
+		mockBroadcastService := mocks.NewMockBroadcastService(gomock.NewController(t))
+		service.broadcastService = mockBroadcastService
+		ctx := setupNewsletterContextWithAuth(mockAuthService, true)
+
+		post := newRevisionTestPost(false)
+		post.Settings.Excerpt = "We launched"
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(post, nil)
+		mockPostRepo.EXPECT().PublishPost(gomock.Any(), "post123", gomock.Any()).Return(nil)
+		expectNewsletterPostData(mockWorkspaceRepo, mockCategoryRepo, mockTemplateRepo)
+
+		mockBroadcastService.EXPECT().
+			CreateBroadcast(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, req *domain.CreateBroadcastRequest) (*domain.Broadcast, error) {
+				assert.Nil(t, ctx.Value(domain.SystemCallKey))
+				assert.Equal(t, "Launch", req.Name)
+				assert.Equal(t, "subscribers", req.Audience.List)
+				require.Len(t, req.TestSettings.Variations, 1)
+				assert.Equal(t, "newsletter-tpl", req.TestSettings.Variations[0].TemplateID)
+				require.NotNil(t, req.UTMParameters)
+				assert.Equal(t, "newsletter", req.UTMParameters.Source)
+				assert.Equal(t, "email", req.UTMParameters.Medium)
+				assert.Equal(t, "launch", req.UTMParameters.Campaign)
+				require.NotNil(t, req.BlogPost)
+				assert.Equal(t, "post123", req.BlogPost.PostID)
+				assert.Equal(t, "We launched", req.BlogPost.Excerpt)
+				assert.Equal(t, "https://blog.example.com/news/launch", req.BlogPost.URL)
+				assert.NotNil(t, req.BlogPost.PublishedAt)
+				require.Len(t, req.BlogPost.TableOfContents, 1)
+				assert.Equal(t, "intro", req.BlogPost.TableOfContents[0].ID)
+				return &domain.Broadcast{ID: "broadcast123"}, nil
+			})
+		mockBroadcastService.EXPECT().
+			ScheduleBroadcast(gomock.Any(), &domain.ScheduleBroadcastRequest{WorkspaceID: "workspace123", ID: "broadcast123", SendNow: true}).
+			Return(nil)
+		mockPostRepo.EXPECT().
+			UpdatePost(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, post *domain.BlogPost) error {
+				assert.Equal(t, "broadcast123", post.Settings.NewsletterBroadcastID)
+				return nil
+			})
+
+		err := service.PublishPost(ctx, &domain.PublishBlogPostRequest{ID: "post123", Newsletter: newNewsletterSettings(0)})
+		require.NoError(t, err)
+	})
+
+	t.Run("delays the newsletter", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, mockAuthService := setupBlogServiceTest(t)
+		mockBroadcastService := mocks.NewMockBroadcastService(gomock.NewController(t))
+		service.broadcastService = mockBroadcastService
+		ctx := setupNewsletterContextWithAuth(mockAuthService, true)
+
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(newRevisionTestPost(false), nil)
+		mockPostRepo.EXPECT().PublishPost(gomock.Any(), "post123", gomock.Any()).Return(nil)
+		expectNewsletterPostData(mockWorkspaceRepo, mockCategoryRepo, mockTemplateRepo)
+		mockBroadcastService.EXPECT().CreateBroadcast(gomock.Any(), gomock.Any()).Return(&domain.Broadcast{ID: "broadcast123"}, nil)
+		mockBroadcastService.EXPECT().
+			ScheduleBroadcast(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, req *domain.ScheduleBroadcastRequest) error {
+				assert.False(t, req.SendNow)
+				assert.Equal(t, "UTC", req.Timezone)
+				assert.NotEmpty(t, req.ScheduledDate)
+				assert.NotEmpty(t, req.ScheduledTime)
+				return nil
+			})
+		mockPostRepo.EXPECT().UpdatePost(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := service.PublishPost(ctx, &domain.PublishBlogPostRequest{ID: "post123", Newsletter: newNewsletterSettings(30)})
+		require.NoError(t, err)
+	})
+
+	t.Run("requires write access to broadcasts", func(t *testing.T) {
+		service, _, mockPostRepo, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+		ctx := setupNewsletterContextWithAuth(mockAuthService, false)
+
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(newRevisionTestPost(false), nil)
+
+		err := service.PublishPost(ctx, &domain.PublishBlogPostRequest{ID: "post123", Newsletter: newNewsletterSettings(0)})
+		require.Error(t, err)
+		var permErr *domain.PermissionError
+		assert.True(t, errors.As(err, &permErr))
+	})
+
+	t.Run("reports a failed newsletter after publishing", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, mockAuthService := setupBlogServiceTest(t)
+		mockBroadcastService := mocks.NewMockBroadcastService(gomock.NewController(t))
+		service.broadcastService = mockBroadcastService
+		ctx := setupNewsletterContextWithAuth(mockAuthService, true)
+
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(newRevisionTestPost(false), nil)
+		mockPostRepo.EXPECT().PublishPost(gomock.Any(), "post123", gomock.Any()).Return(nil)
+		expectNewsletterPostData(mockWorkspaceRepo, mockCategoryRepo, mockTemplateRepo)
+		mockBroadcastService.EXPECT().CreateBroadcast(gomock.Any(), gomock.Any()).Return(nil, errors.New("list not found"))
+
+		err := service.PublishPost(ctx, &domain.PublishBlogPostRequest{ID: "post123", Newsletter: newNewsletterSettings(0)})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "post published, but the newsletter could not be sent")
+	})
+
+	t.Run("sends the category newsletter as a system call", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, mockAuthService := setupBlogServiceTest(t)
+		mockBroadcastService := mocks.NewMockBroadcastService(gomock.NewController(t))
+		service.broadcastService = mockBroadcastService
+		ctx := setupBlogContextWithAuth(mockAuthService, "workspace123", true, true)
+
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(newRevisionTestPost(false), nil)
+		mockPostRepo.EXPECT().PublishPost(gomock.Any(), "post123", gomock.Any()).Return(nil)
+		mockCategoryRepo.EXPECT().
+			GetCategory(gomock.Any(), "cat123").
+			Return(&domain.BlogCategory{
+				ID:   "cat123",
+				Slug: "news",
+				Settings: domain.BlogCategorySettings{
+					Newsletter: &domain.BlogCategoryNewsletterSettings{AutoSend: true, BlogNewsletterSettings: *newNewsletterSettings(0)},
+				},
+			}, nil)
+		expectNewsletterPostData(mockWorkspaceRepo, mockCategoryRepo, mockTemplateRepo)
+		mockBroadcastService.EXPECT().
+			CreateBroadcast(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, req *domain.CreateBroadcastRequest) (*domain.Broadcast, error) {
+				assert.NotNil(t, ctx.Value(domain.SystemCallKey))
+				assert.Equal(t, "newsletter-tpl", req.TestSettings.Variations[0].TemplateID)
+				return &domain.Broadcast{ID: "broadcast123"}, nil
+			})
+		mockBroadcastService.EXPECT().ScheduleBroadcast(gomock.Any(), gomock.Any()).Return(nil)
+		mockPostRepo.EXPECT().UpdatePost(gomock.Any(), gomock.Any()).Return(nil)
+
+		err := service.PublishPost(ctx, &domain.PublishBlogPostRequest{ID: "post123"})
+		require.NoError(t, err)
+	})
+
+	t.Run("skips the category newsletter on request", func(t *testing.T) {
+		service, _, mockPostRepo, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+		ctx := setupBlogContextWithAuth(mockAuthService, "workspace123", true, true)
+
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(newRevisionTestPost(false), nil)
+		mockPostRepo.EXPECT().PublishPost(gomock.Any(), "post123", gomock.Any()).Return(nil)
+
+		err := service.PublishPost(ctx, &domain.PublishBlogPostRequest{ID: "post123", SkipNewsletter: true})
+		require.NoError(t, err)
+	})
+
+	t.Run("does not resend the category newsletter of a published post", func(t *testing.T) {
+		service, _, mockPostRepo, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+		ctx := setupBlogContextWithAuth(mockAuthService, "workspace123", true, true)
+
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(newRevisionTestPost(true), nil)
+		mockPostRepo.EXPECT().PublishPost(gomock.Any(), "post123", gomock.Any()).Return(nil)
+
+		err := service.PublishPost(ctx, &domain.PublishBlogPostRequest{ID: "post123"})
+		require.NoError(t, err)
+	})
+}
+
+func TestBlogService_CreateCategory_Newsletter(t *testing.T) {
+	t.Run("requires write access to broadcasts to auto-send", func(t *testing.T) {
+		service, _, _, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+		ctx := setupNewsletterContextWithAuth(mockAuthService, false)
+
+		_, err := service.CreateCategory(ctx, &domain.CreateBlogCategoryRequest{
+			Name:       "News",
+			Slug:       "news",
+			Newsletter: &domain.BlogCategoryNewsletterSettings{AutoSend: true, BlogNewsletterSettings: *newNewsletterSettings(0)},
+		})
+		require.Error(t, err)
+		var permErr *domain.PermissionError
+		assert.True(t, errors.As(err, &permErr))
+	})
+
+	t.Run("stores the newsletter settings", func(t *testing.T) {
+		service, mockCategoryRepo, _, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+		ctx := setupNewsletterContextWithAuth(mockAuthService, true)
+
+		mockCategoryRepo.EXPECT().GetCategoryBySlug(gomock.Any(), "news").Return(nil, errors.New("not found"))
+		mockCategoryRepo.EXPECT().
+			CreateCategory(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, category *domain.BlogCategory) error {
+				require.NotNil(t, category.Settings.Newsletter)
+				assert.True(t, category.Settings.Newsletter.AutoSend)
+				assert.Equal(t, "newsletter-tpl", category.Settings.Newsletter.TemplateID)
+				return nil
+			})
+
+		_, err := service.CreateCategory(ctx, &domain.CreateBlogCategoryRequest{
+			Name:       "News",
+			Slug:       "news",
+			Newsletter: &domain.BlogCategoryNewsletterSettings{AutoSend: true, BlogNewsletterSettings: *newNewsletterSettings(0)},
+		})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// scheduledPostPublisher publishes a scheduled blog post, implemented by BlogService
+type scheduledPostPublisher interface {
+	publishScheduledPost(ctx context.Context, workspaceID, postID string, scheduledFor time.Time) error
+}
+
+// BlogPostPublishTaskProcessor publishes blog posts at their scheduled time. Each schedule
+// creates a task due at that time; tasks whose schedule has since been replaced or
+// cancelled complete without effect.
+type BlogPostPublishTaskProcessor struct {
+	publisher scheduledPostPublisher
+	logger    logger.Logger
+}
+
+// NewBlogPostPublishTaskProcessor creates a new scheduled blog post publishing task processor
+func NewBlogPostPublishTaskProcessor(blogService *BlogService, logger logger.Logger) *BlogPostPublishTaskProcessor {
+	return &BlogPostPublishTaskProcessor{
+		publisher: blogService,
+		logger:    logger,
+	}
+}
+
+// CanProcess returns whether this processor can handle the given task type
+func (p *BlogPostPublishTaskProcessor) CanProcess(taskType string) bool {
+	return taskType == "publish_blog_post"
+}
+
+// Process publishes the post of the task
+func (p *BlogPostPublishTaskProcessor) Process(ctx context.Context, task *domain.Task, timeoutAt time.Time) (bool, error) {
+	if task.State == nil || task.State.PublishBlogPost == nil {
+		return false, fmt.Errorf("publish blog post state is missing")
+	}
+	state := task.State.PublishBlogPost
+
+	if err := p.publisher.publishScheduledPost(ctx, task.WorkspaceID, state.PostID, state.ScheduledFor); err != nil {
+		p.logger.WithFields(map[string]interface{}{
+			"task_id":      task.ID,
+			"workspace_id": task.WorkspaceID,
+			"post_id":      state.PostID,
+			"error":        err.Error(),
+		}).Error("Failed to publish scheduled blog post")
+		return false, err
+	}
+
+	task.State.Progress = 100
+	task.State.Message = "Scheduled publication processed"
+	return true, nil
+}
@@ -0,0 +1,425 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ==============================
+// Scheduling & Revision Operations
+// ==============================
+
+// authenticateBlogUser authenticates the user of the workspace in context and checks
+// their blog permission
+func (s *BlogService) authenticateBlogUser(ctx context.Context, permissionType domain.PermissionType) (context.Context, string, *domain.User, error) {
+	workspaceID, ok := ctx.Value(domain.WorkspaceIDKey).(string)
+	if !ok {
+		return nil, "", nil, fmt.Errorf("workspace_id not found in context")
+	}
+
+	ctx, user, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceBlog, permissionType) {
+		return nil, "", nil, domain.NewPermissionError(
+			domain.PermissionResourceBlog,
+			permissionType,
+			fmt.Sprintf("Insufficient permissions: %s access to blog required", permissionType),
+		)
+	}
+
+	return ctx, workspaceID, user, nil
+}
+
+// blogRevisionAuthor returns the ID of the user recording a revision
+func blogRevisionAuthor(user *domain.User) string {
+	if user == nil {
+		return ""
+	}
+	return user.ID
+}
+
+// checkPostSlugAvailable returns an error when another post already uses the slug
+func (s *BlogService) checkPostSlugAvailable(ctx context.Context, postID, slug string) error {
+	existing, err := s.postRepo.GetPostBySlug(ctx, slug)
+	if err == nil && existing != nil && existing.ID != postID {
+		return fmt.Errorf("post with slug '%s' already exists", slug)
+	}
+	return nil
+}
+
+// SchedulePost schedules the publication of a post. A draft post is published at the
+// scheduled time; a published post gets its latest draft revision promoted instead.
+// Scheduling again replaces the previous schedule, and a nil time cancels it.
+func (s *BlogService) SchedulePost(ctx context.Context, request *domain.ScheduleBlogPostRequest) (*domain.BlogPost, error) {
+	ctx, workspaceID, _, err := s.authenticateBlogUser(ctx, domain.PermissionTypeWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	post, err := s.postRepo.GetPost(ctx, request.ID)
+	if err != nil {
+		return nil, fmt.Errorf("post not found: %w", err)
+	}
+
+	if request.ScheduledPublishAt == nil {
+		post.ScheduledPublishAt = nil
+	} else {
+		if post.IsPublished() {
+			revisions, err := s.postRepo.ListRevisions(ctx, post.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list post revisions: %w", err)
+			}
+			if len(revisions) == 0 || revisions[0].Status != domain.BlogPostRevisionStatusDraft {
+				return nil, domain.NewValidationError("the post is already published and has no draft revision to promote")
+			}
+		}
+
+		// The task compares this time with the post when it runs, keep it at a
+		// precision the database preserves
+		scheduledAt := request.ScheduledPublishAt.UTC().Truncate(time.Second)
+		post.ScheduledPublishAt = &scheduledAt
+
+		// The task is created first: should the post update fail, it finds the post
+		// without this schedule and does nothing
+		task := &domain.Task{
+			ID:          uuid.New().String(),
+			WorkspaceID: workspaceID,
+			Type:        "publish_blog_post",
+			Status:      domain.TaskStatusPending,
+			State: &domain.TaskState{
+				PublishBlogPost: &domain.PublishBlogPostState{
+					PostID:       post.ID,
+					ScheduledFor: scheduledAt,
+				},
+			},
+			NextRunAfter:  &scheduledAt,
+			MaxRuntime:    60,
+			MaxRetries:    3,
+			RetryInterval: 60,
+		}
+		if err := s.taskService.CreateTask(ctx, workspaceID, task); err != nil {
+			s.logger.WithField("post_id", post.ID).Error(fmt.Sprintf("Failed to create publish task: %v", err))
+			return nil, fmt.Errorf("failed to schedule post: %w", err)
+		}
+	}
+
+	post.UpdatedAt = time.Now().UTC()
+	if err := s.postRepo.UpdatePost(ctx, post); err != nil {
+		s.logger.WithField("post_id", post.ID).Error("Failed to update post schedule")
+		return nil, fmt.Errorf("failed to schedule post: %w", err)
+	}
+
+	return post, nil
+}
+
+// publishScheduledPost runs the scheduled publication of a post for the publish_blog_post
+// task. It does nothing when the post has been rescheduled or unscheduled since.
+func (s *BlogService) publishScheduledPost(ctx context.Context, workspaceID, postID string, scheduledFor time.Time) error {
+	ctx = context.WithValue(ctx, domain.WorkspaceIDKey, workspaceID)
+
+	post, err := s.postRepo.GetPost(ctx, postID)
+	if err != nil {
+		if err.Error() == "blog post not found" {
+			s.logger.WithField("post_id", postID).Info("Scheduled post was deleted, skipping publication")
+			return nil
+		}
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+
+	if post.ScheduledPublishAt == nil || !post.ScheduledPublishAt.Equal(scheduledFor) {
+		s.logger.WithField("post_id", postID).Info("Post schedule changed, skipping stale publication")
+		return nil
+	}
+
+	if !post.IsPublished() {
+		publishedAt := scheduledFor.UTC()
+		post.PublishedAt = &publishedAt
+		post.ScheduledPublishAt = nil
+		post.UpdatedAt = time.Now().UTC()
+		if err := s.postRepo.UpdatePost(ctx, post); err != nil {
+			return fmt.Errorf("failed to publish post: %w", err)
+		}
+		s.clearBlogCache(workspaceID)
+
+		// The post is published, a failed newsletter must not publish it again on retry
+		if err := s.sendCategoryNewsletter(ctx, workspaceID, post); err != nil {
+			s.logger.WithField("post_id", postID).Error(fmt.Sprintf("Failed to send scheduled post newsletter: %v", err))
+		}
+		return nil
+	}
+
+	revisions, err := s.postRepo.ListRevisions(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("failed to list post revisions: %w", err)
+	}
+
+	if len(revisions) == 0 || revisions[0].Status != domain.BlogPostRevisionStatusDraft {
+		s.logger.WithField("post_id", postID).Warn("Scheduled post has no draft revision to promote")
+		post.ScheduledPublishAt = nil
+		if err := s.postRepo.UpdatePost(ctx, post); err != nil {
+			return fmt.Errorf("failed to clear post schedule: %w", err)
+		}
+		return nil
+	}
+
+	return s.promoteRevision(ctx, workspaceID, post, revisions[0])
+}
+
+// SaveDraftRevision records edits of a published post as a draft revision, leaving the
+// live post untouched until the revision is promoted
+func (s *BlogService) SaveDraftRevision(ctx context.Context, request *domain.UpdateBlogPostRequest) (*domain.BlogPostRevision, error) {
+	ctx, workspaceID, user, err := s.authenticateBlogUser(ctx, domain.PermissionTypeWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	post, err := s.postRepo.GetPost(ctx, request.ID)
+	if err != nil {
+		return nil, fmt.Errorf("post not found: %w", err)
+	}
+
+	if !post.IsPublished() {
+		return nil, domain.NewValidationError("the post is not published, update it directly")
+	}
+
+	if post.Slug != request.Slug {
+		if err := s.checkPostSlugAvailable(ctx, post.ID, request.Slug); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.categoryRepo.GetCategory(ctx, request.CategoryID); err != nil {
+		return nil, fmt.Errorf("category not found: %w", err)
+	}
+
+	draft := *post
+	applyBlogPostUpdate(&draft, request)
+	if err := draft.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPostTranslations(ctx, workspaceID, &draft); err != nil {
+		return nil, err
+	}
+
+	token, err := randToken(24)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate preview token: %w", err)
+	}
+
+	revision := domain.NewBlogPostRevision(&draft, domain.BlogPostRevisionStatusDraft, blogRevisionAuthor(user))
+	revision.PreviewToken = token
+
+	if err := s.postRepo.CreateRevision(ctx, revision); err != nil {
+		s.logger.WithField("post_id", post.ID).Error("Failed to save draft revision")
+		return nil, fmt.Errorf("failed to save draft revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+// ListPostRevisions lists the revisions of a post, newest first
+func (s *BlogService) ListPostRevisions(ctx context.Context, postID string) ([]*domain.BlogPostRevision, error) {
+	ctx, _, _, err := s.authenticateBlogUser(ctx, domain.PermissionTypeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	if postID == "" {
+		return nil, domain.NewValidationError("id is required")
+	}
+
+	return s.postRepo.ListRevisions(ctx, postID)
+}
+
+// PromoteRevision makes a draft revision the live content of its post
+func (s *BlogService) PromoteRevision(ctx context.Context, request *domain.BlogPostRevisionRequest) (*domain.BlogPost, error) {
+	ctx, workspaceID, _, err := s.authenticateBlogUser(ctx, domain.PermissionTypeWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	post, revision, err := s.getPostRevision(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if revision.Status != domain.BlogPostRevisionStatusDraft {
+		return nil, domain.NewValidationError(fmt.Sprintf("revision %d is not a draft", revision.Version))
+	}
+
+	if err := s.promoteRevision(ctx, workspaceID, post, revision); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// promoteRevision writes a draft revision to its post and marks it applied. A pending
+// schedule of a published post is consumed by the promotion.
+func (s *BlogService) promoteRevision(ctx context.Context, workspaceID string, post *domain.BlogPost, revision *domain.BlogPostRevision) error {
+	revision.ApplyTo(post)
+	if post.IsPublished() {
+		post.ScheduledPublishAt = nil
+	}
+	post.UpdatedAt = time.Now().UTC()
+
+	if err := post.Validate(); err != nil {
+		return err
+	}
+
+	err := s.postRepo.WithTransaction(ctx, workspaceID, func(tx *sql.Tx) error {
+		if err := s.postRepo.UpdatePostTx(ctx, tx, post); err != nil {
+			return err
+		}
+		return s.postRepo.MarkRevisionAppliedTx(ctx, tx, post.ID, revision.Version)
+	})
+	if err != nil {
+		s.logger.WithField("post_id", post.ID).Error("Failed to promote revision")
+		return fmt.Errorf("failed to promote revision: %w", err)
+	}
+
+	s.refreshPostSearchIndex(ctx, workspaceID, post)
+	s.clearBlogCache(workspaceID)
+	return nil
+}
+
+// RestoreRevision writes the content of a previous revision back to its post, recording
+// it as a new revision so the history stays linear
+func (s *BlogService) RestoreRevision(ctx context.Context, request *domain.BlogPostRevisionRequest) (*domain.BlogPost, error) {
+	ctx, workspaceID, user, err := s.authenticateBlogUser(ctx, domain.PermissionTypeWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	post, revision, err := s.getPostRevision(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if revision.Status == domain.BlogPostRevisionStatusDraft {
+		return nil, domain.NewValidationError(fmt.Sprintf("revision %d is a draft, promote it instead", revision.Version))
+	}
+
+	revision.ApplyTo(post)
+	post.UpdatedAt = time.Now().UTC()
+	if err := post.Validate(); err != nil {
+		return nil, err
+	}
+
+	restored := domain.NewBlogPostRevision(post, domain.BlogPostRevisionStatusApplied, blogRevisionAuthor(user))
+	restored.RestoredFrom = &revision.Version
+
+	err = s.postRepo.WithTransaction(ctx, workspaceID, func(tx *sql.Tx) error {
+		if err := s.postRepo.UpdatePostTx(ctx, tx, post); err != nil {
+			return err
+		}
+		return s.postRepo.CreateRevisionTx(ctx, tx, restored)
+	})
+	if err != nil {
+		s.logger.WithField("post_id", post.ID).Error("Failed to restore revision")
+		return nil, fmt.Errorf("failed to restore revision: %w", err)
+	}
+
+	s.refreshPostSearchIndex(ctx, workspaceID, post)
+	s.clearBlogCache(workspaceID)
+	return post, nil
+}
+
+// getPostRevision loads a post and one of its revisions, checking that the content of
+// the revision can still be applied to the post
+func (s *BlogService) getPostRevision(ctx context.Context, request *domain.BlogPostRevisionRequest) (*domain.BlogPost, *domain.BlogPostRevision, error) {
+	post, err := s.postRepo.GetPost(ctx, request.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("post not found: %w", err)
+	}
+
+	revision, err := s.postRepo.GetRevision(ctx, post.ID, request.Version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if revision.Slug != post.Slug {
+		if err := s.checkPostSlugAvailable(ctx, post.ID, revision.Slug); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if _, err := s.categoryRepo.GetCategory(ctx, revision.CategoryID); err != nil {
+		return nil, nil, fmt.Errorf("category not found: %w", err)
+	}
+
+	return post, revision, nil
+}
+
+// RenderPostPreview renders the post page with the content of the draft revision matching
+// the preview token. Pages rendered here are never cached.
+func (s *BlogService) RenderPostPreview(ctx context.Context, workspaceID, previewToken string, themeVersion *int) (string, error) {
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return "", &domain.BlogRenderError{
+			Code:    domain.ErrCodeRenderFailed,
+			Message: "Failed to get workspace",
+			Details: err,
+		}
+	}
+
+	var theme *domain.BlogTheme
+	if themeVersion != nil {
+		theme, err = s.themeRepo.GetTheme(ctx, *themeVersion)
+	} else {
+		theme, err = s.themeRepo.GetPublishedTheme(ctx)
+	}
+	if err != nil {
+		return "", &domain.BlogRenderError{
+			Code:    domain.ErrCodeThemeNotFound,
+			Message: "Failed to get theme",
+			Details: err,
+		}
+	}
+
+	revision, err := s.postRepo.GetRevisionByPreviewToken(ctx, previewToken)
+	if err != nil {
+		return "", &domain.BlogRenderError{
+			Code:    domain.ErrCodePostNotFound,
+			Message: "Preview not found",
+			Details: err,
+		}
+	}
+
+	post, err := s.postRepo.GetPost(ctx, revision.PostID)
+	if err != nil {
+		return "", &domain.BlogRenderError{
+			Code:    domain.ErrCodePostNotFound,
+			Message: "Post not found",
+			Details: err,
+		}
+	}
+	revision.ApplyTo(post)
+
+	return s.renderPostPage(ctx, workspace, theme, post, "", "", true)
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+)
+
+func newRevisionTestPost(published bool) *domain.BlogPost {
+	post := &domain.BlogPost{
+		ID:         "post123",
+		CategoryID: "cat123",
+		Slug:       "launch",
+		Settings: domain.BlogPostSettings{
+			Title:    "Launch",
+			Template: domain.BlogPostTemplateReference{TemplateID: "tpl123", TemplateVersion: 1},
+		},
+		CreatedAt: time.Now().Add(-48 * time.Hour),
+		UpdatedAt: time.Now().Add(-48 * time.Hour),
+	}
+	if published {
+		publishedAt := time.Now().Add(-24 * time.Hour)
+		post.PublishedAt = &publishedAt
+	}
+	return post
+}
+
+func newDraftRevision(version int) *domain.BlogPostRevision {
+	return &domain.BlogPostRevision{
+		PostID:     "post123",
+		Version:    version,
+		CategoryID: "cat123",
+		Slug:       "launch",
+		Settings: domain.BlogPostSettings{
+			Title:    "Launch v2",
+			Template: domain.BlogPostTemplateReference{TemplateID: "tpl123", TemplateVersion: 2},
+		},
+		Status:       domain.BlogPostRevisionStatusDraft,
+		PreviewToken: "preview-token",
+	}
+}
+
+func expectBlogTransaction(mockPostRepo *mocks.MockBlogPostRepository) {
+	mockPostRepo.EXPECT().
+		WithTransaction(gomock.Any(), "workspace123", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, workspaceID string, fn func(*sql.Tx) error) error {
+			return fn(nil)
+		})
+}
+
+func TestBlogService_SchedulePost(t *testing.T) {
+	t.Run("schedules a draft post", func(t *testing.T) {
+		service, _, mockPostRepo, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+		mockTaskService := mocks.NewMockTaskService(gomock.NewController(t))
+		service.taskService = mockTaskService
+		ctx := setupBlogContextWithAuth(mockAuthService, "workspace123", true, true)
+
+		scheduledAt := time.Now().Add(2 * time.Hour).Add(500 * time.Millisecond)
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(newRevisionTestPost(false), nil)
+		mockTaskService.EXPECT().
+			CreateTask(gomock.Any(), "workspace123", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, task *domain.Task) error {
+				assert.Equal(t, "publish_blog_post", task.Type)
+				require.NotNil(t, task.State.PublishBlogPost)
+				assert.Equal(t, "post123", task.State.PublishBlogPost.PostID)
+				assert.Equal(t, scheduledAt.UTC().Truncate(time.Second), task.State.PublishBlogPost.ScheduledFor)
+				require.NotNil(t, task.NextRunAfter)
+				assert.Equal(t, task.State.PublishBlogPost.ScheduledFor, *task.NextRunAfter)
+				return nil
+			})
+		mockPostRepo.EXPECT().UpdatePost(gomock.Any(), gomock.Any()).Return(nil)
+
+		post, err := service.SchedulePost(ctx, &domain.ScheduleBlogPostRequest{ID: "post123", ScheduledPublishAt: &scheduledAt})
+		require.NoError(t, err)
+		require.NotNil(t, post.ScheduledPublishAt)
+		assert.Equal(t, scheduledAt.UTC().Truncate(time.Second), *post.ScheduledPublishAt)
+		assert.True(t, post.IsScheduled())
+	})
+
+	t.Run("cancels the schedule without a task", func(t *testing.T) {
+		service, _, mockPostRepo, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+		ctx := setupBlogContextWithAuth(mockAuthService, "workspace123", true, true)
+
+		post := newRevisionTestPost(false)
+		scheduledAt := time.Now().Add(time.Hour)
+		post.ScheduledPublishAt = &scheduledAt
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(post, nil)
+		mockPostRepo.EXPECT().UpdatePost(gomock.Any(), gomock.Any()).Return(nil)
+
+		result, err := service.SchedulePost(ctx, &domain.ScheduleBlogPostRequest{ID: "post123"})
+		require.NoError(t, err)
+		assert.Nil(t, result.ScheduledPublishAt)
+	})
+
+	t.Run("published post requires a draft revision", func(t *testing.T) {
+		service, _, mockPostRepo, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+		ctx := setupBlogContextWithAuth(mockAuthService, "workspace123", true, true)
+
+		applied := newDraftRevision(2)
+		applied.Status = domain.BlogPostRevisionStatusApplied
+		scheduledAt := time.Now().Add(time.Hour)
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(newRevisionTestPost(true), nil)
+		mockPostRepo.EXPECT().ListRevisions(gomock.Any(), "post123").Return([]*domain.BlogPostRevision{applied}, nil)
+
+		_, err := service.SchedulePost(ctx, &domain.ScheduleBlogPostRequest{ID: "post123", ScheduledPublishAt: &scheduledAt})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no draft revision")
+	})
+
+	t.Run("rejects a past time", func(t *testing.T) {
+		service, _, _, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+		ctx := setupBlogContextWithAuth(mockAuthService, "workspace123", true, true)
+
+		past := time.Now().Add(-time.Hour)
+		_, err := service.SchedulePost(ctx, &domain.ScheduleBlogPostRequest{ID: "post123", ScheduledPublishAt: &past})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be in the future")
+	})
+
+	t.Run("requires write permission", func(t *testing.T) {
+		service, _, _, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+		ctx := setupBlogContextWithAuth(mockAuthService, "workspace123", true, false)
+
+		_, err := service.SchedulePost(ctx, &domain.ScheduleBlogPostRequest{ID: "post123"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Insufficient permissions")
+	})
+}
+
+func TestBlogService_PublishScheduledPost(t *testing.T) {
+	scheduledFor := time.Now().Truncate(time.Second).UTC()
+
+	t.Run("publishes a draft post at the scheduled time", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, _, _, _, _, _ := setupBlogServiceTest(t)
+
+		post := newRevisionTestPost(false)
+		post.ScheduledPublishAt = &scheduledFor
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(post, nil)
+		mockPostRepo.EXPECT().
+			UpdatePost(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, post *domain.BlogPost) error {
+				assert.Equal(t, "workspace123", ctx.Value(domain.WorkspaceIDKey))
+				require.NotNil(t, post.PublishedAt)
+				assert.Equal(t, scheduledFor, *post.PublishedAt)
+				assert.Nil(t, post.ScheduledPublishAt)
+				return nil
+			})
+		mockCategoryRepo.EXPECT().GetCategory(gomock.Any(), post.CategoryID).Return(&domain.BlogCategory{ID: post.CategoryID}, nil)
+
+		err := service.publishScheduledPost(context.Background(), "workspace123", "post123", scheduledFor)
+		require.NoError(t, err)
+	})
+
+	t.Run("skips a stale schedule", func(t *testing.T) {
+		service, _, mockPostRepo, _, _, _, _, _ := setupBlogServiceTest(t)
+
+		post := newRevisionTestPost(false)
+		rescheduled := scheduledFor.Add(time.Hour)
+		post.ScheduledPublishAt = &rescheduled
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(post, nil)
+
+		err := service.publishScheduledPost(context.Background(), "workspace123", "post123", scheduledFor)
+		require.NoError(t, err)
+	})
+
+	t.Run("skips a deleted post", func(t *testing.T) {
+		service, _, mockPostRepo, _, _, _, _, _ := setupBlogServiceTest(t)
+
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(nil, errors.New("blog post not found"))
+
+		err := service.publishScheduledPost(context.Background(), "workspace123", "post123", scheduledFor)
+		require.NoError(t, err)
+	})
+
+	t.Run("promotes the draft revision of a published post", func(t *testing.T) {
+		service, _, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, _ := setupBlogServiceTest(t)
+
+		post := newRevisionTestPost(true)
+		post.ScheduledPublishAt = &scheduledFor
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(post, nil)
+		mockPostRepo.EXPECT().ListRevisions(gomock.Any(), "post123").Return([]*domain.BlogPostRevision{newDraftRevision(3)}, nil)
+		expectBlogTransaction(mockPostRepo)
+		mockPostRepo.EXPECT().
+			UpdatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, tx *sql.Tx, post *domain.BlogPost) error {
+				assert.Equal(t, "Launch v2", post.Settings.Title)
+				assert.Nil(t, post.ScheduledPublishAt)
+				return nil
+			})
+		mockPostRepo.EXPECT().MarkRevisionAppliedTx(gomock.Any(), gomock.Any(), "post123", 3).Return(nil)
+		expectPostSearchIndex(mockWorkspaceRepo, mockTemplateRepo, mockPostRepo)
+
+		err := service.publishScheduledPost(context.Background(), "workspace123", "post123", scheduledFor)
+		require.NoError(t, err)
+	})
+}
+
+func TestBlogService_SaveDraftRevision(t *testing.T) {
+	request := &domain.UpdateBlogPostRequest{
+		ID:              "post123",
+		CategoryID:      "cat123",
+		Slug:            "launch",
+		Title:           "Launch v2",
+		TemplateID:      "tpl123",
+		TemplateVersion: 2,
+	}
+
+	t.Run("records a draft with a preview token", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+		ctx := setupBlogContextWithAuth(mockAuthService, "workspace123", true, true)
+
+		post := newRevisionTestPost(true)
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(post, nil)
+		mockCategoryRepo.EXPECT().GetCategory(gomock.Any(), "cat123").Return(&domain.BlogCategory{ID: "cat123"}, nil)
+		mockPostRepo.EXPECT().CreateRevision(gomock.Any(), gomock.Any()).Return(nil)
+
+		revision, err := service.SaveDraftRevision(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, domain.BlogPostRevisionStatusDraft, revision.Status)
+		assert.Equal(t, "Launch v2", revision.Settings.Title)
+		assert.Equal(t, "user123", revision.CreatedBy)
+		assert.NotEmpty(t, revision.PreviewToken)
+		assert.Nil(t, revision.AppliedAt)
+
+		// The live post is untouched
+		assert.Equal(t, "Launch", post.Settings.Title)
+	})
+
+	t.Run("rejects draft posts", func(t *testing.T) {
+		service, _, mockPostRepo, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+		ctx := setupBlogContextWithAuth(mockAuthService, "workspace123", true, true)
+
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(newRevisionTestPost(false), nil)
+
+		_, err := service.SaveDraftRevision(ctx, request)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not published")
+	})
+}
+
+func TestBlogService_PromoteRevision(t *testing.T) {
+	t.Run("applies the draft to the post", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, mockAuthService := setupBlogServiceTest(t)
+		ctx := setupBlogContextWithAuth(mockAuthService, "workspace123", true, true)
+
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(newRevisionTestPost(true), nil)
+		mockPostRepo.EXPECT().GetRevision(gomock.Any(), "post123", 3).Return(newDraftRevision(3), nil)
+		mockCategoryRepo.EXPECT().GetCategory(gomock.Any(), "cat123").Return(&domain.BlogCategory{ID: "cat123"}, nil)
+		expectBlogTransaction(mockPostRepo)
+		mockPostRepo.EXPECT().UpdatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockPostRepo.EXPECT().MarkRevisionAppliedTx(gomock.Any(), gomock.Any(), "post123", 3).Return(nil)
+		expectPostSearchIndex(mockWorkspaceRepo, mockTemplateRepo, mockPostRepo)
+
+		post, err := service.PromoteRevision(ctx, &domain.BlogPostRevisionRequest{ID: "post123", Version: 3})
+		require.NoError(t, err)
+		assert.Equal(t, "Launch v2", post.Settings.Title)
+		assert.Equal(t, 2, post.Settings.Template.TemplateVersion)
+	})
+
+	t.Run("rejects applied revisions", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+		ctx := setupBlogContextWithAuth(mockAuthService, "workspace123", true, true)
+
+		applied := newDraftRevision(2)
+		applied.Status = domain.BlogPostRevisionStatusApplied
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(newRevisionTestPost(true), nil)
+		mockPostRepo.EXPECT().GetRevision(gomock.Any(), "post123", 2).Return(applied, nil)
+		mockCategoryRepo.EXPECT().GetCategory(gomock.Any(), "cat123").Return(&domain.BlogCategory{ID: "cat123"}, nil)
+
+		_, err := service.PromoteRevision(ctx, &domain.BlogPostRevisionRequest{ID: "post123", Version: 2})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a draft")
+	})
+}
+
+func TestBlogService_RestoreRevision(t *testing.T) {
+	t.Run("records the restore as a new revision", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, mockAuthService := setupBlogServiceTest(t)
+		ctx := setupBlogContextWithAuth(mockAuthService, "workspace123", true, true)
+
+		previous := newDraftRevision(1)
+		previous.Status = domain.BlogPostRevisionStatusApplied
+		previous.Settings.Title = "Launch v0"
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(newRevisionTestPost(true), nil)
+		mockPostRepo.EXPECT().GetRevision(gomock.Any(), "post123", 1).Return(previous, nil)
+		mockCategoryRepo.EXPECT().GetCategory(gomock.Any(), "cat123").Return(&domain.BlogCategory{ID: "cat123"}, nil)
+		expectBlogTransaction(mockPostRepo)
+		mockPostRepo.EXPECT().UpdatePostTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockPostRepo.EXPECT().
+			CreateRevisionTx(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, tx *sql.Tx, revision *domain.BlogPostRevision) error {
+				assert.Equal(t, domain.BlogPostRevisionStatusApplied, revision.Status)
+				assert.Equal(t, "Launch v0", revision.Settings.Title)
+				require.NotNil(t, revision.RestoredFrom)
+				assert.Equal(t, 1, *revision.RestoredFrom)
+				return nil
+			})
+		expectPostSearchIndex(mockWorkspaceRepo, mockTemplateRepo, mockPostRepo)
+
+		post, err := service.RestoreRevision(ctx, &domain.BlogPostRevisionRequest{ID: "post123", Version: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "Launch v0", post.Settings.Title)
+	})
+
+	t.Run("rejects draft revisions", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+		ctx := setupBlogContextWithAuth(mockAuthService, "workspace123", true, true)
+
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(newRevisionTestPost(true), nil)
+		mockPostRepo.EXPECT().GetRevision(gomock.Any(), "post123", 3).Return(newDraftRevision(3), nil)
+		mockCategoryRepo.EXPECT().GetCategory(gomock.Any(), "cat123").Return(&domain.BlogCategory{ID: "cat123"}, nil)
+
+		_, err := service.RestoreRevision(ctx, &domain.BlogPostRevisionRequest{ID: "post123", Version: 3})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "promote it instead")
+	})
+}
+
+func TestBlogPostPublishTaskProcessor(t *testing.T) {
+	service, mockCategoryRepo, mockPostRepo, _, _, _, _, _ := setupBlogServiceTest(t)
+	processor := NewBlogPostPublishTaskProcessor(service, service.logger)
+
+	assert.True(t, processor.CanProcess("publish_blog_post"))
+	assert.False(t, processor.CanProcess("send_broadcast"))
+
+	t.Run("missing state", func(t *testing.T) {
+		_, err := processor.Process(context.Background(), &domain.Task{WorkspaceID: "workspace123", State: &domain.TaskState{}}, time.Now().Add(time.Minute))
+		require.Error(t, err)
+	})
+
+	t.Run("publishes the post", func(t *testing.T) {
+		scheduledFor := time.Now().Truncate(time.Second).UTC()
+		post := newRevisionTestPost(false)
+		post.ScheduledPublishAt = &scheduledFor
+		mockPostRepo.EXPECT().GetPost(gomock.Any(), "post123").Return(post, nil)
+		mockPostRepo.EXPECT().UpdatePost(gomock.Any(), gomock.Any()).Return(nil)
+		mockCategoryRepo.EXPECT().GetCategory(gomock.Any(), post.CategoryID).Return(&domain.BlogCategory{ID: post.CategoryID}, nil)
+
+		task := &domain.Task{
+			ID:          "task123",
+			WorkspaceID: "workspace123",
+			State: &domain.TaskState{
+				PublishBlogPost: &domain.PublishBlogPostState{PostID: "post123", ScheduledFor: scheduledFor},
+			},
+		}
+		completed, err := processor.Process(context.Background(), task, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, completed)
+		assert.Equal(t, float64(100), task.State.Progress)
+	})
+}
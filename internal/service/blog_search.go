@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/liquid"
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ==============================
+// Search Operations
+// ==============================
+
+// postSearchText returns the text of a post body for search indexing: the plain text
+// extracted by the editor, or the text of the rendered HTML
+func postSearchText(template *domain.Template) string {
+	if template == nil || template.Web == nil {
+		return ""
+	}
+	if template.Web.PlainText != "" {
+		return template.Web.PlainText
+	}
+	if template.Web.HTML == "" {
+		return ""
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(template.Web.HTML))
+	if err != nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(doc.Text()), " ")
+}
+
+// refreshPostSearchIndex indexes the content of a post with the text search configuration
+// of the workspace default language. Failures are only logged: the post is saved and is
+// indexed again on its next update.
+func (s *BlogService) refreshPostSearchIndex(ctx context.Context, workspaceID string, post *domain.BlogPost) {
+	searchConfig := domain.TextSearchConfig(domain.DefaultLanguageCode)
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		s.logger.WithField("post_id", post.ID).Warn(fmt.Sprintf("Failed to get workspace for search indexing: %v", err))
+	} else if workspace.Settings.DefaultLanguage != "" {
+		searchConfig = domain.TextSearchConfig(workspace.Settings.DefaultLanguage)
+	}
+
+	template, err := s.templateRepo.GetTemplateByID(ctx, workspaceID, post.Settings.Template.TemplateID, int64(post.Settings.Template.TemplateVersion))
+	if err != nil {
+		s.logger.WithField("post_id", post.ID).Warn(fmt.Sprintf("Failed to get post template for search indexing: %v", err))
+	}
+
+	if err := s.postRepo.UpdatePostSearchIndex(ctx, post.ID, searchConfig, postSearchText(template)); err != nil {
+		s.logger.WithField("post_id", post.ID).Error(fmt.Sprintf("Failed to index post for search: %v", err))
+	}
+}
+
+// SearchPublicPosts searches the published posts (no auth required)
+func (s *BlogService) SearchPublicPosts(ctx context.Context, params *domain.BlogSearchRequest) (*domain.BlogSearchResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	response, err := s.postRepo.SearchPosts(ctx, *params)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.categoryRepo.ListCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	maskGatedSnippets(response.Results, categories)
+
+	return response, nil
+}
+
+// RenderSearchPage renders the search page with the search.liquid template of the theme,
+// or home.liquid for themes without one. Results are exposed as `posts`, with their
+// highlighted `title_highlight` and `snippet`, and the query as `search.query`.
+func (s *BlogService) RenderSearchPage(ctx context.Context, workspaceID, query string, page int, themeVersion *int) (string, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return "", &domain.BlogRenderError{
+			Code:    domain.ErrCodeRenderFailed,
+			Message: "Failed to get workspace",
+			Details: err,
+		}
+	}
+
+	var theme *domain.BlogTheme
+	if themeVersion != nil {
+		theme, err = s.themeRepo.GetTheme(ctx, *themeVersion)
+	} else {
+		theme, err = s.themeRepo.GetPublishedTheme(ctx)
+	}
+	if err != nil {
+		if err.Error() == "no published theme found" || err.Error() == "sql: no rows in result set" {
+			return "", &domain.BlogRenderError{
+				Code:    domain.ErrCodeThemeNotPublished,
+				Message: "No published theme available",
+				Details: err,
+			}
+		}
+		return "", &domain.BlogRenderError{
+			Code:    domain.ErrCodeThemeNotFound,
+			Message: "Failed to get theme",
+			Details: err,
+		}
+	}
+
+	publicLists, err := s.getPublicListsForWorkspace(ctx, workspaceID)
+	if err != nil {
+		s.logger.WithField("error", err.Error()).Warn("Failed to get public lists for blog search page")
+		publicLists = []*domain.List{}
+	}
+
+	// An empty query renders the search form without results
+	searchResponse := &domain.BlogSearchResponse{Query: strings.TrimSpace(query), Results: []*domain.BlogSearchResult{}, CurrentPage: 1}
+	if searchResponse.Query != "" {
+		params := &domain.BlogSearchRequest{Query: query, Page: page}
+		if err := params.Validate(); err != nil {
+			return "", &domain.BlogRenderError{
+				Code:    domain.ErrCodeRenderFailed,
+				Message: "Invalid search parameters",
+				Details: err,
+			}
+		}
+
+		searchResponse, err = s.postRepo.SearchPosts(ctx, *params)
+		if err != nil {
+			s.logger.WithField("error", err.Error()).Warn("Failed to search posts for blog search page")
+			searchResponse = &domain.BlogSearchResponse{Query: params.Query, Results: []*domain.BlogSearchResult{}, CurrentPage: page}
+		}
+	}
+
+	if page > 1 && searchResponse.TotalPages > 0 && page > searchResponse.TotalPages {
+		return "", &domain.BlogRenderError{
+			Code:    domain.ErrCodePostNotFound, // Reuse for page not found
+			Message: fmt.Sprintf("Page %d does not exist (total pages: %d)", page, searchResponse.TotalPages),
+			Details: nil,
+		}
+	}
+
+	categories, err := s.categoryRepo.ListCategories(ctx)
+	if err != nil {
+		s.logger.WithField("error", err.Error()).Warn("Failed to get categories for blog search page")
+		categories = []*domain.BlogCategory{}
+	}
+	maskGatedSnippets(searchResponse.Results, categories)
+
+	posts := make([]*domain.BlogPost, len(searchResponse.Results))
+	for i, result := range searchResponse.Results {
+		posts[i] = result.Post
+	}
+
+	templateData, err := domain.BuildBlogTemplateData(domain.BlogTemplateDataRequest{
+		Workspace:    workspace,
+		PublicLists:  publicLists,
+		Posts:        posts,
+		Categories:   categories,
+		ThemeVersion: theme.Version,
+		CustomData: domain.MapOfAny{
+			"search": domain.MapOfAny{
+				"query":       searchResponse.Query,
+				"total_count": searchResponse.TotalCount,
+			},
+		},
+		PaginationData: &domain.BlogPostListResponse{
+			TotalCount:      searchResponse.TotalCount,
+			CurrentPage:     searchResponse.CurrentPage,
+			TotalPages:      searchResponse.TotalPages,
+			HasNextPage:     searchResponse.HasNextPage,
+			HasPreviousPage: searchResponse.HasPreviousPage,
+		},
+	})
+	if err != nil {
+		return "", &domain.BlogRenderError{
+			Code:    domain.ErrCodeRenderFailed,
+			Message: "Failed to build template data",
+			Details: err,
+		}
+	}
+
+	// Results keep their category slug even when their category was deleted
+	if postsData, ok := templateData["posts"].([]map[string]interface{}); ok {
+		for i, postData := range postsData {
+			result := searchResponse.Results[i]
+			if result.CategorySlug != "" {
+				postData["category_slug"] = result.CategorySlug
+			}
+			postData["title_highlight"] = result.TitleHighlight
+			postData["snippet"] = result.Snippet
+		}
+	}
+
+	searchTemplate := theme.Files.SearchLiquid
+	if searchTemplate == "" {
+		searchTemplate = theme.Files.HomeLiquid
+	}
+
+	partials := map[string]string{
+		"shared":  theme.Files.SharedLiquid,
+		"header":  theme.Files.HeaderLiquid,
+		"footer":  theme.Files.FooterLiquid,
+		"styles":  theme.Files.StylesCSS,
+		"scripts": theme.Files.ScriptsJS,
+	}
+
+	html, err := liquid.RenderBlogTemplate(searchTemplate, templateData, partials)
+	if err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"error":         err.Error(),
+			"workspace_id":  workspaceID,
+			"theme_version": theme.Version,
+		}).Error("Failed to render search template")
+
+		return "", &domain.BlogRenderError{
+			Code:    domain.ErrCodeInvalidLiquidSyntax,
+			Message: fmt.Sprintf("Failed to render search template: %v", err),
+			Details: err,
+		}
+	}
+
+	html = liquid.InjectFeedDiscoveryTags(html, blogTitleForDiscovery(workspace), "")
+	return html, nil
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expectPostSearchIndex expects a post to be indexed after its content changed
+func expectPostSearchIndex(mockWorkspaceRepo *mocks.MockWorkspaceRepository, mockTemplateRepo *mocks.MockTemplateRepository, mockPostRepo *mocks.MockBlogPostRepository) {
+	mockWorkspaceRepo.EXPECT().
+		GetByID(gomock.Any(), "workspace123").
+		Return(&domain.Workspace{ID: "workspace123"}, nil)
+	mockTemplateRepo.EXPECT().
+		GetTemplateByID(gomock.Any(), "workspace123", gomock.Any(), gomock.Any()).
+		Return(&domain.Template{Web: &domain.WebTemplate{PlainText: "Post body"}}, nil)
+	mockPostRepo.EXPECT().
+		UpdatePostSearchIndex(gomock.Any(), gomock.Any(), "english", "Post body").
+		Return(nil)
+}
+
+func TestPostSearchText(t *testing.T) {
+	t.Run("uses the extracted plain text", func(t *testing.T) {
+		template := &domain.Template{Web: &domain.WebTemplate{PlainText: "Plain body", HTML: "<p>HTML body</p>"}}
+		assert.Equal(t, "Plain body", postSearchText(template))
+	})
+
+	t.Run("falls back to the text of the HTML", func(t *testing.T) {
+		template := &domain.Template{Web: &domain.WebTemplate{HTML: "<h2>Intro</h2>\n<p>Hello <strong>world</strong></p>"}}
+		assert.Equal(t, "Intro Hello world", postSearchText(template))
+	})
+
+	t.Run("without web content", func(t *testing.T) {
+		assert.Equal(t, "", postSearchText(nil))
+		assert.Equal(t, "", postSearchText(&domain.Template{}))
+	})
+}
+
+func TestBlogService_refreshPostSearchIndex(t *testing.T) {
+	post := newRevisionTestPost(true)
+
+	t.Run("indexes with the workspace default language", func(t *testing.T) {
+		service, _, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, _ := setupBlogServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().
+			GetByID(gomock.Any(), "workspace123").
+			Return(&domain.Workspace{ID: "workspace123", Settings: domain.WorkspaceSettings{DefaultLanguage: "fr"}}, nil)
+		mockTemplateRepo.EXPECT().
+			GetTemplateByID(gomock.Any(), "workspace123", "tpl123", int64(1)).
+			Return(&domain.Template{Web: &domain.WebTemplate{PlainText: "Bonjour"}}, nil)
+		mockPostRepo.EXPECT().UpdatePostSearchIndex(gomock.Any(), "post123", "french", "Bonjour").Return(nil)
+
+		service.refreshPostSearchIndex(context.Background(), "workspace123", post)
+	})
+
+	t.Run("indexes the title and excerpt when the template is missing", func(t *testing.T) {
+		service, _, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, _ := setupBlogServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "workspace123").Return(nil, errors.New("db down"))
+		mockTemplateRepo.EXPECT().
+			GetTemplateByID(gomock.Any(), "workspace123", "tpl123", int64(1)).
+			Return(nil, errors.New("template not found"))
+		mockPostRepo.EXPECT().UpdatePostSearchIndex(gomock.Any(), "post123", "english", "").Return(errors.New("db down"))
+
+		service.refreshPostSearchIndex(context.Background(), "workspace123", post)
+	})
+}
+
+func TestBlogService_SearchPublicPosts(t *testing.T) {
+	t.Run("searches with the validated request", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, _, _, _, _, _ := setupBlogServiceTest(t)
+
+		mockPostRepo.EXPECT().
+			SearchPosts(gomock.Any(), domain.BlogSearchRequest{Query: "launch", Page: 2, Limit: 10, Offset: 10}).
+			Return(&domain.BlogSearchResponse{Query: "launch"}, nil)
+		mockCategoryRepo.EXPECT().ListCategories(gomock.Any()).Return([]*domain.BlogCategory{}, nil)
+
+		response, err := service.SearchPublicPosts(context.Background(), &domain.BlogSearchRequest{Query: " launch ", Page: 2})
+		require.NoError(t, err)
+		assert.Equal(t, "launch", response.Query)
+	})
+
+	t.Run("replaces the snippet of gated posts by their excerpt", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, _, _, _, _, _ := setupBlogServiceTest(t)
+
+		gatedPost := &domain.BlogPost{ID: "post-1", CategoryID: "premium", Settings: domain.BlogPostSettings{Excerpt: "Tips & tricks"}}
+		openPost := &domain.BlogPost{ID: "post-2", CategoryID: "news"}
+		mockPostRepo.EXPECT().
+			SearchPosts(gomock.Any(), gomock.Any()).
+			Return(&domain.BlogSearchResponse{Query: "launch", Results: []*domain.BlogSearchResult{
+				{Post: gatedPost, Snippet: "the secret <mark>launch</mark> plan"},
+				{Post: openPost, Snippet: "the public <mark>launch</mark>"},
+			}}, nil)
+		mockCategoryRepo.EXPECT().ListCategories(gomock.Any()).Return([]*domain.BlogCategory{
+			{ID: "premium", Settings: domain.BlogCategorySettings{Gate: &domain.BlogGate{ListID: "members"}}},
+			{ID: "news"},
+		}, nil)
+
+		response, err := service.SearchPublicPosts(context.Background(), &domain.BlogSearchRequest{Query: "launch"})
+		require.NoError(t, err)
+		assert.Equal(t, "Tips &amp; tricks", response.Results[0].Snippet)
+		assert.Equal(t, "the public <mark>launch</mark>", response.Results[1].Snippet)
+	})
+
+	t.Run("requires a query", func(t *testing.T) {
+		service, _, _, _, _, _, _, _ := setupBlogServiceTest(t)
+
+		_, err := service.SearchPublicPosts(context.Background(), &domain.BlogSearchRequest{Query: "  "})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "query is required")
+	})
+}
+
+func TestBlogService_RenderSearchPage(t *testing.T) {
+	ctx := context.WithValue(context.Background(), domain.WorkspaceIDKey, "workspace123")
+	workspace := &domain.Workspace{ID: "workspace123", Name: "Test Workspace"}
+	searchLiquid := `<h1>{{ search.query }} ({{ search.total_count }})</h1>{% for post in posts %}<a href="/{{ post.category_slug }}/{{ post.slug }}">{{ post.title_highlight }}</a><p>{{ post.snippet }}</p>{% endfor %}`
+
+	t.Run("renders the results with the search template", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, mockThemeRepo, mockWorkspaceRepo, mockListRepo, _, _ := setupBlogServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(workspace, nil)
+		mockThemeRepo.EXPECT().
+			GetPublishedTheme(ctx).
+			Return(&domain.BlogTheme{Version: 1, Files: domain.BlogThemeFiles{HomeLiquid: "home", SearchLiquid: searchLiquid}}, nil)
+		mockListRepo.EXPECT().GetLists(ctx, "workspace123").Return([]*domain.List{}, nil)
+		mockPostRepo.EXPECT().
+			SearchPosts(ctx, domain.BlogSearchRequest{Query: "launch", Page: 1, Limit: 10, Offset: 0}).
+			Return(&domain.BlogSearchResponse{
+				Query: "launch",
+				Results: []*domain.BlogSearchResult{{
+					Post:           &domain.BlogPost{ID: "post123", CategoryID: "cat-deleted", Slug: "launch"},
+					CategorySlug:   "news",
+					TitleHighlight: "<mark>Launch</mark> day",
+					Snippet:        "We <mark>launched</mark> today",
+				}},
+				TotalCount:  1,
+				CurrentPage: 1,
+				TotalPages:  1,
+			}, nil)
+		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{}, nil)
+
+		html, err := service.RenderSearchPage(ctx, "workspace123", "launch", 1, nil)
+		require.NoError(t, err)
+		assert.Contains(t, html, "<h1>launch (1)</h1>")
+		assert.Contains(t, html, `<a href="/news/launch"><mark>Launch</mark> day</a>`)
+		assert.Contains(t, html, "<p>We <mark>launched</mark> today</p>")
+	})
+
+	t.Run("renders the search form without a query", func(t *testing.T) {
+		service, mockCategoryRepo, _, mockThemeRepo, mockWorkspaceRepo, mockListRepo, _, _ := setupBlogServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(workspace, nil)
+		mockThemeRepo.EXPECT().
+			GetPublishedTheme(ctx).
+			Return(&domain.BlogTheme{Version: 1, Files: domain.BlogThemeFiles{SearchLiquid: searchLiquid}}, nil)
+		mockListRepo.EXPECT().GetLists(ctx, "workspace123").Return([]*domain.List{}, nil)
+		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{}, nil)
+
+		html, err := service.RenderSearchPage(ctx, "workspace123", "", 1, nil)
+		require.NoError(t, err)
+		assert.Contains(t, html, "<h1> (0)</h1>")
+	})
+
+	t.Run("falls back to the home template", func(t *testing.T) {
+		service, mockCategoryRepo, _, mockThemeRepo, mockWorkspaceRepo, mockListRepo, _, _ := setupBlogServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(workspace, nil)
+		mockThemeRepo.EXPECT().
+			GetPublishedTheme(ctx).
+			Return(&domain.BlogTheme{Version: 1, Files: domain.BlogThemeFiles{HomeLiquid: "<h1>{{ workspace.name }}</h1>"}}, nil)
+		mockListRepo.EXPECT().GetLists(ctx, "workspace123").Return([]*domain.List{}, nil)
+		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{}, nil)
+
+		html, err := service.RenderSearchPage(ctx, "workspace123", "", 1, nil)
+		require.NoError(t, err)
+		assert.Contains(t, html, "<h1>Test Workspace</h1>")
+	})
+
+	t.Run("page out of range", func(t *testing.T) {
+		service, _, mockPostRepo, mockThemeRepo, mockWorkspaceRepo, mockListRepo, _, _ := setupBlogServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(workspace, nil)
+		mockThemeRepo.EXPECT().
+			GetPublishedTheme(ctx).
+			Return(&domain.BlogTheme{Version: 1, Files: domain.BlogThemeFiles{SearchLiquid: searchLiquid}}, nil)
+		mockListRepo.EXPECT().GetLists(ctx, "workspace123").Return([]*domain.List{}, nil)
+		mockPostRepo.EXPECT().
+			SearchPosts(ctx, gomock.Any()).
+			Return(&domain.BlogSearchResponse{Query: "launch", Results: []*domain.BlogSearchResult{}, TotalCount: 1, CurrentPage: 3, TotalPages: 1}, nil)
+
+		_, err := service.RenderSearchPage(ctx, "workspace123", "launch", 3, nil)
+		require.Error(t, err)
+		var blogErr *domain.BlogRenderError
+		require.True(t, errors.As(err, &blogErr))
+		assert.Equal(t, domain.ErrCodePostNotFound, blogErr.Code)
+	})
+}
@@ -20,15 +20,19 @@ import (
 
 // BlogService handles all blog-related operations
 type BlogService struct {
-	logger        logger.Logger
-	categoryRepo  domain.BlogCategoryRepository
-	postRepo      domain.BlogPostRepository
-	themeRepo     domain.BlogThemeRepository
-	workspaceRepo domain.WorkspaceRepository
-	listRepo      domain.ListRepository
-	templateRepo  domain.TemplateRepository
-	authService   domain.AuthService
-	cache         cache.Cache
+	logger               logger.Logger
+	categoryRepo         domain.BlogCategoryRepository
+	postRepo             domain.BlogPostRepository
+	themeRepo            domain.BlogThemeRepository
+	workspaceRepo        domain.WorkspaceRepository
+	listRepo             domain.ListRepository
+	contactListRepo      domain.ContactListRepository
+	templateRepo         domain.TemplateRepository
+	authService          domain.AuthService
+	taskService          domain.TaskService
+	broadcastService     domain.BroadcastService
+	transactionalService domain.TransactionalNotificationService
+	cache                cache.Cache
 }
 
 // NewBlogService creates a new blog service
@@ -39,20 +43,28 @@ func NewBlogService(
 	themeRepository domain.BlogThemeRepository,
 	workspaceRepository domain.WorkspaceRepository,
 	listRepository domain.ListRepository,
+	contactListRepository domain.ContactListRepository,
 	templateRepository domain.TemplateRepository,
 	authService domain.AuthService,
+	taskService domain.TaskService,
+	broadcastService domain.BroadcastService,
+	transactionalService domain.TransactionalNotificationService,
 	cache cache.Cache,
 ) *BlogService {
 	return &BlogService{
-		logger:        logger,
-		categoryRepo:  categoryRepository,
-		postRepo:      postRepository,
-		themeRepo:     themeRepository,
-		workspaceRepo: workspaceRepository,
-		listRepo:      listRepository,
-		templateRepo:  templateRepository,
-		authService:   authService,
-		cache:         cache,
+		logger:               logger,
+		categoryRepo:         categoryRepository,
+		postRepo:             postRepository,
+		themeRepo:            themeRepository,
+		workspaceRepo:        workspaceRepository,
+		listRepo:             listRepository,
+		contactListRepo:      contactListRepository,
+		templateRepo:         templateRepository,
+		authService:          authService,
+		taskService:          taskService,
+		broadcastService:     broadcastService,
+		transactionalService: transactionalService,
+		cache:                cache,
 	}
 }
 
@@ -90,6 +102,13 @@ func (s *BlogService) CreateCategory(ctx context.Context, request *domain.Create
 		return nil, err
 	}
 
+	// Auto-sent newsletters create broadcasts on behalf of whoever publishes a post
+	if request.Newsletter != nil && request.Newsletter.AutoSend {
+		if err := checkNewsletterPermission(ctx, userWorkspace); err != nil {
+			return nil, err
+		}
+	}
+
 	// Check if slug already exists
 	existing, err := s.categoryRepo.GetCategoryBySlug(ctx, request.Slug)
 	if err == nil && existing != nil {
@@ -104,9 +123,12 @@ func (s *BlogService) CreateCategory(ctx context.Context, request *domain.Create
 		ID:   id,
 		Slug: request.Slug,
 		Settings: domain.BlogCategorySettings{
-			Name:        request.Name,
-			Description: request.Description,
-			SEO:         request.SEO,
+			Name:         request.Name,
+			Description:  request.Description,
+			SEO:          request.SEO,
+			Newsletter:   request.Newsletter,
+			Translations: request.Translations,
+			Gate:         request.Gate,
 		},
 		CreatedAt: time.Now().UTC(),
 		UpdatedAt: time.Now().UTC(),
@@ -118,6 +140,14 @@ func (s *BlogService) CreateCategory(ctx context.Context, request *domain.Create
 		return nil, err
 	}
 
+	if err := s.checkCategoryTranslations(ctx, workspaceID, category); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkBlogGate(ctx, workspaceID, category.Settings.Gate); err != nil {
+		return nil, err
+	}
+
 	// Persist the category
 	if err := s.categoryRepo.CreateCategory(ctx, category); err != nil {
 		s.logger.Error("Failed to create category")
@@ -181,11 +211,16 @@ func (s *BlogService) GetCategoryBySlug(ctx context.Context, slug string) (*doma
 	return s.categoryRepo.GetCategoryBySlug(ctx, slug)
 }
 
-// GetPublicCategoryBySlug retrieves a blog category by slug for public blog pages (no authentication required)
-func (s *BlogService) GetPublicCategoryBySlug(ctx context.Context, slug string) (*domain.BlogCategory, error) {
+// GetPublicCategoryBySlug retrieves a blog category by its slug in a language for public
+// blog pages (no authentication required). The category is localized in that language.
+func (s *BlogService) GetPublicCategoryBySlug(ctx context.Context, language, slug string) (*domain.BlogCategory, error) {
 	// For public blog pages, we don't require authentication
-	// Just get the category directly from the repository
-	return s.categoryRepo.GetCategoryBySlug(ctx, slug)
+	category, err := s.findCategoryForLanguage(ctx, language, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return category.Localize(language), nil
 }
 
 // UpdateCategory updates an existing blog category
@@ -225,6 +260,13 @@ func (s *BlogService) UpdateCategory(ctx context.Context, request *domain.Update
 		return nil, fmt.Errorf("category not found: %w", err)
 	}
 
+	// Auto-sent newsletters create broadcasts on behalf of whoever publishes a post
+	if request.Newsletter != nil && request.Newsletter.AutoSend && !newsletterUnchanged(category.Settings.Newsletter, request.Newsletter) {
+		if err := checkNewsletterPermission(ctx, userWorkspace); err != nil {
+			return nil, err
+		}
+	}
+
 	// Check if slug is changing and if new slug already exists
 	if category.Slug != request.Slug {
 		existing, err := s.categoryRepo.GetCategoryBySlug(ctx, request.Slug)
@@ -238,6 +280,9 @@ func (s *BlogService) UpdateCategory(ctx context.Context, request *domain.Update
 	category.Settings.Name = request.Name
 	category.Settings.Description = request.Description
 	category.Settings.SEO = request.SEO
+	category.Settings.Newsletter = request.Newsletter
+	category.Settings.Translations = request.Translations
+	category.Settings.Gate = request.Gate
 	category.UpdatedAt = time.Now().UTC()
 
 	// Validate the updated category
@@ -246,6 +291,14 @@ func (s *BlogService) UpdateCategory(ctx context.Context, request *domain.Update
 		return nil, err
 	}
 
+	if err := s.checkCategoryTranslations(ctx, workspaceID, category); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkBlogGate(ctx, workspaceID, category.Settings.Gate); err != nil {
+		return nil, err
+	}
+
 	// Persist the changes
 	if err := s.categoryRepo.UpdateCategory(ctx, category); err != nil {
 		s.logger.Error("Failed to update category")
@@ -365,7 +418,7 @@ func (s *BlogService) CreatePost(ctx context.Context, request *domain.CreateBlog
 
 	// Authenticate user for workspace
 	var err error
-	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	ctx, user, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to authenticate user: %w", err)
 	}
@@ -416,6 +469,9 @@ func (s *BlogService) CreatePost(ctx context.Context, request *domain.CreateBlog
 			Authors:            request.Authors,
 			ReadingTimeMinutes: request.ReadingTimeMinutes,
 			SEO:                request.SEO,
+			Translations:       request.Translations,
+			CommentsDisabled:   request.CommentsDisabled,
+			Gate:               request.Gate,
 		},
 		PublishedAt: nil, // Draft by default
 		CreatedAt:   time.Now().UTC(),
@@ -428,12 +484,28 @@ func (s *BlogService) CreatePost(ctx context.Context, request *domain.CreateBlog
 		return nil, err
 	}
 
-	// Persist the post
-	if err := s.postRepo.CreatePost(ctx, post); err != nil {
+	if err := s.checkPostTranslations(ctx, workspaceID, post); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkBlogGate(ctx, workspaceID, post.Settings.Gate); err != nil {
+		return nil, err
+	}
+
+	// Persist the post with its first revision
+	err = s.postRepo.WithTransaction(ctx, workspaceID, func(tx *sql.Tx) error {
+		if err := s.postRepo.CreatePostTx(ctx, tx, post); err != nil {
+			return err
+		}
+		return s.postRepo.CreateRevisionTx(ctx, tx, domain.NewBlogPostRevision(post, domain.BlogPostRevisionStatusApplied, blogRevisionAuthor(user)))
+	})
+	if err != nil {
 		s.logger.Error("Failed to create post")
 		return nil, fmt.Errorf("failed to create post: %w", err)
 	}
 
+	s.refreshPostSearchIndex(ctx, workspaceID, post)
+
 	return post, nil
 }
 
@@ -535,7 +607,7 @@ func (s *BlogService) UpdatePost(ctx context.Context, request *domain.UpdateBlog
 
 	// Authenticate user for workspace
 	var err error
-	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	ctx, user, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to authenticate user: %w", err)
 	}
@@ -577,16 +649,7 @@ func (s *BlogService) UpdatePost(ctx context.Context, request *domain.UpdateBlog
 	}
 
 	// Update the post fields
-	post.CategoryID = request.CategoryID
-	post.Slug = request.Slug
-	post.Settings.Title = request.Title
-	post.Settings.Template.TemplateID = request.TemplateID
-	post.Settings.Template.TemplateVersion = request.TemplateVersion
-	post.Settings.Excerpt = request.Excerpt
-	post.Settings.FeaturedImageURL = request.FeaturedImageURL
-	post.Settings.Authors = request.Authors
-	post.Settings.ReadingTimeMinutes = request.ReadingTimeMinutes
-	post.Settings.SEO = request.SEO
+	applyBlogPostUpdate(post, request)
 	post.UpdatedAt = time.Now().UTC()
 
 	// Validate the updated post
@@ -595,12 +658,28 @@ func (s *BlogService) UpdatePost(ctx context.Context, request *domain.UpdateBlog
 		return nil, err
 	}
 
-	// Persist the changes
-	if err := s.postRepo.UpdatePost(ctx, post); err != nil {
+	if err := s.checkPostTranslations(ctx, workspaceID, post); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkBlogGate(ctx, workspaceID, post.Settings.Gate); err != nil {
+		return nil, err
+	}
+
+	// Persist the changes and record them in the revision history
+	err = s.postRepo.WithTransaction(ctx, workspaceID, func(tx *sql.Tx) error {
+		if err := s.postRepo.UpdatePostTx(ctx, tx, post); err != nil {
+			return err
+		}
+		return s.postRepo.CreateRevisionTx(ctx, tx, domain.NewBlogPostRevision(post, domain.BlogPostRevisionStatusApplied, blogRevisionAuthor(user)))
+	})
+	if err != nil {
 		s.logger.Error("Failed to update post")
 		return nil, fmt.Errorf("failed to update post: %w", err)
 	}
 
+	s.refreshPostSearchIndex(ctx, workspaceID, post)
+
 	s.logger.WithField("post_id", post.ID).Info("Post updated successfully, clearing cache...")
 
 	// Invalidate blog caches
@@ -612,6 +691,23 @@ func (s *BlogService) UpdatePost(ctx context.Context, request *domain.UpdateBlog
 	return post, nil
 }
 
+// applyBlogPostUpdate copies the fields of an update request to a post
+func applyBlogPostUpdate(post *domain.BlogPost, request *domain.UpdateBlogPostRequest) {
+	post.CategoryID = request.CategoryID
+	post.Slug = request.Slug
+	post.Settings.Title = request.Title
+	post.Settings.Template.TemplateID = request.TemplateID
+	post.Settings.Template.TemplateVersion = request.TemplateVersion
+	post.Settings.Excerpt = request.Excerpt
+	post.Settings.FeaturedImageURL = request.FeaturedImageURL
+	post.Settings.Authors = request.Authors
+	post.Settings.ReadingTimeMinutes = request.ReadingTimeMinutes
+	post.Settings.SEO = request.SEO
+	post.Settings.Translations = request.Translations
+	post.Settings.CommentsDisabled = request.CommentsDisabled
+	post.Settings.Gate = request.Gate
+}
+
 // DeletePost deletes a blog post
 func (s *BlogService) DeletePost(ctx context.Context, request *domain.DeleteBlogPostRequest) error {
 	// Get workspace ID from context
@@ -725,12 +821,20 @@ func (s *BlogService) PublishPost(ctx context.Context, request *domain.PublishBl
 	}
 
 	// Verify post exists
-	_, err = s.postRepo.GetPost(ctx, request.ID)
+	post, err := s.postRepo.GetPost(ctx, request.ID)
 	if err != nil {
 		s.logger.Error("Failed to get post for publishing")
 		return fmt.Errorf("failed to get post: %w", err)
 	}
 
+	// Sending the post to subscribers creates a broadcast
+	if request.Newsletter != nil {
+		if err := checkNewsletterPermission(ctx, userWorkspace); err != nil {
+			return err
+		}
+	}
+	wasPublished := post.IsPublished()
+
 	// Publish the post with optional custom timestamp
 	if err := s.postRepo.PublishPost(ctx, request.ID, request.PublishedAt); err != nil {
 		s.logger.Error("Failed to publish post")
@@ -740,6 +844,27 @@ func (s *BlogService) PublishPost(ctx context.Context, request *domain.PublishBl
 	// Clear blog cache
 	s.clearBlogCache(workspaceID)
 
+	publishedAt := time.Now().UTC()
+	if request.PublishedAt != nil {
+		publishedAt = request.PublishedAt.UTC()
+	}
+	post.PublishedAt = &publishedAt
+	post.ScheduledPublishAt = nil
+
+	// Send the newsletter requested with the publication, or the one of the category
+	// the first time the post is published
+	var newsletterErr error
+	switch {
+	case request.Newsletter != nil:
+		_, newsletterErr = s.sendPostNewsletter(ctx, workspaceID, post, request.Newsletter)
+	case !request.SkipNewsletter && !wasPublished:
+		newsletterErr = s.sendCategoryNewsletter(ctx, workspaceID, post)
+	}
+	if newsletterErr != nil {
+		s.logger.WithField("post_id", post.ID).Error(fmt.Sprintf("Failed to send post newsletter: %v", newsletterErr))
+		return fmt.Errorf("post published, but the newsletter could not be sent: %w", newsletterErr)
+	}
+
 	return nil
 }
 
@@ -792,9 +917,10 @@ func (s *BlogService) UnpublishPost(ctx context.Context, request *domain.Unpubli
 	return nil
 }
 
-// GetPublicPostByCategoryAndSlug retrieves a published blog post by category slug and post slug (no auth required)
-func (s *BlogService) GetPublicPostByCategoryAndSlug(ctx context.Context, categorySlug, postSlug string) (*domain.BlogPost, error) {
-	post, err := s.postRepo.GetPostByCategoryAndSlug(ctx, categorySlug, postSlug)
+// GetPublicPostByCategoryAndSlug retrieves a published blog post by its category slug and
+// post slug in a language (no auth required). The post is localized in that language.
+func (s *BlogService) GetPublicPostByCategoryAndSlug(ctx context.Context, language, categorySlug, postSlug string) (*domain.BlogPost, error) {
+	post, err := s.findPostForLanguage(ctx, language, categorySlug, postSlug)
 	if err != nil {
 		return nil, err
 	}
@@ -804,7 +930,7 @@ func (s *BlogService) GetPublicPostByCategoryAndSlug(ctx context.Context, catego
 		return nil, fmt.Errorf("post not found")
 	}
 
-	return post, nil
+	return post.Localize(language), nil
 }
 
 // ListPublicPosts retrieves published blog posts (no auth required)
@@ -1114,7 +1240,7 @@ func (s *BlogService) getPublicListsForWorkspace(ctx context.Context, workspaceI
 }
 
 // RenderHomePage renders the blog home page with published posts
-func (s *BlogService) RenderHomePage(ctx context.Context, workspaceID string, page int, themeVersion *int) (string, error) {
+func (s *BlogService) RenderHomePage(ctx context.Context, workspaceID, language string, page int, themeVersion *int) (string, error) {
 	// Validate page number
 	if page < 1 {
 		page = 1
@@ -1129,6 +1255,9 @@ func (s *BlogService) RenderHomePage(ctx context.Context, workspaceID string, pa
 			Details: err,
 		}
 	}
+	if err := checkBlogLanguage(workspace, language); err != nil {
+		return "", err
+	}
 
 	// Get theme (published or specific version)
 	var theme *domain.BlogTheme
@@ -1167,11 +1296,12 @@ func (s *BlogService) RenderHomePage(ctx context.Context, workspaceID string, pa
 		pageSize = workspace.Settings.BlogSettings.GetHomePageSize()
 	}
 
-	// Get published posts for home page
+	// Get published posts for home page, in the language of the page
 	params := &domain.ListBlogPostsRequest{
-		Status: domain.BlogPostStatusPublished,
-		Page:   page,
-		Limit:  pageSize,
+		Status:   domain.BlogPostStatusPublished,
+		Language: language,
+		Page:     page,
+		Limit:    pageSize,
 	}
 	// Validate will calculate offset
 	if err := params.Validate(); err != nil {
@@ -1247,14 +1377,18 @@ func (s *BlogService) RenderHomePage(ctx context.Context, workspaceID string, pa
 		allCategoriesForSlugs = append(allCategoriesForSlugs, cat)
 	}
 
+	languageLinks := blogLanguageLinks(workspace, language, func(string) (string, bool) { return "/", true })
+
 	// Build template data with pagination
 	templateData, err := domain.BuildBlogTemplateData(domain.BlogTemplateDataRequest{
 		Workspace:      workspace,
 		PublicLists:    publicLists,
-		Posts:          postsResponse.Posts,
-		Categories:     allCategoriesForSlugs, // Use all categories (including deleted) for slug lookup
+		Posts:          localizePosts(postsResponse.Posts, language),
+		Categories:     localizeCategories(allCategoriesForSlugs, language), // Use all categories (including deleted) for slug lookup
 		ThemeVersion:   theme.Version,
 		PaginationData: postsResponse,
+		Language:       language,
+		Languages:      languageLinks,
 	})
 	if err != nil {
 		return "", &domain.BlogRenderError{
@@ -1297,12 +1431,12 @@ func (s *BlogService) RenderHomePage(ctx context.Context, workspaceID string, pa
 		}
 	}
 
-	html = liquid.InjectFeedDiscoveryTags(html, blogTitleForDiscovery(workspace), "")
+	html = injectLanguageTags(html, workspace, language, "", languageLinks)
 	return html, nil
 }
 
 // RenderPostPage renders a single blog post page
-func (s *BlogService) RenderPostPage(ctx context.Context, workspaceID, categorySlug, postSlug string, themeVersion *int) (string, error) {
+func (s *BlogService) RenderPostPage(ctx context.Context, workspaceID, language, categorySlug, postSlug string, themeVersion *int) (string, error) {
 	// Get workspace
 	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
 	if err != nil {
@@ -1312,6 +1446,9 @@ func (s *BlogService) RenderPostPage(ctx context.Context, workspaceID, categoryS
 			Details: err,
 		}
 	}
+	if err := checkBlogLanguage(workspace, language); err != nil {
+		return "", err
+	}
 
 	// Get theme (published or specific version)
 	var theme *domain.BlogTheme
@@ -1336,8 +1473,8 @@ func (s *BlogService) RenderPostPage(ctx context.Context, workspaceID, categoryS
 		}
 	}
 
-	// Get post by category and slug
-	post, err := s.postRepo.GetPostByCategoryAndSlug(ctx, categorySlug, postSlug)
+	// Get post by category and slug, in the language of the page
+	post, err := s.findPostForLanguage(ctx, language, categorySlug, postSlug)
 	if err != nil {
 		return "", &domain.BlogRenderError{
 			Code:    domain.ErrCodePostNotFound,
@@ -1355,13 +1492,49 @@ func (s *BlogService) RenderPostPage(ctx context.Context, workspaceID, categoryS
 		}
 	}
 
+	return s.renderPostPage(ctx, workspace, theme, post, language, categorySlug, false)
+}
+
+// renderPostPage renders the post template of a theme for a post in a language, shared
+// by the public post page and the preview of draft revisions. An empty category slug is
+// taken from the category of the post.
+func (s *BlogService) renderPostPage(ctx context.Context, workspace *domain.Workspace, theme *domain.BlogTheme, post *domain.BlogPost, language, categorySlug string, preview bool) (string, error) {
+	workspaceID := workspace.ID
+
 	// Get category
 	category, err := s.categoryRepo.GetCategory(ctx, post.CategoryID)
 	if err != nil {
 		s.logger.WithField("error", err.Error()).Warn("Failed to get category for blog post page")
 		category = nil
+	} else if categorySlug == "" {
+		categorySlug = category.Localize(language).Slug
+	}
+
+	// The post in each language it is translated in
+	languageLinks := blogLanguageLinks(workspace, language, func(linkLanguage string) (string, bool) {
+		localizedPost := post.Localize(linkLanguage)
+		if category == nil || localizedPost == nil {
+			return "", false
+		}
+		return "/" + category.Localize(linkLanguage).Slug + "/" + localizedPost.Slug, true
+	})
+	localizedPost := post.Localize(language)
+	if localizedPost == nil {
+		return "", &domain.BlogRenderError{
+			Code:    domain.ErrCodePostNotFound,
+			Message: "Post is not translated in this language",
+		}
+	}
+	var localizedCategory *domain.BlogCategory
+	if category != nil {
+		localizedCategory = category.Localize(language)
 	}
 
+	// Gated posts only show their body to the subscribers of the gate list, previews
+	// always show it
+	gate := post.EffectiveGate(category)
+	accessGranted := preview || s.hasBlogAccess(ctx, workspaceID, gate)
+
 	// Get public lists
 	publicLists, err := s.getPublicListsForWorkspace(ctx, workspaceID)
 	if err != nil {
@@ -1378,33 +1551,20 @@ func (s *BlogService) RenderPostPage(ctx context.Context, workspaceID, categoryS
 
 	// Fetch the web template for the post content
 	var postContentHTML string
-	template, err := s.templateRepo.GetTemplateByID(ctx, workspaceID, post.Settings.Template.TemplateID, int64(post.Settings.Template.TemplateVersion))
-	if err != nil {
-		s.logger.WithFields(map[string]interface{}{
-			"error":            err.Error(),
-			"template_id":      post.Settings.Template.TemplateID,
-			"template_version": post.Settings.Template.TemplateVersion,
-		}).Warn("Failed to get template for blog post - post content will be empty")
-		postContentHTML = ""
-	} else if template.Web != nil && template.Web.HTML != "" {
-		// Use the pre-rendered HTML from the web template
-		postContentHTML = template.Web.HTML
-	} else {
-		s.logger.WithFields(map[string]interface{}{
-			"template_id":      post.Settings.Template.TemplateID,
-			"template_version": post.Settings.Template.TemplateVersion,
-		}).Warn("Template has no web content - post content will be empty")
-		postContentHTML = ""
+	if accessGranted {
+		postContentHTML = s.loadPostContentHTML(ctx, workspaceID, post, language)
 	}
 
 	// Build template data
 	templateData, err := domain.BuildBlogTemplateData(domain.BlogTemplateDataRequest{
 		Workspace:    workspace,
-		Post:         post,
-		Category:     category,
+		Post:         localizedPost,
+		Category:     localizedCategory,
 		PublicLists:  publicLists,
-		Categories:   categories,
+		Categories:   localizeCategories(categories, language),
 		ThemeVersion: theme.Version,
+		Language:     language,
+		Languages:    languageLinks,
 	})
 	if err != nil {
 		return "", &domain.BlogRenderError{
@@ -1438,6 +1598,18 @@ func (s *BlogService) RenderPostPage(ctx context.Context, workspaceID, categoryS
 			}
 		}
 		postData["table_of_contents"] = tocData
+		postData["is_gated"] = gate != nil
+	}
+
+	// Gated content data
+	templateData["is_gated"] = gate != nil
+	templateData["access_granted"] = accessGranted
+	templateData["viewer"] = nil
+	if viewer := domain.BlogViewerFromContext(ctx); viewer != nil {
+		templateData["viewer"] = domain.MapOfAny{"email": viewer.Email}
+	}
+	if gate != nil {
+		templateData["gate"] = s.buildBlogGateData(ctx, workspaceID, gate)
 	}
 
 	// Prepare partials map for the template engine
@@ -1457,7 +1629,7 @@ func (s *BlogService) RenderPostPage(ctx context.Context, workspaceID, categoryS
 			"error":                err.Error(),
 			"workspace_id":         workspaceID,
 			"theme_version":        theme.Version,
-			"post_slug":            postSlug,
+			"post_slug":            post.Slug,
 			"category_slug":        categorySlug,
 			"post_template_length": len(theme.Files.PostLiquid),
 			"partials":             []string{"shared", "header", "footer", "styles", "scripts"},
@@ -1470,10 +1642,35 @@ func (s *BlogService) RenderPostPage(ctx context.Context, workspaceID, categoryS
 		}
 	}
 
-	html = liquid.InjectFeedDiscoveryTags(html, blogTitleForDiscovery(workspace), categorySlug)
+	html = injectLanguageTags(html, workspace, language, categorySlug, languageLinks)
 	return html, nil
 }
 
+// loadPostContentHTML returns the pre-rendered HTML of the web template of a post,
+// translated in the language, empty when the template cannot be loaded
+func (s *BlogService) loadPostContentHTML(ctx context.Context, workspaceID string, post *domain.BlogPost, language string) string {
+	template, err := s.templateRepo.GetTemplateByID(ctx, workspaceID, post.Settings.Template.TemplateID, int64(post.Settings.Template.TemplateVersion))
+	if err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"error":            err.Error(),
+			"template_id":      post.Settings.Template.TemplateID,
+			"template_version": post.Settings.Template.TemplateVersion,
+		}).Warn("Failed to get template for blog post - post content will be empty")
+		return ""
+	}
+
+	// Use the pre-rendered HTML from the web template, translated in the page language
+	if web := template.ResolveWebContent(language, ""); web != nil && web.HTML != "" {
+		return web.HTML
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"template_id":      post.Settings.Template.TemplateID,
+		"template_version": post.Settings.Template.TemplateVersion,
+	}).Warn("Template has no web content - post content will be empty")
+	return ""
+}
+
 // RenderPostContent returns the post body HTML prepared for syndication
 // (RSS / JSON Feed) — no theme chrome, no header/footer, relative URLs
 // rewritten to absolute against the workspace origin, sanitized against
@@ -1509,6 +1706,15 @@ func (s *BlogService) RenderPostContent(ctx context.Context, workspaceID, catego
 		}
 	}
 
+	// Gated posts are syndicated with their excerpt only
+	category, err := s.categoryRepo.GetCategory(ctx, post.CategoryID)
+	if err != nil {
+		category = nil
+	}
+	if post.EffectiveGate(category) != nil {
+		return post.Settings.Excerpt, nil
+	}
+
 	tmpl, err := s.templateRepo.GetTemplateByID(ctx, workspaceID, post.Settings.Template.TemplateID, int64(post.Settings.Template.TemplateVersion))
 	if err != nil {
 		return "", &domain.BlogRenderError{
@@ -1517,20 +1723,25 @@ func (s *BlogService) RenderPostContent(ctx context.Context, workspaceID, catego
 			Details: err,
 		}
 	}
-	return renderPostContentFromEntities(workspace, tmpl)
+	return renderPostContentFromEntities(workspace, tmpl, "")
 }
 
 // renderPostContentFromEntities is the shared rendering + sanitization path
 // used by both RenderPostContent (which loads entities itself) and BuildFeed
-// (which preloads them in batch). Does not hit the database.
-func renderPostContentFromEntities(workspace *domain.Workspace, tmpl *domain.Template) (string, error) {
+// (which preloads them in batch). Does not hit the database. The content is
+// the translation of the template in language, when it has one.
+func renderPostContentFromEntities(workspace *domain.Workspace, tmpl *domain.Template, language string) (string, error) {
 	if tmpl == nil || tmpl.Web == nil || tmpl.Web.HTML == "" {
 		return "", &domain.BlogRenderError{
 			Code:    domain.ErrCodeRenderFailed,
 			Message: "Post template has no web content",
 		}
 	}
-	sanitized, err := liquid.SanitizeFeedHTML(tmpl.Web.HTML, workspaceBlogOrigin(workspace))
+	web := tmpl.ResolveWebContent(language, "")
+	if web.HTML == "" {
+		web = tmpl.Web
+	}
+	sanitized, err := liquid.SanitizeFeedHTML(web.HTML, workspaceBlogOrigin(workspace))
 	if err != nil {
 		return "", &domain.BlogRenderError{
 			Code:    domain.ErrCodeRenderFailed,
@@ -1543,10 +1754,12 @@ func renderPostContentFromEntities(workspace *domain.Workspace, tmpl *domain.Tem
 
 // BuildFeed loads the newest published posts (optionally filtered by
 // category), renders each body, and returns a *domain.BlogFeed the feed
-// renderer package can serialize. Callers that care about conditional GET
-// should call GetFeedFingerprint first and skip BuildFeed on cache hits —
-// body rendering is the expensive step.
-func (s *BlogService) BuildFeed(ctx context.Context, workspaceID string, categorySlug *string) (*domain.BlogFeed, error) {
+// renderer package can serialize. A non-empty language builds the feed of
+// the posts translated in that language, with category slugs in that
+// language. Callers that care about conditional GET should call
+// GetFeedFingerprint first and skip BuildFeed on cache hits — body
+// rendering is the expensive step.
+func (s *BlogService) BuildFeed(ctx context.Context, workspaceID, language string, categorySlug *string) (*domain.BlogFeed, error) {
 	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get workspace: %w", err)
@@ -1561,13 +1774,14 @@ func (s *BlogService) BuildFeed(ctx context.Context, workspaceID string, categor
 
 	// The repo methods read workspaceID from ctx (like ListPosts).
 	feedCtx := context.WithValue(ctx, domain.WorkspaceIDKey, workspaceID)
+	defaultCategorySlug := s.feedCategorySlug(feedCtx, language, categorySlug)
 
-	posts, err := s.postRepo.ListFeedPosts(feedCtx, categorySlug, limit)
+	posts, err := s.postRepo.ListFeedPosts(feedCtx, language, defaultCategorySlug, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list feed posts: %w", err)
 	}
 
-	maxUpdatedAt, idsHash, err := s.postRepo.GetFeedFingerprint(feedCtx, categorySlug, limit)
+	maxUpdatedAt, idsHash, err := s.postRepo.GetFeedFingerprint(feedCtx, language, defaultCategorySlug, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compute feed fingerprint: %w", err)
 	}
@@ -1610,6 +1824,9 @@ func (s *BlogService) BuildFeed(ctx context.Context, workspaceID string, categor
 	templates := map[string]*domain.Template{}
 	if !summaryOnly {
 		for _, post := range posts {
+			if post.EffectiveGate(categoriesByID[post.CategoryID]) != nil {
+				continue
+			}
 			key := post.Settings.Template.TemplateID + "@" + strconv.Itoa(post.Settings.Template.TemplateVersion)
 			if _, cached := templates[key]; cached {
 				continue
@@ -1631,21 +1848,27 @@ func (s *BlogService) BuildFeed(ctx context.Context, workspaceID string, categor
 	}
 
 	items := make([]domain.BlogFeedItem, 0, len(posts))
-	for _, post := range posts {
-		cat := categoriesByID[post.CategoryID]
+	for _, defaultPost := range posts {
+		cat := categoriesByID[defaultPost.CategoryID]
 		if cat == nil {
 			s.logger.WithFields(map[string]interface{}{
 				"workspace_id": workspaceID,
-				"post_id":      post.ID,
-				"category_id":  post.CategoryID,
+				"post_id":      defaultPost.ID,
+				"category_id":  defaultPost.CategoryID,
 			}).Error("Feed: dropping item — category not found (orphan post)")
 			continue
 		}
+		post := defaultPost.Localize(language)
+		if post == nil {
+			continue
+		}
+		gated := defaultPost.EffectiveGate(cat) != nil
+		cat = cat.Localize(language)
 
 		item := domain.BlogFeedItem{
 			GUID:             post.ID,
 			Title:            post.Settings.Title,
-			URL:              buildPostURL(origin, cat.Slug, post.Slug),
+			URL:              joinURL(origin, domain.BlogLanguagePrefix(language)+"/"+cat.Slug+"/"+post.Slug),
 			CategorySlug:     cat.Slug,
 			CategoryName:     cat.Settings.Name,
 			Excerpt:          post.Settings.Excerpt,
@@ -1657,15 +1880,16 @@ func (s *BlogService) BuildFeed(ctx context.Context, workspaceID string, categor
 			item.PublishedAt = *post.PublishedAt
 		}
 
-		// Content fallback ladder: full render → excerpt → drop.
-		if summaryOnly {
+		// Content fallback ladder: full render → excerpt → drop. Gated posts never
+		// leak their body, whatever the feed settings.
+		if summaryOnly || gated {
 			item.ContentHTML = post.Settings.Excerpt
 			items = append(items, item)
 			continue
 		}
 
 		tmpl := templates[post.Settings.Template.TemplateID+"@"+strconv.Itoa(post.Settings.Template.TemplateVersion)]
-		body, err := renderPostContentFromEntities(workspace, tmpl)
+		body, err := renderPostContentFromEntities(workspace, tmpl, language)
 		if err != nil {
 			s.logger.WithFields(map[string]interface{}{
 				"workspace_id":  workspaceID,
@@ -1689,9 +1913,12 @@ func (s *BlogService) BuildFeed(ctx context.Context, workspaceID string, categor
 		items = append(items, item)
 	}
 
-	language := workspace.Settings.DefaultLanguage
-	if language == "" {
-		language = "en"
+	feedLanguage := language
+	if feedLanguage == "" {
+		feedLanguage = workspace.Settings.DefaultLanguage
+	}
+	if feedLanguage == "" {
+		feedLanguage = "en"
 	}
 	blogTitle := ""
 	blogDescription := ""
@@ -1715,9 +1942,9 @@ func (s *BlogService) BuildFeed(ctx context.Context, workspaceID string, categor
 		blogDescription = blogTitle
 	}
 
-	selfPath := "/feed.xml"
+	selfPath := domain.BlogLanguagePrefix(language) + "/feed.xml"
 	if categorySlug != nil && *categorySlug != "" {
-		selfPath = "/" + *categorySlug + "/feed.xml"
+		selfPath = domain.BlogLanguagePrefix(language) + "/" + *categorySlug + "/feed.xml"
 	}
 
 	meta := domain.BlogFeedMeta{
@@ -1726,18 +1953,18 @@ func (s *BlogService) BuildFeed(ctx context.Context, workspaceID string, categor
 		SiteURL:     origin,
 		FeedURL:     joinURL(origin, selfPath),
 		SelfURL:     joinURL(origin, selfPath),
-		Language:    language,
+		Language:    feedLanguage,
 		IconURL:     iconURL,
 		LogoURL:     logoURL,
 		UpdatedAt:   maxUpdatedAt,
-		ETag:        computeFeedETag(workspace, categorySlug, maxUpdatedAt, idsHash),
+		ETag:        computeFeedETag(workspace, language, categorySlug, maxUpdatedAt, idsHash),
 	}
 	return &domain.BlogFeed{Meta: meta, Items: items}, nil
 }
 
 // GetFeedFingerprint returns (maxUpdatedAt, etag) without rendering items.
 // HTTP handlers use this for conditional GET.
-func (s *BlogService) GetFeedFingerprint(ctx context.Context, workspaceID string, categorySlug *string) (time.Time, string, error) {
+func (s *BlogService) GetFeedFingerprint(ctx context.Context, workspaceID, language string, categorySlug *string) (time.Time, string, error) {
 	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
 	if err != nil {
 		return time.Time{}, "", fmt.Errorf("failed to get workspace: %w", err)
@@ -1745,20 +1972,20 @@ func (s *BlogService) GetFeedFingerprint(ctx context.Context, workspaceID string
 	limit := workspace.Settings.BlogSettings.GetFeedMaxItems()
 	feedCtx := context.WithValue(ctx, domain.WorkspaceIDKey, workspaceID)
 
-	maxUpdatedAt, idsHash, err := s.postRepo.GetFeedFingerprint(feedCtx, categorySlug, limit)
+	maxUpdatedAt, idsHash, err := s.postRepo.GetFeedFingerprint(feedCtx, language, s.feedCategorySlug(feedCtx, language, categorySlug), limit)
 	if err != nil {
 		return time.Time{}, "", fmt.Errorf("failed to compute feed fingerprint: %w", err)
 	}
 	if maxUpdatedAt.IsZero() {
 		maxUpdatedAt = workspace.UpdatedAt.UTC()
 	}
-	return maxUpdatedAt, computeFeedETag(workspace, categorySlug, maxUpdatedAt, idsHash), nil
+	return maxUpdatedAt, computeFeedETag(workspace, language, categorySlug, maxUpdatedAt, idsHash), nil
 }
 
 // computeFeedETag hashes the fingerprint inputs to a short hex ETag.
-// Inputs: maxUpdatedAt, idsHash, categorySlug, settings fingerprint (blog
-// title/logos/feed toggles/default language). Any one changing invalidates.
-func computeFeedETag(ws *domain.Workspace, categorySlug *string, maxUpdatedAt time.Time, idsHash string) string {
+// Inputs: maxUpdatedAt, idsHash, language, categorySlug, settings fingerprint
+// (blog title/logos/feed toggles/default language). Any one changing invalidates.
+func computeFeedETag(ws *domain.Workspace, language string, categorySlug *string, maxUpdatedAt time.Time, idsHash string) string {
 	var settingsPart struct {
 		Title           string
 		LogoURL         string
@@ -1793,6 +2020,8 @@ func computeFeedETag(ws *domain.Workspace, categorySlug *string, maxUpdatedAt ti
 	h.Write([]byte{0})
 	h.Write([]byte(catSlug))
 	h.Write([]byte{0})
+	h.Write([]byte(language))
+	h.Write([]byte{0})
 	h.Write(settingsBlob)
 	sum := h.Sum(nil)
 	// Emit as a weak ETag: sanitizer output is deterministic but not
@@ -1837,7 +2066,7 @@ func workspaceBlogOrigin(ws *domain.Workspace) string {
 }
 
 // RenderCategoryPage renders a category page with posts in that category
-func (s *BlogService) RenderCategoryPage(ctx context.Context, workspaceID, categorySlug string, page int, themeVersion *int) (string, error) {
+func (s *BlogService) RenderCategoryPage(ctx context.Context, workspaceID, language, categorySlug string, page int, themeVersion *int) (string, error) {
 	// Validate page number
 	if page < 1 {
 		page = 1
@@ -1852,6 +2081,9 @@ func (s *BlogService) RenderCategoryPage(ctx context.Context, workspaceID, categ
 			Details: err,
 		}
 	}
+	if err := checkBlogLanguage(workspace, language); err != nil {
+		return "", err
+	}
 
 	// Get theme (published or specific version)
 	var theme *domain.BlogTheme
@@ -1876,8 +2108,8 @@ func (s *BlogService) RenderCategoryPage(ctx context.Context, workspaceID, categ
 		}
 	}
 
-	// Get category by slug
-	category, err := s.categoryRepo.GetCategoryBySlug(ctx, categorySlug)
+	// Get category by its slug in the language of the page
+	category, err := s.findCategoryForLanguage(ctx, language, categorySlug)
 	if err != nil {
 		return "", &domain.BlogRenderError{
 			Code:    domain.ErrCodeCategoryNotFound,
@@ -1903,6 +2135,7 @@ func (s *BlogService) RenderCategoryPage(ctx context.Context, workspaceID, categ
 	params := &domain.ListBlogPostsRequest{
 		CategoryID: category.ID,
 		Status:     domain.BlogPostStatusPublished,
+		Language:   language,
 		Page:       page,
 		Limit:      pageSize,
 	}
@@ -1937,15 +2170,22 @@ func (s *BlogService) RenderCategoryPage(ctx context.Context, workspaceID, categ
 		categories = []*domain.BlogCategory{}
 	}
 
+	languageLinks := blogLanguageLinks(workspace, language, func(linkLanguage string) (string, bool) {
+		return "/" + category.Localize(linkLanguage).Slug, true
+	})
+	localizedCategory := category.Localize(language)
+
 	// Build template data with pagination
 	templateData, err := domain.BuildBlogTemplateData(domain.BlogTemplateDataRequest{
 		Workspace:      workspace,
-		Category:       category,
+		Category:       localizedCategory,
 		PublicLists:    publicLists,
-		Posts:          postsResponse.Posts,
-		Categories:     categories,
+		Posts:          localizePosts(postsResponse.Posts, language),
+		Categories:     localizeCategories(categories, language),
 		ThemeVersion:   theme.Version,
 		PaginationData: postsResponse,
+		Language:       language,
+		Languages:      languageLinks,
 	})
 	if err != nil {
 		return "", &domain.BlogRenderError{
@@ -1989,6 +2229,6 @@ func (s *BlogService) RenderCategoryPage(ctx context.Context, workspaceID, categ
 		}
 	}
 
-	html = liquid.InjectFeedDiscoveryTags(html, blogTitleForDiscovery(workspace), categorySlug)
+	html = injectLanguageTags(html, workspace, language, localizedCategory.Slug, languageLinks)
 	return html, nil
 }
@@ -35,8 +35,12 @@ func setupBlogServiceTest(t *testing.T) (
 	mockThemeRepo := mocks.NewMockBlogThemeRepository(ctrl)
 	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
 	mockListRepo := mocks.NewMockListRepository(ctrl)
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
 	mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
 	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockTaskService := mocks.NewMockTaskService(ctrl)
+	mockBroadcastService := mocks.NewMockBroadcastService(ctrl)
+	mockTransactionalService := mocks.NewMockTransactionalNotificationService(ctrl)
 	mockLogger := logger.NewLoggerWithLevel("disabled")
 	testCache := cache.NewInMemoryCache(30 * time.Second)
 
@@ -47,8 +51,12 @@ func setupBlogServiceTest(t *testing.T) (
 		mockThemeRepo,
 		mockWorkspaceRepo,
 		mockListRepo,
+		mockContactListRepo,
 		mockTemplateRepo,
 		mockAuthService,
+		mockTaskService,
+		mockBroadcastService,
+		mockTransactionalService,
 		testCache,
 	)
 
@@ -240,7 +248,7 @@ func TestBlogService_GetPublicCategoryBySlug(t *testing.T) {
 			GetCategoryBySlug(ctx, "tech-blog").
 			Return(expectedCategory, nil)
 
-		category, err := service.GetPublicCategoryBySlug(ctx, "tech-blog")
+		category, err := service.GetPublicCategoryBySlug(ctx, "", "tech-blog")
 		require.NoError(t, err)
 		assert.Equal(t, expectedCategory, category)
 	})
@@ -252,7 +260,7 @@ func TestBlogService_GetPublicCategoryBySlug(t *testing.T) {
 			GetCategoryBySlug(ctx, "nonexistent").
 			Return(nil, errors.New("category not found"))
 
-		category, err := service.GetPublicCategoryBySlug(ctx, "nonexistent")
+		category, err := service.GetPublicCategoryBySlug(ctx, "", "nonexistent")
 		assert.Error(t, err)
 		assert.Nil(t, category)
 	})
@@ -266,7 +274,7 @@ func TestBlogService_GetPublicCategoryBySlug(t *testing.T) {
 			GetCategoryBySlug(ctx, "tech-blog").
 			Return(nil, errors.New("category not found"))
 
-		category, err := service.GetPublicCategoryBySlug(ctx, "tech-blog")
+		category, err := service.GetPublicCategoryBySlug(ctx, "", "tech-blog")
 		assert.Error(t, err)
 		assert.Nil(t, category)
 	})
@@ -543,7 +551,7 @@ func TestBlogService_ListCategories(t *testing.T) {
 }
 
 func TestBlogService_CreatePost(t *testing.T) {
-	service, mockCategoryRepo, mockPostRepo, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+	service, mockCategoryRepo, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, mockAuthService := setupBlogServiceTest(t)
 
 	categoryID := "cat123"
 
@@ -567,16 +575,30 @@ func TestBlogService_CreatePost(t *testing.T) {
 			GetCategory(ctx, categoryID).
 			Return(&domain.BlogCategory{ID: categoryID}, nil)
 
-		// Mock create
+		// Mock create, with the first revision in the same transaction
+		mockPostRepo.EXPECT().
+			WithTransaction(ctx, "workspace123", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, fn func(*sql.Tx) error) error {
+				return fn(nil)
+			})
 		mockPostRepo.EXPECT().
-			CreatePost(ctx, gomock.Any()).
-			DoAndReturn(func(ctx context.Context, post *domain.BlogPost) error {
+			CreatePostTx(ctx, nil, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, tx *sql.Tx, post *domain.BlogPost) error {
 				assert.Equal(t, req.Title, post.Settings.Title)
 				assert.Equal(t, req.Slug, post.Slug)
 				assert.NotEmpty(t, post.ID)
 				assert.Nil(t, post.PublishedAt) // Draft by default
 				return nil
 			})
+		mockPostRepo.EXPECT().
+			CreateRevisionTx(ctx, nil, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, tx *sql.Tx, revision *domain.BlogPostRevision) error {
+				assert.Equal(t, req.Slug, revision.Slug)
+				assert.Equal(t, domain.BlogPostRevisionStatusApplied, revision.Status)
+				assert.Equal(t, "user123", revision.CreatedBy)
+				return nil
+			})
+		expectPostSearchIndex(mockWorkspaceRepo, mockTemplateRepo, mockPostRepo)
 
 		post, err := service.CreatePost(ctx, req)
 		require.NoError(t, err)
@@ -669,7 +691,7 @@ func TestBlogService_GetPost(t *testing.T) {
 }
 
 func TestBlogService_UpdatePost(t *testing.T) {
-	service, mockCategoryRepo, mockPostRepo, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+	service, mockCategoryRepo, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, mockAuthService := setupBlogServiceTest(t)
 
 	categoryID := "cat123"
 
@@ -709,8 +731,23 @@ func TestBlogService_UpdatePost(t *testing.T) {
 			Return(category, nil)
 
 		mockPostRepo.EXPECT().
-			UpdatePost(ctx, gomock.Any()).
+			WithTransaction(ctx, "workspace123", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, fn func(*sql.Tx) error) error {
+				return fn(nil)
+			})
+		mockPostRepo.EXPECT().
+			UpdatePostTx(ctx, nil, gomock.Any()).
 			Return(nil)
+		// The update is recorded in the revision history
+		mockPostRepo.EXPECT().
+			CreateRevisionTx(ctx, nil, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, tx *sql.Tx, revision *domain.BlogPostRevision) error {
+				assert.Equal(t, "post123", revision.PostID)
+				assert.Equal(t, req.Title, revision.Settings.Title)
+				assert.Equal(t, domain.BlogPostRevisionStatusApplied, revision.Status)
+				return nil
+			})
+		expectPostSearchIndex(mockWorkspaceRepo, mockTemplateRepo, mockPostRepo)
 
 		post, err := service.UpdatePost(ctx, req)
 		require.NoError(t, err)
@@ -874,7 +911,7 @@ func TestBlogService_ListPosts(t *testing.T) {
 }
 
 func TestBlogService_PublishPost(t *testing.T) {
-	service, _, mockPostRepo, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
+	service, mockCategoryRepo, mockPostRepo, _, _, _, _, mockAuthService := setupBlogServiceTest(t)
 
 	t.Run("successful publish", func(t *testing.T) {
 		ctx := setupBlogContextWithAuth(mockAuthService, "workspace123", true, true)
@@ -897,6 +934,11 @@ func TestBlogService_PublishPost(t *testing.T) {
 			PublishPost(ctx, req.ID, req.PublishedAt).
 			Return(nil)
 
+		// The category does not send a newsletter
+		mockCategoryRepo.EXPECT().
+			GetCategory(ctx, "cat-1").
+			Return(&domain.BlogCategory{ID: "cat-1", Slug: "news"}, nil)
+
 		err := service.PublishPost(ctx, req)
 		require.NoError(t, err)
 	})
@@ -991,7 +1033,7 @@ func TestBlogService_GetPublicPostByCategoryAndSlug(t *testing.T) {
 			GetPostByCategoryAndSlug(ctx, "tech", "my-post").
 			Return(expectedPost, nil)
 
-		post, err := service.GetPublicPostByCategoryAndSlug(ctx, "tech", "my-post")
+		post, err := service.GetPublicPostByCategoryAndSlug(ctx, "", "tech", "my-post")
 		require.NoError(t, err)
 		assert.Equal(t, expectedPost, post)
 	})
@@ -1007,7 +1049,7 @@ func TestBlogService_GetPublicPostByCategoryAndSlug(t *testing.T) {
 			GetPostByCategoryAndSlug(ctx, "tech", "my-draft").
 			Return(draftPost, nil)
 
-		post, err := service.GetPublicPostByCategoryAndSlug(ctx, "tech", "my-draft")
+		post, err := service.GetPublicPostByCategoryAndSlug(ctx, "", "tech", "my-draft")
 		require.Error(t, err)
 		assert.Nil(t, post)
 		assert.Contains(t, err.Error(), "post not found")
@@ -1018,7 +1060,7 @@ func TestBlogService_GetPublicPostByCategoryAndSlug(t *testing.T) {
 			GetPostByCategoryAndSlug(ctx, "tech", "nonexistent").
 			Return(nil, errors.New("not found"))
 
-		post, err := service.GetPublicPostByCategoryAndSlug(ctx, "tech", "nonexistent")
+		post, err := service.GetPublicPostByCategoryAndSlug(ctx, "", "tech", "nonexistent")
 		require.Error(t, err)
 		assert.Nil(t, post)
 	})
@@ -1541,7 +1583,7 @@ func TestBlogService_RenderHomePage(t *testing.T) {
 			GetCategoriesByIDs(ctx, []string{"cat-1"}).
 			Return(categories, nil)
 
-		html, err := service.RenderHomePage(ctx, "workspace123", 1, nil)
+		html, err := service.RenderHomePage(ctx, "workspace123", "", 1, nil)
 		require.NoError(t, err)
 		assert.Contains(t, html, "Test Workspace")
 		assert.Contains(t, html, "Newsletter")
@@ -1618,7 +1660,7 @@ func TestBlogService_RenderHomePage(t *testing.T) {
 			GetCategoriesByIDs(ctx, []string{"cat-deleted"}).
 			Return([]*domain.BlogCategory{deletedCategory}, nil)
 
-		html, err := service.RenderHomePage(ctx, "workspace123", 1, nil)
+		html, err := service.RenderHomePage(ctx, "workspace123", "", 1, nil)
 		require.NoError(t, err)
 		// Should contain active category in navigation
 		assert.Contains(t, html, "Active Category")
@@ -1633,7 +1675,7 @@ func TestBlogService_RenderHomePage(t *testing.T) {
 		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(workspace, nil)
 		mockThemeRepo.EXPECT().GetPublishedTheme(ctx).Return(nil, errors.New("no published theme found"))
 
-		html, err := service.RenderHomePage(ctx, "workspace123", 1, nil)
+		html, err := service.RenderHomePage(ctx, "workspace123", "", 1, nil)
 		assert.Error(t, err)
 		assert.Empty(t, html)
 
@@ -1666,7 +1708,7 @@ func TestBlogService_RenderHomePage(t *testing.T) {
 		service.categoryRepo = mockCategoryRepo
 		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{}, nil)
 
-		html, err := service.RenderHomePage(ctx, "workspace123", 1, nil)
+		html, err := service.RenderHomePage(ctx, "workspace123", "", 1, nil)
 		require.NoError(t, err)
 		assert.Contains(t, html, "Home")
 	})
@@ -1699,7 +1741,7 @@ func TestBlogService_RenderHomePage(t *testing.T) {
 			TotalPages:  2,
 		}, nil)
 
-		html, err := service.RenderHomePage(ctx, "workspace123", 5, nil)
+		html, err := service.RenderHomePage(ctx, "workspace123", "", 5, nil)
 		assert.Error(t, err)
 		assert.Empty(t, html)
 
@@ -1772,7 +1814,7 @@ func TestBlogService_RenderPostPage(t *testing.T) {
 		mockListRepo.EXPECT().GetLists(ctx, "workspace123").Return(publicLists, nil)
 		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{category}, nil)
 
-		html, err := service.RenderPostPage(ctx, "workspace123", "tech", "test-post", nil)
+		html, err := service.RenderPostPage(ctx, "workspace123", "", "tech", "test-post", nil)
 		require.NoError(t, err)
 		assert.Contains(t, html, "Test Post")
 		assert.Contains(t, html, "Blog post content")
@@ -1804,7 +1846,7 @@ func TestBlogService_RenderPostPage(t *testing.T) {
 		mockThemeRepo.EXPECT().GetPublishedTheme(ctx).Return(theme, nil)
 		mockPostRepo.EXPECT().GetPostByCategoryAndSlug(ctx, "tech", "draft-post").Return(post, nil)
 
-		html, err := service.RenderPostPage(ctx, "workspace123", "tech", "draft-post", nil)
+		html, err := service.RenderPostPage(ctx, "workspace123", "", "tech", "draft-post", nil)
 		assert.Error(t, err)
 		assert.Empty(t, html)
 
@@ -1824,7 +1866,7 @@ func TestBlogService_RenderPostPage(t *testing.T) {
 		mockThemeRepo.EXPECT().GetPublishedTheme(ctx).Return(theme, nil)
 		mockPostRepo.EXPECT().GetPostByCategoryAndSlug(ctx, "tech", "nonexistent").Return(nil, errors.New("not found"))
 
-		html, err := service.RenderPostPage(ctx, "workspace123", "tech", "nonexistent", nil)
+		html, err := service.RenderPostPage(ctx, "workspace123", "", "tech", "nonexistent", nil)
 		assert.Error(t, err)
 		assert.Empty(t, html)
 
@@ -1884,7 +1926,7 @@ func TestBlogService_RenderPostPage(t *testing.T) {
 		mockListRepo.EXPECT().GetLists(ctx, "workspace123").Return(publicLists, nil)
 		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{category}, nil)
 
-		html, err := service.RenderPostPage(ctx, "workspace123", "tech", "test-post", nil)
+		html, err := service.RenderPostPage(ctx, "workspace123", "", "tech", "test-post", nil)
 		require.NoError(t, err)
 		assert.Contains(t, html, "Test Post")
 		// Content should be empty when template is not found
@@ -1949,7 +1991,7 @@ func TestBlogService_RenderPostPage(t *testing.T) {
 		mockListRepo.EXPECT().GetLists(ctx, "workspace123").Return(publicLists, nil)
 		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{category}, nil)
 
-		html, err := service.RenderPostPage(ctx, "workspace123", "tech", "test-post", nil)
+		html, err := service.RenderPostPage(ctx, "workspace123", "", "tech", "test-post", nil)
 		require.NoError(t, err)
 		assert.Contains(t, html, "Test Post")
 		// Content should be empty when template has no web content
@@ -1999,7 +2041,7 @@ func TestBlogService_RenderCategoryPage(t *testing.T) {
 		mockPostRepo.EXPECT().ListPosts(ctx, gomock.Any()).Return(&domain.BlogPostListResponse{Posts: posts, TotalCount: 1}, nil)
 		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{category}, nil)
 
-		html, err := service.RenderCategoryPage(ctx, "workspace123", "tech", 1, nil)
+		html, err := service.RenderCategoryPage(ctx, "workspace123", "", "tech", 1, nil)
 		require.NoError(t, err)
 		assert.Contains(t, html, "Technology")
 	})
@@ -2015,7 +2057,7 @@ func TestBlogService_RenderCategoryPage(t *testing.T) {
 		mockThemeRepo.EXPECT().GetPublishedTheme(ctx).Return(theme, nil)
 		mockCategoryRepo.EXPECT().GetCategoryBySlug(ctx, "nonexistent").Return(nil, errors.New("not found"))
 
-		html, err := service.RenderCategoryPage(ctx, "workspace123", "nonexistent", 1, nil)
+		html, err := service.RenderCategoryPage(ctx, "workspace123", "", "nonexistent", 1, nil)
 		assert.Error(t, err)
 		assert.Empty(t, html)
 
@@ -2061,7 +2103,7 @@ func TestBlogService_RenderCategoryPage(t *testing.T) {
 			TotalPages:  3,
 		}, nil)
 
-		html, err := service.RenderCategoryPage(ctx, "workspace123", "tech", 10, nil)
+		html, err := service.RenderCategoryPage(ctx, "workspace123", "", "tech", 10, nil)
 		assert.Error(t, err)
 		assert.Empty(t, html)
 
@@ -2121,7 +2163,7 @@ func TestBlogService_RenderHomePage_WithPaginationSettings(t *testing.T) {
 		service.categoryRepo = mockCategoryRepo
 		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{}, nil)
 
-		html, err := service.RenderHomePage(ctx, "workspace123", 1, nil)
+		html, err := service.RenderHomePage(ctx, "workspace123", "", 1, nil)
 		require.NoError(t, err)
 		assert.NotEmpty(t, html)
 	})
@@ -2167,7 +2209,7 @@ func TestBlogService_RenderHomePage_WithPaginationSettings(t *testing.T) {
 		service.categoryRepo = mockCategoryRepo
 		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{}, nil)
 
-		html, err := service.RenderHomePage(ctx, "workspace123", 1, nil)
+		html, err := service.RenderHomePage(ctx, "workspace123", "", 1, nil)
 		require.NoError(t, err)
 		assert.NotEmpty(t, html)
 	})
@@ -2215,7 +2257,7 @@ func TestBlogService_RenderHomePage_WithPaginationSettings(t *testing.T) {
 		service.categoryRepo = mockCategoryRepo
 		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{}, nil)
 
-		html, err := service.RenderHomePage(ctx, "workspace123", 1, nil)
+		html, err := service.RenderHomePage(ctx, "workspace123", "", 1, nil)
 		require.NoError(t, err)
 		assert.NotEmpty(t, html)
 	})
@@ -2277,7 +2319,7 @@ func TestBlogService_RenderCategoryPage_WithPaginationSettings(t *testing.T) {
 
 		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{category}, nil)
 
-		html, err := service.RenderCategoryPage(ctx, "workspace123", "tech", 1, nil)
+		html, err := service.RenderCategoryPage(ctx, "workspace123", "", "tech", 1, nil)
 		require.NoError(t, err)
 		assert.NotEmpty(t, html)
 	})
@@ -2330,7 +2372,7 @@ func TestBlogService_RenderCategoryPage_WithPaginationSettings(t *testing.T) {
 
 		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{category}, nil)
 
-		html, err := service.RenderCategoryPage(ctx, "workspace123", "tech", 1, nil)
+		html, err := service.RenderCategoryPage(ctx, "workspace123", "", "tech", 1, nil)
 		require.NoError(t, err)
 		assert.NotEmpty(t, html)
 	})
@@ -2411,13 +2453,15 @@ func TestBlogService_RenderPostContent(t *testing.T) {
 	}
 
 	t.Run("renders body HTML with absolute URLs and XSS stripped", func(t *testing.T) {
-		service, _, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, _ := setupBlogServiceTest(t)
+		service, mockCategoryRepo, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, _ := setupBlogServiceTest(t)
 		ctx := context.Background()
 
 		mockWorkspaceRepo.EXPECT().
 			GetByID(ctx, workspaceID).Return(newWorkspace(), nil)
 		mockPostRepo.EXPECT().
 			GetPostByCategoryAndSlug(ctx, "tech", "hello").Return(newPost(), nil)
+		mockCategoryRepo.EXPECT().
+			GetCategory(ctx, "cat-1").Return(&domain.BlogCategory{ID: "cat-1", Slug: "tech"}, nil)
 		mockTemplateRepo.EXPECT().
 			GetTemplateByID(ctx, workspaceID, "tpl-1", int64(3)).
 			Return(&domain.Template{
@@ -2432,6 +2476,26 @@ func TestBlogService_RenderPostContent(t *testing.T) {
 		assert.NotContains(t, out, "<script")
 	})
 
+	t.Run("returns the excerpt of gated posts", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, _, mockWorkspaceRepo, _, _, _ := setupBlogServiceTest(t)
+		ctx := context.Background()
+
+		gated := newPost()
+		gated.Settings.Excerpt = "Members only"
+		gated.Settings.Gate = &domain.BlogGate{ListID: "members"}
+
+		mockWorkspaceRepo.EXPECT().
+			GetByID(ctx, workspaceID).Return(newWorkspace(), nil)
+		mockPostRepo.EXPECT().
+			GetPostByCategoryAndSlug(ctx, "tech", "hello").Return(gated, nil)
+		mockCategoryRepo.EXPECT().
+			GetCategory(ctx, "cat-1").Return(&domain.BlogCategory{ID: "cat-1", Slug: "tech"}, nil)
+
+		out, err := service.RenderPostContent(ctx, workspaceID, "tech", "hello")
+		require.NoError(t, err)
+		assert.Equal(t, "Members only", out)
+	})
+
 	t.Run("errors with PostNotFound when post missing", func(t *testing.T) {
 		service, _, mockPostRepo, _, mockWorkspaceRepo, _, _, _ := setupBlogServiceTest(t)
 		ctx := context.Background()
@@ -2468,13 +2532,15 @@ func TestBlogService_RenderPostContent(t *testing.T) {
 	})
 
 	t.Run("errors when template has no web content", func(t *testing.T) {
-		service, _, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, _ := setupBlogServiceTest(t)
+		service, mockCategoryRepo, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, _ := setupBlogServiceTest(t)
 		ctx := context.Background()
 
 		mockWorkspaceRepo.EXPECT().
 			GetByID(ctx, workspaceID).Return(newWorkspace(), nil)
 		mockPostRepo.EXPECT().
 			GetPostByCategoryAndSlug(ctx, "tech", "hello").Return(newPost(), nil)
+		mockCategoryRepo.EXPECT().
+			GetCategory(ctx, "cat-1").Return(&domain.BlogCategory{ID: "cat-1", Slug: "tech"}, nil)
 		mockTemplateRepo.EXPECT().
 			GetTemplateByID(ctx, workspaceID, "tpl-1", int64(3)).
 			Return(&domain.Template{Web: nil}, nil)
@@ -2544,16 +2610,16 @@ func TestBlogService_BuildFeed(t *testing.T) {
 
 		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(newWorkspace(true), nil)
 		mockPostRepo.EXPECT().
-			ListFeedPosts(gomock.Any(), nil, 5).
+			ListFeedPosts(gomock.Any(), "", nil, 5).
 			Return([]*domain.BlogPost{newPost("p1", "hello", "Hello", "A warm greeting.")}, nil)
 		mockPostRepo.EXPECT().
-			GetFeedFingerprint(gomock.Any(), nil, 5).
+			GetFeedFingerprint(gomock.Any(), "", nil, 5).
 			Return(pubTime, "abcd", nil)
 		mockCategoryRepo.EXPECT().
 			GetCategoriesByIDs(gomock.Any(), []string{"cat-1"}).
 			Return([]*domain.BlogCategory{newCategory()}, nil)
 
-		feed, err := service.BuildFeed(ctx, workspaceID, nil)
+		feed, err := service.BuildFeed(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
 		require.Len(t, feed.Items, 1)
 		assert.Equal(t, "A warm greeting.", feed.Items[0].ContentHTML)
@@ -2571,10 +2637,10 @@ func TestBlogService_BuildFeed(t *testing.T) {
 		// lists posts once, batches categories once, batches templates once.
 		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(newWorkspace(false), nil)
 		mockPostRepo.EXPECT().
-			ListFeedPosts(gomock.Any(), nil, 5).
+			ListFeedPosts(gomock.Any(), "", nil, 5).
 			Return([]*domain.BlogPost{newPost("p1", "hello", "Hello", "Excerpt")}, nil)
 		mockPostRepo.EXPECT().
-			GetFeedFingerprint(gomock.Any(), nil, 5).
+			GetFeedFingerprint(gomock.Any(), "", nil, 5).
 			Return(pubTime, "abcd", nil)
 		mockCategoryRepo.EXPECT().
 			GetCategoriesByIDs(gomock.Any(), []string{"cat-1"}).
@@ -2583,7 +2649,7 @@ func TestBlogService_BuildFeed(t *testing.T) {
 			GetTemplateByID(gomock.Any(), workspaceID, "tpl-1", int64(1)).
 			Return(&domain.Template{Web: &domain.WebTemplate{HTML: `<p>Body</p>`}}, nil)
 
-		feed, err := service.BuildFeed(ctx, workspaceID, nil)
+		feed, err := service.BuildFeed(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
 		require.Len(t, feed.Items, 1)
 		assert.Contains(t, feed.Items[0].ContentHTML, "<p>Body</p>")
@@ -2595,13 +2661,13 @@ func TestBlogService_BuildFeed(t *testing.T) {
 
 		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(newWorkspace(false), nil)
 		mockPostRepo.EXPECT().
-			ListFeedPosts(gomock.Any(), nil, 5).
+			ListFeedPosts(gomock.Any(), "", nil, 5).
 			Return([]*domain.BlogPost{
 				newPost("p1", "a", "A", "x"),
 				newPost("p2", "b", "B", "y"),
 				newPost("p3", "c", "C", "z"),
 			}, nil)
-		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), nil, 5).Return(pubTime, "abcd", nil)
+		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), "", nil, 5).Return(pubTime, "abcd", nil)
 		mockCategoryRepo.EXPECT().
 			GetCategoriesByIDs(gomock.Any(), []string{"cat-1"}).
 			Return([]*domain.BlogCategory{newCategory()}, nil)
@@ -2611,7 +2677,7 @@ func TestBlogService_BuildFeed(t *testing.T) {
 			Return(&domain.Template{Web: &domain.WebTemplate{HTML: `<p>Body</p>`}}, nil).
 			Times(1)
 
-		feed, err := service.BuildFeed(ctx, workspaceID, nil)
+		feed, err := service.BuildFeed(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
 		require.Len(t, feed.Items, 3)
 	})
@@ -2622,10 +2688,10 @@ func TestBlogService_BuildFeed(t *testing.T) {
 
 		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(newWorkspace(false), nil)
 		mockPostRepo.EXPECT().
-			ListFeedPosts(gomock.Any(), nil, 5).
+			ListFeedPosts(gomock.Any(), "", nil, 5).
 			Return([]*domain.BlogPost{newPost("p1", "hello", "Hello", "Fallback excerpt")}, nil)
 		mockPostRepo.EXPECT().
-			GetFeedFingerprint(gomock.Any(), nil, 5).
+			GetFeedFingerprint(gomock.Any(), "", nil, 5).
 			Return(pubTime, "abcd", nil)
 		mockCategoryRepo.EXPECT().
 			GetCategoriesByIDs(gomock.Any(), []string{"cat-1"}).
@@ -2636,7 +2702,7 @@ func TestBlogService_BuildFeed(t *testing.T) {
 			GetTemplateByID(gomock.Any(), workspaceID, "tpl-1", int64(1)).
 			Return(nil, errors.New("template missing"))
 
-		feed, err := service.BuildFeed(ctx, workspaceID, nil)
+		feed, err := service.BuildFeed(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
 		require.Len(t, feed.Items, 1)
 		assert.Equal(t, "Fallback excerpt", feed.Items[0].ContentHTML)
@@ -2648,10 +2714,10 @@ func TestBlogService_BuildFeed(t *testing.T) {
 
 		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(newWorkspace(false), nil)
 		mockPostRepo.EXPECT().
-			ListFeedPosts(gomock.Any(), nil, 5).
+			ListFeedPosts(gomock.Any(), "", nil, 5).
 			Return([]*domain.BlogPost{newPost("p1", "hello", "Hello", "")}, nil)
 		mockPostRepo.EXPECT().
-			GetFeedFingerprint(gomock.Any(), nil, 5).
+			GetFeedFingerprint(gomock.Any(), "", nil, 5).
 			Return(pubTime, "abcd", nil)
 		mockCategoryRepo.EXPECT().
 			GetCategoriesByIDs(gomock.Any(), []string{"cat-1"}).
@@ -2660,7 +2726,7 @@ func TestBlogService_BuildFeed(t *testing.T) {
 			GetTemplateByID(gomock.Any(), workspaceID, "tpl-1", int64(1)).
 			Return(nil, errors.New("template missing"))
 
-		feed, err := service.BuildFeed(ctx, workspaceID, nil)
+		feed, err := service.BuildFeed(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
 		assert.Empty(t, feed.Items)
 	})
@@ -2674,7 +2740,7 @@ func TestBlogService_BuildFeed(t *testing.T) {
 		ws.Settings.CustomEndpointURL = nil
 		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(ws, nil)
 
-		_, err := service.BuildFeed(ctx, workspaceID, nil)
+		_, err := service.BuildFeed(ctx, workspaceID, "", nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "no website URL")
 	})
@@ -2686,15 +2752,15 @@ func TestBlogService_BuildFeed(t *testing.T) {
 
 		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(newWorkspace(true), nil)
 		mockPostRepo.EXPECT().
-			ListFeedPosts(gomock.Any(), &slug, 5).
+			ListFeedPosts(gomock.Any(), "", &slug, 5).
 			Return([]*domain.BlogPost{}, nil)
 		mockPostRepo.EXPECT().
-			GetFeedFingerprint(gomock.Any(), &slug, 5).
+			GetFeedFingerprint(gomock.Any(), "", &slug, 5).
 			Return(pubTime, "abcd", nil)
 		// No categories to resolve because no posts.
 		_ = mockCategoryRepo
 
-		feed, err := service.BuildFeed(ctx, workspaceID, &slug)
+		feed, err := service.BuildFeed(ctx, workspaceID, "", &slug)
 		require.NoError(t, err)
 		assert.Equal(t, "https://blog.example.com/tech/feed.xml", feed.Meta.SelfURL)
 	})
@@ -2708,14 +2774,14 @@ func TestBlogService_BuildFeed(t *testing.T) {
 
 		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(ws, nil)
 		mockPostRepo.EXPECT().
-			ListFeedPosts(gomock.Any(), nil, 5).
+			ListFeedPosts(gomock.Any(), "", nil, 5).
 			Return([]*domain.BlogPost{}, nil)
 		// Repo returns zero-time for empty feeds; service must substitute.
 		mockPostRepo.EXPECT().
-			GetFeedFingerprint(gomock.Any(), nil, 5).
+			GetFeedFingerprint(gomock.Any(), "", nil, 5).
 			Return(time.Time{}, "", nil)
 
-		feed, err := service.BuildFeed(ctx, workspaceID, nil)
+		feed, err := service.BuildFeed(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
 		assert.True(t, feed.Meta.UpdatedAt.Equal(ws.UpdatedAt.UTC()),
 			"expected UpdatedAt to fall back to workspace.UpdatedAt, got %s", feed.Meta.UpdatedAt)
@@ -2728,14 +2794,14 @@ func TestBlogService_BuildFeed(t *testing.T) {
 
 		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(newWorkspace(true), nil)
 		mockPostRepo.EXPECT().
-			ListFeedPosts(gomock.Any(), nil, 5).
+			ListFeedPosts(gomock.Any(), "", nil, 5).
 			Return([]*domain.BlogPost{newPost("p1", "hola", "Héllo 👋 مرحبا", "Excerpt")}, nil)
-		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), nil, 5).Return(pubTime, "abcd", nil)
+		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), "", nil, 5).Return(pubTime, "abcd", nil)
 		mockCategoryRepo.EXPECT().
 			GetCategoriesByIDs(gomock.Any(), []string{"cat-1"}).
 			Return([]*domain.BlogCategory{newCategory()}, nil)
 
-		feed, err := service.BuildFeed(ctx, workspaceID, nil)
+		feed, err := service.BuildFeed(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
 		require.Len(t, feed.Items, 1)
 		assert.Equal(t, "Héllo 👋 مرحبا", feed.Items[0].Title)
@@ -2747,11 +2813,11 @@ func TestBlogService_BuildFeed(t *testing.T) {
 
 		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(newWorkspace(true), nil)
 		mockPostRepo.EXPECT().
-			ListFeedPosts(gomock.Any(), nil, 5).
+			ListFeedPosts(gomock.Any(), "", nil, 5).
 			Return([]*domain.BlogPost{}, nil)
-		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), nil, 5).Return(pubTime, "abcd", nil)
+		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), "", nil, 5).Return(pubTime, "abcd", nil)
 
-		feed, err := service.BuildFeed(ctx, workspaceID, nil)
+		feed, err := service.BuildFeed(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
 		assert.True(t, strings.HasPrefix(feed.Meta.ETag, `W/"`),
 			"expected weak ETag prefix, got %q", feed.Meta.ETag)
@@ -2772,11 +2838,11 @@ func TestBlogService_BuildFeed(t *testing.T) {
 		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(ws, nil)
 		// nil BlogSettings → GetFeedMaxItems returns 20 (nil-safe)
 		mockPostRepo.EXPECT().
-			ListFeedPosts(gomock.Any(), nil, 20).
+			ListFeedPosts(gomock.Any(), "", nil, 20).
 			Return([]*domain.BlogPost{}, nil)
-		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), nil, 20).Return(time.Time{}, "", nil)
+		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), "", nil, 20).Return(time.Time{}, "", nil)
 
-		feed, err := service.BuildFeed(ctx, workspaceID, nil)
+		feed, err := service.BuildFeed(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
 		assert.Empty(t, feed.Items)
 		assert.Equal(t, "No Settings Blog", feed.Meta.Title, "should fall back to workspace name")
@@ -2801,11 +2867,11 @@ func TestBlogService_GetFeedFingerprint(t *testing.T) {
 		ctx := context.Background()
 
 		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(mkWorkspace(false), nil).Times(2)
-		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), nil, 10).Return(updated, "hash-x", nil).Times(2)
+		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), "", nil, 10).Return(updated, "hash-x", nil).Times(2)
 
-		_, etag1, err := service.GetFeedFingerprint(ctx, workspaceID, nil)
+		_, etag1, err := service.GetFeedFingerprint(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
-		_, etag2, err := service.GetFeedFingerprint(ctx, workspaceID, nil)
+		_, etag2, err := service.GetFeedFingerprint(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
 		assert.Equal(t, etag1, etag2)
 	})
@@ -2816,12 +2882,12 @@ func TestBlogService_GetFeedFingerprint(t *testing.T) {
 		slug := "tech"
 
 		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(mkWorkspace(false), nil).Times(2)
-		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), nil, 10).Return(updated, "hash-x", nil)
-		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), &slug, 10).Return(updated, "hash-x", nil)
+		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), "", nil, 10).Return(updated, "hash-x", nil)
+		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), "", &slug, 10).Return(updated, "hash-x", nil)
 
-		_, mainETag, err := service.GetFeedFingerprint(ctx, workspaceID, nil)
+		_, mainETag, err := service.GetFeedFingerprint(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
-		_, catETag, err := service.GetFeedFingerprint(ctx, workspaceID, &slug)
+		_, catETag, err := service.GetFeedFingerprint(ctx, workspaceID, "", &slug)
 		require.NoError(t, err)
 		assert.NotEqual(t, mainETag, catETag)
 	})
@@ -2831,12 +2897,12 @@ func TestBlogService_GetFeedFingerprint(t *testing.T) {
 		ctx := context.Background()
 
 		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(mkWorkspace(false), nil).Times(2)
-		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), nil, 10).Return(updated, "before", nil)
-		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), nil, 10).Return(updated, "after", nil)
+		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), "", nil, 10).Return(updated, "before", nil)
+		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), "", nil, 10).Return(updated, "after", nil)
 
-		_, before, err := service.GetFeedFingerprint(ctx, workspaceID, nil)
+		_, before, err := service.GetFeedFingerprint(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
-		_, after, err := service.GetFeedFingerprint(ctx, workspaceID, nil)
+		_, after, err := service.GetFeedFingerprint(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
 		assert.NotEqual(t, before, after)
 	})
@@ -2847,11 +2913,11 @@ func TestBlogService_GetFeedFingerprint(t *testing.T) {
 
 		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(mkWorkspace(false), nil)
 		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(mkWorkspace(true), nil)
-		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), nil, 10).Return(updated, "hash-x", nil).Times(2)
+		mockPostRepo.EXPECT().GetFeedFingerprint(gomock.Any(), "", nil, 10).Return(updated, "hash-x", nil).Times(2)
 
-		_, etagBefore, err := service.GetFeedFingerprint(ctx, workspaceID, nil)
+		_, etagBefore, err := service.GetFeedFingerprint(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
-		_, etagAfter, err := service.GetFeedFingerprint(ctx, workspaceID, nil)
+		_, etagAfter, err := service.GetFeedFingerprint(ctx, workspaceID, "", nil)
 		require.NoError(t, err)
 		assert.NotEqual(t, etagBefore, etagAfter)
 	})
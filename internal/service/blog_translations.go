@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/liquid"
+)
+
+// ==============================
+// Translation Operations
+// ==============================
+
+// checkTranslationLanguages checks that translations are in languages of the workspace
+// other than its default language, which is the language of the content itself
+func (s *BlogService) checkTranslationLanguages(ctx context.Context, workspaceID string, languages []string) error {
+	if len(languages) == 0 {
+		return nil
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	for _, language := range languages {
+		if !workspace.Settings.IsTranslationLanguage(language) {
+			return domain.NewValidationError(fmt.Sprintf("translation language '%s' must be one of the workspace languages other than the default language", language))
+		}
+	}
+
+	return nil
+}
+
+// checkPostTranslations checks the languages of the translations of a post, and that no
+// other post of its category uses their slugs in the same language
+func (s *BlogService) checkPostTranslations(ctx context.Context, workspaceID string, post *domain.BlogPost) error {
+	languages := slices.Sorted(maps.Keys(post.Settings.Translations))
+	if err := s.checkTranslationLanguages(ctx, workspaceID, languages); err != nil {
+		return err
+	}
+
+	for _, language := range languages {
+		slug := post.Settings.Translations[language].Slug
+		existing, err := s.postRepo.GetPostByTranslationSlug(ctx, post.CategoryID, language, slug)
+		if err == nil && existing != nil && existing.ID != post.ID {
+			return fmt.Errorf("post with slug '%s' already exists in language '%s'", slug, language)
+		}
+	}
+
+	return nil
+}
+
+// checkCategoryTranslations checks the languages of the translations of a category, and
+// that no other category has the same slug in one of these languages
+func (s *BlogService) checkCategoryTranslations(ctx context.Context, workspaceID string, category *domain.BlogCategory) error {
+	languages := slices.Sorted(maps.Keys(category.Settings.Translations))
+	if err := s.checkTranslationLanguages(ctx, workspaceID, languages); err != nil {
+		return err
+	}
+	if len(languages) == 0 {
+		return nil
+	}
+
+	categories, err := s.categoryRepo.ListCategories(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	for _, other := range categories {
+		if other.ID == category.ID {
+			continue
+		}
+		for _, language := range languages {
+			slug := category.Localize(language).Slug
+			if other.Localize(language).Slug == slug {
+				return fmt.Errorf("category with slug '%s' already exists in language '%s'", slug, language)
+			}
+		}
+	}
+
+	return nil
+}
+
+// findCategoryForLanguage returns the category with a slug in a language. Categories
+// translated with this slug take precedence over the untranslated ones keeping their slug.
+func (s *BlogService) findCategoryForLanguage(ctx context.Context, language, slug string) (*domain.BlogCategory, error) {
+	if language == "" {
+		return s.categoryRepo.GetCategoryBySlug(ctx, slug)
+	}
+
+	categories, err := s.categoryRepo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var fallback *domain.BlogCategory
+	for _, category := range categories {
+		if translation, ok := category.Settings.Translations[language]; ok && translation.Slug == slug {
+			return category, nil
+		}
+		if fallback == nil && category.Localize(language).Slug == slug {
+			fallback = category
+		}
+	}
+	if fallback == nil {
+		return nil, fmt.Errorf("blog category not found")
+	}
+
+	return fallback, nil
+}
+
+// findPostForLanguage returns the post with category and post slugs in a language
+func (s *BlogService) findPostForLanguage(ctx context.Context, language, categorySlug, postSlug string) (*domain.BlogPost, error) {
+	if language == "" {
+		return s.postRepo.GetPostByCategoryAndSlug(ctx, categorySlug, postSlug)
+	}
+
+	category, err := s.findCategoryForLanguage(ctx, language, categorySlug)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.postRepo.GetPostByTranslationSlug(ctx, category.ID, language, postSlug)
+}
+
+// feedCategorySlug returns the slug in the default language of the category of a feed in
+// a language, which feeds are filtered on. Unknown slugs are kept, for an empty feed.
+func (s *BlogService) feedCategorySlug(ctx context.Context, language string, categorySlug *string) *string {
+	if language == "" || categorySlug == nil || *categorySlug == "" {
+		return categorySlug
+	}
+
+	category, err := s.findCategoryForLanguage(ctx, language, *categorySlug)
+	if err != nil {
+		return categorySlug
+	}
+	return &category.Slug
+}
+
+// localizePosts returns the posts translated in a language, localized
+func localizePosts(posts []*domain.BlogPost, language string) []*domain.BlogPost {
+	localized := make([]*domain.BlogPost, 0, len(posts))
+	for _, post := range posts {
+		if localizedPost := post.Localize(language); localizedPost != nil {
+			localized = append(localized, localizedPost)
+		}
+	}
+	return localized
+}
+
+// localizeCategories returns the categories localized in a language
+func localizeCategories(categories []*domain.BlogCategory, language string) []*domain.BlogCategory {
+	localized := make([]*domain.BlogCategory, len(categories))
+	for i, category := range categories {
+		localized[i] = category.Localize(language)
+	}
+	return localized
+}
+
+// checkBlogLanguage returns a not found render error for languages the workspace blog
+// is not translated in
+func checkBlogLanguage(workspace *domain.Workspace, language string) error {
+	if language != "" && !workspace.Settings.IsTranslationLanguage(language) {
+		return &domain.BlogRenderError{
+			Code:    domain.ErrCodePostNotFound, // Reuse for page not found
+			Message: fmt.Sprintf("Language %s is not available", language),
+		}
+	}
+	return nil
+}
+
+// blogLanguageLinks returns the URLs of a page in the languages of the workspace, in the
+// order of the workspace languages. pathFor returns the path of the page in a language
+// ("" for the default language) without its language prefix, or false when the page does
+// not exist in that language. Workspaces with a single language have no links.
+func blogLanguageLinks(workspace *domain.Workspace, current string, pathFor func(language string) (string, bool)) []domain.BlogLanguageLink {
+	if len(workspace.Settings.Languages) < 2 {
+		return nil
+	}
+
+	origin := workspaceBlogOrigin(workspace)
+	links := []domain.BlogLanguageLink{}
+	for _, code := range workspace.Settings.Languages {
+		language := code
+		if code == workspace.Settings.DefaultLanguage {
+			language = ""
+		}
+
+		path, ok := pathFor(language)
+		if !ok {
+			continue
+		}
+
+		links = append(links, domain.BlogLanguageLink{
+			Code:    code,
+			URL:     joinURL(origin, domain.BlogLanguagePrefix(language)+path),
+			Current: language == current,
+		})
+	}
+
+	return links
+}
+
+// injectLanguageTags adds the feed autodiscovery links of the page language, and the
+// hreflang links to its versions in the other languages, to a rendered blog page
+func injectLanguageTags(html string, workspace *domain.Workspace, language, categorySlug string, links []domain.BlogLanguageLink) string {
+	html = liquid.InjectLocalizedFeedDiscoveryTags(html, blogTitleForDiscovery(workspace), domain.BlogLanguagePrefix(language), categorySlug)
+
+	hreflangLinks := make([]liquid.HreflangLink, len(links))
+	defaultURL := ""
+	for i, link := range links {
+		hreflangLinks[i] = liquid.HreflangLink{Language: link.Code, URL: link.URL}
+		if link.Code == workspace.Settings.DefaultLanguage {
+			defaultURL = link.URL
+		}
+	}
+
+	return liquid.InjectHreflangTags(html, hreflangLinks, defaultURL)
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTranslationTestWorkspace() *domain.Workspace {
+	return &domain.Workspace{
+		ID:   "workspace123",
+		Name: "Test",
+		Settings: domain.WorkspaceSettings{
+			WebsiteURL:      "https://blog.example.com",
+			DefaultLanguage: "en",
+			Languages:       []string{"en", "fr"},
+		},
+	}
+}
+
+func newTranslationTestCategory() *domain.BlogCategory {
+	return &domain.BlogCategory{
+		ID:   "cat-1",
+		Slug: "news",
+		Settings: domain.BlogCategorySettings{
+			Name: "News",
+			Translations: map[string]domain.BlogCategoryTranslation{
+				"fr": {Slug: "actualites", Name: "Actualités"},
+			},
+		},
+	}
+}
+
+func newTranslationTestPost() *domain.BlogPost {
+	publishedAt := time.Now().Add(-time.Hour)
+	return &domain.BlogPost{
+		ID:          "post-1",
+		CategoryID:  "cat-1",
+		Slug:        "launch",
+		PublishedAt: &publishedAt,
+		UpdatedAt:   publishedAt,
+		Settings: domain.BlogPostSettings{
+			Title:    "Launch day",
+			Template: domain.BlogPostTemplateReference{TemplateID: "tpl-1", TemplateVersion: 1},
+			Translations: map[string]domain.BlogPostTranslation{
+				"fr": {Slug: "lancement", Title: "Jour de lancement"},
+			},
+		},
+	}
+}
+
+func TestBlogService_checkPostTranslations(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("accepts translations in the workspace languages", func(t *testing.T) {
+		service, _, mockPostRepo, _, mockWorkspaceRepo, _, _, _ := setupBlogServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(newTranslationTestWorkspace(), nil)
+		mockPostRepo.EXPECT().
+			GetPostByTranslationSlug(ctx, "cat-1", "fr", "lancement").
+			Return(nil, errors.New("blog post not found"))
+
+		assert.NoError(t, service.checkPostTranslations(ctx, "workspace123", newTranslationTestPost()))
+	})
+
+	t.Run("skips posts without translations", func(t *testing.T) {
+		service, _, _, _, _, _, _, _ := setupBlogServiceTest(t)
+
+		assert.NoError(t, service.checkPostTranslations(ctx, "workspace123", &domain.BlogPost{ID: "post-1"}))
+	})
+
+	t.Run("rejects the default language", func(t *testing.T) {
+		service, _, _, _, mockWorkspaceRepo, _, _, _ := setupBlogServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(newTranslationTestWorkspace(), nil)
+
+		post := newTranslationTestPost()
+		post.Settings.Translations = map[string]domain.BlogPostTranslation{"en": {Slug: "launch", Title: "Launch"}}
+		err := service.checkPostTranslations(ctx, "workspace123", post)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "translation language 'en'")
+	})
+
+	t.Run("rejects a slug used by another post", func(t *testing.T) {
+		service, _, mockPostRepo, _, mockWorkspaceRepo, _, _, _ := setupBlogServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(newTranslationTestWorkspace(), nil)
+		mockPostRepo.EXPECT().
+			GetPostByTranslationSlug(ctx, "cat-1", "fr", "lancement").
+			Return(&domain.BlogPost{ID: "post-2"}, nil)
+
+		err := service.checkPostTranslations(ctx, "workspace123", newTranslationTestPost())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists in language 'fr'")
+	})
+}
+
+func TestBlogService_checkCategoryTranslations(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rejects a slug used by another category", func(t *testing.T) {
+		service, mockCategoryRepo, _, _, mockWorkspaceRepo, _, _, _ := setupBlogServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(newTranslationTestWorkspace(), nil)
+		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{
+			newTranslationTestCategory(),
+			{ID: "cat-2", Slug: "actualites"},
+		}, nil)
+
+		err := service.checkCategoryTranslations(ctx, "workspace123", newTranslationTestCategory())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "category with slug 'actualites' already exists in language 'fr'")
+	})
+
+	t.Run("accepts unique slugs", func(t *testing.T) {
+		service, mockCategoryRepo, _, _, mockWorkspaceRepo, _, _, _ := setupBlogServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(newTranslationTestWorkspace(), nil)
+		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{
+			newTranslationTestCategory(),
+			{ID: "cat-2", Slug: "events"},
+		}, nil)
+
+		assert.NoError(t, service.checkCategoryTranslations(ctx, "workspace123", newTranslationTestCategory()))
+	})
+}
+
+func TestBlogService_findCategoryForLanguage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("prefers the translated slug", func(t *testing.T) {
+		service, mockCategoryRepo, _, _, _, _, _, _ := setupBlogServiceTest(t)
+
+		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{
+			{ID: "cat-2", Slug: "actualites"},
+			newTranslationTestCategory(),
+		}, nil)
+
+		category, err := service.findCategoryForLanguage(ctx, "fr", "actualites")
+		require.NoError(t, err)
+		assert.Equal(t, "cat-1", category.ID)
+	})
+
+	t.Run("falls back to untranslated slugs", func(t *testing.T) {
+		service, mockCategoryRepo, _, _, _, _, _, _ := setupBlogServiceTest(t)
+
+		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{
+			newTranslationTestCategory(),
+			{ID: "cat-2", Slug: "events"},
+		}, nil)
+
+		category, err := service.findCategoryForLanguage(ctx, "fr", "events")
+		require.NoError(t, err)
+		assert.Equal(t, "cat-2", category.ID)
+
+		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{newTranslationTestCategory()}, nil)
+		_, err = service.findCategoryForLanguage(ctx, "fr", "news")
+		assert.Error(t, err)
+	})
+
+	t.Run("default language", func(t *testing.T) {
+		service, mockCategoryRepo, _, _, _, _, _, _ := setupBlogServiceTest(t)
+
+		mockCategoryRepo.EXPECT().GetCategoryBySlug(ctx, "news").Return(newTranslationTestCategory(), nil)
+
+		category, err := service.findCategoryForLanguage(ctx, "", "news")
+		require.NoError(t, err)
+		assert.Equal(t, "cat-1", category.ID)
+	})
+}
+
+func TestBlogService_RenderPostPage_Translated(t *testing.T) {
+	ctx := context.WithValue(context.Background(), domain.WorkspaceIDKey, "workspace123")
+	theme := &domain.BlogTheme{
+		Version: 1,
+		Files: domain.BlogThemeFiles{
+			PostLiquid: `<html><head></head><body><h1>{{ post.title }}</h1><a href="{{ base_url }}/{{ category.slug }}">{{ category.name }}</a>{{ post.content }}<span>{{ language.code }}</span></body></html>`,
+		},
+	}
+	template := &domain.Template{
+		ID:      "tpl-1",
+		Version: 1,
+		Web:     &domain.WebTemplate{HTML: "<p>Body</p>"},
+		Translations: map[string]domain.TemplateTranslation{
+			"fr": {Web: &domain.WebTemplate{HTML: "<p>Contenu</p>"}},
+		},
+	}
+
+	t.Run("renders the translation with hreflang links", func(t *testing.T) {
+		service, mockCategoryRepo, mockPostRepo, mockThemeRepo, mockWorkspaceRepo, mockListRepo, mockTemplateRepo, _ := setupBlogServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(newTranslationTestWorkspace(), nil)
+		mockThemeRepo.EXPECT().GetPublishedTheme(ctx).Return(theme, nil)
+		mockCategoryRepo.EXPECT().ListCategories(ctx).Return([]*domain.BlogCategory{newTranslationTestCategory()}, nil).Times(2)
+		mockPostRepo.EXPECT().GetPostByTranslationSlug(ctx, "cat-1", "fr", "lancement").Return(newTranslationTestPost(), nil)
+		mockTemplateRepo.EXPECT().GetTemplateByID(ctx, "workspace123", "tpl-1", int64(1)).Return(template, nil)
+		mockCategoryRepo.EXPECT().GetCategory(ctx, "cat-1").Return(newTranslationTestCategory(), nil)
+		mockListRepo.EXPECT().GetLists(ctx, "workspace123").Return([]*domain.List{}, nil)
+
+		html, err := service.RenderPostPage(ctx, "workspace123", "fr", "actualites", "lancement", nil)
+		require.NoError(t, err)
+		assert.Contains(t, html, "<h1>Jour de lancement</h1>")
+		assert.Contains(t, html, `<a href="https://blog.example.com/fr/actualites">Actualités</a>`)
+		assert.Contains(t, html, "<p>Contenu</p>")
+		assert.Contains(t, html, "<span>fr</span>")
+		assert.Contains(t, html, `hreflang="en" href="https://blog.example.com/news/launch"`)
+		assert.Contains(t, html, `hreflang="fr" href="https://blog.example.com/fr/actualites/lancement"`)
+		assert.Contains(t, html, `hreflang="x-default" href="https://blog.example.com/news/launch"`)
+		assert.Contains(t, html, `href="/fr/actualites/feed.xml"`)
+	})
+
+	t.Run("language not available", func(t *testing.T) {
+		service, _, _, _, mockWorkspaceRepo, _, _, _ := setupBlogServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(newTranslationTestWorkspace(), nil)
+
+		_, err := service.RenderPostPage(ctx, "workspace123", "de", "news", "launch", nil)
+		require.Error(t, err)
+		var blogErr *domain.BlogRenderError
+		require.True(t, errors.As(err, &blogErr))
+		assert.Equal(t, domain.ErrCodePostNotFound, blogErr.Code)
+	})
+}
+
+func TestBlogService_BuildFeed_Translated(t *testing.T) {
+	service, mockCategoryRepo, mockPostRepo, _, mockWorkspaceRepo, _, mockTemplateRepo, _ := setupBlogServiceTest(t)
+	ctx := context.Background()
+
+	mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(newTranslationTestWorkspace(), nil)
+	mockCategoryRepo.EXPECT().ListCategories(gomock.Any()).Return([]*domain.BlogCategory{newTranslationTestCategory()}, nil)
+	mockPostRepo.EXPECT().
+		ListFeedPosts(gomock.Any(), "fr", gomock.Eq(strPtr("news")), 20).
+		Return([]*domain.BlogPost{newTranslationTestPost()}, nil)
+	mockPostRepo.EXPECT().
+		GetFeedFingerprint(gomock.Any(), "fr", gomock.Eq(strPtr("news")), 20).
+		Return(time.Now(), "abcd", nil)
+	mockCategoryRepo.EXPECT().
+		GetCategoriesByIDs(gomock.Any(), []string{"cat-1"}).
+		Return([]*domain.BlogCategory{newTranslationTestCategory()}, nil)
+	mockTemplateRepo.EXPECT().
+		GetTemplateByID(gomock.Any(), "workspace123", "tpl-1", int64(1)).
+		Return(&domain.Template{
+			Web:          &domain.WebTemplate{HTML: "<p>Body</p>"},
+			Translations: map[string]domain.TemplateTranslation{"fr": {Web: &domain.WebTemplate{HTML: "<p>Contenu</p>"}}},
+		}, nil)
+
+	feed, err := service.BuildFeed(ctx, "workspace123", "fr", strPtr("actualites"))
+	require.NoError(t, err)
+	require.Len(t, feed.Items, 1)
+	assert.Equal(t, "Jour de lancement", feed.Items[0].Title)
+	assert.Equal(t, "https://blog.example.com/fr/actualites/lancement", feed.Items[0].URL)
+	assert.Equal(t, "Actualités", feed.Items[0].CategoryName)
+	assert.Contains(t, feed.Items[0].ContentHTML, "Contenu")
+	assert.Equal(t, "fr", feed.Meta.Language)
+	assert.Equal(t, "https://blog.example.com/fr/actualites/feed.xml", feed.Meta.SelfURL)
+}
@@ -21,6 +21,7 @@ type Factory struct {
 	apiEndpoint        string
 	eventBus           domain.EventBus
 	useQueueSender     bool
+	webhookEvents      domain.WebhookEventPublisher
 }
 
 // NewFactory creates a new factory for broadcast components
@@ -62,6 +63,12 @@ func NewFactory(
 	}
 }
 
+// SetWebhookEventPublisher sets the publisher of broadcast lifecycle webhook events
+// handed to the orchestrators created by the factory
+func (f *Factory) SetWebhookEventPublisher(publisher domain.WebhookEventPublisher) {
+	f.webhookEvents = publisher
+}
+
 // CreateMessageSender creates a new message sender
 // If useQueueSender is true, it creates a queue-based sender that enqueues emails
 // for processing by the queue worker. Otherwise, it creates a direct sender.
@@ -103,7 +110,7 @@ func (f *Factory) CreateOrchestrator() BroadcastOrchestratorInterface {
 		f.logger,
 	)
 
-	return NewBroadcastOrchestrator(
+	orchestrator := NewBroadcastOrchestrator(
 		messageSender,
 		f.broadcastRepo,
 		f.templateRepo,
@@ -118,6 +125,10 @@ func (f *Factory) CreateOrchestrator() BroadcastOrchestratorInterface {
 		f.apiEndpoint,
 		f.eventBus,
 	)
+	if f.webhookEvents != nil {
+		orchestrator.(*BroadcastOrchestrator).SetWebhookEventPublisher(f.webhookEvents)
+	}
+	return orchestrator
 }
 
 // RegisterWithTaskService registers the orchestrator with the task service
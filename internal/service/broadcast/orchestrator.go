@@ -52,6 +52,9 @@ type BroadcastOrchestrator struct {
 	timeProvider    TimeProvider
 	apiEndpoint     string
 	eventBus        domain.EventBus
+	// webhookEvents is optional; when set, broadcast lifecycle changes are published
+	// as outgoing webhook events. Injected via SetWebhookEventPublisher.
+	webhookEvents domain.WebhookEventPublisher
 }
 
 // NewBroadcastOrchestrator creates a new broadcast orchestrator
@@ -95,6 +98,25 @@ func NewBroadcastOrchestrator(
 	}
 }
 
+// SetWebhookEventPublisher injects the publisher of broadcast lifecycle webhook events
+func (o *BroadcastOrchestrator) SetWebhookEventPublisher(publisher domain.WebhookEventPublisher) {
+	o.webhookEvents = publisher
+}
+
+// publishWebhookEvent publishes a broadcast lifecycle webhook event, best effort
+func (o *BroadcastOrchestrator) publishWebhookEvent(workspaceID, eventType, broadcastID string, data map[string]interface{}) {
+	if o.webhookEvents == nil {
+		return
+	}
+	payload := map[string]interface{}{
+		"broadcast_id": broadcastID,
+	}
+	for key, value := range data {
+		payload[key] = value
+	}
+	o.webhookEvents.Publish(context.Background(), workspaceID, eventType, payload)
+}
+
 // CanProcess returns true if this processor can handle the given task type
 func (o *BroadcastOrchestrator) CanProcess(taskType string) bool {
 	return taskType == "send_broadcast"
@@ -516,6 +538,12 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 					"task_id":      task.ID,
 					"broadcast_id": broadcastID,
 				}).Info("Broadcast marked as failed due to max retries reached")
+
+				o.publishWebhookEvent(task.WorkspaceID, domain.WebhookEventBroadcastFailed, broadcastID, map[string]interface{}{
+					"name":    broadcast.Name,
+					"task_id": task.ID,
+					"error":   err.Error(),
+				})
 			}
 
 		}
@@ -617,6 +645,11 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 		}).Info("Broadcast sending initialized")
 		// codecov:ignore:end
 
+		o.publishWebhookEvent(task.WorkspaceID, domain.WebhookEventBroadcastStarted, broadcastState.BroadcastID, map[string]interface{}{
+			"task_id":          task.ID,
+			"total_recipients": broadcastState.TotalRecipients,
+		})
+
 		// If there are no recipients, we can mark as completed immediately
 		if broadcastState.TotalRecipients == 0 {
 			task.State.Message = "Broadcast completed: No recipients found"
@@ -667,6 +700,14 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 				"broadcast_id": broadcastState.BroadcastID,
 			}).Info("Broadcast marked as processed successfully (no recipients)")
 
+			o.publishWebhookEvent(task.WorkspaceID, domain.WebhookEventBroadcastCompleted, broadcastState.BroadcastID, map[string]interface{}{
+				"name":             broadcast.Name,
+				"task_id":          task.ID,
+				"total_recipients": 0,
+				"enqueued_count":   0,
+				"failed_count":     0,
+			})
+
 			allDone = true
 			return allDone, err
 		}
@@ -831,6 +872,24 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 		return false, err
 	}
 
+	// Inline synced blocks once per batch run so every recipient renders the
+	// current version of the shared blocks
+	syncedBlocks := workspace.Settings.SyncedBlocks()
+	for templateID, template := range templates {
+		if resolveErr := template.ResolveSyncedBlocks(syncedBlocks); resolveErr != nil {
+			// codecov:ignore:start
+			o.logger.WithFields(map[string]interface{}{
+				"task_id":      task.ID,
+				"broadcast_id": broadcastState.BroadcastID,
+				"template_id":  templateID,
+				"error":        resolveErr.Error(),
+			}).Error("Failed to resolve synced blocks for broadcast template")
+			// codecov:ignore:end
+			err = NewBroadcastError(ErrCodeTemplateInvalid, resolveErr.Error(), false, resolveErr)
+			return false, err
+		}
+	}
+
 	// Phase 3: Process recipients in batches with a timeout
 	// Use the timeoutAt parameter passed from task service
 	processTimeoutAt := timeoutAt
@@ -1112,6 +1171,13 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 						// the broken integration.
 						o.pauseQueueEntries(ctx, task.WorkspaceID, broadcastState.BroadcastID)
 
+						o.publishWebhookEvent(task.WorkspaceID, domain.WebhookEventBroadcastPaused, broadcastState.BroadcastID, map[string]interface{}{
+							"name":         currentBroadcast.Name,
+							"task_id":      task.ID,
+							"reason":       "circuit_breaker",
+							"pause_reason": reason,
+						})
+
 						// Publish circuit breaker event for notification handling
 						if o.eventBus != nil {
 							event := domain.EventPayload{
@@ -1195,6 +1261,13 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 						// Pause already-queued rows so the worker stops sending.
 						o.pauseQueueEntries(ctx, task.WorkspaceID, broadcastState.BroadcastID)
 
+						o.publishWebhookEvent(task.WorkspaceID, domain.WebhookEventBroadcastPaused, broadcastState.BroadcastID, map[string]interface{}{
+							"name":         currentBroadcast.Name,
+							"task_id":      task.ID,
+							"reason":       "recipient_feed_failed",
+							"pause_reason": reason,
+						})
+
 						if o.eventBus != nil {
 							pausedEvent := domain.EventPayload{
 								Type:        domain.EventBroadcastPaused,
@@ -1382,6 +1455,15 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 			"phase":          broadcastState.Phase,
 		}).Info("Broadcast marked as " + statusMessage + " successfully")
 		// codecov:ignore:end
+
+		o.publishWebhookEvent(task.WorkspaceID, domain.WebhookEventBroadcastCompleted, broadcastState.BroadcastID, map[string]interface{}{
+			"name":             broadcast.Name,
+			"task_id":          task.ID,
+			"total_recipients": broadcastState.TotalRecipients,
+			"enqueued_count":   sentCount,
+			"failed_count":     failedCount,
+			"phase":            broadcastState.Phase,
+		})
 	}
 
 	// codecov:ignore:start
@@ -1446,6 +1528,12 @@ func (o *BroadcastOrchestrator) handleTestPhaseCompletion(ctx context.Context, b
 		"test_sent_count": broadcastState.RecipientOffset,
 	}).Info("A/B test phase completed, awaiting winner selection")
 
+	o.publishWebhookEvent(broadcast.WorkspaceID, domain.WebhookEventBroadcastTestCompleted, broadcast.ID, map[string]interface{}{
+		"name":             broadcast.Name,
+		"test_sent_count":  broadcastState.RecipientOffset,
+		"auto_send_winner": broadcast.TestSettings.AutoSendWinner,
+	})
+
 	// Log completion - auto evaluation will happen on next task run if enabled
 	if broadcast.TestSettings.AutoSendWinner {
 		evaluationTime := now.Add(time.Duration(broadcast.TestSettings.TestDurationHours) * time.Hour)
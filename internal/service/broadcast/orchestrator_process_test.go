@@ -565,6 +565,87 @@ func TestProcess_ZeroRecipients(t *testing.T) {
 	assert.Equal(t, "Broadcast completed: No recipients found", task.State.Message)
 }
 
+// TestProcess_PublishesWebhookEvents tests that starting and completing a broadcast
+// publishes the broadcast lifecycle webhook events
+func TestProcess_PublishesWebhookEvents(t *testing.T) {
+	tests := []struct {
+		name           string
+		recipientCount int
+		expectedEvents []string
+	}{
+		{
+			name:           "started",
+			recipientCount: 5,
+			expectedEvents: []string{domain.WebhookEventBroadcastStarted},
+		},
+		{
+			name:           "started and completed without recipients",
+			recipientCount: 0,
+			expectedEvents: []string{domain.WebhookEventBroadcastStarted, domain.WebhookEventBroadcastCompleted},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, mockMessageSender, mockBroadcastRepository, mockTemplateRepo,
+				mockContactRepo, mockTaskRepo, mockWorkspaceRepo, mockLogger, mockTimeProvider, mockEventBus := setupTestEnvironment(t)
+			defer ctrl.Finish()
+
+			mockTimeProvider.EXPECT().Now().Return(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)).AnyTimes()
+
+			broadcastID := "broadcast-123"
+			task := &domain.Task{
+				ID:          "task-123",
+				WorkspaceID: "workspace-123",
+				Type:        "send_broadcast",
+				Status:      domain.TaskStatusRunning,
+				MaxRetries:  3,
+				State: &domain.TaskState{
+					SendBroadcast: &domain.SendBroadcastState{BroadcastID: broadcastID},
+				},
+			}
+
+			mockBroadcast := createMockBroadcast(broadcastID, []string{"template-1"})
+			mockBroadcastRepository.EXPECT().GetBroadcast(gomock.Any(), "workspace-123", broadcastID).Return(mockBroadcast, nil).AnyTimes()
+			mockContactRepo.EXPECT().CountContactsForBroadcast(gomock.Any(), "workspace-123", mockBroadcast.Audience).Return(tt.recipientCount, nil)
+			if tt.recipientCount == 0 {
+				mockBroadcastRepository.EXPECT().UpdateBroadcast(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			var published []string
+			mockPublisher := domainmocks.NewMockWebhookEventPublisher(ctrl)
+			mockPublisher.EXPECT().Publish(gomock.Any(), "workspace-123", gomock.Any(), gomock.Any()).
+				Do(func(_ context.Context, _ string, eventType string, payload map[string]interface{}) {
+					assert.Equal(t, broadcastID, payload["broadcast_id"])
+					assert.Equal(t, "task-123", payload["task_id"])
+					published = append(published, eventType)
+				}).
+				Times(len(tt.expectedEvents))
+
+			orchestrator := broadcast.NewBroadcastOrchestrator(
+				mockMessageSender,
+				mockBroadcastRepository,
+				mockTemplateRepo,
+				mockContactRepo,
+				mockTaskRepo,
+				mockWorkspaceRepo,
+				nil,
+				nil,
+				mockLogger,
+				createTestConfig(),
+				mockTimeProvider,
+				"https://api.example.com",
+				mockEventBus,
+			)
+			orchestrator.(*broadcast.BroadcastOrchestrator).SetWebhookEventPublisher(mockPublisher)
+
+			_, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedEvents, published)
+		})
+	}
+}
+
 // TestProcess_GetTotalRecipientCountError tests error handling when getting recipient count fails
 func TestProcess_GetTotalRecipientCountError(t *testing.T) {
 	// Setup
@@ -77,21 +77,26 @@ func (s *BroadcastService) SetTaskService(taskService domain.TaskService) {
 
 // CreateBroadcast creates a new broadcast
 func (s *BroadcastService) CreateBroadcast(ctx context.Context, request *domain.CreateBroadcastRequest) (*domain.Broadcast, error) {
-	// Authenticate user for workspace
 	var err error
-	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, request.WorkspaceID)
-	if err != nil {
-		s.logger.Error("Failed to authenticate user for workspace")
-		return nil, fmt.Errorf("failed to authenticate user: %w", err)
-	}
 
-	// Check permission for writing broadcasts
-	if !userWorkspace.HasPermission(domain.PermissionResourceBroadcasts, domain.PermissionTypeWrite) {
-		return nil, domain.NewPermissionError(
-			domain.PermissionResourceBroadcasts,
-			domain.PermissionTypeWrite,
-			"Insufficient permissions: write access to broadcasts required",
-		)
+	// System calls (e.g. blog post newsletters) bypass authentication
+	if ctx.Value(domain.SystemCallKey) == nil {
+		// Authenticate user for workspace
+		var userWorkspace *domain.UserWorkspace
+		ctx, _, userWorkspace, err = s.authService.AuthenticateUserForWorkspace(ctx, request.WorkspaceID)
+		if err != nil {
+			s.logger.Error("Failed to authenticate user for workspace")
+			return nil, fmt.Errorf("failed to authenticate user: %w", err)
+		}
+
+		// Check permission for writing broadcasts
+		if !userWorkspace.HasPermission(domain.PermissionResourceBroadcasts, domain.PermissionTypeWrite) {
+			return nil, domain.NewPermissionError(
+				domain.PermissionResourceBroadcasts,
+				domain.PermissionTypeWrite,
+				"Insufficient permissions: write access to broadcasts required",
+			)
+		}
 	}
 
 	// Validate the request
@@ -270,21 +275,26 @@ func (s *BroadcastService) ListBroadcasts(ctx context.Context, params domain.Lis
 
 // ScheduleBroadcast schedules a broadcast for sending
 func (s *BroadcastService) ScheduleBroadcast(ctx context.Context, request *domain.ScheduleBroadcastRequest) error {
-	// Authenticate user for workspace
 	var err error
-	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, request.WorkspaceID)
-	if err != nil {
-		s.logger.WithField("broadcast_id", request.ID).Error("Failed to authenticate user for workspace")
-		return fmt.Errorf("failed to authenticate user: %w", err)
-	}
 
-	// Check permission for writing broadcasts
-	if !userWorkspace.HasPermission(domain.PermissionResourceBroadcasts, domain.PermissionTypeWrite) {
-		return domain.NewPermissionError(
-			domain.PermissionResourceBroadcasts,
-			domain.PermissionTypeWrite,
-			"Insufficient permissions: write access to broadcasts required",
-		)
+	// System calls (e.g. blog post newsletters) bypass authentication
+	if ctx.Value(domain.SystemCallKey) == nil {
+		// Authenticate user for workspace
+		var userWorkspace *domain.UserWorkspace
+		ctx, _, userWorkspace, err = s.authService.AuthenticateUserForWorkspace(ctx, request.WorkspaceID)
+		if err != nil {
+			s.logger.WithField("broadcast_id", request.ID).Error("Failed to authenticate user for workspace")
+			return fmt.Errorf("failed to authenticate user: %w", err)
+		}
+
+		// Check permission for writing broadcasts
+		if !userWorkspace.HasPermission(domain.PermissionResourceBroadcasts, domain.PermissionTypeWrite) {
+			return domain.NewPermissionError(
+				domain.PermissionResourceBroadcasts,
+				domain.PermissionTypeWrite,
+				"Insufficient permissions: write access to broadcasts required",
+			)
+		}
 	}
 
 	// Validate the request
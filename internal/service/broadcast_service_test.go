@@ -162,6 +162,27 @@ func TestBroadcastService_CreateBroadcast_Success(t *testing.T) {
 	assert.NotEmpty(t, b.ID)
 }
 
+func TestBroadcastService_CreateBroadcast_SystemCall(t *testing.T) {
+	d := setupBroadcastSvc(t)
+	defer d.ctrl.Finish()
+
+	// System calls, such as blog post newsletters, are not made on behalf of a user
+	ctx := context.WithValue(context.Background(), domain.SystemCallKey, true)
+	req := &domain.CreateBroadcastRequest{
+		WorkspaceID: "w1",
+		Name:        "Launch",
+		Audience:    domain.AudienceSettings{List: "list1"},
+		BlogPost:    &domain.BroadcastBlogPost{PostID: "post1", Title: "Launch", URL: "https://blog.example.com/news/launch"},
+	}
+
+	d.repo.EXPECT().CreateBroadcast(gomock.Any(), gomock.Any()).Return(nil)
+
+	b, err := d.svc.CreateBroadcast(ctx, req)
+	require.NoError(t, err)
+	require.NotNil(t, b.BlogPost)
+	assert.Equal(t, "post1", b.BlogPost.PostID)
+}
+
 func TestBroadcastService_ScheduleBroadcast_SendNow_Success(t *testing.T) {
 	d := setupBroadcastSvc(t)
 	defer d.ctrl.Finish()
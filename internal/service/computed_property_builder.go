@@ -0,0 +1,221 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// computedPropertySource describes the rows a computed property aggregates for one contact
+type computedPropertySource struct {
+	from       string
+	timeColumn string
+	conditions []string
+}
+
+// BuildComputedPropertiesSQL converts computed property definitions into a single JSONB
+// expression evaluated for the contact aliased "c". Each property becomes a correlated
+// subquery; properties without a value are stripped from the object.
+// Placeholders are numbered from argIndex.
+func BuildComputedPropertiesSQL(props []domain.ComputedProperty, argIndex int) (string, []interface{}, error) {
+	if len(props) == 0 {
+		return "", nil, fmt.Errorf("no computed properties to build")
+	}
+
+	var pairs []string
+	var args []interface{}
+
+	for i := range props {
+		prop := &props[i]
+		if err := prop.Validate(); err != nil {
+			return "", nil, fmt.Errorf("invalid computed property %s: %w", prop.Key, err)
+		}
+
+		expr, newArgs, newArgIndex, err := buildComputedPropertyExpr(prop, argIndex)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to build computed property %s: %w", prop.Key, err)
+		}
+
+		// Keys are validated against ^[a-z][a-z0-9_]*$ so they are safe to embed
+		pairs = append(pairs, fmt.Sprintf("'%s', %s", prop.Key, expr))
+		args = append(args, newArgs...)
+		argIndex = newArgIndex
+	}
+
+	return fmt.Sprintf("jsonb_strip_nulls(jsonb_build_object(%s))", strings.Join(pairs, ", ")), args, nil
+}
+
+// buildComputedPropertyExpr generates the scalar subquery computing one property
+func buildComputedPropertyExpr(prop *domain.ComputedProperty, argIndex int) (string, []interface{}, int, error) {
+	var args []interface{}
+
+	source, sourceArgs, argIndex, err := buildComputedPropertySource(prop, argIndex)
+	if err != nil {
+		return "", nil, argIndex, err
+	}
+	args = append(args, sourceArgs...)
+
+	// Numeric value of a custom event: a number at property_path, or the goal value
+	valueExpr := "ce.goal_value"
+	pathPlaceholder := ""
+	if len(prop.PropertyPath) > 0 {
+		pathPlaceholder = fmt.Sprintf("$%d::text[]", argIndex)
+		args = append(args, "{"+strings.Join(prop.PropertyPath, ",")+"}")
+		argIndex++
+		valueExpr = fmt.Sprintf("CASE WHEN jsonb_typeof(ce.properties #> %s) = 'number' THEN (ce.properties #>> %s)::numeric END", pathPlaceholder, pathPlaceholder)
+	}
+
+	where := strings.Join(source.conditions, " AND ")
+	var inner string
+
+	switch prop.Aggregate {
+	case domain.ComputedPropertyAggregateCount:
+		inner = fmt.Sprintf("SELECT COUNT(*) AS v FROM %s WHERE %s", source.from, where)
+
+	case domain.ComputedPropertyAggregateSum:
+		inner = fmt.Sprintf("SELECT COALESCE(SUM(%s), 0) AS v FROM %s WHERE %s", valueExpr, source.from, where)
+
+	case domain.ComputedPropertyAggregateAvg:
+		inner = fmt.Sprintf("SELECT ROUND(AVG(%s), 2) AS v FROM %s WHERE %s", valueExpr, source.from, where)
+
+	case domain.ComputedPropertyAggregateMin:
+		inner = fmt.Sprintf("SELECT MIN(%s) AS v FROM %s WHERE %s", valueExpr, source.from, where)
+
+	case domain.ComputedPropertyAggregateMax:
+		inner = fmt.Sprintf("SELECT MAX(%s) AS v FROM %s WHERE %s", valueExpr, source.from, where)
+
+	case domain.ComputedPropertyAggregateFirstAt:
+		inner = fmt.Sprintf("SELECT MIN(%s) AS v FROM %s WHERE %s", source.timeColumn, source.from, where)
+
+	case domain.ComputedPropertyAggregateLastAt:
+		inner = fmt.Sprintf("SELECT MAX(%s) AS v FROM %s WHERE %s", source.timeColumn, source.from, where)
+
+	case domain.ComputedPropertyAggregateDaysSinceLast:
+		inner = fmt.Sprintf("SELECT FLOOR(EXTRACT(EPOCH FROM NOW() - MAX(%s)) / 86400)::int AS v FROM %s WHERE %s", source.timeColumn, source.from, where)
+
+	case domain.ComputedPropertyAggregateMostFrequent:
+		// Most common value at property_path, ties broken alphabetically
+		inner = fmt.Sprintf("SELECT ce.properties #>> %s AS v FROM %s WHERE %s AND ce.properties #>> %s IS NOT NULL GROUP BY 1 ORDER BY COUNT(*) DESC, 1 ASC LIMIT 1",
+			pathPlaceholder, source.from, where, pathPlaceholder)
+
+	case domain.ComputedPropertyAggregateEngagementScore:
+		weights := prop.Weights
+		if len(weights) == 0 {
+			weights = domain.DefaultEngagementWeights
+		}
+		// Sort events so the generated SQL is deterministic
+		events := make([]string, 0, len(weights))
+		for event := range weights {
+			events = append(events, event)
+		}
+		sort.Strings(events)
+
+		terms := make([]string, 0, len(events))
+		for _, event := range events {
+			// Events are validated against the message history event list
+			terms = append(terms, fmt.Sprintf("$%d::numeric * (mh.%s_at IS NOT NULL)::int", argIndex, event))
+			args = append(args, weights[event])
+			argIndex++
+		}
+		inner = fmt.Sprintf("SELECT COALESCE(SUM(%s), 0) AS v FROM %s WHERE %s", strings.Join(terms, " + "), source.from, where)
+
+	case domain.ComputedPropertyAggregatePredictedLTV:
+		// Historical spend plus the average daily spend since the first event
+		// (with a 30 day minimum tenure) projected over the horizon
+		horizon := prop.HorizonDays
+		if horizon == 0 {
+			horizon = 365
+		}
+		inner = fmt.Sprintf("SELECT ROUND(COALESCE(SUM(%s), 0) + COALESCE(SUM(%s), 0) / GREATEST(EXTRACT(EPOCH FROM NOW() - MIN(%s)) / 86400, 30) * $%d::numeric, 2) AS v FROM %s WHERE %s",
+			valueExpr, valueExpr, source.timeColumn, argIndex, source.from, where)
+		args = append(args, horizon)
+		argIndex++
+
+	default:
+		return "", nil, argIndex, fmt.Errorf("unsupported aggregate: %s", prop.Aggregate)
+	}
+
+	if len(prop.Buckets) == 0 {
+		return "(" + inner + ")", args, argIndex, nil
+	}
+
+	// Score the value: 1 + the number of thresholds reached (or not exceeded when inverted)
+	comparison := ">="
+	if prop.InvertBuckets {
+		comparison = "<="
+	}
+	scoreTerms := []string{"1"}
+	for _, threshold := range prop.Buckets {
+		scoreTerms = append(scoreTerms, fmt.Sprintf("(x.v %s $%d)::int", comparison, argIndex))
+		args = append(args, threshold)
+		argIndex++
+	}
+
+	return fmt.Sprintf("(SELECT %s FROM (%s) x)", strings.Join(scoreTerms, " + "), inner), args, argIndex, nil
+}
+
+// buildComputedPropertySource generates the FROM and WHERE parts shared by all aggregates
+func buildComputedPropertySource(prop *domain.ComputedProperty, argIndex int) (*computedPropertySource, []interface{}, int, error) {
+	var args []interface{}
+	var source *computedPropertySource
+
+	switch prop.Source {
+	case domain.ComputedPropertySourceCustomEvents:
+		source = &computedPropertySource{
+			from:       "custom_events ce",
+			timeColumn: "ce.occurred_at",
+			conditions: []string{"ce.email = c.email", "ce.deleted_at IS NULL"},
+		}
+		if prop.EventName != "" {
+			source.conditions = append(source.conditions, fmt.Sprintf("ce.event_name = $%d", argIndex))
+			args = append(args, prop.EventName)
+			argIndex++
+		}
+		if prop.GoalType != "" {
+			source.conditions = append(source.conditions, fmt.Sprintf("ce.goal_type = $%d", argIndex))
+			args = append(args, prop.GoalType)
+			argIndex++
+		}
+
+	case domain.ComputedPropertySourceMessageHistory:
+		source = &computedPropertySource{
+			from:       "message_history mh",
+			timeColumn: "mh.sent_at",
+			conditions: []string{"mh.contact_email = c.email"},
+		}
+		if prop.Aggregate != domain.ComputedPropertyAggregateEngagementScore {
+			event := prop.EventName
+			if event == "" {
+				event = "sent"
+			}
+			// Events are validated against the message history event list
+			source.timeColumn = fmt.Sprintf("mh.%s_at", event)
+			source.conditions = append(source.conditions, source.timeColumn+" IS NOT NULL")
+		}
+
+	case domain.ComputedPropertySourceContactTimeline:
+		source = &computedPropertySource{
+			from:       "contact_timeline ct",
+			timeColumn: "ct.created_at",
+			conditions: []string{"ct.email = c.email"},
+		}
+		if prop.EventName != "" {
+			source.conditions = append(source.conditions, fmt.Sprintf("ct.kind = $%d", argIndex))
+			args = append(args, prop.EventName)
+			argIndex++
+		}
+
+	default:
+		return nil, nil, argIndex, fmt.Errorf("unsupported source: %s", prop.Source)
+	}
+
+	if prop.WindowDays > 0 {
+		source.conditions = append(source.conditions, fmt.Sprintf("%s >= NOW() - make_interval(days => $%d)", source.timeColumn, argIndex))
+		args = append(args, prop.WindowDays)
+		argIndex++
+	}
+
+	return source, args, argIndex, nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildComputedPropertiesSQL(t *testing.T) {
+	t.Run("empty list", func(t *testing.T) {
+		_, _, err := BuildComputedPropertiesSQL(nil, 1)
+		require.Error(t, err)
+	})
+
+	t.Run("count of custom events", func(t *testing.T) {
+		sql, args, err := BuildComputedPropertiesSQL([]domain.ComputedProperty{
+			{Key: "orders_count", Source: domain.ComputedPropertySourceCustomEvents, Aggregate: domain.ComputedPropertyAggregateCount, EventName: "order_completed"},
+		}, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "jsonb_strip_nulls(jsonb_build_object('orders_count', (SELECT COUNT(*) AS v FROM custom_events ce WHERE ce.email = c.email AND ce.deleted_at IS NULL AND ce.event_name = $2)))", sql)
+		assert.Equal(t, []interface{}{"order_completed"}, args)
+	})
+
+	t.Run("sum over property path with window", func(t *testing.T) {
+		sql, args, err := BuildComputedPropertiesSQL([]domain.ComputedProperty{
+			{
+				Key:          "revenue_90d",
+				Source:       domain.ComputedPropertySourceCustomEvents,
+				Aggregate:    domain.ComputedPropertyAggregateSum,
+				EventName:    "order_completed",
+				PropertyPath: []string{"order", "total"},
+				WindowDays:   90,
+			},
+		}, 1)
+		require.NoError(t, err)
+		assert.Contains(t, sql, "COALESCE(SUM(CASE WHEN jsonb_typeof(ce.properties #> $3::text[]) = 'number' THEN (ce.properties #>> $3::text[])::numeric END), 0)")
+		assert.Contains(t, sql, "ce.occurred_at >= NOW() - make_interval(days => $2)")
+		assert.Equal(t, []interface{}{"order_completed", 90, "{order,total}"}, args)
+	})
+
+	t.Run("sum falls back to goal value", func(t *testing.T) {
+		sql, args, err := BuildComputedPropertiesSQL([]domain.ComputedProperty{
+			{Key: "revenue", Source: domain.ComputedPropertySourceCustomEvents, Aggregate: domain.ComputedPropertyAggregateSum, GoalType: domain.GoalTypePurchase},
+		}, 1)
+		require.NoError(t, err)
+		assert.Contains(t, sql, "COALESCE(SUM(ce.goal_value), 0)")
+		assert.Contains(t, sql, "ce.goal_type = $1")
+		assert.Equal(t, []interface{}{domain.GoalTypePurchase}, args)
+	})
+
+	t.Run("last opened message", func(t *testing.T) {
+		sql, args, err := BuildComputedPropertiesSQL([]domain.ComputedProperty{
+			{Key: "last_opened_at", Source: domain.ComputedPropertySourceMessageHistory, Aggregate: domain.ComputedPropertyAggregateLastAt, EventName: "opened"},
+		}, 1)
+		require.NoError(t, err)
+		assert.Contains(t, sql, "SELECT MAX(mh.opened_at) AS v FROM message_history mh WHERE mh.contact_email = c.email AND mh.opened_at IS NOT NULL")
+		assert.Empty(t, args)
+	})
+
+	t.Run("engagement score with sorted weights", func(t *testing.T) {
+		sql, args, err := BuildComputedPropertiesSQL([]domain.ComputedProperty{
+			{Key: "engagement", Source: domain.ComputedPropertySourceMessageHistory, Aggregate: domain.ComputedPropertyAggregateEngagementScore, WindowDays: 30},
+		}, 1)
+		require.NoError(t, err)
+		assert.Contains(t, sql, "COALESCE(SUM($2::numeric * (mh.clicked_at IS NOT NULL)::int + $3::numeric * (mh.opened_at IS NOT NULL)::int), 0)")
+		assert.Contains(t, sql, "mh.sent_at >= NOW() - make_interval(days => $1)")
+		assert.Equal(t, []interface{}{30, 3.0, 1.0}, args)
+	})
+
+	t.Run("timeline count by kind", func(t *testing.T) {
+		sql, args, err := BuildComputedPropertiesSQL([]domain.ComputedProperty{
+			{Key: "subscriptions", Source: domain.ComputedPropertySourceContactTimeline, Aggregate: domain.ComputedPropertyAggregateCount, EventName: "list.subscribed"},
+		}, 1)
+		require.NoError(t, err)
+		assert.Contains(t, sql, "FROM contact_timeline ct WHERE ct.email = c.email AND ct.kind = $1")
+		assert.Equal(t, []interface{}{"list.subscribed"}, args)
+	})
+
+	t.Run("most frequent value", func(t *testing.T) {
+		sql, args, err := BuildComputedPropertiesSQL([]domain.ComputedProperty{
+			{Key: "favorite_category", Source: domain.ComputedPropertySourceCustomEvents, Aggregate: domain.ComputedPropertyAggregateMostFrequent, PropertyPath: []string{"category"}},
+		}, 1)
+		require.NoError(t, err)
+		assert.Contains(t, sql, "SELECT ce.properties #>> $1::text[] AS v")
+		assert.Contains(t, sql, "GROUP BY 1 ORDER BY COUNT(*) DESC, 1 ASC LIMIT 1")
+		assert.Equal(t, []interface{}{"{category}"}, args)
+	})
+
+	t.Run("predicted ltv uses default horizon", func(t *testing.T) {
+		sql, args, err := BuildComputedPropertiesSQL([]domain.ComputedProperty{
+			{Key: "ltv", Source: domain.ComputedPropertySourceCustomEvents, Aggregate: domain.ComputedPropertyAggregatePredictedLTV},
+		}, 1)
+		require.NoError(t, err)
+		assert.Contains(t, sql, "GREATEST(EXTRACT(EPOCH FROM NOW() - MIN(ce.occurred_at)) / 86400, 30) * $1::numeric")
+		assert.Equal(t, []interface{}{365}, args)
+	})
+
+	t.Run("inverted buckets score", func(t *testing.T) {
+		sql, args, err := BuildComputedPropertiesSQL([]domain.ComputedProperty{
+			{Key: "rfm_recency", Source: domain.ComputedPropertySourceCustomEvents, Aggregate: domain.ComputedPropertyAggregateDaysSinceLast, Buckets: []float64{7, 30}, InvertBuckets: true},
+		}, 1)
+		require.NoError(t, err)
+		assert.Contains(t, sql, "'rfm_recency', (SELECT 1 + (x.v <= $1)::int + (x.v <= $2)::int FROM (SELECT FLOOR(EXTRACT(EPOCH FROM NOW() - MAX(ce.occurred_at)) / 86400)::int AS v")
+		assert.Equal(t, []interface{}{7.0, 30.0}, args)
+	})
+
+	t.Run("multiple properties continue placeholder numbering", func(t *testing.T) {
+		sql, args, err := BuildComputedPropertiesSQL([]domain.ComputedProperty{
+			{Key: "orders", Source: domain.ComputedPropertySourceCustomEvents, Aggregate: domain.ComputedPropertyAggregateCount, EventName: "order"},
+			{Key: "refunds", Source: domain.ComputedPropertySourceCustomEvents, Aggregate: domain.ComputedPropertyAggregateCount, EventName: "refund"},
+		}, 2)
+		require.NoError(t, err)
+		assert.Contains(t, sql, "'orders', (SELECT COUNT(*) AS v FROM custom_events ce WHERE ce.email = c.email AND ce.deleted_at IS NULL AND ce.event_name = $2)")
+		assert.Contains(t, sql, "'refunds', (SELECT COUNT(*) AS v FROM custom_events ce WHERE ce.email = c.email AND ce.deleted_at IS NULL AND ce.event_name = $3)")
+		assert.Equal(t, []interface{}{"order", "refund"}, args)
+	})
+
+	t.Run("invalid property", func(t *testing.T) {
+		_, _, err := BuildComputedPropertiesSQL([]domain.ComputedProperty{
+			{Key: "bad", Source: domain.ComputedPropertySourceMessageHistory, Aggregate: domain.ComputedPropertyAggregateSum},
+		}, 1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid computed property bad")
+	})
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// contactBulkTargetSQL returns the query selecting the emails of a target
+func contactBulkTargetSQL(target *domain.ContactBulkTarget) (string, []interface{}, error) {
+	switch target.Type {
+	case domain.ContactBulkTargetEmails:
+		return "SELECT email FROM contacts WHERE email = ANY($1)", []interface{}{pq.Array(target.Emails)}, nil
+	case domain.ContactBulkTargetSegment:
+		return "SELECT email FROM contact_segments WHERE segment_id = $1", []interface{}{target.SegmentID}, nil
+	case domain.ContactBulkTargetList:
+		if target.ListStatus != "" {
+			return "SELECT email FROM contact_lists WHERE list_id = $1 AND deleted_at IS NULL AND status = $2",
+				[]interface{}{target.ListID, target.ListStatus}, nil
+		}
+		return "SELECT email FROM contact_lists WHERE list_id = $1 AND deleted_at IS NULL", []interface{}{target.ListID}, nil
+	case domain.ContactBulkTargetFilter:
+		return NewQueryBuilder().BuildSQL(target.Filter)
+	default:
+		return "", nil, fmt.Errorf("invalid target type: %s", target.Type)
+	}
+}
+
+type ContactBulkOperationService struct {
+	repo           domain.ContactBulkOperationRepository
+	segmentRepo    domain.SegmentRepository
+	listRepo       domain.ListRepository
+	automationRepo domain.AutomationRepository
+	taskService    domain.TaskService
+	authService    domain.AuthService
+	logger         logger.Logger
+}
+
+func NewContactBulkOperationService(
+	repo domain.ContactBulkOperationRepository,
+	segmentRepo domain.SegmentRepository,
+	listRepo domain.ListRepository,
+	automationRepo domain.AutomationRepository,
+	taskService domain.TaskService,
+	authService domain.AuthService,
+	logger logger.Logger,
+) *ContactBulkOperationService {
+	return &ContactBulkOperationService{
+		repo:           repo,
+		segmentRepo:    segmentRepo,
+		listRepo:       listRepo,
+		automationRepo: automationRepo,
+		taskService:    taskService,
+		authService:    authService,
+		logger:         logger,
+	}
+}
+
+// authorize authenticates the user and checks their access to contacts
+func (s *ContactBulkOperationService) authorize(ctx context.Context, workspaceID string, permission domain.PermissionType) (context.Context, *domain.User, *domain.UserWorkspace, error) {
+	ctx, user, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceContacts, permission) {
+		return nil, nil, nil, domain.NewPermissionError(
+			domain.PermissionResourceContacts,
+			permission,
+			fmt.Sprintf("Insufficient permissions: %s access to contacts required", permission),
+		)
+	}
+	return ctx, user, userWorkspace, nil
+}
+
+// checkContactBulkActionPermission checks the access required by the resource an action changes
+func checkContactBulkActionPermission(userWorkspace *domain.UserWorkspace, action *domain.ContactBulkAction) error {
+	var resource domain.PermissionResource
+	switch action.Type {
+	case domain.ContactBulkActionAddToList, domain.ContactBulkActionUnsubscribeFromList, domain.ContactBulkActionRemoveFromList:
+		resource = domain.PermissionResourceLists
+	case domain.ContactBulkActionExitAutomation:
+		resource = domain.PermissionResourceAutomations
+	default:
+		return nil
+	}
+
+	if !userWorkspace.HasPermission(resource, domain.PermissionTypeWrite) {
+		return domain.NewPermissionError(
+			resource,
+			domain.PermissionTypeWrite,
+			fmt.Sprintf("Insufficient permissions: write access to %s required", resource),
+		)
+	}
+	return nil
+}
+
+// checkReferences makes sure the segment, list and automation of an operation exist
+func (s *ContactBulkOperationService) checkReferences(ctx context.Context, workspaceID string, target *domain.ContactBulkTarget, action *domain.ContactBulkAction) error {
+	if target.Type == domain.ContactBulkTargetSegment {
+		if _, err := s.segmentRepo.GetSegmentByID(ctx, workspaceID, target.SegmentID); err != nil {
+			var notFound *domain.ErrSegmentNotFound
+			if errors.As(err, &notFound) {
+				return domain.NewValidationError(fmt.Sprintf("segment %s not found", target.SegmentID))
+			}
+			return fmt.Errorf("failed to get segment: %w", err)
+		}
+	}
+
+	listIDs := []string{}
+	if target.Type == domain.ContactBulkTargetList {
+		listIDs = append(listIDs, target.ListID)
+	}
+	if action.ListID != "" {
+		listIDs = append(listIDs, action.ListID)
+	}
+	for _, listID := range listIDs {
+		if _, err := s.listRepo.GetListByID(ctx, workspaceID, listID); err != nil {
+			var notFound *domain.ErrListNotFound
+			if errors.As(err, &notFound) {
+				return domain.NewValidationError(fmt.Sprintf("list %s not found", listID))
+			}
+			return fmt.Errorf("failed to get list: %w", err)
+		}
+	}
+
+	if action.Type == domain.ContactBulkActionExitAutomation {
+		if _, err := s.automationRepo.GetByID(ctx, workspaceID, action.AutomationID); err != nil {
+			return fmt.Errorf("failed to get automation: %w", err)
+		}
+	}
+	return nil
+}
+
+// CreateContactBulkOperation counts the target contacts and, unless it is a dry run,
+// starts the bulk_contact_operation task
+func (s *ContactBulkOperationService) CreateContactBulkOperation(ctx context.Context, req *domain.CreateContactBulkOperationRequest) (*domain.CreateContactBulkOperationResponse, error) {
+	ctx, user, userWorkspace, err := s.authorize(ctx, req.WorkspaceID, domain.PermissionTypeWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, domain.NewValidationError(err.Error())
+	}
+
+	if err := checkContactBulkActionPermission(userWorkspace, &req.Action); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkReferences(ctx, req.WorkspaceID, &req.Target, &req.Action); err != nil {
+		return nil, err
+	}
+
+	targetSQL, args, err := contactBulkTargetSQL(&req.Target)
+	if err != nil {
+		return nil, domain.NewValidationError(err.Error())
+	}
+
+	count, err := s.repo.CountTargets(ctx, req.WorkspaceID, targetSQL, args)
+	if err != nil {
+		s.logger.WithField("workspace_id", req.WorkspaceID).Error(fmt.Sprintf("Failed to count bulk operation targets: %v", err))
+		return nil, fmt.Errorf("failed to count target contacts: %w", err)
+	}
+
+	response := &domain.CreateContactBulkOperationResponse{
+		DryRun:      req.DryRun,
+		TargetCount: count,
+	}
+	if req.DryRun {
+		return response, nil
+	}
+
+	operation := &domain.ContactBulkOperation{
+		ID:         uuid.New().String(),
+		Target:     req.Target,
+		Action:     req.Action,
+		Status:     domain.ContactBulkOperationStatusPending,
+		TotalCount: count,
+	}
+	if user != nil {
+		operation.CreatedBy = user.ID
+	}
+
+	task := &domain.Task{
+		ID:          uuid.New().String(),
+		WorkspaceID: req.WorkspaceID,
+		Type:        "bulk_contact_operation",
+		Status:      domain.TaskStatusPending,
+		State: &domain.TaskState{
+			BulkContactOperation: &domain.BulkContactOperationState{
+				OperationID: operation.ID,
+			},
+		},
+		MaxRuntime:    300, // 5 minutes per run, the task resumes after the last processed email
+		MaxRetries:    3,
+		RetryInterval: 60,
+	}
+	operation.TaskID = &task.ID
+
+	if err := s.repo.Create(ctx, req.WorkspaceID, operation); err != nil {
+		s.logger.WithField("operation_id", operation.ID).Error(fmt.Sprintf("Failed to create contact bulk operation: %v", err))
+		return nil, fmt.Errorf("failed to create contact bulk operation: %w", err)
+	}
+
+	if err := s.taskService.CreateTask(ctx, req.WorkspaceID, task); err != nil {
+		s.logger.WithField("operation_id", operation.ID).Error(fmt.Sprintf("Failed to create bulk operation task: %v", err))
+
+		message := "failed to start the bulk operation task"
+		now := time.Now().UTC()
+		operation.Status = domain.ContactBulkOperationStatusFailed
+		operation.ErrorMessage = &message
+		operation.CompletedAt = &now
+		if updateErr := s.repo.Update(ctx, req.WorkspaceID, operation); updateErr != nil {
+			s.logger.WithField("operation_id", operation.ID).Error(fmt.Sprintf("Failed to update contact bulk operation: %v", updateErr))
+		}
+		return nil, fmt.Errorf("failed to create bulk operation task: %w", err)
+	}
+
+	// Immediately trigger execution of the bulk operation task
+	go func() {
+		// Small delay to ensure transaction is committed
+		time.Sleep(100 * time.Millisecond)
+		timeoutAt := time.Now().Add(time.Duration(task.MaxRuntime) * time.Second)
+		if execErr := s.taskService.ExecuteTask(context.Background(), req.WorkspaceID, task.ID, timeoutAt); execErr != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"operation_id": operation.ID,
+				"task_id":      task.ID,
+				"error":        execErr.Error(),
+			}).Warn("Failed to immediately execute bulk operation task, will be picked up by next cron run")
+		}
+	}()
+
+	response.Operation = operation
+	return response, nil
+}
+
+func (s *ContactBulkOperationService) GetContactBulkOperation(ctx context.Context, workspaceID string, id string) (*domain.ContactBulkOperation, error) {
+	ctx, _, _, err := s.authorize(ctx, workspaceID, domain.PermissionTypeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, workspaceID, id)
+}
+
+func (s *ContactBulkOperationService) ListContactBulkOperations(ctx context.Context, workspaceID string) ([]*domain.ContactBulkOperation, error) {
+	ctx, _, _, err := s.authorize(ctx, workspaceID, domain.PermissionTypeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	operations, err := s.repo.List(ctx, workspaceID)
+	if err != nil {
+		s.logger.WithField("workspace_id", workspaceID).Error(fmt.Sprintf("Failed to list contact bulk operations: %v", err))
+		return nil, fmt.Errorf("failed to list contact bulk operations: %w", err)
+	}
+	return operations, nil
+}
+
+// CancelContactBulkOperation stops an operation, its task notices the cancellation
+// before its next batch
+func (s *ContactBulkOperationService) CancelContactBulkOperation(ctx context.Context, workspaceID string, id string) (*domain.ContactBulkOperation, error) {
+	ctx, _, _, err := s.authorize(ctx, workspaceID, domain.PermissionTypeWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	operation, err := s.repo.GetByID(ctx, workspaceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !operation.Status.IsActive() {
+		return nil, domain.NewValidationError(fmt.Sprintf("the operation is already %s", operation.Status))
+	}
+
+	now := time.Now().UTC()
+	operation.Status = domain.ContactBulkOperationStatusCancelled
+	operation.CompletedAt = &now
+	if err := s.repo.Update(ctx, workspaceID, operation); err != nil {
+		s.logger.WithField("operation_id", id).Error(fmt.Sprintf("Failed to cancel contact bulk operation: %v", err))
+		return nil, fmt.Errorf("failed to cancel contact bulk operation: %w", err)
+	}
+	return operation, nil
+}
+
+// GetContactBulkOperationResultFile returns the outcome for each contact as CSV
+func (s *ContactBulkOperationService) GetContactBulkOperationResultFile(ctx context.Context, workspaceID string, id string) ([]byte, error) {
+	ctx, _, _, err := s.authorize(ctx, workspaceID, domain.PermissionTypeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetByID(ctx, workspaceID, id); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"email", "status", "error"})
+
+	afterEmail := ""
+	for {
+		results, err := s.repo.ListResults(ctx, workspaceID, id, afterEmail, domain.ContactBulkBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get contact bulk operation results: %w", err)
+		}
+		for _, result := range results {
+			_ = w.Write([]string{result.Email, string(result.Status), result.Error})
+		}
+		if len(results) < domain.ContactBulkBatchSize {
+			break
+		}
+		afterEmail = results[len(results)-1].Email
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write result file: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
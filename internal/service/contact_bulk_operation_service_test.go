@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+type contactBulkOperationServiceTest struct {
+	service        *ContactBulkOperationService
+	repo           *mocks.MockContactBulkOperationRepository
+	segmentRepo    *mocks.MockSegmentRepository
+	listRepo       *mocks.MockListRepository
+	automationRepo *mocks.MockAutomationRepository
+	taskService    *mocks.MockTaskService
+	authService    *mocks.MockAuthService
+}
+
+func setupContactBulkOperationServiceTest(t *testing.T) *contactBulkOperationServiceTest {
+	ctrl := gomock.NewController(t)
+
+	st := &contactBulkOperationServiceTest{
+		repo:           mocks.NewMockContactBulkOperationRepository(ctrl),
+		segmentRepo:    mocks.NewMockSegmentRepository(ctrl),
+		listRepo:       mocks.NewMockListRepository(ctrl),
+		automationRepo: mocks.NewMockAutomationRepository(ctrl),
+		taskService:    mocks.NewMockTaskService(ctrl),
+		authService:    mocks.NewMockAuthService(ctrl),
+	}
+	st.service = NewContactBulkOperationService(st.repo, st.segmentRepo, st.listRepo, st.automationRepo,
+		st.taskService, st.authService, logger.NewLogger())
+	return st
+}
+
+func contactBulkOperationTestUserWorkspace(contactsWrite, listsWrite, automationsWrite bool) *domain.UserWorkspace {
+	return &domain.UserWorkspace{
+		UserID:      "user1",
+		WorkspaceID: "ws1",
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceContacts:    {Read: true, Write: contactsWrite},
+			domain.PermissionResourceLists:       {Read: true, Write: listsWrite},
+			domain.PermissionResourceAutomations: {Read: true, Write: automationsWrite},
+		},
+	}
+}
+
+func TestContactBulkTargetSQL(t *testing.T) {
+	query, args, err := contactBulkTargetSQL(&domain.ContactBulkTarget{Type: domain.ContactBulkTargetList, ListID: "news"})
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT email FROM contact_lists WHERE list_id = $1 AND deleted_at IS NULL", query)
+	assert.Equal(t, []interface{}{"news"}, args)
+
+	query, args, err = contactBulkTargetSQL(&domain.ContactBulkTarget{Type: domain.ContactBulkTargetList, ListID: "news", ListStatus: domain.ContactListStatusActive})
+	require.NoError(t, err)
+	assert.Contains(t, query, "status = $2")
+	assert.Len(t, args, 2)
+
+	query, _, err = contactBulkTargetSQL(&domain.ContactBulkTarget{Type: domain.ContactBulkTargetSegment, SegmentID: "seg1"})
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT email FROM contact_segments WHERE segment_id = $1", query)
+
+	query, args, err = contactBulkTargetSQL(&domain.ContactBulkTarget{
+		Type: domain.ContactBulkTargetFilter,
+		Filter: &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source: "contacts",
+				Contact: &domain.ContactCondition{
+					Filters: []*domain.DimensionFilter{
+						{FieldName: "country", FieldType: "string", Operator: "equals", StringValues: []string{"FR"}},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(query, "SELECT email FROM contacts WHERE"))
+	assert.Equal(t, []interface{}{"FR"}, args)
+}
+
+func TestContactBulkOperationService_CreateContactBulkOperation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("dry run only counts the target", func(t *testing.T) {
+		st := setupContactBulkOperationServiceTest(t)
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{ID: "user1"}, contactBulkOperationTestUserWorkspace(true, false, false), nil)
+		st.segmentRepo.EXPECT().GetSegmentByID(ctx, "ws1", "seg1").Return(&domain.Segment{ID: "seg1"}, nil)
+		st.repo.EXPECT().CountTargets(ctx, "ws1", "SELECT email FROM contact_segments WHERE segment_id = $1", []interface{}{"seg1"}).Return(1200, nil)
+
+		response, err := st.service.CreateContactBulkOperation(ctx, &domain.CreateContactBulkOperationRequest{
+			WorkspaceID: "ws1",
+			Target:      domain.ContactBulkTarget{Type: domain.ContactBulkTargetSegment, SegmentID: "seg1"},
+			Action:      domain.ContactBulkAction{Type: domain.ContactBulkActionDelete},
+			DryRun:      true,
+		})
+		require.NoError(t, err)
+		assert.True(t, response.DryRun)
+		assert.Equal(t, 1200, response.TargetCount)
+		assert.Nil(t, response.Operation)
+	})
+
+	t.Run("creates the operation and its task", func(t *testing.T) {
+		st := setupContactBulkOperationServiceTest(t)
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{ID: "user1"}, contactBulkOperationTestUserWorkspace(true, true, false), nil)
+		st.listRepo.EXPECT().GetListByID(ctx, "ws1", "news").Return(&domain.List{ID: "news"}, nil)
+		st.repo.EXPECT().CountTargets(ctx, "ws1", gomock.Any(), gomock.Any()).Return(2, nil)
+
+		var created *domain.ContactBulkOperation
+		st.repo.EXPECT().Create(ctx, "ws1", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, operation *domain.ContactBulkOperation) error {
+			created = operation
+			return nil
+		})
+		st.taskService.EXPECT().CreateTask(ctx, "ws1", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, task *domain.Task) error {
+			assert.Equal(t, "bulk_contact_operation", task.Type)
+			require.NotNil(t, task.State.BulkContactOperation)
+			assert.Equal(t, created.ID, task.State.BulkContactOperation.OperationID)
+			assert.Equal(t, task.ID, *created.TaskID)
+			return nil
+		})
+		st.taskService.EXPECT().ExecuteTask(gomock.Any(), "ws1", gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+		response, err := st.service.CreateContactBulkOperation(ctx, &domain.CreateContactBulkOperationRequest{
+			WorkspaceID: "ws1",
+			Target:      domain.ContactBulkTarget{Type: domain.ContactBulkTargetEmails, Emails: []string{"a@example.com", "b@example.com"}},
+			Action:      domain.ContactBulkAction{Type: domain.ContactBulkActionAddToList, ListID: "news"},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, response.Operation)
+		assert.Equal(t, domain.ContactBulkOperationStatusPending, response.Operation.Status)
+		assert.Equal(t, 2, response.Operation.TotalCount)
+		assert.Equal(t, "user1", response.Operation.CreatedBy)
+	})
+
+	t.Run("list actions require write access to lists", func(t *testing.T) {
+		st := setupContactBulkOperationServiceTest(t)
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactBulkOperationTestUserWorkspace(true, false, false), nil)
+
+		_, err := st.service.CreateContactBulkOperation(ctx, &domain.CreateContactBulkOperationRequest{
+			WorkspaceID: "ws1",
+			Target:      domain.ContactBulkTarget{Type: domain.ContactBulkTargetSegment, SegmentID: "seg1"},
+			Action:      domain.ContactBulkAction{Type: domain.ContactBulkActionUnsubscribeFromList, ListID: "news"},
+		})
+		var permErr *domain.PermissionError
+		assert.True(t, errors.As(err, &permErr))
+	})
+
+	t.Run("exit_automation requires write access to automations", func(t *testing.T) {
+		st := setupContactBulkOperationServiceTest(t)
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactBulkOperationTestUserWorkspace(true, true, false), nil)
+
+		_, err := st.service.CreateContactBulkOperation(ctx, &domain.CreateContactBulkOperationRequest{
+			WorkspaceID: "ws1",
+			Target:      domain.ContactBulkTarget{Type: domain.ContactBulkTargetSegment, SegmentID: "seg1"},
+			Action:      domain.ContactBulkAction{Type: domain.ContactBulkActionExitAutomation, AutomationID: "auto1"},
+		})
+		var permErr *domain.PermissionError
+		assert.True(t, errors.As(err, &permErr))
+	})
+
+	t.Run("unknown segment", func(t *testing.T) {
+		st := setupContactBulkOperationServiceTest(t)
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactBulkOperationTestUserWorkspace(true, false, false), nil)
+		st.segmentRepo.EXPECT().GetSegmentByID(ctx, "ws1", "missing").Return(nil, &domain.ErrSegmentNotFound{Message: "segment not found"})
+
+		_, err := st.service.CreateContactBulkOperation(ctx, &domain.CreateContactBulkOperationRequest{
+			WorkspaceID: "ws1",
+			Target:      domain.ContactBulkTarget{Type: domain.ContactBulkTargetSegment, SegmentID: "missing"},
+			Action:      domain.ContactBulkAction{Type: domain.ContactBulkActionDelete},
+		})
+		var validationErr domain.ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Contains(t, validationErr.Message, "segment missing not found")
+	})
+
+	t.Run("task creation failure marks the operation failed", func(t *testing.T) {
+		st := setupContactBulkOperationServiceTest(t)
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactBulkOperationTestUserWorkspace(true, false, false), nil)
+		st.repo.EXPECT().CountTargets(ctx, "ws1", gomock.Any(), gomock.Any()).Return(1, nil)
+		st.repo.EXPECT().Create(ctx, "ws1", gomock.Any()).Return(nil)
+		st.taskService.EXPECT().CreateTask(ctx, "ws1", gomock.Any()).Return(errors.New("db down"))
+		st.repo.EXPECT().Update(ctx, "ws1", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, operation *domain.ContactBulkOperation) error {
+			assert.Equal(t, domain.ContactBulkOperationStatusFailed, operation.Status)
+			return nil
+		})
+
+		_, err := st.service.CreateContactBulkOperation(ctx, &domain.CreateContactBulkOperationRequest{
+			WorkspaceID: "ws1",
+			Target:      domain.ContactBulkTarget{Type: domain.ContactBulkTargetEmails, Emails: []string{"a@example.com"}},
+			Action:      domain.ContactBulkAction{Type: domain.ContactBulkActionDelete},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestContactBulkOperationService_CancelContactBulkOperation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cancels a running operation", func(t *testing.T) {
+		st := setupContactBulkOperationServiceTest(t)
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactBulkOperationTestUserWorkspace(true, false, false), nil)
+		st.repo.EXPECT().GetByID(ctx, "ws1", "op1").Return(&domain.ContactBulkOperation{ID: "op1", Status: domain.ContactBulkOperationStatusRunning}, nil)
+		st.repo.EXPECT().Update(ctx, "ws1", gomock.Any()).Return(nil)
+
+		operation, err := st.service.CancelContactBulkOperation(ctx, "ws1", "op1")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContactBulkOperationStatusCancelled, operation.Status)
+		assert.NotNil(t, operation.CompletedAt)
+	})
+
+	t.Run("finished operations cannot be cancelled", func(t *testing.T) {
+		st := setupContactBulkOperationServiceTest(t)
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactBulkOperationTestUserWorkspace(true, false, false), nil)
+		st.repo.EXPECT().GetByID(ctx, "ws1", "op1").Return(&domain.ContactBulkOperation{ID: "op1", Status: domain.ContactBulkOperationStatusCompleted}, nil)
+
+		_, err := st.service.CancelContactBulkOperation(ctx, "ws1", "op1")
+		var validationErr domain.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+	})
+
+	t.Run("requires write access to contacts", func(t *testing.T) {
+		st := setupContactBulkOperationServiceTest(t)
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactBulkOperationTestUserWorkspace(false, false, false), nil)
+
+		_, err := st.service.CancelContactBulkOperation(ctx, "ws1", "op1")
+		var permErr *domain.PermissionError
+		assert.True(t, errors.As(err, &permErr))
+	})
+}
+
+func TestContactBulkOperationService_GetContactBulkOperationResultFile(t *testing.T) {
+	ctx := context.Background()
+	st := setupContactBulkOperationServiceTest(t)
+	st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactBulkOperationTestUserWorkspace(false, false, false), nil)
+	st.repo.EXPECT().GetByID(ctx, "ws1", "op1").Return(&domain.ContactBulkOperation{ID: "op1"}, nil)
+
+	// A full page is followed by a request for the next one
+	firstPage := make([]*domain.ContactBulkOperationResult, domain.ContactBulkBatchSize)
+	for i := range firstPage {
+		firstPage[i] = &domain.ContactBulkOperationResult{Email: "a@example.com", Status: domain.ContactBulkResultSucceeded}
+	}
+	firstPage[len(firstPage)-1].Email = "m@example.com"
+	st.repo.EXPECT().ListResults(ctx, "ws1", "op1", "", domain.ContactBulkBatchSize).Return(firstPage, nil)
+	st.repo.EXPECT().ListResults(ctx, "ws1", "op1", "m@example.com", domain.ContactBulkBatchSize).Return([]*domain.ContactBulkOperationResult{
+		{Email: "z@example.com", Status: domain.ContactBulkResultSkipped, Error: "not on the list"},
+	}, nil)
+
+	data, err := st.service.GetContactBulkOperationResultFile(ctx, "ws1", "op1")
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Equal(t, "email,status,error", lines[0])
+	assert.Len(t, lines, domain.ContactBulkBatchSize+2)
+	assert.Equal(t, "z@example.com,skipped,not on the list", lines[len(lines)-1])
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// contactEraser deletes a contact with its related data, implemented by ContactService
+type contactEraser interface {
+	eraseContact(ctx context.Context, workspaceID string, email string) error
+}
+
+// ContactBulkOperationTaskProcessor applies the action of a bulk operation to its
+// target contacts in batches, walking the target in email order. The task resumes
+// after the last processed email when it runs out of time, and stops before its next
+// batch once the operation is cancelled.
+type ContactBulkOperationTaskProcessor struct {
+	operationRepo   domain.ContactBulkOperationRepository
+	workspaceRepo   domain.WorkspaceRepository
+	contactRepo     domain.ContactRepository
+	contactListRepo domain.ContactListRepository
+	automationRepo  domain.AutomationRepository
+	taskRepo        domain.TaskRepository
+	eraser          contactEraser
+	logger          logger.Logger
+	batchSize       int
+}
+
+// NewContactBulkOperationTaskProcessor creates a new contact bulk operation task processor
+func NewContactBulkOperationTaskProcessor(
+	operationRepo domain.ContactBulkOperationRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	contactRepo domain.ContactRepository,
+	contactListRepo domain.ContactListRepository,
+	automationRepo domain.AutomationRepository,
+	taskRepo domain.TaskRepository,
+	contactService *ContactService,
+	logger logger.Logger,
+) *ContactBulkOperationTaskProcessor {
+	return &ContactBulkOperationTaskProcessor{
+		operationRepo:   operationRepo,
+		workspaceRepo:   workspaceRepo,
+		contactRepo:     contactRepo,
+		contactListRepo: contactListRepo,
+		automationRepo:  automationRepo,
+		taskRepo:        taskRepo,
+		eraser:          contactService,
+		logger:          logger,
+		batchSize:       domain.ContactBulkBatchSize,
+	}
+}
+
+// CanProcess returns whether this processor can handle the given task type
+func (p *ContactBulkOperationTaskProcessor) CanProcess(taskType string) bool {
+	return taskType == "bulk_contact_operation"
+}
+
+// Process applies the action to the next batches of contacts until the end of the
+// target or the timeout approaches
+func (p *ContactBulkOperationTaskProcessor) Process(ctx context.Context, task *domain.Task, timeoutAt time.Time) (bool, error) {
+	if task.State == nil || task.State.BulkContactOperation == nil {
+		return false, fmt.Errorf("bulk contact operation state is missing")
+	}
+	state := task.State.BulkContactOperation
+
+	operation, err := p.operationRepo.GetByID(ctx, task.WorkspaceID, state.OperationID)
+	if err != nil {
+		var notFound *domain.ErrContactBulkOperationNotFound
+		if errors.As(err, &notFound) {
+			task.State.Message = "Bulk operation was deleted"
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get contact bulk operation: %w", err)
+	}
+
+	if !operation.Status.IsActive() {
+		task.State.Message = fmt.Sprintf("Bulk operation is %s", operation.Status)
+		return true, nil
+	}
+
+	workspace, err := p.workspaceRepo.GetByID(ctx, task.WorkspaceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	targetSQL, args, err := contactBulkTargetSQL(&operation.Target)
+	if err != nil {
+		return p.fail(ctx, task, operation, err.Error())
+	}
+
+	operation.Status = domain.ContactBulkOperationStatusRunning
+
+	for {
+		// Check if we're approaching timeout
+		if time.Now().Add(10 * time.Second).After(timeoutAt) {
+			p.logger.WithFields(map[string]interface{}{
+				"task_id":      task.ID,
+				"operation_id": operation.ID,
+				"processed":    operation.ProcessedCount,
+			}).Info("Approaching timeout, pausing contact bulk operation")
+			if err := p.saveProgress(ctx, task, operation); err != nil {
+				return false, err
+			}
+			return false, nil
+		}
+
+		emails, err := p.operationRepo.GetTargetEmails(ctx, task.WorkspaceID, targetSQL, args, state.AfterEmail, p.batchSize)
+		if err != nil {
+			return false, fmt.Errorf("failed to get target contacts: %w", err)
+		}
+		if len(emails) == 0 {
+			break
+		}
+
+		results, err := p.processBatch(ctx, workspace, operation, emails)
+		if err != nil {
+			return false, err
+		}
+		// The results and the counters are saved together, a batch retried after a failure
+		// to save the task state is not counted twice
+		if err := p.operationRepo.SaveBatch(ctx, task.WorkspaceID, operation, results); err != nil {
+			return false, fmt.Errorf("failed to save contact bulk operation results: %w", err)
+		}
+
+		state.AfterEmail = emails[len(emails)-1]
+		if operation.TotalCount > 0 {
+			task.Progress = float64(operation.ProcessedCount) / float64(operation.TotalCount)
+			if task.Progress > 1 {
+				task.Progress = 1
+			}
+		}
+		if err := p.saveProgress(ctx, task, operation); err != nil {
+			return false, err
+		}
+
+		// The update returns the stored status, which a cancellation may have changed
+		if operation.Status == domain.ContactBulkOperationStatusCancelled {
+			task.State.Message = fmt.Sprintf("Bulk operation cancelled after %d contacts", operation.ProcessedCount)
+			return true, nil
+		}
+
+		if len(emails) < p.batchSize {
+			break
+		}
+	}
+
+	now := time.Now().UTC()
+	operation.Status = domain.ContactBulkOperationStatusCompleted
+	operation.CompletedAt = &now
+	if err := p.operationRepo.Update(ctx, task.WorkspaceID, operation); err != nil {
+		return false, fmt.Errorf("failed to update contact bulk operation: %w", err)
+	}
+
+	p.logger.WithFields(map[string]interface{}{
+		"task_id":      task.ID,
+		"operation_id": operation.ID,
+		"action":       operation.Action.Type,
+		"succeeded":    operation.SucceededCount,
+		"skipped":      operation.SkippedCount,
+		"failed":       operation.FailedCount,
+	}).Info("Completed contact bulk operation")
+
+	task.State.Message = fmt.Sprintf("%d contacts: %d succeeded, %d skipped, %d failed",
+		operation.ProcessedCount, operation.SucceededCount, operation.SkippedCount, operation.FailedCount)
+	task.Progress = 1
+	return true, nil
+}
+
+// processBatch applies the action to a batch of contacts and returns the outcome for each
+func (p *ContactBulkOperationTaskProcessor) processBatch(ctx context.Context, workspace *domain.Workspace, operation *domain.ContactBulkOperation, emails []string) ([]*domain.ContactBulkOperationResult, error) {
+	action := &operation.Action
+	listCtx := domain.WithConsentContext(ctx, domain.ConsentContext{
+		Source:   domain.ConsentSourceBulkOperation,
+		SourceID: operation.ID,
+	})
+
+	results := make([]*domain.ContactBulkOperationResult, 0, len(emails))
+	add := func(email string, err error) {
+		result := &domain.ContactBulkOperationResult{Email: email, Status: domain.ContactBulkResultSucceeded}
+		if err != nil {
+			result.Status = domain.ContactBulkResultFailed
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	switch action.Type {
+	case domain.ContactBulkActionAddToList:
+		if err := p.contactListRepo.BulkAddContactsToLists(listCtx, workspace.ID, emails, []string{action.ListID}, action.ListStatus); err != nil {
+			return nil, fmt.Errorf("failed to add contacts to list: %w", err)
+		}
+		for _, email := range emails {
+			add(email, nil)
+		}
+
+	case domain.ContactBulkActionExitAutomation:
+		exited, err := p.automationRepo.ExitContactJourneys(ctx, workspace.ID, action.AutomationID, emails, domain.ContactBulkExitReason)
+		if err != nil {
+			return nil, fmt.Errorf("failed to exit automation journeys: %w", err)
+		}
+		inJourney := make(map[string]bool, len(exited))
+		for _, email := range exited {
+			inJourney[email] = true
+		}
+		for _, email := range emails {
+			if inJourney[email] {
+				add(email, nil)
+			} else {
+				results = append(results, &domain.ContactBulkOperationResult{
+					Email:  email,
+					Status: domain.ContactBulkResultSkipped,
+					Error:  "no active journey",
+				})
+			}
+		}
+
+	default:
+		for _, email := range emails {
+			err := p.applyToContact(ctx, listCtx, workspace, action, email)
+			if err == nil && action.Type == domain.ContactBulkActionDelete {
+				// the result log must not keep the address of an erased contact
+				add(domain.ErasedContactResultEmail(email), nil)
+				continue
+			}
+			var notOnList *domain.ErrContactListNotFound
+			if errors.As(err, &notOnList) {
+				results = append(results, &domain.ContactBulkOperationResult{
+					Email:  email,
+					Status: domain.ContactBulkResultSkipped,
+					Error:  "not on the list",
+				})
+				continue
+			}
+			add(email, err)
+		}
+	}
+
+	return results, nil
+}
+
+// applyToContact applies the actions handled one contact at a time
+func (p *ContactBulkOperationTaskProcessor) applyToContact(ctx, listCtx context.Context, workspace *domain.Workspace, action *domain.ContactBulkAction, email string) error {
+	switch action.Type {
+	case domain.ContactBulkActionDelete:
+		return p.eraser.eraseContact(ctx, workspace.ID, email)
+
+	case domain.ContactBulkActionUnsubscribeFromList:
+		return p.contactListRepo.UpdateContactListStatus(listCtx, workspace.ID, email, action.ListID, domain.ContactListStatusUnsubscribed)
+
+	case domain.ContactBulkActionRemoveFromList:
+		return p.contactListRepo.RemoveContactFromList(listCtx, workspace.ID, email, action.ListID)
+
+	case domain.ContactBulkActionUpdateFields:
+		// Contacts are upserted one by one, the bulk upsert cannot clear a field
+		contact, err := action.BuildContact(email)
+		if err != nil {
+			return err
+		}
+		if contact.HasAttributeData() {
+			if err := contact.NormalizeAttributes(workspace.Settings.ContactAttributes); err != nil {
+				return err
+			}
+		}
+		if _, err := p.contactRepo.UpsertContact(ctx, workspace.ID, contact); err != nil {
+			return fmt.Errorf("failed to update contact: %w", err)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("invalid action type: %s", action.Type)
+	}
+}
+
+// fail marks the operation as failed for an error affecting every contact. The task
+// completes as retrying would fail the same way.
+func (p *ContactBulkOperationTaskProcessor) fail(ctx context.Context, task *domain.Task, operation *domain.ContactBulkOperation, message string) (bool, error) {
+	p.logger.WithFields(map[string]interface{}{
+		"task_id":      task.ID,
+		"operation_id": operation.ID,
+		"error":        message,
+	}).Warn("Contact bulk operation failed")
+
+	now := time.Now().UTC()
+	operation.Status = domain.ContactBulkOperationStatusFailed
+	operation.ErrorMessage = &message
+	operation.CompletedAt = &now
+	if err := p.operationRepo.Update(ctx, task.WorkspaceID, operation); err != nil {
+		return false, fmt.Errorf("failed to update contact bulk operation: %w", err)
+	}
+
+	task.State.Message = "Bulk operation failed: " + message
+	return true, nil
+}
+
+// saveProgress persists the operation counters and the task position in the target
+func (p *ContactBulkOperationTaskProcessor) saveProgress(ctx context.Context, task *domain.Task, operation *domain.ContactBulkOperation) error {
+	if err := p.operationRepo.Update(ctx, task.WorkspaceID, operation); err != nil {
+		return fmt.Errorf("failed to update contact bulk operation: %w", err)
+	}
+
+	task.State.Message = fmt.Sprintf("Processing contacts: %d/%d", operation.ProcessedCount, operation.TotalCount)
+	if err := p.taskRepo.SaveState(ctx, task.WorkspaceID, task.ID, task.Progress, task.State); err != nil {
+		return fmt.Errorf("failed to save task state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+)
+
+type fakeContactEraser struct {
+	erased []string
+	errs   map[string]error
+}
+
+func (f *fakeContactEraser) eraseContact(ctx context.Context, workspaceID string, email string) error {
+	if err := f.errs[email]; err != nil {
+		return err
+	}
+	f.erased = append(f.erased, email)
+	return nil
+}
+
+type contactBulkOperationProcessorTest struct {
+	processor       *ContactBulkOperationTaskProcessor
+	operationRepo   *mocks.MockContactBulkOperationRepository
+	workspaceRepo   *mocks.MockWorkspaceRepository
+	contactRepo     *mocks.MockContactRepository
+	contactListRepo *mocks.MockContactListRepository
+	automationRepo  *mocks.MockAutomationRepository
+	taskRepo        *mocks.MockTaskRepository
+	eraser          *fakeContactEraser
+}
+
+func setupContactBulkOperationProcessorTest(t *testing.T) *contactBulkOperationProcessorTest {
+	ctrl := gomock.NewController(t)
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+
+	pt := &contactBulkOperationProcessorTest{
+		operationRepo:   mocks.NewMockContactBulkOperationRepository(ctrl),
+		workspaceRepo:   mocks.NewMockWorkspaceRepository(ctrl),
+		contactRepo:     mocks.NewMockContactRepository(ctrl),
+		contactListRepo: mocks.NewMockContactListRepository(ctrl),
+		automationRepo:  mocks.NewMockAutomationRepository(ctrl),
+		taskRepo:        mocks.NewMockTaskRepository(ctrl),
+		eraser:          &fakeContactEraser{},
+	}
+	pt.processor = NewContactBulkOperationTaskProcessor(pt.operationRepo, pt.workspaceRepo, pt.contactRepo,
+		pt.contactListRepo, pt.automationRepo, pt.taskRepo, nil, mockLogger)
+	pt.processor.eraser = pt.eraser
+	return pt
+}
+
+func contactBulkOperationTestTask(afterEmail string) *domain.Task {
+	return &domain.Task{
+		ID:          "task1",
+		WorkspaceID: "ws1",
+		Type:        "bulk_contact_operation",
+		State: &domain.TaskState{
+			BulkContactOperation: &domain.BulkContactOperationState{OperationID: "op1", AfterEmail: afterEmail},
+		},
+	}
+}
+
+func contactBulkOperationTestOperation(action domain.ContactBulkAction) *domain.ContactBulkOperation {
+	taskID := "task1"
+	return &domain.ContactBulkOperation{
+		ID:         "op1",
+		Target:     domain.ContactBulkTarget{Type: domain.ContactBulkTargetList, ListID: "news"},
+		Action:     action,
+		Status:     domain.ContactBulkOperationStatusPending,
+		TaskID:     &taskID,
+		TotalCount: 3,
+	}
+}
+
+// expectSaves records each saved result batch, counting it as the repository does
+func (pt *contactBulkOperationProcessorTest) expectSaves(saved *[]*domain.ContactBulkOperationResult) {
+	pt.operationRepo.EXPECT().SaveBatch(gomock.Any(), "ws1", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, operation *domain.ContactBulkOperation, results []*domain.ContactBulkOperationResult) error {
+			*saved = append(*saved, results...)
+			for _, result := range results {
+				operation.ProcessedCount++
+				switch result.Status {
+				case domain.ContactBulkResultSucceeded:
+					operation.SucceededCount++
+				case domain.ContactBulkResultSkipped:
+					operation.SkippedCount++
+				case domain.ContactBulkResultFailed:
+					operation.FailedCount++
+				}
+			}
+			return nil
+		}).AnyTimes()
+	pt.operationRepo.EXPECT().Update(gomock.Any(), "ws1", gomock.Any()).Return(nil).AnyTimes()
+	pt.taskRepo.EXPECT().SaveState(gomock.Any(), "ws1", "task1", gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+}
+
+func TestContactBulkOperationTaskProcessor_CanProcess(t *testing.T) {
+	pt := setupContactBulkOperationProcessorTest(t)
+	assert.True(t, pt.processor.CanProcess("bulk_contact_operation"))
+	assert.False(t, pt.processor.CanProcess("import_contacts"))
+}
+
+func TestContactBulkOperationTaskProcessor_Process(t *testing.T) {
+	ctx := context.Background()
+	targetSQL := "SELECT email FROM contact_lists WHERE list_id = $1 AND deleted_at IS NULL"
+	targetArgs := []interface{}{"news"}
+
+	t.Run("walks the target in batches", func(t *testing.T) {
+		pt := setupContactBulkOperationProcessorTest(t)
+		pt.processor.batchSize = 2
+		operation := contactBulkOperationTestOperation(domain.ContactBulkAction{Type: domain.ContactBulkActionDelete})
+		task := contactBulkOperationTestTask("")
+
+		pt.operationRepo.EXPECT().GetByID(ctx, "ws1", "op1").Return(operation, nil)
+		pt.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(&domain.Workspace{ID: "ws1"}, nil)
+		gomock.InOrder(
+			pt.operationRepo.EXPECT().GetTargetEmails(ctx, "ws1", targetSQL, targetArgs, "", 2).Return([]string{"a@example.com", "b@example.com"}, nil),
+			pt.operationRepo.EXPECT().GetTargetEmails(ctx, "ws1", targetSQL, targetArgs, "b@example.com", 2).Return([]string{"c@example.com"}, nil),
+		)
+		pt.eraser.errs = map[string]error{"b@example.com": errors.New("db down")}
+		var saved []*domain.ContactBulkOperationResult
+		pt.expectSaves(&saved)
+
+		completed, err := pt.processor.Process(ctx, task, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, completed)
+
+		assert.Equal(t, []string{"a@example.com", "c@example.com"}, pt.eraser.erased)
+		require.Len(t, saved, 3)
+		// erased addresses are not kept in the result log, failed ones still exist
+		assert.Equal(t, domain.ErasedContactResultEmail("a@example.com"), saved[0].Email)
+		assert.Equal(t, "b@example.com", saved[1].Email)
+		assert.Equal(t, domain.ContactBulkResultFailed, saved[1].Status)
+		assert.Equal(t, "db down", saved[1].Error)
+		assert.Equal(t, domain.ErasedContactResultEmail("c@example.com"), saved[2].Email)
+		assert.Equal(t, domain.ContactBulkOperationStatusCompleted, operation.Status)
+		assert.Equal(t, 3, operation.ProcessedCount)
+		assert.Equal(t, 2, operation.SucceededCount)
+		assert.Equal(t, 1, operation.FailedCount)
+		assert.Equal(t, "c@example.com", task.State.BulkContactOperation.AfterEmail)
+		assert.Equal(t, float64(1), task.Progress)
+	})
+
+	t.Run("resumes after the last processed email and pauses on timeout", func(t *testing.T) {
+		pt := setupContactBulkOperationProcessorTest(t)
+		operation := contactBulkOperationTestOperation(domain.ContactBulkAction{Type: domain.ContactBulkActionDelete})
+		operation.Status = domain.ContactBulkOperationStatusRunning
+		task := contactBulkOperationTestTask("b@example.com")
+
+		pt.operationRepo.EXPECT().GetByID(ctx, "ws1", "op1").Return(operation, nil)
+		pt.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(&domain.Workspace{ID: "ws1"}, nil)
+		var saved []*domain.ContactBulkOperationResult
+		pt.expectSaves(&saved)
+
+		completed, err := pt.processor.Process(ctx, task, time.Now().Add(5*time.Second))
+		require.NoError(t, err)
+		assert.False(t, completed)
+		assert.Equal(t, "b@example.com", task.State.BulkContactOperation.AfterEmail)
+		assert.Empty(t, saved)
+	})
+
+	t.Run("stops once cancelled", func(t *testing.T) {
+		pt := setupContactBulkOperationProcessorTest(t)
+		pt.processor.batchSize = 1
+		operation := contactBulkOperationTestOperation(domain.ContactBulkAction{Type: domain.ContactBulkActionDelete})
+		task := contactBulkOperationTestTask("")
+
+		pt.operationRepo.EXPECT().GetByID(ctx, "ws1", "op1").Return(operation, nil)
+		pt.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(&domain.Workspace{ID: "ws1"}, nil)
+		pt.operationRepo.EXPECT().GetTargetEmails(ctx, "ws1", targetSQL, targetArgs, "", 1).Return([]string{"a@example.com"}, nil)
+		pt.operationRepo.EXPECT().SaveBatch(gomock.Any(), "ws1", operation, gomock.Any()).Return(nil)
+		pt.operationRepo.EXPECT().Update(gomock.Any(), "ws1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, operation *domain.ContactBulkOperation) error {
+				operation.Status = domain.ContactBulkOperationStatusCancelled
+				return nil
+			})
+		pt.taskRepo.EXPECT().SaveState(gomock.Any(), "ws1", "task1", gomock.Any(), gomock.Any()).Return(nil)
+
+		completed, err := pt.processor.Process(ctx, task, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, completed)
+		assert.Equal(t, []string{"a@example.com"}, pt.eraser.erased)
+		assert.Contains(t, task.State.Message, "cancelled")
+	})
+
+	t.Run("finished operations are not processed", func(t *testing.T) {
+		pt := setupContactBulkOperationProcessorTest(t)
+		operation := contactBulkOperationTestOperation(domain.ContactBulkAction{Type: domain.ContactBulkActionDelete})
+		operation.Status = domain.ContactBulkOperationStatusCancelled
+
+		pt.operationRepo.EXPECT().GetByID(ctx, "ws1", "op1").Return(operation, nil)
+
+		completed, err := pt.processor.Process(ctx, contactBulkOperationTestTask(""), time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, completed)
+	})
+
+	t.Run("missing state", func(t *testing.T) {
+		pt := setupContactBulkOperationProcessorTest(t)
+
+		_, err := pt.processor.Process(ctx, &domain.Task{ID: "task1", State: &domain.TaskState{}}, time.Now().Add(time.Minute))
+		assert.Error(t, err)
+	})
+}
+
+func TestContactBulkOperationTaskProcessor_processBatch(t *testing.T) {
+	ctx := context.Background()
+	workspace := &domain.Workspace{ID: "ws1"}
+	emails := []string{"a@example.com", "b@example.com"}
+
+	t.Run("add_to_list records the consent source", func(t *testing.T) {
+		pt := setupContactBulkOperationProcessorTest(t)
+		operation := contactBulkOperationTestOperation(domain.ContactBulkAction{
+			Type: domain.ContactBulkActionAddToList, ListID: "vip", ListStatus: domain.ContactListStatusActive,
+		})
+
+		pt.contactListRepo.EXPECT().BulkAddContactsToLists(gomock.Any(), "ws1", emails, []string{"vip"}, domain.ContactListStatusActive).
+			DoAndReturn(func(ctx context.Context, _ string, _, _ []string, _ domain.ContactListStatus) error {
+				consent := domain.ConsentContextFromContext(ctx)
+				assert.Equal(t, domain.ConsentSourceBulkOperation, consent.Source)
+				assert.Equal(t, "op1", consent.SourceID)
+				return nil
+			})
+
+		results, err := pt.processor.processBatch(ctx, workspace, operation, emails)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, domain.ContactBulkResultSucceeded, results[1].Status)
+	})
+
+	t.Run("unsubscribe skips contacts not on the list", func(t *testing.T) {
+		pt := setupContactBulkOperationProcessorTest(t)
+		operation := contactBulkOperationTestOperation(domain.ContactBulkAction{Type: domain.ContactBulkActionUnsubscribeFromList, ListID: "vip"})
+
+		pt.contactListRepo.EXPECT().UpdateContactListStatus(gomock.Any(), "ws1", "a@example.com", "vip", domain.ContactListStatusUnsubscribed).Return(nil)
+		pt.contactListRepo.EXPECT().UpdateContactListStatus(gomock.Any(), "ws1", "b@example.com", "vip", domain.ContactListStatusUnsubscribed).
+			Return(&domain.ErrContactListNotFound{Message: "contact list not found"})
+
+		results, err := pt.processor.processBatch(ctx, workspace, operation, emails)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContactBulkResultSucceeded, results[0].Status)
+		assert.Equal(t, domain.ContactBulkResultSkipped, results[1].Status)
+	})
+
+	t.Run("remove_from_list", func(t *testing.T) {
+		pt := setupContactBulkOperationProcessorTest(t)
+		operation := contactBulkOperationTestOperation(domain.ContactBulkAction{Type: domain.ContactBulkActionRemoveFromList, ListID: "vip"})
+
+		pt.contactListRepo.EXPECT().RemoveContactFromList(gomock.Any(), "ws1", gomock.Any(), "vip").Return(nil).Times(2)
+
+		results, err := pt.processor.processBatch(ctx, workspace, operation, emails)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContactBulkResultSucceeded, results[0].Status)
+		assert.Equal(t, domain.ContactBulkResultSucceeded, results[1].Status)
+	})
+
+	t.Run("update_fields upserts each contact", func(t *testing.T) {
+		pt := setupContactBulkOperationProcessorTest(t)
+		operation := contactBulkOperationTestOperation(domain.ContactBulkAction{
+			Type: domain.ContactBulkActionUpdateFields, Fields: json.RawMessage(`{"country":"FR","job_title":null}`),
+		})
+
+		pt.contactRepo.EXPECT().UpsertContact(ctx, "ws1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, contact *domain.Contact) (bool, error) {
+				assert.Equal(t, "FR", contact.Country.String)
+				assert.True(t, contact.JobTitle.IsNull)
+				return false, nil
+			}).Times(2)
+
+		results, err := pt.processor.processBatch(ctx, workspace, operation, emails)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContactBulkResultSucceeded, results[0].Status)
+	})
+
+	t.Run("exit_automation skips contacts without an active journey", func(t *testing.T) {
+		pt := setupContactBulkOperationProcessorTest(t)
+		operation := contactBulkOperationTestOperation(domain.ContactBulkAction{Type: domain.ContactBulkActionExitAutomation, AutomationID: "auto1"})
+
+		pt.automationRepo.EXPECT().ExitContactJourneys(ctx, "ws1", "auto1", emails, domain.ContactBulkExitReason).
+			Return([]string{"b@example.com"}, nil)
+
+		results, err := pt.processor.processBatch(ctx, workspace, operation, emails)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContactBulkResultSkipped, results[0].Status)
+		assert.Equal(t, domain.ContactBulkResultSucceeded, results[1].Status)
+	})
+}
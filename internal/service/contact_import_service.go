@@ -0,0 +1,424 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/Notifuse/notifuse/pkg/spreadsheet"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/google/uuid"
+)
+
+// ContactImportFileFetcher reads import files from the workspace file manager bucket
+type ContactImportFileFetcher interface {
+	Fetch(ctx context.Context, settings domain.FileManagerSettings, key string) ([]byte, error)
+}
+
+// s3FileFetcher reads objects with the S3 API, which every supported file manager provider exposes
+type s3FileFetcher struct{}
+
+// NewS3FileFetcher creates a fetcher reading files from S3-compatible storage
+func NewS3FileFetcher() ContactImportFileFetcher {
+	return &s3FileFetcher{}
+}
+
+func (f *s3FileFetcher) Fetch(ctx context.Context, settings domain.FileManagerSettings, key string) ([]byte, error) {
+	if settings.Endpoint == "" || settings.Bucket == "" {
+		return nil, domain.NewValidationError("the workspace file manager is not configured")
+	}
+
+	region := "us-east-1"
+	if settings.Region != nil && *settings.Region != "" {
+		region = *settings.Region
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(settings.Endpoint),
+		Region:           aws.String(region),
+		Credentials:      credentials.NewStaticCredentials(settings.AccessKey, settings.SecretKey, ""),
+		S3ForcePathStyle: aws.Bool(settings.ForcePathStyle),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 session: %w", err)
+	}
+
+	output, err := s3.New(sess).GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(settings.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s from the file manager bucket: %w", key, err)
+	}
+	defer func() { _ = output.Body.Close() }()
+
+	if output.ContentLength != nil && *output.ContentLength > domain.MaxContactImportFileSize {
+		return nil, domain.NewValidationError(fmt.Sprintf("file exceeds the maximum size of %d MB", domain.MaxContactImportFileSize>>20))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(output.Body, domain.MaxContactImportFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	if len(data) > domain.MaxContactImportFileSize {
+		return nil, domain.NewValidationError(fmt.Sprintf("file exceeds the maximum size of %d MB", domain.MaxContactImportFileSize>>20))
+	}
+	return data, nil
+}
+
+// openContactImportFile detects the format of a file and reads its header. Empty
+// headers are named after their position, duplicate headers are rejected as a column
+// could not be mapped unambiguously.
+func openContactImportFile(fileName string, data []byte) (spreadsheet.Format, spreadsheet.Reader, []string, error) {
+	format, err := spreadsheet.DetectFormat(fileName, data)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	reader, err := spreadsheet.NewReader(format, data)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	columns, err := spreadsheet.ReadHeader(reader)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	seen := make(map[string]bool, len(columns))
+	for i, column := range columns {
+		if column == "" {
+			column = "Column " + strconv.Itoa(i+1)
+			columns[i] = column
+		}
+		if seen[column] {
+			return "", nil, nil, fmt.Errorf("duplicate column %q in the header row", column)
+		}
+		seen[column] = true
+	}
+
+	return format, reader, columns, nil
+}
+
+type ContactImportService struct {
+	repo          domain.ContactImportRepository
+	workspaceRepo domain.WorkspaceRepository
+	listRepo      domain.ListRepository
+	taskService   domain.TaskService
+	authService   domain.AuthService
+	fileFetcher   ContactImportFileFetcher
+	logger        logger.Logger
+}
+
+func NewContactImportService(
+	repo domain.ContactImportRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	listRepo domain.ListRepository,
+	taskService domain.TaskService,
+	authService domain.AuthService,
+	fileFetcher ContactImportFileFetcher,
+	logger logger.Logger,
+) *ContactImportService {
+	return &ContactImportService{
+		repo:          repo,
+		workspaceRepo: workspaceRepo,
+		listRepo:      listRepo,
+		taskService:   taskService,
+		authService:   authService,
+		fileFetcher:   fileFetcher,
+		logger:        logger,
+	}
+}
+
+// authorize authenticates the user and checks their access to contacts
+func (s *ContactImportService) authorize(ctx context.Context, workspaceID string, permission domain.PermissionType) (context.Context, *domain.UserWorkspace, error) {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceContacts, permission) {
+		return nil, nil, domain.NewPermissionError(
+			domain.PermissionResourceContacts,
+			permission,
+			fmt.Sprintf("Insufficient permissions: %s access to contacts required", permission),
+		)
+	}
+	return ctx, userWorkspace, nil
+}
+
+// CreateContactImport reads the columns of a file, counts its rows and suggests a mapping
+func (s *ContactImportService) CreateContactImport(ctx context.Context, req *domain.CreateContactImportRequest) (*domain.ContactImport, error) {
+	ctx, _, err := s.authorize(ctx, req.WorkspaceID, domain.PermissionTypeWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, domain.NewValidationError(err.Error())
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	contactImport := &domain.ContactImport{
+		ID:       uuid.New().String(),
+		FileName: req.FileName,
+		Source:   domain.ContactImportSourceUpload,
+		Status:   domain.ContactImportStatusPending,
+		ListIDs:  []string{},
+		Errors:   domain.ContactImportRowErrors{},
+	}
+
+	data := req.FileData
+	if req.S3Key != "" {
+		data, err = s.fileFetcher.Fetch(ctx, workspace.Settings.FileManager, req.S3Key)
+		if err != nil {
+			return nil, err
+		}
+		contactImport.Source = domain.ContactImportSourceS3
+		contactImport.S3Key = req.S3Key
+	} else {
+		contactImport.FileData = data
+	}
+	contactImport.FileSize = int64(len(data))
+
+	format, reader, columns, err := openContactImportFile(req.FileName, data)
+	if err != nil {
+		return nil, domain.NewValidationError(err.Error())
+	}
+	contactImport.Format = string(format)
+	contactImport.Columns = columns
+
+	preview := [][]string{}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, domain.NewValidationError(err.Error())
+		}
+		contactImport.TotalRows++
+		if len(preview) < domain.ContactImportPreviewRows {
+			preview = append(preview, row)
+		}
+	}
+	if contactImport.TotalRows == 0 {
+		return nil, domain.NewValidationError("file has no rows besides the header")
+	}
+	contactImport.SetPreview(preview)
+	contactImport.Mapping = domain.SuggestContactImportMapping(columns, workspace.Settings.ContactAttributes)
+
+	if err := s.repo.Create(ctx, req.WorkspaceID, contactImport); err != nil {
+		s.logger.WithField("import_id", contactImport.ID).Error(fmt.Sprintf("Failed to create contact import: %v", err))
+		return nil, fmt.Errorf("failed to create contact import: %w", err)
+	}
+
+	return contactImport, nil
+}
+
+// StartContactImport checks the import options and starts the import_contacts task.
+// Dry runs can be repeated until the file is imported.
+func (s *ContactImportService) StartContactImport(ctx context.Context, req *domain.StartContactImportRequest) (*domain.ContactImport, error) {
+	ctx, userWorkspace, err := s.authorize(ctx, req.WorkspaceID, domain.PermissionTypeWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, domain.NewValidationError(err.Error())
+	}
+
+	if len(req.ListIDs) > 0 && !req.DryRun && !userWorkspace.HasPermission(domain.PermissionResourceLists, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceLists,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to lists required",
+		)
+	}
+
+	contactImport, err := s.repo.GetByID(ctx, req.WorkspaceID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case contactImport.Status.IsRunning():
+		return nil, domain.NewValidationError("the import is already running")
+	case contactImport.Status == domain.ContactImportStatusCompleted:
+		return nil, domain.NewValidationError("the file has already been imported")
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	mapping := domain.ContactImportMapping{}
+	for column, field := range req.Mapping {
+		if field != "" {
+			mapping[column] = field
+		}
+	}
+	if err := domain.ValidateContactImportMapping(mapping, contactImport.Columns, workspace.Settings.ContactAttributes); err != nil {
+		return nil, domain.NewValidationError(err.Error())
+	}
+
+	for _, listID := range req.ListIDs {
+		if _, err := s.listRepo.GetListByID(ctx, req.WorkspaceID, listID); err != nil {
+			var notFound *domain.ErrListNotFound
+			if errors.As(err, &notFound) {
+				return nil, domain.NewValidationError(fmt.Sprintf("list %s not found", listID))
+			}
+			return nil, fmt.Errorf("failed to get list: %w", err)
+		}
+	}
+
+	listIDs := req.ListIDs
+	if listIDs == nil {
+		listIDs = []string{}
+	}
+
+	task := &domain.Task{
+		ID:          uuid.New().String(),
+		WorkspaceID: req.WorkspaceID,
+		Type:        "import_contacts",
+		Status:      domain.TaskStatusPending,
+		State: &domain.TaskState{
+			ImportContacts: &domain.ImportContactsState{
+				ImportID: contactImport.ID,
+				DryRun:   req.DryRun,
+			},
+		},
+		MaxRuntime:    300, // 5 minutes per run, the task resumes from its row offset
+		MaxRetries:    3,
+		RetryInterval: 60,
+	}
+
+	contactImport.Mapping = mapping
+	contactImport.ListIDs = listIDs
+	contactImport.ListStatus = req.ListStatus
+	contactImport.DedupeStrategy = req.DedupeStrategy
+	contactImport.DryRun = req.DryRun
+	contactImport.TaskID = &task.ID
+	contactImport.Status = domain.ContactImportStatusImporting
+	if req.DryRun {
+		contactImport.Status = domain.ContactImportStatusValidating
+	}
+	contactImport.ResetProgress()
+
+	if err := s.repo.Update(ctx, req.WorkspaceID, contactImport); err != nil {
+		s.logger.WithField("import_id", contactImport.ID).Error(fmt.Sprintf("Failed to update contact import: %v", err))
+		return nil, fmt.Errorf("failed to update contact import: %w", err)
+	}
+
+	if err := s.taskService.CreateTask(ctx, req.WorkspaceID, task); err != nil {
+		s.logger.WithField("import_id", contactImport.ID).Error(fmt.Sprintf("Failed to create import task: %v", err))
+
+		message := "failed to start the import task"
+		contactImport.Status = domain.ContactImportStatusFailed
+		contactImport.ErrorMessage = &message
+		if updateErr := s.repo.Update(ctx, req.WorkspaceID, contactImport); updateErr != nil {
+			s.logger.WithField("import_id", contactImport.ID).Error(fmt.Sprintf("Failed to update contact import: %v", updateErr))
+		}
+		return nil, fmt.Errorf("failed to create import task: %w", err)
+	}
+
+	// Immediately trigger execution of the import task
+	go func() {
+		// Small delay to ensure transaction is committed
+		time.Sleep(100 * time.Millisecond)
+		timeoutAt := time.Now().Add(time.Duration(task.MaxRuntime) * time.Second)
+		if execErr := s.taskService.ExecuteTask(context.Background(), req.WorkspaceID, task.ID, timeoutAt); execErr != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"import_id": contactImport.ID,
+				"task_id":   task.ID,
+				"error":     execErr.Error(),
+			}).Warn("Failed to immediately execute import task, will be picked up by next cron run")
+		}
+	}()
+
+	return contactImport, nil
+}
+
+func (s *ContactImportService) GetContactImport(ctx context.Context, workspaceID string, id string) (*domain.ContactImport, error) {
+	ctx, _, err := s.authorize(ctx, workspaceID, domain.PermissionTypeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, workspaceID, id)
+}
+
+func (s *ContactImportService) ListContactImports(ctx context.Context, workspaceID string) ([]*domain.ContactImport, error) {
+	ctx, _, err := s.authorize(ctx, workspaceID, domain.PermissionTypeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err := s.repo.List(ctx, workspaceID)
+	if err != nil {
+		s.logger.WithField("workspace_id", workspaceID).Error(fmt.Sprintf("Failed to list contact imports: %v", err))
+		return nil, fmt.Errorf("failed to list contact imports: %w", err)
+	}
+	return imports, nil
+}
+
+func (s *ContactImportService) DeleteContactImport(ctx context.Context, workspaceID string, id string) error {
+	ctx, _, err := s.authorize(ctx, workspaceID, domain.PermissionTypeWrite)
+	if err != nil {
+		return err
+	}
+
+	contactImport, err := s.repo.GetByID(ctx, workspaceID, id)
+	if err != nil {
+		return err
+	}
+	if contactImport.Status.IsRunning() {
+		return domain.NewValidationError("a running import cannot be deleted")
+	}
+
+	return s.repo.Delete(ctx, workspaceID, id)
+}
+
+// GetContactImportErrorFile returns the row errors of the last run as CSV
+func (s *ContactImportService) GetContactImportErrorFile(ctx context.Context, workspaceID string, id string) ([]byte, error) {
+	ctx, _, err := s.authorize(ctx, workspaceID, domain.PermissionTypeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	contactImport, err := s.repo.GetByID(ctx, workspaceID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"row", "email", "error"})
+	for _, rowErr := range contactImport.Errors {
+		_ = w.Write([]string{strconv.Itoa(rowErr.Row), rowErr.Email, rowErr.Error})
+	}
+	if omitted := contactImport.FailedCount - len(contactImport.Errors); omitted > 0 {
+		_ = w.Write([]string{"", "", fmt.Sprintf("%d more rows failed, only the first %d errors are kept", omitted, domain.MaxContactImportErrors)})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write error file: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,373 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+type fakeContactImportFileFetcher struct {
+	data []byte
+	err  error
+	key  string
+}
+
+func (f *fakeContactImportFileFetcher) Fetch(ctx context.Context, settings domain.FileManagerSettings, key string) ([]byte, error) {
+	f.key = key
+	return f.data, f.err
+}
+
+type contactImportServiceTest struct {
+	service       *ContactImportService
+	repo          *mocks.MockContactImportRepository
+	workspaceRepo *mocks.MockWorkspaceRepository
+	listRepo      *mocks.MockListRepository
+	taskService   *mocks.MockTaskService
+	authService   *mocks.MockAuthService
+	fetcher       *fakeContactImportFileFetcher
+}
+
+func setupContactImportServiceTest(t *testing.T) *contactImportServiceTest {
+	ctrl := gomock.NewController(t)
+
+	st := &contactImportServiceTest{
+		repo:          mocks.NewMockContactImportRepository(ctrl),
+		workspaceRepo: mocks.NewMockWorkspaceRepository(ctrl),
+		listRepo:      mocks.NewMockListRepository(ctrl),
+		taskService:   mocks.NewMockTaskService(ctrl),
+		authService:   mocks.NewMockAuthService(ctrl),
+		fetcher:       &fakeContactImportFileFetcher{},
+	}
+	st.service = NewContactImportService(st.repo, st.workspaceRepo, st.listRepo, st.taskService, st.authService,
+		st.fetcher, logger.NewLogger())
+	return st
+}
+
+func contactImportTestWorkspace() *domain.Workspace {
+	return &domain.Workspace{
+		ID: "ws1",
+		Settings: domain.WorkspaceSettings{
+			ContactAttributes: []domain.ContactAttribute{{Key: "plan", Type: domain.ContactAttributeTypeString}},
+		},
+	}
+}
+
+func contactImportTestUserWorkspace(contactsWrite, listsWrite bool) *domain.UserWorkspace {
+	return &domain.UserWorkspace{
+		UserID:      "user1",
+		WorkspaceID: "ws1",
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceContacts: {Read: true, Write: contactsWrite},
+			domain.PermissionResourceLists:    {Read: true, Write: listsWrite},
+		},
+	}
+}
+
+func TestContactImportService_CreateContactImport(t *testing.T) {
+	ctx := context.Background()
+	csvData := []byte("Email;First Name;Plan\njohn@example.com;John;pro\n\njane@example.com;Jane;free\n")
+
+	t.Run("upload", func(t *testing.T) {
+		st := setupContactImportServiceTest(t)
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactImportTestUserWorkspace(true, true), nil)
+		st.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+		st.repo.EXPECT().Create(ctx, "ws1", gomock.Any()).Return(nil)
+
+		contactImport, err := st.service.CreateContactImport(ctx, &domain.CreateContactImportRequest{
+			WorkspaceID: "ws1",
+			FileName:    "contacts.csv",
+			FileData:    csvData,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "csv", contactImport.Format)
+		assert.Equal(t, domain.ContactImportSourceUpload, contactImport.Source)
+		assert.Equal(t, []string{"Email", "First Name", "Plan"}, contactImport.Columns)
+		assert.Equal(t, 2, contactImport.TotalRows)
+		assert.Len(t, contactImport.Preview, 2)
+		assert.Equal(t, domain.ContactImportMapping{"Email": "email", "First Name": "first_name", "Plan": "attributes.plan"}, contactImport.Mapping)
+		assert.Equal(t, domain.ContactImportStatusPending, contactImport.Status)
+		assert.Equal(t, csvData, contactImport.FileData)
+	})
+
+	t.Run("file from the workspace bucket", func(t *testing.T) {
+		st := setupContactImportServiceTest(t)
+		st.fetcher.data = csvData
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactImportTestUserWorkspace(true, true), nil)
+		st.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+		st.repo.EXPECT().Create(ctx, "ws1", gomock.Any()).Return(nil)
+
+		contactImport, err := st.service.CreateContactImport(ctx, &domain.CreateContactImportRequest{
+			WorkspaceID: "ws1",
+			S3Key:       "imports/contacts.csv",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "imports/contacts.csv", st.fetcher.key)
+		assert.Equal(t, domain.ContactImportSourceS3, contactImport.Source)
+		assert.Equal(t, "contacts.csv", contactImport.FileName)
+		assert.Nil(t, contactImport.FileData)
+	})
+
+	t.Run("file without rows", func(t *testing.T) {
+		st := setupContactImportServiceTest(t)
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactImportTestUserWorkspace(true, true), nil)
+		st.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+
+		_, err := st.service.CreateContactImport(ctx, &domain.CreateContactImportRequest{
+			WorkspaceID: "ws1",
+			FileName:    "contacts.csv",
+			FileData:    []byte("email,name\n"),
+		})
+		var validationErr domain.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, err.Error(), "no rows")
+	})
+
+	t.Run("duplicate columns", func(t *testing.T) {
+		st := setupContactImportServiceTest(t)
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactImportTestUserWorkspace(true, true), nil)
+		st.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+
+		_, err := st.service.CreateContactImport(ctx, &domain.CreateContactImportRequest{
+			WorkspaceID: "ws1",
+			FileName:    "contacts.csv",
+			FileData:    []byte("email,email\njohn@example.com,john@example.com\n"),
+		})
+		var validationErr domain.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("insufficient permissions", func(t *testing.T) {
+		st := setupContactImportServiceTest(t)
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactImportTestUserWorkspace(false, false), nil)
+
+		_, err := st.service.CreateContactImport(ctx, &domain.CreateContactImportRequest{
+			WorkspaceID: "ws1",
+			FileName:    "contacts.csv",
+			FileData:    csvData,
+		})
+		var permErr *domain.PermissionError
+		require.ErrorAs(t, err, &permErr)
+	})
+}
+
+func TestContactImportService_StartContactImport(t *testing.T) {
+	ctx := context.Background()
+
+	pendingImport := func() *domain.ContactImport {
+		return &domain.ContactImport{
+			ID:      "import1",
+			Columns: []string{"Email", "Name"},
+			Status:  domain.ContactImportStatusPending,
+		}
+	}
+
+	t.Run("starts the import task", func(t *testing.T) {
+		st := setupContactImportServiceTest(t)
+		executed := make(chan string, 1)
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactImportTestUserWorkspace(true, true), nil)
+		st.repo.EXPECT().GetByID(ctx, "ws1", "import1").Return(pendingImport(), nil)
+		st.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+		st.listRepo.EXPECT().GetListByID(ctx, "ws1", "news").Return(&domain.List{ID: "news"}, nil)
+		st.repo.EXPECT().Update(ctx, "ws1", gomock.Any()).Return(nil)
+
+		var createdTask *domain.Task
+		st.taskService.EXPECT().CreateTask(ctx, "ws1", gomock.Any()).DoAndReturn(func(ctx context.Context, workspaceID string, task *domain.Task) error {
+			createdTask = task
+			return nil
+		})
+		st.taskService.EXPECT().ExecuteTask(gomock.Any(), "ws1", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, taskID string, timeoutAt interface{}) error {
+				executed <- taskID
+				return nil
+			})
+
+		contactImport, err := st.service.StartContactImport(ctx, &domain.StartContactImportRequest{
+			WorkspaceID: "ws1",
+			ID:          "import1",
+			Mapping:     domain.ContactImportMapping{"Email": "email", "Name": ""},
+			ListIDs:     []string{"news"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContactImportStatusImporting, contactImport.Status)
+		assert.Equal(t, domain.ContactImportMapping{"Email": "email"}, contactImport.Mapping)
+		assert.Equal(t, domain.ContactImportDedupeOverwrite, contactImport.DedupeStrategy)
+		assert.Equal(t, domain.ContactListStatusActive, contactImport.ListStatus)
+
+		require.NotNil(t, createdTask)
+		assert.Equal(t, "import_contacts", createdTask.Type)
+		assert.Equal(t, "import1", createdTask.State.ImportContacts.ImportID)
+		assert.Equal(t, createdTask.ID, *contactImport.TaskID)
+		assert.Equal(t, createdTask.ID, <-executed)
+	})
+
+	t.Run("dry run does not require list permissions", func(t *testing.T) {
+		st := setupContactImportServiceTest(t)
+		executed := make(chan struct{})
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactImportTestUserWorkspace(true, false), nil)
+		st.repo.EXPECT().GetByID(ctx, "ws1", "import1").Return(pendingImport(), nil)
+		st.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+		st.listRepo.EXPECT().GetListByID(ctx, "ws1", "news").Return(&domain.List{ID: "news"}, nil)
+		st.repo.EXPECT().Update(ctx, "ws1", gomock.Any()).Return(nil)
+		st.taskService.EXPECT().CreateTask(ctx, "ws1", gomock.Any()).Return(nil)
+		st.taskService.EXPECT().ExecuteTask(gomock.Any(), "ws1", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, taskID string, timeoutAt interface{}) error {
+				close(executed)
+				return nil
+			})
+
+		contactImport, err := st.service.StartContactImport(ctx, &domain.StartContactImportRequest{
+			WorkspaceID: "ws1",
+			ID:          "import1",
+			Mapping:     domain.ContactImportMapping{"Email": "email"},
+			ListIDs:     []string{"news"},
+			DryRun:      true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.ContactImportStatusValidating, contactImport.Status)
+		assert.True(t, contactImport.DryRun)
+		<-executed
+	})
+
+	t.Run("list subscriptions require list permissions", func(t *testing.T) {
+		st := setupContactImportServiceTest(t)
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactImportTestUserWorkspace(true, false), nil)
+
+		_, err := st.service.StartContactImport(ctx, &domain.StartContactImportRequest{
+			WorkspaceID: "ws1",
+			ID:          "import1",
+			Mapping:     domain.ContactImportMapping{"Email": "email"},
+			ListIDs:     []string{"news"},
+		})
+		var permErr *domain.PermissionError
+		require.ErrorAs(t, err, &permErr)
+	})
+
+	t.Run("completed import cannot be started again", func(t *testing.T) {
+		st := setupContactImportServiceTest(t)
+		completed := pendingImport()
+		completed.Status = domain.ContactImportStatusCompleted
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactImportTestUserWorkspace(true, true), nil)
+		st.repo.EXPECT().GetByID(ctx, "ws1", "import1").Return(completed, nil)
+
+		_, err := st.service.StartContactImport(ctx, &domain.StartContactImportRequest{
+			WorkspaceID: "ws1",
+			ID:          "import1",
+			Mapping:     domain.ContactImportMapping{"Email": "email"},
+		})
+		var validationErr domain.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("email must be mapped", func(t *testing.T) {
+		st := setupContactImportServiceTest(t)
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactImportTestUserWorkspace(true, true), nil)
+		st.repo.EXPECT().GetByID(ctx, "ws1", "import1").Return(pendingImport(), nil)
+		st.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+
+		_, err := st.service.StartContactImport(ctx, &domain.StartContactImportRequest{
+			WorkspaceID: "ws1",
+			ID:          "import1",
+			Mapping:     domain.ContactImportMapping{"Name": "first_name"},
+		})
+		var validationErr domain.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, err.Error(), "email")
+	})
+
+	t.Run("unknown list", func(t *testing.T) {
+		st := setupContactImportServiceTest(t)
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactImportTestUserWorkspace(true, true), nil)
+		st.repo.EXPECT().GetByID(ctx, "ws1", "import1").Return(pendingImport(), nil)
+		st.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+		st.listRepo.EXPECT().GetListByID(ctx, "ws1", "missing").Return(nil, &domain.ErrListNotFound{Message: "list not found"})
+
+		_, err := st.service.StartContactImport(ctx, &domain.StartContactImportRequest{
+			WorkspaceID: "ws1",
+			ID:          "import1",
+			Mapping:     domain.ContactImportMapping{"Email": "email"},
+			ListIDs:     []string{"missing"},
+		})
+		var validationErr domain.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("task creation failure marks the import failed", func(t *testing.T) {
+		st := setupContactImportServiceTest(t)
+		contactImport := pendingImport()
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactImportTestUserWorkspace(true, true), nil)
+		st.repo.EXPECT().GetByID(ctx, "ws1", "import1").Return(contactImport, nil)
+		st.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+		st.repo.EXPECT().Update(ctx, "ws1", contactImport).Return(nil).Times(2)
+		st.taskService.EXPECT().CreateTask(ctx, "ws1", gomock.Any()).Return(errors.New("db down"))
+
+		_, err := st.service.StartContactImport(ctx, &domain.StartContactImportRequest{
+			WorkspaceID: "ws1",
+			ID:          "import1",
+			Mapping:     domain.ContactImportMapping{"Email": "email"},
+		})
+		require.Error(t, err)
+		assert.Equal(t, domain.ContactImportStatusFailed, contactImport.Status)
+	})
+}
+
+func TestContactImportService_DeleteContactImport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		st := setupContactImportServiceTest(t)
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactImportTestUserWorkspace(true, true), nil)
+		st.repo.EXPECT().GetByID(ctx, "ws1", "import1").Return(&domain.ContactImport{ID: "import1", Status: domain.ContactImportStatusCompleted}, nil)
+		st.repo.EXPECT().Delete(ctx, "ws1", "import1").Return(nil)
+
+		require.NoError(t, st.service.DeleteContactImport(ctx, "ws1", "import1"))
+	})
+
+	t.Run("running import", func(t *testing.T) {
+		st := setupContactImportServiceTest(t)
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactImportTestUserWorkspace(true, true), nil)
+		st.repo.EXPECT().GetByID(ctx, "ws1", "import1").Return(&domain.ContactImport{ID: "import1", Status: domain.ContactImportStatusImporting}, nil)
+
+		err := st.service.DeleteContactImport(ctx, "ws1", "import1")
+		var validationErr domain.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+	})
+}
+
+func TestContactImportService_GetContactImportErrorFile(t *testing.T) {
+	ctx := context.Background()
+	st := setupContactImportServiceTest(t)
+
+	contactImport := &domain.ContactImport{ID: "import1"}
+	contactImport.AddRowError(3, "john@", "invalid email format")
+	contactImport.AddRowError(7, "", "email is required")
+
+	st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, contactImportTestUserWorkspace(false, false), nil)
+	st.repo.EXPECT().GetByID(ctx, "ws1", "import1").Return(contactImport, nil)
+
+	data, err := st.service.GetContactImportErrorFile(ctx, "ws1", "import1")
+	require.NoError(t, err)
+	assert.Equal(t, "row,email,error\n3,john@,invalid email format\n7,,email is required\n", string(data))
+}
@@ -0,0 +1,372 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// ContactImportTaskProcessor imports the rows of a contact file in batches. The task
+// resumes from its row offset when it runs out of time, and the import record holds
+// the counters and row errors shown to the user.
+type ContactImportTaskProcessor struct {
+	importRepo               domain.ContactImportRepository
+	workspaceRepo            domain.WorkspaceRepository
+	contactRepo              domain.ContactRepository
+	contactListRepo          domain.ContactListRepository
+	taskRepo                 domain.TaskRepository
+	fileFetcher              ContactImportFileFetcher
+	emailVerificationService domain.EmailVerificationService
+	logger                   logger.Logger
+	batchSize                int
+}
+
+// NewContactImportTaskProcessor creates a new contact import task processor
+func NewContactImportTaskProcessor(
+	importRepo domain.ContactImportRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	contactRepo domain.ContactRepository,
+	contactListRepo domain.ContactListRepository,
+	taskRepo domain.TaskRepository,
+	fileFetcher ContactImportFileFetcher,
+	logger logger.Logger,
+) *ContactImportTaskProcessor {
+	return &ContactImportTaskProcessor{
+		importRepo:      importRepo,
+		workspaceRepo:   workspaceRepo,
+		contactRepo:     contactRepo,
+		contactListRepo: contactListRepo,
+		taskRepo:        taskRepo,
+		fileFetcher:     fileFetcher,
+		logger:          logger,
+		batchSize:       domain.BulkImportChunkSize,
+	}
+}
+
+// SetEmailVerificationService sets the service verifying imported addresses
+func (p *ContactImportTaskProcessor) SetEmailVerificationService(emailVerificationService domain.EmailVerificationService) {
+	p.emailVerificationService = emailVerificationService
+}
+
+// CanProcess returns whether this processor can handle the given task type
+func (p *ContactImportTaskProcessor) CanProcess(taskType string) bool {
+	return taskType == "import_contacts"
+}
+
+// importRow is a data row of the file with its line number
+type importRow struct {
+	line   int
+	values []string
+}
+
+// Process imports the next batches of rows until the end of the file or the timeout approaches
+func (p *ContactImportTaskProcessor) Process(ctx context.Context, task *domain.Task, timeoutAt time.Time) (bool, error) {
+	if task.State == nil || task.State.ImportContacts == nil {
+		return false, fmt.Errorf("import contacts state is missing")
+	}
+	state := task.State.ImportContacts
+
+	contactImport, err := p.importRepo.GetByID(ctx, task.WorkspaceID, state.ImportID)
+	if err != nil {
+		var notFound *domain.ErrContactImportNotFound
+		if errors.As(err, &notFound) {
+			task.State.Message = "Import was deleted"
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get contact import: %w", err)
+	}
+
+	// A newer run of the same import replaced this task
+	if contactImport.TaskID == nil || *contactImport.TaskID != task.ID || !contactImport.Status.IsRunning() {
+		task.State.Message = "Import run was superseded"
+		return true, nil
+	}
+
+	workspace, err := p.workspaceRepo.GetByID(ctx, task.WorkspaceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	var data []byte
+	if contactImport.Source == domain.ContactImportSourceS3 {
+		data, err = p.fileFetcher.Fetch(ctx, workspace.Settings.FileManager, contactImport.S3Key)
+	} else {
+		data, err = p.importRepo.GetFileData(ctx, task.WorkspaceID, contactImport.ID)
+	}
+	if err != nil {
+		return p.fail(ctx, task, contactImport, fmt.Sprintf("failed to read the file: %v", err))
+	}
+
+	_, reader, columns, err := openContactImportFile(contactImport.FileName, data)
+	if err != nil {
+		return p.fail(ctx, task, contactImport, err.Error())
+	}
+	if !equalStrings(columns, contactImport.Columns) {
+		return p.fail(ctx, task, contactImport, "the columns of the file changed since it was uploaded")
+	}
+
+	// Skip the rows processed by previous runs
+	for i := 0; i < state.RowOffset; i++ {
+		if _, err := reader.Read(); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return p.fail(ctx, task, contactImport, err.Error())
+		}
+	}
+
+	for {
+		// Check if we're approaching timeout
+		if time.Now().Add(10 * time.Second).After(timeoutAt) {
+			p.logger.WithFields(map[string]interface{}{
+				"task_id":   task.ID,
+				"import_id": contactImport.ID,
+				"processed": contactImport.ProcessedRows,
+			}).Info("Approaching timeout, pausing contact import")
+			if err := p.saveProgress(ctx, task, contactImport); err != nil {
+				return false, err
+			}
+			return false, nil
+		}
+
+		batch := make([]importRow, 0, p.batchSize)
+		var readErr error
+		for len(batch) < p.batchSize {
+			values, err := reader.Read()
+			if err != nil {
+				readErr = err
+				break
+			}
+			batch = append(batch, importRow{line: reader.Line(), values: values})
+		}
+		if readErr != nil && readErr != io.EOF {
+			return p.fail(ctx, task, contactImport, readErr.Error())
+		}
+
+		if len(batch) > 0 {
+			if err := p.processBatch(ctx, workspace, contactImport, batch); err != nil {
+				return false, err
+			}
+			state.RowOffset += len(batch)
+			contactImport.ProcessedRows += len(batch)
+			if contactImport.TotalRows > 0 {
+				task.Progress = float64(contactImport.ProcessedRows) / float64(contactImport.TotalRows)
+			}
+			if err := p.saveProgress(ctx, task, contactImport); err != nil {
+				return false, err
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+	}
+
+	now := time.Now().UTC()
+	contactImport.CompletedAt = &now
+	contactImport.TotalRows = contactImport.ProcessedRows
+	contactImport.Status = domain.ContactImportStatusCompleted
+	if contactImport.DryRun {
+		contactImport.Status = domain.ContactImportStatusValidated
+	}
+	if err := p.importRepo.Update(ctx, task.WorkspaceID, contactImport); err != nil {
+		return false, fmt.Errorf("failed to update contact import: %w", err)
+	}
+
+	p.logger.WithFields(map[string]interface{}{
+		"task_id":   task.ID,
+		"import_id": contactImport.ID,
+		"dry_run":   contactImport.DryRun,
+		"created":   contactImport.CreatedCount,
+		"updated":   contactImport.UpdatedCount,
+		"skipped":   contactImport.SkippedCount,
+		"failed":    contactImport.FailedCount,
+	}).Info("Completed contact import")
+
+	task.State.Message = fmt.Sprintf("%d rows: %d created, %d updated, %d skipped, %d failed",
+		contactImport.ProcessedRows, contactImport.CreatedCount, contactImport.UpdatedCount,
+		contactImport.SkippedCount, contactImport.FailedCount)
+	task.Progress = 1
+	return true, nil
+}
+
+// processBatch validates a batch of rows and, unless it is a dry run, upserts the contacts
+// and subscribes them to the import lists
+func (p *ContactImportTaskProcessor) processBatch(ctx context.Context, workspace *domain.Workspace, contactImport *domain.ContactImport, batch []importRow) error {
+	attrs := workspace.Settings.ContactAttributes
+
+	contacts := make([]*domain.Contact, 0, len(batch))
+	byEmail := make(map[string]*domain.Contact, len(batch))
+	verifications := make(map[string]*domain.EmailVerification)
+
+	for _, row := range batch {
+		contact, err := domain.BuildContactFromImportRow(contactImport.Columns, row.values, contactImport.Mapping, attrs)
+		if err == nil {
+			err = contact.Validate()
+		}
+		if err == nil && contact.HasAttributeData() {
+			err = contact.NormalizeAttributes(attrs)
+		}
+		if err == nil && p.emailVerificationService != nil {
+			// Imports skip the SMTP probe to keep them fast
+			var verification *domain.EmailVerification
+			verification, err = p.emailVerificationService.VerifyForIngestion(ctx, workspace, contact.Email, false)
+			if verification != nil && err == nil {
+				verifications[contact.Email] = verification
+			}
+		}
+		if err != nil {
+			email := ""
+			if contact != nil {
+				email = contact.Email
+			}
+			contactImport.AddRowError(row.line, email, err.Error())
+			continue
+		}
+
+		// Rows repeating an email are merged into the first one, a batch cannot
+		// upsert the same contact twice
+		if first, ok := byEmail[contact.Email]; ok {
+			first.Merge(contact)
+			contactImport.UpdatedCount++
+			continue
+		}
+		byEmail[contact.Email] = contact
+		contacts = append(contacts, contact)
+	}
+
+	if len(contacts) == 0 {
+		return nil
+	}
+
+	emails := make([]string, len(contacts))
+	for i, contact := range contacts {
+		emails[i] = contact.Email
+	}
+	existingContacts, err := p.contactRepo.GetContactsByEmails(ctx, workspace.ID, emails)
+	if err != nil {
+		return fmt.Errorf("failed to get existing contacts: %w", err)
+	}
+	existing := make(map[string]*domain.Contact, len(existingContacts))
+	for _, contact := range existingContacts {
+		existing[contact.Email] = contact
+	}
+
+	toUpsert := make([]*domain.Contact, 0, len(contacts))
+	for _, contact := range contacts {
+		current, exists := existing[contact.Email]
+		if exists {
+			switch contactImport.DedupeStrategy {
+			case domain.ContactImportDedupeSkip:
+				contactImport.SkippedCount++
+				continue
+			case domain.ContactImportDedupeFillEmpty:
+				contact.KeepEmptyFields(current)
+			}
+		}
+		toUpsert = append(toUpsert, contact)
+
+		// Dry runs report what the import would do
+		if contactImport.DryRun {
+			if exists {
+				contactImport.UpdatedCount++
+			} else {
+				contactImport.CreatedCount++
+			}
+		}
+	}
+
+	if contactImport.DryRun || len(toUpsert) == 0 {
+		return nil
+	}
+
+	results, err := p.contactRepo.BulkUpsertContacts(ctx, workspace.ID, toUpsert)
+	if err != nil {
+		return fmt.Errorf("failed to upsert contacts: %w", err)
+	}
+
+	upserted := make([]string, 0, len(results))
+	stored := make(map[string]*domain.EmailVerification, len(verifications))
+	for _, result := range results {
+		if result.IsNew {
+			contactImport.CreatedCount++
+		} else {
+			contactImport.UpdatedCount++
+		}
+		upserted = append(upserted, result.Email)
+		if verification, ok := verifications[result.Email]; ok {
+			stored[result.Email] = verification
+		}
+	}
+
+	if len(stored) > 0 {
+		// The contacts are saved, a failure to store their verification is not fatal
+		if err := p.contactRepo.UpdateEmailVerifications(ctx, workspace.ID, stored); err != nil {
+			p.logger.WithField("import_id", contactImport.ID).Warn(fmt.Sprintf("Failed to store email verifications: %v", err))
+		}
+	}
+
+	if len(contactImport.ListIDs) > 0 && len(upserted) > 0 {
+		listCtx := domain.WithConsentContext(ctx, domain.ConsentContext{
+			Source:   domain.ConsentSourceImport,
+			SourceID: contactImport.ID,
+		})
+		if err := p.contactListRepo.BulkAddContactsToLists(listCtx, workspace.ID, upserted, contactImport.ListIDs, contactImport.ListStatus); err != nil {
+			return fmt.Errorf("failed to add contacts to lists: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// fail marks the import as failed for an error affecting the whole file. The task
+// completes as retrying would fail the same way.
+func (p *ContactImportTaskProcessor) fail(ctx context.Context, task *domain.Task, contactImport *domain.ContactImport, message string) (bool, error) {
+	p.logger.WithFields(map[string]interface{}{
+		"task_id":   task.ID,
+		"import_id": contactImport.ID,
+		"error":     message,
+	}).Warn("Contact import failed")
+
+	now := time.Now().UTC()
+	contactImport.Status = domain.ContactImportStatusFailed
+	contactImport.ErrorMessage = &message
+	contactImport.CompletedAt = &now
+	if err := p.importRepo.Update(ctx, task.WorkspaceID, contactImport); err != nil {
+		return false, fmt.Errorf("failed to update contact import: %w", err)
+	}
+
+	task.State.Message = "Import failed: " + message
+	return true, nil
+}
+
+// saveProgress persists the import counters and the task row offset
+func (p *ContactImportTaskProcessor) saveProgress(ctx context.Context, task *domain.Task, contactImport *domain.ContactImport) error {
+	if err := p.importRepo.Update(ctx, task.WorkspaceID, contactImport); err != nil {
+		return fmt.Errorf("failed to update contact import: %w", err)
+	}
+
+	task.State.Message = fmt.Sprintf("Importing contacts: %d/%d rows", contactImport.ProcessedRows, contactImport.TotalRows)
+	if err := p.taskRepo.SaveState(ctx, task.WorkspaceID, task.ID, task.Progress, task.State); err != nil {
+		return fmt.Errorf("failed to save task state: %w", err)
+	}
+
+	return nil
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+)
+
+type contactImportProcessorTest struct {
+	processor       *ContactImportTaskProcessor
+	importRepo      *mocks.MockContactImportRepository
+	workspaceRepo   *mocks.MockWorkspaceRepository
+	contactRepo     *mocks.MockContactRepository
+	contactListRepo *mocks.MockContactListRepository
+	taskRepo        *mocks.MockTaskRepository
+	fetcher         *fakeContactImportFileFetcher
+}
+
+func setupContactImportProcessorTest(t *testing.T) *contactImportProcessorTest {
+	ctrl := gomock.NewController(t)
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+
+	pt := &contactImportProcessorTest{
+		importRepo:      mocks.NewMockContactImportRepository(ctrl),
+		workspaceRepo:   mocks.NewMockWorkspaceRepository(ctrl),
+		contactRepo:     mocks.NewMockContactRepository(ctrl),
+		contactListRepo: mocks.NewMockContactListRepository(ctrl),
+		taskRepo:        mocks.NewMockTaskRepository(ctrl),
+		fetcher:         &fakeContactImportFileFetcher{},
+	}
+	pt.processor = NewContactImportTaskProcessor(pt.importRepo, pt.workspaceRepo, pt.contactRepo, pt.contactListRepo,
+		pt.taskRepo, pt.fetcher, mockLogger)
+	return pt
+}
+
+func contactImportTestTask(rowOffset int) *domain.Task {
+	return &domain.Task{
+		ID:          "task1",
+		WorkspaceID: "ws1",
+		Type:        "import_contacts",
+		State: &domain.TaskState{
+			ImportContacts: &domain.ImportContactsState{ImportID: "import1", RowOffset: rowOffset},
+		},
+	}
+}
+
+func contactImportTestRunningImport(strategy domain.ContactImportDedupeStrategy, dryRun bool) *domain.ContactImport {
+	taskID := "task1"
+	status := domain.ContactImportStatusImporting
+	if dryRun {
+		status = domain.ContactImportStatusValidating
+	}
+	return &domain.ContactImport{
+		ID:             "import1",
+		FileName:       "contacts.csv",
+		Source:         domain.ContactImportSourceUpload,
+		Columns:        []string{"email", "name"},
+		Mapping:        domain.ContactImportMapping{"email": "email", "name": "first_name"},
+		ListIDs:        []string{"news"},
+		ListStatus:     domain.ContactListStatusActive,
+		DedupeStrategy: strategy,
+		Status:         status,
+		DryRun:         dryRun,
+		TaskID:         &taskID,
+		TotalRows:      4,
+		Errors:         domain.ContactImportRowErrors{},
+	}
+}
+
+const contactImportTestCSV = "email,name\njohn@example.com,John\nnot-an-email,Nope\njane@example.com,Jane\nJOHN@example.com,\n"
+
+func TestContactImportTaskProcessor_CanProcess(t *testing.T) {
+	pt := setupContactImportProcessorTest(t)
+
+	assert.True(t, pt.processor.CanProcess("import_contacts"))
+	assert.False(t, pt.processor.CanProcess("compute_contact_properties"))
+}
+
+func TestContactImportTaskProcessor_Process(t *testing.T) {
+	ctx := context.Background()
+	timeoutAt := time.Now().Add(time.Minute)
+
+	t.Run("imports the rows and subscribes the contacts", func(t *testing.T) {
+		pt := setupContactImportProcessorTest(t)
+		contactImport := contactImportTestRunningImport(domain.ContactImportDedupeOverwrite, false)
+		task := contactImportTestTask(0)
+
+		pt.importRepo.EXPECT().GetByID(ctx, "ws1", "import1").Return(contactImport, nil)
+		pt.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+		pt.importRepo.EXPECT().GetFileData(ctx, "ws1", "import1").Return([]byte(contactImportTestCSV), nil)
+		pt.contactRepo.EXPECT().GetContactsByEmails(ctx, "ws1", []string{"john@example.com", "jane@example.com"}).
+			Return([]*domain.Contact{{Email: "jane@example.com"}}, nil)
+		pt.contactRepo.EXPECT().BulkUpsertContacts(ctx, "ws1", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, contacts []*domain.Contact) ([]domain.BulkUpsertResult, error) {
+				require.Len(t, contacts, 2)
+				assert.Equal(t, "John", contacts[0].FirstName.String, "duplicate rows keep the values of the first row")
+				return []domain.BulkUpsertResult{{Email: "john@example.com", IsNew: true}, {Email: "jane@example.com"}}, nil
+			})
+		pt.contactListRepo.EXPECT().BulkAddContactsToLists(gomock.Any(), "ws1", []string{"john@example.com", "jane@example.com"}, []string{"news"}, domain.ContactListStatusActive).
+			DoAndReturn(func(ctx context.Context, workspaceID string, emails []string, listIDs []string, status domain.ContactListStatus) error {
+				consent := domain.ConsentContextFromContext(ctx)
+				assert.Equal(t, domain.ConsentSourceImport, consent.Source)
+				assert.Equal(t, "import1", consent.SourceID)
+				return nil
+			})
+		pt.importRepo.EXPECT().Update(ctx, "ws1", contactImport).Return(nil).Times(2)
+		pt.taskRepo.EXPECT().SaveState(ctx, "ws1", "task1", gomock.Any(), task.State).Return(nil)
+
+		completed, err := pt.processor.Process(ctx, task, timeoutAt)
+		require.NoError(t, err)
+		assert.True(t, completed)
+
+		assert.Equal(t, domain.ContactImportStatusCompleted, contactImport.Status)
+		assert.NotNil(t, contactImport.CompletedAt)
+		assert.Equal(t, 4, contactImport.ProcessedRows)
+		assert.Equal(t, 1, contactImport.CreatedCount)
+		assert.Equal(t, 2, contactImport.UpdatedCount)
+		assert.Equal(t, 1, contactImport.FailedCount)
+		require.Len(t, contactImport.Errors, 1)
+		assert.Equal(t, 3, contactImport.Errors[0].Row)
+		assert.Equal(t, 4, task.State.ImportContacts.RowOffset)
+	})
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		pt := setupContactImportProcessorTest(t)
+		contactImport := contactImportTestRunningImport(domain.ContactImportDedupeOverwrite, true)
+		task := contactImportTestTask(0)
+
+		pt.importRepo.EXPECT().GetByID(ctx, "ws1", "import1").Return(contactImport, nil)
+		pt.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+		pt.importRepo.EXPECT().GetFileData(ctx, "ws1", "import1").Return([]byte(contactImportTestCSV), nil)
+		pt.contactRepo.EXPECT().GetContactsByEmails(ctx, "ws1", gomock.Any()).
+			Return([]*domain.Contact{{Email: "jane@example.com"}}, nil)
+		pt.importRepo.EXPECT().Update(ctx, "ws1", contactImport).Return(nil).Times(2)
+		pt.taskRepo.EXPECT().SaveState(ctx, "ws1", "task1", gomock.Any(), gomock.Any()).Return(nil)
+
+		completed, err := pt.processor.Process(ctx, task, timeoutAt)
+		require.NoError(t, err)
+		assert.True(t, completed)
+
+		assert.Equal(t, domain.ContactImportStatusValidated, contactImport.Status)
+		assert.Equal(t, 1, contactImport.CreatedCount)
+		assert.Equal(t, 2, contactImport.UpdatedCount)
+		assert.Equal(t, 1, contactImport.FailedCount)
+	})
+
+	t.Run("skip strategy leaves existing contacts untouched", func(t *testing.T) {
+		pt := setupContactImportProcessorTest(t)
+		contactImport := contactImportTestRunningImport(domain.ContactImportDedupeSkip, false)
+		task := contactImportTestTask(0)
+
+		pt.importRepo.EXPECT().GetByID(ctx, "ws1", "import1").Return(contactImport, nil)
+		pt.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+		pt.importRepo.EXPECT().GetFileData(ctx, "ws1", "import1").Return([]byte(contactImportTestCSV), nil)
+		pt.contactRepo.EXPECT().GetContactsByEmails(ctx, "ws1", gomock.Any()).
+			Return([]*domain.Contact{{Email: "jane@example.com"}}, nil)
+		pt.contactRepo.EXPECT().BulkUpsertContacts(ctx, "ws1", gomock.Len(1)).
+			Return([]domain.BulkUpsertResult{{Email: "john@example.com", IsNew: true}}, nil)
+		pt.contactListRepo.EXPECT().BulkAddContactsToLists(gomock.Any(), "ws1", []string{"john@example.com"}, []string{"news"}, domain.ContactListStatusActive).Return(nil)
+		pt.importRepo.EXPECT().Update(ctx, "ws1", contactImport).Return(nil).Times(2)
+		pt.taskRepo.EXPECT().SaveState(ctx, "ws1", "task1", gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := pt.processor.Process(ctx, task, timeoutAt)
+		require.NoError(t, err)
+		assert.Equal(t, 1, contactImport.SkippedCount)
+		assert.Equal(t, 1, contactImport.CreatedCount)
+	})
+
+	t.Run("fill empty strategy keeps existing values", func(t *testing.T) {
+		pt := setupContactImportProcessorTest(t)
+		contactImport := contactImportTestRunningImport(domain.ContactImportDedupeFillEmpty, false)
+		contactImport.ListIDs = []string{}
+		task := contactImportTestTask(0)
+
+		pt.importRepo.EXPECT().GetByID(ctx, "ws1", "import1").Return(contactImport, nil)
+		pt.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+		pt.importRepo.EXPECT().GetFileData(ctx, "ws1", "import1").Return([]byte(contactImportTestCSV), nil)
+		pt.contactRepo.EXPECT().GetContactsByEmails(ctx, "ws1", gomock.Any()).
+			Return([]*domain.Contact{{Email: "jane@example.com", FirstName: &domain.NullableString{String: "Janet"}}}, nil)
+		pt.contactRepo.EXPECT().BulkUpsertContacts(ctx, "ws1", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, contacts []*domain.Contact) ([]domain.BulkUpsertResult, error) {
+				require.Len(t, contacts, 2)
+				assert.Nil(t, contacts[1].FirstName)
+				return []domain.BulkUpsertResult{{Email: "john@example.com", IsNew: true}, {Email: "jane@example.com"}}, nil
+			})
+		pt.importRepo.EXPECT().Update(ctx, "ws1", contactImport).Return(nil).Times(2)
+		pt.taskRepo.EXPECT().SaveState(ctx, "ws1", "task1", gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := pt.processor.Process(ctx, task, timeoutAt)
+		require.NoError(t, err)
+	})
+
+	t.Run("resumes from the row offset", func(t *testing.T) {
+		pt := setupContactImportProcessorTest(t)
+		contactImport := contactImportTestRunningImport(domain.ContactImportDedupeOverwrite, false)
+		contactImport.ProcessedRows = 3
+		task := contactImportTestTask(3)
+
+		pt.importRepo.EXPECT().GetByID(ctx, "ws1", "import1").Return(contactImport, nil)
+		pt.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+		pt.importRepo.EXPECT().GetFileData(ctx, "ws1", "import1").Return([]byte(contactImportTestCSV), nil)
+		pt.contactRepo.EXPECT().GetContactsByEmails(ctx, "ws1", []string{"john@example.com"}).Return(nil, nil)
+		pt.contactRepo.EXPECT().BulkUpsertContacts(ctx, "ws1", gomock.Len(1)).
+			Return([]domain.BulkUpsertResult{{Email: "john@example.com", IsNew: true}}, nil)
+		pt.contactListRepo.EXPECT().BulkAddContactsToLists(gomock.Any(), "ws1", []string{"john@example.com"}, gomock.Any(), gomock.Any()).Return(nil)
+		pt.importRepo.EXPECT().Update(ctx, "ws1", contactImport).Return(nil).Times(2)
+		pt.taskRepo.EXPECT().SaveState(ctx, "ws1", "task1", gomock.Any(), gomock.Any()).Return(nil)
+
+		completed, err := pt.processor.Process(ctx, task, timeoutAt)
+		require.NoError(t, err)
+		assert.True(t, completed)
+		assert.Equal(t, 4, contactImport.ProcessedRows)
+	})
+
+	t.Run("pauses when approaching the timeout", func(t *testing.T) {
+		pt := setupContactImportProcessorTest(t)
+		contactImport := contactImportTestRunningImport(domain.ContactImportDedupeOverwrite, false)
+		task := contactImportTestTask(0)
+
+		pt.importRepo.EXPECT().GetByID(ctx, "ws1", "import1").Return(contactImport, nil)
+		pt.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+		pt.importRepo.EXPECT().GetFileData(ctx, "ws1", "import1").Return([]byte(contactImportTestCSV), nil)
+		pt.importRepo.EXPECT().Update(ctx, "ws1", contactImport).Return(nil)
+		pt.taskRepo.EXPECT().SaveState(ctx, "ws1", "task1", gomock.Any(), gomock.Any()).Return(nil)
+
+		completed, err := pt.processor.Process(ctx, task, time.Now())
+		require.NoError(t, err)
+		assert.False(t, completed)
+		assert.Equal(t, domain.ContactImportStatusImporting, contactImport.Status)
+	})
+
+	t.Run("changed columns fail the import", func(t *testing.T) {
+		pt := setupContactImportProcessorTest(t)
+		contactImport := contactImportTestRunningImport(domain.ContactImportDedupeOverwrite, false)
+		contactImport.Source = domain.ContactImportSourceS3
+		contactImport.S3Key = "imports/contacts.csv"
+		pt.fetcher.data = []byte("mail,name\njohn@example.com,John\n")
+
+		pt.importRepo.EXPECT().GetByID(ctx, "ws1", "import1").Return(contactImport, nil)
+		pt.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+		pt.importRepo.EXPECT().Update(ctx, "ws1", contactImport).Return(nil)
+
+		completed, err := pt.processor.Process(ctx, contactImportTestTask(0), timeoutAt)
+		require.NoError(t, err)
+		assert.True(t, completed)
+		assert.Equal(t, domain.ContactImportStatusFailed, contactImport.Status)
+		require.NotNil(t, contactImport.ErrorMessage)
+		assert.Contains(t, *contactImport.ErrorMessage, "columns")
+	})
+
+	t.Run("superseded run", func(t *testing.T) {
+		pt := setupContactImportProcessorTest(t)
+		contactImport := contactImportTestRunningImport(domain.ContactImportDedupeOverwrite, false)
+		otherTask := "task2"
+		contactImport.TaskID = &otherTask
+
+		pt.importRepo.EXPECT().GetByID(ctx, "ws1", "import1").Return(contactImport, nil)
+
+		completed, err := pt.processor.Process(ctx, contactImportTestTask(0), timeoutAt)
+		require.NoError(t, err)
+		assert.True(t, completed)
+	})
+
+	t.Run("database errors are retried", func(t *testing.T) {
+		pt := setupContactImportProcessorTest(t)
+		contactImport := contactImportTestRunningImport(domain.ContactImportDedupeOverwrite, false)
+
+		pt.importRepo.EXPECT().GetByID(ctx, "ws1", "import1").Return(contactImport, nil)
+		pt.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(contactImportTestWorkspace(), nil)
+		pt.importRepo.EXPECT().GetFileData(ctx, "ws1", "import1").Return([]byte(contactImportTestCSV), nil)
+		pt.contactRepo.EXPECT().GetContactsByEmails(ctx, "ws1", gomock.Any()).Return(nil, errors.New("db down"))
+
+		completed, err := pt.processor.Process(ctx, contactImportTestTask(0), timeoutAt)
+		require.Error(t, err)
+		assert.False(t, completed)
+	})
+}
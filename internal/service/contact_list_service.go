@@ -155,3 +155,34 @@ func (s *ContactListService) RemoveContactFromList(ctx context.Context, workspac
 
 	return nil
 }
+
+// ListConsentRecords returns the consent ledger of a contact, a list, or a contact on a list
+func (s *ContactListService) ListConsentRecords(ctx context.Context, req *domain.ListConsentRecordsRequest) (*domain.ListConsentRecordsResponse, error) {
+	var userWorkspace *domain.UserWorkspace
+	var err error
+	ctx, _, userWorkspace, err = s.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceContacts, domain.PermissionTypeRead) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceContacts,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to contacts required",
+		)
+	}
+
+	records, nextCursor, err := s.repo.ListConsentRecords(ctx, req.WorkspaceID, req.Email, req.ListID, req.Limit, req.Cursor)
+	if err != nil {
+		s.logger.WithField("email", req.Email).
+			WithField("list_id", req.ListID).
+			Error(fmt.Sprintf("Failed to list consent records: %v", err))
+		return nil, fmt.Errorf("failed to list consent records: %w", err)
+	}
+
+	return &domain.ListConsentRecordsResponse{
+		Records:    records,
+		NextCursor: nextCursor,
+	}, nil
+}
@@ -353,3 +353,78 @@ func TestContactListService_RemoveContactFromList(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestContactListService_ListConsentRecords(t *testing.T) {
+	mockRepo, mockAuthService, _, _, service, ctrl := setupTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+	email := "test@example.com"
+	req := &domain.ListConsentRecordsRequest{WorkspaceID: workspaceID, Email: email, Limit: 50}
+
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user123",
+		WorkspaceID: workspaceID,
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceContacts: {Read: true, Write: false},
+		},
+	}
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		nextCursor := "cursor"
+		records := []*domain.ConsentRecord{
+			{ID: "rec1", Email: email, ListID: "list1", Action: domain.ConsentActionConfirmed, Source: domain.ConsentSourceNotificationCenter},
+			{ID: "rec2", Email: email, ListID: "list1", Action: domain.ConsentActionPending, Source: domain.ConsentSourceForm},
+		}
+
+		mockAuthService.EXPECT().
+			AuthenticateUserForWorkspace(ctx, workspaceID).
+			Return(ctx, &domain.User{}, userWorkspace, nil)
+
+		mockRepo.EXPECT().
+			ListConsentRecords(gomock.Any(), workspaceID, email, "", 50, nil).
+			Return(records, &nextCursor, nil)
+
+		result, err := service.ListConsentRecords(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, records, result.Records)
+		require.Equal(t, &nextCursor, result.NextCursor)
+	})
+
+	t.Run("insufficient permissions", func(t *testing.T) {
+		noPerms := &domain.UserWorkspace{
+			UserID:      "user123",
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceContacts: {Read: false, Write: false},
+			},
+		}
+
+		mockAuthService.EXPECT().
+			AuthenticateUserForWorkspace(ctx, workspaceID).
+			Return(ctx, &domain.User{}, noPerms, nil)
+
+		result, err := service.ListConsentRecords(ctx, req)
+		require.Error(t, err)
+		require.Nil(t, result)
+		var permErr *domain.PermissionError
+		require.True(t, errors.As(err, &permErr))
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockAuthService.EXPECT().
+			AuthenticateUserForWorkspace(ctx, workspaceID).
+			Return(ctx, &domain.User{}, userWorkspace, nil)
+
+		mockRepo.EXPECT().
+			ListConsentRecords(gomock.Any(), workspaceID, email, "", 50, nil).
+			Return(nil, nil, errors.New("db error"))
+
+		result, err := service.ListConsentRecords(ctx, req)
+		require.Error(t, err)
+		require.Nil(t, result)
+	})
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// contactPropertiesRecomputeInterval is how often computed contact properties are refreshed (daily)
+const contactPropertiesRecomputeInterval int64 = 86400
+
+// ContactPropertiesTaskProcessor evaluates the workspace computed properties for every contact
+// This is a permanent, recurring task: each pass walks all contacts in batches and is
+// rescheduled for the next day once complete
+type ContactPropertiesTaskProcessor struct {
+	workspaceRepo domain.WorkspaceRepository
+	contactRepo   domain.ContactRepository
+	taskRepo      domain.TaskRepository
+	logger        logger.Logger
+	batchSize     int
+}
+
+// NewContactPropertiesTaskProcessor creates a new computed contact properties task processor
+func NewContactPropertiesTaskProcessor(
+	workspaceRepo domain.WorkspaceRepository,
+	contactRepo domain.ContactRepository,
+	taskRepo domain.TaskRepository,
+	logger logger.Logger,
+) *ContactPropertiesTaskProcessor {
+	return &ContactPropertiesTaskProcessor{
+		workspaceRepo: workspaceRepo,
+		contactRepo:   contactRepo,
+		taskRepo:      taskRepo,
+		logger:        logger,
+		batchSize:     500,
+	}
+}
+
+// CanProcess returns whether this processor can handle the given task type
+func (p *ContactPropertiesTaskProcessor) CanProcess(taskType string) bool {
+	return taskType == "compute_contact_properties"
+}
+
+// Process computes the properties of the next batches of contacts until the pass is complete
+// or the timeout approaches. Returns true when the pass is complete so the task is
+// rescheduled after its recurring interval.
+func (p *ContactPropertiesTaskProcessor) Process(ctx context.Context, task *domain.Task, timeoutAt time.Time) (bool, error) {
+	workspace, err := p.workspaceRepo.GetByID(ctx, task.WorkspaceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	if task.State == nil {
+		task.State = &domain.TaskState{}
+	}
+	if task.State.ComputeContactProperties == nil {
+		task.State.ComputeContactProperties = &domain.ComputeContactPropertiesState{}
+	}
+	state := task.State.ComputeContactProperties
+
+	props := workspace.Settings.ComputedProperties
+	if len(props) == 0 {
+		// Properties were removed: drop the stale values once, then idle until the next run
+		cleared, err := p.contactRepo.ClearComputedProperties(ctx, task.WorkspaceID)
+		if err != nil {
+			return false, fmt.Errorf("failed to clear computed properties: %w", err)
+		}
+		if cleared > 0 {
+			p.logger.WithFields(map[string]interface{}{
+				"workspace_id": task.WorkspaceID,
+				"cleared":      cleared,
+			}).Info("Cleared computed contact properties")
+		}
+		*state = domain.ComputeContactPropertiesState{LastCompletedAt: state.LastCompletedAt}
+		task.State.Message = "No computed properties defined"
+		return true, nil
+	}
+
+	propertiesSQL, args, err := BuildComputedPropertiesSQL(props, 2)
+	if err != nil {
+		return false, fmt.Errorf("failed to build computed properties SQL: %w", err)
+	}
+
+	if state.StartedAt == "" {
+		state.StartedAt = time.Now().UTC().Format(time.RFC3339)
+		state.ProcessedCount = 0
+		state.UpdatedCount = 0
+		state.ContactOffset = 0
+
+		total, err := p.contactRepo.Count(ctx, task.WorkspaceID)
+		if err != nil {
+			return false, fmt.Errorf("failed to count contacts: %w", err)
+		}
+		state.TotalContacts = total
+	}
+
+	for {
+		// Check if we're approaching timeout
+		if time.Now().Add(5 * time.Second).After(timeoutAt) {
+			p.logger.WithFields(map[string]interface{}{
+				"task_id":      task.ID,
+				"workspace_id": task.WorkspaceID,
+				"processed":    state.ProcessedCount,
+			}).Info("Approaching timeout, pausing computed properties pass")
+			if err := p.saveProgress(ctx, task, state); err != nil {
+				return false, err
+			}
+			return false, nil
+		}
+
+		emails, err := p.contactRepo.GetBatchForSegment(ctx, task.WorkspaceID, state.ContactOffset, p.batchSize)
+		if err != nil {
+			return false, fmt.Errorf("failed to fetch email batch: %w", err)
+		}
+
+		if len(emails) == 0 {
+			break
+		}
+
+		updated, err := p.contactRepo.UpdateComputedProperties(ctx, task.WorkspaceID, emails, propertiesSQL, args)
+		if err != nil {
+			return false, fmt.Errorf("failed to update computed properties: %w", err)
+		}
+
+		state.ContactOffset += int64(len(emails))
+		state.ProcessedCount += len(emails)
+		state.UpdatedCount += updated
+
+		if state.TotalContacts > 0 {
+			task.Progress = float64(state.ProcessedCount) / float64(state.TotalContacts)
+		}
+
+		if err := p.saveProgress(ctx, task, state); err != nil {
+			p.logger.WithField("error", err.Error()).Warn("Failed to save progress (non-fatal)")
+		}
+
+		if len(emails) < p.batchSize {
+			break
+		}
+	}
+
+	p.logger.WithFields(map[string]interface{}{
+		"task_id":      task.ID,
+		"workspace_id": task.WorkspaceID,
+		"properties":   len(props),
+		"processed":    state.ProcessedCount,
+		"updated":      state.UpdatedCount,
+	}).Info("Completed computed contact properties pass")
+
+	// Reset the cursor for the next pass
+	now := time.Now().UTC()
+	task.State.Message = fmt.Sprintf("Computed %d properties for %d contacts (%d updated)", len(props), state.ProcessedCount, state.UpdatedCount)
+	*state = domain.ComputeContactPropertiesState{
+		TotalContacts:   state.TotalContacts,
+		ProcessedCount:  state.ProcessedCount,
+		UpdatedCount:    state.UpdatedCount,
+		LastCompletedAt: &now,
+	}
+	task.Progress = 0
+	return true, nil
+}
+
+// saveProgress persists the pass cursor so it resumes on the next execution
+func (p *ContactPropertiesTaskProcessor) saveProgress(ctx context.Context, task *domain.Task, state *domain.ComputeContactPropertiesState) error {
+	task.State.Message = fmt.Sprintf("Computing properties: %d/%d contacts", state.ProcessedCount, state.TotalContacts)
+
+	if err := p.taskRepo.SaveState(ctx, task.WorkspaceID, task.ID, task.Progress, task.State); err != nil {
+		return fmt.Errorf("failed to save task state: %w", err)
+	}
+
+	return nil
+}
+
+// EnsureContactPropertiesTask creates the permanent computed properties task for a workspace,
+// or restarts its pass immediately if it already exists.
+// This should be called whenever the computed property definitions change.
+func EnsureContactPropertiesTask(ctx context.Context, taskRepo domain.TaskRepository, workspaceID string) error {
+	filter := domain.TaskFilter{
+		Type:   []string{"compute_contact_properties"},
+		Limit:  1,
+		Offset: 0,
+	}
+
+	tasks, _, err := taskRepo.List(ctx, workspaceID, filter)
+	if err != nil {
+		return fmt.Errorf("failed to check for existing contact properties task: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	if len(tasks) > 0 {
+		existingTask := tasks[0]
+		// Restart the pass from the first contact so new definitions apply to everyone
+		if existingTask.State == nil {
+			existingTask.State = &domain.TaskState{}
+		}
+		var lastCompletedAt *time.Time
+		if existingTask.State.ComputeContactProperties != nil {
+			lastCompletedAt = existingTask.State.ComputeContactProperties.LastCompletedAt
+		}
+		existingTask.State.ComputeContactProperties = &domain.ComputeContactPropertiesState{LastCompletedAt: lastCompletedAt}
+		existingTask.Status = domain.TaskStatusPending
+		existingTask.Progress = 0
+		existingTask.NextRunAfter = &now
+
+		if err := taskRepo.Update(ctx, workspaceID, existingTask); err != nil {
+			return fmt.Errorf("failed to update contact properties task: %w", err)
+		}
+		return nil
+	}
+
+	interval := contactPropertiesRecomputeInterval
+	task := &domain.Task{
+		WorkspaceID:       workspaceID,
+		Type:              "compute_contact_properties",
+		Status:            domain.TaskStatusPending,
+		NextRunAfter:      &now,
+		MaxRuntime:        50, // 50 seconds (same as other tasks)
+		MaxRetries:        3,
+		RetryInterval:     60, // 1 minute
+		Progress:          0,
+		RecurringInterval: &interval,
+		State: &domain.TaskState{
+			Message:                  "Compute contact properties",
+			ComputeContactProperties: &domain.ComputeContactPropertiesState{},
+		},
+	}
+
+	if err := taskRepo.Create(ctx, workspaceID, task); err != nil {
+		return fmt.Errorf("failed to create contact properties task: %w", err)
+	}
+
+	return nil
+}
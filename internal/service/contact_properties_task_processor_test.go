@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactPropertiesTaskProcessor_CanProcess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	processor := NewContactPropertiesTaskProcessor(
+		mocks.NewMockWorkspaceRepository(ctrl),
+		mocks.NewMockContactRepository(ctrl),
+		mocks.NewMockTaskRepository(ctrl),
+		pkgmocks.NewMockLogger(ctrl),
+	)
+
+	assert.True(t, processor.CanProcess("compute_contact_properties"))
+	assert.False(t, processor.CanProcess("check_segment_recompute"))
+}
+
+func TestContactPropertiesTaskProcessor_Process(t *testing.T) {
+	ctx := context.Background()
+
+	props := []domain.ComputedProperty{
+		{Key: "orders_count", Source: domain.ComputedPropertySourceCustomEvents, Aggregate: domain.ComputedPropertyAggregateCount, EventName: "order_completed"},
+	}
+
+	setup := func(t *testing.T) (*ContactPropertiesTaskProcessor, *mocks.MockWorkspaceRepository, *mocks.MockContactRepository, *mocks.MockTaskRepository) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockContactRepo := mocks.NewMockContactRepository(ctrl)
+		mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+		mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+
+		processor := NewContactPropertiesTaskProcessor(mockWorkspaceRepo, mockContactRepo, mockTaskRepo, mockLogger)
+		return processor, mockWorkspaceRepo, mockContactRepo, mockTaskRepo
+	}
+
+	t.Run("computes all contacts in batches", func(t *testing.T) {
+		processor, mockWorkspaceRepo, mockContactRepo, mockTaskRepo := setup(t)
+		processor.batchSize = 2
+
+		task := &domain.Task{ID: "task1", WorkspaceID: "workspace1", Type: "compute_contact_properties"}
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace1").
+			Return(&domain.Workspace{ID: "workspace1", Settings: domain.WorkspaceSettings{ComputedProperties: props}}, nil)
+		mockContactRepo.EXPECT().Count(ctx, "workspace1").Return(3, nil)
+
+		gomock.InOrder(
+			mockContactRepo.EXPECT().GetBatchForSegment(ctx, "workspace1", int64(0), 2).
+				Return([]string{"a@example.com", "b@example.com"}, nil),
+			mockContactRepo.EXPECT().UpdateComputedProperties(ctx, "workspace1", []string{"a@example.com", "b@example.com"}, gomock.Any(), []interface{}{"order_completed"}).
+				DoAndReturn(func(ctx context.Context, workspaceID string, emails []string, propertiesSQL string, args []interface{}) (int, error) {
+					assert.Contains(t, propertiesSQL, "ce.event_name = $2")
+					return 2, nil
+				}),
+			mockContactRepo.EXPECT().GetBatchForSegment(ctx, "workspace1", int64(2), 2).
+				Return([]string{"c@example.com"}, nil),
+			mockContactRepo.EXPECT().UpdateComputedProperties(ctx, "workspace1", []string{"c@example.com"}, gomock.Any(), gomock.Any()).
+				Return(0, nil),
+		)
+		mockTaskRepo.EXPECT().SaveState(ctx, "workspace1", "task1", gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+		completed, err := processor.Process(ctx, task, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, completed)
+
+		state := task.State.ComputeContactProperties
+		require.NotNil(t, state)
+		assert.Equal(t, 3, state.ProcessedCount)
+		assert.Equal(t, 2, state.UpdatedCount)
+		assert.Empty(t, state.StartedAt)
+		assert.Equal(t, int64(0), state.ContactOffset)
+		assert.NotNil(t, state.LastCompletedAt)
+		assert.Equal(t, float64(0), task.Progress)
+	})
+
+	t.Run("resumes a pass from the saved offset", func(t *testing.T) {
+		processor, mockWorkspaceRepo, mockContactRepo, mockTaskRepo := setup(t)
+
+		task := &domain.Task{
+			ID:          "task1",
+			WorkspaceID: "workspace1",
+			State: &domain.TaskState{
+				ComputeContactProperties: &domain.ComputeContactPropertiesState{
+					TotalContacts:  600,
+					ProcessedCount: 500,
+					ContactOffset:  500,
+					StartedAt:      time.Now().UTC().Format(time.RFC3339),
+				},
+			},
+		}
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace1").
+			Return(&domain.Workspace{ID: "workspace1", Settings: domain.WorkspaceSettings{ComputedProperties: props}}, nil)
+		mockContactRepo.EXPECT().GetBatchForSegment(ctx, "workspace1", int64(500), 500).
+			Return([]string{"z@example.com"}, nil)
+		mockContactRepo.EXPECT().UpdateComputedProperties(ctx, "workspace1", []string{"z@example.com"}, gomock.Any(), gomock.Any()).
+			Return(1, nil)
+		mockTaskRepo.EXPECT().SaveState(ctx, "workspace1", "task1", gomock.Any(), gomock.Any()).Return(nil)
+
+		completed, err := processor.Process(ctx, task, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, completed)
+		assert.Equal(t, 501, task.State.ComputeContactProperties.ProcessedCount)
+	})
+
+	t.Run("pauses when approaching timeout", func(t *testing.T) {
+		processor, mockWorkspaceRepo, mockContactRepo, mockTaskRepo := setup(t)
+
+		task := &domain.Task{ID: "task1", WorkspaceID: "workspace1"}
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace1").
+			Return(&domain.Workspace{ID: "workspace1", Settings: domain.WorkspaceSettings{ComputedProperties: props}}, nil)
+		mockContactRepo.EXPECT().Count(ctx, "workspace1").Return(1000, nil)
+		mockTaskRepo.EXPECT().SaveState(ctx, "workspace1", "task1", gomock.Any(), gomock.Any()).Return(nil)
+
+		completed, err := processor.Process(ctx, task, time.Now())
+		require.NoError(t, err)
+		assert.False(t, completed)
+		assert.NotEmpty(t, task.State.ComputeContactProperties.StartedAt)
+	})
+
+	t.Run("clears values when no properties are defined", func(t *testing.T) {
+		processor, mockWorkspaceRepo, mockContactRepo, _ := setup(t)
+
+		task := &domain.Task{ID: "task1", WorkspaceID: "workspace1"}
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace1").
+			Return(&domain.Workspace{ID: "workspace1"}, nil)
+		mockContactRepo.EXPECT().ClearComputedProperties(ctx, "workspace1").Return(12, nil)
+
+		completed, err := processor.Process(ctx, task, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, completed)
+		assert.Equal(t, "No computed properties defined", task.State.Message)
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		processor, mockWorkspaceRepo, mockContactRepo, _ := setup(t)
+
+		task := &domain.Task{ID: "task1", WorkspaceID: "workspace1"}
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace1").
+			Return(&domain.Workspace{ID: "workspace1", Settings: domain.WorkspaceSettings{ComputedProperties: props}}, nil)
+		mockContactRepo.EXPECT().Count(ctx, "workspace1").Return(1, nil)
+		mockContactRepo.EXPECT().GetBatchForSegment(ctx, "workspace1", int64(0), 500).
+			Return([]string{"a@example.com"}, nil)
+		mockContactRepo.EXPECT().UpdateComputedProperties(ctx, "workspace1", gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(0, errors.New("db error"))
+
+		completed, err := processor.Process(ctx, task, time.Now().Add(time.Minute))
+		require.Error(t, err)
+		assert.False(t, completed)
+		assert.Contains(t, err.Error(), "failed to update computed properties")
+	})
+
+	t.Run("workspace error", func(t *testing.T) {
+		processor, mockWorkspaceRepo, _, _ := setup(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace1").Return(nil, errors.New("not found"))
+
+		completed, err := processor.Process(ctx, &domain.Task{ID: "task1", WorkspaceID: "workspace1"}, time.Now().Add(time.Minute))
+		require.Error(t, err)
+		assert.False(t, completed)
+	})
+}
+
+func TestEnsureContactPropertiesTask(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates the recurring task", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+		mockTaskRepo.EXPECT().List(ctx, "workspace1", gomock.Any()).Return(nil, 0, nil)
+		mockTaskRepo.EXPECT().Create(ctx, "workspace1", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, task *domain.Task) error {
+				assert.Equal(t, "compute_contact_properties", task.Type)
+				assert.Equal(t, domain.TaskStatusPending, task.Status)
+				require.NotNil(t, task.RecurringInterval)
+				assert.Equal(t, int64(86400), *task.RecurringInterval)
+				assert.NotNil(t, task.State.ComputeContactProperties)
+				return nil
+			})
+
+		assert.NoError(t, EnsureContactPropertiesTask(ctx, mockTaskRepo, "workspace1"))
+	})
+
+	t.Run("restarts the existing task", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		lastCompleted := time.Now().Add(-time.Hour)
+		existing := &domain.Task{
+			ID:          "task1",
+			WorkspaceID: "workspace1",
+			Type:        "compute_contact_properties",
+			Status:      domain.TaskStatusPaused,
+			Progress:    0.5,
+			State: &domain.TaskState{
+				ComputeContactProperties: &domain.ComputeContactPropertiesState{
+					ContactOffset:   500,
+					StartedAt:       "2024-01-01T00:00:00Z",
+					LastCompletedAt: &lastCompleted,
+				},
+			},
+		}
+
+		mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+		mockTaskRepo.EXPECT().List(ctx, "workspace1", gomock.Any()).Return([]*domain.Task{existing}, 1, nil)
+		mockTaskRepo.EXPECT().Update(ctx, "workspace1", existing).Return(nil)
+
+		require.NoError(t, EnsureContactPropertiesTask(ctx, mockTaskRepo, "workspace1"))
+		assert.Equal(t, domain.TaskStatusPending, existing.Status)
+		assert.Equal(t, float64(0), existing.Progress)
+		assert.NotNil(t, existing.NextRunAfter)
+		assert.Equal(t, int64(0), existing.State.ComputeContactProperties.ContactOffset)
+		assert.Empty(t, existing.State.ComputeContactProperties.StartedAt)
+		assert.Equal(t, &lastCompleted, existing.State.ComputeContactProperties.LastCompletedAt)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+		mockTaskRepo.EXPECT().List(ctx, "workspace1", gomock.Any()).Return(nil, 0, errors.New("db error"))
+
+		err := EnsureContactPropertiesTask(ctx, mockTaskRepo, "workspace1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to check for existing contact properties task")
+	})
+}
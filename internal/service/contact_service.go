@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -18,7 +19,11 @@ type ContactService struct {
 	inboundWebhookEventRepo domain.InboundWebhookEventRepository
 	contactListRepo         domain.ContactListRepository
 	contactTimelineRepo     domain.ContactTimelineRepository
-	logger                  logger.Logger
+	blogCommentRepo         domain.BlogCommentRepository
+	bulkOperationRepo       domain.ContactBulkOperationRepository
+	// optional, addresses are not verified when nil
+	emailVerificationService domain.EmailVerificationService
+	logger                   logger.Logger
 }
 
 func NewContactService(
@@ -29,6 +34,8 @@ func NewContactService(
 	inboundWebhookEventRepo domain.InboundWebhookEventRepository,
 	contactListRepo domain.ContactListRepository,
 	contactTimelineRepo domain.ContactTimelineRepository,
+	blogCommentRepo domain.BlogCommentRepository,
+	bulkOperationRepo domain.ContactBulkOperationRepository,
 	logger logger.Logger,
 ) *ContactService {
 	return &ContactService{
@@ -39,10 +46,17 @@ func NewContactService(
 		inboundWebhookEventRepo: inboundWebhookEventRepo,
 		contactListRepo:         contactListRepo,
 		contactTimelineRepo:     contactTimelineRepo,
+		blogCommentRepo:         blogCommentRepo,
+		bulkOperationRepo:       bulkOperationRepo,
 		logger:                  logger,
 	}
 }
 
+// SetEmailVerificationService enables the verification of ingested addresses
+func (s *ContactService) SetEmailVerificationService(emailVerificationService domain.EmailVerificationService) {
+	s.emailVerificationService = emailVerificationService
+}
+
 func (s *ContactService) GetContactByEmail(ctx context.Context, workspaceID string, email string) (*domain.Contact, error) {
 	// Normalize email for consistent lookups
 	email = domain.NormalizeEmail(email)
@@ -70,6 +84,12 @@ func (s *ContactService) GetContactByEmail(ctx context.Context, workspaceID stri
 	}
 
 	contact, err := s.repo.GetContactByEmail(ctx, workspaceID, email)
+	if errors.Is(err, domain.ErrContactNotFound) {
+		// Former addresses of merged or renamed contacts resolve to the contact
+		if contactEmail, aliasErr := s.repo.ResolveContactAlias(ctx, workspaceID, email); aliasErr == nil && contactEmail != "" {
+			contact, err = s.repo.GetContactByEmail(ctx, workspaceID, contactEmail)
+		}
+	}
 	if err != nil {
 		if strings.Contains(err.Error(), "contact not found") {
 			return nil, err
@@ -154,6 +174,14 @@ func (s *ContactService) DeleteContact(ctx context.Context, workspaceID string,
 		)
 	}
 
+	return s.eraseContact(ctx, workspaceID, email)
+}
+
+// eraseContact deletes a contact with its message history, webhook events, list
+// memberships, timeline, blog comments and reactions, bulk operation results and
+// former addresses. The caller is responsible for the
+// permission check.
+func (s *ContactService) eraseContact(ctx context.Context, workspaceID string, email string) error {
 	// Delete related data first
 	if err := s.messageHistoryRepo.DeleteForEmail(ctx, workspaceID, email); err != nil {
 		s.logger.WithField("email", email).Error(fmt.Sprintf("Failed to delete message history: %v", err))
@@ -175,6 +203,21 @@ func (s *ContactService) DeleteContact(ctx context.Context, workspaceID string,
 		return fmt.Errorf("failed to delete contact timeline: %w", err)
 	}
 
+	if err := s.blogCommentRepo.DeleteForEmail(ctx, workspaceID, email); err != nil {
+		s.logger.WithField("email", email).Error(fmt.Sprintf("Failed to delete blog comments: %v", err))
+		return fmt.Errorf("failed to delete blog comments: %w", err)
+	}
+
+	if err := s.bulkOperationRepo.DeleteResultsForEmail(ctx, workspaceID, email); err != nil {
+		s.logger.WithField("email", email).Error(fmt.Sprintf("Failed to delete bulk operation results: %v", err))
+		return fmt.Errorf("failed to delete bulk operation results: %w", err)
+	}
+
+	if err := s.repo.DeleteContactAliases(ctx, workspaceID, email); err != nil {
+		s.logger.WithField("email", email).Error(fmt.Sprintf("Failed to delete contact aliases: %v", err))
+		return fmt.Errorf("failed to delete contact aliases: %w", err)
+	}
+
 	// Finally delete the contact
 	if err := s.repo.DeleteContact(ctx, workspaceID, email); err != nil {
 		s.logger.WithField("email", email).Error(fmt.Sprintf("Failed to delete contact: %v", err))
@@ -216,11 +259,45 @@ func (s *ContactService) BatchImportContacts(ctx context.Context, workspaceID st
 	validContacts := make([]*domain.Contact, 0, len(contacts))
 	validContactIndices := make([]int, 0, len(contacts))
 
+	// The workspace is loaded on the first contact carrying attributes or to verify addresses
+	var workspace *domain.Workspace
+	loadWorkspace := func() bool {
+		if workspace == nil {
+			workspace, err = s.workspaceRepo.GetByID(ctx, workspaceID)
+			if err != nil {
+				response.Error = fmt.Sprintf("failed to get workspace: %v", err)
+				return false
+			}
+		}
+		return true
+	}
+
+	// Imports skip the SMTP probe, verifications are stored once contacts are upserted
+	verifications := make(map[string]*domain.EmailVerification)
+
 	for i, contact := range contacts {
 		// CreatedAt and UpdatedAt are optional - if not provided, DB will use CURRENT_TIMESTAMP
 		// If provided, the values will be used (allows historical imports)
 
-		if err := contact.Validate(); err != nil {
+		err := contact.Validate()
+		if err == nil && contact.HasAttributeData() {
+			if !loadWorkspace() {
+				return response
+			}
+			err = contact.NormalizeAttributes(workspace.Settings.ContactAttributes)
+		}
+		if err == nil && s.emailVerificationService != nil {
+			if !loadWorkspace() {
+				return response
+			}
+			var verification *domain.EmailVerification
+			verification, err = s.emailVerificationService.VerifyForIngestion(ctx, workspace, contact.Email, false)
+			if verification != nil && err == nil {
+				verifications[contact.Email] = verification
+			}
+		}
+
+		if err != nil {
 			// Record validation error
 			operation := &domain.UpsertContactOperation{
 				Email:  contact.Email,
@@ -283,6 +360,8 @@ func (s *ContactService) BatchImportContacts(ctx context.Context, workspaceID st
 				continue
 			}
 
+			s.storeEmailVerifications(ctx, workspaceID, bulkResults, verifications)
+
 			for _, result := range bulkResults {
 				action := domain.UpsertContactOperationCreate
 				if !result.IsNew {
@@ -351,6 +430,35 @@ func (s *ContactService) UpsertContact(ctx context.Context, workspaceID string,
 		return operation
 	}
 
+	// Check attributes against the workspace registry and sync the legacy custom fields
+	if contact.HasAttributeData() {
+		attrs, err := s.getContactAttributes(ctx, workspaceID)
+		if err == nil {
+			err = contact.NormalizeAttributes(attrs)
+		}
+		if err != nil {
+			operation.Action = domain.UpsertContactOperationError
+			operation.Error = err.Error()
+			s.logger.WithField("email", contact.Email).Error(fmt.Sprintf("Invalid contact attributes: %v", err))
+			return operation
+		}
+	}
+
+	// Verify the address when the workspace enables email verification
+	var verification *domain.EmailVerification
+	if s.emailVerificationService != nil {
+		workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+		if err == nil {
+			verification, err = s.emailVerificationService.VerifyForIngestion(ctx, workspace, contact.Email, true)
+		}
+		if err != nil {
+			operation.Action = domain.UpsertContactOperationError
+			operation.Error = err.Error()
+			s.logger.WithField("email", contact.Email).Error(fmt.Sprintf("Email verification failed: %v", err))
+			return operation
+		}
+	}
+
 	// CreatedAt and UpdatedAt are optional - if not provided, DB will use CURRENT_TIMESTAMP
 	// If provided, the values will be used (allows historical imports)
 
@@ -362,6 +470,13 @@ func (s *ContactService) UpsertContact(ctx context.Context, workspaceID string,
 		return operation
 	}
 
+	if verification != nil {
+		// The contact is saved, a failure to store its verification is not fatal
+		if err := s.repo.UpdateEmailVerifications(ctx, workspaceID, map[string]*domain.EmailVerification{contact.Email: verification}); err != nil {
+			s.logger.WithField("email", contact.Email).Warn(fmt.Sprintf("Failed to store email verification: %v", err))
+		}
+	}
+
 	if !isNew {
 		operation.Action = domain.UpsertContactOperationUpdate
 	}
@@ -369,6 +484,36 @@ func (s *ContactService) UpsertContact(ctx context.Context, workspaceID string,
 	return operation
 }
 
+// storeEmailVerifications stores the verifications of the contacts of a bulk upsert
+func (s *ContactService) storeEmailVerifications(ctx context.Context, workspaceID string, results []domain.BulkUpsertResult, verifications map[string]*domain.EmailVerification) {
+	if len(verifications) == 0 {
+		return
+	}
+
+	chunkVerifications := make(map[string]*domain.EmailVerification, len(results))
+	for _, result := range results {
+		if verification, ok := verifications[result.Email]; ok {
+			chunkVerifications[result.Email] = verification
+		}
+	}
+	if len(chunkVerifications) == 0 {
+		return
+	}
+
+	if err := s.repo.UpdateEmailVerifications(ctx, workspaceID, chunkVerifications); err != nil {
+		s.logger.Warn(fmt.Sprintf("Failed to store email verifications: %v", err))
+	}
+}
+
+// getContactAttributes returns the contact attribute registry of a workspace
+func (s *ContactService) getContactAttributes(ctx context.Context, workspaceID string) ([]domain.ContactAttribute, error) {
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	return workspace.Settings.ContactAttributes, nil
+}
+
 func (s *ContactService) CountContacts(ctx context.Context, workspaceID string) (int, error) {
 	var err error
 	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
@@ -393,3 +538,129 @@ func (s *ContactService) CountContacts(ctx context.Context, workspaceID string)
 
 	return count, nil
 }
+
+// MergeContacts merges the secondary contact into the primary one: the fields of the
+// secondary contact are applied with the request strategy, then its lists, history,
+// events and automation journeys are moved to the primary contact and its address
+// becomes an alias of the primary contact.
+func (s *ContactService) MergeContacts(ctx context.Context, req *domain.MergeContactsRequest) (*domain.Contact, error) {
+	var err error
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Check permission for writing contacts
+	if !userWorkspace.HasPermission(domain.PermissionResourceContacts, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceContacts,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to contacts required",
+		)
+	}
+
+	primary, err := s.repo.GetContactByEmail(ctx, req.WorkspaceID, req.PrimaryEmail)
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := s.repo.GetContactByEmail(ctx, req.WorkspaceID, req.SecondaryEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.UpsertContact(ctx, req.WorkspaceID, primary.MergeFieldsFrom(secondary, req.Strategy)); err != nil {
+		s.logger.WithField("email", req.PrimaryEmail).Error(fmt.Sprintf("Failed to merge contact fields: %v", err))
+		return nil, fmt.Errorf("failed to merge contact fields: %w", err)
+	}
+
+	if err := s.repo.MergeContacts(ctx, req.WorkspaceID, req.PrimaryEmail, req.SecondaryEmail); err != nil {
+		s.logger.WithField("email", req.PrimaryEmail).Error(fmt.Sprintf("Failed to merge contacts: %v", err))
+		return nil, fmt.Errorf("failed to merge contacts: %w", err)
+	}
+
+	return s.repo.GetContactByEmail(ctx, req.WorkspaceID, req.PrimaryEmail)
+}
+
+// ChangeContactEmail moves a contact and its related data to a new address. The
+// previous address becomes an alias of the contact.
+func (s *ContactService) ChangeContactEmail(ctx context.Context, req *domain.ChangeContactEmailRequest) (*domain.Contact, error) {
+	var err error
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Check permission for writing contacts
+	if !userWorkspace.HasPermission(domain.PermissionResourceContacts, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceContacts,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to contacts required",
+		)
+	}
+
+	// Verify the new address when the workspace enables email verification
+	var verification *domain.EmailVerification
+	if s.emailVerificationService != nil {
+		workspace, err := s.workspaceRepo.GetByID(ctx, req.WorkspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workspace: %w", err)
+		}
+		verification, err = s.emailVerificationService.VerifyForIngestion(ctx, workspace, req.NewEmail, true)
+		if err != nil {
+			var blocked *domain.ErrEmailVerificationBlocked
+			if errors.As(err, &blocked) {
+				return nil, domain.NewValidationError(err.Error())
+			}
+			return nil, fmt.Errorf("failed to verify new email: %w", err)
+		}
+	}
+
+	if err := s.repo.ChangeContactEmail(ctx, req.WorkspaceID, req.Email, req.NewEmail); err != nil {
+		if errors.Is(err, domain.ErrContactNotFound) {
+			return nil, err
+		}
+		if errors.Is(err, domain.ErrContactEmailInUse) {
+			return nil, domain.NewValidationError(fmt.Sprintf("%s, merge the contacts instead", err.Error()))
+		}
+		s.logger.WithField("email", req.Email).Error(fmt.Sprintf("Failed to change contact email: %v", err))
+		return nil, fmt.Errorf("failed to change contact email: %w", err)
+	}
+
+	if verification != nil {
+		// The contact is saved, a failure to store its verification is not fatal
+		if err := s.repo.UpdateEmailVerifications(ctx, req.WorkspaceID, map[string]*domain.EmailVerification{req.NewEmail: verification}); err != nil {
+			s.logger.WithField("email", req.NewEmail).Warn(fmt.Sprintf("Failed to store email verification: %v", err))
+		}
+	}
+
+	return s.repo.GetContactByEmail(ctx, req.WorkspaceID, req.NewEmail)
+}
+
+// GetContactAliases returns the former addresses of a contact
+func (s *ContactService) GetContactAliases(ctx context.Context, workspaceID string, email string) ([]*domain.ContactAlias, error) {
+	email = domain.NormalizeEmail(email)
+
+	var err error
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Check permission for reading contacts
+	if !userWorkspace.HasPermission(domain.PermissionResourceContacts, domain.PermissionTypeRead) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceContacts,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to contacts required",
+		)
+	}
+
+	aliases, err := s.repo.GetContactAliases(ctx, workspaceID, email)
+	if err != nil {
+		s.logger.WithField("email", email).Error(fmt.Sprintf("Failed to get contact aliases: %v", err))
+		return nil, fmt.Errorf("failed to get contact aliases: %w", err)
+	}
+
+	return aliases, nil
+}
@@ -14,7 +14,7 @@ import (
 )
 
 // createContactServiceWithMocks creates a ContactService with all required mocks
-func createContactServiceWithMocks(ctrl *gomock.Controller) (*ContactService, *mocks.MockContactRepository, *mocks.MockWorkspaceRepository, *mocks.MockAuthService, *mocks.MockMessageHistoryRepository, *mocks.MockInboundWebhookEventRepository, *mocks.MockContactListRepository, *mocks.MockContactTimelineRepository, *pkgmocks.MockLogger) {
+func createContactServiceWithMocks(ctrl *gomock.Controller) (*ContactService, *mocks.MockContactRepository, *mocks.MockWorkspaceRepository, *mocks.MockAuthService, *mocks.MockMessageHistoryRepository, *mocks.MockInboundWebhookEventRepository, *mocks.MockContactListRepository, *mocks.MockContactTimelineRepository, *mocks.MockBlogCommentRepository, *mocks.MockContactBulkOperationRepository, *pkgmocks.MockLogger) {
 	mockRepo := mocks.NewMockContactRepository(ctrl)
 	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
 	mockAuthService := mocks.NewMockAuthService(ctrl)
@@ -22,6 +22,8 @@ func createContactServiceWithMocks(ctrl *gomock.Controller) (*ContactService, *m
 	mockInboundWebhookEventRepo := mocks.NewMockInboundWebhookEventRepository(ctrl)
 	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
 	mockContactTimelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+	mockBlogCommentRepo := mocks.NewMockBlogCommentRepository(ctrl)
+	mockBulkOperationRepo := mocks.NewMockContactBulkOperationRepository(ctrl)
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 
 	service := NewContactService(
@@ -32,17 +34,19 @@ func createContactServiceWithMocks(ctrl *gomock.Controller) (*ContactService, *m
 		mockInboundWebhookEventRepo,
 		mockContactListRepo,
 		mockContactTimelineRepo,
+		mockBlogCommentRepo,
+		mockBulkOperationRepo,
 		mockLogger,
 	)
 
-	return service, mockRepo, mockWorkspaceRepo, mockAuthService, mockMessageHistoryRepo, mockInboundWebhookEventRepo, mockContactListRepo, mockContactTimelineRepo, mockLogger
+	return service, mockRepo, mockWorkspaceRepo, mockAuthService, mockMessageHistoryRepo, mockInboundWebhookEventRepo, mockContactListRepo, mockContactTimelineRepo, mockBlogCommentRepo, mockBulkOperationRepo, mockLogger
 }
 
 func TestContactService_GetContactByEmail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	service, mockRepo, _, mockAuthService, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
+	service, mockRepo, _, mockAuthService, _, _, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
 
 	ctx := context.Background()
 	workspaceID := "workspace123"
@@ -156,7 +160,7 @@ func TestContactService_GetContactByExternalID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	service, mockRepo, _, mockAuthService, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
+	service, mockRepo, _, mockAuthService, _, _, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
 
 	ctx := context.Background()
 	workspaceID := "workspace123"
@@ -247,7 +251,7 @@ func TestContactService_GetContacts(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	service, mockRepo, _, mockAuthService, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
+	service, mockRepo, _, mockAuthService, _, _, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
 
 	ctx := context.Background()
 	workspaceID := "workspace123"
@@ -302,7 +306,7 @@ func TestContactService_DeleteContact(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	service, mockContactRepo, _, mockAuthService, mockMessageHistoryRepo, mockInboundWebhookEventRepo, mockContactListRepo, mockContactTimelineRepo, mockLogger := createContactServiceWithMocks(ctrl)
+	service, mockContactRepo, _, mockAuthService, mockMessageHistoryRepo, mockInboundWebhookEventRepo, mockContactListRepo, mockContactTimelineRepo, mockBlogCommentRepo, mockBulkOperationRepo, mockLogger := createContactServiceWithMocks(ctrl)
 
 	ctx := context.Background()
 	workspaceID := "test-workspace"
@@ -323,12 +327,65 @@ func TestContactService_DeleteContact(t *testing.T) {
 		mockInboundWebhookEventRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
 		mockContactListRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
 		mockContactTimelineRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
-		mockContactRepo.EXPECT().DeleteContact(ctx, workspaceID, email).Return(nil)
+		mockBlogCommentRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockBulkOperationRepo.EXPECT().DeleteResultsForEmail(ctx, workspaceID, email).Return(nil)
+		gomock.InOrder(
+			mockContactRepo.EXPECT().DeleteContactAliases(ctx, workspaceID, email).Return(nil),
+			mockContactRepo.EXPECT().DeleteContact(ctx, workspaceID, email).Return(nil),
+		)
 
 		err := service.DeleteContact(ctx, workspaceID, email)
 		assert.NoError(t, err)
 	})
 
+	t.Run("keeps the contact when its aliases cannot be deleted", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockMessageHistoryRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockInboundWebhookEventRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockContactListRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockContactTimelineRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockBlogCommentRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockBulkOperationRepo.EXPECT().DeleteResultsForEmail(ctx, workspaceID, email).Return(nil)
+		mockLogger.EXPECT().WithField("email", email).Return(mockLogger)
+		mockContactRepo.EXPECT().DeleteContactAliases(ctx, workspaceID, email).Return(fmt.Errorf("db error"))
+		mockLogger.EXPECT().Error(fmt.Sprintf("Failed to delete contact aliases: %v", fmt.Errorf("db error")))
+
+		err := service.DeleteContact(ctx, workspaceID, email)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete contact aliases")
+	})
+
+	t.Run("keeps the contact when its blog comments cannot be deleted", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockMessageHistoryRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockInboundWebhookEventRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockContactListRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockContactTimelineRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockLogger.EXPECT().WithField("email", email).Return(mockLogger)
+		mockBlogCommentRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(fmt.Errorf("db error"))
+		mockLogger.EXPECT().Error(fmt.Sprintf("Failed to delete blog comments: %v", fmt.Errorf("db error")))
+
+		err := service.DeleteContact(ctx, workspaceID, email)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete blog comments")
+	})
+
+	t.Run("keeps the contact when its bulk operation results cannot be deleted", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockMessageHistoryRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockInboundWebhookEventRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockContactListRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockContactTimelineRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockBlogCommentRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockLogger.EXPECT().WithField("email", email).Return(mockLogger)
+		mockBulkOperationRepo.EXPECT().DeleteResultsForEmail(ctx, workspaceID, email).Return(fmt.Errorf("db error"))
+		mockLogger.EXPECT().Error(fmt.Sprintf("Failed to delete bulk operation results: %v", fmt.Errorf("db error")))
+
+		err := service.DeleteContact(ctx, workspaceID, email)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete bulk operation results")
+	})
+
 	t.Run("authentication error", func(t *testing.T) {
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, nil, nil, fmt.Errorf("auth error"))
 
@@ -343,6 +400,9 @@ func TestContactService_DeleteContact(t *testing.T) {
 		mockInboundWebhookEventRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
 		mockContactListRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
 		mockContactTimelineRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockBlogCommentRepo.EXPECT().DeleteForEmail(ctx, workspaceID, email).Return(nil)
+		mockBulkOperationRepo.EXPECT().DeleteResultsForEmail(ctx, workspaceID, email).Return(nil)
+		mockContactRepo.EXPECT().DeleteContactAliases(ctx, workspaceID, email).Return(nil)
 		mockLogger.EXPECT().WithField("email", email).Return(mockLogger)
 		mockContactRepo.EXPECT().DeleteContact(ctx, workspaceID, email).Return(fmt.Errorf("contact not found"))
 		mockLogger.EXPECT().Error(fmt.Sprintf("Failed to delete contact: %v", fmt.Errorf("contact not found")))
@@ -357,7 +417,7 @@ func TestContactService_UpsertContact(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	service, mockRepo, _, mockAuthService, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
+	service, mockRepo, _, mockAuthService, _, _, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
 
 	ctx := context.Background()
 	workspaceID := "workspace123"
@@ -432,7 +492,7 @@ func TestContactService_UpsertContactWithPartialUpdates(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	service, mockRepo, _, mockAuthService, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+	service, mockRepo, mockWorkspaceRepo, mockAuthService, _, _, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
 
 	ctx := context.Background()
 	workspaceID := "workspace123"
@@ -510,6 +570,7 @@ func TestContactService_UpsertContactWithPartialUpdates(t *testing.T) {
 		}
 
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(&domain.Workspace{ID: workspaceID}, nil)
 		mockRepo.EXPECT().UpsertContact(ctx, workspaceID, gomock.Any()).DoAndReturn(
 			func(ctx context.Context, workspaceID string, contact *domain.Contact) (bool, error) {
 				// Verify that JSON field is properly set
@@ -539,6 +600,7 @@ func TestContactService_UpsertContactWithPartialUpdates(t *testing.T) {
 		}
 
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(&domain.Workspace{ID: workspaceID}, nil)
 		mockRepo.EXPECT().UpsertContact(ctx, workspaceID, gomock.Any()).DoAndReturn(
 			func(ctx context.Context, workspaceID string, contact *domain.Contact) (bool, error) {
 				// Verify that null fields are properly set
@@ -559,13 +621,60 @@ func TestContactService_UpsertContactWithPartialUpdates(t *testing.T) {
 		assert.Equal(t, domain.UpsertContactOperationUpdate, result.Action)
 		assert.Empty(t, result.Error)
 	})
+
+	t.Run("upsert with typed attributes", func(t *testing.T) {
+		workspace := &domain.Workspace{
+			ID: workspaceID,
+			Settings: domain.WorkspaceSettings{
+				ContactAttributes: []domain.ContactAttribute{
+					{Key: "plan", Type: domain.ContactAttributeTypeString, Enum: []string{"free", "pro"}, LegacyField: "custom_string_1"},
+					{Key: "seats", Type: domain.ContactAttributeTypeNumber},
+				},
+			},
+		}
+		attrContact := &domain.Contact{
+			Email:      "attrs@example.com",
+			Attributes: domain.MapOfAny{"plan": " pro ", "seats": 3},
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(workspace, nil)
+		mockRepo.EXPECT().UpsertContact(ctx, workspaceID, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, workspaceID string, contact *domain.Contact) (bool, error) {
+				assert.Equal(t, domain.MapOfAny{"plan": "pro", "seats": 3.0}, contact.Attributes)
+				if assert.NotNil(t, contact.CustomString1) {
+					assert.Equal(t, "pro", contact.CustomString1.String)
+				}
+				return true, nil
+			})
+
+		result := service.UpsertContact(ctx, workspaceID, attrContact)
+		assert.Equal(t, domain.UpsertContactOperationCreate, result.Action)
+		assert.Empty(t, result.Error)
+	})
+
+	t.Run("upsert with unknown attribute", func(t *testing.T) {
+		attrContact := &domain.Contact{
+			Email:      "unknown@example.com",
+			Attributes: domain.MapOfAny{"plan": "pro"},
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(&domain.Workspace{ID: workspaceID}, nil)
+		mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger)
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		result := service.UpsertContact(ctx, workspaceID, attrContact)
+		assert.Equal(t, domain.UpsertContactOperationError, result.Action)
+		assert.Contains(t, result.Error, "unknown contact attribute: plan")
+	})
 }
 
 func TestContactService_BatchImportContacts(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	service, mockRepo, _, mockAuthService, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
+	service, mockRepo, _, mockAuthService, _, _, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
 
 	ctx := context.Background()
 	workspaceID := "workspace123"
@@ -682,7 +791,7 @@ func TestContactService_BatchImportContacts_WithBulkOperations(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	service, mockRepo, _, mockAuthService, _, _, mockContactListRepo, _, mockLogger := createContactServiceWithMocks(ctrl)
+	service, mockRepo, _, mockAuthService, _, _, mockContactListRepo, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
 
 	ctx := context.Background()
 	workspaceID := "workspace123"
@@ -872,7 +981,7 @@ func TestContactService_BatchImportContacts_DuplicateEmails(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	service, mockRepo, _, mockAuthService, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+	service, mockRepo, _, mockAuthService, _, _, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
 
 	ctx := context.Background()
 	workspaceID := "workspace123"
@@ -975,7 +1084,7 @@ func TestContactService_BatchImportContacts_Chunking(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	service, mockRepo, _, mockAuthService, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
+	service, mockRepo, _, mockAuthService, _, _, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
 
 	ctx := context.Background()
 	workspaceID := "workspace123"
@@ -1087,7 +1196,7 @@ func TestContactService_CountContacts(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	service, mockRepo, _, mockAuthService, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
+	service, mockRepo, _, mockAuthService, _, _, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
 
 	ctx := context.Background()
 	workspaceID := "workspace123"
@@ -1149,3 +1258,240 @@ func TestContactService_CountContacts(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to count contacts")
 	})
 }
+
+func TestContactService_EmailVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo, mockWorkspaceRepo, mockAuthService, _, _, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
+	mockVerificationService := mocks.NewMockEmailVerificationService(ctrl)
+	service.SetEmailVerificationService(mockVerificationService)
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+	workspace := &domain.Workspace{ID: workspaceID}
+
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user123",
+		WorkspaceID: workspaceID,
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceContacts: {Read: true, Write: true},
+		},
+	}
+
+	risky := &domain.EmailVerification{Status: domain.EmailVerificationStatusRisky, Reasons: []string{"role_account"}}
+	invalid := &domain.EmailVerification{Status: domain.EmailVerificationStatusInvalid, Reasons: []string{"no_mx"}}
+
+	t.Run("upsert stores the verification", func(t *testing.T) {
+		contact := &domain.Contact{Email: "info@example.com"}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(workspace, nil)
+		mockVerificationService.EXPECT().VerifyForIngestion(ctx, workspace, "info@example.com", true).Return(risky, nil)
+		mockRepo.EXPECT().UpsertContact(ctx, workspaceID, contact).Return(true, nil)
+		mockRepo.EXPECT().UpdateEmailVerifications(ctx, workspaceID, map[string]*domain.EmailVerification{"info@example.com": risky}).Return(nil)
+
+		result := service.UpsertContact(ctx, workspaceID, contact)
+		assert.Equal(t, domain.UpsertContactOperationCreate, result.Action)
+		assert.Empty(t, result.Error)
+	})
+
+	t.Run("upsert rejects a blocked address", func(t *testing.T) {
+		contact := &domain.Contact{Email: "john@nowhere.test"}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(workspace, nil)
+		mockVerificationService.EXPECT().VerifyForIngestion(ctx, workspace, "john@nowhere.test", true).
+			Return(invalid, &domain.ErrEmailVerificationBlocked{Email: "john@nowhere.test", Verification: invalid})
+		mockLogger.EXPECT().WithField("email", "john@nowhere.test").Return(mockLogger)
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		result := service.UpsertContact(ctx, workspaceID, contact)
+		assert.Equal(t, domain.UpsertContactOperationError, result.Action)
+		assert.Contains(t, result.Error, "failed verification (invalid)")
+	})
+
+	t.Run("import skips the probe and records blocked addresses", func(t *testing.T) {
+		contacts := []*domain.Contact{
+			{Email: "info@example.com"},
+			{Email: "john@nowhere.test"},
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(workspace, nil).Times(1)
+		mockVerificationService.EXPECT().VerifyForIngestion(ctx, workspace, "info@example.com", false).Return(risky, nil)
+		mockVerificationService.EXPECT().VerifyForIngestion(ctx, workspace, "john@nowhere.test", false).
+			Return(invalid, &domain.ErrEmailVerificationBlocked{Email: "john@nowhere.test", Verification: invalid})
+		mockRepo.EXPECT().BulkUpsertContacts(ctx, workspaceID, []*domain.Contact{contacts[0]}).
+			Return([]domain.BulkUpsertResult{{Email: "info@example.com", IsNew: true}}, nil)
+		mockRepo.EXPECT().UpdateEmailVerifications(ctx, workspaceID, map[string]*domain.EmailVerification{"info@example.com": risky}).Return(nil)
+
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil)
+		assert.Empty(t, response.Error)
+		assert.Len(t, response.Operations, 2)
+		assert.Equal(t, domain.UpsertContactOperationError, response.Operations[0].Action)
+		assert.Contains(t, response.Operations[0].Error, "invalid contact at index 1")
+		assert.Equal(t, domain.UpsertContactOperationCreate, response.Operations[1].Action)
+	})
+}
+
+func TestContactService_GetContactByEmail_Alias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo, _, mockAuthService, _, _, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+
+	ctx := context.Background()
+	userWorkspace := &domain.UserWorkspace{
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceContacts: {Read: true, Write: true},
+		},
+	}
+	contact := &domain.Contact{Email: "new@example.com"}
+
+	mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, "workspace123").Return(ctx, &domain.User{}, userWorkspace, nil)
+	mockRepo.EXPECT().GetContactByEmail(ctx, "workspace123", "old@example.com").Return(nil, domain.ErrContactNotFound)
+	mockRepo.EXPECT().ResolveContactAlias(ctx, "workspace123", "old@example.com").Return("new@example.com", nil)
+	mockRepo.EXPECT().GetContactByEmail(ctx, "workspace123", "new@example.com").Return(contact, nil)
+
+	result, err := service.GetContactByEmail(ctx, "workspace123", "old@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, contact, result)
+}
+
+func TestContactService_MergeContacts(t *testing.T) {
+	ctx := context.Background()
+	writeWorkspace := &domain.UserWorkspace{
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceContacts: {Read: true, Write: true},
+		},
+	}
+	req := &domain.MergeContactsRequest{
+		WorkspaceID:    "workspace123",
+		PrimaryEmail:   "john@example.com",
+		SecondaryEmail: "john.doe@example.com",
+		Strategy:       domain.ContactMergeFillEmpty,
+	}
+	primary := &domain.Contact{Email: "john@example.com", FirstName: &domain.NullableString{String: "John"}}
+	secondary := &domain.Contact{
+		Email:     "john.doe@example.com",
+		FirstName: &domain.NullableString{String: "Johnny"},
+		LastName:  &domain.NullableString{String: "Doe"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, mockRepo, _, mockAuthService, _, _, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+
+		merged := &domain.Contact{Email: "john@example.com"}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, "workspace123").Return(ctx, &domain.User{}, writeWorkspace, nil)
+		mockRepo.EXPECT().GetContactByEmail(ctx, "workspace123", "john@example.com").Return(primary, nil)
+		mockRepo.EXPECT().GetContactByEmail(ctx, "workspace123", "john.doe@example.com").Return(secondary, nil)
+		mockRepo.EXPECT().UpsertContact(ctx, "workspace123", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, fields *domain.Contact) (bool, error) {
+				assert.Equal(t, "john@example.com", fields.Email)
+				assert.Nil(t, fields.FirstName)
+				assert.Equal(t, "Doe", fields.LastName.String)
+				return false, nil
+			})
+		mockRepo.EXPECT().MergeContacts(ctx, "workspace123", "john@example.com", "john.doe@example.com").Return(nil)
+		mockRepo.EXPECT().GetContactByEmail(ctx, "workspace123", "john@example.com").Return(merged, nil)
+
+		result, err := service.MergeContacts(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, merged, result)
+	})
+
+	t.Run("secondary not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, mockRepo, _, mockAuthService, _, _, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, "workspace123").Return(ctx, &domain.User{}, writeWorkspace, nil)
+		mockRepo.EXPECT().GetContactByEmail(ctx, "workspace123", "john@example.com").Return(primary, nil)
+		mockRepo.EXPECT().GetContactByEmail(ctx, "workspace123", "john.doe@example.com").Return(nil, domain.ErrContactNotFound)
+
+		_, err := service.MergeContacts(ctx, req)
+		assert.ErrorIs(t, err, domain.ErrContactNotFound)
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, _, _, mockAuthService, _, _, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+
+		readOnly := &domain.UserWorkspace{
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceContacts: {Read: true, Write: false},
+			},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, "workspace123").Return(ctx, &domain.User{}, readOnly, nil)
+
+		_, err := service.MergeContacts(ctx, req)
+		var permErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permErr)
+	})
+}
+
+func TestContactService_ChangeContactEmail(t *testing.T) {
+	ctx := context.Background()
+	writeWorkspace := &domain.UserWorkspace{
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceContacts: {Read: true, Write: true},
+		},
+	}
+	req := &domain.ChangeContactEmailRequest{
+		WorkspaceID: "workspace123",
+		Email:       "old@example.com",
+		NewEmail:    "new@example.com",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, mockRepo, _, mockAuthService, _, _, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+
+		contact := &domain.Contact{Email: "new@example.com"}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, "workspace123").Return(ctx, &domain.User{}, writeWorkspace, nil)
+		mockRepo.EXPECT().ChangeContactEmail(ctx, "workspace123", "old@example.com", "new@example.com").Return(nil)
+		mockRepo.EXPECT().GetContactByEmail(ctx, "workspace123", "new@example.com").Return(contact, nil)
+
+		result, err := service.ChangeContactEmail(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, contact, result)
+	})
+
+	t.Run("new email already used", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, mockRepo, _, mockAuthService, _, _, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, "workspace123").Return(ctx, &domain.User{}, writeWorkspace, nil)
+		mockRepo.EXPECT().ChangeContactEmail(ctx, "workspace123", "old@example.com", "new@example.com").Return(domain.ErrContactEmailInUse)
+
+		_, err := service.ChangeContactEmail(ctx, req)
+		var validationErr domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, err.Error(), "merge the contacts instead")
+	})
+
+	t.Run("rejected by email verification", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, _, mockWorkspaceRepo, mockAuthService, _, _, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+		mockVerification := mocks.NewMockEmailVerificationService(ctrl)
+		service.SetEmailVerificationService(mockVerification)
+
+		workspace := &domain.Workspace{ID: "workspace123"}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, "workspace123").Return(ctx, &domain.User{}, writeWorkspace, nil)
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace123").Return(workspace, nil)
+		mockVerification.EXPECT().VerifyForIngestion(ctx, workspace, "new@example.com", true).
+			Return(nil, &domain.ErrEmailVerificationBlocked{Email: "new@example.com", Verification: &domain.EmailVerification{Status: domain.EmailVerificationStatusInvalid}})
+
+		_, err := service.ChangeContactEmail(ctx, req)
+		var validationErr domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
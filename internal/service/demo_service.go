@@ -278,6 +278,8 @@ func (s *DemoService) createDemoWorkspace(ctx context.Context) error {
 		"https://webhook.site/demo",
 		domain.WebhookEventTypes, // Subscribe to all event types
 		nil,
+		nil,
+		nil,
 	)
 	if err != nil {
 		s.logger.WithField("workspace_id", workspace.ID).WithField("error", err.Error()).Warn("Failed to create demo webhook subscription")
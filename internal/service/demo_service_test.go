@@ -276,7 +276,7 @@ func TestDemoService_SubscribeContactsToList_Success(t *testing.T) {
 	mockInboundWebhookEventRepo := domainmocks.NewMockInboundWebhookEventRepository(ctrl)
 	mockContactTimelineRepo := domainmocks.NewMockContactTimelineRepository(ctrl)
 	mockCache := pkgmocks.NewMockCache(ctrl)
-	contactSvc := NewContactService(mockContactRepo, mockWorkspaceRepo, mockAuth, mockMessageHistoryRepo, mockInboundWebhookEventRepo, mockContactListRepo, mockContactTimelineRepo, logger.NewLoggerWithLevel("disabled"))
+	contactSvc := NewContactService(mockContactRepo, mockWorkspaceRepo, mockAuth, mockMessageHistoryRepo, mockInboundWebhookEventRepo, mockContactListRepo, mockContactTimelineRepo, domainmocks.NewMockBlogCommentRepository(ctrl), domainmocks.NewMockContactBulkOperationRepository(ctrl), logger.NewLoggerWithLevel("disabled"))
 	listSvc := NewListService(mockListRepo, mockWorkspaceRepo, mockContactListRepo, mockContactRepo, mockMessageHistoryRepo, mockAuth, mockEmail, logger.NewLoggerWithLevel("disabled"), "https://api.test", mockCache)
 
 	svc := &DemoService{
@@ -316,7 +316,7 @@ func TestDemoService_SubscribeContactsToList_Success(t *testing.T) {
 	// Check existing subscription (not found)
 	mockContactListRepo.EXPECT().GetContactListByIDs(ctx, "demo", gomock.Any(), "newsletter").Return(nil, &domain.ErrContactListNotFound{Message: "not found"}).Times(2)
 	// Add to list
-	mockContactListRepo.EXPECT().AddContactToList(ctx, "demo", gomock.Any()).Return(nil).Times(2)
+	mockContactListRepo.EXPECT().AddContactToList(gomock.Any(), "demo", gomock.Any()).Return(nil).Times(2)
 
 	err := svc.subscribeContactsToList(ctx, "demo", "newsletter")
 	assert.NoError(t, err)
@@ -317,6 +317,7 @@ func (s *EmailService) SendEmailForTemplate(ctx context.Context, request domain.
 		MessageID:        request.MessageID,
 		TemplateData:     request.MessageData.Data,
 		TrackingSettings: trackingSettings,
+		SyncedBlocks:     workspace.Settings.SyncedBlocks(),
 	}
 	// Wires the resolved variant's tree/source + its inbox-preview override;
 	// an explicit per-send override from EmailOptions still wins.
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/emailverifier"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// EmailVerifier is the address verifier used by EmailVerificationService,
+// implemented by *emailverifier.Verifier
+type EmailVerifier interface {
+	Verify(ctx context.Context, email string, opts emailverifier.Options) *emailverifier.Result
+}
+
+type EmailVerificationService struct {
+	verifier    EmailVerifier
+	contactRepo domain.ContactRepository
+	authService domain.AuthService
+	logger      logger.Logger
+}
+
+func NewEmailVerificationService(
+	verifier EmailVerifier,
+	contactRepo domain.ContactRepository,
+	authService domain.AuthService,
+	logger logger.Logger,
+) *EmailVerificationService {
+	return &EmailVerificationService{
+		verifier:    verifier,
+		contactRepo: contactRepo,
+		authService: authService,
+		logger:      logger,
+	}
+}
+
+func (s *EmailVerificationService) verify(ctx context.Context, email string, smtpProbe bool) *domain.EmailVerification {
+	result := s.verifier.Verify(ctx, email, emailverifier.Options{SMTPProbe: smtpProbe})
+	return &domain.EmailVerification{
+		Status:     domain.EmailVerificationStatus(result.Status),
+		Reasons:    result.Reasons,
+		Suggestion: result.Suggestion,
+		CatchAll:   result.CatchAll,
+		VerifiedAt: time.Now().UTC(),
+	}
+}
+
+// VerifyEmail verifies an address on demand and stores the result on the matching contact
+func (s *EmailVerificationService) VerifyEmail(ctx context.Context, workspaceID string, email string) (*domain.EmailVerification, error) {
+	var err error
+	var userWorkspace *domain.UserWorkspace
+	ctx, _, userWorkspace, err = s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Storing the result updates the contact
+	if !userWorkspace.HasPermission(domain.PermissionResourceContacts, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceContacts,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to contacts required",
+		)
+	}
+
+	email = domain.NormalizeEmail(email)
+	verification := s.verify(ctx, email, true)
+
+	// Unknown emails are ignored by the repository
+	if err := s.contactRepo.UpdateEmailVerifications(ctx, workspaceID, map[string]*domain.EmailVerification{email: verification}); err != nil {
+		s.logger.WithField("email", email).Error(fmt.Sprintf("Failed to store email verification: %v", err))
+		return nil, fmt.Errorf("failed to store email verification: %w", err)
+	}
+
+	return verification, nil
+}
+
+// VerifyForIngestion verifies an address entering the workspace according to its email verification settings
+func (s *EmailVerificationService) VerifyForIngestion(ctx context.Context, workspace *domain.Workspace, email string, allowSMTPProbe bool) (*domain.EmailVerification, error) {
+	settings := workspace.Settings.EmailVerification
+	if settings == nil || !settings.Enabled {
+		return nil, nil
+	}
+
+	email = strings.TrimSpace(email)
+	verification := s.verify(ctx, email, allowSMTPProbe && settings.SMTPProbe)
+
+	if settings.Blocks(verification.Status) {
+		return verification, &domain.ErrEmailVerificationBlocked{Email: email, Verification: verification}
+	}
+
+	return verification, nil
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/emailverifier"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEmailVerifier struct {
+	result *emailverifier.Result
+	opts   []emailverifier.Options
+}
+
+func (v *fakeEmailVerifier) Verify(ctx context.Context, email string, opts emailverifier.Options) *emailverifier.Result {
+	v.opts = append(v.opts, opts)
+	return v.result
+}
+
+func TestEmailVerificationService_VerifyForIngestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	verifier := &fakeEmailVerifier{result: &emailverifier.Result{
+		Status:     emailverifier.StatusRisky,
+		Reasons:    []string{emailverifier.ReasonPossibleTypo},
+		Suggestion: "john@gmail.com",
+	}}
+	service := NewEmailVerificationService(verifier, mocks.NewMockContactRepository(ctrl), mocks.NewMockAuthService(ctrl), pkgmocks.NewMockLogger(ctrl))
+
+	workspaceWith := func(settings *domain.EmailVerificationSettings) *domain.Workspace {
+		return &domain.Workspace{ID: "ws", Settings: domain.WorkspaceSettings{EmailVerification: settings}}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		verification, err := service.VerifyForIngestion(ctx, workspaceWith(nil), "john@gmial.com", true)
+		require.NoError(t, err)
+		assert.Nil(t, verification)
+
+		verification, err = service.VerifyForIngestion(ctx, workspaceWith(&domain.EmailVerificationSettings{Mode: domain.EmailVerificationModeBlock}), "john@gmial.com", true)
+		require.NoError(t, err)
+		assert.Nil(t, verification)
+		assert.Empty(t, verifier.opts)
+	})
+
+	t.Run("flag mode", func(t *testing.T) {
+		verifier.opts = nil
+		settings := &domain.EmailVerificationSettings{Enabled: true, Mode: domain.EmailVerificationModeFlag, SMTPProbe: true}
+
+		verification, err := service.VerifyForIngestion(ctx, workspaceWith(settings), "john@gmial.com", true)
+		require.NoError(t, err)
+		require.NotNil(t, verification)
+		assert.Equal(t, domain.EmailVerificationStatusRisky, verification.Status)
+		assert.Equal(t, "john@gmail.com", verification.Suggestion)
+		assert.False(t, verification.VerifiedAt.IsZero())
+		assert.Equal(t, []emailverifier.Options{{SMTPProbe: true}}, verifier.opts)
+	})
+
+	t.Run("bulk callers skip the probe", func(t *testing.T) {
+		verifier.opts = nil
+		settings := &domain.EmailVerificationSettings{Enabled: true, Mode: domain.EmailVerificationModeFlag, SMTPProbe: true}
+
+		_, err := service.VerifyForIngestion(ctx, workspaceWith(settings), "john@gmial.com", false)
+		require.NoError(t, err)
+		assert.Equal(t, []emailverifier.Options{{SMTPProbe: false}}, verifier.opts)
+	})
+
+	t.Run("block mode", func(t *testing.T) {
+		settings := &domain.EmailVerificationSettings{
+			Enabled:         true,
+			Mode:            domain.EmailVerificationModeBlock,
+			BlockedStatuses: []domain.EmailVerificationStatus{domain.EmailVerificationStatusRisky},
+		}
+
+		verification, err := service.VerifyForIngestion(ctx, workspaceWith(settings), "john@gmial.com", true)
+		var blocked *domain.ErrEmailVerificationBlocked
+		require.True(t, errors.As(err, &blocked))
+		assert.Equal(t, verification, blocked.Verification)
+		assert.Equal(t, "john@gmial.com", blocked.Email)
+	})
+}
+
+func TestEmailVerificationService_VerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	verifier := &fakeEmailVerifier{result: &emailverifier.Result{Status: emailverifier.StatusValid}}
+	service := NewEmailVerificationService(verifier, mockContactRepo, mockAuthService, mockLogger)
+
+	writer := &domain.UserWorkspace{
+		WorkspaceID: "ws",
+		Permissions: domain.UserPermissions{domain.PermissionResourceContacts: {Read: true, Write: true}},
+	}
+	reader := &domain.UserWorkspace{
+		WorkspaceID: "ws",
+		Permissions: domain.UserPermissions{domain.PermissionResourceContacts: {Read: true}},
+	}
+
+	t.Run("stores the result with a probe", func(t *testing.T) {
+		verifier.opts = nil
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws").Return(ctx, &domain.User{}, writer, nil)
+		mockContactRepo.EXPECT().UpdateEmailVerifications(ctx, "ws", gomock.Any()).DoAndReturn(
+			func(ctx context.Context, workspaceID string, verifications map[string]*domain.EmailVerification) error {
+				require.Contains(t, verifications, "john@example.com")
+				assert.Equal(t, domain.EmailVerificationStatusValid, verifications["john@example.com"].Status)
+				return nil
+			})
+
+		verification, err := service.VerifyEmail(ctx, "ws", " John@Example.com ")
+		require.NoError(t, err)
+		assert.Equal(t, domain.EmailVerificationStatusValid, verification.Status)
+		assert.Equal(t, []emailverifier.Options{{SMTPProbe: true}}, verifier.opts)
+	})
+
+	t.Run("requires write permission", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws").Return(ctx, &domain.User{}, reader, nil)
+
+		_, err := service.VerifyEmail(ctx, "ws", "john@example.com")
+		var permErr *domain.PermissionError
+		assert.True(t, errors.As(err, &permErr))
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws").Return(ctx, &domain.User{}, writer, nil)
+		mockContactRepo.EXPECT().UpdateEmailVerifications(ctx, "ws", gomock.Any()).Return(errors.New("db down"))
+		mockLogger.EXPECT().WithField("email", "john@example.com").Return(mockLogger)
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		_, err := service.VerifyEmail(ctx, "ws", "john@example.com")
+		assert.ErrorContains(t, err, "failed to store email verification")
+	})
+}
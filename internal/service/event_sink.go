@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// eventSinkTimeout bounds the connection and publication of a batch
+const eventSinkTimeout = 30 * time.Second
+
+// eventSinkFactory creates the Kafka, NATS and Postgres sinks of webhook subscriptions
+type eventSinkFactory struct {
+	workspaceRepo domain.WorkspaceRepository
+	secretKey     string
+}
+
+// NewEventSinkFactory creates the default event sink factory
+func NewEventSinkFactory(workspaceRepo domain.WorkspaceRepository, secretKey string) domain.EventSinkFactory {
+	return &eventSinkFactory{
+		workspaceRepo: workspaceRepo,
+		secretKey:     secretKey,
+	}
+}
+
+// NewEventSink creates the sink described by the settings. The credentials are decrypted
+// on a copy of the settings, the subscription keeps only their encrypted form.
+func (f *eventSinkFactory) NewEventSink(ctx context.Context, workspaceID string, settings *domain.EventSinkSettings) (domain.EventSink, error) {
+	if settings == nil {
+		return nil, fmt.Errorf("sink settings are required")
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	settings = settings.Clone()
+	if err := settings.DecryptSecretKeys(f.secretKey); err != nil {
+		return nil, err
+	}
+
+	switch settings.Type {
+	case domain.EventSinkTypeKafka:
+		return newKafkaEventSink(settings.Kafka, eventSinkTimeout), nil
+	case domain.EventSinkTypeNATS:
+		return newNATSEventSink(settings.NATS, eventSinkTimeout), nil
+	case domain.EventSinkTypePostgres:
+		db, err := f.workspaceRepo.GetConnection(ctx, workspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+		}
+		return newPostgresEventSink(db, settings.Postgres.Channel), nil
+	default:
+		return nil, fmt.Errorf("unsupported sink type: %s", settings.Type)
+	}
+}
+
+// buildEventSinkMessage wraps a delivery in the webhook envelope and signs it with the
+// subscription secret, exactly like an HTTP webhook request
+func buildEventSinkMessage(workspaceID string, delivery *domain.WebhookDelivery, secret []byte) (*domain.EventSinkMessage, error) {
+	body, err := buildWebhookEnvelope(delivery.ID, delivery.EventType, workspaceID, delivery.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := time.Now().Unix()
+	return &domain.EventSinkMessage{
+		ID:        delivery.ID,
+		EventType: delivery.EventType,
+		Key:       domain.WebhookPayloadContactEmail(delivery.Payload),
+		Timestamp: timestamp,
+		Signature: signPayload(delivery.ID, timestamp, body, secret),
+		Body:      body,
+	}, nil
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// natsEventSink publishes with the NATS client over a single connection, which keeps the
+// messages in order. Messages carry the Standard Webhooks metadata as NATS headers
+// (NATS 2.2+). Without JetStream, a batch is confirmed by a flush round trip; with
+// JetStream every message waits for its ack, and the Nats-Msg-Id header lets the stream
+// drop the duplicates of a retried batch.
+type natsEventSink struct {
+	settings *domain.NATSSinkSettings
+	timeout  time.Duration
+
+	mu   sync.Mutex
+	conn *nats.Conn
+	js   jetstream.JetStream
+}
+
+func newNATSEventSink(settings *domain.NATSSinkSettings, timeout time.Duration) *natsEventSink {
+	return &natsEventSink{
+		settings: settings,
+		timeout:  timeout,
+	}
+}
+
+// Publish publishes the batch and waits for the server confirmation. Any error drops
+// the connection, the next batch reconnects.
+func (s *natsEventSink) Publish(ctx context.Context, messages []*domain.EventSinkMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := s.publish(ctx, messages); err != nil {
+		s.closeConn()
+		return err
+	}
+	return nil
+}
+
+func (s *natsEventSink) publish(ctx context.Context, messages []*domain.EventSinkMessage) error {
+	if s.conn == nil || s.conn.IsClosed() {
+		if err := s.connect(); err != nil {
+			return err
+		}
+	}
+
+	if s.js != nil {
+		return s.publishJetStream(ctx, messages)
+	}
+
+	for _, msg := range messages {
+		if err := s.conn.PublishMsg(buildNATSMsg(msg, s.settings.Subject)); err != nil {
+			return fmt.Errorf("failed to publish to nats: %w", err)
+		}
+	}
+	if err := s.conn.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to flush nats connection: %w", err)
+	}
+	// Publications are not acknowledged: a permissions violation is reported
+	// asynchronously, before the flush completes
+	if err := s.conn.LastError(); err != nil {
+		return fmt.Errorf("nats server error: %w", err)
+	}
+	return nil
+}
+
+// publishJetStream publishes the batch asynchronously, in order, and waits for the ack
+// of every message
+func (s *natsEventSink) publishJetStream(ctx context.Context, messages []*domain.EventSinkMessage) error {
+	futures := make([]jetstream.PubAckFuture, 0, len(messages))
+	for i, msg := range messages {
+		future, err := s.js.PublishMsgAsync(buildNATSMsg(msg, s.settings.Subject))
+		if err != nil {
+			return fmt.Errorf("failed to publish message %d to jetstream: %w", i+1, err)
+		}
+		futures = append(futures, future)
+	}
+
+	for i, future := range futures {
+		select {
+		case <-future.Ok():
+		case err := <-future.Err():
+			return fmt.Errorf("jetstream rejected message %d: %w", i+1, err)
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the jetstream ack of message %d: %w", i+1, ctx.Err())
+		}
+	}
+	return nil
+}
+
+// connect opens the connection and authenticates. The URL scheme selects TLS.
+func (s *natsEventSink) connect() error {
+	options := []nats.Option{
+		nats.Name("notifuse"),
+		nats.Timeout(s.timeout),
+	}
+	if s.settings.Username != "" {
+		options = append(options, nats.UserInfo(s.settings.Username, s.settings.Password))
+	}
+	if s.settings.Token != "" {
+		options = append(options, nats.Token(s.settings.Token))
+	}
+
+	conn, err := nats.Connect(s.settings.URL, options...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to nats: %w", err)
+	}
+	if !conn.HeadersSupported() {
+		conn.Close()
+		return fmt.Errorf("nats server does not support headers (NATS 2.2+ is required)")
+	}
+
+	s.conn = conn
+	s.js = nil
+	if s.settings.JetStream {
+		js, err := jetstream.New(conn)
+		if err != nil {
+			conn.Close()
+			s.conn = nil
+			return fmt.Errorf("failed to create jetstream context: %w", err)
+		}
+		s.js = js
+	}
+	return nil
+}
+
+// Close closes the connection
+func (s *natsEventSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeConn()
+	return nil
+}
+
+func (s *natsEventSink) closeConn() {
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	s.conn = nil
+	s.js = nil
+}
+
+// buildNATSMsg maps a message to a NATS message on `{subject}.{event_type}`, the
+// Standard Webhooks metadata and the JetStream deduplication ID as headers
+func buildNATSMsg(msg *domain.EventSinkMessage, subject string) *nats.Msg {
+	header := nats.Header{}
+	for name, value := range msg.Headers() {
+		header[name] = []string{value}
+	}
+	header[jetstream.MsgIDHeader] = []string{msg.ID}
+
+	return &nats.Msg{
+		Subject: msg.Subject(subject),
+		Header:  header,
+		Data:    msg.Body,
+	}
+}
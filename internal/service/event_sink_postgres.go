@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// postgresNotifyMaxPayload is the NOTIFY payload limit (8000 bytes) minus some margin
+const postgresNotifyMaxPayload = 7900
+
+// postgresEventSink sends events with pg_notify on a channel of the workspace database.
+// A batch is sent in one transaction: listeners receive it at commit, in order, or not
+// at all.
+type postgresEventSink struct {
+	db      *sql.DB
+	channel string
+}
+
+func newPostgresEventSink(db *sql.DB, channel string) *postgresEventSink {
+	return &postgresEventSink{
+		db:      db,
+		channel: channel,
+	}
+}
+
+// postgresNotification is the NOTIFY payload. Event holds the signed envelope bytes;
+// it is omitted (Truncated) when the notification would exceed the NOTIFY limit, the
+// listener then fetches the delivery with webhookSubscriptions.deliveries.
+type postgresNotification struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Key       string          `json:"key,omitempty"`
+	Timestamp int64           `json:"timestamp"`
+	Signature string          `json:"signature"`
+	Event     json.RawMessage `json:"event,omitempty"`
+	Truncated bool            `json:"truncated,omitempty"`
+}
+
+// Publish notifies the messages in one transaction
+func (s *postgresEventSink) Publish(ctx context.Context, messages []*domain.EventSinkMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin notify transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, msg := range messages {
+		payload, err := buildPostgresNotification(msg)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `SELECT pg_notify($1, $2)`, s.channel, string(payload)); err != nil {
+			return fmt.Errorf("failed to notify channel %s: %w", s.channel, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit notify transaction: %w", err)
+	}
+	return nil
+}
+
+// Close releases nothing, the workspace connection pool is shared
+func (s *postgresEventSink) Close() error {
+	return nil
+}
+
+func buildPostgresNotification(msg *domain.EventSinkMessage) ([]byte, error) {
+	notification := postgresNotification{
+		ID:        msg.ID,
+		Type:      msg.EventType,
+		Key:       msg.Key,
+		Timestamp: msg.Timestamp,
+		Signature: msg.Signature,
+		Event:     json.RawMessage(msg.Body),
+	}
+
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode notification: %w", err)
+	}
+	if len(payload) <= postgresNotifyMaxPayload {
+		return payload, nil
+	}
+
+	notification.Event = nil
+	notification.Truncated = true
+	payload, err = json.Marshal(notification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode notification: %w", err)
+	}
+	return payload, nil
+}
@@ -0,0 +1,455 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+)
+
+func testEventSinkMessages() []*domain.EventSinkMessage {
+	return []*domain.EventSinkMessage{
+		{ID: "d1", EventType: "contact.created", Key: "a@example.com", Timestamp: 1700000000, Signature: "v1,sig1", Body: []byte(`{"id":"d1"}`)},
+		{ID: "d2", EventType: "email.sent", Timestamp: 1700000001, Signature: "v1,sig2", Body: []byte(`{"id":"d2"}`)},
+	}
+}
+
+func TestBuildEventSinkMessage(t *testing.T) {
+	key := []byte("abcdefghijklmnopqrstuvwxyz012345")
+	delivery := &domain.WebhookDelivery{
+		ID:        "delivery1",
+		EventType: "contact.updated",
+		Payload: map[string]interface{}{
+			"contact": map[string]interface{}{"email": "john@example.com"},
+		},
+	}
+
+	msg, err := buildEventSinkMessage("ws1", delivery, key)
+	require.NoError(t, err)
+
+	assert.Equal(t, "delivery1", msg.ID)
+	assert.Equal(t, "contact.updated", msg.EventType)
+	assert.Equal(t, "john@example.com", msg.Key)
+	assert.Equal(t, signPayload("delivery1", msg.Timestamp, msg.Body, key), msg.Signature)
+
+	var envelope map[string]interface{}
+	require.NoError(t, json.Unmarshal(msg.Body, &envelope))
+	assert.Equal(t, "delivery1", envelope["id"])
+	assert.Equal(t, "contact.updated", envelope["type"])
+	assert.Equal(t, "ws1", envelope["workspace_id"])
+}
+
+func TestEventSinkFactory_NewEventSink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	factory := NewEventSinkFactory(mockWorkspaceRepo, "test-secret-key")
+	ctx := context.Background()
+
+	t.Run("creates kafka sink", func(t *testing.T) {
+		sink, err := factory.NewEventSink(ctx, "ws1", &domain.EventSinkSettings{
+			Type:  domain.EventSinkTypeKafka,
+			Kafka: &domain.KafkaSinkSettings{Brokers: []string{"localhost:9092"}, Topic: "events"},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, &kafkaEventSink{}, sink)
+	})
+
+	t.Run("creates nats sink", func(t *testing.T) {
+		sink, err := factory.NewEventSink(ctx, "ws1", &domain.EventSinkSettings{
+			Type: domain.EventSinkTypeNATS,
+			NATS: &domain.NATSSinkSettings{URL: "nats://localhost:4222", Subject: "events"},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, &natsEventSink{}, sink)
+	})
+
+	t.Run("decrypts the credentials without changing the settings", func(t *testing.T) {
+		settings := &domain.EventSinkSettings{
+			Type: domain.EventSinkTypeNATS,
+			NATS: &domain.NATSSinkSettings{URL: "nats://localhost:4222", Subject: "events", Token: "tok"},
+		}
+		require.NoError(t, settings.EncryptSecretKeys("test-secret-key"))
+
+		sink, err := factory.NewEventSink(ctx, "ws1", settings)
+		require.NoError(t, err)
+		assert.Equal(t, "tok", sink.(*natsEventSink).settings.Token)
+		assert.Empty(t, settings.NATS.Token)
+	})
+
+	t.Run("creates postgres sink on the workspace database", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mockWorkspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+
+		sink, err := factory.NewEventSink(ctx, "ws1", &domain.EventSinkSettings{
+			Type:     domain.EventSinkTypePostgres,
+			Postgres: &domain.PostgresSinkSettings{Channel: "events"},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, &postgresEventSink{}, sink)
+	})
+
+	t.Run("rejects invalid settings", func(t *testing.T) {
+		_, err := factory.NewEventSink(ctx, "ws1", &domain.EventSinkSettings{Type: "sqs"})
+		assert.Error(t, err)
+
+		_, err = factory.NewEventSink(ctx, "ws1", nil)
+		assert.Error(t, err)
+	})
+}
+
+// runKafkaTestCluster starts an in-process Kafka cluster with the given topic
+func runKafkaTestCluster(t *testing.T, topic string, opts ...kfake.Opt) *kfake.Cluster {
+	cluster, err := kfake.NewCluster(append([]kfake.Opt{kfake.NumBrokers(1), kfake.SeedTopics(3, topic)}, opts...)...)
+	require.NoError(t, err)
+	t.Cleanup(cluster.Close)
+	return cluster
+}
+
+// consumeKafkaRecords reads the first records of a topic
+func consumeKafkaRecords(t *testing.T, brokers []string, topic string, count int, opts ...kgo.Opt) []*kgo.Record {
+	client, err := kgo.NewClient(append([]kgo.Opt{
+		kgo.SeedBrokers(brokers...),
+		kgo.ConsumeTopics(topic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	}, opts...)...)
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var records []*kgo.Record
+	for len(records) < count {
+		fetches := client.PollFetches(ctx)
+		require.NoError(t, ctx.Err(), "timed out waiting for kafka records")
+		records = append(records, fetches.Records()...)
+	}
+	return records
+}
+
+func TestKafkaEventSink_Publish(t *testing.T) {
+	ctx := context.Background()
+	topic := "notifuse.events"
+
+	t.Run("produces keyed records with webhook headers", func(t *testing.T) {
+		cluster := runKafkaTestCluster(t, topic)
+		brokers := cluster.ListenAddrs()
+
+		sink := newKafkaEventSink(&domain.KafkaSinkSettings{Brokers: brokers, Topic: topic}, 5*time.Second)
+		defer sink.Close()
+		require.NoError(t, sink.Publish(ctx, testEventSinkMessages()))
+
+		records := consumeKafkaRecords(t, brokers, topic, 2)
+		require.Len(t, records, 2)
+
+		byID := make(map[string]*kgo.Record)
+		for _, record := range records {
+			headers := make(map[string]string)
+			for _, h := range record.Headers {
+				headers[h.Key] = string(h.Value)
+			}
+			byID[headers["webhook-id"]] = record
+			if headers["webhook-id"] == "d1" {
+				assert.Equal(t, "1700000000", headers["webhook-timestamp"])
+				assert.Equal(t, "v1,sig1", headers["webhook-signature"])
+			}
+		}
+		require.Contains(t, byID, "d1")
+		require.Contains(t, byID, "d2")
+		assert.Equal(t, "a@example.com", string(byID["d1"].Key))
+		assert.Equal(t, `{"id":"d1"}`, string(byID["d1"].Value))
+		assert.Nil(t, byID["d2"].Key)
+	})
+
+	t.Run("keeps the events of a contact in order in one partition", func(t *testing.T) {
+		cluster := runKafkaTestCluster(t, topic)
+		brokers := cluster.ListenAddrs()
+
+		var messages []*domain.EventSinkMessage
+		for i := 0; i < 20; i++ {
+			messages = append(messages, &domain.EventSinkMessage{
+				ID:        fmt.Sprintf("d%d", i),
+				EventType: "contact.updated",
+				Key:       fmt.Sprintf("contact%d@example.com", i%4),
+				Body:      []byte(fmt.Sprintf(`{"seq":%d}`, i)),
+			})
+		}
+
+		sink := newKafkaEventSink(&domain.KafkaSinkSettings{Brokers: brokers, Topic: topic}, 5*time.Second)
+		defer sink.Close()
+		require.NoError(t, sink.Publish(ctx, messages[:10]))
+		require.NoError(t, sink.Publish(ctx, messages[10:]))
+
+		records := consumeKafkaRecords(t, brokers, topic, len(messages))
+		partitions := make(map[string]int32)
+		sequences := make(map[string][]string)
+		for _, record := range records {
+			key := string(record.Key)
+			if partition, ok := partitions[key]; ok {
+				assert.Equal(t, partition, record.Partition, "events of %s spread over partitions", key)
+			}
+			partitions[key] = record.Partition
+			sequences[key] = append(sequences[key], string(record.Value))
+		}
+		for i := 0; i < 4; i++ {
+			key := fmt.Sprintf("contact%d@example.com", i)
+			var expected []string
+			for seq := i; seq < len(messages); seq += 4 {
+				expected = append(expected, fmt.Sprintf(`{"seq":%d}`, seq))
+			}
+			assert.Equal(t, expected, sequences[key])
+		}
+	})
+
+	t.Run("authenticates with sasl", func(t *testing.T) {
+		cluster := runKafkaTestCluster(t, topic, kfake.EnableSASL(), kfake.Superuser("PLAIN", "key", "secret"))
+		brokers := cluster.ListenAddrs()
+
+		sink := newKafkaEventSink(&domain.KafkaSinkSettings{
+			Brokers:       brokers,
+			Topic:         topic,
+			SASLMechanism: domain.KafkaSASLMechanismPlain,
+			Username:      "key",
+			Password:      "secret",
+		}, 5*time.Second)
+		defer sink.Close()
+		require.NoError(t, sink.Publish(ctx, testEventSinkMessages()))
+
+		records := consumeKafkaRecords(t, brokers, topic, 2, kgo.SASL(plain.Auth{User: "key", Pass: "secret"}.AsMechanism()))
+		assert.Len(t, records, 2)
+	})
+
+	t.Run("fails when the topic does not exist", func(t *testing.T) {
+		cluster := runKafkaTestCluster(t, topic)
+
+		sink := newKafkaEventSink(&domain.KafkaSinkSettings{Brokers: cluster.ListenAddrs(), Topic: "missing"}, 2*time.Second)
+		defer sink.Close()
+
+		err := sink.Publish(ctx, testEventSinkMessages())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to produce to kafka")
+	})
+}
+
+// runNATSTestServer starts an embedded NATS server on a random port
+func runNATSTestServer(t *testing.T, opts *server.Options) *server.Server {
+	opts.Host = "127.0.0.1"
+	opts.Port = -1
+	opts.NoLog = true
+	opts.NoSigs = true
+	if opts.JetStream {
+		opts.StoreDir = t.TempDir()
+	}
+
+	srv, err := server.NewServer(opts)
+	require.NoError(t, err)
+	go srv.Start()
+	require.True(t, srv.ReadyForConnections(5*time.Second), "nats server not ready")
+	t.Cleanup(srv.Shutdown)
+	return srv
+}
+
+func TestNATSEventSink_Publish(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("publishes in order with webhook headers", func(t *testing.T) {
+		srv := runNATSTestServer(t, &server.Options{Authorization: "tok"})
+
+		consumer, err := nats.Connect(srv.ClientURL(), nats.Token("tok"))
+		require.NoError(t, err)
+		defer consumer.Close()
+		sub, err := consumer.SubscribeSync("notifuse.>")
+		require.NoError(t, err)
+		require.NoError(t, consumer.Flush())
+
+		sink := newNATSEventSink(&domain.NATSSinkSettings{URL: srv.ClientURL(), Subject: "notifuse", Token: "tok"}, 5*time.Second)
+		defer sink.Close()
+
+		require.NoError(t, sink.Publish(ctx, testEventSinkMessages()))
+
+		first, err := sub.NextMsg(time.Second)
+		require.NoError(t, err)
+		second, err := sub.NextMsg(time.Second)
+		require.NoError(t, err)
+
+		assert.Equal(t, "notifuse.contact.created", first.Subject)
+		assert.Equal(t, "notifuse.email.sent", second.Subject)
+		assert.Equal(t, `{"id":"d1"}`, string(first.Data))
+		assert.Equal(t, "d1", first.Header.Get("webhook-id"))
+		assert.Equal(t, "v1,sig1", first.Header.Get("webhook-signature"))
+		assert.Equal(t, "1700000000", first.Header.Get("webhook-timestamp"))
+		assert.Equal(t, "d1", first.Header.Get("Nats-Msg-Id"))
+	})
+
+	t.Run("authenticates with username and password", func(t *testing.T) {
+		srv := runNATSTestServer(t, &server.Options{Username: "notifuse", Password: "pass"})
+
+		sink := newNATSEventSink(&domain.NATSSinkSettings{URL: srv.ClientURL(), Subject: "notifuse", Username: "notifuse", Password: "pass"}, 5*time.Second)
+		defer sink.Close()
+		require.NoError(t, sink.Publish(ctx, testEventSinkMessages()))
+
+		rejected := newNATSEventSink(&domain.NATSSinkSettings{URL: srv.ClientURL(), Subject: "notifuse", Username: "notifuse", Password: "wrong"}, 5*time.Second)
+		assert.Error(t, rejected.Publish(ctx, testEventSinkMessages()))
+	})
+
+	t.Run("reuses the connection between batches", func(t *testing.T) {
+		srv := runNATSTestServer(t, &server.Options{})
+		sink := newNATSEventSink(&domain.NATSSinkSettings{URL: srv.ClientURL(), Subject: "notifuse"}, 5*time.Second)
+		defer sink.Close()
+
+		require.NoError(t, sink.Publish(ctx, testEventSinkMessages()))
+		conn := sink.conn
+		require.NoError(t, sink.Publish(ctx, testEventSinkMessages()))
+
+		assert.Same(t, conn, sink.conn)
+		assert.Equal(t, 1, srv.NumClients())
+	})
+
+	t.Run("waits for jetstream acks and deduplicates retried batches", func(t *testing.T) {
+		srv := runNATSTestServer(t, &server.Options{JetStream: true})
+
+		admin, err := nats.Connect(srv.ClientURL())
+		require.NoError(t, err)
+		defer admin.Close()
+		js, err := jetstream.New(admin)
+		require.NoError(t, err)
+		stream, err := js.CreateStream(ctx, jetstream.StreamConfig{Name: "EVENTS", Subjects: []string{"notifuse.>"}})
+		require.NoError(t, err)
+
+		sink := newNATSEventSink(&domain.NATSSinkSettings{URL: srv.ClientURL(), Subject: "notifuse", JetStream: true}, 5*time.Second)
+		defer sink.Close()
+
+		require.NoError(t, sink.Publish(ctx, testEventSinkMessages()))
+		require.NoError(t, sink.Publish(ctx, testEventSinkMessages()))
+
+		info, err := stream.Info(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), info.State.Msgs)
+
+		first, err := stream.GetMsg(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "notifuse.contact.created", first.Subject)
+		assert.Equal(t, "d1", first.Header.Get("webhook-id"))
+	})
+
+	t.Run("fails when no jetstream stream captures the subject", func(t *testing.T) {
+		srv := runNATSTestServer(t, &server.Options{JetStream: true})
+		sink := newNATSEventSink(&domain.NATSSinkSettings{URL: srv.ClientURL(), Subject: "notifuse", JetStream: true}, 5*time.Second)
+		defer sink.Close()
+
+		err := sink.Publish(ctx, testEventSinkMessages())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "jetstream rejected message 1")
+		assert.Nil(t, sink.conn)
+	})
+
+	t.Run("returns permission violations and reconnects", func(t *testing.T) {
+		srv := runNATSTestServer(t, &server.Options{
+			Users: []*server.User{{
+				Username:    "notifuse",
+				Password:    "pass",
+				Permissions: &server.Permissions{Publish: &server.SubjectPermission{Deny: []string{"notifuse.>"}}},
+			}},
+		})
+		sink := newNATSEventSink(&domain.NATSSinkSettings{URL: srv.ClientURL(), Subject: "notifuse", Username: "notifuse", Password: "pass"}, 5*time.Second)
+		defer sink.Close()
+
+		err := sink.Publish(ctx, testEventSinkMessages())
+		require.Error(t, err)
+		assert.Contains(t, strings.ToLower(err.Error()), "permissions violation")
+		assert.Nil(t, sink.conn)
+	})
+
+	t.Run("fails when the server is unreachable", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		_ = listener.Close()
+
+		sink := newNATSEventSink(&domain.NATSSinkSettings{URL: "nats://" + address, Subject: "notifuse"}, time.Second)
+		err = sink.Publish(ctx, testEventSinkMessages())
+		assert.Error(t, err)
+	})
+}
+
+func TestPostgresEventSink_Publish(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("notifies the batch in one transaction", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`SELECT pg_notify`).WithArgs("events", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`SELECT pg_notify`).WithArgs("events", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		require.NoError(t, newPostgresEventSink(db, "events").Publish(ctx, testEventSinkMessages()))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back when a notification fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`SELECT pg_notify`).WillReturnError(fmt.Errorf("connection lost"))
+		mock.ExpectRollback()
+
+		err = newPostgresEventSink(db, "events").Publish(ctx, testEventSinkMessages())
+		require.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestBuildPostgresNotification(t *testing.T) {
+	t.Run("embeds the event", func(t *testing.T) {
+		payload, err := buildPostgresNotification(testEventSinkMessages()[0])
+		require.NoError(t, err)
+
+		var notification postgresNotification
+		require.NoError(t, json.Unmarshal(payload, &notification))
+		assert.Equal(t, "d1", notification.ID)
+		assert.Equal(t, "a@example.com", notification.Key)
+		assert.JSONEq(t, `{"id":"d1"}`, string(notification.Event))
+		assert.False(t, notification.Truncated)
+	})
+
+	t.Run("drops the event above the NOTIFY limit", func(t *testing.T) {
+		msg := testEventSinkMessages()[0]
+		msg.Body = []byte(`{"data":"` + strings.Repeat("x", 10000) + `"}`)
+
+		payload, err := buildPostgresNotification(msg)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(payload), postgresNotifyMaxPayload)
+
+		var notification postgresNotification
+		require.NoError(t, json.Unmarshal(payload, &notification))
+		assert.True(t, notification.Truncated)
+		assert.Empty(t, notification.Event)
+		assert.Equal(t, "v1,sig1", notification.Signature)
+	})
+}
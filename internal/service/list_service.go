@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -24,6 +25,8 @@ type ListService struct {
 	logger             logger.Logger
 	apiEndpoint        string
 	blogCache          cache.Cache
+	// optional, addresses are not verified when nil
+	emailVerificationService domain.EmailVerificationService
 }
 
 func NewListService(repo domain.ListRepository, workspaceRepo domain.WorkspaceRepository, contactListRepo domain.ContactListRepository, contactRepo domain.ContactRepository, messageHistoryRepo domain.MessageHistoryRepository, authService domain.AuthService, emailService domain.EmailServiceInterface, logger logger.Logger, apiEndpoint string, blogCache cache.Cache) *ListService {
@@ -41,6 +44,11 @@ func NewListService(repo domain.ListRepository, workspaceRepo domain.WorkspaceRe
 	}
 }
 
+// SetEmailVerificationService enables the verification of subscribing addresses
+func (s *ListService) SetEmailVerificationService(emailVerificationService domain.EmailVerificationService) {
+	s.emailVerificationService = emailVerificationService
+}
+
 func (s *ListService) CreateList(ctx context.Context, workspaceID string, list *domain.List) error {
 	var err error
 	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
@@ -262,6 +270,31 @@ func (s *ListService) SubscribeToLists(ctx context.Context, payload *domain.Subs
 		isAuthenticated = true
 	}
 
+	// consent ledger details recorded with each subscription change
+	consent := domain.ConsentContextFromContext(ctx)
+	switch {
+	case hasBearerToken:
+		consent.Source = domain.ConsentSourceAPI
+	case isAuthenticated:
+		consent.Source = domain.ConsentSourceNotificationCenter
+	default:
+		consent.Source = domain.ConsentSourceForm
+	}
+	if payload.PageURL != "" {
+		consent.PageURL = payload.PageURL
+	}
+	consent.ConsentTextVersion = payload.ConsentTextVersion
+
+	// Verify the address when the workspace enables email verification, a blocked
+	// address is returned as an ErrEmailVerificationBlocked
+	var verification *domain.EmailVerification
+	if s.emailVerificationService != nil {
+		verification, err = s.emailVerificationService.VerifyForIngestion(ctx, workspace, payload.Contact.Email, true)
+		if err != nil {
+			return err
+		}
+	}
+
 	// if the contact is not authenticated we only allow inserting the contact to avoid public frontend injections
 	canUpsert := true
 	if !isAuthenticated {
@@ -272,6 +305,10 @@ func (s *ListService) SubscribeToLists(ctx context.Context, payload *domain.Subs
 	}
 
 	if canUpsert {
+		if err := payload.Contact.NormalizeAttributes(workspace.Settings.ContactAttributes); err != nil {
+			return fmt.Errorf("invalid contact attributes: %w", err)
+		}
+
 		// upsert the contact
 		_, err = s.contactRepo.UpsertContact(ctx, workspace.ID, &payload.Contact)
 		if err != nil {
@@ -280,6 +317,12 @@ func (s *ListService) SubscribeToLists(ctx context.Context, payload *domain.Subs
 		}
 	}
 
+	if verification != nil {
+		if err := s.contactRepo.UpdateEmailVerifications(ctx, workspace.ID, map[string]*domain.EmailVerification{payload.Contact.Email: verification}); err != nil {
+			s.logger.WithField("email", payload.Contact.Email).Warn(fmt.Sprintf("Failed to store email verification: %v", err))
+		}
+	}
+
 	// get the lists
 	lists, err := s.repo.GetLists(ctx, workspace.ID)
 	if err != nil {
@@ -359,9 +402,20 @@ func (s *ListService) SubscribeToLists(ctx context.Context, payload *domain.Subs
 			contactList.Status = domain.ContactListStatusPending
 		}
 
+		messageID := uuid.New().String()
+
 		if !skipDBWrite {
+			// the ledger references the double opt-in email sent below, or the one being confirmed
+			listConsent := consent
+			listConsent.MessageID = ""
+			if contactList.Status == domain.ContactListStatusPending && list.DoubleOptInTemplate != nil && workspace.Settings.MarketingEmailProviderID != "" {
+				listConsent.MessageID = messageID
+			} else if contactList.Status == domain.ContactListStatusActive {
+				listConsent.MessageID = payload.MessageID
+			}
+
 			// Subscribe to the list
-			err = s.contactListRepo.AddContactToList(ctx, workspace.ID, contactList)
+			err = s.contactListRepo.AddContactToList(domain.WithConsentContext(ctx, listConsent), workspace.ID, contactList)
 			if err != nil {
 				// codecov:ignore:start
 				s.logger.WithField("email", contactList.Email).
@@ -395,8 +449,6 @@ func (s *ListService) SubscribeToLists(ctx context.Context, payload *domain.Subs
 			return fmt.Errorf("failed to get contact: %w", err)
 		}
 
-		messageID := uuid.New().String()
-
 		// Resolve the tracking/base endpoint: custom endpoint if set, else the API endpoint.
 		endpoint := workspace.Settings.ResolveEndpoint(s.apiEndpoint)
 
@@ -505,6 +557,17 @@ func (s *ListService) UnsubscribeFromLists(ctx context.Context, payload *domain.
 		return fmt.Errorf("failed to get lists: %w", err)
 	}
 
+	// consent ledger details: the handler tells one-click unsubscribes apart, other
+	// HMAC-authenticated requests come from the notification center
+	consent := domain.ConsentContextFromContext(ctx)
+	if hasBearerToken {
+		consent.Source = domain.ConsentSourceAPI
+	} else if consent.Source == domain.ConsentSourceAPI {
+		consent.Source = domain.ConsentSourceNotificationCenter
+	}
+	consent.MessageID = payload.MessageID
+	consentCtx := domain.WithConsentContext(ctx, consent)
+
 	// Process each list for unsubscription
 	for _, listID := range payload.ListIDs {
 		var list *domain.List
@@ -521,7 +584,15 @@ func (s *ListService) UnsubscribeFromLists(ctx context.Context, payload *domain.
 		}
 
 		// Update contact's status to unsubscribed for this list
-		err = s.contactListRepo.UpdateContactListStatus(ctx, workspace.ID, payload.Email, listID, domain.ContactListStatusUnsubscribed)
+		err = s.contactListRepo.UpdateContactListStatus(consentCtx, workspace.ID, payload.Email, listID, domain.ContactListStatusUnsubscribed)
+		var notFound *domain.ErrContactListNotFound
+		if errors.As(err, &notFound) {
+			// Links of messages sent before a merge or an email change carry a former address
+			if contactEmail, aliasErr := s.contactRepo.ResolveContactAlias(ctx, workspace.ID, payload.Email); aliasErr == nil && contactEmail != "" {
+				payload.Email = contactEmail
+				err = s.contactListRepo.UpdateContactListStatus(consentCtx, workspace.ID, payload.Email, listID, domain.ContactListStatusUnsubscribed)
+			}
+		}
 		if err != nil {
 			s.logger.WithField("email", payload.Email).
 				WithField("list_id", listID).
@@ -1197,6 +1197,63 @@ func TestListService_SubscribeToLists_StatusProtection(t *testing.T) {
 	})
 }
 
+func TestListService_SubscribeToLists_EmailVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockListRepository(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+	mockCache := pkgmocks.NewMockCache(ctrl)
+	mockVerificationService := mocks.NewMockEmailVerificationService(ctrl)
+
+	service := NewListService(mockRepo, mockWorkspaceRepo, mockContactListRepo, mockContactRepo, mockMessageHistoryRepo, mockAuthService, mockEmailService, mockLogger, "https://api.example.com", mockCache)
+	service.SetEmailVerificationService(mockVerificationService)
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+	workspace := &domain.Workspace{ID: workspaceID, Settings: domain.WorkspaceSettings{SecretKey: "test-secret-key"}}
+
+	payload := &domain.SubscribeToListsRequest{
+		WorkspaceID: workspaceID,
+		Contact: domain.Contact{
+			Email:     "test@example.com",
+			EmailHMAC: domain.ComputeEmailHMAC("test@example.com", "test-secret-key"),
+		},
+		ListIDs: []string{"list123"},
+	}
+
+	t.Run("blocked address is rejected before any write", func(t *testing.T) {
+		invalid := &domain.EmailVerification{Status: domain.EmailVerificationStatusInvalid}
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+		mockVerificationService.EXPECT().VerifyForIngestion(gomock.Any(), workspace, "test@example.com", true).
+			Return(invalid, &domain.ErrEmailVerificationBlocked{Email: "test@example.com", Verification: invalid})
+
+		err := service.SubscribeToLists(ctx, payload, false)
+		var blocked *domain.ErrEmailVerificationBlocked
+		assert.True(t, errors.As(err, &blocked))
+	})
+
+	t.Run("verification is stored on the contact", func(t *testing.T) {
+		valid := &domain.EmailVerification{Status: domain.EmailVerificationStatusValid}
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+		mockVerificationService.EXPECT().VerifyForIngestion(gomock.Any(), workspace, "test@example.com", true).Return(valid, nil)
+		mockContactRepo.EXPECT().UpsertContact(gomock.Any(), workspaceID, gomock.Any()).Return(true, nil)
+		mockContactRepo.EXPECT().UpdateEmailVerifications(gomock.Any(), workspaceID, map[string]*domain.EmailVerification{"test@example.com": valid}).Return(nil)
+		mockRepo.EXPECT().GetLists(gomock.Any(), workspaceID).Return([]*domain.List{{ID: "list123", Name: "Test List", IsPublic: true}}, nil)
+		mockContactListRepo.EXPECT().GetContactListByIDs(gomock.Any(), workspaceID, "test@example.com", "list123").Return(nil, &domain.ErrContactListNotFound{Message: "not found"})
+		mockContactListRepo.EXPECT().AddContactToList(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+		err := service.SubscribeToLists(ctx, payload, false)
+		assert.NoError(t, err)
+	})
+}
+
 func TestListService_UnsubscribeFromLists(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1291,6 +1348,45 @@ func TestListService_UnsubscribeFromLists(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("unsubscribe with a former address of the contact", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+		mockRepo.EXPECT().GetLists(gomock.Any(), workspaceID).Return([]*domain.List{{ID: listID, Name: "Test List", IsPublic: true}}, nil)
+		mockContactListRepo.EXPECT().UpdateContactListStatus(gomock.Any(), workspaceID, email, listID, domain.ContactListStatusUnsubscribed).
+			Return(&domain.ErrContactListNotFound{Message: "contact list not found"})
+		mockContactRepo.EXPECT().ResolveContactAlias(gomock.Any(), workspaceID, email).Return("renamed@example.com", nil)
+		mockContactListRepo.EXPECT().UpdateContactListStatus(gomock.Any(), workspaceID, "renamed@example.com", listID, domain.ContactListStatusUnsubscribed).Return(nil)
+
+		aliasPayload := *payload
+		err := service.UnsubscribeFromLists(ctx, &aliasPayload, false)
+		assert.NoError(t, err)
+	})
+
+	t.Run("consent ledger source follows the entry point", func(t *testing.T) {
+		lists := []*domain.List{{ID: listID, Name: "Test List", IsPublic: true}}
+		oneClickPayload := *payload
+		oneClickPayload.MessageID = "msg-123"
+
+		var sources []domain.ConsentSource
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil).Times(2)
+		mockRepo.EXPECT().GetLists(gomock.Any(), workspaceID).Return(lists, nil).Times(2)
+		mockContactListRepo.EXPECT().UpdateContactListStatus(gomock.Any(), workspaceID, email, listID, domain.ContactListStatusUnsubscribed).
+			DoAndReturn(func(ctx context.Context, _, _, _ string, _ domain.ContactListStatus) error {
+				consent := domain.ConsentContextFromContext(ctx)
+				sources = append(sources, consent.Source)
+				if consent.Source == domain.ConsentSourceOneClick {
+					assert.Equal(t, "msg-123", consent.MessageID)
+				}
+				return nil
+			}).Times(2)
+		mockMessageHistoryRepo.EXPECT().SetStatusesIfNotSet(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+		oneClickCtx := domain.WithConsentContext(ctx, domain.ConsentContext{Source: domain.ConsentSourceOneClick})
+		assert.NoError(t, service.UnsubscribeFromLists(oneClickCtx, &oneClickPayload, false))
+		assert.NoError(t, service.UnsubscribeFromLists(ctx, payload, false))
+
+		assert.Equal(t, []domain.ConsentSource{domain.ConsentSourceOneClick, domain.ConsentSourceNotificationCenter}, sources)
+	})
+
 	t.Run("unsubscribe without confirmation email", func(t *testing.T) {
 		// Setup workspace with marketing email provider but no unsubscribe template
 		// (unsubscribe templates are no longer supported - automations handle this now)
@@ -1667,22 +1763,35 @@ func TestListService_SubscribeToLists_DoubleOptInEmailSent(t *testing.T) {
 	mockContactRepo.EXPECT().UpsertContact(gomock.Any(), workspaceID, gomock.Any()).Return(true, nil)
 	mockRepo.EXPECT().GetLists(gomock.Any(), workspaceID).Return([]*domain.List{list}, nil)
 	mockContactListRepo.EXPECT().GetContactListByIDs(gomock.Any(), workspaceID, contactEmail, "list123").Return(nil, &domain.ErrContactListNotFound{Message: "not found"})
-	mockContactListRepo.EXPECT().AddContactToList(gomock.Any(), workspaceID, gomock.Any()).Do(func(_ context.Context, _ string, cl *domain.ContactList) {
+	var consent domain.ConsentContext
+	mockContactListRepo.EXPECT().AddContactToList(gomock.Any(), workspaceID, gomock.Any()).Do(func(ctx context.Context, _ string, cl *domain.ContactList) {
 		assert.Equal(t, domain.ContactListStatusPending, cl.Status)
+		consent = domain.ConsentContextFromContext(ctx)
 	}).Return(nil)
 	mockEmailService.EXPECT().SendEmailForTemplate(gomock.Any(), gomock.Any()).Do(func(_ context.Context, req domain.SendEmailRequest) {
 		assert.Equal(t, "double-template", req.TemplateConfig.TemplateID)
 		assert.Equal(t, domain.EmailProviderKindSparkPost, req.EmailProvider.Kind)
+		// The consent ledger references the double opt-in email
+		assert.Equal(t, req.MessageID, consent.MessageID)
 	}).Return(nil)
 
 	payload := &domain.SubscribeToListsRequest{
-		WorkspaceID: workspaceID,
-		Contact:     domain.Contact{Email: contactEmail},
-		ListIDs:     []string{"list123"},
+		WorkspaceID:        workspaceID,
+		Contact:            domain.Contact{Email: contactEmail},
+		ListIDs:            []string{"list123"},
+		PageURL:            "https://example.com/newsletter",
+		ConsentTextVersion: "v2",
 	}
 
+	ctx = domain.WithConsentContext(ctx, domain.ConsentContext{IPAddress: "203.0.113.7", UserAgent: "Mozilla/5.0", PageURL: "https://example.com/referer"})
 	err := service.SubscribeToLists(ctx, payload, false)
 	assert.NoError(t, err)
+	assert.Equal(t, domain.ConsentSourceForm, consent.Source)
+	assert.Equal(t, "203.0.113.7", consent.IPAddress)
+	assert.Equal(t, "Mozilla/5.0", consent.UserAgent)
+	assert.Equal(t, "https://example.com/newsletter", consent.PageURL)
+	assert.Equal(t, "v2", consent.ConsentTextVersion)
+	assert.NotEmpty(t, consent.MessageID)
 }
 
 func TestListService_SubscribeToLists_GetEmailProviderError(t *testing.T) {
@@ -0,0 +1,555 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+	"github.com/google/uuid"
+	"github.com/microcosm-cc/bluemonday"
+)
+
+const (
+	// subjectHistoryWindow is how far back the past subject lines given as examples go
+	subjectHistoryWindow = 180 * 24 * time.Hour
+	// subjectHistoryMinSent ignores the subject lines sent to too few recipients to be meaningful
+	subjectHistoryMinSent = 50
+	subjectHistoryLimit   = 20
+	// llmCopyContentLimit caps the email content included in a prompt
+	llmCopyContentLimit = 4000
+)
+
+var (
+	// liquidTagRegex matches Liquid output and logic tags
+	liquidTagRegex = regexp.MustCompile(`\{\{.*?\}\}|\{%.*?%\}`)
+	// hrefRegex matches the link targets of HTML anchors
+	hrefRegex = regexp.MustCompile(`href\s*=\s*["']([^"']*)["']`)
+)
+
+const subjectLinesSystemPrompt = `You are an email marketing copywriter. You write subject lines and preheaders for newsletters.
+Subject lines are under 70 characters, preheaders under 120 characters. Each candidate takes a distinct angle so that they can be A/B tested against each other.
+Keep the language of the email. Keep any Liquid tag such as {{ contact.first_name }} exactly as written.
+Reply with JSON only, in the format {"candidates": [{"subject": "...", "preheader": "...", "rationale": "..."}]}.`
+
+const rewriteBlocksSystemPrompt = `You are an email copy editor. You rewrite the HTML content of email blocks.
+Keep the HTML structure, every Liquid tag such as {{ contact.first_name }} or {% if ... %} and every link URL exactly as written. Only change the wording.
+Reply with JSON only, in the format {"blocks": [{"id": "...", "content": "..."}]}, with one entry for every block you were given.`
+
+// GenerateSubjectLines generates subject line and preheader candidates for a template, using
+// the best performing subject lines of the past broadcasts of the workspace as examples
+func (s *LLMService) GenerateSubjectLines(ctx context.Context, req *domain.GenerateSubjectLinesRequest) (*domain.GenerateSubjectLinesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, domain.NewValidationError(err.Error())
+	}
+
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+	if !userWorkspace.HasPermission(domain.PermissionResourceLLM, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceLLM,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to LLM required",
+		)
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	integration, err := getLLMIntegration(workspace, req.IntegrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := s.templateService.GetTemplateByID(ctx, req.WorkspaceID, req.TemplateID, 0)
+	if err != nil {
+		return nil, err
+	}
+	if template.Email == nil {
+		return nil, domain.NewValidationError("template is not an email template")
+	}
+
+	history, err := s.messageHistoryRepo.GetSubjectPerformance(ctx, req.WorkspaceID, domain.SubjectPerformanceParams{
+		Since:   time.Now().Add(-subjectHistoryWindow),
+		MinSent: subjectHistoryMinSent,
+		Limit:   subjectHistoryLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subject performance: %w", err)
+	}
+
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "Write %d subject line candidates for the following email.\n\n", req.Count)
+	fmt.Fprintf(&prompt, "Current subject: %s\n", template.Email.Subject)
+	if template.Email.SubjectPreview != nil && *template.Email.SubjectPreview != "" {
+		fmt.Fprintf(&prompt, "Current preheader: %s\n", *template.Email.SubjectPreview)
+	}
+	if audience := s.describeAudience(ctx, req.WorkspaceID, req.Audience); audience != "" {
+		fmt.Fprintf(&prompt, "Audience: %s\n", audience)
+	}
+	if content := emailPlainText(template.Email); content != "" {
+		fmt.Fprintf(&prompt, "\nEmail content:\n%s\n", content)
+	}
+	if len(history) > 0 {
+		prompt.WriteString("\nOpen rates of the past subject lines of this sender, best first:\n")
+		for _, performance := range history {
+			fmt.Fprintf(&prompt, "- %q: %.1f%% opened (%d sent)\n", performance.Subject, performance.OpenRate*100, performance.Sent)
+		}
+	}
+	if req.Instructions != "" {
+		fmt.Fprintf(&prompt, "\nAdditional instructions: %s\n", req.Instructions)
+	}
+
+	text, usage, err := s.complete(ctx, req.WorkspaceID, integration, subjectLinesSystemPrompt, prompt.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var reply struct {
+		Candidates []domain.SubjectLineCandidate `json:"candidates"`
+	}
+	if err := parseLLMJSON(text, &reply); err != nil {
+		return nil, err
+	}
+
+	candidates := make([]domain.SubjectLineCandidate, 0, len(reply.Candidates))
+	for _, candidate := range reply.Candidates {
+		candidate.Subject = strings.TrimSpace(candidate.Subject)
+		candidate.Preheader = strings.TrimSpace(candidate.Preheader)
+		if candidate.Subject == "" {
+			continue
+		}
+		candidates = append(candidates, candidate)
+		if len(candidates) == req.Count {
+			break
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("the LLM did not return any subject line")
+	}
+
+	return &domain.GenerateSubjectLinesResponse{
+		Candidates: candidates,
+		History:    history,
+		Usage:      usage,
+	}, nil
+}
+
+// CreateSubjectVariations copies the template of the first variation of a broadcast once per
+// candidate, with the subject line and preheader of the candidate, and adds the copies to the
+// A/B test of the broadcast
+func (s *LLMService) CreateSubjectVariations(ctx context.Context, req *domain.CreateSubjectVariationsRequest) (*domain.Broadcast, error) {
+	if err := req.Validate(); err != nil {
+		return nil, domain.NewValidationError(err.Error())
+	}
+
+	// The templates are created before the broadcast is updated, so both permissions are
+	// checked upfront to not leave orphan templates behind
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+	if !userWorkspace.HasPermission(domain.PermissionResourceTemplates, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceTemplates,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to templates required",
+		)
+	}
+	if !userWorkspace.HasPermission(domain.PermissionResourceBroadcasts, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceBroadcasts,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to broadcasts required",
+		)
+	}
+
+	broadcast, err := s.broadcastService.GetBroadcast(ctx, req.WorkspaceID, req.BroadcastID)
+	if err != nil {
+		return nil, err
+	}
+	if broadcast.Status != domain.BroadcastStatusDraft && broadcast.Status != domain.BroadcastStatusScheduled {
+		return nil, domain.NewValidationError(fmt.Sprintf("cannot add variations to a broadcast with status: %s", broadcast.Status))
+	}
+	if len(broadcast.TestSettings.Variations) == 0 {
+		return nil, domain.NewValidationError("broadcast has no template")
+	}
+	if len(broadcast.TestSettings.Variations)+len(req.Candidates) > 8 {
+		return nil, domain.NewValidationError("maximum 8 variations are allowed for A/B testing")
+	}
+
+	base, err := s.templateService.GetTemplateByID(ctx, req.WorkspaceID, broadcast.TestSettings.Variations[0].TemplateID, 0)
+	if err != nil {
+		return nil, err
+	}
+	if base.Email == nil {
+		return nil, domain.NewValidationError("broadcast template is not an email template")
+	}
+
+	variations := broadcast.TestSettings.Variations
+	for i, candidate := range req.Candidates {
+		variation, err := cloneTemplate(base)
+		if err != nil {
+			return nil, err
+		}
+		variation.ID = strings.ReplaceAll(uuid.New().String(), "-", "")[:32]
+		variation.Name = variationTemplateName(base.Name, len(variations)+1)
+		variation.Email.Subject = strings.TrimSpace(candidate.Subject)
+		if preheader := strings.TrimSpace(candidate.Preheader); preheader != "" {
+			variation.Email.SubjectPreview = &preheader
+		}
+
+		if err := s.templateService.CreateTemplate(ctx, req.WorkspaceID, variation); err != nil {
+			return nil, fmt.Errorf("failed to create template for candidate %d: %w", i+1, err)
+		}
+
+		variations = append(variations, domain.BroadcastVariation{
+			VariationName: fmt.Sprintf("Subject %d", len(variations)+1),
+			TemplateID:    variation.ID,
+		})
+	}
+
+	testSettings := broadcast.TestSettings
+	testSettings.Enabled = true
+	testSettings.Variations = variations
+	if testSettings.SamplePercentage == 0 {
+		testSettings.SamplePercentage = 50
+	}
+
+	updated, err := s.broadcastService.UpdateBroadcast(ctx, &domain.UpdateBroadcastRequest{
+		WorkspaceID:   req.WorkspaceID,
+		ID:            broadcast.ID,
+		Name:          broadcast.Name,
+		Audience:      broadcast.Audience,
+		Schedule:      broadcast.Schedule,
+		TestSettings:  testSettings,
+		UTMParameters: broadcast.UTMParameters,
+		Metadata:      broadcast.Metadata,
+		DataFeed:      broadcast.DataFeed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update broadcast: %w", err)
+	}
+	return updated, nil
+}
+
+// RewriteBlocks rewrites the text and button blocks of a visual editor tree in a tone or
+// language. The output of the LLM is rejected when it drops or alters a Liquid tag or a link.
+func (s *LLMService) RewriteBlocks(ctx context.Context, req *domain.RewriteBlocksRequest) (*domain.RewriteBlocksResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, domain.NewValidationError(err.Error())
+	}
+
+	tree, err := notifuse_mjml.UnmarshalEmailBlock(req.Tree)
+	if err != nil {
+		return nil, domain.NewValidationError(fmt.Sprintf("invalid visual_editor_tree: %v", err))
+	}
+
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+	if !userWorkspace.HasPermission(domain.PermissionResourceLLM, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceLLM,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to LLM required",
+		)
+	}
+
+	blocks, err := collectTextBlocks(tree, req.BlockIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 {
+		return nil, domain.NewValidationError("the tree has no text block to rewrite")
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	integration, err := getLLMIntegration(workspace, req.IntegrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	type blockContent struct {
+		ID      string `json:"id"`
+		Content string `json:"content"`
+	}
+	input := make([]blockContent, len(blocks))
+	for i, block := range blocks {
+		input[i] = blockContent{ID: block.GetID(), Content: *block.GetContent()}
+	}
+	inputJSON, err := json.Marshal(map[string]interface{}{"blocks": input})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal blocks: %w", err)
+	}
+
+	var prompt strings.Builder
+	prompt.WriteString("Rewrite the content of the following email blocks.\n")
+	if req.Tone != "" {
+		fmt.Fprintf(&prompt, "Tone: %s\n", req.Tone)
+	}
+	if req.Language != "" {
+		fmt.Fprintf(&prompt, "Language: write the blocks in the language %q\n", req.Language)
+	}
+	fmt.Fprintf(&prompt, "\n%s\n", inputJSON)
+
+	text, usage, err := s.complete(ctx, req.WorkspaceID, integration, rewriteBlocksSystemPrompt, prompt.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var reply struct {
+		Blocks []blockContent `json:"blocks"`
+	}
+	if err := parseLLMJSON(text, &reply); err != nil {
+		return nil, err
+	}
+	rewritten := make(map[string]string, len(reply.Blocks))
+	for _, block := range reply.Blocks {
+		rewritten[block.ID] = block.Content
+	}
+
+	rewrittenIDs := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		content, ok := rewritten[block.GetID()]
+		if !ok || strings.TrimSpace(content) == "" {
+			return nil, fmt.Errorf("the LLM did not rewrite block %s", block.GetID())
+		}
+		if err := checkPreservedMarkup(*block.GetContent(), content); err != nil {
+			return nil, fmt.Errorf("rejected the rewrite of block %s: %w", block.GetID(), err)
+		}
+		block.SetContent(&content)
+		rewrittenIDs = append(rewrittenIDs, block.GetID())
+	}
+
+	if err := notifuse_mjml.ValidateEmailStructure(tree); err != nil {
+		return nil, fmt.Errorf("rewritten tree is invalid: %w", err)
+	}
+
+	return &domain.RewriteBlocksResponse{
+		Tree:         tree,
+		RewrittenIDs: rewrittenIDs,
+		Usage:        usage,
+	}, nil
+}
+
+// complete sends a single prompt to the LLM integration and collects the streamed reply
+func (s *LLMService) complete(
+	ctx context.Context,
+	workspaceID string,
+	integration *domain.Integration,
+	systemPrompt string,
+	prompt string,
+) (string, domain.LLMUsage, error) {
+	req := &domain.LLMChatRequest{
+		WorkspaceID:   workspaceID,
+		IntegrationID: integration.ID,
+		SystemPrompt:  systemPrompt,
+		Messages:      []domain.LLMMessage{{Role: "user", Content: prompt}},
+		MaxTokens:     4096,
+	}
+
+	var text strings.Builder
+	var usage domain.LLMUsage
+	err := s.streamWithProvider(ctx, req, integration.LLMProvider, nil, func(event domain.LLMChatEvent) error {
+		switch event.Type {
+		case "text":
+			text.WriteString(event.Content)
+		case "done":
+			usage.Model = event.Model
+			if event.InputTokens != nil {
+				usage.InputTokens = *event.InputTokens
+			}
+			if event.OutputTokens != nil {
+				usage.OutputTokens = *event.OutputTokens
+			}
+			if event.TotalCost != nil {
+				usage.TotalCost = *event.TotalCost
+			}
+		case "error":
+			return fmt.Errorf("LLM error: %s", event.Error)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", usage, err
+	}
+	return text.String(), usage, nil
+}
+
+// parseLLMJSON decodes the JSON object of an LLM reply, ignoring the markdown code fence or
+// the prose models sometimes wrap it in
+func parseLLMJSON(text string, v interface{}) error {
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return fmt.Errorf("the LLM reply is not JSON")
+	}
+	if err := json.Unmarshal([]byte(text[start:end+1]), v); err != nil {
+		return fmt.Errorf("failed to parse the LLM reply: %w", err)
+	}
+	return nil
+}
+
+// checkPreservedMarkup returns an error when the rewritten content does not contain exactly
+// the Liquid tags and link targets of the original content
+func checkPreservedMarkup(original, rewritten string) error {
+	if !sameStrings(liquidTagRegex.FindAllString(original, -1), liquidTagRegex.FindAllString(rewritten, -1)) {
+		return fmt.Errorf("liquid tags were not preserved")
+	}
+	if !sameStrings(hrefTargets(original), hrefTargets(rewritten)) {
+		return fmt.Errorf("links were not preserved")
+	}
+	return nil
+}
+
+// hrefTargets returns the link targets of an HTML fragment
+func hrefTargets(content string) []string {
+	matches := hrefRegex.FindAllStringSubmatch(content, -1)
+	targets := make([]string, len(matches))
+	for i, match := range matches {
+		targets[i] = match[1]
+	}
+	return targets
+}
+
+// sameStrings returns true when both slices contain the same strings, in any order
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// collectTextBlocks returns the text and button blocks of a tree with content. When ids is not
+// empty, only these blocks are returned, and each of them must be a text or button block.
+func collectTextBlocks(tree notifuse_mjml.EmailBlock, ids []string) ([]notifuse_mjml.EmailBlock, error) {
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	found := make(map[string]bool, len(ids))
+
+	var blocks []notifuse_mjml.EmailBlock
+	var walk func(block notifuse_mjml.EmailBlock)
+	walk = func(block notifuse_mjml.EmailBlock) {
+		if block == nil {
+			return
+		}
+		blockType := block.GetType()
+		isText := blockType == notifuse_mjml.MJMLComponentMjText || blockType == notifuse_mjml.MJMLComponentMjButton
+		if isText && block.GetContent() != nil && strings.TrimSpace(*block.GetContent()) != "" {
+			if len(wanted) == 0 || wanted[block.GetID()] {
+				blocks = append(blocks, block)
+				found[block.GetID()] = true
+			}
+		}
+		for _, child := range block.GetChildren() {
+			walk(child)
+		}
+	}
+	walk(tree)
+
+	var missing []string
+	for id := range wanted {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, domain.NewValidationError(fmt.Sprintf("not a text or button block: %s", strings.Join(missing, ", ")))
+	}
+	return blocks, nil
+}
+
+// emailPlainText returns the text of an email template, without markup, for a prompt
+func emailPlainText(email *domain.EmailTemplate) string {
+	var text string
+	switch {
+	case email.Text != nil && *email.Text != "":
+		text = *email.Text
+	case email.VisualEditorTree != nil:
+		blocks, _ := collectTextBlocks(email.VisualEditorTree, nil)
+		parts := make([]string, 0, len(blocks))
+		for _, block := range blocks {
+			parts = append(parts, *block.GetContent())
+		}
+		text = strings.Join(parts, "\n")
+	case email.MjmlSource != nil:
+		text = *email.MjmlSource
+	}
+
+	text = html.UnescapeString(bluemonday.StrictPolicy().Sanitize(text))
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) > llmCopyContentLimit {
+		text = text[:llmCopyContentLimit]
+	}
+	return text
+}
+
+// describeAudience returns the names of the list and segments of an audience
+func (s *LLMService) describeAudience(ctx context.Context, workspaceID string, audience domain.AudienceSettings) string {
+	var parts []string
+	if audience.List != "" {
+		if list, err := s.listRepo.GetListByID(ctx, workspaceID, audience.List); err == nil {
+			description := "subscribers of the list " + list.Name
+			if list.Description != "" {
+				description += " (" + list.Description + ")"
+			}
+			parts = append(parts, description)
+		}
+	}
+	for _, segmentID := range audience.Segments {
+		if segment, err := s.segmentRepo.GetSegmentByID(ctx, workspaceID, segmentID); err == nil {
+			parts = append(parts, "contacts of the segment "+segment.Name)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// cloneTemplate returns a deep copy of a template
+func cloneTemplate(template *domain.Template) (*domain.Template, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy template: %w", err)
+	}
+	var clone domain.Template
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf("failed to copy template: %w", err)
+	}
+	return &clone, nil
+}
+
+// variationTemplateName returns the name of the template of a variation, within the 32
+// characters allowed for template names
+func variationTemplateName(baseName string, index int) string {
+	suffix := fmt.Sprintf(" - subject %d", index)
+	runes := []rune(baseName)
+	if limit := 32 - len(suffix); len(runes) > limit {
+		runes = runes[:limit]
+	}
+	return strings.TrimSpace(string(runes)) + suffix
+}
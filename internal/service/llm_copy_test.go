@@ -0,0 +1,435 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type llmCopyTestMocks struct {
+	authService        *mocks.MockAuthService
+	workspaceRepo      *mocks.MockWorkspaceRepository
+	templateService    *mocks.MockTemplateService
+	broadcastService   *mocks.MockBroadcastService
+	messageHistoryRepo *mocks.MockMessageHistoryRepository
+	listRepo           *mocks.MockListRepository
+	segmentRepo        *mocks.MockSegmentRepository
+}
+
+func setupLLMCopyTest(t *testing.T) (*LLMService, *llmCopyTestMocks) {
+	ctrl := gomock.NewController(t)
+	m := &llmCopyTestMocks{
+		authService:        mocks.NewMockAuthService(ctrl),
+		workspaceRepo:      mocks.NewMockWorkspaceRepository(ctrl),
+		templateService:    mocks.NewMockTemplateService(ctrl),
+		broadcastService:   mocks.NewMockBroadcastService(ctrl),
+		messageHistoryRepo: mocks.NewMockMessageHistoryRepository(ctrl),
+		listRepo:           mocks.NewMockListRepository(ctrl),
+		segmentRepo:        mocks.NewMockSegmentRepository(ctrl),
+	}
+	service := NewLLMService(LLMServiceConfig{
+		AuthService:        m.authService,
+		WorkspaceRepo:      m.workspaceRepo,
+		Logger:             logger.NewLoggerWithLevel("disabled"),
+		TemplateService:    m.templateService,
+		BroadcastService:   m.broadcastService,
+		MessageHistoryRepo: m.messageHistoryRepo,
+		ListRepo:           m.listRepo,
+		SegmentRepo:        m.segmentRepo,
+	})
+	return service, m
+}
+
+// newOpenAIStub serves an OpenAI compatible chat completion stream replying with the given
+// text, and records the prompts it received
+func newOpenAIStub(t *testing.T, reply string) (*httptest.Server, *[]string) {
+	var prompts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var request struct {
+			Messages []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		_ = json.Unmarshal(body, &request)
+		for _, message := range request.Messages {
+			if message.Role == "user" {
+				prompts = append(prompts, message.Content)
+			}
+		}
+
+		content, _ := json.Marshal(reply)
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"created\":0,\"model\":\"gpt-4.1\",\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":%s},\"finish_reason\":null}]}\n\n", content)
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"created\":0,\"model\":\"gpt-4.1\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"stop\"}]}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"created\":0,\"model\":\"gpt-4.1\",\"choices\":[],\"usage\":{\"prompt_tokens\":120,\"completion_tokens\":40,\"total_tokens\":160}}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	t.Cleanup(server.Close)
+	return server, &prompts
+}
+
+func llmCopyWorkspace(baseURL string) *domain.Workspace {
+	return &domain.Workspace{
+		ID: "ws1",
+		Integrations: []domain.Integration{{
+			ID:   "llm1",
+			Type: domain.IntegrationTypeLLM,
+			LLMProvider: &domain.LLMProvider{
+				Kind: domain.LLMProviderKindOpenAI,
+				OpenAI: &domain.OpenAISettings{
+					APIKey:  "sk-test",
+					Model:   "gpt-4.1",
+					BaseURL: baseURL,
+				},
+			},
+		}},
+	}
+}
+
+func expectLLMCopyAuth(m *llmCopyTestMocks, permissions domain.UserPermissions) {
+	ctx := context.WithValue(context.Background(), domain.WorkspaceIDKey, "ws1")
+	m.authService.EXPECT().
+		AuthenticateUserForWorkspace(gomock.Any(), "ws1").
+		Return(ctx, &domain.User{ID: "user1"}, &domain.UserWorkspace{
+			UserID:      "user1",
+			WorkspaceID: "ws1",
+			Role:        "member",
+			Permissions: permissions,
+		}, nil)
+}
+
+func llmCopyEmailTemplate() *domain.Template {
+	preview := "Everything we shipped this month"
+	text := "<p>Hi {{ contact.first_name }}, here is what is new.</p>"
+	return &domain.Template{
+		ID:       "newsletter",
+		Name:     "Monthly newsletter template",
+		Version:  4,
+		Channel:  domain.ChannelEmail,
+		Category: "marketing",
+		Email: &domain.EmailTemplate{
+			Subject:        "Product update",
+			SubjectPreview: &preview,
+			Text:           &text,
+		},
+	}
+}
+
+const llmCopyTree = `{"id":"root","type":"mjml","children":[{"id":"body","type":"mj-body","children":[` +
+	`{"id":"section","type":"mj-section","children":[{"id":"column","type":"mj-column","children":[` +
+	`{"id":"intro","type":"mj-text","content":"<p>Hi {{ contact.first_name }}, <a href=\"https://example.com/sale\">the sale</a> is live.</p>"},` +
+	`{"id":"cta","type":"mj-button","content":"Shop now"}]}]}]}]}`
+
+func TestLLMService_GenerateSubjectLines(t *testing.T) {
+	t.Run("generates candidates from the past open rates", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		server, prompts := newOpenAIStub(t, "```json\n"+`{"candidates":[`+
+			`{"subject":" Your May update is here ","preheader":"5 new features","rationale":"Direct"},`+
+			`{"subject":"","preheader":"ignored"},`+
+			`{"subject":"{{ contact.first_name }}, see what is new","preheader":"Personalized"},`+
+			`{"subject":"One too many"}]}`+"\n```")
+
+		expectLLMCopyAuth(m, domain.UserPermissions{
+			domain.PermissionResourceLLM: domain.ResourcePermissions{Read: true, Write: true},
+		})
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(llmCopyWorkspace(server.URL), nil)
+		m.templateService.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "newsletter", int64(0)).Return(llmCopyEmailTemplate(), nil)
+		m.messageHistoryRepo.EXPECT().GetSubjectPerformance(gomock.Any(), "ws1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, params domain.SubjectPerformanceParams) ([]*domain.SubjectPerformance, error) {
+				assert.Equal(t, subjectHistoryMinSent, params.MinSent)
+				return []*domain.SubjectPerformance{
+					{Subject: "Last chance: 30% off", Sent: 1000, Opened: 420, OpenRate: 0.42},
+				}, nil
+			})
+		m.listRepo.EXPECT().GetListByID(gomock.Any(), "ws1", "customers").Return(&domain.List{ID: "customers", Name: "Customers"}, nil)
+
+		response, err := service.GenerateSubjectLines(context.Background(), &domain.GenerateSubjectLinesRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			TemplateID:    "newsletter",
+			Audience:      domain.AudienceSettings{List: "customers"},
+			Count:         2,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, response.Candidates, 2)
+		assert.Equal(t, "Your May update is here", response.Candidates[0].Subject)
+		assert.Equal(t, "{{ contact.first_name }}, see what is new", response.Candidates[1].Subject)
+		assert.Len(t, response.History, 1)
+		assert.Equal(t, int64(120), response.Usage.InputTokens)
+		assert.Equal(t, int64(40), response.Usage.OutputTokens)
+
+		require.Len(t, *prompts, 1)
+		prompt := (*prompts)[0]
+		assert.Contains(t, prompt, "Write 2 subject line candidates")
+		assert.Contains(t, prompt, "Current subject: Product update")
+		assert.Contains(t, prompt, "subscribers of the list Customers")
+		assert.Contains(t, prompt, `"Last chance: 30% off": 42.0% opened`)
+		assert.Contains(t, prompt, "Hi {{ contact.first_name }}, here is what is new.")
+		assert.NotContains(t, prompt, "<p>")
+	})
+
+	t.Run("requires the LLM write permission", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		expectLLMCopyAuth(m, domain.UserPermissions{
+			domain.PermissionResourceLLM: domain.ResourcePermissions{Read: true},
+		})
+
+		_, err := service.GenerateSubjectLines(context.Background(), &domain.GenerateSubjectLinesRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			TemplateID:    "newsletter",
+		})
+		var permissionErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permissionErr)
+	})
+
+	t.Run("rejects more candidates than variations", func(t *testing.T) {
+		service, _ := setupLLMCopyTest(t)
+
+		_, err := service.GenerateSubjectLines(context.Background(), &domain.GenerateSubjectLinesRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			TemplateID:    "newsletter",
+			Count:         9,
+		})
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+}
+
+func TestLLMService_CreateSubjectVariations(t *testing.T) {
+	writePermissions := domain.UserPermissions{
+		domain.PermissionResourceTemplates:  domain.ResourcePermissions{Read: true, Write: true},
+		domain.PermissionResourceBroadcasts: domain.ResourcePermissions{Read: true, Write: true},
+	}
+	draftBroadcast := func() *domain.Broadcast {
+		return &domain.Broadcast{
+			ID:          "b1",
+			WorkspaceID: "ws1",
+			Name:        "May newsletter",
+			Status:      domain.BroadcastStatusDraft,
+			Audience:    domain.AudienceSettings{List: "customers"},
+			TestSettings: domain.BroadcastTestSettings{
+				Variations: []domain.BroadcastVariation{{VariationName: "Default", TemplateID: "newsletter"}},
+			},
+		}
+	}
+
+	t.Run("adds a variation per candidate", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		expectLLMCopyAuth(m, writePermissions)
+		m.broadcastService.EXPECT().GetBroadcast(gomock.Any(), "ws1", "b1").Return(draftBroadcast(), nil)
+		m.templateService.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "newsletter", int64(0)).Return(llmCopyEmailTemplate(), nil)
+
+		var created []*domain.Template
+		m.templateService.EXPECT().CreateTemplate(gomock.Any(), "ws1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, template *domain.Template) error {
+				created = append(created, template)
+				return nil
+			}).Times(2)
+		m.broadcastService.EXPECT().UpdateBroadcast(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *domain.UpdateBroadcastRequest) (*domain.Broadcast, error) {
+				broadcast := draftBroadcast()
+				broadcast.TestSettings = req.TestSettings
+				return broadcast, nil
+			})
+
+		broadcast, err := service.CreateSubjectVariations(context.Background(), &domain.CreateSubjectVariationsRequest{
+			WorkspaceID: "ws1",
+			BroadcastID: "b1",
+			Candidates: []domain.SubjectLineCandidate{
+				{Subject: "Your May update is here", Preheader: "5 new features"},
+				{Subject: "See what is new"},
+			},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, created, 2)
+		assert.Len(t, created[0].ID, 32)
+		assert.NotEqual(t, created[0].ID, created[1].ID)
+		assert.LessOrEqual(t, len(created[0].Name), 32)
+		assert.Equal(t, "Your May update is here", created[0].Email.Subject)
+		assert.Equal(t, "5 new features", *created[0].Email.SubjectPreview)
+		assert.Equal(t, "See what is new", created[1].Email.Subject)
+		assert.Equal(t, "Everything we shipped this month", *created[1].Email.SubjectPreview, "keeps the preheader of the base template")
+		assert.Equal(t, "Product update", llmCopyEmailTemplate().Email.Subject)
+
+		assert.True(t, broadcast.TestSettings.Enabled)
+		assert.Equal(t, 50, broadcast.TestSettings.SamplePercentage)
+		require.Len(t, broadcast.TestSettings.Variations, 3)
+		assert.Equal(t, "newsletter", broadcast.TestSettings.Variations[0].TemplateID)
+		assert.Equal(t, created[0].ID, broadcast.TestSettings.Variations[1].TemplateID)
+		assert.Equal(t, created[1].ID, broadcast.TestSettings.Variations[2].TemplateID)
+	})
+
+	t.Run("rejects a broadcast that is sending", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		expectLLMCopyAuth(m, writePermissions)
+		broadcast := draftBroadcast()
+		broadcast.Status = domain.BroadcastStatusProcessing
+		m.broadcastService.EXPECT().GetBroadcast(gomock.Any(), "ws1", "b1").Return(broadcast, nil)
+
+		_, err := service.CreateSubjectVariations(context.Background(), &domain.CreateSubjectVariationsRequest{
+			WorkspaceID: "ws1",
+			BroadcastID: "b1",
+			Candidates:  []domain.SubjectLineCandidate{{Subject: "Hello"}},
+		})
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+
+	t.Run("rejects more than 8 variations", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		expectLLMCopyAuth(m, writePermissions)
+		m.broadcastService.EXPECT().GetBroadcast(gomock.Any(), "ws1", "b1").Return(draftBroadcast(), nil)
+
+		candidates := make([]domain.SubjectLineCandidate, 8)
+		for i := range candidates {
+			candidates[i].Subject = fmt.Sprintf("Subject %d", i)
+		}
+		_, err := service.CreateSubjectVariations(context.Background(), &domain.CreateSubjectVariationsRequest{
+			WorkspaceID: "ws1",
+			BroadcastID: "b1",
+			Candidates:  candidates,
+		})
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+
+	t.Run("requires the broadcasts write permission", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		expectLLMCopyAuth(m, domain.UserPermissions{
+			domain.PermissionResourceTemplates: domain.ResourcePermissions{Read: true, Write: true},
+		})
+
+		_, err := service.CreateSubjectVariations(context.Background(), &domain.CreateSubjectVariationsRequest{
+			WorkspaceID: "ws1",
+			BroadcastID: "b1",
+			Candidates:  []domain.SubjectLineCandidate{{Subject: "Hello"}},
+		})
+		var permissionErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permissionErr)
+	})
+}
+
+func TestLLMService_RewriteBlocks(t *testing.T) {
+	llmPermissions := domain.UserPermissions{
+		domain.PermissionResourceLLM: domain.ResourcePermissions{Read: true, Write: true},
+	}
+
+	t.Run("rewrites the text and button blocks", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		server, prompts := newOpenAIStub(t, `{"blocks":[`+
+			`{"id":"intro","content":"<p>Bonjour {{ contact.first_name }}, <a href=\"https://example.com/sale\">les soldes</a> ont commencé.</p>"},`+
+			`{"id":"cta","content":"Acheter"}]}`)
+		expectLLMCopyAuth(m, llmPermissions)
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(llmCopyWorkspace(server.URL), nil)
+
+		response, err := service.RewriteBlocks(context.Background(), &domain.RewriteBlocksRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			Tree:          json.RawMessage(llmCopyTree),
+			Language:      "fr",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"intro", "cta"}, response.RewrittenIDs)
+		blocks, err := collectTextBlocks(response.Tree, nil)
+		require.NoError(t, err)
+		assert.Contains(t, *blocks[0].GetContent(), "les soldes")
+		assert.Equal(t, "Acheter", *blocks[1].GetContent())
+		assert.Contains(t, (*prompts)[0], `"fr"`)
+	})
+
+	t.Run("rewrites the selected blocks only", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		server, prompts := newOpenAIStub(t, `{"blocks":[{"id":"cta","content":"Grab yours"}]}`)
+		expectLLMCopyAuth(m, llmPermissions)
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(llmCopyWorkspace(server.URL), nil)
+
+		response, err := service.RewriteBlocks(context.Background(), &domain.RewriteBlocksRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			Tree:          json.RawMessage(llmCopyTree),
+			BlockIDs:      []string{"cta"},
+			Tone:          "playful",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"cta"}, response.RewrittenIDs)
+		assert.NotContains(t, (*prompts)[0], "intro")
+	})
+
+	t.Run("rejects a rewrite dropping a liquid tag", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		server, _ := newOpenAIStub(t, `{"blocks":[`+
+			`{"id":"intro","content":"<p>Hi there, <a href=\"https://example.com/sale\">the sale</a> is live.</p>"},`+
+			`{"id":"cta","content":"Shop"}]}`)
+		expectLLMCopyAuth(m, llmPermissions)
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(llmCopyWorkspace(server.URL), nil)
+
+		_, err := service.RewriteBlocks(context.Background(), &domain.RewriteBlocksRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			Tree:          json.RawMessage(llmCopyTree),
+			Tone:          "casual",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "liquid tags were not preserved")
+	})
+
+	t.Run("rejects an unknown block", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		expectLLMCopyAuth(m, llmPermissions)
+
+		_, err := service.RewriteBlocks(context.Background(), &domain.RewriteBlocksRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			Tree:          json.RawMessage(llmCopyTree),
+			BlockIDs:      []string{"cta", "column"},
+			Tone:          "casual",
+		})
+		assert.IsType(t, domain.ValidationError{}, err)
+		assert.Contains(t, err.Error(), "column")
+	})
+}
+
+func TestCheckPreservedMarkup(t *testing.T) {
+	original := `Hi {{ contact.first_name }}, {% if vip %}<a href="https://example.com/vip">VIP</a>{% endif %}`
+
+	assert.NoError(t, checkPreservedMarkup(original, `{% if vip %}<a href="https://example.com/vip">Members</a>{% endif %} Hello {{ contact.first_name }}`))
+	assert.Error(t, checkPreservedMarkup(original, `Hi {{ contact.firstname }}, {% if vip %}<a href="https://example.com/vip">VIP</a>{% endif %}`))
+	assert.Error(t, checkPreservedMarkup(original, `Hi {{ contact.first_name }}, {% if vip %}<a href="https://example.com/other">VIP</a>{% endif %}`))
+	assert.Error(t, checkPreservedMarkup(original, `Hi {{ contact.first_name }}, {% if vip %}VIP{% endif %}`))
+}
+
+func TestParseLLMJSON(t *testing.T) {
+	var reply struct {
+		Value string `json:"value"`
+	}
+	require.NoError(t, parseLLMJSON("Here you go:\n```json\n{\"value\": \"ok\"}\n```", &reply))
+	assert.Equal(t, "ok", reply.Value)
+
+	assert.Error(t, parseLLMJSON("I cannot help with that", &reply))
+	assert.Error(t, parseLLMJSON("{not json}", &reply))
+}
+
+func TestVariationTemplateName(t *testing.T) {
+	assert.Equal(t, "Newsletter - subject 2", variationTemplateName("Newsletter", 2))
+
+	name := variationTemplateName(strings.Repeat("a", 40), 3)
+	assert.Len(t, name, 32)
+	assert.True(t, strings.HasSuffix(name, " - subject 3"))
+}
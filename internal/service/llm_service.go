@@ -45,27 +45,48 @@ func calculateCost(model string, inputTokens, outputTokens int64) (inputCost, ou
 
 // LLMServiceConfig contains configuration for the LLM service
 type LLMServiceConfig struct {
-	AuthService   domain.AuthService
-	WorkspaceRepo domain.WorkspaceRepository
-	Logger        logger.Logger
-	ToolRegistry  *ServerSideToolRegistry
+	AuthService        domain.AuthService
+	WorkspaceRepo      domain.WorkspaceRepository
+	Logger             logger.Logger
+	ToolRegistry       *ServerSideToolRegistry
+	TemplateService    domain.TemplateService
+	BroadcastService   domain.BroadcastService
+	MessageHistoryRepo domain.MessageHistoryRepository
+	ListRepo           domain.ListRepository
+	SegmentRepo        domain.SegmentRepository
+	WorkspaceTools     *WorkspaceToolRegistry
+	ToolCallRepo       domain.LLMToolCallRepository
 }
 
 // LLMService implements the LLM chat functionality
 type LLMService struct {
-	authService   domain.AuthService
-	workspaceRepo domain.WorkspaceRepository
-	logger        logger.Logger
-	toolRegistry  *ServerSideToolRegistry
+	authService        domain.AuthService
+	workspaceRepo      domain.WorkspaceRepository
+	logger             logger.Logger
+	toolRegistry       *ServerSideToolRegistry
+	templateService    domain.TemplateService
+	broadcastService   domain.BroadcastService
+	messageHistoryRepo domain.MessageHistoryRepository
+	listRepo           domain.ListRepository
+	segmentRepo        domain.SegmentRepository
+	workspaceTools     *WorkspaceToolRegistry
+	toolCallRepo       domain.LLMToolCallRepository
 }
 
 // NewLLMService creates a new LLM service
 func NewLLMService(config LLMServiceConfig) *LLMService {
 	return &LLMService{
-		authService:   config.AuthService,
-		workspaceRepo: config.WorkspaceRepo,
-		logger:        config.Logger,
-		toolRegistry:  config.ToolRegistry,
+		authService:        config.AuthService,
+		workspaceRepo:      config.WorkspaceRepo,
+		logger:             config.Logger,
+		toolRegistry:       config.ToolRegistry,
+		templateService:    config.TemplateService,
+		broadcastService:   config.BroadcastService,
+		messageHistoryRepo: config.MessageHistoryRepo,
+		listRepo:           config.ListRepo,
+		segmentRepo:        config.SegmentRepo,
+		workspaceTools:     config.WorkspaceTools,
+		toolCallRepo:       config.ToolCallRepo,
 	}
 }
 
@@ -108,37 +129,69 @@ func (s *LLMService) StreamChat(ctx context.Context, req *domain.LLMChatRequest,
 		s.logger.Debug("Injected Firecrawl tools into LLM request")
 	}
 
+	// 3.7 Inject the workspace tools the user has the permissions for
+	tools := &llmToolSession{workspaceID: req.WorkspaceID, firecrawl: firecrawlSettings}
+	if req.WorkspaceTools && s.workspaceTools != nil {
+		req.Tools = append(req.Tools, s.workspaceTools.GetTools(userWorkspace)...)
+		tools.userWorkspace = userWorkspace
+	}
+
 	// 4. Find the LLM integration
-	integration := workspace.GetIntegrationByID(req.IntegrationID)
+	integration, err := getLLMIntegration(workspace, req.IntegrationID)
+	if err != nil {
+		return err
+	}
+
+	// 5. Dispatch to provider-specific streaming implementation
+	return s.streamWithProvider(ctx, req, integration.LLMProvider, tools, onEvent)
+}
+
+// getLLMIntegration returns the LLM integration of a workspace
+func getLLMIntegration(workspace *domain.Workspace, integrationID string) (*domain.Integration, error) {
+	integration := workspace.GetIntegrationByID(integrationID)
 	if integration == nil {
-		return fmt.Errorf("integration not found: %s", req.IntegrationID)
+		return nil, fmt.Errorf("integration not found: %s", integrationID)
 	}
 	if integration.Type != domain.IntegrationTypeLLM {
-		return fmt.Errorf("integration is not an LLM integration: %s", req.IntegrationID)
+		return nil, fmt.Errorf("integration is not an LLM integration: %s", integrationID)
 	}
 	if integration.LLMProvider == nil {
-		return fmt.Errorf("LLM provider configuration is missing")
+		return nil, fmt.Errorf("LLM provider configuration is missing")
 	}
+	return integration, nil
+}
 
-	// 5. Dispatch to provider-specific streaming implementation
-	switch integration.LLMProvider.Kind {
+// streamWithProvider dispatches a chat request to the streaming implementation of the provider
+func (s *LLMService) streamWithProvider(
+	ctx context.Context,
+	req *domain.LLMChatRequest,
+	provider *domain.LLMProvider,
+	tools *llmToolSession,
+	onEvent func(domain.LLMChatEvent) error,
+) error {
+	switch provider.Kind {
 	case domain.LLMProviderKindAnthropic:
-		if integration.LLMProvider.Anthropic == nil {
+		if provider.Anthropic == nil {
 			return fmt.Errorf("Anthropic configuration is missing")
 		}
-		return s.streamChatAnthropic(ctx, req, integration.LLMProvider.Anthropic, firecrawlSettings, onEvent)
+		return s.streamChatAnthropic(ctx, req, provider.Anthropic, tools, onEvent)
 	case domain.LLMProviderKindOpenAI:
-		if integration.LLMProvider.OpenAI == nil {
+		if provider.OpenAI == nil {
 			return fmt.Errorf("OpenAI configuration is missing")
 		}
-		return s.streamChatOpenAI(ctx, req, integration.LLMProvider.OpenAI, firecrawlSettings, onEvent)
+		return s.streamChatOpenAI(ctx, req, provider.OpenAI, tools, onEvent)
 	case domain.LLMProviderKindGemini:
-		if integration.LLMProvider.Gemini == nil {
+		if provider.Gemini == nil {
 			return fmt.Errorf("Gemini configuration is missing")
 		}
-		return s.streamChatGemini(ctx, req, integration.LLMProvider.Gemini, firecrawlSettings, onEvent)
+		return s.streamChatGemini(ctx, req, provider.Gemini, tools, onEvent)
+	case domain.LLMProviderKindOllama:
+		if provider.Ollama == nil {
+			return fmt.Errorf("Ollama configuration is missing")
+		}
+		return s.streamChatOllama(ctx, req, provider.Ollama, tools, onEvent)
 	default:
-		return fmt.Errorf("unsupported LLM provider: %s", integration.LLMProvider.Kind)
+		return fmt.Errorf("unsupported LLM provider: %s", provider.Kind)
 	}
 }
 
@@ -147,7 +200,7 @@ func (s *LLMService) streamChatAnthropic(
 	ctx context.Context,
 	req *domain.LLMChatRequest,
 	settings *domain.AnthropicSettings,
-	firecrawlSettings *domain.FirecrawlSettings,
+	tools *llmToolSession,
 	onEvent func(domain.LLMChatEvent) error,
 ) error {
 	// Get decrypted API key (already decrypted by AfterLoad in repository)
@@ -279,7 +332,7 @@ func (s *LLMService) streamChatAnthropic(
 			}
 
 			// Check if this is a server-side tool
-			if firecrawlSettings != nil && s.toolRegistry != nil && s.toolRegistry.IsServerSideTool(toolBlock.Name) {
+			if s.isServerSideTool(tools, toolBlock.Name) {
 				serverToolCalls = append(serverToolCalls, struct {
 					ID    string
 					Name  string
@@ -330,7 +383,7 @@ func (s *LLMService) streamChatAnthropic(
 				s.logger.WithField("error", err.Error()).Warn("Failed to send server_tool_start event")
 			}
 
-			result, err := s.toolRegistry.ExecuteTool(ctx, firecrawlSettings, tool.Name, tool.Input)
+			result, err := s.executeServerSideTool(ctx, tools, tool.Name, tool.Input, onEvent)
 			isError := false
 			if err != nil {
 				s.logger.WithFields(map[string]interface{}{
@@ -433,7 +486,7 @@ func (s *LLMService) streamChatAnthropic(
 					continue
 				}
 
-				if s.toolRegistry.IsServerSideTool(toolBlock.Name) {
+				if s.isServerSideTool(tools, toolBlock.Name) {
 					serverToolCalls = append(serverToolCalls, struct {
 						ID    string
 						Name  string
@@ -22,7 +22,7 @@ func (s *LLMService) streamChatGemini(
 	ctx context.Context,
 	req *domain.LLMChatRequest,
 	settings *domain.GeminiSettings,
-	firecrawlSettings *domain.FirecrawlSettings,
+	tools *llmToolSession,
 	onEvent func(domain.LLMChatEvent) error,
 ) error {
 	// Get decrypted API key (already decrypted by AfterLoad in repository)
@@ -133,7 +133,7 @@ func (s *LLMService) streamChatGemini(
 					// when the assistant turn is replayed in the agentic loop.
 					fcParts = append(fcParts, part)
 
-					if firecrawlSettings != nil && s.toolRegistry != nil && s.toolRegistry.IsServerSideTool(fc.Name) {
+					if s.isServerSideTool(tools, fc.Name) {
 						serverToolCalls = append(serverToolCalls, geminiToolCall{
 							ID:    fc.ID,
 							Name:  fc.Name,
@@ -203,7 +203,7 @@ func (s *LLMService) streamChatGemini(
 				s.logger.WithField("error", err.Error()).Warn("Failed to send server_tool_start event")
 			}
 
-			result, execErr := s.toolRegistry.ExecuteTool(ctx, firecrawlSettings, tool.Name, tool.Input)
+			result, execErr := s.executeServerSideTool(ctx, tools, tool.Name, tool.Input, onEvent)
 			isError := false
 			if execErr != nil {
 				s.logger.WithFields(map[string]interface{}{
@@ -0,0 +1,493 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// ollamaModelsTimeout bounds the listing of the models of a server
+const ollamaModelsTimeout = 30 * time.Second
+
+// errOllamaToolsUnsupported is returned when the model of an Ollama server cannot call tools
+var errOllamaToolsUnsupported = errors.New("the model does not support tools")
+
+// ollamaChatRequest is a request to the native chat API of Ollama
+type ollamaChatRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Tools    []ollamaTool    `json:"tools,omitempty"`
+	Stream   bool            `json:"stream"`
+	Options  ollamaOptions   `json:"options"`
+}
+
+type ollamaOptions struct {
+	NumCtx     int `json:"num_ctx,omitempty"`
+	NumPredict int `json:"num_predict,omitempty"`
+}
+
+type ollamaMessage struct {
+	Role      string           `json:"role"`
+	Content   string           `json:"content"`
+	ToolCalls []ollamaToolCall `json:"tool_calls,omitempty"`
+	ToolName  string           `json:"tool_name,omitempty"` // Tool result messages only
+}
+
+type ollamaToolCall struct {
+	Function struct {
+		Name      string                 `json:"name"`
+		Arguments map[string]interface{} `json:"arguments"`
+	} `json:"function"`
+}
+
+type ollamaTool struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description"`
+		Parameters  json.RawMessage `json:"parameters"`
+	} `json:"function"`
+}
+
+// ollamaChatChunk is a line of the stream of the chat API
+type ollamaChatChunk struct {
+	Message         ollamaMessage `json:"message"`
+	Done            bool          `json:"done"`
+	PromptEvalCount int64         `json:"prompt_eval_count"`
+	EvalCount       int64         `json:"eval_count"`
+	Error           string        `json:"error"`
+}
+
+// ollamaTurn is a reply of the model, with the tokens it used
+type ollamaTurn struct {
+	message      ollamaMessage
+	inputTokens  int64
+	outputTokens int64
+}
+
+// streamChatOllama implements streaming chat with a self-hosted model. Ollama is reached
+// through its native chat API, a llama.cpp server through its chat completion API.
+func (s *LLMService) streamChatOllama(
+	ctx context.Context,
+	req *domain.LLMChatRequest,
+	settings *domain.OllamaSettings,
+	tools *llmToolSession,
+	onEvent func(domain.LLMChatEvent) error,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, settings.Timeout())
+	defer cancel()
+
+	if settings.IsLlamaCpp() {
+		return s.streamChatLlamaCpp(ctx, req, settings, tools, onEvent)
+	}
+
+	// Set default max tokens
+	maxTokens := req.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 2048
+	}
+
+	chatReq := &ollamaChatRequest{
+		Model:  settings.Model,
+		Stream: true,
+		Options: ollamaOptions{
+			NumCtx:     settings.ContextWindow,
+			NumPredict: maxTokens,
+		},
+	}
+
+	// System prompt goes as a system message
+	if req.SystemPrompt != "" {
+		chatReq.Messages = append(chatReq.Messages, ollamaMessage{Role: "system", Content: req.SystemPrompt})
+	}
+	for _, msg := range req.Messages {
+		chatReq.Messages = append(chatReq.Messages, ollamaMessage{Role: msg.Role, Content: msg.Content})
+	}
+
+	for _, t := range req.Tools {
+		tool := ollamaTool{Type: "function"}
+		tool.Function.Name = t.Name
+		tool.Function.Description = t.Description
+		tool.Function.Parameters = t.InputSchema
+		chatReq.Tools = append(chatReq.Tools, tool)
+	}
+
+	var totalInputTokens, totalOutputTokens int64
+
+	// Agentic loop for server-side tool execution, up to 10 rounds of tool calls
+	for iteration := 0; ; iteration++ {
+		turn, err := s.ollamaChatTurn(ctx, settings, chatReq, onEvent)
+		if errors.Is(err, errOllamaToolsUnsupported) && len(chatReq.Tools) > 0 {
+			// Tools are offered on a best effort basis, the chat goes on without them
+			s.logger.WithField("model", settings.Model).Warn("Ollama model does not support tools, chatting without them")
+			chatReq.Tools = nil
+			turn, err = s.ollamaChatTurn(ctx, settings, chatReq, onEvent)
+		}
+		if err != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("the Ollama server did not reply within %s", settings.Timeout())
+			}
+			return err
+		}
+
+		totalInputTokens += turn.inputTokens
+		totalOutputTokens += turn.outputTokens
+
+		// Handle server-side vs client-side tool calls
+		var serverToolCalls []ollamaToolCall
+		for _, call := range turn.message.ToolCalls {
+			if s.isServerSideTool(tools, call.Function.Name) {
+				serverToolCalls = append(serverToolCalls, call)
+				continue
+			}
+			// Forward client-side tool to frontend
+			if err := onEvent(domain.LLMChatEvent{
+				Type:      "tool_use",
+				ToolName:  call.Function.Name,
+				ToolInput: call.Function.Arguments,
+			}); err != nil {
+				return fmt.Errorf("failed to send tool_use event: %w", err)
+			}
+		}
+
+		if len(serverToolCalls) == 0 || iteration == 10 {
+			break
+		}
+
+		s.logger.WithFields(map[string]interface{}{
+			"iteration":  iteration,
+			"tool_count": len(serverToolCalls),
+		}).Debug("Executing server-side tools")
+
+		// Add assistant message with tool calls to conversation
+		chatReq.Messages = append(chatReq.Messages, turn.message)
+
+		for _, call := range serverToolCalls {
+			result := s.runOllamaServerTool(ctx, tools, call, onEvent)
+			chatReq.Messages = append(chatReq.Messages, ollamaMessage{
+				Role:     "tool",
+				Content:  result,
+				ToolName: call.Function.Name,
+			})
+		}
+	}
+
+	// Self-hosted models cost nothing per token
+	var noCost float64
+	return onEvent(domain.LLMChatEvent{
+		Type:         "done",
+		InputTokens:  &totalInputTokens,
+		OutputTokens: &totalOutputTokens,
+		InputCost:    &noCost,
+		OutputCost:   &noCost,
+		TotalCost:    &noCost,
+		Model:        settings.Model,
+	})
+}
+
+// runOllamaServerTool executes a server-side tool called by the model, and returns the result
+// to hand back to it
+func (s *LLMService) runOllamaServerTool(
+	ctx context.Context,
+	tools *llmToolSession,
+	call ollamaToolCall,
+	onEvent func(domain.LLMChatEvent) error,
+) string {
+	name := call.Function.Name
+
+	// Emit server_tool_start event for frontend visibility
+	if err := onEvent(domain.LLMChatEvent{
+		Type:      "server_tool_start",
+		ToolName:  name,
+		ToolInput: call.Function.Arguments,
+	}); err != nil {
+		s.logger.WithField("error", err.Error()).Warn("Failed to send server_tool_start event")
+	}
+
+	result, execErr := s.executeServerSideTool(ctx, tools, name, call.Function.Arguments, onEvent)
+	errorMessage := ""
+	if execErr != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"tool_name": name,
+			"error":     execErr.Error(),
+		}).Warn("Server-side tool execution failed")
+		result = fmt.Sprintf("Error: %s", execErr.Error())
+		errorMessage = result
+	}
+
+	// Emit server_tool_result event for frontend visibility
+	resultSummary := result
+	if len(resultSummary) > 500 {
+		resultSummary = resultSummary[:500] + "..."
+	}
+	if err := onEvent(domain.LLMChatEvent{
+		Type:     "server_tool_result",
+		ToolName: name,
+		Content:  resultSummary,
+		Error:    errorMessage,
+	}); err != nil {
+		s.logger.WithField("error", err.Error()).Warn("Failed to send server_tool_result event")
+	}
+
+	return result
+}
+
+// ollamaChatTurn streams a reply of the model, forwarding its text as it is generated
+func (s *LLMService) ollamaChatTurn(
+	ctx context.Context,
+	settings *domain.OllamaSettings,
+	chatReq *ollamaChatRequest,
+	onEvent func(domain.LLMChatEvent) error,
+) (*ollamaTurn, error) {
+	body, err := json.Marshal(chatReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Ollama request: %w", err)
+	}
+
+	resp, err := ollamaDo(ctx, settings, http.MethodPost, "/api/chat", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message := ollamaErrorMessage(resp)
+		if resp.StatusCode == http.StatusBadRequest && strings.Contains(message, "does not support tools") {
+			return nil, errOllamaToolsUnsupported
+		}
+		return nil, fmt.Errorf("Ollama server returned %d: %s", resp.StatusCode, message)
+	}
+
+	turn := &ollamaTurn{message: ollamaMessage{Role: "assistant"}}
+	var content strings.Builder
+
+	// The reply is streamed as a JSON object per line
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var chunk ollamaChatChunk
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return nil, fmt.Errorf("failed to parse Ollama stream: %w", err)
+		}
+		if chunk.Error != "" {
+			s.logger.WithField("error", chunk.Error).Error("Stream error from Ollama")
+			return nil, fmt.Errorf("stream error: %s", chunk.Error)
+		}
+
+		if chunk.Message.Content != "" {
+			content.WriteString(chunk.Message.Content)
+			if err := onEvent(domain.LLMChatEvent{
+				Type:    "text",
+				Content: chunk.Message.Content,
+			}); err != nil {
+				return nil, fmt.Errorf("failed to send event: %w", err)
+			}
+		}
+		turn.message.ToolCalls = append(turn.message.ToolCalls, chunk.Message.ToolCalls...)
+
+		if chunk.Done {
+			turn.inputTokens = chunk.PromptEvalCount
+			turn.outputTokens = chunk.EvalCount
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		s.logger.WithField("error", err.Error()).Error("Stream error from Ollama")
+		return nil, fmt.Errorf("stream error: %w", err)
+	}
+
+	turn.message.Content = content.String()
+	return turn, nil
+}
+
+// streamChatLlamaCpp streams a chat with a llama.cpp server, whose chat completion API is
+// compatible with the OpenAI one
+func (s *LLMService) streamChatLlamaCpp(
+	ctx context.Context,
+	req *domain.LLMChatRequest,
+	settings *domain.OllamaSettings,
+	tools *llmToolSession,
+	onEvent func(domain.LLMChatEvent) error,
+) error {
+	// The server ignores the API key unless it was started with one
+	apiKey := settings.APIKey
+	if apiKey == "" {
+		apiKey = "no-key"
+	}
+	openAISettings := &domain.OpenAISettings{
+		APIKey:  apiKey,
+		Model:   settings.Model,
+		BaseURL: strings.TrimRight(settings.BaseURL, "/") + "/v1",
+	}
+
+	err := s.streamChatOpenAI(ctx, req, openAISettings, tools, func(event domain.LLMChatEvent) error {
+		// Self-hosted models cost nothing per token, whatever their name
+		if event.Type == "done" {
+			var noCost float64
+			event.InputCost, event.OutputCost, event.TotalCost = &noCost, &noCost, &noCost
+		}
+		return onEvent(event)
+	})
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("the llama.cpp server did not reply within %s", settings.Timeout())
+	}
+	return err
+}
+
+// ListOllamaModels lists the models available on the server of an Ollama integration, or of
+// the server being configured. Like managing integrations, it is reserved to the owners.
+func (s *LLMService) ListOllamaModels(ctx context.Context, req *domain.ListOllamaModelsRequest) (*domain.ListOllamaModelsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, domain.NewValidationError(err.Error())
+	}
+
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+	if userWorkspace.Role != "owner" {
+		return nil, &domain.ErrUnauthorized{Message: "user is not an owner of the workspace"}
+	}
+
+	settings := req.Ollama
+	if req.IntegrationID != "" {
+		workspace, err := s.workspaceRepo.GetByID(ctx, req.WorkspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workspace: %w", err)
+		}
+		integration, err := getLLMIntegration(workspace, req.IntegrationID)
+		if err != nil {
+			return nil, domain.NewValidationError(err.Error())
+		}
+		stored := integration.LLMProvider.Ollama
+		if integration.LLMProvider.Kind != domain.LLMProviderKindOllama || stored == nil {
+			return nil, domain.NewValidationError("integration is not an Ollama integration")
+		}
+
+		if settings == nil {
+			settings = stored
+		} else if settings.APIKey == "" && settings.BaseURL == stored.BaseURL {
+			// The form does not send back the API key of the integration being edited
+			withKey := *settings
+			withKey.APIKey = stored.APIKey
+			settings = &withKey
+		}
+	}
+
+	models, err := fetchOllamaModels(ctx, settings)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ListOllamaModelsResponse{Models: models}, nil
+}
+
+// fetchOllamaModels lists the models of a server, from the native API of Ollama or the
+// model list of llama.cpp
+func fetchOllamaModels(ctx context.Context, settings *domain.OllamaSettings) ([]domain.OllamaModel, error) {
+	ctx, cancel := context.WithTimeout(ctx, ollamaModelsTimeout)
+	defer cancel()
+
+	path := "/api/tags"
+	if settings.IsLlamaCpp() {
+		path = "/v1/models"
+	}
+	resp, err := ollamaDo(ctx, settings, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned %d: %s", resp.StatusCode, ollamaErrorMessage(resp))
+	}
+
+	var list struct {
+		// Ollama
+		Models []struct {
+			Name       string    `json:"name"`
+			Size       int64     `json:"size"`
+			ModifiedAt time.Time `json:"modified_at"`
+			Details    struct {
+				Family            string `json:"family"`
+				ParameterSize     string `json:"parameter_size"`
+				QuantizationLevel string `json:"quantization_level"`
+			} `json:"details"`
+		} `json:"models"`
+		// llama.cpp
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to parse the model list: %w", err)
+	}
+
+	models := make([]domain.OllamaModel, 0, len(list.Models)+len(list.Data))
+	if settings.IsLlamaCpp() {
+		for _, model := range list.Data {
+			models = append(models, domain.OllamaModel{Name: model.ID})
+		}
+		return models, nil
+	}
+	for _, model := range list.Models {
+		models = append(models, domain.OllamaModel{
+			Name:              model.Name,
+			Size:              model.Size,
+			ModifiedAt:        model.ModifiedAt,
+			Family:            model.Details.Family,
+			ParameterSize:     model.Details.ParameterSize,
+			QuantizationLevel: model.Details.QuantizationLevel,
+		})
+	}
+	return models, nil
+}
+
+// ollamaDo sends a request to a server, with the API key of the settings as a bearer token
+func ollamaDo(ctx context.Context, settings *domain.OllamaSettings, method string, path string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(settings.BaseURL, "/")+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if settings.APIKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+settings.APIKey)
+	}
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the server at %s: %w", settings.BaseURL, err)
+	}
+	return resp, nil
+}
+
+// ollamaErrorMessage reads the error of a failed request, returned as {"error": "..."}
+func ollamaErrorMessage(resp *http.Response) string {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var payload struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Error != "" {
+		return payload.Error
+	}
+	return strings.TrimSpace(string(body))
+}
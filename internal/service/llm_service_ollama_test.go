@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ollamaStubReply is a reply of the Ollama stub: an error status and body, or the lines of
+// a chat stream
+type ollamaStubReply struct {
+	status int
+	lines  []string
+}
+
+// newOllamaStub serves the chat API of Ollama, replying to each request with the next reply,
+// and records the requests it received
+func newOllamaStub(t *testing.T, replies ...ollamaStubReply) (*httptest.Server, *[]map[string]interface{}) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/chat", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		var request map[string]interface{}
+		_ = json.Unmarshal(body, &request)
+		requests = append(requests, request)
+
+		if !assert.LessOrEqual(t, len(requests), len(replies), "unexpected request") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		reply := replies[len(requests)-1]
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		if reply.status != 0 {
+			w.WriteHeader(reply.status)
+		}
+		for _, line := range reply.lines {
+			fmt.Fprintln(w, line)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func ollamaTextReply(text string, promptTokens, evalTokens int) ollamaStubReply {
+	content, _ := json.Marshal(text)
+	return ollamaStubReply{lines: []string{
+		fmt.Sprintf(`{"model":"llama3.1:8b","message":{"role":"assistant","content":%s},"done":false}`, content),
+		fmt.Sprintf(`{"model":"llama3.1:8b","message":{"role":"assistant","content":""},"done":true,"done_reason":"stop","prompt_eval_count":%d,"eval_count":%d}`, promptTokens, evalTokens),
+	}}
+}
+
+func ollamaToolReply(toolName string, arguments string) ollamaStubReply {
+	return ollamaStubReply{lines: []string{
+		fmt.Sprintf(`{"model":"llama3.1:8b","message":{"role":"assistant","content":"","tool_calls":[{"function":{"name":%q,"arguments":%s}}]},"done":false}`, toolName, arguments),
+		`{"model":"llama3.1:8b","message":{"role":"assistant","content":""},"done":true,"done_reason":"stop","prompt_eval_count":40,"eval_count":12}`,
+	}}
+}
+
+func ollamaWorkspace(settings *domain.OllamaSettings) *domain.Workspace {
+	return &domain.Workspace{
+		ID: "ws1",
+		Integrations: []domain.Integration{{
+			ID:   "llm1",
+			Type: domain.IntegrationTypeLLM,
+			LLMProvider: &domain.LLMProvider{
+				Kind:   domain.LLMProviderKindOllama,
+				Ollama: settings,
+			},
+		}},
+	}
+}
+
+func expectOllamaChat(m *llmCopyTestMocks, settings *domain.OllamaSettings) {
+	expectLLMCopyAuth(m, domain.UserPermissions{
+		domain.PermissionResourceLLM:        domain.ResourcePermissions{Read: true, Write: true},
+		domain.PermissionResourceBroadcasts: domain.ResourcePermissions{Read: true},
+	})
+	m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(ollamaWorkspace(settings), nil)
+}
+
+func collectLLMEvents(events *[]domain.LLMChatEvent) func(domain.LLMChatEvent) error {
+	return func(event domain.LLMChatEvent) error {
+		*events = append(*events, event)
+		return nil
+	}
+}
+
+func TestLLMService_StreamChat_Ollama(t *testing.T) {
+	t.Run("streams the reply at no cost", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		server, requests := newOllamaStub(t, ollamaTextReply("Hello", 26, 8))
+		expectOllamaChat(m, &domain.OllamaSettings{BaseURL: server.URL, Model: "llama3.1:8b", ContextWindow: 16384})
+
+		var events []domain.LLMChatEvent
+		err := service.StreamChat(context.Background(), &domain.LLMChatRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			SystemPrompt:  "You write emails",
+			MaxTokens:     500,
+			Messages:      []domain.LLMMessage{{Role: "user", Content: "Hi"}},
+		}, collectLLMEvents(&events))
+		require.NoError(t, err)
+
+		require.Len(t, *requests, 1)
+		request := (*requests)[0]
+		assert.Equal(t, "llama3.1:8b", request["model"])
+		assert.Equal(t, true, request["stream"])
+		assert.Equal(t, map[string]interface{}{"num_ctx": float64(16384), "num_predict": float64(500)}, request["options"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"role": "system", "content": "You write emails"},
+			map[string]interface{}{"role": "user", "content": "Hi"},
+		}, request["messages"])
+		assert.NotContains(t, request, "tools")
+
+		require.Len(t, events, 2)
+		assert.Equal(t, domain.LLMChatEvent{Type: "text", Content: "Hello"}, events[0])
+		done := events[1]
+		assert.Equal(t, "done", done.Type)
+		assert.Equal(t, "llama3.1:8b", done.Model)
+		assert.Equal(t, int64(26), *done.InputTokens)
+		assert.Equal(t, int64(8), *done.OutputTokens)
+		assert.Zero(t, *done.TotalCost)
+	})
+
+	t.Run("executes a server-side tool", func(t *testing.T) {
+		service, m, toolMocks, toolCallRepo := setupLLMToolCallsTest(t)
+		server, requests := newOllamaStub(t, ollamaToolReply(ToolListBroadcasts, `{"limit":5}`), ollamaTextReply("Two broadcasts", 80, 20))
+		expectOllamaChat(m, &domain.OllamaSettings{BaseURL: server.URL, Model: "llama3.1:8b"})
+
+		toolMocks.broadcastService.EXPECT().ListBroadcasts(gomock.Any(), domain.ListBroadcastsParams{WorkspaceID: "ws1", Limit: 5}).
+			Return(&domain.BroadcastListResponse{Broadcasts: []*domain.Broadcast{{ID: "b1", Name: "May newsletter"}}, TotalCount: 1}, nil)
+		toolCallRepo.EXPECT().Create(gomock.Any(), "ws1", gomock.Any()).Return(nil)
+
+		var events []domain.LLMChatEvent
+		err := service.StreamChat(context.Background(), workspaceToolsChatRequest(), collectLLMEvents(&events))
+		require.NoError(t, err)
+
+		require.Len(t, *requests, 2)
+		tools, _ := (*requests)[0]["tools"].([]interface{})
+		assert.Len(t, tools, 2)
+		messages, _ := (*requests)[1]["messages"].([]interface{})
+		require.Len(t, messages, 3)
+		assistant := messages[1].(map[string]interface{})
+		assert.Equal(t, "assistant", assistant["role"])
+		assert.Len(t, assistant["tool_calls"], 1)
+		result := messages[2].(map[string]interface{})
+		assert.Equal(t, "tool", result["role"])
+		assert.Equal(t, ToolListBroadcasts, result["tool_name"])
+		assert.Contains(t, result["content"], "May newsletter")
+
+		var types []string
+		for _, event := range events {
+			types = append(types, event.Type)
+		}
+		assert.Equal(t, []string{"server_tool_start", "server_tool_result", "text", "done"}, types)
+		done := events[len(events)-1]
+		assert.Equal(t, int64(120), *done.InputTokens)
+		assert.Equal(t, int64(32), *done.OutputTokens)
+	})
+
+	t.Run("forwards a client-side tool", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		server, requests := newOllamaStub(t, ollamaToolReply("update_template", `{"subject":"Hello"}`))
+		expectOllamaChat(m, &domain.OllamaSettings{BaseURL: server.URL, Model: "llama3.1:8b"})
+
+		var events []domain.LLMChatEvent
+		err := service.StreamChat(context.Background(), &domain.LLMChatRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			Messages:      []domain.LLMMessage{{Role: "user", Content: "Change the subject"}},
+			Tools: []domain.LLMTool{{
+				Name:        "update_template",
+				Description: "Updates the template",
+				InputSchema: json.RawMessage(`{"type":"object","properties":{"subject":{"type":"string"}}}`),
+			}},
+		}, collectLLMEvents(&events))
+		require.NoError(t, err)
+
+		assert.Len(t, *requests, 1)
+		require.Len(t, events, 2)
+		assert.Equal(t, "tool_use", events[0].Type)
+		assert.Equal(t, "update_template", events[0].ToolName)
+		assert.Equal(t, map[string]interface{}{"subject": "Hello"}, events[0].ToolInput)
+	})
+
+	t.Run("chats without tools when the model does not support them", func(t *testing.T) {
+		service, m, _, _ := setupLLMToolCallsTest(t)
+		server, requests := newOllamaStub(t,
+			ollamaStubReply{status: http.StatusBadRequest, lines: []string{`{"error":"registry.ollama.ai/library/gemma:2b does not support tools"}`}},
+			ollamaTextReply("Hello", 10, 2),
+		)
+		expectOllamaChat(m, &domain.OllamaSettings{BaseURL: server.URL, Model: "gemma:2b"})
+
+		var events []domain.LLMChatEvent
+		err := service.StreamChat(context.Background(), workspaceToolsChatRequest(), collectLLMEvents(&events))
+		require.NoError(t, err)
+
+		require.Len(t, *requests, 2)
+		assert.Contains(t, (*requests)[0], "tools")
+		assert.NotContains(t, (*requests)[1], "tools")
+		assert.Equal(t, "Hello", events[0].Content)
+	})
+
+	t.Run("returns the error of the server", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		server, _ := newOllamaStub(t, ollamaStubReply{
+			status: http.StatusNotFound,
+			lines:  []string{`{"error":"model \"llama9\" not found, try pulling it first"}`},
+		})
+		expectOllamaChat(m, &domain.OllamaSettings{BaseURL: server.URL, Model: "llama9"})
+
+		err := service.StreamChat(context.Background(), &domain.LLMChatRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			Messages:      []domain.LLMMessage{{Role: "user", Content: "Hi"}},
+		}, func(domain.LLMChatEvent) error { return nil })
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Ollama server returned 404: model \"llama9\" not found")
+	})
+
+	t.Run("chats with a llama.cpp server at no cost", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		server, prompts := newOpenAIStub(t, "Hello")
+		// The stub reports a model with a price, a self-hosted copy is free nonetheless
+		expectOllamaChat(m, &domain.OllamaSettings{Server: domain.OllamaServerLlamaCpp, BaseURL: server.URL, Model: "gpt-4.1"})
+
+		var events []domain.LLMChatEvent
+		err := service.StreamChat(context.Background(), &domain.LLMChatRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			Messages:      []domain.LLMMessage{{Role: "user", Content: "Hi"}},
+		}, collectLLMEvents(&events))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"Hi"}, *prompts)
+		done := events[len(events)-1]
+		assert.Equal(t, "done", done.Type)
+		assert.Equal(t, int64(120), *done.InputTokens)
+		assert.Zero(t, *done.InputCost)
+		assert.Zero(t, *done.TotalCost)
+	})
+}
+
+func TestLLMService_ListOllamaModels(t *testing.T) {
+	expectOwner := func(m *llmCopyTestMocks, role string) {
+		m.authService.EXPECT().
+			AuthenticateUserForWorkspace(gomock.Any(), "ws1").
+			Return(context.Background(), &domain.User{ID: "user1"}, &domain.UserWorkspace{UserID: "user1", WorkspaceID: "ws1", Role: role}, nil)
+	}
+	newModelsStub := func(t *testing.T, authorizations *[]string) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*authorizations = append(*authorizations, r.Header.Get("Authorization"))
+			switch r.URL.Path {
+			case "/api/tags":
+				fmt.Fprint(w, `{"models":[{"name":"llama3.1:8b","model":"llama3.1:8b","modified_at":"2026-09-01T10:00:00Z","size":4920753328,"details":{"family":"llama","parameter_size":"8.0B","quantization_level":"Q4_K_M"}}]}`)
+			case "/v1/models":
+				fmt.Fprint(w, `{"object":"list","data":[{"id":"qwen2.5-14b-instruct-q4_k_m.gguf","object":"model"}]}`)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	t.Run("lists the models of the server being configured", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		var authorizations []string
+		server := newModelsStub(t, &authorizations)
+		expectOwner(m, "owner")
+
+		response, err := service.ListOllamaModels(context.Background(), &domain.ListOllamaModelsRequest{
+			WorkspaceID: "ws1",
+			Ollama:      &domain.OllamaSettings{BaseURL: server.URL + "/"},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, response.Models, 1)
+		model := response.Models[0]
+		assert.Equal(t, "llama3.1:8b", model.Name)
+		assert.Equal(t, int64(4920753328), model.Size)
+		assert.Equal(t, "8.0B", model.ParameterSize)
+		assert.Equal(t, "Q4_K_M", model.QuantizationLevel)
+		assert.Equal(t, []string{""}, authorizations)
+	})
+
+	t.Run("lists the models of a llama.cpp server", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		var authorizations []string
+		server := newModelsStub(t, &authorizations)
+		expectOwner(m, "owner")
+
+		response, err := service.ListOllamaModels(context.Background(), &domain.ListOllamaModelsRequest{
+			WorkspaceID: "ws1",
+			Ollama:      &domain.OllamaSettings{Server: domain.OllamaServerLlamaCpp, BaseURL: server.URL},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []domain.OllamaModel{{Name: "qwen2.5-14b-instruct-q4_k_m.gguf"}}, response.Models)
+	})
+
+	t.Run("uses the API key of the integration for its server only", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		var authorizations []string
+		server := newModelsStub(t, &authorizations)
+		other := newModelsStub(t, &authorizations)
+		workspace := ollamaWorkspace(&domain.OllamaSettings{BaseURL: server.URL, Model: "llama3.1:8b", APIKey: "proxy-token"})
+
+		for _, baseURL := range []string{server.URL, other.URL} {
+			expectOwner(m, "owner")
+			m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(workspace, nil)
+
+			_, err := service.ListOllamaModels(context.Background(), &domain.ListOllamaModelsRequest{
+				WorkspaceID:   "ws1",
+				IntegrationID: "llm1",
+				Ollama:        &domain.OllamaSettings{BaseURL: baseURL},
+			})
+			require.NoError(t, err)
+		}
+		assert.Equal(t, []string{"Bearer proxy-token", ""}, authorizations)
+	})
+
+	t.Run("rejects an integration of another provider", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		expectOwner(m, "owner")
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(llmCopyWorkspace("http://localhost"), nil)
+
+		_, err := service.ListOllamaModels(context.Background(), &domain.ListOllamaModelsRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+		})
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+
+	t.Run("is reserved to the owners", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		expectOwner(m, "member")
+
+		_, err := service.ListOllamaModels(context.Background(), &domain.ListOllamaModelsRequest{
+			WorkspaceID: "ws1",
+			Ollama:      &domain.OllamaSettings{BaseURL: "http://localhost:11434"},
+		})
+		var unauthorized *domain.ErrUnauthorized
+		assert.ErrorAs(t, err, &unauthorized)
+	})
+
+	t.Run("validates the request", func(t *testing.T) {
+		service, _ := setupLLMCopyTest(t)
+
+		_, err := service.ListOllamaModels(context.Background(), &domain.ListOllamaModelsRequest{WorkspaceID: "ws1"})
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+}
@@ -17,7 +17,7 @@ func (s *LLMService) streamChatOpenAI(
 	ctx context.Context,
 	req *domain.LLMChatRequest,
 	settings *domain.OpenAISettings,
-	firecrawlSettings *domain.FirecrawlSettings,
+	tools *llmToolSession,
 	onEvent func(domain.LLMChatEvent) error,
 ) error {
 	// Get decrypted API key
@@ -137,7 +137,7 @@ func (s *LLMService) streamChatOpenAI(
 				continue
 			}
 
-			if firecrawlSettings != nil && s.toolRegistry != nil && s.toolRegistry.IsServerSideTool(toolCall.Function.Name) {
+			if s.isServerSideTool(tools, toolCall.Function.Name) {
 				serverToolCalls = append(serverToolCalls, struct {
 					ID    string
 					Name  string
@@ -190,7 +190,7 @@ func (s *LLMService) streamChatOpenAI(
 				s.logger.WithField("error", err.Error()).Warn("Failed to send server_tool_start event")
 			}
 
-			result, execErr := s.toolRegistry.ExecuteTool(ctx, firecrawlSettings, tool.Name, tool.Input)
+			result, execErr := s.executeServerSideTool(ctx, tools, tool.Name, tool.Input, onEvent)
 			isError := false
 			if execErr != nil {
 				s.logger.WithFields(map[string]interface{}{
@@ -262,7 +262,7 @@ func (s *LLMService) streamChatOpenAI(
 					continue
 				}
 
-				if s.toolRegistry.IsServerSideTool(toolCall.Function.Name) {
+				if s.isServerSideTool(tools, toolCall.Function.Name) {
 					serverToolCalls = append(serverToolCalls, struct {
 						ID    string
 						Name  string
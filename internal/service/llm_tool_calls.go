@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/google/uuid"
+)
+
+// toolConfirmationResult is handed to the assistant in place of the result of a write
+// tool, which only runs once the user confirms it
+const toolConfirmationResult = "The user was asked to confirm this change, it has not been made yet. Tell the user what will change and wait for the confirmation."
+
+// llmToolSession holds what the server-side tools of a chat need
+type llmToolSession struct {
+	workspaceID string
+	firecrawl   *domain.FirecrawlSettings
+	// userWorkspace is the user chatting, set when the workspace tools are offered
+	userWorkspace *domain.UserWorkspace
+}
+
+// isServerSideTool checks if a tool called by the assistant is handled by the server
+// rather than forwarded to the frontend
+func (s *LLMService) isServerSideTool(tools *llmToolSession, toolName string) bool {
+	if tools == nil {
+		return false
+	}
+	if tools.firecrawl != nil && s.toolRegistry != nil && s.toolRegistry.IsServerSideTool(toolName) {
+		return true
+	}
+	return tools.userWorkspace != nil && s.workspaceTools != nil && s.workspaceTools.IsWorkspaceTool(toolName)
+}
+
+// executeServerSideTool executes a server-side tool. A workspace tool changing data is
+// not executed: it is recorded as proposed, and the frontend is asked to confirm it.
+// Every workspace tool call is recorded in the audit trail.
+func (s *LLMService) executeServerSideTool(
+	ctx context.Context,
+	tools *llmToolSession,
+	toolName string,
+	input map[string]interface{},
+	onEvent func(domain.LLMChatEvent) error,
+) (string, error) {
+	if tools.userWorkspace == nil || !s.workspaceTools.IsWorkspaceTool(toolName) {
+		return s.toolRegistry.ExecuteTool(ctx, tools.firecrawl, toolName, input)
+	}
+
+	call := &domain.LLMToolCall{
+		ID:       uuid.New().String(),
+		UserID:   tools.userWorkspace.UserID,
+		ToolName: toolName,
+		Input:    input,
+	}
+
+	if err := s.workspaceTools.CheckPermission(tools.userWorkspace, toolName, input); err != nil {
+		call.Status = domain.LLMToolCallStatusFailed
+		call.Error = err.Error()
+		s.recordToolCall(ctx, tools.workspaceID, call)
+		return "", err
+	}
+
+	if s.workspaceTools.RequiresConfirmation(toolName) {
+		call.Status = domain.LLMToolCallStatusProposed
+		if err := s.toolCallRepo.Create(ctx, tools.workspaceID, call); err != nil {
+			return "", fmt.Errorf("failed to record the tool call: %w", err)
+		}
+		if err := onEvent(domain.LLMChatEvent{
+			Type:       "tool_confirmation",
+			ToolName:   toolName,
+			ToolInput:  input,
+			ToolCallID: call.ID,
+		}); err != nil {
+			return "", fmt.Errorf("failed to send tool_confirmation event: %w", err)
+		}
+		return toolConfirmationResult, nil
+	}
+
+	result, err := s.workspaceTools.ExecuteTool(ctx, tools.workspaceID, toolName, input)
+	call.Status = domain.LLMToolCallStatusSucceeded
+	if err != nil {
+		call.Status = domain.LLMToolCallStatusFailed
+		call.Error = err.Error()
+	}
+	s.recordToolCall(ctx, tools.workspaceID, call)
+	return result, err
+}
+
+// recordToolCall adds a tool call to the audit trail. A failure is logged, it does not
+// interrupt the chat.
+func (s *LLMService) recordToolCall(ctx context.Context, workspaceID string, call *domain.LLMToolCall) {
+	if err := s.toolCallRepo.Create(ctx, workspaceID, call); err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"workspace_id": workspaceID,
+			"tool_name":    call.ToolName,
+			"error":        err.Error(),
+		}).Error("Failed to record LLM tool call")
+	}
+}
+
+// ConfirmToolCall executes or declines a workspace tool call the assistant proposed to the
+// user. Only the user it was proposed to can confirm it, with the permissions they have now.
+func (s *LLMService) ConfirmToolCall(ctx context.Context, req *domain.ConfirmLLMToolCallRequest) (*domain.ConfirmLLMToolCallResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, domain.NewValidationError(err.Error())
+	}
+
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+	if !userWorkspace.HasPermission(domain.PermissionResourceLLM, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceLLM,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to LLM required",
+		)
+	}
+
+	call, err := s.toolCallRepo.GetByID(ctx, req.WorkspaceID, req.ToolCallID)
+	if err != nil {
+		return nil, err
+	}
+	if call.UserID != userWorkspace.UserID {
+		return nil, domain.NewValidationError("the tool call was proposed to another user")
+	}
+	if call.Status != domain.LLMToolCallStatusProposed {
+		return nil, domain.NewValidationError(domain.ErrLLMToolCallResolved.Error())
+	}
+
+	if !req.Approve {
+		call.Status = domain.LLMToolCallStatusDeclined
+		if err := s.updateToolCallStatus(ctx, req.WorkspaceID, call, domain.LLMToolCallStatusProposed); err != nil {
+			return nil, err
+		}
+		return &domain.ConfirmLLMToolCallResponse{ToolCall: call}, nil
+	}
+
+	if err := s.workspaceTools.CheckPermission(userWorkspace, call.ToolName, call.Input); err != nil {
+		return nil, err
+	}
+
+	call.Status = domain.LLMToolCallStatusRunning
+	if err := s.updateToolCallStatus(ctx, req.WorkspaceID, call, domain.LLMToolCallStatusProposed); err != nil {
+		return nil, err
+	}
+
+	result, execErr := s.workspaceTools.ExecuteTool(ctx, req.WorkspaceID, call.ToolName, call.Input)
+	call.Status = domain.LLMToolCallStatusSucceeded
+	if execErr != nil {
+		call.Status = domain.LLMToolCallStatusFailed
+		call.Error = execErr.Error()
+	}
+	if err := s.toolCallRepo.UpdateStatus(ctx, req.WorkspaceID, call, domain.LLMToolCallStatusRunning); err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"tool_call_id": call.ID,
+			"error":        err.Error(),
+		}).Error("Failed to record the outcome of an LLM tool call")
+	}
+
+	return &domain.ConfirmLLMToolCallResponse{ToolCall: call, Result: result}, nil
+}
+
+// updateToolCallStatus saves the status of a proposed tool call, and reports a call
+// confirmed or declined concurrently as a validation error
+func (s *LLMService) updateToolCallStatus(ctx context.Context, workspaceID string, call *domain.LLMToolCall, from domain.LLMToolCallStatus) error {
+	err := s.toolCallRepo.UpdateStatus(ctx, workspaceID, call, from)
+	if errors.Is(err, domain.ErrLLMToolCallResolved) {
+		return domain.NewValidationError(err.Error())
+	}
+	return err
+}
+
+// ListToolCalls returns the most recent workspace tool calls of the assistant
+func (s *LLMService) ListToolCalls(ctx context.Context, workspaceID string, limit int) ([]*domain.LLMToolCall, error) {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+	if !userWorkspace.HasPermission(domain.PermissionResourceLLM, domain.PermissionTypeRead) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceLLM,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to LLM required",
+		)
+	}
+
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.toolCallRepo.List(ctx, workspaceID, limit)
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupLLMToolCallsTest(t *testing.T) (*LLMService, *llmCopyTestMocks, *workspaceToolMocks, *mocks.MockLLMToolCallRepository) {
+	service, m := setupLLMCopyTest(t)
+	registry, toolMocks := setupWorkspaceToolRegistryTest(t)
+	toolCallRepo := mocks.NewMockLLMToolCallRepository(gomock.NewController(t))
+	service.workspaceTools = registry
+	service.toolCallRepo = toolCallRepo
+	return service, m, toolMocks, toolCallRepo
+}
+
+// newOpenAIToolStub serves an OpenAI compatible chat completion stream calling a tool, then
+// replying with a text once it received the result, and records the requests it received
+func newOpenAIToolStub(t *testing.T, toolName string, arguments string) (*httptest.Server, *[]map[string]interface{}) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var request map[string]interface{}
+		_ = json.Unmarshal(body, &request)
+		requests = append(requests, request)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		if len(requests) == 1 {
+			args, _ := json.Marshal(arguments)
+			fmt.Fprintf(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"created\":0,\"model\":\"gpt-4.1\",\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"tool_calls\":[{\"index\":0,\"id\":\"call_1\",\"type\":\"function\",\"function\":{\"name\":%q,\"arguments\":%s}}]},\"finish_reason\":null}]}\n\n", toolName, args)
+			fmt.Fprint(w, "data: {\"id\":\"1\",\"object\":\"chat.completion.chunk\",\"created\":0,\"model\":\"gpt-4.1\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"tool_calls\"}]}\n\n")
+		} else {
+			fmt.Fprint(w, "data: {\"id\":\"2\",\"object\":\"chat.completion.chunk\",\"created\":0,\"model\":\"gpt-4.1\",\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":\"Done\"},\"finish_reason\":null}]}\n\n")
+			fmt.Fprint(w, "data: {\"id\":\"2\",\"object\":\"chat.completion.chunk\",\"created\":0,\"model\":\"gpt-4.1\",\"choices\":[{\"index\":0,\"delta\":{},\"finish_reason\":\"stop\"}]}\n\n")
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// toolMessages returns the content of the tool result messages of a chat completion request
+func toolMessages(request map[string]interface{}) []string {
+	var contents []string
+	messages, _ := request["messages"].([]interface{})
+	for _, message := range messages {
+		message, _ := message.(map[string]interface{})
+		if message["role"] == "tool" {
+			content, _ := message["content"].(string)
+			contents = append(contents, content)
+		}
+	}
+	return contents
+}
+
+func workspaceToolsChatRequest() *domain.LLMChatRequest {
+	return &domain.LLMChatRequest{
+		WorkspaceID:    "ws1",
+		IntegrationID:  "llm1",
+		Messages:       []domain.LLMMessage{{Role: "user", Content: "How did the last broadcasts perform?"}},
+		WorkspaceTools: true,
+	}
+}
+
+func TestLLMService_StreamChat_WorkspaceTools(t *testing.T) {
+	t.Run("executes a read tool and records it", func(t *testing.T) {
+		service, m, toolMocks, toolCallRepo := setupLLMToolCallsTest(t)
+		server, requests := newOpenAIToolStub(t, ToolListBroadcasts, `{"limit":5}`)
+		expectLLMCopyAuth(m, domain.UserPermissions{
+			domain.PermissionResourceLLM:        domain.ResourcePermissions{Read: true, Write: true},
+			domain.PermissionResourceBroadcasts: domain.ResourcePermissions{Read: true},
+		})
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(llmCopyWorkspace(server.URL), nil)
+
+		toolMocks.broadcastService.EXPECT().ListBroadcasts(gomock.Any(), domain.ListBroadcastsParams{WorkspaceID: "ws1", Limit: 5}).
+			Return(&domain.BroadcastListResponse{Broadcasts: []*domain.Broadcast{{ID: "b1", Name: "May newsletter"}}, TotalCount: 1}, nil)
+		toolCallRepo.EXPECT().Create(gomock.Any(), "ws1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, call *domain.LLMToolCall) error {
+				assert.Equal(t, "user1", call.UserID)
+				assert.Equal(t, ToolListBroadcasts, call.ToolName)
+				assert.Equal(t, domain.LLMToolCallStatusSucceeded, call.Status)
+				return nil
+			})
+
+		var events []domain.LLMChatEvent
+		err := service.StreamChat(context.Background(), workspaceToolsChatRequest(), func(event domain.LLMChatEvent) error {
+			events = append(events, event)
+			return nil
+		})
+		require.NoError(t, err)
+
+		require.Len(t, *requests, 2)
+		tools, _ := (*requests)[0]["tools"].([]interface{})
+		assert.Len(t, tools, 2, "only the tools the user has access to are offered")
+		results := toolMessages((*requests)[1])
+		require.Len(t, results, 1)
+		assert.Contains(t, results[0], "May newsletter")
+
+		var types []string
+		for _, event := range events {
+			types = append(types, event.Type)
+		}
+		assert.Equal(t, []string{"server_tool_start", "server_tool_result", "text", "done"}, types)
+	})
+
+	t.Run("asks the confirmation of a write tool", func(t *testing.T) {
+		service, m, _, toolCallRepo := setupLLMToolCallsTest(t)
+		server, requests := newOpenAIToolStub(t, ToolCreateSegment, `{"name":"Repeat buyers","tree":{"kind":"leaf"}}`)
+		expectLLMCopyAuth(m, domain.UserPermissions{
+			domain.PermissionResourceLLM:      domain.ResourcePermissions{Read: true, Write: true},
+			domain.PermissionResourceContacts: domain.ResourcePermissions{Read: true, Write: true},
+		})
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(llmCopyWorkspace(server.URL), nil)
+
+		var proposed *domain.LLMToolCall
+		toolCallRepo.EXPECT().Create(gomock.Any(), "ws1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, call *domain.LLMToolCall) error {
+				proposed = call
+				return nil
+			})
+
+		var confirmation *domain.LLMChatEvent
+		err := service.StreamChat(context.Background(), workspaceToolsChatRequest(), func(event domain.LLMChatEvent) error {
+			if event.Type == "tool_confirmation" {
+				confirmation = &event
+			}
+			return nil
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, proposed)
+		assert.Equal(t, domain.LLMToolCallStatusProposed, proposed.Status)
+		require.NotNil(t, confirmation)
+		assert.Equal(t, proposed.ID, confirmation.ToolCallID)
+		assert.Equal(t, "Repeat buyers", confirmation.ToolInput["name"])
+		assert.Equal(t, []string{toolConfirmationResult}, toolMessages((*requests)[1]))
+	})
+
+	t.Run("does not offer the tools without the flag", func(t *testing.T) {
+		service, m, _, _ := setupLLMToolCallsTest(t)
+		server, prompts := newOpenAIStub(t, "Hello")
+		expectLLMCopyAuth(m, domain.UserPermissions{
+			domain.PermissionResourceLLM: domain.ResourcePermissions{Read: true, Write: true},
+		})
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(llmCopyWorkspace(server.URL), nil)
+
+		req := workspaceToolsChatRequest()
+		req.WorkspaceTools = false
+		require.NoError(t, service.StreamChat(context.Background(), req, func(domain.LLMChatEvent) error { return nil }))
+		assert.Len(t, *prompts, 1)
+		assert.Empty(t, req.Tools)
+	})
+}
+
+func TestLLMService_ConfirmToolCall(t *testing.T) {
+	permissions := domain.UserPermissions{
+		domain.PermissionResourceLLM:      domain.ResourcePermissions{Read: true, Write: true},
+		domain.PermissionResourceContacts: domain.ResourcePermissions{Read: true, Write: true},
+	}
+	proposedCall := func() *domain.LLMToolCall {
+		return &domain.LLMToolCall{
+			ID:       "call1",
+			UserID:   "user1",
+			ToolName: ToolCreateSegment,
+			Input:    domain.MapOfAny{"name": "Repeat buyers", "tree": map[string]interface{}{"kind": "leaf"}},
+			Status:   domain.LLMToolCallStatusProposed,
+		}
+	}
+
+	t.Run("executes an approved call", func(t *testing.T) {
+		service, m, toolMocks, toolCallRepo := setupLLMToolCallsTest(t)
+		expectLLMCopyAuth(m, permissions)
+		toolCallRepo.EXPECT().GetByID(gomock.Any(), "ws1", "call1").Return(proposedCall(), nil)
+		gomock.InOrder(
+			toolCallRepo.EXPECT().UpdateStatus(gomock.Any(), "ws1", gomock.Any(), domain.LLMToolCallStatusProposed).Return(nil),
+			toolCallRepo.EXPECT().UpdateStatus(gomock.Any(), "ws1", gomock.Any(), domain.LLMToolCallStatusRunning).Return(nil),
+		)
+		toolMocks.segmentService.EXPECT().CreateSegment(gomock.Any(), gomock.Any()).
+			Return(&domain.Segment{ID: "seg1", Name: "Repeat buyers", Status: "building"}, nil)
+
+		response, err := service.ConfirmToolCall(context.Background(), &domain.ConfirmLLMToolCallRequest{
+			WorkspaceID: "ws1",
+			ToolCallID:  "call1",
+			Approve:     true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.LLMToolCallStatusSucceeded, response.ToolCall.Status)
+		assert.Contains(t, response.Result, `"segment_id":"seg1"`)
+	})
+
+	t.Run("records a failed execution", func(t *testing.T) {
+		service, m, toolMocks, toolCallRepo := setupLLMToolCallsTest(t)
+		expectLLMCopyAuth(m, permissions)
+		toolCallRepo.EXPECT().GetByID(gomock.Any(), "ws1", "call1").Return(proposedCall(), nil)
+		toolCallRepo.EXPECT().UpdateStatus(gomock.Any(), "ws1", gomock.Any(), domain.LLMToolCallStatusProposed).Return(nil)
+		toolCallRepo.EXPECT().UpdateStatus(gomock.Any(), "ws1", gomock.Any(), domain.LLMToolCallStatusRunning).
+			DoAndReturn(func(_ context.Context, _ string, call *domain.LLMToolCall, _ domain.LLMToolCallStatus) error {
+				assert.Equal(t, domain.LLMToolCallStatusFailed, call.Status)
+				assert.Contains(t, call.Error, "invalid tree")
+				return nil
+			})
+		toolMocks.segmentService.EXPECT().CreateSegment(gomock.Any(), gomock.Any()).Return(nil, errors.New("invalid tree"))
+
+		response, err := service.ConfirmToolCall(context.Background(), &domain.ConfirmLLMToolCallRequest{
+			WorkspaceID: "ws1",
+			ToolCallID:  "call1",
+			Approve:     true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.LLMToolCallStatusFailed, response.ToolCall.Status)
+	})
+
+	t.Run("declines a call", func(t *testing.T) {
+		service, m, _, toolCallRepo := setupLLMToolCallsTest(t)
+		expectLLMCopyAuth(m, permissions)
+		toolCallRepo.EXPECT().GetByID(gomock.Any(), "ws1", "call1").Return(proposedCall(), nil)
+		toolCallRepo.EXPECT().UpdateStatus(gomock.Any(), "ws1", gomock.Any(), domain.LLMToolCallStatusProposed).Return(nil)
+
+		response, err := service.ConfirmToolCall(context.Background(), &domain.ConfirmLLMToolCallRequest{
+			WorkspaceID: "ws1",
+			ToolCallID:  "call1",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.LLMToolCallStatusDeclined, response.ToolCall.Status)
+		assert.Empty(t, response.Result)
+	})
+
+	t.Run("rejects a call confirmed concurrently", func(t *testing.T) {
+		service, m, _, toolCallRepo := setupLLMToolCallsTest(t)
+		expectLLMCopyAuth(m, permissions)
+		toolCallRepo.EXPECT().GetByID(gomock.Any(), "ws1", "call1").Return(proposedCall(), nil)
+		toolCallRepo.EXPECT().UpdateStatus(gomock.Any(), "ws1", gomock.Any(), domain.LLMToolCallStatusProposed).
+			Return(domain.ErrLLMToolCallResolved)
+
+		_, err := service.ConfirmToolCall(context.Background(), &domain.ConfirmLLMToolCallRequest{
+			WorkspaceID: "ws1",
+			ToolCallID:  "call1",
+			Approve:     true,
+		})
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+
+	t.Run("rejects a call proposed to another user", func(t *testing.T) {
+		service, m, _, toolCallRepo := setupLLMToolCallsTest(t)
+		expectLLMCopyAuth(m, permissions)
+		call := proposedCall()
+		call.UserID = "user2"
+		toolCallRepo.EXPECT().GetByID(gomock.Any(), "ws1", "call1").Return(call, nil)
+
+		_, err := service.ConfirmToolCall(context.Background(), &domain.ConfirmLLMToolCallRequest{
+			WorkspaceID: "ws1",
+			ToolCallID:  "call1",
+			Approve:     true,
+		})
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+
+	t.Run("checks the permissions of the user again", func(t *testing.T) {
+		service, m, _, toolCallRepo := setupLLMToolCallsTest(t)
+		expectLLMCopyAuth(m, domain.UserPermissions{
+			domain.PermissionResourceLLM:      domain.ResourcePermissions{Read: true, Write: true},
+			domain.PermissionResourceContacts: domain.ResourcePermissions{Read: true},
+		})
+		toolCallRepo.EXPECT().GetByID(gomock.Any(), "ws1", "call1").Return(proposedCall(), nil)
+
+		_, err := service.ConfirmToolCall(context.Background(), &domain.ConfirmLLMToolCallRequest{
+			WorkspaceID: "ws1",
+			ToolCallID:  "call1",
+			Approve:     true,
+		})
+		var permissionErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permissionErr)
+	})
+}
+
+func TestLLMService_ListToolCalls(t *testing.T) {
+	t.Run("lists the calls", func(t *testing.T) {
+		service, m, _, toolCallRepo := setupLLMToolCallsTest(t)
+		expectLLMCopyAuth(m, domain.UserPermissions{
+			domain.PermissionResourceLLM: domain.ResourcePermissions{Read: true},
+		})
+		toolCallRepo.EXPECT().List(gomock.Any(), "ws1", 50).Return([]*domain.LLMToolCall{{ID: "call1"}}, nil)
+
+		calls, err := service.ListToolCalls(context.Background(), "ws1", 0)
+		require.NoError(t, err)
+		assert.Len(t, calls, 1)
+	})
+
+	t.Run("requires the LLM read permission", func(t *testing.T) {
+		service, m, _, _ := setupLLMToolCallsTest(t)
+		expectLLMCopyAuth(m, domain.UserPermissions{})
+
+		_, err := service.ListToolCalls(context.Background(), "ws1", 10)
+		var permissionErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permissionErr)
+	})
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+const translateEmailSystemPrompt = `You are a professional translator of marketing and transactional emails.
+Translate the subject, the preheader and the HTML content of every block you are given. Keep the HTML structure, every Liquid tag such as {{ contact.first_name }} or {% if ... %} and every link URL exactly as written: only translate the text around them.
+Reply with JSON only, in the format {"subject": "...", "preheader": "...", "blocks": [{"id": "...", "content": "..."}]}, with one entry for every block you were given.`
+
+// TranslateTemplates translates the subject, preheader and text blocks of email templates
+// into the languages of the workspace, and stores the results as draft translations flagged
+// as machine translated. Without a template ID, every email template missing one of the
+// languages is translated. A failed translation does not stop the others: it is reported in
+// the results.
+func (s *LLMService) TranslateTemplates(ctx context.Context, req *domain.TranslateTemplatesRequest) (*domain.TranslateTemplatesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, domain.NewValidationError(err.Error())
+	}
+
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+	if !userWorkspace.HasPermission(domain.PermissionResourceLLM, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceLLM,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to LLM required",
+		)
+	}
+	if !userWorkspace.HasPermission(domain.PermissionResourceTemplates, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceTemplates,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to templates required",
+		)
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	integration, err := getLLMIntegration(workspace, req.IntegrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	languages, err := translationLanguages(workspace, req.Languages)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := s.templatesToTranslate(ctx, req.WorkspaceID, req.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.TranslateTemplatesResponse{Results: []domain.TemplateTranslationResult{}}
+	for _, template := range templates {
+		var translated []int
+		for _, language := range languages {
+			if _, exists := template.Translations[language]; exists && !req.Overwrite {
+				continue
+			}
+
+			result := domain.TemplateTranslationResult{TemplateID: template.ID, Language: language}
+			email, usage, err := s.translateEmail(ctx, req.WorkspaceID, integration, template.Email, workspace.Settings.DefaultLanguage, language)
+			addLLMUsage(&response.Usage, usage)
+			if err == nil {
+				err = template.SetMachineTranslation(language, email)
+			}
+			if err != nil {
+				s.logger.WithFields(map[string]interface{}{
+					"template_id": template.ID,
+					"language":    language,
+					"error":       err.Error(),
+				}).Warn("Failed to translate template")
+				result.Error = err.Error()
+			} else {
+				translated = append(translated, len(response.Results))
+			}
+			response.Results = append(response.Results, result)
+		}
+
+		if len(translated) == 0 {
+			continue
+		}
+		if err := s.templateService.UpdateTemplate(ctx, req.WorkspaceID, template); err != nil {
+			if req.TemplateID != "" {
+				return nil, err
+			}
+			for _, i := range translated {
+				response.Results[i].Error = fmt.Sprintf("failed to save translation: %v", err)
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// translationLanguages returns the requested target languages, or every language of the
+// workspace but the default one
+func translationLanguages(workspace *domain.Workspace, requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		var languages []string
+		for _, language := range workspace.Settings.Languages {
+			if language != workspace.Settings.DefaultLanguage {
+				languages = append(languages, language)
+			}
+		}
+		if len(languages) == 0 {
+			return nil, domain.NewValidationError("the workspace has no language other than the default one")
+		}
+		return languages, nil
+	}
+
+	for _, language := range requested {
+		if language == workspace.Settings.DefaultLanguage {
+			return nil, domain.NewValidationError(fmt.Sprintf("%s is the default language of the workspace", language))
+		}
+		found := false
+		for _, workspaceLanguage := range workspace.Settings.Languages {
+			if workspaceLanguage == language {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, domain.NewValidationError(fmt.Sprintf("%s is not a language of the workspace", language))
+		}
+	}
+	return requested, nil
+}
+
+// templatesToTranslate returns the template to translate, or every email template of the
+// workspace built with the visual editor
+func (s *LLMService) templatesToTranslate(ctx context.Context, workspaceID, templateID string) ([]*domain.Template, error) {
+	if templateID != "" {
+		template, err := s.templateService.GetTemplateByID(ctx, workspaceID, templateID, 0)
+		if err != nil {
+			return nil, err
+		}
+		if template.Channel != domain.ChannelEmail || template.Email == nil {
+			return nil, domain.NewValidationError("only email templates can be translated")
+		}
+		if template.Email.EditorMode == domain.EditorModeCode {
+			return nil, domain.NewValidationError("only templates built with the visual editor can be translated")
+		}
+		return []*domain.Template{template}, nil
+	}
+
+	listed, err := s.templateService.GetTemplates(ctx, workspaceID, "", domain.ChannelEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	templates := make([]*domain.Template, 0, len(listed))
+	for _, summary := range listed {
+		// The listing may not carry the full content, so each template is loaded again
+		template, err := s.templateService.GetTemplateByID(ctx, workspaceID, summary.ID, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get template %s: %w", summary.ID, err)
+		}
+		if template.Email == nil || template.Email.EditorMode == domain.EditorModeCode || template.IntegrationID != nil {
+			continue
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// translateEmail translates the subject, preheader and text blocks of an email into a language.
+// The translation is rejected when a Liquid tag or a link was dropped or altered.
+func (s *LLMService) translateEmail(
+	ctx context.Context,
+	workspaceID string,
+	integration *domain.Integration,
+	email *domain.EmailTemplate,
+	sourceLanguage string,
+	targetLanguage string,
+) (*domain.EmailTemplate, domain.LLMUsage, error) {
+	translated, err := cloneEmailTemplate(email)
+	if err != nil {
+		return nil, domain.LLMUsage{}, err
+	}
+	blocks, err := collectTextBlocks(translated.VisualEditorTree, nil)
+	if err != nil {
+		return nil, domain.LLMUsage{}, err
+	}
+
+	type blockContent struct {
+		ID      string `json:"id"`
+		Content string `json:"content"`
+	}
+	type emailContent struct {
+		Subject   string         `json:"subject"`
+		Preheader string         `json:"preheader"`
+		Blocks    []blockContent `json:"blocks"`
+	}
+	input := emailContent{Subject: email.Subject, Blocks: make([]blockContent, len(blocks))}
+	if email.SubjectPreview != nil {
+		input.Preheader = *email.SubjectPreview
+	}
+	for i, block := range blocks {
+		input.Blocks[i] = blockContent{ID: block.GetID(), Content: *block.GetContent()}
+	}
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return nil, domain.LLMUsage{}, fmt.Errorf("failed to marshal email: %w", err)
+	}
+
+	prompt := fmt.Sprintf("Translate this email from %s to %s.\n\n%s\n",
+		languageName(sourceLanguage), languageName(targetLanguage), inputJSON)
+	text, usage, err := s.complete(ctx, workspaceID, integration, translateEmailSystemPrompt, prompt)
+	if err != nil {
+		return nil, usage, err
+	}
+
+	var reply emailContent
+	if err := parseLLMJSON(text, &reply); err != nil {
+		return nil, usage, err
+	}
+
+	reply.Subject = strings.TrimSpace(reply.Subject)
+	if reply.Subject == "" {
+		return nil, usage, fmt.Errorf("the LLM did not translate the subject")
+	}
+	if err := checkPreservedMarkup(input.Subject, reply.Subject); err != nil {
+		return nil, usage, fmt.Errorf("rejected the translation of the subject: %w", err)
+	}
+	translated.Subject = reply.Subject
+	if input.Preheader != "" {
+		preheader := strings.TrimSpace(reply.Preheader)
+		if err := checkPreservedMarkup(input.Preheader, preheader); err != nil {
+			return nil, usage, fmt.Errorf("rejected the translation of the preheader: %w", err)
+		}
+		if preheader != "" {
+			translated.SubjectPreview = &preheader
+		}
+	}
+
+	contents := make(map[string]string, len(reply.Blocks))
+	for _, block := range reply.Blocks {
+		contents[block.ID] = block.Content
+	}
+	for _, block := range blocks {
+		content, ok := contents[block.GetID()]
+		if !ok || strings.TrimSpace(content) == "" {
+			return nil, usage, fmt.Errorf("the LLM did not translate block %s", block.GetID())
+		}
+		if err := checkPreservedMarkup(*block.GetContent(), content); err != nil {
+			return nil, usage, fmt.Errorf("rejected the translation of block %s: %w", block.GetID(), err)
+		}
+		block.SetContent(&content)
+	}
+
+	// The preview is compiled again from the translated tree on validation
+	translated.CompiledPreview = ""
+	translated.Text = nil
+	return translated, usage, nil
+}
+
+// cloneEmailTemplate returns a deep copy of an email template
+func cloneEmailTemplate(email *domain.EmailTemplate) (*domain.EmailTemplate, error) {
+	data, err := json.Marshal(email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy email: %w", err)
+	}
+	var clone domain.EmailTemplate
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf("failed to copy email: %w", err)
+	}
+	return &clone, nil
+}
+
+// languageName returns the display name of a language code for a prompt
+func languageName(code string) string {
+	if name, ok := domain.SupportedLanguages[code]; ok {
+		return name
+	}
+	return code
+}
+
+// addLLMUsage adds the token consumption of an LLM call to a total
+func addLLMUsage(total *domain.LLMUsage, usage domain.LLMUsage) {
+	if usage.Model != "" {
+		total.Model = usage.Model
+	}
+	total.InputTokens += usage.InputTokens
+	total.OutputTokens += usage.OutputTokens
+	total.TotalCost += usage.TotalCost
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const translatedEmailReply = `{"subject":"Les nouveautés de mai","preheader":"Tout ce que nous avons livré","blocks":[` +
+	`{"id":"intro","content":"<p>Bonjour {{ contact.first_name }}, <a href=\"https://example.com/sale\">les soldes</a> ont commencé.</p>"},` +
+	`{"id":"cta","content":"Acheter"}]}`
+
+func translateTestWorkspace(baseURL string) *domain.Workspace {
+	workspace := llmCopyWorkspace(baseURL)
+	workspace.Settings.DefaultLanguage = "en"
+	workspace.Settings.Languages = []string{"en", "fr", "de"}
+	return workspace
+}
+
+func translatableTemplate(t *testing.T, id string) *domain.Template {
+	tree, err := notifuse_mjml.UnmarshalEmailBlock([]byte(llmCopyTree))
+	require.NoError(t, err)
+	preview := "Everything we shipped"
+	return &domain.Template{
+		ID:       id,
+		Name:     id,
+		Version:  2,
+		Channel:  domain.ChannelEmail,
+		Category: "marketing",
+		Email: &domain.EmailTemplate{
+			Subject:          "What is new in May",
+			SubjectPreview:   &preview,
+			CompiledPreview:  "<mjml></mjml>",
+			VisualEditorTree: tree,
+		},
+	}
+}
+
+func translateTestPermissions() domain.UserPermissions {
+	return domain.UserPermissions{
+		domain.PermissionResourceLLM:       domain.ResourcePermissions{Read: true, Write: true},
+		domain.PermissionResourceTemplates: domain.ResourcePermissions{Read: true, Write: true},
+	}
+}
+
+func TestLLMService_TranslateTemplates(t *testing.T) {
+	t.Run("stores the missing languages as draft translations", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		server, prompts := newOpenAIStub(t, translatedEmailReply)
+		expectLLMCopyAuth(m, translateTestPermissions())
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(translateTestWorkspace(server.URL), nil)
+
+		template := translatableTemplate(t, "newsletter")
+		existing := template.Email
+		template.Translations = map[string]domain.TemplateTranslation{"de": {Email: existing}}
+		m.templateService.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "newsletter", int64(0)).Return(template, nil)
+
+		var saved *domain.Template
+		m.templateService.EXPECT().UpdateTemplate(gomock.Any(), "ws1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, template *domain.Template) error {
+				saved = template
+				return nil
+			})
+
+		response, err := service.TranslateTemplates(context.Background(), &domain.TranslateTemplatesRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			TemplateID:    "newsletter",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []domain.TemplateTranslationResult{{TemplateID: "newsletter", Language: "fr"}}, response.Results)
+		assert.Equal(t, int64(120), response.Usage.InputTokens)
+		require.Len(t, *prompts, 1)
+		assert.Contains(t, (*prompts)[0], "from English to French")
+
+		require.NotNil(t, saved)
+		translation := saved.Translations["fr"]
+		assert.True(t, translation.Draft)
+		assert.True(t, translation.MachineTranslated)
+		assert.Equal(t, "Les nouveautés de mai", translation.Email.Subject)
+		assert.Equal(t, "Tout ce que nous avons livré", *translation.Email.SubjectPreview)
+		assert.Contains(t, translation.Email.CompiledPreview, "les soldes")
+		blocks, err := collectTextBlocks(translation.Email.VisualEditorTree, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Acheter", *blocks[1].GetContent())
+
+		assert.Equal(t, "What is new in May", saved.Email.Subject, "the default content is left untouched")
+		assert.False(t, saved.Translations["de"].Draft, "existing translations are kept")
+	})
+
+	t.Run("translates every template missing a language", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		server, prompts := newOpenAIStub(t, translatedEmailReply)
+		expectLLMCopyAuth(m, translateTestPermissions())
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(translateTestWorkspace(server.URL), nil)
+
+		missing := translatableTemplate(t, "missing")
+		translated := translatableTemplate(t, "translated")
+		translated.Translations = map[string]domain.TemplateTranslation{"fr": {Email: translated.Email}}
+		code := translatableTemplate(t, "code")
+		code.Email.EditorMode = domain.EditorModeCode
+
+		m.templateService.EXPECT().GetTemplates(gomock.Any(), "ws1", "", domain.ChannelEmail).
+			Return([]*domain.Template{{ID: "missing"}, {ID: "translated"}, {ID: "code"}}, nil)
+		m.templateService.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "missing", int64(0)).Return(missing, nil)
+		m.templateService.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "translated", int64(0)).Return(translated, nil)
+		m.templateService.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "code", int64(0)).Return(code, nil)
+		m.templateService.EXPECT().UpdateTemplate(gomock.Any(), "ws1", missing).Return(nil)
+
+		response, err := service.TranslateTemplates(context.Background(), &domain.TranslateTemplatesRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			Languages:     []string{"fr"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []domain.TemplateTranslationResult{{TemplateID: "missing", Language: "fr"}}, response.Results)
+		assert.Len(t, *prompts, 1)
+	})
+
+	t.Run("rejects a translation altering a link", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		server, _ := newOpenAIStub(t, `{"subject":"Les nouveautés","blocks":[`+
+			`{"id":"intro","content":"<p>Bonjour {{ contact.first_name }}, <a href=\"https://example.fr/soldes\">les soldes</a>.</p>"},`+
+			`{"id":"cta","content":"Acheter"}]}`)
+		expectLLMCopyAuth(m, translateTestPermissions())
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(translateTestWorkspace(server.URL), nil)
+		m.templateService.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "newsletter", int64(0)).Return(translatableTemplate(t, "newsletter"), nil)
+
+		response, err := service.TranslateTemplates(context.Background(), &domain.TranslateTemplatesRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			TemplateID:    "newsletter",
+			Languages:     []string{"fr"},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, response.Results, 1)
+		assert.Contains(t, response.Results[0].Error, "links were not preserved")
+	})
+
+	t.Run("rejects a language of another workspace", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		expectLLMCopyAuth(m, translateTestPermissions())
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(translateTestWorkspace("http://localhost"), nil)
+
+		_, err := service.TranslateTemplates(context.Background(), &domain.TranslateTemplatesRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			TemplateID:    "newsletter",
+			Languages:     []string{"es"},
+		})
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+
+	t.Run("rejects a code mode template", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		expectLLMCopyAuth(m, translateTestPermissions())
+		m.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(translateTestWorkspace("http://localhost"), nil)
+		template := translatableTemplate(t, "newsletter")
+		template.Email.EditorMode = domain.EditorModeCode
+		m.templateService.EXPECT().GetTemplateByID(gomock.Any(), "ws1", "newsletter", int64(0)).Return(template, nil)
+
+		_, err := service.TranslateTemplates(context.Background(), &domain.TranslateTemplatesRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+			TemplateID:    "newsletter",
+		})
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+
+	t.Run("requires the templates write permission", func(t *testing.T) {
+		service, m := setupLLMCopyTest(t)
+		expectLLMCopyAuth(m, domain.UserPermissions{
+			domain.PermissionResourceLLM: domain.ResourcePermissions{Read: true, Write: true},
+		})
+
+		_, err := service.TranslateTemplates(context.Background(), &domain.TranslateTemplatesRequest{
+			WorkspaceID:   "ws1",
+			IntegrationID: "llm1",
+		})
+		var permissionErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permissionErr)
+	})
+}
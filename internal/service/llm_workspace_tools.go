@@ -0,0 +1,634 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/analytics"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+	"github.com/google/uuid"
+)
+
+const (
+	// ToolQueryAnalytics is the name of the analytics query tool
+	ToolQueryAnalytics = "query_analytics"
+	// ToolListBroadcasts is the name of the broadcast listing tool
+	ToolListBroadcasts = "list_broadcasts"
+	// ToolListAutomations is the name of the automation listing tool
+	ToolListAutomations = "list_automations"
+	// ToolPreviewSegment is the name of the segment preview tool
+	ToolPreviewSegment = "preview_segment"
+	// ToolDraftTemplate is the name of the template drafting tool
+	ToolDraftTemplate = "draft_template"
+	// ToolCreateSegment is the name of the segment creation tool
+	ToolCreateSegment = "create_segment"
+
+	// maxAnalyticsToolRows caps the rows returned by an analytics query to the assistant
+	maxAnalyticsToolRows = 200
+	// maxPreviewToolEmails caps the sample emails returned by a segment preview
+	maxPreviewToolEmails = 10
+)
+
+// analyticsSchemaResources maps each analytics schema to the resource a user must be
+// able to read to query it
+var analyticsSchemaResources = map[string]domain.PermissionResource{
+	"message_history":            domain.PermissionResourceMessageHistory,
+	"email_queue":                domain.PermissionResourceMessageHistory,
+	"contacts":                   domain.PermissionResourceContacts,
+	"segment_history":            domain.PermissionResourceContacts,
+	"broadcasts":                 domain.PermissionResourceBroadcasts,
+	"automation_node_executions": domain.PermissionResourceAutomations,
+	"webhook_deliveries":         domain.PermissionResourceWorkspace,
+}
+
+// segmentTreeDescription documents the segment condition tree for the assistant
+const segmentTreeDescription = `Segment condition tree. A node is either {"kind": "branch", "branch": {"operator": "and"|"or", "leaves": [nodes]}} or {"kind": "leaf", "leaf": {...}}.
+A leaf has a "source" and the matching condition:
+- "contacts": {"contact": {"filters": [{"field_name": "country", "field_type": "string"|"number"|"time"|"json", "operator": "equals"|"not_equals"|"gt"|"gte"|"lt"|"lte"|"contains"|"is_set"|"is_not_set"|"in_date_range", "string_values": [...], "number_values": [...]}]}}
+- "contact_lists": {"contact_list": {"operator": "in"|"not_in", "list_id": "...", "status": "active"}}
+- "contact_timeline": {"contact_timeline": {"kind": "insert_message_history", "count_operator": "at_least"|"at_most"|"exactly", "count_value": 1, "timeframe_operator": "anytime"|"in_the_last_days"|"in_date_range"|"before_date"|"after_date", "timeframe_values": ["30"]}}
+- "custom_events_goals": {"custom_events_goal": {"goal_type": "purchase"|"subscription"|"lead"|"signup"|"booking"|"trial"|"other"|"*", "aggregate_operator": "sum"|"count"|"avg"|"min"|"max", "operator": "gte"|"lte"|"eq"|"between", "value": 2, "timeframe_operator": "anytime"}}
+- "message_history": {"message_history": {"event": "sent"|"delivered"|"opened"|"clicked"|"bounced"|"complained"|"unsubscribed"|"failed", "count_operator": "at_least"|"at_most"|"exactly", "count_value": 0, "broadcast_id": "...", "timeframe_operator": "in_the_last_days", "timeframe_values": ["60"]}}
+- "contact_automations": {"contact_automation": {"operator": "in"|"not_in", "automation_id": "...", "status": "active"|"completed"|"exited"|"failed"}}`
+
+// workspaceTool is a tool of the assistant acting on the workspace data, with the
+// permission the user needs to call it. Tools needing a write permission are only
+// executed once the user confirms them.
+type workspaceTool struct {
+	name        string
+	description string
+	schema      map[string]interface{}
+	resource    domain.PermissionResource
+	permission  domain.PermissionType
+}
+
+// WorkspaceToolRegistry manages the assistant tools reading and changing workspace data.
+// The tools run with the context of the user chatting, so the services they call apply
+// the same checks as the API.
+type WorkspaceToolRegistry struct {
+	analyticsService  domain.AnalyticsService
+	broadcastService  domain.BroadcastService
+	automationService domain.AutomationService
+	segmentService    domain.SegmentService
+	templateService   domain.TemplateService
+	logger            logger.Logger
+	tools             []workspaceTool
+}
+
+// NewWorkspaceToolRegistry creates a new workspace tool registry
+func NewWorkspaceToolRegistry(
+	analyticsService domain.AnalyticsService,
+	broadcastService domain.BroadcastService,
+	automationService domain.AutomationService,
+	segmentService domain.SegmentService,
+	templateService domain.TemplateService,
+	log logger.Logger,
+) *WorkspaceToolRegistry {
+	return &WorkspaceToolRegistry{
+		analyticsService:  analyticsService,
+		broadcastService:  broadcastService,
+		automationService: automationService,
+		segmentService:    segmentService,
+		templateService:   templateService,
+		logger:            log,
+		tools:             workspaceTools(),
+	}
+}
+
+func workspaceTools() []workspaceTool {
+	treeSchema := map[string]interface{}{
+		"type":        "object",
+		"description": segmentTreeDescription,
+	}
+
+	return []workspaceTool{
+		{
+			name: ToolQueryAnalytics,
+			// The available schemas are appended to the description for each user
+			description: "Runs an aggregated analytics query on the workspace data, e.g. the open and click rates of the recent broadcasts. " +
+				"Group with dimensions and time dimensions, and narrow with filters (operators: equals, notEquals, contains, gt, gte, lt, lte, in, notIn, set, notSet, inDateRange, beforeDate, afterDate).",
+			schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"schema": map[string]interface{}{
+						"type":        "string",
+						"description": "The schema to query",
+					},
+					"measures": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Measures of the schema to compute",
+					},
+					"dimensions": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Dimensions of the schema to group by",
+					},
+					"timeDimensions": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"dimension":   map[string]interface{}{"type": "string"},
+								"granularity": map[string]interface{}{"type": "string", "enum": []string{"hour", "day", "week", "month", "year"}},
+								"dateRange": map[string]interface{}{
+									"type":        "array",
+									"items":       map[string]interface{}{"type": "string"},
+									"description": "Start and end dates, e.g. ['2025-01-01', '2025-01-31']",
+								},
+							},
+							"required": []string{"dimension", "granularity"},
+						},
+					},
+					"filters": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"member":   map[string]interface{}{"type": "string"},
+								"operator": map[string]interface{}{"type": "string"},
+								"values":   map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+							},
+							"required": []string{"member", "operator"},
+						},
+					},
+					"order": map[string]interface{}{
+						"type":        "object",
+						"description": "Sort order by measure or dimension, e.g. {\"count_opened\": \"desc\"}",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("Maximum number of rows (default and max %d)", maxAnalyticsToolRows),
+					},
+					"timezone": map[string]interface{}{
+						"type":        "string",
+						"description": "IANA timezone of the time dimensions (default UTC)",
+					},
+				},
+				"required": []string{"schema", "measures"},
+			},
+			resource:   domain.PermissionResourceMessageHistory, // Checked per schema
+			permission: domain.PermissionTypeRead,
+		},
+		{
+			name:        ToolListBroadcasts,
+			description: "Lists the broadcasts of the workspace, most recent first, with their status, audience, schedule and A/B test settings.",
+			schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"status": map[string]interface{}{
+						"type":        "string",
+						"description": "Only broadcasts with this status: draft, scheduled, processing, paused, processed, cancelled, failed, testing, test_completed or winner_selected",
+					},
+					"search": map[string]interface{}{
+						"type":        "string",
+						"description": "Only broadcasts whose name contains this text",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of broadcasts (default 20, max 50)",
+					},
+				},
+			},
+			resource:   domain.PermissionResourceBroadcasts,
+			permission: domain.PermissionTypeRead,
+		},
+		{
+			name:        ToolListAutomations,
+			description: "Lists the automations of the workspace with their status, list, trigger and enrollment stats.",
+			schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"status": map[string]interface{}{
+						"type": "string",
+						"enum": []string{string(domain.AutomationStatusDraft), string(domain.AutomationStatusLive), string(domain.AutomationStatusPaused)},
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of automations (default 20, max 50)",
+					},
+				},
+			},
+			resource:   domain.PermissionResourceAutomations,
+			permission: domain.PermissionTypeRead,
+		},
+		{
+			name:        ToolPreviewSegment,
+			description: "Counts the contacts matching a segment condition tree, with a sample of their emails, without saving the segment.",
+			schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"tree": treeSchema,
+				},
+				"required": []string{"tree"},
+			},
+			resource:   domain.PermissionResourceContacts,
+			permission: domain.PermissionTypeRead,
+		},
+		{
+			name:        ToolDraftTemplate,
+			description: "Creates a marketing email template built with the visual editor, from a subject, paragraphs and an optional button. The user confirms it before it is created.",
+			schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name":      map[string]interface{}{"type": "string", "description": "Template name, at most 32 characters"},
+					"subject":   map[string]interface{}{"type": "string"},
+					"preheader": map[string]interface{}{"type": "string"},
+					"paragraphs": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "HTML paragraphs of the body, Liquid tags such as {{ contact.first_name }} are allowed",
+					},
+					"button_text": map[string]interface{}{"type": "string"},
+					"button_url":  map[string]interface{}{"type": "string"},
+				},
+				"required": []string{"name", "subject", "paragraphs"},
+			},
+			resource:   domain.PermissionResourceTemplates,
+			permission: domain.PermissionTypeWrite,
+		},
+		{
+			name:        ToolCreateSegment,
+			description: "Creates a segment from a condition tree. Preview the tree first. The user confirms it before it is created.",
+			schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name":     map[string]interface{}{"type": "string"},
+					"tree":     treeSchema,
+					"color":    map[string]interface{}{"type": "string", "description": "Tag color (default blue)"},
+					"timezone": map[string]interface{}{"type": "string", "description": "IANA timezone of the relative dates (default UTC)"},
+				},
+				"required": []string{"name", "tree"},
+			},
+			resource:   domain.PermissionResourceContacts,
+			permission: domain.PermissionTypeWrite,
+		},
+	}
+}
+
+// GetTools returns the tools the user has the permission to call
+func (r *WorkspaceToolRegistry) GetTools(userWorkspace *domain.UserWorkspace) []domain.LLMTool {
+	var tools []domain.LLMTool
+	for _, tool := range r.tools {
+		description := tool.description
+		if tool.name == ToolQueryAnalytics {
+			schemas := readableAnalyticsSchemas(userWorkspace)
+			if len(schemas) == 0 {
+				continue
+			}
+			description += "\n\nAvailable schemas:\n" + describeAnalyticsSchemas(schemas)
+		} else if !userWorkspace.HasPermission(tool.resource, tool.permission) {
+			continue
+		}
+
+		schema, _ := json.Marshal(tool.schema)
+		tools = append(tools, domain.LLMTool{
+			Name:        tool.name,
+			Description: description,
+			InputSchema: schema,
+		})
+	}
+	return tools
+}
+
+// IsWorkspaceTool checks if a tool acts on the workspace data
+func (r *WorkspaceToolRegistry) IsWorkspaceTool(toolName string) bool {
+	return r.getTool(toolName) != nil
+}
+
+// RequiresConfirmation checks if a tool changes the workspace data, and must be
+// confirmed by the user before it is executed
+func (r *WorkspaceToolRegistry) RequiresConfirmation(toolName string) bool {
+	tool := r.getTool(toolName)
+	return tool != nil && tool.permission == domain.PermissionTypeWrite
+}
+
+// CheckPermission returns a permission error when the user cannot call a tool with an input
+func (r *WorkspaceToolRegistry) CheckPermission(userWorkspace *domain.UserWorkspace, toolName string, input map[string]interface{}) error {
+	tool := r.getTool(toolName)
+	if tool == nil {
+		return fmt.Errorf("unknown tool: %s", toolName)
+	}
+
+	resource := tool.resource
+	if tool.name == ToolQueryAnalytics {
+		schema, _ := input["schema"].(string)
+		var ok bool
+		if resource, ok = analyticsSchemaResources[schema]; !ok {
+			return domain.NewValidationError(fmt.Sprintf("unknown analytics schema: %s", schema))
+		}
+	}
+
+	if !userWorkspace.HasPermission(resource, tool.permission) {
+		return domain.NewPermissionError(
+			resource,
+			tool.permission,
+			fmt.Sprintf("Insufficient permissions: %s access to %s required", tool.permission, resource),
+		)
+	}
+	return nil
+}
+
+// ExecuteTool executes a workspace tool and returns its result as JSON. The permission
+// of the user must have been checked with CheckPermission.
+func (r *WorkspaceToolRegistry) ExecuteTool(ctx context.Context, workspaceID string, toolName string, input map[string]interface{}) (string, error) {
+	r.logger.WithFields(map[string]interface{}{
+		"workspace_id": workspaceID,
+		"tool_name":    toolName,
+	}).Debug("Executing workspace tool")
+
+	switch toolName {
+	case ToolQueryAnalytics:
+		return r.executeQueryAnalytics(ctx, workspaceID, input)
+	case ToolListBroadcasts:
+		return r.executeListBroadcasts(ctx, workspaceID, input)
+	case ToolListAutomations:
+		return r.executeListAutomations(ctx, workspaceID, input)
+	case ToolPreviewSegment:
+		return r.executePreviewSegment(ctx, workspaceID, input)
+	case ToolDraftTemplate:
+		return r.executeDraftTemplate(ctx, workspaceID, input)
+	case ToolCreateSegment:
+		return r.executeCreateSegment(ctx, workspaceID, input)
+	default:
+		return "", fmt.Errorf("unknown tool: %s", toolName)
+	}
+}
+
+func (r *WorkspaceToolRegistry) getTool(toolName string) *workspaceTool {
+	for i := range r.tools {
+		if r.tools[i].name == toolName {
+			return &r.tools[i]
+		}
+	}
+	return nil
+}
+
+func (r *WorkspaceToolRegistry) executeQueryAnalytics(ctx context.Context, workspaceID string, input map[string]interface{}) (string, error) {
+	var query analytics.Query
+	if err := decodeToolInput(input, &query); err != nil {
+		return "", err
+	}
+	if query.Limit == nil || *query.Limit <= 0 || *query.Limit > maxAnalyticsToolRows {
+		limit := maxAnalyticsToolRows
+		query.Limit = &limit
+	}
+
+	response, err := r.analyticsService.Query(ctx, workspaceID, query)
+	if err != nil {
+		return "", err
+	}
+	return toolResultJSON(response.Data)
+}
+
+func (r *WorkspaceToolRegistry) executeListBroadcasts(ctx context.Context, workspaceID string, input map[string]interface{}) (string, error) {
+	var params struct {
+		Status string `json:"status"`
+		Search string `json:"search"`
+		Limit  int    `json:"limit"`
+	}
+	if err := decodeToolInput(input, &params); err != nil {
+		return "", err
+	}
+
+	response, err := r.broadcastService.ListBroadcasts(ctx, domain.ListBroadcastsParams{
+		WorkspaceID: workspaceID,
+		Status:      domain.BroadcastStatus(params.Status),
+		Search:      params.Search,
+		Limit:       toolListLimit(params.Limit),
+	})
+	if err != nil {
+		return "", err
+	}
+	return toolResultJSON(response)
+}
+
+func (r *WorkspaceToolRegistry) executeListAutomations(ctx context.Context, workspaceID string, input map[string]interface{}) (string, error) {
+	var params struct {
+		Status string `json:"status"`
+		Limit  int    `json:"limit"`
+	}
+	if err := decodeToolInput(input, &params); err != nil {
+		return "", err
+	}
+
+	filter := domain.AutomationFilter{Limit: toolListLimit(params.Limit)}
+	if params.Status != "" {
+		filter.Status = []domain.AutomationStatus{domain.AutomationStatus(params.Status)}
+	}
+	automations, total, err := r.automationService.List(ctx, workspaceID, filter)
+	if err != nil {
+		return "", err
+	}
+
+	// The workflow nodes are left out, they would fill the context of the assistant
+	summaries := make([]domain.Automation, len(automations))
+	for i, automation := range automations {
+		summaries[i] = *automation
+		summaries[i].Nodes = nil
+		summaries[i].TriggerSQL = nil
+	}
+	return toolResultJSON(map[string]interface{}{
+		"automations": summaries,
+		"total_count": total,
+	})
+}
+
+func (r *WorkspaceToolRegistry) executePreviewSegment(ctx context.Context, workspaceID string, input map[string]interface{}) (string, error) {
+	var params struct {
+		Tree *domain.TreeNode `json:"tree"`
+	}
+	if err := decodeToolInput(input, &params); err != nil {
+		return "", err
+	}
+	if params.Tree == nil {
+		return "", fmt.Errorf("tree is required")
+	}
+
+	preview, err := r.segmentService.PreviewSegment(ctx, workspaceID, params.Tree, maxPreviewToolEmails)
+	if err != nil {
+		return "", err
+	}
+	return toolResultJSON(map[string]interface{}{
+		"total_count":   preview.TotalCount,
+		"sample_emails": preview.Emails,
+	})
+}
+
+func (r *WorkspaceToolRegistry) executeDraftTemplate(ctx context.Context, workspaceID string, input map[string]interface{}) (string, error) {
+	var params struct {
+		Name       string   `json:"name"`
+		Subject    string   `json:"subject"`
+		Preheader  string   `json:"preheader"`
+		Paragraphs []string `json:"paragraphs"`
+		ButtonText string   `json:"button_text"`
+		ButtonURL  string   `json:"button_url"`
+	}
+	if err := decodeToolInput(input, &params); err != nil {
+		return "", err
+	}
+	if len(params.Paragraphs) == 0 {
+		return "", fmt.Errorf("paragraphs are required")
+	}
+
+	name := []rune(strings.TrimSpace(params.Name))
+	if len(name) > 32 {
+		name = name[:32]
+	}
+	template := &domain.Template{
+		ID:       strings.ReplaceAll(uuid.New().String(), "-", "")[:32],
+		Name:     strings.TrimSpace(string(name)),
+		Channel:  domain.ChannelEmail,
+		Category: string(domain.TemplateCategoryMarketing),
+		Email: &domain.EmailTemplate{
+			Subject:          params.Subject,
+			EditorMode:       domain.EditorModeVisual,
+			VisualEditorTree: draftEmailTree(params.Paragraphs, params.ButtonText, params.ButtonURL),
+		},
+	}
+	if params.Preheader != "" {
+		template.Email.SubjectPreview = &params.Preheader
+	}
+
+	if err := r.templateService.CreateTemplate(ctx, workspaceID, template); err != nil {
+		return "", err
+	}
+	return toolResultJSON(map[string]interface{}{
+		"template_id": template.ID,
+		"name":        template.Name,
+	})
+}
+
+func (r *WorkspaceToolRegistry) executeCreateSegment(ctx context.Context, workspaceID string, input map[string]interface{}) (string, error) {
+	var params struct {
+		Name     string           `json:"name"`
+		Tree     *domain.TreeNode `json:"tree"`
+		Color    string           `json:"color"`
+		Timezone string           `json:"timezone"`
+	}
+	if err := decodeToolInput(input, &params); err != nil {
+		return "", err
+	}
+	if params.Color == "" {
+		params.Color = "blue"
+	}
+	if params.Timezone == "" {
+		params.Timezone = "UTC"
+	}
+
+	segment, err := r.segmentService.CreateSegment(ctx, &domain.CreateSegmentRequest{
+		WorkspaceID: workspaceID,
+		ID:          strings.ReplaceAll(uuid.New().String(), "-", "")[:32],
+		Name:        params.Name,
+		Color:       params.Color,
+		Tree:        params.Tree,
+		Timezone:    params.Timezone,
+	})
+	if err != nil {
+		return "", err
+	}
+	return toolResultJSON(map[string]interface{}{
+		"segment_id": segment.ID,
+		"name":       segment.Name,
+		"status":     segment.Status,
+	})
+}
+
+// draftEmailTree builds a visual editor tree with a text block per paragraph and an
+// optional button
+func draftEmailTree(paragraphs []string, buttonText, buttonURL string) notifuse_mjml.EmailBlock {
+	column := &notifuse_mjml.MJColumnBlock{BaseBlock: notifuse_mjml.NewBaseBlock("column-1", notifuse_mjml.MJMLComponentMjColumn)}
+	for i, paragraph := range paragraphs {
+		content := paragraph
+		text := notifuse_mjml.NewBaseBlock(fmt.Sprintf("text-%d", i+1), notifuse_mjml.MJMLComponentMjText)
+		text.Content = &content
+		column.Children = append(column.Children, &notifuse_mjml.MJTextBlock{BaseBlock: text})
+	}
+	if buttonText != "" && buttonURL != "" {
+		button := notifuse_mjml.NewBaseBlock("button-1", notifuse_mjml.MJMLComponentMjButton)
+		button.Content = &buttonText
+		button.Attributes["href"] = buttonURL
+		column.Children = append(column.Children, &notifuse_mjml.MJButtonBlock{BaseBlock: button})
+	}
+
+	section := &notifuse_mjml.MJSectionBlock{BaseBlock: notifuse_mjml.NewBaseBlock("section-1", notifuse_mjml.MJMLComponentMjSection)}
+	section.Children = []notifuse_mjml.EmailBlock{column}
+	body := &notifuse_mjml.MJBodyBlock{BaseBlock: notifuse_mjml.NewBaseBlock("body-1", notifuse_mjml.MJMLComponentMjBody)}
+	body.Children = []notifuse_mjml.EmailBlock{section}
+	root := &notifuse_mjml.MJMLBlock{BaseBlock: notifuse_mjml.NewBaseBlock("mjml-1", notifuse_mjml.MJMLComponentMjml)}
+	root.Children = []notifuse_mjml.EmailBlock{body}
+	return root
+}
+
+// readableAnalyticsSchemas returns the analytics schemas the user can query, sorted by name
+func readableAnalyticsSchemas(userWorkspace *domain.UserWorkspace) []string {
+	var schemas []string
+	for schema, resource := range analyticsSchemaResources {
+		if _, exists := domain.PredefinedSchemas[schema]; exists && userWorkspace.HasPermission(resource, domain.PermissionTypeRead) {
+			schemas = append(schemas, schema)
+		}
+	}
+	sort.Strings(schemas)
+	return schemas
+}
+
+// describeAnalyticsSchemas lists the measures and dimensions of analytics schemas
+func describeAnalyticsSchemas(schemas []string) string {
+	var sb strings.Builder
+	for _, name := range schemas {
+		schema := domain.PredefinedSchemas[name]
+		measures := make([]string, 0, len(schema.Measures))
+		for measure := range schema.Measures {
+			measures = append(measures, measure)
+		}
+		dimensions := make([]string, 0, len(schema.Dimensions))
+		for dimension := range schema.Dimensions {
+			dimensions = append(dimensions, dimension)
+		}
+		sort.Strings(measures)
+		sort.Strings(dimensions)
+		sb.WriteString(fmt.Sprintf("- %s: measures %s; dimensions %s\n", name, strings.Join(measures, ", "), strings.Join(dimensions, ", ")))
+	}
+	return sb.String()
+}
+
+// decodeToolInput decodes the input of a tool call into a typed value
+func decodeToolInput(input map[string]interface{}, value interface{}) error {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("invalid tool input: %w", err)
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("invalid tool input: %w", err)
+	}
+	return nil
+}
+
+// toolListLimit returns the page size of a listing tool (default 20, max 50)
+func toolListLimit(limit int) int {
+	if limit <= 0 {
+		return 20
+	}
+	if limit > 50 {
+		return 50
+	}
+	return limit
+}
+
+// toolResultJSON encodes the result of a tool for the assistant, within MaxContentSize
+func toolResultJSON(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode tool result: %w", err)
+	}
+	result := string(data)
+	if len(result) > MaxContentSize {
+		result = result[:MaxContentSize] + "\n\n[Content truncated...]"
+	}
+	return result, nil
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/analytics"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type workspaceToolMocks struct {
+	analyticsService  *mocks.MockAnalyticsService
+	broadcastService  *mocks.MockBroadcastService
+	automationService *mocks.MockAutomationService
+	segmentService    *mocks.MockSegmentService
+	templateService   *mocks.MockTemplateService
+}
+
+func setupWorkspaceToolRegistryTest(t *testing.T) (*WorkspaceToolRegistry, *workspaceToolMocks) {
+	ctrl := gomock.NewController(t)
+	m := &workspaceToolMocks{
+		analyticsService:  mocks.NewMockAnalyticsService(ctrl),
+		broadcastService:  mocks.NewMockBroadcastService(ctrl),
+		automationService: mocks.NewMockAutomationService(ctrl),
+		segmentService:    mocks.NewMockSegmentService(ctrl),
+		templateService:   mocks.NewMockTemplateService(ctrl),
+	}
+	registry := NewWorkspaceToolRegistry(
+		m.analyticsService,
+		m.broadcastService,
+		m.automationService,
+		m.segmentService,
+		m.templateService,
+		logger.NewLoggerWithLevel("disabled"),
+	)
+	return registry, m
+}
+
+func workspaceToolUser(permissions domain.UserPermissions) *domain.UserWorkspace {
+	return &domain.UserWorkspace{UserID: "user1", WorkspaceID: "ws1", Role: "member", Permissions: permissions}
+}
+
+func TestWorkspaceToolRegistry_GetTools(t *testing.T) {
+	registry, _ := setupWorkspaceToolRegistryTest(t)
+	user := workspaceToolUser(domain.UserPermissions{
+		domain.PermissionResourceBroadcasts: domain.ResourcePermissions{Read: true},
+		domain.PermissionResourceContacts:   domain.ResourcePermissions{Read: true},
+		domain.PermissionResourceTemplates:  domain.ResourcePermissions{Read: true, Write: true},
+	})
+
+	tools := registry.GetTools(user)
+
+	var names []string
+	for _, tool := range tools {
+		names = append(names, tool.Name)
+		assert.True(t, json.Valid(tool.InputSchema), tool.Name)
+	}
+	assert.Equal(t, []string{ToolQueryAnalytics, ToolListBroadcasts, ToolPreviewSegment, ToolDraftTemplate}, names)
+
+	// Only the schemas the user can read are described
+	assert.Contains(t, tools[0].Description, "- broadcasts: measures")
+	assert.Contains(t, tools[0].Description, "- contacts: measures")
+	assert.NotContains(t, tools[0].Description, "- message_history:")
+
+	assert.Empty(t, registry.GetTools(workspaceToolUser(nil)))
+	assert.Len(t, registry.GetTools(&domain.UserWorkspace{Role: "owner"}), 6)
+}
+
+func TestWorkspaceToolRegistry_CheckPermission(t *testing.T) {
+	registry, _ := setupWorkspaceToolRegistryTest(t)
+	user := workspaceToolUser(domain.UserPermissions{
+		domain.PermissionResourceBroadcasts: domain.ResourcePermissions{Read: true},
+		domain.PermissionResourceContacts:   domain.ResourcePermissions{Read: true},
+	})
+
+	assert.NoError(t, registry.CheckPermission(user, ToolQueryAnalytics, map[string]interface{}{"schema": "broadcasts"}))
+	assert.NoError(t, registry.CheckPermission(user, ToolPreviewSegment, nil))
+
+	var permissionErr *domain.PermissionError
+	assert.ErrorAs(t, registry.CheckPermission(user, ToolQueryAnalytics, map[string]interface{}{"schema": "message_history"}), &permissionErr)
+	assert.ErrorAs(t, registry.CheckPermission(user, ToolCreateSegment, nil), &permissionErr)
+	assert.IsType(t, domain.ValidationError{}, registry.CheckPermission(user, ToolQueryAnalytics, map[string]interface{}{"schema": "users"}))
+
+	assert.True(t, registry.RequiresConfirmation(ToolCreateSegment))
+	assert.True(t, registry.RequiresConfirmation(ToolDraftTemplate))
+	assert.False(t, registry.RequiresConfirmation(ToolPreviewSegment))
+	assert.False(t, registry.IsWorkspaceTool(ToolScrapeURL))
+}
+
+func TestWorkspaceToolRegistry_ExecuteTool(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("query_analytics caps the rows", func(t *testing.T) {
+		registry, m := setupWorkspaceToolRegistryTest(t)
+		m.analyticsService.EXPECT().Query(ctx, "ws1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, query analytics.Query) (*analytics.Response, error) {
+				assert.Equal(t, "message_history", query.Schema)
+				assert.Equal(t, []string{"count_opened"}, query.Measures)
+				assert.Equal(t, maxAnalyticsToolRows, *query.Limit)
+				return &analytics.Response{Data: []map[string]interface{}{{"broadcast_id": "b1", "count_opened": 42}}}, nil
+			})
+
+		result, err := registry.ExecuteTool(ctx, "ws1", ToolQueryAnalytics, map[string]interface{}{
+			"schema":     "message_history",
+			"measures":   []interface{}{"count_opened"},
+			"dimensions": []interface{}{"broadcast_id"},
+			"limit":      float64(5000),
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"broadcast_id":"b1","count_opened":42}]`, result)
+	})
+
+	t.Run("list_automations leaves out the nodes", func(t *testing.T) {
+		registry, m := setupWorkspaceToolRegistryTest(t)
+		m.automationService.EXPECT().List(ctx, "ws1", domain.AutomationFilter{
+			Status: []domain.AutomationStatus{domain.AutomationStatusLive},
+			Limit:  20,
+		}).Return([]*domain.Automation{{
+			ID:     "a1",
+			Name:   "Welcome",
+			Status: domain.AutomationStatusLive,
+			Nodes:  []*domain.AutomationNode{{ID: "n1"}},
+		}}, 1, nil)
+
+		result, err := registry.ExecuteTool(ctx, "ws1", ToolListAutomations, map[string]interface{}{"status": "live"})
+		require.NoError(t, err)
+		assert.Contains(t, result, `"name":"Welcome"`)
+		assert.Contains(t, result, `"total_count":1`)
+		assert.Contains(t, result, `"nodes":null`)
+	})
+
+	t.Run("preview_segment returns a sample", func(t *testing.T) {
+		registry, m := setupWorkspaceToolRegistryTest(t)
+		m.segmentService.EXPECT().PreviewSegment(ctx, "ws1", gomock.Any(), maxPreviewToolEmails).
+			Return(&domain.PreviewSegmentResponse{Emails: []string{"a@example.com"}, TotalCount: 12, GeneratedSQL: "SELECT"}, nil)
+
+		result, err := registry.ExecuteTool(ctx, "ws1", ToolPreviewSegment, map[string]interface{}{
+			"tree": map[string]interface{}{"kind": "leaf", "leaf": map[string]interface{}{"source": "contacts"}},
+		})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"total_count":12,"sample_emails":["a@example.com"]}`, result)
+	})
+
+	t.Run("draft_template creates a visual editor template", func(t *testing.T) {
+		registry, m := setupWorkspaceToolRegistryTest(t)
+		var created *domain.Template
+		m.templateService.EXPECT().CreateTemplate(ctx, "ws1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, template *domain.Template) error {
+				created = template
+				return nil
+			})
+
+		result, err := registry.ExecuteTool(ctx, "ws1", ToolDraftTemplate, map[string]interface{}{
+			"name":        "Win back customers who stopped opening",
+			"subject":     "We miss you, {{ contact.first_name }}",
+			"preheader":   "A little something for you",
+			"paragraphs":  []interface{}{"<p>It has been a while.</p>", "<p>Here is 10% off.</p>"},
+			"button_text": "Shop now",
+			"button_url":  "https://example.com/shop",
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, created)
+		assert.Contains(t, result, created.ID)
+		assert.Len(t, created.Name, 32)
+		assert.Equal(t, "A little something for you", *created.Email.SubjectPreview)
+		blocks, err := collectTextBlocks(created.Email.VisualEditorTree, nil)
+		require.NoError(t, err)
+		assert.Len(t, blocks, 3)
+
+		created.Version = 1
+		require.NoError(t, created.Validate())
+		assert.Contains(t, created.Email.CompiledPreview, "Here is 10% off.")
+	})
+
+	t.Run("create_segment applies the defaults", func(t *testing.T) {
+		registry, m := setupWorkspaceToolRegistryTest(t)
+		m.segmentService.EXPECT().CreateSegment(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *domain.CreateSegmentRequest) (*domain.Segment, error) {
+				assert.Equal(t, "ws1", req.WorkspaceID)
+				assert.Len(t, req.ID, 32)
+				assert.Equal(t, "blue", req.Color)
+				assert.Equal(t, "UTC", req.Timezone)
+				require.NotNil(t, req.Tree)
+				return &domain.Segment{ID: req.ID, Name: req.Name, Status: string(domain.SegmentStatusBuilding)}, nil
+			})
+
+		result, err := registry.ExecuteTool(ctx, "ws1", ToolCreateSegment, map[string]interface{}{
+			"name": "Repeat buyers",
+			"tree": map[string]interface{}{"kind": "leaf", "leaf": map[string]interface{}{"source": "contacts"}},
+		})
+		require.NoError(t, err)
+		assert.Contains(t, result, `"status":"building"`)
+	})
+}
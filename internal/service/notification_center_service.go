@@ -2,31 +2,36 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/pkg/logger"
 )
 
 type NotificationCenterService struct {
-	contactRepo   domain.ContactRepository
-	workspaceRepo domain.WorkspaceRepository
-	listRepo      domain.ListRepository
-	logger        logger.Logger
+	contactRepo     domain.ContactRepository
+	workspaceRepo   domain.WorkspaceRepository
+	listRepo        domain.ListRepository
+	contactListRepo domain.ContactListRepository
+	logger          logger.Logger
 }
 
 func NewNotificationCenterService(
 	contactRepo domain.ContactRepository,
 	workspaceRepo domain.WorkspaceRepository,
 	listRepo domain.ListRepository,
+	contactListRepo domain.ContactListRepository,
 	logger logger.Logger,
 ) *NotificationCenterService {
 	return &NotificationCenterService{
-		contactRepo:   contactRepo,
-		workspaceRepo: workspaceRepo,
-		listRepo:      listRepo,
-		logger:        logger,
+		contactRepo:     contactRepo,
+		workspaceRepo:   workspaceRepo,
+		listRepo:        listRepo,
+		contactListRepo: contactListRepo,
+		logger:          logger,
 	}
 }
 
@@ -49,8 +54,14 @@ func (s *NotificationCenterService) GetContactPreferences(ctx context.Context, w
 		return nil, fmt.Errorf("invalid email verification")
 	}
 
-	// Get the contact
+	// Get the contact, links of messages sent before a merge or an email change
+	// carry a former address of the contact
 	contact, err := s.contactRepo.GetContactByEmail(ctx, workspaceID, email)
+	if errors.Is(err, domain.ErrContactNotFound) {
+		if contactEmail, aliasErr := s.contactRepo.ResolveContactAlias(ctx, workspaceID, email); aliasErr == nil && contactEmail != "" {
+			contact, err = s.contactRepo.GetContactByEmail(ctx, workspaceID, contactEmail)
+		}
+	}
 	if err != nil {
 		if strings.Contains(err.Error(), "contact not found") {
 			return nil, err
@@ -85,7 +96,7 @@ func (s *NotificationCenterService) GetContactPreferences(ctx context.Context, w
 	}, nil
 }
 
-// UpdateContactPreferences updates a contact's language and/or timezone
+// UpdateContactPreferences updates a contact's language, timezone, marketing pause and list frequencies
 func (s *NotificationCenterService) UpdateContactPreferences(ctx context.Context, req *domain.UpdateContactPreferencesRequest) error {
 	workspace, err := s.workspaceRepo.GetByID(ctx, req.WorkspaceID)
 	if err != nil {
@@ -97,18 +108,67 @@ func (s *NotificationCenterService) UpdateContactPreferences(ctx context.Context
 		return fmt.Errorf("invalid email verification")
 	}
 
-	contact := &domain.Contact{Email: req.Email}
-	if req.Language != "" {
-		contact.Language = &domain.NullableString{String: req.Language, IsNull: false}
+	if req.Language != "" || req.Timezone != "" {
+		contact := &domain.Contact{Email: req.Email}
+		if req.Language != "" {
+			contact.Language = &domain.NullableString{String: req.Language, IsNull: false}
+		}
+		if req.Timezone != "" {
+			contact.Timezone = &domain.NullableString{String: req.Timezone, IsNull: false}
+		}
+
+		_, err = s.contactRepo.UpsertContact(ctx, req.WorkspaceID, contact)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to upsert contact preferences: %v", err))
+			return fmt.Errorf("failed to update contact preferences: %w", err)
+		}
 	}
-	if req.Timezone != "" {
-		contact.Timezone = &domain.NullableString{String: req.Timezone, IsNull: false}
+
+	if req.PauseDays != nil {
+		// A zero pause resumes marketing emails
+		var pausedUntil *time.Time
+		if *req.PauseDays > 0 {
+			until := time.Now().UTC().AddDate(0, 0, *req.PauseDays)
+			pausedUntil = &until
+		}
+
+		if err := s.contactListRepo.PauseContactLists(ctx, req.WorkspaceID, req.Email, pausedUntil); err != nil {
+			s.logger.WithField("email", req.Email).Error(fmt.Sprintf("Failed to pause contact lists: %v", err))
+			return fmt.Errorf("failed to update contact preferences: %w", err)
+		}
 	}
 
-	_, err = s.contactRepo.UpsertContact(ctx, req.WorkspaceID, contact)
-	if err != nil {
-		s.logger.Error(fmt.Sprintf("Failed to upsert contact preferences: %v", err))
-		return fmt.Errorf("failed to update contact preferences: %w", err)
+	if len(req.ListFrequencies) > 0 {
+		lists, err := s.listRepo.GetLists(ctx, req.WorkspaceID)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to get lists: %v", err))
+			return fmt.Errorf("failed to get lists: %w", err)
+		}
+
+		publicLists := make(map[string]bool, len(lists))
+		for _, list := range lists {
+			if list.IsPublic && list.DeletedAt == nil {
+				publicLists[list.ID] = true
+			}
+		}
+
+		// Only the frequency of public lists can be changed from the notification center
+		for listID := range req.ListFrequencies {
+			if !publicLists[listID] {
+				return domain.NewValidationError(fmt.Sprintf("list %s is not available in the notification center", listID))
+			}
+		}
+
+		for listID, frequency := range req.ListFrequencies {
+			if err := s.contactListRepo.UpdateContactListFrequency(ctx, req.WorkspaceID, req.Email, listID, frequency); err != nil {
+				var notFound *domain.ErrContactListNotFound
+				if errors.As(err, &notFound) {
+					return domain.NewValidationError(fmt.Sprintf("contact is not subscribed to list %s", listID))
+				}
+				s.logger.WithField("email", req.Email).Error(fmt.Sprintf("Failed to update list frequency: %v", err))
+				return fmt.Errorf("failed to update contact preferences: %w", err)
+			}
+		}
 	}
 
 	return nil
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/domain/mocks"
@@ -31,7 +32,7 @@ func NewTestNotificationCenterService(
 	mockHMACVerifier func(email string, providedHMAC string, secretKey string) bool,
 ) *TestNotificationCenterService {
 	return &TestNotificationCenterService{
-		NotificationCenterService: NewNotificationCenterService(contactRepo, workspaceRepo, listRepo, logger),
+		NotificationCenterService: NewNotificationCenterService(contactRepo, workspaceRepo, listRepo, nil, logger),
 		mockHMACVerifier:          mockHMACVerifier,
 	}
 }
@@ -474,6 +475,7 @@ func TestNotificationCenterService_GetContactPreferences(t *testing.T) {
 				mockContactRepo,
 				mockWorkspaceRepo,
 				mockListRepo,
+				mocks.NewMockContactListRepository(ctrl),
 				mockLogger,
 			)
 
@@ -695,6 +697,7 @@ func TestNotificationCenterService_UpdateContactPreferences(t *testing.T) {
 				mockContactRepo,
 				mockWorkspaceRepo,
 				mockListRepo,
+				mocks.NewMockContactListRepository(ctrl),
 				mockLogger,
 			)
 
@@ -709,3 +712,142 @@ func TestNotificationCenterService_UpdateContactPreferences(t *testing.T) {
 		})
 	}
 }
+
+func TestNotificationCenterService_UpdateContactPreferences_DeliveryPreferences(t *testing.T) {
+	secretKey := "test-secret-key"
+	validEmail := "user@example.com"
+	validHMAC := crypto.ComputeHMAC256([]byte(validEmail), secretKey)
+	workspace := &domain.Workspace{
+		ID:       "workspace-123",
+		Settings: domain.WorkspaceSettings{SecretKey: secretKey},
+	}
+	lists := []*domain.List{
+		{ID: "news", IsPublic: true},
+		{ID: "internal", IsPublic: false},
+	}
+
+	setup := func(t *testing.T) (*NotificationCenterService, *mocks.MockContactRepository, *mocks.MockListRepository, *mocks.MockContactListRepository) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockContactRepo := mocks.NewMockContactRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockListRepo := mocks.NewMockListRepository(ctrl)
+		mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+		mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "workspace-123").Return(workspace, nil)
+
+		service := NewNotificationCenterService(mockContactRepo, mockWorkspaceRepo, mockListRepo, mockContactListRepo, mockLogger)
+		return service, mockContactRepo, mockListRepo, mockContactListRepo
+	}
+
+	t.Run("pause marketing emails without touching the contact", func(t *testing.T) {
+		service, _, _, mockContactListRepo := setup(t)
+		pauseDays := 30
+
+		mockContactListRepo.EXPECT().
+			PauseContactLists(gomock.Any(), "workspace-123", validEmail, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID, email string, pausedUntil *time.Time) error {
+				assert.NotNil(t, pausedUntil)
+				assert.WithinDuration(t, time.Now().UTC().AddDate(0, 0, 30), *pausedUntil, time.Minute)
+				return nil
+			})
+
+		err := service.UpdateContactPreferences(context.Background(), &domain.UpdateContactPreferencesRequest{
+			WorkspaceID: "workspace-123",
+			Email:       validEmail,
+			EmailHMAC:   validHMAC,
+			PauseDays:   &pauseDays,
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("resume marketing emails", func(t *testing.T) {
+		service, _, _, mockContactListRepo := setup(t)
+		resume := 0
+
+		mockContactListRepo.EXPECT().
+			PauseContactLists(gomock.Any(), "workspace-123", validEmail, nil).
+			Return(nil)
+
+		err := service.UpdateContactPreferences(context.Background(), &domain.UpdateContactPreferencesRequest{
+			WorkspaceID: "workspace-123",
+			Email:       validEmail,
+			EmailHMAC:   validHMAC,
+			PauseDays:   &resume,
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("update the frequency of a public list", func(t *testing.T) {
+		service, _, mockListRepo, mockContactListRepo := setup(t)
+
+		mockListRepo.EXPECT().GetLists(gomock.Any(), "workspace-123").Return(lists, nil)
+		mockContactListRepo.EXPECT().
+			UpdateContactListFrequency(gomock.Any(), "workspace-123", validEmail, "news", domain.ContactListFrequencyWeeklyDigest).
+			Return(nil)
+
+		err := service.UpdateContactPreferences(context.Background(), &domain.UpdateContactPreferencesRequest{
+			WorkspaceID:     "workspace-123",
+			Email:           validEmail,
+			EmailHMAC:       validHMAC,
+			ListFrequencies: map[string]domain.ContactListFrequency{"news": domain.ContactListFrequencyWeeklyDigest},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("reject the frequency of a private list", func(t *testing.T) {
+		service, _, mockListRepo, _ := setup(t)
+
+		mockListRepo.EXPECT().GetLists(gomock.Any(), "workspace-123").Return(lists, nil)
+
+		err := service.UpdateContactPreferences(context.Background(), &domain.UpdateContactPreferencesRequest{
+			WorkspaceID:     "workspace-123",
+			Email:           validEmail,
+			EmailHMAC:       validHMAC,
+			ListFrequencies: map[string]domain.ContactListFrequency{"internal": domain.ContactListFrequencyWeeklyDigest},
+		})
+		var validationErr domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("reject the frequency of a list the contact is not subscribed to", func(t *testing.T) {
+		service, _, mockListRepo, mockContactListRepo := setup(t)
+
+		mockListRepo.EXPECT().GetLists(gomock.Any(), "workspace-123").Return(lists, nil)
+		mockContactListRepo.EXPECT().
+			UpdateContactListFrequency(gomock.Any(), "workspace-123", validEmail, "news", domain.ContactListFrequencyAll).
+			Return(&domain.ErrContactListNotFound{Message: "contact list not found"})
+
+		err := service.UpdateContactPreferences(context.Background(), &domain.UpdateContactPreferencesRequest{
+			WorkspaceID:     "workspace-123",
+			Email:           validEmail,
+			EmailHMAC:       validHMAC,
+			ListFrequencies: map[string]domain.ContactListFrequency{"news": domain.ContactListFrequencyAll},
+		})
+		var validationErr domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, err.Error(), "not subscribed to list news")
+	})
+
+	t.Run("pause failure", func(t *testing.T) {
+		service, _, _, mockContactListRepo := setup(t)
+		pauseDays := 90
+
+		mockContactListRepo.EXPECT().
+			PauseContactLists(gomock.Any(), "workspace-123", validEmail, gomock.Any()).
+			Return(errors.New("database error"))
+
+		err := service.UpdateContactPreferences(context.Background(), &domain.UpdateContactPreferencesRequest{
+			WorkspaceID: "workspace-123",
+			Email:       validEmail,
+			EmailHMAC:   validHMAC,
+			PauseDays:   &pauseDays,
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update contact preferences")
+	})
+}
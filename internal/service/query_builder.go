@@ -92,6 +92,12 @@ func (qb *QueryBuilder) initializeContactFields() {
 			fieldType: "json",
 		}
 	}
+
+	// Email verification status (valid, risky, unknown, invalid), NULL until verified
+	qb.allowedFields["email_verification_status"] = fieldConfig{
+		dbColumn:  "(email_verification->>'status')",
+		fieldType: "string",
+	}
 }
 
 // initializeOperators sets up the whitelist of allowed operators
@@ -241,8 +247,20 @@ func (qb *QueryBuilder) parseLeaf(leaf *domain.TreeNodeLeaf, argIndex int) (stri
 		}
 		return qb.parseCustomEventsGoalCondition(leaf.CustomEventsGoal, argIndex)
 
+	case "message_history":
+		if leaf.MessageHistory == nil {
+			return "", nil, argIndex, fmt.Errorf("leaf with source 'message_history' must have 'message_history' field")
+		}
+		return qb.parseMessageHistoryCondition(leaf.MessageHistory, argIndex, "contacts.email")
+
+	case "contact_automations":
+		if leaf.ContactAutomation == nil {
+			return "", nil, argIndex, fmt.Errorf("leaf with source 'contact_automations' must have 'contact_automation' field")
+		}
+		return qb.parseContactAutomationCondition(leaf.ContactAutomation, argIndex, "contacts.email")
+
 	default:
-		return "", nil, argIndex, fmt.Errorf("unsupported source: %s (supported: 'contacts', 'contact_lists', 'contact_timeline', 'custom_events_goals')", leaf.Source)
+		return "", nil, argIndex, fmt.Errorf("unsupported source: %s (supported: 'contacts', 'contact_lists', 'contact_timeline', 'custom_events_goals', 'message_history', 'contact_automations')", leaf.Source)
 	}
 }
 
@@ -283,8 +301,14 @@ func (qb *QueryBuilder) parseFilter(filter *domain.DimensionFilter, argIndex int
 		return "", nil, argIndex, fmt.Errorf("filter cannot be nil")
 	}
 
-	// Validate field exists in whitelist
+	// Validate field exists in whitelist (computed properties and attributes are resolved by key)
 	fieldCfg, ok := qb.allowedFields[filter.FieldName]
+	if !ok {
+		fieldCfg, ok = qb.computedPropertyField(filter)
+	}
+	if !ok {
+		fieldCfg, ok = qb.contactAttributeField(filter)
+	}
 	if !ok {
 		return "", nil, argIndex, fmt.Errorf("invalid field name: %s", filter.FieldName)
 	}
@@ -342,6 +366,42 @@ func (qb *QueryBuilder) parseFilter(filter *domain.DimensionFilter, argIndex int
 	return qb.buildCondition(fieldCfg.dbColumn, filter.Operator, sqlOp, values, argIndex)
 }
 
+// computedPropertyField maps a "computed.<key>" filter to an expression over the
+// contacts.computed_properties JSONB column, cast to the filter type. Values of another
+// JSON type yield NULL instead of failing the cast.
+func (qb *QueryBuilder) computedPropertyField(filter *domain.DimensionFilter) (fieldConfig, bool) {
+	key, ok := domain.ComputedPropertyKeyFromField(filter.FieldName)
+	if !ok {
+		return fieldConfig{}, false
+	}
+	return jsonKeyField("computed_properties", key, filter.FieldType)
+}
+
+// contactAttributeField maps an "attributes.<key>" filter to an expression over the
+// contacts.attributes JSONB column. Boolean attributes are filtered as "true"/"false"
+// strings. The expressions match the indexes of filterable attributes.
+func (qb *QueryBuilder) contactAttributeField(filter *domain.DimensionFilter) (fieldConfig, bool) {
+	key, ok := domain.ContactAttributeKeyFromField(filter.FieldName)
+	if !ok {
+		return fieldConfig{}, false
+	}
+	return jsonKeyField("attributes", key, filter.FieldType)
+}
+
+// jsonKeyField returns the field config reading a key of a contacts JSONB column.
+// Keys are validated against ^[a-z][a-z0-9_]*$ so they are safe to embed.
+func jsonKeyField(column, key, fieldType string) (fieldConfig, bool) {
+	switch fieldType {
+	case "string", "number", "time":
+		return fieldConfig{
+			dbColumn:  domain.ContactJSONFieldSQL(column, key, fieldType),
+			fieldType: fieldType,
+		}, true
+	default:
+		return fieldConfig{}, false
+	}
+}
+
 // getStringValues extracts string values from filter
 func (qb *QueryBuilder) getStringValues(filter *domain.DimensionFilter) ([]interface{}, error) {
 	if len(filter.StringValues) == 0 {
@@ -715,6 +775,11 @@ func (qb *QueryBuilder) parseGoalTimeframeCondition(operator string, values []st
 
 // parseTimeframeCondition generates SQL for timeline timeframe filters
 func (qb *QueryBuilder) parseTimeframeCondition(operator string, values []string, argIndex int) (string, []interface{}, int, error) {
+	return qb.parseTimeframeConditionOnColumn("ct.created_at", operator, values, argIndex)
+}
+
+// parseTimeframeConditionOnColumn generates SQL for a timeframe filter on the given timestamp column
+func (qb *QueryBuilder) parseTimeframeConditionOnColumn(column, operator string, values []string, argIndex int) (string, []interface{}, int, error) {
 	var args []interface{}
 
 	switch operator {
@@ -731,7 +796,7 @@ func (qb *QueryBuilder) parseTimeframeCondition(operator string, values []string
 			return "", nil, argIndex, fmt.Errorf("invalid end time: %w", err)
 		}
 		args = append(args, startTime, endTime)
-		condition := fmt.Sprintf("ct.created_at BETWEEN $%d AND $%d", argIndex, argIndex+1)
+		condition := fmt.Sprintf("%s BETWEEN $%d AND $%d", column, argIndex, argIndex+1)
 		return condition, args, argIndex + 2, nil
 
 	case "before_date":
@@ -743,7 +808,7 @@ func (qb *QueryBuilder) parseTimeframeCondition(operator string, values []string
 			return "", nil, argIndex, fmt.Errorf("invalid time: %w", err)
 		}
 		args = append(args, t)
-		condition := fmt.Sprintf("ct.created_at < $%d", argIndex)
+		condition := fmt.Sprintf("%s < $%d", column, argIndex)
 		return condition, args, argIndex + 1, nil
 
 	case "after_date":
@@ -755,7 +820,7 @@ func (qb *QueryBuilder) parseTimeframeCondition(operator string, values []string
 			return "", nil, argIndex, fmt.Errorf("invalid time: %w", err)
 		}
 		args = append(args, t)
-		condition := fmt.Sprintf("ct.created_at > $%d", argIndex)
+		condition := fmt.Sprintf("%s > $%d", column, argIndex)
 		return condition, args, argIndex + 1, nil
 
 	case "in_the_last_days":
@@ -770,7 +835,7 @@ func (qb *QueryBuilder) parseTimeframeCondition(operator string, values []string
 		}
 		// Note: Not using parameterized query for interval as PostgreSQL doesn't support it directly
 		// But the value is parsed as int so it's safe from SQL injection
-		condition := fmt.Sprintf("ct.created_at > NOW() - INTERVAL '%d days'", days)
+		condition := fmt.Sprintf("%s > NOW() - INTERVAL '%d days'", column, days)
 		return condition, args, argIndex, nil
 
 	default:
@@ -1184,6 +1249,18 @@ func (qb *QueryBuilder) parseLeafWithEmailRef(leaf *domain.TreeNodeLeaf, argInde
 		}
 		return qb.parseCustomEventsGoalConditionWithEmailRef(leaf.CustomEventsGoal, argIndex, emailRef)
 
+	case "message_history":
+		if leaf.MessageHistory == nil {
+			return "", nil, argIndex, fmt.Errorf("leaf with source 'message_history' must have 'message_history' field")
+		}
+		return qb.parseMessageHistoryCondition(leaf.MessageHistory, argIndex, emailRef)
+
+	case "contact_automations":
+		if leaf.ContactAutomation == nil {
+			return "", nil, argIndex, fmt.Errorf("leaf with source 'contact_automations' must have 'contact_automation' field")
+		}
+		return qb.parseContactAutomationCondition(leaf.ContactAutomation, argIndex, emailRef)
+
 	default:
 		return "", nil, argIndex, fmt.Errorf("unsupported source: %s", leaf.Source)
 	}
@@ -1465,3 +1542,137 @@ func (qb *QueryBuilder) parseCustomEventsGoalConditionWithEmailRef(goal *domain.
 
 	return existsClause, args, argIndex, nil
 }
+
+// messageHistoryEventColumns maps message_history condition events to the timestamp column recording them
+var messageHistoryEventColumns = map[string]string{
+	"sent":         "sent_at",
+	"delivered":    "delivered_at",
+	"opened":       "opened_at",
+	"clicked":      "clicked_at",
+	"bounced":      "bounced_at",
+	"complained":   "complained_at",
+	"unsubscribed": "unsubscribed_at",
+	"failed":       "failed_at",
+}
+
+// parseMessageHistoryCondition generates SQL counting the messages sent to a contact that reached an event,
+// optionally scoped to a broadcast, automation, template or channel.
+// emailRef is "contacts.email" for segments and e.g. "NEW.email" in trigger context.
+func (qb *QueryBuilder) parseMessageHistoryCondition(cond *domain.MessageHistoryCondition, argIndex int, emailRef string) (string, []interface{}, int, error) {
+	if cond == nil {
+		return "", nil, argIndex, fmt.Errorf("message_history condition cannot be nil")
+	}
+
+	eventColumn, ok := messageHistoryEventColumns[cond.Event]
+	if !ok {
+		return "", nil, argIndex, fmt.Errorf("invalid message_history event: %s", cond.Event)
+	}
+	eventColumn = "mh." + eventColumn
+
+	var args []interface{}
+	conditions := []string{eventColumn + " IS NOT NULL"}
+
+	// Optional scoping filters
+	scopes := []struct {
+		column string
+		value  *string
+	}{
+		{"mh.broadcast_id", cond.BroadcastID},
+		{"mh.automation_id", cond.AutomationID},
+		{"mh.template_id", cond.TemplateID},
+		{"mh.channel", cond.Channel},
+	}
+	for _, scope := range scopes {
+		if scope.value != nil && *scope.value != "" {
+			args = append(args, *scope.value)
+			conditions = append(conditions, fmt.Sprintf("%s = $%d", scope.column, argIndex))
+			argIndex++
+		}
+	}
+
+	// Timeframe applies to the timestamp of the event itself
+	if cond.TimeframeOperator != nil && *cond.TimeframeOperator != "" && *cond.TimeframeOperator != "anytime" {
+		timeCondition, timeArgs, newArgIndex, err := qb.parseTimeframeConditionOnColumn(eventColumn, *cond.TimeframeOperator, cond.TimeframeValues, argIndex)
+		if err != nil {
+			return "", nil, argIndex, err
+		}
+		conditions = append(conditions, timeCondition)
+		args = append(args, timeArgs...)
+		argIndex = newArgIndex
+	}
+
+	var countComparison string
+	switch cond.CountOperator {
+	case "at_least":
+		countComparison = ">="
+	case "at_most":
+		countComparison = "<="
+	case "exactly":
+		countComparison = "="
+	default:
+		return "", nil, argIndex, fmt.Errorf("invalid count_operator: %s (must be 'at_least', 'at_most', or 'exactly')", cond.CountOperator)
+	}
+
+	args = append(args, cond.CountValue)
+	countCondition := fmt.Sprintf(
+		"(SELECT COUNT(*) FROM message_history mh WHERE mh.contact_email = %s AND %s) %s $%d",
+		emailRef,
+		strings.Join(conditions, " AND "),
+		countComparison,
+		argIndex,
+	)
+	argIndex++
+
+	return countCondition, args, argIndex, nil
+}
+
+// parseContactAutomationCondition generates SQL checking whether a contact is (or was) enrolled in an automation,
+// optionally with a given status and entry timeframe.
+// emailRef is "contacts.email" for segments and e.g. "NEW.email" in trigger context.
+func (qb *QueryBuilder) parseContactAutomationCondition(cond *domain.ContactAutomationCondition, argIndex int, emailRef string) (string, []interface{}, int, error) {
+	if cond == nil {
+		return "", nil, argIndex, fmt.Errorf("contact_automation condition cannot be nil")
+	}
+
+	if cond.AutomationID == "" {
+		return "", nil, argIndex, fmt.Errorf("contact_automation must have 'automation_id'")
+	}
+
+	var args []interface{}
+	var conditions []string
+
+	args = append(args, cond.AutomationID)
+	conditions = append(conditions, fmt.Sprintf("ca.automation_id = $%d", argIndex))
+	argIndex++
+
+	if cond.Status != nil && *cond.Status != "" {
+		args = append(args, *cond.Status)
+		conditions = append(conditions, fmt.Sprintf("ca.status = $%d", argIndex))
+		argIndex++
+	}
+
+	if cond.TimeframeOperator != nil && *cond.TimeframeOperator != "" && *cond.TimeframeOperator != "anytime" {
+		timeCondition, timeArgs, newArgIndex, err := qb.parseTimeframeConditionOnColumn("ca.entered_at", *cond.TimeframeOperator, cond.TimeframeValues, argIndex)
+		if err != nil {
+			return "", nil, argIndex, err
+		}
+		conditions = append(conditions, timeCondition)
+		args = append(args, timeArgs...)
+		argIndex = newArgIndex
+	}
+
+	existsClause := fmt.Sprintf(
+		"EXISTS (SELECT 1 FROM contact_automations ca WHERE ca.contact_email = %s AND %s)",
+		emailRef,
+		strings.Join(conditions, " AND "),
+	)
+
+	// Handle NOT IN operator
+	if cond.Operator == "not_in" {
+		existsClause = "NOT " + existsClause
+	} else if cond.Operator != "in" && cond.Operator != "" {
+		return "", nil, argIndex, fmt.Errorf("invalid contact_automation operator: %s (must be 'in' or 'not_in')", cond.Operator)
+	}
+
+	return existsClause, args, argIndex, nil
+}
@@ -2080,3 +2080,436 @@ func TestQueryBuilder_BuildTriggerCondition(t *testing.T) {
 		assert.Equal(t, []interface{}{"open_email", "welcome-email", 1}, args)
 	})
 }
+
+func TestQueryBuilder_MessageHistory(t *testing.T) {
+	qb := NewQueryBuilder()
+	strPtr := func(s string) *string { return &s }
+
+	t.Run("opened a broadcast", func(t *testing.T) {
+		tree := &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source: "message_history",
+				MessageHistory: &domain.MessageHistoryCondition{
+					Event:         "opened",
+					CountOperator: "at_least",
+					CountValue:    1,
+					BroadcastID:   strPtr("bc_123"),
+				},
+			},
+		}
+
+		sql, args, err := qb.BuildSQL(tree)
+		require.NoError(t, err)
+
+		assert.Contains(t, sql, "FROM message_history mh")
+		assert.Contains(t, sql, "WHERE mh.contact_email = contacts.email")
+		assert.Contains(t, sql, "mh.opened_at IS NOT NULL")
+		assert.Contains(t, sql, "mh.broadcast_id = $1")
+		assert.Contains(t, sql, ">= $2")
+		assert.Equal(t, []interface{}{"bc_123", 1}, args)
+	})
+
+	t.Run("opened a broadcast but did not click", func(t *testing.T) {
+		tree := &domain.TreeNode{
+			Kind: "branch",
+			Branch: &domain.TreeNodeBranch{
+				Operator: "and",
+				Leaves: []*domain.TreeNode{
+					{
+						Kind: "leaf",
+						Leaf: &domain.TreeNodeLeaf{
+							Source: "message_history",
+							MessageHistory: &domain.MessageHistoryCondition{
+								Event: "opened", CountOperator: "at_least", CountValue: 1, BroadcastID: strPtr("bc_123"),
+							},
+						},
+					},
+					{
+						Kind: "leaf",
+						Leaf: &domain.TreeNodeLeaf{
+							Source: "message_history",
+							MessageHistory: &domain.MessageHistoryCondition{
+								Event: "clicked", CountOperator: "exactly", CountValue: 0, BroadcastID: strPtr("bc_123"),
+							},
+						},
+					},
+				},
+			},
+		}
+
+		sql, args, err := qb.BuildSQL(tree)
+		require.NoError(t, err)
+
+		assert.Contains(t, sql, "mh.opened_at IS NOT NULL AND mh.broadcast_id = $1) >= $2")
+		assert.Contains(t, sql, "mh.clicked_at IS NOT NULL AND mh.broadcast_id = $3) = $4")
+		assert.Equal(t, []interface{}{"bc_123", 1, "bc_123", 0}, args)
+	})
+
+	t.Run("received emails in the last days with all scopes", func(t *testing.T) {
+		tree := &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source: "message_history",
+				MessageHistory: &domain.MessageHistoryCondition{
+					Event:             "sent",
+					CountOperator:     "at_least",
+					CountValue:        6,
+					Channel:           strPtr("email"),
+					AutomationID:      strPtr("auto_1"),
+					TemplateID:        strPtr("welcome"),
+					TimeframeOperator: strPtr("in_the_last_days"),
+					TimeframeValues:   []string{"30"},
+				},
+			},
+		}
+
+		sql, args, err := qb.BuildSQL(tree)
+		require.NoError(t, err)
+
+		assert.Contains(t, sql, "mh.sent_at IS NOT NULL")
+		assert.Contains(t, sql, "mh.automation_id = $1")
+		assert.Contains(t, sql, "mh.template_id = $2")
+		assert.Contains(t, sql, "mh.channel = $3")
+		assert.Contains(t, sql, "mh.sent_at > NOW() - INTERVAL '30 days'")
+		assert.Contains(t, sql, ">= $4")
+		assert.Equal(t, []interface{}{"auto_1", "welcome", "email", 6}, args)
+	})
+
+	t.Run("date range applies to the event column", func(t *testing.T) {
+		tree := &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source: "message_history",
+				MessageHistory: &domain.MessageHistoryCondition{
+					Event:             "clicked",
+					CountOperator:     "at_least",
+					CountValue:        1,
+					TimeframeOperator: strPtr("in_date_range"),
+					TimeframeValues:   []string{"2026-01-01T00:00:00Z", "2026-02-01T00:00:00Z"},
+				},
+			},
+		}
+
+		sql, args, err := qb.BuildSQL(tree)
+		require.NoError(t, err)
+
+		assert.Contains(t, sql, "mh.clicked_at BETWEEN $1 AND $2")
+		assert.Len(t, args, 3)
+	})
+
+	t.Run("invalid event", func(t *testing.T) {
+		tree := &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source: "message_history",
+				MessageHistory: &domain.MessageHistoryCondition{
+					Event:         "printed",
+					CountOperator: "at_least",
+					CountValue:    1,
+				},
+			},
+		}
+
+		_, _, err := qb.BuildSQL(tree)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid message_history event")
+	})
+}
+
+func TestQueryBuilder_ContactAutomations(t *testing.T) {
+	qb := NewQueryBuilder()
+	strPtr := func(s string) *string { return &s }
+
+	t.Run("currently active in automation", func(t *testing.T) {
+		tree := &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source: "contact_automations",
+				ContactAutomation: &domain.ContactAutomationCondition{
+					Operator:     "in",
+					AutomationID: "auto_1",
+					Status:       strPtr("active"),
+				},
+			},
+		}
+
+		sql, args, err := qb.BuildSQL(tree)
+		require.NoError(t, err)
+
+		assert.Contains(t, sql, "EXISTS (SELECT 1 FROM contact_automations ca WHERE ca.contact_email = contacts.email")
+		assert.Contains(t, sql, "ca.automation_id = $1")
+		assert.Contains(t, sql, "ca.status = $2")
+		assert.NotContains(t, sql, "NOT EXISTS")
+		assert.Equal(t, []interface{}{"auto_1", "active"}, args)
+	})
+
+	t.Run("never entered automation", func(t *testing.T) {
+		tree := &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source: "contact_automations",
+				ContactAutomation: &domain.ContactAutomationCondition{
+					Operator:     "not_in",
+					AutomationID: "auto_1",
+				},
+			},
+		}
+
+		sql, args, err := qb.BuildSQL(tree)
+		require.NoError(t, err)
+
+		assert.Contains(t, sql, "NOT EXISTS (SELECT 1 FROM contact_automations ca")
+		assert.NotContains(t, sql, "ca.status")
+		assert.Equal(t, []interface{}{"auto_1"}, args)
+	})
+
+	t.Run("completed automation in the last days", func(t *testing.T) {
+		tree := &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source: "contact_automations",
+				ContactAutomation: &domain.ContactAutomationCondition{
+					Operator:          "in",
+					AutomationID:      "auto_1",
+					Status:            strPtr("completed"),
+					TimeframeOperator: strPtr("in_the_last_days"),
+					TimeframeValues:   []string{"7"},
+				},
+			},
+		}
+
+		sql, _, err := qb.BuildSQL(tree)
+		require.NoError(t, err)
+
+		assert.Contains(t, sql, "ca.entered_at > NOW() - INTERVAL '7 days'")
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		tree := &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source: "contact_automations",
+				ContactAutomation: &domain.ContactAutomationCondition{
+					Operator:     "in",
+					AutomationID: "auto_1",
+					Status:       strPtr("paused"),
+				},
+			},
+		}
+
+		_, _, err := qb.BuildSQL(tree)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid contact_automation status")
+	})
+}
+
+func TestQueryBuilder_BuildTriggerCondition_MessageHistoryAndAutomations(t *testing.T) {
+	qb := NewQueryBuilder()
+	strPtr := func(s string) *string { return &s }
+
+	tree := &domain.TreeNode{
+		Kind: "branch",
+		Branch: &domain.TreeNodeBranch{
+			Operator: "and",
+			Leaves: []*domain.TreeNode{
+				{
+					Kind: "leaf",
+					Leaf: &domain.TreeNodeLeaf{
+						Source: "message_history",
+						MessageHistory: &domain.MessageHistoryCondition{
+							Event: "opened", CountOperator: "at_least", CountValue: 1, TemplateID: strPtr("newsletter"),
+						},
+					},
+				},
+				{
+					Kind: "leaf",
+					Leaf: &domain.TreeNodeLeaf{
+						Source: "contact_automations",
+						ContactAutomation: &domain.ContactAutomationCondition{
+							Operator: "not_in", AutomationID: "auto_1", Status: strPtr("active"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	sql, args, err := qb.BuildTriggerCondition(tree, "NEW.email")
+	require.NoError(t, err)
+
+	assert.Contains(t, sql, "mh.contact_email = NEW.email")
+	assert.Contains(t, sql, "mh.template_id = $1")
+	assert.Contains(t, sql, "NOT EXISTS (SELECT 1 FROM contact_automations ca WHERE ca.contact_email = NEW.email")
+	assert.Contains(t, sql, "ca.automation_id = $3")
+	assert.Contains(t, sql, "ca.status = $4")
+	assert.NotContains(t, sql, "contacts.email")
+	assert.Equal(t, []interface{}{"newsletter", 1, "auto_1", "active"}, args)
+}
+
+func TestQueryBuilder_ComputedProperties(t *testing.T) {
+	qb := NewQueryBuilder()
+
+	buildTree := func(filter *domain.DimensionFilter) *domain.TreeNode {
+		return &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source:  "contacts",
+				Contact: &domain.ContactCondition{Filters: []*domain.DimensionFilter{filter}},
+			},
+		}
+	}
+
+	t.Run("number filter", func(t *testing.T) {
+		sql, args, err := qb.BuildSQL(buildTree(&domain.DimensionFilter{
+			FieldName:    "computed.rfm_score",
+			FieldType:    "number",
+			Operator:     "gte",
+			NumberValues: []float64{4},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT email FROM contacts WHERE ((CASE WHEN jsonb_typeof(computed_properties->'rfm_score') = 'number' THEN (computed_properties->>'rfm_score')::numeric END) >= $1)", sql)
+		assert.Equal(t, []interface{}{4.0}, args)
+	})
+
+	t.Run("string filter", func(t *testing.T) {
+		sql, args, err := qb.BuildSQL(buildTree(&domain.DimensionFilter{
+			FieldName:    "computed.favorite_category",
+			FieldType:    "string",
+			Operator:     "equals",
+			StringValues: []string{"shoes"},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT email FROM contacts WHERE ((computed_properties->>'favorite_category') = $1)", sql)
+		assert.Equal(t, []interface{}{"shoes"}, args)
+	})
+
+	t.Run("time filter", func(t *testing.T) {
+		sql, _, err := qb.BuildSQL(buildTree(&domain.DimensionFilter{
+			FieldName:    "computed.last_order_at",
+			FieldType:    "time",
+			Operator:     "in_the_last_days",
+			StringValues: []string{"30"},
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, sql, "(computed_properties->>'last_order_at')::timestamptz")
+	})
+
+	t.Run("is set without value", func(t *testing.T) {
+		sql, args, err := qb.BuildSQL(buildTree(&domain.DimensionFilter{
+			FieldName: "computed.ltv",
+			FieldType: "number",
+			Operator:  "is_set",
+		}))
+		require.NoError(t, err)
+		assert.Contains(t, sql, "(computed_properties->>'ltv')::numeric END) IS NOT NULL")
+		assert.Empty(t, args)
+	})
+
+	t.Run("invalid key is rejected", func(t *testing.T) {
+		_, _, err := qb.BuildSQL(buildTree(&domain.DimensionFilter{
+			FieldName:    "computed.score' OR '1'='1",
+			FieldType:    "string",
+			Operator:     "equals",
+			StringValues: []string{"x"},
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid field name")
+	})
+
+	t.Run("json field type is rejected", func(t *testing.T) {
+		_, _, err := qb.BuildSQL(buildTree(&domain.DimensionFilter{
+			FieldName:    "computed.score",
+			FieldType:    "json",
+			Operator:     "equals",
+			StringValues: []string{"x"},
+		}))
+		require.Error(t, err)
+	})
+}
+
+func TestQueryBuilder_ContactAttributes(t *testing.T) {
+	qb := NewQueryBuilder()
+
+	buildTree := func(filter *domain.DimensionFilter) *domain.TreeNode {
+		return &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source:  "contacts",
+				Contact: &domain.ContactCondition{Filters: []*domain.DimensionFilter{filter}},
+			},
+		}
+	}
+
+	t.Run("string filter", func(t *testing.T) {
+		sql, args, err := qb.BuildSQL(buildTree(&domain.DimensionFilter{
+			FieldName:    "attributes.plan",
+			FieldType:    "string",
+			Operator:     "equals",
+			StringValues: []string{"pro"},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT email FROM contacts WHERE ((attributes->>'plan') = $1)", sql)
+		assert.Equal(t, []interface{}{"pro"}, args)
+	})
+
+	t.Run("number filter", func(t *testing.T) {
+		sql, args, err := qb.BuildSQL(buildTree(&domain.DimensionFilter{
+			FieldName:    "attributes.seats",
+			FieldType:    "number",
+			Operator:     "gt",
+			NumberValues: []float64{10},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT email FROM contacts WHERE ((CASE WHEN jsonb_typeof(attributes->'seats') = 'number' THEN (attributes->>'seats')::numeric END) > $1)", sql)
+		assert.Equal(t, []interface{}{10.0}, args)
+	})
+
+	t.Run("invalid key is rejected", func(t *testing.T) {
+		_, _, err := qb.BuildSQL(buildTree(&domain.DimensionFilter{
+			FieldName:    "attributes.Plan",
+			FieldType:    "string",
+			Operator:     "equals",
+			StringValues: []string{"x"},
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid field name")
+	})
+}
+
+func TestQueryBuilder_EmailVerificationStatus(t *testing.T) {
+	qb := NewQueryBuilder()
+
+	buildTree := func(filter *domain.DimensionFilter) *domain.TreeNode {
+		return &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source:  "contacts",
+				Contact: &domain.ContactCondition{Filters: []*domain.DimensionFilter{filter}},
+			},
+		}
+	}
+
+	t.Run("status equals", func(t *testing.T) {
+		sql, args, err := qb.BuildSQL(buildTree(&domain.DimensionFilter{
+			FieldName:    "email_verification_status",
+			FieldType:    "string",
+			Operator:     "equals",
+			StringValues: []string{"risky"},
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT email FROM contacts WHERE ((email_verification->>'status') = $1)", sql)
+		assert.Equal(t, []interface{}{"risky"}, args)
+	})
+
+	t.Run("never verified", func(t *testing.T) {
+		sql, args, err := qb.BuildSQL(buildTree(&domain.DimensionFilter{
+			FieldName: "email_verification_status",
+			FieldType: "string",
+			Operator:  "is_not_set",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT email FROM contacts WHERE ((email_verification->>'status') IS NULL)", sql)
+		assert.Empty(t, args)
+	})
+}
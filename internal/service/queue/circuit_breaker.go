@@ -95,7 +95,8 @@ func (cb *CircuitBreaker) RecordSuccess() {
 }
 
 // RecordFailure records a failed call and opens the circuit if threshold is reached
-func (cb *CircuitBreaker) RecordFailure(classifiedErr *emailerror.ClassifiedError) {
+// Returns true if this failure opened the circuit
+func (cb *CircuitBreaker) RecordFailure(classifiedErr *emailerror.ClassifiedError) bool {
 	cb.mutex.Lock()
 	defer cb.mutex.Unlock()
 
@@ -103,9 +104,11 @@ func (cb *CircuitBreaker) RecordFailure(classifiedErr *emailerror.ClassifiedErro
 	cb.lastFailure = time.Now()
 	cb.lastError = classifiedErr
 
-	if cb.failures >= cb.threshold {
+	if cb.failures >= cb.threshold && !cb.isOpen {
 		cb.isOpen = true
+		return true
 	}
+	return false
 }
 
 // GetLastError returns the last error that caused a failure
@@ -171,14 +174,20 @@ func (icb *IntegrationCircuitBreaker) RecordSuccess(integrationID string) {
 // Only counts provider errors toward the circuit breaker threshold
 // Returns true if the error was counted (provider error), false if ignored (recipient error)
 func (icb *IntegrationCircuitBreaker) RecordFailure(integrationID string, classifiedErr *emailerror.ClassifiedError) bool {
+	counted, _ := icb.RecordFailureAndCheckOpened(integrationID, classifiedErr)
+	return counted
+}
+
+// RecordFailureAndCheckOpened records a failure like RecordFailure and also reports
+// whether this failure opened the circuit, so that only one caller acts on the transition
+func (icb *IntegrationCircuitBreaker) RecordFailureAndCheckOpened(integrationID string, classifiedErr *emailerror.ClassifiedError) (counted bool, opened bool) {
 	// Only count provider errors toward circuit breaker
 	if classifiedErr == nil || classifiedErr.IsRecipientError() {
-		return false
+		return false, false
 	}
 
 	cb := icb.getOrCreateBreaker(integrationID)
-	cb.RecordFailure(classifiedErr)
-	return true
+	return true, cb.RecordFailure(classifiedErr)
 }
 
 // GetLastError returns the last error for an integration
@@ -137,6 +137,34 @@ func TestIntegrationCircuitBreaker_IgnoresRecipientErrors(t *testing.T) {
 	assert.True(t, icb.IsOpen("integration1"))
 }
 
+func TestIntegrationCircuitBreaker_RecordFailureAndCheckOpened(t *testing.T) {
+	icb := NewIntegrationCircuitBreaker(CircuitBreakerConfig{
+		Threshold:      2,
+		CooldownPeriod: 1 * time.Minute,
+	})
+
+	recipientErr := &emailerror.ClassifiedError{Type: emailerror.ErrorTypeRecipient}
+	providerErr := &emailerror.ClassifiedError{Type: emailerror.ErrorTypeProvider}
+
+	counted, opened := icb.RecordFailureAndCheckOpened("integration1", recipientErr)
+	assert.False(t, counted)
+	assert.False(t, opened)
+
+	counted, opened = icb.RecordFailureAndCheckOpened("integration1", providerErr)
+	assert.True(t, counted)
+	assert.False(t, opened)
+
+	// Only the failure reaching the threshold reports the transition
+	counted, opened = icb.RecordFailureAndCheckOpened("integration1", providerErr)
+	assert.True(t, counted)
+	assert.True(t, opened)
+
+	counted, opened = icb.RecordFailureAndCheckOpened("integration1", providerErr)
+	assert.True(t, counted)
+	assert.False(t, opened)
+	assert.True(t, icb.IsOpen("integration1"))
+}
+
 func TestIntegrationCircuitBreaker_NilError(t *testing.T) {
 	config := CircuitBreakerConfig{
 		Threshold:      2,
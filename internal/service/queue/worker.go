@@ -38,8 +38,13 @@ func DefaultWorkerConfig() *EmailQueueWorkerConfig {
 // EmailSentCallback is called when an email is successfully sent
 type EmailSentCallback func(workspaceID string, sourceType domain.EmailQueueSourceType, sourceID string, messageID string)
 
-// EmailFailedCallback is called when an email fails to send
-type EmailFailedCallback func(workspaceID string, sourceType domain.EmailQueueSourceType, sourceID string, messageID string, err error, isPermanent bool)
+// EmailFailedCallback is called when an email fails to send. classifiedErr is nil for
+// internal errors (e.g. integration not found) and isPermanent is true when the entry
+// is dropped instead of being retried.
+type EmailFailedCallback func(workspaceID string, entry *domain.EmailQueueEntry, err error, classifiedErr *emailerror.ClassifiedError, isPermanent bool)
+
+// CircuitOpenedCallback is called when provider errors open the circuit of an integration
+type CircuitOpenedCallback func(workspaceID string, integrationID string, lastErr *emailerror.ClassifiedError)
 
 // EmailQueueWorker processes queued emails
 type EmailQueueWorker struct {
@@ -66,8 +71,9 @@ type EmailQueueWorker struct {
 	mu      sync.RWMutex
 
 	// Callbacks for progress tracking
-	onEmailSent   EmailSentCallback
-	onEmailFailed EmailFailedCallback
+	onEmailSent     EmailSentCallback
+	onEmailFailed   EmailFailedCallback
+	onCircuitOpened CircuitOpenedCallback
 }
 
 // NewEmailQueueWorker creates a new EmailQueueWorker
@@ -114,6 +120,11 @@ func (w *EmailQueueWorker) SetCallbacks(onSent EmailSentCallback, onFailed Email
 	w.onEmailFailed = onFailed
 }
 
+// SetCircuitOpenedCallback sets the callback called when the circuit of an integration opens
+func (w *EmailQueueWorker) SetCircuitOpenedCallback(onOpened CircuitOpenedCallback) {
+	w.onCircuitOpened = onOpened
+}
+
 // Start begins processing queued emails
 func (w *EmailQueueWorker) Start(ctx context.Context) error {
 	w.mu.Lock()
@@ -394,7 +405,17 @@ func (w *EmailQueueWorker) processEntry(workspace *domain.Workspace, entry *doma
 		}).Debug("Classified send error")
 
 		// Record failure to circuit breaker (only counts provider errors)
-		w.circuitBreaker.RecordFailure(entry.IntegrationID, classifiedErr)
+		if _, opened := w.circuitBreaker.RecordFailureAndCheckOpened(entry.IntegrationID, classifiedErr); opened {
+			w.logger.WithFields(map[string]interface{}{
+				"workspace_id":   workspace.ID,
+				"integration_id": entry.IntegrationID,
+				"error_type":     classifiedErr.Type,
+			}).Warn("Circuit breaker opened for integration")
+
+			if w.onCircuitOpened != nil {
+				w.onCircuitOpened(workspace.ID, entry.IntegrationID, classifiedErr)
+			}
+		}
 
 		w.handleError(workspace, entry, err, classifiedErr)
 		return
@@ -477,7 +498,7 @@ func (w *EmailQueueWorker) handleError(workspace *domain.Workspace, entry *domai
 
 		// Call failure callback (isPermanent = true)
 		if w.onEmailFailed != nil {
-			w.onEmailFailed(workspace.ID, entry.SourceType, entry.SourceID, entry.MessageID, sendErr, true)
+			w.onEmailFailed(workspace.ID, entry, sendErr, classifiedErr, true)
 		}
 		return
 	}
@@ -493,7 +514,7 @@ func (w *EmailQueueWorker) handleError(workspace *domain.Workspace, entry *domai
 
 	// Call failure callback (isPermanent = false, will retry)
 	if w.onEmailFailed != nil {
-		w.onEmailFailed(workspace.ID, entry.SourceType, entry.SourceID, entry.MessageID, sendErr, false)
+		w.onEmailFailed(workspace.ID, entry, sendErr, classifiedErr, false)
 	}
 }
 
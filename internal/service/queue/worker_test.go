@@ -9,6 +9,7 @@ import (
 
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/emailerror"
 	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -1606,3 +1607,80 @@ func TestEmailQueueWorker_ProcessEntry_JITGuardLookupErrorFailsOpen(t *testing.T
 
 	worker.processEntry(workspace, entry)
 }
+
+func TestEmailQueueWorker_ProcessEntry_FailureCallbacks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+	mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	workspace := &domain.Workspace{
+		ID: "workspace-1",
+		Integrations: []domain.Integration{
+			{
+				ID: "integration-1",
+				EmailProvider: domain.EmailProvider{
+					Kind:               domain.EmailProviderKindSMTP,
+					RateLimitPerMinute: 6000,
+				},
+			},
+		},
+	}
+	newEntry := func(id string) *domain.EmailQueueEntry {
+		return &domain.EmailQueueEntry{
+			ID:            id,
+			Status:        domain.EmailQueueStatusPending,
+			SourceType:    domain.EmailQueueSourceBroadcast,
+			SourceID:      "broadcast-1",
+			IntegrationID: "integration-1",
+			ContactEmail:  "test@example.com",
+			MessageID:     "msg-" + id,
+			MaxAttempts:   3,
+		}
+	}
+
+	sendErr := errors.New("SMTP connection failed")
+	mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), "workspace-1", gomock.Any()).Return(nil).Times(2)
+	mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).Return(sendErr).Times(2)
+	mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), "workspace-1", gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	mockQueueRepo.EXPECT().MarkAsFailed(gomock.Any(), "workspace-1", gomock.Any(), sendErr.Error(), gomock.Any()).Return(nil).Times(2)
+
+	config := DefaultWorkerConfig()
+	config.CircuitBreakerThreshold = 2
+	worker := NewEmailQueueWorker(mockQueueRepo, mockWorkspaceRepo, mockEmailService, mockMessageHistoryRepo, config, mockLogger)
+	worker.ctx = context.Background()
+
+	var failedEntries []*domain.EmailQueueEntry
+	worker.SetCallbacks(nil, func(workspaceID string, entry *domain.EmailQueueEntry, err error, classifiedErr *emailerror.ClassifiedError, isPermanent bool) {
+		assert.Equal(t, "workspace-1", workspaceID)
+		assert.Equal(t, sendErr, err)
+		require.NotNil(t, classifiedErr)
+		assert.False(t, isPermanent)
+		failedEntries = append(failedEntries, entry)
+	})
+	var openedIntegrations []string
+	worker.SetCircuitOpenedCallback(func(workspaceID string, integrationID string, lastErr *emailerror.ClassifiedError) {
+		assert.Equal(t, "workspace-1", workspaceID)
+		require.NotNil(t, lastErr)
+		openedIntegrations = append(openedIntegrations, integrationID)
+	})
+
+	worker.processEntry(workspace, newEntry("entry-1"))
+	assert.Empty(t, openedIntegrations)
+
+	worker.processEntry(workspace, newEntry("entry-2"))
+	assert.Equal(t, []string{"integration-1"}, openedIntegrations)
+
+	require.Len(t, failedEntries, 2)
+	assert.Equal(t, "test@example.com", failedEntries[1].ContactEmail)
+	assert.Equal(t, "msg-entry-2", failedEntries[1].MessageID)
+}
@@ -104,12 +104,39 @@ func (p *SegmentRecomputeTaskProcessor) Process(ctx context.Context, task *domai
 		"tasks_created": tasksCreated,
 	}).Info("Completed segment recompute check")
 
+	p.snapshotSegmentHistory(ctx, task)
+
 	// This is a permanent recurring task - return false to keep it as "pending"
 	// This allows it to be picked up again on the next cron run
 	task.Progress = 0 // Reset progress for next run
 	return false, nil // false = task is not complete, will be marked as "pending" and re-run
 }
 
+// snapshotSegmentHistory records the segment history of the current UTC day, and
+// finalizes the previous day on the first run after midnight. Snapshots taken within
+// the last hour are skipped, so most runs do no real work.
+func (p *SegmentRecomputeTaskProcessor) snapshotSegmentHistory(ctx context.Context, task *domain.Task) {
+	now := time.Now().UTC()
+
+	created, err := p.segmentRepo.SnapshotSegmentHistory(ctx, task.WorkspaceID, now)
+	if err != nil {
+		p.logger.WithFields(map[string]interface{}{
+			"task_id":      task.ID,
+			"workspace_id": task.WorkspaceID,
+			"error":        err.Error(),
+		}).Warn("Failed to snapshot segment history (will retry on next run)")
+		return
+	}
+
+	if created > 0 {
+		p.logger.WithFields(map[string]interface{}{
+			"workspace_id": task.WorkspaceID,
+			"day":          now.Format(domain.SegmentHistoryDateFormat),
+			"snapshots":    created,
+		}).Info("Recorded segment history snapshots")
+	}
+}
+
 // EnsureSegmentRecomputeTask creates or updates the permanent recompute checking task for a workspace
 // This should be called when a workspace is created or during migration
 func EnsureSegmentRecomputeTask(ctx context.Context, taskRepo domain.TaskRepository, workspaceID string) error {
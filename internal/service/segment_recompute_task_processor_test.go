@@ -99,6 +99,14 @@ func TestSegmentRecomputeTaskProcessor_Process(t *testing.T) {
 			Return(nil).
 			Times(2) // Two segments
 
+		// Daily history is snapshotted for the current UTC day
+		mockSegmentRepo.EXPECT().
+			SnapshotSegmentHistory(ctx, "workspace1", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, at time.Time) (int, error) {
+				assert.Equal(t, now.Format(domain.SegmentHistoryDateFormat), at.Format(domain.SegmentHistoryDateFormat))
+				return 2, nil
+			})
+
 		completed, err := processor.Process(ctx, task, timeoutAt)
 		assert.NoError(t, err)
 		assert.False(t, completed) // Task should remain recurring
@@ -121,6 +129,10 @@ func TestSegmentRecomputeTaskProcessor_Process(t *testing.T) {
 			GetSegmentsDueForRecompute(ctx, "workspace1", 100).
 			Return([]*domain.Segment{}, nil)
 
+		mockSegmentRepo.EXPECT().
+			SnapshotSegmentHistory(ctx, "workspace1", gomock.Any()).
+			Return(0, nil)
+
 		completed, err := processor.Process(ctx, task, timeoutAt)
 		assert.NoError(t, err)
 		assert.False(t, completed) // Task should remain recurring
@@ -148,6 +160,31 @@ func TestSegmentRecomputeTaskProcessor_Process(t *testing.T) {
 		assert.False(t, completed)
 	})
 
+	t.Run("with history snapshot error", func(t *testing.T) {
+		ctx := context.Background()
+		now := time.Now().UTC()
+		timeoutAt := now.Add(1 * time.Minute)
+
+		task := &domain.Task{
+			ID:          "task1",
+			WorkspaceID: "workspace1",
+			Type:        "check_segment_recompute",
+			Status:      domain.TaskStatusPending,
+		}
+
+		mockSegmentRepo.EXPECT().
+			GetSegmentsDueForRecompute(ctx, "workspace1", 100).
+			Return([]*domain.Segment{}, nil)
+
+		mockSegmentRepo.EXPECT().
+			SnapshotSegmentHistory(ctx, "workspace1", gomock.Any()).
+			Return(0, assert.AnError)
+
+		completed, err := processor.Process(ctx, task, timeoutAt)
+		assert.NoError(t, err) // Snapshot errors don't fail the recurring task
+		assert.False(t, completed)
+	})
+
 	t.Run("with task creation error", func(t *testing.T) {
 		ctx := context.Background()
 		now := time.Now().UTC()
@@ -177,6 +214,10 @@ func TestSegmentRecomputeTaskProcessor_Process(t *testing.T) {
 			CreateTask(ctx, "workspace1", gomock.Any()).
 			Return(assert.AnError)
 
+		mockSegmentRepo.EXPECT().
+			SnapshotSegmentHistory(ctx, "workspace1", gomock.Any()).
+			Return(0, nil)
+
 		completed, err := processor.Process(ctx, task, timeoutAt)
 		assert.NoError(t, err) // Task creation errors don't fail the recurring task
 		assert.False(t, completed)
@@ -502,6 +502,26 @@ func (s *SegmentService) GetSegmentContacts(ctx context.Context, workspaceID, se
 	return emails, nil
 }
 
+// GetSegmentHistory retrieves the daily size and churn snapshots of a segment
+func (s *SegmentService) GetSegmentHistory(ctx context.Context, req *domain.GetSegmentHistoryRequest) ([]*domain.SegmentHistoryEntry, error) {
+	from, to, err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure the segment exists so unknown IDs surface as not found rather than an empty history
+	if _, err := s.segmentRepo.GetSegmentByID(ctx, req.WorkspaceID, req.SegmentID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.segmentRepo.GetSegmentHistory(ctx, req.WorkspaceID, req.SegmentID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get segment history: %w", err)
+	}
+
+	return history, nil
+}
+
 // calculateNext5AMInTimezone calculates the next occurrence of 5:00 AM in the given timezone
 // and returns it as a UTC time
 func calculateNext5AMInTimezone(tz string) (time.Time, error) {
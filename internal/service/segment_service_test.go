@@ -999,6 +999,82 @@ func TestSegmentService_GetSegmentContacts(t *testing.T) {
 	})
 }
 
+func TestSegmentService_GetSegmentHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSegmentRepository(ctrl)
+	mockTaskService := mocks.NewMockTaskService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	service := NewSegmentService(mockRepo, mockWorkspaceRepo, mockTaskService, mockLogger)
+	ctx := context.Background()
+
+	t.Run("successful get", func(t *testing.T) {
+		req := &domain.GetSegmentHistoryRequest{
+			WorkspaceID: "workspace123",
+			SegmentID:   "segment1",
+			From:        "2026-01-01",
+			To:          "2026-01-31",
+		}
+
+		expected := []*domain.SegmentHistoryEntry{
+			{SegmentID: "segment1", Day: "2026-01-01", UsersCount: 10, JoinedCount: 3, LeftCount: 1},
+		}
+
+		mockRepo.EXPECT().GetSegmentByID(ctx, "workspace123", "segment1").Return(&domain.Segment{ID: "segment1"}, nil)
+		mockRepo.EXPECT().
+			GetSegmentHistory(ctx, "workspace123", "segment1", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, from, to time.Time) ([]*domain.SegmentHistoryEntry, error) {
+				assert.Equal(t, "2026-01-01", from.Format(domain.SegmentHistoryDateFormat))
+				assert.Equal(t, "2026-01-31", to.Format(domain.SegmentHistoryDateFormat))
+				return expected, nil
+			})
+
+		history, err := service.GetSegmentHistory(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, expected, history)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		history, err := service.GetSegmentHistory(ctx, &domain.GetSegmentHistoryRequest{WorkspaceID: "workspace123"})
+		assert.Error(t, err)
+		assert.Nil(t, history)
+		assert.Contains(t, err.Error(), "segment_id is required")
+	})
+
+	t.Run("segment not found", func(t *testing.T) {
+		mockRepo.EXPECT().GetSegmentByID(ctx, "workspace123", "nonexistent").Return(
+			nil,
+			&domain.ErrSegmentNotFound{Message: "not found"},
+		)
+
+		history, err := service.GetSegmentHistory(ctx, &domain.GetSegmentHistoryRequest{
+			WorkspaceID: "workspace123",
+			SegmentID:   "nonexistent",
+		})
+		assert.Nil(t, history)
+		_, ok := err.(*domain.ErrSegmentNotFound)
+		assert.True(t, ok)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo.EXPECT().GetSegmentByID(ctx, "workspace123", "segment1").Return(&domain.Segment{ID: "segment1"}, nil)
+		mockRepo.EXPECT().
+			GetSegmentHistory(ctx, "workspace123", "segment1", gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("db error"))
+
+		history, err := service.GetSegmentHistory(ctx, &domain.GetSegmentHistoryRequest{
+			WorkspaceID: "workspace123",
+			SegmentID:   "segment1",
+		})
+		assert.Error(t, err)
+		assert.Nil(t, history)
+		assert.Contains(t, err.Error(), "failed to get segment history")
+	})
+}
+
 func TestNewSegmentService(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,430 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/cache"
+	"github.com/Notifuse/notifuse/pkg/captcha"
+	"github.com/Notifuse/notifuse/pkg/disposable_emails"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// signupFormChallengeTTL is how long a proof-of-work challenge can be submitted after it was issued
+const signupFormChallengeTTL = 10 * time.Minute
+
+type SignupFormService struct {
+	repo          domain.SignupFormRepository
+	listRepo      domain.ListRepository
+	workspaceRepo domain.WorkspaceRepository
+	listService   domain.ListService
+	authService   domain.AuthService
+	verifier      captcha.Verifier
+	replayCache   cache.Cache // solved proof-of-work challenges, until they expire
+	logger        logger.Logger
+	secretKey     string
+}
+
+func NewSignupFormService(
+	repo domain.SignupFormRepository,
+	listRepo domain.ListRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	listService domain.ListService,
+	authService domain.AuthService,
+	verifier captcha.Verifier,
+	replayCache cache.Cache,
+	logger logger.Logger,
+	secretKey string,
+) *SignupFormService {
+	return &SignupFormService{
+		repo:          repo,
+		listRepo:      listRepo,
+		workspaceRepo: workspaceRepo,
+		listService:   listService,
+		authService:   authService,
+		verifier:      verifier,
+		replayCache:   replayCache,
+		logger:        logger,
+		secretKey:     secretKey,
+	}
+}
+
+// authorize authenticates the user and checks their access to lists, forms share the lists permission
+func (s *SignupFormService) authorize(ctx context.Context, workspaceID string, permission domain.PermissionType) (context.Context, error) {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceLists, permission) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceLists,
+			permission,
+			fmt.Sprintf("Insufficient permissions: %s access to lists required", permission),
+		)
+	}
+	return ctx, nil
+}
+
+// prepareForm validates the form against the workspace and encrypts its captcha secret
+func (s *SignupFormService) prepareForm(ctx context.Context, workspaceID string, form *domain.SignupForm) error {
+	if err := form.Validate(); err != nil {
+		return domain.NewValidationError(err.Error())
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+	if err := form.ValidateFields(workspace.Settings.ContactAttributes); err != nil {
+		return domain.NewValidationError(err.Error())
+	}
+
+	// anonymous visitors can only subscribe to public lists
+	lists, err := s.listRepo.GetLists(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get lists: %w", err)
+	}
+	for _, listID := range form.ListIDs {
+		var list *domain.List
+		for _, l := range lists {
+			if l.ID == listID {
+				list = l
+				break
+			}
+		}
+		if list == nil {
+			return domain.NewValidationError(fmt.Sprintf("list %s not found", listID))
+		}
+		if !list.IsPublic {
+			return domain.NewValidationError(fmt.Sprintf("list %s is not public", listID))
+		}
+	}
+
+	form.ComputeConsentTextVersion()
+
+	if err := form.Captcha.EncryptSecretKey(s.secretKey); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *SignupFormService) CreateSignupForm(ctx context.Context, workspaceID string, form *domain.SignupForm) error {
+	ctx, err := s.authorize(ctx, workspaceID, domain.PermissionTypeWrite)
+	if err != nil {
+		return err
+	}
+
+	if err := s.prepareForm(ctx, workspaceID, form); err != nil {
+		return err
+	}
+
+	if err := s.repo.CreateSignupForm(ctx, workspaceID, form); err != nil {
+		s.logger.WithField("form_id", form.ID).Error(fmt.Sprintf("Failed to create signup form: %v", err))
+		return fmt.Errorf("failed to create signup form: %w", err)
+	}
+	return nil
+}
+
+func (s *SignupFormService) GetSignupForm(ctx context.Context, workspaceID string, id string) (*domain.SignupForm, error) {
+	ctx, err := s.authorize(ctx, workspaceID, domain.PermissionTypeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	form, err := s.repo.GetSignupFormByID(ctx, workspaceID, id)
+	if err != nil {
+		if _, ok := err.(*domain.ErrSignupFormNotFound); ok {
+			return nil, err
+		}
+		s.logger.WithField("form_id", id).Error(fmt.Sprintf("Failed to get signup form: %v", err))
+		return nil, fmt.Errorf("failed to get signup form: %w", err)
+	}
+	return form, nil
+}
+
+func (s *SignupFormService) ListSignupForms(ctx context.Context, workspaceID string) ([]*domain.SignupForm, error) {
+	ctx, err := s.authorize(ctx, workspaceID, domain.PermissionTypeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	forms, err := s.repo.GetSignupForms(ctx, workspaceID)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to get signup forms: %v", err))
+		return nil, fmt.Errorf("failed to get signup forms: %w", err)
+	}
+	return forms, nil
+}
+
+func (s *SignupFormService) UpdateSignupForm(ctx context.Context, workspaceID string, form *domain.SignupForm) error {
+	ctx, err := s.authorize(ctx, workspaceID, domain.PermissionTypeWrite)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.repo.GetSignupFormByID(ctx, workspaceID, form.ID)
+	if err != nil {
+		return err
+	}
+
+	// keep the stored captcha secret unless a new one is provided
+	if form.Captcha.SecretKey == "" && form.Captcha.EncryptedSecretKey == "" && form.Captcha.Provider == existing.Captcha.Provider {
+		form.Captcha.EncryptedSecretKey = existing.Captcha.EncryptedSecretKey
+	}
+
+	if err := s.prepareForm(ctx, workspaceID, form); err != nil {
+		return err
+	}
+
+	form.CreatedAt = existing.CreatedAt
+	if err := s.repo.UpdateSignupForm(ctx, workspaceID, form); err != nil {
+		s.logger.WithField("form_id", form.ID).Error(fmt.Sprintf("Failed to update signup form: %v", err))
+		return fmt.Errorf("failed to update signup form: %w", err)
+	}
+	return nil
+}
+
+func (s *SignupFormService) DeleteSignupForm(ctx context.Context, workspaceID string, id string) error {
+	ctx, err := s.authorize(ctx, workspaceID, domain.PermissionTypeWrite)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteSignupForm(ctx, workspaceID, id); err != nil {
+		if _, ok := err.(*domain.ErrSignupFormNotFound); ok {
+			return err
+		}
+		s.logger.WithField("form_id", id).Error(fmt.Sprintf("Failed to delete signup form: %v", err))
+		return fmt.Errorf("failed to delete signup form: %w", err)
+	}
+	return nil
+}
+
+func (s *SignupFormService) GetSignupFormStats(ctx context.Context, req *domain.GetSignupFormStatsRequest) (*domain.SignupFormStatsResponse, error) {
+	from, to, err := req.Validate()
+	if err != nil {
+		return nil, domain.NewValidationError(err.Error())
+	}
+
+	ctx, err = s.authorize(ctx, req.WorkspaceID, domain.PermissionTypeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetSignupFormByID(ctx, req.WorkspaceID, req.ID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repo.GetSignupFormStats(ctx, req.WorkspaceID, req.ID, from, to)
+	if err != nil {
+		s.logger.WithField("form_id", req.ID).Error(fmt.Sprintf("Failed to get signup form stats: %v", err))
+		return nil, fmt.Errorf("failed to get signup form stats: %w", err)
+	}
+
+	// return every day of the range, days without activity have no row
+	byDay := make(map[string]*domain.SignupFormStatsEntry, len(entries))
+	for _, entry := range entries {
+		byDay[entry.Day] = entry
+	}
+
+	response := &domain.SignupFormStatsResponse{
+		Days:   []*domain.SignupFormStatsEntry{},
+		Totals: &domain.SignupFormStatsEntry{},
+	}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format(domain.SegmentHistoryDateFormat)
+		entry, ok := byDay[key]
+		if !ok {
+			entry = &domain.SignupFormStatsEntry{Day: key}
+		}
+		response.Days = append(response.Days, entry)
+		response.Totals.Add(entry)
+	}
+	if response.Totals.Views > 0 {
+		response.ConversionRate = float64(response.Totals.Subscribed) / float64(response.Totals.Views)
+	}
+
+	return response, nil
+}
+
+// getEnabledForm loads a form for a public request, disabled and deleted forms are not found
+func (s *SignupFormService) getEnabledForm(ctx context.Context, workspaceID string, id string) (*domain.Workspace, *domain.SignupForm, error) {
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		var notFound *domain.ErrWorkspaceNotFound
+		if errors.As(err, &notFound) {
+			return nil, nil, &domain.ErrSignupFormNotFound{Message: "signup form not found"}
+		}
+		return nil, nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	form, err := s.repo.GetSignupFormByID(ctx, workspaceID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !form.Enabled {
+		return nil, nil, &domain.ErrSignupFormNotFound{Message: "signup form not found"}
+	}
+	return workspace, form, nil
+}
+
+// recordStats increments the form counters, failures are logged and never fail the request
+func (s *SignupFormService) recordStats(ctx context.Context, workspaceID string, formID string, at time.Time, metrics ...domain.SignupFormMetric) {
+	if err := s.repo.IncrementSignupFormStats(ctx, workspaceID, formID, at, metrics...); err != nil {
+		s.logger.WithField("form_id", formID).Warn(fmt.Sprintf("Failed to record signup form stats: %v", err))
+	}
+}
+
+func (s *SignupFormService) GetPublicSignupForm(ctx context.Context, workspaceID string, id string, countView bool) (*domain.SignupFormPublicConfig, error) {
+	workspace, form, err := s.getEnabledForm(ctx, workspaceID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if countView {
+		s.recordStats(ctx, workspaceID, id, time.Now().UTC(), domain.SignupFormMetricViews)
+	}
+
+	return form.PublicConfig(workspace.ID, workspace.Settings.ContactAttributes), nil
+}
+
+// challengeScope binds proof-of-work challenges to a single form
+func challengeScope(workspaceID string, formID string) string {
+	return "signup_form:" + workspaceID + ":" + formID
+}
+
+func (s *SignupFormService) CreateSignupFormChallenge(ctx context.Context, workspaceID string, id string) (*domain.SignupFormChallenge, error) {
+	workspace, form, err := s.getEnabledForm(ctx, workspaceID, id)
+	if err != nil {
+		return nil, err
+	}
+	if form.Captcha.Provider != domain.SignupFormCaptchaProofOfWork {
+		return nil, domain.NewValidationError("signup form does not use proof of work")
+	}
+
+	difficulty := form.Captcha.GetDifficulty()
+	challenge, expiresAt, err := captcha.NewChallenge(workspace.Settings.SecretKey, challengeScope(workspaceID, id), difficulty, signupFormChallengeTTL, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create challenge: %w", err)
+	}
+
+	return &domain.SignupFormChallenge{
+		Challenge:  challenge,
+		Difficulty: difficulty,
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+// verifyCaptcha checks the proof of work or the third-party captcha token of a submission
+func (s *SignupFormService) verifyCaptcha(ctx context.Context, workspace *domain.Workspace, form *domain.SignupForm, submission *domain.SignupFormSubmission, remoteIP string) error {
+	switch {
+	case form.Captcha.Provider == domain.SignupFormCaptchaProofOfWork:
+		now := time.Now()
+		expiresAt, err := captcha.VerifySolution(workspace.Settings.SecretKey, challengeScope(workspace.ID, form.ID), submission.Challenge, submission.Nonce, now)
+		if err != nil {
+			return err
+		}
+		// a solved challenge can only be used once
+		replayed := true
+		_, _ = s.replayCache.GetOrSet("signup_form_pow:"+submission.Challenge, expiresAt.Sub(now)+time.Second, func() (interface{}, error) {
+			replayed = false
+			return true, nil
+		})
+		if replayed {
+			return fmt.Errorf("proof of work already used")
+		}
+		return nil
+
+	case form.Captcha.IsThirdParty():
+		settings := form.Captcha
+		if err := settings.DecryptSecretKey(s.secretKey); err != nil {
+			return err
+		}
+		return s.verifier.Verify(ctx, settings.Provider, settings.SecretKey, submission.CaptchaToken, remoteIP)
+	}
+	return nil
+}
+
+func (s *SignupFormService) successResult(form *domain.SignupForm) *domain.SignupFormSubmitResult {
+	result := &domain.SignupFormSubmitResult{
+		Message:     form.SuccessMessage,
+		RedirectURL: form.SuccessRedirectURL,
+	}
+	if result.Message == "" {
+		result.Message = "Thanks for subscribing!"
+	}
+	return result
+}
+
+func (s *SignupFormService) SubmitSignupForm(ctx context.Context, workspaceID string, id string, submission *domain.SignupFormSubmission) (*domain.SignupFormSubmitResult, error) {
+	workspace, form, err := s.getEnabledForm(ctx, workspaceID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	consent := domain.ConsentContextFromContext(ctx)
+
+	reject := func(reason domain.SignupFormMetric, message string) error {
+		s.recordStats(ctx, workspaceID, id, now, domain.SignupFormMetricSubmitted, reason)
+		return &domain.SignupFormRejectedError{Reason: reason, Message: message}
+	}
+
+	// bots filling the honeypot get a success response so they don't adapt
+	if submission.Honeypot != "" {
+		s.recordStats(ctx, workspaceID, id, now, domain.SignupFormMetricSubmitted, domain.SignupFormMetricSpamBlocked)
+		return s.successResult(form), nil
+	}
+
+	if err := s.verifyCaptcha(ctx, workspace, form, submission, consent.IPAddress); err != nil {
+		s.logger.WithField("form_id", id).WithField("ip", consent.IPAddress).Debug(fmt.Sprintf("Signup form captcha rejected: %v", err))
+		return nil, reject(domain.SignupFormMetricSpamBlocked, "Captcha verification failed, please try again")
+	}
+
+	contact, err := submission.BuildContact(form, workspace.Settings.ContactAttributes)
+	if err != nil {
+		return nil, reject(domain.SignupFormMetricInvalid, err.Error())
+	}
+
+	// the disposable list is keyed by domain, the email is already normalized and validated
+	if disposable_emails.IsDisposableEmail(contact.Email[strings.LastIndex(contact.Email, "@")+1:]) {
+		return nil, reject(domain.SignupFormMetricDisposableBlocked, "Disposable email addresses are not allowed")
+	}
+
+	payload := &domain.SubscribeToListsRequest{
+		WorkspaceID:        workspaceID,
+		Contact:            *contact,
+		ListIDs:            form.ListIDs,
+		PageURL:            submission.PageURL,
+		ConsentTextVersion: form.ConsentTextVersion,
+	}
+	if err := payload.Validate(); err != nil {
+		return nil, reject(domain.SignupFormMetricInvalid, err.Error())
+	}
+
+	consent.Source = domain.ConsentSourceForm
+	consent.SourceID = form.ID
+	if err := s.listService.SubscribeToLists(domain.WithConsentContext(ctx, consent), payload, false); err != nil {
+		if strings.Contains(err.Error(), "invalid contact attributes") {
+			return nil, reject(domain.SignupFormMetricInvalid, strings.TrimPrefix(err.Error(), "invalid contact attributes: "))
+		}
+		var blocked *domain.ErrEmailVerificationBlocked
+		if errors.As(err, &blocked) {
+			message := "This email address does not appear to be valid"
+			if blocked.Verification.Suggestion != "" {
+				message += fmt.Sprintf(", did you mean %s?", blocked.Verification.Suggestion)
+			}
+			return nil, reject(domain.SignupFormMetricInvalid, message)
+		}
+		s.logger.WithField("form_id", id).WithField("email", contact.Email).Error(fmt.Sprintf("Failed to subscribe signup form contact: %v", err))
+		return nil, fmt.Errorf("failed to subscribe to lists: %w", err)
+	}
+
+	s.recordStats(ctx, workspaceID, id, now, domain.SignupFormMetricSubmitted, domain.SignupFormMetricSubscribed)
+	return s.successResult(form), nil
+}
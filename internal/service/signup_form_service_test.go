@@ -0,0 +1,393 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/cache"
+	"github.com/Notifuse/notifuse/pkg/captcha"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+type fakeCaptchaVerifier struct {
+	err      error
+	provider string
+	secret   string
+	token    string
+	remoteIP string
+}
+
+func (v *fakeCaptchaVerifier) Verify(ctx context.Context, provider string, secret string, token string, remoteIP string) error {
+	v.provider, v.secret, v.token, v.remoteIP = provider, secret, token, remoteIP
+	return v.err
+}
+
+type signupFormServiceTest struct {
+	service       *SignupFormService
+	repo          *mocks.MockSignupFormRepository
+	listRepo      *mocks.MockListRepository
+	workspaceRepo *mocks.MockWorkspaceRepository
+	listService   *mocks.MockListService
+	authService   *mocks.MockAuthService
+	verifier      *fakeCaptchaVerifier
+}
+
+const signupFormTestSecretKey = "test-secret-key"
+
+func setupSignupFormServiceTest(t *testing.T) *signupFormServiceTest {
+	ctrl := gomock.NewController(t)
+	replayCache := cache.NewInMemoryCache(time.Minute)
+	t.Cleanup(replayCache.Stop)
+
+	st := &signupFormServiceTest{
+		repo:          mocks.NewMockSignupFormRepository(ctrl),
+		listRepo:      mocks.NewMockListRepository(ctrl),
+		workspaceRepo: mocks.NewMockWorkspaceRepository(ctrl),
+		listService:   mocks.NewMockListService(ctrl),
+		authService:   mocks.NewMockAuthService(ctrl),
+		verifier:      &fakeCaptchaVerifier{},
+	}
+	st.service = NewSignupFormService(st.repo, st.listRepo, st.workspaceRepo, st.listService, st.authService,
+		st.verifier, replayCache, logger.NewLogger(), signupFormTestSecretKey)
+	return st
+}
+
+func signupFormTestWorkspace() *domain.Workspace {
+	return &domain.Workspace{
+		ID: "ws1",
+		Settings: domain.WorkspaceSettings{
+			SecretKey:         "workspace-secret",
+			ContactAttributes: []domain.ContactAttribute{{Key: "plan", Type: "string"}},
+		},
+	}
+}
+
+func signupFormTestForm() *domain.SignupForm {
+	return &domain.SignupForm{
+		ID:          "form1",
+		Name:        "Newsletter",
+		ListIDs:     []string{"news"},
+		Fields:      domain.SignupFormFields{{Key: "first_name", Label: "First name"}, {Key: "attributes.plan", Label: "Plan"}},
+		ConsentText: "I agree",
+		Enabled:     true,
+	}
+}
+
+func signupFormTestUserWorkspace(read, write bool) *domain.UserWorkspace {
+	return &domain.UserWorkspace{
+		UserID:      "user1",
+		WorkspaceID: "ws1",
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceLists: {Read: read, Write: write},
+		},
+	}
+}
+
+func TestSignupFormService_CreateSignupForm(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		st := setupSignupFormServiceTest(t)
+		form := signupFormTestForm()
+		form.Captcha = domain.SignupFormCaptcha{Provider: domain.SignupFormCaptchaTurnstile, SiteKey: "site", SecretKey: "secret"}
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, signupFormTestUserWorkspace(true, true), nil)
+		st.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(signupFormTestWorkspace(), nil)
+		st.listRepo.EXPECT().GetLists(ctx, "ws1").Return([]*domain.List{{ID: "news", IsPublic: true}}, nil)
+		st.repo.EXPECT().CreateSignupForm(ctx, "ws1", form).Return(nil)
+
+		require.NoError(t, st.service.CreateSignupForm(ctx, "ws1", form))
+		assert.NotEmpty(t, form.ConsentTextVersion)
+		assert.NotEmpty(t, form.Captcha.EncryptedSecretKey)
+		assert.Empty(t, form.Captcha.SecretKey)
+	})
+
+	t.Run("private list is rejected", func(t *testing.T) {
+		st := setupSignupFormServiceTest(t)
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, signupFormTestUserWorkspace(true, true), nil)
+		st.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(signupFormTestWorkspace(), nil)
+		st.listRepo.EXPECT().GetLists(ctx, "ws1").Return([]*domain.List{{ID: "news", IsPublic: false}}, nil)
+
+		err := st.service.CreateSignupForm(ctx, "ws1", signupFormTestForm())
+		var validationErr domain.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, err.Error(), "not public")
+	})
+
+	t.Run("unknown attribute is rejected", func(t *testing.T) {
+		st := setupSignupFormServiceTest(t)
+		form := signupFormTestForm()
+		form.Fields = append(form.Fields, domain.SignupFormField{Key: "attributes.unknown"})
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, signupFormTestUserWorkspace(true, true), nil)
+		st.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(signupFormTestWorkspace(), nil)
+
+		err := st.service.CreateSignupForm(ctx, "ws1", form)
+		var validationErr domain.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("insufficient permissions", func(t *testing.T) {
+		st := setupSignupFormServiceTest(t)
+
+		st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, signupFormTestUserWorkspace(true, false), nil)
+
+		err := st.service.CreateSignupForm(ctx, "ws1", signupFormTestForm())
+		var permErr *domain.PermissionError
+		require.ErrorAs(t, err, &permErr)
+	})
+}
+
+func TestSignupFormService_UpdateSignupForm(t *testing.T) {
+	ctx := context.Background()
+	st := setupSignupFormServiceTest(t)
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	existing := signupFormTestForm()
+	existing.CreatedAt = createdAt
+	existing.Captcha = domain.SignupFormCaptcha{Provider: domain.SignupFormCaptchaTurnstile, SiteKey: "site", SecretKey: "secret"}
+	require.NoError(t, existing.Captcha.EncryptSecretKey(signupFormTestSecretKey))
+
+	// the secret is not sent back by the console, the stored one is kept
+	form := signupFormTestForm()
+	form.Captcha = domain.SignupFormCaptcha{Provider: domain.SignupFormCaptchaTurnstile, SiteKey: "site"}
+
+	st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, signupFormTestUserWorkspace(true, true), nil)
+	st.repo.EXPECT().GetSignupFormByID(ctx, "ws1", "form1").Return(existing, nil)
+	st.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(signupFormTestWorkspace(), nil)
+	st.listRepo.EXPECT().GetLists(ctx, "ws1").Return([]*domain.List{{ID: "news", IsPublic: true}}, nil)
+	st.repo.EXPECT().UpdateSignupForm(ctx, "ws1", form).Return(nil)
+
+	require.NoError(t, st.service.UpdateSignupForm(ctx, "ws1", form))
+	assert.Equal(t, existing.Captcha.EncryptedSecretKey, form.Captcha.EncryptedSecretKey)
+	assert.Equal(t, createdAt, form.CreatedAt)
+}
+
+func TestSignupFormService_GetSignupFormStats(t *testing.T) {
+	ctx := context.Background()
+	st := setupSignupFormServiceTest(t)
+	req := &domain.GetSignupFormStatsRequest{WorkspaceID: "ws1", ID: "form1", From: "2026-03-01", To: "2026-03-03"}
+
+	st.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").Return(ctx, &domain.User{}, signupFormTestUserWorkspace(true, false), nil)
+	st.repo.EXPECT().GetSignupFormByID(ctx, "ws1", "form1").Return(signupFormTestForm(), nil)
+	st.repo.EXPECT().GetSignupFormStats(ctx, "ws1", "form1", gomock.Any(), gomock.Any()).Return([]*domain.SignupFormStatsEntry{
+		{Day: "2026-03-02", Views: 10, Submitted: 5, Subscribed: 4, SpamBlocked: 1},
+	}, nil)
+
+	stats, err := st.service.GetSignupFormStats(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, stats.Days, 3)
+	assert.Equal(t, "2026-03-01", stats.Days[0].Day)
+	assert.Equal(t, 10, stats.Days[1].Views)
+	assert.Equal(t, 4, stats.Totals.Subscribed)
+	assert.InDelta(t, 0.4, stats.ConversionRate, 0.0001)
+}
+
+func TestSignupFormService_GetPublicSignupForm(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("records a view", func(t *testing.T) {
+		st := setupSignupFormServiceTest(t)
+		st.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(signupFormTestWorkspace(), nil)
+		st.repo.EXPECT().GetSignupFormByID(ctx, "ws1", "form1").Return(signupFormTestForm(), nil)
+		st.repo.EXPECT().IncrementSignupFormStats(ctx, "ws1", "form1", gomock.Any(), domain.SignupFormMetricViews).Return(nil)
+
+		config, err := st.service.GetPublicSignupForm(ctx, "ws1", "form1", true)
+		require.NoError(t, err)
+		assert.Equal(t, "ws1", config.WorkspaceID)
+		assert.Len(t, config.Fields, 2)
+	})
+
+	t.Run("disabled form is not found", func(t *testing.T) {
+		st := setupSignupFormServiceTest(t)
+		form := signupFormTestForm()
+		form.Enabled = false
+		st.workspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(signupFormTestWorkspace(), nil)
+		st.repo.EXPECT().GetSignupFormByID(ctx, "ws1", "form1").Return(form, nil)
+
+		_, err := st.service.GetPublicSignupForm(ctx, "ws1", "form1", true)
+		var notFound *domain.ErrSignupFormNotFound
+		require.ErrorAs(t, err, &notFound)
+	})
+
+	t.Run("unknown workspace is not found", func(t *testing.T) {
+		st := setupSignupFormServiceTest(t)
+		st.workspaceRepo.EXPECT().GetByID(ctx, "nope").Return(nil, &domain.ErrWorkspaceNotFound{WorkspaceID: "nope"})
+
+		_, err := st.service.GetPublicSignupForm(ctx, "nope", "form1", false)
+		var notFound *domain.ErrSignupFormNotFound
+		require.ErrorAs(t, err, &notFound)
+	})
+}
+
+func TestSignupFormService_SubmitSignupForm(t *testing.T) {
+	consentCtx := domain.WithConsentContext(context.Background(), domain.ConsentContext{IPAddress: "203.0.113.7"})
+
+	validSubmission := func() *domain.SignupFormSubmission {
+		return &domain.SignupFormSubmission{
+			Email:   "jane@example.com",
+			Consent: true,
+			Fields:  map[string]string{"first_name": "Jane", "attributes.plan": "pro"},
+			PageURL: "https://example.com/pricing",
+		}
+	}
+
+	expectForm := func(st *signupFormServiceTest, form *domain.SignupForm) {
+		st.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(signupFormTestWorkspace(), nil)
+		st.repo.EXPECT().GetSignupFormByID(gomock.Any(), "ws1", "form1").Return(form, nil)
+	}
+
+	expectStats := func(st *signupFormServiceTest, metrics ...domain.SignupFormMetric) {
+		expected := make([]interface{}, 0, len(metrics))
+		for _, metric := range metrics {
+			expected = append(expected, metric)
+		}
+		st.repo.EXPECT().IncrementSignupFormStats(gomock.Any(), "ws1", "form1", gomock.Any(), expected...).Return(nil)
+	}
+
+	t.Run("subscribes the contact with form consent", func(t *testing.T) {
+		st := setupSignupFormServiceTest(t)
+		form := signupFormTestForm()
+		form.ComputeConsentTextVersion()
+		form.SuccessRedirectURL = "https://example.com/thanks"
+		expectForm(st, form)
+
+		st.listService.EXPECT().SubscribeToLists(gomock.Any(), gomock.Any(), false).
+			DoAndReturn(func(ctx context.Context, payload *domain.SubscribeToListsRequest, hasBearerToken bool) error {
+				consent := domain.ConsentContextFromContext(ctx)
+				assert.Equal(t, domain.ConsentSourceForm, consent.Source)
+				assert.Equal(t, "form1", consent.SourceID)
+				assert.Equal(t, "203.0.113.7", consent.IPAddress)
+				assert.Equal(t, []string{"news"}, payload.ListIDs)
+				assert.Equal(t, form.ConsentTextVersion, payload.ConsentTextVersion)
+				assert.Equal(t, "jane@example.com", payload.Contact.Email)
+				assert.Equal(t, "pro", payload.Contact.Attributes["plan"])
+				return nil
+			})
+		expectStats(st, domain.SignupFormMetricSubmitted, domain.SignupFormMetricSubscribed)
+
+		result, err := st.service.SubmitSignupForm(consentCtx, "ws1", "form1", validSubmission())
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/thanks", result.RedirectURL)
+	})
+
+	t.Run("honeypot pretends success", func(t *testing.T) {
+		st := setupSignupFormServiceTest(t)
+		expectForm(st, signupFormTestForm())
+		expectStats(st, domain.SignupFormMetricSubmitted, domain.SignupFormMetricSpamBlocked)
+
+		submission := validSubmission()
+		submission.Honeypot = "https://spam.example"
+		result, err := st.service.SubmitSignupForm(consentCtx, "ws1", "form1", submission)
+		require.NoError(t, err)
+		assert.Equal(t, "Thanks for subscribing!", result.Message)
+	})
+
+	t.Run("disposable email is rejected", func(t *testing.T) {
+		st := setupSignupFormServiceTest(t)
+		expectForm(st, signupFormTestForm())
+		expectStats(st, domain.SignupFormMetricSubmitted, domain.SignupFormMetricDisposableBlocked)
+
+		submission := validSubmission()
+		submission.Email = "bot@mailinator.com"
+		_, err := st.service.SubmitSignupForm(consentCtx, "ws1", "form1", submission)
+		var rejected *domain.SignupFormRejectedError
+		require.ErrorAs(t, err, &rejected)
+		assert.Equal(t, domain.SignupFormMetricDisposableBlocked, rejected.Reason)
+	})
+
+	t.Run("missing consent is invalid", func(t *testing.T) {
+		st := setupSignupFormServiceTest(t)
+		expectForm(st, signupFormTestForm())
+		expectStats(st, domain.SignupFormMetricSubmitted, domain.SignupFormMetricInvalid)
+
+		submission := validSubmission()
+		submission.Consent = false
+		_, err := st.service.SubmitSignupForm(consentCtx, "ws1", "form1", submission)
+		var rejected *domain.SignupFormRejectedError
+		require.ErrorAs(t, err, &rejected)
+		assert.Equal(t, domain.SignupFormMetricInvalid, rejected.Reason)
+	})
+
+	t.Run("failed email verification is invalid", func(t *testing.T) {
+		st := setupSignupFormServiceTest(t)
+		expectForm(st, signupFormTestForm())
+		verification := &domain.EmailVerification{Status: domain.EmailVerificationStatusRisky, Suggestion: "jane@gmail.com"}
+		st.listService.EXPECT().SubscribeToLists(gomock.Any(), gomock.Any(), false).
+			Return(&domain.ErrEmailVerificationBlocked{Email: "jane@gmial.com", Verification: verification})
+		expectStats(st, domain.SignupFormMetricSubmitted, domain.SignupFormMetricInvalid)
+
+		submission := validSubmission()
+		submission.Email = "jane@gmial.com"
+		_, err := st.service.SubmitSignupForm(consentCtx, "ws1", "form1", submission)
+		var rejected *domain.SignupFormRejectedError
+		require.ErrorAs(t, err, &rejected)
+		assert.Equal(t, domain.SignupFormMetricInvalid, rejected.Reason)
+		assert.Equal(t, "This email address does not appear to be valid, did you mean jane@gmail.com?", rejected.Message)
+	})
+
+	t.Run("third-party captcha", func(t *testing.T) {
+		st := setupSignupFormServiceTest(t)
+		form := signupFormTestForm()
+		form.Captcha = domain.SignupFormCaptcha{Provider: domain.SignupFormCaptchaHCaptcha, SiteKey: "site", SecretKey: "hcaptcha-secret"}
+		require.NoError(t, form.Captcha.EncryptSecretKey(signupFormTestSecretKey))
+		st.verifier.err = errors.New("invalid-input-response")
+		expectForm(st, form)
+		expectStats(st, domain.SignupFormMetricSubmitted, domain.SignupFormMetricSpamBlocked)
+
+		submission := validSubmission()
+		submission.CaptchaToken = "token"
+		_, err := st.service.SubmitSignupForm(consentCtx, "ws1", "form1", submission)
+		var rejected *domain.SignupFormRejectedError
+		require.ErrorAs(t, err, &rejected)
+		assert.Equal(t, domain.SignupFormMetricSpamBlocked, rejected.Reason)
+		assert.Equal(t, "hcaptcha-secret", st.verifier.secret)
+		assert.Equal(t, "token", st.verifier.token)
+		assert.Equal(t, "203.0.113.7", st.verifier.remoteIP)
+	})
+
+	t.Run("proof of work can only be used once", func(t *testing.T) {
+		st := setupSignupFormServiceTest(t)
+		form := signupFormTestForm()
+		form.Captcha = domain.SignupFormCaptcha{Provider: domain.SignupFormCaptchaProofOfWork, Difficulty: captcha.MinDifficulty}
+		st.workspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(signupFormTestWorkspace(), nil).Times(3)
+		st.repo.EXPECT().GetSignupFormByID(gomock.Any(), "ws1", "form1").Return(form, nil).Times(3)
+
+		challenge, err := st.service.CreateSignupFormChallenge(consentCtx, "ws1", "form1")
+		require.NoError(t, err)
+		assert.Equal(t, captcha.MinDifficulty, challenge.Difficulty)
+
+		submission := validSubmission()
+		submission.Challenge = challenge.Challenge
+		submission.Nonce = captcha.Solve(challenge.Challenge, challenge.Difficulty)
+
+		st.listService.EXPECT().SubscribeToLists(gomock.Any(), gomock.Any(), false).Return(nil)
+		expectStats(st, domain.SignupFormMetricSubmitted, domain.SignupFormMetricSubscribed)
+		_, err = st.service.SubmitSignupForm(consentCtx, "ws1", "form1", submission)
+		require.NoError(t, err)
+
+		expectStats(st, domain.SignupFormMetricSubmitted, domain.SignupFormMetricSpamBlocked)
+		_, err = st.service.SubmitSignupForm(consentCtx, "ws1", "form1", submission)
+		var rejected *domain.SignupFormRejectedError
+		require.ErrorAs(t, err, &rejected)
+	})
+
+	t.Run("challenge requires proof of work", func(t *testing.T) {
+		st := setupSignupFormServiceTest(t)
+		expectForm(st, signupFormTestForm())
+
+		_, err := st.service.CreateSignupFormChallenge(consentCtx, "ws1", "form1")
+		var validationErr domain.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+	})
+}
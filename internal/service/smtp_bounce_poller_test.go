@@ -58,6 +58,8 @@ func (m *mockIMAPClient) Close() error {
 
 // mockWebhookService implements InboundWebhookEventServiceInterface for testing
 type mockWebhookService struct {
+	// Embedded so methods the poller never calls need no stub
+	domain.InboundWebhookEventServiceInterface
 	mu       sync.Mutex
 	calls    []webhookCall
 	err      error
@@ -95,6 +97,8 @@ func (m *mockWebhookService) getCalls() []webhookCall {
 
 // mockBounceWorkspaceRepo implements domain.WorkspaceRepository for testing
 type mockBounceWorkspaceRepo struct {
+	// Embedded so methods the poller never calls need no stub
+	domain.WorkspaceRepository
 	workspaces []*domain.Workspace
 	listErr    error
 }
@@ -365,7 +365,11 @@ func (s *SupabaseService) ProcessUserCreatedHook(ctx context.Context, workspaceI
 				UpdatedAt: time.Now().UTC(),
 			}
 
-			err = s.contactListRepo.AddContactToList(systemCtx, workspaceID, contactList)
+			consentCtx := domain.WithConsentContext(systemCtx, domain.ConsentContext{
+				Source:   domain.ConsentSourceSupabase,
+				SourceID: integrationID,
+			})
+			err = s.contactListRepo.AddContactToList(consentCtx, workspaceID, contactList)
 			if err != nil {
 				s.logger.WithField("list_id", targetListID).WithField("error", err.Error()).Error("Failed to add contact to list")
 				// Continue with next list - contact was created successfully
@@ -117,6 +117,49 @@ func (s *SystemNotificationService) HandleCircuitBreakerEvent(ctx context.Contex
 	}
 }
 
+// HandleWebhookSubscriptionDisabledEvent notifies the workspace owners that a webhook
+// subscription was disabled after failing continuously
+func (s *SystemNotificationService) HandleWebhookSubscriptionDisabledEvent(ctx context.Context, payload domain.EventPayload) {
+	s.logger.WithFields(map[string]interface{}{
+		"event_type":   payload.Type,
+		"workspace_id": payload.WorkspaceID,
+		"entity_id":    payload.EntityID,
+	}).Info("Processing webhook subscription disabled event")
+
+	subscriptionName, _ := payload.Data["subscription_name"].(string)
+	if subscriptionName == "" {
+		subscriptionName = payload.EntityID
+	}
+	reason, _ := payload.Data["reason"].(string)
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, payload.WorkspaceID)
+	if err != nil || workspace == nil {
+		fields := map[string]interface{}{
+			"event_type":      payload.Type,
+			"workspace_id":    payload.WorkspaceID,
+			"subscription_id": payload.EntityID,
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		}
+		s.logger.WithFields(fields).Error("Failed to get workspace for webhook disabled notification")
+		return
+	}
+
+	err = s.notifyWorkspaceOwners(ctx, payload.WorkspaceID, func(owner *domain.UserWorkspaceWithEmail) error {
+		return s.mailer.SendWebhookDisabledAlert(owner.Email, workspace.Name, subscriptionName, reason, owner.Language)
+	})
+
+	if err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"event_type":      payload.Type,
+			"workspace_id":    payload.WorkspaceID,
+			"subscription_id": payload.EntityID,
+			"error":           err.Error(),
+		}).Error("Failed to send webhook disabled notifications to workspace owners")
+	}
+}
+
 // HandleBroadcastFailedEvent processes broadcast failure events (placeholder for future use)
 func (s *SystemNotificationService) HandleBroadcastFailedEvent(ctx context.Context, payload domain.EventPayload) {
 	s.logger.WithFields(map[string]interface{}{
@@ -198,6 +241,9 @@ func (s *SystemNotificationService) RegisterWithEventBus(eventBus domain.EventBu
 	// Register for broadcast failure events (for future use)
 	eventBus.Subscribe(domain.EventBroadcastFailed, s.HandleBroadcastFailedEvent)
 
+	// Register for webhook subscriptions disabled by the delivery worker
+	eventBus.Subscribe(domain.EventWebhookSubscriptionDisabled, s.HandleWebhookSubscriptionDisabledEvent)
+
 	// Future: Add more event subscriptions as needed
 	// eventBus.Subscribe(domain.EventSystemAlert, s.HandleSystemAlert)
 
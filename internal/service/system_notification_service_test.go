@@ -240,6 +240,54 @@ func TestSystemNotificationService_HandleCircuitBreakerEvent(t *testing.T) {
 	})
 }
 
+func TestSystemNotificationService_HandleWebhookSubscriptionDisabledEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockBroadcastRepo := mocks.NewMockBroadcastRepository(ctrl)
+	mockMailer := pkgmocks.NewMockMailer(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	service := NewSystemNotificationService(mockWorkspaceRepo, mockBroadcastRepo, mockMailer, mockLogger)
+
+	ctx := context.Background()
+	payload := domain.EventPayload{
+		Type:        domain.EventWebhookSubscriptionDisabled,
+		WorkspaceID: "workspace-123",
+		EntityID:    "sub-1",
+		Data: map[string]interface{}{
+			"subscription_name": "CRM sync",
+			"reason":            "HTTP 503",
+		},
+	}
+
+	t.Run("notifies the workspace owners", func(t *testing.T) {
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).Times(2)
+		mockLogger.EXPECT().Info("Processing webhook subscription disabled event")
+		mockLogger.EXPECT().Info("Notification sent successfully to workspace owner")
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace-123").Return(&domain.Workspace{ID: "workspace-123", Name: "Test Workspace"}, nil)
+		mockWorkspaceRepo.EXPECT().GetWorkspaceUsersWithEmail(ctx, "workspace-123").Return([]*domain.UserWorkspaceWithEmail{
+			{UserWorkspace: domain.UserWorkspace{UserID: "user-1", Role: "owner"}, Email: "owner@example.com", Language: "de"},
+			{UserWorkspace: domain.UserWorkspace{UserID: "user-2", Role: "member"}, Email: "member@example.com"},
+		}, nil)
+		mockMailer.EXPECT().SendWebhookDisabledAlert("owner@example.com", "Test Workspace", "CRM sync", "HTTP 503", "de").Return(nil)
+
+		service.HandleWebhookSubscriptionDisabledEvent(ctx, payload)
+	})
+
+	t.Run("workspace lookup error", func(t *testing.T) {
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).Times(2)
+		mockLogger.EXPECT().Info("Processing webhook subscription disabled event")
+		mockLogger.EXPECT().Error("Failed to get workspace for webhook disabled notification")
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace-123").Return(nil, errors.New("db error"))
+
+		service.HandleWebhookSubscriptionDisabledEvent(ctx, payload)
+	})
+}
+
 func TestSystemNotificationService_HandleBroadcastFailedEvent(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -510,6 +558,7 @@ func TestSystemNotificationService_RegisterWithEventBus(t *testing.T) {
 	// Expect subscriptions to be registered
 	mockEventBus.EXPECT().Subscribe(domain.EventBroadcastCircuitBreaker, gomock.Any())
 	mockEventBus.EXPECT().Subscribe(domain.EventBroadcastFailed, gomock.Any())
+	mockEventBus.EXPECT().Subscribe(domain.EventWebhookSubscriptionDisabled, gomock.Any())
 
 	mockLogger.EXPECT().Info("System notification service registered with event bus")
 
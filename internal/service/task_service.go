@@ -40,6 +40,9 @@ type TaskService struct {
 	// Wired from TaskScheduler.Enabled — an instance running its own scheduler executes its
 	// own tasks directly (no self-call); see SetDirectExecution.
 	directExecution bool
+	// webhookEvents is optional; when set, tasks failing for good are published as
+	// task.failed webhook events. Injected via SetWebhookEventPublisher.
+	webhookEvents domain.WebhookEventPublisher
 }
 
 // WithTransaction executes a function within a transaction
@@ -103,6 +106,13 @@ func (s *TaskService) IsDirectExecution() bool {
 	return s.directExecution
 }
 
+// SetWebhookEventPublisher injects the publisher of task.failed webhook events
+func (s *TaskService) SetWebhookEventPublisher(publisher domain.WebhookEventPublisher) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.webhookEvents = publisher
+}
+
 // RegisterProcessor registers a task processor for a specific task type
 func (s *TaskService) RegisterProcessor(processor domain.TaskProcessor) {
 	s.lock.Lock()
@@ -128,7 +138,10 @@ func getTaskTypes() []string {
 		"build_segment",
 		"process_contact_segment_queue",
 		"check_segment_recompute",
+		"compute_contact_properties",
 		"sync_integration",
+		"bulk_contact_operation",
+		"publish_blog_post",
 	}
 }
 
@@ -766,12 +779,39 @@ func (s *TaskService) ExecuteTask(ctx context.Context, workspace, taskID string,
 			}).Error("Failed to mark task as failed")
 			return fmt.Errorf("failed to mark task as failed: %w", markErr)
 		}
+
+		// MarkAsFailed reschedules the task until its retries are exhausted
+		if task.RetryCount >= task.MaxRetries {
+			s.publishTaskFailed(bgCtx, task, err)
+		}
 		return err
 	}
 
 	return nil
 }
 
+// publishTaskFailed publishes a task.failed webhook event, best effort
+func (s *TaskService) publishTaskFailed(ctx context.Context, task *domain.Task, taskErr error) {
+	s.lock.RLock()
+	publisher := s.webhookEvents
+	s.lock.RUnlock()
+	if publisher == nil {
+		return
+	}
+
+	payload := map[string]interface{}{
+		"task_id":     task.ID,
+		"type":        task.Type,
+		"retry_count": task.RetryCount + 1,
+		"max_retries": task.MaxRetries,
+		"error":       taskErr.Error(),
+	}
+	if task.BroadcastID != nil {
+		payload["broadcast_id"] = *task.BroadcastID
+	}
+	publisher.Publish(ctx, task.WorkspaceID, domain.WebhookEventTaskFailed, payload)
+}
+
 // GetLastCronRun retrieves the last cron execution timestamp
 func (s *TaskService) GetLastCronRun(ctx context.Context) (*time.Time, error) {
 	ctx, span := tracing.StartServiceSpan(ctx, "TaskService", "GetLastCronRun")
@@ -806,11 +806,26 @@ func TestTaskService_RegisterProcessor(t *testing.T) {
 			Return(false).
 			Times(1)
 
+		mockProcessor.EXPECT().
+			CanProcess("compute_contact_properties").
+			Return(false).
+			Times(1)
+
 		mockProcessor.EXPECT().
 			CanProcess("sync_integration").
 			Return(false).
 			Times(1)
 
+		mockProcessor.EXPECT().
+			CanProcess("bulk_contact_operation").
+			Return(false).
+			Times(1)
+
+		mockProcessor.EXPECT().
+			CanProcess("publish_blog_post").
+			Return(false).
+			Times(1)
+
 		// Register the processor
 		taskService.RegisterProcessor(mockProcessor)
 
@@ -2820,6 +2835,79 @@ func TestTaskService_ExecuteTask_RecurringReschedules(t *testing.T) {
 	})
 }
 
+func TestTaskService_ExecuteTask_PublishesTaskFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskRepository(ctrl)
+	mockSettingRepo := mocks.NewMockSettingRepository(ctrl)
+	mockPublisher := mocks.NewMockWebhookEventPublisher(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	taskService := NewTaskService(mockRepo, mockSettingRepo, mockLogger, nil, "http://localhost:8080")
+	taskService.SetAutoExecuteImmediate(false)
+	taskService.SetWebhookEventPublisher(mockPublisher)
+
+	mockProcessor := mocks.NewMockTaskProcessor(ctrl)
+	mockProcessor.EXPECT().CanProcess(gomock.Any()).DoAndReturn(func(taskType string) bool {
+		return taskType == "send_broadcast"
+	}).AnyTimes()
+	taskService.RegisterProcessor(mockProcessor)
+
+	mockRepo.EXPECT().
+		WithTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(*sql.Tx) error) error {
+			return fn(nil)
+		}).AnyTimes()
+
+	broadcastID := "broadcast-1"
+	tests := []struct {
+		name       string
+		retryCount int
+		publishes  bool
+	}{
+		{name: "retry scheduled", retryCount: 1, publishes: false},
+		{name: "retries exhausted", retryCount: 3, publishes: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &domain.Task{
+				ID:          "task-1",
+				WorkspaceID: "ws-1",
+				Type:        "send_broadcast",
+				Status:      domain.TaskStatusPending,
+				BroadcastID: &broadcastID,
+				MaxRuntime:  60,
+				RetryCount:  tt.retryCount,
+				MaxRetries:  3,
+			}
+
+			mockRepo.EXPECT().GetTx(gomock.Any(), gomock.Any(), "ws-1", "task-1").Return(task, nil)
+			mockRepo.EXPECT().MarkAsRunningTx(gomock.Any(), gomock.Any(), "ws-1", "task-1", gomock.Any()).Return(nil)
+			mockProcessor.EXPECT().Process(gomock.Any(), task, gomock.Any()).Return(false, errors.New("provider unavailable"))
+			mockRepo.EXPECT().MarkAsFailed(gomock.Any(), "ws-1", "task-1", gomock.Any()).Return(nil)
+			if tt.publishes {
+				mockPublisher.EXPECT().Publish(gomock.Any(), "ws-1", domain.WebhookEventTaskFailed, gomock.Any()).
+					Do(func(_ context.Context, _ string, _ string, payload map[string]interface{}) {
+						assert.Equal(t, "task-1", payload["task_id"])
+						assert.Equal(t, "send_broadcast", payload["type"])
+						assert.Equal(t, broadcastID, payload["broadcast_id"])
+						assert.Contains(t, payload["error"], "provider unavailable")
+					})
+			}
+
+			err := taskService.ExecuteTask(context.Background(), "ws-1", "task-1", time.Now().Add(60*time.Second))
+			assert.Error(t, err)
+		})
+	}
+}
+
 // Regression test for #320: an auth proxy (Cloudflare Access, oauth2-proxy,
 // etc.) sitting in front of /api/tasks.execute returns a 302 to its login
 // page. The Go default http.Client would follow as a GET to a 200 OK HTML
@@ -11,20 +11,26 @@ import (
 )
 
 type TemplateBlockService struct {
-	repo        domain.WorkspaceRepository
-	authService domain.AuthService
-	logger      logger.Logger
+	repo           domain.WorkspaceRepository
+	templateRepo   domain.TemplateRepository
+	automationRepo domain.AutomationRepository
+	authService    domain.AuthService
+	logger         logger.Logger
 }
 
 func NewTemplateBlockService(
 	repo domain.WorkspaceRepository,
+	templateRepo domain.TemplateRepository,
+	automationRepo domain.AutomationRepository,
 	authService domain.AuthService,
 	logger logger.Logger,
 ) *TemplateBlockService {
 	return &TemplateBlockService{
-		repo:        repo,
-		authService: authService,
-		logger:      logger,
+		repo:           repo,
+		templateRepo:   templateRepo,
+		automationRepo: automationRepo,
+		authService:    authService,
+		logger:         logger,
 	}
 }
 
@@ -60,6 +66,9 @@ func (s *TemplateBlockService) CreateTemplateBlock(ctx context.Context, workspac
 		block.Created = time.Now().UTC()
 	}
 	block.Updated = time.Now().UTC()
+	if block.Version <= 0 {
+		block.Version = 1
+	}
 
 	// Validate block
 	if block.Name == "" {
@@ -198,8 +207,10 @@ func (s *TemplateBlockService) UpdateTemplateBlock(ctx context.Context, workspac
 	found := false
 	for i := range workspace.Settings.TemplateBlocks {
 		if workspace.Settings.TemplateBlocks[i].ID == block.ID {
-			// Preserve Created timestamp
+			// Preserve Created timestamp and bump the version so synced
+			// references can tell which revision they render
 			block.Created = workspace.Settings.TemplateBlocks[i].Created
+			block.Version = workspace.Settings.TemplateBlocks[i].Version + 1
 			block.Updated = time.Now().UTC()
 			workspace.Settings.TemplateBlocks[i] = *block
 			found = true
@@ -261,6 +272,16 @@ func (s *TemplateBlockService) DeleteTemplateBlock(ctx context.Context, workspac
 		return &domain.ErrTemplateBlockNotFound{Message: fmt.Sprintf("template block with id %s not found", id)}
 	}
 
+	// Refuse to delete a block that templates still reference, as they would
+	// no longer compile
+	templates, err := s.findTemplatesUsingBlock(ctx, workspaceID, id)
+	if err != nil {
+		return err
+	}
+	if len(templates) > 0 {
+		return &domain.ErrTemplateBlockInUse{Message: fmt.Sprintf("template block %s is used by %d template(s)", id, len(templates))}
+	}
+
 	workspace.Settings.TemplateBlocks = newBlocks
 	workspace.UpdatedAt = time.Now().UTC()
 
@@ -272,3 +293,120 @@ func (s *TemplateBlockService) DeleteTemplateBlock(ctx context.Context, workspac
 
 	return nil
 }
+
+func (s *TemplateBlockService) GetTemplateBlockUsage(ctx context.Context, workspaceID string, id string) (*domain.TemplateBlockUsage, error) {
+	// Authenticate user for workspace
+	var err error
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Check permission for reading templates
+	if !userWorkspace.HasPermission(domain.PermissionResourceTemplates, domain.PermissionTypeRead) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceTemplates,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to templates required",
+		)
+	}
+
+	// Get the workspace
+	workspace, err := s.repo.GetByID(ctx, workspaceID)
+	if err != nil {
+		s.logger.WithField("workspace_id", workspaceID).WithField("error", err.Error()).Error("Failed to get workspace")
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	var block *domain.TemplateBlock
+	for i := range workspace.Settings.TemplateBlocks {
+		if workspace.Settings.TemplateBlocks[i].ID == id {
+			block = &workspace.Settings.TemplateBlocks[i]
+			break
+		}
+	}
+	if block == nil {
+		return nil, &domain.ErrTemplateBlockNotFound{Message: fmt.Sprintf("template block with id %s not found", id)}
+	}
+
+	templates, err := s.findTemplatesUsingBlock(ctx, workspaceID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &domain.TemplateBlockUsage{
+		BlockID:      block.ID,
+		BlockVersion: block.Version,
+		Templates:    make([]domain.TemplateBlockUsageTemplate, 0, len(templates)),
+		Automations:  []domain.TemplateBlockUsageAutomation{},
+	}
+
+	templateIDs := make(map[string]bool, len(templates))
+	for _, template := range templates {
+		templateIDs[template.ID] = true
+		usage.Templates = append(usage.Templates, domain.TemplateBlockUsageTemplate{
+			ID:       template.ID,
+			Name:     template.Name,
+			Category: template.Category,
+			Version:  template.Version,
+		})
+	}
+	usage.TemplatesCount = len(usage.Templates)
+
+	if len(templateIDs) == 0 {
+		return usage, nil
+	}
+
+	// Live automations sending any of these templates are affected as soon as the block changes
+	automations, _, err := s.automationRepo.List(ctx, workspaceID, domain.AutomationFilter{
+		Status: []domain.AutomationStatus{domain.AutomationStatusLive},
+	})
+	if err != nil {
+		s.logger.WithField("workspace_id", workspaceID).WithField("error", err.Error()).Error("Failed to list automations")
+		return nil, fmt.Errorf("failed to list automations: %w", err)
+	}
+
+	for _, automation := range automations {
+		var usedTemplateIDs []string
+		for _, node := range automation.Nodes {
+			if node == nil || node.Type != domain.NodeTypeEmail {
+				continue
+			}
+			templateID, _ := node.Config["template_id"].(string)
+			if templateIDs[templateID] {
+				usedTemplateIDs = append(usedTemplateIDs, templateID)
+			}
+		}
+		if len(usedTemplateIDs) > 0 {
+			usage.Automations = append(usage.Automations, domain.TemplateBlockUsageAutomation{
+				ID:          automation.ID,
+				Name:        automation.Name,
+				TemplateIDs: usedTemplateIDs,
+			})
+		}
+	}
+	usage.AutomationsCount = len(usage.Automations)
+
+	return usage, nil
+}
+
+// findTemplatesUsingBlock returns the latest version of every email template
+// that references the given block through a synced block node
+func (s *TemplateBlockService) findTemplatesUsingBlock(ctx context.Context, workspaceID string, blockID string) ([]*domain.Template, error) {
+	templates, err := s.templateRepo.GetTemplates(ctx, workspaceID, "", domain.ChannelEmail)
+	if err != nil {
+		s.logger.WithField("workspace_id", workspaceID).WithField("error", err.Error()).Error("Failed to list templates")
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	var result []*domain.Template
+	for _, template := range templates {
+		for _, id := range template.SyncedBlockIDs() {
+			if id == blockID {
+				result = append(result, template)
+				break
+			}
+		}
+	}
+	return result, nil
+}
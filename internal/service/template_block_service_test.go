@@ -37,8 +37,10 @@ func createTestTemplateBlock(id, name string) *domain.TemplateBlock {
 }
 
 // Setup function for template block service tests
-func setupTemplateBlockServiceTest(ctrl *gomock.Controller) (*service.TemplateBlockService, *domainmocks.MockWorkspaceRepository, *domainmocks.MockAuthService, *pkgmocks.MockLogger) {
+func setupTemplateBlockServiceTest(ctrl *gomock.Controller) (*service.TemplateBlockService, *domainmocks.MockWorkspaceRepository, *domainmocks.MockAuthService, *pkgmocks.MockLogger, *domainmocks.MockTemplateRepository, *domainmocks.MockAutomationRepository) {
 	mockRepo := domainmocks.NewMockWorkspaceRepository(ctrl)
+	mockTemplateRepo := domainmocks.NewMockTemplateRepository(ctrl)
+	mockAutomationRepo := domainmocks.NewMockAutomationRepository(ctrl)
 	mockAuthService := domainmocks.NewMockAuthService(ctrl)
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 
@@ -46,8 +48,8 @@ func setupTemplateBlockServiceTest(ctrl *gomock.Controller) (*service.TemplateBl
 	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
-	templateBlockService := service.NewTemplateBlockService(mockRepo, mockAuthService, mockLogger)
-	return templateBlockService, mockRepo, mockAuthService, mockLogger
+	templateBlockService := service.NewTemplateBlockService(mockRepo, mockTemplateRepo, mockAutomationRepo, mockAuthService, mockLogger)
+	return templateBlockService, mockRepo, mockAuthService, mockLogger, mockTemplateRepo, mockAutomationRepo
 }
 
 func TestTemplateBlockService_CreateTemplateBlock(t *testing.T) {
@@ -59,7 +61,7 @@ func TestTemplateBlockService_CreateTemplateBlock(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		block := createTestTemplateBlock("", blockName)
 
@@ -101,7 +103,7 @@ func TestTemplateBlockService_CreateTemplateBlock(t *testing.T) {
 	t.Run("Authentication Failure", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, _, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, _, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		block := createTestTemplateBlock("", blockName)
 		authErr := errors.New("auth error")
@@ -117,7 +119,7 @@ func TestTemplateBlockService_CreateTemplateBlock(t *testing.T) {
 	t.Run("Permission Denied", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, _, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, _, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		block := createTestTemplateBlock("", blockName)
 
@@ -149,7 +151,7 @@ func TestTemplateBlockService_CreateTemplateBlock(t *testing.T) {
 		mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 		mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
-		blockService := service.NewTemplateBlockService(mockRepo, mockAuthService, mockLogger)
+		blockService := service.NewTemplateBlockService(mockRepo, domainmocks.NewMockTemplateRepository(ctrl), domainmocks.NewMockAutomationRepository(ctrl), mockAuthService, mockLogger)
 
 		block := createTestTemplateBlock("", "")
 		block.Name = ""
@@ -182,7 +184,7 @@ func TestTemplateBlockService_CreateTemplateBlock(t *testing.T) {
 	t.Run("Duplicate ID", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		existingBlockID := uuid.New().String()
 		block := createTestTemplateBlock(existingBlockID, blockName)
@@ -217,7 +219,7 @@ func TestTemplateBlockService_CreateTemplateBlock(t *testing.T) {
 	t.Run("Repository Error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		block := createTestTemplateBlock("", blockName)
 
@@ -257,7 +259,7 @@ func TestTemplateBlockService_GetTemplateBlock(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		expectedBlock := createTestTemplateBlock(blockID, "Test Block")
 
@@ -293,7 +295,7 @@ func TestTemplateBlockService_GetTemplateBlock(t *testing.T) {
 	t.Run("Authentication Failure", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, _, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, _, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		authErr := errors.New("auth error")
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, nil, nil, authErr)
@@ -308,7 +310,7 @@ func TestTemplateBlockService_GetTemplateBlock(t *testing.T) {
 	t.Run("Permission Denied", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, _, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, _, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, &domain.UserWorkspace{
 			UserID:      userID,
@@ -330,7 +332,7 @@ func TestTemplateBlockService_GetTemplateBlock(t *testing.T) {
 	t.Run("Block Not Found", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, &domain.UserWorkspace{
 			UserID:      userID,
@@ -362,7 +364,7 @@ func TestTemplateBlockService_GetTemplateBlock(t *testing.T) {
 	t.Run("Repository Error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, &domain.UserWorkspace{
 			UserID:      userID,
@@ -391,7 +393,7 @@ func TestTemplateBlockService_ListTemplateBlocks(t *testing.T) {
 	t.Run("Success - Empty List", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, &domain.UserWorkspace{
 			UserID:      userID,
@@ -422,7 +424,7 @@ func TestTemplateBlockService_ListTemplateBlocks(t *testing.T) {
 	t.Run("Success - With Blocks", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		block1 := createTestTemplateBlock(uuid.New().String(), "Block 1")
 		block2 := createTestTemplateBlock(uuid.New().String(), "Block 2")
@@ -459,7 +461,7 @@ func TestTemplateBlockService_ListTemplateBlocks(t *testing.T) {
 	t.Run("Authentication Failure", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, _, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, _, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		authErr := errors.New("auth error")
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, nil, nil, authErr)
@@ -474,7 +476,7 @@ func TestTemplateBlockService_ListTemplateBlocks(t *testing.T) {
 	t.Run("Permission Denied", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, _, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, _, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, &domain.UserWorkspace{
 			UserID:      userID,
@@ -496,7 +498,7 @@ func TestTemplateBlockService_ListTemplateBlocks(t *testing.T) {
 	t.Run("Repository Error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, &domain.UserWorkspace{
 			UserID:      userID,
@@ -526,7 +528,7 @@ func TestTemplateBlockService_UpdateTemplateBlock(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		existingBlock := createTestTemplateBlock(blockID, "Old Name")
 		updatedBlock := createTestTemplateBlock(blockID, "New Name")
@@ -555,6 +557,7 @@ func TestTemplateBlockService_UpdateTemplateBlock(t *testing.T) {
 			require.Len(t, ws.Settings.TemplateBlocks, 1)
 			assert.Equal(t, "New Name", ws.Settings.TemplateBlocks[0].Name)
 			assert.Equal(t, existingBlock.Created, ws.Settings.TemplateBlocks[0].Created)
+			assert.Equal(t, existingBlock.Version+1, ws.Settings.TemplateBlocks[0].Version)
 			return nil
 		})
 
@@ -566,7 +569,7 @@ func TestTemplateBlockService_UpdateTemplateBlock(t *testing.T) {
 	t.Run("Authentication Failure", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, _, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, _, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		block := createTestTemplateBlock(blockID, "Updated Block")
 		authErr := errors.New("auth error")
@@ -582,7 +585,7 @@ func TestTemplateBlockService_UpdateTemplateBlock(t *testing.T) {
 	t.Run("Permission Denied", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, _, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, _, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		block := createTestTemplateBlock(blockID, "Updated Block")
 
@@ -605,7 +608,7 @@ func TestTemplateBlockService_UpdateTemplateBlock(t *testing.T) {
 	t.Run("Block Not Found", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		block := createTestTemplateBlock(blockID, "Updated Block")
 
@@ -638,7 +641,7 @@ func TestTemplateBlockService_UpdateTemplateBlock(t *testing.T) {
 	t.Run("Validation Failure", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		block := createTestTemplateBlock(blockID, "")
 
@@ -670,7 +673,7 @@ func TestTemplateBlockService_UpdateTemplateBlock(t *testing.T) {
 	t.Run("Repository Error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		existingBlock := createTestTemplateBlock(blockID, "Old Name")
 		updatedBlock := createTestTemplateBlock(blockID, "New Name")
@@ -713,7 +716,7 @@ func TestTemplateBlockService_DeleteTemplateBlock(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, mockTemplateRepo, _ := setupTemplateBlockServiceTest(ctrl)
 
 		block1 := createTestTemplateBlock(blockID, "Block 1")
 		block2 := createTestTemplateBlock(uuid.New().String(), "Block 2")
@@ -739,6 +742,7 @@ func TestTemplateBlockService_DeleteTemplateBlock(t *testing.T) {
 			},
 		}
 		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(existingWorkspace, nil)
+		mockTemplateRepo.EXPECT().GetTemplates(ctx, workspaceID, "", domain.ChannelEmail).Return([]*domain.Template{}, nil)
 		mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, ws *domain.Workspace) error {
 			assert.Len(t, ws.Settings.TemplateBlocks, 1)
 			assert.Equal(t, block2.ID, ws.Settings.TemplateBlocks[0].ID)
@@ -753,7 +757,7 @@ func TestTemplateBlockService_DeleteTemplateBlock(t *testing.T) {
 	t.Run("Authentication Failure", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, _, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, _, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		authErr := errors.New("auth error")
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, nil, nil, authErr)
@@ -767,7 +771,7 @@ func TestTemplateBlockService_DeleteTemplateBlock(t *testing.T) {
 	t.Run("Permission Denied", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, _, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, _, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, &domain.UserWorkspace{
 			UserID:      userID,
@@ -788,7 +792,7 @@ func TestTemplateBlockService_DeleteTemplateBlock(t *testing.T) {
 	t.Run("Block Not Found", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
 
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, &domain.UserWorkspace{
 			UserID:      userID,
@@ -819,7 +823,7 @@ func TestTemplateBlockService_DeleteTemplateBlock(t *testing.T) {
 	t.Run("Repository Error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
-		service, mockRepo, mockAuthService, _ := setupTemplateBlockServiceTest(ctrl)
+		service, mockRepo, mockAuthService, _, mockTemplateRepo, _ := setupTemplateBlockServiceTest(ctrl)
 
 		block := createTestTemplateBlock(blockID, "Block 1")
 
@@ -843,6 +847,7 @@ func TestTemplateBlockService_DeleteTemplateBlock(t *testing.T) {
 			},
 		}
 		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(existingWorkspace, nil)
+		mockTemplateRepo.EXPECT().GetTemplates(ctx, workspaceID, "", domain.ChannelEmail).Return([]*domain.Template{}, nil)
 		mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(errors.New("database error"))
 
 		err := service.DeleteTemplateBlock(ctx, workspaceID, blockID)
@@ -850,4 +855,170 @@ func TestTemplateBlockService_DeleteTemplateBlock(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to delete template block")
 	})
+
+	t.Run("Block In Use", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, mockRepo, mockAuthService, _, mockTemplateRepo, _ := setupTemplateBlockServiceTest(ctrl)
+
+		block := createTestTemplateBlock(blockID, "Footer")
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, &domain.UserWorkspace{
+			UserID:      userID,
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceTemplates: {Read: true, Write: true},
+			},
+		}, nil)
+
+		existingWorkspace := &domain.Workspace{
+			ID:   workspaceID,
+			Name: "Test Workspace",
+			Settings: domain.WorkspaceSettings{
+				Timezone:       "UTC",
+				TemplateBlocks: []domain.TemplateBlock{*block},
+			},
+		}
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(existingWorkspace, nil)
+		mockTemplateRepo.EXPECT().GetTemplates(ctx, workspaceID, "", domain.ChannelEmail).Return([]*domain.Template{
+			createTemplateWithSyncedBlock("welcome", blockID),
+		}, nil)
+
+		err := service.DeleteTemplateBlock(ctx, workspaceID, blockID)
+
+		require.Error(t, err)
+		var inUseErr *domain.ErrTemplateBlockInUse
+		assert.ErrorAs(t, err, &inUseErr)
+	})
+}
+
+// createTemplateWithSyncedBlock creates an email template whose body references the given block
+func createTemplateWithSyncedBlock(id string, blockID string) *domain.Template {
+	treeJSON := []byte(`{"id":"root","type":"mjml","children":[{"id":"body","type":"mj-body","children":[` +
+		`{"id":"ref","type":"mj-synced-block","attributes":{"blockId":"` + blockID + `"}}]}]}`)
+	tree, _ := notifuse_mjml.UnmarshalEmailBlock(treeJSON)
+	return &domain.Template{
+		ID:       id,
+		Name:     id,
+		Version:  3,
+		Channel:  domain.ChannelEmail,
+		Category: "marketing",
+		Email: &domain.EmailTemplate{
+			Subject:          "Hello",
+			VisualEditorTree: tree,
+		},
+	}
+}
+
+func TestTemplateBlockService_GetTemplateBlockUsage(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "ws-123"
+	userID := "user-456"
+	blockID := uuid.New().String()
+
+	readWorkspace := &domain.UserWorkspace{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceTemplates: {Read: true, Write: false},
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, mockRepo, mockAuthService, _, mockTemplateRepo, mockAutomationRepo := setupTemplateBlockServiceTest(ctrl)
+
+		block := createTestTemplateBlock(blockID, "Footer")
+		block.Version = 4
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, readWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(&domain.Workspace{
+			ID:       workspaceID,
+			Settings: domain.WorkspaceSettings{Timezone: "UTC", TemplateBlocks: []domain.TemplateBlock{*block}},
+		}, nil)
+		mockTemplateRepo.EXPECT().GetTemplates(ctx, workspaceID, "", domain.ChannelEmail).Return([]*domain.Template{
+			createTemplateWithSyncedBlock("welcome", blockID),
+			createTemplateWithSyncedBlock("other", "another-block"),
+		}, nil)
+		mockAutomationRepo.EXPECT().List(ctx, workspaceID, domain.AutomationFilter{
+			Status: []domain.AutomationStatus{domain.AutomationStatusLive},
+		}).Return([]*domain.Automation{
+			{ID: "a1", Name: "Onboarding", Nodes: []*domain.AutomationNode{
+				{ID: "n1", Type: domain.NodeTypeEmail, Config: map[string]interface{}{"template_id": "welcome"}},
+			}},
+			{ID: "a2", Name: "Winback", Nodes: []*domain.AutomationNode{
+				{ID: "n1", Type: domain.NodeTypeEmail, Config: map[string]interface{}{"template_id": "other"}},
+			}},
+		}, 2, nil)
+
+		usage, err := service.GetTemplateBlockUsage(ctx, workspaceID, blockID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, usage.BlockVersion)
+		assert.Equal(t, 1, usage.TemplatesCount)
+		assert.Equal(t, "welcome", usage.Templates[0].ID)
+		assert.Equal(t, int64(3), usage.Templates[0].Version)
+		assert.Equal(t, 1, usage.AutomationsCount)
+		assert.Equal(t, "a1", usage.Automations[0].ID)
+		assert.Equal(t, []string{"welcome"}, usage.Automations[0].TemplateIDs)
+	})
+
+	t.Run("Unused Block Skips Automations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, mockRepo, mockAuthService, _, mockTemplateRepo, _ := setupTemplateBlockServiceTest(ctrl)
+
+		block := createTestTemplateBlock(blockID, "Footer")
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, readWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(&domain.Workspace{
+			ID:       workspaceID,
+			Settings: domain.WorkspaceSettings{Timezone: "UTC", TemplateBlocks: []domain.TemplateBlock{*block}},
+		}, nil)
+		mockTemplateRepo.EXPECT().GetTemplates(ctx, workspaceID, "", domain.ChannelEmail).Return([]*domain.Template{}, nil)
+
+		usage, err := service.GetTemplateBlockUsage(ctx, workspaceID, blockID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, usage.TemplatesCount)
+		assert.Empty(t, usage.Automations)
+	})
+
+	t.Run("Block Not Found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, mockRepo, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, readWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(&domain.Workspace{
+			ID:       workspaceID,
+			Settings: domain.WorkspaceSettings{Timezone: "UTC"},
+		}, nil)
+
+		_, err := service.GetTemplateBlockUsage(ctx, workspaceID, blockID)
+
+		var notFoundErr *domain.ErrTemplateBlockNotFound
+		assert.ErrorAs(t, err, &notFoundErr)
+	})
+
+	t.Run("Permission Denied", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, _, mockAuthService, _, _, _ := setupTemplateBlockServiceTest(ctrl)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, &domain.UserWorkspace{
+			UserID:      userID,
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{},
+		}, nil)
+
+		_, err := service.GetTemplateBlockUsage(ctx, workspaceID, blockID)
+
+		var permErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permErr)
+	})
 }
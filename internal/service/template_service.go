@@ -433,6 +433,16 @@ func (s *TemplateService) CompileTemplate(ctx context.Context, payload domain.Co
 		payload.TrackingSettings.Endpoint = s.apiEndpoint
 	}
 
+	// Load the workspace template blocks when the tree references synced blocks
+	// and the caller did not supply them
+	if payload.SyncedBlocks == nil && notifuse_mjml.HasSyncedBlocks(payload.VisualEditorTree) {
+		ws, err := s.workspaceRepo.GetByID(ctx, payload.WorkspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workspace: %w", err)
+		}
+		payload.SyncedBlocks = ws.Settings.SyncedBlocks()
+	}
+
 	// Expose the workspace URLs so {{ workspace.base_url }} / {{ workspace.website_url }}
 	// render in the preview exactly as they do at send time (where BuildTemplateData
 	// injects them). The compile endpoint renders Liquid from the supplied test_data
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -30,8 +31,28 @@ type WebhookDeliveryWorker struct {
 	lastCleanupTime  time.Time
 	cleanupInterval  time.Duration
 	retentionDays    int
+	autoDisableAfter time.Duration
+	eventBus         domain.EventBus
+	queryBuilder     *QueryBuilder
+	sinkFactory      domain.EventSinkFactory
+	sinksMu          sync.Mutex
+	sinks            map[string]*cachedEventSink // by subscription ID
 }
 
+// cachedEventSink keeps the connection of a sink subscription open between polls,
+// until the subscription settings change
+type cachedEventSink struct {
+	sink      domain.EventSink
+	updatedAt time.Time
+}
+
+// maxSinkBatchesPerPoll bounds the batches published per sink subscription and poll
+const maxSinkBatchesPerPoll = 10
+
+// defaultWebhookAutoDisableAfter is how long a subscription may fail continuously
+// before it is disabled automatically
+const defaultWebhookAutoDisableAfter = 72 * time.Hour
+
 // Aggressive retry delays as per Standard Webhooks spec
 var retryDelays = []time.Duration{
 	30 * time.Second,
@@ -53,6 +74,7 @@ func NewWebhookDeliveryWorker(
 	workspaceRepo domain.WorkspaceRepository,
 	logger logger.Logger,
 	httpClient *http.Client,
+	secretKey string,
 ) *WebhookDeliveryWorker {
 	if httpClient == nil {
 		httpClient = &http.Client{
@@ -70,9 +92,30 @@ func NewWebhookDeliveryWorker(
 		batchSize:        100,
 		cleanupInterval:  1 * time.Hour,
 		retentionDays:    7,
+		autoDisableAfter: defaultWebhookAutoDisableAfter,
+		queryBuilder:     NewQueryBuilder(),
+		sinkFactory:      NewEventSinkFactory(workspaceRepo, secretKey),
+		sinks:            make(map[string]*cachedEventSink),
 	}
 }
 
+// SetEventBus sets the event bus used to notify the workspace owners when a
+// subscription is disabled automatically
+func (w *WebhookDeliveryWorker) SetEventBus(eventBus domain.EventBus) {
+	w.eventBus = eventBus
+}
+
+// SetAutoDisableAfter sets how long a subscription may fail continuously before
+// it is disabled. Zero or a negative duration never disables subscriptions.
+func (w *WebhookDeliveryWorker) SetAutoDisableAfter(d time.Duration) {
+	w.autoDisableAfter = d
+}
+
+// SetEventSinkFactory sets the factory creating the Kafka, NATS and Postgres sinks
+func (w *WebhookDeliveryWorker) SetEventSinkFactory(factory domain.EventSinkFactory) {
+	w.sinkFactory = factory
+}
+
 // Start starts the webhook delivery worker
 func (w *WebhookDeliveryWorker) Start(ctx context.Context) {
 	w.logger.Info("Webhook delivery worker started")
@@ -84,6 +127,7 @@ func (w *WebhookDeliveryWorker) Start(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			w.logger.Info("Webhook delivery worker stopping...")
+			w.closeEventSinks()
 			return
 		case <-ticker.C:
 			w.processDeliveries(ctx)
@@ -110,6 +154,12 @@ func (w *WebhookDeliveryWorker) processDeliveries(ctx context.Context) {
 				"error":        err.Error(),
 			}).Error("Failed to process webhook deliveries for workspace")
 		}
+		if err := w.processWorkspaceSinks(ctx, workspace.ID); err != nil {
+			w.logger.WithFields(map[string]interface{}{
+				"workspace_id": workspace.ID,
+				"error":        err.Error(),
+			}).Error("Failed to process event sink deliveries for workspace")
+		}
 	}
 }
 
@@ -158,6 +208,26 @@ func (w *WebhookDeliveryWorker) processWorkspaceDeliveries(ctx context.Context,
 				continue
 			}
 
+			// Drop deliveries that don't match the subscription payload filters
+			matched, err := w.matchesPayloadFilters(ctx, workspaceID, delivery, sub)
+			if err != nil {
+				w.logger.WithFields(map[string]interface{}{
+					"delivery_id":     delivery.ID,
+					"subscription_id": sub.ID,
+					"error":           err.Error(),
+				}).Error("Failed to evaluate webhook payload filters")
+				continue
+			}
+			if !matched {
+				if err := w.deliveryRepo.UpdateStatus(ctx, workspaceID, delivery.ID, domain.WebhookDeliveryStatusFiltered, delivery.Attempts, nil, nil, nil); err != nil {
+					w.logger.WithFields(map[string]interface{}{
+						"delivery_id": delivery.ID,
+						"error":       err.Error(),
+					}).Error("Failed to mark delivery as filtered")
+				}
+				continue
+			}
+
 			// Process the delivery
 			w.processDelivery(ctx, workspaceID, delivery, sub)
 		}
@@ -166,6 +236,263 @@ func (w *WebhookDeliveryWorker) processWorkspaceDeliveries(ctx context.Context,
 	return nil
 }
 
+// matchesPayloadFilters checks a delivery against the list, segment, broadcast and
+// contact condition filters of its subscription
+func (w *WebhookDeliveryWorker) matchesPayloadFilters(ctx context.Context, workspaceID string, delivery *domain.WebhookDelivery, sub *domain.WebhookSubscription) (bool, error) {
+	filters := sub.Settings.PayloadFilters
+	if filters == nil || filters.IsEmpty() {
+		return true, nil
+	}
+
+	if !filters.MatchesIDs(delivery.Payload) {
+		return false, nil
+	}
+
+	if filters.ContactCondition == nil {
+		return true, nil
+	}
+
+	// Events without a contact (broadcast, task...) are not restricted by the condition
+	email := domain.WebhookPayloadContactEmail(delivery.Payload)
+	if email == "" {
+		return true, nil
+	}
+
+	sqlStr, args, err := w.queryBuilder.BuildSQL(filters.ContactCondition)
+	if err != nil {
+		return false, fmt.Errorf("failed to build contact condition: %w", err)
+	}
+
+	db, err := w.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	checkSQL := fmt.Sprintf("SELECT EXISTS (%s AND email = $%d)", sqlStr, len(args)+1)
+	args = append(args, email)
+
+	var exists bool
+	if err := db.QueryRowContext(ctx, checkSQL, args...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("contact condition query failed: %w", err)
+	}
+
+	return exists, nil
+}
+
+// processWorkspaceSinks publishes the pending deliveries of the sink subscriptions of a workspace
+func (w *WebhookDeliveryWorker) processWorkspaceSinks(ctx context.Context, workspaceID string) error {
+	subs, err := w.subscriptionRepo.List(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	active := make(map[string]bool)
+	for _, sub := range subs {
+		if sub.Settings.Sink == nil || !sub.Enabled {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		active[sub.ID] = true
+		w.processSinkSubscription(ctx, workspaceID, sub)
+	}
+
+	w.pruneEventSinks(workspaceID, active)
+	return nil
+}
+
+// processSinkSubscription publishes the pending deliveries of a sink subscription in
+// batches, in insertion order. A failed batch counts as a failed attempt of its first
+// delivery, which blocks the deliveries behind it until its retry: the events of a
+// contact are never published out of order.
+func (w *WebhookDeliveryWorker) processSinkSubscription(ctx context.Context, workspaceID string, sub *domain.WebhookSubscription) {
+	batchSize := sub.Settings.Sink.GetBatchSize()
+
+	key, err := decodeSecret(sub.Secret)
+	if err != nil {
+		w.logger.WithFields(map[string]interface{}{
+			"subscription_id": sub.ID,
+			"error":           err.Error(),
+		}).Error("Failed to decode webhook secret")
+		return
+	}
+
+	for i := 0; i < maxSinkBatchesPerPoll; i++ {
+		deliveries, err := w.deliveryRepo.GetPendingForSubscription(ctx, workspaceID, sub.ID, batchSize)
+		if err != nil {
+			w.logger.WithFields(map[string]interface{}{
+				"subscription_id": sub.ID,
+				"error":           err.Error(),
+			}).Error("Failed to get pending event sink deliveries")
+			return
+		}
+		if len(deliveries) == 0 {
+			return
+		}
+
+		now := time.Now()
+		batch := make([]*domain.WebhookDelivery, 0, len(deliveries))
+		messages := make([]*domain.EventSinkMessage, 0, len(deliveries))
+		complete := true
+		for _, delivery := range deliveries {
+			// Stop at the first delivery waiting for a retry
+			if delivery.NextAttemptAt.After(now) {
+				complete = false
+				break
+			}
+
+			matched, err := w.matchesPayloadFilters(ctx, workspaceID, delivery, sub)
+			if err != nil {
+				w.logger.WithFields(map[string]interface{}{
+					"delivery_id":     delivery.ID,
+					"subscription_id": sub.ID,
+					"error":           err.Error(),
+				}).Error("Failed to evaluate webhook payload filters")
+				complete = false
+				break
+			}
+			if !matched {
+				if err := w.deliveryRepo.UpdateStatus(ctx, workspaceID, delivery.ID, domain.WebhookDeliveryStatusFiltered, delivery.Attempts, nil, nil, nil); err != nil {
+					w.logger.WithFields(map[string]interface{}{
+						"delivery_id": delivery.ID,
+						"error":       err.Error(),
+					}).Error("Failed to mark delivery as filtered")
+					complete = false
+					break
+				}
+				continue
+			}
+
+			message, err := buildEventSinkMessage(workspaceID, delivery, key)
+			if err != nil {
+				w.logger.WithFields(map[string]interface{}{
+					"delivery_id": delivery.ID,
+					"error":       err.Error(),
+				}).Error("Failed to marshal webhook payload")
+				complete = false
+				break
+			}
+			batch = append(batch, delivery)
+			messages = append(messages, message)
+		}
+
+		if len(batch) > 0 && !w.publishSinkBatch(ctx, workspaceID, sub, batch, messages) {
+			return
+		}
+
+		if !complete || len(deliveries) < batchSize {
+			return
+		}
+	}
+}
+
+// publishSinkBatch publishes a batch and records its outcome, it returns false when
+// the batch failed
+func (w *WebhookDeliveryWorker) publishSinkBatch(ctx context.Context, workspaceID string, sub *domain.WebhookSubscription, batch []*domain.WebhookDelivery, messages []*domain.EventSinkMessage) bool {
+	sink, err := w.getEventSink(ctx, workspaceID, sub)
+	if err == nil {
+		err = sink.Publish(ctx, messages)
+		if err != nil {
+			w.releaseEventSink(workspaceID, sub.ID)
+		}
+	}
+	if err != nil {
+		w.handleDeliveryFailure(ctx, workspaceID, batch[0], sub, nil, "", err.Error())
+		return false
+	}
+
+	ids := make([]string, len(batch))
+	for i, delivery := range batch {
+		ids[i] = delivery.ID
+	}
+	if err := w.deliveryRepo.MarkBatchDelivered(ctx, workspaceID, ids); err != nil {
+		w.logger.WithFields(map[string]interface{}{
+			"subscription_id": sub.ID,
+			"count":           len(ids),
+			"error":           err.Error(),
+		}).Error("Failed to mark event sink deliveries as delivered")
+		return false
+	}
+
+	if err := w.subscriptionRepo.UpdateLastDeliveryAt(ctx, workspaceID, sub.ID, time.Now().UTC()); err != nil {
+		w.logger.WithFields(map[string]interface{}{
+			"subscription_id": sub.ID,
+			"error":           err.Error(),
+		}).Error("Failed to update last delivery timestamp")
+	}
+
+	w.logger.WithFields(map[string]interface{}{
+		"subscription_id": sub.ID,
+		"sink":            sub.Settings.Sink.Destination(),
+		"count":           len(ids),
+	}).Debug("Published events to sink")
+	return true
+}
+
+// getEventSink returns the cached sink of a subscription, recreated when its settings changed
+func (w *WebhookDeliveryWorker) getEventSink(ctx context.Context, workspaceID string, sub *domain.WebhookSubscription) (domain.EventSink, error) {
+	cacheKey := workspaceID + ":" + sub.ID
+
+	w.sinksMu.Lock()
+	defer w.sinksMu.Unlock()
+
+	if cached, ok := w.sinks[cacheKey]; ok {
+		if cached.updatedAt.Equal(sub.UpdatedAt) {
+			return cached.sink, nil
+		}
+		_ = cached.sink.Close()
+		delete(w.sinks, cacheKey)
+	}
+
+	sink, err := w.sinkFactory.NewEventSink(ctx, workspaceID, sub.Settings.Sink)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event sink: %w", err)
+	}
+	w.sinks[cacheKey] = &cachedEventSink{sink: sink, updatedAt: sub.UpdatedAt}
+	return sink, nil
+}
+
+// releaseEventSink closes the cached sink of a subscription
+func (w *WebhookDeliveryWorker) releaseEventSink(workspaceID, subscriptionID string) {
+	cacheKey := workspaceID + ":" + subscriptionID
+
+	w.sinksMu.Lock()
+	defer w.sinksMu.Unlock()
+
+	if cached, ok := w.sinks[cacheKey]; ok {
+		_ = cached.sink.Close()
+		delete(w.sinks, cacheKey)
+	}
+}
+
+// pruneEventSinks closes the cached sinks of the workspace subscriptions that were
+// deleted, disabled or switched back to HTTP
+func (w *WebhookDeliveryWorker) pruneEventSinks(workspaceID string, active map[string]bool) {
+	prefix := workspaceID + ":"
+
+	w.sinksMu.Lock()
+	defer w.sinksMu.Unlock()
+
+	for cacheKey, cached := range w.sinks {
+		if strings.HasPrefix(cacheKey, prefix) && !active[strings.TrimPrefix(cacheKey, prefix)] {
+			_ = cached.sink.Close()
+			delete(w.sinks, cacheKey)
+		}
+	}
+}
+
+// closeEventSinks closes all the cached sinks
+func (w *WebhookDeliveryWorker) closeEventSinks() {
+	w.sinksMu.Lock()
+	defer w.sinksMu.Unlock()
+
+	for cacheKey, cached := range w.sinks {
+		_ = cached.sink.Close()
+		delete(w.sinks, cacheKey)
+	}
+}
+
 // cleanupOldDeliveries removes webhook deliveries older than the retention period
 func (w *WebhookDeliveryWorker) cleanupOldDeliveries(ctx context.Context) {
 	// Skip if not enough time has passed since last cleanup
@@ -201,15 +528,7 @@ func (w *WebhookDeliveryWorker) cleanupOldDeliveries(ctx context.Context) {
 // processDelivery sends a single webhook delivery
 func (w *WebhookDeliveryWorker) processDelivery(ctx context.Context, workspaceID string, delivery *domain.WebhookDelivery, sub *domain.WebhookSubscription) {
 	// Build the full payload envelope
-	envelope := map[string]interface{}{
-		"id":           delivery.ID,
-		"type":         delivery.EventType,
-		"workspace_id": workspaceID,
-		"timestamp":    time.Now().UTC().Format(time.RFC3339),
-		"data":         delivery.Payload,
-	}
-
-	payloadBytes, err := json.Marshal(envelope)
+	payloadBytes, err := buildWebhookEnvelope(delivery.ID, delivery.EventType, workspaceID, delivery.Payload)
 	if err != nil {
 		w.logger.WithFields(map[string]interface{}{
 			"delivery_id": delivery.ID,
@@ -319,6 +638,8 @@ func (w *WebhookDeliveryWorker) handleDeliveryFailure(ctx context.Context, works
 			"attempts":        attempts,
 			"error":           errorMsg,
 		}).Warn("Webhook delivery permanently failed after max retries")
+
+		w.recordSubscriptionFailure(ctx, workspaceID, sub, errorMsg)
 		return
 	}
 
@@ -345,6 +666,70 @@ func (w *WebhookDeliveryWorker) handleDeliveryFailure(ctx context.Context, works
 		"next_attempt":    nextAttempt.Format(time.RFC3339),
 		"error":           errorMsg,
 	}).Debug("Webhook delivery failed, scheduled retry")
+
+	w.recordSubscriptionFailure(ctx, workspaceID, sub, errorMsg)
+}
+
+// recordSubscriptionFailure tracks since when a subscription has been failing and
+// disables it once it has failed continuously for longer than autoDisableAfter
+func (w *WebhookDeliveryWorker) recordSubscriptionFailure(ctx context.Context, workspaceID string, sub *domain.WebhookSubscription, errorMsg string) {
+	now := time.Now().UTC()
+
+	failingSince, err := w.subscriptionRepo.RecordFailure(ctx, workspaceID, sub.ID, now)
+	if err != nil {
+		w.logger.WithFields(map[string]interface{}{
+			"subscription_id": sub.ID,
+			"error":           err.Error(),
+		}).Error("Failed to record webhook subscription failure")
+		return
+	}
+	sub.FailingSince = &failingSince
+
+	if w.autoDisableAfter <= 0 || now.Sub(failingSince) < w.autoDisableAfter {
+		return
+	}
+
+	reason := fmt.Sprintf("Deliveries failing continuously since %s (last error: %s)", failingSince.Format(time.RFC3339), errorMsg)
+	if err := w.subscriptionRepo.Disable(ctx, workspaceID, sub.ID, reason); err != nil {
+		w.logger.WithFields(map[string]interface{}{
+			"subscription_id": sub.ID,
+			"error":           err.Error(),
+		}).Error("Failed to disable failing webhook subscription")
+		return
+	}
+	// The worker caches subscriptions per batch, skip the remaining deliveries
+	sub.Enabled = false
+	sub.DisabledReason = &reason
+
+	w.logger.WithFields(map[string]interface{}{
+		"workspace_id":    workspaceID,
+		"subscription_id": sub.ID,
+		"failing_since":   failingSince.Format(time.RFC3339),
+	}).Warn("Webhook subscription disabled after failing continuously")
+
+	if w.eventBus != nil {
+		w.eventBus.Publish(ctx, domain.EventPayload{
+			Type:        domain.EventWebhookSubscriptionDisabled,
+			WorkspaceID: workspaceID,
+			EntityID:    sub.ID,
+			Data: map[string]interface{}{
+				"subscription_name": sub.Name,
+				"url":               sub.URL,
+				"reason":            reason,
+			},
+		})
+	}
+}
+
+// buildWebhookEnvelope builds the JSON body shared by webhook requests and sink messages
+func buildWebhookEnvelope(id, eventType, workspaceID string, payload map[string]interface{}) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"id":           id,
+		"type":         eventType,
+		"workspace_id": workspaceID,
+		"timestamp":    time.Now().UTC().Format(time.RFC3339),
+		"data":         payload,
+	})
 }
 
 // signPayload signs the webhook payload using Standard Webhooks spec.
@@ -416,6 +801,15 @@ func buildTestPayload(eventType string) map[string]interface{} {
 				payload["bounce_reason"] = "Test bounce reason"
 			case "complained":
 				payload["complaint_type"] = "abuse"
+			case "failed":
+				payload["source_type"] = "broadcast"
+				payload["source_id"] = "test_broadcast_012"
+				payload["attempts"] = 1
+				payload["error"] = "mailbox unavailable"
+				payload["error_type"] = "recipient"
+				payload["provider"] = "ses"
+				payload["http_status"] = 400
+				payload["retryable"] = false
 			}
 		}
 		return payload
@@ -433,6 +827,72 @@ func buildTestPayload(eventType string) map[string]interface{} {
 			},
 			"created_at": now,
 		}
+	case "broadcast":
+		payload := map[string]interface{}{
+			"broadcast_id": "test_broadcast_012",
+			"name":         "Test Broadcast",
+			"task_id":      "test_task_678",
+		}
+		if len(parts) > 1 {
+			switch parts[1] {
+			case "started":
+				payload["total_recipients"] = 1000
+			case "completed":
+				payload["total_recipients"] = 1000
+				payload["enqueued_count"] = 998
+				payload["failed_count"] = 2
+				payload["phase"] = "single"
+			case "paused":
+				payload["reason"] = "circuit_breaker"
+				payload["pause_reason"] = "Circuit breaker triggered: provider unavailable"
+			case "failed":
+				payload["error"] = "Test failure reason"
+			case "test_completed":
+				payload["test_sent_count"] = 100
+				payload["auto_send_winner"] = true
+			}
+		}
+		return payload
+	case "automation":
+		payload := map[string]interface{}{
+			"automation_id":         "test_automation_901",
+			"contact_automation_id": "test_journey_234",
+			"email":                 "test@example.com",
+			"status":                "active",
+			"entered_at":            now,
+		}
+		if len(parts) > 1 {
+			switch parts[1] {
+			case "completed":
+				payload["status"] = "completed"
+				payload["exit_reason"] = "completed"
+			case "exited":
+				payload["status"] = "exited"
+				payload["exit_reason"] = "filter_rejected"
+			case "failed":
+				payload["status"] = "failed"
+				payload["exit_reason"] = "failed"
+				payload["error"] = "node execution failed: Test failure reason"
+			}
+		}
+		return payload
+	case "integration":
+		return map[string]interface{}{
+			"integration_id": "test_integration_567",
+			"provider":       "ses",
+			"error_type":     "provider",
+			"http_status":    503,
+			"error":          "service unavailable",
+		}
+	case "task":
+		return map[string]interface{}{
+			"task_id":      "test_task_678",
+			"type":         "send_broadcast",
+			"broadcast_id": "test_broadcast_012",
+			"retry_count":  3,
+			"max_retries":  3,
+			"error":        "Test failure reason",
+		}
 	default:
 		// Fallback for unknown event types
 		return map[string]interface{}{
@@ -453,15 +913,11 @@ func (w *WebhookDeliveryWorker) SendTestWebhook(ctx context.Context, workspaceID
 		eventType = "test"
 	}
 
-	envelope := map[string]interface{}{
-		"id":           testID,
-		"type":         eventType,
-		"workspace_id": workspaceID,
-		"timestamp":    time.Now().UTC().Format(time.RFC3339),
-		"data":         buildTestPayload(eventType),
+	if sub.Settings.Sink != nil {
+		return w.sendTestEvent(ctx, workspaceID, sub, testID, eventType)
 	}
 
-	payloadBytes, err := json.Marshal(envelope)
+	payloadBytes, err := buildWebhookEnvelope(testID, eventType, workspaceID, buildTestPayload(eventType))
 	if err != nil {
 		return 0, "", fmt.Errorf("failed to marshal test payload: %w", err)
 	}
@@ -501,3 +957,34 @@ func (w *WebhookDeliveryWorker) SendTestWebhook(ctx context.Context, workspaceID
 
 	return resp.StatusCode, responseBody, nil
 }
+
+// sendTestEvent publishes a test event to the sink of a subscription
+func (w *WebhookDeliveryWorker) sendTestEvent(ctx context.Context, workspaceID string, sub *domain.WebhookSubscription, testID, eventType string) (int, string, error) {
+	key, err := decodeSecret(sub.Secret)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid webhook secret: %w", err)
+	}
+
+	delivery := &domain.WebhookDelivery{
+		ID:        testID,
+		EventType: eventType,
+		Payload:   buildTestPayload(eventType),
+	}
+	message, err := buildEventSinkMessage(workspaceID, delivery, key)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to marshal test payload: %w", err)
+	}
+
+	// A dedicated sink, the cached ones belong to the worker goroutine
+	sink, err := w.sinkFactory.NewEventSink(ctx, workspaceID, sub.Settings.Sink)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to create event sink: %w", err)
+	}
+	defer func() { _ = sink.Close() }()
+
+	if err := sink.Publish(ctx, []*domain.EventSinkMessage{message}); err != nil {
+		return 0, "", fmt.Errorf("failed to publish test event: %w", err)
+	}
+
+	return http.StatusOK, fmt.Sprintf("Published to %s", sub.Settings.Sink.Destination()), nil
+}
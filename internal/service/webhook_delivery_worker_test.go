@@ -34,7 +34,7 @@ func TestNewWebhookDeliveryWorker(t *testing.T) {
 
 	t.Run("creates worker with provided HTTP client", func(t *testing.T) {
 		customClient := &http.Client{Timeout: 45 * time.Second}
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, customClient)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, customClient, "")
 
 		assert.NotNil(t, worker)
 		assert.Equal(t, customClient, worker.httpClient)
@@ -49,7 +49,7 @@ func TestNewWebhookDeliveryWorker(t *testing.T) {
 	})
 
 	t.Run("creates worker with default HTTP client when nil provided", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 
 		assert.NotNil(t, worker)
 		assert.NotNil(t, worker.httpClient)
@@ -75,7 +75,7 @@ func TestWebhookDeliveryWorker_Start(t *testing.T) {
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
 	t.Run("stops when context is cancelled", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 		worker.pollInterval = 50 * time.Millisecond // Speed up for testing
 
 		ctx, cancel := context.WithCancel(context.Background())
@@ -118,10 +118,13 @@ func TestWebhookDeliveryWorker_processDeliveries(t *testing.T) {
 	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
+	// No sink subscriptions
+	mockSubRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
 	ctx := context.Background()
 
 	t.Run("successfully processes deliveries for multiple workspaces", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 		worker.lastCleanupTime = time.Now() // Prevent cleanup from running during this test
 
 		workspaces := []*domain.Workspace{
@@ -137,7 +140,7 @@ func TestWebhookDeliveryWorker_processDeliveries(t *testing.T) {
 	})
 
 	t.Run("handles workspace list error", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 		worker.lastCleanupTime = time.Now() // Prevent cleanup from running during this test
 
 		mockWorkspaceRepo.EXPECT().List(ctx).Return(nil, errors.New("database error"))
@@ -147,7 +150,7 @@ func TestWebhookDeliveryWorker_processDeliveries(t *testing.T) {
 	})
 
 	t.Run("continues processing other workspaces on error", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 		worker.lastCleanupTime = time.Now() // Prevent cleanup from running during this test
 
 		workspaces := []*domain.Workspace{
@@ -182,7 +185,7 @@ func TestWebhookDeliveryWorker_processWorkspaceDeliveries(t *testing.T) {
 	workspaceID := "workspace1"
 
 	t.Run("returns error when getting pending deliveries fails", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 
 		mockDeliveryRepo.EXPECT().GetPendingForWorkspace(ctx, workspaceID, 100).
 			Return(nil, errors.New("database error"))
@@ -193,7 +196,7 @@ func TestWebhookDeliveryWorker_processWorkspaceDeliveries(t *testing.T) {
 	})
 
 	t.Run("returns nil when no pending deliveries", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 
 		mockDeliveryRepo.EXPECT().GetPendingForWorkspace(ctx, workspaceID, 100).
 			Return([]*domain.WebhookDelivery{}, nil)
@@ -203,7 +206,7 @@ func TestWebhookDeliveryWorker_processWorkspaceDeliveries(t *testing.T) {
 	})
 
 	t.Run("skips delivery when subscription not found", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 
 		delivery := &domain.WebhookDelivery{
 			ID:             "delivery1",
@@ -224,7 +227,7 @@ func TestWebhookDeliveryWorker_processWorkspaceDeliveries(t *testing.T) {
 	})
 
 	t.Run("skips delivery when subscription is disabled", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 
 		delivery := &domain.WebhookDelivery{
 			ID:             "delivery1",
@@ -252,7 +255,7 @@ func TestWebhookDeliveryWorker_processWorkspaceDeliveries(t *testing.T) {
 	})
 
 	t.Run("returns on context cancellation", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel() // Cancel immediately
@@ -275,7 +278,7 @@ func TestWebhookDeliveryWorker_processWorkspaceDeliveries(t *testing.T) {
 	})
 
 	t.Run("caches subscriptions to avoid repeated lookups", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 
 		// Create a test server that will receive the webhooks
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -323,6 +326,40 @@ func TestWebhookDeliveryWorker_processWorkspaceDeliveries(t *testing.T) {
 		err := worker.processWorkspaceDeliveries(ctx, workspaceID)
 		assert.NoError(t, err)
 	})
+
+	t.Run("marks delivery as filtered when payload does not match filters", func(t *testing.T) {
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
+
+		subscription := &domain.WebhookSubscription{
+			ID:      "sub1",
+			URL:     "https://example.com/webhook",
+			Secret:  "whsec_YWJjZGVmZ2hpamtsbW5vcHFyc3R1dnd4eXowMTIzNDU=",
+			Enabled: true,
+			Settings: domain.WebhookSubscriptionSettings{
+				EventTypes:     []string{"list.subscribed"},
+				PayloadFilters: &domain.WebhookPayloadFilters{ListIDs: []string{"newsletter"}},
+			},
+		}
+
+		delivery := &domain.WebhookDelivery{
+			ID:             "delivery1",
+			SubscriptionID: "sub1",
+			EventType:      "list.subscribed",
+			Payload:        map[string]interface{}{"list_id": "promotions"},
+			Attempts:       0,
+			MaxAttempts:    10,
+		}
+
+		mockDeliveryRepo.EXPECT().GetPendingForWorkspace(ctx, workspaceID, 100).
+			Return([]*domain.WebhookDelivery{delivery}, nil)
+		mockSubRepo.EXPECT().GetByID(ctx, workspaceID, "sub1").
+			Return(subscription, nil)
+		mockDeliveryRepo.EXPECT().UpdateStatus(ctx, workspaceID, "delivery1", domain.WebhookDeliveryStatusFiltered, 0, nil, nil, nil).
+			Return(nil)
+
+		err := worker.processWorkspaceDeliveries(ctx, workspaceID)
+		assert.NoError(t, err)
+	})
 }
 
 func TestWebhookDeliveryWorker_deliverWebhook(t *testing.T) {
@@ -341,6 +378,8 @@ func TestWebhookDeliveryWorker_deliverWebhook(t *testing.T) {
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 
+	mockSubRepo.EXPECT().RecordFailure(gomock.Any(), gomock.Any(), "sub1", gomock.Any()).Return(time.Now().UTC(), nil).AnyTimes()
+
 	ctx := context.Background()
 	workspaceID := "workspace1"
 
@@ -363,7 +402,7 @@ func TestWebhookDeliveryWorker_deliverWebhook(t *testing.T) {
 		}))
 		defer server.Close()
 
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 
 		delivery := &domain.WebhookDelivery{
 			ID:             "delivery1",
@@ -394,7 +433,7 @@ func TestWebhookDeliveryWorker_deliverWebhook(t *testing.T) {
 		}))
 		defer server.Close()
 
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 
 		delivery := &domain.WebhookDelivery{
 			ID:             "delivery1",
@@ -422,7 +461,7 @@ func TestWebhookDeliveryWorker_deliverWebhook(t *testing.T) {
 	})
 
 	t.Run("handles network error", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 
 		delivery := &domain.WebhookDelivery{
 			ID:             "delivery1",
@@ -455,7 +494,7 @@ func TestWebhookDeliveryWorker_deliverWebhook(t *testing.T) {
 		}))
 		defer server.Close()
 
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 
 		delivery := &domain.WebhookDelivery{
 			ID:             "delivery1",
@@ -494,7 +533,7 @@ func TestWebhookDeliveryWorker_deliverWebhook(t *testing.T) {
 		cancel() // Cancel immediately
 
 		customClient := &http.Client{Timeout: 1 * time.Second}
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, customClient)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, customClient, "")
 
 		delivery := &domain.WebhookDelivery{
 			ID:             "delivery1",
@@ -679,6 +718,8 @@ func TestWebhookDeliveryWorker_retryScheduling(t *testing.T) {
 	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
+	mockSubRepo.EXPECT().RecordFailure(gomock.Any(), gomock.Any(), "sub1", gomock.Any()).Return(time.Now().UTC(), nil).AnyTimes()
+
 	ctx := context.Background()
 	workspaceID := "workspace1"
 
@@ -721,7 +762,7 @@ func TestWebhookDeliveryWorker_retryScheduling(t *testing.T) {
 			}))
 			defer server.Close()
 
-			worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+			worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 
 			delivery := &domain.WebhookDelivery{
 				ID:             "delivery1",
@@ -771,7 +812,7 @@ func TestWebhookDeliveryWorker_handleDeliverySuccess(t *testing.T) {
 	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
-	worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+	worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 	ctx := context.Background()
 	workspaceID := "workspace1"
 
@@ -823,7 +864,9 @@ func TestWebhookDeliveryWorker_handleDeliveryFailure(t *testing.T) {
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 
-	worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+	mockSubRepo.EXPECT().RecordFailure(gomock.Any(), gomock.Any(), "sub1", gomock.Any()).Return(time.Now().UTC(), nil).AnyTimes()
+
+	worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 	ctx := context.Background()
 	workspaceID := "workspace1"
 
@@ -912,6 +955,84 @@ func TestWebhookDeliveryWorker_handleDeliveryFailure(t *testing.T) {
 	})
 }
 
+func TestWebhookDeliveryWorker_recordSubscriptionFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSubRepo := mocks.NewMockWebhookSubscriptionRepository(ctrl)
+	mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockEventBus := mocks.NewMockEventBus(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+
+	worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
+	worker.SetEventBus(mockEventBus)
+	worker.SetAutoDisableAfter(24 * time.Hour)
+	ctx := context.Background()
+	workspaceID := "workspace1"
+
+	t.Run("keeps subscription enabled below threshold", func(t *testing.T) {
+		subscription := &domain.WebhookSubscription{ID: "sub1", Enabled: true}
+
+		mockSubRepo.EXPECT().RecordFailure(ctx, workspaceID, "sub1", gomock.Any()).
+			Return(time.Now().UTC().Add(-time.Hour), nil)
+
+		worker.recordSubscriptionFailure(ctx, workspaceID, subscription, "HTTP 500")
+
+		assert.True(t, subscription.Enabled)
+		assert.NotNil(t, subscription.FailingSince)
+	})
+
+	t.Run("disables subscription and publishes event after threshold", func(t *testing.T) {
+		subscription := &domain.WebhookSubscription{ID: "sub1", Name: "CRM sync", URL: "https://example.com/webhook", Enabled: true}
+
+		mockSubRepo.EXPECT().RecordFailure(ctx, workspaceID, "sub1", gomock.Any()).
+			Return(time.Now().UTC().Add(-25*time.Hour), nil)
+		mockSubRepo.EXPECT().Disable(ctx, workspaceID, "sub1", gomock.Any()).Return(nil)
+		mockEventBus.EXPECT().Publish(ctx, gomock.Any()).Do(func(_ context.Context, payload domain.EventPayload) {
+			assert.Equal(t, domain.EventWebhookSubscriptionDisabled, payload.Type)
+			assert.Equal(t, workspaceID, payload.WorkspaceID)
+			assert.Equal(t, "sub1", payload.EntityID)
+			assert.Equal(t, "CRM sync", payload.Data["subscription_name"])
+		})
+
+		worker.recordSubscriptionFailure(ctx, workspaceID, subscription, "HTTP 500")
+
+		assert.False(t, subscription.Enabled)
+		require.NotNil(t, subscription.DisabledReason)
+		assert.Contains(t, *subscription.DisabledReason, "HTTP 500")
+	})
+
+	t.Run("does not publish when disable fails", func(t *testing.T) {
+		subscription := &domain.WebhookSubscription{ID: "sub1", Enabled: true}
+
+		mockSubRepo.EXPECT().RecordFailure(ctx, workspaceID, "sub1", gomock.Any()).
+			Return(time.Now().UTC().Add(-25*time.Hour), nil)
+		mockSubRepo.EXPECT().Disable(ctx, workspaceID, "sub1", gomock.Any()).Return(errors.New("database error"))
+
+		worker.recordSubscriptionFailure(ctx, workspaceID, subscription, "HTTP 500")
+
+		assert.True(t, subscription.Enabled)
+	})
+
+	t.Run("never disables when auto-disable is off", func(t *testing.T) {
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
+		worker.SetAutoDisableAfter(0)
+		subscription := &domain.WebhookSubscription{ID: "sub1", Enabled: true}
+
+		mockSubRepo.EXPECT().RecordFailure(ctx, workspaceID, "sub1", gomock.Any()).
+			Return(time.Now().UTC().Add(-1000*time.Hour), nil)
+
+		worker.recordSubscriptionFailure(ctx, workspaceID, subscription, "HTTP 500")
+
+		assert.True(t, subscription.Enabled)
+	})
+}
+
 func TestWebhookDeliveryWorker_SendTestWebhook(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -921,7 +1042,7 @@ func TestWebhookDeliveryWorker_SendTestWebhook(t *testing.T) {
 	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 
-	worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+	worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 	ctx := context.Background()
 	workspaceID := "workspace1"
 
@@ -1036,7 +1157,7 @@ func TestWebhookDeliveryWorker_SendTestWebhook(t *testing.T) {
 		}
 
 		customClient := &http.Client{Timeout: 1 * time.Second}
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, customClient)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, customClient, "")
 
 		statusCode, responseBody, err := worker.SendTestWebhook(ctx, workspaceID, subscription, "custom_event.created")
 
@@ -1113,7 +1234,7 @@ func TestWebhookDeliveryWorker_cleanupOldDeliveries(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("skips cleanup when interval has not passed", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 		worker.lastCleanupTime = time.Now() // Set to now so interval hasn't passed
 
 		// Should not call List or CleanupOldDeliveries
@@ -1121,7 +1242,7 @@ func TestWebhookDeliveryWorker_cleanupOldDeliveries(t *testing.T) {
 	})
 
 	t.Run("runs cleanup when interval has passed", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 		worker.lastCleanupTime = time.Now().Add(-2 * time.Hour) // Set to 2 hours ago
 
 		workspaces := []*domain.Workspace{
@@ -1140,7 +1261,7 @@ func TestWebhookDeliveryWorker_cleanupOldDeliveries(t *testing.T) {
 	})
 
 	t.Run("handles workspace list error", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 		worker.lastCleanupTime = time.Now().Add(-2 * time.Hour)
 
 		mockWorkspaceRepo.EXPECT().List(ctx).Return(nil, errors.New("database error"))
@@ -1150,7 +1271,7 @@ func TestWebhookDeliveryWorker_cleanupOldDeliveries(t *testing.T) {
 	})
 
 	t.Run("continues cleanup for other workspaces on error", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 		worker.lastCleanupTime = time.Now().Add(-2 * time.Hour)
 
 		workspaces := []*domain.Workspace{
@@ -1166,7 +1287,7 @@ func TestWebhookDeliveryWorker_cleanupOldDeliveries(t *testing.T) {
 	})
 
 	t.Run("does not log when no records deleted", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 		worker.lastCleanupTime = time.Now().Add(-2 * time.Hour)
 
 		workspaces := []*domain.Workspace{
@@ -1181,7 +1302,7 @@ func TestWebhookDeliveryWorker_cleanupOldDeliveries(t *testing.T) {
 	})
 
 	t.Run("runs on first call (zero lastCleanupTime)", func(t *testing.T) {
-		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
 		// lastCleanupTime is zero value
 
 		workspaces := []*domain.Workspace{
@@ -1194,3 +1315,149 @@ func TestWebhookDeliveryWorker_cleanupOldDeliveries(t *testing.T) {
 		worker.cleanupOldDeliveries(ctx)
 	})
 }
+
+func TestWebhookDeliveryWorker_processSinkSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSubRepo := mocks.NewMockWebhookSubscriptionRepository(ctrl)
+	mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockFactory := mocks.NewMockEventSinkFactory(ctrl)
+	mockSink := mocks.NewMockEventSink(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+
+	ctx := context.Background()
+	workspaceID := "workspace1"
+	updatedAt := time.Now().UTC()
+
+	newSub := func() *domain.WebhookSubscription {
+		return &domain.WebhookSubscription{
+			ID:      "sub1",
+			Secret:  "whsec_YWJjZGVmZ2hpamtsbW5vcHFyc3R1dnd4eXowMTIzNDU=",
+			Enabled: true,
+			Settings: domain.WebhookSubscriptionSettings{
+				EventTypes: []string{"contact.created"},
+				Sink: &domain.EventSinkSettings{
+					Type:      domain.EventSinkTypePostgres,
+					BatchSize: 2,
+					Postgres:  &domain.PostgresSinkSettings{Channel: "events"},
+				},
+			},
+			UpdatedAt: updatedAt,
+		}
+	}
+	newDelivery := func(id string, nextAttempt time.Time) *domain.WebhookDelivery {
+		return &domain.WebhookDelivery{
+			ID:             id,
+			SubscriptionID: "sub1",
+			EventType:      "contact.created",
+			Payload:        map[string]interface{}{"contact": map[string]interface{}{"email": "a@example.com"}},
+			MaxAttempts:    10,
+			NextAttemptAt:  nextAttempt,
+		}
+	}
+	newWorker := func() *WebhookDeliveryWorker {
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil, "")
+		worker.SetEventSinkFactory(mockFactory)
+		return worker
+	}
+
+	t.Run("publishes full batches until the queue is drained", func(t *testing.T) {
+		worker := newWorker()
+		sub := newSub()
+		past := time.Now().Add(-time.Minute)
+
+		gomock.InOrder(
+			mockDeliveryRepo.EXPECT().GetPendingForSubscription(ctx, workspaceID, "sub1", 2).
+				Return([]*domain.WebhookDelivery{newDelivery("d1", past), newDelivery("d2", past)}, nil),
+			mockDeliveryRepo.EXPECT().GetPendingForSubscription(ctx, workspaceID, "sub1", 2).
+				Return([]*domain.WebhookDelivery{newDelivery("d3", past)}, nil),
+		)
+		mockFactory.EXPECT().NewEventSink(ctx, workspaceID, sub.Settings.Sink).Return(mockSink, nil).Times(1)
+		mockSink.EXPECT().Publish(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, messages []*domain.EventSinkMessage) error {
+			for _, msg := range messages {
+				assert.Equal(t, "a@example.com", msg.Key)
+				assert.True(t, strings.HasPrefix(msg.Signature, "v1,"))
+			}
+			return nil
+		}).Times(2)
+		mockDeliveryRepo.EXPECT().MarkBatchDelivered(ctx, workspaceID, []string{"d1", "d2"}).Return(nil)
+		mockDeliveryRepo.EXPECT().MarkBatchDelivered(ctx, workspaceID, []string{"d3"}).Return(nil)
+		mockSubRepo.EXPECT().UpdateLastDeliveryAt(ctx, workspaceID, "sub1", gomock.Any()).Return(nil).Times(2)
+
+		worker.processSinkSubscription(ctx, workspaceID, sub)
+		assert.Len(t, worker.sinks, 1)
+	})
+
+	t.Run("stops at a delivery waiting for its retry", func(t *testing.T) {
+		worker := newWorker()
+		sub := newSub()
+
+		mockDeliveryRepo.EXPECT().GetPendingForSubscription(ctx, workspaceID, "sub1", 2).
+			Return([]*domain.WebhookDelivery{newDelivery("d1", time.Now().Add(time.Hour)), newDelivery("d2", time.Now())}, nil)
+
+		// Nothing is published: d2 must not overtake d1
+		worker.processSinkSubscription(ctx, workspaceID, sub)
+	})
+
+	t.Run("schedules a retry of the first delivery when publishing fails", func(t *testing.T) {
+		worker := newWorker()
+		sub := newSub()
+		past := time.Now().Add(-time.Minute)
+
+		mockDeliveryRepo.EXPECT().GetPendingForSubscription(ctx, workspaceID, "sub1", 2).
+			Return([]*domain.WebhookDelivery{newDelivery("d1", past), newDelivery("d2", past)}, nil)
+		mockFactory.EXPECT().NewEventSink(ctx, workspaceID, sub.Settings.Sink).Return(mockSink, nil)
+		mockSink.EXPECT().Publish(ctx, gomock.Any()).Return(errors.New("broker unavailable"))
+		mockSink.EXPECT().Close().Return(nil)
+		mockDeliveryRepo.EXPECT().ScheduleRetry(ctx, workspaceID, "d1", gomock.Any(), 1, nil, gomock.Any(), gomock.Any()).Return(nil)
+		mockSubRepo.EXPECT().RecordFailure(ctx, workspaceID, "sub1", gomock.Any()).Return(time.Now().UTC(), nil)
+
+		worker.processSinkSubscription(ctx, workspaceID, sub)
+		assert.Empty(t, worker.sinks)
+	})
+
+	t.Run("recreates the sink when the subscription changed", func(t *testing.T) {
+		worker := newWorker()
+		sub := newSub()
+		staleSink := mocks.NewMockEventSink(ctrl)
+		worker.sinks[workspaceID+":sub1"] = &cachedEventSink{sink: staleSink, updatedAt: updatedAt.Add(-time.Hour)}
+
+		staleSink.EXPECT().Close().Return(nil)
+		mockFactory.EXPECT().NewEventSink(ctx, workspaceID, sub.Settings.Sink).Return(mockSink, nil)
+
+		sink, err := worker.getEventSink(ctx, workspaceID, sub)
+		require.NoError(t, err)
+		assert.Equal(t, mockSink, sink)
+	})
+}
+
+func TestWebhookDeliveryWorker_pruneEventSinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	worker := NewWebhookDeliveryWorker(nil, nil, nil, mockLogger, nil, "")
+
+	kept := mocks.NewMockEventSink(ctrl)
+	removed := mocks.NewMockEventSink(ctrl)
+	otherWorkspace := mocks.NewMockEventSink(ctrl)
+	worker.sinks["ws1:sub1"] = &cachedEventSink{sink: kept}
+	worker.sinks["ws1:sub2"] = &cachedEventSink{sink: removed}
+	worker.sinks["ws2:sub2"] = &cachedEventSink{sink: otherWorkspace}
+
+	removed.EXPECT().Close().Return(nil)
+
+	worker.pruneEventSinks("ws1", map[string]bool{"sub1": true})
+
+	assert.Contains(t, worker.sinks, "ws1:sub1")
+	assert.NotContains(t, worker.sinks, "ws1:sub2")
+	assert.Contains(t, worker.sinks, "ws2:sub2")
+}
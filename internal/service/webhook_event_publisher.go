@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/emailerror"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// webhookEventMaxAttempts matches the max_attempts of the deliveries queued by the
+// webhook database triggers
+const webhookEventMaxAttempts = 10
+
+// WebhookEventPublisher queues lifecycle webhook events (broadcasts, automations,
+// queue failures, tasks) as deliveries picked up by the WebhookDeliveryWorker
+type WebhookEventPublisher struct {
+	subscriptionRepo domain.WebhookSubscriptionRepository
+	deliveryRepo     domain.WebhookDeliveryRepository
+	logger           logger.Logger
+}
+
+// NewWebhookEventPublisher creates a new webhook event publisher
+func NewWebhookEventPublisher(
+	subscriptionRepo domain.WebhookSubscriptionRepository,
+	deliveryRepo domain.WebhookDeliveryRepository,
+	logger logger.Logger,
+) *WebhookEventPublisher {
+	return &WebhookEventPublisher{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		logger:           logger,
+	}
+}
+
+// Publish queues a delivery of the event for every enabled subscription of the
+// workspace subscribed to it
+func (p *WebhookEventPublisher) Publish(ctx context.Context, workspaceID string, eventType string, payload map[string]interface{}) {
+	subscriptions, err := p.subscriptionRepo.List(ctx, workspaceID)
+	if err != nil {
+		p.logger.WithFields(map[string]interface{}{
+			"workspace_id": workspaceID,
+			"event_type":   eventType,
+			"error":        err.Error(),
+		}).Warn("Failed to list webhook subscriptions for event")
+		return
+	}
+
+	for _, sub := range subscriptions {
+		if !sub.Enabled || !slices.Contains(sub.Settings.EventTypes, eventType) {
+			continue
+		}
+
+		delivery := &domain.WebhookDelivery{
+			ID:             uuid.NewString(),
+			SubscriptionID: sub.ID,
+			EventType:      eventType,
+			Payload:        payload,
+			Status:         domain.WebhookDeliveryStatusPending,
+			MaxAttempts:    webhookEventMaxAttempts,
+		}
+		if err := p.deliveryRepo.Create(ctx, workspaceID, delivery); err != nil {
+			p.logger.WithFields(map[string]interface{}{
+				"workspace_id":    workspaceID,
+				"subscription_id": sub.ID,
+				"event_type":      eventType,
+				"error":           err.Error(),
+			}).Warn("Failed to queue webhook event delivery")
+		}
+	}
+}
+
+// HandleEmailFailed is the EmailQueueWorker failure callback. It publishes
+// email.failed once a queued email is dropped; failures scheduled for retry are ignored.
+func (p *WebhookEventPublisher) HandleEmailFailed(workspaceID string, entry *domain.EmailQueueEntry, sendErr error, classifiedErr *emailerror.ClassifiedError, isPermanent bool) {
+	if !isPermanent {
+		return
+	}
+
+	payload := map[string]interface{}{
+		"email":       entry.ContactEmail,
+		"message_id":  entry.MessageID,
+		"template_id": entry.TemplateID,
+		"source_type": string(entry.SourceType),
+		"source_id":   entry.SourceID,
+		"attempts":    entry.Attempts,
+		"error":       sendErr.Error(),
+	}
+	switch entry.SourceType {
+	case domain.EmailQueueSourceBroadcast:
+		payload["broadcast_id"] = entry.SourceID
+	case domain.EmailQueueSourceAutomation:
+		payload["automation_id"] = entry.SourceID
+	}
+	if classifiedErr != nil {
+		payload["error_type"] = string(classifiedErr.Type)
+		payload["provider"] = classifiedErr.Provider
+		payload["http_status"] = classifiedErr.HTTPStatus
+		payload["retryable"] = classifiedErr.Retryable
+	}
+
+	p.Publish(context.Background(), workspaceID, domain.WebhookEventEmailFailed, payload)
+}
+
+// HandleCircuitOpened is the EmailQueueWorker callback called when too many provider
+// errors open the circuit of an integration and its queued emails are held back
+func (p *WebhookEventPublisher) HandleCircuitOpened(workspaceID string, integrationID string, lastErr *emailerror.ClassifiedError) {
+	payload := map[string]interface{}{
+		"integration_id": integrationID,
+	}
+	if lastErr != nil {
+		payload["provider"] = lastErr.Provider
+		payload["error_type"] = string(lastErr.Type)
+		payload["http_status"] = lastErr.HTTPStatus
+		payload["error"] = lastErr.Error()
+	}
+
+	p.Publish(context.Background(), workspaceID, domain.WebhookEventIntegrationCircuitOpened, payload)
+}
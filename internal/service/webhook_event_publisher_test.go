@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/emailerror"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupWebhookEventPublisherTest(t *testing.T) (
+	*mocks.MockWebhookSubscriptionRepository,
+	*mocks.MockWebhookDeliveryRepository,
+	*WebhookEventPublisher,
+) {
+	ctrl := gomock.NewController(t)
+	mockSubRepo := mocks.NewMockWebhookSubscriptionRepository(ctrl)
+	mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+
+	return mockSubRepo, mockDeliveryRepo, NewWebhookEventPublisher(mockSubRepo, mockDeliveryRepo, mockLogger)
+}
+
+func TestWebhookEventPublisher_Publish(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("queues a delivery for each enabled subscription to the event", func(t *testing.T) {
+		mockSubRepo, mockDeliveryRepo, publisher := setupWebhookEventPublisherTest(t)
+
+		mockSubRepo.EXPECT().List(ctx, "ws1").Return([]*domain.WebhookSubscription{
+			{ID: "sub1", Enabled: true, Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"broadcast.completed"}}},
+			{ID: "sub2", Enabled: false, Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"broadcast.completed"}}},
+			{ID: "sub3", Enabled: true, Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"broadcast.started"}}},
+			{ID: "sub4", Enabled: true, Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"contact.created", "broadcast.completed"}}},
+		}, nil)
+
+		var queued []*domain.WebhookDelivery
+		mockDeliveryRepo.EXPECT().Create(ctx, "ws1", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, delivery *domain.WebhookDelivery) error {
+				queued = append(queued, delivery)
+				return nil
+			}).Times(2)
+
+		payload := map[string]interface{}{"broadcast_id": "b1"}
+		publisher.Publish(ctx, "ws1", domain.WebhookEventBroadcastCompleted, payload)
+
+		require.Len(t, queued, 2)
+		assert.Equal(t, "sub1", queued[0].SubscriptionID)
+		assert.Equal(t, "sub4", queued[1].SubscriptionID)
+		for _, delivery := range queued {
+			assert.NotEmpty(t, delivery.ID)
+			assert.Equal(t, "broadcast.completed", delivery.EventType)
+			assert.Equal(t, payload, delivery.Payload)
+			assert.Equal(t, domain.WebhookDeliveryStatusPending, delivery.Status)
+			assert.Equal(t, 10, delivery.MaxAttempts)
+		}
+		assert.NotEqual(t, queued[0].ID, queued[1].ID)
+	})
+
+	t.Run("list error is swallowed", func(t *testing.T) {
+		mockSubRepo, _, publisher := setupWebhookEventPublisherTest(t)
+
+		mockSubRepo.EXPECT().List(ctx, "ws1").Return(nil, errors.New("db down"))
+
+		publisher.Publish(ctx, "ws1", domain.WebhookEventTaskFailed, map[string]interface{}{})
+	})
+
+	t.Run("create error does not stop other deliveries", func(t *testing.T) {
+		mockSubRepo, mockDeliveryRepo, publisher := setupWebhookEventPublisherTest(t)
+
+		mockSubRepo.EXPECT().List(ctx, "ws1").Return([]*domain.WebhookSubscription{
+			{ID: "sub1", Enabled: true, Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"task.failed"}}},
+			{ID: "sub2", Enabled: true, Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"task.failed"}}},
+		}, nil)
+		gomock.InOrder(
+			mockDeliveryRepo.EXPECT().Create(ctx, "ws1", gomock.Any()).Return(errors.New("insert failed")),
+			mockDeliveryRepo.EXPECT().Create(ctx, "ws1", gomock.Any()).Return(nil),
+		)
+
+		publisher.Publish(ctx, "ws1", domain.WebhookEventTaskFailed, map[string]interface{}{})
+	})
+}
+
+func TestWebhookEventPublisher_HandleEmailFailed(t *testing.T) {
+	entry := &domain.EmailQueueEntry{
+		SourceType:   domain.EmailQueueSourceBroadcast,
+		SourceID:     "b1",
+		ContactEmail: "john@example.com",
+		MessageID:    "msg1",
+		TemplateID:   "tpl1",
+		Attempts:     1,
+	}
+	classifiedErr := &emailerror.ClassifiedError{
+		Original:   errors.New("mailbox unavailable"),
+		Type:       emailerror.ErrorTypeRecipient,
+		Provider:   "ses",
+		HTTPStatus: 400,
+	}
+
+	t.Run("retried failures are ignored", func(t *testing.T) {
+		_, _, publisher := setupWebhookEventPublisherTest(t)
+
+		publisher.HandleEmailFailed("ws1", entry, classifiedErr.Original, classifiedErr, false)
+	})
+
+	t.Run("permanent failure publishes email.failed with the classified error", func(t *testing.T) {
+		mockSubRepo, mockDeliveryRepo, publisher := setupWebhookEventPublisherTest(t)
+
+		mockSubRepo.EXPECT().List(gomock.Any(), "ws1").Return([]*domain.WebhookSubscription{
+			{ID: "sub1", Enabled: true, Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"email.failed"}}},
+		}, nil)
+		mockDeliveryRepo.EXPECT().Create(gomock.Any(), "ws1", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, delivery *domain.WebhookDelivery) error {
+				assert.Equal(t, "email.failed", delivery.EventType)
+				assert.Equal(t, "john@example.com", delivery.Payload["email"])
+				assert.Equal(t, "msg1", delivery.Payload["message_id"])
+				assert.Equal(t, "b1", delivery.Payload["broadcast_id"])
+				assert.Equal(t, "mailbox unavailable", delivery.Payload["error"])
+				assert.Equal(t, "recipient", delivery.Payload["error_type"])
+				assert.Equal(t, "ses", delivery.Payload["provider"])
+				assert.Equal(t, 400, delivery.Payload["http_status"])
+				assert.Equal(t, false, delivery.Payload["retryable"])
+				return nil
+			})
+
+		publisher.HandleEmailFailed("ws1", entry, classifiedErr.Original, classifiedErr, true)
+	})
+}
+
+func TestWebhookEventPublisher_HandleCircuitOpened(t *testing.T) {
+	mockSubRepo, mockDeliveryRepo, publisher := setupWebhookEventPublisherTest(t)
+
+	mockSubRepo.EXPECT().List(gomock.Any(), "ws1").Return([]*domain.WebhookSubscription{
+		{ID: "sub1", Enabled: true, Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"integration.circuit_opened"}}},
+	}, nil)
+	mockDeliveryRepo.EXPECT().Create(gomock.Any(), "ws1", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, delivery *domain.WebhookDelivery) error {
+			assert.Equal(t, "integration.circuit_opened", delivery.EventType)
+			assert.Equal(t, "int1", delivery.Payload["integration_id"])
+			assert.Equal(t, "provider", delivery.Payload["error_type"])
+			assert.Equal(t, "service unavailable", delivery.Payload["error"])
+			return nil
+		})
+
+	publisher.HandleCircuitOpened("ws1", "int1", &emailerror.ClassifiedError{
+		Original:   errors.New("service unavailable"),
+		Type:       emailerror.ErrorTypeProvider,
+		Provider:   "ses",
+		HTTPStatus: 503,
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/pkg/logger"
@@ -23,6 +24,7 @@ type WebhookSubscriptionService struct {
 	deliveryRepo domain.WebhookDeliveryRepository
 	authService  *AuthService
 	logger       logger.Logger
+	secretKey    string
 }
 
 // NewWebhookSubscriptionService creates a new webhook subscription service
@@ -31,12 +33,14 @@ func NewWebhookSubscriptionService(
 	deliveryRepo domain.WebhookDeliveryRepository,
 	authService *AuthService,
 	logger logger.Logger,
+	secretKey string,
 ) *WebhookSubscriptionService {
 	return &WebhookSubscriptionService{
 		repo:         repo,
 		deliveryRepo: deliveryRepo,
 		authService:  authService,
 		logger:       logger,
+		secretKey:    secretKey,
 	}
 }
 
@@ -110,14 +114,46 @@ func validateEventTypes(eventTypes []string) error {
 	return nil
 }
 
-// Create creates a new webhook subscription
-func (s *WebhookSubscriptionService) Create(ctx context.Context, workspaceID string, name, webhookURL string, eventTypes []string, customEventFilters *domain.CustomEventFilters) (*domain.WebhookSubscription, error) {
+// validatePayloadFilters validates the optional payload filters
+func validatePayloadFilters(payloadFilters *domain.WebhookPayloadFilters) error {
+	if payloadFilters == nil {
+		return nil
+	}
+	if err := payloadFilters.Validate(); err != nil {
+		return err
+	}
+	if payloadFilters.ContactCondition != nil {
+		if _, _, err := NewQueryBuilder().BuildSQL(payloadFilters.ContactCondition); err != nil {
+			return fmt.Errorf("invalid contact_condition: %w", err)
+		}
+	}
+	return nil
+}
+
+// resolveDestination validates the URL of an HTTP subscription, or the sink settings
+// of a sink subscription whose URL then describes the sink
+func resolveDestination(webhookURL string, sink *domain.EventSinkSettings) (string, error) {
+	if sink == nil {
+		if err := validateURL(webhookURL); err != nil {
+			return "", err
+		}
+		return webhookURL, nil
+	}
+	if err := sink.Validate(); err != nil {
+		return "", fmt.Errorf("invalid sink: %w", err)
+	}
+	return sink.Destination(), nil
+}
+
+// Create creates a new webhook subscription, delivered over HTTP or to a streaming sink
+func (s *WebhookSubscriptionService) Create(ctx context.Context, workspaceID string, name, webhookURL string, eventTypes []string, customEventFilters *domain.CustomEventFilters, payloadFilters *domain.WebhookPayloadFilters, sink *domain.EventSinkSettings) (*domain.WebhookSubscription, error) {
 	// Validate inputs
 	if name == "" {
 		return nil, fmt.Errorf("name is required")
 	}
 
-	if err := validateURL(webhookURL); err != nil {
+	webhookURL, err := resolveDestination(webhookURL, sink)
+	if err != nil {
 		return nil, err
 	}
 
@@ -125,6 +161,16 @@ func (s *WebhookSubscriptionService) Create(ctx context.Context, workspaceID str
 		return nil, err
 	}
 
+	if err := validatePayloadFilters(payloadFilters); err != nil {
+		return nil, err
+	}
+
+	if sink != nil {
+		if err := sink.EncryptSecretKeys(s.secretKey); err != nil {
+			return nil, fmt.Errorf("failed to encrypt sink credentials: %w", err)
+		}
+	}
+
 	// Generate secret
 	secret, err := generateSecret()
 	if err != nil {
@@ -139,6 +185,8 @@ func (s *WebhookSubscriptionService) Create(ctx context.Context, workspaceID str
 		Settings: domain.WebhookSubscriptionSettings{
 			EventTypes:         eventTypes,
 			CustomEventFilters: customEventFilters,
+			PayloadFilters:     payloadFilters,
+			Sink:               sink,
 		},
 		Enabled: true,
 	}
@@ -175,7 +223,7 @@ func (s *WebhookSubscriptionService) List(ctx context.Context, workspaceID strin
 }
 
 // Update updates an existing webhook subscription
-func (s *WebhookSubscriptionService) Update(ctx context.Context, workspaceID string, id, name, webhookURL string, eventTypes []string, customEventFilters *domain.CustomEventFilters, enabled bool) (*domain.WebhookSubscription, error) {
+func (s *WebhookSubscriptionService) Update(ctx context.Context, workspaceID string, id, name, webhookURL string, eventTypes []string, customEventFilters *domain.CustomEventFilters, payloadFilters *domain.WebhookPayloadFilters, sink *domain.EventSinkSettings, enabled bool) (*domain.WebhookSubscription, error) {
 	// Get existing subscription
 	existing, err := s.repo.GetByID(ctx, workspaceID, id)
 	if err != nil {
@@ -187,7 +235,8 @@ func (s *WebhookSubscriptionService) Update(ctx context.Context, workspaceID str
 		return nil, fmt.Errorf("name is required")
 	}
 
-	if err := validateURL(webhookURL); err != nil {
+	webhookURL, err = resolveDestination(webhookURL, sink)
+	if err != nil {
 		return nil, err
 	}
 
@@ -195,14 +244,28 @@ func (s *WebhookSubscriptionService) Update(ctx context.Context, workspaceID str
 		return nil, err
 	}
 
+	if err := validatePayloadFilters(payloadFilters); err != nil {
+		return nil, err
+	}
+
+	if sink != nil {
+		// Credentials are write-only, keep the stored ones when not provided again
+		sink.PreserveSecretKeys(existing.Settings.Sink)
+		if err := sink.EncryptSecretKeys(s.secretKey); err != nil {
+			return nil, fmt.Errorf("failed to encrypt sink credentials: %w", err)
+		}
+	}
+
 	// Update fields
 	existing.Name = name
 	existing.URL = webhookURL
 	existing.Settings = domain.WebhookSubscriptionSettings{
 		EventTypes:         eventTypes,
 		CustomEventFilters: customEventFilters,
+		PayloadFilters:     payloadFilters,
+		Sink:               sink,
 	}
-	existing.Enabled = enabled
+	setSubscriptionEnabled(existing, enabled)
 
 	if err := s.repo.Update(ctx, workspaceID, existing); err != nil {
 		return nil, fmt.Errorf("failed to update webhook subscription: %w", err)
@@ -217,6 +280,18 @@ func (s *WebhookSubscriptionService) Update(ctx context.Context, workspaceID str
 	return existing, nil
 }
 
+// setSubscriptionEnabled sets the enabled flag, re-enabling a disabled subscription
+// resets its failure tracking (mirrors the repository Update query)
+func setSubscriptionEnabled(sub *domain.WebhookSubscription, enabled bool) {
+	if enabled {
+		if !sub.Enabled {
+			sub.FailingSince = nil
+		}
+		sub.DisabledReason = nil
+	}
+	sub.Enabled = enabled
+}
+
 // Delete deletes a webhook subscription
 func (s *WebhookSubscriptionService) Delete(ctx context.Context, workspaceID, id string) error {
 	if err := s.repo.Delete(ctx, workspaceID, id); err != nil {
@@ -239,7 +314,7 @@ func (s *WebhookSubscriptionService) Toggle(ctx context.Context, workspaceID, id
 		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
 	}
 
-	existing.Enabled = enabled
+	setSubscriptionEnabled(existing, enabled)
 
 	if err := s.repo.Update(ctx, workspaceID, existing); err != nil {
 		return nil, fmt.Errorf("failed to toggle webhook subscription: %w", err)
@@ -291,6 +366,59 @@ func (s *WebhookSubscriptionService) GetDeliveries(ctx context.Context, workspac
 	return deliveries, total, nil
 }
 
+// Redeliver queues a delivery to be sent again with a fresh attempt budget
+func (s *WebhookSubscriptionService) Redeliver(ctx context.Context, workspaceID, deliveryID string) (*domain.WebhookDelivery, error) {
+	delivery, err := s.deliveryRepo.GetByID(ctx, workspaceID, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	if delivery.Status == domain.WebhookDeliveryStatusPending || delivery.Status == domain.WebhookDeliveryStatusDelivering {
+		return nil, fmt.Errorf("webhook delivery is already queued")
+	}
+
+	if err := s.deliveryRepo.Requeue(ctx, workspaceID, deliveryID); err != nil {
+		return nil, fmt.Errorf("failed to redeliver webhook delivery: %w", err)
+	}
+
+	delivery.Status = domain.WebhookDeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.DeliveredAt = nil
+
+	s.logger.WithFields(map[string]interface{}{
+		"workspace_id":    workspaceID,
+		"delivery_id":     deliveryID,
+		"subscription_id": delivery.SubscriptionID,
+	}).Info("Requeued webhook delivery")
+
+	return delivery, nil
+}
+
+// RedeliverFailed queues again the deliveries created in [from, to) that failed
+// after exhausting their attempts, optionally for a single subscription
+func (s *WebhookSubscriptionService) RedeliverFailed(ctx context.Context, workspaceID string, subscriptionID *string, from, to time.Time) (int64, error) {
+	if from.IsZero() || to.IsZero() {
+		return 0, fmt.Errorf("from and to are required")
+	}
+	if !from.Before(to) {
+		return 0, fmt.Errorf("from must be before to")
+	}
+
+	count, err := s.deliveryRepo.RequeueFailed(ctx, workspaceID, subscriptionID, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("failed to redeliver webhook deliveries: %w", err)
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"workspace_id": workspaceID,
+		"from":         from.Format(time.RFC3339),
+		"to":           to.Format(time.RFC3339),
+		"count":        count,
+	}).Info("Requeued failed webhook deliveries")
+
+	return count, nil
+}
+
 // GetEventTypes returns the list of available event types
 func (s *WebhookSubscriptionService) GetEventTypes() []string {
 	return domain.WebhookEventTypes
@@ -16,6 +16,9 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// testWebhookSecretKey encrypts the sink credentials in the tests
+const testWebhookSecretKey = "test-secret-key"
+
 func setupWebhookSubscriptionTest(t *testing.T) (
 	*mocks.MockWebhookSubscriptionRepository,
 	*mocks.MockWebhookDeliveryRepository,
@@ -37,7 +40,7 @@ func setupWebhookSubscriptionTest(t *testing.T) (
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
 	// AuthService is not used by WebhookSubscriptionService methods, so pass nil
-	service := NewWebhookSubscriptionService(mockRepo, mockDeliveryRepo, nil, mockLogger)
+	service := NewWebhookSubscriptionService(mockRepo, mockDeliveryRepo, nil, mockLogger, testWebhookSecretKey)
 
 	return mockRepo, mockDeliveryRepo, mockLogger, service, ctrl
 }
@@ -50,7 +53,7 @@ func TestNewWebhookSubscriptionService(t *testing.T) {
 	mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 
-	service := NewWebhookSubscriptionService(mockRepo, mockDeliveryRepo, nil, mockLogger)
+	service := NewWebhookSubscriptionService(mockRepo, mockDeliveryRepo, nil, mockLogger, testWebhookSecretKey)
 
 	require.NotNil(t, service)
 	require.Equal(t, mockRepo, service.repo)
@@ -235,6 +238,8 @@ func TestWebhookSubscriptionService_Create(t *testing.T) {
 				tc.webhookURL,
 				tc.eventTypes,
 				tc.customEventFilters,
+				nil,
+				nil,
 			)
 
 			if tc.expectError {
@@ -560,6 +565,8 @@ func TestWebhookSubscriptionService_Update(t *testing.T) {
 				tc.webhookURL,
 				tc.eventTypes,
 				tc.customEventFilters,
+				nil,
+				nil,
 				tc.enabled,
 			)
 
@@ -980,6 +987,127 @@ func TestWebhookSubscriptionService_GetDeliveries(t *testing.T) {
 	}
 }
 
+func TestWebhookSubscriptionService_Redeliver(t *testing.T) {
+	t.Run("requeues a failed delivery", func(t *testing.T) {
+		_, mockDeliveryRepo, _, service, ctrl := setupWebhookSubscriptionTest(t)
+		defer ctrl.Finish()
+
+		deliveredAt := time.Now()
+		mockDeliveryRepo.EXPECT().
+			GetByID(gomock.Any(), "workspace123", "delivery1").
+			Return(&domain.WebhookDelivery{
+				ID:             "delivery1",
+				SubscriptionID: "sub123",
+				Status:         domain.WebhookDeliveryStatusFailed,
+				Attempts:       10,
+				DeliveredAt:    &deliveredAt,
+			}, nil)
+		mockDeliveryRepo.EXPECT().Requeue(gomock.Any(), "workspace123", "delivery1").Return(nil)
+
+		delivery, err := service.Redeliver(context.Background(), "workspace123", "delivery1")
+		require.NoError(t, err)
+		require.Equal(t, domain.WebhookDeliveryStatusPending, delivery.Status)
+		require.Equal(t, 0, delivery.Attempts)
+		require.Nil(t, delivery.DeliveredAt)
+	})
+
+	t.Run("rejects a delivery already queued", func(t *testing.T) {
+		_, mockDeliveryRepo, _, service, ctrl := setupWebhookSubscriptionTest(t)
+		defer ctrl.Finish()
+
+		mockDeliveryRepo.EXPECT().
+			GetByID(gomock.Any(), "workspace123", "delivery1").
+			Return(&domain.WebhookDelivery{ID: "delivery1", Status: domain.WebhookDeliveryStatusPending}, nil)
+
+		_, err := service.Redeliver(context.Background(), "workspace123", "delivery1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "already queued")
+	})
+
+	t.Run("returns error when delivery not found", func(t *testing.T) {
+		_, mockDeliveryRepo, _, service, ctrl := setupWebhookSubscriptionTest(t)
+		defer ctrl.Finish()
+
+		mockDeliveryRepo.EXPECT().
+			GetByID(gomock.Any(), "workspace123", "missing").
+			Return(nil, errors.New("webhook delivery not found"))
+
+		_, err := service.Redeliver(context.Background(), "workspace123", "missing")
+		require.Error(t, err)
+	})
+}
+
+func TestWebhookSubscriptionService_RedeliverFailed(t *testing.T) {
+	to := time.Now().UTC()
+	from := to.Add(-24 * time.Hour)
+	subID := "sub123"
+
+	t.Run("requeues failed deliveries in range", func(t *testing.T) {
+		_, mockDeliveryRepo, _, service, ctrl := setupWebhookSubscriptionTest(t)
+		defer ctrl.Finish()
+
+		mockDeliveryRepo.EXPECT().
+			RequeueFailed(gomock.Any(), "workspace123", &subID, from, to).
+			Return(int64(12), nil)
+
+		count, err := service.RedeliverFailed(context.Background(), "workspace123", &subID, from, to)
+		require.NoError(t, err)
+		require.Equal(t, int64(12), count)
+	})
+
+	t.Run("rejects missing bounds", func(t *testing.T) {
+		_, _, _, service, ctrl := setupWebhookSubscriptionTest(t)
+		defer ctrl.Finish()
+
+		_, err := service.RedeliverFailed(context.Background(), "workspace123", nil, time.Time{}, to)
+		require.Error(t, err)
+	})
+
+	t.Run("rejects inverted range", func(t *testing.T) {
+		_, _, _, service, ctrl := setupWebhookSubscriptionTest(t)
+		defer ctrl.Finish()
+
+		_, err := service.RedeliverFailed(context.Background(), "workspace123", nil, to, from)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "from must be before to")
+	})
+
+	t.Run("returns repository error", func(t *testing.T) {
+		_, mockDeliveryRepo, _, service, ctrl := setupWebhookSubscriptionTest(t)
+		defer ctrl.Finish()
+
+		mockDeliveryRepo.EXPECT().
+			RequeueFailed(gomock.Any(), "workspace123", nil, from, to).
+			Return(int64(0), errors.New("database error"))
+
+		_, err := service.RedeliverFailed(context.Background(), "workspace123", nil, from, to)
+		require.Error(t, err)
+	})
+}
+
+func TestWebhookSubscriptionService_Toggle_ClearsAutoDisable(t *testing.T) {
+	mockRepo, _, _, service, ctrl := setupWebhookSubscriptionTest(t)
+	defer ctrl.Finish()
+
+	failingSince := time.Now().Add(-80 * time.Hour)
+	reason := "Deliveries failing continuously"
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), "workspace123", "sub123").
+		Return(&domain.WebhookSubscription{
+			ID:             "sub123",
+			Enabled:        false,
+			FailingSince:   &failingSince,
+			DisabledReason: &reason,
+		}, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), "workspace123", gomock.Any()).Return(nil)
+
+	sub, err := service.Toggle(context.Background(), "workspace123", "sub123", true)
+	require.NoError(t, err)
+	require.True(t, sub.Enabled)
+	require.Nil(t, sub.FailingSince)
+	require.Nil(t, sub.DisabledReason)
+}
+
 func TestWebhookSubscriptionService_GetEventTypes(t *testing.T) {
 	_, _, _, service, ctrl := setupWebhookSubscriptionTest(t)
 	defer ctrl.Finish()
@@ -1252,6 +1380,8 @@ func TestWebhookSubscriptionService_Create_SecretGeneration(t *testing.T) {
 			"https://example.com/webhook",
 			[]string{"contact.created"},
 			nil,
+			nil,
+			nil,
 		)
 		require.NoError(t, err)
 	}
@@ -1286,6 +1416,8 @@ func TestWebhookSubscriptionService_Create_IDGeneration(t *testing.T) {
 			"https://example.com/webhook",
 			[]string{"contact.created"},
 			nil,
+			nil,
+			nil,
 		)
 		require.NoError(t, err)
 	}
@@ -1321,6 +1453,8 @@ func TestWebhookSubscriptionService_Create_DefaultValues(t *testing.T) {
 		"https://example.com/webhook",
 		[]string{"contact.created"},
 		nil,
+		nil,
+		nil,
 	)
 	require.NoError(t, err)
 }
@@ -1355,7 +1489,67 @@ func TestWebhookSubscriptionService_Update_PreservesSecret(t *testing.T) {
 		"https://new.example.com/webhook",
 		[]string{"contact.updated"},
 		nil,
+		nil,
+		nil,
 		true,
 	)
 	require.NoError(t, err)
 }
+
+func TestWebhookSubscriptionService_SinkCredentials(t *testing.T) {
+	t.Run("create encrypts the sink credentials", func(t *testing.T) {
+		mockRepo, _, _, service, ctrl := setupWebhookSubscriptionTest(t)
+		defer ctrl.Finish()
+
+		mockRepo.EXPECT().
+			Create(gomock.Any(), "workspace123", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, sub *domain.WebhookSubscription) error {
+				nats := sub.Settings.Sink.NATS
+				assert.Empty(t, nats.Token)
+				require.NotEmpty(t, nats.EncryptedToken)
+				assert.NotContains(t, nats.EncryptedToken, "secret-token")
+				return nil
+			})
+
+		sub, err := service.Create(context.Background(), "workspace123", "Events", "", []string{"contact.created"}, nil, nil, &domain.EventSinkSettings{
+			Type: domain.EventSinkTypeNATS,
+			NATS: &domain.NATSSinkSettings{URL: "nats://localhost:4222", Subject: "notifuse", Token: "secret-token"},
+		})
+		require.NoError(t, err)
+
+		decrypted := sub.Settings.Sink.Clone()
+		require.NoError(t, decrypted.DecryptSecretKeys(testWebhookSecretKey))
+		assert.Equal(t, "secret-token", decrypted.NATS.Token)
+	})
+
+	t.Run("update keeps the stored credentials when not provided", func(t *testing.T) {
+		mockRepo, _, _, service, ctrl := setupWebhookSubscriptionTest(t)
+		defer ctrl.Finish()
+
+		mockRepo.EXPECT().
+			GetByID(gomock.Any(), "workspace123", "sub123").
+			Return(&domain.WebhookSubscription{
+				ID: "sub123",
+				Settings: domain.WebhookSubscriptionSettings{
+					Sink: &domain.EventSinkSettings{
+						Type:  domain.EventSinkTypeKafka,
+						Kafka: &domain.KafkaSinkSettings{Brokers: []string{"localhost:9092"}, Topic: "events", EncryptedPassword: "stored"},
+					},
+				},
+			}, nil)
+
+		mockRepo.EXPECT().
+			Update(gomock.Any(), "workspace123", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, sub *domain.WebhookSubscription) error {
+				assert.Equal(t, "stored", sub.Settings.Sink.Kafka.EncryptedPassword)
+				assert.Equal(t, "renamed", sub.Settings.Sink.Kafka.Topic)
+				return nil
+			})
+
+		_, err := service.Update(context.Background(), "workspace123", "sub123", "Events", "", []string{"contact.created"}, nil, nil, &domain.EventSinkSettings{
+			Type:  domain.EventSinkTypeKafka,
+			Kafka: &domain.KafkaSinkSettings{Brokers: []string{"localhost:9092"}, Topic: "renamed"},
+		}, true)
+		require.NoError(t, err)
+	})
+}
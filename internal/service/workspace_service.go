@@ -35,6 +35,7 @@ type WorkspaceService struct {
 	secretKey              string
 	dnsVerificationService *DNSVerificationService
 	blogService            *BlogService
+	contactRepo            domain.ContactRepository
 }
 
 func NewWorkspaceService(
@@ -366,17 +367,23 @@ func (s *WorkspaceService) UpdateWorkspace(ctx context.Context, id string, name
 	}
 
 	existingWorkspace.Settings.CustomEndpointURL = settings.CustomEndpointURL
-	// Note: Custom field labels and blog settings are intentionally NOT updated here.
-	// They are each managed exclusively via dedicated, permission-checked endpoints
-	// (/api/workspaces.setCustomFieldLabels for labels, /api/workspaces.setBlogSettings
-	// for the blog enable flag + config), which enforce granular permissions
-	// (workspace:write and blog:write respectively) instead of requiring owner role.
-	// This also prevents an owner's (possibly stale) settings save from clobbering
-	// values set by a member. Existing labels and blog settings on existingWorkspace
-	// are preserved as-is.
+	// Note: Custom field labels, computed properties and blog settings are intentionally
+	// NOT updated here. They are each managed exclusively via dedicated, permission-checked
+	// endpoints (/api/workspaces.setCustomFieldLabels for labels,
+	// /api/workspaces.setComputedProperties for computed properties,
+	// /api/workspaces.setBlogSettings for the blog enable flag + config), which enforce
+	// granular permissions (workspace:write and blog:write respectively) instead of
+	// requiring owner role. This also prevents an owner's (possibly stale) settings save
+	// from clobbering values set by a member. Existing labels, computed properties and
+	// blog settings on existingWorkspace are preserved as-is.
 	existingWorkspace.Settings.DefaultLanguage = settings.DefaultLanguage
 	existingWorkspace.Settings.Languages = settings.Languages
 
+	// Email verification settings are only replaced when provided
+	if settings.EmailVerification != nil {
+		existingWorkspace.Settings.EmailVerification = settings.EmailVerification
+	}
+
 	// Handle template blocks - preserve existing blocks if not provided in update
 	// Note: Template blocks should be managed via dedicated /api/templateBlocks.* endpoints
 	// which support granular template permissions instead of requiring owner role.
@@ -845,6 +852,124 @@ func (s *WorkspaceService) SetCustomFieldLabels(ctx context.Context, workspaceID
 	return nil
 }
 
+// SetComputedProperties replaces the workspace computed contact property definitions
+// and restarts the compute_contact_properties task so the new definitions are applied
+// to every contact right away.
+func (s *WorkspaceService) SetComputedProperties(ctx context.Context, workspaceID string, props []domain.ComputedProperty) error {
+	var userWorkspace *domain.UserWorkspace
+	var err error
+	ctx, _, userWorkspace, err = s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Check permission for writing workspace settings
+	if !userWorkspace.HasPermission(domain.PermissionResourceWorkspace, domain.PermissionTypeWrite) {
+		return domain.NewPermissionError(
+			domain.PermissionResourceWorkspace,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to workspace required",
+		)
+	}
+
+	existingWorkspace, err := s.repo.GetByID(ctx, workspaceID)
+	if err != nil {
+		s.logger.WithField("workspace_id", workspaceID).WithField("error", err.Error()).Error("Failed to get existing workspace")
+		return err
+	}
+
+	if err := domain.ValidateComputedProperties(props); err != nil {
+		return err
+	}
+
+	existingWorkspace.Settings.ComputedProperties = props
+	existingWorkspace.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.Update(ctx, existingWorkspace); err != nil {
+		s.logger.WithField("workspace_id", workspaceID).WithField("error", err.Error()).Error("Failed to update computed properties")
+		return err
+	}
+
+	// Don't fail the update if the task can't be scheduled - values refresh on the next daily run
+	if err := EnsureContactPropertiesTask(ctx, s.taskRepo, workspaceID); err != nil {
+		s.logger.WithField("workspace_id", workspaceID).WithField("error", err.Error()).Error("Failed to schedule contact properties task")
+	}
+
+	return nil
+}
+
+// SetContactRepo injects the contact repository used to backfill attributes mapped
+// onto legacy custom fields and to index filterable attributes. Optional; when
+// unset both steps are skipped.
+func (s *WorkspaceService) SetContactRepo(repo domain.ContactRepository) {
+	s.contactRepo = repo
+}
+
+// SetContactAttributes replaces the workspace contact attribute registry. Attributes
+// newly mapped onto a legacy custom field are backfilled from it, and expression
+// indexes are created or dropped to match the filterable attributes.
+func (s *WorkspaceService) SetContactAttributes(ctx context.Context, workspaceID string, attrs []domain.ContactAttribute) error {
+	var userWorkspace *domain.UserWorkspace
+	var err error
+	ctx, _, userWorkspace, err = s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Check permission for writing workspace settings
+	if !userWorkspace.HasPermission(domain.PermissionResourceWorkspace, domain.PermissionTypeWrite) {
+		return domain.NewPermissionError(
+			domain.PermissionResourceWorkspace,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to workspace required",
+		)
+	}
+
+	existingWorkspace, err := s.repo.GetByID(ctx, workspaceID)
+	if err != nil {
+		s.logger.WithField("workspace_id", workspaceID).WithField("error", err.Error()).Error("Failed to get existing workspace")
+		return err
+	}
+
+	if err := domain.ValidateContactAttributes(attrs); err != nil {
+		return err
+	}
+
+	previousMappings := make(map[string]string, len(existingWorkspace.Settings.ContactAttributes))
+	for _, attr := range existingWorkspace.Settings.ContactAttributes {
+		previousMappings[attr.Key] = attr.LegacyField
+	}
+
+	existingWorkspace.Settings.ContactAttributes = attrs
+	existingWorkspace.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.Update(ctx, existingWorkspace); err != nil {
+		s.logger.WithField("workspace_id", workspaceID).WithField("error", err.Error()).Error("Failed to update contact attributes")
+		return err
+	}
+
+	if s.contactRepo == nil {
+		return nil
+	}
+
+	for _, attr := range attrs {
+		if attr.LegacyField == "" || previousMappings[attr.Key] == attr.LegacyField {
+			continue
+		}
+		if _, err := s.contactRepo.BackfillContactAttribute(ctx, workspaceID, attr); err != nil {
+			s.logger.WithField("workspace_id", workspaceID).WithField("attribute", attr.Key).WithField("error", err.Error()).Error("Failed to backfill contact attribute")
+			return fmt.Errorf("failed to backfill contact attribute %s: %w", attr.Key, err)
+		}
+	}
+
+	if err := s.contactRepo.SyncContactAttributeIndexes(ctx, workspaceID, attrs); err != nil {
+		s.logger.WithField("workspace_id", workspaceID).WithField("error", err.Error()).Error("Failed to sync contact attribute indexes")
+		return fmt.Errorf("failed to sync contact attribute indexes: %w", err)
+	}
+
+	return nil
+}
+
 // SetBlogSettings updates the workspace-level blog configuration (the enable flag
 // plus title/SEO/pagination/feed settings). Unlike UpdateWorkspace (owner-only),
 // this is gated on the granular blog:write permission so a delegated blog manager
@@ -1481,6 +1606,16 @@ func (s *WorkspaceService) UpdateIntegration(ctx context.Context, req domain.Upd
 				updatedIntegration.LLMProvider.Gemini.EncryptedAPIKey =
 					existingIntegration.LLMProvider.Gemini.EncryptedAPIKey
 			}
+
+			// Preserve Ollama encrypted API key if not provided in update
+			if req.LLMProvider.Ollama != nil &&
+				req.LLMProvider.Ollama.APIKey == "" &&
+				req.LLMProvider.Ollama.EncryptedAPIKey == "" &&
+				existingIntegration.LLMProvider != nil &&
+				existingIntegration.LLMProvider.Ollama != nil {
+				updatedIntegration.LLMProvider.Ollama.EncryptedAPIKey =
+					existingIntegration.LLMProvider.Ollama.EncryptedAPIKey
+			}
 		} else {
 			// If no settings provided, preserve existing
 			updatedIntegration.LLMProvider = existingIntegration.LLMProvider
@@ -977,6 +977,245 @@ func TestWorkspaceService_SetCustomFieldLabels(t *testing.T) {
 	})
 }
 
+func TestWorkspaceService_SetComputedProperties(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+
+	service := NewWorkspaceService(
+		mockRepo,
+		mocks.NewMockUserRepository(ctrl),
+		mockTaskRepo,
+		mockLogger,
+		mocks.NewMockUserServiceInterface(ctrl),
+		mockAuthService,
+		pkgmocks.NewMockMailer(ctrl),
+		&config.Config{RootEmail: "test@example.com"},
+		mocks.NewMockContactService(ctrl),
+		mocks.NewMockListService(ctrl),
+		mocks.NewMockContactListService(ctrl),
+		mocks.NewMockTemplateService(ctrl),
+		mocks.NewMockWebhookRegistrationService(ctrl),
+		"secret_key",
+		&SupabaseService{},
+		&DNSVerificationService{},
+		&BlogService{},
+	)
+
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	ctx := context.Background()
+	workspaceID := "testworkspace"
+	userID := "testuser"
+	ownerWorkspace := &domain.UserWorkspace{UserID: userID, WorkspaceID: workspaceID, Role: "owner"}
+	props := []domain.ComputedProperty{
+		{Key: "orders_count", Source: domain.ComputedPropertySourceCustomEvents, Aggregate: domain.ComputedPropertyAggregateCount, EventName: "order_completed"},
+	}
+
+	t.Run("owner can set properties and the task is scheduled", func(t *testing.T) {
+		existing := &domain.Workspace{ID: workspaceID, Name: "WS", Settings: domain.WorkspaceSettings{Timezone: "UTC", CustomFieldLabels: map[string]string{"custom_string_1": "Company"}}}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, ownerWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(existing, nil)
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, ws *domain.Workspace) error {
+			assert.Equal(t, props, ws.Settings.ComputedProperties)
+			assert.Equal(t, "Company", ws.Settings.CustomFieldLabels["custom_string_1"])
+			return nil
+		})
+		mockTaskRepo.EXPECT().List(ctx, workspaceID, gomock.Any()).Return(nil, 0, nil)
+		mockTaskRepo.EXPECT().Create(ctx, workspaceID, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, task *domain.Task) error {
+			assert.Equal(t, "compute_contact_properties", task.Type)
+			return nil
+		})
+
+		err := service.SetComputedProperties(ctx, workspaceID, props)
+		require.NoError(t, err)
+	})
+
+	t.Run("task scheduling failure does not fail the update", func(t *testing.T) {
+		existing := &domain.Workspace{ID: workspaceID, Name: "WS", Settings: domain.WorkspaceSettings{Timezone: "UTC"}}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, ownerWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(existing, nil)
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		mockTaskRepo.EXPECT().List(ctx, workspaceID, gomock.Any()).Return(nil, 0, assert.AnError)
+
+		err := service.SetComputedProperties(ctx, workspaceID, props)
+		require.NoError(t, err)
+	})
+
+	t.Run("member with workspace read only is denied", func(t *testing.T) {
+		memberWorkspace := &domain.UserWorkspace{
+			UserID:      userID,
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceWorkspace: {Read: true, Write: false},
+			},
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, memberWorkspace, nil)
+
+		err := service.SetComputedProperties(ctx, workspaceID, props)
+		require.Error(t, err)
+		var permErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permErr)
+	})
+
+	t.Run("invalid property is rejected before update", func(t *testing.T) {
+		existing := &domain.Workspace{ID: workspaceID, Name: "WS", Settings: domain.WorkspaceSettings{Timezone: "UTC"}}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, ownerWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(existing, nil)
+		// No Update expected — validation fails first.
+
+		err := service.SetComputedProperties(ctx, workspaceID, []domain.ComputedProperty{
+			{Key: "Invalid", Source: domain.ComputedPropertySourceCustomEvents, Aggregate: domain.ComputedPropertyAggregateCount},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "key must start with a lowercase letter")
+	})
+
+	t.Run("update error is propagated", func(t *testing.T) {
+		existing := &domain.Workspace{ID: workspaceID, Name: "WS", Settings: domain.WorkspaceSettings{Timezone: "UTC"}}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, ownerWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(existing, nil)
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(assert.AnError)
+
+		err := service.SetComputedProperties(ctx, workspaceID, props)
+		require.Error(t, err)
+	})
+}
+
+func TestWorkspaceService_SetContactAttributes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+
+	service := NewWorkspaceService(
+		mockRepo,
+		mocks.NewMockUserRepository(ctrl),
+		mocks.NewMockTaskRepository(ctrl),
+		mockLogger,
+		mocks.NewMockUserServiceInterface(ctrl),
+		mockAuthService,
+		pkgmocks.NewMockMailer(ctrl),
+		&config.Config{RootEmail: "test@example.com"},
+		mocks.NewMockContactService(ctrl),
+		mocks.NewMockListService(ctrl),
+		mocks.NewMockContactListService(ctrl),
+		mocks.NewMockTemplateService(ctrl),
+		mocks.NewMockWebhookRegistrationService(ctrl),
+		"secret_key",
+		&SupabaseService{},
+		&DNSVerificationService{},
+		&BlogService{},
+	)
+	service.SetContactRepo(mockContactRepo)
+
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	ctx := context.Background()
+	workspaceID := "testworkspace"
+	userID := "testuser"
+	ownerWorkspace := &domain.UserWorkspace{UserID: userID, WorkspaceID: workspaceID, Role: "owner"}
+	plan := domain.ContactAttribute{Key: "plan", Type: domain.ContactAttributeTypeString, LegacyField: "custom_string_1", Filterable: true}
+	seats := domain.ContactAttribute{Key: "seats", Type: domain.ContactAttributeTypeNumber}
+
+	t.Run("new legacy mapping is backfilled and indexes are synced", func(t *testing.T) {
+		existing := &domain.Workspace{ID: workspaceID, Name: "WS", Settings: domain.WorkspaceSettings{Timezone: "UTC"}}
+		attrs := []domain.ContactAttribute{plan, seats}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, ownerWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(existing, nil)
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, ws *domain.Workspace) error {
+			assert.Equal(t, attrs, ws.Settings.ContactAttributes)
+			return nil
+		})
+		mockContactRepo.EXPECT().BackfillContactAttribute(ctx, workspaceID, plan).Return(12, nil)
+		mockContactRepo.EXPECT().SyncContactAttributeIndexes(ctx, workspaceID, attrs).Return(nil)
+
+		err := service.SetContactAttributes(ctx, workspaceID, attrs)
+		require.NoError(t, err)
+	})
+
+	t.Run("unchanged legacy mapping is not backfilled again", func(t *testing.T) {
+		existing := &domain.Workspace{ID: workspaceID, Name: "WS", Settings: domain.WorkspaceSettings{Timezone: "UTC", ContactAttributes: []domain.ContactAttribute{plan}}}
+		attrs := []domain.ContactAttribute{plan}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, ownerWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(existing, nil)
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		mockContactRepo.EXPECT().SyncContactAttributeIndexes(ctx, workspaceID, attrs).Return(nil)
+
+		err := service.SetContactAttributes(ctx, workspaceID, attrs)
+		require.NoError(t, err)
+	})
+
+	t.Run("backfill error is propagated", func(t *testing.T) {
+		existing := &domain.Workspace{ID: workspaceID, Name: "WS", Settings: domain.WorkspaceSettings{Timezone: "UTC"}}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, ownerWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(existing, nil)
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		mockContactRepo.EXPECT().BackfillContactAttribute(ctx, workspaceID, plan).Return(0, assert.AnError)
+
+		err := service.SetContactAttributes(ctx, workspaceID, []domain.ContactAttribute{plan})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("member with workspace read only is denied", func(t *testing.T) {
+		memberWorkspace := &domain.UserWorkspace{
+			UserID:      userID,
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceWorkspace: {Read: true, Write: false},
+			},
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, memberWorkspace, nil)
+
+		err := service.SetContactAttributes(ctx, workspaceID, []domain.ContactAttribute{seats})
+		require.Error(t, err)
+		var permErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permErr)
+	})
+
+	t.Run("duplicate keys are rejected before update", func(t *testing.T) {
+		existing := &domain.Workspace{ID: workspaceID, Name: "WS", Settings: domain.WorkspaceSettings{Timezone: "UTC"}}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, ownerWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(existing, nil)
+
+		err := service.SetContactAttributes(ctx, workspaceID, []domain.ContactAttribute{seats, seats})
+		require.Error(t, err)
+	})
+
+	t.Run("filterable datetime is rejected before update", func(t *testing.T) {
+		existing := &domain.Workspace{ID: workspaceID, Name: "WS", Settings: domain.WorkspaceSettings{Timezone: "UTC"}}
+		renewal := domain.ContactAttribute{Key: "renewal_at", Type: domain.ContactAttributeTypeDatetime, Filterable: true}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, ownerWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(existing, nil)
+
+		err := service.SetContactAttributes(ctx, workspaceID, []domain.ContactAttribute{renewal})
+		assert.ErrorContains(t, err, "filterable is only supported")
+	})
+}
+
 func TestWorkspaceService_SetBlogSettings(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -2352,6 +2591,67 @@ func TestWorkspaceService_UpdateIntegration(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("successful update ollama LLM integration preserves API key", func(t *testing.T) {
+		ollamaIntegrationID := "ollama123"
+		expectedUser := &domain.User{ID: userID}
+		expectedUserWorkspace := &domain.UserWorkspace{
+			UserID:      userID,
+			WorkspaceID: workspaceID,
+			Role:        "owner",
+		}
+
+		// Existing Ollama LLM integration behind an authenticating proxy
+		existingIntegration := domain.Integration{
+			ID:   ollamaIntegrationID,
+			Name: "Original Ollama",
+			Type: domain.IntegrationTypeLLM,
+			LLMProvider: &domain.LLMProvider{
+				Kind: domain.LLMProviderKindOllama,
+				Ollama: &domain.OllamaSettings{
+					BaseURL:         "https://llm.internal.example.com",
+					Model:           "llama3.1:8b",
+					EncryptedAPIKey: "encrypted-existing-ollama-key",
+				},
+			},
+			CreatedAt: time.Now().Add(-24 * time.Hour),
+			UpdatedAt: time.Now().Add(-24 * time.Hour),
+		}
+
+		expectedWorkspace := &domain.Workspace{
+			ID:           workspaceID,
+			Name:         "Test Workspace",
+			Integrations: []domain.Integration{existingIntegration},
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, expectedUser, expectedUserWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(expectedWorkspace, nil)
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, workspace *domain.Workspace) error {
+			require.Equal(t, 1, len(workspace.Integrations))
+			require.NotNil(t, workspace.Integrations[0].LLMProvider.Ollama)
+			// Model and context window should be updated
+			require.Equal(t, "qwen2.5:14b", workspace.Integrations[0].LLMProvider.Ollama.Model)
+			require.Equal(t, 32768, workspace.Integrations[0].LLMProvider.Ollama.ContextWindow)
+			// API key should be preserved since no new key was provided
+			require.Equal(t, "encrypted-existing-ollama-key", workspace.Integrations[0].LLMProvider.Ollama.EncryptedAPIKey)
+			return nil
+		})
+
+		err := service.UpdateIntegration(ctx, domain.UpdateIntegrationRequest{
+			WorkspaceID:   workspaceID,
+			IntegrationID: ollamaIntegrationID,
+			Name:          "Updated Ollama",
+			LLMProvider: &domain.LLMProvider{
+				Kind: domain.LLMProviderKindOllama,
+				Ollama: &domain.OllamaSettings{
+					BaseURL:       "https://llm.internal.example.com",
+					Model:         "qwen2.5:14b",
+					ContextWindow: 32768,
+				},
+			},
+		})
+		require.NoError(t, err)
+	})
+
 	t.Run("successful update firecrawl integration replaces API key", func(t *testing.T) {
 		firecrawlIntegrationID := "firecrawl456"
 		expectedUser := &domain.User{
@@ -0,0 +1,191 @@
+package captcha
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported third-party captcha providers
+const (
+	ProviderTurnstile = "turnstile"
+	ProviderHCaptcha  = "hcaptcha"
+	ProviderRecaptcha = "recaptcha"
+)
+
+// verifyURLs are the siteverify endpoints of the supported providers. They all take
+// the same form parameters (secret, response, remoteip) and return {"success": bool}.
+var verifyURLs = map[string]string{
+	ProviderTurnstile: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+	ProviderHCaptcha:  "https://api.hcaptcha.com/siteverify",
+	ProviderRecaptcha: "https://www.google.com/recaptcha/api/siteverify",
+}
+
+// Verifier checks captcha tokens produced by a third-party widget
+type Verifier interface {
+	Verify(ctx context.Context, provider, secret, token, remoteIP string) error
+}
+
+// HTTPVerifier verifies tokens against the provider siteverify endpoints
+type HTTPVerifier struct {
+	client *http.Client
+	// urls can be overridden in tests
+	urls map[string]string
+}
+
+// NewHTTPVerifier creates a verifier calling the provider APIs with the given client
+func NewHTTPVerifier(client *http.Client) *HTTPVerifier {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &HTTPVerifier{client: client, urls: verifyURLs}
+}
+
+// IsSupportedProvider returns true if the provider can be verified by HTTPVerifier
+func IsSupportedProvider(provider string) bool {
+	_, ok := verifyURLs[provider]
+	return ok
+}
+
+// Verify returns an error if the token is missing or rejected by the provider
+func (v *HTTPVerifier) Verify(ctx context.Context, provider, secret, token, remoteIP string) error {
+	endpoint, ok := v.urls[provider]
+	if !ok {
+		return fmt.Errorf("unsupported captcha provider: %s", provider)
+	}
+	if token == "" {
+		return fmt.Errorf("captcha token is required")
+	}
+
+	form := url.Values{}
+	form.Set("secret", secret)
+	form.Set("response", token)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create captcha verification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to verify captcha: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("captcha verification failed with status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode captcha verification response: %w", err)
+	}
+	if !result.Success {
+		return fmt.Errorf("captcha rejected: %s", strings.Join(result.ErrorCodes, ", "))
+	}
+	return nil
+}
+
+// Proof-of-work difficulty bounds, in leading zero bits of the solution hash
+const (
+	MinDifficulty     = 8
+	MaxDifficulty     = 24
+	DefaultDifficulty = 16
+)
+
+// NewChallenge issues a stateless proof-of-work challenge bound to scope. The challenge
+// has the form "<expires>.<salt>.<difficulty>.<signature>"; clients must find a nonce
+// such that sha256(challenge + ":" + nonce) starts with difficulty zero bits.
+func NewChallenge(secret, scope string, difficulty int, ttl time.Duration, now time.Time) (string, time.Time, error) {
+	if difficulty < MinDifficulty || difficulty > MaxDifficulty {
+		return "", time.Time{}, fmt.Errorf("difficulty must be between %d and %d", MinDifficulty, MaxDifficulty)
+	}
+
+	salt := make([]byte, 12)
+	if _, err := rand.Read(salt); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+
+	expiresAt := now.Add(ttl).UTC().Truncate(time.Second)
+	payload := fmt.Sprintf("%d.%s.%d", expiresAt.Unix(), hex.EncodeToString(salt), difficulty)
+	return payload + "." + sign(secret, scope, payload), expiresAt, nil
+}
+
+// VerifySolution checks that challenge was issued for scope, has not expired and that
+// nonce solves it. It returns the challenge expiry so callers can reject replays until then.
+func VerifySolution(secret, scope, challenge, nonce string, now time.Time) (time.Time, error) {
+	parts := strings.Split(challenge, ".")
+	if len(parts) != 4 || nonce == "" || len(nonce) > 32 {
+		return time.Time{}, fmt.Errorf("invalid proof of work")
+	}
+
+	payload := strings.Join(parts[:3], ".")
+	if !hmac.Equal([]byte(parts[3]), []byte(sign(secret, scope, payload))) {
+		return time.Time{}, fmt.Errorf("invalid proof of work signature")
+	}
+
+	expires, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid proof of work")
+	}
+	expiresAt := time.Unix(expires, 0).UTC()
+	if now.After(expiresAt) {
+		return time.Time{}, fmt.Errorf("proof of work challenge expired")
+	}
+
+	difficulty, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid proof of work")
+	}
+
+	if leadingZeroBits(sha256.Sum256([]byte(challenge+":"+nonce))) < difficulty {
+		return time.Time{}, fmt.Errorf("proof of work does not meet difficulty")
+	}
+	return expiresAt, nil
+}
+
+// Solve finds a nonce for challenge. It is used by tests and server-side clients,
+// browsers run the equivalent loop in JavaScript.
+func Solve(challenge string, difficulty int) string {
+	for i := 0; ; i++ {
+		nonce := strconv.Itoa(i)
+		if leadingZeroBits(sha256.Sum256([]byte(challenge+":"+nonce))) >= difficulty {
+			return nonce
+		}
+	}
+}
+
+func sign(secret, scope, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(scope + "|" + payload))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+func leadingZeroBits(sum [32]byte) int {
+	count := 0
+	for _, b := range sum {
+		if b == 0 {
+			count += 8
+			continue
+		}
+		return count + bits.LeadingZeros8(b)
+	}
+	return count
+}
@@ -0,0 +1,107 @@
+package captcha
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofOfWork(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	challenge, expiresAt, err := NewChallenge("secret", "ws:form", MinDifficulty, 5*time.Minute, now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(5*time.Minute), expiresAt)
+	assert.Len(t, strings.Split(challenge, "."), 4)
+
+	nonce := Solve(challenge, MinDifficulty)
+
+	t.Run("valid solution", func(t *testing.T) {
+		got, err := VerifySolution("secret", "ws:form", challenge, nonce, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, expiresAt, got)
+	})
+
+	t.Run("other scope", func(t *testing.T) {
+		_, err := VerifySolution("secret", "ws:other", challenge, nonce, now)
+		assert.ErrorContains(t, err, "signature")
+	})
+
+	t.Run("other secret", func(t *testing.T) {
+		_, err := VerifySolution("other", "ws:form", challenge, nonce, now)
+		assert.ErrorContains(t, err, "signature")
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		_, err := VerifySolution("secret", "ws:form", challenge, nonce, now.Add(6*time.Minute))
+		assert.ErrorContains(t, err, "expired")
+	})
+
+	t.Run("tampered difficulty", func(t *testing.T) {
+		parts := strings.Split(challenge, ".")
+		parts[2] = "0"
+		_, err := VerifySolution("secret", "ws:form", strings.Join(parts, "."), nonce, now)
+		assert.ErrorContains(t, err, "signature")
+	})
+
+	t.Run("wrong nonce", func(t *testing.T) {
+		// find a nonce that does not solve the challenge
+		for i := 0; ; i++ {
+			candidate := "x" + string(rune('a'+i%26)) + strings.Repeat("y", i/26)
+			if _, err := VerifySolution("secret", "ws:form", challenge, candidate, now); err != nil {
+				assert.ErrorContains(t, err, "difficulty")
+				return
+			}
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := VerifySolution("secret", "ws:form", "abc", nonce, now)
+		assert.Error(t, err)
+		_, err = VerifySolution("secret", "ws:form", challenge, "", now)
+		assert.Error(t, err)
+	})
+
+	t.Run("difficulty bounds", func(t *testing.T) {
+		_, _, err := NewChallenge("secret", "ws:form", MaxDifficulty+1, time.Minute, now)
+		assert.Error(t, err)
+		_, _, err = NewChallenge("secret", "ws:form", MinDifficulty-1, time.Minute, now)
+		assert.Error(t, err)
+	})
+}
+
+func TestHTTPVerifier_Verify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "secret", r.PostForm.Get("secret"))
+		assert.Equal(t, "1.2.3.4", r.PostForm.Get("remoteip"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("response") == "good" {
+			_, _ = w.Write([]byte(`{"success": true}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success": false, "error-codes": ["invalid-input-response"]}`))
+	}))
+	defer server.Close()
+
+	verifier := NewHTTPVerifier(server.Client())
+	verifier.urls = map[string]string{ProviderTurnstile: server.URL}
+
+	assert.NoError(t, verifier.Verify(context.Background(), ProviderTurnstile, "secret", "good", "1.2.3.4"))
+	assert.ErrorContains(t, verifier.Verify(context.Background(), ProviderTurnstile, "secret", "bad", "1.2.3.4"), "invalid-input-response")
+	assert.ErrorContains(t, verifier.Verify(context.Background(), ProviderTurnstile, "secret", "", "1.2.3.4"), "token is required")
+	assert.ErrorContains(t, verifier.Verify(context.Background(), "unknown", "secret", "good", ""), "unsupported")
+}
+
+func TestIsSupportedProvider(t *testing.T) {
+	assert.True(t, IsSupportedProvider(ProviderTurnstile))
+	assert.True(t, IsSupportedProvider(ProviderHCaptcha))
+	assert.True(t, IsSupportedProvider(ProviderRecaptcha))
+	assert.False(t, IsSupportedProvider("none"))
+}
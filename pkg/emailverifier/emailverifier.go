@@ -0,0 +1,434 @@
+package emailverifier
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Notifuse/notifuse/pkg/cache"
+	"github.com/Notifuse/notifuse/pkg/disposable_emails"
+)
+
+// Verification statuses, from the most to the least deliverable
+const (
+	StatusValid   = "valid"
+	StatusRisky   = "risky"
+	StatusUnknown = "unknown"
+	StatusInvalid = "invalid"
+)
+
+// Reasons explaining why an address is not considered valid
+const (
+	ReasonInvalidSyntax   = "invalid_syntax"
+	ReasonNoMX            = "no_mx"
+	ReasonDNSError        = "dns_error"
+	ReasonRoleAccount     = "role_account"
+	ReasonDisposable      = "disposable"
+	ReasonPossibleTypo    = "possible_typo"
+	ReasonMailboxRejected = "mailbox_rejected"
+	ReasonCatchAll        = "catch_all"
+	ReasonSMTPUnreachable = "smtp_unreachable"
+)
+
+// reasonStatuses maps every reason to the status it implies
+var reasonStatuses = map[string]string{
+	ReasonInvalidSyntax:   StatusInvalid,
+	ReasonNoMX:            StatusInvalid,
+	ReasonMailboxRejected: StatusInvalid,
+	ReasonRoleAccount:     StatusRisky,
+	ReasonDisposable:      StatusRisky,
+	ReasonPossibleTypo:    StatusRisky,
+	ReasonCatchAll:        StatusRisky,
+	ReasonDNSError:        StatusUnknown,
+	ReasonSMTPUnreachable: StatusUnknown,
+}
+
+// statusPriority ranks statuses so that the worst reason wins
+var statusPriority = map[string]int{
+	StatusValid:   0,
+	StatusUnknown: 1,
+	StatusRisky:   2,
+	StatusInvalid: 3,
+}
+
+// domainCacheTTL is how long DNS lookups of a domain are reused
+const domainCacheTTL = 30 * time.Minute
+
+// Resolver performs the DNS lookups needed to verify a domain.
+// *net.Resolver satisfies this interface.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// Prober asks a mail server whether it accepts the given recipients.
+// It returns one entry per recipient, true when the RCPT command was accepted.
+type Prober interface {
+	Probe(ctx context.Context, host string, recipients []string) ([]bool, error)
+}
+
+// Options controls the checks performed by Verify
+type Options struct {
+	// SMTPProbe connects to the mail server of the domain to check the mailbox
+	SMTPProbe bool
+}
+
+// Result is the outcome of an address verification
+type Result struct {
+	Email       string   `json:"email"`
+	Status      string   `json:"status"`
+	Reasons     []string `json:"reasons,omitempty"`
+	Suggestion  string   `json:"suggestion,omitempty"`
+	HasMX       bool     `json:"has_mx"`
+	RoleAccount bool     `json:"role_account"`
+	Disposable  bool     `json:"disposable"`
+	CatchAll    bool     `json:"catch_all"`
+}
+
+func (r *Result) addReason(reason string) {
+	r.Reasons = append(r.Reasons, reason)
+	if statusPriority[reasonStatuses[reason]] > statusPriority[r.Status] {
+		r.Status = reasonStatuses[reason]
+	}
+}
+
+// Verifier checks email addresses for syntax, domain and mailbox problems
+type Verifier struct {
+	resolver Resolver
+	prober   Prober
+	cache    cache.Cache
+}
+
+// New creates a verifier. A nil resolver uses the system resolver and a nil
+// prober uses an SMTPProber announcing itself as localhost.
+func New(resolver Resolver, prober Prober) *Verifier {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	if prober == nil {
+		prober = NewSMTPProber("localhost", "")
+	}
+	return &Verifier{
+		resolver: resolver,
+		prober:   prober,
+		cache:    cache.NewInMemoryCache(domainCacheTTL),
+	}
+}
+
+// Verify runs every check on the address and never returns nil
+func (v *Verifier) Verify(ctx context.Context, email string, opts Options) *Result {
+	email = strings.TrimSpace(email)
+	result := &Result{Email: email, Status: StatusValid}
+
+	local, domain, err := CheckSyntax(email)
+	if err != nil {
+		result.addReason(ReasonInvalidSyntax)
+		return result
+	}
+	domain = strings.ToLower(domain)
+
+	if IsRoleAccount(local) {
+		result.RoleAccount = true
+		result.addReason(ReasonRoleAccount)
+	}
+	if disposable_emails.IsDisposableEmail(domain) {
+		result.Disposable = true
+		result.addReason(ReasonDisposable)
+	}
+	// Disposable domains are often one edit away from a provider on purpose
+	if suggestion := SuggestDomain(domain); suggestion != "" && !result.Disposable {
+		result.Suggestion = local + "@" + suggestion
+		result.addReason(ReasonPossibleTypo)
+	}
+
+	info := v.lookupDomain(ctx, domain)
+	if info.reason != "" {
+		result.addReason(info.reason)
+		return result
+	}
+	result.HasMX = info.hasMX
+
+	if opts.SMTPProbe {
+		v.probe(ctx, result, local, domain, info.hosts)
+	}
+
+	return result
+}
+
+// probe checks the mailbox and, in the same session, a random address of the
+// domain: a server accepting both accepts everything and proves nothing.
+func (v *Verifier) probe(ctx context.Context, result *Result, local, domain string, hosts []string) {
+	recipients := []string{local + "@" + domain, randomLocalPart() + "@" + domain}
+
+	// Only try the two most preferred hosts to bound the time spent
+	if len(hosts) > 2 {
+		hosts = hosts[:2]
+	}
+
+	for _, host := range hosts {
+		accepted, err := v.prober.Probe(ctx, host, recipients)
+		if err != nil || len(accepted) != len(recipients) {
+			continue
+		}
+
+		switch {
+		case !accepted[0]:
+			result.addReason(ReasonMailboxRejected)
+		case accepted[1]:
+			result.CatchAll = true
+			result.addReason(ReasonCatchAll)
+		}
+		return
+	}
+
+	result.addReason(ReasonSMTPUnreachable)
+}
+
+// domainInfo is the cached outcome of the DNS lookups of a domain
+type domainInfo struct {
+	hosts  []string
+	hasMX  bool
+	reason string
+}
+
+func (v *Verifier) lookupDomain(ctx context.Context, domain string) domainInfo {
+	if cached, ok := v.cache.Get(domain); ok {
+		return cached.(domainInfo)
+	}
+
+	info := v.resolveDomain(ctx, domain)
+
+	// Temporary failures are retried on the next verification
+	if info.reason != ReasonDNSError {
+		v.cache.Set(domain, info, domainCacheTTL)
+	}
+	return info
+}
+
+func (v *Verifier) resolveDomain(ctx context.Context, domain string) domainInfo {
+	mxs, err := v.resolver.LookupMX(ctx, domain)
+	if err != nil && !isNotFound(err) {
+		return domainInfo{reason: ReasonDNSError}
+	}
+
+	if len(mxs) > 0 {
+		// A single "." record is a null MX (RFC 7505): the domain accepts no mail
+		if len(mxs) == 1 && strings.TrimSuffix(mxs[0].Host, ".") == "" {
+			return domainInfo{reason: ReasonNoMX}
+		}
+
+		sort.SliceStable(mxs, func(i, j int) bool { return mxs[i].Pref < mxs[j].Pref })
+
+		hosts := make([]string, 0, len(mxs))
+		for _, mx := range mxs {
+			if host := strings.TrimSuffix(mx.Host, "."); host != "" {
+				hosts = append(hosts, host)
+			}
+		}
+		return domainInfo{hosts: hosts, hasMX: true}
+	}
+
+	// Without MX records the domain itself is the implicit mail server (RFC 5321 section 5.1)
+	addrs, err := v.resolver.LookupHost(ctx, domain)
+	if err != nil {
+		if isNotFound(err) {
+			return domainInfo{reason: ReasonNoMX}
+		}
+		return domainInfo{reason: ReasonDNSError}
+	}
+	if len(addrs) == 0 {
+		return domainInfo{reason: ReasonNoMX}
+	}
+
+	return domainInfo{hosts: []string{domain}}
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+func randomLocalPart() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return "verify-" + hex.EncodeToString(b)
+}
+
+// CheckSyntax validates a bare address (no display name) against RFC 5322 and
+// the length limits of RFC 5321, and returns its local part and domain
+func CheckSyntax(email string) (local string, domain string, err error) {
+	if len(email) > 254 {
+		return "", "", fmt.Errorf("address exceeds 254 characters")
+	}
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid address: %w", err)
+	}
+	if addr.Name != "" || strings.HasPrefix(email, "<") {
+		return "", "", fmt.Errorf("address must not contain a display name")
+	}
+
+	at := strings.LastIndex(email, "@")
+	local, domain = email[:at], email[at+1:]
+
+	if len(local) > 64 {
+		return "", "", fmt.Errorf("local part exceeds 64 characters")
+	}
+	if strings.HasPrefix(domain, "[") {
+		return "", "", fmt.Errorf("address literals are not supported")
+	}
+
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return "", "", fmt.Errorf("domain must contain a top-level domain")
+	}
+	for _, label := range labels {
+		if !isValidLabel(label) {
+			return "", "", fmt.Errorf("invalid domain label %q", label)
+		}
+	}
+	if isNumeric(labels[len(labels)-1]) {
+		return "", "", fmt.Errorf("top-level domain cannot be numeric")
+	}
+
+	return local, domain, nil
+}
+
+func isValidLabel(label string) bool {
+	if label == "" || len(label) > 63 {
+		return false
+	}
+	if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+		return false
+	}
+	for _, r := range label {
+		if r != '-' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// roleAccounts are local parts addressing a function rather than a person
+var roleAccounts = map[string]bool{
+	"abuse": true, "accounts": true, "accounting": true, "admin": true, "administrator": true,
+	"billing": true, "careers": true, "contact": true, "customerservice": true, "enquiries": true,
+	"feedback": true, "help": true, "hello": true, "hostmaster": true, "hr": true,
+	"info": true, "inquiries": true, "jobs": true, "legal": true, "mail": true,
+	"marketing": true, "media": true, "newsletter": true, "no-reply": true, "noreply": true,
+	"office": true, "postmaster": true, "press": true, "privacy": true, "root": true,
+	"sales": true, "security": true, "support": true, "team": true, "webmaster": true,
+}
+
+// IsRoleAccount returns true if the local part is a role address such as info or admin.
+// Sub-addressing tags (info+tag) are ignored.
+func IsRoleAccount(local string) bool {
+	local = strings.ToLower(local)
+	if i := strings.Index(local, "+"); i >= 0 {
+		local = local[:i]
+	}
+	return roleAccounts[local]
+}
+
+// popularDomains are the mailbox providers typos are matched against
+var popularDomains = []string{
+	"gmail.com", "googlemail.com", "yahoo.com", "yahoo.fr", "yahoo.co.uk", "ymail.com",
+	"hotmail.com", "hotmail.fr", "hotmail.co.uk", "outlook.com", "live.com", "msn.com",
+	"icloud.com", "me.com", "mac.com", "aol.com", "protonmail.com", "proton.me",
+	"gmx.com", "gmx.de", "web.de", "orange.fr", "free.fr", "comcast.net",
+	"zoho.com", "yandex.com", "mail.ru",
+}
+
+// legitimateDomains are real providers close enough to a popular domain to look like a typo
+var legitimateDomains = map[string]bool{
+	"email.com": true, "aim.com": true, "gmx.net": true, "mail.com": true,
+}
+
+// SuggestDomain returns the popular provider the domain is likely a typo of
+// (gmial.com -> gmail.com), or an empty string
+func SuggestDomain(domain string) string {
+	domain = strings.ToLower(domain)
+	if legitimateDomains[domain] {
+		return ""
+	}
+
+	providers := make(map[string]bool, len(popularDomains))
+	names := make(map[string]bool, len(popularDomains))
+	for _, popular := range popularDomains {
+		if popular == domain {
+			return ""
+		}
+		providers[popular] = true
+		names[strings.SplitN(popular, ".", 2)[0]] = true
+	}
+
+	// A provider name with another TLD is usually a regional domain (hotmail.de),
+	// unless the TLD is one edit away from com (gmail.con)
+	name, tld, _ := strings.Cut(domain, ".")
+	if names[name] {
+		if distance(tld, "com") == 1 && providers[name+".com"] {
+			return name + ".com"
+		}
+		return ""
+	}
+
+	best, bestDistance := "", -1
+	for _, popular := range popularDomains {
+		d := distance(domain, popular)
+		if bestDistance < 0 || d < bestDistance {
+			best, bestDistance = popular, d
+		}
+	}
+
+	// Short domains need a closer match to avoid suggesting a provider for unrelated domains
+	if bestDistance > 0 && bestDistance <= 2 && bestDistance*4 <= len(domain) {
+		return best
+	}
+	return ""
+}
+
+// distance is the optimal string alignment distance between a and b:
+// the number of insertions, deletions, substitutions and adjacent transpositions
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
@@ -0,0 +1,379 @@
+package emailverifier
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/Notifuse/notifuse/pkg/safehttpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResolver struct {
+	mx      map[string][]*net.MX
+	hosts   map[string][]string
+	mxErr   error
+	mxCalls int
+}
+
+func (r *fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	r.mxCalls++
+	if r.mxErr != nil {
+		return nil, r.mxErr
+	}
+	if mxs, ok := r.mx[name]; ok {
+		return mxs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+type fakeProber struct {
+	accept    func(recipient string) bool
+	err       error
+	hosts     []string
+	lastRcpts []string
+}
+
+func (p *fakeProber) Probe(ctx context.Context, host string, recipients []string) ([]bool, error) {
+	p.hosts = append(p.hosts, host)
+	p.lastRcpts = recipients
+	if p.err != nil {
+		return nil, p.err
+	}
+	accepted := make([]bool, len(recipients))
+	for i, recipient := range recipients {
+		accepted[i] = p.accept(recipient)
+	}
+	return accepted, nil
+}
+
+func newResolver() *fakeResolver {
+	return &fakeResolver{
+		mx: map[string][]*net.MX{
+			"example.com":   {{Host: "mx2.example.com.", Pref: 20}, {Host: "mx1.example.com.", Pref: 10}},
+			"nullmx.com":    {{Host: ".", Pref: 0}},
+			"gmail.com":     {{Host: "gmail-smtp-in.l.google.com.", Pref: 5}},
+			"gmial.com":     {{Host: "mx.gmial.com.", Pref: 10}},
+			"0-mail.com":    {{Host: "mx.0-mail.com.", Pref: 10}},
+			"catchall.org":  {{Host: "mx.catchall.org.", Pref: 10}},
+			"implicit.test": nil,
+		},
+		hosts: map[string][]string{
+			"amx.net": {"192.0.2.1"},
+		},
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		email      string
+		wantStatus string
+		wantReason []string
+	}{
+		{"valid", "john@example.com", StatusValid, nil},
+		{"invalid syntax", "john@@example.com", StatusInvalid, []string{ReasonInvalidSyntax}},
+		{"unknown domain", "john@nowhere.test", StatusInvalid, []string{ReasonNoMX}},
+		{"null mx", "john@nullmx.com", StatusInvalid, []string{ReasonNoMX}},
+		{"address records fallback", "john@amx.net", StatusValid, nil},
+		{"role account", "Info+news@example.com", StatusRisky, []string{ReasonRoleAccount}},
+		{"disposable", "john@0-mail.com", StatusRisky, []string{ReasonDisposable}},
+		{"typo", "john@gmial.com", StatusRisky, []string{ReasonPossibleTypo}},
+		{"invalid wins over risky", "admin@nowhere.test", StatusInvalid, []string{ReasonRoleAccount, ReasonNoMX}},
+	}
+
+	verifier := New(newResolver(), &fakeProber{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := verifier.Verify(ctx, tt.email, Options{})
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantReason, result.Reasons)
+		})
+	}
+
+	t.Run("suggestion keeps the local part", func(t *testing.T) {
+		result := verifier.Verify(ctx, "john@gmial.com", Options{})
+		assert.Equal(t, "john@gmail.com", result.Suggestion)
+	})
+
+	t.Run("mx flag", func(t *testing.T) {
+		assert.True(t, verifier.Verify(ctx, "john@example.com", Options{}).HasMX)
+		assert.False(t, verifier.Verify(ctx, "john@amx.net", Options{}).HasMX)
+	})
+}
+
+func TestVerifier_DNSCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("definitive results are cached", func(t *testing.T) {
+		resolver := newResolver()
+		verifier := New(resolver, &fakeProber{})
+
+		verifier.Verify(ctx, "a@example.com", Options{})
+		verifier.Verify(ctx, "b@example.com", Options{})
+		assert.Equal(t, 1, resolver.mxCalls)
+	})
+
+	t.Run("temporary errors are not cached", func(t *testing.T) {
+		resolver := newResolver()
+		resolver.mxErr = &net.DNSError{Err: "server misbehaving", IsTemporary: true}
+		verifier := New(resolver, &fakeProber{})
+
+		result := verifier.Verify(ctx, "a@example.com", Options{})
+		assert.Equal(t, StatusUnknown, result.Status)
+		assert.Equal(t, []string{ReasonDNSError}, result.Reasons)
+
+		verifier.Verify(ctx, "b@example.com", Options{})
+		assert.Equal(t, 2, resolver.mxCalls)
+	})
+}
+
+func TestVerifier_SMTPProbe(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("accepted mailbox", func(t *testing.T) {
+		prober := &fakeProber{accept: func(r string) bool { return r == "john@example.com" }}
+		result := New(newResolver(), prober).Verify(ctx, "john@example.com", Options{SMTPProbe: true})
+
+		assert.Equal(t, StatusValid, result.Status)
+		assert.Equal(t, []string{"mx1.example.com"}, prober.hosts)
+		require.Len(t, prober.lastRcpts, 2)
+		assert.True(t, strings.HasSuffix(prober.lastRcpts[1], "@example.com"))
+	})
+
+	t.Run("rejected mailbox", func(t *testing.T) {
+		prober := &fakeProber{accept: func(r string) bool { return false }}
+		result := New(newResolver(), prober).Verify(ctx, "john@example.com", Options{SMTPProbe: true})
+
+		assert.Equal(t, StatusInvalid, result.Status)
+		assert.Equal(t, []string{ReasonMailboxRejected}, result.Reasons)
+	})
+
+	t.Run("catch-all domain", func(t *testing.T) {
+		prober := &fakeProber{accept: func(r string) bool { return true }}
+		result := New(newResolver(), prober).Verify(ctx, "john@catchall.org", Options{SMTPProbe: true})
+
+		assert.Equal(t, StatusRisky, result.Status)
+		assert.True(t, result.CatchAll)
+		assert.Equal(t, []string{ReasonCatchAll}, result.Reasons)
+	})
+
+	t.Run("unreachable servers", func(t *testing.T) {
+		prober := &fakeProber{err: errors.New("connection refused")}
+		result := New(newResolver(), prober).Verify(ctx, "john@example.com", Options{SMTPProbe: true})
+
+		assert.Equal(t, StatusUnknown, result.Status)
+		assert.Equal(t, []string{ReasonSMTPUnreachable}, result.Reasons)
+		assert.Equal(t, []string{"mx1.example.com", "mx2.example.com"}, prober.hosts)
+	})
+
+	t.Run("no probe for invalid domains", func(t *testing.T) {
+		prober := &fakeProber{accept: func(r string) bool { return true }}
+		New(newResolver(), prober).Verify(ctx, "john@nullmx.com", Options{SMTPProbe: true})
+		assert.Empty(t, prober.hosts)
+	})
+}
+
+func TestCheckSyntax(t *testing.T) {
+	valid := []string{
+		"john@example.com",
+		"john.doe+tag@sub.example.co.uk",
+		`"john doe"@example.com`,
+		"jöhn@exämple.com",
+	}
+	for _, email := range valid {
+		_, _, err := CheckSyntax(email)
+		assert.NoError(t, err, email)
+	}
+
+	invalid := []string{
+		"",
+		"john",
+		"john@",
+		"@example.com",
+		"john@localhost",
+		"John <john@example.com>",
+		"john@[192.0.2.1]",
+		"john@-example.com",
+		"john@example..com",
+		"john@example.123",
+		strings.Repeat("a", 65) + "@example.com",
+		"john@" + strings.Repeat("a", 250) + ".com",
+	}
+	for _, email := range invalid {
+		_, _, err := CheckSyntax(email)
+		assert.Error(t, err, email)
+	}
+
+	local, domain, err := CheckSyntax("John.Doe@Example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "John.Doe", local)
+	assert.Equal(t, "Example.com", domain)
+}
+
+func TestIsRoleAccount(t *testing.T) {
+	assert.True(t, IsRoleAccount("info"))
+	assert.True(t, IsRoleAccount("Admin"))
+	assert.True(t, IsRoleAccount("support+eu"))
+	assert.False(t, IsRoleAccount("john"))
+	assert.False(t, IsRoleAccount("information"))
+}
+
+func TestSuggestDomain(t *testing.T) {
+	tests := map[string]string{
+		"gmial.com":    "gmail.com",
+		"gmai.com":     "gmail.com",
+		"gmail.con":    "gmail.com",
+		"gmial.con":    "gmail.com",
+		"hotmial.com":  "hotmail.com",
+		"yaho.com":     "yahoo.com",
+		"outlok.com":   "outlook.com",
+		"gmail.com":    "",
+		"hotmail.de":   "",
+		"mail.com":     "",
+		"email.com":    "",
+		"example.com":  "",
+		"ab.com":       "",
+		"notifuse.com": "",
+	}
+	for domain, want := range tests {
+		assert.Equal(t, want, SuggestDomain(domain), domain)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, distance("gmail.com", "gmail.com"))
+	assert.Equal(t, 1, distance("gmial.com", "gmail.com"))
+	assert.Equal(t, 1, distance("gmai.com", "gmail.com"))
+	assert.Equal(t, 2, distance("gmial.con", "gmail.com"))
+	assert.Equal(t, 3, distance("", "abc"))
+}
+
+// smtpServer answers a single SMTP session on conn with the given RCPT replies
+func smtpServer(t *testing.T, conn net.Conn, rcptReplies []string) {
+	t.Helper()
+	go func() {
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		write := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+		write("220 mx.example.com ESMTP")
+		rcpt := 0
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"):
+				write("250 mx.example.com")
+			case strings.HasPrefix(cmd, "MAIL FROM"):
+				write("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO"):
+				write(rcptReplies[rcpt])
+				rcpt++
+			case strings.HasPrefix(cmd, "QUIT"):
+				write("221 Bye")
+				return
+			default:
+				write("502 Not implemented")
+			}
+		}
+	}()
+}
+
+func newPipeProber(t *testing.T, rcptReplies []string) *SMTPProber {
+	prober := NewSMTPProber("notifuse.test", "")
+	prober.dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+		assert.Equal(t, "mx.example.com:25", address)
+		client, server := net.Pipe()
+		smtpServer(t, server, rcptReplies)
+		return client, nil
+	}
+	return prober
+}
+
+func TestSMTPProber_Probe(t *testing.T) {
+	ctx := context.Background()
+	recipients := []string{"john@example.com", "random@example.com"}
+
+	t.Run("accepted and rejected recipients", func(t *testing.T) {
+		prober := newPipeProber(t, []string{"250 OK", "550 No such user"})
+
+		accepted, err := prober.Probe(ctx, "mx.example.com", recipients)
+		require.NoError(t, err)
+		assert.Equal(t, []bool{true, false}, accepted)
+	})
+
+	t.Run("temporary failure", func(t *testing.T) {
+		prober := newPipeProber(t, []string{"451 Greylisted", "250 OK"})
+
+		_, err := prober.Probe(ctx, "mx.example.com", recipients)
+		assert.ErrorContains(t, err, "deferred")
+	})
+
+	t.Run("connection failure", func(t *testing.T) {
+		prober := NewSMTPProber("notifuse.test", "")
+		prober.dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+			return nil, errors.New("connection refused")
+		}
+
+		_, err := prober.Probe(ctx, "mx.example.com", recipients)
+		assert.ErrorContains(t, err, "failed to connect")
+	})
+}
+
+func TestSMTPProber_RejectsPrivateAddresses(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("does not connect to an MX on the internal network", func(t *testing.T) {
+		prober := NewSMTPProber("notifuse.test", "")
+
+		for _, host := range []string{"127.0.0.1", "localhost", "10.0.0.5", "169.254.169.254", "::1"} {
+			_, err := prober.Probe(ctx, host, []string{"john@example.com"})
+			require.Error(t, err, host)
+			assert.ErrorIs(t, err, safehttpclient.ErrPrivateIP, host)
+		}
+	})
+
+	t.Run("checks the resolved address", func(t *testing.T) {
+		tests := []struct {
+			address string
+			allowed bool
+		}{
+			{"93.184.216.34:25", true},
+			{"[2606:2800:220:1::1]:25", true},
+			{"127.0.0.1:25", false},
+			{"192.168.1.10:25", false},
+			{"172.16.0.1:25", false},
+			{"169.254.169.254:25", false},
+			{"0.0.0.0:25", false},
+			{"[::1]:25", false},
+			{"[fe80::1]:25", false},
+			{"[::ffff:10.0.0.1]:25", false},
+		}
+
+		for _, tt := range tests {
+			err := rejectPrivateAddress("tcp", tt.address, nil)
+			if tt.allowed {
+				assert.NoError(t, err, tt.address)
+			} else {
+				assert.ErrorIs(t, err, safehttpclient.ErrPrivateIP, tt.address)
+			}
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package emailverifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"syscall"
+	"time"
+
+	"github.com/Notifuse/notifuse/pkg/safehttpclient"
+)
+
+// defaultProbeTimeout bounds a whole SMTP probe session
+const defaultProbeTimeout = 10 * time.Second
+
+// SMTPProber probes recipients with RCPT TO commands without sending any message
+type SMTPProber struct {
+	heloName string
+	mailFrom string
+	timeout  time.Duration
+	// dial can be overridden in tests
+	dial func(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// NewSMTPProber creates a prober announcing heloName in the EHLO command.
+// An empty mailFrom uses the null reverse-path, as bounces do.
+func NewSMTPProber(heloName, mailFrom string) *SMTPProber {
+	// MX records are controlled by the owner of the contact domain: never connect
+	// to loopback, private or link-local addresses of the internal network
+	dialer := &net.Dialer{Control: rejectPrivateAddress}
+	return &SMTPProber{
+		heloName: heloName,
+		mailFrom: mailFrom,
+		timeout:  defaultProbeTimeout,
+		dial:     dialer.DialContext,
+	}
+}
+
+// rejectPrivateAddress refuses the connection when the resolved IP address is not public
+func rejectPrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid address %s: %w", address, err)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || safehttpclient.IsPrivateIP(ip) {
+		return fmt.Errorf("%w: %s", safehttpclient.ErrPrivateIP, host)
+	}
+	return nil
+}
+
+// Probe connects to port 25 of host and reports which recipients the server accepts.
+// Permanent (5xx) rejections are reported as false, any other failure as an error.
+func (p *SMTPProber) Probe(ctx context.Context, host string, recipients []string) ([]bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	conn, err := p.dial(ctx, "tcp", net.JoinHostPort(host, "25"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", host, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to start SMTP session with %s: %w", host, err)
+	}
+	defer client.Close()
+
+	if err := client.Hello(p.heloName); err != nil {
+		return nil, fmt.Errorf("EHLO rejected by %s: %w", host, err)
+	}
+	if err := client.Mail(p.mailFrom); err != nil {
+		return nil, fmt.Errorf("MAIL FROM rejected by %s: %w", host, err)
+	}
+
+	accepted := make([]bool, len(recipients))
+	for i, recipient := range recipients {
+		err := client.Rcpt(recipient)
+		if err == nil {
+			accepted[i] = true
+			continue
+		}
+
+		var protoErr *textproto.Error
+		if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+			continue
+		}
+		// Temporary failures (greylisting, rate limits) say nothing about the mailbox
+		return nil, fmt.Errorf("RCPT TO deferred by %s: %w", host, err)
+	}
+
+	_ = client.Quit()
+	return accepted, nil
+}
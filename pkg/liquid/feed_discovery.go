@@ -12,6 +12,13 @@ import (
 // On category pages pass categorySlug to include the per-category feed links
 // alongside the main feed. Pass empty string for non-category pages.
 func InjectFeedDiscoveryTags(rendered, blogTitle, categorySlug string) string {
+	return InjectLocalizedFeedDiscoveryTags(rendered, blogTitle, "", categorySlug)
+}
+
+// InjectLocalizedFeedDiscoveryTags inserts the feed autodiscovery links of a
+// blog page in a translation language, whose feeds live under languagePrefix
+// (e.g. "/fr"). An empty prefix links the default language feeds.
+func InjectLocalizedFeedDiscoveryTags(rendered, blogTitle, languagePrefix, categorySlug string) string {
 	idx := strings.Index(strings.ToLower(rendered), "</head>")
 	if idx == -1 {
 		return rendered
@@ -22,11 +29,11 @@ func InjectFeedDiscoveryTags(rendered, blogTitle, categorySlug string) string {
 	var tags strings.Builder
 	tags.WriteString(`<link rel="alternate" type="application/rss+xml" title="`)
 	tags.WriteString(escapedTitle)
-	tags.WriteString(` — RSS" href="/feed.xml">`)
+	tags.WriteString(` — RSS" href="` + languagePrefix + `/feed.xml">`)
 	tags.WriteString("\n")
 	tags.WriteString(`<link rel="alternate" type="application/feed+json" title="`)
 	tags.WriteString(escapedTitle)
-	tags.WriteString(` — JSON Feed" href="/feed.json">`)
+	tags.WriteString(` — JSON Feed" href="` + languagePrefix + `/feed.json">`)
 	tags.WriteString("\n")
 
 	if categorySlug != "" {
@@ -34,7 +41,7 @@ func InjectFeedDiscoveryTags(rendered, blogTitle, categorySlug string) string {
 		tags.WriteString(escapedTitle)
 		tags.WriteString(" — ")
 		tags.WriteString(html.EscapeString(categorySlug))
-		tags.WriteString(` RSS" href="/`)
+		tags.WriteString(` RSS" href="` + languagePrefix + `/`)
 		tags.WriteString(categorySlug)
 		tags.WriteString(`/feed.xml">`)
 		tags.WriteString("\n")
@@ -42,7 +49,7 @@ func InjectFeedDiscoveryTags(rendered, blogTitle, categorySlug string) string {
 		tags.WriteString(escapedTitle)
 		tags.WriteString(" — ")
 		tags.WriteString(html.EscapeString(categorySlug))
-		tags.WriteString(` JSON Feed" href="/`)
+		tags.WriteString(` JSON Feed" href="` + languagePrefix + `/`)
 		tags.WriteString(categorySlug)
 		tags.WriteString(`/feed.json">`)
 		tags.WriteString("\n")
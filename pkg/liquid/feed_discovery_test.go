@@ -41,3 +41,14 @@ func TestInjectFeedDiscoveryTags_HTMLEscapesTitle(t *testing.T) {
 	out := InjectFeedDiscoveryTags(in, `Blog "with" <special> & chars`, "")
 	assert.Contains(t, out, `Blog &#34;with&#34; &lt;special&gt; &amp; chars`)
 }
+
+func TestInjectLocalizedFeedDiscoveryTags(t *testing.T) {
+	in := `<html><head><title>Blog</title></head><body></body></html>`
+	out := InjectLocalizedFeedDiscoveryTags(in, "My Blog", "/fr", "actualites")
+
+	assert.Contains(t, out, `href="/fr/feed.xml"`)
+	assert.Contains(t, out, `href="/fr/feed.json"`)
+	assert.Contains(t, out, `href="/fr/actualites/feed.xml"`)
+	assert.Contains(t, out, `href="/fr/actualites/feed.json"`)
+	assert.NotContains(t, out, `href="/feed.xml"`)
+}
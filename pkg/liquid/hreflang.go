@@ -0,0 +1,45 @@
+package liquid
+
+import (
+	"html"
+	"strings"
+)
+
+// HreflangLink is the URL of a page in one language
+type HreflangLink struct {
+	Language string
+	URL      string
+}
+
+// InjectHreflangTags inserts the <link rel="alternate" hreflang> elements
+// pointing to the versions of a page in each language before the closing
+// </head>, plus an x-default link to defaultURL when it is set. Pages with a
+// single version are returned unchanged, as is HTML without </head>.
+func InjectHreflangTags(rendered string, links []HreflangLink, defaultURL string) string {
+	if len(links) < 2 {
+		return rendered
+	}
+
+	idx := strings.Index(strings.ToLower(rendered), "</head>")
+	if idx == -1 {
+		return rendered
+	}
+
+	var tags strings.Builder
+	for _, link := range links {
+		tags.WriteString(`<link rel="alternate" hreflang="`)
+		tags.WriteString(html.EscapeString(link.Language))
+		tags.WriteString(`" href="`)
+		tags.WriteString(html.EscapeString(link.URL))
+		tags.WriteString(`">`)
+		tags.WriteString("\n")
+	}
+	if defaultURL != "" {
+		tags.WriteString(`<link rel="alternate" hreflang="x-default" href="`)
+		tags.WriteString(html.EscapeString(defaultURL))
+		tags.WriteString(`">`)
+		tags.WriteString("\n")
+	}
+
+	return rendered[:idx] + tags.String() + rendered[idx:]
+}
@@ -0,0 +1,46 @@
+package liquid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjectHreflangTags(t *testing.T) {
+	links := []HreflangLink{
+		{Language: "en", URL: "https://blog.example.com/news/launch"},
+		{Language: "fr", URL: "https://blog.example.com/fr/actualites/lancement"},
+	}
+
+	t.Run("links every language and the default", func(t *testing.T) {
+		in := `<html><head><title>Blog</title></head><body></body></html>`
+		out := InjectHreflangTags(in, links, "https://blog.example.com/news/launch")
+
+		assert.Contains(t, out, `<link rel="alternate" hreflang="en" href="https://blog.example.com/news/launch">`)
+		assert.Contains(t, out, `<link rel="alternate" hreflang="fr" href="https://blog.example.com/fr/actualites/lancement">`)
+		assert.Contains(t, out, `<link rel="alternate" hreflang="x-default" href="https://blog.example.com/news/launch">`)
+		assert.Contains(t, out, "\n</head>")
+	})
+
+	t.Run("single language", func(t *testing.T) {
+		in := `<html><head></head><body></body></html>`
+		assert.Equal(t, in, InjectHreflangTags(in, links[:1], links[0].URL))
+	})
+
+	t.Run("no head", func(t *testing.T) {
+		in := `<div>no head tag here</div>`
+		assert.Equal(t, in, InjectHreflangTags(in, links, links[0].URL))
+	})
+
+	t.Run("escapes URLs", func(t *testing.T) {
+		in := `<html><head></head><body></body></html>`
+		out := InjectHreflangTags(in, []HreflangLink{
+			{Language: "en", URL: `/a"b`},
+			{Language: "fr", URL: "/fr/a&b"},
+		}, "")
+
+		assert.Contains(t, out, `href="/a&#34;b"`)
+		assert.Contains(t, out, `href="/fr/a&amp;b"`)
+		assert.NotContains(t, out, "x-default")
+	})
+}
@@ -20,6 +20,8 @@ type Mailer interface {
 	SendMagicCode(email, code, language string) error
 	// SendCircuitBreakerAlert sends a notification when a broadcast is paused due to circuit breaker
 	SendCircuitBreakerAlert(email, workspaceName, broadcastName, reason, language string) error
+	// SendWebhookDisabledAlert sends a notification when a webhook subscription is disabled after failing continuously
+	SendWebhookDisabledAlert(email, workspaceName, subscriptionName, reason, language string) error
 }
 
 // Config holds the configuration for the mailer
@@ -297,6 +299,90 @@ func (m *SMTPMailer) SendCircuitBreakerAlert(email, workspaceName, broadcastName
 	return nil
 }
 
+// SendWebhookDisabledAlert sends a notification when a webhook subscription is disabled after failing continuously
+func (m *SMTPMailer) SendWebhookDisabledAlert(email, workspaceName, subscriptionName, reason, language string) error {
+	t := GetTranslations(language)
+
+	// Create a new message
+	msg := mail.NewMsg(mail.WithNoDefaultUserAgent())
+
+	// Set sender and recipient
+	if err := msg.FromFormat(m.config.FromName, m.config.FromEmail); err != nil {
+		return fmt.Errorf("failed to set email from address: %w", err)
+	}
+
+	if err := msg.To(email); err != nil {
+		return fmt.Errorf("failed to set email recipient: %w", err)
+	}
+
+	// Set subject
+	subject := fmt.Sprintf(t.WebhookDisabled.Subject, subscriptionName)
+	msg.Subject(subject)
+
+	// Create HTML content
+	htmlBody := fmt.Sprintf(`
+	<html lang="%s">
+		<body>
+			<h1 style="color: #d32f2f;">%s</h1>
+			<p>%s</p>
+			<p>%s</p>
+
+			<div style="background-color: #fff3cd; border: 1px solid #ffeaa7; padding: 15px; border-radius: 5px; margin: 20px 0;">
+				<h3 style="color: #856404; margin-top: 0;">%s</h3>
+				<p style="margin-bottom: 0; color: #856404;"><strong>%s</strong></p>
+			</div>
+
+			<p>%s</p>
+
+			<p>%s<br>%s</p>
+		</body>
+	</html>`,
+		t.Lang,
+		t.WebhookDisabled.Heading,
+		t.Common.Greeting,
+		fmt.Sprintf(t.WebhookDisabled.Body, `<strong>"`+subscriptionName+`"</strong>`, "<strong>"+workspaceName+"</strong>"),
+		t.WebhookDisabled.ReasonLabel,
+		reason,
+		t.WebhookDisabled.ReEnable,
+		t.WebhookDisabled.SignOff, t.Common.TeamName)
+
+	// Set alternative body parts
+	plainBody := fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s %s\n\n%s\n\n%s\n%s",
+		t.WebhookDisabled.Heading,
+		t.Common.Greeting,
+		fmt.Sprintf(t.WebhookDisabled.Body, `"`+subscriptionName+`"`, workspaceName),
+		t.WebhookDisabled.ReasonLabel, reason,
+		t.WebhookDisabled.ReEnable,
+		t.WebhookDisabled.SignOff, t.Common.TeamName)
+
+	msg.SetBodyString(mail.TypeTextHTML, htmlBody)
+	msg.AddAlternativeString(mail.TypeTextPlain, plainBody)
+
+	// Create SMTP client
+	client, err := m.createSMTPClient()
+	if err != nil {
+		return err
+	}
+
+	// For testing - log information if client is nil
+	if client == nil {
+		log.Printf("Sending webhook disabled alert to: %s", email)
+		log.Printf("From: %s <%s>", m.config.FromName, m.config.FromEmail)
+		log.Printf("Subject: %s", subject)
+		log.Printf("Webhook: %s", subscriptionName)
+		log.Printf("Workspace: %s", workspaceName)
+		log.Printf("Reason: %s", reason)
+		return nil
+	}
+
+	// Send the email
+	if err := client.DialAndSend(msg); err != nil {
+		return fmt.Errorf("failed to send webhook disabled alert email: %w", err)
+	}
+
+	return nil
+}
+
 // createSMTPClient creates and configures a new SMTP client
 func (m *SMTPMailer) createSMTPClient() (*mail.Client, error) {
 	// In test mode, return nil client to avoid SMTP connections
@@ -427,3 +513,23 @@ func (m *ConsoleMailer) SendCircuitBreakerAlert(email, workspaceName, broadcastN
 
 	return nil
 }
+
+// SendWebhookDisabledAlert logs the webhook disabled alert details to console
+func (m *ConsoleMailer) SendWebhookDisabledAlert(email, workspaceName, subscriptionName, reason, language string) error {
+	t := GetTranslations(language)
+	fmt.Println("==============================================================")
+	fmt.Println("                 WEBHOOK DISABLED ALERT EMAIL                 ")
+	fmt.Println("==============================================================")
+	fmt.Printf("To: %s\n", email)
+	fmt.Printf("Subject: %s\n\n", fmt.Sprintf(t.WebhookDisabled.Subject, subscriptionName))
+	fmt.Println("Email Content:")
+	fmt.Printf("%s\n\n", t.WebhookDisabled.Heading)
+	fmt.Printf("%s\n\n", t.Common.Greeting)
+	fmt.Printf("%s\n\n", fmt.Sprintf(t.WebhookDisabled.Body, `"`+subscriptionName+`"`, workspaceName))
+	fmt.Printf("%s %s\n\n", t.WebhookDisabled.ReasonLabel, reason)
+	fmt.Printf("%s\n\n", t.WebhookDisabled.ReEnable)
+	fmt.Printf("%s\n%s\n", t.WebhookDisabled.SignOff, t.Common.TeamName)
+	fmt.Println("==============================================================")
+
+	return nil
+}
@@ -76,6 +76,13 @@ func (m *MockMailer) SendCircuitBreakerAlert(email, workspaceName, broadcastName
 	return nil
 }
 
+func (m *MockMailer) SendWebhookDisabledAlert(email, workspaceName, subscriptionName, reason, language string) error {
+	if m.shouldFail {
+		return errors.New("mock mailer error")
+	}
+	return nil
+}
+
 // ValidatingMailer is a mock implementation that validates inputs
 type ValidatingMailer struct {
 	config *Config
@@ -684,6 +691,64 @@ func TestSMTPMailer_SendCircuitBreakerAlert(t *testing.T) {
 	}
 }
 
+func TestConsoleMailer_SendWebhookDisabledAlert(t *testing.T) {
+	mailer := NewConsoleMailer()
+
+	output := captureOutput(func() {
+		err := mailer.SendWebhookDisabledAlert("test@example.com", "Test Workspace", "CRM sync", "HTTP 503 since 2026-01-01T00:00:00Z", "en")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	})
+
+	expectedStrings := []string{
+		"WEBHOOK DISABLED ALERT EMAIL",
+		"To: test@example.com",
+		"Subject: ⚠️ Webhook Disabled - CRM sync",
+		"Your webhook \"CRM sync\" in workspace Test Workspace",
+		"Reason: HTTP 503 since 2026-01-01T00:00:00Z",
+		"redeliver the failed events",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain '%s', but it didn't. Output: %s", expected, output)
+		}
+	}
+}
+
+func TestSMTPMailer_SendWebhookDisabledAlert(t *testing.T) {
+	config := &Config{
+		SMTPHost:    "smtp.example.com",
+		SMTPPort:    587,
+		FromEmail:   "noreply@example.com",
+		FromName:    "Notifuse",
+		APIEndpoint: "https://notifuse.example.com",
+	}
+	mailer := NewTestSMTPMailer(config)
+
+	logOutput := captureLog(func() {
+		err := mailer.SendWebhookDisabledAlert("test@example.com", "Test Workspace", "CRM sync", "HTTP 503", "fr")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	})
+
+	expectedLogLines := []string{
+		"Sending webhook disabled alert to: test@example.com",
+		"Subject: ⚠️ Webhook désactivé - CRM sync",
+		"Webhook: CRM sync",
+		"Workspace: Test Workspace",
+		"Reason: HTTP 503",
+	}
+
+	for _, expected := range expectedLogLines {
+		if !strings.Contains(logOutput, expected) {
+			t.Errorf("Expected log to contain '%s', but it didn't. Log: %s", expected, logOutput)
+		}
+	}
+}
+
 func TestSMTPMailer_SendCircuitBreakerAlert_EdgeCases(t *testing.T) {
 	testCases := []struct {
 		name          string
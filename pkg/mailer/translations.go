@@ -7,15 +7,16 @@ import "strings"
 const DefaultEmailLanguage = "en"
 
 // Translations holds every localized string used by the system emails
-// (magic code, workspace invitation and circuit-breaker alert).
+// (magic code, workspace invitation, circuit-breaker and webhook-disabled alerts).
 type Translations struct {
 	// Lang is the canonical locale code for this set (e.g. "en", "pt-BR"),
 	// used for the HTML lang attribute.
-	Lang           string
-	Common         CommonStrings
-	MagicCode      MagicCodeStrings
-	Invitation     InvitationStrings
-	CircuitBreaker CircuitBreakerStrings
+	Lang            string
+	Common          CommonStrings
+	MagicCode       MagicCodeStrings
+	Invitation      InvitationStrings
+	CircuitBreaker  CircuitBreakerStrings
+	WebhookDisabled WebhookDisabledStrings
 }
 
 // CommonStrings holds strings shared across every system email.
@@ -61,6 +62,18 @@ type CircuitBreakerStrings struct {
 	SignOff     string
 }
 
+// WebhookDisabledStrings holds the strings for the webhook-subscription-disabled
+// alert email. Subject takes one argument (subscription name). Body takes two
+// indexed arguments (%[1]s subscription, %[2]s workspace).
+type WebhookDisabledStrings struct {
+	Subject     string
+	Heading     string
+	Body        string
+	ReasonLabel string
+	ReEnable    string
+	SignOff     string
+}
+
 // systemEmailTranslations maps lowercased locale codes to their translation set.
 // Keys are stored lowercased so that lookups are case-insensitive ("pt-BR" == "pt-br");
 // each set's canonical-cased code lives in its Lang field. This registry is
@@ -132,6 +145,14 @@ var englishTranslations = Translations{
 		ReasonLabel: "Reason:",
 		SignOff:     "Best regards,",
 	},
+	WebhookDisabled: WebhookDisabledStrings{
+		Subject:     "⚠️ Webhook Disabled - %s",
+		Heading:     "⚠️ Webhook Automatically Disabled",
+		Body:        "Your webhook %[1]s in workspace %[2]s has been automatically disabled because its endpoint kept failing.",
+		ReasonLabel: "Reason:",
+		ReEnable:    "Once the endpoint is fixed, enable the webhook again from the workspace settings and redeliver the failed events.",
+		SignOff:     "Best regards,",
+	},
 }
 
 // frenchTranslations holds the French (fr) system email strings.
@@ -167,6 +188,14 @@ var frenchTranslations = Translations{
 		ReasonLabel: "Raison :",
 		SignOff:     "Cordialement,",
 	},
+	WebhookDisabled: WebhookDisabledStrings{
+		Subject:     "⚠️ Webhook désactivé - %s",
+		Heading:     "⚠️ Webhook automatiquement désactivé",
+		Body:        "Votre webhook %[1]s dans l'espace de travail %[2]s a été automatiquement désactivé car son endpoint échoue en continu.",
+		ReasonLabel: "Raison :",
+		ReEnable:    "Une fois l'endpoint corrigé, réactivez le webhook depuis les paramètres de l'espace de travail et relancez les événements en échec.",
+		SignOff:     "Cordialement,",
+	},
 }
 
 // spanishTranslations holds the Spanish (es) system email strings.
@@ -202,6 +231,14 @@ var spanishTranslations = Translations{
 		ReasonLabel: "Motivo:",
 		SignOff:     "Un saludo,",
 	},
+	WebhookDisabled: WebhookDisabledStrings{
+		Subject:     "⚠️ Webhook desactivado - %s",
+		Heading:     "⚠️ Webhook desactivado automáticamente",
+		Body:        "Tu webhook %[1]s en el espacio de trabajo %[2]s se ha desactivado automáticamente porque su endpoint fallaba de forma continua.",
+		ReasonLabel: "Motivo:",
+		ReEnable:    "Cuando el endpoint esté corregido, vuelve a activar el webhook desde la configuración del espacio de trabajo y reenvía los eventos fallidos.",
+		SignOff:     "Un saludo,",
+	},
 }
 
 // germanTranslations holds the German (de) system email strings.
@@ -237,6 +274,14 @@ var germanTranslations = Translations{
 		ReasonLabel: "Grund:",
 		SignOff:     "Mit freundlichen Grüßen,",
 	},
+	WebhookDisabled: WebhookDisabledStrings{
+		Subject:     "⚠️ Webhook deaktiviert - %s",
+		Heading:     "⚠️ Webhook automatisch deaktiviert",
+		Body:        "Ihr Webhook %[1]s im Workspace %[2]s wurde automatisch deaktiviert, da sein Endpunkt dauerhaft fehlschlug.",
+		ReasonLabel: "Grund:",
+		ReEnable:    "Sobald der Endpunkt behoben ist, aktivieren Sie den Webhook in den Workspace-Einstellungen wieder und stellen Sie die fehlgeschlagenen Ereignisse erneut zu.",
+		SignOff:     "Mit freundlichen Grüßen,",
+	},
 }
 
 // catalanTranslations holds the Catalan (ca) system email strings.
@@ -272,6 +317,14 @@ var catalanTranslations = Translations{
 		ReasonLabel: "Motiu:",
 		SignOff:     "Salutacions cordials,",
 	},
+	WebhookDisabled: WebhookDisabledStrings{
+		Subject:     "⚠️ Webhook desactivat - %s",
+		Heading:     "⚠️ Webhook desactivat automàticament",
+		Body:        "El teu webhook %[1]s a l'espai de treball %[2]s s'ha desactivat automàticament perquè el seu endpoint fallava de manera contínua.",
+		ReasonLabel: "Motiu:",
+		ReEnable:    "Quan l'endpoint estigui corregit, torna a activar el webhook des de la configuració de l'espai de treball i reenvia els esdeveniments fallits.",
+		SignOff:     "Salutacions cordials,",
+	},
 }
 
 // portugueseBRTranslations holds the Brazilian Portuguese (pt-BR) system email strings.
@@ -307,6 +360,14 @@ var portugueseBRTranslations = Translations{
 		ReasonLabel: "Motivo:",
 		SignOff:     "Atenciosamente,",
 	},
+	WebhookDisabled: WebhookDisabledStrings{
+		Subject:     "⚠️ Webhook desativado - %s",
+		Heading:     "⚠️ Webhook desativado automaticamente",
+		Body:        "Seu webhook %[1]s no espaço de trabalho %[2]s foi desativado automaticamente porque seu endpoint falhava continuamente.",
+		ReasonLabel: "Motivo:",
+		ReEnable:    "Depois de corrigir o endpoint, reative o webhook nas configurações do espaço de trabalho e reenvie os eventos com falha.",
+		SignOff:     "Atenciosamente,",
+	},
 }
 
 // japaneseTranslations holds the Japanese (ja) system email strings.
@@ -342,6 +403,14 @@ var japaneseTranslations = Translations{
 		ReasonLabel: "理由:",
 		SignOff:     "よろしくお願いいたします、",
 	},
+	WebhookDisabled: WebhookDisabledStrings{
+		Subject:     "⚠️ Webhook が無効化されました - %s",
+		Heading:     "⚠️ Webhook が自動的に無効化されました",
+		Body:        "ワークスペース %[2]s の Webhook %[1]s は、エンドポイントへの送信が失敗し続けたため自動的に無効化されました。",
+		ReasonLabel: "理由:",
+		ReEnable:    "エンドポイントを修正したら、ワークスペースの設定から Webhook を再度有効にし、失敗したイベントを再送してください。",
+		SignOff:     "よろしくお願いいたします、",
+	},
 }
 
 // italianTranslations holds the Italian (it) system email strings.
@@ -377,4 +446,12 @@ var italianTranslations = Translations{
 		ReasonLabel: "Motivo:",
 		SignOff:     "Cordiali saluti,",
 	},
+	WebhookDisabled: WebhookDisabledStrings{
+		Subject:     "⚠️ Webhook disattivato - %s",
+		Heading:     "⚠️ Webhook disattivato automaticamente",
+		Body:        "Il tuo webhook %[1]s nello spazio di lavoro %[2]s è stato disattivato automaticamente perché il suo endpoint continuava a fallire.",
+		ReasonLabel: "Motivo:",
+		ReEnable:    "Una volta corretto l'endpoint, riattiva il webhook dalle impostazioni dello spazio di lavoro e reinvia gli eventi non riusciti.",
+		SignOff:     "Cordiali saluti,",
+	},
 }
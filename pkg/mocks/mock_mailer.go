@@ -61,6 +61,20 @@ func (mr *MockMailerMockRecorder) SendMagicCode(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMagicCode", reflect.TypeOf((*MockMailer)(nil).SendMagicCode), arg0, arg1, arg2)
 }
 
+// SendWebhookDisabledAlert mocks base method.
+func (m *MockMailer) SendWebhookDisabledAlert(arg0, arg1, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendWebhookDisabledAlert", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendWebhookDisabledAlert indicates an expected call of SendWebhookDisabledAlert.
+func (mr *MockMailerMockRecorder) SendWebhookDisabledAlert(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendWebhookDisabledAlert", reflect.TypeOf((*MockMailer)(nil).SendWebhookDisabledAlert), arg0, arg1, arg2, arg3, arg4)
+}
+
 // SendWorkspaceInvitation mocks base method.
 func (m *MockMailer) SendWorkspaceInvitation(arg0, arg1, arg2, arg3, arg4 string) error {
 	m.ctrl.T.Helper()
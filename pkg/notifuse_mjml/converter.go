@@ -129,6 +129,12 @@ func convertBlockToMJMLWithParsedData(block EmailBlock, indentLevel int, templat
 		return content
 	}
 
+	// Unresolved synced block references render nothing; CompileTemplate
+	// resolves them against the workspace blocks before conversion
+	if blockType == MJMLComponentMjSyncedBlock {
+		return ""
+	}
+
 	indent := strings.Repeat("  ", indentLevel)
 	tagName := string(blockType)
 	children := block.GetChildren()
@@ -208,6 +214,12 @@ func convertBlockToMJMLRaw(block EmailBlock, indentLevel int) string {
 		return content
 	}
 
+	// Unresolved synced block references render nothing; CompileTemplate
+	// resolves them against the workspace blocks before conversion
+	if blockType == MJMLComponentMjSyncedBlock {
+		return ""
+	}
+
 	indent := strings.Repeat("  ", indentLevel)
 	tagName := string(blockType)
 	children := block.GetChildren()
@@ -34,6 +34,7 @@ const (
 	MJMLComponentMjTitle          MJMLComponentType = "mj-title"
 	MJMLComponentMjRaw            MJMLComponentType = "mj-raw"
 	MJMLComponentMjLiquid         MJMLComponentType = "mj-liquid"
+	MJMLComponentMjSyncedBlock    MJMLComponentType = "mj-synced-block"
 	MJMLComponentMjAll            MJMLComponentType = "mj-all"
 	MJMLComponentMjClass          MJMLComponentType = "mj-class"
 )
@@ -386,6 +387,12 @@ type MJLiquidBlock struct {
 	*BaseBlock
 }
 
+// MJSyncedBlock references a workspace template block by ID; it is replaced by
+// the block's current content when the template is compiled
+type MJSyncedBlock struct {
+	*BaseBlock
+}
+
 // Email builder state types
 type EmailBuilderState struct {
 	SelectedBlockID *string      `json:"selectedBlockId,omitempty"`
@@ -625,6 +632,8 @@ func createTypedBlock(base *BaseBlock) EmailBlock {
 		return &MJRawBlock{BaseBlock: base}
 	case MJMLComponentMjLiquid:
 		return &MJLiquidBlock{BaseBlock: base}
+	case MJMLComponentMjSyncedBlock:
+		return &MJSyncedBlock{BaseBlock: base}
 	case MJMLComponentMjAttributes:
 		return &MJAttributesBlock{BaseBlock: base}
 	case MJMLComponentMjAll:
@@ -679,11 +688,13 @@ var ValidChildrenMap = map[MJMLComponentType][]MJMLComponentType{
 		MJMLComponentMjSection,
 		MJMLComponentMjRaw,
 		MJMLComponentMjLiquid,
+		MJMLComponentMjSyncedBlock,
 	},
 	MJMLComponentMjWrapper: {
 		MJMLComponentMjSection,
 		MJMLComponentMjRaw,
 		MJMLComponentMjLiquid,
+		MJMLComponentMjSyncedBlock,
 	},
 	MJMLComponentMjSection: {
 		MJMLComponentMjColumn,
@@ -700,6 +711,7 @@ var ValidChildrenMap = map[MJMLComponentType][]MJMLComponentType{
 		MJMLComponentMjSocial,
 		MJMLComponentMjRaw,
 		MJMLComponentMjLiquid,
+		MJMLComponentMjSyncedBlock,
 	},
 	MJMLComponentMjGroup: {
 		MJMLComponentMjColumn,
@@ -726,6 +738,7 @@ var ValidChildrenMap = map[MJMLComponentType][]MJMLComponentType{
 	MJMLComponentMjSocialElement:  {},
 	MJMLComponentMjRaw:            {},
 	MJMLComponentMjLiquid:         {},
+	MJMLComponentMjSyncedBlock:    {},
 	MJMLComponentMjAttributes: {
 		MJMLComponentMjAll, MJMLComponentMjClass,
 		MJMLComponentMjText, MJMLComponentMjButton, MJMLComponentMjImage,
@@ -843,6 +856,8 @@ func GetComponentDisplayName(componentType MJMLComponentType) string {
 		return "Raw HTML"
 	case MJMLComponentMjLiquid:
 		return "Liquid"
+	case MJMLComponentMjSyncedBlock:
+		return "Synced Block"
 	default:
 		// Convert kebab-case to Title Case
 		parts := strings.Split(string(componentType), "-")
@@ -873,6 +888,8 @@ func GetComponentCategory(componentType MJMLComponentType) string {
 		return "Head"
 	case MJMLComponentMjRaw:
 		return "Raw"
+	case MJMLComponentMjSyncedBlock:
+		return "Synced"
 	default:
 		return "Other"
 	}
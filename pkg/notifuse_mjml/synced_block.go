@@ -0,0 +1,189 @@
+package notifuse_mjml
+
+import (
+	"fmt"
+)
+
+// SyncedBlockIDAttribute is the attribute of an mj-synced-block node that holds
+// the ID of the workspace template block it references
+const SyncedBlockIDAttribute = "blockId"
+
+// maxSyncedBlockDepth bounds how deeply synced blocks may reference other synced blocks
+const maxSyncedBlockDepth = 5
+
+// ErrSyncedBlockNotFound is returned when a template references a synced block
+// that does not exist in the workspace
+type ErrSyncedBlockNotFound struct {
+	BlockID string
+}
+
+func (e *ErrSyncedBlockNotFound) Error() string {
+	return fmt.Sprintf("synced block %s not found", e.BlockID)
+}
+
+// GetSyncedBlockID returns the referenced block ID of an mj-synced-block node,
+// or an empty string if the block is not a synced block reference
+func GetSyncedBlockID(block EmailBlock) string {
+	if block == nil || block.GetType() != MJMLComponentMjSyncedBlock {
+		return ""
+	}
+	if id, ok := block.GetAttributes()[SyncedBlockIDAttribute].(string); ok {
+		return id
+	}
+	return ""
+}
+
+// CollectSyncedBlockIDs returns the distinct block IDs referenced by
+// mj-synced-block nodes in the tree, in order of first appearance
+func CollectSyncedBlockIDs(tree EmailBlock) []string {
+	seen := make(map[string]bool)
+	var ids []string
+
+	var walk func(block EmailBlock)
+	walk = func(block EmailBlock) {
+		if block == nil {
+			return
+		}
+		if block.GetType() == MJMLComponentMjSyncedBlock {
+			if id := GetSyncedBlockID(block); id != "" && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		for _, child := range block.GetChildren() {
+			walk(child)
+		}
+	}
+	walk(tree)
+
+	return ids
+}
+
+// HasSyncedBlocks reports whether the tree contains at least one mj-synced-block node
+func HasSyncedBlocks(tree EmailBlock) bool {
+	if tree == nil {
+		return false
+	}
+	if tree.GetType() == MJMLComponentMjSyncedBlock {
+		return true
+	}
+	for _, child := range tree.GetChildren() {
+		if HasSyncedBlocks(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// CloneEmailBlock returns a deep copy of the block and its children
+func CloneEmailBlock(block EmailBlock) (EmailBlock, error) {
+	if block == nil {
+		return nil, nil
+	}
+	data, err := MarshalEmailBlock(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal block: %w", err)
+	}
+	return UnmarshalEmailBlock(data)
+}
+
+// ResolveSyncedBlocks returns a copy of the tree where every mj-synced-block node
+// is replaced by the current content of the workspace block it references.
+// The input tree is never mutated. Synced blocks may themselves contain synced
+// blocks; cycles and unknown block IDs are reported as errors, as is a block
+// whose root type cannot be placed under the parent of the reference.
+func ResolveSyncedBlocks(tree EmailBlock, blocks map[string]EmailBlock) (EmailBlock, error) {
+	if tree == nil || !HasSyncedBlocks(tree) {
+		return tree, nil
+	}
+
+	clone, err := CloneEmailBlock(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	if clone.GetType() == MJMLComponentMjSyncedBlock {
+		return nil, fmt.Errorf("synced block cannot be the root of a template")
+	}
+
+	if err := resolveSyncedChildren(clone, blocks, nil); err != nil {
+		return nil, err
+	}
+
+	return clone, nil
+}
+
+// resolveSyncedChildren replaces the synced block references among the children
+// of parent, recursing into every child. path holds the block IDs being expanded
+// to detect cycles.
+func resolveSyncedChildren(parent EmailBlock, blocks map[string]EmailBlock, path []string) error {
+	children := parent.GetChildren()
+	for i, child := range children {
+		if child == nil {
+			continue
+		}
+
+		if child.GetType() != MJMLComponentMjSyncedBlock {
+			if err := resolveSyncedChildren(child, blocks, path); err != nil {
+				return err
+			}
+			continue
+		}
+
+		blockID := GetSyncedBlockID(child)
+		if blockID == "" {
+			return fmt.Errorf("synced block %s is missing the %s attribute", child.GetID(), SyncedBlockIDAttribute)
+		}
+		for _, id := range path {
+			if id == blockID {
+				return fmt.Errorf("synced block %s references itself", blockID)
+			}
+		}
+		if len(path) >= maxSyncedBlockDepth {
+			return fmt.Errorf("synced block %s exceeds the maximum nesting depth of %d", blockID, maxSyncedBlockDepth)
+		}
+
+		source, ok := blocks[blockID]
+		if !ok || source == nil {
+			return &ErrSyncedBlockNotFound{BlockID: blockID}
+		}
+
+		resolved, err := CloneEmailBlock(source)
+		if err != nil {
+			return fmt.Errorf("failed to copy synced block %s: %w", blockID, err)
+		}
+
+		// A synced block may wrap another synced block at its root
+		if resolved.GetType() == MJMLComponentMjSyncedBlock {
+			wrapper := &BaseBlock{Type: parent.GetType(), Children: []EmailBlock{resolved}}
+			if err := resolveSyncedChildren(wrapper, blocks, append(path, blockID)); err != nil {
+				return err
+			}
+			resolved = wrapper.Children[0]
+		} else if err := resolveSyncedChildren(resolved, blocks, append(path, blockID)); err != nil {
+			return err
+		}
+
+		if !CanDropCheck(resolved.GetType(), parent.GetType()) {
+			return fmt.Errorf("synced block %s of type %s cannot be placed inside %s", blockID, resolved.GetType(), parent.GetType())
+		}
+
+		// Prefix IDs so the same block can appear several times in one template
+		prefixBlockIDs(resolved, child.GetID())
+		children[i] = resolved
+	}
+
+	parent.SetChildren(children)
+	return nil
+}
+
+// prefixBlockIDs prefixes the ID of the block and all its descendants
+func prefixBlockIDs(block EmailBlock, prefix string) {
+	if block == nil || prefix == "" {
+		return
+	}
+	block.SetID(prefix + "-" + block.GetID())
+	for _, child := range block.GetChildren() {
+		prefixBlockIDs(child, prefix)
+	}
+}
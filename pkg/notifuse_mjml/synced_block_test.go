@@ -0,0 +1,169 @@
+package notifuse_mjml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSyncedRef(id, blockID string) EmailBlock {
+	return &MJSyncedBlock{BaseBlock: &BaseBlock{
+		ID:         id,
+		Type:       MJMLComponentMjSyncedBlock,
+		Attributes: map[string]interface{}{SyncedBlockIDAttribute: blockID},
+	}}
+}
+
+func newTextBlock(id, content string) EmailBlock {
+	return &MJTextBlock{BaseBlock: &BaseBlock{
+		ID:         id,
+		Type:       MJMLComponentMjText,
+		Attributes: map[string]interface{}{},
+		Content:    &content,
+	}}
+}
+
+func newTreeWithColumnChildren(children ...EmailBlock) EmailBlock {
+	column := &MJColumnBlock{BaseBlock: &BaseBlock{ID: "col", Type: MJMLComponentMjColumn, Children: children}}
+	section := &MJSectionBlock{BaseBlock: &BaseBlock{ID: "sec", Type: MJMLComponentMjSection, Children: []EmailBlock{column}}}
+	body := &MJBodyBlock{BaseBlock: &BaseBlock{ID: "body", Type: MJMLComponentMjBody, Children: []EmailBlock{section}}}
+	return &MJMLBlock{BaseBlock: &BaseBlock{ID: "root", Type: MJMLComponentMjml, Children: []EmailBlock{body}}}
+}
+
+func firstColumnChildren(tree EmailBlock) []EmailBlock {
+	return tree.GetChildren()[0].GetChildren()[0].GetChildren()[0].GetChildren()
+}
+
+func TestCollectSyncedBlockIDs(t *testing.T) {
+	tree := newTreeWithColumnChildren(
+		newSyncedRef("ref1", "footer"),
+		newTextBlock("t1", "Hello"),
+		newSyncedRef("ref2", "header"),
+		newSyncedRef("ref3", "footer"),
+	)
+
+	assert.Equal(t, []string{"footer", "header"}, CollectSyncedBlockIDs(tree))
+	assert.True(t, HasSyncedBlocks(tree))
+	assert.False(t, HasSyncedBlocks(newTreeWithColumnChildren(newTextBlock("t1", "Hello"))))
+	assert.Nil(t, CollectSyncedBlockIDs(nil))
+}
+
+func TestResolveSyncedBlocks(t *testing.T) {
+	t.Run("replaces references without mutating the input", func(t *testing.T) {
+		tree := newTreeWithColumnChildren(newSyncedRef("ref1", "footer"), newSyncedRef("ref2", "footer"))
+		blocks := map[string]EmailBlock{"footer": newTextBlock("footer-text", "Unsubscribe")}
+
+		resolved, err := ResolveSyncedBlocks(tree, blocks)
+		require.NoError(t, err)
+
+		children := firstColumnChildren(resolved)
+		require.Len(t, children, 2)
+		assert.Equal(t, MJMLComponentMjText, children[0].GetType())
+		assert.Equal(t, "Unsubscribe", *children[0].GetContent())
+		assert.Equal(t, "ref1-footer-text", children[0].GetID())
+		assert.Equal(t, "ref2-footer-text", children[1].GetID())
+
+		// Original tree and source block are untouched
+		assert.Equal(t, MJMLComponentMjSyncedBlock, firstColumnChildren(tree)[0].GetType())
+		assert.Equal(t, "footer-text", blocks["footer"].GetID())
+	})
+
+	t.Run("returns the tree as-is when it has no references", func(t *testing.T) {
+		tree := newTreeWithColumnChildren(newTextBlock("t1", "Hello"))
+		resolved, err := ResolveSyncedBlocks(tree, nil)
+		require.NoError(t, err)
+		assert.Same(t, tree, resolved)
+	})
+
+	t.Run("resolves nested synced blocks", func(t *testing.T) {
+		column := &MJColumnBlock{BaseBlock: &BaseBlock{ID: "c", Type: MJMLComponentMjColumn, Children: []EmailBlock{newSyncedRef("inner", "legal")}}}
+		section := &MJSectionBlock{BaseBlock: &BaseBlock{ID: "s", Type: MJMLComponentMjSection, Children: []EmailBlock{column}}}
+		body := &MJBodyBlock{BaseBlock: &BaseBlock{ID: "body", Type: MJMLComponentMjBody, Children: []EmailBlock{newSyncedRef("ref", "footer")}}}
+		tree := &MJMLBlock{BaseBlock: &BaseBlock{ID: "root", Type: MJMLComponentMjml, Children: []EmailBlock{body}}}
+
+		resolved, err := ResolveSyncedBlocks(tree, map[string]EmailBlock{
+			"footer": section,
+			"legal":  newTextBlock("legal-text", "Legal"),
+		})
+		require.NoError(t, err)
+
+		resolvedSection := resolved.GetChildren()[0].GetChildren()[0]
+		assert.Equal(t, MJMLComponentMjSection, resolvedSection.GetType())
+		text := resolvedSection.GetChildren()[0].GetChildren()[0]
+		assert.Equal(t, "Legal", *text.GetContent())
+		assert.False(t, HasSyncedBlocks(resolved))
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		tree := newTreeWithColumnChildren(newSyncedRef("ref1", "missing"))
+		_, err := ResolveSyncedBlocks(tree, map[string]EmailBlock{})
+		require.Error(t, err)
+		var notFound *ErrSyncedBlockNotFound
+		assert.ErrorAs(t, err, &notFound)
+		assert.Equal(t, "missing", notFound.BlockID)
+	})
+
+	t.Run("missing block id attribute", func(t *testing.T) {
+		tree := newTreeWithColumnChildren(newSyncedRef("ref1", ""))
+		_, err := ResolveSyncedBlocks(tree, map[string]EmailBlock{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing the blockId attribute")
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		column := &MJColumnBlock{BaseBlock: &BaseBlock{ID: "c", Type: MJMLComponentMjColumn, Children: []EmailBlock{newSyncedRef("inner", "loop")}}}
+		section := &MJSectionBlock{BaseBlock: &BaseBlock{ID: "s", Type: MJMLComponentMjSection, Children: []EmailBlock{column}}}
+		body := &MJBodyBlock{BaseBlock: &BaseBlock{ID: "body", Type: MJMLComponentMjBody, Children: []EmailBlock{newSyncedRef("ref", "loop")}}}
+		tree := &MJMLBlock{BaseBlock: &BaseBlock{ID: "root", Type: MJMLComponentMjml, Children: []EmailBlock{body}}}
+
+		_, err := ResolveSyncedBlocks(tree, map[string]EmailBlock{"loop": section})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "references itself")
+	})
+
+	t.Run("invalid placement", func(t *testing.T) {
+		section := &MJSectionBlock{BaseBlock: &BaseBlock{ID: "s", Type: MJMLComponentMjSection}}
+		tree := newTreeWithColumnChildren(newSyncedRef("ref1", "section"))
+		_, err := ResolveSyncedBlocks(tree, map[string]EmailBlock{"section": section})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be placed inside mj-column")
+	})
+}
+
+func TestCompileTemplate_SyncedBlocks(t *testing.T) {
+	tree := newTreeWithColumnChildren(newSyncedRef("ref1", "footer"))
+
+	t.Run("renders the referenced block", func(t *testing.T) {
+		resp, err := CompileTemplate(CompileTemplateRequest{
+			WorkspaceID:      "ws",
+			MessageID:        "msg",
+			VisualEditorTree: tree,
+			SyncedBlocks:     map[string]EmailBlock{"footer": newTextBlock("footer-text", "Shared footer")},
+		})
+		require.NoError(t, err)
+		require.True(t, resp.Success)
+		assert.True(t, strings.Contains(*resp.HTML, "Shared footer"))
+		assert.Equal(t, MJMLComponentMjSyncedBlock, firstColumnChildren(tree)[0].GetType())
+	})
+
+	t.Run("fails when the block is missing", func(t *testing.T) {
+		resp, err := CompileTemplate(CompileTemplateRequest{
+			WorkspaceID:      "ws",
+			MessageID:        "msg",
+			VisualEditorTree: tree,
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.Success)
+		require.NotNil(t, resp.Error)
+		assert.Contains(t, resp.Error.Message, "synced block footer not found")
+	})
+}
+
+func TestConvertJSONToMJML_SkipsUnresolvedSyncedBlocks(t *testing.T) {
+	tree := newTreeWithColumnChildren(newSyncedRef("ref1", "footer"), newTextBlock("t1", "Hello"))
+	mjmlString := ConvertJSONToMJML(tree)
+	assert.NotContains(t, mjmlString, "mj-synced-block")
+	assert.Contains(t, mjmlString, "Hello")
+}
@@ -254,6 +254,9 @@ type CompileTemplateRequest struct {
 	Channel                string           `json:"channel,omitempty"`                  // "email" or "web"
 	PreserveLiquid         bool             `json:"preserve_liquid,omitempty"`          // When true, skip Liquid template processing and preserve raw syntax
 	SubjectPreviewOverride *string          `json:"subject_preview_override,omitempty"` // Override mj-preview content before compilation
+	// SyncedBlocks maps workspace template block IDs to their content, used to
+	// resolve mj-synced-block references in the visual editor tree
+	SyncedBlocks map[string]EmailBlock `json:"-"`
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling for CompileTemplateRequest
@@ -429,6 +432,21 @@ func CompileTemplate(req CompileTemplateRequest) (resp *CompileTemplateResponse,
 
 		tree := req.VisualEditorTree
 
+		// Replace synced block references with the current workspace blocks.
+		// The resolved tree is a copy, so the caller's tree is left untouched.
+		if HasSyncedBlocks(tree) {
+			resolvedTree, err := ResolveSyncedBlocks(tree, req.SyncedBlocks)
+			if err != nil {
+				return &CompileTemplateResponse{
+					Success:        false,
+					Subject:        renderedSubject,
+					SubjectPreview: renderedSubjectPreview,
+					Error:          &mjml.Error{Message: err.Error()},
+				}, nil
+			}
+			tree = resolvedTree
+		}
+
 		// Apply subject_preview override in the tree before conversion.
 		// WARNING: updateBlockContent mutates the caller's tree in place (the mj-preview block).
 		// Callers pass VisualEditorTree by pointer; this is safe only because templates are loaded
@@ -0,0 +1,174 @@
+// Package spreadsheet reads the rows of CSV and XLSX files, used to import contacts
+// from spreadsheets exported by other tools.
+package spreadsheet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Format is the file format of a spreadsheet
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ErrUnsupportedFormat is returned for files that are neither CSV nor XLSX
+var ErrUnsupportedFormat = errors.New("unsupported file format, expected CSV or XLSX")
+
+// zipMagic starts every XLSX file (a zip archive)
+var zipMagic = []byte("PK\x03\x04")
+
+// utf8BOM is written by Excel at the start of "CSV UTF-8" exports
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// Reader returns the rows of a spreadsheet one at a time, skipping blank rows.
+// Read returns io.EOF after the last row.
+type Reader interface {
+	Read() ([]string, error)
+	// Line returns the line (CSV) or row number (XLSX) of the last row read, starting at 1
+	Line() int
+}
+
+// DetectFormat returns the format of a file from its content, falling back to
+// its extension for empty files
+func DetectFormat(fileName string, data []byte) (Format, error) {
+	if bytes.HasPrefix(data, zipMagic) {
+		return FormatXLSX, nil
+	}
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".xlsx":
+		// a .xlsx file that is not a zip archive is corrupted
+		return "", fmt.Errorf("invalid XLSX file")
+	case ".xls":
+		return "", fmt.Errorf("legacy .xls files are not supported, save the file as XLSX or CSV")
+	}
+
+	if bytes.IndexByte(data, 0) != -1 {
+		return "", ErrUnsupportedFormat
+	}
+	return FormatCSV, nil
+}
+
+// NewReader returns a reader for data in the given format
+func NewReader(format Format, data []byte) (Reader, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVReader(bytes.NewReader(data))
+	case FormatXLSX:
+		return NewXLSXReader(data)
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// ReadHeader returns the first row of a spreadsheet with trimmed column names
+func ReadHeader(r Reader) ([]string, error) {
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	return header, nil
+}
+
+// csvReader skips blank lines and lets rows have a variable number of fields
+type csvReader struct {
+	reader *csv.Reader
+	line   int
+}
+
+// NewCSVReader returns a reader for CSV data. The delimiter (comma, semicolon or
+// tab) is detected from the first line and a leading UTF-8 BOM is ignored.
+func NewCSVReader(r io.Reader) (Reader, error) {
+	buffered := bufio.NewReader(r)
+
+	if prefix, err := buffered.Peek(len(utf8BOM)); err == nil && bytes.Equal(prefix, utf8BOM) {
+		_, _ = buffered.Discard(len(utf8BOM))
+	}
+
+	// Peek returns what is buffered when the file is shorter than the buffer
+	firstLine, _ := buffered.Peek(buffered.Size())
+	if i := bytes.IndexByte(firstLine, '\n'); i != -1 {
+		firstLine = firstLine[:i]
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.Comma = detectDelimiter(firstLine)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	return &csvReader{reader: reader}, nil
+}
+
+func (r *csvReader) Read() ([]string, error) {
+	for {
+		record, err := r.reader.Read()
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("invalid CSV on line %d: %v", parseErr.Line, parseErr.Err)
+			}
+			return nil, err
+		}
+		if !isBlankRow(record) {
+			r.line, _ = r.reader.FieldPos(0)
+			return record, nil
+		}
+	}
+}
+
+func (r *csvReader) Line() int {
+	return r.line
+}
+
+// detectDelimiter returns the candidate delimiter appearing the most in the header line.
+// Spreadsheet software in many locales exports CSV with semicolons.
+func detectDelimiter(line []byte) rune {
+	best := ','
+	bestCount := 0
+	for _, delimiter := range []rune{',', ';', '\t'} {
+		count := countOutsideQuotes(line, byte(delimiter))
+		if count > bestCount {
+			best = delimiter
+			bestCount = count
+		}
+	}
+	return best
+}
+
+func countOutsideQuotes(line []byte, c byte) int {
+	count := 0
+	quoted := false
+	for _, b := range line {
+		switch {
+		case b == '"':
+			quoted = !quoted
+		case b == c && !quoted:
+			count++
+		}
+	}
+	return count
+}
+
+func isBlankRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,189 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAll(t *testing.T, r Reader) [][]string {
+	t.Helper()
+	rows := [][]string{}
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return rows
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+}
+
+func buildXLSX(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+const testWorkbook = `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <sheets><sheet name="Contacts" sheetId="1" r:id="rId2"/><sheet name="Other" sheetId="2" r:id="rId1"/></sheets>
+</workbook>`
+
+const testWorkbookRels = `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Id="rId1" Type="worksheet" Target="worksheets/sheet2.xml"/>
+  <Relationship Id="rId2" Type="worksheet" Target="/xl/worksheets/contacts.xml"/>
+</Relationships>`
+
+const testSharedStrings = `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <si><t>Email</t></si>
+  <si><t>First Name</t></si>
+  <si><r><t>john@</t></r><r><t>example.com</t></r></si>
+</sst>`
+
+const testStyles = `<?xml version="1.0" encoding="UTF-8"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <numFmts count="2"><numFmt numFmtId="164" formatCode="dd/mm/yyyy hh:mm"/><numFmt numFmtId="165" formatCode="&quot;Day&quot; 0"/></numFmts>
+  <cellXfs count="4"><xf numFmtId="0"/><xf numFmtId="14"/><xf numFmtId="164"/><xf numFmtId="165"/></cellXfs>
+</styleSheet>`
+
+const testSheet = `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+  <sheetData>
+    <row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="inlineStr"><is><t>Signed up</t></is></c><c r="D1" t="inlineStr"><is><t>Score</t></is></c><c r="E1" t="inlineStr"><is><t>VIP</t></is></c></row>
+    <row r="3"><c r="A3" t="s"><v>2</v></c><c r="C3" s="1"><v>45352</v></c><c r="D3" s="3"><v>12.5</v></c><c r="E3" t="b"><v>1</v></c></row>
+    <row r="4"><c r="A4" t="inlineStr"><is><t>jane@example.com</t></is></c><c r="C4" s="2"><v>45352.5</v></c></row>
+  </sheetData>
+</worksheet>`
+
+func TestXLSXReader(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/workbook.xml":            testWorkbook,
+		"xl/_rels/workbook.xml.rels": testWorkbookRels,
+		"xl/sharedStrings.xml":       testSharedStrings,
+		"xl/styles.xml":              testStyles,
+		"xl/worksheets/contacts.xml": testSheet,
+		"xl/worksheets/sheet2.xml":   `<worksheet><sheetData><row><c t="inlineStr"><is><t>wrong sheet</t></is></c></row></sheetData></worksheet>`,
+	})
+
+	format, err := DetectFormat("contacts.xlsx", data)
+	require.NoError(t, err)
+	assert.Equal(t, FormatXLSX, format)
+
+	reader, err := NewReader(format, data)
+	require.NoError(t, err)
+
+	header, err := ReadHeader(reader)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Email", "First Name", "Signed up", "Score", "VIP"}, header)
+
+	// the empty second row is skipped, missing cells are filled with empty strings
+	row, err := reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"john@example.com", "", "2024-03-01", "12.5", "TRUE"}, row)
+	assert.Equal(t, 3, reader.Line())
+
+	assert.Equal(t, [][]string{
+		{"jane@example.com", "", "2024-03-01T12:00:00Z"},
+	}, readAll(t, reader))
+	assert.Equal(t, 4, reader.Line())
+}
+
+func TestXLSXReader_Invalid(t *testing.T) {
+	_, err := NewXLSXReader([]byte("PK\x03\x04 not a zip"))
+	assert.ErrorContains(t, err, "invalid XLSX file")
+
+	_, err = NewXLSXReader(buildXLSX(t, map[string]string{"docProps/app.xml": "<Properties/>"}))
+	assert.ErrorContains(t, err, "missing workbook")
+}
+
+func TestCSVReader(t *testing.T) {
+	t.Run("comma with BOM and blank lines", func(t *testing.T) {
+		reader, err := NewCSVReader(strings.NewReader("\xef\xbb\xbfemail,first_name\n\njohn@example.com,\"Doe, John\"\n,\njane@example.com\n"))
+		require.NoError(t, err)
+
+		header, err := ReadHeader(reader)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"email", "first_name"}, header)
+
+		row, err := reader.Read()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"john@example.com", "Doe, John"}, row)
+		assert.Equal(t, 3, reader.Line())
+
+		assert.Equal(t, [][]string{{"jane@example.com"}}, readAll(t, reader))
+		assert.Equal(t, 5, reader.Line())
+	})
+
+	t.Run("semicolon", func(t *testing.T) {
+		reader, err := NewCSVReader(strings.NewReader("email;name;note\r\njohn@example.com;John;\"a, b\"\r\n"))
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"email", "name", "note"},
+			{"john@example.com", "John", "a, b"},
+		}, readAll(t, reader))
+	})
+
+	t.Run("tab", func(t *testing.T) {
+		reader, err := NewCSVReader(strings.NewReader("email\tname\njohn@example.com\tJohn"))
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"email", "name"}, {"john@example.com", "John"}}, readAll(t, reader))
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		reader, err := NewCSVReader(strings.NewReader(""))
+		require.NoError(t, err)
+		_, err = ReadHeader(reader)
+		assert.ErrorContains(t, err, "file is empty")
+	})
+}
+
+func TestDetectFormat(t *testing.T) {
+	format, err := DetectFormat("contacts.csv", []byte("email\n"))
+	require.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+
+	_, err = DetectFormat("contacts.xls", []byte{0xd0, 0xcf, 0x11, 0xe0, 0x00})
+	assert.ErrorContains(t, err, "legacy .xls")
+
+	_, err = DetectFormat("contacts.xlsx", []byte("email\n"))
+	assert.ErrorContains(t, err, "invalid XLSX file")
+
+	_, err = DetectFormat("photo.png", []byte("\x89PNG\x00\x00"))
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestIsDateFormatCode(t *testing.T) {
+	assert.True(t, isDateFormatCode("yyyy-mm-dd"))
+	assert.True(t, isDateFormatCode("[$-409]d-mmm-yy;@"))
+	assert.True(t, isDateFormatCode("h:mm AM/PM"))
+	assert.False(t, isDateFormatCode("0.00"))
+	assert.False(t, isDateFormatCode(`"Day" 0`))
+	assert.False(t, isDateFormatCode(`[Red]#,##0`))
+	assert.False(t, isDateFormatCode(`#,##0;"days"`))
+}
+
+func TestColumnIndex(t *testing.T) {
+	for ref, want := range map[string]int{"A1": 0, "Z9": 25, "AA10": 26, "AB2": 27, "BA3": 52} {
+		got, ok := columnIndex(ref)
+		assert.True(t, ok)
+		assert.Equal(t, want, got, ref)
+	}
+	_, ok := columnIndex("12")
+	assert.False(t, ok)
+}
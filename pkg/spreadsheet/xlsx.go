@@ -0,0 +1,430 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxXLSXPartSize bounds the uncompressed size of a single part of the archive,
+// protecting the reader against zip bombs
+const maxXLSXPartSize = 512 << 20
+
+// builtinDateFormats are the built-in number formats of SpreadsheetML rendering dates
+var builtinDateFormats = map[int]bool{
+	14: true, 15: true, 16: true, 17: true, 18: true, 19: true, 20: true, 21: true, 22: true,
+	45: true, 46: true, 47: true,
+}
+
+// xlsxReader returns the rows of the first worksheet of a workbook
+type xlsxReader struct {
+	rows [][]string
+	next int
+}
+
+// NewXLSXReader returns a reader for the first worksheet of an XLSX workbook.
+// Cells are returned as displayed text: shared and inline strings, raw numbers,
+// TRUE/FALSE booleans, and dates formatted as YYYY-MM-DD (or RFC3339 when they
+// carry a time).
+func NewXLSXReader(data []byte) (Reader, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX file: %w", err)
+	}
+
+	parts := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		parts[strings.TrimPrefix(f.Name, "/")] = f
+	}
+
+	sheetPath, date1904, err := firstSheetPath(parts)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedStrings, err := readSharedStrings(parts)
+	if err != nil {
+		return nil, err
+	}
+
+	dateStyles, err := readDateStyles(parts)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet, ok := parts[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("invalid XLSX file: missing worksheet %s", sheetPath)
+	}
+
+	rows, err := readSheet(sheet, sharedStrings, dateStyles, date1904)
+	if err != nil {
+		return nil, err
+	}
+
+	return &xlsxReader{rows: rows}, nil
+}
+
+func (r *xlsxReader) Line() int {
+	return r.next
+}
+
+func (r *xlsxReader) Read() ([]string, error) {
+	for r.next < len(r.rows) {
+		row := r.rows[r.next]
+		r.next++
+		if !isBlankRow(row) {
+			return row, nil
+		}
+	}
+	return nil, io.EOF
+}
+
+func openPart(f *zip.File) (io.ReadCloser, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("invalid XLSX file: %w", err)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(rc, maxXLSXPartSize), rc}, nil
+}
+
+func decodePart(f *zip.File, v interface{}) error {
+	rc, err := openPart(f)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+
+	if err := xml.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("invalid XLSX file: failed to parse %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+type xlsxWorkbook struct {
+	WorkbookPr struct {
+		Date1904 string `xml:"date1904,attr"`
+	} `xml:"workbookPr"`
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		// r:id, matched on the local name
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// firstSheetPath returns the archive path of the first worksheet in workbook order
+func firstSheetPath(parts map[string]*zip.File) (string, bool, error) {
+	workbookPart, ok := parts["xl/workbook.xml"]
+	if !ok {
+		return "", false, fmt.Errorf("invalid XLSX file: missing workbook")
+	}
+
+	var workbook xlsxWorkbook
+	if err := decodePart(workbookPart, &workbook); err != nil {
+		return "", false, err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", false, fmt.Errorf("invalid XLSX file: workbook has no sheets")
+	}
+	date1904 := workbook.WorkbookPr.Date1904 == "1" || workbook.WorkbookPr.Date1904 == "true"
+
+	// Fall back to the conventional path when the relationships cannot be resolved
+	sheetPath := "xl/worksheets/sheet1.xml"
+
+	if relsPart, ok := parts["xl/_rels/workbook.xml.rels"]; ok {
+		var rels xlsxRelationships
+		if err := decodePart(relsPart, &rels); err != nil {
+			return "", false, err
+		}
+		for _, rel := range rels.Relationships {
+			if rel.ID != workbook.Sheets[0].RelID {
+				continue
+			}
+			if strings.HasPrefix(rel.Target, "/") {
+				sheetPath = strings.TrimPrefix(rel.Target, "/")
+			} else {
+				sheetPath = path.Join("xl", rel.Target)
+			}
+			break
+		}
+	}
+
+	return sheetPath, date1904, nil
+}
+
+// xlsxText is a string item: either a plain <t> or rich text runs <r><t>
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t *xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var sb strings.Builder
+	sb.WriteString(t.T)
+	for _, run := range t.Runs {
+		sb.WriteString(run.T)
+	}
+	return sb.String()
+}
+
+func readSharedStrings(parts map[string]*zip.File) ([]string, error) {
+	part, ok := parts["xl/sharedStrings.xml"]
+	if !ok {
+		return nil, nil
+	}
+
+	var sst struct {
+		Items []xlsxText `xml:"si"`
+	}
+	if err := decodePart(part, &sst); err != nil {
+		return nil, err
+	}
+
+	strs := make([]string, len(sst.Items))
+	for i := range sst.Items {
+		strs[i] = sst.Items[i].String()
+	}
+	return strs, nil
+}
+
+// readDateStyles returns, for each cell style index, whether its number format renders a date
+func readDateStyles(parts map[string]*zip.File) ([]bool, error) {
+	part, ok := parts["xl/styles.xml"]
+	if !ok {
+		return nil, nil
+	}
+
+	var styles struct {
+		NumFmts []struct {
+			ID         int    `xml:"numFmtId,attr"`
+			FormatCode string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		CellXfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if err := decodePart(part, &styles); err != nil {
+		return nil, err
+	}
+
+	customDateFormats := make(map[int]bool, len(styles.NumFmts))
+	for _, f := range styles.NumFmts {
+		customDateFormats[f.ID] = isDateFormatCode(f.FormatCode)
+	}
+
+	dateStyles := make([]bool, len(styles.CellXfs))
+	for i, xf := range styles.CellXfs {
+		dateStyles[i] = builtinDateFormats[xf.NumFmtID] || customDateFormats[xf.NumFmtID]
+	}
+	return dateStyles, nil
+}
+
+// isDateFormatCode reports whether a custom number format displays a date or time,
+// ignoring quoted literals, escaped characters and [color]/[locale] sections
+func isDateFormatCode(code string) bool {
+	// Only the first section applies to positive numbers
+	inQuotes := false
+	inBrackets := false
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		switch {
+		case inQuotes:
+			if c == '"' {
+				inQuotes = false
+			}
+		case inBrackets:
+			if c == ']' {
+				inBrackets = false
+			}
+		case c == '"':
+			inQuotes = true
+		case c == '[':
+			inBrackets = true
+		case c == '\\' || c == '_' || c == '*':
+			i++
+		case c == ';':
+			return false
+		default:
+			switch c | 0x20 { // lowercase ASCII letters
+			case 'y', 'm', 'd', 'h', 's':
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type xlsxCell struct {
+	Ref    string   `xml:"r,attr"`
+	Style  int      `xml:"s,attr"`
+	Type   string   `xml:"t,attr"`
+	Value  string   `xml:"v"`
+	Inline xlsxText `xml:"is"`
+}
+
+// readSheet streams the rows of a worksheet
+func readSheet(f *zip.File, sharedStrings []string, dateStyles []bool, date1904 bool) ([][]string, error) {
+	rc, err := openPart(f)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	decoder := xml.NewDecoder(rc)
+	rows := [][]string{}
+	var row []string
+	rowIndex := 0
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid XLSX file: failed to parse worksheet: %w", err)
+		}
+
+		switch el := token.(type) {
+		case xml.StartElement:
+			switch el.Name.Local {
+			case "row":
+				// Rows may be sparse, keep their position so line numbers match the sheet
+				rowNumber := rowIndex + 1
+				for _, attr := range el.Attr {
+					if attr.Name.Local == "r" {
+						if n, err := strconv.Atoi(attr.Value); err == nil && n > rowIndex {
+							rowNumber = n
+						}
+					}
+				}
+				for rowIndex+1 < rowNumber {
+					rows = append(rows, nil)
+					rowIndex++
+				}
+				row = []string{}
+
+			case "c":
+				var cell xlsxCell
+				if err := decoder.DecodeElement(&cell, &el); err != nil {
+					return nil, fmt.Errorf("invalid XLSX file: failed to parse cell: %w", err)
+				}
+
+				col := len(row)
+				if cell.Ref != "" {
+					if parsed, ok := columnIndex(cell.Ref); ok {
+						col = parsed
+					}
+				}
+				for len(row) < col {
+					row = append(row, "")
+				}
+
+				value, err := cellValue(&cell, sharedStrings, dateStyles, date1904)
+				if err != nil {
+					return nil, err
+				}
+				if col < len(row) {
+					row[col] = value
+				} else {
+					row = append(row, value)
+				}
+			}
+
+		case xml.EndElement:
+			if el.Name.Local == "row" {
+				rows = append(rows, row)
+				rowIndex++
+				row = nil
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+func cellValue(cell *xlsxCell, sharedStrings []string, dateStyles []bool, date1904 bool) (string, error) {
+	switch cell.Type {
+	case "s":
+		i, err := strconv.Atoi(strings.TrimSpace(cell.Value))
+		if err != nil || i < 0 || i >= len(sharedStrings) {
+			return "", fmt.Errorf("invalid XLSX file: invalid shared string reference in cell %s", cell.Ref)
+		}
+		return sharedStrings[i], nil
+	case "inlineStr":
+		return cell.Inline.String(), nil
+	case "b":
+		if cell.Value == "1" {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case "str", "e":
+		return cell.Value, nil
+	}
+
+	// Numbers, possibly formatted as dates
+	if cell.Value != "" && cell.Style >= 0 && cell.Style < len(dateStyles) && dateStyles[cell.Style] {
+		if serial, err := strconv.ParseFloat(cell.Value, 64); err == nil {
+			return formatSerialDate(serial, date1904), nil
+		}
+	}
+	return cell.Value, nil
+}
+
+// formatSerialDate converts a spreadsheet date serial number (days since the workbook epoch)
+func formatSerialDate(serial float64, date1904 bool) string {
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	if date1904 {
+		epoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	days := math.Floor(serial)
+	seconds := math.Round((serial - days) * 86400)
+	t := epoch.AddDate(0, 0, int(days)).Add(time.Duration(seconds) * time.Second)
+
+	if seconds == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
+
+// columnIndex returns the zero-based column of a cell reference such as "AB12"
+func columnIndex(ref string) (int, bool) {
+	col := 0
+	i := 0
+	for ; i < len(ref); i++ {
+		c := ref[i]
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		col = col*26 + int(c-'A'+1)
+	}
+	if i == 0 {
+		return 0, false
+	}
+	return col - 1, true
+}
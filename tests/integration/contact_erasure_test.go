@@ -0,0 +1,91 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestContactErasureWithAliases checks that deleting a contact erases the consent records
+// of its current address and of the former addresses kept as aliases by an email change
+func TestContactErasureWithAliases(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer func() { suite.Cleanup() }()
+
+	client := suite.APIClient
+	factory := suite.DataFactory
+
+	user, err := factory.CreateUser()
+	require.NoError(t, err)
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+	require.NoError(t, factory.AddUserToWorkspace(user.ID, workspace.ID, "owner"))
+	require.NoError(t, client.Login(user.Email, "password"))
+	client.SetWorkspaceID(workspace.ID)
+
+	firstAddress := testutil.GenerateTestEmail()
+	secondAddress := testutil.GenerateTestEmail()
+	currentAddress := testutil.GenerateTestEmail()
+	addresses := []string{firstAddress, secondAddress, currentAddress}
+
+	_, err = factory.CreateContact(workspace.ID, testutil.WithContactEmail(firstAddress))
+	require.NoError(t, err)
+
+	// Every address gives its consent to a list before moving to the next one
+	for i, address := range addresses {
+		list, err := factory.CreateList(workspace.ID)
+		require.NoError(t, err)
+		_, err = factory.CreateContactList(workspace.ID,
+			testutil.WithContactListEmail(address),
+			testutil.WithContactListListID(list.ID),
+		)
+		require.NoError(t, err)
+
+		if i < len(addresses)-1 {
+			resp, err := client.Post("/api/contacts.changeEmail", map[string]interface{}{
+				"workspace_id": workspace.ID,
+				"email":        address,
+				"new_email":    addresses[i+1],
+			})
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+	}
+
+	workspaceDB, err := factory.GetWorkspaceDB(workspace.ID)
+	require.NoError(t, err)
+	countRows := func(query string) int {
+		var count int
+		require.NoError(t, workspaceDB.QueryRowContext(context.Background(), query, firstAddress, secondAddress, currentAddress).Scan(&count))
+		return count
+	}
+	consentQuery := `SELECT COUNT(*) FROM consent_records WHERE email IN ($1, $2, $3)`
+	aliasQuery := `SELECT COUNT(*) FROM contact_aliases WHERE email IN ($1, $2, $3) OR contact_email IN ($1, $2, $3)`
+
+	require.Equal(t, 3, countRows(consentQuery), "each address has its consent record")
+	require.Equal(t, 2, countRows(aliasQuery), "the former addresses are aliases")
+
+	resp, err := client.Post("/api/contacts.delete", map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"email":        currentAddress,
+	})
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Zero(t, countRows(consentQuery), "no consent record is left for any address")
+	assert.Zero(t, countRows(aliasQuery), "no alias is left")
+}